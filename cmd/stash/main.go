@@ -163,5 +163,13 @@ func handleSignals(exit chan<- int) {
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 
 	<-signals
+	logger.Info("Shutting down, press Ctrl+C again to force exit")
+	go func() {
+		// force exit if a second signal is received while shutting down
+		<-signals
+		logger.Warn("Forcing exit")
+		os.Exit(1)
+	}()
+
 	exit <- 0
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/session"
 )
 
@@ -26,7 +27,11 @@ const (
 
 func allowUnauthenticated(r *http.Request) bool {
 	// #2715 - allow access to UI files
-	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets")
+	// share links, external player tokens and signed image transforms perform
+	// their own access checks
+	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets") ||
+		strings.HasPrefix(r.URL.Path, shareEndpoint+"/") || strings.HasPrefix(r.URL.Path, playerEndpoint+"/") ||
+		strings.HasPrefix(r.URL.Path, transformEndpoint+"/")
 }
 
 func authenticateHandler() func(http.Handler) http.Handler {
@@ -103,6 +108,11 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			}
 
 			ctx = session.SetCurrentUserID(ctx, userID)
+			ctx = models.WithCurrentUsername(ctx, userID)
+
+			if !manager.GetInstance().SessionStore.RestrictedContentUnlocked(r) {
+				ctx = models.WithRestrictedContentHidden(ctx)
+			}
 
 			r = r.WithContext(ctx)
 
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// bulkUpdateBatchSize is the number of objects updated in each transaction
+// of a bulk update job.
+const bulkUpdateBatchSize = 100
+
+// bulkUpdateJob applies an update to objects in batches, each batch in its
+// own transaction. Batches completed before an error or cancellation remain
+// applied. The job context keeps the values of the request context, so
+// ratings are set for the user that started the job.
+type bulkUpdateJob struct {
+	r *mutationResolver
+	// findIDs returns the ids of the objects to update. Called within a
+	// read transaction.
+	findIDs func(ctx context.Context) ([]int, error)
+	// updateBatch updates the objects. Called within a transaction.
+	updateBatch func(ctx context.Context, ids []int) error
+	// postHook is executed for each updated object after its batch is
+	// committed.
+	postHook func(ctx context.Context, id int)
+}
+
+func (j *bulkUpdateJob) Execute(ctx context.Context, progress *job.Progress) error {
+	var ids []int
+	if err := j.r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ids, err = j.findIDs(ctx)
+		return err
+	}); err != nil {
+		return fmt.Errorf("finding objects to update: %w", err)
+	}
+
+	progress.SetTotal(len(ids))
+
+	for start := 0; start < len(ids); start += bulkUpdateBatchSize {
+		if job.IsCancelled(ctx) {
+			logger.Infof("Stopping bulk update due to user request after updating %d objects", start)
+			return nil
+		}
+
+		end := min(start+bulkUpdateBatchSize, len(ids))
+		batch := ids[start:end]
+
+		if err := j.r.withTxn(ctx, func(ctx context.Context) error {
+			return j.updateBatch(ctx, batch)
+		}); err != nil {
+			return fmt.Errorf("updating objects %d to %d of %d: %w", start+1, end, len(ids), err)
+		}
+
+		for _, id := range batch {
+			j.postHook(ctx, id)
+		}
+
+		progress.AddProcessed(len(batch))
+	}
+
+	logger.Infof("Bulk update finished: updated %d objects", len(ids))
+	return nil
+}
+
+// bulkUpdateJobIDs returns the ids to update from the ids or filter of a bulk
+// update job input. Exactly one of ids or filter must be set.
+func bulkUpdateJobIDs[F any](ids []string, filter *F, query func(ctx context.Context, filter *F) ([]int, error)) (func(ctx context.Context) ([]int, error), error) {
+	switch {
+	case len(ids) > 0 && filter != nil:
+		return nil, fmt.Errorf("%w: only one of ids or filter may be set", ErrInput)
+	case filter != nil:
+		return func(ctx context.Context) ([]int, error) {
+			return query(ctx, filter)
+		}, nil
+	case len(ids) > 0:
+		intIDs, err := stringslice.StringSliceToIntSlice(ids)
+		if err != nil {
+			return nil, fmt.Errorf("converting ids: %w", err)
+		}
+		return func(ctx context.Context) ([]int, error) {
+			return intIDs, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: ids or filter must be set", ErrInput)
+	}
+}
+
+func allPagesFindFilter() *models.FindFilterType {
+	perPage := -1
+	return &models.FindFilterType{
+		PerPage: &perPage,
+	}
+}
+
+func (r *mutationResolver) BulkSceneUpdateJob(ctx context.Context, input BulkSceneUpdateJobInput) (string, error) {
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, updateInputField+".update"),
+	}
+
+	updatedScene, err := scenePartialFromBulkSceneUpdateInput(translator, *input.Update)
+	if err != nil {
+		return "", err
+	}
+
+	findIDs, err := bulkUpdateJobIDs(input.Ids, input.SceneFilter, func(ctx context.Context, filter *models.SceneFilterType) ([]int, error) {
+		result, err := r.repository.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: allPagesFindFilter(),
+			},
+			SceneFilter: filter,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.IDs, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	fieldSources := translator.manualFieldSources()
+
+	j := &bulkUpdateJob{
+		r:       r,
+		findIDs: findIDs,
+		updateBatch: func(ctx context.Context, ids []int) error {
+			_, err := r.bulkSceneUpdate(ctx, ids, updatedScene, fieldSources)
+			return err
+		},
+		postHook: func(ctx context.Context, id int) {
+			r.hookExecutor.ExecutePostHooks(ctx, id, hook.SceneUpdatePost, *input.Update, translator.getFields())
+		},
+	}
+
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Updating scenes...", j)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) BulkImageUpdateJob(ctx context.Context, input BulkImageUpdateJobInput) (string, error) {
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, updateInputField+".update"),
+	}
+
+	updatedImage, err := imagePartialFromBulkImageUpdateInput(translator, *input.Update)
+	if err != nil {
+		return "", err
+	}
+
+	findIDs, err := bulkUpdateJobIDs(input.Ids, input.ImageFilter, func(ctx context.Context, filter *models.ImageFilterType) ([]int, error) {
+		result, err := r.repository.Image.Query(ctx, models.ImageQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: allPagesFindFilter(),
+			},
+			ImageFilter: filter,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.IDs, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	j := &bulkUpdateJob{
+		r:       r,
+		findIDs: findIDs,
+		updateBatch: func(ctx context.Context, ids []int) error {
+			_, err := r.bulkImageUpdate(ctx, ids, updatedImage)
+			return err
+		},
+		postHook: func(ctx context.Context, id int) {
+			r.hookExecutor.ExecutePostHooks(ctx, id, hook.ImageUpdatePost, *input.Update, translator.getFields())
+		},
+	}
+
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Updating images...", j)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) BulkGalleryUpdateJob(ctx context.Context, input BulkGalleryUpdateJobInput) (string, error) {
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, updateInputField+".update"),
+	}
+
+	updatedGallery, err := galleryPartialFromBulkGalleryUpdateInput(translator, *input.Update)
+	if err != nil {
+		return "", err
+	}
+
+	findIDs, err := bulkUpdateJobIDs(input.Ids, input.GalleryFilter, func(ctx context.Context, filter *models.GalleryFilterType) ([]int, error) {
+		galleries, _, err := r.repository.Gallery.Query(ctx, filter, allPagesFindFilter())
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]int, len(galleries))
+		for i, g := range galleries {
+			ids[i] = g.ID
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	j := &bulkUpdateJob{
+		r:       r,
+		findIDs: findIDs,
+		updateBatch: func(ctx context.Context, ids []int) error {
+			_, err := r.bulkGalleryUpdate(ctx, ids, updatedGallery)
+			return err
+		},
+		postHook: func(ctx context.Context, id int) {
+			r.hookExecutor.ExecutePostHooks(ctx, id, hook.GalleryUpdatePost, *input.Update, translator.getFields())
+		},
+	}
+
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Updating galleries...", j)
+	return strconv.Itoa(jobID), nil
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkUpdateJobIDs(t *testing.T) {
+	filter := &models.SceneFilterType{}
+	filterIDs := []int{4, 5}
+
+	query := func(ctx context.Context, f *models.SceneFilterType) ([]int, error) {
+		assert.Same(t, filter, f)
+		return filterIDs, nil
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		filter  *models.SceneFilterType
+		want    []int
+		wantErr bool
+	}{
+		{"ids", []string{"1", "2", "3"}, nil, []int{1, 2, 3}, false},
+		{"filter", nil, filter, filterIDs, false},
+		{"ids and filter", []string{"1"}, filter, nil, true},
+		{"neither", nil, nil, nil, true},
+		{"empty ids", []string{}, nil, nil, true},
+		{"invalid id", []string{"1", "abc"}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findIDs, err := bulkUpdateJobIDs(tt.ids, tt.filter, query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bulkUpdateJobIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got, err := findIDs(testCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// validation errors are reported as input errors
+	_, err := bulkUpdateJobIDs([]string{"1"}, filter, query)
+	assert.ErrorIs(t, err, ErrInput)
+	_, err = bulkUpdateJobIDs(nil, nil, query)
+	assert.ErrorIs(t, err, ErrInput)
+}
+
+// executeJob runs the job in a job manager, returning the error returned by
+// the job.
+func executeJob(ctx context.Context, m *job.Manager, j job.JobExec) error {
+	done := make(chan error, 1)
+	m.Start(ctx, "test", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		done <- j.Execute(ctx, progress)
+		return nil
+	}))
+
+	return <-done
+}
+
+func sequentialIDs(n int) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = i + 1
+	}
+	return ret
+}
+
+func TestBulkUpdateJob_Execute(t *testing.T) {
+	const username = "user"
+
+	ids := sequentialIDs(bulkUpdateBatchSize*2 + 1)
+
+	db := mocks.NewDatabase()
+	r := &mutationResolver{newResolver(db)}
+
+	var batches [][]int
+	var hooked []int
+	j := &bulkUpdateJob{
+		r: r,
+		findIDs: func(ctx context.Context) ([]int, error) {
+			return ids, nil
+		},
+		updateBatch: func(ctx context.Context, ids []int) error {
+			// values of the request context are kept
+			assert.Equal(t, username, models.CurrentUsername(ctx))
+
+			// hooks are executed after each batch is committed
+			assert.Len(t, hooked, len(batches)*bulkUpdateBatchSize)
+
+			batches = append(batches, ids)
+			return nil
+		},
+		postHook: func(ctx context.Context, id int) {
+			hooked = append(hooked, id)
+		},
+	}
+
+	m := job.NewManager()
+	defer m.Stop()
+
+	ctx := models.WithCurrentUsername(testCtx, username)
+	if err := executeJob(ctx, m, j); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	assert.Equal(t, [][]int{
+		ids[:bulkUpdateBatchSize],
+		ids[bulkUpdateBatchSize : bulkUpdateBatchSize*2],
+		ids[bulkUpdateBatchSize*2:],
+	}, batches)
+	assert.Equal(t, ids, hooked)
+}
+
+func TestBulkUpdateJob_ExecuteCancelled(t *testing.T) {
+	ids := sequentialIDs(bulkUpdateBatchSize * 3)
+
+	db := mocks.NewDatabase()
+	r := &mutationResolver{newResolver(db)}
+
+	m := job.NewManager()
+	defer m.Stop()
+
+	var batches [][]int
+	var hooked []int
+	j := &bulkUpdateJob{
+		r: r,
+		findIDs: func(ctx context.Context) ([]int, error) {
+			return ids, nil
+		},
+		updateBatch: func(ctx context.Context, ids []int) error {
+			batches = append(batches, ids)
+
+			// cancel during the first batch
+			m.CancelAll()
+			return nil
+		},
+		postHook: func(ctx context.Context, id int) {
+			hooked = append(hooked, id)
+		},
+	}
+
+	// cancelling is not an error
+	if err := executeJob(testCtx, m, j); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// the batch in progress is completed, including its hooks
+	assert.Equal(t, [][]int{ids[:bulkUpdateBatchSize]}, batches)
+	assert.Equal(t, ids[:bulkUpdateBatchSize], hooked)
+}
+
+func TestBulkUpdateJob_ExecuteError(t *testing.T) {
+	ids := sequentialIDs(bulkUpdateBatchSize * 3)
+	errUpdate := errors.New("update error")
+
+	tests := []struct {
+		name        string
+		findErr     error
+		failBatch   int
+		wantBatches int
+		wantHooked  int
+	}{
+		{"find error", errors.New("find error"), -1, 0, 0},
+		{"first batch", nil, 0, 1, 0},
+		// completed batches remain applied
+		{"second batch", nil, 1, 2, bulkUpdateBatchSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			r := &mutationResolver{newResolver(db)}
+
+			batches := 0
+			hooked := 0
+			j := &bulkUpdateJob{
+				r: r,
+				findIDs: func(ctx context.Context) ([]int, error) {
+					return ids, tt.findErr
+				},
+				updateBatch: func(ctx context.Context, ids []int) error {
+					batches++
+					if batches-1 == tt.failBatch {
+						return errUpdate
+					}
+					return nil
+				},
+				postHook: func(ctx context.Context, id int) {
+					hooked++
+				},
+			}
+
+			m := job.NewManager()
+			defer m.Stop()
+
+			err := executeJob(testCtx, m, j)
+			if tt.findErr != nil {
+				assert.ErrorIs(t, err, tt.findErr)
+			} else {
+				assert.ErrorIs(t, err, errUpdate)
+			}
+
+			assert.Equal(t, tt.wantBatches, batches)
+			assert.Equal(t, tt.wantHooked, hooked)
+		})
+	}
+}
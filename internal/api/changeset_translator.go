@@ -93,6 +93,13 @@ func (t changesetTranslator) getFields() []string {
 	return ret
 }
 
+// manualFieldSources returns manual field sources for the metadata fields
+// set in the input.
+func (t changesetTranslator) manualFieldSources() []*models.FieldSource {
+	fields := models.FieldSourceNames(t.getFields())
+	return models.NewFieldSources(fields, models.FieldSourceTypeManual, "")
+}
+
 func (t changesetTranslator) string(value *string) string {
 	if value == nil {
 		return ""
@@ -293,6 +300,21 @@ func (t changesetTranslator) optionalURLs(value []string, legacyValue *string) *
 	return nil
 }
 
+// optionalTypedURLs returns the update of the URLs and the types of the URLs
+// from the typed_urls field. Returns nil, nil if the field is not set.
+func (t changesetTranslator) optionalTypedURLs(value []models.TypedURL) (*models.UpdateStrings, map[string]*models.URLType) {
+	const field = "typed_urls"
+	if !t.hasField(field) {
+		return nil, nil
+	}
+
+	urls, types := models.TypedURLs(value)
+	return &models.UpdateStrings{
+		Values: urls,
+		Mode:   models.RelationshipUpdateModeSet,
+	}, types
+}
+
 func (t changesetTranslator) optionalURLsBulk(value *BulkUpdateStrings, legacyValue *string) *models.UpdateStrings {
 	const (
 		legacyField = "url"
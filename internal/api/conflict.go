@@ -0,0 +1,22 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// checkConflict returns ErrConflict if expectedUpdatedAt is set and differs
+// from updatedAt, the current updated at time of the object being updated.
+// Updated at times are stored with second precision, so modifications made
+// within the same second as the expected time are not detected.
+func checkConflict(expectedUpdatedAt *time.Time, updatedAt time.Time) error {
+	if expectedUpdatedAt == nil {
+		return nil
+	}
+
+	if !expectedUpdatedAt.Truncate(time.Second).Equal(updatedAt.Truncate(time.Second)) {
+		return fmt.Errorf("%w: object was modified at %s", ErrConflict, updatedAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
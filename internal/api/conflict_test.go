@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckConflict(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sameSecond := updatedAt.Add(500 * time.Millisecond)
+	earlier := updatedAt.Add(-time.Second)
+	otherZone := updatedAt.In(time.FixedZone("UTC+10", 10*60*60))
+
+	tests := []struct {
+		name     string
+		expected *time.Time
+		want     error
+	}{
+		{"not set", nil, nil},
+		{"equal", &updatedAt, nil},
+		{"same second", &sameSecond, nil},
+		{"other zone", &otherZone, nil},
+		{"modified", &earlier, ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkConflict(tt.expected, updatedAt)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("checkConflict() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
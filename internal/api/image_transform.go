@@ -0,0 +1,40 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/stashapp/stash/pkg/image"
+)
+
+func imageTransformFromInput(input ImageTransformInput) (image.Transform, error) {
+	ret := image.Transform{
+		Format: image.TransformFormatJpeg,
+	}
+
+	if input.Width != nil {
+		ret.Width = *input.Width
+	}
+	if input.Height != nil {
+		ret.Height = *input.Height
+	}
+	if input.Crop != nil {
+		ret.Crop = &image.CropRect{
+			X:      input.Crop.X,
+			Y:      input.Crop.Y,
+			Width:  input.Crop.Width,
+			Height: input.Crop.Height,
+		}
+	}
+	if input.Rotate != nil {
+		ret.Rotate = *input.Rotate
+	}
+	if input.Format != nil {
+		ret.Format = image.TransformFormat(strings.ToLower(input.Format.String()))
+	}
+
+	if err := ret.Validate(); err != nil {
+		return ret, err
+	}
+
+	return ret, nil
+}
@@ -19,6 +19,8 @@
 //go:generate go run github.com/vektah/dataloaden SceneOHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden ScenePlayHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden SceneLastPlayedLoader int *time.Time
+//go:generate go run github.com/vektah/dataloaden RelatedIDsLoader int []int
+//go:generate go run github.com/vektah/dataloaden GalleryImageCountLoader int int
 package loaders
 
 import (
@@ -49,19 +51,36 @@ type Loaders struct {
 	SceneOHistory    *SceneOHistoryLoader
 	SceneLastPlayed  *SceneLastPlayedLoader
 
+	SceneGalleryIDs   *RelatedIDsLoader
+	ScenePerformerIDs *RelatedIDsLoader
+	SceneTagIDs       *RelatedIDsLoader
+
 	ImageFiles   *ImageFileIDsLoader
 	GalleryFiles *GalleryFileIDsLoader
 
 	GalleryByID *GalleryLoader
 	ImageByID   *ImageLoader
 
+	GallerySceneIDs     *RelatedIDsLoader
+	GalleryPerformerIDs *RelatedIDsLoader
+	GalleryTagIDs       *RelatedIDsLoader
+	GalleryImageCount   *GalleryImageCountLoader
+
+	ImageGalleryIDs   *RelatedIDsLoader
+	ImagePerformerIDs *RelatedIDsLoader
+	ImageTagIDs       *RelatedIDsLoader
+
 	PerformerByID         *PerformerLoader
 	PerformerCustomFields *CustomFieldsLoader
+	PerformerTagIDs       *RelatedIDsLoader
 
 	StudioByID *StudioLoader
 	TagByID    *TagLoader
 	GroupByID  *GroupLoader
 	FileByID   *FileLoader
+
+	StudioTagIDs *RelatedIDsLoader
+	GroupTagIDs  *RelatedIDsLoader
 }
 
 type Middleware struct {
@@ -157,6 +176,71 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 				maxBatch: maxBatch,
 				fetch:    m.fetchScenesOHistory(ctx),
 			},
+			SceneGalleryIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Scene.GetManyGalleryIDs),
+			},
+			ScenePerformerIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Scene.GetManyPerformerIDs),
+			},
+			SceneTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Scene.GetManyTagIDs),
+			},
+			GallerySceneIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Gallery.GetManySceneIDs),
+			},
+			GalleryPerformerIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Gallery.GetManyPerformerIDs),
+			},
+			GalleryTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Gallery.GetManyTagIDs),
+			},
+			ImageGalleryIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Image.GetManyGalleryIDs),
+			},
+			ImagePerformerIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Image.GetManyPerformerIDs),
+			},
+			ImageTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Image.GetManyTagIDs),
+			},
+			PerformerTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Performer.GetManyTagIDs),
+			},
+			StudioTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Studio.GetManyTagIDs),
+			},
+			GroupTagIDs: &RelatedIDsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchRelatedIDs(ctx, m.Repository.Group.GetManyTagIDs),
+			},
+			GalleryImageCount: &GalleryImageCountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchGalleriesImageCount(ctx),
+			},
 		}
 
 		newCtx := context.WithValue(r.Context(), loadersCtxKey, ldrs)
@@ -366,3 +450,27 @@ func (m Middleware) fetchScenesLastPlayed(ctx context.Context) func(keys []int)
 		return ret, toErrorSlice(err)
 	}
 }
+
+// fetchRelatedIDs returns a fetch function for a RelatedIDsLoader, using fn
+// to get the related IDs of the keys.
+func (m Middleware) fetchRelatedIDs(ctx context.Context, fn func(ctx context.Context, ids []int) ([][]int, error)) func(keys []int) ([][]int, []error) {
+	return func(keys []int) (ret [][]int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = fn(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+func (m Middleware) fetchGalleriesImageCount(ctx context.Context) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.Image.CountByGalleryIDs(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
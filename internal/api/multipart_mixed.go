@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	gqlTransport "github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	multipartMixedBoundary    = "-"
+	multipartMixedContentType = `multipart/mixed; boundary="` + multipartMixedBoundary + `"; deferSpec=20220824`
+)
+
+// multipartMixedTransport is a POST transport that delivers the results of
+// @defer fragments incrementally, using the multipart/mixed response format
+// of the incremental delivery specification. It is used when the client
+// accepts multipart/mixed responses.
+//
+// Responses without deferred fragments are written as a normal JSON response.
+type multipartMixedTransport struct{}
+
+var _ graphql.Transport = multipartMixedTransport{}
+
+func (t multipartMixedTransport) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost || r.Header.Get("Upgrade") != "" {
+		return false
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "multipart/mixed") {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json"
+}
+
+func (t multipartMixedTransport) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		gqlTransport.SendErrorf(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	params := &graphql.RawParams{}
+	start := graphql.Now()
+	params.Headers = r.Header
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(params); err != nil {
+		gqlTransport.SendErrorf(w, http.StatusBadRequest, "json request body could not be decoded: %v", err)
+		return
+	}
+
+	params.ReadTime = graphql.TraceTiming{
+		Start: start,
+		End:   graphql.Now(),
+	}
+
+	rc, opErr := exec.CreateOperationContext(ctx, params)
+	if opErr != nil {
+		status := http.StatusOK
+		if errcode.GetErrorKind(opErr) == errcode.KindProtocol {
+			status = http.StatusUnprocessableEntity
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		writeGraphQLJSON(w, exec.DispatchError(graphql.WithOperationContext(ctx, rc), opErr))
+		return
+	}
+
+	responses, ctx := exec.DispatchOperation(ctx, rc)
+
+	first := responses(ctx)
+	if first == nil || first.HasNext == nil {
+		// no deferred fragments
+		w.Header().Set("Content-Type", "application/json")
+		writeGraphQLJSON(w, first)
+		return
+	}
+
+	w.Header().Set("Content-Type", multipartMixedContentType)
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	writeMultipartMixedPart(w, first)
+	flusher.Flush()
+
+	hasNext := *first.HasNext
+	for hasNext {
+		resp := responses(ctx)
+		if resp == nil {
+			break
+		}
+
+		hasNext = resp.HasNext != nil && *resp.HasNext
+		writeMultipartMixedPart(w, incrementalResponse(resp, hasNext))
+		flusher.Flush()
+	}
+
+	// closing delimiter
+	fmt.Fprintf(w, "\r\n--%s--\r\n", multipartMixedBoundary)
+	flusher.Flush()
+}
+
+type incrementalResult struct {
+	Data       json.RawMessage `json:"data"`
+	Path       ast.Path        `json:"path,omitempty"`
+	Label      string          `json:"label,omitempty"`
+	Errors     gqlerror.List   `json:"errors,omitempty"`
+	Extensions map[string]any  `json:"extensions,omitempty"`
+}
+
+type incrementalPayload struct {
+	Incremental []incrementalResult `json:"incremental"`
+	HasNext     bool                `json:"hasNext"`
+}
+
+// incrementalResponse converts a subsequent response of a deferred operation
+// into an incremental delivery payload.
+func incrementalResponse(resp *graphql.Response, hasNext bool) incrementalPayload {
+	return incrementalPayload{
+		Incremental: []incrementalResult{
+			{
+				Data:       resp.Data,
+				Path:       resp.Path,
+				Label:      resp.Label,
+				Errors:     resp.Errors,
+				Extensions: resp.Extensions,
+			},
+		},
+		HasNext: hasNext,
+	}
+}
+
+func writeMultipartMixedPart(w io.Writer, v interface{}) {
+	fmt.Fprintf(w, "\r\n--%s\r\nContent-Type: application/json; charset=utf-8\r\n\r\n", multipartMixedBoundary)
+	writeGraphQLJSON(w, v)
+}
+
+func writeGraphQLJSON(w io.Writer, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	_, _ = w.Write(b)
+}
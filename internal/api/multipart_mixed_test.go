@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type responsesExecutor struct {
+	responses []*graphql.Response
+}
+
+func (e *responsesExecutor) CreateOperationContext(ctx context.Context, params *graphql.RawParams) (*graphql.OperationContext, gqlerror.List) {
+	return &graphql.OperationContext{}, nil
+}
+
+func (e *responsesExecutor) DispatchOperation(ctx context.Context, rc *graphql.OperationContext) (graphql.ResponseHandler, context.Context) {
+	return func(ctx context.Context) *graphql.Response {
+		if len(e.responses) == 0 {
+			return nil
+		}
+		ret := e.responses[0]
+		e.responses = e.responses[1:]
+		return ret
+	}, ctx
+}
+
+func (e *responsesExecutor) DispatchError(ctx context.Context, list gqlerror.List) *graphql.Response {
+	return &graphql.Response{Errors: list}
+}
+
+func newMultipartMixedRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ tag }"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "multipart/mixed;deferSpec=20220824,application/json")
+	return r
+}
+
+func TestMultipartMixedTransport_Supports(t *testing.T) {
+	tr := multipartMixedTransport{}
+
+	r := newMultipartMixedRequest()
+	assert.True(t, tr.Supports(r))
+
+	r.Header.Set("Accept", "application/json")
+	assert.False(t, tr.Supports(r))
+
+	r = newMultipartMixedRequest()
+	r.Method = http.MethodGet
+	assert.False(t, tr.Supports(r))
+}
+
+func TestMultipartMixedTransport_Do(t *testing.T) {
+	hasNext := true
+	noNext := false
+
+	exec := &responsesExecutor{
+		responses: []*graphql.Response{
+			{Data: []byte(`{"tag":{"id":"1"}}`), HasNext: &hasNext},
+			{Data: []byte(`{"scene_count":2}`), Path: ast.Path{ast.PathName("tag")}, Label: "counts", HasNext: &noNext},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	multipartMixedTransport{}.Do(w, newMultipartMixedRequest(), exec)
+
+	assert.Equal(t, multipartMixedContentType, w.Header().Get("Content-Type"))
+
+	const part = "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"
+	expected := part + `{"data":{"tag":{"id":"1"}},"hasNext":true}` +
+		part + `{"incremental":[{"data":{"scene_count":2},"path":["tag"],"label":"counts"}],"hasNext":false}` +
+		"\r\n-----\r\n"
+	assert.Equal(t, expected, w.Body.String())
+}
+
+func TestMultipartMixedTransport_DoNotDeferred(t *testing.T) {
+	exec := &responsesExecutor{
+		responses: []*graphql.Response{
+			{Data: []byte(`{"tag":{"id":"1"}}`)},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	multipartMixedTransport{}.Do(w, newMultipartMixedRequest(), exec)
+
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"data":{"tag":{"id":"1"}}}`, w.Body.String())
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// rootFieldResources maps keywords in root field names to the resource they
+// access. Keywords are matched case-insensitively in order, so more specific
+// keywords must come first.
+var rootFieldResources = []struct {
+	keyword  string
+	resource string
+}{
+	{"stashbox", plugin.PermissionResourceScrapers},
+	{"scrape", plugin.PermissionResourceScrapers},
+	{"plugin", plugin.PermissionResourcePlugins},
+	{"package", plugin.PermissionResourcePlugins},
+	{"configur", plugin.PermissionResourceConfig},
+	{"apikey", plugin.PermissionResourceConfig},
+	{"metadata", plugin.PermissionResourceJobs},
+	{"marker", plugin.PermissionResourceMarkers},
+	{"scene", plugin.PermissionResourceScenes},
+	{"galler", plugin.PermissionResourceGalleries},
+	{"reading", plugin.PermissionResourceGalleries},
+	{"performerimage", plugin.PermissionResourcePerformers},
+	{"image", plugin.PermissionResourceImages},
+	{"performer", plugin.PermissionResourcePerformers},
+	{"studio", plugin.PermissionResourceStudios},
+	{"group", plugin.PermissionResourceGroups},
+	{"movie", plugin.PermissionResourceGroups},
+	{"tag", plugin.PermissionResourceTags},
+	{"filter", plugin.PermissionResourceFilters},
+	{"file", plugin.PermissionResourceFiles},
+	{"folder", plugin.PermissionResourceFiles},
+	{"directory", plugin.PermissionResourceFiles},
+	{"job", plugin.PermissionResourceJobs},
+}
+
+// objectResources maps object types to the resource they belong to.
+// Resolving any field of these types requires read permission for the
+// resource. Other types are only reachable through fields that are checked.
+var objectResources = map[string]string{
+	"Scene":             plugin.PermissionResourceScenes,
+	"SceneMarker":       plugin.PermissionResourceMarkers,
+	"Image":             plugin.PermissionResourceImages,
+	"Gallery":           plugin.PermissionResourceGalleries,
+	"GalleryChapter":    plugin.PermissionResourceGalleries,
+	"Performer":         plugin.PermissionResourcePerformers,
+	"PerformerImage":    plugin.PermissionResourcePerformers,
+	"Studio":            plugin.PermissionResourceStudios,
+	"Group":             plugin.PermissionResourceGroups,
+	"Movie":             plugin.PermissionResourceGroups,
+	"Tag":               plugin.PermissionResourceTags,
+	"SavedFilter":       plugin.PermissionResourceFilters,
+	"SavedFilterFolder": plugin.PermissionResourceFilters,
+}
+
+// routeResources maps the first segment of non-GraphQL routes to the
+// resource they access. Routes not listed do not require a permission, since
+// they either serve the UI or perform their own authorization.
+var routeResources = map[string]string{
+	"scene":        plugin.PermissionResourceScenes,
+	"image":        plugin.PermissionResourceImages,
+	"gallery":      plugin.PermissionResourceGalleries,
+	"performer":    plugin.PermissionResourcePerformers,
+	"studio":       plugin.PermissionResourceStudios,
+	"group":        plugin.PermissionResourceGroups,
+	"tag":          plugin.PermissionResourceTags,
+	"downloads":    plugin.PermissionResourceJobs,
+	"reports":      plugin.PermissionResourceJobs,
+	"integrations": plugin.PermissionResourceJobs,
+	"worker":       plugin.PermissionResourceJobs,
+	"plugin":       plugin.PermissionResourcePlugins,
+	"custom":       plugin.PermissionResourceFiles,
+	"restricted":   plugin.PermissionResourceConfig,
+}
+
+// sqlRootFields run arbitrary SQL statements, which can read every table.
+// They require system write permission, even when used as a query.
+var sqlRootFields = map[string]bool{
+	"querysql": true,
+	"execsql":  true,
+}
+
+// rootFieldResource returns the resource accessed by the root field name.
+func rootFieldResource(name string) string {
+	lower := strings.ToLower(name)
+	for _, r := range rootFieldResources {
+		if strings.Contains(lower, r.keyword) {
+			return r.resource
+		}
+	}
+
+	return plugin.PermissionResourceSystem
+}
+
+// fieldPermission returns the resource accessed by resolving field of object,
+// and whether write access is required. Returns an empty resource if no
+// permission is required.
+func fieldPermission(object string, field string) (resource string, write bool) {
+	if strings.HasPrefix(field, "__") {
+		return "", false
+	}
+
+	if (object == "Query" || object == "Mutation") && sqlRootFields[strings.ToLower(field)] {
+		return plugin.PermissionResourceSystem, true
+	}
+
+	switch object {
+	case "Query", "Subscription":
+		return rootFieldResource(field), false
+	case "Mutation":
+		return rootFieldResource(field), true
+	}
+
+	return objectResources[object], false
+}
+
+// routePermission returns the resource accessed by the request, and whether
+// write access is required. Returns an empty resource if no permission is
+// required.
+func routePermission(r *http.Request) (resource string, write bool) {
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	first, rest, _ := strings.Cut(p, "/")
+
+	resource = routeResources[first]
+	if resource == plugin.PermissionResourceScenes && strings.Contains(rest, "scene_marker/") {
+		resource = plugin.PermissionResourceMarkers
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		write = false
+	default:
+		write = true
+	}
+
+	// unlocking restricted content changes what can be read, so it
+	// requires write permission regardless of the method
+	if first == "restricted" {
+		write = true
+	}
+
+	return resource, write
+}
+
+// pluginRoutePermissionsHandler prevents plugins with restricted permissions
+// from accessing routes outside of the GraphQL API that they do not have
+// permission for. GraphQL fields are checked by pluginPermissionsMiddleware.
+func pluginRoutePermissionsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account := session.GetPluginServiceAccount(r.Context())
+		if account == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		resource, write := routePermission(r)
+		if resource == "" || plugin.Permissions(account.Permissions).Allows(resource, write) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		msg := fmt.Sprintf("plugin %s does not have %s permission", account.PluginID, plugin.Permission(resource, write))
+		http.Error(w, msg, http.StatusForbidden)
+	})
+}
+
+// pluginPermissionsMiddleware prevents plugins with restricted permissions
+// from resolving fields they do not have permission for.
+func pluginPermissionsMiddleware(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	account := session.GetPluginServiceAccount(ctx)
+	if account == nil {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	resource, write := fieldPermission(fc.Object, fc.Field.Name)
+	if resource == "" || plugin.Permissions(account.Permissions).Allows(resource, write) {
+		return next(ctx)
+	}
+
+	return nil, fmt.Errorf("plugin %s does not have %s permission", account.PluginID, plugin.Permission(resource, write))
+}
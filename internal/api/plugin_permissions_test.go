@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+func TestFieldPermission(t *testing.T) {
+	tests := []struct {
+		object       string
+		field        string
+		wantResource string
+		wantWrite    bool
+	}{
+		{"Query", "findTags", plugin.PermissionResourceTags, false},
+		{"Mutation", "tagUpdate", plugin.PermissionResourceTags, true},
+		{"Query", "findSceneMarkers", plugin.PermissionResourceMarkers, false},
+		{"Mutation", "sceneMarkerCreate", plugin.PermissionResourceMarkers, true},
+		{"Query", "scrapeSingleScene", plugin.PermissionResourceScrapers, false},
+		{"Mutation", "submitStashBoxSceneDraft", plugin.PermissionResourceScrapers, true},
+		{"Mutation", "metadataAutoTag", plugin.PermissionResourceJobs, true},
+		{"Mutation", "bulkSceneUpdateJob", plugin.PermissionResourceScenes, true},
+		{"Mutation", "addGalleryImages", plugin.PermissionResourceGalleries, true},
+		{"Query", "findDefaultFilter", plugin.PermissionResourceFilters, false},
+		{"Mutation", "configurePlugin", plugin.PermissionResourcePlugins, true},
+		{"Query", "configuration", plugin.PermissionResourceConfig, false},
+		{"Query", "version", plugin.PermissionResourceSystem, false},
+		{"Query", "querySQL", plugin.PermissionResourceSystem, true},
+		{"Mutation", "querySQL", plugin.PermissionResourceSystem, true},
+		{"Mutation", "execSQL", plugin.PermissionResourceSystem, true},
+		{"Subscription", "jobsSubscribe", plugin.PermissionResourceJobs, false},
+		{"Scene", "tags", plugin.PermissionResourceScenes, false},
+		{"Tag", "name", plugin.PermissionResourceTags, false},
+		{"Tag", "__typename", "", false},
+		{"VideoFile", "path", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.object+"."+tt.field, func(t *testing.T) {
+			resource, write := fieldPermission(tt.object, tt.field)
+			if resource != tt.wantResource || write != tt.wantWrite {
+				t.Errorf("fieldPermission() = (%q, %v), want (%q, %v)", resource, write, tt.wantResource, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestPermissionsAllows(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions plugin.Permissions
+		resource    string
+		write       bool
+		want        bool
+	}{
+		{"read", plugin.Permissions{"tags:read"}, "tags", false, true},
+		{"read denies write", plugin.Permissions{"tags:read"}, "tags", true, false},
+		{"write implies read", plugin.Permissions{"tags:write"}, "tags", false, true},
+		{"other resource", plugin.Permissions{"tags:write"}, "scenes", false, false},
+		{"wildcard", plugin.Permissions{"*:read"}, "scenes", false, true},
+		{"wildcard read denies write", plugin.Permissions{"*:read", "tags:write"}, "scenes", true, false},
+		{"wildcard with specific write", plugin.Permissions{"*:read", "tags:write"}, "tags", true, true},
+		{"none", plugin.Permissions{}, "tags", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.permissions.Allows(tt.resource, tt.write); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutePermission(t *testing.T) {
+	tests := []struct {
+		method       string
+		path         string
+		wantResource string
+		wantWrite    bool
+	}{
+		{http.MethodGet, "/scene/1/stream", plugin.PermissionResourceScenes, false},
+		{http.MethodGet, "/scene/1/scene_marker/2/stream", plugin.PermissionResourceMarkers, false},
+		{http.MethodGet, "/image/1/image", plugin.PermissionResourceImages, false},
+		{http.MethodGet, "/downloads/abc/export.zip", plugin.PermissionResourceJobs, false},
+		{http.MethodGet, "/reports/report.csv", plugin.PermissionResourceJobs, false},
+		{http.MethodPost, "/integrations/download-complete", plugin.PermissionResourceJobs, true},
+		{http.MethodPost, "/restricted/unlock", plugin.PermissionResourceConfig, true},
+		{http.MethodPost, "/graphql", "", true},
+		{http.MethodGet, "/", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			resource, write := routePermission(r)
+			if resource != tt.wantResource || write != tt.wantWrite {
+				t.Errorf("routePermission() = (%q, %v), want (%q, %v)", resource, write, tt.wantResource, tt.wantWrite)
+			}
+		})
+	}
+}
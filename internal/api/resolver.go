@@ -7,8 +7,10 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/build"
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
@@ -26,6 +28,9 @@ var (
 
 	// ErrInput signifies errors where the input isn't valid for some reason. And no more specific error exists.
 	ErrInput = errors.New("input error")
+
+	// ErrConflict is returned when an update is based on an outdated version of the object.
+	ErrConflict = errors.New("conflict")
 )
 
 type hookExecutor interface {
@@ -52,6 +57,9 @@ func (r *Resolver) Gallery() GalleryResolver {
 func (r *Resolver) GalleryChapter() GalleryChapterResolver {
 	return &galleryChapterResolver{r}
 }
+func (r *Resolver) GalleryReadingSession() GalleryReadingSessionResolver {
+	return &galleryReadingSessionResolver{r}
+}
 func (r *Resolver) Mutation() MutationResolver {
 	return &mutationResolver{r}
 }
@@ -70,6 +78,12 @@ func (r *Resolver) Image() ImageResolver {
 func (r *Resolver) SceneMarker() SceneMarkerResolver {
 	return &sceneMarkerResolver{r}
 }
+func (r *Resolver) SceneMarkerSuggestion() SceneMarkerSuggestionResolver {
+	return &sceneMarkerSuggestionResolver{r}
+}
+func (r *Resolver) SceneRating() SceneRatingResolver {
+	return &sceneRatingResolver{r}
+}
 func (r *Resolver) Studio() StudioResolver {
 	return &studioResolver{r}
 }
@@ -93,12 +107,39 @@ func (r *Resolver) GalleryFile() GalleryFileResolver {
 func (r *Resolver) VideoFile() VideoFileResolver {
 	return &videoFileResolver{r}
 }
+func (r *Resolver) TagCategory() TagCategoryResolver {
+	return &tagCategoryResolver{r}
+}
 func (r *Resolver) ImageFile() ImageFileResolver {
 	return &imageFileResolver{r}
 }
 func (r *Resolver) SavedFilter() SavedFilterResolver {
 	return &savedFilterResolver{r}
 }
+func (r *Resolver) SavedFilterFolder() SavedFilterFolderResolver {
+	return &savedFilterFolderResolver{r}
+}
+func (r *Resolver) ShareLink() ShareLinkResolver {
+	return &shareLinkResolver{r}
+}
+func (r *Resolver) JobArtifact() JobArtifactResolver {
+	return &jobArtifactResolver{r}
+}
+func (r *Resolver) Note() NoteResolver {
+	return &noteResolver{r}
+}
+func (r *Resolver) ImageRegion() ImageRegionResolver {
+	return &imageRegionResolver{r}
+}
+func (r *Resolver) PerformerImage() PerformerImageResolver {
+	return &performerImageResolver{r}
+}
+func (r *Resolver) PerformerMergePreview() PerformerMergePreviewResolver {
+	return &performerMergePreviewResolver{r}
+}
+func (r *Resolver) SceneImageDuplicate() SceneImageDuplicateResolver {
+	return &sceneImageDuplicateResolver{r}
+}
 func (r *Resolver) Plugin() PluginResolver {
 	return &pluginResolver{r}
 }
@@ -112,9 +153,12 @@ type subscriptionResolver struct{ *Resolver }
 
 type galleryResolver struct{ *Resolver }
 type galleryChapterResolver struct{ *Resolver }
+type galleryReadingSessionResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
+type sceneMarkerSuggestionResolver struct{ *Resolver }
+type sceneRatingResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
 type studioResolver struct{ *Resolver }
 
@@ -123,10 +167,19 @@ type groupResolver struct{ *Resolver }
 type movieResolver struct{ *groupResolver }
 
 type tagResolver struct{ *Resolver }
+type tagCategoryResolver struct{ *Resolver }
 type galleryFileResolver struct{ *Resolver }
 type videoFileResolver struct{ *Resolver }
 type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
+type savedFilterFolderResolver struct{ *Resolver }
+type shareLinkResolver struct{ *Resolver }
+type jobArtifactResolver struct{ *Resolver }
+type noteResolver struct{ *Resolver }
+type imageRegionResolver struct{ *Resolver }
+type performerImageResolver struct{ *Resolver }
+type performerMergePreviewResolver struct{ *Resolver }
+type sceneImageDuplicateResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
 
@@ -289,6 +342,10 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 	return &ret, nil
 }
 
+func (r *queryResolver) RestrictedContentUnlocked(ctx context.Context) (bool, error) {
+	return !models.RestrictedContentHidden(ctx), nil
+}
+
 func (r *queryResolver) Version(ctx context.Context) (*Version, error) {
 	version, hash, buildtime := build.Version()
 
@@ -355,6 +412,45 @@ func (r *mutationResolver) QuerySQL(ctx context.Context, sql string, args []inte
 	}, nil
 }
 
+// QuerySQL executes a read-only SQL statement. The statement is run in a read
+// transaction, which uses a read-only database connection, so statements that
+// write to the database fail.
+func (r *queryResolver) QuerySQL(ctx context.Context, sql string, params []interface{}) (*SQLQueryResult, error) {
+	c := config.GetInstance()
+	if !c.IsSQLQueryEnabled() {
+		return nil, fmt.Errorf("%w: SQL queries are disabled", ErrNotSupported)
+	}
+
+	// without credentials, there is no way to restrict who may query the database
+	if !c.HasCredentials() {
+		return nil, fmt.Errorf("%w: SQL queries require credentials to be configured", ErrNotSupported)
+	}
+
+	return r.readOnlyQuerySQL(ctx, manager.GetInstance().Database, sql, params)
+}
+
+type sqlQueryer interface {
+	QuerySQL(ctx context.Context, query string, args []interface{}) ([]string, [][]interface{}, error)
+}
+
+func (r *queryResolver) readOnlyQuerySQL(ctx context.Context, db sqlQueryer, sql string, params []interface{}) (*SQLQueryResult, error) {
+	var cols []string
+	var rows [][]interface{}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		cols, rows, err = db.QuerySQL(ctx, sql, params)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &SQLQueryResult{
+		Columns: cols,
+		Rows:    rows,
+	}, nil
+}
+
 // Get scene marker tags which show up under the video.
 func (r *queryResolver) SceneMarkerTags(ctx context.Context, scene_id string) ([]*SceneMarkerTag, error) {
 	sceneID, err := strconv.Atoi(scene_id)
@@ -420,3 +516,24 @@ func firstError(errs []error) error {
 
 	return nil
 }
+
+// loadRelatedIDs loads the related ids of the object with the given id using
+// the dataloader, so that the ids of objects in the same request are loaded
+// in batches. Does nothing if the ids are already loaded.
+func loadRelatedIDs(ids *models.RelatedIDs, id int, loader *loaders.RelatedIDsLoader) error {
+	if ids.Loaded() {
+		return nil
+	}
+
+	ret, err := loader.Load(id)
+	if err != nil {
+		return err
+	}
+
+	if ret == nil {
+		ret = []int{}
+	}
+
+	*ids = models.NewRelatedIDs(ret)
+	return nil
+}
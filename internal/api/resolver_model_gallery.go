@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *galleryResolver) getFiles(ctx context.Context, obj *models.Gallery) ([]models.File, error) {
@@ -91,12 +93,8 @@ func (r *galleryResolver) Rating100(ctx context.Context, obj *models.Gallery) (*
 }
 
 func (r *galleryResolver) Scenes(ctx context.Context, obj *models.Gallery) (ret []*models.Scene, err error) {
-	if !obj.SceneIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadSceneIDs(ctx, r.repository.Gallery)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.SceneIDs, obj.ID, loaders.From(ctx).GallerySceneIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -113,12 +111,8 @@ func (r *galleryResolver) Studio(ctx context.Context, obj *models.Gallery) (ret
 }
 
 func (r *galleryResolver) Tags(ctx context.Context, obj *models.Gallery) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Gallery)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).GalleryTagIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -127,12 +121,8 @@ func (r *galleryResolver) Tags(ctx context.Context, obj *models.Gallery) (ret []
 }
 
 func (r *galleryResolver) Performers(ctx context.Context, obj *models.Gallery) (ret []*models.Performer, err error) {
-	if !obj.PerformerIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadPerformerIDs(ctx, r.repository.Gallery)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.PerformerIDs, obj.ID, loaders.From(ctx).GalleryPerformerIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -141,15 +131,7 @@ func (r *galleryResolver) Performers(ctx context.Context, obj *models.Gallery) (
 }
 
 func (r *galleryResolver) ImageCount(ctx context.Context, obj *models.Gallery) (ret int, err error) {
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		var err error
-		ret, err = r.repository.Image.CountByGalleryID(ctx, obj.ID)
-		return err
-	}); err != nil {
-		return 0, err
-	}
-
-	return ret, nil
+	return loaders.From(ctx).GalleryImageCount.Load(obj.ID)
 }
 
 func (r *galleryResolver) Chapters(ctx context.Context, obj *models.Gallery) (ret []*models.GalleryChapter, err error) {
@@ -192,13 +174,27 @@ func (r *galleryResolver) Urls(ctx context.Context, obj *models.Gallery) ([]stri
 	return obj.URLs.List(), nil
 }
 
+func (r *galleryResolver) TypedUrls(ctx context.Context, obj *models.Gallery) ([]*models.TypedURL, error) {
+	var urls []models.TypedURL
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		urls, err = r.repository.Gallery.GetTypedURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(urls), nil
+}
+
 func (r *galleryResolver) Paths(ctx context.Context, obj *models.Gallery) (*GalleryPathsType, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	builder := urlbuilders.NewGalleryURLBuilder(baseURL, obj)
 
 	return &GalleryPathsType{
-		Cover:   builder.GetCoverURL(),
-		Preview: builder.GetPreviewURL(),
+		Cover:    builder.GetCoverURL(),
+		Preview:  builder.GetPreviewURL(),
+		Download: builder.GetDownloadURL(),
 	}, nil
 }
 
@@ -216,3 +212,104 @@ func (r *galleryResolver) Image(ctx context.Context, obj *models.Gallery, index
 
 	return
 }
+
+func (r *galleryResolver) ImageStacks(ctx context.Context, obj *models.Gallery, options *ImageStackOptionsInput) (ret []*image.Stack, err error) {
+	stackOptions := image.DefaultStackOptions
+	if options != nil {
+		if options.Interval != nil {
+			stackOptions.Interval = *options.Interval
+		}
+		if options.Distance != nil {
+			stackOptions.Distance = *options.Distance
+		}
+		if options.DuplicateDistance != nil {
+			stackOptions.DuplicateDistance = *options.DuplicateDistance
+		}
+		if options.MinSize != nil {
+			stackOptions.MinSize = *options.MinSize
+		}
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = image.FindStacks(ctx, r.repository.Image, r.repository.File, obj.ID, stackOptions)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *galleryResolver) OCounter(ctx context.Context, obj *models.Gallery) (ret *int, err error) {
+	var count int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		count, err = r.repository.Gallery.GetOCount(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *galleryResolver) OHistory(ctx context.Context, obj *models.Gallery) (ret []*time.Time, err error) {
+	var dates []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		dates, err = r.repository.Gallery.GetODates(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(dates), nil
+}
+
+func (r *galleryResolver) ViewCount(ctx context.Context, obj *models.Gallery) (ret *int, err error) {
+	var count int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		count, err = r.repository.Gallery.CountViews(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *galleryResolver) ViewHistory(ctx context.Context, obj *models.Gallery) (ret []*time.Time, err error) {
+	var dates []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		dates, err = r.repository.Gallery.GetViewDates(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(dates), nil
+}
+
+func (r *galleryResolver) LastViewedAt(ctx context.Context, obj *models.Gallery) (ret *time.Time, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		lastViewed, err := r.repository.Gallery.GetManyLastViewed(ctx, []int{obj.ID})
+		if err != nil {
+			return err
+		}
+		ret = lastViewed[0]
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *galleryResolver) ReadingSession(ctx context.Context, obj *models.Gallery) (ret *models.GalleryReadingSession, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.GetReadingSession(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
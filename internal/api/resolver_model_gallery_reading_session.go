@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *galleryReadingSessionResolver) Gallery(ctx context.Context, obj *models.GalleryReadingSession) (ret *models.Gallery, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.Find(ctx, obj.GalleryID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *galleryReadingSessionResolver) Progress(ctx context.Context, obj *models.GalleryReadingSession) (float64, error) {
+	var imageCount int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		imageCount, err = r.repository.Image.CountByGalleryID(ctx, obj.GalleryID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return gallery.ReadingProgress(obj.Page, imageCount), nil
+}
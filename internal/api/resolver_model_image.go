@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *imageResolver) getFiles(ctx context.Context, obj *models.Image) ([]models.File, error) {
@@ -84,13 +87,21 @@ func (r *imageResolver) Paths(ctx context.Context, obj *models.Image) (*ImagePat
 	}, nil
 }
 
+func (r *imageResolver) TransformURL(ctx context.Context, obj *models.Image, input ImageTransformInput) (string, error) {
+	t, err := imageTransformFromInput(input)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	expiresAt := manager.ImageTransformExpiry(time.Now())
+	sig := manager.SignImageTransform(manager.ImageTransformSource(obj.ID), t, expiresAt)
+	return urlbuilders.NewImageURLBuilder(baseURL, obj).GetTransformURL(t, sig, expiresAt), nil
+}
+
 func (r *imageResolver) Galleries(ctx context.Context, obj *models.Image) (ret []*models.Gallery, err error) {
-	if !obj.GalleryIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadGalleryIDs(ctx, r.repository.Image)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.GalleryIDs, obj.ID, loaders.From(ctx).ImageGalleryIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -111,12 +122,8 @@ func (r *imageResolver) Studio(ctx context.Context, obj *models.Image) (ret *mod
 }
 
 func (r *imageResolver) Tags(ctx context.Context, obj *models.Image) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Image)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).ImageTagIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -125,12 +132,8 @@ func (r *imageResolver) Tags(ctx context.Context, obj *models.Image) (ret []*mod
 }
 
 func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret []*models.Performer, err error) {
-	if !obj.PerformerIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadPerformerIDs(ctx, r.repository.Image)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.PerformerIDs, obj.ID, loaders.From(ctx).ImagePerformerIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -138,6 +141,17 @@ func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret
 	return ret, firstError(errs)
 }
 
+func (r *imageResolver) Regions(ctx context.Context, obj *models.Image) (ret []*models.ImageRegion, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.ImageRegion.FindByImageID(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *imageResolver) URL(ctx context.Context, obj *models.Image) (*string, error) {
 	if !obj.URLs.Loaded() {
 		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
@@ -166,3 +180,42 @@ func (r *imageResolver) Urls(ctx context.Context, obj *models.Image) ([]string,
 
 	return obj.URLs.List(), nil
 }
+
+func (r *imageResolver) ViewCount(ctx context.Context, obj *models.Image) (ret *int, err error) {
+	var count int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		count, err = r.repository.Image.CountViews(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *imageResolver) ViewHistory(ctx context.Context, obj *models.Image) (ret []*time.Time, err error) {
+	var dates []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		dates, err = r.repository.Image.GetViewDates(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(dates), nil
+}
+
+func (r *imageResolver) LastViewedAt(ctx context.Context, obj *models.Image) (ret *time.Time, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		lastViewed, err := r.repository.Image.GetManyLastViewed(ctx, []int{obj.ID})
+		if err != nil {
+			return err
+		}
+		ret = lastViewed[0]
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *imageRegionResolver) Image(ctx context.Context, obj *models.ImageRegion) (*models.Image, error) {
+	return loaders.From(ctx).ImageByID.Load(obj.ImageID)
+}
+
+func (r *imageRegionResolver) Tag(ctx context.Context, obj *models.ImageRegion) (*models.Tag, error) {
+	if obj.TagID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).TagByID.Load(*obj.TagID)
+}
+
+func (r *imageRegionResolver) Performer(ctx context.Context, obj *models.ImageRegion) (*models.Performer, error) {
+	if obj.PerformerID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).PerformerByID.Load(*obj.PerformerID)
+}
@@ -60,12 +60,8 @@ func (r *groupResolver) Studio(ctx context.Context, obj *models.Group) (ret *mod
 }
 
 func (r groupResolver) Tags(ctx context.Context, obj *models.Group) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Group)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).GroupTagIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
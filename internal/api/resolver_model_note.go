@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/note"
+)
+
+func (r *noteResolver) Scene(ctx context.Context, obj *models.Note) (*models.Scene, error) {
+	if obj.SceneID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).SceneByID.Load(*obj.SceneID)
+}
+
+func (r *noteResolver) Performer(ctx context.Context, obj *models.Note) (*models.Performer, error) {
+	if obj.PerformerID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).PerformerByID.Load(*obj.PerformerID)
+}
+
+func (r *noteResolver) BodyHTML(ctx context.Context, obj *models.Note) (string, error) {
+	return note.RenderMarkdown(obj.Body), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *performerResolver) AliasList(ctx context.Context, obj *models.Performer) ([]string, error) {
@@ -97,6 +98,34 @@ func (r *performerResolver) Urls(ctx context.Context, obj *models.Performer) ([]
 	return obj.URLs.List(), nil
 }
 
+func (r *performerResolver) TypedUrls(ctx context.Context, obj *models.Performer) ([]*models.TypedURL, error) {
+	var urls []models.TypedURL
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		urls, err = r.repository.Performer.GetTypedURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(urls), nil
+}
+
+func (r *performerResolver) URLStatuses(ctx context.Context, obj *models.Performer) (ret []*models.URLStatus, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if err := obj.LoadURLs(ctx, r.repository.Performer); err != nil {
+			return err
+		}
+
+		ret, err = r.repository.URLStatus.FindByURLs(ctx, obj.URLs.List())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *performerResolver) Height(ctx context.Context, obj *models.Performer) (*string, error) {
 	if obj.Height != nil {
 		ret := strconv.Itoa(*obj.Height)
@@ -109,6 +138,55 @@ func (r *performerResolver) HeightCm(ctx context.Context, obj *models.Performer)
 	return obj.Height, nil
 }
 
+func (r *performerResolver) Bust(ctx context.Context, obj *models.Performer) (*int, error) {
+	return models.ParseMeasurements(obj.Measurements).Bust, nil
+}
+
+func (r *performerResolver) Waist(ctx context.Context, obj *models.Performer) (*int, error) {
+	return models.ParseMeasurements(obj.Measurements).Waist, nil
+}
+
+func (r *performerResolver) Hips(ctx context.Context, obj *models.Performer) (*int, error) {
+	return models.ParseMeasurements(obj.Measurements).Hips, nil
+}
+
+func (r *performerResolver) CupSize(ctx context.Context, obj *models.Performer) (*string, error) {
+	if v := models.ParseMeasurements(obj.Measurements).CupSize; v != "" {
+		return &v, nil
+	}
+	return nil, nil
+}
+
+func (r *performerResolver) FormattedHeight(ctx context.Context, obj *models.Performer, units models.MeasurementUnits) (*string, error) {
+	if obj.Height != nil {
+		ret := models.FormatHeight(*obj.Height, units)
+		return &ret, nil
+	}
+	return nil, nil
+}
+
+func (r *performerResolver) FormattedWeight(ctx context.Context, obj *models.Performer, units models.MeasurementUnits) (*string, error) {
+	if obj.Weight != nil {
+		ret := models.FormatWeight(*obj.Weight, units)
+		return &ret, nil
+	}
+	return nil, nil
+}
+
+func (r *performerResolver) FormattedMeasurements(ctx context.Context, obj *models.Performer, units models.MeasurementUnits) (*string, error) {
+	if obj.Measurements == "" {
+		return nil, nil
+	}
+
+	m := models.ParseMeasurements(obj.Measurements)
+	if m.IsEmpty() {
+		return &obj.Measurements, nil
+	}
+
+	ret := m.Format(units)
+	return &ret, nil
+}
+
 func (r *performerResolver) Birthdate(ctx context.Context, obj *models.Performer) (*string, error) {
 	if obj.Birthdate != nil {
 		ret := obj.Birthdate.String()
@@ -132,13 +210,20 @@ func (r *performerResolver) ImagePath(ctx context.Context, obj *models.Performer
 	return &imagePath, nil
 }
 
+func (r *performerResolver) Images(ctx context.Context, obj *models.Performer) (ret []*models.PerformerImage, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.GetImages(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *performerResolver) Tags(ctx context.Context, obj *models.Performer) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Performer)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).PerformerTagIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -268,6 +353,28 @@ func (r *performerResolver) Groups(ctx context.Context, obj *models.Performer) (
 	return ret, nil
 }
 
+func (r *performerResolver) Notes(ctx context.Context, obj *models.Performer) (ret []*models.Note, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Note.FindByPerformerID(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *performerResolver) FieldSources(ctx context.Context, obj *models.Performer) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.GetFieldSources(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) (map[string]interface{}, error) {
 	m, err := loaders.From(ctx).PerformerCustomFields.Load(obj.ID)
 	if err != nil {
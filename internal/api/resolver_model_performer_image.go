@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *performerImageResolver) ImagePath(ctx context.Context, obj *models.PerformerImage) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	builder := urlbuilders.PerformerURLBuilder{
+		BaseURL:     baseURL,
+		PerformerID: strconv.Itoa(obj.PerformerID),
+	}
+	return builder.GetPerformerSetImageURL(obj), nil
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *performerMergePreviewResolver) Destination(ctx context.Context, obj *models.PerformerMergePreview) (*models.Performer, error) {
+	return loaders.From(ctx).PerformerByID.Load(obj.DestinationID)
+}
+
+func (r *performerMergePreviewResolver) Sources(ctx context.Context, obj *models.PerformerMergePreview) (ret []*models.Performer, err error) {
+	var errs []error
+	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.SourceIDs)
+	return ret, firstError(errs)
+}
+
+func (r *performerMergePreviewResolver) Tags(ctx context.Context, obj *models.PerformerMergePreview) (ret []*models.Tag, err error) {
+	var errs []error
+	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs)
+	return ret, firstError(errs)
+}
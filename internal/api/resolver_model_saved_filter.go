@@ -9,3 +9,18 @@ import (
 func (r *savedFilterResolver) Filter(ctx context.Context, obj *models.SavedFilter) (string, error) {
 	return "", nil
 }
+
+func (r *savedFilterResolver) Folder(ctx context.Context, obj *models.SavedFilter) (ret *models.SavedFilterFolder, err error) {
+	if obj.FolderID == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SavedFilterFolder.Find(ctx, *obj.FolderID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *savedFilterFolderResolver) Parent(ctx context.Context, obj *models.SavedFilterFolder) (ret *models.SavedFilterFolder, err error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SavedFilterFolder.Find(ctx, *obj.ParentID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
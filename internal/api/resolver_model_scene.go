@@ -9,6 +9,8 @@ import (
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func convertVideoFile(f models.File) (*models.VideoFile, error) {
@@ -103,6 +105,75 @@ func (r *sceneResolver) Rating100(ctx context.Context, obj *models.Scene) (*int,
 	return obj.Rating, nil
 }
 
+func (r *sceneResolver) Ratings(ctx context.Context, obj *models.Scene) (ret []*models.SceneRating, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetRatings(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) PendingFingerprints(ctx context.Context, obj *models.Scene) (ret []*models.Fingerprint, err error) {
+	var fp []models.Fingerprint
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		fp, err = r.repository.Scene.GetPendingFingerprints(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range fp {
+		ret = append(ret, &fp[i])
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) Transcript(ctx context.Context, obj *models.Scene) (ret *models.SceneTranscript, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetTranscript(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) PersonalRating100(ctx context.Context, obj *models.Scene) (*int, error) {
+	ratings, err := r.Ratings(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	username := models.CurrentUsername(ctx)
+	for _, rating := range ratings {
+		if rating.Username == username {
+			return &rating.Rating, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *sceneResolver) RatingStats(ctx context.Context, obj *models.Scene) (*models.RatingStats, error) {
+	ratings, err := r.Ratings(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]int, len(ratings))
+	for i, rating := range ratings {
+		values[i] = rating.Rating
+	}
+
+	ret := models.NewRatingStats(values)
+	return &ret, nil
+}
+
 func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePathsType, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	config := manager.GetInstance().Config
@@ -111,26 +182,46 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	previewPath := builder.GetStreamPreviewURL()
 	streamPath := builder.GetStreamURL(config.GetAPIKey()).String()
 	webpPath := builder.GetStreamPreviewImageURL()
+	trailerPath := builder.GetTrailerURL(config.GetAPIKey()).String()
 	objHash := obj.GetHash(config.GetVideoFileNamingAlgorithm())
 	vttPath := builder.GetSpriteVTTURL(objHash)
 	spritePath := builder.GetSpriteURL(objHash)
+	barcodePath := builder.GetBarcodeURL(objHash)
 	funscriptPath := builder.GetFunscriptURL()
 	captionBasePath := builder.GetCaptionURL()
 	interactiveHeatmap := builder.GetInteractiveHeatmapURL()
+	heatmap := builder.GetHeatmapURL()
+	heatmapData := builder.GetHeatmapDataURL()
 
 	return &ScenePathsType{
 		Screenshot:         &screenshotPath,
 		Preview:            &previewPath,
 		Stream:             &streamPath,
 		Webp:               &webpPath,
+		Trailer:            &trailerPath,
 		Vtt:                &vttPath,
 		Sprite:             &spritePath,
+		Barcode:            &barcodePath,
 		Funscript:          &funscriptPath,
 		InteractiveHeatmap: &interactiveHeatmap,
+		Heatmap:            &heatmap,
+		HeatmapData:        &heatmapData,
 		Caption:            &captionBasePath,
 	}, nil
 }
 
+func (r *sceneResolver) ScreenshotTransformURL(ctx context.Context, obj *models.Scene, input ImageTransformInput) (string, error) {
+	t, err := imageTransformFromInput(input)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	expiresAt := manager.ImageTransformExpiry(time.Now())
+	sig := manager.SignImageTransform(manager.SceneScreenshotTransformSource(obj.ID), t, expiresAt)
+	return urlbuilders.NewSceneURLBuilder(baseURL, obj).GetScreenshotTransformURL(t, sig, expiresAt), nil
+}
+
 func (r *sceneResolver) SceneMarkers(ctx context.Context, obj *models.Scene) (ret []*models.SceneMarker, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.SceneMarker.FindBySceneID(ctx, obj.ID)
@@ -162,12 +253,8 @@ func (r *sceneResolver) Captions(ctx context.Context, obj *models.Scene) (ret []
 }
 
 func (r *sceneResolver) Galleries(ctx context.Context, obj *models.Scene) (ret []*models.Gallery, err error) {
-	if !obj.GalleryIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadGalleryIDs(ctx, r.repository.Scene)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.GalleryIDs, obj.ID, loaders.From(ctx).SceneGalleryIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -246,12 +333,8 @@ func (r *sceneResolver) Groups(ctx context.Context, obj *models.Scene) (ret []*S
 }
 
 func (r *sceneResolver) Tags(ctx context.Context, obj *models.Scene) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Scene)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).SceneTagIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -260,12 +343,8 @@ func (r *sceneResolver) Tags(ctx context.Context, obj *models.Scene) (ret []*mod
 }
 
 func (r *sceneResolver) Performers(ctx context.Context, obj *models.Scene) (ret []*models.Performer, err error) {
-	if !obj.PerformerIDs.Loaded() {
-		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadPerformerIDs(ctx, r.repository.Scene)
-		}); err != nil {
-			return nil, err
-		}
+	if err := loadRelatedIDs(&obj.PerformerIDs, obj.ID, loaders.From(ctx).ScenePerformerIDs); err != nil {
+		return nil, err
 	}
 
 	var errs []error
@@ -273,6 +352,25 @@ func (r *sceneResolver) Performers(ctx context.Context, obj *models.Scene) (ret
 	return ret, firstError(errs)
 }
 
+func (r *sceneResolver) PerformerAges(ctx context.Context, obj *models.Scene) ([]*ScenePerformerAge, error) {
+	performers, err := r.Performers(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*ScenePerformerAge{}
+	for _, p := range performers {
+		if age := performer.SceneAge(p, obj); age != nil {
+			ret = append(ret, &ScenePerformerAge{
+				Performer: p,
+				Age:       *age,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []*models.StashID, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		return obj.LoadStashIDs(ctx, r.repository.Scene)
@@ -283,7 +381,29 @@ func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []
 	return stashIDsSliceToPtrSlice(obj.StashIDs.List()), nil
 }
 
-func (r *sceneResolver) SceneStreams(ctx context.Context, obj *models.Scene) ([]*manager.SceneStreamEndpoint, error) {
+func (r *sceneResolver) Notes(ctx context.Context, obj *models.Scene) (ret []*models.Note, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Note.FindBySceneID(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) FieldSources(ctx context.Context, obj *models.Scene) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetFieldSources(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) SceneStreams(ctx context.Context, obj *models.Scene, profile *manager.StreamClientProfile) ([]*manager.SceneStreamEndpoint, error) {
 	// load the primary file into the scene
 	_, err := r.getPrimaryFile(ctx, obj)
 	if err != nil {
@@ -296,9 +416,28 @@ func (r *sceneResolver) SceneStreams(ctx context.Context, obj *models.Scene) ([]
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
 	apiKey := config.GetAPIKey()
 
+	if profile != nil {
+		return manager.NegotiateSceneStreamPaths(obj, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize(), *profile)
+	}
+
 	return manager.GetSceneStreamPaths(obj, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
 }
 
+func (r *sceneResolver) PlaybackHints(ctx context.Context, obj *models.Scene, clients []manager.PlaybackClient) ([]*manager.ScenePlaybackHint, error) {
+	// load the primary file into the scene
+	_, err := r.getPrimaryFile(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	config := manager.GetInstance().Config
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
+
+	return manager.GetScenePlaybackHints(obj, builder.GetStreamURL(config.GetAPIKey()), config.GetMaxStreamingTranscodeSize(), clients)
+}
+
 func (r *sceneResolver) Interactive(ctx context.Context, obj *models.Scene) (bool, error) {
 	primaryFile, err := r.getPrimaryFile(ctx, obj)
 	if err != nil {
@@ -352,6 +491,34 @@ func (r *sceneResolver) Urls(ctx context.Context, obj *models.Scene) ([]string,
 	return obj.URLs.List(), nil
 }
 
+func (r *sceneResolver) TypedUrls(ctx context.Context, obj *models.Scene) ([]*models.TypedURL, error) {
+	var urls []models.TypedURL
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		urls, err = r.repository.Scene.GetTypedURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(urls), nil
+}
+
+func (r *sceneResolver) URLStatuses(ctx context.Context, obj *models.Scene) (ret []*models.URLStatus, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if err := obj.LoadURLs(ctx, r.repository.Scene); err != nil {
+			return err
+		}
+
+		ret, err = r.repository.URLStatus.FindByURLs(ctx, obj.URLs.List())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) OCounter(ctx context.Context, obj *models.Scene) (*int, error) {
 	ret, err := loaders.From(ctx).SceneOCount.Load(obj.ID)
 	if err != nil {
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+func (r *sceneImageDuplicateResolver) Scenes(ctx context.Context, obj *models.SceneImageDuplicate) (ret []*models.Scene, err error) {
+	var errs []error
+	ret, errs = loaders.From(ctx).SceneByID.LoadAll(obj.SceneIDs)
+	return ret, firstError(errs)
+}
+
+func (r *sceneImageDuplicateResolver) Images(ctx context.Context, obj *models.SceneImageDuplicate) (ret []*models.Image, err error) {
+	var errs []error
+	ret, errs = loaders.From(ctx).ImageByID.LoadAll(obj.ImageIDs)
+	return ret, firstError(errs)
+}
+
+func (r *sceneImageDuplicateResolver) Galleries(ctx context.Context, obj *models.SceneImageDuplicate) (ret []*models.Gallery, err error) {
+	var galleryIDs []int
+	coverRegex := config.GetInstance().GetGalleryCoverRegex()
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, imageID := range obj.ImageIDs {
+			i, err := r.repository.Image.Find(ctx, imageID)
+			if err != nil {
+				return err
+			}
+			if i == nil {
+				continue
+			}
+
+			if err := i.LoadGalleryIDs(ctx, r.repository.Image); err != nil {
+				return err
+			}
+
+			for _, galleryID := range i.GalleryIDs.List() {
+				cover, err := image.FindGalleryCover(ctx, r.repository.Image, galleryID, coverRegex)
+				if err != nil {
+					return err
+				}
+
+				if cover != nil && cover.ID == imageID {
+					galleryIDs = sliceutil.AppendUnique(galleryIDs, galleryID)
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).GalleryByID.LoadAll(galleryIDs)
+	return ret, firstError(errs)
+}
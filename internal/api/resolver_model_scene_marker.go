@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 
+	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -40,6 +41,24 @@ func (r *sceneMarkerResolver) Tags(ctx context.Context, obj *models.SceneMarker)
 	return ret, err
 }
 
+func (r *sceneMarkerResolver) Performers(ctx context.Context, obj *models.SceneMarker) (ret []*models.Performer, err error) {
+	var performerIDs []int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		performerIDs, err = r.repository.SceneMarker.GetPerformerIDs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(performerIDs)
+	return ret, firstError(errs)
+}
+
+func (r *sceneMarkerResolver) Rating100(ctx context.Context, obj *models.SceneMarker) (*int, error) {
+	return obj.Rating, nil
+}
+
 func (r *sceneMarkerResolver) Stream(ctx context.Context, obj *models.SceneMarker) (string, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	return urlbuilders.NewSceneMarkerURLBuilder(baseURL, obj).GetStreamURL(), nil
@@ -0,0 +1,16 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *sceneMarkerSuggestionResolver) Scene(ctx context.Context, obj *models.SceneMarkerSuggestion) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
+
+func (r *sceneMarkerSuggestionResolver) PrimaryTag(ctx context.Context, obj *models.SceneMarkerSuggestion) (*models.Tag, error) {
+	return loaders.From(ctx).TagByID.Load(obj.PrimaryTagID)
+}
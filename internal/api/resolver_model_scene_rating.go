@@ -0,0 +1,11 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *sceneRatingResolver) Rating100(ctx context.Context, obj *models.SceneRating) (int, error) {
+	return obj.Rating, nil
+}
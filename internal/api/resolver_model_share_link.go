@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *shareLinkResolver) URL(ctx context.Context, obj *models.ShareLink) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	return urlbuilders.NewShareLinkURLBuilder(baseURL, obj).GetShareURL(), nil
+}
+
+func (r *shareLinkResolver) Scene(ctx context.Context, obj *models.ShareLink) (*models.Scene, error) {
+	if obj.SceneID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).SceneByID.Load(*obj.SceneID)
+}
+
+func (r *shareLinkResolver) Gallery(ctx context.Context, obj *models.ShareLink) (*models.Gallery, error) {
+	if obj.GalleryID == nil {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).GalleryByID.Load(*obj.GalleryID)
+}
+
+func (r *shareLinkResolver) Active(ctx context.Context, obj *models.ShareLink) (bool, error) {
+	return obj.IsActive(time.Now()), nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func (r *studioResolver) ImagePath(ctx context.Context, obj *models.Studio) (*string, error) {
@@ -40,15 +41,52 @@ func (r *studioResolver) Aliases(ctx context.Context, obj *models.Studio) ([]str
 	return obj.Aliases.List(), nil
 }
 
-func (r *studioResolver) Tags(ctx context.Context, obj *models.Studio) (ret []*models.Tag, err error) {
-	if !obj.TagIDs.Loaded() {
+func (r *studioResolver) URL(ctx context.Context, obj *models.Studio) (*string, error) {
+	if !obj.URLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadURLs(ctx, r.repository.Studio)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	urls := obj.URLs.List()
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *studioResolver) Urls(ctx context.Context, obj *models.Studio) ([]string, error) {
+	if !obj.URLs.Loaded() {
 		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-			return obj.LoadTagIDs(ctx, r.repository.Studio)
+			return obj.LoadURLs(ctx, r.repository.Studio)
 		}); err != nil {
 			return nil, err
 		}
 	}
 
+	return obj.URLs.List(), nil
+}
+func (r *studioResolver) TypedUrls(ctx context.Context, obj *models.Studio) ([]*models.TypedURL, error) {
+	var urls []models.TypedURL
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		urls, err = r.repository.Studio.GetTypedURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.ValuesToPtrs(urls), nil
+}
+
+func (r *studioResolver) Tags(ctx context.Context, obj *models.Studio) (ret []*models.Tag, err error) {
+	if err := loadRelatedIDs(&obj.TagIDs, obj.ID, loaders.From(ctx).StudioTagIDs); err != nil {
+		return nil, err
+	}
+
 	var errs []error
 	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs.List())
 	return ret, firstError(errs)
@@ -164,3 +202,40 @@ func (r *studioResolver) Groups(ctx context.Context, obj *models.Studio) (ret []
 func (r *studioResolver) Movies(ctx context.Context, obj *models.Studio) (ret []*models.Group, err error) {
 	return r.Groups(ctx, obj)
 }
+
+func (r *studioResolver) FieldSources(ctx context.Context, obj *models.Studio) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Studio.GetFieldSources(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *studioResolver) DefaultTags(ctx context.Context, obj *models.Studio) (ret []*models.Tag, err error) {
+	if !obj.DefaultTagIDs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadDefaultTagIDs(ctx, r.repository.Studio)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.DefaultTagIDs.List())
+	return ret, firstError(errs)
+}
+
+func (r *studioResolver) DefaultUrls(ctx context.Context, obj *models.Studio) ([]string, error) {
+	if !obj.DefaultURLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadDefaultURLs(ctx, r.repository.Studio)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.DefaultURLs.List(), nil
+}
@@ -42,6 +42,21 @@ func (r *tagResolver) Children(ctx context.Context, obj *models.Tag) (ret []*mod
 	return ret, firstError(errs)
 }
 
+func (r *tagResolver) Category(ctx context.Context, obj *models.Tag) (ret *models.TagCategory, err error) {
+	if obj.CategoryID == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.TagCategory.Find(ctx, *obj.CategoryID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *tagResolver) Aliases(ctx context.Context, obj *models.Tag) (ret []string, err error) {
 	if !obj.Aliases.Loaded() {
 		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
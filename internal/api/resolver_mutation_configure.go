@@ -5,17 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/backup"
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -88,6 +94,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
+			if err := config.ValidateStashFingerprints(s.Fingerprints); err != nil {
+				return makeConfigGeneralResult(), fmt.Errorf("stash path %s: %w", s.Path, err)
+			}
+
 			// Only validate existence of new paths
 			isNew := true
 			for _, path := range existingPaths {
@@ -150,6 +160,71 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.BackupDirectoryPath, *input.BackupDirectoryPath)
 	}
 
+	if input.BackupInterval != nil && *input.BackupInterval < 0 {
+		return makeConfigGeneralResult(), errors.New("backup interval must not be negative")
+	}
+	r.setConfigInt(config.BackupInterval, input.BackupInterval)
+
+	if input.BackupRetention != nil && *input.BackupRetention < 0 {
+		return makeConfigGeneralResult(), errors.New("backup retention must not be negative")
+	}
+	r.setConfigInt(config.BackupRetention, input.BackupRetention)
+
+	existingReportsDirectoryPath := c.GetReportsDirectoryPath()
+	if input.ReportsDirectoryPath != nil && existingReportsDirectoryPath != *input.ReportsDirectoryPath {
+		if err := validateDir(config.ReportsDirectoryPath, *input.ReportsDirectoryPath, true); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetString(config.ReportsDirectoryPath, *input.ReportsDirectoryPath)
+	}
+
+	if input.ReportRetention != nil && *input.ReportRetention < 0 {
+		return makeConfigGeneralResult(), errors.New("report retention must not be negative")
+	}
+	r.setConfigInt(config.ReportRetention, input.ReportRetention)
+
+	if input.DiskSpaceWarningPercent != nil && (*input.DiskSpaceWarningPercent < 1 || *input.DiskSpaceWarningPercent > 100) {
+		return makeConfigGeneralResult(), errors.New("disk space warning percent must be between 1 and 100")
+	}
+	r.setConfigInt(config.DiskSpaceWarningPercent, input.DiskSpaceWarningPercent)
+
+	if input.DiskSpaceReserved != nil && *input.DiskSpaceReserved < 0 {
+		return makeConfigGeneralResult(), errors.New("reserved disk space must not be negative")
+	}
+	r.setConfigInt(config.DiskSpaceReserved, input.DiskSpaceReserved)
+
+	if input.DiskSpaceWebhookURLs != nil {
+		for _, u := range input.DiskSpaceWebhookURLs {
+			if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return makeConfigGeneralResult(), fmt.Errorf("invalid disk space webhook URL: %s", u)
+			}
+		}
+		c.SetInterface(config.DiskSpaceWebhookURLs, input.DiskSpaceWebhookURLs)
+	}
+
+	r.setConfigBool(config.BackfillEnabled, input.BackfillEnabled)
+	r.setConfigBool(config.BackfillPhashes, input.BackfillPhashes)
+	r.setConfigBool(config.BackfillSprites, input.BackfillSprites)
+	r.setConfigBool(config.BackfillPreviews, input.BackfillPreviews)
+
+	if input.BackfillMaxCPUPercent != nil && (*input.BackfillMaxCPUPercent < 1 || *input.BackfillMaxCPUPercent > 100) {
+		return makeConfigGeneralResult(), errors.New("backfill max CPU percent must be between 1 and 100")
+	}
+	r.setConfigInt(config.BackfillMaxCPUPercent, input.BackfillMaxCPUPercent)
+
+	if input.BackfillMaxTemperature != nil && *input.BackfillMaxTemperature < 0 {
+		return makeConfigGeneralResult(), errors.New("backfill max temperature must not be negative")
+	}
+	r.setConfigInt(config.BackfillMaxTemperature, input.BackfillMaxTemperature)
+
+	if input.BackfillWindow != nil {
+		if _, err := manager.ParseTimeWindow(*input.BackfillWindow); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid backfill window: %w", err)
+		}
+		c.SetString(config.BackfillWindow, *input.BackfillWindow)
+	}
+
 	existingGeneratedPath := c.GetGeneratedPath()
 	if input.GeneratedPath != nil && existingGeneratedPath != *input.GeneratedPath {
 		if err := validateDir(config.Generated, *input.GeneratedPath, false); err != nil {
@@ -269,7 +344,18 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
+
+	if input.FingerprintAlgorithms != nil {
+		for _, a := range input.FingerprintAlgorithms {
+			if !hash.IsChecksumAlgorithm(a) {
+				return makeConfigGeneralResult(), fmt.Errorf("unsupported fingerprint algorithm %q", a)
+			}
+		}
+
+		c.SetInterface(config.FingerprintAlgorithms, input.FingerprintAlgorithms)
+	}
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
+	r.setConfigBool(config.LowMemoryMode, input.LowMemoryMode)
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
 	r.setConfigFloat(config.PreviewSegmentDuration, input.PreviewSegmentDuration)
@@ -280,6 +366,31 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.TranscodeHardwareAcceleration, input.TranscodeHardwareAcceleration)
+	r.setConfigBool(config.GenerateHardwareDecoding, input.GenerateHardwareDecoding)
+	r.setConfigBool(config.RemoteWorkersEnabled, input.RemoteWorkersEnabled)
+
+	if input.RemoteWorkerServerURL != nil && *input.RemoteWorkerServerURL != "" {
+		u := *input.RemoteWorkerServerURL
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid remote worker server URL: %s", u)
+		}
+	}
+	r.setConfigString(config.RemoteWorkerServerURL, input.RemoteWorkerServerURL)
+	r.setConfigString(config.RemoteWorkerAPIKey, input.RemoteWorkerAPIKey)
+
+	if input.TranscribeAPIURL != nil && *input.TranscribeAPIURL != "" {
+		u := *input.TranscribeAPIURL
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid transcription API URL: %s", u)
+		}
+	}
+	r.setConfigString(config.TranscribeWhisperPath, input.TranscribeWhisperPath)
+	r.setConfigString(config.TranscribeWhisperModel, input.TranscribeWhisperModel)
+	r.setConfigString(config.TranscribeAPIURL, input.TranscribeAPIURL)
+	r.setConfigString(config.TranscribeAPIKey, input.TranscribeAPIKey)
+	r.setConfigString(config.TranscribeAPIModel, input.TranscribeAPIModel)
+	r.setConfigString(config.TranscribeLanguage, input.TranscribeLanguage)
+
 	if input.MaxTranscodeSize != nil {
 		c.SetString(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
@@ -288,6 +399,11 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
 	r.setConfigBool(config.WriteImageThumbnails, input.WriteImageThumbnails)
+
+	if input.ImageTransformCacheSize != nil && *input.ImageTransformCacheSize < 0 {
+		return makeConfigGeneralResult(), errors.New("image transform cache size must not be negative")
+	}
+	r.setConfigInt(config.ImageTransformCacheSize, input.ImageTransformCacheSize)
 	r.setConfigBool(config.CreateImageClipsFromVideos, input.CreateImageClipsFromVideos)
 
 	if input.GalleryCoverRegex != nil {
@@ -299,6 +415,16 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.GalleryCoverRegex, *input.GalleryCoverRegex)
 	}
 
+	refreshSceneTitles := false
+	if input.SceneTitleTemplate != nil && *input.SceneTitleTemplate != c.GetSceneTitleTemplate() {
+		if _, err := models.ParseTitleTemplate(*input.SceneTitleTemplate); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetString(config.SceneTitleTemplate, *input.SceneTitleTemplate)
+		refreshSceneTitles = true
+	}
+
 	if input.Username != nil && *input.Username != c.GetUsername() {
 		c.SetString(config.Username, *input.Username)
 		if *input.Password == "" {
@@ -324,6 +450,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigInt(config.MaxSessionAge, input.MaxSessionAge)
+	r.setConfigBool(config.SQLQueryEnabled, input.SQLQueryEnabled)
 	r.setConfigString(config.LogFile, input.LogFile)
 	r.setConfigBool(config.LogOut, input.LogOut)
 	r.setConfigBool(config.LogAccess, input.LogAccess)
@@ -354,6 +481,13 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetInterface(config.ImageExclude, input.ImageExcludes)
 	}
 
+	if input.CleanRules != nil {
+		if err := c.ValidateCleanRules(input.CleanRules); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		c.SetInterface(config.CleanRules, input.CleanRules)
+	}
+
 	if input.VideoExtensions != nil {
 		c.SetInterface(config.VideoExtensions, input.VideoExtensions)
 	}
@@ -367,6 +501,33 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
+	r.setConfigBool(config.ImportImageSidecars, input.ImportImageSidecars)
+
+	if input.ImageSidecarTagMappings != nil {
+		for _, m := range input.ImageSidecarTagMappings {
+			if !strings.Contains(m, "=") {
+				return makeConfigGeneralResult(), fmt.Errorf("sidecar tag mapping '%v' invalid: must be <namespace>=<tag name>", m)
+			}
+		}
+
+		c.SetInterface(config.ImageSidecarTagMappings, input.ImageSidecarTagMappings)
+	}
+
+	if input.ScenePrimaryFileRules != nil {
+		if _, err := scene.ParsePrimaryFileRules(input.ScenePrimaryFileRules); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetInterface(config.ScenePrimaryFileRules, input.ScenePrimaryFileRules)
+	}
+
+	if input.PhashOptions != nil {
+		if err := input.PhashOptions.Validate(); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("phash options invalid: %w", err)
+		}
+
+		c.SetInterface(config.PhashOptions, input.PhashOptions)
+	}
 
 	if input.CustomPerformerImageLocation != nil {
 		c.SetString(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
@@ -380,6 +541,13 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetInterface(config.StashBoxes, input.StashBoxes)
 	}
 
+	if input.FederatedInstances != nil {
+		if err := c.ValidateFederatedInstances(input.FederatedInstances); err != nil {
+			return nil, err
+		}
+		c.SetInterface(config.FederatedInstances, input.FederatedInstances)
+	}
+
 	if input.PythonPath != nil {
 		r.setConfigString(config.PythonPath, input.PythonPath)
 	}
@@ -438,6 +606,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshSceneTitles {
+		manager.GetInstance().SetSceneTitleTemplate()
+		manager.GetInstance().RefreshSceneTitles(ctx)
+	}
 
 	return makeConfigGeneralResult(), nil
 }
@@ -527,6 +699,7 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 	}
 
 	r.setConfigString(config.DLNAVideoSortOrder, input.VideoSortOrder)
+	r.setConfigBool(config.DLNAServePreviews, input.ServePreviews)
 	r.setConfigInt(config.DLNAPort, input.Port)
 
 	refresh := false
@@ -535,14 +708,32 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 		refresh = true
 	}
 
+	// changes to the network settings require the service to be restarted
+	restart := input.Port != nil || input.Interfaces != nil || input.Ipv6 != nil || input.InterfaceSettings != nil
+
 	if input.Interfaces != nil {
 		c.SetInterface(config.DLNAInterfaces, input.Interfaces)
 	}
 
+	r.setConfigBool(config.DLNAIPv6, input.Ipv6)
+
+	if input.InterfaceSettings != nil {
+		for i, setting := range input.InterfaceSettings {
+			if setting.Name == "" {
+				return makeConfigDLNAResult(), fmt.Errorf("interface setting %d: name is required", i)
+			}
+		}
+		c.SetInterface(config.DLNAInterfaceSettings, input.InterfaceSettings)
+	}
+
 	if err := c.Write(); err != nil {
 		return makeConfigDLNAResult(), err
 	}
 
+	if restart {
+		manager.GetInstance().RestartDLNA()
+	}
+
 	if refresh {
 		manager.GetInstance().RefreshDLNA()
 	}
@@ -574,8 +765,46 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 		c.SetInterface(config.ScraperExcludeTagPatterns, input.ExcludeTagPatterns)
 	}
 
+	if input.URLRoutes != nil {
+		for i, route := range input.URLRoutes {
+			if err := route.Validate(); err != nil {
+				return makeConfigScrapingResult(), fmt.Errorf("url route %d: %w", i+1, err)
+			}
+			if route.IdentifyPreset != "" && c.GetIdentifyPreset(route.IdentifyPreset) == nil {
+				return makeConfigScrapingResult(), fmt.Errorf("url route %d: identify preset %q not found", i+1, route.IdentifyPreset)
+			}
+		}
+		c.SetInterface(config.ScraperURLRoutes, input.URLRoutes)
+	}
+
+	if input.Proxies != nil {
+		for i, proxy := range input.Proxies {
+			if err := proxy.Validate(); err != nil {
+				return makeConfigScrapingResult(), fmt.Errorf("proxy %d: %w", i+1, err)
+			}
+		}
+		c.SetInterface(config.ScraperProxies, input.Proxies)
+		refreshScraperCache = true
+	}
+
+	if input.ProxyHealthCheckURL != nil {
+		if *input.ProxyHealthCheckURL != "" {
+			if _, err := url.ParseRequestURI(*input.ProxyHealthCheckURL); err != nil {
+				return makeConfigScrapingResult(), fmt.Errorf("proxy health check url invalid: %w", err)
+			}
+		}
+		c.SetString(config.ScraperProxyHealthCheckURL, *input.ProxyHealthCheckURL)
+		refreshScraperCache = true
+	}
+
 	r.setConfigBool(config.ScraperCertCheck, input.ScraperCertCheck)
 
+	if input.URLCheckInterval != nil && *input.URLCheckInterval < 0 {
+		return makeConfigScrapingResult(), errors.New("url check interval must not be negative")
+	}
+	r.setConfigInt(config.URLCheckInterval, input.URLCheckInterval)
+	r.setConfigBool(config.URLCheckRescrape, input.URLCheckRescrape)
+
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
@@ -586,6 +815,42 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 	return makeConfigScrapingResult(), nil
 }
 
+func (r *mutationResolver) ConfigureBackupTarget(ctx context.Context, input *backup.Target) (*backup.Target, error) {
+	c := config.GetInstance()
+
+	if input != nil {
+		if err := input.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+	}
+
+	c.SetInterface(config.BackupTarget, input)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return c.GetBackupTarget(), nil
+}
+
+func (r *mutationResolver) ConfigureInbox(ctx context.Context, input *organize.Inbox) (*organize.Inbox, error) {
+	c := config.GetInstance()
+
+	if input != nil {
+		if err := manager.ValidateInbox(*input); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+	}
+
+	c.SetInterface(config.Inbox, input)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return c.GetInbox(), nil
+}
+
 func (r *mutationResolver) ConfigureDefaults(ctx context.Context, input ConfigDefaultSettingsInput) (*ConfigDefaultSettingsResult, error) {
 	c := config.GetInstance()
 
@@ -593,6 +858,22 @@ func (r *mutationResolver) ConfigureDefaults(ctx context.Context, input ConfigDe
 		c.SetInterface(config.DefaultIdentifySettings, input.Identify)
 	}
 
+	if input.IdentifyPresets != nil {
+		names := make(map[string]bool)
+		for _, p := range input.IdentifyPresets {
+			p.Name = strings.TrimSpace(p.Name)
+			if p.Name == "" {
+				return makeConfigDefaultsResult(), errors.New("identify preset name must not be empty")
+			}
+			if names[p.Name] {
+				return makeConfigDefaultsResult(), fmt.Errorf("duplicate identify preset name %q", p.Name)
+			}
+			names[p.Name] = true
+		}
+
+		c.SetInterface(config.IdentifyPresets, input.IdentifyPresets)
+	}
+
 	if input.Scan != nil {
 		// if input.Scan is used then ScanMetadataOptions is included in the config file
 		// this causes the values to not be read correctly
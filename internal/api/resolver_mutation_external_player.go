@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) ExternalPlayerLink(ctx context.Context, sceneID string, expiresIn *int) (*ExternalPlayerLink, error) {
+	id, err := strconv.Atoi(sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	duration := manager.DefaultExternalPlayerTokenDuration
+	if expiresIn != nil {
+		duration = time.Duration(*expiresIn) * time.Second
+		if duration <= 0 {
+			return nil, errors.New("expires_in must be greater than zero")
+		}
+		if duration > manager.MaxExternalPlayerTokenDuration {
+			duration = manager.MaxExternalPlayerTokenDuration
+		}
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.repository.Scene.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", id)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// tokens are only accurate to the second
+	expiresAt := time.Now().Add(duration).Truncate(time.Second)
+	token, err := manager.GenerateExternalPlayerToken(id, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	builder := urlbuilders.NewExternalPlayerURLBuilder(baseURL, token)
+
+	return &ExternalPlayerLink{
+		StreamURL:   builder.GetStreamURL(),
+		PlaylistURL: builder.GetPlaylistURL(),
+		ProgressURL: builder.GetProgressURL(),
+		VlcURL:      "vlc://" + builder.GetPlaylistURL(),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
@@ -14,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -47,10 +49,18 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 	newGallery.Code = translator.string(input.Code)
 	newGallery.Details = translator.string(input.Details)
 	newGallery.Photographer = translator.string(input.Photographer)
+	newGallery.Country = translator.string(input.Country)
+	newGallery.City = translator.string(input.City)
+	newGallery.Latitude = input.Latitude
+	newGallery.Longitude = input.Longitude
 	newGallery.Rating = input.Rating100
 
 	var err error
 
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
 	newGallery.Date, err = translator.datePtr(input.Date)
 	if err != nil {
 		return nil, fmt.Errorf("converting date: %w", err)
@@ -73,9 +83,15 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 		return nil, fmt.Errorf("converting scene ids: %w", err)
 	}
 
-	if input.Urls != nil {
+	var urlTypes map[string]*models.URLType
+	switch {
+	case input.TypedUrls != nil:
+		var urls []string
+		urls, urlTypes = models.TypedURLs(sliceutil.PtrsToValues(input.TypedUrls))
+		newGallery.URLs = models.NewRelatedStrings(urls)
+	case input.Urls != nil:
 		newGallery.URLs = models.NewRelatedStrings(input.Urls)
-	} else if input.URL != nil {
+	case input.URL != nil:
 		newGallery.URLs = models.NewRelatedStrings([]string{*input.URL})
 	}
 
@@ -86,6 +102,12 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
+		if urlTypes != nil {
+			if err := qb.SetURLTypes(ctx, newGallery.ID, urlTypes); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -173,6 +195,10 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, fmt.Errorf("gallery with id %d not found", galleryID)
 	}
 
+	if err := checkConflict(input.ExpectedUpdatedAt, originalGallery.UpdatedAt); err != nil {
+		return nil, err
+	}
+
 	// Populate gallery from the input
 	updatedGallery := models.NewGalleryPartial()
 
@@ -188,8 +214,17 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	updatedGallery.Code = translator.optionalString(input.Code, "code")
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
 	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedGallery.Country = translator.optionalString(input.Country, "country")
+	updatedGallery.City = translator.optionalString(input.City, "city")
+	updatedGallery.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedGallery.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
 	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedGallery.Archived = translator.optionalBool(input.Archived, "archived")
+
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
 
 	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
@@ -201,6 +236,11 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	}
 
 	updatedGallery.URLs = translator.optionalURLs(input.Urls, input.URL)
+	// typed_urls takes precedence over urls and url
+	typedURLs, urlTypes := translator.optionalTypedURLs(input.TypedUrls)
+	if typedURLs != nil {
+		updatedGallery.URLs = typedURLs
+	}
 
 	updatedGallery.PrimaryFileID, err = translator.fileIDPtrFromString(input.PrimaryFileID)
 	if err != nil {
@@ -246,6 +286,12 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	if urlTypes != nil {
+		if err := qb.SetURLTypes(ctx, galleryID, urlTypes); err != nil {
+			return nil, err
+		}
+	}
+
 	return gallery, nil
 }
 
@@ -259,72 +305,139 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	updatedGallery, err := galleryPartialFromBulkGalleryUpdateInput(translator, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*models.Gallery{}
+
+	// Start the transaction and save the galleries
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.bulkGalleryUpdate(ctx, galleryIDs, updatedGallery)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	var newRet []*models.Gallery
+	for _, gallery := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, input, translator.getFields())
+
+		gallery, err := r.getGallery(ctx, gallery.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, gallery)
+	}
+
+	return newRet, nil
+}
+
+func galleryPartialFromBulkGalleryUpdateInput(translator changesetTranslator, input BulkGalleryUpdateInput) (ret models.GalleryPartial, err error) {
 	// Populate gallery from the input
 	updatedGallery := models.NewGalleryPartial()
 
 	updatedGallery.Code = translator.optionalString(input.Code, "code")
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
 	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedGallery.Country = translator.optionalString(input.Country, "country")
+	updatedGallery.City = translator.optionalString(input.City, "city")
+	updatedGallery.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedGallery.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
 	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedGallery.Archived = translator.optionalBool(input.Archived, "archived")
 	updatedGallery.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
 
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		err = fmt.Errorf("%w: %v", ErrInput, err)
+		return
+	}
+
 	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
+		err = fmt.Errorf("converting date: %w", err)
+		return
 	}
 	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		err = fmt.Errorf("converting studio id: %w", err)
+		return
 	}
 
 	updatedGallery.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
+		err = fmt.Errorf("converting performer ids: %w", err)
+		return
 	}
 	updatedGallery.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+		err = fmt.Errorf("converting tag ids: %w", err)
+		return
 	}
 	updatedGallery.SceneIDs, err = translator.updateIdsBulk(input.SceneIds, "scene_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting scene ids: %w", err)
+		err = fmt.Errorf("converting scene ids: %w", err)
+		return
 	}
 
-	ret := []*models.Gallery{}
+	ret = updatedGallery
+	return
+}
+
+// bulkGalleryUpdate applies the partial update to the galleries. Must be
+// called within a transaction.
+func (r *mutationResolver) bulkGalleryUpdate(ctx context.Context, galleryIDs []int, updatedGallery models.GalleryPartial) ([]*models.Gallery, error) {
+	var ret []*models.Gallery
+	qb := r.repository.Gallery
+
+	for _, galleryID := range galleryIDs {
+		gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, gallery)
+	}
+
+	return ret, nil
+}
+
+// GalleriesArchive sets the archived status of the galleries.
+func (r *mutationResolver) GalleriesArchive(ctx context.Context, ids []string, archived bool) (bool, error) {
+	galleryIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	updatedGallery := models.NewGalleryPartial()
+	updatedGallery.Archived = models.NewOptionalBool(archived)
 
-	// Start the transaction and save the galleries
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Gallery
-
-		for _, galleryID := range galleryIDs {
-			gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
-			if err != nil {
-				return err
+		for _, id := range galleryIDs {
+			if _, err := qb.UpdatePartial(ctx, id, updatedGallery); err != nil {
+				return fmt.Errorf("updating gallery %d: %w", id, err)
 			}
-
-			ret = append(ret, gallery)
 		}
-
 		return nil
 	}); err != nil {
-		return nil, err
+		return false, err
 	}
 
 	// execute post hooks outside of txn
-	var newRet []*models.Gallery
-	for _, gallery := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, input, translator.getFields())
-
-		gallery, err := r.getGallery(ctx, gallery.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		newRet = append(newRet, gallery)
+	input := map[string]interface{}{
+		"ids":      ids,
+		"archived": archived,
+	}
+	for _, id := range galleryIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.GalleryUpdatePost, input, []string{"archived"})
 	}
 
-	return newRet, nil
+	return true, nil
 }
 
 func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.GalleryDestroyInput) (bool, error) {
@@ -610,6 +723,10 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 			return fmt.Errorf("gallery chapter with id %d not found", chapterID)
 		}
 
+		if err := checkConflict(input.ExpectedUpdatedAt, existingChapter.UpdatedAt); err != nil {
+			return err
+		}
+
 		galleryID := existingChapter.GalleryID
 		imageIndex := existingChapter.ImageIndex
 
@@ -672,3 +789,193 @@ func (r *mutationResolver) GalleryChapterDestroy(ctx context.Context, id string)
 
 	return true, nil
 }
+
+func (r *mutationResolver) GalleryAddO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.AddO(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryDeleteO(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.DeleteO(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryResetO(ctx context.Context, id string) (ret int, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		ret, err = qb.ResetO(ctx, galleryID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) GalleryAddView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.AddViews(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryDeleteView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		updatedTimes, err = qb.DeleteViews(ctx, galleryID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) GalleryResetViews(ctx context.Context, id string) (ret int, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+
+		ret, err = qb.DeleteAllViews(ctx, galleryID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) GallerySaveReadingProgress(ctx context.Context, id string, page int) (ret *models.GalleryReadingSession, err error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ended, err := r.galleryService.SaveReadingProgress(ctx, galleryID, page)
+		if err != nil || ended {
+			return err
+		}
+
+		ret, err = r.repository.Gallery.GetReadingSession(ctx, galleryID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) GalleryResetReadingProgress(ctx context.Context, id string) (bool, error) {
+	galleryID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.Gallery.DeleteReadingSession(ctx, galleryID)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
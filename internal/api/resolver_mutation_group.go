@@ -187,6 +187,19 @@ func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInp
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if input.ExpectedUpdatedAt != nil {
+			existing, err := r.repository.Group.Find(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("group with id %d not found", groupID)
+			}
+			if err := checkConflict(input.ExpectedUpdatedAt, existing.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
 		frontImage := group.ImageInput{
 			Image: frontimageData,
 			Set:   frontImageIncluded,
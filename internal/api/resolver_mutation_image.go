@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
@@ -104,6 +105,10 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 		return nil, fmt.Errorf("image with id %d not found", imageID)
 	}
 
+	if err := checkConflict(input.ExpectedUpdatedAt, i.UpdatedAt); err != nil {
+		return nil, err
+	}
+
 	// Populate image from the input
 	updatedImage := models.NewImagePartial()
 
@@ -111,8 +116,17 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 	updatedImage.Code = translator.optionalString(input.Code, "code")
 	updatedImage.Details = translator.optionalString(input.Details, "details")
 	updatedImage.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedImage.Country = translator.optionalString(input.Country, "country")
+	updatedImage.City = translator.optionalString(input.City, "city")
+	updatedImage.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedImage.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
 	updatedImage.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedImage.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedImage.Archived = translator.optionalBool(input.Archived, "archived")
+
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
 
 	updatedImage.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
@@ -203,6 +217,36 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	updatedImage, err := imagePartialFromBulkImageUpdateInput(translator, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start the transaction and save the images
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.bulkImageUpdate(ctx, imageIDs, updatedImage)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	var newRet []*models.Image
+	for _, image := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, input, translator.getFields())
+
+		image, err = r.getImage(ctx, image.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, image)
+	}
+
+	return newRet, nil
+}
+
+func imagePartialFromBulkImageUpdateInput(translator changesetTranslator, input BulkImageUpdateInput) (ret models.ImagePartial, err error) {
 	// Populate image from the input
 	updatedImage := models.NewImagePartial()
 
@@ -210,96 +254,133 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 	updatedImage.Code = translator.optionalString(input.Code, "code")
 	updatedImage.Details = translator.optionalString(input.Details, "details")
 	updatedImage.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedImage.Country = translator.optionalString(input.Country, "country")
+	updatedImage.City = translator.optionalString(input.City, "city")
+	updatedImage.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedImage.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
 	updatedImage.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedImage.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedImage.Archived = translator.optionalBool(input.Archived, "archived")
+
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		err = fmt.Errorf("%w: %v", ErrInput, err)
+		return
+	}
 
 	updatedImage.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
+		err = fmt.Errorf("converting date: %w", err)
+		return
 	}
 	updatedImage.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		err = fmt.Errorf("converting studio id: %w", err)
+		return
 	}
 
 	updatedImage.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
 
 	updatedImage.GalleryIDs, err = translator.updateIdsBulk(input.GalleryIds, "gallery_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting gallery ids: %w", err)
+		err = fmt.Errorf("converting gallery ids: %w", err)
+		return
 	}
 	updatedImage.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
+		err = fmt.Errorf("converting performer ids: %w", err)
+		return
 	}
 	updatedImage.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+		err = fmt.Errorf("converting tag ids: %w", err)
+		return
 	}
 
-	// Start the transaction and save the images
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		var updatedGalleryIDs []int
-		qb := r.repository.Image
-
-		for _, imageID := range imageIDs {
-			i, err := r.repository.Image.Find(ctx, imageID)
-			if err != nil {
-				return err
-			}
+	ret = updatedImage
+	return
+}
 
-			if i == nil {
-				return fmt.Errorf("image with id %d not found", imageID)
-			}
+// bulkImageUpdate applies the partial update to the images. Must be called
+// within a transaction.
+func (r *mutationResolver) bulkImageUpdate(ctx context.Context, imageIDs []int, updatedImage models.ImagePartial) ([]*models.Image, error) {
+	var ret []*models.Image
+	var updatedGalleryIDs []int
+	qb := r.repository.Image
 
-			if updatedImage.GalleryIDs != nil {
-				// ensure gallery IDs are loaded
-				if err := i.LoadGalleryIDs(ctx, r.repository.Image); err != nil {
-					return err
-				}
+	for _, imageID := range imageIDs {
+		i, err := r.repository.Image.Find(ctx, imageID)
+		if err != nil {
+			return nil, err
+		}
 
-				if err := r.galleryService.ValidateImageGalleryChange(ctx, i, *updatedImage.GalleryIDs); err != nil {
-					return err
-				}
+		if i == nil {
+			return nil, fmt.Errorf("image with id %d not found", imageID)
+		}
 
-				thisUpdatedGalleryIDs := updatedImage.GalleryIDs.ImpactedIDs(i.GalleryIDs.List())
-				updatedGalleryIDs = sliceutil.AppendUniques(updatedGalleryIDs, thisUpdatedGalleryIDs)
+		if updatedImage.GalleryIDs != nil {
+			// ensure gallery IDs are loaded
+			if err := i.LoadGalleryIDs(ctx, r.repository.Image); err != nil {
+				return nil, err
 			}
 
-			image, err := qb.UpdatePartial(ctx, imageID, updatedImage)
-			if err != nil {
-				return err
+			if err := r.galleryService.ValidateImageGalleryChange(ctx, i, *updatedImage.GalleryIDs); err != nil {
+				return nil, err
 			}
 
-			ret = append(ret, image)
+			thisUpdatedGalleryIDs := updatedImage.GalleryIDs.ImpactedIDs(i.GalleryIDs.List())
+			updatedGalleryIDs = sliceutil.AppendUniques(updatedGalleryIDs, thisUpdatedGalleryIDs)
 		}
 
-		// #3759 - update all impacted galleries
-		for _, galleryID := range updatedGalleryIDs {
-			if err := r.galleryService.Updated(ctx, galleryID); err != nil {
-				return fmt.Errorf("updating gallery %d: %w", galleryID, err)
-			}
+		image, err := qb.UpdatePartial(ctx, imageID, updatedImage)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
-	}); err != nil {
-		return nil, err
+		ret = append(ret, image)
 	}
 
-	// execute post hooks outside of txn
-	var newRet []*models.Image
-	for _, image := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, input, translator.getFields())
+	// #3759 - update all impacted galleries
+	for _, galleryID := range updatedGalleryIDs {
+		if err := r.galleryService.Updated(ctx, galleryID); err != nil {
+			return nil, fmt.Errorf("updating gallery %d: %w", galleryID, err)
+		}
+	}
 
-		image, err = r.getImage(ctx, image.ID)
-		if err != nil {
-			return nil, err
+	return ret, nil
+}
+
+// ImagesArchive sets the archived status of the images.
+func (r *mutationResolver) ImagesArchive(ctx context.Context, ids []string, archived bool) (bool, error) {
+	imageIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	updatedImage := models.NewImagePartial()
+	updatedImage.Archived = models.NewOptionalBool(archived)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+		for _, id := range imageIDs {
+			if _, err := qb.UpdatePartial(ctx, id, updatedImage); err != nil {
+				return fmt.Errorf("updating image %d: %w", id, err)
+			}
 		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
 
-		newRet = append(newRet, image)
+	// execute post hooks outside of txn
+	input := map[string]interface{}{
+		"ids":      ids,
+		"archived": archived,
+	}
+	for _, id := range imageIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.ImageUpdatePost, input, []string{"archived"})
 	}
 
-	return newRet, nil
+	return true, nil
 }
 
 func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageDestroyInput) (ret bool, err error) {
@@ -447,3 +528,80 @@ func (r *mutationResolver) ImageResetO(ctx context.Context, id string) (ret int,
 
 	return ret, nil
 }
+
+func (r *mutationResolver) ImageAddView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	// convert time to local time, so that sorting is consistent
+	for _, tt := range t {
+		times = append(times, tt.Local())
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.AddViews(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageDeleteView(ctx context.Context, id string, t []*time.Time) (*HistoryMutationResult, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var times []time.Time
+
+	for _, tt := range t {
+		times = append(times, *tt)
+	}
+
+	var updatedTimes []time.Time
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		updatedTimes, err = qb.DeleteViews(ctx, imageID, times)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &HistoryMutationResult{
+		Count:   len(updatedTimes),
+		History: sliceutil.ValuesToPtrs(updatedTimes),
+	}, nil
+}
+
+func (r *mutationResolver) ImageResetViews(ctx context.Context, id string) (ret int, err error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Image
+
+		ret, err = qb.DeleteAllViews(ctx, imageID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// validateImageRegionRefs ensures that the tag and performer of the region exist.
+func (r *mutationResolver) validateImageRegionRefs(ctx context.Context, region models.ImageRegion) error {
+	if region.TagID != nil {
+		t, err := r.repository.Tag.Find(ctx, *region.TagID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("tag with id %d not found", *region.TagID)
+		}
+	}
+
+	if region.PerformerID != nil {
+		p, err := r.repository.Performer.Find(ctx, *region.PerformerID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", *region.PerformerID)
+		}
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) ImageRegionCreate(ctx context.Context, input ImageRegionCreateInput) (*models.ImageRegion, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	imageID, err := strconv.Atoi(input.ImageID)
+	if err != nil {
+		return nil, fmt.Errorf("converting image id: %w", err)
+	}
+
+	newRegion := models.NewImageRegion()
+	newRegion.ImageID = imageID
+	newRegion.X = input.X
+	newRegion.Y = input.Y
+	newRegion.Width = input.Width
+	newRegion.Height = input.Height
+
+	newRegion.TagID, err = translator.intPtrFromString(input.TagID)
+	if err != nil {
+		return nil, fmt.Errorf("converting tag id: %w", err)
+	}
+	newRegion.PerformerID, err = translator.intPtrFromString(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	if err := image.ValidateRegion(newRegion); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		i, err := r.repository.Image.Find(ctx, imageID)
+		if err != nil {
+			return err
+		}
+		if i == nil {
+			return fmt.Errorf("image with id %d not found", imageID)
+		}
+
+		if err := r.validateImageRegionRefs(ctx, newRegion); err != nil {
+			return err
+		}
+
+		return r.repository.ImageRegion.Create(ctx, &newRegion)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newRegion, nil
+}
+
+func (r *mutationResolver) ImageRegionUpdate(ctx context.Context, input ImageRegionUpdateInput) (*models.ImageRegion, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedRegion := models.NewImageRegionPartial()
+	updatedRegion.X = translator.optionalFloat64(input.X, "x")
+	updatedRegion.Y = translator.optionalFloat64(input.Y, "y")
+	updatedRegion.Width = translator.optionalFloat64(input.Width, "width")
+	updatedRegion.Height = translator.optionalFloat64(input.Height, "height")
+
+	updatedRegion.TagID, err = translator.optionalIntFromString(input.TagID, "tag_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag id: %w", err)
+	}
+	updatedRegion.PerformerID, err = translator.optionalIntFromString(input.PerformerID, "performer_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	var ret *models.ImageRegion
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ImageRegion
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("image region with id %d not found", id)
+		}
+
+		// validate the region as it will be after the update
+		region := image.ApplyRegionPartial(*existing, updatedRegion)
+		if err := image.ValidateRegion(region); err != nil {
+			return fmt.Errorf("%w: %v", ErrInput, err)
+		}
+
+		if err := r.validateImageRegionRefs(ctx, region); err != nil {
+			return err
+		}
+
+		ret, err = qb.UpdatePartial(ctx, id, updatedRegion)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ImageRegionDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.ImageRegion.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+func (r *mutationResolver) AnalyzeLibraryHealth(ctx context.Context, input manager.AnalyzeLibraryHealthInput) (string, error) {
+	jobID, err := manager.GetInstance().AnalyzeLibraryHealth(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) KeepBestScenes(ctx context.Context, input KeepBestScenesInput) ([]string, error) {
+	var groups [][]int
+	for _, g := range input.Groups {
+		ids, err := stringslice.StringSliceToIntSlice(g)
+		if err != nil {
+			return nil, fmt.Errorf("converting ids: %w", err)
+		}
+		groups = append(groups, ids)
+	}
+
+	var deleted []*models.Scene
+	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        file.NewDeleter(),
+		FileNamingAlgo: fileNamingAlgo,
+		Paths:          manager.GetInstance().Paths,
+	}
+
+	deleteGenerated := utils.IsTrue(input.DeleteGenerated)
+	deleteFile := utils.IsTrue(input.DeleteFile)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		// a scene may appear in more than one group
+		destroyed := make(map[int]bool)
+
+		for _, ids := range groups {
+			var scenes []*models.Scene
+			for _, id := range ids {
+				if destroyed[id] {
+					continue
+				}
+
+				s, err := qb.Find(ctx, id)
+				if err != nil {
+					return err
+				}
+				if s == nil {
+					return fmt.Errorf("scene with id %d not found", id)
+				}
+
+				if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+					return fmt.Errorf("loading primary file for scene %d: %w", id, err)
+				}
+
+				scenes = append(scenes, s)
+			}
+
+			if len(scenes) < 2 {
+				continue
+			}
+
+			// rank using the current file data, rather than the data in the report
+			best := scene.NewHealthGroup(scenes).Scenes[0].SceneID
+
+			for _, s := range scenes {
+				if s.ID == best {
+					continue
+				}
+
+				// kill any running encoders
+				manager.KillRunningStreams(s, fileNamingAlgo)
+
+				if err := r.sceneService.Destroy(ctx, s, fileDeleter, deleteGenerated, deleteFile); err != nil {
+					return err
+				}
+
+				destroyed[s.ID] = true
+				deleted = append(deleted, s)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		fileDeleter.Rollback()
+		return nil, err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	ret := []string{}
+	for _, s := range deleted {
+		ret = append(ret, strconv.Itoa(s.ID))
+
+		// call post hook after performing the other actions
+		r.hookExecutor.ExecutePostHooks(ctx, s.ID, hook.SceneDestroyPost, plugin.ScenesDestroyInput{
+			ScenesDestroyInput: models.ScenesDestroyInput{
+				Ids:             []string{strconv.Itoa(s.ID)},
+				DeleteFile:      input.DeleteFile,
+				DeleteGenerated: input.DeleteGenerated,
+			},
+			Checksum: s.Checksum,
+			OSHash:   s.OSHash,
+			Path:     s.Path,
+		}, nil)
+	}
+
+	return ret, nil
+}
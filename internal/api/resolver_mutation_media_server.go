@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) ImportMediaServer(ctx context.Context, input manager.ImportMediaServerInput) (string, error) {
+	jobID, err := manager.GetInstance().ImportMediaServer(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
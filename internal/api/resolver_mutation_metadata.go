@@ -11,7 +11,9 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sqlite"
 )
 
 func (r *mutationResolver) MetadataScan(ctx context.Context, input manager.ScanMetadataInput) (string, error) {
@@ -88,14 +90,70 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
+	if input.Preset != nil && *input.Preset != "" {
+		preset := config.GetInstance().GetIdentifyPreset(*input.Preset)
+		if preset == nil {
+			return "", fmt.Errorf("identify preset %q not found", *input.Preset)
+		}
+
+		input = input.ApplyPreset(*preset)
+	}
+
 	t := manager.CreateIdentifyJob(input)
 	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
 
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) CheckURLs(ctx context.Context, input manager.CheckURLsInput) (string, error) {
+	jobID, err := manager.GetInstance().CheckURLs(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) GenerateFunscriptMarkers(ctx context.Context, input manager.FunscriptMarkersInput) (string, error) {
+	jobID, err := manager.GetInstance().FunscriptMarkers(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.CleanMetadataInput) (string, error) {
-	jobID := manager.GetInstance().Clean(ctx, input)
+	jobID, err := manager.GetInstance().Clean(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataCalculateFingerprints(ctx context.Context, input manager.CalculateFingerprintsInput) (string, error) {
+	jobID := manager.GetInstance().CalculateFingerprints(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ConsolidateFiles(ctx context.Context, input ConsolidateFilesInput) (string, error) {
+	var mode fsutil.LinkMode
+	switch input.Mode {
+	case FileLinkModeHardlink:
+		mode = fsutil.LinkModeHardlink
+	case FileLinkModeReflink:
+		mode = fsutil.LinkModeReflink
+	}
+
+	jobID, err := manager.GetInstance().ConsolidateFiles(ctx, manager.ConsolidateFilesInput{
+		Mode:   mode,
+		DryRun: input.DryRun,
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return strconv.Itoa(jobID), nil
 }
 
@@ -181,3 +239,35 @@ func (r *mutationResolver) OptimiseDatabase(ctx context.Context) (string, error)
 	jobID := manager.GetInstance().OptimiseDatabase(ctx)
 	return strconv.Itoa(jobID), nil
 }
+
+func (r *mutationResolver) DatabaseCheckpoint(ctx context.Context, mode *sqlite.CheckpointMode) (*sqlite.CheckpointResult, error) {
+	m := sqlite.CheckpointModePassive
+	if mode != nil {
+		m = *mode
+	}
+
+	return manager.GetInstance().Database.Checkpoint(ctx, m)
+}
+
+func (r *mutationResolver) DatabaseSnapshotBegin(ctx context.Context, timeout *int) (bool, error) {
+	const defaultSnapshotTimeout = 600
+
+	t := defaultSnapshotTimeout
+	if timeout != nil {
+		if *timeout <= 0 {
+			return false, fmt.Errorf("timeout must be greater than zero")
+		}
+		t = *timeout
+	}
+
+	// the snapshot outlives the request, so don't use the request context
+	if err := manager.GetInstance().Database.BeginSnapshot(context.Background(), time.Duration(t)*time.Second); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) DatabaseSnapshotEnd(ctx context.Context) (bool, error) {
+	return manager.GetInstance().Database.EndSnapshot()
+}
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -39,6 +41,28 @@ func (r *mutationResolver) MigrateBlobs(ctx context.Context, input MigrateBlobsI
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MigrateGeneratedLayout(ctx context.Context, input MigrateGeneratedLayoutInput) (string, error) {
+	mgr := manager.GetInstance()
+	if input.Layout == mgr.Paths.Generated.Layout {
+		return "", fmt.Errorf("%w: generated files already use the %s layout", ErrInput, input.Layout)
+	}
+
+	from := *mgr.Paths
+	to := paths.NewPaths(mgr.Config.GetGeneratedPath(), mgr.Config.GetBlobsPath(), input.Layout)
+
+	t := &task.MigrateGeneratedLayoutJob{
+		From:                     &from,
+		To:                       &to,
+		VideoFileNamingAlgorithm: mgr.Config.GetVideoFileNamingAlgorithm(),
+		TxnManager:               mgr.Repository.TxnManager,
+		SceneReader:              mgr.Repository.Scene,
+		LayoutSetter:             mgr,
+	}
+	jobID := mgr.JobManager.Add(ctx, "Migrating generated layout...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) Migrate(ctx context.Context, input manager.MigrateInput) (string, error) {
 	mgr := manager.GetInstance()
 	t := &task.MigrateJob{
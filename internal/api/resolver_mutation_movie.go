@@ -176,6 +176,20 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 	var group *models.Group
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
+
+		if input.ExpectedUpdatedAt != nil {
+			existing, err := qb.Find(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("group with id %d not found", groupID)
+			}
+			if err := checkConflict(input.ExpectedUpdatedAt, existing.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
 		group, err = qb.UpdatePartial(ctx, groupID, updatedGroup)
 		if err != nil {
 			return err
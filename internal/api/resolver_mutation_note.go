@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/note"
+)
+
+func (r *mutationResolver) NoteCreate(ctx context.Context, input NoteCreateInput) (*models.Note, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	sceneID, err := translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	performerID, err := translator.intPtrFromString(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	createInput := note.CreateInput{
+		SceneID:     sceneID,
+		PerformerID: performerID,
+		Author:      translator.string(input.Author),
+		Body:        input.Body,
+		Pinned:      translator.bool(input.Pinned),
+	}
+
+	var ret *models.Note
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		// ensure the target exists
+		if sceneID != nil {
+			s, err := r.repository.Scene.Find(ctx, *sceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", *sceneID)
+			}
+		}
+		if performerID != nil {
+			p, err := r.repository.Performer.Find(ctx, *performerID)
+			if err != nil {
+				return err
+			}
+			if p == nil {
+				return fmt.Errorf("performer with id %d not found", *performerID)
+			}
+		}
+
+		ret, err = note.Create(ctx, r.repository.Note, createInput)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) NoteUpdate(ctx context.Context, input NoteUpdateInput) (*models.Note, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedNote := models.NewNotePartial()
+	updatedNote.Author = translator.optionalString(input.Author, "author")
+	updatedNote.Body = translator.optionalString(input.Body, "body")
+	updatedNote.Pinned = translator.optionalBool(input.Pinned, "pinned")
+
+	if updatedNote.Body.Set {
+		if err := note.ValidateBody(updatedNote.Body.Value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+	}
+
+	var ret *models.Note
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Note
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("note with id %d not found", id)
+		}
+
+		ret, err = qb.UpdatePartial(ctx, id, updatedNote)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) NoteDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.Note.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) OrganizeFiles(ctx context.Context, input manager.OrganizeFilesInput) (string, error) {
+	jobID := manager.GetInstance().OrganizeFiles(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) OrganizeFilesRollback(ctx context.Context, journalID string) (string, error) {
+	// ensure the journal exists before queuing the job
+	if _, err := manager.GetOrganizeJournalStore().Find(journalID); err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().OrganizeRollback(ctx, journalID)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ProcessInbox(ctx context.Context) (string, error) {
+	jobID, err := manager.GetInstance().ProcessInbox(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ExportMarkerClips(ctx context.Context, input manager.ExportMarkerClipsInput) (string, error) {
+	jobID, err := manager.GetInstance().ExportMarkerClips(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) DownloadMarkerClips(ctx context.Context, input manager.DownloadMarkerClipsInput) (string, error) {
+	downloadHash, err := manager.GetInstance().DownloadMarkerClips(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+
+	// generate timestamp
+	suffix := time.Now().Format("20060102-150405")
+	return baseURL + "/downloads/" + downloadHash + "/markers" + suffix + ".zip", nil
+}
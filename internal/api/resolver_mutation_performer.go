@@ -75,6 +75,14 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		newPerformer.URLs.Add(input.Urls...)
 	}
 
+	// typed_urls takes precedence over the other url fields
+	var urlTypes map[string]*models.URLType
+	if input.TypedUrls != nil {
+		var urls []string
+		urls, urlTypes = models.TypedURLs(input.TypedUrls)
+		newPerformer.URLs = models.NewRelatedStrings(urls)
+	}
+
 	var err error
 
 	newPerformer.Birthdate, err = translator.datePtr(input.Birthdate)
@@ -119,6 +127,12 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 			return err
 		}
 
+		if urlTypes != nil {
+			if err := qb.SetURLTypes(ctx, newPerformer.ID, urlTypes); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(ctx, newPerformer.ID, imageData); err != nil {
@@ -126,7 +140,7 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 			}
 		}
 
-		return nil
+		return qb.SetFieldSources(ctx, newPerformer.ID, translator.manualFieldSources())
 	}); err != nil {
 		return nil, err
 	}
@@ -259,7 +273,17 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	updatedPerformer.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
 	updatedPerformer.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
-	if translator.hasField("urls") {
+	var urlTypes map[string]*models.URLType
+	switch {
+	case translator.hasField("typed_urls"):
+		// ensure url/twitter/instagram are not included in the input
+		if err := r.validateNoLegacyURLs(translator); err != nil {
+			return nil, err
+		}
+
+		// typed_urls takes precedence over urls
+		updatedPerformer.URLs, urlTypes = translator.optionalTypedURLs(input.TypedUrls)
+	case translator.hasField("urls"):
 		// ensure url/twitter/instagram are not included in the input
 		if err := r.validateNoLegacyURLs(translator); err != nil {
 			return nil, err
@@ -314,6 +338,19 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 
+		if input.ExpectedUpdatedAt != nil {
+			existing, err := qb.Find(ctx, performerID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("performer with id %d not found", performerID)
+			}
+			if err := checkConflict(input.ExpectedUpdatedAt, existing.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
 		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
 			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
 				return err
@@ -329,6 +366,12 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 			return err
 		}
 
+		if urlTypes != nil {
+			if err := qb.SetURLTypes(ctx, performerID, urlTypes); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if imageIncluded {
 			if err := qb.UpdateImage(ctx, performerID, imageData); err != nil {
@@ -336,7 +379,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 			}
 		}
 
-		return nil
+		return qb.SetFieldSources(ctx, performerID, translator.manualFieldSources())
 	}); err != nil {
 		return nil, err
 	}
@@ -417,6 +460,7 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	}
 
 	ret := []*models.Performer{}
+	fieldSources := translator.manualFieldSources()
 
 	// Start the transaction and save the performers
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -438,6 +482,10 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 				return err
 			}
 
+			if err := qb.SetFieldSources(ctx, performerID, fieldSources); err != nil {
+				return err
+			}
+
 			ret = append(ret, performer)
 		}
 
@@ -504,3 +552,42 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) PerformerMerge(ctx context.Context, input PerformerMergeInput) (*models.Performer, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	var p *models.Performer
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		var err error
+		p, err = qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", destination)
+		}
+
+		return qb.Merge(ctx, source, destination)
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, p.ID, hook.PerformerMergePost, input, nil)
+
+	return p, nil
+}
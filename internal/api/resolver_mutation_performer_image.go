@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// touchPerformer updates the updated_at time of the performer, so that
+// cached copies of its primary image are invalidated.
+func (r *mutationResolver) touchPerformer(ctx context.Context, performerID int) error {
+	_, err := r.repository.Performer.UpdatePartial(ctx, performerID, models.NewPerformerPartial())
+	return err
+}
+
+func (r *mutationResolver) PerformerImageAdd(ctx context.Context, input PerformerImageAddInput) (*models.PerformerImage, error) {
+	performerID, err := strconv.Atoi(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	imageData, err := utils.ProcessImageInput(ctx, input.Image)
+	if err != nil {
+		return nil, fmt.Errorf("processing image: %w", err)
+	}
+
+	source := ""
+	if input.Source != nil {
+		source = strings.TrimSpace(*input.Source)
+	} else if strings.HasPrefix(input.Image, "http://") || strings.HasPrefix(input.Image, "https://") {
+		source = input.Image
+	}
+
+	var ret *models.PerformerImage
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		p, err := qb.Find(ctx, performerID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", performerID)
+		}
+
+		ret, err = qb.AddImage(ctx, performerID, imageData, source)
+		if err != nil {
+			return err
+		}
+
+		if input.Primary != nil && *input.Primary && !ret.Primary {
+			if err := qb.SetPrimaryImage(ctx, ret.ID); err != nil {
+				return err
+			}
+			ret.Primary = true
+		}
+
+		return r.touchPerformer(ctx, performerID)
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, performerID, hook.PerformerUpdatePost, input, nil)
+	return ret, nil
+}
+
+// updatePerformerImage runs fn on the performer image with the given id,
+// returning the id of its performer.
+func (r *mutationResolver) updatePerformerImage(ctx context.Context, id string, fn func(ctx context.Context, image *models.PerformerImage) error) (int, error) {
+	imageID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("converting id: %w", err)
+	}
+
+	var performerID int
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		image, err := r.repository.Performer.FindImage(ctx, imageID)
+		if err != nil {
+			return err
+		}
+		if image == nil {
+			return fmt.Errorf("performer image with id %d not found", imageID)
+		}
+
+		performerID = image.PerformerID
+		if err := fn(ctx, image); err != nil {
+			return err
+		}
+
+		return r.touchPerformer(ctx, performerID)
+	}); err != nil {
+		return 0, err
+	}
+
+	return performerID, nil
+}
+
+func (r *mutationResolver) PerformerImageDestroy(ctx context.Context, id string) (bool, error) {
+	performerID, err := r.updatePerformerImage(ctx, id, func(ctx context.Context, image *models.PerformerImage) error {
+		return r.repository.Performer.RemoveImage(ctx, image.ID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, performerID, hook.PerformerUpdatePost, id, nil)
+	return true, nil
+}
+
+func (r *mutationResolver) PerformerImageSetPrimary(ctx context.Context, id string) (bool, error) {
+	performerID, err := r.updatePerformerImage(ctx, id, func(ctx context.Context, image *models.PerformerImage) error {
+		return r.repository.Performer.SetPrimaryImage(ctx, image.ID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, performerID, hook.PerformerUpdatePost, id, nil)
+	return true, nil
+}
+
+func (r *mutationResolver) PerformerImagesReorder(ctx context.Context, input PerformerImagesReorderInput) ([]*models.PerformerImage, error) {
+	performerID, err := strconv.Atoi(input.PerformerID)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer id: %w", err)
+	}
+
+	imageIDs, err := stringslice.StringSliceToIntSlice(input.ImageIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting image ids: %w", err)
+	}
+
+	var ret []*models.PerformerImage
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+		if err := qb.ReorderImages(ctx, performerID, imageIDs); err != nil {
+			return err
+		}
+
+		ret, err = qb.GetImages(ctx, performerID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, performerID, hook.PerformerUpdatePost, input, nil)
+	return ret, nil
+}
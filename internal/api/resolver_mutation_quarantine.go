@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) QuarantinedFilesRetry(ctx context.Context, ids []string) (string, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return "", fmt.Errorf("converting ids: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().RetryQuarantinedFiles(ctx, idInts)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) QuarantinedFilesIgnore(ctx context.Context, ids []string, ignored bool) (bool, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Quarantine
+
+		for _, id := range idInts {
+			partial := models.NewQuarantinedFilePartial()
+			partial.Ignored = models.NewOptionalBool(ignored)
+			if _, err := qb.UpdatePartial(ctx, id, partial); err != nil {
+				return fmt.Errorf("updating quarantined file %d: %w", id, err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) QuarantinedFilesDestroy(ctx context.Context, ids []string) (bool, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Quarantine
+
+		for _, id := range idInts {
+			if err := qb.Destroy(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
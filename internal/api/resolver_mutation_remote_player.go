@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/remoteplayer"
+)
+
+func (r *mutationResolver) RemotePlayerCommand(ctx context.Context, input RemotePlayerCommandInput) (bool, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	sceneID, err := translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	cmd := remoteplayer.Command{
+		Type:    input.Type,
+		SceneID: sceneID,
+		Time:    input.Time,
+	}
+
+	if err := cmd.Validate(); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	// ensure the scene exists before sending it to the player
+	if sceneID != nil {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			s, err := r.repository.Scene.Find(ctx, *sceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", *sceneID)
+			}
+			return nil
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	if err := manager.GetInstance().RemotePlayers.Send(input.PlayerID, currentUsername(ctx), cmd); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) RemotePlayerReportState(ctx context.Context, input RemotePlayerStateInput) (bool, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	sceneID, err := translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	state := remoteplayer.State{
+		SceneID: sceneID,
+		Time:    input.Time,
+		Paused:  input.Paused,
+	}
+
+	if err := manager.GetInstance().RemotePlayers.ReportState(input.PlayerID, currentUsername(ctx), state); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -27,6 +30,15 @@ func (r *mutationResolver) SaveFilter(ctx context.Context, input SaveFilterInput
 		id = &idv
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	folderID, err := translator.intPtrFromString(input.FolderID)
+	if err != nil {
+		return nil, fmt.Errorf("converting folder id: %w", err)
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.SavedFilter
 
@@ -36,6 +48,36 @@ func (r *mutationResolver) SaveFilter(ctx context.Context, input SaveFilterInput
 			FindFilter:   input.FindFilter,
 			ObjectFilter: input.ObjectFilter,
 			UIOptions:    input.UIOptions,
+			FolderID:     folderID,
+		}
+		if input.SortOrder != nil {
+			f.SortOrder = *input.SortOrder
+		}
+
+		if id != nil {
+			existing, err := qb.Find(ctx, *id)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("saved filter with id %d not found", *id)
+			}
+
+			// keep the organisation of the filter unless set
+			if !translator.hasField("folder_id") {
+				f.FolderID = existing.FolderID
+			}
+			if !translator.hasField("sort_order") {
+				f.SortOrder = existing.SortOrder
+			}
+			// the default filter of the old mode does not apply to the new one
+			f.IsDefault = existing.IsDefault && existing.Mode == f.Mode
+		}
+
+		if f.FolderID != nil {
+			if err := savedfilter.ValidateFolder(ctx, r.repository.SavedFilterFolder, f.Mode, *f.FolderID); err != nil {
+				return err
+			}
 		}
 
 		if id == nil {
@@ -69,6 +111,94 @@ func (r *mutationResolver) DestroySavedFilter(ctx context.Context, input Destroy
 	return true, nil
 }
 
+func (r *mutationResolver) MoveSavedFilters(ctx context.Context, input MoveSavedFiltersInput) (bool, error) {
+	ids, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	var folderID *int
+	if input.FolderID != nil {
+		idv, err := strconv.Atoi(*input.FolderID)
+		if err != nil {
+			return false, fmt.Errorf("converting folder id: %w", err)
+		}
+		folderID = &idv
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SavedFilter
+
+		if folderID != nil {
+			filters, err := qb.FindMany(ctx, ids, false)
+			if err != nil {
+				return err
+			}
+
+			for _, f := range filters {
+				if err := savedfilter.ValidateFolder(ctx, r.repository.SavedFilterFolder, f.Mode, *folderID); err != nil {
+					return fmt.Errorf("saved filter %q: %w", f.Name, err)
+				}
+			}
+		}
+
+		return qb.SetFolder(ctx, ids, folderID)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ReorderSavedFilters(ctx context.Context, ids []string) (bool, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SavedFilter.Reorder(ctx, idInts)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SetSavedFilterDefault(ctx context.Context, mode models.FilterMode, id *string) (bool, error) {
+	var idInt *int
+	if id != nil {
+		idv, err := strconv.Atoi(*id)
+		if err != nil {
+			return false, fmt.Errorf("converting id: %w", err)
+		}
+		idInt = &idv
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SavedFilter
+
+		if idInt != nil {
+			f, err := qb.Find(ctx, *idInt)
+			if err != nil {
+				return err
+			}
+			if f == nil {
+				return fmt.Errorf("saved filter with id %d not found", *idInt)
+			}
+			if f.Mode != mode {
+				return fmt.Errorf("saved filter %q is not a %s filter", f.Name, mode)
+			}
+		}
+
+		return qb.SetDefault(ctx, mode, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) SetDefaultFilter(ctx context.Context, input SetDefaultFilterInput) (bool, error) {
 	// deprecated - write to the config in the meantime
 	config := config.GetInstance()
@@ -117,3 +247,62 @@ func (r *mutationResolver) SetDefaultFilter(ctx context.Context, input SetDefaul
 
 	return true, nil
 }
+
+// currentUsername returns the name of the authenticated user, or an empty
+// string if authentication is not enabled.
+func currentUsername(ctx context.Context) string {
+	if u := session.GetCurrentUserID(ctx); u != nil {
+		return *u
+	}
+	return ""
+}
+
+func (r *mutationResolver) SaveDefaultFilter(ctx context.Context, input DefaultFilterInput) (*models.DefaultFilter, error) {
+	if input.DisplayMode != nil && *input.DisplayMode < 0 {
+		return nil, errors.New("display mode must not be negative")
+	}
+	if input.ZoomIndex != nil && *input.ZoomIndex < 0 {
+		return nil, errors.New("zoom index must not be negative")
+	}
+
+	f := models.NewDefaultFilter()
+	f.Username = currentUsername(ctx)
+	f.Mode = input.Mode
+	f.FindFilter = input.FindFilter
+	f.ObjectFilter = input.ObjectFilter
+	f.DisplayMode = input.DisplayMode
+	f.ZoomIndex = input.ZoomIndex
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.DefaultFilter.Set(ctx, &f)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *mutationResolver) DestroyDefaultFilter(ctx context.Context, mode models.FilterMode) (bool, error) {
+	username := currentUsername(ctx)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.DefaultFilter.Destroy(ctx, username, mode)
+	}); err != nil {
+		return false, err
+	}
+
+	// remove any filter previously stored in the UI configuration, so that it
+	// is not returned in its place
+	if legacy, _ := uiConfigDefaultFilter(mode); legacy != nil {
+		c := config.GetInstance()
+		m := utils.NestedMap(c.GetUIConfiguration())
+		m.Delete("defaultFilters." + strings.ToLower(mode.String()))
+		c.SetUIConfiguration(m)
+
+		if err := c.Write(); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
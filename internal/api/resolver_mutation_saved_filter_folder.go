@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) SavedFilterFolderCreate(ctx context.Context, input SavedFilterFolderCreateInput) (*models.SavedFilterFolder, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	newFolder := models.SavedFilterFolder{
+		Mode: input.Mode,
+		Name: strings.TrimSpace(input.Name),
+	}
+	if newFolder.Name == "" {
+		return nil, errors.New("name must be non-empty")
+	}
+	if input.SortOrder != nil {
+		newFolder.SortOrder = *input.SortOrder
+	}
+
+	var err error
+	newFolder.ParentID, err = translator.intPtrFromString(input.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("converting parent id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SavedFilterFolder
+
+		if newFolder.ParentID != nil {
+			if err := savedfilter.ValidateFolderParent(ctx, qb, 0, newFolder.Mode, *newFolder.ParentID); err != nil {
+				return err
+			}
+		}
+
+		return qb.Create(ctx, &newFolder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newFolder, nil
+}
+
+func (r *mutationResolver) SavedFilterFolderUpdate(ctx context.Context, input SavedFilterFolderUpdateInput) (*models.SavedFilterFolder, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedFolder := models.SavedFilterFolderPartial{
+		Name:      translator.optionalString(input.Name, "name"),
+		SortOrder: translator.optionalInt(input.SortOrder, "sort_order"),
+	}
+	updatedFolder.ParentID, err = translator.optionalIntFromString(input.ParentID, "parent_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting parent id: %w", err)
+	}
+
+	if updatedFolder.Name.Set {
+		updatedFolder.Name.Value = strings.TrimSpace(updatedFolder.Name.Value)
+		if updatedFolder.Name.Value == "" {
+			return nil, errors.New("name must be non-empty")
+		}
+	}
+
+	var ret *models.SavedFilterFolder
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SavedFilterFolder
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("saved filter folder with id %d not found", id)
+		}
+
+		if updatedFolder.ParentID.Set && !updatedFolder.ParentID.Null {
+			if err := savedfilter.ValidateFolderParent(ctx, qb, id, existing.Mode, updatedFolder.ParentID.Value); err != nil {
+				return err
+			}
+		}
+
+		ret, err = qb.UpdatePartial(ctx, id, updatedFolder)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SavedFilterFolderDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SavedFilterFolder.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ReorderSavedFilterFolders(ctx context.Context, ids []string) (bool, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.SavedFilterFolder.Reorder(ctx, idInts)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
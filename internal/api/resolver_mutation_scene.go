@@ -48,10 +48,34 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 	newScene.Code = translator.string(input.Code)
 	newScene.Details = translator.string(input.Details)
 	newScene.Director = translator.string(input.Director)
+	newScene.Country = translator.string(input.Country)
+	newScene.City = translator.string(input.City)
+	newScene.Latitude = input.Latitude
+	newScene.Longitude = input.Longitude
+	newScene.StreamStart = input.StreamStart
+	newScene.StreamEnd = input.StreamEnd
 	newScene.Rating = input.Rating100
 	newScene.Organized = translator.bool(input.Organized)
+	newScene.Pending = translator.bool(input.Pending)
 	newScene.StashIDs = models.NewRelatedStashIDs(models.StashIDInputs(input.StashIds).ToStashIDs())
 
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	if err = models.ValidateStreamTrim(input.StreamStart, input.StreamEnd); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	if newScene.Pending && len(fileIDs) > 0 {
+		return nil, fmt.Errorf("%w: pending scenes cannot have files", ErrInput)
+	}
+
+	pendingFingerprints, err := models.ToPendingFingerprints(input.PendingFingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
 	newScene.Date, err = translator.datePtr(input.Date)
 	if err != nil {
 		return nil, fmt.Errorf("converting date: %w", err)
@@ -61,9 +85,15 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 		return nil, fmt.Errorf("converting studio id: %w", err)
 	}
 
-	if input.Urls != nil {
+	var urlTypes map[string]*models.URLType
+	switch {
+	case input.TypedUrls != nil:
+		var urls []string
+		urls, urlTypes = models.TypedURLs(input.TypedUrls)
+		newScene.URLs = models.NewRelatedStrings(urls)
+	case input.Urls != nil:
 		newScene.URLs = models.NewRelatedStrings(input.Urls)
-	} else if input.URL != nil {
+	case input.URL != nil:
 		newScene.URLs = models.NewRelatedStrings([]string{*input.URL})
 	}
 
@@ -104,7 +134,33 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if input.Rating100 != nil {
+			if err := r.repository.Scene.SetRating(ctx, ret.ID, models.CurrentUsername(ctx), input.Rating100); err != nil {
+				return err
+			}
+		}
+
+		if urlTypes != nil {
+			if err := r.repository.Scene.SetURLTypes(ctx, ret.ID, urlTypes); err != nil {
+				return err
+			}
+		}
+
+		if len(pendingFingerprints) > 0 {
+			if err := r.repository.Scene.UpdatePendingFingerprints(ctx, ret.ID, pendingFingerprints); err != nil {
+				return err
+			}
+		}
+
+		if err := r.repository.Scene.SetFieldSources(ctx, ret.ID, translator.manualFieldSources()); err != nil {
+			return err
+		}
+
+		return r.applyStudioDefaults(ctx, ret, nil)
 	}); err != nil {
 		return nil, err
 	}
@@ -179,6 +235,12 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
+	updatedScene.Country = translator.optionalString(input.Country, "country")
+	updatedScene.City = translator.optionalString(input.City, "city")
+	updatedScene.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedScene.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
+	updatedScene.StreamStart = translator.optionalFloat64(input.StreamStart, "stream_start")
+	updatedScene.StreamEnd = translator.optionalFloat64(input.StreamEnd, "stream_end")
 	updatedScene.Rating = translator.optionalInt(input.Rating100, "rating100")
 
 	if input.OCounter != nil {
@@ -191,10 +253,20 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 
 	updatedScene.PlayDuration = translator.optionalFloat64(input.PlayDuration, "play_duration")
 	updatedScene.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedScene.Archived = translator.optionalBool(input.Archived, "archived")
+	updatedScene.Pending = translator.optionalBool(input.Pending, "pending")
 	updatedScene.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
 	var err error
 
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	if err = models.ValidateStreamTrim(input.StreamStart, input.StreamEnd); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
 	updatedScene.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
 		return nil, fmt.Errorf("converting date: %w", err)
@@ -205,6 +277,10 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	}
 
 	updatedScene.URLs = translator.optionalURLs(input.Urls, input.URL)
+	// typed_urls takes precedence over urls and url
+	if typedURLs, _ := translator.optionalTypedURLs(input.TypedUrls); typedURLs != nil {
+		updatedScene.URLs = typedURLs
+	}
 
 	updatedScene.PrimaryFileID, err = translator.fileIDPtrFromString(input.PrimaryFileID)
 	if err != nil {
@@ -256,6 +332,10 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, fmt.Errorf("scene with id %d not found", sceneID)
 	}
 
+	if err := checkConflict(input.ExpectedUpdatedAt, originalScene.UpdatedAt); err != nil {
+		return nil, err
+	}
+
 	// Populate scene from the input
 	updatedScene, err := scenePartialFromInput(input, translator)
 	if err != nil {
@@ -273,6 +353,24 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		}
 	}
 
+	if updatedScene.Pending.Set && updatedScene.Pending.Value {
+		if err := originalScene.LoadFiles(ctx, r.repository.Scene); err != nil {
+			return nil, err
+		}
+
+		if len(originalScene.Files.List()) > 0 {
+			return nil, fmt.Errorf("%w: scenes with files cannot be pending", ErrInput)
+		}
+	}
+
+	var pendingFingerprints []models.Fingerprint
+	if translator.hasField("pending_fingerprints") {
+		pendingFingerprints, err = models.ToPendingFingerprints(input.PendingFingerprints)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+	}
+
 	if updatedScene.PrimaryFileID != nil {
 		newPrimaryFileID := *updatedScene.PrimaryFileID
 
@@ -304,18 +402,70 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		}
 	}
 
+	// the rating is set per user, which updates the aggregate rating
+	rating := updatedScene.Rating
+	updatedScene.Rating = models.OptionalInt{}
+
 	scene, err := qb.UpdatePartial(ctx, sceneID, *updatedScene)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.setSceneRating(ctx, sceneID, rating); err != nil {
+		return nil, err
+	}
+
+	if _, urlTypes := translator.optionalTypedURLs(input.TypedUrls); urlTypes != nil {
+		if err := qb.SetURLTypes(ctx, sceneID, urlTypes); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.sceneUpdateCoverImage(ctx, scene, coverImageData); err != nil {
 		return nil, err
 	}
 
+	if translator.hasField("pending_fingerprints") {
+		if err := qb.UpdatePendingFingerprints(ctx, sceneID, pendingFingerprints); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := qb.SetFieldSources(ctx, sceneID, translator.manualFieldSources()); err != nil {
+		return nil, err
+	}
+
+	if err := r.applyStudioDefaults(ctx, scene, originalScene.StudioID); err != nil {
+		return nil, err
+	}
+
 	return scene, nil
 }
 
+// applyStudioDefaults applies the defaults of the studio of the scene if the
+// studio differs from the original studio.
+func (r *mutationResolver) applyStudioDefaults(ctx context.Context, s *models.Scene, originalStudioID *int) error {
+	if s.StudioID == nil || (originalStudioID != nil && *originalStudioID == *s.StudioID) {
+		return nil
+	}
+
+	if _, err := scene.ApplyStudioDefaults(ctx, r.repository.Scene, r.repository.Studio, s); err != nil {
+		return fmt.Errorf("applying studio defaults: %w", err)
+	}
+
+	return nil
+}
+
+// setSceneRating sets the rating of the scene by the current user, if the
+// rating is set.
+func (r *mutationResolver) setSceneRating(ctx context.Context, sceneID int, rating models.OptionalInt) error {
+	if !rating.Set {
+		return nil
+	}
+
+	return r.repository.Scene.SetRating(ctx, sceneID, models.CurrentUsername(ctx), rating.Ptr())
+}
+
 func (r *mutationResolver) sceneUpdateCoverImage(ctx context.Context, s *models.Scene, coverImageData []byte) error {
 	if len(coverImageData) > 0 {
 		qb := r.repository.Scene
@@ -339,6 +489,37 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	updatedScene, err := scenePartialFromBulkSceneUpdateInput(translator, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*models.Scene{}
+
+	// Start the transaction and save the scenes
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.bulkSceneUpdate(ctx, sceneIDs, updatedScene, translator.manualFieldSources())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	// execute post hooks outside of txn
+	var newRet []*models.Scene
+	for _, scene := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, input, translator.getFields())
+
+		scene, err = r.getScene(ctx, scene.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, scene)
+	}
+
+	return newRet, nil
+}
+
+func scenePartialFromBulkSceneUpdateInput(translator changesetTranslator, input BulkSceneUpdateInput) (ret models.ScenePartial, err error) {
 	// Populate scene from the input
 	updatedScene := models.NewScenePartial()
 
@@ -346,79 +527,145 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
+	updatedScene.Country = translator.optionalString(input.Country, "country")
+	updatedScene.City = translator.optionalString(input.City, "city")
+	updatedScene.Latitude = translator.optionalFloat64(input.Latitude, "latitude")
+	updatedScene.Longitude = translator.optionalFloat64(input.Longitude, "longitude")
 	updatedScene.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedScene.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedScene.Archived = translator.optionalBool(input.Archived, "archived")
+
+	if err = models.ValidateCoordinates(input.Latitude, input.Longitude); err != nil {
+		err = fmt.Errorf("%w: %v", ErrInput, err)
+		return
+	}
 
 	updatedScene.Date, err = translator.optionalDate(input.Date, "date")
 	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
+		err = fmt.Errorf("converting date: %w", err)
+		return
 	}
 	updatedScene.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		err = fmt.Errorf("converting studio id: %w", err)
+		return
 	}
 
 	updatedScene.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
 
 	updatedScene.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
+		err = fmt.Errorf("converting performer ids: %w", err)
+		return
 	}
 	updatedScene.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+		err = fmt.Errorf("converting tag ids: %w", err)
+		return
 	}
 	updatedScene.GalleryIDs, err = translator.updateIdsBulk(input.GalleryIds, "gallery_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting gallery ids: %w", err)
+		err = fmt.Errorf("converting gallery ids: %w", err)
+		return
 	}
 
 	if translator.hasField("group_ids") {
 		updatedScene.GroupIDs, err = translator.updateGroupIDsBulk(input.GroupIds, "group_ids")
 		if err != nil {
-			return nil, fmt.Errorf("converting group ids: %w", err)
+			err = fmt.Errorf("converting group ids: %w", err)
+			return
 		}
 	} else if translator.hasField("movie_ids") {
 		updatedScene.GroupIDs, err = translator.updateGroupIDsBulk(input.MovieIds, "movie_ids")
 		if err != nil {
-			return nil, fmt.Errorf("converting movie ids: %w", err)
+			err = fmt.Errorf("converting movie ids: %w", err)
+			return
 		}
 	}
 
-	ret := []*models.Scene{}
+	ret = updatedScene
+	return
+}
 
-	// Start the transaction and save the scenes
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+// bulkSceneUpdate applies the partial update to the scenes. Must be called
+// within a transaction.
+func (r *mutationResolver) bulkSceneUpdate(ctx context.Context, sceneIDs []int, updatedScene models.ScenePartial, fieldSources []*models.FieldSource) ([]*models.Scene, error) {
+	var ret []*models.Scene
+	qb := r.repository.Scene
 
-		for _, sceneID := range sceneIDs {
-			scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
+	// the rating is set per user, which updates the aggregate rating
+	rating := updatedScene.Rating
+	updatedScene.Rating = models.OptionalInt{}
+
+	for _, sceneID := range sceneIDs {
+		var originalStudioID *int
+		if updatedScene.StudioID.Set {
+			original, err := qb.Find(ctx, sceneID)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			if original != nil {
+				originalStudioID = original.StudioID
 			}
+		}
 
-			ret = append(ret, scene)
+		scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
-	}); err != nil {
-		return nil, err
+		if err := r.setSceneRating(ctx, sceneID, rating); err != nil {
+			return nil, err
+		}
+
+		if err := qb.SetFieldSources(ctx, sceneID, fieldSources); err != nil {
+			return nil, err
+		}
+
+		if updatedScene.StudioID.Set {
+			if err := r.applyStudioDefaults(ctx, scene, originalStudioID); err != nil {
+				return nil, err
+			}
+		}
+
+		ret = append(ret, scene)
 	}
 
-	// execute post hooks outside of txn
-	var newRet []*models.Scene
-	for _, scene := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, input, translator.getFields())
+	return ret, nil
+}
 
-		scene, err = r.getScene(ctx, scene.ID)
-		if err != nil {
-			return nil, err
+// ScenesArchive sets the archived status of the scenes.
+func (r *mutationResolver) ScenesArchive(ctx context.Context, ids []string, archived bool) (bool, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	updatedScene := models.NewScenePartial()
+	updatedScene.Archived = models.NewOptionalBool(archived)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+		for _, id := range sceneIDs {
+			if _, err := qb.UpdatePartial(ctx, id, updatedScene); err != nil {
+				return fmt.Errorf("updating scene %d: %w", id, err)
+			}
 		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
 
-		newRet = append(newRet, scene)
+	// execute post hooks outside of txn
+	input := map[string]interface{}{
+		"ids":      ids,
+		"archived": archived,
+	}
+	for _, id := range sceneIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.SceneUpdatePost, input, []string{"archived"})
 	}
 
-	return newRet, nil
+	return true, nil
 }
 
 func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneDestroyInput) (bool, error) {
@@ -652,9 +899,15 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 
 	newMarker.Title = input.Title
 	newMarker.Seconds = input.Seconds
+	newMarker.Rating = input.Rating100
+	newMarker.CoverSeconds = input.CoverSeconds
 	newMarker.PrimaryTagID = primaryTagID
 	newMarker.SceneID = sceneID
 
+	if input.Details != nil {
+		newMarker.Details = *input.Details
+	}
+
 	if input.EndSeconds != nil {
 		if err := validateSceneMarkerEndSeconds(newMarker.Seconds, *input.EndSeconds); err != nil {
 			return nil, err
@@ -667,6 +920,11 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	performerIDs, err := stringslice.StringSliceToIntSlice(input.PerformerIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer ids: %w", err)
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.SceneMarker
 
@@ -675,6 +933,10 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 			return err
 		}
 
+		if err := qb.UpdatePerformers(ctx, newMarker.ID, performerIDs); err != nil {
+			return err
+		}
+
 		// Save the marker tags
 		// If this tag is the primary tag, then let's not add it.
 		tagIDs = sliceutil.Exclude(tagIDs, []int{newMarker.PrimaryTagID})
@@ -710,6 +972,9 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	updatedMarker.Title = translator.optionalString(input.Title, "title")
 	updatedMarker.Seconds = translator.optionalFloat64(input.Seconds, "seconds")
 	updatedMarker.EndSeconds = translator.optionalFloat64(input.EndSeconds, "end_seconds")
+	updatedMarker.Details = translator.optionalString(input.Details, "details")
+	updatedMarker.Rating = translator.optionalInt(input.Rating100, "rating100")
+	updatedMarker.CoverSeconds = translator.optionalFloat64(input.CoverSeconds, "cover_seconds")
 	updatedMarker.SceneID, err = translator.optionalIntFromString(input.SceneID, "scene_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -728,6 +993,15 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		}
 	}
 
+	var performerIDs []int
+	performerIdsIncluded := translator.hasField("performer_ids")
+	if input.PerformerIds != nil {
+		performerIDs, err = stringslice.StringSliceToIntSlice(input.PerformerIds)
+		if err != nil {
+			return nil, fmt.Errorf("converting performer ids: %w", err)
+		}
+	}
+
 	mgr := manager.GetInstance()
 
 	fileDeleter := &scene.FileDeleter{
@@ -750,6 +1024,10 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return fmt.Errorf("scene marker with id %d not found", markerID)
 		}
 
+		if err := checkConflict(input.ExpectedUpdatedAt, existingMarker.UpdatedAt); err != nil {
+			return err
+		}
+
 		// Validate end_seconds
 		shouldValidateEndSeconds := (updatedMarker.Seconds.Set || updatedMarker.EndSeconds.Set) && !updatedMarker.EndSeconds.Null
 		if shouldValidateEndSeconds {
@@ -783,14 +1061,20 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return fmt.Errorf("scene with id %d not found", existingMarker.SceneID)
 		}
 
-		// remove the marker preview if the scene changed or if the timestamp was changed
-		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds || existingMarker.EndSeconds != newMarker.EndSeconds {
+		// remove the marker preview if the scene changed or if the timestamp or cover frame was changed
+		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds || existingMarker.EndSeconds != newMarker.EndSeconds || existingMarker.ScreenshotSeconds() != newMarker.ScreenshotSeconds() {
 			seconds := int(existingMarker.Seconds)
 			if err := fileDeleter.MarkMarkerFiles(existingScene, seconds); err != nil {
 				return err
 			}
 		}
 
+		if performerIdsIncluded {
+			if err := qb.UpdatePerformers(ctx, markerID, performerIDs); err != nil {
+				return err
+			}
+		}
+
 		if tagIdsIncluded {
 			// Save the marker tags
 			// If this tag is the primary tag, then let's not add it.
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) SceneMarkerSuggestionsAccept(ctx context.Context, ids []string) ([]*models.SceneMarker, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, fmt.Errorf("converting ids: %w", err)
+	}
+
+	var ret []*models.SceneMarker
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Suggestion
+
+		suggestions, err := qb.FindMany(ctx, idInts)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range suggestions {
+			marker := s.SceneMarker()
+			if marker.EndSeconds != nil {
+				if err := validateSceneMarkerEndSeconds(marker.Seconds, *marker.EndSeconds); err != nil {
+					return err
+				}
+			}
+
+			if err := r.repository.SceneMarker.Create(ctx, &marker); err != nil {
+				return fmt.Errorf("creating marker: %w", err)
+			}
+
+			if err := qb.Destroy(ctx, s.ID); err != nil {
+				return err
+			}
+
+			ret = append(ret, &marker)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, m := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, m.ID, hook.SceneMarkerCreatePost, ids, nil)
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneMarkerSuggestionsDestroy(ctx context.Context, ids []string) (bool, error) {
+	idInts, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Suggestion
+
+		for _, id := range idInts {
+			if err := qb.Destroy(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sharelink"
+)
+
+func (r *mutationResolver) ShareLinkCreate(ctx context.Context, input ShareLinkCreateInput) (*models.ShareLink, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	sceneID, err := translator.intPtrFromString(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+	galleryID, err := translator.intPtrFromString(input.GalleryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery id: %w", err)
+	}
+
+	createInput := sharelink.CreateInput{
+		SceneID:   sceneID,
+		GalleryID: galleryID,
+		Password:  translator.string(input.Password),
+		ExpiresAt: input.ExpiresAt,
+		MaxViews:  input.MaxViews,
+	}
+	if input.Preview != nil {
+		createInput.Preview = *input.Preview
+	}
+
+	var ret *models.ShareLink
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		// ensure the target exists
+		if sceneID != nil {
+			s, err := r.repository.Scene.Find(ctx, *sceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", *sceneID)
+			}
+		}
+		if galleryID != nil {
+			g, err := r.repository.Gallery.Find(ctx, *galleryID)
+			if err != nil {
+				return err
+			}
+			if g == nil {
+				return fmt.Errorf("gallery with id %d not found", *galleryID)
+			}
+		}
+
+		ret, err = sharelink.Create(ctx, r.repository.ShareLink, createInput)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ShareLinkUpdate(ctx context.Context, input ShareLinkUpdateInput) (*models.ShareLink, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var ret *models.ShareLink
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ShareLink
+
+		if input.Preview == nil {
+			ret, err = qb.Find(ctx, id)
+			if err == nil && ret == nil {
+				err = fmt.Errorf("share link with id %d not found", id)
+			}
+			return err
+		}
+
+		ret, err = qb.UpdatePreview(ctx, id, *input.Preview)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ShareLinkDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.ShareLink.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
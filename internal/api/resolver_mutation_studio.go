@@ -33,7 +33,6 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	newStudio := models.NewStudio()
 
 	newStudio.Name = input.Name
-	newStudio.URL = translator.string(input.URL)
 	newStudio.Rating = input.Rating100
 	newStudio.Favorite = translator.bool(input.Favorite)
 	newStudio.Details = translator.string(input.Details)
@@ -41,6 +40,18 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	newStudio.Aliases = models.NewRelatedStrings(input.Aliases)
 	newStudio.StashIDs = models.NewRelatedStashIDs(models.StashIDInputs(input.StashIds).ToStashIDs())
 
+	var urlTypes map[string]*models.URLType
+	switch {
+	case input.TypedUrls != nil:
+		var urls []string
+		urls, urlTypes = models.TypedURLs(input.TypedUrls)
+		newStudio.URLs = models.NewRelatedStrings(urls)
+	case input.Urls != nil:
+		newStudio.URLs = models.NewRelatedStrings(input.Urls)
+	case input.URL != nil:
+		newStudio.URLs = models.NewRelatedStrings([]string{*input.URL})
+	}
+
 	var err error
 
 	newStudio.ParentID, err = translator.intPtrFromString(input.ParentID)
@@ -53,6 +64,17 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	newStudio.PropagateDefaults = translator.bool(input.PropagateDefaults)
+	newStudio.DefaultURLs = models.NewRelatedStrings(input.DefaultUrls)
+	if err := studio.ValidateDefaultURLs(input.DefaultUrls); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	newStudio.DefaultTagIDs, err = translator.relatedIds(input.DefaultTagIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting default tag ids: %w", err)
+	}
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	if input.Image != nil {
@@ -76,13 +98,19 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 			return err
 		}
 
+		if urlTypes != nil {
+			if err := qb.SetURLTypes(ctx, newStudio.ID, urlTypes); err != nil {
+				return err
+			}
+		}
+
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(ctx, newStudio.ID, imageData); err != nil {
 				return err
 			}
 		}
 
-		return nil
+		return qb.SetFieldSources(ctx, newStudio.ID, translator.manualFieldSources())
 	}); err != nil {
 		return nil, err
 	}
@@ -106,7 +134,7 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 
 	updatedStudio.ID = studioID
 	updatedStudio.Name = translator.optionalString(input.Name, "name")
-	updatedStudio.URL = translator.optionalString(input.URL, "url")
+	updatedStudio.URLs = translator.optionalURLs(input.Urls, input.URL)
 	updatedStudio.Details = translator.optionalString(input.Details, "details")
 	updatedStudio.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedStudio.Favorite = translator.optionalBool(input.Favorite, "favorite")
@@ -124,6 +152,23 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	// typed_urls takes precedence over urls and url
+	typedURLs, urlTypes := translator.optionalTypedURLs(input.TypedUrls)
+	if typedURLs != nil {
+		updatedStudio.URLs = typedURLs
+	}
+
+	updatedStudio.PropagateDefaults = translator.optionalBool(input.PropagateDefaults, "propagate_defaults")
+	updatedStudio.DefaultURLs = translator.updateStrings(input.DefaultUrls, "default_urls")
+	if err := studio.ValidateDefaultURLs(input.DefaultUrls); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInput, err)
+	}
+
+	updatedStudio.DefaultTagIDs, err = translator.updateIds(input.DefaultTagIds, "default_tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting default tag ids: %w", err)
+	}
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
@@ -139,6 +184,19 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 
+		if input.ExpectedUpdatedAt != nil {
+			existing, err := qb.Find(ctx, studioID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("studio with id %d not found", studioID)
+			}
+			if err := checkConflict(input.ExpectedUpdatedAt, existing.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
 		if err := studio.ValidateModify(ctx, updatedStudio, qb); err != nil {
 			return err
 		}
@@ -148,13 +206,19 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 			return err
 		}
 
+		if urlTypes != nil {
+			if err := qb.SetURLTypes(ctx, studioID, urlTypes); err != nil {
+				return err
+			}
+		}
+
 		if imageIncluded {
 			if err := qb.UpdateImage(ctx, studioID, imageData); err != nil {
 				return err
 			}
 		}
 
-		return nil
+		return qb.SetFieldSources(ctx, studioID, translator.manualFieldSources())
 	}); err != nil {
 		return nil, err
 	}
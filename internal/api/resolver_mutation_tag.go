@@ -37,9 +37,15 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 	newTag.Favorite = translator.bool(input.Favorite)
 	newTag.Description = translator.string(input.Description)
 	newTag.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
+	newTag.Restricted = translator.bool(input.Restricted)
 
 	var err error
 
+	newTag.CategoryID, err = translator.intPtrFromString(input.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("converting category id: %w", err)
+	}
+
 	newTag.ParentIDs, err = translator.relatedIds(input.ParentIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting parent tag ids: %w", err)
@@ -104,10 +110,16 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	updatedTag.Name = translator.optionalString(input.Name, "name")
 	updatedTag.Favorite = translator.optionalBool(input.Favorite, "favorite")
 	updatedTag.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+	updatedTag.Restricted = translator.optionalBool(input.Restricted, "restricted")
 	updatedTag.Description = translator.optionalString(input.Description, "description")
 
 	updatedTag.Aliases = translator.updateStrings(input.Aliases, "aliases")
 
+	updatedTag.CategoryID, err = translator.optionalIntFromString(input.CategoryID, "category_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting category id: %w", err)
+	}
+
 	updatedTag.ParentIDs, err = translator.updateIds(input.ParentIds, "parent_ids")
 	if err != nil {
 		return nil, fmt.Errorf("converting parent tag ids: %w", err)
@@ -132,6 +144,19 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
 
+		if input.ExpectedUpdatedAt != nil {
+			existing, err := qb.Find(ctx, tagID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return fmt.Errorf("tag with id %d not found", tagID)
+			}
+			if err := checkConflict(input.ExpectedUpdatedAt, existing.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
 		if err := tag.ValidateUpdate(ctx, tagID, updatedTag, qb); err != nil {
 			return err
 		}
@@ -173,9 +198,15 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 	updatedTag.Description = translator.optionalString(input.Description, "description")
 	updatedTag.Favorite = translator.optionalBool(input.Favorite, "favorite")
 	updatedTag.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+	updatedTag.Restricted = translator.optionalBool(input.Restricted, "restricted")
 
 	updatedTag.Aliases = translator.updateStringsBulk(input.Aliases, "aliases")
 
+	updatedTag.CategoryID, err = translator.optionalIntFromString(input.CategoryID, "category_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting category id: %w", err)
+	}
+
 	updatedTag.ParentIDs, err = translator.updateIdsBulk(input.ParentIds, "parent_ids")
 	if err != nil {
 		return nil, fmt.Errorf("converting parent tag ids: %w", err)
@@ -286,40 +317,51 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 
 	var t *models.Tag
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Tag
-
 		var err error
-		t, err = qb.Find(ctx, destination)
-		if err != nil {
-			return err
-		}
+		t, err = r.mergeTags(ctx, source, destination)
+		return err
+	}); err != nil {
+		return nil, err
+	}
 
-		if t == nil {
-			return fmt.Errorf("tag with id %d not found", destination)
-		}
+	r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagMergePost, input, nil)
 
-		parents, children, err := tag.MergeHierarchy(ctx, destination, source, qb)
-		if err != nil {
-			return err
-		}
+	return t, nil
+}
 
-		if err = qb.Merge(ctx, source, destination); err != nil {
-			return err
-		}
+func (r *mutationResolver) TagsMergeMany(ctx context.Context, input []*TagsMergeInput) ([]*models.Tag, error) {
+	type merge struct {
+		input       *TagsMergeInput
+		source      []int
+		destination int
+	}
 
-		err = qb.UpdateParentTags(ctx, destination, parents)
+	var merges []merge
+	for _, in := range input {
+		source, err := stringslice.StringSliceToIntSlice(in.Source)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("converting source ids: %w", err)
 		}
-		err = qb.UpdateChildTags(ctx, destination, children)
+
+		destination, err := strconv.Atoi(in.Destination)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("converting destination id: %w", err)
 		}
 
-		err = tag.ValidateHierarchyExisting(ctx, t, parents, children, qb)
-		if err != nil {
-			logger.Errorf("Error merging tag: %s", err)
-			return err
+		if len(source) > 0 {
+			merges = append(merges, merge{input: in, source: source, destination: destination})
+		}
+	}
+
+	var ret []*models.Tag
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		for _, m := range merges {
+			t, err := r.mergeTags(ctx, m.source, m.destination)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, t)
 		}
 
 		return nil
@@ -327,7 +369,50 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 		return nil, err
 	}
 
-	r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagMergePost, input, nil)
+	for i, t := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagMergePost, merges[i].input, nil)
+	}
+
+	return ret, nil
+}
+
+// mergeTags merges the source tags into the destination tag, combining their
+// hierarchies. It must be called within a transaction.
+func (r *mutationResolver) mergeTags(ctx context.Context, source []int, destination int) (*models.Tag, error) {
+	qb := r.repository.Tag
+
+	t, err := qb.Find(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("tag with id %d not found", destination)
+	}
+
+	parents, children, err := tag.MergeHierarchy(ctx, destination, source, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = qb.Merge(ctx, source, destination); err != nil {
+		return nil, err
+	}
+
+	err = qb.UpdateParentTags(ctx, destination, parents)
+	if err != nil {
+		return nil, err
+	}
+	err = qb.UpdateChildTags(ctx, destination, children)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tag.ValidateHierarchyExisting(ctx, t, parents, children, qb)
+	if err != nil {
+		logger.Errorf("Error merging tag: %s", err)
+		return nil, err
+	}
 
 	return t, nil
 }
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+func (r *mutationResolver) TagCategoryCreate(ctx context.Context, input TagCategoryCreateInput) (*models.TagCategory, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	newCategory := models.NewTagCategory()
+	newCategory.Name = strings.TrimSpace(input.Name)
+	newCategory.Description = translator.string(input.Description)
+	if input.SortOrder != nil {
+		newCategory.SortOrder = *input.SortOrder
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.TagCategory
+
+		if err := tag.EnsureCategoryNameUnique(ctx, 0, newCategory.Name, qb); err != nil {
+			return err
+		}
+
+		return qb.Create(ctx, &newCategory)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newCategory, nil
+}
+
+func (r *mutationResolver) TagCategoryUpdate(ctx context.Context, input TagCategoryUpdateInput) (*models.TagCategory, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedCategory := models.NewTagCategoryPartial()
+	updatedCategory.Name = translator.optionalString(input.Name, "name")
+	updatedCategory.Description = translator.optionalString(input.Description, "description")
+	updatedCategory.SortOrder = translator.optionalInt(input.SortOrder, "sort_order")
+
+	if updatedCategory.Name.Set {
+		updatedCategory.Name.Value = strings.TrimSpace(updatedCategory.Name.Value)
+	}
+
+	var ret *models.TagCategory
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.TagCategory
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("tag category with id %d not found", id)
+		}
+
+		if updatedCategory.Name.Set {
+			if err := tag.EnsureCategoryNameUnique(ctx, id, updatedCategory.Name.Value, qb); err != nil {
+				return err
+			}
+		}
+
+		ret, err = qb.UpdatePartial(ctx, id, updatedCategory)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) TagCategoryDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.TagCategory.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+func (r *mutationResolver) TwoFactorEnroll(ctx context.Context) (*TwoFactorEnrollment, error) {
+	enrollment, err := manager.GetInstance().TOTPService.Enroll(ctx, config.GetInstance().GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	}, nil
+}
+
+func (r *mutationResolver) TwoFactorConfirm(ctx context.Context, code string) ([]string, error) {
+	ret, err := manager.GetInstance().TOTPService.Confirm(ctx, config.GetInstance().GetUsername(), code)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Two-factor authentication enabled")
+	return ret, nil
+}
+
+func (r *mutationResolver) TwoFactorDisable(ctx context.Context, code string) (bool, error) {
+	if err := manager.GetInstance().TOTPService.Disable(ctx, config.GetInstance().GetUsername(), code); err != nil {
+		return false, err
+	}
+
+	logger.Info("Two-factor authentication disabled")
+	return true, nil
+}
+
+func (r *mutationResolver) TwoFactorRegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	return manager.GetInstance().TOTPService.RegenerateRecoveryCodes(ctx, config.GetInstance().GetUsername(), code)
+}
@@ -6,9 +6,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"golang.org/x/text/collate"
 )
@@ -60,13 +62,18 @@ func getParent(path string) *string {
 }
 
 func makeConfigResult() *ConfigResult {
+	c := config.GetInstance()
+
 	return &ConfigResult{
-		General:   makeConfigGeneralResult(),
-		Interface: makeConfigInterfaceResult(),
-		Dlna:      makeConfigDLNAResult(),
-		Scraping:  makeConfigScrapingResult(),
-		Defaults:  makeConfigDefaultsResult(),
-		UI:        makeConfigUIResult(),
+		SchemaVersion:   config.SchemaVersion,
+		Revision:        c.GetRevision(),
+		RestartRequired: c.GetRestartRequired(),
+		General:         makeConfigGeneralResult(),
+		Interface:       makeConfigInterfaceResult(),
+		Dlna:            makeConfigDLNAResult(),
+		Scraping:        makeConfigScrapingResult(),
+		Defaults:        makeConfigDefaultsResult(),
+		UI:              makeConfigUIResult(),
 	}
 }
 
@@ -78,11 +85,28 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 	maxStreamingTranscodeSize := config.GetMaxStreamingTranscodeSize()
 
 	customPerformerImageLocation := config.GetCustomPerformerImageLocation()
+	phashOptions := config.GetPhashOptions()
 
 	return &ConfigGeneralResult{
 		Stashes:                       config.GetStashPaths(),
 		DatabasePath:                  config.GetDatabasePath(),
 		BackupDirectoryPath:           config.GetBackupDirectoryPath(),
+		BackupInterval:                int(config.GetBackupInterval().Hours()),
+		BackupRetention:               config.GetBackupRetention(),
+		ReportsDirectoryPath:          config.GetReportsDirectoryPath(),
+		ReportRetention:               int(config.GetReportRetention().Hours() / 24),
+		DiskSpaceWarningPercent:       config.GetDiskSpaceWarningPercent(),
+		DiskSpaceReserved:             config.GetDiskSpaceReserved(),
+		DiskSpaceWebhookURLs:          config.GetDiskSpaceWebhookURLs(),
+		BackfillEnabled:               config.GetBackfillEnabled(),
+		BackfillPhashes:               config.GetBackfillPhashes(),
+		BackfillSprites:               config.GetBackfillSprites(),
+		BackfillPreviews:              config.GetBackfillPreviews(),
+		BackfillMaxCPUPercent:         config.GetBackfillMaxCPUPercent(),
+		BackfillMaxTemperature:        config.GetBackfillMaxTemperature(),
+		BackfillWindow:                config.GetBackfillWindow(),
+		BackupTarget:                  config.GetBackupTarget(),
+		Inbox:                         config.GetInbox(),
 		GeneratedPath:                 config.GetGeneratedPath(),
 		MetadataPath:                  config.GetMetadataPath(),
 		ConfigFilePath:                config.GetConfigFile(),
@@ -91,11 +115,14 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CachePath:                     config.GetCachePath(),
 		BlobsPath:                     config.GetBlobsPath(),
 		BlobsStorage:                  config.GetBlobsStorage(),
+		GeneratedLayout:               config.GetGeneratedLayout(),
 		FfmpegPath:                    config.GetFFMpegPath(),
 		FfprobePath:                   config.GetFFProbePath(),
 		CalculateMd5:                  config.IsCalculateMD5(),
+		FingerprintAlgorithms:         config.GetFingerprintAlgorithms(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		LowMemoryMode:                 config.GetLowMemoryMode(),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
@@ -103,15 +130,28 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		PreviewExcludeEnd:             config.GetPreviewExcludeEnd(),
 		PreviewPreset:                 config.GetPreviewPreset(),
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
+		GenerateHardwareDecoding:      config.GetGenerateHardwareDecoding(),
+		RemoteWorkersEnabled:          config.GetRemoteWorkersEnabled(),
+		RemoteWorkerServerURL:         config.GetRemoteWorkerServerURL(),
+		RemoteWorkerAPIKey:            config.GetRemoteWorkerAPIKey(),
+		TranscribeWhisperPath:         config.GetTranscribeWhisperPath(),
+		TranscribeWhisperModel:        config.GetTranscribeWhisperModel(),
+		TranscribeAPIURL:              config.GetTranscribeAPIURL(),
+		TranscribeAPIKey:              config.GetTranscribeAPIKey(),
+		TranscribeAPIModel:            config.GetTranscribeAPIModel(),
+		TranscribeLanguage:            config.GetTranscribeLanguage(),
 		MaxTranscodeSize:              &maxTranscodeSize,
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
+		ImageTransformCacheSize:       config.GetImageTransformCacheSize(),
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
 		GalleryCoverRegex:             config.GetGalleryCoverRegex(),
+		SceneTitleTemplate:            config.GetSceneTitleTemplate(),
 		APIKey:                        config.GetAPIKey(),
 		Username:                      config.GetUsername(),
 		Password:                      config.GetPasswordHash(),
 		MaxSessionAge:                 config.GetMaxSessionAge(),
+		SQLQueryEnabled:               config.IsSQLQueryEnabled(),
 		LogFile:                       &logFile,
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
@@ -120,10 +160,16 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:    config.GetCreateGalleriesFromFolders(),
+		ImportImageSidecars:           config.GetImportImageSidecars(),
+		ImageSidecarTagMappings:       config.GetImageSidecarTagMappings(),
+		ScenePrimaryFileRules:         config.GetScenePrimaryFileRules(),
+		PhashOptions:                  &phashOptions,
 		Excludes:                      config.GetExcludes(),
 		ImageExcludes:                 config.GetImageExcludes(),
+		CleanRules:                    config.GetCleanRules(),
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
 		StashBoxes:                    config.GetStashBoxes(),
+		FederatedInstances:            config.GetFederatedInstances(),
 		PythonPath:                    config.GetPythonPath(),
 		TranscodeInputArgs:            config.GetTranscodeInputArgs(),
 		TranscodeOutputArgs:           config.GetTranscodeOutputArgs(),
@@ -203,6 +249,15 @@ func makeConfigDLNAResult() *ConfigDLNAResult {
 		WhitelistedIPs: config.GetDLNADefaultIPWhitelist(),
 		Interfaces:     config.GetDLNAInterfaces(),
 		VideoSortOrder: config.GetVideoSortOrder(),
+		ServePreviews:  config.GetDLNAServePreviews(),
+		Ipv6:           config.GetDLNAIPv6(),
+		InterfaceSettings: func() []*dlna.InterfaceConfig {
+			ret := config.GetDLNAInterfaceSettings()
+			if ret == nil {
+				ret = []*dlna.InterfaceConfig{}
+			}
+			return ret
+		}(),
 	}
 }
 
@@ -212,11 +267,26 @@ func makeConfigScrapingResult() *ConfigScrapingResult {
 	scraperUserAgent := config.GetScraperUserAgent()
 	scraperCDPPath := config.GetScraperCDPPath()
 
+	urlRoutes := config.GetScraperURLRoutes()
+	if urlRoutes == nil {
+		urlRoutes = []*scraper.URLRoute{}
+	}
+
+	proxies := config.GetScraperProxies()
+	if proxies == nil {
+		proxies = []*scraper.Proxy{}
+	}
+
 	return &ConfigScrapingResult{
-		ScraperUserAgent:   &scraperUserAgent,
-		ScraperCertCheck:   config.GetScraperCertCheck(),
-		ScraperCDPPath:     &scraperCDPPath,
-		ExcludeTagPatterns: config.GetScraperExcludeTagPatterns(),
+		ScraperUserAgent:    &scraperUserAgent,
+		ScraperCertCheck:    config.GetScraperCertCheck(),
+		ScraperCDPPath:      &scraperCDPPath,
+		ExcludeTagPatterns:  config.GetScraperExcludeTagPatterns(),
+		URLRoutes:           urlRoutes,
+		Proxies:             proxies,
+		ProxyHealthCheckURL: config.GetScraperProxyHealthCheckURL(),
+		URLCheckInterval:    int(config.GetURLCheckInterval().Hours()),
+		URLCheckRescrape:    config.GetURLCheckRescrape(),
 	}
 }
 
@@ -227,6 +297,7 @@ func makeConfigDefaultsResult() *ConfigDefaultSettingsResult {
 
 	return &ConfigDefaultSettingsResult{
 		Identify:        config.GetDefaultIdentifySettings(),
+		IdentifyPresets: config.GetIdentifyPresets(),
 		Scan:            config.GetDefaultScanSettings(),
 		AutoTag:         config.GetDefaultAutoTagSettings(),
 		Generate:        config.GetDefaultGenerateSettings(),
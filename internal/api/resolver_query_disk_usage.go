@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) DiskUsage(ctx context.Context) (ret []*manager.StashPathDiskUsage, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = manager.GetStashPathDiskUsage(ctx, r.repository)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -68,3 +68,19 @@ func (r *queryResolver) AllGalleries(ctx context.Context) (ret []*models.Gallery
 
 	return ret, nil
 }
+
+func (r *queryResolver) ContinueReading(ctx context.Context, limit *int) (ret []*models.GalleryReadingSession, err error) {
+	l := -1
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.FindReadingSessions(ctx, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
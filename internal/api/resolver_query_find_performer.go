@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -24,6 +26,35 @@ func (r *queryResolver) FindPerformer(ctx context.Context, id string) (ret *mode
 	return ret, nil
 }
 
+func (r *queryResolver) PerformerTimeline(ctx context.Context, id string) (ret []*performer.TimelineYear, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		p, err := r.repository.Performer.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", idInt)
+		}
+
+		scenes, err := r.repository.Scene.FindByPerformerID(ctx, idInt)
+		if err != nil {
+			return err
+		}
+
+		ret = performer.Timeline(p, scenes)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *models.PerformerFilterType, filter *models.FindFilterType, performerIDs []int, ids []string) (ret *FindPerformersResultType, err error) {
 	if len(ids) > 0 {
 		performerIDs, err = stringslice.StringSliceToIntSlice(ids)
@@ -71,3 +102,52 @@ func (r *queryResolver) AllPerformers(ctx context.Context) (ret []*models.Perfor
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindDuplicatePerformers(ctx context.Context, input *FindDuplicatePerformersInput) (ret []*performer.DuplicateGroup, err error) {
+	options := performer.DuplicateOptions{
+		NameSimilarity: performer.DefaultNameSimilarity,
+		PhashDistance:  -1,
+	}
+	if input != nil {
+		if input.NameSimilarity != nil {
+			options.NameSimilarity = *input.NameSimilarity
+		}
+		if input.PhashDistance != nil {
+			options.PhashDistance = *input.PhashDistance
+		}
+	}
+
+	if options.NameSimilarity < 0 || options.NameSimilarity > 1 {
+		return nil, fmt.Errorf("name similarity must be between 0 and 1")
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = performer.FindDuplicates(ctx, r.repository.Performer, options)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) PerformerMergePreview(ctx context.Context, input PerformerMergeInput) (ret *models.PerformerMergePreview, err error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.MergePreview(ctx, source, destination)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
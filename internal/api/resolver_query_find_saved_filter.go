@@ -7,6 +7,7 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -40,8 +41,33 @@ func (r *queryResolver) FindSavedFilters(ctx context.Context, mode *models.Filte
 	return ret, err
 }
 
+func (r *queryResolver) FindSavedFilterFolders(ctx context.Context, mode *models.FilterMode) (ret []*models.SavedFilterFolder, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if mode != nil {
+			ret, err = r.repository.SavedFilterFolder.FindByMode(ctx, *mode)
+		} else {
+			ret, err = r.repository.SavedFilterFolder.All(ctx)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = []*models.SavedFilterFolder{}
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) FindDefaultFilter(ctx context.Context, mode models.FilterMode) (ret *models.SavedFilter, err error) {
 	// deprecated - read from the config in the meantime
+	return uiConfigDefaultFilter(mode)
+}
+
+// uiConfigDefaultFilter returns the default filter for the mode stored in the
+// UI configuration, or nil if none is set.
+func uiConfigDefaultFilter(mode models.FilterMode) (*models.SavedFilter, error) {
 	config := config.GetInstance()
 
 	uiConfig := config.GetUIConfiguration()
@@ -56,7 +82,7 @@ func (r *queryResolver) FindDefaultFilter(ctx context.Context, mode models.Filte
 		return nil, nil
 	}
 
-	ret = &models.SavedFilter{}
+	ret := &models.SavedFilter{}
 	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		TagName:          "json",
 		WeaklyTypedInput: true,
@@ -73,3 +99,92 @@ func (r *queryResolver) FindDefaultFilter(ctx context.Context, mode models.Filte
 
 	return ret, nil
 }
+
+func (r *queryResolver) DefaultFilters(ctx context.Context) (ret []*models.DefaultFilter, err error) {
+	username := currentUsername(ctx)
+
+	set := make(map[models.FilterMode]bool)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.DefaultFilter.FindByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range ret {
+			set[f.Mode] = true
+		}
+
+		// fall back to the default saved filter of modes that have not been
+		// set by the user
+		for _, mode := range models.AllFilterMode {
+			if set[mode] {
+				continue
+			}
+
+			f, err := r.repository.SavedFilter.FindDefault(ctx, mode)
+			if err != nil {
+				return err
+			}
+
+			if f != nil {
+				ret = append(ret, defaultFilterFromSavedFilter(username, mode, f))
+				set[mode] = true
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// fall back to the default filters previously stored in the UI
+	// configuration for modes that have not been set
+	for _, mode := range models.AllFilterMode {
+		if set[mode] {
+			continue
+		}
+
+		legacy, err := uiConfigDefaultFilter(mode)
+		if err != nil {
+			logger.Warnf("error reading default %s filter from UI configuration: %v", mode, err)
+			continue
+		}
+
+		if legacy != nil {
+			ret = append(ret, defaultFilterFromSavedFilter(username, mode, legacy))
+		}
+	}
+
+	if ret == nil {
+		ret = []*models.DefaultFilter{}
+	}
+
+	return ret, nil
+}
+
+func defaultFilterFromSavedFilter(username string, mode models.FilterMode, f *models.SavedFilter) *models.DefaultFilter {
+	ret := &models.DefaultFilter{
+		Username:     username,
+		Mode:         mode,
+		FindFilter:   f.FindFilter,
+		ObjectFilter: f.ObjectFilter,
+	}
+
+	intOption := func(key string) *int {
+		var i int
+		switch v := f.UIOptions[key].(type) {
+		case int:
+			i = v
+		case float64:
+			i = int(v)
+		default:
+			return nil
+		}
+		return &i
+	}
+
+	ret.DisplayMode = intOption("display_mode")
+	ret.ZoomIndex = intOption("zoom_index")
+
+	return ret
+}
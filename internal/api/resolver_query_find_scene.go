@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"slices"
 	"strconv"
 
@@ -228,7 +230,35 @@ func (r *queryResolver) ParseSceneFilenames(ctx context.Context, filter *models.
 	return ret, nil
 }
 
-func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int, durationDiff *float64) (ret [][]*models.Scene, err error) {
+func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int, durationDiff *float64, customPhash *bool, distanceMetric *models.PhashDistanceMetric) (ret [][]*models.Scene, err error) {
+	dist := 0
+	durDiff := -1.
+	if distance != nil {
+		dist = *distance
+	}
+	if durationDiff != nil {
+		durDiff = *durationDiff
+	}
+
+	var options models.PhashDuplicateOptions
+	if customPhash != nil && *customPhash {
+		options.FingerprintType = models.FingerprintTypePhashCustom
+	}
+	if distanceMetric != nil {
+		options.Metric = *distanceMetric
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.FindDuplicates(ctx, dist, durDiff, options)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindDuplicateSceneImages(ctx context.Context, distance *int, durationDiff *float64) (ret []*models.SceneImageDuplicate, err error) {
 	dist := 0
 	durDiff := -1.
 	if distance != nil {
@@ -238,7 +268,7 @@ func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int,
 		durDiff = *durationDiff
 	}
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		ret, err = r.repository.Scene.FindDuplicates(ctx, dist, durDiff)
+		ret, err = r.repository.Scene.FindImageDuplicates(ctx, dist, durDiff)
 		return err
 	}); err != nil {
 		return nil, err
@@ -247,6 +277,32 @@ func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int,
 	return ret, nil
 }
 
+func (r *queryResolver) CompareScenes(ctx context.Context, ids []string) (ret *scene.Comparison, err error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = scene.Compare(ctx, scene.CompareRepository{
+			Scene:     r.repository.Scene,
+			Studio:    r.repository.Studio,
+			Performer: r.repository.Performer,
+			Tag:       r.repository.Tag,
+			Group:     r.repository.Group,
+			Gallery:   r.repository.Gallery,
+		}, sceneIDs)
+		return err
+	}); err != nil {
+		if errors.Is(err, scene.ErrCompareTooFewScenes) {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) AllScenes(ctx context.Context) (ret []*models.Scene, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Scene.All(ctx)
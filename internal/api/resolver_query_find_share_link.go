@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindShareLinks(ctx context.Context, filter *ShareLinkFilterInput) (ret []*models.ShareLink, err error) {
+	var sceneID, galleryID *int
+	activeOnly := false
+
+	if filter != nil {
+		translator := changesetTranslator{}
+
+		sceneID, err = translator.intPtrFromString(filter.SceneID)
+		if err != nil {
+			return nil, err
+		}
+		galleryID, err = translator.intPtrFromString(filter.GalleryID)
+		if err != nil {
+			return nil, err
+		}
+		activeOnly = translator.bool(filter.ActiveOnly)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ShareLink
+
+		switch {
+		case sceneID != nil:
+			ret, err = qb.FindBySceneID(ctx, *sceneID)
+		case galleryID != nil:
+			ret, err = qb.FindByGalleryID(ctx, *galleryID)
+		default:
+			ret, err = qb.All(ctx)
+		}
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if activeOnly {
+		now := time.Now()
+		var active []*models.ShareLink
+		for _, l := range ret {
+			if l.IsActive(now) {
+				active = append(active, l)
+			}
+		}
+		ret = active
+	}
+
+	if ret == nil {
+		ret = []*models.ShareLink{}
+	}
+
+	return ret, nil
+}
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 func (r *queryResolver) FindTag(ctx context.Context, id string) (ret *models.Tag, err error) {
@@ -69,3 +71,45 @@ func (r *queryResolver) AllTags(ctx context.Context) (ret []*models.Tag, err err
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindUnusedTags(ctx context.Context) (ret []*models.Tag, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = tag.FindUnused(ctx, r.repository.Tag)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindHierarchyOnlyTags(ctx context.Context) (ret []*models.Tag, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = tag.FindHierarchyOnly(ctx, r.repository.Tag)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindDuplicateTags(ctx context.Context, distance *int) (ret [][]*models.Tag, err error) {
+	dist := tag.DefaultDuplicateDistance
+	if distance != nil {
+		dist = *distance
+	}
+
+	if dist < 0 {
+		return nil, fmt.Errorf("distance must not be negative")
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = tag.FindDuplicates(ctx, r.repository.Tag, dist)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
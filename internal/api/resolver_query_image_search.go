@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	"github.com/stashapp/stash/pkg/imagesearch"
+)
+
+// maxReverseImageSearchSize is the maximum size of an image uploaded for a
+// reverse image search.
+const maxReverseImageSearchSize = 20 * 1024 * 1024
+
+func (r *queryResolver) ReverseImageSearch(ctx context.Context, input ReverseImageSearchInput) ([]*imagesearch.Match, error) {
+	options := imagesearch.Options{
+		Types: input.Types,
+	}
+	if input.Distance != nil {
+		options.Distance = *input.Distance
+	}
+	if input.Limit != nil {
+		options.Limit = *input.Limit
+	}
+
+	img := io.LimitReader(input.Image.File, maxReverseImageSearchSize)
+
+	// Search manages its own transactions, as missing phashes are written
+	return imagesearch.Search(ctx, imagesearch.NewRepository(r.repository), img, options)
+}
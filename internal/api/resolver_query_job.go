@@ -42,6 +42,11 @@ func jobToJobModel(j job.Job) *Job {
 		EndTime:     j.EndTime,
 		AddTime:     j.AddTime,
 		Error:       j.Error,
+		Artifacts:   make([]*job.Artifact, len(j.Artifacts)),
+	}
+
+	for i := range j.Artifacts {
+		ret.Artifacts[i] = &j.Artifacts[i]
 	}
 
 	if j.Progress != -1 {
@@ -50,3 +55,17 @@ func jobToJobModel(j job.Job) *Job {
 
 	return ret
 }
+
+func (r *queryResolver) JobArtifacts(ctx context.Context) ([]*job.Artifact, error) {
+	artifacts, err := manager.GetInstance().ArtifactStore.List()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*job.Artifact, len(artifacts))
+	for i := range artifacts {
+		ret[i] = &artifacts[i]
+	}
+
+	return ret, nil
+}
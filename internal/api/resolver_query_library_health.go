@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *queryResolver) LibraryHealthReport(ctx context.Context) (*scene.HealthReport, error) {
+	return manager.GetLibraryHealthReport()
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) LocationClusters(ctx context.Context, input models.LocationClusterInput) (ret []*models.LocationCluster, err error) {
+	if input.CellSize <= 0 {
+		return nil, fmt.Errorf("%w: cell_size must be greater than zero", ErrInput)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		repo := r.repository
+
+		scenes, err := repo.Scene.LocationClusters(ctx, input.CellSize, input.Bounds)
+		if err != nil {
+			return err
+		}
+
+		galleries, err := repo.Gallery.LocationClusters(ctx, input.CellSize, input.Bounds)
+		if err != nil {
+			return err
+		}
+
+		images, err := repo.Image.LocationClusters(ctx, input.CellSize, input.Bounds)
+		if err != nil {
+			return err
+		}
+
+		ret = models.MergeLocationClusters(input.CellSize, scenes, galleries, images)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
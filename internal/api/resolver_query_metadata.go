@@ -2,10 +2,41 @@ package api
 
 import (
 	"context"
+	"runtime"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 )
 
+type MemoryStats struct {
+	HeapAlloc     int64 `json:"heapAlloc"`
+	HeapInUse     int64 `json:"heapInUse"`
+	HeapSys       int64 `json:"heapSys"`
+	Sys           int64 `json:"sys"`
+	NumGC         int   `json:"numGC"`
+	Goroutines    int   `json:"goroutines"`
+	LowMemoryMode bool  `json:"lowMemoryMode"`
+}
+
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) CleanReport(ctx context.Context) (*manager.CleanReport, error) {
+	return manager.GetInstance().LastCleanReport(), nil
+}
+
+func (r *queryResolver) MemoryStats(ctx context.Context) (*MemoryStats, error) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	return &MemoryStats{
+		HeapAlloc:     int64(m.HeapAlloc),
+		HeapInUse:     int64(m.HeapInuse),
+		HeapSys:       int64(m.HeapSys),
+		Sys:           int64(m.Sys),
+		NumGC:         int(m.NumGC),
+		Goroutines:    runtime.NumGoroutine(),
+		LowMemoryMode: config.GetInstance().GetLowMemoryMode(),
+	}, nil
+}
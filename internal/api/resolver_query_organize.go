@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/organize"
+)
+
+func (r *queryResolver) OrganizeFilesPlan(ctx context.Context, input manager.OrganizeFilesInput) (ret []*organize.Move, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		moves, err := manager.PlanOrganizeFiles(ctx, r.repository, input)
+		if err != nil {
+			return err
+		}
+
+		for i := range moves {
+			ret = append(ret, &moves[i])
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) OrganizeJournals(ctx context.Context) ([]*organize.Journal, error) {
+	return manager.GetOrganizeJournalStore().All()
+}
+
+func (r *queryResolver) InboxReport(ctx context.Context) (*manager.InboxReport, error) {
+	return manager.GetInstance().LastInboxReport(), nil
+}
@@ -77,19 +77,31 @@ func remotePackageToPackage(p pkg.RemotePackage, index pkg.RemotePackageIndex) *
 	ret.SourceURL = p.Repository.Path()
 
 	for _, r := range p.Requires {
+		req, err := pkg.ParseRequirement(r)
+		if err != nil {
+			// ignore invalid requirements
+			continue
+		}
+
 		// required packages must come from the same source
 		spec := models.PackageSpecInput{
-			ID:        r,
+			ID:        req.ID,
 			SourceURL: p.Repository.Path(),
 		}
 
-		req, found := index[spec]
+		dep, found := index[spec]
 		if !found {
 			// shouldn't happen, but we'll ignore it
 			continue
 		}
 
-		ret.Requires = append(ret.Requires, remotePackageToPackage(req, index))
+		// only list packages that satisfy the version constraint, since
+		// installing fails otherwise
+		if !req.SatisfiedBy(dep.Version) {
+			continue
+		}
+
+		ret.Requires = append(ret.Requires, remotePackageToPackage(dep, index))
 	}
 
 	return ret
@@ -126,7 +138,7 @@ func sortedPackageSpecKeys[V any](m map[models.PackageSpecInput]V) []models.Pack
 	return keys
 }
 
-func (r *queryResolver) getInstalledPackagesWithUpgrades(ctx context.Context, pm *pkg.Manager) ([]*Package, error) {
+func (r *queryResolver) getInstalledPackagesWithUpgrades(ctx context.Context, pm *pkg.Manager, upgradableOnly bool) ([]*Package, error) {
 	// get all installed packages
 	installed, err := pm.ListInstalled(ctx)
 	if err != nil {
@@ -141,18 +153,20 @@ func (r *queryResolver) getInstalledPackagesWithUpgrades(ctx context.Context, pm
 
 	packageStatusIndex := pkg.MakePackageStatusIndex(installed, allRemoteList)
 
-	ret := make([]*Package, len(packageStatusIndex))
-	i := 0
+	ret := make([]*Package, 0, len(packageStatusIndex))
 
 	for _, k := range sortedPackageSpecKeys(packageStatusIndex) {
 		v := packageStatusIndex[k]
+		if upgradableOnly && !v.Upgradable() {
+			continue
+		}
+
 		p := manifestToPackage(*v.Local)
 		if v.Remote != nil {
 			pp := remotePackageToPackage(*v.Remote, allRemoteList)
 			p.SourcePackage = pp
 		}
-		ret[i] = p
-		i++
+		ret = append(ret, p)
 	}
 
 	return ret, nil
@@ -167,7 +181,7 @@ func (r *queryResolver) InstalledPackages(ctx context.Context, typeArg PackageTy
 	var ret []*Package
 
 	if slices.Contains(graphql.CollectAllFields(ctx), "source_package") {
-		ret, err = r.getInstalledPackagesWithUpgrades(ctx, pm)
+		ret, err = r.getInstalledPackagesWithUpgrades(ctx, pm, false)
 		if err != nil {
 			return nil, err
 		}
@@ -210,3 +224,12 @@ func (r *queryResolver) AvailablePackages(ctx context.Context, typeArg PackageTy
 
 	return ret, nil
 }
+
+func (r *queryResolver) AvailablePluginUpdates(ctx context.Context) ([]*Package, error) {
+	pm, err := getPackageManager(PackageTypePlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getInstalledPackagesWithUpgrades(ctx, pm, true)
+}
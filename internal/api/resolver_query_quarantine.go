@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) QuarantinedFiles(ctx context.Context, includeIgnored *bool) (ret []*models.QuarantinedFile, err error) {
+	var all []*models.QuarantinedFile
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		all, err = r.repository.Quarantine.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	ret = []*models.QuarantinedFile{}
+	for _, f := range all {
+		if f.Ignored && (includeIgnored == nil || !*includeIgnored) {
+			continue
+		}
+		ret = append(ret, f)
+	}
+
+	return ret, nil
+}
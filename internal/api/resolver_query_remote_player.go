@@ -0,0 +1,19 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/remoteplayer"
+)
+
+func (r *queryResolver) RemotePlayers(ctx context.Context) ([]*remoteplayer.Player, error) {
+	players := manager.GetInstance().RemotePlayers.Players(currentUsername(ctx))
+
+	ret := make([]*remoteplayer.Player, len(players))
+	for i := range players {
+		ret[i] = &players[i]
+	}
+
+	return ret, nil
+}
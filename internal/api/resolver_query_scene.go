@@ -10,7 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 )
 
-func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manager.SceneStreamEndpoint, error) {
+func (r *queryResolver) SceneStreams(ctx context.Context, id *string, profile *manager.StreamClientProfile) ([]*manager.SceneStreamEndpoint, error) {
 	sceneID, err := strconv.Atoi(*id)
 	if err != nil {
 		return nil, err
@@ -41,5 +41,9 @@ func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manage
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, scene)
 	apiKey := config.GetAPIKey()
 
+	if profile != nil {
+		return manager.NegotiateSceneStreamPaths(scene, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize(), *profile)
+	}
+
 	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
 }
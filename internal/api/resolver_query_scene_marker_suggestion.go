@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) SceneMarkerSuggestions(ctx context.Context, sceneID *string) (ret []*models.SceneMarkerSuggestion, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Suggestion
+
+		if sceneID == nil {
+			ret, err = qb.All(ctx)
+			return err
+		}
+
+		id, err := strconv.Atoi(*sceneID)
+		if err != nil {
+			return fmt.Errorf("converting scene id: %w", err)
+		}
+
+		ret, err = qb.FindBySceneID(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = []*models.SceneMarkerSuggestion{}
+	}
+
+	return ret, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper"
@@ -162,7 +163,17 @@ func filterGroupTags(p []*models.ScrapedMovie) {
 }
 
 func (r *queryResolver) ScrapeSceneURL(ctx context.Context, url string) (*scraper.ScrapedScene, error) {
-	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeScene)
+	route, err := r.FindScraperURLRoute(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var content scraper.ScrapedContent
+	if route != nil && route.ScraperID != "" {
+		content, err = r.scraperCache().ScrapeURLWithScraper(ctx, route.ScraperID, url, scraper.ScrapeContentTypeScene)
+	} else {
+		content, err = r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeScene)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +185,38 @@ func (r *queryResolver) ScrapeSceneURL(ctx context.Context, url string) (*scrape
 
 	if ret != nil {
 		filterSceneTags([]*scraper.ScrapedScene{ret})
+
+		if route != nil && route.IdentifyPreset != "" {
+			preset := config.GetInstance().GetIdentifyPreset(route.IdentifyPreset)
+			if preset != nil {
+				preset.FilterScrapedScene(route.ScraperID, ret)
+			} else {
+				logger.Warnf("identify preset %q of url route not found", route.IdentifyPreset)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindScraperURLRoute(ctx context.Context, url string) (ret *scraper.URLRoute, err error) {
+	routes := config.GetInstance().GetScraperURLRoutes()
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret = scraper.MatchURLRoute(routes, url, func(studioID int) []string {
+			urls, err := r.repository.Studio.GetURLs(ctx, studioID)
+			if err != nil {
+				logger.Warnf("error getting urls of studio %d of url route: %v", studioID, err)
+				return nil
+			}
+			return urls
+		})
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	return ret, nil
@@ -246,6 +289,33 @@ func (r *queryResolver) ScrapeGroupURL(ctx context.Context, url string) (*models
 	return group, nil
 }
 
+func (r *queryResolver) DebugScraper(ctx context.Context, input ScraperDebugInput) (*scraper.DebugResult, error) {
+	debugInput := scraper.DebugInput{
+		ScraperID: input.ScraperID,
+		Type:      input.Type,
+		URL:       input.URL,
+		Query:     input.Query,
+	}
+
+	if input.SceneInput != nil || input.GalleryInput != nil || input.PerformerInput != nil {
+		debugInput.Fragment = &scraper.Input{
+			Scene:     input.SceneInput,
+			Gallery:   input.GalleryInput,
+			Performer: input.PerformerInput,
+		}
+	}
+
+	ret, err := r.scraperCache().Debug(ctx, debugInput)
+	if err != nil {
+		if errors.Is(err, scraper.ErrInvalidDebugInput) {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeSingleScene(ctx context.Context, source scraper.Source, input ScrapeSingleSceneInput) ([]*scraper.ScrapedScene, error) {
 	var ret []*scraper.ScrapedScene
 
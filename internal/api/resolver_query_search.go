@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/search"
+)
+
+func (r *queryResolver) Search(ctx context.Context, q string, types []search.ResultType, limit *int) (ret []*search.Result, err error) {
+	l := 0
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = search.Search(ctx, search.NewRepository(r.repository), q, types, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,53 @@
+//go:build integration
+// +build integration
+
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	// necessary to register custom migrations
+	_ "github.com/stashapp/stash/pkg/sqlite/migrations"
+)
+
+func TestReadOnlyQuerySQL(t *testing.T) {
+	// initialise empty config - needed by some db migrations
+	_ = config.InitializeEmpty()
+
+	db := sqlite.NewDatabase()
+	if err := db.Open(filepath.Join(t.TempDir(), "stash.sqlite")); err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	defer db.Close()
+
+	r := &queryResolver{&Resolver{repository: db.Repository()}}
+	ctx := context.Background()
+
+	ret, err := r.readOnlyQuerySQL(ctx, db, "SELECT ? AS value", []interface{}{1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"value"}, ret.Columns)
+		assert.Equal(t, [][]interface{}{{int64(1)}}, ret.Rows)
+	}
+
+	// statements that write to the database fail
+	_, err = r.readOnlyQuerySQL(ctx, db, "INSERT INTO tags (name, created_at, updated_at) VALUES ('tag', 0, 0)", nil)
+	assert.Error(t, err)
+
+	_, err = r.readOnlyQuerySQL(ctx, db, "DELETE FROM tags", nil)
+	assert.Error(t, err)
+
+	ret, err = r.readOnlyQuerySQL(ctx, db, "SELECT COUNT(*) FROM tags", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, [][]interface{}{{int64(0)}}, ret.Rows)
+	}
+
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func TestQuerySQLDisabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		credentials bool
+	}{
+		{"disabled", false, true},
+		{"no credentials", true, false},
+		{"disabled without credentials", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.InitializeEmpty()
+			c.SetBool(config.SQLQueryEnabled, tt.enabled)
+			if tt.credentials {
+				c.SetString(config.Username, "user")
+				c.SetString(config.Password, "password hash")
+			}
+
+			r := &queryResolver{&Resolver{}}
+			_, err := r.QuerySQL(context.Background(), "SELECT 1", nil)
+			if !errors.Is(err, ErrNotSupported) {
+				t.Errorf("QuerySQL() error = %v, want %v", err, ErrNotSupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+func (r *queryResolver) StudioHierarchyPlan(ctx context.Context, input manager.StudioHierarchyInput) (ret []*studio.HierarchyChange, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		changes, err := manager.PlanStudioHierarchy(ctx, r.repository, input)
+		if err != nil {
+			return err
+		}
+
+		for i := range changes {
+			ret = append(ret, &changes[i])
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
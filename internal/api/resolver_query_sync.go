@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type SyncResult struct {
+	Cursor       string              `json:"cursor"`
+	Scenes       *models.SyncChanges `json:"scenes"`
+	Images       *models.SyncChanges `json:"images"`
+	Galleries    *models.SyncChanges `json:"galleries"`
+	Performers   *models.SyncChanges `json:"performers"`
+	Studios      *models.SyncChanges `json:"studios"`
+	Tags         *models.SyncChanges `json:"tags"`
+	Groups       *models.SyncChanges `json:"groups"`
+	SceneMarkers *models.SyncChanges `json:"scene_markers"`
+}
+
+func (r *queryResolver) Sync(ctx context.Context, since *string) (*SyncResult, error) {
+	var sinceTime *time.Time
+	if since != nil && *since != "" {
+		t, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %w", *since, err)
+		}
+		sinceTime = &t
+	}
+
+	// timestamps are stored to the second, and changes are returned from the
+	// start of the cursor's second. Changes made in the same second as this
+	// request may therefore be returned again by the next request.
+	now := time.Now().UTC().Truncate(time.Second)
+
+	ret := &SyncResult{
+		Cursor: now.Format(time.RFC3339),
+	}
+
+	fields := map[models.SyncObjectType]**models.SyncChanges{
+		models.SyncObjectTypeScene:       &ret.Scenes,
+		models.SyncObjectTypeImage:       &ret.Images,
+		models.SyncObjectTypeGallery:     &ret.Galleries,
+		models.SyncObjectTypePerformer:   &ret.Performers,
+		models.SyncObjectTypeStudio:      &ret.Studios,
+		models.SyncObjectTypeTag:         &ret.Tags,
+		models.SyncObjectTypeGroup:       &ret.Groups,
+		models.SyncObjectTypeSceneMarker: &ret.SceneMarkers,
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Sync
+
+		for _, t := range models.AllSyncObjectType {
+			changes, err := qb.Changes(ctx, t, sinceTime)
+			if err != nil {
+				return fmt.Errorf("reading %s changes: %w", t, err)
+			}
+			*fields[t] = changes
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
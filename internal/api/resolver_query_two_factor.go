@@ -0,0 +1,20 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *queryResolver) TwoFactorStatus(ctx context.Context) (*TwoFactorStatus, error) {
+	status, err := manager.GetInstance().TOTPService.Status(ctx, config.GetInstance().GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorStatus{
+		Enabled:                status.Enabled,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	}, nil
+}
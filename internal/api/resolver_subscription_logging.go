@@ -26,25 +26,67 @@ func getLogLevel(logType string) LogLevel {
 	}
 }
 
+func getLogType(level LogLevel) string {
+	switch level {
+	case LogLevelProgress:
+		return "progress"
+	case LogLevelTrace:
+		return "trace"
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarning:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+func logEntryFromLogItem(entry log.LogItem) *LogEntry {
+	ret := &LogEntry{
+		Time:    entry.Time,
+		Level:   getLogLevel(entry.Type),
+		Message: entry.Message,
+	}
+	if entry.Subsystem != "" {
+		subsystem := entry.Subsystem
+		ret.Subsystem = &subsystem
+	}
+
+	return ret
+}
+
 func logEntriesFromLogItems(logItems []log.LogItem) []*LogEntry {
 	ret := make([]*LogEntry, len(logItems))
 
 	for i, entry := range logItems {
-		ret[i] = &LogEntry{
-			Time:    entry.Time,
-			Level:   getLogLevel(entry.Type),
-			Message: entry.Message,
-		}
+		ret[i] = logEntryFromLogItem(entry)
 	}
 
 	return ret
 }
 
-func (r *subscriptionResolver) LoggingSubscribe(ctx context.Context) (<-chan []*LogEntry, error) {
+func (r *subscriptionResolver) LoggingSubscribe(ctx context.Context, filter *LogFilterInput, backfill *int) (<-chan []*LogEntry, error) {
+	var logFilter log.LogFilter
+	if filter != nil {
+		if filter.MinLevel != nil {
+			logFilter.MinLevel = getLogType(*filter.MinLevel)
+		}
+		logFilter.Subsystems = filter.Subsystems
+	}
+
+	n := 0
+	if backfill != nil {
+		n = *backfill
+	}
+
 	ret := make(chan []*LogEntry, 100)
 	stop := make(chan int, 1)
 	logger := manager.GetInstance().Logger
-	logSub := logger.SubscribeToLog(stop)
+	logSub := logger.SubscribeToFilteredLog(stop, logFilter, n)
 
 	go func() {
 		for {
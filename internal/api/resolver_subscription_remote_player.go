@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/remoteplayer"
+)
+
+func (r *subscriptionResolver) RemotePlayerRegister(ctx context.Context, input RemotePlayerRegisterInput) (<-chan *remoteplayer.Command, error) {
+	commands, err := manager.GetInstance().RemotePlayers.Register(ctx, input.ID, input.Name, currentUsername(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(chan *remoteplayer.Command, 10)
+
+	go func() {
+		defer close(ret)
+
+		for cmd := range commands {
+			ret <- &cmd
+		}
+	}()
+
+	return ret, nil
+}
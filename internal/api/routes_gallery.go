@@ -3,16 +3,22 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/static"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -22,7 +28,9 @@ type GalleryFinder interface {
 }
 
 type GalleryImageFinder interface {
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.Image, error)
 	FindByGalleryIDIndex(ctx context.Context, galleryID int, index uint) (*models.Image, error)
+	CountByGalleryID(ctx context.Context, galleryID int) (int, error)
 	image.Queryer
 	image.CoverQueryer
 }
@@ -43,6 +51,7 @@ func (rs galleryRoutes) Routes() chi.Router {
 
 		r.Get("/cover", rs.Cover)
 		r.Get("/preview/{imageIndex}", rs.Preview)
+		r.Get("/download", rs.Download)
 	})
 
 	return r
@@ -119,6 +128,81 @@ func (rs galleryRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	rs.imageRoutes.serveThumbnail(w, r, i, nil)
 }
 
+// Download streams the images of the gallery as a zip archive. The images
+// query parameter optionally restricts the download to a comma-separated
+// list of image ids, and the template query parameter sets the names of
+// the files in the archive.
+func (rs galleryRoutes) Download(w http.ResponseWriter, r *http.Request) {
+	g := r.Context().Value(galleryKey).(*models.Gallery)
+
+	t := gallery.DefaultDownloadTemplate
+	if v := r.URL.Query().Get("template"); v != "" {
+		t = organize.ImageTemplate(v)
+	}
+	if err := t.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var selected map[int]bool
+	if v := r.URL.Query().Get("images"); v != "" {
+		selected = make(map[int]bool)
+		for _, s := range strings.Split(v, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid image id %q", s), http.StatusBadRequest)
+				return
+			}
+			selected[id] = true
+		}
+	}
+
+	var files []gallery.DownloadFile
+	if err := rs.withReadTxn(r, func(ctx context.Context) error {
+		images, err := rs.imageFinder.FindByGalleryID(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+
+		// keep the gallery order, ignoring ids of images not in the gallery
+		var toDownload []*models.Image
+		for _, i := range images {
+			if selected != nil && !selected[i.ID] {
+				continue
+			}
+
+			if err := i.LoadPrimaryFile(ctx, rs.fileGetter); err != nil {
+				return fmt.Errorf("loading primary file for image %d: %w", i.ID, err)
+			}
+			toDownload = append(toDownload, i)
+		}
+
+		files, err = gallery.DownloadFiles(g, toDownload, t)
+		return err
+	}); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Errorf("error preparing download of gallery %d: %v", g.ID, err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if len(files) == 0 {
+		http.Error(w, "no images to download", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": g.GetTitle() + ".zip",
+	}))
+
+	// the response has started, so errors can only be logged
+	if err := gallery.WriteDownload(r.Context(), w, &file.OsFS{}, files); err != nil && !errors.Is(err, context.Canceled) {
+		logger.Errorf("error writing download of gallery %d: %v", g.ID, err)
+	}
+}
+
 func (rs galleryRoutes) GalleryCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		galleryIdentifierQueryParam := chi.URLParam(r, "galleryId")
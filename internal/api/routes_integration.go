@@ -0,0 +1,83 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// integrationRoutes are called by external applications, such as the
+// post-processing hooks of download clients.
+type integrationRoutes struct {
+	manager *manager.Manager
+}
+
+func (rs integrationRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/download-complete", rs.DownloadComplete)
+
+	return r
+}
+
+type downloadCompleteResult struct {
+	JobID string `json:"job_id"`
+}
+
+// DownloadComplete scans a finished download. The parameters are read from
+// the query string or form body, so that the endpoint can be called with
+// curl from the external program hooks of download clients:
+//   - path: the downloaded file or folder
+//   - identify: identify the scenes of the download if true
+//   - preset: the identify preset to use, instead of the default identify
+//     settings
+func (rs integrationRoutes) DownloadComplete(w http.ResponseWriter, r *http.Request) {
+	input, err := parseDownloadCompleteInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobID, err := rs.manager.DownloadComplete(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, manager.ErrInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		logger.Errorf("error handling completed download: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, downloadCompleteResult{
+		JobID: strconv.Itoa(jobID),
+	})
+}
+
+func parseDownloadCompleteInput(r *http.Request) (manager.DownloadCompleteInput, error) {
+	input := manager.DownloadCompleteInput{
+		Path:   r.FormValue("path"),
+		Preset: r.FormValue("preset"),
+	}
+
+	if v := r.FormValue("identify"); v != "" {
+		var err error
+		input.Identify, err = strconv.ParseBool(v)
+		if err != nil {
+			return input, errors.New("identify must be a boolean")
+		}
+	}
+
+	// setting a preset implies identifying
+	if input.Preset != "" {
+		input.Identify = true
+	}
+
+	return input, nil
+}
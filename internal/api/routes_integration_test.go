@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDownloadCompleteInput(t *testing.T) {
+	const path = "/downloads/scene.mp4"
+
+	tests := []struct {
+		name    string
+		query   url.Values
+		form    url.Values
+		want    manager.DownloadCompleteInput
+		wantErr bool
+	}{
+		{
+			"path only",
+			url.Values{"path": {path}},
+			nil,
+			manager.DownloadCompleteInput{Path: path},
+			false,
+		},
+		{
+			"identify true",
+			url.Values{"path": {path}, "identify": {"true"}},
+			nil,
+			manager.DownloadCompleteInput{Path: path, Identify: true},
+			false,
+		},
+		{
+			"identify 1",
+			url.Values{"path": {path}, "identify": {"1"}},
+			nil,
+			manager.DownloadCompleteInput{Path: path, Identify: true},
+			false,
+		},
+		{
+			"identify false",
+			url.Values{"path": {path}, "identify": {"false"}},
+			nil,
+			manager.DownloadCompleteInput{Path: path},
+			false,
+		},
+		{
+			"invalid identify",
+			url.Values{"path": {path}, "identify": {"yes please"}},
+			nil,
+			manager.DownloadCompleteInput{},
+			true,
+		},
+		{
+			"preset implies identify",
+			url.Values{"path": {path}, "preset": {"preset"}},
+			nil,
+			manager.DownloadCompleteInput{Path: path, Identify: true, Preset: "preset"},
+			false,
+		},
+		{
+			"preset with identify false",
+			url.Values{"path": {path}, "identify": {"false"}, "preset": {"preset"}},
+			nil,
+			manager.DownloadCompleteInput{Path: path, Identify: true, Preset: "preset"},
+			false,
+		},
+		{
+			"form body",
+			nil,
+			url.Values{"path": {path}, "identify": {"true"}},
+			manager.DownloadCompleteInput{Path: path, Identify: true},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/download-complete?" + tt.query.Encode()
+			r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(tt.form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			got, err := parseDownloadCompleteInput(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDownloadCompleteInput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDownloadCompleteInvalidIdentify(t *testing.T) {
+	// the input is rejected before the manager is used
+	rs := integrationRoutes{}
+
+	r := httptest.NewRequest(http.MethodPost, "/download-complete?path=/downloads&identify=maybe", nil)
+	w := httptest.NewRecorder()
+
+	rs.Routes().ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "identify must be a boolean")
+}
@@ -16,6 +16,8 @@ import (
 type PerformerFinder interface {
 	models.PerformerGetter
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	FindImage(ctx context.Context, id int) (*models.PerformerImage, error)
+	GetImageData(ctx context.Context, id int) ([]byte, error)
 }
 
 type performerRoutes struct {
@@ -29,6 +31,7 @@ func (rs performerRoutes) Routes() chi.Router {
 	r.Route("/{performerId}", func(r chi.Router) {
 		r.Use(rs.PerformerCtx)
 		r.Get("/image", rs.Image)
+		r.Get("/images/{imageId}", rs.SetImage)
 	})
 
 	return r
@@ -60,6 +63,40 @@ func (rs performerRoutes) Image(w http.ResponseWriter, r *http.Request) {
 	utils.ServeImage(w, r, image)
 }
 
+// SetImage serves an image from the image set of the performer.
+func (rs performerRoutes) SetImage(w http.ResponseWriter, r *http.Request) {
+	performer := r.Context().Value(performerKey).(*models.Performer)
+	imageID, err := strconv.Atoi(chi.URLParam(r, "imageId"))
+	if err != nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	var image []byte
+	readTxnErr := rs.withReadTxn(r, func(ctx context.Context) error {
+		img, err := rs.performerFinder.FindImage(ctx, imageID)
+		if err != nil || img == nil || img.PerformerID != performer.ID {
+			return err
+		}
+
+		image, err = rs.performerFinder.GetImageData(ctx, imageID)
+		return err
+	})
+	if errors.Is(readTxnErr, context.Canceled) {
+		return
+	}
+	if readTxnErr != nil {
+		logger.Warnf("read transaction error on fetch performer image: %v", readTxnErr)
+	}
+
+	if len(image) == 0 {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	utils.ServeImage(w, r, image)
+}
+
 func (rs performerRoutes) PerformerCtx(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		performerID, err := strconv.Atoi(chi.URLParam(r, "performerId"))
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type PlayerSceneUpdater interface {
+	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
+	AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error)
+}
+
+// playerRoutes serve scene files to external players, such as mpv, VLC and
+// Kodi, using tokens issued by the externalPlayerLink mutation. Players may
+// report playback progress using the same token.
+type playerRoutes struct {
+	routes
+	sceneUpdater PlayerSceneUpdater
+	sceneRoutes  sceneRoutes
+}
+
+// playerProgress is the request body of the progress endpoint.
+type playerProgress struct {
+	// Current playback position in seconds
+	ResumeTime *float64 `json:"resume_time"`
+	// Seconds played since the previous progress report
+	PlayDuration *float64 `json:"play_duration"`
+	// Adds a play to the play history of the scene
+	Played bool `json:"played"`
+}
+
+func (rs playerRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{playerToken}", func(r chi.Router) {
+		r.Use(rs.PlayerCtx)
+
+		r.Get("/stream", rs.sceneRoutes.StreamDirect)
+		r.Get("/playlist.m3u", rs.Playlist)
+		r.Post("/progress", rs.Progress)
+	})
+
+	return r
+}
+
+// Playlist returns an M3U playlist containing the stream of the scene.
+// VLC starts playback from the resume time of the scene.
+func (rs playerRoutes) Playlist(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	token := chi.URLParam(r, "playerToken")
+
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+	streamURL := urlbuilders.NewExternalPlayerURLBuilder(baseURL, token).GetStreamURL()
+
+	// titles must not span multiple lines
+	title := strings.Join(strings.Fields(scene.DisplayName()), " ")
+	duration := -1
+	if f := scene.Files.Primary(); f != nil {
+		duration = int(f.DurationFinite())
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&sb, "#EXTINF:%d,%s\n", duration, title)
+	if scene.ResumeTime > 0 {
+		fmt.Fprintf(&sb, "#EXTVLCOPT:start-time=%.3f\n", scene.ResumeTime)
+	}
+	sb.WriteString(streamURL + "\n")
+
+	w.Header().Set("Content-Type", "audio/x-mpegurl")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write([]byte(sb.String())); err != nil {
+		logger.Warnf("error writing playlist: %v", err)
+	}
+}
+
+// Progress records the playback progress reported by an external player.
+func (rs playerRoutes) Progress(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	var progress playerProgress
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&progress); err != nil {
+		http.Error(w, fmt.Sprintf("invalid progress: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if (progress.ResumeTime != nil && *progress.ResumeTime < 0) || (progress.PlayDuration != nil && *progress.PlayDuration < 0) {
+		http.Error(w, "resume time and play duration must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	if err := txn.WithTxn(r.Context(), rs.txnManager, func(ctx context.Context) error {
+		if progress.ResumeTime != nil || progress.PlayDuration != nil {
+			if _, err := rs.sceneUpdater.SaveActivity(ctx, scene.ID, progress.ResumeTime, progress.PlayDuration); err != nil {
+				return err
+			}
+		}
+
+		if progress.Played {
+			if _, err := rs.sceneUpdater.AddViews(ctx, scene.ID, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("error saving external player progress for scene %d: %v", scene.ID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PlayerCtx validates the player token and loads its scene into the request
+// context.
+func (rs playerRoutes) PlayerCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := manager.GetExternalPlayerClaims(chi.URLParam(r, "playerToken"))
+		if err != nil {
+			http.Error(w, manager.ErrInvalidPlayerToken.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var scene *models.Scene
+		_ = rs.withReadTxn(r, func(ctx context.Context) error {
+			scene, _ = rs.sceneRoutes.sceneFinder.Find(ctx, claims.SceneID)
+			if scene != nil {
+				if err := scene.LoadPrimaryFile(ctx, rs.sceneRoutes.fileGetter); err != nil {
+					if !errors.Is(err, context.Canceled) {
+						logger.Errorf("error loading primary file for scene %d: %v", scene.ID, err)
+					}
+					scene = nil
+				}
+			}
+			return nil
+		})
+		if scene == nil {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), sceneKey, scene)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/pkg/job"
+)
+
+type reportsRoutes struct {
+	artifactStore *job.ArtifactStore
+}
+
+func (rs reportsRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{name}", rs.report)
+
+	return r
+}
+
+func (rs reportsRoutes) report(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	path, err := rs.artifactStore.Path(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": name,
+	}))
+	w.Header().Set("Cache-Control", "no-store")
+	http.ServeFile(w, r, path)
+}
@@ -70,13 +70,17 @@ func (rs sceneRoutes) Routes() chi.Router {
 
 		r.Get("/screenshot", rs.Screenshot)
 		r.Get("/preview", rs.Preview)
+		r.Get("/trailer", rs.Trailer)
 		r.Get("/webp", rs.Webp)
 		r.Get("/vtt/chapter", rs.VttChapter)
 		r.Get("/vtt/thumbs", rs.VttThumbs)
 		r.Get("/vtt/sprite", rs.VttSprite)
+		r.Get("/barcode", rs.Barcode)
 		r.Get("/funscript", rs.Funscript)
 		r.Get("/interactive_csv", rs.InteractiveCSV)
 		r.Get("/interactive_heatmap", rs.InteractiveHeatmap)
+		r.Get("/heatmap", rs.Heatmap)
+		r.Get("/heatmap_data", rs.HeatmapData)
 		r.Get("/caption", rs.CaptionLang)
 
 		r.Get("/scene_marker/{sceneMarkerId}/stream", rs.SceneMarkerStream)
@@ -85,6 +89,7 @@ func (rs sceneRoutes) Routes() chi.Router {
 	})
 	r.Get("/{sceneHash}_thumbs.vtt", rs.VttThumbs)
 	r.Get("/{sceneHash}_sprite.jpg", rs.VttSprite)
+	r.Get("/{sceneHash}_barcode.png", rs.Barcode)
 
 	return r
 }
@@ -158,12 +163,32 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 		VideoFile:  f,
 		Resolution: resolution,
 		StartTime:  ss,
+		Trim:       streamTrim(r, scene),
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
 	streamManager.ServeTranscode(w, r, options)
 }
 
+// streamTrim returns the trim of the scene's transcoded streams. Clients that
+// skip the trimmed parts themselves, such as the stash player, request the
+// whole file by setting the trim query parameter to false. Assumes the query
+// form has been parsed.
+func streamTrim(r *http.Request, scene *models.Scene) ffmpeg.StreamTrim {
+	if trim, err := strconv.ParseBool(r.Form.Get("trim")); err == nil && !trim {
+		return ffmpeg.StreamTrim{}
+	}
+
+	var ret ffmpeg.StreamTrim
+	if scene.StreamStart != nil {
+		ret.Start = *scene.StreamStart
+	}
+	if scene.StreamEnd != nil {
+		ret.End = *scene.StreamEnd
+	}
+	return ret
+}
+
 func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, "HLS")
 }
@@ -193,7 +218,7 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 	resolution := r.Form.Get("resolution")
 
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
-	streamManager.ServeManifest(w, r, streamType, f, resolution)
+	streamManager.ServeManifest(w, r, streamType, f, resolution, streamTrim(r, scene))
 }
 
 func (rs sceneRoutes) StreamHLSSegment(w http.ResponseWriter, r *http.Request) {
@@ -260,6 +285,18 @@ func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+// Trailer serves the generated preview as a video resource for external
+// applications, returning not found if it has not been generated.
+func (rs sceneRoutes) Trailer(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	ss := manager.SceneServer{
+		TxnManager:       rs.txnManager,
+		SceneCoverGetter: rs.sceneFinder,
+	}
+	ss.ServePreview(scene, w, r)
+}
+
 func (rs sceneRoutes) Webp(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
@@ -369,6 +406,19 @@ func (rs sceneRoutes) VttSprite(w http.ResponseWriter, r *http.Request) {
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+func (rs sceneRoutes) Barcode(w http.ResponseWriter, r *http.Request) {
+	scene, ok := r.Context().Value(sceneKey).(*models.Scene)
+	var sceneHash string
+	if ok && scene != nil {
+		sceneHash = scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	} else {
+		sceneHash = chi.URLParam(r, "sceneHash")
+	}
+	filepath := manager.GetInstance().Paths.Scene.GetBarcodeFilePath(sceneHash)
+
+	utils.ServeStaticFile(w, r, filepath)
+}
+
 func (rs sceneRoutes) Funscript(w http.ResponseWriter, r *http.Request) {
 	s := r.Context().Value(sceneKey).(*models.Scene)
 	filepath := video.GetFunscriptPath(s.Path)
@@ -398,6 +448,22 @@ func (rs sceneRoutes) InteractiveHeatmap(w http.ResponseWriter, r *http.Request)
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+func (rs sceneRoutes) Heatmap(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	filepath := manager.GetInstance().Paths.Scene.GetHeatmapPath(sceneHash)
+
+	utils.ServeStaticFile(w, r, filepath)
+}
+
+func (rs sceneRoutes) HeatmapData(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	filepath := manager.GetInstance().Paths.Scene.GetHeatmapDataPath(sceneHash)
+
+	utils.ServeStaticFile(w, r, filepath)
+}
+
 func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang string, ext string) {
 	s := r.Context().Value(sceneKey).(*models.Scene)
 
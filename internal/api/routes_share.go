@@ -0,0 +1,319 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sharelink"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const (
+	sharePasswordHeader = "X-Share-Password"
+	shareViewParameter  = "view"
+)
+
+type ShareLinkFinder interface {
+	FindByToken(ctx context.Context, token string) (*models.ShareLink, error)
+	IncrementViewCount(ctx context.Context, id int) (bool, error)
+}
+
+type shareRoutes struct {
+	routes
+	shareLinkFinder ShareLinkFinder
+	sceneRoutes     sceneRoutes
+	galleryRoutes   galleryRoutes
+}
+
+// shareInfo is returned when opening a share link. It describes the
+// shared content and the relative URLs that can be used to view it.
+// ViewToken must be passed as the view parameter of content URLs that are
+// not returned, such as those of gallery images.
+type shareInfo struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	ViewToken  string `json:"view_token"`
+	Stream     string `json:"stream,omitempty"`
+	Screenshot string `json:"screenshot,omitempty"`
+	Cover      string `json:"cover,omitempty"`
+	ImageCount int    `json:"image_count,omitempty"`
+}
+
+func (rs shareRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{shareToken}", func(r chi.Router) {
+		r.Use(rs.ShareCtx)
+
+		r.With(rs.requireAccess).Get("/", rs.Info)
+		r.With(rs.requireAccess).Get("/oembed", rs.OEmbed)
+		r.Get("/thumbnail", rs.Thumbnail)
+
+		// content endpoints require a view token issued by Info
+		r.Group(func(r chi.Router) {
+			r.Use(rs.requireView)
+
+			// scene endpoints
+			r.With(rs.requireScene).Get("/stream", rs.sceneRoutes.StreamDirect)
+			r.With(rs.requireScene).Get("/screenshot", rs.sceneRoutes.Screenshot)
+
+			// gallery endpoints
+			r.With(rs.requireGallery).Get("/cover", rs.galleryRoutes.Cover)
+			r.With(rs.requireGallery).Get("/preview/{imageIndex}", rs.galleryRoutes.Preview)
+			r.With(rs.requireGallery).Get("/image/{imageIndex}", rs.Image)
+		})
+	})
+
+	return r
+}
+
+// Info returns a description of the shared content, and a view token which
+// grants access to the content. Each call counts as a view of the share link.
+// Requests for HTML, such as from link previews, are served the Open Graph
+// metadata of the content instead.
+func (rs shareRoutes) Info(w http.ResponseWriter, r *http.Request) {
+	if acceptsHTML(r) {
+		rs.Page(w, r)
+		return
+	}
+
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+
+	var counted bool
+	if err := txn.WithTxn(r.Context(), rs.txnManager, func(ctx context.Context) error {
+		var err error
+		counted, err = rs.shareLinkFinder.IncrementViewCount(ctx, link.ID)
+		return err
+	}); err != nil {
+		logger.Errorf("error incrementing view count for share link %d: %v", link.ID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !counted {
+		rs.writeAccessError(w, sharelink.ErrViewsExhausted)
+		return
+	}
+
+	viewToken, err := manager.GenerateShareViewToken(link, time.Now())
+	if err != nil {
+		logger.Errorf("error generating view token for share link %d: %v", link.ID, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	base := getProxyPrefix(r) + "/share/" + link.Token
+	query := "?" + url.Values{shareViewParameter: {viewToken}}.Encode()
+	var info shareInfo
+
+	if scene, ok := r.Context().Value(sceneKey).(*models.Scene); ok {
+		info = shareInfo{
+			Type:       "scene",
+			Title:      scene.DisplayName(),
+			ViewToken:  viewToken,
+			Stream:     base + "/stream" + query,
+			Screenshot: base + "/screenshot" + query,
+		}
+	} else {
+		g := r.Context().Value(galleryKey).(*models.Gallery)
+
+		var count int
+		if err := rs.withReadTxn(r, func(ctx context.Context) error {
+			var err error
+			count, err = rs.galleryRoutes.imageFinder.CountByGalleryID(ctx, g.ID)
+			return err
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		info = shareInfo{
+			Type:       "gallery",
+			Title:      g.DisplayName(),
+			ViewToken:  viewToken,
+			Cover:      base + "/cover" + query,
+			ImageCount: count,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		logger.Warnf("error writing share info: %v", err)
+	}
+}
+
+// Image serves the full image at the given index of the shared gallery.
+func (rs shareRoutes) Image(w http.ResponseWriter, r *http.Request) {
+	g := r.Context().Value(galleryKey).(*models.Gallery)
+
+	index, err := strconv.Atoi(chi.URLParam(r, "imageIndex"))
+	if err != nil || index < 0 {
+		http.Error(w, "bad index", http.StatusBadRequest)
+		return
+	}
+
+	var i *models.Image
+	_ = rs.withReadTxn(r, func(ctx context.Context) error {
+		i, _ = rs.galleryRoutes.imageFinder.FindByGalleryIDIndex(ctx, g.ID, uint(index))
+		if i == nil {
+			return nil
+		}
+
+		if err := i.LoadPrimaryFile(ctx, rs.galleryRoutes.fileGetter); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Errorf("error loading primary file for image %d: %v", i.ID, err)
+			}
+			i = nil
+		}
+
+		return nil
+	})
+	if i == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	const useDefault = false
+	rs.galleryRoutes.imageRoutes.serveImage(w, r, i, useDefault)
+}
+
+func (rs shareRoutes) writeAccessError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sharelink.ErrPasswordRequired), errors.Is(err, sharelink.ErrInvalidPassword):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, sharelink.ErrExpired), errors.Is(err, sharelink.ErrViewsExhausted):
+		http.Error(w, err.Error(), http.StatusGone)
+	default:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	}
+}
+
+func getSharePassword(r *http.Request) string {
+	return r.Header.Get(sharePasswordHeader)
+}
+
+// requireAccess rejects requests for expired links, or without the correct
+// password. View limits are enforced when Info counts the view.
+func (rs shareRoutes) requireAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+
+		if err := sharelink.CheckAccess(link, getSharePassword(r), time.Now(), false); err != nil {
+			rs.writeAccessError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireView rejects requests for expired links, or without a view token
+// issued for the link.
+func (rs shareRoutes) requireView(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+
+		if link.IsExpired(time.Now()) {
+			rs.writeAccessError(w, sharelink.ErrExpired)
+			return
+		}
+
+		if err := manager.VerifyShareViewToken(link, r.URL.Query().Get(shareViewParameter)); err != nil {
+			rs.writeAccessError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rs shareRoutes) requireScene(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(sceneKey).(*models.Scene); !ok {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rs shareRoutes) requireGallery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(galleryKey).(*models.Gallery); !ok {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ShareCtx loads the share link and its target into the request context.
+func (rs shareRoutes) ShareCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := chi.URLParam(r, "shareToken")
+
+		var (
+			link    *models.ShareLink
+			scene   *models.Scene
+			gallery *models.Gallery
+		)
+		_ = rs.withReadTxn(r, func(ctx context.Context) error {
+			link, _ = rs.shareLinkFinder.FindByToken(ctx, token)
+			if link == nil {
+				return nil
+			}
+
+			if link.SceneID != nil {
+				scene, _ = rs.sceneRoutes.sceneFinder.Find(ctx, *link.SceneID)
+				if scene != nil {
+					if err := scene.LoadPrimaryFile(ctx, rs.sceneRoutes.fileGetter); err != nil {
+						if !errors.Is(err, context.Canceled) {
+							logger.Errorf("error loading primary file for scene %d: %v", scene.ID, err)
+						}
+						scene = nil
+					}
+				}
+			}
+
+			if link.GalleryID != nil {
+				gallery, _ = rs.galleryRoutes.galleryFinder.Find(ctx, *link.GalleryID)
+				if gallery != nil {
+					if err := gallery.LoadPrimaryFile(ctx, rs.galleryRoutes.fileGetter); err != nil {
+						if !errors.Is(err, context.Canceled) {
+							logger.Errorf("error loading primary file for gallery %d: %v", gallery.ID, err)
+						}
+						gallery = nil
+					}
+				}
+			}
+
+			return nil
+		})
+
+		if link == nil || (scene == nil && gallery == nil) {
+			http.Error(w, sharelink.ErrNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), shareLinkKey, link)
+		if scene != nil {
+			ctx = context.WithValue(ctx, sceneKey, scene)
+		}
+		if gallery != nil {
+			ctx = context.WithValue(ctx, galleryKey, gallery)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sharelink"
+)
+
+const shareProviderName = "Stash"
+
+// sharePreview is the metadata of a share link shown when the link is
+// unfurled. Fields are omitted according to the preview setting of the link.
+type sharePreview struct {
+	URL       string
+	OEmbedURL string
+	// Type is the Open Graph type of the content.
+	Type      string
+	Title     string
+	Thumbnail string
+	Width     int
+	Height    int
+	// Duration is in whole seconds.
+	Duration int
+}
+
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{.Title}}</title>
+<meta property="og:site_name" content="` + shareProviderName + `">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:type" content="{{.Type}}">
+<meta property="og:url" content="{{.URL}}">
+{{- if .Thumbnail}}
+<meta property="og:image" content="{{.Thumbnail}}">
+{{- if .Width}}
+<meta property="og:image:width" content="{{.Width}}">
+<meta property="og:image:height" content="{{.Height}}">
+{{- end}}
+<meta name="twitter:card" content="summary_large_image">
+{{- end}}
+{{- if .Duration}}
+<meta property="video:duration" content="{{.Duration}}">
+{{- end}}
+{{- if .OEmbedURL}}
+<link rel="alternate" type="application/json+oembed" href="{{.OEmbedURL}}" title="{{.Title}}">
+{{- end}}
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Thumbnail}}
+<img src="{{.Thumbnail}}" alt="">
+{{- end}}
+</body>
+</html>
+`))
+
+// acceptsHTML returns true if the request is from a browser or a link
+// preview, rather than from an API client.
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// getPreview returns the preview of the share link in the request context,
+// and the preview setting that applies to it.
+func (rs shareRoutes) getPreview(r *http.Request) (sharePreview, models.ShareLinkPreview) {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+	shareURL := urlbuilders.NewShareLinkURLBuilder(baseURL, link).GetShareURL()
+
+	level := sharelink.GetPreview(link, time.Now())
+	ret := sharePreview{
+		URL:   shareURL,
+		Type:  "website",
+		Title: "Shared content",
+	}
+
+	if level == models.ShareLinkPreviewNone {
+		return ret, level
+	}
+
+	ret.OEmbedURL = shareURL + "/oembed"
+
+	if scene, ok := r.Context().Value(sceneKey).(*models.Scene); ok {
+		ret.Title = scene.DisplayName()
+		if level == models.ShareLinkPreviewFull {
+			ret.Type = "video.other"
+			ret.Thumbnail = shareURL + "/thumbnail"
+			if f := scene.Files.Primary(); f != nil {
+				ret.Width = f.Width
+				ret.Height = f.Height
+				ret.Duration = int(math.Round(f.Duration))
+			}
+		}
+	} else {
+		g := r.Context().Value(galleryKey).(*models.Gallery)
+		ret.Title = g.DisplayName()
+		if level == models.ShareLinkPreviewFull {
+			ret.Thumbnail = shareURL + "/thumbnail"
+		}
+	}
+
+	return ret, level
+}
+
+// Page serves an HTML page with the Open Graph metadata of the shared
+// content. It does not count as a view of the share link.
+func (rs shareRoutes) Page(w http.ResponseWriter, r *http.Request) {
+	preview, _ := rs.getPreview(r)
+
+	var buf bytes.Buffer
+	if err := sharePageTemplate.Execute(&buf, preview); err != nil {
+		logger.Errorf("error rendering share page: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src 'self'")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Warnf("error writing share page: %v", err)
+	}
+}
+
+// Thumbnail serves the screenshot or cover of the shared content for link
+// previews. It is only available if the preview of the link includes the
+// thumbnail, and does not count as a view of the share link.
+func (rs shareRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
+	link := r.Context().Value(shareLinkKey).(*models.ShareLink)
+	if sharelink.GetPreview(link, time.Now()) != models.ShareLinkPreviewFull {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	if _, ok := r.Context().Value(sceneKey).(*models.Scene); ok {
+		rs.sceneRoutes.Screenshot(w, r)
+		return
+	}
+
+	rs.galleryRoutes.Cover(w, r)
+}
+
+// shareOEmbed is an oEmbed link response. Duration is not part of the oEmbed
+// specification, but is read by some consumers.
+type shareOEmbed struct {
+	Version         string `json:"version"`
+	Type            string `json:"type"`
+	ProviderName    string `json:"provider_name"`
+	Title           string `json:"title"`
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+	Duration        int    `json:"duration,omitempty"`
+}
+
+// OEmbed serves the oEmbed metadata of the shared content. It does not count
+// as a view of the share link.
+func (rs shareRoutes) OEmbed(w http.ResponseWriter, r *http.Request) {
+	if format := r.URL.Query().Get("format"); format != "" && format != "json" {
+		http.Error(w, "only the json format is supported", http.StatusNotImplemented)
+		return
+	}
+
+	preview, level := rs.getPreview(r)
+	if level == models.ShareLinkPreviewNone {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	ret := shareOEmbed{
+		Version:      "1.0",
+		Type:         "link",
+		ProviderName: shareProviderName,
+		Title:        preview.Title,
+		ThumbnailURL: preview.Thumbnail,
+		Duration:     preview.Duration,
+	}
+
+	// thumbnail dimensions must be provided together
+	if preview.Thumbnail != "" && preview.Width > 0 && preview.Height > 0 {
+		ret.ThumbnailWidth = preview.Width
+		ret.ThumbnailHeight = preview.Height
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(ret); err != nil {
+		logger.Warnf("error writing share oembed: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+var ogImageRE = regexp.MustCompile(`<meta property="og:image" content="([^"]+)">`)
+
+func newTestShareRouter(db *mocks.Database) http.Handler {
+	rs := shareRoutes{
+		routes:          routes{txnManager: db},
+		shareLinkFinder: db.ShareLink,
+		sceneRoutes: sceneRoutes{
+			routes:      routes{txnManager: db},
+			sceneFinder: db.Scene,
+			fileGetter:  db.File,
+		},
+	}
+
+	r := chi.NewRouter()
+	r.Mount(shareEndpoint, rs.Routes())
+	return r
+}
+
+func TestShareThumbnail(t *testing.T) {
+	const (
+		token   = "share-token"
+		sceneID = 1
+	)
+
+	// PNG signature, so that the content type is detected
+	cover := []byte("\x89PNG\r\n\x1a\n")
+
+	sid := sceneID
+	link := &models.ShareLink{
+		ID:      1,
+		Token:   token,
+		SceneID: &sid,
+		Preview: models.ShareLinkPreviewFull,
+	}
+
+	db := mocks.NewDatabase()
+	db.ShareLink.On("FindByToken", mock.Anything, token).Return(link, nil)
+	db.Scene.On("Find", mock.Anything, sceneID).Return(&models.Scene{ID: sceneID, Title: "scene"}, nil)
+	db.Scene.On("GetCover", mock.Anything, sceneID).Return(cover, nil)
+
+	router := newTestShareRouter(db)
+
+	r := httptest.NewRequest(http.MethodGet, shareEndpoint+"/"+token, nil)
+	r.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if !assert.Equal(t, http.StatusOK, w.Code) {
+		return
+	}
+
+	m := ogImageRE.FindStringSubmatch(w.Body.String())
+	if !assert.Len(t, m, 2, "og:image not found in page") {
+		return
+	}
+
+	// the thumbnail does not require a view token
+	r = httptest.NewRequest(http.MethodGet, m[1], nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, cover, w.Body.Bytes())
+
+	// neither counts as a view
+	db.ShareLink.AssertNotCalled(t, "IncrementViewCount", mock.Anything, mock.Anything)
+
+	// the thumbnail is not available if the preview does not include it
+	link.Preview = models.ShareLinkPreviewTitle
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, m[1], nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	link.Preview = models.ShareLinkPreviewFull
+	link.Password = "hash"
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, m[1], nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
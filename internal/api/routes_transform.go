@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// transformRoutes serve resized, cropped, rotated and converted images and
+// scene screenshots. Requests must include the signature of the transform,
+// which is added to the URLs returned by the GraphQL API. This allows the
+// URLs to be used by clients without credentials, and prevents arbitrary
+// transforms from being requested.
+type transformRoutes struct {
+	routes
+	imageRoutes imageRoutes
+	sceneRoutes sceneRoutes
+}
+
+func (rs transformRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/image/{imageId}", func(r chi.Router) {
+		r.Use(rs.TransformCtx("imageId", manager.ImageTransformSource))
+		r.Use(rs.imageRoutes.ImageCtx)
+
+		r.Get("/", rs.Image)
+	})
+
+	r.Route("/scene/{sceneId}/screenshot", func(r chi.Router) {
+		r.Use(rs.TransformCtx("sceneId", manager.SceneScreenshotTransformSource))
+		r.Use(rs.sceneRoutes.SceneCtx)
+
+		r.Get("/", rs.SceneScreenshot)
+	})
+
+	return r
+}
+
+func (rs transformRoutes) Image(w http.ResponseWriter, r *http.Request) {
+	img := r.Context().Value(imageKey).(*models.Image)
+	t := r.Context().Value(transformKey).(image.Transform)
+
+	f := img.Files.Primary()
+	if f == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	version := manager.ImageTransformSource(img.ID) + "@" + img.Checksum + "-" + strconv.FormatInt(img.UpdatedAt.Unix(), 10)
+	manager.GetInstance().ServeImageTransform(w, r, version, t, func(ctx context.Context) ([]byte, error) {
+		reader, err := f.Base().Open(&file.OsFS{})
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	})
+}
+
+func (rs transformRoutes) SceneScreenshot(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	t := r.Context().Value(transformKey).(image.Transform)
+
+	ss := manager.SceneServer{
+		TxnManager:       rs.txnManager,
+		SceneCoverGetter: rs.sceneRoutes.sceneFinder,
+	}
+	ss.ServeScreenshotTransform(scene, t, w, r)
+}
+
+// TransformCtx parses the transform from the query parameters, and verifies
+// its signature for the source with the id in the named URL parameter.
+func (rs transformRoutes) TransformCtx(idParam string, source func(id int) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := strconv.Atoi(chi.URLParam(r, idParam))
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				return
+			}
+
+			q := r.URL.Query()
+			t, err := image.ParseTransform(q)
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, image.ErrInvalidTransform) {
+					status = http.StatusBadRequest
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+
+			exp, err := strconv.ParseInt(q.Get("exp"), 10, 64)
+			if err != nil || !manager.VerifyImageTransform(source(id), t, time.Unix(exp, 0), q.Get("sig"), time.Now()) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), transformKey, t)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
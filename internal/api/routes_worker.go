@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/worker"
+)
+
+// workerRoutes are used by remote workers to take generate jobs.
+type workerRoutes struct {
+	pool *worker.Pool
+}
+
+func (rs workerRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(rs.enabled)
+
+	r.Post("/register", rs.Register)
+
+	r.Route("/{workerId}", func(r chi.Router) {
+		r.Post("/next", rs.Next)
+
+		r.Route("/jobs/{jobId}", func(r chi.Router) {
+			r.Post("/heartbeat", rs.Heartbeat)
+			r.Get("/source", rs.Source)
+			r.Put("/artifacts/{name}", rs.Artifact)
+			r.Post("/complete", rs.Complete)
+		})
+	})
+
+	return r
+}
+
+func (rs workerRoutes) enabled(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !config.GetInstance().GetRemoteWorkersEnabled() {
+			http.Error(w, "remote workers are not enabled", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func workerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, worker.ErrUnknownWorker):
+		// message is matched by the client to re-register
+		http.Error(w, worker.ErrUnknownWorker.Error(), http.StatusNotFound)
+	case errors.Is(err, worker.ErrUnknownJob), errors.Is(err, worker.ErrUnknownArtifact):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		logger.Errorf("remote worker error: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warnf("error writing response: %v", err)
+	}
+}
+
+func (rs workerRoutes) Register(w http.ResponseWriter, r *http.Request) {
+	var input worker.RegisterInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ret := rs.pool.Register(input.Name, input.Types)
+	logger.Infof("Remote worker %s registered from %s", input.Name, r.RemoteAddr)
+
+	writeJSON(w, ret)
+}
+
+func (rs workerRoutes) Next(w http.ResponseWriter, r *http.Request) {
+	job, err := rs.pool.Next(chi.URLParam(r, "workerId"))
+	if err != nil {
+		workerError(w, err)
+		return
+	}
+
+	if job == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, job)
+}
+
+func (rs workerRoutes) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	if err := rs.pool.Heartbeat(chi.URLParam(r, "workerId"), chi.URLParam(r, "jobId")); err != nil {
+		workerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rs workerRoutes) Source(w http.ResponseWriter, r *http.Request) {
+	fn, err := rs.pool.SourcePath(chi.URLParam(r, "workerId"), chi.URLParam(r, "jobId"))
+	if err != nil {
+		workerError(w, err)
+		return
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		workerError(w, err)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		workerError(w, err)
+		return
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+func (rs workerRoutes) Artifact(w http.ResponseWriter, r *http.Request) {
+	dest, err := rs.pool.ArtifactPath(chi.URLParam(r, "workerId"), chi.URLParam(r, "jobId"), chi.URLParam(r, "name"))
+	if err != nil {
+		workerError(w, err)
+		return
+	}
+
+	if err := writeArtifact(dest, r.Body); err != nil {
+		workerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeArtifact writes to a temporary file next to dest, so that dest is
+// not left incomplete if the upload fails.
+func writeArtifact(dest string, body io.Reader) error {
+	dir := filepath.Dir(dest)
+	if err := fsutil.EnsureDir(dir); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dest)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (rs workerRoutes) Complete(w http.ResponseWriter, r *http.Request) {
+	var result worker.Result
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := rs.pool.Complete(chi.URLParam(r, "workerId"), chi.URLParam(r, "jobId"), result); err != nil {
+		workerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -43,8 +43,18 @@ import (
 const (
 	loginEndpoint      = "/login"
 	logoutEndpoint     = "/logout"
+	restrictedEndpoint = "/restricted"
 	gqlEndpoint        = "/graphql"
 	playgroundEndpoint = "/playground"
+	shareEndpoint      = "/share"
+	playerEndpoint     = "/player"
+	transformEndpoint  = "/transform"
+)
+
+// number of parsed graphql queries to cache
+const (
+	queryCacheSizeDefault   = 1000
+	queryCacheSizeLowMemory = 100
 )
 
 type Server struct {
@@ -125,6 +135,7 @@ func Initialize() (*Server, error) {
 	r.Use(authenticateHandler())
 	visitedPluginHandler := mgr.SessionStore.VisitedPluginHandler()
 	r.Use(visitedPluginHandler)
+	r.Use(pluginRoutePermissionsHandler)
 
 	r.Use(middleware.Recoverer)
 
@@ -181,13 +192,20 @@ func Initialize() (*Server, error) {
 	})
 	gqlSrv.AddTransport(gqlTransport.Options{})
 	gqlSrv.AddTransport(gqlTransport.GET{})
+	// must be added before POST, which also supports these requests
+	gqlSrv.AddTransport(multipartMixedTransport{})
 	gqlSrv.AddTransport(gqlTransport.POST{})
 	gqlSrv.AddTransport(gqlTransport.MultipartForm{
 		MaxUploadSize: cfg.GetMaxUploadSize(),
 	})
 
-	gqlSrv.SetQueryCache(gqlLru.New[*ast.QueryDocument](1000))
+	queryCacheSize := queryCacheSizeDefault
+	if cfg.GetLowMemoryMode() {
+		queryCacheSize = queryCacheSizeLowMemory
+	}
+	gqlSrv.SetQueryCache(gqlLru.New[*ast.QueryDocument](queryCacheSize))
 	gqlSrv.Use(gqlExtension.Introspection{})
+	gqlSrv.AroundFields(pluginPermissionsMiddleware)
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
 
@@ -217,7 +235,13 @@ func Initialize() (*Server, error) {
 	r.Mount("/group", server.getGroupRoutes())
 	r.Mount("/tag", server.getTagRoutes())
 	r.Mount("/downloads", server.getDownloadsRoutes())
+	r.Mount("/reports", server.getReportsRoutes())
 	r.Mount("/plugin", server.getPluginRoutes())
+	r.Mount("/worker", server.getWorkerRoutes())
+	r.Mount("/integrations", server.getIntegrationRoutes())
+	r.Mount(shareEndpoint, server.getShareRoutes())
+	r.Mount(playerEndpoint, server.getPlayerRoutes())
+	r.Mount(transformEndpoint, server.getTransformRoutes())
 
 	r.HandleFunc("/css", cssHandler(cfg))
 	r.HandleFunc("/javascript", javascriptHandler(cfg))
@@ -228,6 +252,8 @@ func Initialize() (*Server, error) {
 	r.Get(loginEndpoint, handleLogin())
 	r.Post(loginEndpoint, handleLoginPost())
 	r.Get(logoutEndpoint, handleLogout())
+	r.Post(restrictedEndpoint+"/unlock", handleUnlockRestricted())
+	r.Post(restrictedEndpoint+"/lock", handleLockRestricted())
 	r.HandleFunc(loginEndpoint+"/*", func(w http.ResponseWriter, r *http.Request) {
 		r.URL.Path = strings.TrimPrefix(r.URL.Path, loginEndpoint)
 		w.Header().Set("Cache-Control", "no-cache")
@@ -378,16 +404,91 @@ func (s *Server) getTagRoutes() chi.Router {
 	}.Routes()
 }
 
+func (s *Server) getShareRoutes() chi.Router {
+	repo := s.manager.Repository
+	rts := routes{txnManager: repo.TxnManager}
+	return shareRoutes{
+		routes:          rts,
+		shareLinkFinder: repo.ShareLink,
+		sceneRoutes: sceneRoutes{
+			routes:      rts,
+			sceneFinder: repo.Scene,
+			fileGetter:  repo.File,
+		},
+		galleryRoutes: galleryRoutes{
+			routes: rts,
+			imageRoutes: imageRoutes{
+				routes:      rts,
+				imageFinder: repo.Image,
+				fileGetter:  repo.File,
+			},
+			imageFinder:   repo.Image,
+			galleryFinder: repo.Gallery,
+			fileGetter:    repo.File,
+		},
+	}.Routes()
+}
+
+func (s *Server) getPlayerRoutes() chi.Router {
+	repo := s.manager.Repository
+	rts := routes{txnManager: repo.TxnManager}
+	return playerRoutes{
+		routes:       rts,
+		sceneUpdater: repo.Scene,
+		sceneRoutes: sceneRoutes{
+			routes:      rts,
+			sceneFinder: repo.Scene,
+			fileGetter:  repo.File,
+		},
+	}.Routes()
+}
+
+func (s *Server) getTransformRoutes() chi.Router {
+	repo := s.manager.Repository
+	rts := routes{txnManager: repo.TxnManager}
+	return transformRoutes{
+		routes: rts,
+		imageRoutes: imageRoutes{
+			routes:      rts,
+			imageFinder: repo.Image,
+			fileGetter:  repo.File,
+		},
+		sceneRoutes: sceneRoutes{
+			routes:      rts,
+			sceneFinder: repo.Scene,
+			fileGetter:  repo.File,
+		},
+	}.Routes()
+}
+
 func (s *Server) getDownloadsRoutes() chi.Router {
 	return downloadsRoutes{}.Routes()
 }
 
+func (s *Server) getReportsRoutes() chi.Router {
+	return reportsRoutes{
+		artifactStore: s.manager.ArtifactStore,
+	}.Routes()
+}
+
 func (s *Server) getPluginRoutes() chi.Router {
 	return pluginRoutes{
 		pluginCache: s.manager.PluginCache,
 	}.Routes()
 }
 
+func (s *Server) getWorkerRoutes() chi.Router {
+	return workerRoutes{
+		pool: s.manager.RemoteWorkers,
+	}.Routes()
+}
+
+func (s *Server) getIntegrationRoutes() chi.Router {
+	return integrationRoutes{
+		manager: s.manager,
+	}.Routes()
+}
+
 func copyFile(w io.Writer, path string) error {
 	f, err := os.Open(path)
 	if err != nil {
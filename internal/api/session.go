@@ -30,9 +30,15 @@ func getLoginPage() []byte {
 type loginTemplateData struct {
 	URL   string
 	Error string
+	// TwoFactor is true if the two-factor code should be requested
+	TwoFactor bool
 }
 
 func serveLoginPage(w http.ResponseWriter, r *http.Request, returnURL string, loginError string) {
+	serveLoginTemplate(w, r, loginTemplateData{URL: returnURL, Error: loginError})
+}
+
+func serveLoginTemplate(w http.ResponseWriter, r *http.Request, data loginTemplateData) {
 	loginPage := string(getLoginPage())
 	prefix := getProxyPrefix(r)
 	loginPage = strings.ReplaceAll(loginPage, "/%BASE_URL%", prefix)
@@ -44,7 +50,7 @@ func serveLoginPage(w http.ResponseWriter, r *http.Request, returnURL string, lo
 	}
 
 	buffer := bytes.Buffer{}
-	err = templ.Execute(&buffer, loginTemplateData{URL: returnURL, Error: loginError})
+	err = templ.Execute(&buffer, data)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("error: %s", err), http.StatusInternalServerError)
 		return
@@ -84,7 +90,7 @@ func handleLoginPost() http.HandlerFunc {
 		}
 
 		err := manager.GetInstance().SessionStore.Login(w, r)
-		if err != nil {
+		if err != nil && !errors.Is(err, session.ErrTwoFactorRequired) {
 			// always log the error
 			logger.Errorf("Error logging in: %v", err)
 		}
@@ -97,6 +103,18 @@ func handleLoginPost() http.HandlerFunc {
 			return
 		}
 
+		switch {
+		case errors.Is(err, session.ErrTwoFactorRequired):
+			serveLoginTemplate(w, r, loginTemplateData{URL: url, TwoFactor: true})
+			return
+		case errors.Is(err, session.ErrInvalidTwoFactorCode):
+			serveLoginTemplate(w, r, loginTemplateData{URL: url, TwoFactor: true, Error: "Authentication code is invalid"})
+			return
+		case errors.Is(err, session.ErrTooManyTwoFactorAttempts):
+			serveLoginPage(w, r, url, "Too many invalid authentication codes")
+			return
+		}
+
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -122,3 +140,33 @@ func handleLogout() http.HandlerFunc {
 		}
 	}
 }
+
+func handleUnlockRestricted() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := manager.GetInstance().SessionStore.UnlockRestrictedContent(w, r, config.GetInstance().HasCredentials())
+
+		var invalidCredentialsError *session.InvalidCredentialsError
+		if errors.As(err, &invalidCredentialsError) {
+			http.Error(w, "Password is invalid", http.StatusUnauthorized)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func handleLockRestricted() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := manager.GetInstance().SessionStore.LockRestrictedContent(w, r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,29 @@
+package urlbuilders
+
+type ExternalPlayerURLBuilder struct {
+	BaseURL string
+	Token   string
+}
+
+func NewExternalPlayerURLBuilder(baseURL string, token string) ExternalPlayerURLBuilder {
+	return ExternalPlayerURLBuilder{
+		BaseURL: baseURL,
+		Token:   token,
+	}
+}
+
+func (b ExternalPlayerURLBuilder) base() string {
+	return b.BaseURL + "/player/" + b.Token
+}
+
+func (b ExternalPlayerURLBuilder) GetStreamURL() string {
+	return b.base() + "/stream"
+}
+
+func (b ExternalPlayerURLBuilder) GetPlaylistURL() string {
+	return b.base() + "/playlist.m3u"
+}
+
+func (b ExternalPlayerURLBuilder) GetProgressURL() string {
+	return b.base() + "/progress"
+}
@@ -25,3 +25,7 @@ func (b GalleryURLBuilder) GetPreviewURL() string {
 func (b GalleryURLBuilder) GetCoverURL() string {
 	return b.BaseURL + "/gallery/" + b.GalleryID + "/cover"
 }
+
+func (b GalleryURLBuilder) GetDownloadURL() string {
+	return b.BaseURL + "/gallery/" + b.GalleryID + "/download"
+}
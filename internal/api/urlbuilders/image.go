@@ -2,9 +2,11 @@ package urlbuilders
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -39,3 +41,9 @@ func (b ImageURLBuilder) GetPreviewURL() string {
 		return ""
 	}
 }
+
+// GetTransformURL returns the URL of the transform of the image, including
+// its signature and expiry time.
+func (b ImageURLBuilder) GetTransformURL(t image.Transform, sig string, expiresAt time.Time) string {
+	return b.BaseURL + "/transform/image/" + b.ImageID + "?" + transformQuery(t, sig, expiresAt, b.UpdatedAt)
+}
@@ -0,0 +1,19 @@
+package urlbuilders
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/image"
+)
+
+// transformQuery returns the query of a transform URL. The update time is
+// not part of the signature, and only prevents stale images from being
+// cached by clients.
+func transformQuery(t image.Transform, sig string, expiresAt time.Time, updatedAt string) string {
+	q := t.Query()
+	q.Set("exp", strconv.FormatInt(expiresAt.Unix(), 10))
+	q.Set("sig", sig)
+	q.Set("t", updatedAt)
+	return q.Encode()
+}
@@ -0,0 +1,23 @@
+package urlbuilders
+
+import (
+	"net/url"
+
+	"github.com/stashapp/stash/pkg/job"
+)
+
+type JobArtifactURLBuilder struct {
+	BaseURL string
+	Name    string
+}
+
+func NewJobArtifactURLBuilder(baseURL string, artifact *job.Artifact) JobArtifactURLBuilder {
+	return JobArtifactURLBuilder{
+		BaseURL: baseURL,
+		Name:    artifact.Name,
+	}
+}
+
+func (b JobArtifactURLBuilder) GetDownloadURL() string {
+	return b.BaseURL + "/reports/" + url.PathEscape(b.Name)
+}
@@ -27,3 +27,9 @@ func (b PerformerURLBuilder) GetPerformerImageURL(hasImage bool) string {
 	}
 	return url
 }
+
+// GetPerformerSetImageURL returns the URL of an image in the image set of the
+// performer. The checksum is used to invalidate cached images.
+func (b PerformerURLBuilder) GetPerformerSetImageURL(image *models.PerformerImage) string {
+	return b.BaseURL + "/performer/" + b.PerformerID + "/images/" + strconv.Itoa(image.ID) + "?t=" + image.Checksum
+}
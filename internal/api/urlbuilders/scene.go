@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -37,6 +39,23 @@ func (b SceneURLBuilder) GetStreamURL(apiKey string) *url.URL {
 	return u
 }
 
+// GetTrailerURL returns the URL of the generated preview, including the
+// API key if set, so that it may be used by external applications.
+func (b SceneURLBuilder) GetTrailerURL(apiKey string) *url.URL {
+	u, err := url.Parse(fmt.Sprintf("%s/scene/%s/trailer", b.BaseURL, b.SceneID))
+	if err != nil {
+		// shouldn't happen
+		panic(err)
+	}
+
+	if apiKey != "" {
+		v := u.Query()
+		v.Set("apikey", apiKey)
+		u.RawQuery = v.Encode()
+	}
+	return u
+}
+
 func (b SceneURLBuilder) GetStreamPreviewURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/preview"
 }
@@ -53,10 +72,20 @@ func (b SceneURLBuilder) GetSpriteURL(checksum string) string {
 	return b.BaseURL + "/scene/" + checksum + "_sprite.jpg"
 }
 
+func (b SceneURLBuilder) GetBarcodeURL(checksum string) string {
+	return b.BaseURL + "/scene/" + checksum + "_barcode.png"
+}
+
 func (b SceneURLBuilder) GetScreenshotURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/screenshot?t=" + b.UpdatedAt
 }
 
+// GetScreenshotTransformURL returns the URL of the transform of the
+// screenshot, including its signature and expiry time.
+func (b SceneURLBuilder) GetScreenshotTransformURL(t image.Transform, sig string, expiresAt time.Time) string {
+	return b.BaseURL + "/transform/scene/" + b.SceneID + "/screenshot?" + transformQuery(t, sig, expiresAt, b.UpdatedAt)
+}
+
 func (b SceneURLBuilder) GetFunscriptURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/funscript"
 }
@@ -68,3 +97,11 @@ func (b SceneURLBuilder) GetCaptionURL() string {
 func (b SceneURLBuilder) GetInteractiveHeatmapURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/interactive_heatmap"
 }
+
+func (b SceneURLBuilder) GetHeatmapURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/heatmap"
+}
+
+func (b SceneURLBuilder) GetHeatmapDataURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/heatmap_data"
+}
@@ -0,0 +1,21 @@
+package urlbuilders
+
+import (
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type ShareLinkURLBuilder struct {
+	BaseURL string
+	Token   string
+}
+
+func NewShareLinkURLBuilder(baseURL string, link *models.ShareLink) ShareLinkURLBuilder {
+	return ShareLinkURLBuilder{
+		BaseURL: baseURL,
+		Token:   link.Token,
+	}
+}
+
+func (b ShareLinkURLBuilder) GetShareURL() string {
+	return b.BaseURL + "/share/" + b.Token
+}
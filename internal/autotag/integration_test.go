@@ -609,7 +609,7 @@ func TestParseStudioScenes(t *testing.T) {
 				return err
 			}
 
-			return tagger.StudioScenes(ctx, s, nil, aliases, r.Scene)
+			return tagger.StudioScenes(ctx, s, nil, aliases, r.Scene, r.Studio)
 		}); err != nil {
 			t.Errorf("Error auto-tagging performers: %s", err)
 		}
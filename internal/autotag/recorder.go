@@ -0,0 +1,80 @@
+package autotag
+
+import (
+	"context"
+	"sync"
+)
+
+// Change is a performer, studio or tag that auto-tag added to a scene, image
+// or gallery.
+type Change struct {
+	// Type is the type of the object: scene, image or gallery.
+	Type string
+	ID   int
+	Name string
+	// AddedType is the type of the added object: performer, studio or tag.
+	AddedType string
+	AddedID   int
+	AddedName string
+}
+
+// Recorder records the changes made by auto-tag. It is safe for concurrent
+// use.
+type Recorder struct {
+	mutex   sync.Mutex
+	changes []Change
+}
+
+func (r *Recorder) record(c Change) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.changes = append(r.changes, c)
+}
+
+// Changes returns the recorded changes in the order they were made.
+func (r *Recorder) Changes() []Change {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Change(nil), r.changes...)
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context that records the changes made by auto-tag
+// to r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+func recorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// change returns the change of adding the other object to the tagger object.
+// The change is always from the point of view of the scene, image or
+// gallery.
+func (t *tagger) change(otherType string, otherID int, otherName string) Change {
+	switch otherType {
+	case "scene", "image", "gallery":
+		return Change{
+			Type:      otherType,
+			ID:        otherID,
+			Name:      otherName,
+			AddedType: t.Type,
+			AddedID:   t.ID,
+			AddedName: t.Name,
+		}
+	}
+
+	return Change{
+		Type:      t.Type,
+		ID:        t.ID,
+		Name:      t.Name,
+		AddedType: otherType,
+		AddedID:   otherID,
+		AddedName: otherName,
+	}
+}
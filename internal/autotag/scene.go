@@ -14,6 +14,20 @@ type SceneFinderUpdater interface {
 	models.SceneUpdater
 }
 
+// SceneStudioUpdater provides the methods needed to set the studio of scenes
+// and apply the studio defaults.
+type SceneStudioUpdater interface {
+	SceneFinderUpdater
+	scene.StudioDefaultsWriter
+}
+
+// SceneStudioReader provides the methods needed to find studios to tag
+// scenes with and read the studio defaults.
+type SceneStudioReader interface {
+	models.StudioAutoTagQueryer
+	scene.StudioDefaultsReader
+}
+
 type ScenePerformerUpdater interface {
 	models.PerformerIDLoader
 	models.SceneUpdater
@@ -59,7 +73,7 @@ func ScenePerformers(ctx context.Context, s *models.Scene, rw ScenePerformerUpda
 // SceneStudios tags the provided scene with the first studio whose name matches the scene's path.
 //
 // Scenes will not be tagged if studio is already set.
-func SceneStudios(ctx context.Context, s *models.Scene, rw SceneFinderUpdater, studioReader models.StudioAutoTagQueryer, cache *match.Cache) error {
+func SceneStudios(ctx context.Context, s *models.Scene, rw SceneStudioUpdater, studioReader SceneStudioReader, cache *match.Cache) error {
 	if s.StudioID != nil {
 		// don't modify
 		return nil
@@ -68,7 +82,7 @@ func SceneStudios(ctx context.Context, s *models.Scene, rw SceneFinderUpdater, s
 	t := getSceneFileTagger(s, cache)
 
 	return t.tagStudios(ctx, studioReader, func(subjectID, otherID int) (bool, error) {
-		return addSceneStudio(ctx, rw, s, otherID)
+		return addSceneStudio(ctx, rw, studioReader, s, otherID)
 	})
 }
 
@@ -248,6 +248,10 @@ func TestSceneStudios(t *testing.T) {
 				return scenePartialsEqual(got, expected)
 			})
 			db.Scene.On("UpdatePartial", testCtx, sceneID, matchPartial).Return(nil, nil).Once()
+			db.Scene.On("SetFieldSources", testCtx, sceneID, mock.Anything).Return(nil).Once()
+			db.Studio.On("Find", testCtx, studioID).Return(&models.Studio{ID: studioID}, nil).Once()
+			db.Studio.On("GetDefaultTagIDs", testCtx, studioID).Return(nil, nil).Once()
+			db.Studio.On("GetDefaultURLs", testCtx, studioID).Return(nil, nil).Once()
 		}
 
 		scene := models.Scene{
@@ -5,13 +5,27 @@ import (
 
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/txn"
 )
 
+// setAutoTagFieldSource records auto tagging as the source of the field
+// if the writer supports field sources. Only fields that auto tagging sets
+// when empty are recorded, since adding performers and tags to the
+// existing values does not replace a manually set value.
+func setAutoTagFieldSource(ctx context.Context, w interface{}, id int, field string) error {
+	fw, ok := w.(models.FieldSourceWriter)
+	if !ok {
+		return nil
+	}
+
+	return fw.SetFieldSources(ctx, id, models.NewFieldSources([]string{field}, models.FieldSourceTypeAutoTag, ""))
+}
+
 // the following functions aren't used in Tagger because they assume
 // use within a transaction
 
-func addSceneStudio(ctx context.Context, sceneWriter models.SceneUpdater, o *models.Scene, studioID int) (bool, error) {
+func addSceneStudio(ctx context.Context, sceneWriter scene.StudioDefaultsWriter, studioReader scene.StudioDefaultsReader, o *models.Scene, studioID int) (bool, error) {
 	// don't set if already set
 	if o.StudioID != nil {
 		return false, nil
@@ -24,6 +38,16 @@ func addSceneStudio(ctx context.Context, sceneWriter models.SceneUpdater, o *mod
 	if _, err := sceneWriter.UpdatePartial(ctx, o.ID, scenePartial); err != nil {
 		return false, err
 	}
+	if err := setAutoTagFieldSource(ctx, sceneWriter, o.ID, "studio"); err != nil {
+		return false, err
+	}
+
+	updated := *o
+	updated.StudioID = &studioID
+	if _, err := scene.ApplyStudioDefaults(ctx, sceneWriter, studioReader, &updated); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -79,27 +103,20 @@ func getStudioTagger(p *models.Studio, aliases []string, cache *match.Cache) []t
 }
 
 // StudioScenes searches for scenes whose path matches the provided studio name and tags the scene with the studio, if studio is not already set on the scene.
-func (tagger *Tagger) StudioScenes(ctx context.Context, p *models.Studio, paths []string, aliases []string, rw SceneFinderUpdater) error {
+func (tagger *Tagger) StudioScenes(ctx context.Context, p *models.Studio, paths []string, aliases []string, rw SceneStudioUpdater, studioReader scene.StudioDefaultsReader) error {
 	t := getStudioTagger(p, aliases, tagger.Cache)
 
 	for _, tt := range t {
 		if err := tt.tagScenes(ctx, paths, rw, func(o *models.Scene) (bool, error) {
-			// don't set if already set
-			if o.StudioID != nil {
-				return false, nil
-			}
-
-			// set the studio id
-			scenePartial := models.NewScenePartial()
-			scenePartial.StudioID = models.NewOptionalInt(p.ID)
-
+			var added bool
 			if err := txn.WithTxn(ctx, tagger.TxnManager, func(ctx context.Context) error {
-				_, err := rw.UpdatePartial(ctx, o.ID, scenePartial)
+				var err error
+				added, err = addSceneStudio(ctx, rw, studioReader, o, p.ID)
 				return err
 			}); err != nil {
 				return false, err
 			}
-			return true, nil
+			return added, nil
 		}); err != nil {
 			return err
 		}
@@ -160,13 +160,18 @@ func testStudioScenes(t *testing.T, tc testStudioCase) {
 			return scenePartialsEqual(got, expected)
 		})
 		db.Scene.On("UpdatePartial", mock.Anything, sceneID, matchPartial).Return(nil, nil).Once()
+		db.Scene.On("SetFieldSources", mock.Anything, sceneID, mock.Anything).Return(nil).Once()
 	}
 
+	db.Studio.On("Find", mock.Anything, studioID).Return(&studio, nil)
+	db.Studio.On("GetDefaultTagIDs", mock.Anything, studioID).Return(nil, nil)
+	db.Studio.On("GetDefaultURLs", mock.Anything, studioID).Return(nil, nil)
+
 	tagger := Tagger{
 		TxnManager: db,
 	}
 
-	err := tagger.StudioScenes(testCtx, &studio, nil, aliases, db.Scene)
+	err := tagger.StudioScenes(testCtx, &studio, nil, aliases, db.Scene, db.Studio)
 
 	assert := assert.New(t)
 
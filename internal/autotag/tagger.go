@@ -47,8 +47,12 @@ func (t *tagger) addError(otherType, otherName string, err error) error {
 	return fmt.Errorf("error adding %s '%s' to %s '%s': %s", otherType, otherName, t.Type, t.Name, err.Error())
 }
 
-func (t *tagger) addLog(otherType, otherName string) {
+func (t *tagger) addLog(ctx context.Context, otherType string, otherID int, otherName string) {
 	logger.Infof("Added %s '%s' to %s '%s'", otherType, otherName, t.Type, t.Name)
+
+	if r := recorderFromContext(ctx); r != nil {
+		r.record(t.change(otherType, otherID, otherName))
+	}
 }
 
 func (t *tagger) tagPerformers(ctx context.Context, performerReader models.PerformerAutoTagQueryer, addFunc addLinkFunc) error {
@@ -65,7 +69,7 @@ func (t *tagger) tagPerformers(ctx context.Context, performerReader models.Perfo
 		}
 
 		if added {
-			t.addLog("performer", p.Name)
+			t.addLog(ctx, "performer", p.ID, p.Name)
 		}
 	}
 
@@ -86,7 +90,7 @@ func (t *tagger) tagStudios(ctx context.Context, studioReader models.StudioAutoT
 		}
 
 		if added {
-			t.addLog("studio", studio.Name)
+			t.addLog(ctx, "studio", studio.ID, studio.Name)
 		}
 	}
 
@@ -107,7 +111,7 @@ func (t *tagger) tagTags(ctx context.Context, tagReader models.TagAutoTagQueryer
 		}
 
 		if added {
-			t.addLog("tag", p.Name)
+			t.addLog(ctx, "tag", p.ID, p.Name)
 		}
 	}
 
@@ -123,7 +127,7 @@ func (t *tagger) tagScenes(ctx context.Context, paths []string, sceneReader mode
 		}
 
 		if added {
-			t.addLog("scene", p.DisplayName())
+			t.addLog(ctx, "scene", p.ID, p.DisplayName())
 		}
 
 		return nil
@@ -139,7 +143,7 @@ func (t *tagger) tagImages(ctx context.Context, paths []string, imageReader mode
 		}
 
 		if added {
-			t.addLog("image", p.DisplayName())
+			t.addLog(ctx, "image", p.ID, p.DisplayName())
 		}
 
 		return nil
@@ -155,7 +159,7 @@ func (t *tagger) tagGalleries(ctx context.Context, paths []string, galleryReader
 		}
 
 		if added {
-			t.addLog("gallery", p.DisplayName())
+			t.addLog(ctx, "gallery", p.ID, p.DisplayName())
 		}
 
 		return nil
@@ -76,17 +76,24 @@ func (me *contentDirectoryService) updateIDString() string {
 	return fmt.Sprintf("%d", uint32(os.Getpid()))
 }
 
-func sceneToContainer(scene *models.Scene, parent string, host string) interface{} {
-	// make stash server URL
-	// TODO - fix this
-	iconURI := (&url.URL{
+// sceneIconURL returns the URL of the screenshot of the scene, resized for
+// the DLNA image profile.
+func sceneIconURL(host string, sceneID int, profile string) string {
+	return (&url.URL{
 		Scheme: "http",
 		Host:   host,
 		Path:   iconPath,
 		RawQuery: url.Values{
-			"scene": {strconv.Itoa(scene.ID)},
+			"scene":   {strconv.Itoa(sceneID)},
+			"profile": {profile},
 		}.Encode(),
 	}).String()
+}
+
+// sceneToContainer returns the item for the scene. If servePreviews is true,
+// the generated preview is included as an additional video resource.
+func sceneToContainer(scene *models.Scene, parent string, host string, servePreviews bool) interface{} {
+	iconURI := sceneIconURL(host, scene.ID, "JPEG_TN")
 
 	// Object goes first
 	obj := upnpav.Object{
@@ -137,10 +144,30 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		// Resolution: resolution,
 	})
 
-	item.Res = append(item.Res, upnpav.Resource{
-		URL:          iconURI,
-		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_MED",
-	})
+	if servePreviews {
+		// the preview is not guaranteed to exist, in which case the
+		// resource returns not found
+		item.Res = append(item.Res, upnpav.Resource{
+			URL: (&url.URL{
+				Scheme: "http",
+				Host:   host,
+				Path:   trailerPath,
+				RawQuery: url.Values{
+					"scene": {strconv.Itoa(scene.ID)},
+				}.Encode(),
+			}).String(),
+			ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, dlna.ContentFeatures{
+				SupportRange: true,
+			}.String()),
+		})
+	}
+
+	for _, profile := range []string{"JPEG_TN", "JPEG_MED"} {
+		item.Res = append(item.Res, upnpav.Resource{
+			URL:          sceneIconURL(host, scene.ID, profile),
+			ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=" + profile,
+		})
+	}
 
 	return item
 }
@@ -375,7 +402,7 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 		}
 
 		if scene != nil {
-			upnpObject := sceneToContainer(scene, "-1", host)
+			upnpObject := sceneToContainer(scene, "-1", host, me.ServePreviews)
 			objs = []interface{}{upnpObject}
 
 			// http://upnp.org/specs/av/UPnP-av-ContentDirectory-v1-Service.pdf
@@ -468,8 +495,9 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 
 		if total > pageSize {
 			pager := scenePager{
-				sceneFilter: sceneFilter,
-				parentID:    parentID,
+				sceneFilter:   sceneFilter,
+				parentID:      parentID,
+				servePreviews: me.ServePreviews,
 			}
 
 			objs, err = pager.getPages(ctx, r.SceneFinder, total)
@@ -482,7 +510,7 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 					return err
 				}
 
-				objs = append(objs, sceneToContainer(s, parentID, host))
+				objs = append(objs, sceneToContainer(s, parentID, host, me.ServePreviews))
 			}
 		}
 
@@ -500,8 +528,9 @@ func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilter
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
 		pager := scenePager{
-			sceneFilter: sceneFilter,
-			parentID:    parentID,
+			sceneFilter:   sceneFilter,
+			parentID:      parentID,
+			servePreviews: me.ServePreviews,
 		}
 
 		sort := me.VideoSortOrder
@@ -44,9 +44,9 @@ import (
 	"time"
 
 	"github.com/anacrolix/dms/soap"
-	"github.com/anacrolix/dms/ssdp"
 	"github.com/anacrolix/dms/upnp"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -77,6 +77,7 @@ const (
 	rootDeviceType              = "urn:schemas-upnp-org:device:MediaServer:1"
 	rootDeviceModelName         = "dms 1.0xb"
 	resPath                     = "/res"
+	trailerPath                 = "/trailer"
 	iconPath                    = "/icon"
 	rootDescPath                = "/rootDesc.xml"
 	contentDirectoryEventSubURL = "/evt/ContentDirectory"
@@ -148,7 +149,7 @@ func serviceTypes() (ret []string) {
 	return
 }
 func (me *Server) httpPort() int {
-	return me.HTTPConn.Addr().(*net.TCPAddr).Port
+	return me.HTTPConns[0].Addr().(*net.TCPAddr).Port
 }
 
 func (me *Server) serveHTTP() error {
@@ -168,7 +169,20 @@ func (me *Server) serveHTTP() error {
 			}, r)
 		}),
 	}
-	err := srv.Serve(me.HTTPConn)
+	errs := make(chan error, len(me.HTTPConns))
+	for _, l := range me.HTTPConns {
+		go func(l net.Listener) {
+			errs <- srv.Serve(l)
+		}(l)
+	}
+
+	var err error
+	for range me.HTTPConns {
+		if e := <-errs; err == nil {
+			err = e
+		}
+	}
+
 	select {
 	case <-me.closed:
 		return nil
@@ -184,13 +198,20 @@ func (me *Server) doSSDP() {
 	active := 0
 	stopped := make(chan struct{})
 	for _, if_ := range me.Interfaces {
-		active++
-		go func(if_ net.Interface) {
-			defer func() {
-				stopped <- struct{}{}
-			}()
-			me.ssdpInterface(if_)
-		}(if_)
+		families := []bool{false}
+		if me.IPv6 {
+			families = append(families, true)
+		}
+
+		for _, ipv6 := range families {
+			active++
+			go func(if_ net.Interface, ipv6 bool) {
+				defer func() {
+					stopped <- struct{}{}
+				}()
+				me.ssdpInterface(if_, ipv6)
+			}(if_, ipv6)
+		}
 	}
 	for active > 0 {
 		<-stopped
@@ -199,19 +220,18 @@ func (me *Server) doSSDP() {
 }
 
 // Run SSDP server on an interface.
-func (me *Server) ssdpInterface(if_ net.Interface) {
-	s := ssdp.Server{
-		Interface: if_,
-		Devices:   devices(),
-		Services:  serviceTypes(),
-		Location: func(ip net.IP) string {
+func (me *Server) ssdpInterface(if_ net.Interface, ipv6 bool) {
+	s := &ssdpServer{
+		iface: if_,
+		ipv6:  ipv6,
+		uuid:  me.interfaceDevice(if_.Name).uuid,
+		types: append(devices(), serviceTypes()...),
+		location: func(ip net.IP) string {
 			return me.location(ip)
 		},
-		Server:         serverField,
-		UUID:           me.rootDeviceUUID,
-		NotifyInterval: me.NotifyInterval,
+		notifyInterval: me.NotifyInterval,
 	}
-	if err := s.Init(); err != nil {
+	if err := s.init(); err != nil {
 		if if_.Flags&ssdpInterfaceFlags != ssdpInterfaceFlags {
 			// Didn't expect it to work anyway.
 			return
@@ -222,27 +242,18 @@ func (me *Server) ssdpInterface(if_ net.Interface) {
 			// good.
 			return
 		}
-		logger.Errorf("error creating ssdp server on %s: %s", if_.Name, err)
+		logger.Errorf("error creating %s ssdp server on %s: %s", s.family(), if_.Name, err)
 		return
 	}
-	defer s.Close()
-	logger.Debugf("started SSDP on %s", if_.Name)
+	logger.Debugf("started %s SSDP on %s", s.family(), if_.Name)
 	stopped := make(chan struct{})
 	go func() {
 		defer close(stopped)
-		// FIXME - this currently blocks forever unless it encounters an error
-		// See https://github.com/anacrolix/dms/pull/150
-		// Needs to be fixed upstream
-		//nolint:staticcheck
-		if err := s.Serve(); err != nil {
-			logger.Errorf("%q: %q\n", if_.Name, err)
-		}
+		s.serve()
 	}()
-	select {
-	case <-me.closed:
-		// Returning will close the server.
-	case <-stopped:
-	}
+	<-me.closed
+	s.close()
+	<-stopped
 }
 
 var (
@@ -255,16 +266,28 @@ type Icon struct {
 	io.ReadSeeker
 }
 
+// deviceDesc is the root device that the server is announced as.
+type deviceDesc struct {
+	uuid    string
+	descXML []byte
+}
+
 type Server struct {
-	HTTPConn       net.Listener
-	FriendlyName   string
-	Interfaces     []net.Interface
+	// HTTPConns are the listeners that the HTTP server serves on.
+	HTTPConns    []net.Listener
+	FriendlyName string
+	Interfaces   []net.Interface
+	// InterfaceConfigs override the friendly name on individual interfaces.
+	InterfaceConfigs []*InterfaceConfig
+	// IPv6 enables SSDP announcements over IPv6.
+	IPv6           bool
 	httpServeMux   *http.ServeMux
 	RootObjectPath string
-	rootDescXML    []byte
 	rootDeviceUUID string
-	closed         chan struct{}
-	ssdpStopped    chan struct{}
+	// root devices keyed by friendly name
+	rootDevices map[string]*deviceDesc
+	closed      chan struct{}
+	ssdpStopped chan struct{}
 	// The service SOAP handler keyed by service URN.
 	services   map[string]UPnPService
 	LogHeaders bool
@@ -279,6 +302,8 @@ type Server struct {
 	sceneServer        sceneServer
 	ipWhitelistManager *ipWhitelistManager
 	VideoSortOrder     string
+	// ServePreviews offers the generated preview of each scene as a trailer resource
+	ServePreviews bool
 
 	subscribeLock sync.Mutex
 }
@@ -437,6 +462,14 @@ func (me *Server) serviceControlHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// iconProfiles are the transforms of the scene screenshot that are served
+// for the DLNA image profiles. The full size screenshot is served if no
+// profile is requested.
+var iconProfiles = map[string]image.Transform{
+	"JPEG_TN":  {Width: 160, Height: 160, Format: image.TransformFormatJpeg},
+	"JPEG_MED": {Width: 1024, Height: 768, Format: image.TransformFormatJpeg},
+}
+
 func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 	sceneId := r.URL.Query().Get("scene")
 	if sceneId == "" {
@@ -461,9 +494,45 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if t, ok := iconProfiles[r.URL.Query().Get("profile")]; ok {
+		me.sceneServer.ServeScreenshotTransform(scene, t, w, r)
+		return
+	}
+
 	me.sceneServer.ServeScreenshot(scene, w, r)
 }
 
+func (me *Server) serveTrailer(w http.ResponseWriter, r *http.Request) {
+	sceneId := r.URL.Query().Get("scene")
+	if sceneId == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var scene *models.Scene
+	repo := me.repository
+	err := repo.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		idInt, err := strconv.Atoi(sceneId)
+		if err != nil {
+			return nil
+		}
+		scene, _ = repo.SceneFinder.Find(ctx, idInt)
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("failed to execute read transaction while trying to serve a trailer: %v", err)
+	}
+
+	if scene == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("transferMode.dlna.org", "Streaming")
+	w.Header().Set("contentFeatures.dlna.org", "DLNA.ORG_OP=01;DLNA.ORG_CI=0;DLNA.ORG_FLAGS=01500000000000000000000000000000")
+	me.sceneServer.ServePreview(scene, w, r)
+}
+
 func (me *Server) contentDirectoryInitialEvent(ctx context.Context, urls []*url.URL, sid string) {
 	body := xmlMarshalOrPanic(upnp.PropertySet{
 		Properties: []upnp.Property{
@@ -593,6 +662,7 @@ func (me *Server) initMux(mux *http.ServeMux) {
 	})
 	mux.HandleFunc(contentDirectoryEventSubURL, me.contentDirectoryEventSubHandler)
 	mux.HandleFunc(iconPath, me.serveIcon)
+	mux.HandleFunc(trailerPath, me.serveTrailer)
 	mux.HandleFunc(resPath, func(w http.ResponseWriter, r *http.Request) {
 		sceneId := r.URL.Query().Get("scene")
 		var scene *models.Scene
@@ -618,11 +688,12 @@ func (me *Server) initMux(mux *http.ServeMux) {
 		me.sceneServer.StreamSceneDirect(scene, w, r)
 	})
 	mux.HandleFunc(rootDescPath, func(w http.ResponseWriter, r *http.Request) {
+		rootDescXML := me.requestDevice(r).descXML
 		w.Header().Set("content-type", `text/xml; charset="utf-8"`)
-		w.Header().Set("content-length", fmt.Sprint(len(me.rootDescXML)))
+		w.Header().Set("content-length", fmt.Sprint(len(rootDescXML)))
 		w.Header().Set("server", serverField)
-		if k, err := w.Write(me.rootDescXML); err != nil {
-			logger.Warnf("could not write rootDescXML (wrote %v bytes of %v): %v", k, len(me.rootDescXML), err)
+		if k, err := w.Write(rootDescXML); err != nil {
+			logger.Warnf("could not write rootDescXML (wrote %v bytes of %v): %v", k, len(rootDescXML), err)
 		}
 	})
 	handleSCPDs(mux)
@@ -653,11 +724,12 @@ func (me *Server) initServices() {
 func (me *Server) Serve() (err error) {
 	me.initServices()
 	me.closed = make(chan struct{})
-	if me.HTTPConn == nil {
-		me.HTTPConn, err = net.Listen("tcp", "")
+	if len(me.HTTPConns) == 0 {
+		conn, err := net.Listen("tcp", "")
 		if err != nil {
-			return
+			return err
 		}
+		me.HTTPConns = []net.Listener{conn}
 	}
 	if me.Interfaces == nil {
 		ifs, err := net.Interfaces()
@@ -674,16 +746,46 @@ func (me *Server) Serve() (err error) {
 		me.Interfaces = tmp
 	}
 	me.httpServeMux = http.NewServeMux()
-	me.rootDeviceUUID = makeDeviceUuid(me.FriendlyName)
-	me.rootDescXML, err = xml.MarshalIndent(
+	me.rootDevices = make(map[string]*deviceDesc)
+	root, err := me.makeDevice(me.FriendlyName)
+	if err != nil {
+		return
+	}
+	me.rootDeviceUUID = root.uuid
+	for _, if_ := range me.Interfaces {
+		if _, err = me.makeDevice(interfaceFriendlyName(me.InterfaceConfigs, if_.Name, me.FriendlyName)); err != nil {
+			return
+		}
+	}
+	for _, l := range me.HTTPConns {
+		logger.Debug("HTTP srv on", l.Addr())
+	}
+	me.initMux(me.httpServeMux)
+	me.ssdpStopped = make(chan struct{})
+	go func() {
+		me.doSSDP()
+		close(me.ssdpStopped)
+	}()
+	return me.serveHTTP()
+}
+
+// makeDevice creates the root device with the given friendly name, if it
+// does not already exist.
+func (me *Server) makeDevice(friendlyName string) (*deviceDesc, error) {
+	if d := me.rootDevices[friendlyName]; d != nil {
+		return d, nil
+	}
+
+	uuid := makeDeviceUuid(friendlyName)
+	descXML, err := xml.MarshalIndent(
 		upnp.DeviceDesc{
 			SpecVersion: upnp.SpecVersion{Major: 1, Minor: 0},
 			Device: upnp.Device{
 				DeviceType:   rootDeviceType,
-				FriendlyName: me.FriendlyName,
-				Manufacturer: me.FriendlyName,
+				FriendlyName: friendlyName,
+				Manufacturer: friendlyName,
 				ModelName:    rootDeviceModelName,
-				UDN:          me.rootDeviceUUID,
+				UDN:          uuid,
 				ServiceList: func() (ss []upnp.Service) {
 					for _, s := range services {
 						ss = append(ss, s.Service)
@@ -706,22 +808,52 @@ func (me *Server) Serve() (err error) {
 		},
 		" ", "  ")
 	if err != nil {
-		return
+		return nil, err
 	}
-	me.rootDescXML = append([]byte(`<?xml version="1.0"?>`), me.rootDescXML...)
-	logger.Debug("HTTP srv on", me.HTTPConn.Addr())
-	me.initMux(me.httpServeMux)
-	me.ssdpStopped = make(chan struct{})
-	go func() {
-		me.doSSDP()
-		close(me.ssdpStopped)
-	}()
-	return me.serveHTTP()
+
+	ret := &deviceDesc{
+		uuid:    uuid,
+		descXML: append([]byte(`<?xml version="1.0"?>`), descXML...),
+	}
+	me.rootDevices[friendlyName] = ret
+	return ret, nil
+}
+
+// interfaceDevice returns the root device announced on the named interface.
+func (me *Server) interfaceDevice(name string) *deviceDesc {
+	if d := me.rootDevices[interfaceFriendlyName(me.InterfaceConfigs, name, me.FriendlyName)]; d != nil {
+		return d
+	}
+	return me.rootDevices[me.FriendlyName]
+}
+
+// requestDevice returns the root device announced on the interface that the
+// request was received on.
+func (me *Server) requestDevice(r *http.Request) *deviceDesc {
+	if addr, ok := r.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr); ok {
+		for _, if_ := range me.Interfaces {
+			addrs, err := if_.Addrs()
+			if err != nil {
+				continue
+			}
+			for _, a := range addrs {
+				if ip, _ := addrIP(a); ip != nil && ip.Equal(addr.IP) {
+					return me.interfaceDevice(if_.Name)
+				}
+			}
+		}
+	}
+
+	return me.rootDevices[me.FriendlyName]
 }
 
 func (me *Server) Close() (err error) {
 	close(me.closed)
-	err = me.HTTPConn.Close()
+	for _, l := range me.HTTPConns {
+		if e := l.Close(); err == nil {
+			err = e
+		}
+	}
 	<-me.ssdpStopped
 	return
 }
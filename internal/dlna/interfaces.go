@@ -0,0 +1,120 @@
+package dlna
+
+import (
+	"net"
+)
+
+// InterfaceConfig is the DLNA configuration of a network interface.
+// Interfaces without a configuration are enabled and use the server name.
+type InterfaceConfig struct {
+	// Name is the name of the network interface.
+	Name string `json:"name"`
+	// Enabled is false if DLNA should not be served or announced on the
+	// interface.
+	Enabled bool `json:"enabled"`
+	// FriendlyName is the name the server is announced as on the interface.
+	// If empty, the server name is used.
+	FriendlyName string `json:"friendly_name"`
+}
+
+func findInterfaceConfig(configs []*InterfaceConfig, name string) *InterfaceConfig {
+	for _, c := range configs {
+		if c != nil && c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// excludeDisabledInterfaces returns the interfaces that are not disabled in
+// configs.
+func excludeDisabledInterfaces(ifs []net.Interface, configs []*InterfaceConfig) []net.Interface {
+	var ret []net.Interface
+	for _, if_ := range ifs {
+		if c := findInterfaceConfig(configs, if_.Name); c != nil && !c.Enabled {
+			continue
+		}
+		ret = append(ret, if_)
+	}
+
+	return ret
+}
+
+// interfaceFriendlyName returns the name the server is announced as on the
+// named interface.
+func interfaceFriendlyName(configs []*InterfaceConfig, name string, defaultName string) string {
+	if c := findInterfaceConfig(configs, name); c != nil && c.FriendlyName != "" {
+		return c.FriendlyName
+	}
+
+	return defaultName
+}
+
+// addrIP returns the IP address and network of an interface address.
+func addrIP(addr net.Addr) (net.IP, *net.IPNet) {
+	switch v := addr.(type) {
+	case *net.IPNet:
+		return v.IP, v
+	case *net.IPAddr:
+		return v.IP, nil
+	}
+
+	return nil, nil
+}
+
+// announceIPs returns the addresses that the server is announced on. For
+// IPv6, link-local addresses are excluded, since they cannot be used in a
+// location URL without the zone of the client.
+func announceIPs(addrs []net.Addr, ipv6 bool) []net.IP {
+	var ret []net.IP
+	for _, a := range addrs {
+		ip, _ := addrIP(a)
+		if ip == nil || ip.IsLoopback() {
+			continue
+		}
+
+		isIPv4 := ip.To4() != nil
+		if isIPv4 == ipv6 {
+			continue
+		}
+
+		if ipv6 && ip.IsLinkLocalUnicast() {
+			continue
+		}
+
+		ret = append(ret, ip)
+	}
+
+	return ret
+}
+
+// bindAddresses returns the addresses of the interfaces that the HTTP server
+// listens on. IPv6 addresses are only included if ipv6 is true.
+func bindAddresses(ifs []net.Interface, ipv6 bool) []string {
+	var ret []string
+	for _, if_ := range ifs {
+		addrs, err := if_.Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, a := range addrs {
+			ip, _ := addrIP(a)
+			if ip == nil {
+				continue
+			}
+
+			switch {
+			case ip.To4() != nil:
+				ret = append(ret, ip.String())
+			case ipv6 && ip.IsLinkLocalUnicast():
+				ret = append(ret, ip.String()+"%"+if_.Name)
+			case ipv6:
+				ret = append(ret, ip.String())
+			}
+		}
+	}
+
+	return ret
+}
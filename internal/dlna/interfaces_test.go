@@ -0,0 +1,53 @@
+package dlna
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludeDisabledInterfaces(t *testing.T) {
+	ifs := []net.Interface{{Name: "eth0"}, {Name: "eth1"}, {Name: "wlan0"}}
+	configs := []*InterfaceConfig{
+		{Name: "eth1", Enabled: false},
+		{Name: "wlan0", Enabled: true, FriendlyName: "wifi"},
+		nil,
+	}
+
+	got := excludeDisabledInterfaces(ifs, configs)
+	assert.Equal(t, []net.Interface{{Name: "eth0"}, {Name: "wlan0"}}, got)
+}
+
+func TestInterfaceFriendlyName(t *testing.T) {
+	configs := []*InterfaceConfig{
+		{Name: "eth0", Enabled: true},
+		{Name: "wlan0", Enabled: true, FriendlyName: "wifi"},
+	}
+
+	assert.Equal(t, "stash", interfaceFriendlyName(configs, "eth0", "stash"))
+	assert.Equal(t, "wifi", interfaceFriendlyName(configs, "wlan0", "stash"))
+	assert.Equal(t, "stash", interfaceFriendlyName(configs, "eth1", "stash"))
+}
+
+func TestAnnounceIPs(t *testing.T) {
+	ipNet := func(s string) net.Addr {
+		ip, n, err := net.ParseCIDR(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.IP = ip
+		return n
+	}
+
+	addrs := []net.Addr{
+		ipNet("127.0.0.1/8"),
+		ipNet("192.168.1.10/24"),
+		ipNet("fe80::1/64"),
+		ipNet("2001:db8::10/64"),
+		ipNet("::1/128"),
+	}
+
+	assert.Equal(t, []net.IP{net.ParseIP("192.168.1.10")}, announceIPs(addrs, false))
+	assert.Equal(t, []net.IP{net.ParseIP("2001:db8::10")}, announceIPs(addrs, true))
+}
@@ -19,7 +19,7 @@ func (mrrs *mediaReceiverRegistrarService) Handle(action string, argsXML []byte,
 		}, nil
 	case "RegisterDevice":
 		return map[string]string{
-			"RegistrationRespMsg": mrrs.rootDeviceUUID,
+			"RegistrationRespMsg": mrrs.requestDevice(r).uuid,
 		}, nil
 	default:
 		return nil, upnp.InvalidActionError
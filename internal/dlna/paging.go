@@ -11,8 +11,9 @@ import (
 )
 
 type scenePager struct {
-	sceneFilter *models.SceneFilterType
-	parentID    string
+	sceneFilter   *models.SceneFilterType
+	parentID      string
+	servePreviews bool
 }
 
 func (p *scenePager) getPageID(page int) string {
@@ -79,7 +80,7 @@ func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.
 			return nil, err
 		}
 
-		objs = append(objs, sceneToContainer(s, p.parentID, host))
+		objs = append(objs, sceneToContainer(s, p.parentID, host, p.servePreviews))
 	}
 
 	return objs, nil
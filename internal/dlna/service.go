@@ -2,13 +2,16 @@ package dlna
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -64,18 +67,24 @@ type dmsConfig struct {
 	StallEventSubscribe bool
 	NotifyInterval      time.Duration
 	VideoSortOrder      string
+	ServePreviews       bool
 }
 
 type sceneServer interface {
 	StreamSceneDirect(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 	ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request)
+	ServeScreenshotTransform(scene *models.Scene, t image.Transform, w http.ResponseWriter, r *http.Request)
+	ServePreview(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 }
 
 type Config interface {
 	GetDLNAInterfaces() []string
+	GetDLNAInterfaceSettings() []*InterfaceConfig
+	GetDLNAIPv6() bool
 	GetDLNAServerName() string
 	GetDLNADefaultIPWhitelist() []string
 	GetVideoSortOrder() string
+	GetDLNAServePreviews() bool
 	GetDLNAPortAsString() string
 }
 
@@ -126,10 +135,58 @@ func (s *Service) getInterfaces() ([]net.Interface, error) {
 		}
 		tmp = append(tmp, if_)
 	}
-	ifs = tmp
+	ifs = excludeDisabledInterfaces(tmp, s.config.GetDLNAInterfaceSettings())
 	return ifs, nil
 }
 
+// listen creates the listeners of the HTTP server. If the interfaces are
+// restricted by the configuration, then the server only listens on the
+// addresses of the interfaces, otherwise it listens on all addresses.
+func (s *Service) listen(interfaces []net.Interface, restricted bool) ([]net.Listener, error) {
+	port := s.config.GetDLNAPortAsString()
+	if !restricted {
+		conn, err := net.Listen("tcp", port)
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{conn}, nil
+	}
+
+	addrs := bindAddresses(interfaces, s.config.GetDLNAIPv6())
+	if len(addrs) == 0 {
+		return nil, errors.New("no addresses to listen on for the configured DLNA interfaces")
+	}
+
+	var ret []net.Listener
+	for _, addr := range addrs {
+		conn, err := net.Listen("tcp", net.JoinHostPort(addr, strings.TrimPrefix(port, ":")))
+		if err != nil {
+			for _, l := range ret {
+				l.Close()
+			}
+			return nil, err
+		}
+		ret = append(ret, conn)
+	}
+
+	return ret, nil
+}
+
+// interfacesRestricted returns true if DLNA is not served on all interfaces.
+func (s *Service) interfacesRestricted() bool {
+	if len(s.config.GetDLNAInterfaces()) > 0 {
+		return true
+	}
+
+	for _, c := range s.config.GetDLNAInterfaceSettings() {
+		if c != nil && !c.Enabled {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Service) init() error {
 	friendlyName := s.config.GetDLNAServerName()
 	if friendlyName == "" {
@@ -144,6 +201,7 @@ func (s *Service) init() error {
 		LogHeaders:     false,
 		NotifyInterval: 30 * time.Second,
 		VideoSortOrder: s.config.GetVideoSortOrder(),
+		ServePreviews:  s.config.GetDLNAServePreviews(),
 	}
 
 	interfaces, err := s.getInterfaces()
@@ -151,21 +209,22 @@ func (s *Service) init() error {
 		return err
 	}
 
+	conns, err := s.listen(interfaces, s.interfacesRestricted())
+	if err != nil {
+		return err
+	}
+
 	s.server = &Server{
 		repository:         s.repository,
 		sceneServer:        s.sceneServer,
 		ipWhitelistManager: s.ipWhitelistMgr,
 		Interfaces:         interfaces,
-		HTTPConn: func() net.Listener {
-			conn, err := net.Listen("tcp", dmsConfig.Http)
-			if err != nil {
-				logger.Error(err.Error())
-			}
-			return conn
-		}(),
-		FriendlyName:   dmsConfig.FriendlyName,
-		RootObjectPath: filepath.Clean(dmsConfig.Path),
-		LogHeaders:     dmsConfig.LogHeaders,
+		InterfaceConfigs:   s.config.GetDLNAInterfaceSettings(),
+		IPv6:               s.config.GetDLNAIPv6(),
+		HTTPConns:          conns,
+		FriendlyName:       dmsConfig.FriendlyName,
+		RootObjectPath:     filepath.Clean(dmsConfig.Path),
+		LogHeaders:         dmsConfig.LogHeaders,
 		// Icons: []Icon{
 		// 	{
 		// 		Width:    48,
@@ -185,6 +244,7 @@ func (s *Service) init() error {
 		StallEventSubscribe: dmsConfig.StallEventSubscribe,
 		NotifyInterval:      dmsConfig.NotifyInterval,
 		VideoSortOrder:      dmsConfig.VideoSortOrder,
+		ServePreviews:       dmsConfig.ServePreviews,
 	}
 
 	return nil
@@ -241,12 +301,7 @@ func (s *Service) Start(duration *time.Duration) error {
 			return err
 		}
 
-		go func() {
-			logger.Info("Starting DLNA " + s.server.HTTPConn.Addr().String())
-			if err := s.server.Serve(); err != nil {
-				logger.Error(err)
-			}
-		}()
+		s.serve()
 		s.running = true
 
 		if s.startTimer != nil {
@@ -275,6 +330,45 @@ func (s *Service) Start(duration *time.Duration) error {
 	return nil
 }
 
+func (s *Service) serve() {
+	server := s.server
+	go func() {
+		var addrs []string
+		for _, l := range server.HTTPConns {
+			addrs = append(addrs, l.Addr().String())
+		}
+		logger.Info("Starting DLNA " + strings.Join(addrs, ", "))
+		if err := server.Serve(); err != nil {
+			logger.Error(err)
+		}
+	}()
+}
+
+// Restart restarts the DLNA service if it is running, applying changes to
+// the network configuration. Timers set by Start and Stop are kept.
+func (s *Service) Restart() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.running {
+		return nil
+	}
+
+	logger.Info("Restarting DLNA")
+	if err := s.server.Close(); err != nil {
+		logger.Error(err)
+	}
+
+	if err := s.init(); err != nil {
+		s.running = false
+		logger.Error(err)
+		return err
+	}
+
+	s.serve()
+	return nil
+}
+
 // Stop stops the DLNA service. If duration is provided, then the service
 // is started after the duration has elapsed.
 func (s *Service) Stop(duration *time.Duration) {
@@ -0,0 +1,375 @@
+package dlna
+
+// Derived from: https://github.com/anacrolix/dms
+// Copyright (c) 2012, Matt Joiner <anacrolix@gmail.com>.
+// All rights reserved. See dms.go for the full license.
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/dms/ssdp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	ssdpPort       = 1900
+	ssdpRootDevice = "upnp:rootdevice"
+	ssdpAliveNTS   = "ssdp:alive"
+	ssdpByeByeNTS  = "ssdp:byebye"
+	ssdpIPv4Host   = "239.255.255.250:1900"
+	// link-local scope SSDP multicast address
+	ssdpIPv6Host = "[FF02::C]:1900"
+)
+
+var (
+	ssdpIPv4Group = &net.UDPAddr{IP: net.IPv4(239, 255, 255, 250), Port: ssdpPort}
+	ssdpIPv6Group = &net.UDPAddr{IP: net.ParseIP("ff02::c"), Port: ssdpPort}
+)
+
+// ssdpServer announces the server on a single interface, over either IPv4
+// or IPv6. Unlike the upstream implementation, announcements are sent out of
+// the interface they are made for, and searches received on other interfaces
+// are ignored, so that multi-homed hosts announce the correct location on
+// each network.
+type ssdpServer struct {
+	iface          net.Interface
+	ipv6           bool
+	uuid           string
+	types          []string
+	location       func(ip net.IP) string
+	notifyInterval time.Duration
+
+	conn *net.UDPConn
+	// read reads a packet, returning the index of the interface that it was
+	// received on, or 0 if not known.
+	read   func(b []byte) (n int, ifIndex int, src *net.UDPAddr, err error)
+	closed chan struct{}
+}
+
+func (s *ssdpServer) group() *net.UDPAddr {
+	if s.ipv6 {
+		return ssdpIPv6Group
+	}
+	return ssdpIPv4Group
+}
+
+func (s *ssdpServer) host() string {
+	if s.ipv6 {
+		return ssdpIPv6Host
+	}
+	return ssdpIPv4Host
+}
+
+func (s *ssdpServer) family() string {
+	if s.ipv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+func (s *ssdpServer) init() error {
+	network := "udp4"
+	if s.ipv6 {
+		network = "udp6"
+	}
+
+	conn, err := net.ListenMulticastUDP(network, &s.iface, s.group())
+	if err != nil {
+		return err
+	}
+
+	// control messages are not supported on all platforms - ignore errors
+	// and accept packets from any interface if so
+	if s.ipv6 {
+		p := ipv6.NewPacketConn(conn)
+		if err := p.SetMulticastInterface(&s.iface); err != nil {
+			conn.Close()
+			return err
+		}
+		_ = p.SetMulticastHopLimit(2)
+		_ = p.SetMulticastLoopback(true)
+		_ = p.SetControlMessage(ipv6.FlagInterface, true)
+		s.read = func(b []byte) (int, int, *net.UDPAddr, error) {
+			n, cm, src, err := p.ReadFrom(b)
+			return n, ifIndexIPv6(cm), udpAddr(src), err
+		}
+	} else {
+		p := ipv4.NewPacketConn(conn)
+		if err := p.SetMulticastInterface(&s.iface); err != nil {
+			conn.Close()
+			return err
+		}
+		_ = p.SetMulticastTTL(2)
+		_ = p.SetMulticastLoopback(true)
+		_ = p.SetControlMessage(ipv4.FlagInterface, true)
+		s.read = func(b []byte) (int, int, *net.UDPAddr, error) {
+			n, cm, src, err := p.ReadFrom(b)
+			return n, ifIndexIPv4(cm), udpAddr(src), err
+		}
+	}
+
+	s.conn = conn
+	s.closed = make(chan struct{})
+	return nil
+}
+
+func ifIndexIPv4(cm *ipv4.ControlMessage) int {
+	if cm == nil {
+		return 0
+	}
+	return cm.IfIndex
+}
+
+func ifIndexIPv6(cm *ipv6.ControlMessage) int {
+	if cm == nil {
+		return 0
+	}
+	return cm.IfIndex
+}
+
+func udpAddr(a net.Addr) *net.UDPAddr {
+	ret, _ := a.(*net.UDPAddr)
+	return ret
+}
+
+// ips returns the addresses of the interface that the server is announced
+// on.
+func (s *ssdpServer) ips() []net.IP {
+	addrs, err := s.iface.Addrs()
+	if err != nil {
+		logger.Warnf("error getting addresses of %s: %v", s.iface.Name, err)
+		return nil
+	}
+
+	return announceIPs(addrs, s.ipv6)
+}
+
+// serve handles searches and sends announcements until the server is closed.
+func (s *ssdpServer) serve() {
+	go s.serveSearches()
+
+	for {
+		for _, ip := range s.ips() {
+			s.notifyAll(ssdpAliveNTS, [][2]string{
+				{"CACHE-CONTROL", fmt.Sprintf("max-age=%d", 5*s.notifyInterval/2/time.Second)},
+				{"LOCATION", s.location(ip)},
+			})
+		}
+
+		select {
+		case <-s.closed:
+			return
+		case <-time.After(s.notifyInterval):
+		}
+	}
+}
+
+func (s *ssdpServer) serveSearches() {
+	mtu := s.iface.MTU
+	if mtu <= 0 {
+		mtu = 1500
+	}
+
+	for {
+		b := make([]byte, mtu)
+		n, ifIndex, src, err := s.read(b)
+		select {
+		case <-s.closed:
+			return
+		default:
+		}
+		if err != nil {
+			logger.Errorf("error reading SSDP %s packet on %s: %v", s.family(), s.iface.Name, err)
+			return
+		}
+
+		// the socket receives packets for the group from all interfaces
+		if ifIndex != 0 && ifIndex != s.iface.Index {
+			continue
+		}
+
+		if src != nil {
+			go s.handle(b[:n], src)
+		}
+	}
+}
+
+func (s *ssdpServer) close() {
+	close(s.closed)
+	s.sendByeBye()
+	s.conn.Close()
+}
+
+func (s *ssdpServer) usnFromTarget(target string) string {
+	if target == s.uuid {
+		return target
+	}
+	return s.uuid + "::" + target
+}
+
+func (s *ssdpServer) allTypes() []string {
+	return append([]string{ssdpRootDevice, s.uuid}, s.types...)
+}
+
+func (s *ssdpServer) makeNotifyMessage(target, nts string, extraHdrs [][2]string) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprint(buf, "NOTIFY * HTTP/1.1\r\n")
+	for _, pair := range [][2]string{
+		{"HOST", s.host()},
+		{"NT", target},
+		{"NTS", nts},
+		{"SERVER", serverField},
+		{"USN", s.usnFromTarget(target)},
+	} {
+		fmt.Fprintf(buf, "%s: %s\r\n", pair[0], pair[1])
+	}
+	for _, pair := range extraHdrs {
+		fmt.Fprintf(buf, "%s: %s\r\n", pair[0], pair[1])
+	}
+	fmt.Fprint(buf, "\r\n")
+	return buf.Bytes()
+}
+
+func (s *ssdpServer) send(buf []byte, addr *net.UDPAddr) {
+	if n, err := s.conn.WriteTo(buf, addr); err != nil {
+		logger.Debugf("error writing SSDP %s packet on %s: %v", s.family(), s.iface.Name, err)
+	} else if n != len(buf) {
+		logger.Debugf("short SSDP write on %s: %d/%d bytes", s.iface.Name, n, len(buf))
+	}
+}
+
+func (s *ssdpServer) delayedSend(delay time.Duration, buf []byte, addr *net.UDPAddr) {
+	go func() {
+		select {
+		case <-time.After(delay):
+			s.send(buf, addr)
+		case <-s.closed:
+		}
+	}()
+}
+
+func (s *ssdpServer) sendByeBye() {
+	for _, t := range s.allTypes() {
+		s.send(s.makeNotifyMessage(t, ssdpByeByeNTS, nil), s.group())
+	}
+}
+
+func (s *ssdpServer) notifyAll(nts string, extraHdrs [][2]string) {
+	for _, t := range s.allTypes() {
+		buf := s.makeNotifyMessage(t, nts, extraHdrs)
+		delay := time.Duration(rand.Int63n(int64(100 * time.Millisecond)))
+		s.delayedSend(delay, buf, s.group())
+	}
+}
+
+// responseIPs returns the addresses to respond to a search from sender with.
+// Addresses on the network of the sender are preferred.
+func (s *ssdpServer) responseIPs(sender net.IP) []net.IP {
+	addrs, err := s.iface.Addrs()
+	if err != nil {
+		logger.Warnf("error getting addresses of %s: %v", s.iface.Name, err)
+		return nil
+	}
+
+	var ret []net.IP
+	for _, a := range addrs {
+		ip, network := addrIP(a)
+		if network != nil && network.Contains(sender) {
+			ret = append(ret, ip)
+		}
+	}
+
+	// IPv6 searches are usually sent from a link-local address, which cannot
+	// be used in the location
+	ret = announceIPs(ipAddrs(ret), s.ipv6)
+	if len(ret) == 0 {
+		ret = announceIPs(addrs, s.ipv6)
+	}
+
+	return ret
+}
+
+func ipAddrs(ips []net.IP) []net.Addr {
+	ret := make([]net.Addr, len(ips))
+	for i, ip := range ips {
+		ret[i] = &net.IPAddr{IP: ip}
+	}
+	return ret
+}
+
+func (s *ssdpServer) handle(buf []byte, sender *net.UDPAddr) {
+	req, err := ssdp.ReadRequest(bufio.NewReader(bytes.NewReader(buf)))
+	if err != nil {
+		logger.Tracef("invalid SSDP request from %s: %v", sender, err)
+		return
+	}
+	if req.Method != "M-SEARCH" || req.Header.Get("man") != `"ssdp:discover"` {
+		return
+	}
+
+	var mx uint
+	if strings.EqualFold(req.Header.Get("Host"), s.host()) {
+		mxHeader := req.Header.Get("mx")
+		i, err := strconv.ParseUint(mxHeader, 0, 0)
+		if err != nil {
+			logger.Tracef("invalid SSDP mx header %q from %s: %v", mxHeader, sender, err)
+			return
+		}
+		mx = uint(i)
+	} else {
+		mx = 1
+	}
+
+	var types []string
+	st := req.Header.Get("st")
+	for _, t := range s.allTypes() {
+		if st == "ssdp:all" || t == st {
+			types = append(types, t)
+		}
+	}
+
+	for _, ip := range s.responseIPs(sender.IP) {
+		for _, t := range types {
+			resp := s.makeResponse(ip, t, req)
+			delay := time.Duration(rand.Int63n(int64(time.Second) * int64(mx)))
+			s.delayedSend(delay, resp, sender)
+		}
+	}
+}
+
+func (s *ssdpServer) makeResponse(ip net.IP, target string, req *http.Request) []byte {
+	resp := &http.Response{
+		StatusCode: 200,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Request:    req,
+	}
+	for _, pair := range [...][2]string{
+		{"CACHE-CONTROL", fmt.Sprintf("max-age=%d", 5*s.notifyInterval/2/time.Second)},
+		{"EXT", ""},
+		{"LOCATION", s.location(ip)},
+		{"SERVER", serverField},
+		{"ST", target},
+		{"USN", s.usnFromTarget(target)},
+	} {
+		resp.Header.Set(pair[0], pair[1])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := resp.Write(buf); err != nil {
+		logger.Errorf("error writing SSDP response: %v", err)
+	}
+	return buf.Bytes()
+}
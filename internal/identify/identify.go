@@ -44,6 +44,22 @@ type ScraperSource struct {
 	Options    *MetadataOptions
 	Scraper    SceneScraper
 	RemoteSite string
+
+	// SourceType and SourceID are recorded as the source of the fields set
+	// from this source.
+	SourceType models.FieldSourceType
+	SourceID   string
+
+	// PreScrape sources are applied to the scene before the other sources
+	// are scraped, so that the other sources can use the values they set.
+	// A result from a PreScrape source does not stop the other sources
+	// from being used.
+	PreScrape bool
+}
+
+type FieldSourceReaderWriter interface {
+	models.FieldSourceReader
+	models.FieldSourceWriter
 }
 
 type SceneIdentifier struct {
@@ -53,17 +69,54 @@ type SceneIdentifier struct {
 	PerformerCreator   PerformerCreator
 	TagFinderCreator   models.TagFinderCreator
 
+	// SceneMarkerReaderCreator is used to create scraped scene markers.
+	// Scraped markers are ignored if nil.
+	SceneMarkerReaderCreator SceneMarkerReaderCreator
+
+	// SceneFieldSourceReaderWriter is used to record the source of the
+	// fields set on the scene. Field sources are not recorded or
+	// considered if nil.
+	SceneFieldSourceReaderWriter FieldSourceReaderWriter
+
 	DefaultOptions              *MetadataOptions
 	Sources                     []ScraperSource
 	SceneUpdatePostHookExecutor SceneUpdatePostHookExecutor
 }
 
+// Outcome is the outcome of identifying a scene.
+type Outcome string
+
+const (
+	OutcomeMatched         Outcome = "matched"
+	OutcomeUnmatched       Outcome = "unmatched"
+	OutcomeMultipleMatches Outcome = "multiple_matches"
+)
+
+// Result is the result of identifying a scene.
+type Result struct {
+	Outcome Outcome
+	// Source is the name of the source that matched the scene, or that
+	// returned multiple matches.
+	Source string
+}
+
 func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) error {
+	_, err := t.IdentifyWithResult(ctx, scene)
+	return err
+}
+
+// IdentifyWithResult identifies the scene, returning the outcome.
+func (t *SceneIdentifier) IdentifyWithResult(ctx context.Context, scene *models.Scene) (*Result, error) {
+	scene, err := t.preScrape(ctx, scene)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := t.scrapeScene(ctx, scene)
 	var multipleMatchErr *MultipleMatchesFoundError
 	if err != nil {
 		if !errors.As(err, &multipleMatchErr) {
-			return err
+			return nil, err
 		}
 	}
 
@@ -71,28 +124,36 @@ func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) err
 		if multipleMatchErr != nil {
 			logger.Debugf("Identify skipped because multiple results returned for %s", scene.Path)
 
+			ret := &Result{
+				Outcome: OutcomeMultipleMatches,
+				Source:  multipleMatchErr.Source.Name,
+			}
+
 			// find if the scene should be tagged for multiple results
 			options := t.getOptions(multipleMatchErr.Source)
 			if options.SkipMultipleMatchTag != nil && len(*options.SkipMultipleMatchTag) > 0 {
 				// Tag it with the multiple results tag
 				err := t.addTagToScene(ctx, scene, *options.SkipMultipleMatchTag)
 				if err != nil {
-					return err
+					return nil, err
 				}
-				return nil
 			}
-		} else {
-			logger.Debugf("Unable to identify %s", scene.Path)
+			return ret, nil
 		}
-		return nil
+
+		logger.Debugf("Unable to identify %s", scene.Path)
+		return &Result{Outcome: OutcomeUnmatched}, nil
 	}
 
 	// results were found, modify the scene
 	if err := t.modifyScene(ctx, scene, result); err != nil {
-		return fmt.Errorf("error modifying scene: %v", err)
+		return nil, fmt.Errorf("error modifying scene: %v", err)
 	}
 
-	return nil
+	return &Result{
+		Outcome: OutcomeMatched,
+		Source:  result.source.Name,
+	}, nil
 }
 
 type scrapeResult struct {
@@ -100,9 +161,60 @@ type scrapeResult struct {
 	source ScraperSource
 }
 
+// preScrape applies the results of the PreScrape sources to the scene. It
+// returns the scene with the values that were set.
+func (t *SceneIdentifier) preScrape(ctx context.Context, s *models.Scene) (*models.Scene, error) {
+	for _, source := range t.Sources {
+		if !source.PreScrape {
+			continue
+		}
+
+		results, err := source.Scraper.ScrapeScenes(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		if len(results) == 0 {
+			continue
+		}
+
+		if err := t.modifyScene(ctx, s, &scrapeResult{
+			result: results[0],
+			source: source,
+		}); err != nil {
+			return nil, fmt.Errorf("error modifying scene: %v", err)
+		}
+
+		// reload the scene so that the values just set are treated as
+		// existing values by the following sources
+		if err := txn.WithReadTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+			updated, err := t.SceneReaderUpdater.Find(ctx, s.ID)
+			if err != nil {
+				return err
+			}
+
+			if updated == nil {
+				return fmt.Errorf("scene with id %d not found", s.ID)
+			}
+
+			s = updated
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
 func (t *SceneIdentifier) scrapeScene(ctx context.Context, scene *models.Scene) (*scrapeResult, error) {
 	// iterate through the input sources
 	for _, source := range t.Sources {
+		if source.PreScrape {
+			continue
+		}
+
 		// scrape using the source
 		results, err := source.Scraper.ScrapeScenes(ctx, scene.ID)
 		if err != nil {
@@ -160,26 +272,42 @@ func (t *SceneIdentifier) getOptions(source ScraperSource) MetadataOptions {
 	if source.Options.SkipSingleNamePerformerTag != nil && len(*source.Options.SkipSingleNamePerformerTag) > 0 {
 		options.SkipSingleNamePerformerTag = source.Options.SkipSingleNamePerformerTag
 	}
+	if source.Options.SkipManuallySetFields != nil {
+		options.SkipManuallySetFields = source.Options.SkipManuallySetFields
+	}
 
 	return options
 }
 
-func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene, result *scrapeResult) (*scene.UpdateSet, error) {
-	ret := &scene.UpdateSet{
-		ID: s.ID,
-	}
-
+// Returns the field options for the source, with source specific options taking precedence over the defaults
+func (t *SceneIdentifier) getFieldOptions(source ScraperSource) map[string]*FieldOptions {
 	allOptions := []MetadataOptions{}
-	if result.source.Options != nil {
-		allOptions = append(allOptions, *result.source.Options)
+	if source.Options != nil {
+		allOptions = append(allOptions, *source.Options)
 	}
 	if t.DefaultOptions != nil {
 		allOptions = append(allOptions, *t.DefaultOptions)
 	}
 
-	fieldOptions := getFieldOptions(allOptions)
+	return getFieldOptions(allOptions)
+}
+
+func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene, result *scrapeResult) (*scene.UpdateSet, error) {
+	ret := &scene.UpdateSet{
+		ID: s.ID,
+	}
+
+	fieldOptions := t.getFieldOptions(result.source)
 	options := t.getOptions(result.source)
 
+	if utils.IsTrue(options.SkipManuallySetFields) {
+		var err error
+		fieldOptions, err = t.ignoreManuallySetFields(ctx, s.ID, fieldOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	scraped := result.result
 
 	rel := sceneRelationships{
@@ -293,14 +421,30 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, resu
 			return err
 		}
 
+		markersCreated, err := t.createMarkers(ctx, s, result)
+		if err != nil {
+			return err
+		}
+
 		// don't update anything if nothing was set
 		if updater.IsEmpty() {
-			logger.Debugf("Nothing to set for %s", s.Path)
-			return nil
-		}
+			if markersCreated == 0 {
+				logger.Debugf("Nothing to set for %s", s.Path)
+				return nil
+			}
+		} else {
+			updated, err := updater.Update(ctx, t.SceneReaderUpdater)
+			if err != nil {
+				return fmt.Errorf("error updating scene: %w", err)
+			}
+
+			if err := t.setFieldSources(ctx, updater, result.source); err != nil {
+				return err
+			}
 
-		if _, err := updater.Update(ctx, t.SceneReaderUpdater); err != nil {
-			return fmt.Errorf("error updating scene: %w", err)
+			if err := t.applyStudioDefaults(ctx, s, updated, updater); err != nil {
+				return err
+			}
 		}
 
 		as := ""
@@ -325,6 +469,95 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, resu
 	return nil
 }
 
+// ignoreManuallySetFields returns a copy of fieldOptions that ignores the
+// fields whose values were last set manually.
+func (t *SceneIdentifier) ignoreManuallySetFields(ctx context.Context, sceneID int, fieldOptions map[string]*FieldOptions) (map[string]*FieldOptions, error) {
+	if t.SceneFieldSourceReaderWriter == nil {
+		return fieldOptions, nil
+	}
+
+	sources, err := t.SceneFieldSourceReaderWriter.GetFieldSources(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting field sources: %w", err)
+	}
+
+	ret := make(map[string]*FieldOptions, len(fieldOptions))
+	for k, v := range fieldOptions {
+		ret[k] = v
+	}
+
+	for _, src := range sources {
+		if src.Type == models.FieldSourceTypeManual {
+			ret[src.Field] = &FieldOptions{
+				Field:    src.Field,
+				Strategy: FieldStrategyIgnore,
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// applyStudioDefaults applies the defaults of the studio set by the updater
+// to the updated scene.
+func (t *SceneIdentifier) applyStudioDefaults(ctx context.Context, original *models.Scene, updated *models.Scene, updater *scene.UpdateSet) error {
+	studioID := updater.Partial.StudioID
+	if !studioID.Set || studioID.Null || updated == nil {
+		return nil
+	}
+
+	if original.StudioID != nil && *original.StudioID == studioID.Value {
+		return nil
+	}
+
+	if _, err := scene.ApplyStudioDefaults(ctx, t.SceneReaderUpdater, t.StudioReaderWriter, updated); err != nil {
+		return fmt.Errorf("error applying studio defaults: %w", err)
+	}
+
+	return nil
+}
+
+// setFieldSources records the source as the source of the fields set by
+// the updater.
+func (t *SceneIdentifier) setFieldSources(ctx context.Context, updater *scene.UpdateSet, source ScraperSource) error {
+	if t.SceneFieldSourceReaderWriter == nil || source.SourceType == "" {
+		return nil
+	}
+
+	fields := models.FieldSourceNames(utils.NotNilFields(updater.UpdateInput(), "json"))
+	sources := models.NewFieldSources(fields, source.SourceType, source.SourceID)
+	if err := t.SceneFieldSourceReaderWriter.SetFieldSources(ctx, updater.ID, sources); err != nil {
+		return fmt.Errorf("error setting field sources: %w", err)
+	}
+
+	return nil
+}
+
+func (t *SceneIdentifier) createMarkers(ctx context.Context, s *models.Scene, result *scrapeResult) (int, error) {
+	if t.SceneMarkerReaderCreator == nil || len(result.result.Markers) == 0 {
+		return 0, nil
+	}
+
+	m := sceneMarkers{
+		markerReaderCreator: t.SceneMarkerReaderCreator,
+		tagFinderCreator:    t.TagFinderCreator,
+		scene:               s,
+		scraped:             result.result.Markers,
+		fieldOptions:        t.getFieldOptions(result.source)["markers"],
+	}
+
+	ret, err := m.create(ctx)
+	if err != nil {
+		return ret, fmt.Errorf("error creating scene markers: %w", err)
+	}
+
+	if ret > 0 {
+		logger.Infof("Created %d scene markers for %s", ret, s.Path)
+	}
+
+	return ret, nil
+}
+
 func (t *SceneIdentifier) addTagToScene(ctx context.Context, s *models.Scene, tagToAdd string) error {
 	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
 		tagID, err := strconv.Atoi(tagToAdd)
@@ -406,6 +639,13 @@ func getScenePartial(scene *models.Scene, scraped *scraper.ScrapedScene, fieldOp
 					Mode:   models.RelationshipUpdateModeSet,
 				}
 			}
+		case FieldStrategyOnlyIfEmpty:
+			if len(scene.URLs.List()) == 0 {
+				partial.URLs = &models.UpdateStrings{
+					Values: scraped.URLs,
+					Mode:   models.RelationshipUpdateModeSet,
+				}
+			}
 		case FieldStrategyMerge:
 			// if merge, add if not already present
 			urls := sliceutil.AppendUniques(scene.URLs.List(), scraped.URLs)
@@ -455,5 +695,6 @@ func shouldSetSingleValueField(strategy *FieldOptions, hasExistingValue bool) bo
 		return false
 	}
 
+	// MERGE and ONLY_IF_EMPTY are equivalent for single-value fields
 	return !hasExistingValue || fs == FieldStrategyOverwrite
 }
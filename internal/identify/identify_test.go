@@ -212,6 +212,79 @@ func TestSceneIdentifier_Identify(t *testing.T) {
 	}
 }
 
+func TestSceneIdentifier_Identify_PreScrape(t *testing.T) {
+	const sceneID = 1
+
+	var (
+		localTitle   = "localTitle"
+		scrapedTitle = "scrapedTitle"
+		boolFalse    = false
+	)
+
+	db := mocks.NewDatabase()
+
+	db.Scene.On("GetURLs", mock.Anything, sceneID).Return(nil, nil)
+	db.Scene.On("UpdatePartial", mock.Anything, sceneID, mock.Anything).Return(nil, nil)
+	db.Scene.On("Find", mock.Anything, sceneID).Return(&models.Scene{
+		ID:           sceneID,
+		Title:        localTitle,
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+	}, nil)
+
+	identifier := SceneIdentifier{
+		TxnManager:         db,
+		SceneReaderUpdater: db.Scene,
+		StudioReaderWriter: db.Studio,
+		PerformerCreator:   db.Performer,
+		TagFinderCreator:   db.Tag,
+		DefaultOptions: &MetadataOptions{
+			SetOrganized:             &boolFalse,
+			SetCoverImage:            &boolFalse,
+			IncludeMalePerformers:    &boolFalse,
+			SkipSingleNamePerformers: &boolFalse,
+		},
+		// the pre-scrape source is applied first regardless of its position
+		Sources: []ScraperSource{
+			{
+				Scraper: mockSceneScraper{
+					results: map[int][]*scraper.ScrapedScene{
+						sceneID: {{Title: &scrapedTitle}},
+					},
+				},
+			},
+			{
+				Scraper: mockSceneScraper{
+					results: map[int][]*scraper.ScrapedScene{
+						sceneID: {{Title: &localTitle}},
+					},
+				},
+				PreScrape: true,
+			},
+		},
+		SceneUpdatePostHookExecutor: mockHookExecutor{},
+	}
+
+	scene := &models.Scene{
+		ID:           sceneID,
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+	}
+	if err := identifier.Identify(testCtx, scene); err != nil {
+		t.Errorf("SceneIdentifier.Identify() error = %v", err)
+		return
+	}
+
+	// the title set by the pre-scrape source is not replaced with the merge
+	// strategy, so the scene is only updated once
+	db.Scene.AssertNumberOfCalls(t, "UpdatePartial", 1)
+	db.Scene.AssertCalled(t, "UpdatePartial", mock.Anything, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.Title.Value == localTitle
+	}))
+}
+
 func TestSceneIdentifier_modifyScene(t *testing.T) {
 	db := mocks.NewDatabase()
 
@@ -338,6 +411,40 @@ func Test_getFieldOptions(t *testing.T) {
 	}
 }
 
+func TestSceneIdentifier_ignoreManuallySetFields(t *testing.T) {
+	const sceneID = 1
+
+	db := mocks.NewDatabase()
+	db.Scene.On("GetFieldSources", testCtx, sceneID).Return([]*models.FieldSource{
+		{Field: "title", Type: models.FieldSourceTypeManual},
+		{Field: "details", Type: models.FieldSourceTypeScraper, Source: "scraper"},
+	}, nil)
+
+	tr := &SceneIdentifier{
+		SceneFieldSourceReaderWriter: db.Scene,
+	}
+
+	fieldOptions := map[string]*FieldOptions{
+		"title": {
+			Field:    "title",
+			Strategy: FieldStrategyOverwrite,
+		},
+		"details": {
+			Field:    "details",
+			Strategy: FieldStrategyOverwrite,
+		},
+	}
+
+	got, err := tr.ignoreManuallySetFields(testCtx, sceneID, fieldOptions)
+	assert.Nil(t, err)
+
+	assert.Equal(t, FieldStrategyIgnore, got["title"].Strategy)
+	assert.Equal(t, FieldStrategyOverwrite, got["details"].Strategy)
+
+	// original options must not be modified
+	assert.Equal(t, FieldStrategyOverwrite, fieldOptions["title"].Strategy)
+}
+
 func Test_getScenePartial(t *testing.T) {
 	var (
 		originalTitle   = "originalTitle"
@@ -571,6 +678,26 @@ func Test_shouldSetSingleValueField(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"only if empty existing",
+			args{
+				&FieldOptions{
+					Strategy: FieldStrategyOnlyIfEmpty,
+				},
+				true,
+			},
+			false,
+		},
+		{
+			"only if empty absent",
+			args{
+				&FieldOptions{
+					Strategy: FieldStrategyOnlyIfEmpty,
+				},
+				false,
+			},
+			true,
+		},
 		{
 			"nil (merge) existing",
 			args{
@@ -0,0 +1,165 @@
+package identify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type SceneMarkerReaderCreator interface {
+	FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneMarker, error)
+	models.SceneMarkerCreator
+	UpdateTags(ctx context.Context, markerID int, tagIDs []int) error
+}
+
+// markerSecondsTolerance is the difference in start time within which a
+// scraped marker with the same primary tag is considered to already exist.
+const markerSecondsTolerance = 1.0
+
+type sceneMarkers struct {
+	markerReaderCreator SceneMarkerReaderCreator
+	tagFinderCreator    models.TagFinderCreator
+	scene               *models.Scene
+	scraped             []*scraper.ScrapedSceneMarker
+	fieldOptions        *FieldOptions
+
+	// tags created while creating markers, by name
+	createdTags map[string]int
+}
+
+func markerExists(existing []*models.SceneMarker, primaryTagID int, seconds float64) bool {
+	for _, m := range existing {
+		if m.PrimaryTagID == primaryTagID && math.Abs(m.Seconds-seconds) < markerSecondsTolerance {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tagID returns the ID of the scraped tag, creating it if createMissing is
+// true. Returns nil if the tag does not exist and was not created.
+func (g *sceneMarkers) tagID(ctx context.Context, t *models.ScrapedTag, createMissing bool) (*int, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	if t.StoredID != nil {
+		id, err := strconv.Atoi(*t.StoredID)
+		if err != nil {
+			return nil, fmt.Errorf("error converting tag ID %s: %w", *t.StoredID, err)
+		}
+		return &id, nil
+	}
+
+	if id, found := g.createdTags[t.Name]; found {
+		return &id, nil
+	}
+
+	if !createMissing {
+		return nil, nil
+	}
+
+	// the tag may have been created for the scene tags
+	existing, err := g.tagFinderCreator.FindByName(ctx, t.Name, true)
+	if err != nil {
+		return nil, fmt.Errorf("error finding tag %s: %w", t.Name, err)
+	}
+
+	id := 0
+	if existing != nil {
+		id = existing.ID
+	} else {
+		newTag := models.NewTag()
+		newTag.Name = t.Name
+		if err := g.tagFinderCreator.Create(ctx, &newTag); err != nil {
+			return nil, fmt.Errorf("error creating tag: %w", err)
+		}
+		id = newTag.ID
+	}
+
+	if g.createdTags == nil {
+		g.createdTags = make(map[string]int)
+	}
+	g.createdTags[t.Name] = id
+
+	return &id, nil
+}
+
+// create creates the scraped markers that the scene does not already have.
+// Existing markers are never removed or changed. Returns the number of
+// markers created.
+func (g *sceneMarkers) create(ctx context.Context) (int, error) {
+	if len(g.scraped) == 0 || getFieldStrategy(g.fieldOptions) == FieldStrategyIgnore {
+		return 0, nil
+	}
+
+	createMissing := g.fieldOptions != nil && utils.IsTrue(g.fieldOptions.CreateMissing)
+
+	existing, err := g.markerReaderCreator.FindBySceneID(ctx, g.scene.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting scene markers: %w", err)
+	}
+
+	if getFieldStrategy(g.fieldOptions) == FieldStrategyOnlyIfEmpty && len(existing) > 0 {
+		return 0, nil
+	}
+
+	created := 0
+	for _, m := range g.scraped {
+		primaryTagID, err := g.tagID(ctx, m.PrimaryTag, createMissing)
+		if err != nil {
+			return created, err
+		}
+
+		if primaryTagID == nil {
+			logger.Debugf("Skipping marker %q at %.2fs: primary tag not found", m.Title, m.Seconds)
+			continue
+		}
+
+		if markerExists(existing, *primaryTagID, m.Seconds) {
+			continue
+		}
+
+		var tagIDs []int
+		for _, t := range m.Tags {
+			tagID, err := g.tagID(ctx, t, createMissing)
+			if err != nil {
+				return created, err
+			}
+
+			if tagID != nil && *tagID != *primaryTagID {
+				tagIDs = sliceutil.AppendUnique(tagIDs, *tagID)
+			}
+		}
+
+		newMarker := models.NewSceneMarker()
+		newMarker.Title = m.Title
+		newMarker.Seconds = m.Seconds
+		if m.EndSeconds != nil && *m.EndSeconds > m.Seconds {
+			newMarker.EndSeconds = m.EndSeconds
+		}
+		newMarker.PrimaryTagID = *primaryTagID
+		newMarker.SceneID = g.scene.ID
+
+		if err := g.markerReaderCreator.Create(ctx, &newMarker); err != nil {
+			return created, fmt.Errorf("error creating scene marker: %w", err)
+		}
+
+		if err := g.markerReaderCreator.UpdateTags(ctx, newMarker.ID, tagIDs); err != nil {
+			return created, fmt.Errorf("error setting scene marker tags: %w", err)
+		}
+
+		existing = append(existing, &newMarker)
+		created++
+	}
+
+	return created, nil
+}
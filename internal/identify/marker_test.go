@@ -0,0 +1,252 @@
+package identify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_sceneMarkers_create(t *testing.T) {
+	const (
+		sceneID         = 1
+		existingTagID   = 1
+		storedTagID     = 2
+		otherTagID      = 3
+		foundTagID      = 4
+		createdTagID    = 5
+		createdMarkerID = 10
+	)
+
+	var (
+		existingTagIDStr = "1"
+		storedTagIDStr   = "2"
+		otherTagIDStr    = "3"
+		invalidTagIDStr  = "invalid"
+		foundTagName     = "found"
+		missingTagName   = "missing"
+		endBeforeStart   = 5.0
+		endSeconds       = 40.0
+		createMissing    = true
+	)
+
+	scene := &models.Scene{ID: sceneID}
+	existing := []*models.SceneMarker{
+		{ID: 100, SceneID: sceneID, PrimaryTagID: existingTagID, Seconds: 10},
+	}
+
+	storedTag := func(id *string) *models.ScrapedTag {
+		return &models.ScrapedTag{Name: "stored " + *id, StoredID: id}
+	}
+	namedTag := func(name string) *models.ScrapedTag {
+		return &models.ScrapedTag{Name: name}
+	}
+
+	tests := []struct {
+		name          string
+		scraped       []*scraper.ScrapedSceneMarker
+		fieldOptions  *FieldOptions
+		existing      []*models.SceneMarker
+		wantMarkers   []models.SceneMarker
+		wantTagIDs    [][]int
+		wantCreateTag bool
+		want          int
+		wantErr       bool
+	}{
+		{
+			name: "ignore",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "marker", Seconds: 30, PrimaryTag: storedTag(&storedTagIDStr)},
+			},
+			fieldOptions: &FieldOptions{Strategy: FieldStrategyIgnore},
+			existing:     existing,
+			want:         0,
+		},
+		{
+			name: "only if empty with existing markers",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "marker", Seconds: 30, PrimaryTag: storedTag(&storedTagIDStr)},
+			},
+			fieldOptions: &FieldOptions{Strategy: FieldStrategyOnlyIfEmpty},
+			existing:     existing,
+			want:         0,
+		},
+		{
+			name: "only if empty without existing markers",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "marker", Seconds: 30, PrimaryTag: storedTag(&storedTagIDStr)},
+			},
+			fieldOptions: &FieldOptions{Strategy: FieldStrategyOnlyIfEmpty},
+			wantMarkers: []models.SceneMarker{
+				{Title: "marker", Seconds: 30, PrimaryTagID: storedTagID, SceneID: sceneID},
+			},
+			wantTagIDs: [][]int{nil},
+			want:       1,
+		},
+		{
+			name: "existing marker within tolerance",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "marker", Seconds: 10.5, PrimaryTag: storedTag(&existingTagIDStr)},
+			},
+			existing: existing,
+			want:     0,
+		},
+		{
+			name: "merge",
+			scraped: []*scraper.ScrapedSceneMarker{
+				// same primary tag as the existing marker, but at a different time
+				{Title: "later", Seconds: 30, EndSeconds: &endSeconds, PrimaryTag: storedTag(&existingTagIDStr)},
+				{
+					Title:      "tagged",
+					Seconds:    10,
+					EndSeconds: &endBeforeStart,
+					PrimaryTag: storedTag(&storedTagIDStr),
+					Tags: []*models.ScrapedTag{
+						storedTag(&storedTagIDStr),
+						storedTag(&otherTagIDStr),
+						storedTag(&otherTagIDStr),
+						namedTag(missingTagName),
+					},
+				},
+				// duplicate of the marker above
+				{Title: "duplicate", Seconds: 10.2, PrimaryTag: storedTag(&storedTagIDStr)},
+				// primary tag does not exist
+				{Title: "missing", Seconds: 50, PrimaryTag: namedTag(missingTagName)},
+			},
+			existing: existing,
+			wantMarkers: []models.SceneMarker{
+				{Title: "later", Seconds: 30, EndSeconds: &endSeconds, PrimaryTagID: existingTagID, SceneID: sceneID},
+				{Title: "tagged", Seconds: 10, PrimaryTagID: storedTagID, SceneID: sceneID},
+			},
+			wantTagIDs: [][]int{nil, {otherTagID}},
+			want:       2,
+		},
+		{
+			name: "create missing tags",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "created", Seconds: 20, PrimaryTag: namedTag(missingTagName), Tags: []*models.ScrapedTag{namedTag(foundTagName)}},
+				{Title: "created again", Seconds: 40, PrimaryTag: namedTag(missingTagName)},
+			},
+			fieldOptions: &FieldOptions{CreateMissing: &createMissing},
+			wantMarkers: []models.SceneMarker{
+				{Title: "created", Seconds: 20, PrimaryTagID: createdTagID, SceneID: sceneID},
+				{Title: "created again", Seconds: 40, PrimaryTagID: createdTagID, SceneID: sceneID},
+			},
+			wantTagIDs:    [][]int{{foundTagID}, nil},
+			wantCreateTag: true,
+			want:          2,
+		},
+		{
+			name: "invalid stored id",
+			scraped: []*scraper.ScrapedSceneMarker{
+				{Title: "marker", Seconds: 30, PrimaryTag: storedTag(&invalidTagIDStr)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+
+			db.SceneMarker.On("FindBySceneID", testCtx, sceneID).Return(tt.existing, nil)
+
+			var created []models.SceneMarker
+			db.SceneMarker.On("Create", testCtx, mock.AnythingOfType("*models.SceneMarker")).Run(func(args mock.Arguments) {
+				m := args.Get(1).(*models.SceneMarker)
+				m.ID = createdMarkerID + len(created)
+				created = append(created, *m)
+			}).Return(nil)
+
+			var tagIDs [][]int
+			db.SceneMarker.On("UpdateTags", testCtx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				assert.Equal(t, createdMarkerID+len(tagIDs), args.Int(1))
+				tagIDs = append(tagIDs, args.Get(2).([]int))
+			}).Return(nil)
+
+			db.Tag.On("FindByName", testCtx, foundTagName, true).Return(&models.Tag{ID: foundTagID}, nil)
+			db.Tag.On("FindByName", testCtx, missingTagName, true).Return(nil, nil)
+			db.Tag.On("Create", testCtx, mock.MatchedBy(func(t *models.Tag) bool {
+				return t.Name == missingTagName
+			})).Run(func(args mock.Arguments) {
+				args.Get(1).(*models.Tag).ID = createdTagID
+			}).Return(nil).Once()
+
+			g := &sceneMarkers{
+				markerReaderCreator: db.SceneMarker,
+				tagFinderCreator:    db.Tag,
+				scene:               scene,
+				scraped:             tt.scraped,
+				fieldOptions:        tt.fieldOptions,
+			}
+
+			got, err := g.create(testCtx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sceneMarkers.create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+
+			// ignore the timestamps set by NewSceneMarker
+			for i := range created {
+				created[i].ID = 0
+				created[i].CreatedAt = tt.wantMarkers[i].CreatedAt
+				created[i].UpdatedAt = tt.wantMarkers[i].UpdatedAt
+			}
+			assert.Equal(t, tt.wantMarkers, created)
+			assert.Equal(t, tt.wantTagIDs, tagIDs)
+
+			if tt.wantCreateTag {
+				db.Tag.AssertNumberOfCalls(t, "Create", 1)
+			} else {
+				db.Tag.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func Test_sceneMarkers_createError(t *testing.T) {
+	const sceneID = 1
+	storedTagID := "2"
+
+	scraped := []*scraper.ScrapedSceneMarker{
+		{Title: "marker", Seconds: 30, PrimaryTag: &models.ScrapedTag{Name: "tag", StoredID: &storedTagID}},
+	}
+
+	t.Run("find error", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		db.SceneMarker.On("FindBySceneID", testCtx, sceneID).Return(nil, errors.New("find error"))
+
+		g := &sceneMarkers{
+			markerReaderCreator: db.SceneMarker,
+			tagFinderCreator:    db.Tag,
+			scene:               &models.Scene{ID: sceneID},
+			scraped:             scraped,
+		}
+
+		_, err := g.create(testCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		db.SceneMarker.On("FindBySceneID", testCtx, sceneID).Return(nil, nil)
+		db.SceneMarker.On("Create", testCtx, mock.Anything).Return(errors.New("create error"))
+
+		g := &sceneMarkers{
+			markerReaderCreator: db.SceneMarker,
+			tagFinderCreator:    db.Tag,
+			scene:               &models.Scene{ID: sceneID},
+			scraped:             scraped,
+		}
+
+		got, err := g.create(testCtx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, got)
+		db.SceneMarker.AssertNotCalled(t, "UpdateTags", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
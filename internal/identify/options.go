@@ -23,6 +23,29 @@ type Options struct {
 	SceneIDs []string `json:"sceneIDs"`
 	// paths of scenes to identify - ignored if scene ids are set
 	Paths []string `json:"paths"`
+	// name of a saved preset to use for any sources or options not provided
+	Preset *string `json:"preset,omitempty"`
+}
+
+// Preset is a named, saved set of identify sources and options.
+type Preset struct {
+	Name    string           `json:"name"`
+	Sources []*Source        `json:"sources"`
+	Options *MetadataOptions `json:"options"`
+}
+
+// ApplyPreset returns a copy of the options with the sources and options of
+// the provided preset used where they have not been explicitly set.
+func (o Options) ApplyPreset(p Preset) Options {
+	ret := o
+	if len(ret.Sources) == 0 {
+		ret.Sources = p.Sources
+	}
+	if ret.Options == nil {
+		ret.Options = p.Options
+	}
+	ret.Preset = nil
+	return ret
 }
 
 type MetadataOptions struct {
@@ -41,6 +64,8 @@ type MetadataOptions struct {
 	SkipSingleNamePerformers *bool `json:"skipSingleNamePerformers"`
 	// ID of tag to tag skipped single name performers with
 	SkipSingleNamePerformerTag *string `json:"skipSingleNamePerformerTag"`
+	// don't change fields that were last set manually - defaults to false
+	SkipManuallySetFields *bool `json:"skipManuallySetFields"`
 }
 
 type FieldOptions struct {
@@ -62,17 +87,21 @@ const (
 	//   For multi-value fields, any existing values are removed and replaced with the
 	//   scraped values.
 	FieldStrategyOverwrite FieldStrategy = "OVERWRITE"
+	// Only sets the value if the field has no existing value.
+	//   For multi-value fields, values are only set if there are no existing values.
+	FieldStrategyOnlyIfEmpty FieldStrategy = "ONLY_IF_EMPTY"
 )
 
 var AllFieldStrategy = []FieldStrategy{
 	FieldStrategyIgnore,
 	FieldStrategyMerge,
 	FieldStrategyOverwrite,
+	FieldStrategyOnlyIfEmpty,
 }
 
 func (e FieldStrategy) IsValid() bool {
 	switch e {
-	case FieldStrategyIgnore, FieldStrategyMerge, FieldStrategyOverwrite:
+	case FieldStrategyIgnore, FieldStrategyMerge, FieldStrategyOverwrite, FieldStrategyOnlyIfEmpty:
 		return true
 	}
 	return false
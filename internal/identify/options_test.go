@@ -0,0 +1,75 @@
+package identify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+func TestOptions_ApplyPreset(t *testing.T) {
+	presetName := "preset"
+	scraperID := "scraper"
+	presetSources := []*Source{
+		{
+			Source: &scraper.Source{ScraperID: &scraperID},
+		},
+	}
+	presetOptions := &MetadataOptions{
+		FieldOptions: []*FieldOptions{
+			{
+				Field:    "title",
+				Strategy: FieldStrategyOnlyIfEmpty,
+			},
+		},
+	}
+	inputSources := []*Source{
+		{
+			Source: &scraper.Source{},
+		},
+	}
+	inputOptions := &MetadataOptions{}
+
+	preset := Preset{
+		Name:    presetName,
+		Sources: presetSources,
+		Options: presetOptions,
+	}
+
+	tests := []struct {
+		name  string
+		input Options
+		want  Options
+	}{
+		{
+			"empty input",
+			Options{
+				Preset:   &presetName,
+				SceneIDs: []string{"1"},
+			},
+			Options{
+				Sources:  presetSources,
+				Options:  presetOptions,
+				SceneIDs: []string{"1"},
+			},
+		},
+		{
+			"input overrides",
+			Options{
+				Sources: inputSources,
+				Options: inputOptions,
+				Preset:  &presetName,
+			},
+			Options{
+				Sources: inputSources,
+				Options: inputOptions,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.input.ApplyPreset(preset))
+		})
+	}
+}
@@ -20,6 +20,7 @@ type SceneCoverGetter interface {
 }
 
 type SceneReaderUpdater interface {
+	models.SceneGetter
 	SceneCoverGetter
 	models.SceneUpdater
 	models.PerformerIDLoader
@@ -89,6 +90,10 @@ func (g sceneRelationships) performers(ctx context.Context, ignoreMale bool) ([]
 	var performerIDs []int
 	originalPerformerIDs := g.scene.PerformerIDs.List()
 
+	if strategy == FieldStrategyOnlyIfEmpty && len(originalPerformerIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		performerIDs = originalPerformerIDs
@@ -148,6 +153,10 @@ func (g sceneRelationships) tags(ctx context.Context) ([]int, error) {
 	var tagIDs []int
 	originalTagIDs := target.TagIDs.List()
 
+	if strategy == FieldStrategyOnlyIfEmpty && len(originalTagIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		tagIDs = originalTagIDs
@@ -165,6 +174,13 @@ func (g sceneRelationships) tags(ctx context.Context) ([]int, error) {
 		} else if createMissing {
 			newTag := models.NewTag()
 			newTag.Name = t.Name
+			if t.CategoryID != nil {
+				categoryID, err := strconv.Atoi(*t.CategoryID)
+				if err != nil {
+					return nil, fmt.Errorf("error converting tag category ID %s: %w", *t.CategoryID, err)
+				}
+				newTag.CategoryID = &categoryID
+			}
 
 			err := g.tagCreator.Create(ctx, &newTag)
 			if err != nil {
@@ -209,6 +225,10 @@ func (g sceneRelationships) stashIDs(ctx context.Context, setUpdateTime bool) ([
 	var stashIDs models.StashIDs
 	originalStashIDs := target.StashIDs.List()
 
+	if strategy == FieldStrategyOnlyIfEmpty && len(originalStashIDs) > 0 {
+		return nil, nil
+	}
+
 	if strategy == FieldStrategyMerge {
 		// add to existing
 		// make a copy so we don't modify the original
@@ -250,16 +270,27 @@ func (g sceneRelationships) stashIDs(ctx context.Context, setUpdateTime bool) ([
 func (g sceneRelationships) cover(ctx context.Context) ([]byte, error) {
 	scraped := g.result.result.Image
 
-	if scraped == nil || *scraped == "" {
+	// unlike other fields, the cover is overwritten by default
+	fieldStrategy := g.fieldOptions["cover_image"]
+	strategy := FieldStrategyOverwrite
+	if fieldStrategy != nil && fieldStrategy.Strategy.IsValid() {
+		strategy = fieldStrategy.Strategy
+	}
+
+	if scraped == nil || *scraped == "" || strategy == FieldStrategyIgnore {
 		return nil, nil
 	}
 
-	// always overwrite if present
 	existingCover, err := g.sceneReader.GetCover(ctx, g.scene.ID)
 	if err != nil {
 		logger.Errorf("Error getting scene cover: %v", err)
 	}
 
+	// MERGE and ONLY_IF_EMPTY only set the cover if there is no existing cover
+	if strategy != FieldStrategyOverwrite && len(existingCover) > 0 {
+		return nil, nil
+	}
+
 	data, err := utils.ProcessImageInput(ctx, *scraped)
 	if err != nil {
 		return nil, fmt.Errorf("error processing image input: %w", err)
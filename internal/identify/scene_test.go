@@ -275,6 +275,38 @@ func Test_sceneRelationships_performers(t *testing.T) {
 			[]int{validStoredIDInt},
 			false,
 		},
+		{
+			"only if empty existing",
+			sceneWithPerformer,
+			&FieldOptions{
+				Strategy: FieldStrategyOnlyIfEmpty,
+			},
+			[]*models.ScrapedPerformer{
+				{
+					Name:     &validName,
+					StoredID: &validStoredID,
+				},
+			},
+			false,
+			nil,
+			false,
+		},
+		{
+			"only if empty absent",
+			emptyScene,
+			&FieldOptions{
+				Strategy: FieldStrategyOnlyIfEmpty,
+			},
+			[]*models.ScrapedPerformer{
+				{
+					Name:     &validName,
+					StoredID: &validStoredID,
+				},
+			},
+			false,
+			[]int{validStoredIDInt},
+			false,
+		},
 		{
 			"ignore male (not male)",
 			sceneWithPerformer,
@@ -843,3 +875,61 @@ func Test_sceneRelationships_cover(t *testing.T) {
 		})
 	}
 }
+
+func Test_sceneRelationships_coverStrategy(t *testing.T) {
+	const (
+		sceneID = iota
+		emptySceneID
+	)
+	existingData := []byte("existingData")
+	newData := []byte("newData")
+	newDataEncoded := "data:image/png;base64," + utils.GetBase64StringFromData(newData)
+
+	db := mocks.NewDatabase()
+
+	db.Scene.On("GetCover", testCtx, sceneID).Return(existingData, nil)
+	db.Scene.On("GetCover", testCtx, emptySceneID).Return(nil, nil)
+
+	tr := sceneRelationships{
+		sceneReader:  db.Scene,
+		fieldOptions: make(map[string]*FieldOptions),
+		result: &scrapeResult{
+			result: &scraper.ScrapedScene{
+				Image: &newDataEncoded,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sceneID  int
+		strategy FieldStrategy
+		want     []byte
+	}{
+		{"ignore", emptySceneID, FieldStrategyIgnore, nil},
+		{"overwrite existing", sceneID, FieldStrategyOverwrite, newData},
+		{"merge existing", sceneID, FieldStrategyMerge, nil},
+		{"merge absent", emptySceneID, FieldStrategyMerge, newData},
+		{"only if empty existing", sceneID, FieldStrategyOnlyIfEmpty, nil},
+		{"only if empty absent", emptySceneID, FieldStrategyOnlyIfEmpty, newData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr.scene = &models.Scene{
+				ID: tt.sceneID,
+			}
+			tr.fieldOptions["cover_image"] = &FieldOptions{
+				Strategy: tt.strategy,
+			}
+
+			got, err := tr.cover(testCtx)
+			if err != nil {
+				t.Errorf("sceneRelationships.cover() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sceneRelationships.cover() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
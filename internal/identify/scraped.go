@@ -0,0 +1,101 @@
+package identify
+
+import (
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+// metadataOptions returns the options of the preset source using the given
+// scraper, followed by the preset options, in order of precedence.
+func (p Preset) metadataOptions(scraperID string) []MetadataOptions {
+	var ret []MetadataOptions
+	for _, s := range p.Sources {
+		if s.Options != nil && s.Source != nil && s.Source.ScraperID != nil && *s.Source.ScraperID == scraperID {
+			ret = append(ret, *s.Options)
+			break
+		}
+	}
+
+	if p.Options != nil {
+		ret = append(ret, *p.Options)
+	}
+
+	return ret
+}
+
+// FilterScrapedScene removes the values of a scene scraped by the given
+// scraper that the preset would not set when identifying a scene. Fields with
+// the IGNORE strategy are cleared, as are male performers and the cover
+// image where the preset excludes them.
+func (p Preset) FilterScrapedScene(scraperID string, s *scraper.ScrapedScene) {
+	options := p.metadataOptions(scraperID)
+	fieldOptions := getFieldOptions(options)
+
+	ignored := func(field string) bool {
+		return getFieldStrategy(fieldOptions[field]) == FieldStrategyIgnore
+	}
+
+	if ignored("title") {
+		s.Title = nil
+	}
+	if ignored("code") {
+		s.Code = nil
+	}
+	if ignored("details") {
+		s.Details = nil
+	}
+	if ignored("director") {
+		s.Director = nil
+	}
+	if ignored("date") {
+		s.Date = nil
+	}
+	if ignored("url") {
+		s.URL = nil
+		s.URLs = nil
+	}
+	if ignored("studio") {
+		s.Studio = nil
+	}
+	if ignored("performers") {
+		s.Performers = nil
+	}
+	if ignored("tags") {
+		s.Tags = nil
+	}
+	if ignored("stash_ids") {
+		s.RemoteSiteID = nil
+	}
+
+	setCoverImage := true
+	includeMalePerformers := true
+	for _, o := range options {
+		if o.SetCoverImage != nil {
+			setCoverImage = *o.SetCoverImage
+			break
+		}
+	}
+	for _, o := range options {
+		if o.IncludeMalePerformers != nil {
+			includeMalePerformers = *o.IncludeMalePerformers
+			break
+		}
+	}
+
+	if ignored("cover_image") || !setCoverImage {
+		s.Image = nil
+	}
+
+	if !includeMalePerformers && len(s.Performers) > 0 {
+		var performers []*models.ScrapedPerformer
+		for _, p := range s.Performers {
+			if p.Gender != nil && strings.EqualFold(*p.Gender, models.GenderEnumMale.String()) {
+				continue
+			}
+			performers = append(performers, p)
+		}
+		s.Performers = performers
+	}
+}
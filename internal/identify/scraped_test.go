@@ -0,0 +1,71 @@
+package identify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+func TestPreset_FilterScrapedScene(t *testing.T) {
+	scraperID := "scraper"
+	male := models.GenderEnumMale.String()
+	female := models.GenderEnumFemale.String()
+	title := "title"
+	details := "details"
+	image := "image"
+	falseVal := false
+
+	preset := Preset{
+		Sources: []*Source{
+			{
+				Source: &scraper.Source{ScraperID: &scraperID},
+				Options: &MetadataOptions{
+					FieldOptions: []*FieldOptions{
+						{Field: "details", Strategy: FieldStrategyIgnore},
+					},
+					IncludeMalePerformers: &falseVal,
+				},
+			},
+		},
+		Options: &MetadataOptions{
+			FieldOptions: []*FieldOptions{
+				{Field: "title", Strategy: FieldStrategyIgnore},
+				{Field: "details", Strategy: FieldStrategyOverwrite},
+			},
+			SetCoverImage: &falseVal,
+		},
+	}
+
+	newScene := func() *scraper.ScrapedScene {
+		return &scraper.ScrapedScene{
+			Title:   &title,
+			Details: &details,
+			Image:   &image,
+			Performers: []*models.ScrapedPerformer{
+				{Gender: &male},
+				{Gender: &female},
+			},
+		}
+	}
+
+	// source options take precedence for the matching scraper
+	s := newScene()
+	preset.FilterScrapedScene(scraperID, s)
+	assert.Nil(t, s.Title)
+	assert.Nil(t, s.Details)
+	assert.Nil(t, s.Image)
+	if assert.Len(t, s.Performers, 1) {
+		assert.Equal(t, female, *s.Performers[0].Gender)
+	}
+
+	// only the preset options apply to other scrapers
+	s = newScene()
+	preset.FilterScrapedScene("other", s)
+	assert.Nil(t, s.Title)
+	assert.Equal(t, &details, s.Details)
+	assert.Nil(t, s.Image)
+	assert.Len(t, s.Performers, 2)
+}
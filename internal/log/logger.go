@@ -12,20 +12,47 @@ import (
 )
 
 type LogItem struct {
-	Time    time.Time `json:"time"`
-	Type    string    `json:"type"`
-	Message string    `json:"message"`
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	// Subsystem is the bracketed prefix of the message, such as "scan" for
+	// "[scan] ...". It is empty if the message has no prefix.
+	Subsystem string `json:"subsystem,omitempty"`
+	Message   string `json:"message"`
+
+	// seq is the sequence number of the item, used to avoid sending items
+	// to subscribers more than once.
+	seq uint64
+}
+
+// logCacheSize is the maximum number of items kept in the log cache.
+const logCacheSize = 1000
+
+// subsystemFromMessage returns the bracketed prefix of the message, or an
+// empty string if the message has no prefix.
+func subsystemFromMessage(msg string) string {
+	if !strings.HasPrefix(msg, "[") {
+		return ""
+	}
+
+	end := strings.Index(msg, "]")
+	if end == -1 {
+		return ""
+	}
+
+	return strings.TrimSpace(msg[1:end])
 }
 
 type Logger struct {
 	logger         *logrus.Logger
 	progressLogger *logrus.Logger
 	mutex          sync.Mutex
-	logCache       []LogItem
-	logSubs        []chan []LogItem
-	waiting        bool
-	lastBroadcast  time.Time
-	logBuffer      []LogItem
+	// logCache holds the most recent items, oldest first
+	logCache      []LogItem
+	logSubs       []chan []LogItem
+	seq           uint64
+	waiting       bool
+	lastBroadcast time.Time
+	logBuffer     []LogItem
 }
 
 func NewLogger() *Logger {
@@ -99,7 +126,7 @@ func logLevelFromString(level string) logrus.Level {
 	switch strings.ToLower(level) {
 	case "debug":
 		ret = logrus.DebugLevel
-	case "warning":
+	case "warning", "warn":
 		ret = logrus.WarnLevel
 	case "error":
 		ret = logrus.ErrorLevel
@@ -115,9 +142,9 @@ func (log *Logger) addToCache(l *LogItem) {
 	// only add to cache if meets minimum log level
 	level := logLevelFromString(l.Type)
 	if level <= log.logger.Level {
-		log.logCache = append([]LogItem{*l}, log.logCache...)
-		if len(log.logCache) > 30 {
-			log.logCache = log.logCache[:len(log.logCache)-1]
+		log.logCache = append(log.logCache, *l)
+		if len(log.logCache) > logCacheSize {
+			log.logCache = log.logCache[len(log.logCache)-logCacheSize:]
 		}
 	}
 }
@@ -125,16 +152,22 @@ func (log *Logger) addToCache(l *LogItem) {
 func (log *Logger) addLogItem(l *LogItem) {
 	log.mutex.Lock()
 	l.Time = time.Now()
+	l.Subsystem = subsystemFromMessage(l.Message)
+	log.seq++
+	l.seq = log.seq
 	log.addToCache(l)
 	log.mutex.Unlock()
 	go log.broadcastLogItem(l)
 }
 
+// GetLogCache returns the cached log items, newest first.
 func (log *Logger) GetLogCache() []LogItem {
 	log.mutex.Lock()
 
 	ret := make([]LogItem, len(log.logCache))
-	copy(ret, log.logCache)
+	for i, l := range log.logCache {
+		ret[len(ret)-i-1] = l
+	}
 
 	log.mutex.Unlock()
 
@@ -156,6 +189,98 @@ func (log *Logger) SubscribeToLog(stop chan int) <-chan []LogItem {
 	return ret
 }
 
+// LogFilter filters log items by level and subsystem.
+type LogFilter struct {
+	// MinLevel is the minimum level of the items, such as "info". Items of
+	// all levels are included if empty.
+	MinLevel string
+	// Subsystems are the subsystems of the items to include, compared
+	// case-insensitively. Items of all subsystems are included if empty.
+	Subsystems []string
+}
+
+// Matches returns true if the item is included by the filter.
+func (f LogFilter) Matches(l LogItem) bool {
+	if f.MinLevel != "" && logLevelFromString(l.Type) > logLevelFromString(f.MinLevel) {
+		return false
+	}
+
+	if len(f.Subsystems) == 0 {
+		return true
+	}
+
+	for _, s := range f.Subsystems {
+		if strings.EqualFold(s, l.Subsystem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filterLogItems(items []LogItem, filter LogFilter, afterSeq uint64) []LogItem {
+	var ret []LogItem
+	for _, l := range items {
+		if l.seq > afterSeq && filter.Matches(l) {
+			ret = append(ret, l)
+		}
+	}
+
+	return ret
+}
+
+// SubscribeToFilteredLog subscribes to the log items matching the filter.
+// The first batch sent to the returned channel contains up to backfill of
+// the most recent cached items matching the filter, oldest first. Items are
+// never sent more than once.
+func (log *Logger) SubscribeToFilteredLog(stop chan int, filter LogFilter, backfill int) <-chan []LogItem {
+	ret := make(chan []LogItem, 100)
+
+	// the backfill and the subscription are taken under the same lock so
+	// that no items are missed between them
+	log.mutex.Lock()
+
+	var history []LogItem
+	if backfill > 0 {
+		history = filterLogItems(log.logCache, filter, 0)
+		if len(history) > backfill {
+			history = history[len(history)-backfill:]
+		}
+	}
+	lastSeq := log.seq
+
+	sub := make(chan []LogItem, 100)
+	log.logSubs = append(log.logSubs, sub)
+	log.mutex.Unlock()
+
+	go func() {
+		defer close(ret)
+		defer log.unsubscribeFromLog(sub)
+
+		pending := history
+		for {
+			if len(pending) > 0 {
+				select {
+				case ret <- pending:
+					pending = nil
+				case <-stop:
+					return
+				}
+				continue
+			}
+
+			select {
+			case items := <-sub:
+				pending = filterLogItems(items, filter, lastSeq)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return ret
+}
+
 func (log *Logger) unsubscribeFromLog(toRemove chan []LogItem) {
 	log.mutex.Lock()
 	for i, c := range log.logSubs {
@@ -0,0 +1,115 @@
+package log
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger() *Logger {
+	ret := NewLogger()
+	ret.logger.SetOutput(io.Discard)
+	ret.progressLogger.SetOutput(io.Discard)
+	ret.SetLogLevel("debug")
+	return ret
+}
+
+func TestSubsystemFromMessage(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"[scan] scanning file", "scan"},
+		{"[Plugin / my plugin] hello", "Plugin / my plugin"},
+		{"no subsystem", ""},
+		{"[unterminated", ""},
+		{"not a [prefix]", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, subsystemFromMessage(tt.msg), tt.msg)
+	}
+}
+
+func TestLogFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter LogFilter
+		item   LogItem
+		want   bool
+	}{
+		{"empty", LogFilter{}, LogItem{Type: "trace"}, true},
+		{"below min level", LogFilter{MinLevel: "info"}, LogItem{Type: "debug"}, false},
+		{"min level", LogFilter{MinLevel: "info"}, LogItem{Type: "info"}, true},
+		{"above min level", LogFilter{MinLevel: "info"}, LogItem{Type: "warn"}, true},
+		{"warning below error", LogFilter{MinLevel: "error"}, LogItem{Type: "warn"}, false},
+		{"subsystem", LogFilter{Subsystems: []string{"Scan"}}, LogItem{Type: "info", Subsystem: "scan"}, true},
+		{"other subsystem", LogFilter{Subsystems: []string{"scan"}}, LogItem{Type: "info", Subsystem: "generator"}, false},
+		{"no subsystem", LogFilter{Subsystems: []string{"scan"}}, LogItem{Type: "info"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(tt.item))
+		})
+	}
+}
+
+func receiveMessages(t *testing.T, c <-chan []LogItem) []string {
+	t.Helper()
+
+	select {
+	case items := <-c:
+		var ret []string
+		for _, l := range items {
+			ret = append(ret, l.Message)
+		}
+		return ret
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for log items")
+		return nil
+	}
+}
+
+func TestLogger_SubscribeToFilteredLog(t *testing.T) {
+	l := newTestLogger()
+
+	l.Info("[scan] one")
+	l.Debug("[scan] two")
+	l.Info("[generator] three")
+	l.Info("[scan] four")
+
+	stop := make(chan int, 1)
+	defer func() { stop <- 0 }()
+
+	filter := LogFilter{
+		MinLevel:   "info",
+		Subsystems: []string{"scan"},
+	}
+	c := l.SubscribeToFilteredLog(stop, filter, 5)
+
+	// backfill is oldest first and filtered
+	assert.Equal(t, []string{"[scan] one", "[scan] four"}, receiveMessages(t, c))
+
+	l.Info("[generator] five")
+	l.Info("[scan] six")
+
+	// items logged before subscribing are not sent again
+	assert.Equal(t, []string{"[scan] six"}, receiveMessages(t, c))
+}
+
+func TestLogger_SubscribeToFilteredLog_BackfillLimit(t *testing.T) {
+	l := newTestLogger()
+
+	l.Info("one")
+	l.Info("two")
+	l.Info("three")
+
+	stop := make(chan int, 1)
+	defer func() { stop <- 0 }()
+
+	c := l.SubscribeToFilteredLog(stop, LogFilter{}, 2)
+	assert.Equal(t, []string{"two", "three"}, receiveMessages(t, c))
+}
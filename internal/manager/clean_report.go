@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+)
+
+type CleanReportItemType string
+
+const (
+	CleanReportItemTypeFile    CleanReportItemType = "FILE"
+	CleanReportItemTypeFolder  CleanReportItemType = "FOLDER"
+	CleanReportItemTypeGallery CleanReportItemType = "GALLERY"
+)
+
+func (e CleanReportItemType) IsValid() bool {
+	switch e {
+	case CleanReportItemTypeFile, CleanReportItemTypeFolder, CleanReportItemTypeGallery:
+		return true
+	}
+	return false
+}
+
+func (e CleanReportItemType) String() string {
+	return string(e)
+}
+
+func (e *CleanReportItemType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CleanReportItemType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CleanReportItemType", str)
+	}
+	return nil
+}
+
+func (e CleanReportItemType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CleanReportItem struct {
+	Type   CleanReportItemType `json:"type"`
+	Path   string              `json:"path"`
+	Reason string              `json:"reason"`
+}
+
+// CleanReport lists the files, folders and galleries that a dry run of the
+// clean task found to be removed. A clean with clean rules configured must
+// confirm a report, and only removes the items listed in it.
+type CleanReport struct {
+	ID        string             `json:"id"`
+	CreatedAt time.Time          `json:"created_at"`
+	Paths     []string           `json:"paths"`
+	Items     []*CleanReportItem `json:"items"`
+}
+
+func (r *CleanReport) addFileReport(report *file.CleanReport) {
+	for _, i := range report.Items {
+		t := CleanReportItemTypeFile
+		if i.Folder {
+			t = CleanReportItemTypeFolder
+		}
+
+		r.Items = append(r.Items, &CleanReportItem{
+			Type:   t,
+			Path:   i.Path,
+			Reason: i.Reason,
+		})
+	}
+}
+
+// fileReport returns the files and folders in the report.
+func (r *CleanReport) fileReport() *file.CleanReport {
+	ret := &file.CleanReport{}
+	for _, i := range r.Items {
+		if i.Type == CleanReportItemTypeGallery {
+			continue
+		}
+
+		ret.Items = append(ret.Items, file.CleanReportItem{
+			Path:   i.Path,
+			Folder: i.Type == CleanReportItemTypeFolder,
+			Reason: i.Reason,
+		})
+	}
+
+	return ret
+}
+
+func (r *CleanReport) containsGallery(path string) bool {
+	for _, i := range r.Items {
+		if i.Type == CleanReportItemTypeGallery && i.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cleanReportStore holds the report of the most recent dry run clean.
+type cleanReportStore struct {
+	report *CleanReport
+	mutex  sync.Mutex
+}
+
+func (s *cleanReportStore) get() *CleanReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.report
+}
+
+func (s *cleanReportStore) set(r *CleanReport) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.report = r
+}
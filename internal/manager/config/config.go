@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"sync"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
@@ -20,41 +21,62 @@ import (
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
 
+	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/backup"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/hash"
+	"github.com/stashapp/stash/pkg/hash/videophash"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/organize"
+	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
 const (
-	Stash               = "stash"
-	Cache               = "cache"
-	BackupDirectoryPath = "backup_directory_path"
-	Generated           = "generated"
-	Metadata            = "metadata"
-	BlobsPath           = "blobs_path"
-	Downloads           = "downloads"
-	ApiKey              = "api_key"
-	Username            = "username"
-	Password            = "password"
-	MaxSessionAge       = "max_session_age"
+	Stash                = "stash"
+	Cache                = "cache"
+	BackupDirectoryPath  = "backup_directory_path"
+	ReportsDirectoryPath = "reports_directory_path"
+	Generated            = "generated"
+	Metadata             = "metadata"
+	BlobsPath            = "blobs_path"
+	Downloads            = "downloads"
+	ApiKey               = "api_key"
+	Username             = "username"
+	Password             = "password"
+	MaxSessionAge        = "max_session_age"
+
+	// SQLQueryEnabled is the config key to enable the read-only querySQL query.
+	SQLQueryEnabled = "sql_query_enabled"
 
 	FFMpegPath  = "ffmpeg_path"
 	FFProbePath = "ffprobe_path"
 
 	BlobsStorage = "blobs_storage"
 
+	// GeneratedLayout is the directory layout of the generated scene files.
+	// It is changed by the generated layout migration, which moves the
+	// existing files.
+	GeneratedLayout = "generated_layout"
+
 	DefaultMaxSessionAge = 60 * 60 * 1 // 1 hours
 
 	Database = "database"
 
+	// SQLite write-ahead log tuning, for use with external replication tools
+	DatabaseSynchronous       = "database_synchronous"
+	DatabaseWALAutoCheckpoint = "database_wal_autocheckpoint"
+
 	Exclude      = "exclude"
 	ImageExclude = "image_exclude"
 
+	// per-path rules applied by the clean task
+	CleanRules = "clean_rules"
+
 	VideoExtensions            = "video_extensions"
 	ImageExtensions            = "image_extensions"
 	GalleryExtensions          = "gallery_extensions"
@@ -64,6 +86,10 @@ const (
 	// for video files.
 	CalculateMD5 = "calculate_md5"
 
+	// FingerprintAlgorithms is the config key for the additional checksum
+	// algorithms calculated for all files.
+	FingerprintAlgorithms = "fingerprint_algorithms"
+
 	// VideoFileNamingAlgorithm is the config key used to determine what hash
 	// should be used when generating and using generated files for scenes.
 	VideoFileNamingAlgorithm = "video_file_naming_algorithm"
@@ -80,8 +106,41 @@ const (
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// LowMemoryMode bounds caches and worker counts for devices with
+	// limited memory
+	LowMemoryMode = "low_memory_mode"
+
+	// seconds to wait for running jobs to finish when shutting down
+	ShutdownGracePeriod        = "shutdown_grace_period"
+	shutdownGracePeriodDefault = 5
+
 	PreviewPreset                 = "preview_preset"
 	TranscodeHardwareAcceleration = "ffmpeg.hardware_acceleration"
+	GenerateHardwareDecoding      = "ffmpeg.generate.hardware_decoding"
+
+	// RemoteWorkersEnabled allows remote workers to take generate jobs.
+	RemoteWorkersEnabled = "remote_workers_enabled"
+
+	// RemoteWorkerServerURL is the URL of the main instance that this
+	// instance takes generate jobs from. Empty if this instance is not a
+	// remote worker.
+	RemoteWorkerServerURL = "remote_worker_server_url"
+	RemoteWorkerAPIKey    = "remote_worker_api_key"
+
+	// TranscribeWhisperPath is the path of a whisper.cpp binary used to
+	// transcribe scene audio. Takes precedence over the transcription API.
+	TranscribeWhisperPath  = "transcribe_whisper_path"
+	TranscribeWhisperModel = "transcribe_whisper_model"
+
+	// TranscribeAPIURL is the URL of an OpenAI-compatible speech-to-text
+	// endpoint used to transcribe scene audio.
+	TranscribeAPIURL   = "transcribe_api_url"
+	TranscribeAPIKey   = "transcribe_api_key"
+	TranscribeAPIModel = "transcribe_api_model"
+
+	// TranscribeLanguage is the spoken language of scenes. The language is
+	// detected if empty.
+	TranscribeLanguage = "transcribe_language"
 
 	SequentialScanning        = "sequential_scanning"
 	SequentialScanningDefault = false
@@ -107,6 +166,10 @@ const (
 	CreateImageClipsFromVideos        = "create_image_clip_from_videos"
 	createImageClipsFromVideosDefault = false
 
+	// maximum size of the cache of transformed images, in MiB
+	ImageTransformCacheSize        = "image_transform_cache_size"
+	imageTransformCacheSizeDefault = 512
+
 	Host        = "host"
 	hostDefault = "0.0.0.0"
 
@@ -134,10 +197,55 @@ const (
 	ScraperCertCheck          = "scraper_cert_check"
 	ScraperCDPPath            = "scraper_cdp_path"
 	ScraperExcludeTagPatterns = "scraper_exclude_tag_patterns"
+	ScraperURLRoutes          = "scraper_url_routes"
+
+	// directory containing the managed runtime environments of script
+	// scrapers
+	ScraperRuntimesPath = "scraper_runtimes_path"
+
+	ScraperProxies                    = "scraper_proxies"
+	ScraperProxyHealthCheckURL        = "scraper_proxy_health_check_url"
+	scraperProxyHealthCheckURLDefault = "http://connectivitycheck.gstatic.com/generate_204"
+
+	// scene and performer url check options
+	URLCheckInterval = "url_check_interval"
+	URLCheckRescrape = "url_check_rescrape"
 
 	// stash-box options
 	StashBoxes = "stash_boxes"
 
+	// other stash instances to pull metadata from
+	FederatedInstances = "federated_instances"
+
+	// backup options
+	BackupInterval  = "backup_interval"
+	BackupRetention = "backup_retention"
+	BackupTarget    = "backup_target"
+
+	// job report options
+	ReportRetention        = "report_retention"
+	reportRetentionDefault = 30
+
+	// watch folder that new files are organized from
+	Inbox = "inbox"
+
+	// disk space options
+	DiskSpaceWarningPercent        = "disk_space.warning_percent"
+	diskSpaceWarningPercentDefault = 90
+	DiskSpaceReserved              = "disk_space.reserved"
+	diskSpaceReservedDefault       = 1024
+	DiskSpaceWebhookURLs           = "disk_space.webhook_urls"
+
+	// idle background generation options
+	BackfillEnabled              = "backfill.enabled"
+	BackfillPhashes              = "backfill.phashes"
+	BackfillSprites              = "backfill.sprites"
+	BackfillPreviews             = "backfill.previews"
+	BackfillMaxCPUPercent        = "backfill.max_cpu_percent"
+	backfillMaxCPUPercentDefault = 25
+	BackfillMaxTemperature       = "backfill.max_temperature"
+	BackfillWindow               = "backfill.window"
+
 	PythonPath = "python_path"
 
 	// plugin options
@@ -173,6 +281,24 @@ const (
 	GalleryCoverRegex        = "gallery_cover_regex"
 	galleryCoverRegexDefault = `(poster|cover|folder|board)\.[^\.]+$`
 
+	// SceneTitleTemplate is the template used to generate titles for scenes
+	// without a title, such as "{performers} - {studio} - {date}".
+	SceneTitleTemplate = "scene_title_template"
+
+	// ImportImageSidecars imports tags, urls and titles from image tag
+	// sidecar files when scanning.
+	ImportImageSidecars = "import_image_sidecars"
+
+	// ImageSidecarTagMappings maps sidecar tag namespaces to tag names.
+	ImageSidecarTagMappings = "image_sidecar_tag_mappings"
+
+	// ScenePrimaryFileRules are the rules used to choose the primary file
+	// when scan adds a file to an existing scene.
+	ScenePrimaryFileRules = "scene_primary_file_rules"
+
+	// PhashOptions are the options used to generate custom phashes.
+	PhashOptions = "phash_options"
+
 	// Interface options
 	MenuItems = "menu_items"
 
@@ -241,10 +367,14 @@ const (
 	DLNADefaultEnabled     = "dlna.default_enabled"
 	DLNADefaultIPWhitelist = "dlna.default_whitelist"
 	DLNAInterfaces         = "dlna.interfaces"
+	DLNAInterfaceSettings  = "dlna.interface_settings"
+	DLNAIPv6               = "dlna.ipv6"
 
 	DLNAVideoSortOrder        = "dlna.video_sort_order"
 	dlnaVideoSortOrderDefault = "title"
 
+	DLNAServePreviews = "dlna.serve_previews"
+
 	DLNAPort        = "dlna.port"
 	DLNAPortDefault = 1338
 
@@ -260,6 +390,7 @@ const (
 	// Default settings
 	DefaultScanSettings     = "defaults.scan_task"
 	DefaultIdentifySettings = "defaults.identify_task"
+	IdentifyPresets         = "defaults.identify_presets"
 	DefaultAutoTagSettings  = "defaults.auto_tag_task"
 	DefaultGenerateSettings = "defaults.generate_task"
 
@@ -321,6 +452,12 @@ type Config struct {
 	// configUpdates  chan int
 	certFile string
 	keyFile  string
+
+	// number of changes since startup
+	revision int
+	// settings changed in the config file that require a restart
+	restartRequired []string
+
 	sync.RWMutex
 	// deadlock.RWMutex // for deadlock testing/issues
 }
@@ -427,6 +564,7 @@ func (i *Config) set(key string, value interface{}) {
 	// default behaviour for Set is to merge the value
 	// we want to replace it
 	i.main.Delete(key)
+	i.revision++
 
 	if value == nil {
 		return
@@ -654,6 +792,12 @@ func (i *Config) GetStashPaths() StashConfigs {
 		}
 	}
 
+	// normalise the paths so that extended-length paths and paths with
+	// trailing separators match the paths of scanned files
+	for _, s := range ret {
+		s.Path = fsutil.NormalizePath(s.Path)
+	}
+
 	return ret
 }
 
@@ -665,6 +809,17 @@ func (i *Config) GetGeneratedPath() string {
 	return i.getString(Generated)
 }
 
+// GetGeneratedLayout returns the directory layout of the generated scene
+// files. Defaults to the flat layout.
+func (i *Config) GetGeneratedLayout() paths.Layout {
+	ret := paths.Layout(i.getString(GeneratedLayout))
+	if !ret.IsValid() {
+		ret = paths.LayoutFlat
+	}
+
+	return ret
+}
+
 func (i *Config) GetBlobsPath() string {
 	return i.getString(BlobsPath)
 }
@@ -709,6 +864,222 @@ func (i *Config) GetBackupDirectoryPathOrDefault() string {
 	return ret
 }
 
+func (i *Config) GetReportsDirectoryPath() string {
+	return i.getString(ReportsDirectoryPath)
+}
+
+// GetReportsDirectoryPathOrDefault returns the directory that job reports
+// are written to. Defaults to the reports directory in the config directory.
+func (i *Config) GetReportsDirectoryPathOrDefault() string {
+	ret := i.GetReportsDirectoryPath()
+	if ret == "" {
+		return filepath.Join(i.GetConfigPath(), "reports")
+	}
+
+	return ret
+}
+
+// GetReportRetention returns how long job reports are kept in the reports
+// directory. Returns 0 if all reports are kept.
+func (i *Config) GetReportRetention() time.Duration {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := reportRetentionDefault
+	v := i.forKey(ReportRetention)
+	if v.Exists(ReportRetention) {
+		ret = v.Int(ReportRetention)
+	}
+	return time.Duration(ret) * 24 * time.Hour
+}
+
+// GetBackupInterval returns the interval between scheduled database backups.
+// Returns 0 if scheduled backups are disabled.
+func (i *Config) GetBackupInterval() time.Duration {
+	return time.Duration(i.getInt(BackupInterval)) * time.Hour
+}
+
+// GetDatabaseSynchronous returns the SQLite synchronous mode of the database.
+// Returns an empty string if not set.
+func (i *Config) GetDatabaseSynchronous() string {
+	return strings.ToUpper(i.getString(DatabaseSynchronous))
+}
+
+// GetDatabaseWALAutoCheckpoint returns the number of write-ahead log pages
+// after which the database is checkpointed automatically. Zero disables
+// automatic checkpoints. Returns -1 if not set.
+func (i *Config) GetDatabaseWALAutoCheckpoint() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	v := i.forKey(DatabaseWALAutoCheckpoint)
+	if v.Exists(DatabaseWALAutoCheckpoint) {
+		return v.Int(DatabaseWALAutoCheckpoint)
+	}
+	return -1
+}
+
+// GetShutdownGracePeriod returns the time to wait for running jobs to
+// finish or checkpoint their progress when the server shuts down.
+func (i *Config) GetShutdownGracePeriod() time.Duration {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := shutdownGracePeriodDefault
+	v := i.forKey(ShutdownGracePeriod)
+	if v.Exists(ShutdownGracePeriod) {
+		ret = v.Int(ShutdownGracePeriod)
+	}
+	return time.Duration(ret) * time.Second
+}
+
+// GetDiskSpaceWarningPercent returns the percentage of used disk space, or
+// of a library path quota, at which a warning is raised.
+func (i *Config) GetDiskSpaceWarningPercent() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := diskSpaceWarningPercentDefault
+	v := i.forKey(DiskSpaceWarningPercent)
+	if v.Exists(DiskSpaceWarningPercent) {
+		ret = v.Int(DiskSpaceWarningPercent)
+	}
+	return ret
+}
+
+// GetDiskSpaceReserved returns the number of MiB that tasks writing files
+// must leave free on the disk. Returns 0 if disabled.
+func (i *Config) GetDiskSpaceReserved() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := diskSpaceReservedDefault
+	v := i.forKey(DiskSpaceReserved)
+	if v.Exists(DiskSpaceReserved) {
+		ret = v.Int(DiskSpaceReserved)
+	}
+	return ret
+}
+
+// GetDiskSpaceReservedBytes returns GetDiskSpaceReserved in bytes.
+func (i *Config) GetDiskSpaceReservedBytes() int64 {
+	return int64(i.GetDiskSpaceReserved()) * 1024 * 1024
+}
+
+// GetBackfillEnabled returns true if missing generated content is generated
+// in the background while the system is idle.
+func (i *Config) GetBackfillEnabled() bool {
+	return i.getBool(BackfillEnabled)
+}
+
+// GetBackfillPhashes returns true if missing phashes are generated in the
+// background. Defaults to true.
+func (i *Config) GetBackfillPhashes() bool {
+	return i.getBoolDefault(BackfillPhashes, true)
+}
+
+// GetBackfillSprites returns true if missing sprites are generated in the
+// background. Defaults to true.
+func (i *Config) GetBackfillSprites() bool {
+	return i.getBoolDefault(BackfillSprites, true)
+}
+
+// GetBackfillPreviews returns true if missing previews are generated in the
+// background. Defaults to true.
+func (i *Config) GetBackfillPreviews() bool {
+	return i.getBoolDefault(BackfillPreviews, true)
+}
+
+// GetBackfillMaxCPUPercent returns the percentage of CPU usage above which
+// background generation is paused.
+func (i *Config) GetBackfillMaxCPUPercent() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := backfillMaxCPUPercentDefault
+	v := i.forKey(BackfillMaxCPUPercent)
+	if v.Exists(BackfillMaxCPUPercent) {
+		ret = v.Int(BackfillMaxCPUPercent)
+	}
+	return ret
+}
+
+// GetBackfillMaxTemperature returns the temperature, in degrees Celsius,
+// above which background generation is paused. Returns 0 if the temperature
+// is not checked.
+func (i *Config) GetBackfillMaxTemperature() int {
+	return i.getInt(BackfillMaxTemperature)
+}
+
+// GetBackfillWindow returns the daily time window that background generation
+// runs in, in the form HH:MM-HH:MM. Returns an empty string if it may run at
+// any time.
+func (i *Config) GetBackfillWindow() string {
+	return i.getString(BackfillWindow)
+}
+
+// GetDiskSpaceWebhookURLs returns the URLs that disk space warnings are
+// posted to.
+func (i *Config) GetDiskSpaceWebhookURLs() []string {
+	return i.getStringSlice(DiskSpaceWebhookURLs)
+}
+
+// GetBackupRetention returns the number of database backups kept in the
+// backup directory. Returns 0 if all backups are kept.
+func (i *Config) GetBackupRetention() int {
+	return i.getInt(BackupRetention)
+}
+
+// GetBackupTarget returns the off-site target that scheduled backups are
+// uploaded to. Returns nil if not set.
+func (i *Config) GetBackupTarget() *backup.Target {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(BackupTarget)
+
+	if v.Exists(BackupTarget) && v.Get(BackupTarget) != nil {
+		var ret backup.Target
+
+		if err := v.Unmarshal(BackupTarget, &ret); err != nil {
+			logger.Warnf("error in unmarshalkey: %v", err)
+			return nil
+		}
+
+		if !ret.Enabled() {
+			return nil
+		}
+		return &ret
+	}
+
+	return nil
+}
+
+// GetInbox returns the watch folder configuration. Returns nil if not set.
+func (i *Config) GetInbox() *organize.Inbox {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(Inbox)
+
+	if v.Exists(Inbox) && v.Get(Inbox) != nil {
+		var ret organize.Inbox
+
+		if err := v.Unmarshal(Inbox, &ret); err != nil {
+			logger.Warnf("error in unmarshalkey: %v", err)
+			return nil
+		}
+
+		if ret.Path == "" {
+			return nil
+		}
+		if ret.Collision == "" {
+			ret.Collision = organize.CollisionStrategySkip
+		}
+		return &ret
+	}
+
+	return nil
+}
+
 // GetFFMpegPath returns the path to the FFMpeg executable.
 // If empty, stash will attempt to resolve it from the path.
 func (i *Config) GetFFMpegPath() string {
@@ -736,6 +1107,13 @@ func (i *Config) GetDefaultScrapersPath() string {
 	return fn
 }
 
+func (i *Config) GetDefaultScraperRuntimesPath() string {
+	// default to the same directory as the config file. Environments are
+	// not stored in the scrapers directory, since it is searched for
+	// scraper configurations.
+	return filepath.Join(i.GetConfigPath(), "scraper_runtimes")
+}
+
 func (i *Config) GetExcludes() []string {
 	return i.getStringSlice(Exclude)
 }
@@ -744,6 +1122,15 @@ func (i *Config) GetImageExcludes() []string {
 	return i.getStringSlice(ImageExclude)
 }
 
+func (i *Config) GetCleanRules() []*models.CleanRule {
+	var rules []*models.CleanRule
+	if err := i.unmarshalKey(CleanRules, &rules); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return rules
+}
+
 func (i *Config) GetVideoExtensions() []string {
 	ret := i.getStringSlice(VideoExtensions)
 	if len(ret) == 0 {
@@ -789,6 +1176,19 @@ func (i *Config) IsCalculateMD5() bool {
 	return i.getBool(CalculateMD5)
 }
 
+// GetFingerprintAlgorithms returns the additional checksum algorithms to
+// calculate for all files. Unsupported algorithms are ignored.
+func (i *Config) GetFingerprintAlgorithms() []string {
+	var ret []string
+	for _, a := range i.getStringSlice(FingerprintAlgorithms) {
+		if hash.IsChecksumAlgorithm(a) {
+			ret = append(ret, a)
+		}
+	}
+
+	return ret
+}
+
 // GetVideoFileNamingAlgorithm returns what hash algorithm should be used for
 // naming generated scene video files.
 func (i *Config) GetVideoFileNamingAlgorithm() models.HashAlgorithm {
@@ -806,6 +1206,10 @@ func (i *Config) GetSequentialScanning() bool {
 	return i.getBool(SequentialScanning)
 }
 
+func (i *Config) GetSceneTitleTemplate() string {
+	return i.getString(SceneTitleTemplate)
+}
+
 func (i *Config) GetGalleryCoverRegex() string {
 	var regexString = i.getString(GalleryCoverRegex)
 
@@ -818,10 +1222,57 @@ func (i *Config) GetGalleryCoverRegex() string {
 	return regexString
 }
 
+func (i *Config) GetImportImageSidecars() bool {
+	return i.getBool(ImportImageSidecars)
+}
+
+// GetImageSidecarTagMappings returns the mappings of sidecar tag namespaces
+// to tag names, in the form <namespace>=<tag name>.
+func (i *Config) GetImageSidecarTagMappings() []string {
+	return i.getStringSlice(ImageSidecarTagMappings)
+}
+
+// GetScenePrimaryFileRules returns the ordered rules used to choose the
+// primary file when scan adds a file to an existing scene.
+func (i *Config) GetScenePrimaryFileRules() []string {
+	return i.getStringSlice(ScenePrimaryFileRules)
+}
+
+// GetPhashOptions returns the options used to generate custom phashes.
+// Unset options are the default phash options.
+func (i *Config) GetPhashOptions() videophash.Options {
+	var ret videophash.Options
+	if err := i.unmarshalKey(PhashOptions, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	if ret.FrameCount == 0 {
+		ret.FrameCount = videophash.DefaultOptions.FrameCount
+	}
+	if ret.Sampling == "" {
+		ret.Sampling = videophash.DefaultOptions.Sampling
+	}
+	if ret.DCTSize == 0 {
+		ret.DCTSize = videophash.DefaultOptions.DCTSize
+	}
+
+	return ret
+}
+
 func (i *Config) GetScrapersPath() string {
 	return i.getString(ScrapersPath)
 }
 
+// GetScraperRuntimesPath returns the directory containing the managed
+// python and node environments of script scrapers.
+func (i *Config) GetScraperRuntimesPath() string {
+	ret := i.getString(ScraperRuntimesPath)
+	if ret == "" {
+		ret = i.GetDefaultScraperRuntimesPath()
+	}
+	return ret
+}
+
 func (i *Config) GetScraperUserAgent() string {
 	return i.getString(ScraperUserAgent)
 }
@@ -842,6 +1293,68 @@ func (i *Config) GetScraperExcludeTagPatterns() []string {
 	return i.getStringSlice(ScraperExcludeTagPatterns)
 }
 
+// GetScraperURLRoutes returns the routes used to choose the scraper and
+// identify preset for a scene URL, in order of precedence.
+// Returns nil if the routes could not be unmarshalled, or if none have been set.
+func (i *Config) GetScraperURLRoutes() []*scraper.URLRoute {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(ScraperURLRoutes)
+
+	if v.Exists(ScraperURLRoutes) && v.Get(ScraperURLRoutes) != nil {
+		var ret []*scraper.URLRoute
+
+		if err := v.Unmarshal(ScraperURLRoutes, &ret); err != nil {
+			return nil
+		}
+		return ret
+	}
+
+	return nil
+}
+
+// GetScraperProxies returns the proxies that scraper requests are routed
+// through. Returns nil if the proxies could not be unmarshalled, or if none
+// have been set.
+func (i *Config) GetScraperProxies() []*scraper.Proxy {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(ScraperProxies)
+
+	if v.Exists(ScraperProxies) && v.Get(ScraperProxies) != nil {
+		var ret []*scraper.Proxy
+
+		if err := v.Unmarshal(ScraperProxies, &ret); err != nil {
+			return nil
+		}
+		return ret
+	}
+
+	return nil
+}
+
+// GetURLCheckInterval returns the interval between scheduled checks of each
+// scene and performer url. Returns 0 if scheduled checks are disabled.
+func (i *Config) GetURLCheckInterval() time.Duration {
+	return time.Duration(i.getInt(URLCheckInterval)) * time.Hour
+}
+
+// GetURLCheckRescrape returns true if urls with changed content are scraped
+// to detect metadata updates.
+func (i *Config) GetURLCheckRescrape() bool {
+	return i.getBool(URLCheckRescrape)
+}
+
+// GetScraperProxyHealthCheckURL returns the url requested through each
+// scraper proxy to check that it is available.
+func (i *Config) GetScraperProxyHealthCheckURL() string {
+	ret := i.getString(ScraperProxyHealthCheckURL)
+	if ret == "" {
+		ret = scraperProxyHealthCheckURLDefault
+	}
+	return ret
+}
+
 func (i *Config) GetStashBoxes() []*models.StashBox {
 	var boxes []*models.StashBox
 	if err := i.unmarshalKey(StashBoxes, &boxes); err != nil {
@@ -851,6 +1364,28 @@ func (i *Config) GetStashBoxes() []*models.StashBox {
 	return boxes
 }
 
+func (i *Config) GetFederatedInstances() []*models.FederatedInstance {
+	var instances []*models.FederatedInstance
+	if err := i.unmarshalKey(FederatedInstances, &instances); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return instances
+}
+
+// GetFederatedInstance returns the federated instance with the given URL,
+// or nil if not found.
+func (i *Config) GetFederatedInstance(url string) *models.FederatedInstance {
+	url = strings.TrimSuffix(url, "/")
+	for _, instance := range i.GetFederatedInstances() {
+		if strings.EqualFold(strings.TrimSuffix(instance.URL, "/"), url) {
+			return instance
+		}
+	}
+
+	return nil
+}
+
 func (i *Config) GetDefaultPluginsPath() string {
 	// default to the same directory as the config file
 	fn := filepath.Join(i.GetConfigPath(), "plugins")
@@ -948,7 +1483,14 @@ func (i *Config) GetParallelTasks() int {
 	return i.getInt(ParallelTasks)
 }
 
+// GetParallelTasksWithAutoDetection returns the number of parallel tasks,
+// detecting the number from the number of CPUs if not set. Returns 1 in
+// low memory mode.
 func (i *Config) GetParallelTasksWithAutoDetection() int {
+	if i.GetLowMemoryMode() {
+		return 1
+	}
+
 	parallelTasks := i.getInt(ParallelTasks)
 	if parallelTasks <= 0 {
 		parallelTasks = (runtime.NumCPU() / 4) + 1
@@ -956,6 +1498,12 @@ func (i *Config) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetLowMemoryMode returns true if memory use should be reduced at the cost
+// of performance, for devices such as the Raspberry Pi.
+func (i *Config) GetLowMemoryMode() bool {
+	return i.getBool(LowMemoryMode)
+}
+
 func (i *Config) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
@@ -1001,6 +1549,62 @@ func (i *Config) GetTranscodeHardwareAcceleration() bool {
 	return i.getBool(TranscodeHardwareAcceleration)
 }
 
+// GetGenerateHardwareDecoding returns true if hardware accelerated decoding
+// should be used when extracting frames for generated sprites, covers and
+// previews.
+func (i *Config) GetGenerateHardwareDecoding() bool {
+	return i.getBool(GenerateHardwareDecoding)
+}
+
+// GetRemoteWorkersEnabled returns true if remote workers may register and
+// take generate jobs.
+func (i *Config) GetRemoteWorkersEnabled() bool {
+	return i.getBool(RemoteWorkersEnabled)
+}
+
+// GetRemoteWorkerServerURL returns the URL of the main instance that this
+// instance takes generate jobs from.
+func (i *Config) GetRemoteWorkerServerURL() string {
+	return i.getString(RemoteWorkerServerURL)
+}
+
+// GetRemoteWorkerAPIKey returns the API key used to authenticate with the
+// main instance.
+func (i *Config) GetRemoteWorkerAPIKey() string {
+	return i.getString(RemoteWorkerAPIKey)
+}
+
+// GetTranscribeWhisperPath returns the path of the whisper.cpp binary used
+// to transcribe scene audio.
+func (i *Config) GetTranscribeWhisperPath() string {
+	return i.getString(TranscribeWhisperPath)
+}
+
+// GetTranscribeWhisperModel returns the path of the whisper.cpp model file.
+func (i *Config) GetTranscribeWhisperModel() string {
+	return i.getString(TranscribeWhisperModel)
+}
+
+// GetTranscribeAPIURL returns the URL of the speech-to-text endpoint used to
+// transcribe scene audio.
+func (i *Config) GetTranscribeAPIURL() string {
+	return i.getString(TranscribeAPIURL)
+}
+
+func (i *Config) GetTranscribeAPIKey() string {
+	return i.getString(TranscribeAPIKey)
+}
+
+func (i *Config) GetTranscribeAPIModel() string {
+	return i.getString(TranscribeAPIModel)
+}
+
+// GetTranscribeLanguage returns the spoken language of scenes, or an empty
+// string if the language should be detected.
+func (i *Config) GetTranscribeLanguage() string {
+	return i.getString(TranscribeLanguage)
+}
+
 func (i *Config) GetMaxTranscodeSize() models.StreamingResolutionEnum {
 	ret := i.getString(MaxTranscodeSize)
 
@@ -1053,6 +1657,37 @@ func (i *Config) IsCreateImageClipsFromVideos() bool {
 	return i.getBool(CreateImageClipsFromVideos)
 }
 
+// GetImageTransformCacheSize returns the maximum size in MiB of the cache of
+// transformed images. Returns 0 if disabled.
+func (i *Config) GetImageTransformCacheSize() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := imageTransformCacheSizeDefault
+	v := i.forKey(ImageTransformCacheSize)
+	if v.Exists(ImageTransformCacheSize) {
+		ret = v.Int(ImageTransformCacheSize)
+	}
+	return ret
+}
+
+// GetImageTransformCacheSizeBytes returns GetImageTransformCacheSize in bytes.
+func (i *Config) GetImageTransformCacheSizeBytes() int64 {
+	return int64(i.GetImageTransformCacheSize()) * 1024 * 1024
+}
+
+// GetImageTransformCachePath returns the directory of the cache of
+// transformed images. Returns an empty string if the cache directory is not
+// set.
+func (i *Config) GetImageTransformCachePath() string {
+	cachePath := i.GetCachePath()
+	if cachePath == "" {
+		return ""
+	}
+
+	return filepath.Join(cachePath, "image_transforms")
+}
+
 func (i *Config) GetAPIKey() string {
 	return i.getString(ApiKey)
 }
@@ -1135,6 +1770,50 @@ func (i *Config) ValidateStashBoxes(boxes []*StashBoxInput) error {
 	return nil
 }
 
+func (i *Config) ValidateFederatedInstances(instances []*models.FederatedInstance) error {
+	names := make(map[string]bool)
+
+	for _, instance := range instances {
+		if instance.Name == "" {
+			return fmt.Errorf("federated instance name cannot be blank")
+		}
+
+		if names[strings.ToLower(instance.Name)] {
+			return fmt.Errorf("federated instance name %q is used more than once", instance.Name)
+		}
+		names[strings.ToLower(instance.Name)] = true
+
+		u, err := url.Parse(instance.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("federated instance %q has an invalid url: %q", instance.Name, instance.URL)
+		}
+	}
+
+	return nil
+}
+
+func (i *Config) ValidateCleanRules(rules []*models.CleanRule) error {
+	for _, r := range rules {
+		if r.Path == "" {
+			return fmt.Errorf("clean rule path cannot be blank")
+		}
+
+		if (r.MinSize != nil && *r.MinSize < 0) || (r.MaxSize != nil && *r.MaxSize < 0) {
+			return fmt.Errorf("clean rule for %q has a negative size", r.Path)
+		}
+
+		if r.MinSize != nil && r.MaxSize != nil && *r.MinSize > *r.MaxSize {
+			return fmt.Errorf("clean rule for %q has a minimum size greater than its maximum size", r.Path)
+		}
+
+		if r.MaxAgeDays != nil && *r.MaxAgeDays <= 0 {
+			return fmt.Errorf("clean rule for %q must have a positive maximum age", r.Path)
+		}
+	}
+
+	return nil
+}
+
 // GetMaxSessionAge gets the maximum age for session cookies, in seconds.
 // Session cookie expiry times are refreshed every request.
 func (i *Config) GetMaxSessionAge() int {
@@ -1150,6 +1829,12 @@ func (i *Config) GetMaxSessionAge() int {
 	return ret
 }
 
+// IsSQLQueryEnabled returns true if read-only SQL queries may be executed
+// against the database using the querySQL query.
+func (i *Config) IsSQLQueryEnabled() bool {
+	return i.getBool(SQLQueryEnabled)
+}
+
 // GetCustomServedFolders gets the map of custom paths to their applicable
 // filesystem locations
 func (i *Config) GetCustomServedFolders() utils.URLMap {
@@ -1475,6 +2160,37 @@ func (i *Config) GetDefaultIdentifySettings() *identify.Options {
 	return nil
 }
 
+// GetIdentifyPresets returns the saved Identify task presets.
+// Returns nil if the presets could not be unmarshalled, or if none have been set.
+func (i *Config) GetIdentifyPresets() []*identify.Preset {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(IdentifyPresets)
+
+	if v.Exists(IdentifyPresets) && v.Get(IdentifyPresets) != nil {
+		var ret []*identify.Preset
+
+		if err := v.Unmarshal(IdentifyPresets, &ret); err != nil {
+			return nil
+		}
+		return ret
+	}
+
+	return nil
+}
+
+// GetIdentifyPreset returns the saved Identify task preset with the given name.
+// Returns nil if no such preset exists.
+func (i *Config) GetIdentifyPreset(name string) *identify.Preset {
+	for _, p := range i.GetIdentifyPresets() {
+		if p.Name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
 // GetDefaultScanSettings returns the default Scan task settings.
 // Returns nil if the settings could not be unmarshalled, or if it
 // has not been set.
@@ -1568,6 +2284,32 @@ func (i *Config) GetDLNAInterfaces() []string {
 	return i.getStringSlice(DLNAInterfaces)
 }
 
+// GetDLNAInterfaceSettings returns the per-interface DLNA settings, used to
+// disable DLNA or set the friendly name on individual interfaces.
+// Returns nil if the settings could not be unmarshalled, or if none have been set.
+func (i *Config) GetDLNAInterfaceSettings() []*dlna.InterfaceConfig {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(DLNAInterfaceSettings)
+
+	if v.Exists(DLNAInterfaceSettings) && v.Get(DLNAInterfaceSettings) != nil {
+		var ret []*dlna.InterfaceConfig
+
+		if err := v.Unmarshal(DLNAInterfaceSettings, &ret); err != nil {
+			return nil
+		}
+		return ret
+	}
+
+	return nil
+}
+
+// GetDLNAIPv6 returns true if DLNA should be announced over IPv6 as well as
+// IPv4.
+func (i *Config) GetDLNAIPv6() bool {
+	return i.getBool(DLNAIPv6)
+}
+
 // GetDLNAPort returns the port to run the DLNA server on. If empty, 1338
 // will be used.
 func (i *Config) GetDLNAPort() int {
@@ -1594,6 +2336,12 @@ func (i *Config) GetVideoSortOrder() string {
 	return ret
 }
 
+// GetDLNAServePreviews returns true if the generated preview of each scene
+// should be offered to DLNA clients as a trailer resource.
+func (i *Config) GetDLNAServePreviews() bool {
+	return i.getBool(DLNAServePreviews)
+}
+
 // GetLogFile returns the filename of the file to output logs to.
 // An empty string means that file logging will be disabled.
 func (i *Config) GetLogFile() string {
@@ -1724,6 +2472,12 @@ func (i *Config) GetScraperPackagePathGetter() packagePathGetter {
 func (i *Config) Validate() error {
 	i.RLock()
 	defer i.RUnlock()
+
+	return i.validate()
+}
+
+// validate assumes lock held.
+func (i *Config) validate() error {
 	mandatoryPaths := []string{
 		Database,
 		Generated,
@@ -1743,7 +2497,7 @@ func (i *Config) Validate() error {
 		}
 	}
 
-	if i.GetBlobsStorage() == BlobStorageTypeFilesystem && i.forKey(BlobsPath).String(BlobsPath) == "" {
+	if BlobsStorageType(i.forKey(BlobsStorage).String(BlobsStorage)) == BlobStorageTypeFilesystem && i.forKey(BlobsPath).String(BlobsPath) == "" {
 		return MissingConfigError{
 			missingFields: []string{BlobsPath},
 		}
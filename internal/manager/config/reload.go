@@ -0,0 +1,244 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/providers/rawbytes"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// SchemaVersion is the version of the configuration API. It is incremented
+// when configuration fields are removed or change meaning, so that clients
+// can detect an incompatible server.
+const SchemaVersion = 1
+
+// reloadDelay is how long to wait after the last change to the config file
+// before reloading it. Editors often write a file in several steps.
+const reloadDelay = 500 * time.Millisecond
+
+// restartRequiredKeys are the settings that are only read at startup.
+// Changes to them are loaded, but do not take effect until stash is
+// restarted.
+var restartRequiredKeys = []string{
+	Database,
+	DatabaseSynchronous,
+	DatabaseWALAutoCheckpoint,
+	Generated,
+	Metadata,
+	Cache,
+	BlobsPath,
+	BlobsStorage,
+	Host,
+	Port,
+	sslCertPath,
+	sslKeyPath,
+	LogFile,
+	LogOut,
+}
+
+// KeyChanged returns true if the setting key, or any setting nested under
+// it, is in changed.
+func KeyChanged(changed []string, key string) bool {
+	for _, c := range changed {
+		if c == key || strings.HasPrefix(c, key+".") || strings.HasPrefix(key, c+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRevision returns the number of times the configuration has changed
+// since startup, either through the API or by reloading the config file.
+func (i *Config) GetRevision() int {
+	i.RLock()
+	defer i.RUnlock()
+	return i.revision
+}
+
+// GetRestartRequired returns the settings that have been changed in the
+// config file since startup and require a restart to take effect.
+func (i *Config) GetRestartRequired() []string {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := make([]string, len(i.restartRequired))
+	copy(ret, i.restartRequired)
+	return ret
+}
+
+// normalised returns a copy of k as it would be read from the config file.
+// Values set through the API may have different types to those parsed from
+// yaml, so they cannot be compared directly.
+func normalised(k *koanf.Koanf) (*koanf.Koanf, error) {
+	data, err := k.Marshal(yaml.Parser())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := koanf.New(".")
+	if err := ret.Load(rawbytes.Provider(data), yaml.Parser()); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// changedKeys returns the sorted flattened keys with different values in
+// a and b.
+func changedKeys(a, b *koanf.Koanf) []string {
+	aa := a.All()
+	bb := b.All()
+
+	var ret []string
+	for k, v := range aa {
+		if bv, found := bb[k]; !found || !reflect.DeepEqual(v, bv) {
+			ret = append(ret, k)
+		}
+	}
+	for k := range bb {
+		if _, found := aa[k]; !found {
+			ret = append(ret, k)
+		}
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+// Reload reads the config file and replaces the current configuration with
+// it. Returns the flattened keys of the settings that changed. The current
+// configuration is kept if the file is invalid.
+func (i *Config) Reload() ([]string, error) {
+	fn := i.GetConfigFile()
+
+	loaded := koanf.New(".")
+	if err := loaded.Load(file.Provider(fn), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", fn, err)
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	current, err := normalised(i.main)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := changedKeys(current, loaded)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	previous := i.main
+	i.main = loaded
+	if err := i.validate(); err != nil {
+		i.main = previous
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	i.revision++
+
+	for _, key := range restartRequiredKeys {
+		if KeyChanged(changed, key) {
+			i.restartRequired = sliceutil.AppendUnique(i.restartRequired, key)
+		}
+	}
+
+	return changed, nil
+}
+
+// Watch reloads the config file when it changes on disk, until ctx is
+// cancelled. onChange is called with the changed keys after each reload
+// that changes the configuration. Writes made through Write do not change
+// the configuration, so do not call onChange.
+func (i *Config) Watch(ctx context.Context, onChange func(changed []string)) error {
+	fn := i.GetConfigFile()
+	if fn == "" {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating config watcher: %w", err)
+	}
+
+	// watch the directory, since editors often replace the file rather than
+	// writing to it
+	if err := watcher.Add(filepath.Dir(fn)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watching %s: %w", fn, err)
+	}
+
+	reload := func() {
+		changed, err := i.Reload()
+		if err != nil {
+			logger.Errorf("Error reloading config file: %v", err)
+			return
+		}
+
+		if len(changed) == 0 {
+			return
+		}
+
+		logger.Infof("Config file changed, reloaded settings: %s", strings.Join(changed, ", "))
+		for _, key := range restartRequiredKeys {
+			if KeyChanged(changed, key) {
+				logger.Warnf("Setting %s was changed and requires a restart to take effect", key)
+			}
+		}
+
+		onChange(changed)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var timer *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != filepath.Clean(fn) {
+					continue
+				}
+
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(reloadDelay, reload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warnf("Error watching config file: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
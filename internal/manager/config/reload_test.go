@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyChanged(t *testing.T) {
+	changed := []string{"loglevel", "ffmpeg.transcode.input_args", "ui.theme"}
+
+	assert.True(t, KeyChanged(changed, LogLevel))
+	assert.True(t, KeyChanged(changed, TranscodeInputArgs))
+	assert.True(t, KeyChanged(changed, "ui"))
+	assert.False(t, KeyChanged(changed, LogFile))
+	assert.False(t, KeyChanged(changed, TranscodeOutputArgs))
+}
+
+func TestConfig_Reload(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "config.yml")
+
+	i := InitializeEmpty()
+	i.SetConfigFile(fn)
+	i.SetString(Database, "stash.sqlite")
+	i.SetString(Generated, "generated")
+	i.SetInterface(Exclude, []string{"excluded"})
+	i.SetString(LogLevel, "Info")
+
+	if err := i.Write(); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	// the written file does not change anything
+	revision := i.GetRevision()
+	changed, err := i.Reload()
+	assert.Nil(t, err)
+	assert.Empty(t, changed)
+	assert.Equal(t, revision, i.GetRevision())
+
+	data := "database: stash.sqlite\ngenerated: other\nexclude:\n- excluded\nloglevel: Debug\n"
+	if err := os.WriteFile(fn, []byte(data), 0640); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	changed, err = i.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{Generated, LogLevel}, changed)
+	assert.Equal(t, "Debug", i.GetLogLevel())
+	assert.Equal(t, revision+1, i.GetRevision())
+	assert.Equal(t, []string{Generated}, i.GetRestartRequired())
+
+	// invalid configuration is not loaded
+	if err := os.WriteFile(fn, []byte("loglevel: Trace\n"), 0640); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	_, err = i.Reload()
+	assert.NotNil(t, err)
+	assert.Equal(t, "Debug", i.GetLogLevel())
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 // Stash configuration details
@@ -11,12 +14,39 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Maximum size of the files in the path, in bytes. 0 for no quota.
+	Quota int64 `json:"quota"`
+	// Fingerprint types to calculate when scanning files in the path.
+	// Empty to use the global settings.
+	Fingerprints []string `json:"fingerprints"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Maximum size of the files in the path, in bytes. 0 for no quota.
+	Quota int64 `json:"quota"`
+	// Fingerprints are the fingerprint types to calculate when scanning files
+	// in the path, overriding the global settings. Empty to use the global
+	// settings. The fingerprints required to identify files are always
+	// calculated.
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// ValidateStashFingerprints returns an error if any of the fingerprint types
+// cannot be calculated during a scan.
+func ValidateStashFingerprints(types []string) error {
+	for _, t := range types {
+		switch {
+		case t == models.FingerprintTypeOshash, t == models.FingerprintTypePhash:
+		case hash.IsChecksumAlgorithm(t):
+		default:
+			return fmt.Errorf("unsupported fingerprint type %q", t)
+		}
+	}
+
+	return nil
 }
 
 type StashConfigs []*StashConfig
@@ -0,0 +1,56 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+// configChanged applies the settings changed by reloading the config file.
+// Settings that are only read when needed take effect without any action.
+// Settings that require a restart are left alone.
+func (s *Manager) configChanged(changed []string) {
+	ctx := context.Background()
+	cfg := s.Config
+
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if config.KeyChanged(changed, key) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if has(config.LogLevel) {
+		s.Logger.SetLogLevel(cfg.GetLogLevel())
+	}
+
+	if has(config.ParallelTasks) {
+		s.ImageThumbnailGenerateWaitGroup.Size = cfg.GetParallelTasksWithAutoDetection()
+	}
+
+	if has(config.ScrapersPath, config.ScraperProxies, config.ScraperProxyHealthCheckURL) {
+		s.RefreshScraperCache()
+	}
+	if has(config.ScrapersPath, config.ScraperPackageSources) {
+		s.RefreshScraperSourceManager()
+	}
+
+	if has(config.PluginsPath) {
+		s.RefreshPluginCache()
+	}
+	if has(config.PluginsPath, config.PluginPackageSources) {
+		s.RefreshPluginSourceManager()
+	}
+
+	if has(config.FFMpegPath, config.FFProbePath) {
+		s.RefreshFFMpeg(ctx)
+		// stream manager holds the ffmpeg instance
+		s.RefreshStreamManager()
+	}
+
+	if has(config.DLNADefaultEnabled) {
+		s.RefreshDLNA()
+	}
+}
@@ -0,0 +1,232 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/desktop"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// diskSpaceCheckInterval is the interval at which disk usage is checked
+	// for warnings.
+	diskSpaceCheckInterval = 10 * time.Minute
+
+	diskSpaceWebhookTimeout = 10 * time.Second
+)
+
+// StashPathDiskUsage is the disk usage of a library path.
+type StashPathDiskUsage struct {
+	Path string `json:"path"`
+	// Total and Free are the size and available space of the filesystem
+	// containing the path, in bytes.
+	Total int64 `json:"total"`
+	Free  int64 `json:"free"`
+	// LibrarySize is the total size of the files in the path, in bytes.
+	LibrarySize int64 `json:"library_size"`
+	// Quota is the configured maximum size of the files in the path.
+	// 0 if not set.
+	Quota    int64    `json:"quota"`
+	Warnings []string `json:"warnings"`
+}
+
+// diskUsageWarnings returns the warnings for the usage of a library path.
+func diskUsageWarnings(u StashPathDiskUsage, warningPercent int) []string {
+	var ret []string
+
+	ds := fsutil.DiskSpace{Total: u.Total, Free: u.Free}
+	if used := ds.UsedPercent(); used >= float64(warningPercent) {
+		ret = append(ret, fmt.Sprintf("disk is %.0f%% full", used))
+	}
+
+	if u.Quota > 0 {
+		quotaPercent := float64(u.LibrarySize) / float64(u.Quota) * 100
+		switch {
+		case u.LibrarySize > u.Quota:
+			ret = append(ret, fmt.Sprintf("library is over quota (%.0f%%)", quotaPercent))
+		case quotaPercent >= float64(warningPercent):
+			ret = append(ret, fmt.Sprintf("library has used %.0f%% of quota", quotaPercent))
+		}
+	}
+
+	return ret
+}
+
+// GetStashPathDiskUsage returns the disk usage of each library path.
+// Must be called within a read transaction.
+func GetStashPathDiskUsage(ctx context.Context, r models.Repository) ([]*StashPathDiskUsage, error) {
+	cfg := config.GetInstance()
+	warningPercent := cfg.GetDiskSpaceWarningPercent()
+
+	var ret []*StashPathDiskUsage
+	for _, s := range cfg.GetStashPaths() {
+		u := &StashPathDiskUsage{
+			Path:  s.Path,
+			Quota: s.Quota,
+		}
+
+		ds, err := fsutil.GetDiskSpace(s.Path)
+		if err != nil {
+			logger.Warnf("Error getting disk space: %v", err)
+		} else {
+			u.Total = ds.Total
+			u.Free = ds.Free
+		}
+
+		u.LibrarySize, err = r.File.SizeAllInPaths(ctx, []string{s.Path})
+		if err != nil {
+			return nil, err
+		}
+
+		u.Warnings = diskUsageWarnings(*u, warningPercent)
+		ret = append(ret, u)
+	}
+
+	return ret, nil
+}
+
+// checkReservedDiskSpace returns an error if the filesystem containing path
+// has less than the reserved disk space available.
+func checkReservedDiskSpace(path string) error {
+	return fsutil.CheckDiskSpace(path, config.GetInstance().GetDiskSpaceReservedBytes())
+}
+
+type diskSpaceWarningEvent struct {
+	Event string `json:"event"`
+	StashPathDiskUsage
+}
+
+// diskSpaceMonitor periodically checks the disk usage of the library paths
+// and raises warnings when a path starts exceeding its thresholds.
+type diskSpaceMonitor struct {
+	manager *Manager
+
+	mutex sync.Mutex
+	// warnings raised in the last check, keyed by path
+	warned map[string]string
+}
+
+func (m *diskSpaceMonitor) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(diskSpaceCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+}
+
+func (m *diskSpaceMonitor) check(ctx context.Context) {
+	mgr := m.manager
+	if mgr.Config.IsNewSystem() || mgr.Database.Ready() != nil {
+		return
+	}
+
+	var usage []*StashPathDiskUsage
+	r := mgr.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		usage, err = GetStashPathDiskUsage(ctx, r)
+		return err
+	}); err != nil {
+		logger.Errorf("Error checking disk usage: %v", err)
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.warned == nil {
+		m.warned = make(map[string]string)
+	}
+
+	for _, u := range usage {
+		if len(u.Warnings) == 0 {
+			delete(m.warned, u.Path)
+			continue
+		}
+
+		// only raise a warning when it changes
+		msg := fmt.Sprintf("%v", u.Warnings)
+		if m.warned[u.Path] == msg {
+			continue
+		}
+		m.warned[u.Path] = msg
+
+		m.warn(ctx, *u)
+	}
+}
+
+func (m *diskSpaceMonitor) warn(ctx context.Context, u StashPathDiskUsage) {
+	cfg := m.manager.Config
+
+	for _, w := range u.Warnings {
+		logger.Warnf("Library path %s: %s", u.Path, w)
+	}
+
+	if cfg.GetNotificationsEnabled() {
+		desktop.SendNotification("Disk space", fmt.Sprintf("Library path %s: %v", u.Path, u.Warnings))
+	}
+
+	urls := cfg.GetDiskSpaceWebhookURLs()
+	if len(urls) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(diskSpaceWarningEvent{
+		Event:              "disk_space_warning",
+		StashPathDiskUsage: u,
+	})
+	if err != nil {
+		logger.Errorf("Error encoding disk space webhook: %v", err)
+		return
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+		Timeout: diskSpaceWebhookTimeout,
+	}
+
+	for _, url := range urls {
+		if err := postWebhook(ctx, client, url, body); err != nil {
+			logger.Errorf("Error posting disk space webhook to %s: %v", url, err)
+		}
+	}
+}
+
+func postWebhook(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http error %d", resp.StatusCode)
+	}
+
+	return nil
+}
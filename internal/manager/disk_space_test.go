@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsageWarnings(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+
+	tests := []struct {
+		name  string
+		usage StashPathDiskUsage
+		want  []string
+	}{
+		{
+			"no warnings",
+			StashPathDiskUsage{Total: 100 * gb, Free: 50 * gb, LibrarySize: 10 * gb, Quota: 20 * gb},
+			nil,
+		},
+		{
+			"disk full",
+			StashPathDiskUsage{Total: 100 * gb, Free: 5 * gb},
+			[]string{"disk is 95% full"},
+		},
+		{
+			"near quota",
+			StashPathDiskUsage{Total: 100 * gb, Free: 50 * gb, LibrarySize: 19 * gb, Quota: 20 * gb},
+			[]string{"library has used 95% of quota"},
+		},
+		{
+			"over quota",
+			StashPathDiskUsage{Total: 100 * gb, Free: 50 * gb, LibrarySize: 25 * gb, Quota: 20 * gb},
+			[]string{"library is over quota (125%)"},
+		},
+		{
+			"unknown disk size",
+			StashPathDiskUsage{LibrarySize: 25 * gb},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diskUsageWarnings(tt.usage, 90))
+		})
+	}
+}
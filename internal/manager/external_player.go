@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+var ErrInvalidPlayerToken = errors.New("invalid external player token")
+
+const ExternalPlayerSubject = "ExternalPlayer"
+
+const (
+	// DefaultExternalPlayerTokenDuration is the default lifetime of an
+	// external player token. It is long enough to cover pausing and resuming
+	// playback of long scenes.
+	DefaultExternalPlayerTokenDuration = 12 * time.Hour
+	MaxExternalPlayerTokenDuration     = 7 * 24 * time.Hour
+)
+
+type ExternalPlayerClaims struct {
+	SceneID int `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// GenerateExternalPlayerToken returns a token which grants access to the
+// primary file of a scene, and to report playback progress of the scene,
+// until the expiry time.
+func GenerateExternalPlayerToken(sceneID int, expiresAt time.Time) (string, error) {
+	claims := &ExternalPlayerClaims{
+		SceneID: sceneID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   ExternalPlayerSubject,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(config.GetInstance().GetJWTSignKey())
+}
+
+// GetExternalPlayerClaims validates the provided external player token and
+// returns its claims. Expired tokens are rejected.
+func GetExternalPlayerClaims(playerToken string) (*ExternalPlayerClaims, error) {
+	claims := &ExternalPlayerClaims{}
+	token, err := jwt.ParseWithClaims(playerToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidPlayerToken
+		}
+		return config.GetInstance().GetJWTSignKey(), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// tokens without an expiry are valid indefinitely, so they must not be
+	// accepted
+	if !token.Valid || claims.Subject != ExternalPlayerSubject || claims.ExpiresAt == nil {
+		return nil, ErrInvalidPlayerToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,52 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalPlayerToken(t *testing.T) {
+	c := config.InitializeEmpty()
+	c.SetString(config.JWTSignKey, "test-sign-key")
+
+	const sceneID = 12
+
+	token, err := GenerateExternalPlayerToken(sceneID, time.Now().Add(time.Hour))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	claims, err := GetExternalPlayerClaims(token)
+	if assert.NoError(t, err) {
+		assert.Equal(t, sceneID, claims.SceneID)
+	}
+
+	// expired
+	token, err = GenerateExternalPlayerToken(sceneID, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	_, err = GetExternalPlayerClaims(token)
+	assert.Error(t, err)
+
+	// api keys are signed with the same key, but must not be accepted
+	apiKey, err := GenerateAPIKey("user")
+	assert.NoError(t, err)
+	_, err = GetExternalPlayerClaims(apiKey)
+	assert.Error(t, err)
+
+	// signed with a different key
+	other := jwt.NewWithClaims(jwt.SigningMethodHS256, &ExternalPlayerClaims{
+		SceneID: sceneID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   ExternalPlayerSubject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	otherToken, err := other.SignedString([]byte("other-key"))
+	assert.NoError(t, err)
+	_, err = GetExternalPlayerClaims(otherToken)
+	assert.Error(t, err)
+}
@@ -4,10 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
-	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/hash/oshash"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -41,7 +42,9 @@ func (c *fingerprintCalculator) calculateOshash(f *models.BaseFile, o file.Opene
 	}, nil
 }
 
-func (c *fingerprintCalculator) calculateMD5(o file.Opener) (*models.Fingerprint, error) {
+// calculateChecksums calculates the checksums of the file for each of the
+// algorithms in a single pass, returning fingerprints in the same order.
+func (c *fingerprintCalculator) calculateChecksums(o file.Opener, algorithms []string) ([]models.Fingerprint, error) {
 	r, err := o.Open()
 	if err != nil {
 		return nil, fmt.Errorf("opening file: %w", err)
@@ -49,20 +52,69 @@ func (c *fingerprintCalculator) calculateMD5(o file.Opener) (*models.Fingerprint
 
 	defer r.Close()
 
-	hash, err := md5.FromReader(r)
+	checksums, err := hash.ChecksumsFromReader(r, algorithms)
 	if err != nil {
-		return nil, fmt.Errorf("calculating md5: %w", err)
+		return nil, fmt.Errorf("calculating checksums: %w", err)
 	}
 
-	return &models.Fingerprint{
-		Type:        models.FingerprintTypeMD5,
-		Fingerprint: hash,
-	}, nil
+	ret := make([]models.Fingerprint, len(algorithms))
+	for i, a := range algorithms {
+		ret[i] = models.Fingerprint{
+			Type:        a,
+			Fingerprint: checksums[a],
+		}
+	}
+
+	return ret, nil
+}
+
+// checksumAlgorithms returns the checksum algorithms to calculate for the
+// file, in order and without duplicates. The fingerprints of the stash path
+// containing the file override the global settings.
+func (c *fingerprintCalculator) checksumAlgorithms(f *models.BaseFile) []string {
+	var ret []string
+
+	isVideo := useAsVideo(f.Path)
+	algorithms := c.Config.GetFingerprintAlgorithms()
+	calculateMD5 := c.Config.IsCalculateMD5()
+
+	if override := pathFingerprints(c.Config.GetStashPaths(), f.Path); len(override) > 0 {
+		algorithms = override
+		// MD5 is still required for videos if used to name generated files
+		calculateMD5 = slices.Contains(override, models.FingerprintTypeMD5) ||
+			c.Config.GetVideoFileNamingAlgorithm() == models.HashAlgorithmMd5
+	}
+
+	// only calculate MD5 for videos if enabled in config
+	if !isVideo || calculateMD5 {
+		ret = append(ret, models.FingerprintTypeMD5)
+	}
+
+	for _, a := range algorithms {
+		if !hash.IsChecksumAlgorithm(a) {
+			continue
+		}
+		if !slices.Contains(ret, a) {
+			ret = append(ret, a)
+		}
+	}
+
+	return ret
+}
+
+// pathFingerprints returns the fingerprint types configured for the stash
+// path containing path, or nil if the global settings apply.
+func pathFingerprints(stashPaths config.StashConfigs, path string) []string {
+	s := stashPaths.GetStashFromPath(path)
+	if s == nil {
+		return nil
+	}
+
+	return s.Fingerprints
 }
 
 func (c *fingerprintCalculator) CalculateFingerprints(f *models.BaseFile, o file.Opener, useExisting bool) ([]models.Fingerprint, error) {
 	var ret []models.Fingerprint
-	calculateMD5 := true
 
 	if useAsVideo(f.Path) {
 		var (
@@ -83,34 +135,32 @@ func (c *fingerprintCalculator) CalculateFingerprints(f *models.BaseFile, o file
 		}
 
 		ret = append(ret, *fp)
-
-		// only calculate MD5 if enabled in config
-		calculateMD5 = c.Config.IsCalculateMD5()
 	}
 
-	if calculateMD5 {
-		var (
-			fp  *models.Fingerprint
-			err error
-		)
-
+	var missing []string
+	for _, a := range c.checksumAlgorithms(f) {
 		if useExisting {
-			fp = f.Fingerprints.For(models.FingerprintTypeMD5)
+			if fp := f.Fingerprints.For(a); fp != nil {
+				ret = append(ret, *fp)
+				continue
+			}
 		}
 
-		if fp == nil {
-			if useExisting {
-				// log to indicate missing fingerprint is being calculated
-				logger.Infof("Calculating checksum for %s ...", f.Path)
-			}
+		missing = append(missing, a)
+	}
 
-			fp, err = c.calculateMD5(o)
-			if err != nil {
-				return nil, err
-			}
+	if len(missing) > 0 {
+		if useExisting {
+			// log to indicate missing fingerprint is being calculated
+			logger.Infof("Calculating checksum for %s ...", f.Path)
 		}
 
-		ret = append(ret, *fp)
+		fps, err := c.calculateChecksums(o, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, fps...)
 	}
 
 	return ret, nil
@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathFingerprints(t *testing.T) {
+	local := filepath.Join("stash", "local")
+	cloud := filepath.Join("stash", "cloud")
+	stashPaths := config.StashConfigs{
+		{Path: local},
+		{Path: cloud, Fingerprints: []string{"oshash", "blake3"}},
+	}
+
+	assert.Nil(t, pathFingerprints(stashPaths, filepath.Join(local, "a.mp4")))
+	assert.Equal(t, []string{"oshash", "blake3"}, pathFingerprints(stashPaths, filepath.Join(cloud, "a.mp4")))
+	assert.Nil(t, pathFingerprints(stashPaths, filepath.Join("other", "a.mp4")))
+}
+
+func TestSceneGeneratorsGeneratePhash(t *testing.T) {
+	local := filepath.Join("stash", "local")
+	cloud := filepath.Join("stash", "cloud")
+	g := &sceneGenerators{
+		input: ScanMetadataInput{
+			ScanMetadataOptions: config.ScanMetadataOptions{ScanGeneratePhashes: true},
+		},
+		stashPaths: config.StashConfigs{
+			{Path: local, Fingerprints: []string{"phash"}},
+			{Path: cloud, Fingerprints: []string{"oshash"}},
+		},
+	}
+
+	assert.True(t, g.generatePhash(filepath.Join(local, "a.mp4")))
+	assert.False(t, g.generatePhash(filepath.Join(cloud, "a.mp4")))
+	assert.True(t, g.generatePhash(filepath.Join("other", "a.mp4")))
+
+	g.input.ScanGeneratePhashes = false
+	assert.True(t, g.generatePhash(filepath.Join(local, "a.mp4")))
+	assert.False(t, g.generatePhash(filepath.Join("other", "a.mp4")))
+}
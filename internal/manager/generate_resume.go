@@ -0,0 +1,238 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+const (
+	generateResumeFile = "generate_resume.json"
+
+	// generateResumeSaveInterval is the minimum interval between writes of
+	// the generate progress to disk.
+	generateResumeSaveInterval = 5 * time.Second
+)
+
+// generateResumeState is the persisted progress of a running generate job.
+// It is removed when the job completes or is cancelled, so an existing state
+// indicates that the job was interrupted by a shutdown or crash.
+type generateResumeState struct {
+	Input GenerateMetadataInput `json:"input"`
+	// CompletedSceneIDs are the scenes for which all tasks have completed.
+	CompletedSceneIDs []int `json:"completed_scene_ids"`
+}
+
+func generateResumePath() string {
+	return filepath.Join(config.GetInstance().GetConfigPath(), generateResumeFile)
+}
+
+// loadGenerateResumeState returns the progress of an interrupted generate
+// job. Returns nil, nil if there is no interrupted job.
+func loadGenerateResumeState() (*generateResumeState, error) {
+	data, err := os.ReadFile(generateResumePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret generateResumeState
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func removeGenerateResumeState() {
+	if err := os.Remove(generateResumePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("error removing generate progress: %v", err)
+	}
+}
+
+// matches returns true if the state was saved by a generate job with the
+// same input.
+func (s *generateResumeState) matches(input GenerateMetadataInput) bool {
+	a, err := json.Marshal(s.Input)
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(input)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(a, b)
+}
+
+// generateResumer records the scenes completed by a generate job,
+// periodically writing them to disk.
+type generateResumer struct {
+	mutex     sync.Mutex
+	input     GenerateMetadataInput
+	completed map[int]struct{}
+	lastSaved time.Time
+}
+
+// newGenerateResumer returns a generateResumer for a generate job with the
+// given input. If an interrupted job with the same input exists, its
+// progress is resumed.
+func newGenerateResumer(input GenerateMetadataInput) *generateResumer {
+	ret := &generateResumer{
+		input:     input,
+		completed: make(map[int]struct{}),
+	}
+
+	existing, err := loadGenerateResumeState()
+	if err != nil {
+		logger.Warnf("error loading progress of interrupted generate: %v", err)
+	}
+
+	if existing != nil && existing.matches(input) && len(existing.CompletedSceneIDs) > 0 {
+		logger.Infof("Resuming interrupted generate, skipping %d completed scenes", len(existing.CompletedSceneIDs))
+		for _, id := range existing.CompletedSceneIDs {
+			ret.completed[id] = struct{}{}
+		}
+	}
+
+	ret.save()
+
+	return ret
+}
+
+func (r *generateResumer) isCompleted(sceneID int) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, found := r.completed[sceneID]
+	return found
+}
+
+func (r *generateResumer) complete(sceneID int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.completed[sceneID] = struct{}{}
+
+	if time.Since(r.lastSaved) >= generateResumeSaveInterval {
+		r.saveLocked()
+	}
+}
+
+func (r *generateResumer) save() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.saveLocked()
+}
+
+func (r *generateResumer) saveLocked() {
+	r.lastSaved = time.Now()
+
+	state := generateResumeState{
+		Input:             r.input,
+		CompletedSceneIDs: make([]int, 0, len(r.completed)),
+	}
+	for id := range r.completed {
+		state.CompletedSceneIDs = append(state.CompletedSceneIDs, id)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err == nil {
+		err = os.WriteFile(generateResumePath(), data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error saving generate progress: %v", err)
+	}
+}
+
+// sceneTaskCounter counts the remaining tasks of a scene, marking the scene
+// as completed when all of its tasks have completed.
+type sceneTaskCounter struct {
+	mutex     sync.Mutex
+	sceneID   int
+	remaining int
+	resumer   *generateResumer
+}
+
+func (c *sceneTaskCounter) done() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remaining--
+	if c.remaining == 0 {
+		c.resumer.complete(c.sceneID)
+	}
+}
+
+// resumableSceneTask is a scene task that reports its completion to a
+// sceneTaskCounter. Tasks that end while the job is cancelled or shutting
+// down are not counted, since they may have been stopped early, so that they
+// are run again when the job is resumed.
+type resumableSceneTask struct {
+	Task
+	counter *sceneTaskCounter
+}
+
+func (t *resumableSceneTask) Start(ctx context.Context) {
+	t.Task.Start(ctx)
+
+	if ctx.Err() == nil && !job.IsShuttingDown(ctx) {
+		t.counter.done()
+	}
+}
+
+// queueResumableSceneJobs queues the tasks of a scene, skipping scenes
+// completed by an interrupted run of the job.
+func (j *GenerateJob) queueResumableSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task) {
+	if j.resumer.isCompleted(scene.ID) {
+		return
+	}
+
+	// collect the tasks of the scene so that they can be counted
+	sceneQueue := make(chan Task)
+	var tasks []Task
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for t := range sceneQueue {
+			tasks = append(tasks, t)
+		}
+	}()
+
+	j.queueSceneJobs(ctx, g, scene, sceneQueue)
+	close(sceneQueue)
+	<-collected
+
+	if len(tasks) == 0 {
+		j.resumer.complete(scene.ID)
+		return
+	}
+
+	counter := &sceneTaskCounter{
+		sceneID:   scene.ID,
+		remaining: len(tasks),
+		resumer:   j.resumer,
+	}
+
+	for _, t := range tasks {
+		queue <- &resumableSceneTask{
+			Task:    t,
+			counter: counter,
+		}
+	}
+}
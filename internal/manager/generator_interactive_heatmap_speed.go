@@ -9,6 +9,7 @@ import (
 	"image/png"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/lucasb-eyer/go-colorful"
@@ -77,6 +78,10 @@ func (g *InteractiveHeatmapSpeedGenerator) Generate(funscriptPath string, heatma
 	g.Funscript = funscript
 	g.Funscript.UpdateIntensityAndSpeed()
 
+	if err := fsutil.EnsureDirAll(filepath.Dir(heatmapPath)); err != nil {
+		return err
+	}
+
 	err = g.RenderHeatmap(heatmapPath, sceneDurationMilli)
 
 	if err != nil {
@@ -0,0 +1,225 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// sceneHeatmapIntensityScale scales heat values in the range 0-1 to the
+// intensity range used by getSegmentColor.
+const sceneHeatmapIntensityScale = 500
+
+// SceneHeatmap is a per-segment heatmap of a scene, combining interactive
+// intensity, marker density and watch data. Channel values are normalised to
+// the range 0-1.
+type SceneHeatmap struct {
+	Duration       float64               `json:"duration"`
+	SegmentSeconds float64               `json:"segment_seconds"`
+	Segments       []SceneHeatmapSegment `json:"segments"`
+}
+
+type SceneHeatmapSegment struct {
+	Start       float64 `json:"start"`
+	Interactive float64 `json:"interactive"`
+	Markers     float64 `json:"markers"`
+	Watch       float64 `json:"watch"`
+	// Heat is the mean of the channels that have data for the scene.
+	Heat float64 `json:"heat"`
+}
+
+// SceneHeatmapInput is the data used to generate a scene heatmap.
+type SceneHeatmapInput struct {
+	Duration float64
+	// Funscript must have intensity updated. Nil if the scene is not interactive.
+	Funscript *Script
+	Markers   []*models.SceneMarker
+	WatchHeat models.WatchHeat
+}
+
+type SceneHeatmapGenerator struct {
+	Width       int
+	Height      int
+	NumSegments int
+}
+
+func NewSceneHeatmapGenerator() *SceneHeatmapGenerator {
+	return &SceneHeatmapGenerator{
+		Width:       1280,
+		Height:      60,
+		NumSegments: 200,
+	}
+}
+
+// Generate writes the heatmap data to dataPath and the rendered heatmap to
+// imagePath. Returns an error if there is no data for the scene.
+func (g *SceneHeatmapGenerator) Generate(input SceneHeatmapInput, imagePath string, dataPath string) error {
+	if input.Duration <= 0 {
+		return fmt.Errorf("invalid scene duration %f", input.Duration)
+	}
+
+	heatmap := g.Compose(input)
+	if !heatmap.hasData() {
+		return fmt.Errorf("no interactive, marker or watch data")
+	}
+
+	data, err := json.Marshal(heatmap)
+	if err != nil {
+		return err
+	}
+
+	if err := fsutil.EnsureDirAll(filepath.Dir(dataPath)); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(dataPath, data, 0644); err != nil {
+		return err
+	}
+
+	return g.Render(heatmap, imagePath)
+}
+
+// Compose combines the input data into a heatmap.
+func (g *SceneHeatmapGenerator) Compose(input SceneHeatmapInput) *SceneHeatmap {
+	n := g.NumSegments
+	segmentSeconds := input.Duration / float64(n)
+
+	interactive := make([]float64, n)
+	markers := make([]float64, n)
+	watch := make([]float64, n)
+
+	segmentAt := func(seconds float64) int {
+		i := int(seconds / segmentSeconds)
+		return max(0, min(i, n-1))
+	}
+
+	// add value to each segment in proportion to its overlap with the range
+	addRange := func(values []float64, start float64, end float64, value float64) {
+		start = math.Max(start, 0)
+		end = math.Min(end, input.Duration)
+		for i := segmentAt(start); i < n && end > start; i++ {
+			segmentEnd := float64(i+1) * segmentSeconds
+			overlap := math.Min(segmentEnd, end) - start
+			values[i] += value * overlap
+			start = segmentEnd
+		}
+	}
+
+	if input.Funscript != nil {
+		counts := make([]int, n)
+		for _, a := range input.Funscript.Actions {
+			i := segmentAt(float64(a.At) / 1000)
+			interactive[i] += a.Speed
+			counts[i]++
+		}
+		for i := range interactive {
+			if counts[i] > 0 {
+				interactive[i] /= float64(counts[i])
+			}
+		}
+	}
+
+	for _, m := range input.Markers {
+		if m.EndSeconds != nil && *m.EndSeconds > m.Seconds {
+			addRange(markers, m.Seconds, *m.EndSeconds, 1/segmentSeconds)
+		} else if m.Seconds >= 0 && m.Seconds < input.Duration {
+			markers[segmentAt(m.Seconds)]++
+		}
+	}
+
+	for bucket, seconds := range input.WatchHeat {
+		start := float64(bucket * models.WatchHeatBucketSeconds)
+		addRange(watch, start, start+models.WatchHeatBucketSeconds, seconds/models.WatchHeatBucketSeconds)
+	}
+
+	channels := [][]float64{interactive, markers, watch}
+	numChannels := 0
+	for _, c := range channels {
+		if normalise(c) {
+			numChannels++
+		}
+	}
+
+	ret := &SceneHeatmap{
+		Duration:       input.Duration,
+		SegmentSeconds: segmentSeconds,
+		Segments:       make([]SceneHeatmapSegment, n),
+	}
+
+	for i := range ret.Segments {
+		s := &ret.Segments[i]
+		s.Start = float64(i) * segmentSeconds
+		s.Interactive = interactive[i]
+		s.Markers = markers[i]
+		s.Watch = watch[i]
+
+		if numChannels > 0 {
+			s.Heat = (s.Interactive + s.Markers + s.Watch) / float64(numChannels)
+		}
+	}
+
+	return ret
+}
+
+// normalise scales values to the range 0-1. Returns false if all values are zero.
+func normalise(values []float64) bool {
+	var maxValue float64
+	for _, v := range values {
+		maxValue = math.Max(maxValue, v)
+	}
+
+	if maxValue <= 0 {
+		return false
+	}
+
+	for i := range values {
+		values[i] /= maxValue
+	}
+
+	return true
+}
+
+func (h *SceneHeatmap) hasData() bool {
+	for _, s := range h.Segments {
+		if s.Heat > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Render draws the heatmap to a png image at path.
+func (g *SceneHeatmapGenerator) Render(heatmap *SceneHeatmap, path string) error {
+	img := image.NewRGBA(image.Rect(0, 0, g.Width, g.Height))
+	for x := 0; x < g.Width; x++ {
+		i := x * len(heatmap.Segments) / g.Width
+		c := getSegmentColor(heatmap.Segments[i].Heat * sceneHeatmapIntensityScale)
+		draw.Draw(img, image.Rect(x, 0, x+1, g.Height), &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+
+	// add 10 minute marks
+	const tick = 600
+	c, _ := colorful.Hex("#000000")
+	for ts := float64(tick); ts < heatmap.Duration; ts += tick {
+		x := int(ts / heatmap.Duration * float64(g.Width))
+		draw.Draw(img, image.Rect(x-1, g.Height/2, x+1, g.Height), &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+
+	outpng, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer outpng.Close()
+
+	return png.Encode(outpng, img)
+}
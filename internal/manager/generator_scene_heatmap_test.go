@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneHeatmapGenerator_Compose(t *testing.T) {
+	g := &SceneHeatmapGenerator{NumSegments: 4}
+
+	endSeconds := 30.0
+	input := SceneHeatmapInput{
+		Duration: 40,
+		Markers: []*models.SceneMarker{
+			{Seconds: 5},
+			{Seconds: 20, EndSeconds: &endSeconds},
+		},
+		// segments 0-1 watched once, segment 2 watched twice
+		WatchHeat: models.WatchHeat{5, 5, 5, 5, 10, 10},
+	}
+
+	got := g.Compose(input)
+
+	assert.Equal(t, 10.0, got.SegmentSeconds)
+	if !assert.Len(t, got.Segments, 4) {
+		return
+	}
+
+	wantMarkers := []float64{1, 0, 1, 0}
+	wantWatch := []float64{0.5, 0.5, 1, 0}
+	for i, s := range got.Segments {
+		assert.Equal(t, float64(i*10), s.Start)
+		assert.Zero(t, s.Interactive)
+		assert.Equal(t, wantMarkers[i], s.Markers, "markers[%d]", i)
+		assert.Equal(t, wantWatch[i], s.Watch, "watch[%d]", i)
+		// interactive channel has no data so is not included
+		assert.Equal(t, (wantMarkers[i]+wantWatch[i])/2, s.Heat, "heat[%d]", i)
+	}
+}
+
+func TestSceneHeatmapGenerator_ComposeInteractive(t *testing.T) {
+	g := &SceneHeatmapGenerator{NumSegments: 2}
+
+	input := SceneHeatmapInput{
+		Duration: 10,
+		Funscript: &Script{
+			Actions: []Action{
+				{At: 1000, Speed: 100},
+				{At: 2000, Speed: 300},
+				{At: 6000, Speed: 100},
+			},
+		},
+	}
+
+	got := g.Compose(input)
+	if !got.hasData() {
+		t.Fatal("hasData() = false, want true")
+	}
+
+	assert.Equal(t, 1.0, got.Segments[0].Interactive)
+	assert.Equal(t, 0.5, got.Segments[1].Interactive)
+	assert.Equal(t, got.Segments[1].Interactive, got.Segments[1].Heat)
+
+	empty := g.Compose(SceneHeatmapInput{Duration: 10})
+	assert.False(t, empty.hasData())
+}
@@ -39,7 +39,7 @@ func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageO
 	chunkCount := rows * cols
 
 	// For files with small duration / low frame count  try to seek using frame number intead of seconds
-	if videoFile.VideoStreamDuration < 5 || (0 < videoFile.FrameCount && videoFile.FrameCount <= int64(chunkCount)) { // some files can have FrameCount == 0, only use SlowSeek  if duration < 5
+	if spriteRequiresSlowSeek(videoFile, chunkCount) {
 		if videoFile.VideoStreamDuration <= 0 {
 			s := fmt.Sprintf("video %s: duration(%.3f)/frame count(%d) invalid, skipping sprite creation", videoFile.Path, videoFile.VideoStreamDuration, videoFile.FrameCount)
 			return nil, errors.New(s)
@@ -83,6 +83,13 @@ func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageO
 	}, nil
 }
 
+// spriteRequiresSlowSeek returns true if the sprite frames of the video file
+// must be selected by frame number rather than by time.
+func spriteRequiresSlowSeek(videoFile ffmpeg.VideoFile, chunkCount int) bool {
+	// some files can have FrameCount == 0, only use SlowSeek if duration < 5
+	return videoFile.VideoStreamDuration < 5 || (0 < videoFile.FrameCount && videoFile.FrameCount <= int64(chunkCount))
+}
+
 func (g *SpriteGenerator) Generate() error {
 	if err := g.generateSpriteImage(); err != nil {
 		return err
@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// ImageTransformSource returns the source of transforms of an image.
+func ImageTransformSource(imageID int) string {
+	return "image/" + strconv.Itoa(imageID)
+}
+
+// SceneScreenshotTransformSource returns the source of transforms of the
+// screenshot of a scene.
+func SceneScreenshotTransformSource(sceneID int) string {
+	return "scene/" + strconv.Itoa(sceneID) + "/screenshot"
+}
+
+const (
+	// ImageTransformURLDuration is the minimum lifetime of a signed
+	// transform URL.
+	ImageTransformURLDuration = 30 * 24 * time.Hour
+	// imageTransformURLInterval is the interval at which the expiry time of
+	// new transform URLs changes. URLs signed within the same interval are
+	// identical, so that they can be cached by clients.
+	imageTransformURLInterval = 24 * time.Hour
+)
+
+// ImageTransformExpiry returns the expiry time of transform URLs signed at
+// the provided time.
+func ImageTransformExpiry(now time.Time) time.Time {
+	return now.Truncate(imageTransformURLInterval).Add(imageTransformURLInterval + ImageTransformURLDuration)
+}
+
+func imageTransformMAC(source string, t image.Transform, expiresAt time.Time) []byte {
+	mac := hmac.New(sha256.New, config.GetInstance().GetJWTSignKey())
+	// prefixed so that signatures cannot be used for anything else
+	mac.Write([]byte("image-transform:" + source + "?" + t.String() + "&exp=" + strconv.FormatInt(expiresAt.Unix(), 10)))
+	return mac.Sum(nil)
+}
+
+// SignImageTransform returns the signature granting access to the transform
+// of the source image until the expiry time.
+func SignImageTransform(source string, t image.Transform, expiresAt time.Time) string {
+	return base64.RawURLEncoding.EncodeToString(imageTransformMAC(source, t, expiresAt))
+}
+
+// VerifyImageTransform returns true if sig is the signature of the transform
+// of the source image, and the expiry time has not passed.
+func VerifyImageTransform(source string, t image.Transform, expiresAt time.Time, sig string, now time.Time) bool {
+	if !now.Before(expiresAt) {
+		return false
+	}
+
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(got, imageTransformMAC(source, t, expiresAt))
+}
+
+// ServeImageTransform serves the transform of an image, using the cached
+// result if present. version identifies the current contents of the image,
+// and load returns the contents of the image.
+func (s *Manager) ServeImageTransform(w http.ResponseWriter, r *http.Request, version string, t image.Transform, load func(ctx context.Context) ([]byte, error)) {
+	cache := s.ImageTransformCache
+	key := version + "?" + t.String()
+
+	if path := cache.Get(key, t.Format); path != "" {
+		utils.ServeStaticFile(w, r, path)
+		return
+	}
+
+	data, err := load(r.Context())
+	switch {
+	case errors.Is(err, context.Canceled):
+		return
+	case errors.Is(err, fs.ErrNotExist):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		logger.Errorf("error reading image %s: %v", version, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// share the limit on concurrent thumbnail generation
+	wg := &s.ImageThumbnailGenerateWaitGroup
+	wg.Add()
+	defer wg.Done()
+
+	encoder := image.NewThumbnailEncoder(s.FFMpeg, s.FFProbe, image.ClipPreviewOptions{})
+	out, err := encoder.Transform(r.Context(), data, t)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Errorf("error transforming image %s: %v", version, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if _, err := cache.Put(key, t.Format, out); err != nil {
+		logger.Warnf("error caching transformed image %s: %v", version, err)
+	}
+
+	w.Header().Set("Content-Type", t.Format.ContentType())
+	utils.ServeStaticContent(w, r, out)
+}
@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignImageTransform(t *testing.T) {
+	c := config.InitializeEmpty()
+	c.SetString(config.JWTSignKey, "test-sign-key")
+
+	now := time.Now()
+	exp := ImageTransformExpiry(now)
+	source := ImageTransformSource(1)
+	transform := image.Transform{Width: 320, Format: image.TransformFormatJpeg}
+	sig := SignImageTransform(source, transform, exp)
+
+	assert.True(t, VerifyImageTransform(source, transform, exp, sig, now))
+
+	// equivalent transforms have the same signature
+	assert.True(t, VerifyImageTransform(source, image.Transform{Width: 320}, exp, sig, now))
+
+	// different source or transform
+	assert.False(t, VerifyImageTransform(ImageTransformSource(2), transform, exp, sig, now))
+	assert.False(t, VerifyImageTransform(SceneScreenshotTransformSource(1), transform, exp, sig, now))
+	assert.False(t, VerifyImageTransform(source, image.Transform{Width: 640}, exp, sig, now))
+
+	// the expiry time cannot be changed
+	assert.False(t, VerifyImageTransform(source, transform, exp.Add(time.Hour), sig, now))
+
+	// expired
+	assert.False(t, VerifyImageTransform(source, transform, exp, sig, exp))
+
+	assert.False(t, VerifyImageTransform(source, transform, exp, "", now))
+	assert.False(t, VerifyImageTransform(source, transform, exp, "not base64!", now))
+
+	// signed with a different key
+	c.SetString(config.JWTSignKey, "other-sign-key")
+	assert.False(t, VerifyImageTransform(source, transform, exp, sig, now))
+}
+
+func TestImageTransformExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	exp := ImageTransformExpiry(now)
+	assert.True(t, exp.Sub(now) >= ImageTransformURLDuration)
+
+	// URLs signed on the same day are identical
+	assert.Equal(t, exp, ImageTransformExpiry(now.Add(time.Hour)))
+}
@@ -23,11 +23,14 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/remoteplayer"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/totp"
 	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stashapp/stash/pkg/worker"
 	"github.com/stashapp/stash/ui"
 )
 
@@ -72,6 +75,12 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		Repository: db.Group,
 	}
 
+	totpService := &totp.Service{
+		TxnManager: repo.TxnManager,
+		Repository: db.TOTP,
+		Issuer:     "Stash",
+	}
+
 	sceneServer := &SceneServer{
 		TxnManager:       repo.TxnManager,
 		SceneCoverGetter: repo.Scene,
@@ -87,11 +96,15 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		Paths: mgrPaths,
 
 		ImageThumbnailGenerateWaitGroup: sizedwaitgroup.New(1),
+		ImageTransformCache:             image.NewTransformCache(cfg),
 
 		JobManager:      initJobManager(cfg),
 		ReadLockManager: fsutil.NewReadLockManager(),
+		ArtifactStore:   job.NewArtifactStore(cfg),
 
 		DownloadStore: NewDownloadStore(),
+		RemotePlayers: remoteplayer.NewRegistry(),
+		RemoteWorkers: worker.NewPool(),
 
 		PluginCache:  pluginCache,
 		ScraperCache: scraperCache,
@@ -105,8 +118,11 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		ImageService:   imageService,
 		GalleryService: galleryService,
 		GroupService:   groupService,
+		TOTPService:    totpService,
 
-		scanSubs: &subscriptionManager{},
+		scanSubs:     &subscriptionManager{},
+		cleanReports: &cleanReportStore{},
+		inboxReports: &inboxReportStore{},
 	}
 
 	if !cfg.IsNewSystem() {
@@ -136,6 +152,33 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	instance = mgr
+
+	if !cfg.IsNewSystem() {
+		mgr.resumeInterruptedJobs(ctx)
+
+		if err := cfg.Watch(context.Background(), mgr.configChanged); err != nil {
+			logger.Warnf("Config file changes will not be reloaded: %v", err)
+		}
+	}
+
+	mgr.backupScheduler = &backupScheduler{manager: mgr}
+	mgr.backupScheduler.start(ctx)
+
+	mgr.inboxScheduler = &inboxScheduler{manager: mgr}
+	mgr.inboxScheduler.start(ctx)
+
+	mgr.urlCheckScheduler = &urlCheckScheduler{manager: mgr}
+	mgr.urlCheckScheduler.start(ctx)
+
+	mgr.diskSpaceMonitor = &diskSpaceMonitor{manager: mgr}
+	mgr.diskSpaceMonitor.start(ctx)
+
+	mgr.backfillWorker = &backfillWorker{manager: mgr}
+	mgr.backfillWorker.start(ctx)
+
+	mgr.remoteWorker = &remoteWorker{manager: mgr}
+	mgr.remoteWorker.start(ctx)
+
 	return mgr, nil
 }
 
@@ -190,6 +233,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshConfig()
 
 	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore.RegisterTwoFactorProvider(s.TOTPService)
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	s.RefreshPluginCache()
@@ -201,6 +245,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshDLNA()
 
 	s.SetBlobStoreOptions()
+	s.SetSceneTitleTemplate()
 
 	s.writeStashIcon()
 
@@ -227,6 +272,12 @@ func (s *Manager) postInit(ctx context.Context) error {
 		})
 	}
 
+	s.Database.SetLowMemoryMode(s.Config.GetLowMemoryMode())
+	s.Database.SetWALOptions(sqlite.WALOptions{
+		Synchronous:    sqlite.SynchronousMode(s.Config.GetDatabaseSynchronous()),
+		AutoCheckpoint: s.Config.GetDatabaseWALAutoCheckpoint(),
+	})
+
 	if err := s.Database.Open(s.Config.GetDatabasePath()); err != nil {
 		var migrationNeededErr *sqlite.MigrationNeededError
 		if errors.As(err, &migrationNeededErr) {
@@ -0,0 +1,171 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// jobQueueFile stores the jobs that were queued or running when the server
+// was shut down, so that they can be queued again on the next start.
+const jobQueueFile = "job_queue.json"
+
+type persistedJob struct {
+	Scan     *ScanMetadataInput     `json:"scan,omitempty"`
+	Generate *GenerateMetadataInput `json:"generate,omitempty"`
+}
+
+func jobQueuePath() string {
+	return filepath.Join(config.GetInstance().GetConfigPath(), jobQueueFile)
+}
+
+// loadJobQueue returns the jobs persisted on shutdown. Returns nil, nil if
+// the server was not shut down gracefully.
+func loadJobQueue() ([]persistedJob, error) {
+	data, err := os.ReadFile(jobQueuePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ret := []persistedJob{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func saveJobQueue(jobs []persistedJob) error {
+	if jobs == nil {
+		jobs = []persistedJob{}
+	}
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(jobQueuePath(), data, 0644)
+}
+
+// shutdownJobs stops the job manager, waiting for running jobs to finish or
+// checkpoint their progress. Interrupted and queued scan and generate jobs
+// are persisted so that they are resumed on the next start.
+func (s *Manager) shutdownJobs() {
+	if s.JobManager == nil {
+		return
+	}
+
+	grace := s.Config.GetShutdownGracePeriod()
+	logger.Infof("Waiting up to %s for running jobs to stop", grace)
+
+	var jobs []persistedJob
+	for _, e := range s.JobManager.Shutdown(grace) {
+		switch j := e.(type) {
+		case *ScanJob:
+			input := j.input
+			jobs = append(jobs, persistedJob{Scan: &input})
+		case *GenerateJob:
+			input := j.input
+			jobs = append(jobs, persistedJob{Generate: &input})
+		default:
+			logger.Infof("Job %T cannot be resumed and will not be queued on the next start", e)
+		}
+	}
+
+	// the queue file is only meaningful if the config path is set up
+	if s.Config.IsNewSystem() {
+		return
+	}
+
+	if err := saveJobQueue(jobs); err != nil {
+		logger.Errorf("Error saving job queue: %v", err)
+		return
+	}
+
+	if len(jobs) > 0 {
+		logger.Infof("Saved %d jobs to resume on the next start", len(jobs))
+	}
+}
+
+// resumeInterruptedJobs queues the jobs persisted on shutdown. If the server
+// was not shut down gracefully, scan and generate jobs with saved progress
+// are queued instead.
+func (s *Manager) resumeInterruptedJobs(ctx context.Context) {
+	if s.Database.Ready() != nil {
+		return
+	}
+
+	jobs, err := loadJobQueue()
+	if err != nil {
+		logger.Warnf("error loading saved job queue: %v", err)
+	}
+
+	if jobs == nil {
+		s.resumeInterruptedScan(ctx)
+		s.resumeInterruptedGenerate(ctx)
+		return
+	}
+
+	if err := os.Remove(jobQueuePath()); err != nil {
+		logger.Warnf("error removing saved job queue: %v", err)
+	}
+
+	// progress of jobs that were not queued at shutdown, such as jobs
+	// cancelled by the user while stopping, is discarded
+	hasScan, hasGenerate := false, false
+	for _, j := range jobs {
+		hasScan = hasScan || j.Scan != nil
+		hasGenerate = hasGenerate || j.Generate != nil
+	}
+	if !hasScan {
+		removeScanResumeState()
+	}
+	if !hasGenerate {
+		removeGenerateResumeState()
+	}
+
+	for _, j := range jobs {
+		switch {
+		case j.Scan != nil:
+			logger.Info("Queuing scan interrupted by shutdown")
+			_, err = s.Scan(ctx, *j.Scan)
+		case j.Generate != nil:
+			logger.Info("Queuing generate interrupted by shutdown")
+			_, err = s.Generate(ctx, *j.Generate)
+		default:
+			continue
+		}
+
+		if err != nil {
+			logger.Warnf("error resuming interrupted job: %v", err)
+		}
+	}
+}
+
+// resumeInterruptedGenerate queues a generate job interrupted by a crash,
+// which skips the scenes it had completed.
+func (s *Manager) resumeInterruptedGenerate(ctx context.Context) {
+	state, err := loadGenerateResumeState()
+	if err != nil {
+		logger.Warnf("error loading progress of interrupted generate: %v", err)
+		return
+	}
+
+	if state == nil {
+		return
+	}
+
+	logger.Info("Queuing interrupted generate")
+	if _, err := s.Generate(ctx, state.Input); err != nil {
+		logger.Warnf("error resuming interrupted generate: %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -18,15 +19,19 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/pkg"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/remoteplayer"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/totp"
+	"github.com/stashapp/stash/pkg/worker"
 
 	// register custom migrations
 	_ "github.com/stashapp/stash/pkg/sqlite/migrations"
@@ -39,6 +44,8 @@ type Manager struct {
 	// ImageThumbnailGenerateWaitGroup is the global wait group image thumbnail generation
 	// It uses the parallel tasks setting from the configuration.
 	ImageThumbnailGenerateWaitGroup sizedwaitgroup.SizedWaitGroup
+	// ImageTransformCache stores the results of on demand image transforms
+	ImageTransformCache *image.TransformCache
 
 	Paths *paths.Paths
 
@@ -48,9 +55,14 @@ type Manager struct {
 
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	// ArtifactStore stores the reports produced by jobs
+	ArtifactStore *job.ArtifactStore
 
 	DownloadStore *DownloadStore
 	SessionStore  *session.Store
+	RemotePlayers *remoteplayer.Registry
+	// RemoteWorkers holds the generate jobs dispatched to remote workers
+	RemoteWorkers *worker.Pool
 
 	PluginCache  *plugin.Cache
 	ScraperCache *scraper.Cache
@@ -67,8 +79,17 @@ type Manager struct {
 	ImageService   ImageService
 	GalleryService GalleryService
 	GroupService   GroupService
-
-	scanSubs *subscriptionManager
+	TOTPService    *totp.Service
+
+	scanSubs          *subscriptionManager
+	cleanReports      *cleanReportStore
+	inboxReports      *inboxReportStore
+	backupScheduler   *backupScheduler
+	inboxScheduler    *inboxScheduler
+	urlCheckScheduler *urlCheckScheduler
+	diskSpaceMonitor  *diskSpaceMonitor
+	backfillWorker    *backfillWorker
+	remoteWorker      *remoteWorker
 }
 
 var instance *Manager
@@ -93,9 +114,20 @@ func (s *Manager) SetBlobStoreOptions() {
 	})
 }
 
+// SetSceneTitleTemplate sets the template used to generate scene titles
+// from the configuration.
+func (s *Manager) SetSceneTitleTemplate() {
+	tmpl, err := models.ParseTitleTemplate(s.Config.GetSceneTitleTemplate())
+	if err != nil {
+		logger.Warnf("Not generating scene titles: %v", err)
+	}
+
+	s.Database.SetSceneTitleTemplate(tmpl)
+}
+
 func (s *Manager) RefreshConfig() {
 	cfg := s.Config
-	*s.Paths = paths.NewPaths(cfg.GetGeneratedPath(), cfg.GetBlobsPath())
+	*s.Paths = paths.NewPaths(cfg.GetGeneratedPath(), cfg.GetBlobsPath(), cfg.GetGeneratedLayout())
 	if cfg.Validate() == nil {
 		if err := fsutil.EnsureDir(s.Paths.Generated.Screenshots); err != nil {
 			logger.Warnf("could not create screenshots directory: %v", err)
@@ -115,9 +147,48 @@ func (s *Manager) RefreshConfig() {
 		if err := fsutil.EnsureDir(s.Paths.Generated.InteractiveHeatmap); err != nil {
 			logger.Warnf("could not create interactive heatmaps directory: %v", err)
 		}
+		if err := fsutil.EnsureDir(s.Paths.Generated.Heatmaps); err != nil {
+			logger.Warnf("could not create heatmaps directory: %v", err)
+		}
+		if s.Paths.Generated.Layout == paths.LayoutScene {
+			if err := fsutil.EnsureDir(s.Paths.Generated.Scenes); err != nil {
+				logger.Warnf("could not create scenes directory: %v", err)
+			}
+		}
 
 		s.ImageThumbnailGenerateWaitGroup.Size = cfg.GetParallelTasksWithAutoDetection()
 	}
+
+	s.setGCPercent()
+}
+
+// SetGeneratedLayout saves the layout of the generated scene files to the
+// configuration and refreshes the paths. It does not move existing files.
+func (s *Manager) SetGeneratedLayout(layout paths.Layout) error {
+	s.Config.SetInterface(config.GeneratedLayout, layout.String())
+	if err := s.Config.Write(); err != nil {
+		return err
+	}
+
+	s.RefreshConfig()
+	return nil
+}
+
+// garbage collection target percentage used in low memory mode
+const lowMemoryGCPercent = 50
+
+// setGCPercent collects garbage more often in low memory mode, unless the
+// GOGC environment variable is set.
+func (s *Manager) setGCPercent() {
+	if os.Getenv("GOGC") != "" {
+		return
+	}
+
+	gcPercent := 100
+	if s.Config.GetLowMemoryMode() {
+		gcPercent = lowMemoryGCPercent
+	}
+	debug.SetGCPercent(gcPercent)
 }
 
 // RefreshPluginCache refreshes the plugin cache.
@@ -159,6 +230,14 @@ func (s *Manager) RefreshDLNA() {
 	}
 }
 
+// RestartDLNA restarts the DLNA service if it is running, so that changes to
+// its network settings take effect.
+func (s *Manager) RestartDLNA() {
+	if err := s.DLNAService.Restart(); err != nil {
+		logger.Warnf("error restarting DLNA service: %v", err)
+	}
+}
+
 func createPackageManager(localPath string, srcPathGetter pkg.SourcePathGetter) *pkg.Manager {
 	const timeout = 10 * time.Second
 	httpClient := &http.Client{
@@ -296,6 +375,10 @@ func (s *Manager) Setup(ctx context.Context, input SetupInput) error {
 
 	cfg.FinalizeSetup()
 
+	if err := cfg.Watch(context.Background(), s.configChanged); err != nil {
+		logger.Warnf("Config file changes will not be reloaded: %v", err)
+	}
+
 	return nil
 }
 
@@ -338,6 +421,14 @@ func (s *Manager) BackupDatabase(download bool) (string, string, error) {
 		return "", "", err
 	}
 
+	if !download {
+		if err := verifyBackup(s.Database, backupPath); err != nil {
+			return "", "", err
+		}
+
+		rotateBackups(s.Database, filepath.Dir(backupPath), s.Config.GetBackupRetention())
+	}
+
 	return backupPath, backupName, nil
 }
 
@@ -422,6 +513,9 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 func (s *Manager) Shutdown() {
 	// TODO: Each part of the manager needs to gracefully stop at some point
 
+	// jobs must stop before the database is closed
+	s.shutdownJobs()
+
 	if s.StreamManager != nil {
 		s.StreamManager.Shutdown()
 		s.StreamManager = nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -59,6 +60,7 @@ func getScanPaths(inputPaths []string) []*config.StashConfig {
 
 	var ret config.StashConfigs
 	for _, p := range inputPaths {
+		p = fsutil.NormalizePath(p)
 		s := stashPaths.GetStashFromDirPath(p)
 		if s == nil {
 			logger.Warnf("%s is not in the configured stash paths", p)
@@ -100,7 +102,17 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		return 0, err
 	}
 
-	scanner := &file.Scanner{
+	scanJob := ScanJob{
+		scanner:       s.newScanner(),
+		input:         input,
+		subscriptions: s.scanSubs,
+	}
+
+	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+}
+
+func (s *Manager) newScanner() *file.Scanner {
+	return &file.Scanner{
 		Repository: file.NewRepository(s.Repository),
 		FileDecorators: []file.Decorator{
 			&file.FilteredDecorator{
@@ -119,14 +131,6 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		FingerprintCalculator: &fingerprintCalculator{s.Config},
 		FS:                    &file.OsFS{},
 	}
-
-	scanJob := ScanJob{
-		scanner:       scanner,
-		input:         input,
-		subscriptions: s.scanSubs,
-	}
-
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -270,11 +274,15 @@ type AutoTagMetadataInput struct {
 	Studios []string `json:"studios"`
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
+	// Create studios and parent studio links from the directory structure
+	// of the files in Paths before tagging
+	InferStudioHierarchy bool `json:"inferStudioHierarchy"`
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 	j := autoTagJob{
 		repository: s.Repository,
+		artifacts:  s.ArtifactStore,
 		input:      input,
 	}
 
@@ -285,9 +293,53 @@ type CleanMetadataInput struct {
 	Paths []string `json:"paths"`
 	// Do a dry run. Don't delete any files
 	DryRun bool `json:"dryRun"`
+	// ID of the dry run report to confirm
+	ConfirmReportID *string `json:"confirmReportId"`
+}
+
+var (
+	ErrCleanReportRequired = errors.New("clean rules are configured: a dry run report must be confirmed")
+	ErrCleanReportNotFound = errors.New("clean report not found")
+)
+
+// LastCleanReport returns the report of the most recent dry run clean, or nil
+// if there is none.
+func (s *Manager) LastCleanReport() *CleanReport {
+	return s.cleanReports.get()
+}
+
+// getConfirmedCleanReport returns the dry run report that a clean must be
+// limited to, or nil if the clean is not limited.
+func (s *Manager) getConfirmedCleanReport(input CleanMetadataInput) (*CleanReport, error) {
+	if input.DryRun {
+		return nil, nil
+	}
+
+	if input.ConfirmReportID == nil {
+		if len(s.Config.GetCleanRules()) > 0 {
+			return nil, ErrCleanReportRequired
+		}
+		return nil, nil
+	}
+
+	report := s.cleanReports.get()
+	if report == nil || report.ID != *input.ConfirmReportID {
+		return nil, fmt.Errorf("%w: %s", ErrCleanReportNotFound, *input.ConfirmReportID)
+	}
+
+	if !slices.Equal(report.Paths, input.Paths) {
+		return nil, fmt.Errorf("paths do not match those of clean report %s", report.ID)
+	}
+
+	return report, nil
 }
 
-func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
+func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) (int, error) {
+	confirmed, err := s.getConfirmedCleanReport(input)
+	if err != nil {
+		return 0, err
+	}
+
 	cleaner := &file.Cleaner{
 		FS:         &file.OsFS{},
 		Repository: file.NewRepository(s.Repository),
@@ -302,10 +354,14 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		sceneService: s.SceneService,
 		imageService: s.ImageService,
 		input:        input,
+		rules:        s.Config.GetCleanRules(),
+		confirmed:    confirmed,
+		reports:      s.cleanReports,
+		artifacts:    s.ArtifactStore,
 		scanSubs:     s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+	return s.JobManager.Add(ctx, "Cleaning...", &j), nil
 }
 
 func (s *Manager) OptimiseDatabase(ctx context.Context) int {
@@ -316,6 +372,39 @@ func (s *Manager) OptimiseDatabase(ctx context.Context) int {
 	return s.JobManager.Add(ctx, "Optimising database...", &j)
 }
 
+// RefreshSceneTitles starts a job that regenerates the generated titles of
+// all scenes using the current title template.
+func (s *Manager) RefreshSceneTitles(ctx context.Context) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		logger.Info("Regenerating scene titles")
+
+		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+			return s.Repository.Scene.RefreshGeneratedTitles(ctx)
+		}); err != nil {
+			return fmt.Errorf("regenerating scene titles: %w", err)
+		}
+
+		logger.Info("Finished regenerating scene titles")
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Regenerating scene titles...", j)
+}
+
+// CalculateFingerprints starts a job that calculates the missing
+// fingerprints of the files in the given paths, or of all files if paths is
+// empty.
+func (s *Manager) CalculateFingerprints(ctx context.Context, input CalculateFingerprintsInput) int {
+	j := &CalculateFingerprintsJob{
+		repository: s.Repository,
+		fs:         &file.OsFS{},
+		calculator: &fingerprintCalculator{s.Config},
+		paths:      input.Paths,
+	}
+
+	return s.JobManager.Add(ctx, "Calculating fingerprints...", j)
+}
+
 func (s *Manager) MigrateHash(ctx context.Context) int {
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
 		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
@@ -387,6 +476,8 @@ type StashBoxBatchTagInput struct {
 	//
 	// Deprecated: please use Names
 	PerformerNames []string `json:"performer_names"`
+	// Merge local favorites with the favorites of the stash-box user after tagging
+	SyncFavorites bool `json:"sync_favorites"`
 }
 
 func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, box *models.StashBox, input StashBoxBatchTagInput) int {
@@ -499,11 +590,16 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, box *models.Sta
 			}
 		}
 
-		if len(tasks) == 0 {
+		total := len(tasks)
+		if input.SyncFavorites {
+			total++
+		}
+
+		if total == 0 {
 			return nil
 		}
 
-		progress.SetTotal(len(tasks))
+		progress.SetTotal(total)
 
 		logger.Infof("Starting stash-box batch operation for %d performers", len(tasks))
 
@@ -515,6 +611,21 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, box *models.Sta
 			progress.Increment()
 		}
 
+		if input.SyncFavorites {
+			task := StashBoxFavoritesTask{
+				repository: s.Repository,
+				box:        box,
+				taskType:   Performer,
+			}
+			progress.ExecuteTask(task.Description(), func() {
+				if err := task.Start(ctx); err != nil {
+					logger.Errorf("Error syncing stash-box favorites: %v", err)
+				}
+			})
+
+			progress.Increment()
+		}
+
 		return nil
 	})
 
@@ -620,11 +731,16 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 			}
 		}
 
-		if len(tasks) == 0 {
+		total := len(tasks)
+		if input.SyncFavorites {
+			total++
+		}
+
+		if total == 0 {
 			return nil
 		}
 
-		progress.SetTotal(len(tasks))
+		progress.SetTotal(total)
 
 		logger.Infof("Starting stash-box batch operation for %d studios", len(tasks))
 
@@ -636,6 +752,21 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 			progress.Increment()
 		}
 
+		if input.SyncFavorites {
+			task := StashBoxFavoritesTask{
+				repository: s.Repository,
+				box:        box,
+				taskType:   Studio,
+			}
+			progress.ExecuteTask(task.Description(), func() {
+				if err := task.Start(ctx); err != nil {
+					logger.Errorf("Error syncing stash-box favorites: %v", err)
+				}
+			})
+
+			progress.Increment()
+		}
+
 		return nil
 	})
 
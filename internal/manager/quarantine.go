@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// RetryQuarantinedFiles stops ignoring the quarantined files with the given
+// ids, and starts a scan of them. Files that scan successfully are removed
+// from quarantine by the scan. Returns the job ID.
+func (s *Manager) RetryQuarantinedFiles(ctx context.Context, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, errors.New("no quarantined files provided")
+	}
+
+	var paths []string
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.Quarantine
+
+		files, err := qb.FindMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range files {
+			if f.Ignored {
+				partial := models.NewQuarantinedFilePartial()
+				partial.Ignored = models.NewOptionalBool(false)
+				if _, err := qb.UpdatePartial(ctx, f.ID, partial); err != nil {
+					return err
+				}
+			}
+
+			paths = append(paths, f.Path)
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return s.Scan(ctx, ScanMetadataInput{
+		Paths: paths,
+	})
+}
@@ -0,0 +1,214 @@
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/remeh/sizedwaitgroup"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/worker"
+)
+
+// maxRemoteTasks is the maximum number of generate tasks waiting for remote
+// workers at once.
+const maxRemoteTasks = 64
+
+// remoteTask is a generate task that can be run by a remote worker.
+type remoteTask interface {
+	Task
+	remoteJobType() worker.JobType
+	// remoteTask returns the task to dispatch to a remote worker, or nil if
+	// the task must be run locally.
+	remoteTask(ctx context.Context) (*worker.Task, error)
+	// applyRemoteResult stores the result of a completed remote job.
+	applyRemoteResult(ctx context.Context, r *worker.Result) error
+}
+
+// remoteGenerateTask runs a task on a remote worker. The task is run
+// locally if no remote worker completes it.
+type remoteGenerateTask struct {
+	task remoteTask
+	// local limits the tasks run locally
+	local *sizedwaitgroup.SizedWaitGroup
+}
+
+// asRemoteTask returns t wrapped to run on a remote worker, if an active
+// remote worker accepts it.
+func asRemoteTask(t Task, local *sizedwaitgroup.SizedWaitGroup) (Task, bool) {
+	switch tt := t.(type) {
+	case *resumableSceneTask:
+		inner, ok := asRemoteTask(tt.Task, local)
+		if !ok {
+			return t, false
+		}
+		return &resumableSceneTask{Task: inner, counter: tt.counter}, true
+	case remoteTask:
+		if !instance.RemoteWorkers.Active(tt.remoteJobType()) {
+			return t, false
+		}
+		return &remoteGenerateTask{task: tt, local: local}, true
+	}
+
+	return t, false
+}
+
+func (t *remoteGenerateTask) GetDescription() string {
+	return t.task.GetDescription() + " (remote)"
+}
+
+func (t *remoteGenerateTask) Start(ctx context.Context) {
+	task, err := t.task.remoteTask(ctx)
+	if err != nil {
+		logger.Errorf("%s: %v", t.task.GetDescription(), err)
+		return
+	}
+
+	if task != nil {
+		err := t.dispatch(ctx, task)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		logger.Warnf("%s: remote worker failed, generating locally: %v", t.task.GetDescription(), err)
+	}
+
+	t.local.Add()
+	defer t.local.Done()
+	t.task.Start(ctx)
+}
+
+func (t *remoteGenerateTask) dispatch(ctx context.Context, task *worker.Task) error {
+	r, err := instance.RemoteWorkers.Dispatch(ctx, *task)
+	if err != nil {
+		return err
+	}
+
+	if r.Error != "" {
+		return errors.New(r.Error)
+	}
+
+	return t.task.applyRemoteResult(ctx, r)
+}
+
+// newRemoteSceneTask returns a remote task for the primary file of the scene.
+func newRemoteSceneTask(jobType worker.JobType, scene *models.Scene, hash string) *worker.Task {
+	f := scene.Files.Primary()
+
+	return &worker.Task{
+		Job: worker.Job{
+			Type:     jobType,
+			Hash:     hash,
+			Basename: f.Basename,
+			Duration: f.Duration,
+		},
+		SourcePath: f.Path,
+		Artifacts:  make(map[string]string),
+	}
+}
+
+func (t *GenerateSpriteTask) remoteJobType() worker.JobType {
+	return worker.JobTypeSprite
+}
+
+func (t *GenerateSpriteTask) remoteTask(ctx context.Context) (*worker.Task, error) {
+	if !t.required() {
+		return nil, nil
+	}
+
+	hash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	ret := newRemoteSceneTask(worker.JobTypeSprite, &t.Scene, hash)
+	ret.Artifacts[worker.ArtifactSpriteImage] = instance.Paths.Scene.GetSpriteImageFilePath(hash)
+	ret.Artifacts[worker.ArtifactSpriteVTT] = instance.Paths.Scene.GetSpriteVttFilePath(hash)
+
+	return ret, nil
+}
+
+func (t *GenerateSpriteTask) applyRemoteResult(ctx context.Context, r *worker.Result) error {
+	return nil
+}
+
+func (t *GeneratePreviewTask) remoteJobType() worker.JobType {
+	return worker.JobTypePreview
+}
+
+func (t *GeneratePreviewTask) remoteTask(ctx context.Context) (*worker.Task, error) {
+	if !t.required() {
+		return nil, nil
+	}
+
+	hash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	ret := newRemoteSceneTask(worker.JobTypePreview, &t.Scene, hash)
+	ret.Preview = &worker.PreviewOptions{
+		Options: t.Options,
+	}
+
+	if t.videoPreviewRequired() {
+		ret.Artifacts[worker.ArtifactPreviewVideo] = instance.Paths.Scene.GetVideoPreviewPath(hash)
+	}
+	if t.imagePreviewRequired() {
+		ret.Artifacts[worker.ArtifactPreviewImage] = instance.Paths.Scene.GetWebpPreviewPath(hash)
+	}
+
+	return ret, nil
+}
+
+func (t *GeneratePreviewTask) applyRemoteResult(ctx context.Context, r *worker.Result) error {
+	return nil
+}
+
+func (t *GenerateTranscodeTask) remoteJobType() worker.JobType {
+	return worker.JobTypeTranscode
+}
+
+func (t *GenerateTranscodeTask) remoteTask(ctx context.Context) (*worker.Task, error) {
+	p, err := t.plan()
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	ret := newRemoteSceneTask(worker.JobTypeTranscode, &t.Scene, p.hash)
+	ret.SourcePath = p.input
+	ret.Transcode = &p.options
+	ret.Artifacts[worker.ArtifactTranscode] = instance.Paths.Scene.GetTranscodePath(p.hash)
+
+	return ret, nil
+}
+
+func (t *GenerateTranscodeTask) applyRemoteResult(ctx context.Context, r *worker.Result) error {
+	return nil
+}
+
+func (t *GeneratePhashTask) remoteJobType() worker.JobType {
+	return worker.JobTypePhash
+}
+
+func (t *GeneratePhashTask) remoteTask(ctx context.Context) (*worker.Task, error) {
+	if !t.required() {
+		return nil, nil
+	}
+
+	// existing phashes of files with the same oshash are reused locally
+	if !t.Overwrite {
+		if existing, err := t.findExistingPhash(ctx); err == nil && existing != nil {
+			return nil, nil
+		}
+	}
+
+	return &worker.Task{
+		Job: worker.Job{
+			Type:     worker.JobTypePhash,
+			Basename: t.File.Basename,
+			Duration: t.File.Duration,
+		},
+		SourcePath: t.File.Path,
+	}, nil
+}
+
+func (t *GeneratePhashTask) applyRemoteResult(ctx context.Context, r *worker.Result) error {
+	if r.Phash == nil {
+		return errors.New("remote worker did not return a phash")
+	}
+
+	return t.setPhash(ctx, int64(*r.Phash))
+}
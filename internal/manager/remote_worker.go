@@ -0,0 +1,354 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/pkg/hash/videophash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/worker"
+)
+
+const (
+	remoteWorkerPollInterval      = 10 * time.Second
+	remoteWorkerHeartbeatInterval = time.Minute
+)
+
+// remoteWorker takes generate jobs from the main instance when a remote
+// worker server URL is configured. It runs up to the configured number of
+// parallel tasks at once.
+type remoteWorker struct {
+	manager *Manager
+
+	// only accessed from the worker goroutine
+	client  *worker.Client
+	running int
+}
+
+func (w *remoteWorker) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(remoteWorkerPollInterval)
+		defer ticker.Stop()
+
+		done := make(chan struct{})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-done:
+				w.running--
+			}
+
+			w.poll(ctx, done)
+		}
+	}()
+}
+
+func remoteWorkerName() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "stash"
+	}
+	return name
+}
+
+// poll takes jobs from the main instance until the worker is busy or there
+// are no more jobs. done is signalled when a job finishes.
+func (w *remoteWorker) poll(ctx context.Context, done chan<- struct{}) {
+	mgr := w.manager
+	cfg := mgr.Config
+
+	serverURL := cfg.GetRemoteWorkerServerURL()
+	if serverURL == "" || cfg.IsNewSystem() {
+		w.client = nil
+		return
+	}
+
+	apiKey := cfg.GetRemoteWorkerAPIKey()
+	if w.client == nil || w.client.URL != serverURL || w.client.APIKey != apiKey {
+		w.client = &worker.Client{
+			URL:    serverURL,
+			APIKey: apiKey,
+		}
+	}
+
+	if mgr.validateFFmpeg() != nil {
+		return
+	}
+
+	if err := mgr.Paths.Generated.EnsureTmpDir(); err != nil {
+		logger.Warnf("could not create temporary directory: %v", err)
+		return
+	}
+
+	for w.running < cfg.GetParallelTasksWithAutoDetection() {
+		if w.client.WorkerID == "" {
+			if err := w.client.Register(ctx, remoteWorkerName(), worker.AllJobTypes); err != nil {
+				logger.Warnf("Could not register as a remote worker with %s: %v", serverURL, err)
+				return
+			}
+			logger.Infof("Registered as a remote worker with %s", serverURL)
+		}
+
+		j, err := w.client.Next(ctx)
+		if errors.Is(err, worker.ErrUnknownWorker) {
+			// the main instance was restarted
+			w.client.WorkerID = ""
+			continue
+		}
+		if err != nil {
+			logger.Warnf("Error getting remote job from %s: %v", serverURL, err)
+			return
+		}
+
+		if j == nil {
+			return
+		}
+
+		w.running++
+
+		// the job uses a copy of the client, which is reset by the worker goroutine
+		c := *w.client
+		go func() {
+			w.run(ctx, &c, j)
+
+			select {
+			case done <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+}
+
+func (w *remoteWorker) run(ctx context.Context, c *worker.Client, j *worker.Job) {
+	logger.Infof("Running remote %s job for %s", j.Type, j.Basename)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go w.heartbeat(jobCtx, c, j.ID, cancel)
+
+	var result worker.Result
+	phash, err := w.execute(jobCtx, c, j)
+	if err != nil {
+		if jobCtx.Err() != nil {
+			// cancelled by shutdown or because the job was reassigned
+			return
+		}
+
+		logger.Errorf("Error running remote %s job for %s: %v", j.Type, j.Basename, err)
+		logErrorOutput(err)
+		result.Error = err.Error()
+	}
+	result.Phash = phash
+
+	if err := c.Complete(ctx, j.ID, result); err != nil {
+		logger.Warnf("Error completing remote %s job for %s: %v", j.Type, j.Basename, err)
+	}
+}
+
+// heartbeat keeps the lease on the job until ctx is done. The job is
+// cancelled if the main instance no longer recognises it.
+func (w *remoteWorker) heartbeat(ctx context.Context, c *worker.Client, jobID string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(remoteWorkerHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := c.Heartbeat(ctx, jobID)
+			var statusErr *worker.StatusError
+			if errors.Is(err, worker.ErrUnknownWorker) || errors.As(err, &statusErr) {
+				logger.Warnf("Stopping remote job: %v", err)
+				cancel()
+				return
+			}
+			if err != nil {
+				logger.Warnf("Error sending heartbeat for remote job: %v", err)
+			}
+		}
+	}
+}
+
+// execute generates the artifacts of the job and uploads them to the main
+// instance. Returns the generated phash for phash jobs.
+func (w *remoteWorker) execute(ctx context.Context, c *worker.Client, j *worker.Job) (*uint64, error) {
+	dir, err := os.MkdirTemp(w.manager.Paths.Generated.Tmp, "remote-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// keep the extension so that the format can be detected
+	input := filepath.Join(dir, "source"+filepath.Ext(j.Basename))
+	if err := c.DownloadSource(ctx, j.ID, input); err != nil {
+		return nil, err
+	}
+
+	var outputs map[string]string
+	switch j.Type {
+	case worker.JobTypePhash:
+		return videophash.Generate(w.manager.FFMpeg, &models.VideoFile{
+			BaseFile: &models.BaseFile{Path: input},
+			Duration: j.Duration,
+		})
+	case worker.JobTypeSprite:
+		outputs, err = w.generateSprite(j, input, dir)
+	case worker.JobTypePreview:
+		outputs, err = w.generatePreview(j, input, dir)
+	case worker.JobTypeTranscode:
+		outputs, err = w.generateTranscode(ctx, j, input, dir)
+	default:
+		err = fmt.Errorf("unsupported job type %q", j.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range j.Artifacts {
+		fn, found := outputs[name]
+		if !found {
+			return nil, fmt.Errorf("%w: %s", worker.ErrUnknownArtifact, name)
+		}
+
+		if err := c.UploadArtifact(ctx, j.ID, name, fn); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// remoteScenePaths writes generated files of remote jobs to a job directory.
+// File names match the main instance, since sprite VTT files refer to the
+// sprite image by name.
+type remoteScenePaths struct {
+	dir string
+}
+
+func (p remoteScenePaths) TempFile(pattern string) (*os.File, error) {
+	return os.CreateTemp(p.dir, pattern)
+}
+
+func (p remoteScenePaths) GetVideoPreviewPath(checksum string) string {
+	return filepath.Join(p.dir, checksum+".mp4")
+}
+
+func (p remoteScenePaths) GetWebpPreviewPath(checksum string) string {
+	return filepath.Join(p.dir, checksum+".webp")
+}
+
+func (p remoteScenePaths) GetSpriteImageFilePath(checksum string) string {
+	return filepath.Join(p.dir, checksum+"_sprite.jpg")
+}
+
+func (p remoteScenePaths) GetSpriteVttFilePath(checksum string) string {
+	return filepath.Join(p.dir, checksum+"_thumbs.vtt")
+}
+
+func (p remoteScenePaths) GetBarcodeFilePath(checksum string) string {
+	return filepath.Join(p.dir, checksum+"_barcode.png")
+}
+
+func (p remoteScenePaths) GetTranscodePath(checksum string) string {
+	return filepath.Join(p.dir, checksum+"_transcode.mp4")
+}
+
+func (w *remoteWorker) generator(dir string) *generate.Generator {
+	mgr := w.manager
+	return &generate.Generator{
+		Encoder:      mgr.FFMpeg,
+		FFMpegConfig: mgr.Config,
+		LockManager:  mgr.ReadLockManager,
+		ScenePaths:   remoteScenePaths{dir: dir},
+		Overwrite:    true,
+	}
+}
+
+func (w *remoteWorker) generateSprite(j *worker.Job, input string, dir string) (map[string]string, error) {
+	videoFile, err := w.manager.FFProbe.NewVideoFile(input)
+	if err != nil {
+		return nil, fmt.Errorf("reading video file: %w", err)
+	}
+
+	paths := remoteScenePaths{dir: dir}
+	imagePath := paths.GetSpriteImageFilePath(j.Hash)
+	vttPath := paths.GetSpriteVttFilePath(j.Hash)
+
+	g, err := NewSpriteGenerator(*videoFile, j.Hash, imagePath, vttPath, 9, 9)
+	if err != nil {
+		return nil, err
+	}
+	g.Overwrite = true
+
+	if err := g.Generate(); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		worker.ArtifactSpriteImage: imagePath,
+		worker.ArtifactSpriteVTT:   vttPath,
+	}, nil
+}
+
+func (w *remoteWorker) generatePreview(j *worker.Job, input string, dir string) (map[string]string, error) {
+	if j.Preview == nil {
+		return nil, errors.New("missing preview options")
+	}
+
+	videoFile, err := w.manager.FFProbe.NewVideoFile(input)
+	if err != nil {
+		return nil, fmt.Errorf("reading video file: %w", err)
+	}
+
+	t := &GeneratePreviewTask{
+		Scene:     models.Scene{Path: input},
+		Options:   j.Preview.Options,
+		Overwrite: true,
+		generator: w.generator(dir),
+	}
+
+	// the image preview is generated from the video preview
+	if err := t.generateVideo(j.Hash, videoFile.VideoStreamDuration, videoFile.FrameRate); err != nil {
+		return nil, err
+	}
+
+	paths := remoteScenePaths{dir: dir}
+	ret := map[string]string{
+		worker.ArtifactPreviewVideo: paths.GetVideoPreviewPath(j.Hash),
+	}
+
+	if j.HasArtifact(worker.ArtifactPreviewImage) {
+		if err := t.generateWebp(j.Hash); err != nil {
+			return nil, err
+		}
+		ret[worker.ArtifactPreviewImage] = paths.GetWebpPreviewPath(j.Hash)
+	}
+
+	return ret, nil
+}
+
+func (w *remoteWorker) generateTranscode(ctx context.Context, j *worker.Job, input string, dir string) (map[string]string, error) {
+	if j.Transcode == nil {
+		return nil, errors.New("missing transcode options")
+	}
+
+	if err := runTranscode(ctx, w.generator(dir), input, j.Hash, *j.Transcode); err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		worker.ArtifactTranscode: remoteScenePaths{dir: dir}.GetTranscodePath(j.Hash),
+	}, nil
+}
@@ -33,6 +33,8 @@ type GalleryService interface {
 	ValidateImageGalleryChange(ctx context.Context, i *models.Image, updateIDs models.UpdateIDs) error
 
 	Updated(ctx context.Context, galleryID int) error
+
+	SaveReadingProgress(ctx context.Context, galleryID int, page int) (bool, error)
 }
 
 type GroupService interface {
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"os"
+	"strconv"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/static"
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -56,6 +59,25 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	http.ServeFile(w, r, filepath)
 }
 
+// ServePreview serves the generated video preview of the scene, returning
+// not found if it has not been generated.
+func (s *SceneServer) ServePreview(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	if sceneHash == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	filepath := GetInstance().Paths.Scene.GetVideoPreviewPath(sceneHash)
+	if exists, _ := fsutil.FileExists(filepath); !exists {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "video/mp4")
+	utils.ServeStaticFile(w, r, filepath)
+}
+
 func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
 	var cover []byte
 	readTxnErr := txn.WithReadTxn(r.Context(), s.TxnManager, func(ctx context.Context) error {
@@ -95,3 +117,39 @@ func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter
 
 	utils.ServeImage(w, r, cover)
 }
+
+// ServeScreenshotTransform serves the transform of the screenshot of the
+// scene.
+func (s *SceneServer) ServeScreenshotTransform(scene *models.Scene, t image.Transform, w http.ResponseWriter, r *http.Request) {
+	version := SceneScreenshotTransformSource(scene.ID) + "@" + strconv.FormatInt(scene.UpdatedAt.Unix(), 10)
+	GetInstance().ServeImageTransform(w, r, version, t, func(ctx context.Context) ([]byte, error) {
+		return s.getScreenshot(ctx, scene)
+	})
+}
+
+// getScreenshot returns the cover of the scene, falling back to the legacy
+// screenshot file and then the default cover.
+func (s *SceneServer) getScreenshot(ctx context.Context, scene *models.Scene) ([]byte, error) {
+	var cover []byte
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		var err error
+		cover, err = s.SceneCoverGetter.GetCover(ctx, scene.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if cover != nil {
+		return cover, nil
+	}
+
+	if scene.Path != "" {
+		sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+		filepath := GetInstance().Paths.Scene.GetLegacyScreenshotPath(sceneHash)
+		if exists, _ := fsutil.FileExists(filepath); exists {
+			return os.ReadFile(filepath)
+		}
+	}
+
+	return static.ReadAll(static.DefaultSceneImage), nil
+}
@@ -0,0 +1,176 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	scanResumeFile = "scan_resume.json"
+
+	// scanResumeSaveInterval is the minimum interval between writes of the
+	// scan progress to disk.
+	scanResumeSaveInterval = 5 * time.Second
+)
+
+// scanResumeState is the persisted progress of a running scan. It is
+// removed when the scan completes or is cancelled, so an existing state
+// indicates that the scan was interrupted by a restart or crash.
+type scanResumeState struct {
+	Input ScanMetadataInput `json:"input"`
+	// Cursors maps scan paths to the last file processed in the path.
+	Cursors map[string]string `json:"cursors"`
+}
+
+func scanResumePath() string {
+	return filepath.Join(config.GetInstance().GetConfigPath(), scanResumeFile)
+}
+
+// loadScanResumeState returns the progress of an interrupted scan.
+// Returns nil, nil if there is no interrupted scan.
+func loadScanResumeState() (*scanResumeState, error) {
+	data, err := os.ReadFile(scanResumePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret scanResumeState
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func removeScanResumeState() {
+	if err := os.Remove(scanResumePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("error removing scan progress: %v", err)
+	}
+}
+
+// matches returns true if the state was saved by a scan with the same input.
+func (s *scanResumeState) matches(input ScanMetadataInput) bool {
+	a, err := json.Marshal(s.Input)
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(input)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(a, b)
+}
+
+// scanResumer records the progress of a scan as it is reported by the
+// scanner, periodically writing it to disk.
+type scanResumer struct {
+	mutex     sync.Mutex
+	state     scanResumeState
+	lastSaved time.Time
+}
+
+// newScanResumer returns a scanResumer for a scan with the given input.
+// If an interrupted scan with the same input exists, its progress is
+// resumed.
+func newScanResumer(input ScanMetadataInput) *scanResumer {
+	ret := &scanResumer{
+		state: scanResumeState{
+			Input:   input,
+			Cursors: make(map[string]string),
+		},
+	}
+
+	existing, err := loadScanResumeState()
+	if err != nil {
+		logger.Warnf("error loading progress of interrupted scan: %v", err)
+	}
+
+	if existing != nil && existing.matches(input) && len(existing.Cursors) > 0 {
+		logger.Info("Resuming interrupted scan")
+		ret.state.Cursors = existing.Cursors
+	}
+
+	ret.save()
+
+	return ret
+}
+
+// cursors returns a copy of the resumed progress.
+func (r *scanResumer) cursors() map[string]string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ret := make(map[string]string, len(r.state.Cursors))
+	for k, v := range r.state.Cursors {
+		ret[k] = v
+	}
+
+	return ret
+}
+
+func (r *scanResumer) checkpoint(path string, cursor string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.state.Cursors[path] = cursor
+
+	if time.Since(r.lastSaved) >= scanResumeSaveInterval {
+		r.saveLocked()
+	}
+}
+
+func (r *scanResumer) save() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.saveLocked()
+}
+
+func (r *scanResumer) saveLocked() {
+	r.lastSaved = time.Now()
+
+	data, err := json.MarshalIndent(r.state, "", "  ")
+	if err == nil {
+		err = os.WriteFile(scanResumePath(), data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error saving scan progress: %v", err)
+	}
+}
+
+// resumeInterruptedScan queues a scan interrupted by a restart or crash,
+// which continues from where it left off.
+func (s *Manager) resumeInterruptedScan(ctx context.Context) {
+	if s.Database.Ready() != nil {
+		return
+	}
+
+	state, err := loadScanResumeState()
+	if err != nil {
+		logger.Warnf("error loading progress of interrupted scan: %v", err)
+		return
+	}
+
+	if state == nil {
+		return
+	}
+
+	logger.Info("Queuing interrupted scan")
+	if _, err := s.Scan(ctx, state.Input); err != nil {
+		logger.Warnf("error resuming interrupted scan: %v", err)
+	}
+}
@@ -14,6 +14,14 @@ type SceneStreamEndpoint struct {
 	URL      string  `json:"url"`
 	MimeType *string `json:"mime_type"`
 	Label    *string `json:"label"`
+	// Method and Reason are only set when negotiated against a client
+	// profile.
+	Method *StreamMethod `json:"method"`
+	Reason *string       `json:"reason"`
+
+	// format and resolution of the output, set when negotiated
+	format     streamFormat
+	resolution int
 }
 
 type endpointType struct {
@@ -75,6 +83,67 @@ func GetVideoFileContainer(file *models.VideoFile) (ffmpeg.Container, error) {
 	return container, nil
 }
 
+// includeSceneStreamPath returns true if a stream of streamingResolution
+// should be offered for a scene of sceneResolution.
+func includeSceneStreamPath(sceneResolution int, maxStreamingTranscodeSize models.StreamingResolutionEnum, streamingResolution models.StreamingResolutionEnum) bool {
+	// convert StreamingResolutionEnum to ResolutionEnum
+	maxStreamingResolution := models.ResolutionEnum(maxStreamingTranscodeSize)
+
+	var minResolution int
+	if streamingResolution == models.StreamingResolutionEnumOriginal {
+		minResolution = sceneResolution
+	} else {
+		// convert StreamingResolutionEnum to ResolutionEnum so we can get the min
+		// resolution
+		convertedRes := models.ResolutionEnum(streamingResolution)
+		minResolution = convertedRes.GetMinResolution()
+
+		// don't include if scene resolution is smaller than the streamingResolution
+		if sceneResolution != 0 && sceneResolution < minResolution {
+			return false
+		}
+	}
+
+	// if we always allow everything, then return true
+	if maxStreamingTranscodeSize == models.StreamingResolutionEnumOriginal {
+		return true
+	}
+
+	return maxStreamingResolution.GetMinResolution() >= minResolution
+}
+
+func newStreamEndpoint(directStreamURL *url.URL, t endpointType, resolution models.StreamingResolutionEnum) *SceneStreamEndpoint {
+	url := *directStreamURL
+	url.Path += t.extension
+
+	label := t.label
+
+	if resolution != "" {
+		v := url.Query()
+		v.Set("resolution", resolution.String())
+		url.RawQuery = v.Encode()
+
+		switch resolution {
+		case models.StreamingResolutionEnumFourK:
+			label += " 4K (2160p)"
+		case models.StreamingResolutionEnumFullHd:
+			label += " Full HD (1080p)"
+		case models.StreamingResolutionEnumStandardHd:
+			label += " HD (720p)"
+		case models.StreamingResolutionEnumStandard:
+			label += " Standard (480p)"
+		case models.StreamingResolutionEnumLow:
+			label += " Low (240p)"
+		}
+	}
+
+	return &SceneStreamEndpoint{
+		URL:      url.String(),
+		MimeType: &t.mimeType,
+		Label:    &label,
+	}
+}
+
 func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum) ([]*SceneStreamEndpoint, error) {
 	if scene == nil {
 		return nil, fmt.Errorf("nil scene")
@@ -85,63 +154,13 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 		return nil, nil
 	}
 
-	// convert StreamingResolutionEnum to ResolutionEnum
-	maxStreamingResolution := models.ResolutionEnum(maxStreamingTranscodeSize)
 	sceneResolution := models.GetMinResolution(pf)
 	includeSceneStreamPath := func(streamingResolution models.StreamingResolutionEnum) bool {
-		var minResolution int
-		if streamingResolution == models.StreamingResolutionEnumOriginal {
-			minResolution = sceneResolution
-		} else {
-			// convert StreamingResolutionEnum to ResolutionEnum so we can get the min
-			// resolution
-			convertedRes := models.ResolutionEnum(streamingResolution)
-			minResolution = convertedRes.GetMinResolution()
-
-			// don't include if scene resolution is smaller than the streamingResolution
-			if sceneResolution != 0 && sceneResolution < minResolution {
-				return false
-			}
-		}
-
-		// if we always allow everything, then return true
-		if maxStreamingTranscodeSize == models.StreamingResolutionEnumOriginal {
-			return true
-		}
-
-		return maxStreamingResolution.GetMinResolution() >= minResolution
+		return includeSceneStreamPath(sceneResolution, maxStreamingTranscodeSize, streamingResolution)
 	}
 
 	makeStreamEndpoint := func(t endpointType, resolution models.StreamingResolutionEnum) *SceneStreamEndpoint {
-		url := *directStreamURL
-		url.Path += t.extension
-
-		label := t.label
-
-		if resolution != "" {
-			v := url.Query()
-			v.Set("resolution", resolution.String())
-			url.RawQuery = v.Encode()
-
-			switch resolution {
-			case models.StreamingResolutionEnumFourK:
-				label += " 4K (2160p)"
-			case models.StreamingResolutionEnumFullHd:
-				label += " Full HD (1080p)"
-			case models.StreamingResolutionEnumStandardHd:
-				label += " HD (720p)"
-			case models.StreamingResolutionEnumStandard:
-				label += " Standard (480p)"
-			case models.StreamingResolutionEnumLow:
-				label += " Low (240p)"
-			}
-		}
-
-		return &SceneStreamEndpoint{
-			URL:      url.String(),
-			MimeType: &t.mimeType,
-			Label:    &label,
-		}
+		return newStreamEndpoint(directStreamURL, t, resolution)
 	}
 
 	var endpoints []*SceneStreamEndpoint
@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// PlaybackClient is a client with a known stream client profile.
+type PlaybackClient string
+
+const (
+	PlaybackClientChrome  PlaybackClient = "CHROME"
+	PlaybackClientFirefox PlaybackClient = "FIREFOX"
+	PlaybackClientSafari  PlaybackClient = "SAFARI"
+	// PlaybackClientAndroid is an ExoPlayer based Android client.
+	PlaybackClientAndroid PlaybackClient = "ANDROID"
+)
+
+var AllPlaybackClient = []PlaybackClient{
+	PlaybackClientChrome,
+	PlaybackClientFirefox,
+	PlaybackClientSafari,
+	PlaybackClientAndroid,
+}
+
+func (e PlaybackClient) IsValid() bool {
+	return slices.Contains(AllPlaybackClient, e)
+}
+
+func (e PlaybackClient) String() string {
+	return string(e)
+}
+
+func (e *PlaybackClient) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PlaybackClient(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PlaybackClient", str)
+	}
+	return nil
+}
+
+func (e PlaybackClient) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// playbackClientProfiles are the natively supported formats of each client.
+var playbackClientProfiles = map[PlaybackClient]StreamClientProfile{
+	PlaybackClientChrome: {
+		Containers:  []string{string(ffmpeg.Mp4), string(ffmpeg.Webm)},
+		VideoCodecs: []string{ffmpeg.H264, ffmpeg.Hevc, ffmpeg.Vp8, ffmpeg.Vp9, "av1"},
+		AudioCodecs: []string{string(ffmpeg.Aac), string(ffmpeg.Mp3), string(ffmpeg.Opus), string(ffmpeg.Vorbis), "flac"},
+	},
+	PlaybackClientFirefox: {
+		Containers:  []string{string(ffmpeg.Mp4), string(ffmpeg.Webm)},
+		VideoCodecs: []string{ffmpeg.H264, ffmpeg.Vp8, ffmpeg.Vp9, "av1"},
+		AudioCodecs: []string{string(ffmpeg.Aac), string(ffmpeg.Mp3), string(ffmpeg.Opus), string(ffmpeg.Vorbis), "flac"},
+	},
+	PlaybackClientSafari: {
+		Containers:  []string{string(ffmpeg.Mp4), ffmpeg.Hls},
+		VideoCodecs: []string{ffmpeg.H264, ffmpeg.Hevc},
+		AudioCodecs: []string{string(ffmpeg.Aac), string(ffmpeg.Mp3), "flac"},
+	},
+	PlaybackClientAndroid: {
+		Containers:  []string{string(ffmpeg.Mp4), string(ffmpeg.Webm), ffmpeg.Mkv, ffmpeg.Hls, "dash"},
+		VideoCodecs: []string{ffmpeg.H264, ffmpeg.Hevc, ffmpeg.Vp8, ffmpeg.Vp9, "av1"},
+		AudioCodecs: []string{string(ffmpeg.Aac), string(ffmpeg.Mp3), string(ffmpeg.Opus), string(ffmpeg.Vorbis), "flac"},
+	},
+}
+
+// ScenePlaybackHint is the recommended way for a client to play a scene.
+type ScenePlaybackHint struct {
+	Client PlaybackClient `json:"client"`
+	// Stream is the best stream for the client. It is nil if the scene has
+	// no file, or the client cannot play any stream.
+	Stream *SceneStreamEndpoint `json:"stream"`
+	// RemuxSufficient is true if the scene can be played without
+	// re-encoding the video.
+	RemuxSufficient bool `json:"remux_sufficient"`
+	// TranscodeCost is the estimated CPU cost of the stream, or nil if the
+	// stream is not transcoded.
+	TranscodeCost *float64 `json:"transcode_cost"`
+}
+
+// relative cost per pixel of decoding and encoding each video codec, using
+// the encoder settings of live transcodes
+var (
+	decodeCosts = map[string]float64{
+		ffmpeg.H264: 1,
+		ffmpeg.Hevc: 2,
+		ffmpeg.Vp8:  1,
+		ffmpeg.Vp9:  1.5,
+		"av1":       2.5,
+	}
+	encodeCosts = map[string]float64{
+		ffmpeg.H264: 1,
+		ffmpeg.Vp9:  4,
+	}
+)
+
+const (
+	// decodeWeight is the cost of decoding relative to encoding
+	decodeWeight = 0.2
+	// costFrameRate is the frame rate of the baseline transcode
+	costFrameRate = 30
+)
+
+func codecCost(costs map[string]float64, codec string) float64 {
+	if v, ok := costs[normaliseCodec(codec)]; ok {
+		return v
+	}
+	return 1
+}
+
+// pixelsFor returns the number of pixels in a 16:9 frame with the given
+// shorter side.
+func pixelsFor(resolution int) float64 {
+	return float64(resolution) * float64(resolution) * 16 / 9
+}
+
+// transcodeCost estimates the CPU cost of transcoding src to the output in
+// real time, relative to transcoding 1080p 30fps H.264 to H.264. Returns nil
+// if the source resolution is unknown.
+func transcodeCost(src streamSource, frameRate float64, output streamFormat, outputResolution int) *float64 {
+	if src.resolution <= 0 || outputResolution <= 0 {
+		return nil
+	}
+
+	if frameRate <= 0 {
+		frameRate = costFrameRate
+	}
+
+	cost := pixelsFor(src.resolution)*codecCost(decodeCosts, src.format.videoCodec)*decodeWeight +
+		pixelsFor(outputResolution)*codecCost(encodeCosts, output.videoCodec)
+	baseline := pixelsFor(1080) * (decodeWeight + 1)
+
+	ret := math.Round(cost*frameRate/costFrameRate/baseline*100) / 100
+	return &ret
+}
+
+func playbackHint(client PlaybackClient, src streamSource, frameRate float64, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum) *ScenePlaybackHint {
+	ret := &ScenePlaybackHint{
+		Client: client,
+	}
+
+	streams := negotiateStreams(src, directStreamURL, maxStreamingTranscodeSize, playbackClientProfiles[client])
+	if len(streams) == 0 {
+		return ret
+	}
+
+	ret.Stream = streams[0]
+	switch *ret.Stream.Method {
+	case StreamMethodDirect, StreamMethodRemux:
+		ret.RemuxSufficient = true
+	case StreamMethodTranscode:
+		ret.TranscodeCost = transcodeCost(src, frameRate, ret.Stream.format, ret.Stream.resolution)
+	}
+
+	return ret
+}
+
+// GetScenePlaybackHints returns the recommended stream of the scene for
+// each of the clients, or for all known clients if clients is empty.
+func GetScenePlaybackHints(scene *models.Scene, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum, clients []PlaybackClient) ([]*ScenePlaybackHint, error) {
+	if scene == nil {
+		return nil, fmt.Errorf("nil scene")
+	}
+
+	if len(clients) == 0 {
+		clients = AllPlaybackClient
+	}
+
+	pf := scene.Files.Primary()
+
+	var ret []*ScenePlaybackHint
+	for _, c := range clients {
+		if pf == nil {
+			ret = append(ret, &ScenePlaybackHint{Client: c})
+			continue
+		}
+
+		ret = append(ret, playbackHint(c, sceneStreamSource(scene, pf), pf.FrameRate, directStreamURL, maxStreamingTranscodeSize))
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,74 @@
+package manager
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaybackHint(t *testing.T) {
+	streamURL, _ := url.Parse("http://localhost/scene/1/stream")
+
+	tests := []struct {
+		name   string
+		client PlaybackClient
+		src    streamSource
+		// label of the expected stream, with its method
+		want  string
+		remux bool
+		cost  *float64
+	}{
+		{
+			"direct",
+			PlaybackClientFirefox,
+			streamSource{streamFormat{"mp4", "h264", "aac"}, 1080, 0},
+			"DIRECT Direct stream",
+			true,
+			nil,
+		},
+		{
+			"remux",
+			PlaybackClientChrome,
+			streamSource{streamFormat{"matroska", "h264", "aac"}, 1080, 0},
+			"REMUX MP4",
+			true,
+			nil,
+		},
+		{
+			"transcode",
+			PlaybackClientFirefox,
+			streamSource{streamFormat{"mp4", "hevc", "aac"}, 1080, 0},
+			"TRANSCODE MP4",
+			false,
+			// decoding hevc costs twice as much as h264
+			floatPtr(1.17),
+		},
+		{
+			"unknown resolution",
+			PlaybackClientSafari,
+			streamSource{streamFormat{"webm", "vp9", "opus"}, 0, 0},
+			"TRANSCODE MP4",
+			false,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := playbackHint(tt.client, tt.src, 30, streamURL, models.StreamingResolutionEnumOriginal)
+
+			assert.Equal(t, tt.client, got.Client)
+			if assert.NotNil(t, got.Stream) {
+				assert.Equal(t, tt.want, got.Stream.Method.String()+" "+*got.Stream.Label)
+			}
+			assert.Equal(t, tt.remux, got.RemuxSufficient)
+			assert.Equal(t, tt.cost, got.TranscodeCost)
+		})
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
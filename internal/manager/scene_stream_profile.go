@@ -0,0 +1,314 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// StreamMethod is how a stream endpoint produces its output from the source
+// file.
+type StreamMethod string
+
+const (
+	// StreamMethodDirect serves the file as is.
+	StreamMethodDirect StreamMethod = "DIRECT"
+	// StreamMethodRemux copies the video stream into another container.
+	StreamMethodRemux StreamMethod = "REMUX"
+	// StreamMethodTranscode re-encodes the video stream.
+	StreamMethodTranscode StreamMethod = "TRANSCODE"
+)
+
+var AllStreamMethod = []StreamMethod{
+	StreamMethodDirect,
+	StreamMethodRemux,
+	StreamMethodTranscode,
+}
+
+func (e StreamMethod) IsValid() bool {
+	switch e {
+	case StreamMethodDirect, StreamMethodRemux, StreamMethodTranscode:
+		return true
+	}
+	return false
+}
+
+func (e StreamMethod) String() string {
+	return string(e)
+}
+
+func (e *StreamMethod) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StreamMethod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StreamMethod", str)
+	}
+	return nil
+}
+
+func (e StreamMethod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StreamClientProfile describes what a client is able to play.
+type StreamClientProfile struct {
+	// Containers may use mkv for matroska, and hls or dash for the
+	// segmented streams.
+	Containers  []string `json:"containers"`
+	VideoCodecs []string `json:"video_codecs"`
+	AudioCodecs []string `json:"audio_codecs"`
+	// MaxResolution is the maximum size of the shorter side of the video.
+	MaxResolution *int `json:"max_resolution"`
+	// MaxBitrate is in bits per second.
+	MaxBitrate *int `json:"max_bitrate"`
+}
+
+func normaliseContainer(c string) string {
+	c = strings.ToLower(strings.TrimSpace(c))
+	switch c {
+	case ffmpeg.Mkv:
+		return string(ffmpeg.Matroska)
+	// browsers treat these the same as mp4
+	case string(ffmpeg.M4v), string(ffmpeg.Mov):
+		return string(ffmpeg.Mp4)
+	}
+	return c
+}
+
+func normaliseCodec(c string) string {
+	c = strings.ToLower(strings.TrimSpace(c))
+	if c == ffmpeg.H265 {
+		return ffmpeg.Hevc
+	}
+	return c
+}
+
+func profileContains(values []string, v string, normalise func(string) string) bool {
+	v = normalise(v)
+	return slices.ContainsFunc(values, func(s string) bool {
+		return normalise(s) == v
+	})
+}
+
+// streamFormat is the container and codecs of a stream.
+type streamFormat struct {
+	container  string
+	videoCodec string
+	// audioCodec is empty if there is no audio
+	audioCodec string
+}
+
+func (f streamFormat) String() string {
+	ret := f.container + "/" + f.videoCodec
+	if f.audioCodec != "" {
+		ret += "/" + f.audioCodec
+	}
+	return ret
+}
+
+// incompatibilities returns the reasons that the client cannot play a stream
+// of format f with the given resolution and bitrate. Unknown resolution and
+// bitrate values are zero and are not checked.
+func (p StreamClientProfile) incompatibilities(f streamFormat, resolution int, bitrate int64) []string {
+	var ret []string
+
+	if !profileContains(p.Containers, f.container, normaliseContainer) {
+		ret = append(ret, fmt.Sprintf("container %s not supported", f.container))
+	}
+	if !profileContains(p.VideoCodecs, f.videoCodec, normaliseCodec) {
+		ret = append(ret, fmt.Sprintf("video codec %s not supported", f.videoCodec))
+	}
+	if f.audioCodec != "" && !profileContains(p.AudioCodecs, f.audioCodec, normaliseCodec) {
+		ret = append(ret, fmt.Sprintf("audio codec %s not supported", f.audioCodec))
+	}
+	if p.MaxResolution != nil && *p.MaxResolution > 0 && resolution > *p.MaxResolution {
+		ret = append(ret, fmt.Sprintf("resolution %dp exceeds %dp", resolution, *p.MaxResolution))
+	}
+	if p.MaxBitrate != nil && *p.MaxBitrate > 0 && bitrate > int64(*p.MaxBitrate) {
+		ret = append(ret, fmt.Sprintf("bitrate %d exceeds %d", bitrate, *p.MaxBitrate))
+	}
+
+	return ret
+}
+
+type transcodeOutput struct {
+	endpoint endpointType
+	format   streamFormat
+}
+
+// transcodeOutputs are the live transcode endpoints in order of preference.
+var transcodeOutputs = []transcodeOutput{
+	{mp4EndpointType, streamFormat{string(ffmpeg.Mp4), ffmpeg.H264, string(ffmpeg.Aac)}},
+	{hlsEndpointType, streamFormat{ffmpeg.Hls, ffmpeg.H264, string(ffmpeg.Aac)}},
+	{webmEndpointType, streamFormat{string(ffmpeg.Webm), ffmpeg.Vp9, string(ffmpeg.Opus)}},
+	{dashEndpointType, streamFormat{"dash", ffmpeg.Vp9, string(ffmpeg.Opus)}},
+}
+
+// streamSource describes the file served by the direct stream endpoint.
+type streamSource struct {
+	format     streamFormat
+	resolution int
+	bitrate    int64
+}
+
+func newStreamEndpointWithMethod(directStreamURL *url.URL, t endpointType, resolution models.StreamingResolutionEnum, method StreamMethod, reason string, format streamFormat, outputResolution int) *SceneStreamEndpoint {
+	ret := newStreamEndpoint(directStreamURL, t, resolution)
+	ret.Method = &method
+	ret.Reason = &reason
+	ret.format = format
+	ret.resolution = outputResolution
+	return ret
+}
+
+// negotiateStreams returns the endpoints playable by the client, ranked
+// direct first, then remux, then transcode from the highest resolution.
+func negotiateStreams(src streamSource, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum, profile StreamClientProfile) []*SceneStreamEndpoint {
+	var ret []*SceneStreamEndpoint
+
+	directIssues := profile.incompatibilities(src.format, src.resolution, src.bitrate)
+	if len(directIssues) == 0 {
+		reason := fmt.Sprintf("source %s is supported", src.format)
+		ret = append(ret, newStreamEndpointWithMethod(directStreamURL, directEndpointType, "", StreamMethodDirect, reason, src.format, src.resolution))
+	}
+
+	sourceIssue := "source " + src.format.String()
+	if len(directIssues) > 0 {
+		sourceIssue += ": " + strings.Join(directIssues, ", ")
+	}
+
+	// remuxes copy the video stream, so the resolution and bitrate are
+	// those of the source
+	type remux struct {
+		endpoint   endpointType
+		resolution models.StreamingResolutionEnum
+		format     streamFormat
+	}
+	var remuxes []remux
+
+	audioFor := func(codec ffmpeg.ProbeAudioCodec) string {
+		if src.format.audioCodec == "" {
+			return ""
+		}
+		return string(codec)
+	}
+
+	switch normaliseCodec(src.format.videoCodec) {
+	case ffmpeg.H264:
+		remuxes = append(remuxes, remux{mp4EndpointType, models.StreamingResolutionEnumOriginal, streamFormat{string(ffmpeg.Mp4), src.format.videoCodec, audioFor(ffmpeg.Aac)}})
+	case ffmpeg.Vp8, ffmpeg.Vp9:
+		remuxes = append(remuxes, remux{webmEndpointType, models.StreamingResolutionEnumOriginal, streamFormat{string(ffmpeg.Webm), src.format.videoCodec, audioFor(ffmpeg.Opus)}})
+	}
+
+	// the mkv endpoint is only offered for matroska files
+	if src.format.container == string(ffmpeg.Matroska) {
+		remuxes = append(remuxes, remux{mkvEndpointType, "", streamFormat{string(ffmpeg.Matroska), src.format.videoCodec, audioFor(ffmpeg.Opus)}})
+	}
+
+	remuxed := make(map[endpointType]bool)
+	for _, r := range remuxes {
+		if len(profile.incompatibilities(r.format, src.resolution, src.bitrate)) > 0 {
+			continue
+		}
+
+		reason := fmt.Sprintf("video copied to %s; %s", r.format, sourceIssue)
+		ret = append(ret, newStreamEndpointWithMethod(directStreamURL, r.endpoint, r.resolution, StreamMethodRemux, reason, r.format, src.resolution))
+		remuxed[r.endpoint] = true
+	}
+
+	resolutions := []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumOriginal,
+		models.StreamingResolutionEnumFourK,
+		models.StreamingResolutionEnumFullHd,
+		models.StreamingResolutionEnumStandardHd,
+		models.StreamingResolutionEnumStandard,
+		models.StreamingResolutionEnumLow,
+	}
+
+	for _, res := range resolutions {
+		if !includeSceneStreamPath(src.resolution, maxStreamingTranscodeSize, res) {
+			continue
+		}
+
+		resolution := src.resolution
+		if res != models.StreamingResolutionEnumOriginal {
+			resolution = res.GetMaxResolution()
+		}
+
+		for _, o := range transcodeOutputs {
+			// already offered as a remux
+			if res == models.StreamingResolutionEnumOriginal && remuxed[o.endpoint] {
+				continue
+			}
+
+			f := o.format
+			if src.format.audioCodec == "" {
+				f.audioCodec = ""
+			}
+
+			// the transcoded bitrate is not known in advance
+			if len(profile.incompatibilities(f, resolution, 0)) > 0 {
+				continue
+			}
+
+			reason := fmt.Sprintf("transcoded to %s; %s", f, sourceIssue)
+			ret = append(ret, newStreamEndpointWithMethod(directStreamURL, o.endpoint, res, StreamMethodTranscode, reason, f, resolution))
+		}
+	}
+
+	return ret
+}
+
+// NegotiateSceneStreamPaths returns the stream endpoints of the scene that a
+// client with the given profile can play, best first. Each endpoint has the
+// method used to produce it and the reason it was chosen.
+func NegotiateSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum, profile StreamClientProfile) ([]*SceneStreamEndpoint, error) {
+	if scene == nil {
+		return nil, fmt.Errorf("nil scene")
+	}
+
+	pf := scene.Files.Primary()
+	if pf == nil {
+		return nil, nil
+	}
+
+	return negotiateStreams(sceneStreamSource(scene, pf), directStreamURL, maxStreamingTranscodeSize, profile), nil
+}
+
+// sceneStreamSource returns the source served by the direct stream endpoint
+// of the scene with primary file pf.
+func sceneStreamSource(scene *models.Scene, pf *models.VideoFile) streamSource {
+	// don't care if we can't get the container
+	container, _ := GetVideoFileContainer(pf)
+
+	src := streamSource{
+		format: streamFormat{
+			container:  string(container),
+			videoCodec: pf.VideoCodec,
+		},
+		resolution: models.GetMinResolution(pf),
+		bitrate:    pf.BitRate,
+	}
+
+	if ffmpeg.ProbeAudioCodec(pf.AudioCodec) != ffmpeg.MissingUnsupported {
+		src.format.audioCodec = pf.AudioCodec
+	}
+
+	// the direct endpoint serves the generated transcode if present
+	if HasTranscode(scene, config.GetInstance().GetVideoFileNamingAlgorithm()) {
+		src.format = streamFormat{string(ffmpeg.Mp4), ffmpeg.H264, string(ffmpeg.Aac)}
+		src.bitrate = 0
+	}
+
+	return src
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateStreams(t *testing.T) {
+	streamURL, _ := url.Parse("http://localhost/scene/1/stream")
+
+	browser := StreamClientProfile{
+		Containers:  []string{"mp4", "webm", "hls"},
+		VideoCodecs: []string{"h264", "vp9"},
+		AudioCodecs: []string{"aac", "opus"},
+	}
+
+	maxResolution := 720
+	maxBitrate := 4000000
+
+	tests := []struct {
+		name    string
+		src     streamSource
+		profile StreamClientProfile
+		// labels of the expected endpoints in order, with their method
+		want []string
+	}{
+		{
+			"direct",
+			streamSource{streamFormat{"mp4", "h264", "aac"}, 480, 2000000},
+			browser,
+			[]string{
+				"DIRECT Direct stream",
+				"REMUX MP4",
+				"TRANSCODE HLS",
+				"TRANSCODE WEBM",
+				"TRANSCODE MP4 Standard (480p)",
+				"TRANSCODE HLS Standard (480p)",
+				"TRANSCODE WEBM Standard (480p)",
+				"TRANSCODE MP4 Low (240p)",
+				"TRANSCODE HLS Low (240p)",
+				"TRANSCODE WEBM Low (240p)",
+			},
+		},
+		{
+			"remux mkv",
+			streamSource{streamFormat{"matroska", "h264", "aac"}, 240, 0},
+			browser,
+			[]string{
+				"REMUX MP4",
+				"TRANSCODE HLS",
+				"TRANSCODE WEBM",
+				"TRANSCODE MP4 Low (240p)",
+				"TRANSCODE HLS Low (240p)",
+				"TRANSCODE WEBM Low (240p)",
+			},
+		},
+		{
+			"transcode hevc",
+			streamSource{streamFormat{"mp4", "hevc", ""}, 240, 0},
+			StreamClientProfile{
+				Containers:  []string{"mp4"},
+				VideoCodecs: []string{"h264"},
+			},
+			[]string{
+				"TRANSCODE MP4",
+				"TRANSCODE MP4 Low (240p)",
+			},
+		},
+		{
+			"h265 alias",
+			streamSource{streamFormat{"matroska", "hevc", "opus"}, 240, 0},
+			StreamClientProfile{
+				Containers:  []string{"mkv"},
+				VideoCodecs: []string{"h265"},
+				AudioCodecs: []string{"opus"},
+			},
+			[]string{
+				"DIRECT Direct stream",
+				"REMUX MKV",
+			},
+		},
+		{
+			"limits",
+			streamSource{streamFormat{"mp4", "h264", "aac"}, 1080, 8000000},
+			StreamClientProfile{
+				Containers:    []string{"mp4"},
+				VideoCodecs:   []string{"h264"},
+				AudioCodecs:   []string{"aac"},
+				MaxResolution: &maxResolution,
+				MaxBitrate:    &maxBitrate,
+			},
+			[]string{
+				"TRANSCODE MP4 HD (720p)",
+				"TRANSCODE MP4 Standard (480p)",
+				"TRANSCODE MP4 Low (240p)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := negotiateStreams(tt.src, streamURL, models.StreamingResolutionEnumOriginal, tt.profile)
+
+			var labels []string
+			for _, e := range got {
+				if assert.NotNil(t, e.Method) && assert.NotNil(t, e.Reason) {
+					labels = append(labels, e.Method.String()+" "+*e.Label)
+				}
+			}
+
+			assert.Equal(t, tt.want, labels)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var ErrInvalidShareViewToken = errors.New("invalid share view token")
+
+const ShareViewSubject = "ShareView"
+
+// ShareViewTokenDuration is the lifetime of a share view token. It is long
+// enough to cover pausing and resuming playback of long scenes.
+const ShareViewTokenDuration = 12 * time.Hour
+
+type ShareViewClaims struct {
+	ShareLinkID int `json:"lid"`
+	jwt.RegisteredClaims
+}
+
+// GenerateShareViewToken returns a token which grants access to the content
+// of a share link for a single view. The token expires with the share link.
+func GenerateShareViewToken(link *models.ShareLink, now time.Time) (string, error) {
+	expiresAt := now.Add(ShareViewTokenDuration)
+	if link.ExpiresAt != nil && link.ExpiresAt.Before(expiresAt) {
+		expiresAt = *link.ExpiresAt
+	}
+
+	claims := &ShareViewClaims{
+		ShareLinkID: link.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   ShareViewSubject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(config.GetInstance().GetJWTSignKey())
+}
+
+// VerifyShareViewToken returns ErrInvalidShareViewToken if the provided
+// token is not a valid, unexpired view token of the share link.
+func VerifyShareViewToken(link *models.ShareLink, viewToken string) error {
+	claims := &ShareViewClaims{}
+	token, err := jwt.ParseWithClaims(viewToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidShareViewToken
+		}
+		return config.GetInstance().GetJWTSignKey(), nil
+	})
+
+	if err != nil {
+		return ErrInvalidShareViewToken
+	}
+
+	if !token.Valid || claims.Subject != ShareViewSubject || claims.ExpiresAt == nil || claims.ShareLinkID != link.ID {
+		return ErrInvalidShareViewToken
+	}
+
+	return nil
+}
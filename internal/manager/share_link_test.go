@@ -0,0 +1,40 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareViewToken(t *testing.T) {
+	c := config.InitializeEmpty()
+	c.SetString(config.JWTSignKey, "test-sign-key")
+
+	now := time.Now()
+	link := &models.ShareLink{ID: 3}
+
+	token, err := GenerateShareViewToken(link, now)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, VerifyShareViewToken(link, token))
+
+	// tokens are only valid for the link they were issued for
+	assert.ErrorIs(t, VerifyShareViewToken(&models.ShareLink{ID: 4}, token), ErrInvalidShareViewToken)
+
+	// tokens expire with the share link
+	expiresAt := now.Add(-time.Minute)
+	expiring := &models.ShareLink{ID: 3, ExpiresAt: &expiresAt}
+	token, err = GenerateShareViewToken(expiring, now)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, VerifyShareViewToken(expiring, token), ErrInvalidShareViewToken)
+
+	// api keys are signed with the same key, but must not be accepted
+	apiKey, err := GenerateAPIKey("user")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, VerifyShareViewToken(link, apiKey), ErrInvalidShareViewToken)
+}
@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+type StudioHierarchyInput struct {
+	// Paths to infer the hierarchy from, null for all files
+	Paths []string `json:"paths"`
+}
+
+// studioHierarchyDirs returns the directories of the scene files in paths,
+// relative to their library paths.
+func studioHierarchyDirs(ctx context.Context, r models.Repository, paths []string) ([]string, error) {
+	stashPaths := config.GetInstance().GetStashPaths()
+
+	const batchSize = 1000
+	findFilter := models.BatchFindFilter(batchSize)
+	sceneFilter := scene.FilterFromPaths(paths)
+
+	var ret []string
+	seen := make(map[string]bool)
+
+	more := true
+	for more {
+		scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+		if err != nil {
+			return nil, fmt.Errorf("querying scenes: %w", err)
+		}
+
+		for _, s := range scenes {
+			dir := filepath.Dir(s.Path)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+
+			stash := stashPaths.GetStashFromDirPath(dir)
+			if stash == nil {
+				continue
+			}
+
+			rel, err := filepath.Rel(stash.Path, dir)
+			if err != nil {
+				continue
+			}
+
+			ret = append(ret, rel)
+		}
+
+		if len(scenes) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return ret, nil
+}
+
+// PlanStudioHierarchy returns the studio changes inferred from the directory
+// structure of the scene files in the input paths, without applying them.
+// Must be called within a read transaction.
+func PlanStudioHierarchy(ctx context.Context, r models.Repository, input StudioHierarchyInput) ([]studio.HierarchyChange, error) {
+	dirs, err := studioHierarchyDirs(ctx, r, input.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return studio.PlanHierarchy(ctx, r.Studio, dirs)
+}
@@ -7,9 +7,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -184,6 +186,13 @@ func (j *CleanGeneratedJob) Execute(ctx context.Context, progress *job.Progress)
 		return nil
 	}
 
+	// remove the scene folders that are now empty
+	if exists, _ := fsutil.DirExists(j.Paths.Generated.Scenes); exists && j.Paths.Generated.Layout == paths.LayoutScene && !j.Options.DryRun {
+		if err := fsutil.RemoveEmptyDirs(j.Paths.Generated.Scenes); err != nil {
+			logger.Warnf("error removing empty scene folders: %v", err)
+		}
+	}
+
 	logger.Infof("Finished cleaning generated files")
 	return nil
 }
@@ -335,89 +344,111 @@ const (
 	oshashLength = 16
 )
 
-func (j *CleanGeneratedJob) hashPatternPrefix() string {
-	hashLen := oshashLength
+func (j *CleanGeneratedJob) hashLength() int {
 	if j.VideoFileNamingAlgorithm == models.HashAlgorithmMd5 {
-		hashLen = md5Length
+		return md5Length
 	}
 
-	return fmt.Sprintf("%%%dx", hashLen)
+	return oshashLength
 }
 
-func (j *CleanGeneratedJob) getSpriteFileHash(basename string) (string, error) {
-	patternPrefix := j.hashPatternPrefix()
-	spritePattern := patternPrefix + "_sprite.jpg"
+func (j *CleanGeneratedJob) hashPatternPrefix() string {
+	return fmt.Sprintf("%%%dx", j.hashLength())
+}
 
-	var hash string
-	_, err := fmt.Sscanf(basename, spritePattern, &hash)
-	if err != nil {
-		// also try thumbs
-		thumbPattern := patternPrefix + "_thumbs.vtt"
-		_, err = fmt.Sscanf(basename, thumbPattern, &hash)
+// errSkipFile is returned by the scene file hash functions for files that
+// are not of the type being cleaned.
+var errSkipFile = errors.New("skip file")
 
-		if err != nil {
-			return "", err
-		}
+// baseNameHash returns a scene file hash function that gets the hash from
+// the base name of the file.
+func baseNameHash(getHash func(basename string) (string, error)) func(path string) (string, error) {
+	return func(path string) (string, error) {
+		return getHash(filepath.Base(path))
 	}
-
-	return fmt.Sprintf("%x", hash), nil
 }
 
-func (j *CleanGeneratedJob) cleanSpriteFiles(ctx context.Context, progress *job.Progress) error {
-	if job.IsCancelled(ctx) {
-		return nil
+// getSceneFolderHash returns the hash of the scene of a scene folder in the
+// scene layout.
+func (j *CleanGeneratedJob) getSceneFolderHash(path string) (string, error) {
+	hash, err := j.getMarkerSceneFileHash(filepath.Base(path))
+	if err != nil {
+		return "", err
 	}
 
-	logger.Infof("Cleaning sprite files")
+	if len(hash) != j.hashLength() || j.Paths.Generated.GetSceneFolderPath(hash) != path {
+		return "", fmt.Errorf("%s is not a scene folder", path)
+	}
 
-	// walk through the sprite directory
-	if err := filepath.Walk(j.Paths.Generated.Vtt, func(path string, info fs.FileInfo, err error) error {
+	return hash, nil
+}
+
+// sceneFolderFileHash returns a scene file hash function for the scene
+// layout, which gets the hash from the scene folder of the file. names
+// returns the names of the files of the type being cleaned.
+func (j *CleanGeneratedJob) sceneFolderFileHash(names func(hash string) []string) func(path string) (string, error) {
+	return func(path string) (string, error) {
+		hash, err := j.getSceneFolderHash(filepath.Dir(path))
 		if err != nil {
-			return err
+			// files of marker folders
+			return "", errSkipFile
 		}
 
-		if err = ctx.Err(); err != nil {
-			return err
+		if !slices.Contains(names(hash), filepath.Base(path)) {
+			return "", errSkipFile
 		}
 
-		if info.IsDir() {
-			return nil
-		}
+		return hash, nil
+	}
+}
 
-		filename := info.Name()
+// sceneFilesRoot returns the directory that contains the scene files of
+// the given directory in the current layout.
+func (j *CleanGeneratedJob) sceneFilesRoot(dir string) string {
+	if j.Paths.Generated.Layout == paths.LayoutScene {
+		return j.Paths.Generated.Scenes
+	}
 
-		hash, err := j.getSpriteFileHash(filename)
-		if err != nil {
-			logger.Warnf("Ignoring unknown sprite file: %s", filename)
-			return nil
-		}
+	return dir
+}
 
-		j.setProgressFromFilename(hash[0:2], progress)
+func (j *CleanGeneratedJob) getSpriteFileHash(basename string) (string, error) {
+	patternPrefix := j.hashPatternPrefix()
+	spritePattern := patternPrefix + "_sprite.jpg"
 
-		var exists []*models.Scene
+	var hash string
+	_, err := fmt.Sscanf(basename, spritePattern, &hash)
+	if err != nil {
+		// also try thumbs
+		thumbPattern := patternPrefix + "_thumbs.vtt"
+		_, err = fmt.Sscanf(basename, thumbPattern, &hash)
+	}
 
-		if err := j.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
-			exists, err = j.getScenesWithHash(ctx, hash)
-			return err
-		}); err != nil {
-			logger.Errorf("error checking scene entry for sprite: %v", err)
-			return nil
-		}
+	if err != nil {
+		// also try barcodes, which are stored alongside sprites
+		barcodePattern := patternPrefix + "_barcode.png"
+		_, err = fmt.Sscanf(basename, barcodePattern, &hash)
 
-		if len(exists) == 0 {
-			j.logDelete("deleting unused sprite file: %s", filename)
-			j.deleteFile(path)
+		if err != nil {
+			return "", err
 		}
+	}
 
-		return nil
-	}); err != nil {
-		return err
+	return fmt.Sprintf("%x", hash), nil
+}
+
+func (j *CleanGeneratedJob) cleanSpriteFiles(ctx context.Context, progress *job.Progress) error {
+	getHash := baseNameHash(j.getSpriteFileHash)
+	if j.Paths.Generated.Layout == paths.LayoutScene {
+		getHash = j.sceneFolderFileHash(func(hash string) []string {
+			return []string{hash + "_sprite.jpg", hash + "_thumbs.vtt", "barcode.png"}
+		})
 	}
 
-	return nil
+	return j.cleanSceneFiles(ctx, j.sceneFilesRoot(j.Paths.Generated.Vtt), "sprite", getHash, progress)
 }
 
-func (j *CleanGeneratedJob) cleanSceneFiles(ctx context.Context, path string, typ string, getSceneFileHash func(filename string) (string, error), progress *job.Progress) error {
+func (j *CleanGeneratedJob) cleanSceneFiles(ctx context.Context, path string, typ string, getSceneFileHash func(path string) (string, error), progress *job.Progress) error {
 	if job.IsCancelled(ctx) {
 		return nil
 	}
@@ -439,7 +470,10 @@ func (j *CleanGeneratedJob) cleanSceneFiles(ctx context.Context, path string, ty
 		}
 
 		filename := info.Name()
-		hash, err := getSceneFileHash(filename)
+		hash, err := getSceneFileHash(path)
+		if errors.Is(err, errSkipFile) {
+			return nil
+		}
 		if err != nil {
 			logger.Warnf("Ignoring unknown %s file: %s", typ, filename)
 			return nil
@@ -483,7 +517,14 @@ func (j *CleanGeneratedJob) getScreenshotFileHash(basename string) (string, erro
 }
 
 func (j *CleanGeneratedJob) cleanScreenshotFiles(ctx context.Context, progress *job.Progress) error {
-	return j.cleanSceneFiles(ctx, j.Paths.Generated.Screenshots, "screenshot", j.getScreenshotFileHash, progress)
+	getHash := baseNameHash(j.getScreenshotFileHash)
+	if j.Paths.Generated.Layout == paths.LayoutScene {
+		getHash = j.sceneFolderFileHash(func(string) []string {
+			return []string{"preview.mp4", "preview.webp"}
+		})
+	}
+
+	return j.cleanSceneFiles(ctx, j.sceneFilesRoot(j.Paths.Generated.Screenshots), "screenshot", getHash, progress)
 }
 
 func (j *CleanGeneratedJob) getTranscodeFileHash(basename string) (string, error) {
@@ -497,7 +538,14 @@ func (j *CleanGeneratedJob) getTranscodeFileHash(basename string) (string, error
 }
 
 func (j *CleanGeneratedJob) cleanTranscodeFiles(ctx context.Context, progress *job.Progress) error {
-	return j.cleanSceneFiles(ctx, j.Paths.Generated.Transcodes, "transcode", j.getTranscodeFileHash, progress)
+	getHash := baseNameHash(j.getTranscodeFileHash)
+	if j.Paths.Generated.Layout == paths.LayoutScene {
+		getHash = j.sceneFolderFileHash(func(string) []string {
+			return []string{"transcode.mp4"}
+		})
+	}
+
+	return j.cleanSceneFiles(ctx, j.sceneFilesRoot(j.Paths.Generated.Transcodes), "transcode", getHash, progress)
 }
 
 func (j *CleanGeneratedJob) getMarkerSceneFileHash(basename string) (string, error) {
@@ -510,6 +558,26 @@ func (j *CleanGeneratedJob) getMarkerSceneFileHash(basename string) (string, err
 	return fmt.Sprintf("%x", hash), nil
 }
 
+// getMarkerFolderHash returns the hash of the scene of a marker folder.
+func (j *CleanGeneratedJob) getMarkerFolderHash(path string) (string, error) {
+	name := filepath.Base(path)
+	if j.Paths.Generated.Layout == paths.LayoutScene {
+		// markers are in a subfolder of the scene folder
+		name = filepath.Base(filepath.Dir(path))
+	}
+
+	hash, err := j.getMarkerSceneFileHash(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(hash) != j.hashLength() || j.Paths.SceneMarkers.GetFolderPath(hash) != path {
+		return "", fmt.Errorf("%s is not a marker folder", path)
+	}
+
+	return hash, nil
+}
+
 func (j *CleanGeneratedJob) getMarkerFileSeconds(basename string) (int, error) {
 	var ret int
 	var ext string
@@ -533,8 +601,11 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 	var sceneHash string
 	var markers []*models.SceneMarker
 
+	isScene := j.Paths.Generated.Layout == paths.LayoutScene
+	root := j.sceneFilesRoot(j.Paths.Generated.Markers)
+
 	// walk through the markers directory
-	if err := filepath.Walk(j.Paths.Generated.Markers, func(path string, info fs.FileInfo, err error) error {
+	if err := filepath.Walk(root, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -545,20 +616,18 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 
 		if info.IsDir() {
 			// ignore markers directory
-			if path == j.Paths.Generated.Markers {
+			if path == root || j.Paths.Generated.IsShardDir(root, path) {
 				return nil
 			}
 
 			markers = nil
 
-			if filepath.Dir(path) != j.Paths.Generated.Markers {
-				logger.Warnf("Ignoring unknown marker directory: %s", path)
-				return nil
-			}
-
-			sceneHash, err = j.getMarkerSceneFileHash(info.Name())
+			sceneHash, err = j.getMarkerFolderHash(path)
 			if err != nil {
-				logger.Warnf("Ignoring unknown marker directory: %s", path)
+				// scene folders contain the marker folders in the scene layout
+				if !isScene {
+					logger.Warnf("Ignoring unknown marker directory: %s", path)
+				}
 				return nil
 			}
 
@@ -594,6 +663,11 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 			return nil
 		}
 
+		// other generated files are stored in the scene folder in the scene layout
+		if isScene && filepath.Base(filepath.Dir(path)) != "markers" {
+			return nil
+		}
+
 		filename := info.Name()
 		seconds, err := j.getMarkerFileSeconds(filename)
 		if err != nil {
@@ -602,9 +676,8 @@ func (j *CleanGeneratedJob) cleanMarkerFiles(ctx context.Context, progress *job.
 		}
 
 		// scenes should be set by the directory walk
-		hash := filepath.Base(filepath.Dir(path))
-		if hash != sceneHash {
-			logger.Errorf("internal error: scene hash mismatch: %s != %s", hash, sceneHash)
+		if sceneHash == "" || filepath.Dir(path) != j.Paths.SceneMarkers.GetFolderPath(sceneHash) {
+			logger.Errorf("internal error: scene hash mismatch: %s != %s", filepath.Dir(path), sceneHash)
 			return nil
 		}
 
@@ -0,0 +1,112 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type GeneratedLayoutSetter interface {
+	// SetGeneratedLayout persists the layout and starts using it.
+	SetGeneratedLayout(layout paths.Layout) error
+}
+
+// MigrateGeneratedLayoutJob moves the generated files of all scenes from the
+// paths of From to the paths of To. The layout of To is set once all files
+// have been moved.
+type MigrateGeneratedLayoutJob struct {
+	From *paths.Paths
+	To   *paths.Paths
+
+	VideoFileNamingAlgorithm models.HashAlgorithm
+
+	TxnManager   txn.Manager
+	SceneReader  models.SceneReader
+	LayoutSetter GeneratedLayoutSetter
+}
+
+func (j *MigrateGeneratedLayoutJob) Execute(ctx context.Context, progress *job.Progress) error {
+	layout := j.To.Generated.Layout
+
+	var (
+		hashes []string
+		err    error
+	)
+	progress.ExecuteTask("Finding scenes", func() {
+		hashes, err = j.getSceneHashes(ctx)
+		progress.SetTotal(len(hashes))
+	})
+
+	if err != nil {
+		return fmt.Errorf("error finding scenes: %w", err)
+	}
+
+	logger.Infof("Migrating generated files of %d scenes to the %s layout", len(hashes), layout)
+
+	failed := 0
+	for _, hash := range hashes {
+		if job.IsCancelled(ctx) {
+			logger.Info("Cancelled migrating generated layout. The layout has not been changed")
+			return nil
+		}
+
+		if err := scene.MigrateLayout(j.From, j.To, hash); err != nil {
+			logger.Errorf("error migrating generated files of %s: %v", hash, err)
+			failed++
+		}
+
+		progress.Increment()
+	}
+
+	// remove the directories left empty by the old layout
+	g := j.From.Generated
+	for _, dir := range []string{g.Screenshots, g.Vtt, g.Markers, g.Transcodes, g.InteractiveHeatmap, g.Heatmaps, g.Scenes} {
+		if exists, _ := fsutil.DirExists(dir); !exists {
+			continue
+		}
+
+		if err := fsutil.RemoveEmptyDirs(dir); err != nil {
+			logger.Warnf("error removing empty directories of %s: %v", dir, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("generated files of %d scenes could not be migrated; the layout has not been changed", failed)
+	}
+
+	if err := j.LayoutSetter.SetGeneratedLayout(layout); err != nil {
+		return fmt.Errorf("error setting generated layout: %w", err)
+	}
+
+	logger.Infof("Finished migrating generated files to the %s layout", layout)
+	return nil
+}
+
+func (j *MigrateGeneratedLayoutJob) getSceneHashes(ctx context.Context) ([]string, error) {
+	var ret []string
+	if err := txn.WithReadTxn(ctx, j.TxnManager, func(ctx context.Context) error {
+		scenes, err := j.SceneReader.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			if hash := s.GetHash(j.VideoFileNamingAlgorithm); hash != "" {
+				ret = append(ret, hash)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -16,10 +16,12 @@ import (
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/studio"
 )
 
 type autoTagJob struct {
 	repository models.Repository
+	artifacts  *job.ArtifactStore
 	input      AutoTagMetadataInput
 
 	cache match.Cache
@@ -28,7 +30,14 @@ type autoTagJob struct {
 func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) error {
 	begin := time.Now()
 
+	var recorder autotag.Recorder
+	ctx = autotag.WithRecorder(ctx, &recorder)
+
 	input := j.input
+	if input.InferStudioHierarchy {
+		j.inferStudioHierarchy(ctx)
+	}
+
 	if j.isFileBasedAutoTag(input) {
 		// doing file-based auto-tag
 		j.autoTagFiles(ctx, progress, input.Paths, len(input.Performers) > 0, len(input.Studios) > 0, len(input.Tags) > 0)
@@ -37,10 +46,54 @@ func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) error
 		j.autoTagSpecific(ctx, progress)
 	}
 
+	if err := j.writeArtifact(progress, recorder.Changes()); err != nil {
+		logger.Warnf("error writing auto-tag report: %v", err)
+	}
+
 	logger.Infof("Finished auto-tag after %s", time.Since(begin).String())
 	return nil
 }
 
+// writeArtifact writes the changes made by auto-tag as a CSV artifact of the
+// job.
+func (j *autoTagJob) writeArtifact(progress *job.Progress, changes []autotag.Change) error {
+	if j.artifacts == nil || len(changes) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		rows[i] = []string{c.Type, strconv.Itoa(c.ID), c.Name, c.AddedType, strconv.Itoa(c.AddedID), c.AddedName}
+	}
+
+	header := []string{"type", "id", "name", "added_type", "added_id", "added_name"}
+	return j.artifacts.WriteCSV(progress, "autotag", header, rows)
+}
+
+func (j *autoTagJob) inferStudioHierarchy(ctx context.Context) {
+	if job.IsCancelled(ctx) {
+		return
+	}
+
+	logger.Info("Inferring studio hierarchy...")
+
+	r := j.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		changes, err := PlanStudioHierarchy(ctx, r, StudioHierarchyInput{
+			Paths: j.input.Paths,
+		})
+		if err != nil {
+			return err
+		}
+
+		return studio.ApplyHierarchy(ctx, r.Studio, changes)
+	}); err != nil {
+		if !job.IsCancelled(ctx) {
+			logger.Errorf("error inferring studio hierarchy: %v", err)
+		}
+	}
+}
+
 func (j *autoTagJob) isFileBasedAutoTag(input AutoTagMetadataInput) bool {
 	const wildcard = "*"
 	performerIds := input.Performers
@@ -278,7 +331,7 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 						return fmt.Errorf("getting studio aliases: %w", err)
 					}
 
-					if err := tagger.StudioScenes(ctx, studio, paths, aliases, r.Scene); err != nil {
+					if err := tagger.StudioScenes(ctx, studio, paths, aliases, r.Scene, r.Studio); err != nil {
 						return fmt.Errorf("processing scenes: %w", err)
 					}
 					if err := tagger.StudioImages(ctx, studio, paths, aliases, r.Image); err != nil {
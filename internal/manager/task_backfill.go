@@ -0,0 +1,287 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sysload"
+)
+
+const (
+	backfillCheckInterval = time.Minute
+	// CPU usage is measured over this interval before each scene is
+	// generated, so that it does not include the previous scene
+	backfillCPUSampleInterval = 5 * time.Second
+	// after all scenes have been checked, new scenes are not looked for
+	// until this interval has passed
+	backfillRescanInterval = time.Hour
+	backfillBatchSize      = 100
+)
+
+// TimeWindow is a daily time window. The window wraps around midnight if
+// its end is before its start.
+type TimeWindow struct {
+	// offsets from midnight
+	start time.Duration
+	end   time.Duration
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: must be HH:MM", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseTimeWindow parses a time window in the form HH:MM-HH:MM. Returns nil
+// if s is empty.
+func ParseTimeWindow(s string) (*TimeWindow, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid time window %q: must be HH:MM-HH:MM", s)
+	}
+
+	start, err := parseTimeOfDay(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if start == end {
+		return nil, fmt.Errorf("invalid time window %q: start and end must differ", s)
+	}
+
+	return &TimeWindow{start: start, end: end}, nil
+}
+
+// Contains returns true if the time of day of t is within the window.
+func (w TimeWindow) Contains(t time.Time) bool {
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+
+	return offset >= w.start || offset < w.end
+}
+
+// backfillWorker generates missing phashes, sprites and previews one scene
+// at a time while the system is idle, so that large libraries do not need
+// a single long generate task.
+type backfillWorker struct {
+	manager *Manager
+
+	// only accessed from the worker goroutine
+	// ID of the last scene that was checked
+	cursor   int
+	nextPass time.Time
+}
+
+func (w *backfillWorker) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(backfillCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.check(ctx)
+			}
+		}
+	}()
+}
+
+func (w *backfillWorker) check(ctx context.Context) {
+	mgr := w.manager
+	cfg := mgr.Config
+
+	if !cfg.GetBackfillEnabled() || cfg.IsNewSystem() || mgr.Database.Ready() != nil {
+		return
+	}
+
+	if time.Now().Before(w.nextPass) {
+		return
+	}
+
+	input := GenerateMetadataInput{
+		Phashes:  cfg.GetBackfillPhashes(),
+		Sprites:  cfg.GetBackfillSprites(),
+		Previews: cfg.GetBackfillPreviews(),
+	}
+	if !input.Phashes && !input.Sprites && !input.Previews {
+		return
+	}
+
+	if err := mgr.Paths.Generated.EnsureTmpDir(); err != nil {
+		logger.Warnf("could not create temporary directory: %v", err)
+	}
+
+	for ctx.Err() == nil {
+		if reason := w.busyReason(ctx); reason != "" {
+			logger.Tracef("Background generation paused: %s", reason)
+			return
+		}
+
+		tasks, err := w.nextTasks(ctx, input)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Error finding scenes for background generation: %v", err)
+			}
+			return
+		}
+
+		if len(tasks) == 0 {
+			logger.Debugf("Background generation: all scenes checked")
+			w.cursor = 0
+			w.nextPass = time.Now().Add(backfillRescanInterval)
+			return
+		}
+
+		for _, t := range tasks {
+			if err := checkReservedDiskSpace(cfg.GetGeneratedPath()); err != nil {
+				logger.Warnf("Background generation paused: %v", err)
+				return
+			}
+
+			logger.Debugf("Background generation: %s", t.GetDescription())
+			t.Start(ctx)
+		}
+	}
+}
+
+// busyReason returns the reason that the system is not idle, or an empty
+// string if background generation may run.
+func (w *backfillWorker) busyReason(ctx context.Context) string {
+	mgr := w.manager
+	cfg := mgr.Config
+
+	window, err := ParseTimeWindow(cfg.GetBackfillWindow())
+	if err != nil {
+		return err.Error()
+	}
+	if window != nil && !window.Contains(time.Now()) {
+		return "outside of time window"
+	}
+
+	if len(mgr.JobManager.GetQueue()) > 0 {
+		return "jobs are running"
+	}
+
+	if maxTemp := cfg.GetBackfillMaxTemperature(); maxTemp > 0 {
+		temp, err := sysload.MaxTemperature()
+		switch {
+		case errors.Is(err, sysload.ErrUnsupported):
+		case err != nil:
+			logger.Warnf("Error reading system temperature: %v", err)
+		case temp > float64(maxTemp):
+			return fmt.Sprintf("temperature %.0f°C is above %d°C", temp, maxTemp)
+		}
+	}
+
+	maxCPU := cfg.GetBackfillMaxCPUPercent()
+	cpu, err := sysload.CPUPercent(ctx, backfillCPUSampleInterval)
+	switch {
+	case errors.Is(err, sysload.ErrUnsupported):
+	case err != nil:
+		if ctx.Err() != nil {
+			return ctx.Err().Error()
+		}
+		logger.Warnf("Error reading CPU usage: %v", err)
+	case cpu > float64(maxCPU):
+		return fmt.Sprintf("CPU usage %.0f%% is above %d%%", cpu, maxCPU)
+	}
+
+	return ""
+}
+
+// nextTasks returns the generate tasks of the next scene after the cursor
+// that is missing generated content, and advances the cursor to it. Returns
+// no tasks if there are no more scenes.
+func (w *backfillWorker) nextTasks(ctx context.Context, input GenerateMetadataInput) ([]Task, error) {
+	mgr := w.manager
+	r := mgr.Repository
+
+	j := &GenerateJob{
+		repository:     r,
+		input:          input,
+		fileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
+	}
+
+	g := &generate.Generator{
+		Encoder:      mgr.FFMpeg,
+		FFMpegConfig: mgr.Config,
+		LockManager:  mgr.ReadLockManager,
+		MarkerPaths:  mgr.Paths.SceneMarkers,
+		ScenePaths:   mgr.Paths.Scene,
+	}
+
+	var ret []Task
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		for ctx.Err() == nil {
+			sceneFilter := &models.SceneFilterType{
+				ID: &models.IntCriterionInput{
+					Value:    w.cursor,
+					Modifier: models.CriterionModifierGreaterThan,
+				},
+			}
+			findFilter := models.BatchFindFilter(backfillBatchSize)
+			sort := "id"
+			direction := models.SortDirectionEnumAsc
+			findFilter.Sort = &sort
+			findFilter.Direction = &direction
+
+			scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				w.cursor = s.ID
+
+				if err := s.LoadFiles(ctx, r.Scene); err != nil {
+					return err
+				}
+
+				queue := make(chan Task)
+				go func() {
+					defer close(queue)
+					j.queueSceneJobs(ctx, g, s, queue)
+				}()
+
+				for t := range queue {
+					ret = append(ret, t)
+				}
+
+				if len(ret) > 0 {
+					return nil
+				}
+			}
+
+			if len(scenes) < backfillBatchSize {
+				return nil
+			}
+		}
+
+		return ctx.Err()
+	})
+
+	return ret, err
+}
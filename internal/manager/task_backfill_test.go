@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	w, err := ParseTimeWindow("")
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	for _, s := range []string{"01:00", "25:00-02:00", "01:00-1am", "02:00-02:00"} {
+		_, err := ParseTimeWindow(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTimeWindowContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		window string
+		t      time.Time
+		want   bool
+	}{
+		{"01:00-06:00", at(0, 59), false},
+		{"01:00-06:00", at(1, 0), true},
+		{"01:00-06:00", at(5, 59), true},
+		{"01:00-06:00", at(6, 0), false},
+		// wraps around midnight
+		{"22:30-06:00", at(22, 29), false},
+		{"22:30-06:00", at(23, 0), true},
+		{"22:30-06:00", at(3, 0), true},
+		{"22:30-06:00", at(6, 0), false},
+	}
+
+	for _, tt := range tests {
+		w, err := ParseTimeWindow(tt.window)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		assert.Equal(t, tt.want, w.Contains(tt.t), "%s at %s", tt.window, tt.t.Format("15:04"))
+	}
+}
@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/stashapp/stash/pkg/backup"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+const (
+	// backupScheduleCheckInterval is the interval at which the schedule is
+	// checked for a due backup.
+	backupScheduleCheckInterval = time.Minute
+
+	backupUploadTimeout = time.Hour
+)
+
+// BackupDatabaseJob backs up the database into a directory, verifies the
+// integrity of the backup, removes old backups and uploads the backup to an
+// off-site target.
+type BackupDatabaseJob struct {
+	Database  *sqlite.Database
+	Directory string
+	// Retention is the number of backups kept in the directory. All
+	// backups are kept if zero.
+	Retention int
+	// Target is the off-site target. Not uploaded if nil.
+	Target *backup.Target
+}
+
+func (j *BackupDatabaseJob) Execute(ctx context.Context, progress *job.Progress) error {
+	total := 3
+	if j.Target != nil {
+		total++
+	}
+	progress.SetTotal(total)
+
+	if err := fsutil.EnsureDir(j.Directory); err != nil {
+		return fmt.Errorf("could not create backup directory %v: %w", j.Directory, err)
+	}
+
+	backupPath := j.Database.DatabaseBackupPath(j.Directory)
+
+	var err error
+	progress.ExecuteTask("Backing up database", func() {
+		err = j.Database.Backup(backupPath)
+		progress.Increment()
+	})
+	if err != nil {
+		return fmt.Errorf("error backing up database: %w", err)
+	}
+
+	progress.ExecuteTask("Verifying backup", func() {
+		err = verifyBackup(j.Database, backupPath)
+		progress.Increment()
+	})
+	if err != nil {
+		return err
+	}
+
+	progress.ExecuteTask("Removing old backups", func() {
+		rotateBackups(j.Database, j.Directory, j.Retention)
+		progress.Increment()
+	})
+
+	if j.Target != nil {
+		progress.ExecuteTask("Uploading backup", func() {
+			err = uploadBackup(ctx, *j.Target, backupPath)
+			progress.Increment()
+		})
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error uploading backup: %w", err)
+		}
+	}
+
+	logger.Infof("Finished backing up database into %s", backupPath)
+	return nil
+}
+
+// verifyBackup checks the integrity of the backup, removing it if it is
+// corrupt.
+func verifyBackup(db *sqlite.Database, backupPath string) error {
+	if err := db.VerifyBackup(backupPath); err != nil {
+		if removeErr := os.Remove(backupPath); removeErr != nil {
+			logger.Warnf("error removing corrupt backup %s: %v", backupPath, removeErr)
+		}
+		return fmt.Errorf("error verifying backup: %w", err)
+	}
+
+	return nil
+}
+
+// rotateBackups removes the oldest backups in the directory, keeping the
+// newest keep backups. Does nothing if keep is zero.
+func rotateBackups(db *sqlite.Database, dir string, keep int) {
+	if keep <= 0 {
+		return
+	}
+
+	backups, err := db.Backups(dir)
+	if err != nil {
+		logger.Warnf("error listing backups in %s: %v", dir, err)
+		return
+	}
+
+	if len(backups) <= keep {
+		return
+	}
+
+	for _, p := range backups[:len(backups)-keep] {
+		logger.Infof("Removing old backup %s", p)
+		if err := os.Remove(p); err != nil {
+			logger.Warnf("error removing old backup %s: %v", p, err)
+		}
+	}
+}
+
+func uploadBackup(ctx context.Context, target backup.Target, backupPath string) error {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+		Timeout: backupUploadTimeout,
+	}
+
+	logger.Infof("Uploading backup %s to %s target", backupPath, target.Type)
+	return target.Upload(ctx, client, backupPath)
+}
+
+// lastBackupTime returns the modification time of the newest backup in the
+// directory. Returns the zero time if there are no backups.
+func lastBackupTime(db *sqlite.Database, dir string) time.Time {
+	backups, err := db.Backups(dir)
+	if err != nil || len(backups) == 0 {
+		return time.Time{}
+	}
+
+	info, err := os.Stat(backups[len(backups)-1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+// backupScheduler queues a BackupDatabaseJob when the configured backup
+// interval has elapsed since the last backup.
+type backupScheduler struct {
+	manager *Manager
+	queued  atomic.Bool
+}
+
+func (s *backupScheduler) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(backupScheduleCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.check(ctx)
+			}
+		}
+	}()
+}
+
+func (s *backupScheduler) check(ctx context.Context) {
+	mgr := s.manager
+	cfg := mgr.Config
+
+	interval := cfg.GetBackupInterval()
+	if interval <= 0 || cfg.IsNewSystem() || mgr.Database.Ready() != nil {
+		return
+	}
+
+	dir := cfg.GetBackupDirectoryPathOrDefault()
+	if time.Since(lastBackupTime(mgr.Database, dir)) < interval {
+		return
+	}
+
+	// don't queue another backup while one is pending
+	if !s.queued.CompareAndSwap(false, true) {
+		return
+	}
+
+	j := &BackupDatabaseJob{
+		Database:  mgr.Database,
+		Directory: dir,
+		Retention: cfg.GetBackupRetention(),
+		Target:    cfg.GetBackupTarget(),
+	}
+
+	mgr.JobManager.Add(ctx, "Backing up database...", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		defer s.queued.Store(false)
+		return j.Execute(ctx, progress)
+	}))
+}
@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type CalculateFingerprintsInput struct {
+	// Paths to calculate fingerprints for. Defaults to all library paths.
+	Paths []string `json:"paths"`
+}
+
+// fileOpener opens a file, including files in zip files.
+type fileOpener struct {
+	fs models.FS
+	f  *models.BaseFile
+}
+
+func (o *fileOpener) Open() (io.ReadCloser, error) {
+	return o.f.Open(o.fs)
+}
+
+// CalculateFingerprintsJob calculates the missing fingerprints of existing
+// files, such as those of newly enabled checksum algorithms, without
+// rescanning the files or their metadata.
+type CalculateFingerprintsJob struct {
+	repository models.Repository
+	fs         models.FS
+	calculator *fingerprintCalculator
+	paths      []string
+}
+
+func (j *CalculateFingerprintsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Info("Calculating missing fingerprints")
+
+	r := j.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		total, err := r.File.CountAllInPaths(ctx, j.paths)
+		if err != nil {
+			return err
+		}
+
+		progress.SetTotal(total)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("counting files: %w", err)
+	}
+
+	const batchSize = 1000
+	updated := 0
+	for offset := 0; ; offset += batchSize {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		var files []models.File
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			files, err = r.File.FindAllInPaths(ctx, j.paths, batchSize, offset)
+			return err
+		}); err != nil {
+			return fmt.Errorf("querying for files: %w", err)
+		}
+
+		for _, f := range files {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			path := f.Base().Path
+			progress.ExecuteTask(fmt.Sprintf("Calculating fingerprints for %s", path), func() {
+				changed, err := j.calculateFile(ctx, f)
+				if err != nil {
+					// not fatal - the file may be missing or unreadable
+					logger.Errorf("error calculating fingerprints for %s: %v", path, err)
+				} else if changed {
+					updated++
+				}
+				progress.Increment()
+			})
+		}
+
+		if len(files) < batchSize {
+			break
+		}
+	}
+
+	logger.Infof("Finished calculating missing fingerprints. Updated %d files", updated)
+	return nil
+}
+
+// calculateFile calculates and stores the missing fingerprints of f,
+// returning true if any were added.
+func (j *CalculateFingerprintsJob) calculateFile(ctx context.Context, f models.File) (bool, error) {
+	base := f.Base()
+	if base.PendingContent {
+		// contents are not downloaded, so can't be read
+		return false, nil
+	}
+
+	const useExisting = true
+	fp, err := j.calculator.CalculateFingerprints(base, &fileOpener{fs: j.fs, f: base}, useExisting)
+	if err != nil {
+		return false, err
+	}
+
+	if !models.Fingerprints(fp).ContentsChanged(base.Fingerprints) {
+		return false, nil
+	}
+
+	if err := j.repository.WithTxn(ctx, func(ctx context.Context) error {
+		return j.repository.File.ModifyFingerprints(ctx, base.ID, fp)
+	}); err != nil {
+		return false, fmt.Errorf("updating fingerprints: %w", err)
+	}
+
+	return true, nil
+}
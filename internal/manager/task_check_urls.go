@@ -0,0 +1,457 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+const (
+	// urlCheckScheduleCheckInterval is the interval at which the schedule is
+	// checked for due url checks.
+	urlCheckScheduleCheckInterval = time.Hour
+
+	// urlCheckHostDelay is the minimum time between requests to the same
+	// host.
+	urlCheckHostDelay = time.Second
+)
+
+type CheckURLsInput struct {
+	// SceneIDs and PerformerIDs are the objects whose urls are checked. All
+	// urls are checked if both are empty.
+	SceneIDs     []string `json:"scene_ids"`
+	PerformerIDs []string `json:"performer_ids"`
+}
+
+// CheckURLs queues a job that checks the urls of the scenes and performers
+// in the input, or all urls if none are provided.
+func (s *Manager) CheckURLs(ctx context.Context, input CheckURLsInput) (int, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: converting scene ids: %v", ErrInput, err)
+	}
+
+	performerIDs, err := stringslice.StringSliceToIntSlice(input.PerformerIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: converting performer ids: %v", ErrInput, err)
+	}
+
+	j := s.newCheckURLsJob()
+
+	if len(sceneIDs) > 0 || len(performerIDs) > 0 {
+		j.urls, err = s.findURLs(ctx, sceneIDs, performerIDs)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		// check every url regardless of when it was last checked
+		j.checkedBefore = time.Now()
+	}
+
+	return s.JobManager.Add(ctx, "Checking urls...", j), nil
+}
+
+func (s *Manager) findURLs(ctx context.Context, sceneIDs []int, performerIDs []int) ([]string, error) {
+	r := s.Repository
+
+	var ret []string
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scenes, err := r.Scene.FindMany(ctx, sceneIDs)
+		if err != nil {
+			return fmt.Errorf("finding scenes: %w", err)
+		}
+
+		for _, sc := range scenes {
+			if err := sc.LoadURLs(ctx, r.Scene); err != nil {
+				return fmt.Errorf("loading scene urls: %w", err)
+			}
+			ret = append(ret, sc.URLs.List()...)
+		}
+
+		performers, err := r.Performer.FindMany(ctx, performerIDs)
+		if err != nil {
+			return fmt.Errorf("finding performers: %w", err)
+		}
+
+		for _, p := range performers {
+			if err := p.LoadURLs(ctx, r.Performer); err != nil {
+				return fmt.Errorf("loading performer urls: %w", err)
+			}
+			ret = append(ret, p.URLs.List()...)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return sliceutil.Unique(ret), nil
+}
+
+func (s *Manager) newCheckURLsJob() *CheckURLsJob {
+	return &CheckURLsJob{
+		repository:    s.Repository,
+		cache:         s.ScraperCache,
+		rescrape:      s.Config.GetURLCheckRescrape(),
+		checkedBefore: time.Now().Add(-s.Config.GetURLCheckInterval()),
+	}
+}
+
+// CheckURLsJob requests scene and performer urls, and stores the status of
+// each url. If rescrape is true, urls with changed content are scraped, and
+// flagged as changed if the scraped metadata differs from the stored
+// metadata.
+type CheckURLsJob struct {
+	repository models.Repository
+	cache      *scraper.Cache
+	rescrape   bool
+
+	// urls are the urls to check. If nil, the urls that have not been
+	// checked since checkedBefore are checked.
+	urls          []string
+	checkedBefore time.Time
+}
+
+func (j *CheckURLsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	urls := j.urls
+	if urls == nil {
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			urls, err = r.URLStatus.FindDue(ctx, j.checkedBefore)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	progress.SetTotal(len(urls))
+	logger.Infof("Checking %d urls", len(urls))
+
+	counts := make(map[models.URLCheckStatus]int)
+	lastRequest := make(map[string]time.Time)
+
+	for _, u := range urls {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask("Checking "+u, func() {
+			j.waitForHost(ctx, u, lastRequest)
+
+			status, err := j.checkURL(ctx, u)
+			if err != nil {
+				logger.Errorf("error checking url %s: %v", u, err)
+				return
+			}
+
+			counts[status.Status]++
+		})
+
+		progress.Increment()
+	}
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.URLStatus.DestroyUnused(ctx)
+	}); err != nil {
+		logger.Warnf("error removing unused url statuses: %v", err)
+	}
+
+	logger.Infof("Finished checking urls: %d ok, %d changed, %d redirected, %d dead, %d errors",
+		counts[models.URLCheckStatusOK], counts[models.URLCheckStatusChanged], counts[models.URLCheckStatusRedirected],
+		counts[models.URLCheckStatusDead], counts[models.URLCheckStatusError])
+	return nil
+}
+
+// waitForHost waits until urlCheckHostDelay has passed since the last
+// request to the host of the url.
+func (j *CheckURLsJob) waitForHost(ctx context.Context, u string, lastRequest map[string]time.Time) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return
+	}
+
+	host := strings.ToLower(parsed.Host)
+	if wait := urlCheckHostDelay - time.Since(lastRequest[host]); wait > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+		}
+	}
+
+	lastRequest[host] = time.Now()
+}
+
+func (j *CheckURLsJob) checkURL(ctx context.Context, u string) (*models.URLStatus, error) {
+	r := j.repository
+
+	var prev *models.URLStatus
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		statuses, err := r.URLStatus.FindByURLs(ctx, []string{u})
+		if err != nil {
+			return err
+		}
+		if len(statuses) > 0 {
+			prev = statuses[0]
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	status := &models.URLStatus{
+		URL:       u,
+		CheckedAt: now,
+	}
+
+	// keep the content of the last successful response, so that a
+	// temporary error is not seen as a change
+	if prev != nil {
+		status.ContentHash = prev.ContentHash
+		status.ChangedAt = prev.ChangedAt
+	}
+
+	res, err := j.cache.CheckURL(ctx, u)
+	if err != nil {
+		status.Status = models.URLCheckStatusError
+		status.Error = err.Error()
+	} else {
+		status.Status = models.URLCheckStatusFromCode(res.StatusCode)
+		status.StatusCode = &res.StatusCode
+		status.RedirectURL = res.RedirectURL
+	}
+
+	if status.Status == models.URLCheckStatusOK {
+		changed := prev != nil && prev.ContentHash != "" && prev.ContentHash != res.ContentHash
+		if changed {
+			status.ChangedAt = &now
+		}
+		status.ContentHash = res.ContentHash
+
+		// keep checking changed urls until the stored metadata is updated
+		wasChanged := prev != nil && prev.Status == models.URLCheckStatusChanged
+		if j.rescrape && (changed || wasChanged) {
+			metadataChanged, err := j.metadataChanged(ctx, u)
+			if err != nil {
+				logger.Warnf("error scraping url %s: %v", u, err)
+				// keep the result of the previous check
+				metadataChanged = wasChanged
+			}
+			if metadataChanged {
+				status.Status = models.URLCheckStatusChanged
+			}
+		}
+	}
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.URLStatus.Upsert(ctx, status)
+	}); err != nil {
+		return nil, err
+	}
+
+	if status.Status != models.URLCheckStatusOK {
+		logger.Infof("url %s: %s", u, status.Status)
+	}
+
+	return status, nil
+}
+
+// metadataChanged scrapes the url, and returns true if the scraped metadata
+// differs from the metadata of a scene or performer with the url.
+func (j *CheckURLsJob) metadataChanged(ctx context.Context, u string) (bool, error) {
+	r := j.repository
+
+	var (
+		scenes     []*models.Scene
+		performers []*models.Performer
+	)
+	urlFilter := &models.StringCriterionInput{
+		Modifier: models.CriterionModifierEquals,
+		Value:    u,
+	}
+	perPage := -1
+	findFilter := &models.FindFilterType{
+		PerPage: &perPage,
+	}
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		result, err := r.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: findFilter,
+			},
+			SceneFilter: &models.SceneFilterType{URL: urlFilter},
+		})
+		if err != nil {
+			return fmt.Errorf("finding scenes: %w", err)
+		}
+
+		scenes, err = r.Scene.FindMany(ctx, result.IDs)
+		if err != nil {
+			return fmt.Errorf("finding scenes: %w", err)
+		}
+
+		performers, _, err = r.Performer.Query(ctx, &models.PerformerFilterType{URL: urlFilter}, findFilter)
+		if err != nil {
+			return fmt.Errorf("finding performers: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if len(scenes) > 0 {
+		content, err := j.cache.ScrapeURL(ctx, u, scraper.ScrapeContentTypeScene)
+		if err != nil {
+			return false, err
+		}
+
+		for _, s := range scenes {
+			if sceneMetadataChanged(s, content) {
+				return true, nil
+			}
+		}
+	}
+
+	if len(performers) > 0 {
+		content, err := j.cache.ScrapeURL(ctx, u, scraper.ScrapeContentTypePerformer)
+		if err != nil {
+			return false, err
+		}
+
+		for _, p := range performers {
+			if performerMetadataChanged(p, content) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// stringChanged returns true if the scraped value is set and differs from
+// the stored value.
+func stringChanged(stored string, scraped *string) bool {
+	return scraped != nil && strings.TrimSpace(*scraped) != "" && strings.TrimSpace(*scraped) != strings.TrimSpace(stored)
+}
+
+// dateChanged returns true if the scraped date is valid and differs from the
+// stored date.
+func dateChanged(stored *models.Date, scraped *string) bool {
+	if scraped == nil {
+		return false
+	}
+
+	d, err := models.ParseDate(*scraped)
+	if err != nil {
+		return false
+	}
+
+	return stored == nil || stored.String() != d.String()
+}
+
+func sceneMetadataChanged(s *models.Scene, content scraper.ScrapedContent) bool {
+	var scraped *scraper.ScrapedScene
+	switch v := content.(type) {
+	case *scraper.ScrapedScene:
+		scraped = v
+	case scraper.ScrapedScene:
+		scraped = &v
+	}
+
+	if scraped == nil {
+		return false
+	}
+
+	return stringChanged(s.Title, scraped.Title) ||
+		stringChanged(s.Code, scraped.Code) ||
+		stringChanged(s.Details, scraped.Details) ||
+		stringChanged(s.Director, scraped.Director) ||
+		dateChanged(s.Date, scraped.Date)
+}
+
+func performerMetadataChanged(p *models.Performer, content scraper.ScrapedContent) bool {
+	var scraped *models.ScrapedPerformer
+	switch v := content.(type) {
+	case *models.ScrapedPerformer:
+		scraped = v
+	case models.ScrapedPerformer:
+		scraped = &v
+	}
+
+	if scraped == nil {
+		return false
+	}
+
+	return stringChanged(p.Name, scraped.Name) ||
+		stringChanged(p.Disambiguation, scraped.Disambiguation) ||
+		stringChanged(p.Details, scraped.Details) ||
+		stringChanged(p.Country, scraped.Country) ||
+		stringChanged(p.Ethnicity, scraped.Ethnicity) ||
+		dateChanged(p.Birthdate, scraped.Birthdate) ||
+		dateChanged(p.DeathDate, scraped.DeathDate)
+}
+
+// urlCheckScheduler queues a CheckURLsJob for the urls that have not been
+// checked within the configured url check interval.
+type urlCheckScheduler struct {
+	manager *Manager
+	queued  atomic.Bool
+
+	// only accessed from the scheduler goroutine
+	lastRun time.Time
+}
+
+func (s *urlCheckScheduler) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(urlCheckScheduleCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.check(ctx)
+			}
+		}
+	}()
+}
+
+func (s *urlCheckScheduler) check(ctx context.Context) {
+	mgr := s.manager
+	cfg := mgr.Config
+
+	interval := cfg.GetURLCheckInterval()
+	if interval <= 0 || cfg.IsNewSystem() || mgr.Database.Ready() != nil {
+		return
+	}
+
+	if time.Since(s.lastRun) < interval {
+		return
+	}
+
+	// don't queue another check while one is pending
+	if !s.queued.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.lastRun = time.Now()
+	j := mgr.newCheckURLsJob()
+	mgr.JobManager.Add(ctx, "Checking urls...", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		defer s.queued.Store(false)
+		return j.Execute(ctx, progress)
+	}))
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+func TestSceneMetadataChanged(t *testing.T) {
+	date, _ := models.ParseDate("2020-01-02")
+	s := &models.Scene{
+		Title:   "Title",
+		Details: "Details",
+		Date:    &date,
+	}
+
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		content scraper.ScrapedContent
+		want    bool
+	}{
+		{"nil content", nil, false},
+		{"same", &scraper.ScrapedScene{Title: str("Title"), Details: str(" Details "), Date: str("2020-01-02")}, false},
+		{"empty fields", &scraper.ScrapedScene{Title: str(""), Code: str("")}, false},
+		{"changed title", &scraper.ScrapedScene{Title: str("New title")}, true},
+		{"new code", scraper.ScrapedScene{Code: str("ABC-123")}, true},
+		{"changed date", &scraper.ScrapedScene{Date: str("2021-01-02")}, true},
+		{"invalid date", &scraper.ScrapedScene{Date: str("not a date")}, false},
+		{"other content", &models.ScrapedPerformer{Name: str("Name")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sceneMetadataChanged(s, tt.content); got != tt.want {
+				t.Errorf("sceneMetadataChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerformerMetadataChanged(t *testing.T) {
+	p := &models.Performer{
+		Name:    "Name",
+		Country: "US",
+	}
+
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		content scraper.ScrapedContent
+		want    bool
+	}{
+		{"same", &models.ScrapedPerformer{Name: str("Name"), Country: str("US")}, false},
+		{"changed country", &models.ScrapedPerformer{Country: str("CA")}, true},
+		{"new birthdate", &models.ScrapedPerformer{Birthdate: str("1990-01-01")}, true},
+		{"scene content", &scraper.ScrapedScene{Title: str("Title")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := performerMetadataChanged(p, tt.content); got != tt.want {
+				t.Errorf("performerMetadataChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -20,13 +21,17 @@ import (
 )
 
 type cleaner interface {
-	Clean(ctx context.Context, options file.CleanOptions, progress *job.Progress)
+	Clean(ctx context.Context, options file.CleanOptions, progress *job.Progress) *file.CleanReport
 }
 
 type cleanJob struct {
 	cleaner      cleaner
 	repository   models.Repository
 	input        CleanMetadataInput
+	rules        []*models.CleanRule
+	confirmed    *CleanReport
+	reports      *cleanReportStore
+	artifacts    *job.ArtifactStore
 	sceneService SceneService
 	imageService ImageService
 	scanSubs     *subscriptionManager
@@ -39,18 +44,43 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		logger.Infof("Running in Dry Mode")
 	}
 
-	j.cleaner.Clean(ctx, file.CleanOptions{
+	options := file.CleanOptions{
 		Paths:      j.input.Paths,
 		DryRun:     j.input.DryRun,
 		PathFilter: newCleanFilter(instance.Config),
-	}, progress)
+		Rules:      j.rules,
+	}
+	if j.confirmed != nil {
+		logger.Infof("Cleaning files confirmed in report %s", j.confirmed.ID)
+		options.Confirmed = j.confirmed.fileReport()
+	}
+
+	fileReport := j.cleaner.Clean(ctx, options, progress)
 
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
 	}
 
-	j.cleanEmptyGalleries(ctx)
+	report := &CleanReport{
+		CreatedAt: start,
+		Paths:     j.input.Paths,
+	}
+	if fileReport != nil {
+		report.addFileReport(fileReport)
+	}
+
+	j.cleanEmptyGalleries(ctx, report)
+
+	if err := j.writeArtifact(progress, report); err != nil {
+		logger.Warnf("error writing clean report: %v", err)
+	}
+
+	if j.input.DryRun {
+		if err := j.storeReport(report); err != nil {
+			return err
+		}
+	}
 
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
@@ -58,7 +88,39 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 	return nil
 }
 
-func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
+func (j *cleanJob) storeReport(report *CleanReport) error {
+	id, err := hash.GenerateRandomKey(8)
+	if err != nil {
+		return fmt.Errorf("generating clean report id: %w", err)
+	}
+
+	report.ID = id
+	j.reports.set(report)
+	logger.Infof("Dry run found %d items to clean. Confirm report %s to clean them", len(report.Items), id)
+
+	return nil
+}
+
+// writeArtifact writes the items of the report as a CSV artifact of the job.
+func (j *cleanJob) writeArtifact(progress *job.Progress, report *CleanReport) error {
+	if j.artifacts == nil {
+		return nil
+	}
+
+	name := "clean"
+	if j.input.DryRun {
+		name = "clean-dry-run"
+	}
+
+	rows := make([][]string, len(report.Items))
+	for i, item := range report.Items {
+		rows[i] = []string{item.Type.String(), item.Path, item.Reason}
+	}
+
+	return j.artifacts.WriteCSV(progress, name, []string{"type", "path", "reason"}, rows)
+}
+
+func (j *cleanJob) cleanEmptyGalleries(ctx context.Context, report *CleanReport) {
 	const batchSize = 1000
 	var toClean []int
 	findFilter := models.BatchFindFilter(batchSize)
@@ -88,8 +150,18 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 					continue
 				}
 
+				if j.confirmed != nil && !j.confirmed.containsGallery(g.Path) {
+					logger.Infof("Not in confirmed clean report, skipping: %s", g.DisplayName())
+					continue
+				}
+
 				logger.Infof("Gallery has 0 images. Marking to clean: %s", g.DisplayName())
 				toClean = append(toClean, g.ID)
+				report.Items = append(report.Items, &CleanReportItem{
+					Type:   CleanReportItemTypeGallery,
+					Path:   g.Path,
+					Reason: "gallery has no images",
+				})
 			}
 
 			*findFilter.Page++
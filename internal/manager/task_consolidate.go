@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type ConsolidateFilesInput struct {
+	Mode fsutil.LinkMode
+	// If true, identical files are reported but not linked.
+	DryRun bool
+}
+
+type ConsolidateFilesJob struct {
+	repository models.Repository
+	input      ConsolidateFilesInput
+
+	linked    int
+	reclaimed int64
+}
+
+// ConsolidateFiles starts a job that replaces byte-identical files on the
+// same filesystem with links to a single copy.
+func (s *Manager) ConsolidateFiles(ctx context.Context, input ConsolidateFilesInput) (int, error) {
+	if !input.Mode.IsValid() {
+		return 0, fmt.Errorf("invalid link mode %q", input.Mode)
+	}
+
+	j := &ConsolidateFilesJob{
+		repository: s.Repository,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Consolidating duplicate files...", j), nil
+}
+
+func (j *ConsolidateFilesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	var groups [][]models.FileID
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		groups, err = r.File.FindIdenticalFiles(ctx)
+		return err
+	}); err != nil {
+		return fmt.Errorf("finding identical files: %w", err)
+	}
+
+	progress.SetTotal(len(groups))
+
+	for _, ids := range groups {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		if err := j.consolidateGroup(ctx, ids); err != nil {
+			logger.Errorf("error consolidating files %v: %v", ids, err)
+		}
+
+		progress.Increment()
+	}
+
+	if j.input.DryRun {
+		logger.Infof("Consolidation dry run complete: %d files can be linked, reclaiming %d bytes", j.linked, j.reclaimed)
+	} else {
+		logger.Infof("Consolidation complete: %d files linked, %d bytes reclaimed", j.linked, j.reclaimed)
+	}
+
+	return nil
+}
+
+func (j *ConsolidateFilesJob) consolidateGroup(ctx context.Context, ids []models.FileID) error {
+	r := j.repository
+
+	var files []models.File
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		files, err = r.File.Find(ctx, ids...)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	// link each file to the first file on the same filesystem, which is
+	// the oldest file of the group
+	var targets []*models.BaseFile
+	for _, f := range files {
+		base := f.Base()
+
+		if _, err := os.Stat(base.Path); err != nil {
+			logger.Debugf("skipping %s: %v", base.Path, err)
+			continue
+		}
+
+		done := false
+		for _, target := range targets {
+			var err error
+			done, err = j.consolidateFile(ctx, target, base)
+			if err != nil {
+				logger.Warnf("not linking %s to %s: %v", base.Path, target.Path, err)
+				done = true
+			}
+
+			if done {
+				break
+			}
+		}
+
+		if !done {
+			targets = append(targets, base)
+		}
+	}
+
+	return nil
+}
+
+// consolidateFile replaces f with a link to target. Returns false if the
+// files are on different filesystems or their contents differ.
+func (j *ConsolidateFilesJob) consolidateFile(ctx context.Context, target *models.BaseFile, f *models.BaseFile) (bool, error) {
+	sameDevice, err := fsutil.SameDevice(target.Path, f.Path)
+	if err != nil || !sameDevice {
+		return false, err
+	}
+
+	targetInfo, err := os.Stat(target.Path)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return false, err
+	}
+
+	alreadyLinked := os.SameFile(targetInfo, info)
+	if !alreadyLinked {
+		// oshash only covers part of the file, so compare the full contents
+		same, err := fsutil.SameContents(target.Path, f.Path)
+		if err != nil || !same {
+			return false, err
+		}
+
+		if j.input.DryRun {
+			logger.Infof("[dry run] %s is identical to %s", f.Path, target.Path)
+			j.linked++
+			j.reclaimed += f.Size
+			return true, nil
+		}
+
+		if err := fsutil.ReplaceWithLink(target.Path, f.Path, j.input.Mode); err != nil {
+			return false, err
+		}
+
+		logger.Infof("Linked %s to %s", f.Path, target.Path)
+		j.linked++
+		j.reclaimed += f.Size
+
+		info, err = os.Stat(f.Path)
+		if err != nil {
+			return true, err
+		}
+	} else if f.LinkedFileID != nil || j.input.DryRun {
+		// nothing to update
+		return true, nil
+	}
+
+	// update the file to reflect the shared storage, setting the mod time so
+	// that the file is not rescanned
+	r := j.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		ff, err := r.File.Find(ctx, f.ID)
+		if err != nil {
+			return err
+		}
+		if len(ff) == 0 {
+			return fmt.Errorf("file %d not found", f.ID)
+		}
+
+		base := ff[0].Base()
+		base.LinkedFileID = &target.ID
+		base.ModTime = info.ModTime()
+		base.UpdatedAt = time.Now()
+
+		return r.File.Update(ctx, ff[0])
+	}); err != nil {
+		return true, fmt.Errorf("updating file: %w", err)
+	}
+
+	return true, nil
+}
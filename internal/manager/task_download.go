@@ -0,0 +1,168 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// DownloadCompleteInput is a download reported as finished by a download
+// client, such as qBittorrent or SABnzbd.
+type DownloadCompleteInput struct {
+	// Path is the downloaded file, or the folder containing the downloaded
+	// files.
+	Path string
+	// Identify identifies the scenes of the download after scanning.
+	Identify bool
+	// Preset is the name of the identify preset to identify with. The
+	// default identify settings are used if empty.
+	Preset string
+}
+
+// DownloadComplete queues a job that scans the files of a finished
+// download, then optionally identifies its scenes. The path of the download
+// must be within a library path.
+func (s *Manager) DownloadComplete(ctx context.Context, input DownloadCompleteInput) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	j, err := newDownloadJob(s.Config, input)
+	if err != nil {
+		return 0, err
+	}
+
+	j.manager = s
+
+	logger.Infof("Download complete: %s", j.path)
+
+	return s.JobManager.Add(ctx, "Importing download...", j), nil
+}
+
+// newDownloadJob validates the input and returns the job for the download.
+// The manager of the returned job is not set.
+func newDownloadJob(cfg *config.Config, input DownloadCompleteInput) (*DownloadJob, error) {
+	if input.Path == "" || !filepath.IsAbs(input.Path) {
+		return nil, fmt.Errorf("%w: download path must be absolute", ErrInput)
+	}
+
+	path := filepath.Clean(input.Path)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: download path %s does not exist", ErrInput, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.GetStashPaths().GetStashFromDirPath(path) == nil {
+		return nil, fmt.Errorf("%w: download path %s must be within a stash library path", ErrInput, path)
+	}
+
+	j := &DownloadJob{
+		path:  path,
+		isDir: info.IsDir(),
+	}
+
+	if input.Identify {
+		j.identify, err = getIdentifyOptions(cfg, input.Preset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+
+		if j.identify == nil {
+			return nil, fmt.Errorf("%w: default identify settings are not set", ErrInput)
+		}
+	}
+
+	return j, nil
+}
+
+type DownloadJob struct {
+	manager *Manager
+	path    string
+	isDir   bool
+	// identify is nil if the scenes are not identified
+	identify *identify.Options
+}
+
+func (j *DownloadJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := j.manager
+
+	scanJob := ScanJob{
+		scanner: mgr.newScanner(),
+		input: ScanMetadataInput{
+			Paths: []string{j.path},
+		},
+		subscriptions: mgr.scanSubs,
+	}
+	if err := scanJob.Execute(ctx, progress); err != nil {
+		return fmt.Errorf("scanning download: %w", err)
+	}
+
+	if j.identify == nil || job.IsCancelled(ctx) {
+		return nil
+	}
+
+	sceneIDs, err := j.findScenes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(sceneIDs) == 0 {
+		logger.Infof("No scenes found in %s", j.path)
+		return nil
+	}
+
+	if err := identifySceneIDs(ctx, progress, *j.identify, sceneIDs); err != nil {
+		return fmt.Errorf("identifying download scenes: %w", err)
+	}
+
+	return nil
+}
+
+func (j *DownloadJob) findScenes(ctx context.Context) ([]int, error) {
+	r := j.manager.Repository
+
+	sceneFilter := &models.SceneFilterType{
+		Path: &models.StringCriterionInput{
+			Modifier: models.CriterionModifierEquals,
+			Value:    j.path,
+		},
+	}
+	if j.isDir {
+		sceneFilter = scene.FilterFromPaths([]string{j.path})
+	}
+
+	perPage := -1
+	var ret []int
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		result, err := r.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: &models.FindFilterType{
+					PerPage: &perPage,
+				},
+			},
+			SceneFilter: sceneFilter,
+		})
+		if err != nil {
+			return err
+		}
+
+		ret = result.IDs
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding download scenes: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDownloadJob(t *testing.T) {
+	library := t.TempDir()
+	outside := t.TempDir()
+
+	download := filepath.Join(library, "download")
+	file := filepath.Join(download, "scene.mp4")
+	outsideFile := filepath.Join(outside, "scene.mp4")
+
+	if err := os.MkdirAll(download, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{file, outsideFile} {
+		if err := os.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const presetName = "preset"
+	defaultSource := "default"
+	presetSource := "preset"
+
+	noDefaults := config.InitializeEmpty()
+	noDefaults.SetInterface(config.Stash, []*config.StashConfig{{Path: library}})
+
+	withDefaults := config.InitializeEmpty()
+	withDefaults.SetInterface(config.Stash, []*config.StashConfig{{Path: library}})
+	withDefaults.SetInterface(config.DefaultIdentifySettings, identify.Options{
+		Sources: []*identify.Source{{Source: &scraper.Source{ScraperID: &defaultSource}}},
+	})
+	withDefaults.SetInterface(config.IdentifyPresets, []*identify.Preset{
+		{
+			Name:    presetName,
+			Sources: []*identify.Source{{Source: &scraper.Source{ScraperID: &presetSource}}},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		input      DownloadCompleteInput
+		wantPath   string
+		wantDir    bool
+		wantSource *string
+		wantErr    bool
+	}{
+		{"empty path", withDefaults, DownloadCompleteInput{}, "", false, nil, true},
+		{"relative path", withDefaults, DownloadCompleteInput{Path: filepath.Join("download", "scene.mp4")}, "", false, nil, true},
+		{"missing path", withDefaults, DownloadCompleteInput{Path: filepath.Join(download, "missing.mp4")}, "", false, nil, true},
+		{"outside library", withDefaults, DownloadCompleteInput{Path: outsideFile}, "", false, nil, true},
+		{"escapes library", withDefaults, DownloadCompleteInput{Path: filepath.Join(library, "..", filepath.Base(outside), "scene.mp4")}, "", false, nil, true},
+		{"file", withDefaults, DownloadCompleteInput{Path: file}, file, false, nil, false},
+		{"folder", withDefaults, DownloadCompleteInput{Path: download + string(filepath.Separator)}, download, true, nil, false},
+		{"identify with defaults", withDefaults, DownloadCompleteInput{Path: file, Identify: true}, file, false, &defaultSource, false},
+		{"identify without defaults", noDefaults, DownloadCompleteInput{Path: file, Identify: true}, "", false, nil, true},
+		{"preset", withDefaults, DownloadCompleteInput{Path: file, Identify: true, Preset: presetName}, file, false, &presetSource, false},
+		{"missing preset", withDefaults, DownloadCompleteInput{Path: file, Identify: true, Preset: "missing"}, "", false, nil, true},
+		// the preset is ignored when not identifying
+		{"preset without identify", noDefaults, DownloadCompleteInput{Path: file, Preset: "missing"}, file, false, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newDownloadJob(tt.cfg, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newDownloadJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInput), "error should be an input error: %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.wantPath, got.path)
+			assert.Equal(t, tt.wantDir, got.isDir)
+
+			if tt.wantSource == nil {
+				assert.Nil(t, got.identify)
+				return
+			}
+
+			if assert.NotNil(t, got.identify) && assert.Len(t, got.identify.Sources, 1) {
+				assert.Equal(t, *tt.wantSource, *got.identify.Sources[0].Source.ScraperID)
+			}
+		})
+	}
+}
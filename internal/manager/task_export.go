@@ -22,6 +22,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/json"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/note"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/scene"
@@ -50,6 +51,9 @@ type ExportTask struct {
 
 	includeDependencies bool
 
+	// fetch objects in batches rather than all at once
+	lowMemory bool
+
 	DownloadHash string
 }
 
@@ -124,6 +128,11 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	// @manager.total = Scene.count + Gallery.count + Performer.count + Studio.count + Group.count
 	workerCount := runtime.GOMAXPROCS(0) // set worker count to number of cpus available
 
+	t.lowMemory = config.GetInstance().GetLowMemoryMode()
+	if t.lowMemory {
+		workerCount = 1
+	}
+
 	startTime := time.Now()
 
 	if t.full {
@@ -356,19 +365,6 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 
 	sceneReader := t.repository.Scene
 
-	var scenes []*models.Scene
-	var err error
-	all := t.full || (t.scenes != nil && t.scenes.all)
-	if all {
-		scenes, err = sceneReader.All(ctx)
-	} else if t.scenes != nil && len(t.scenes.IDs) > 0 {
-		scenes, err = sceneReader.FindMany(ctx, t.scenes.IDs)
-	}
-
-	if err != nil {
-		logger.Errorf("[scenes] failed to fetch scenes: %v", err)
-	}
-
 	jobCh := make(chan *models.Scene, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[scenes] exporting")
@@ -379,13 +375,34 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 		go t.exportScene(ctx, &scenesWg, jobCh)
 	}
 
-	for i, scene := range scenes {
-		index := i + 1
+	all := t.full || (t.scenes != nil && t.scenes.all)
+	if all && t.lowMemory {
+		if err := exportBatched(ctx, "scenes", sceneReader.Count, func(findFilter *models.FindFilterType) ([]*models.Scene, error) {
+			return scene.Query(ctx, sceneReader, nil, findFilter)
+		}, jobCh); err != nil {
+			logger.Errorf("[scenes] failed to fetch scenes: %v", err)
+		}
+	} else {
+		var scenes []*models.Scene
+		var err error
+		if all {
+			scenes, err = sceneReader.All(ctx)
+		} else if t.scenes != nil && len(t.scenes.IDs) > 0 {
+			scenes, err = sceneReader.FindMany(ctx, t.scenes.IDs)
+		}
+
+		if err != nil {
+			logger.Errorf("[scenes] failed to fetch scenes: %v", err)
+		}
+
+		for i, scene := range scenes {
+			index := i + 1
 
-		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[scenes] %d of %d", index, len(scenes))
+			if (i % 100) == 0 { // make progress easier to read
+				logger.Progressf("[scenes] %d of %d", index, len(scenes))
+			}
+			jobCh <- scene // feed workers
 		}
-		jobCh <- scene // feed workers
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
@@ -394,6 +411,48 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 	logger.Infof("[scenes] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
+// number of objects fetched at a time when exporting in low memory mode
+const exportBatchSize = 500
+
+// exportBatched sends all objects returned by query to jobCh, querying a
+// batch at a time so that they are not all held in memory at once.
+func exportBatched[T any](ctx context.Context, name string, count func(ctx context.Context) (int, error), query func(findFilter *models.FindFilterType) ([]T, error), jobCh chan<- T) error {
+	total, err := count(ctx)
+	if err != nil {
+		return err
+	}
+
+	// sort by id so that the pages are stable
+	sort := "id"
+	findFilter := models.BatchFindFilter(exportBatchSize)
+	findFilter.Sort = &sort
+
+	index := 0
+	for more := true; more; {
+		objs, err := query(findFilter)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range objs {
+			if (index % 100) == 0 { // make progress easier to read
+				logger.Progressf("[%s] %d of %d", name, index+1, total)
+			}
+			index++
+
+			jobCh <- o // feed workers
+		}
+
+		if len(objs) != exportBatchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
+
 func (t *ExportTask) exportFile(f models.File) {
 	newFileJSON := fileToJSON(f)
 
@@ -442,6 +501,11 @@ func fileToJSON(f models.File) jsonschema.DirEntry {
 			AudioCodec:       ff.AudioCodec,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
+			BitDepth:         ff.BitDepth,
+			AudioBitRate:     ff.AudioBitRate,
+			HDRFormat:        ff.HDRFormat,
+			VRProjection:     ff.VRProjection,
+			StereoLayout:     ff.StereoLayout,
 			Interactive:      ff.Interactive,
 			InteractiveSpeed: ff.InteractiveSpeed,
 		}
@@ -558,6 +622,12 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		newSceneJSON.Notes, err = note.GetSceneNotesJSON(ctx, r.Note, s.ID)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene notes JSON: %v", sceneHash, err)
+			continue
+		}
+
 		newSceneJSON.Groups, err = scene.GetSceneGroupsJSON(ctx, groupReader, s)
 		if err != nil {
 			logger.Errorf("[scenes] <%s> error getting scene groups JSON: %v", sceneHash, err)
@@ -605,19 +675,6 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int) {
 	r := t.repository
 	imageReader := r.Image
 
-	var images []*models.Image
-	var err error
-	all := t.full || (t.images != nil && t.images.all)
-	if all {
-		images, err = imageReader.All(ctx)
-	} else if t.images != nil && len(t.images.IDs) > 0 {
-		images, err = imageReader.FindMany(ctx, t.images.IDs)
-	}
-
-	if err != nil {
-		logger.Errorf("[images] failed to fetch images: %v", err)
-	}
-
 	jobCh := make(chan *models.Image, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[images] exporting")
@@ -628,13 +685,34 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int) {
 		go t.exportImage(ctx, &imagesWg, jobCh)
 	}
 
-	for i, image := range images {
-		index := i + 1
+	all := t.full || (t.images != nil && t.images.all)
+	if all && t.lowMemory {
+		if err := exportBatched(ctx, "images", imageReader.Count, func(findFilter *models.FindFilterType) ([]*models.Image, error) {
+			return image.Query(ctx, imageReader, nil, findFilter)
+		}, jobCh); err != nil {
+			logger.Errorf("[images] failed to fetch images: %v", err)
+		}
+	} else {
+		var images []*models.Image
+		var err error
+		if all {
+			images, err = imageReader.All(ctx)
+		} else if t.images != nil && len(t.images.IDs) > 0 {
+			images, err = imageReader.FindMany(ctx, t.images.IDs)
+		}
+
+		if err != nil {
+			logger.Errorf("[images] failed to fetch images: %v", err)
+		}
 
-		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[images] %d of %d", index, len(images))
+		for i, image := range images {
+			index := i + 1
+
+			if (i % 100) == 0 { // make progress easier to read
+				logger.Progressf("[images] %d of %d", index, len(images))
+			}
+			jobCh <- image // feed workers
 		}
-		jobCh <- image // feed workers
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
@@ -710,6 +788,12 @@ func (t *ExportTask) exportImage(ctx context.Context, wg *sync.WaitGroup, jobCha
 
 		newImageJSON.Tags = tag.GetNames(tags)
 
+		newImageJSON.Regions, err = image.GetRegionsJSON(ctx, r.ImageRegion, tagReader, performerReader, s.ID)
+		if err != nil {
+			logger.Errorf("[images] <%s> error getting image regions JSON: %v", imageHash, err)
+			continue
+		}
+
 		if t.includeDependencies {
 			if s.StudioID != nil {
 				t.studios.IDs = sliceutil.AppendUnique(t.studios.IDs, *s.StudioID)
@@ -733,19 +817,6 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int) {
 
 	reader := t.repository.Gallery
 
-	var galleries []*models.Gallery
-	var err error
-	all := t.full || (t.galleries != nil && t.galleries.all)
-	if all {
-		galleries, err = reader.All(ctx)
-	} else if t.galleries != nil && len(t.galleries.IDs) > 0 {
-		galleries, err = reader.FindMany(ctx, t.galleries.IDs)
-	}
-
-	if err != nil {
-		logger.Errorf("[galleries] failed to fetch galleries: %v", err)
-	}
-
 	jobCh := make(chan *models.Gallery, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[galleries] exporting")
@@ -756,14 +827,36 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int) {
 		go t.exportGallery(ctx, &galleriesWg, jobCh)
 	}
 
-	for i, gallery := range galleries {
-		index := i + 1
+	all := t.full || (t.galleries != nil && t.galleries.all)
+	if all && t.lowMemory {
+		if err := exportBatched(ctx, "galleries", reader.Count, func(findFilter *models.FindFilterType) ([]*models.Gallery, error) {
+			galleries, _, err := reader.Query(ctx, nil, findFilter)
+			return galleries, err
+		}, jobCh); err != nil {
+			logger.Errorf("[galleries] failed to fetch galleries: %v", err)
+		}
+	} else {
+		var galleries []*models.Gallery
+		var err error
+		if all {
+			galleries, err = reader.All(ctx)
+		} else if t.galleries != nil && len(t.galleries.IDs) > 0 {
+			galleries, err = reader.FindMany(ctx, t.galleries.IDs)
+		}
 
-		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[galleries] %d of %d", index, len(galleries))
+		if err != nil {
+			logger.Errorf("[galleries] failed to fetch galleries: %v", err)
 		}
 
-		jobCh <- gallery
+		for i, gallery := range galleries {
+			index := i + 1
+
+			if (i % 100) == 0 { // make progress easier to read
+				logger.Progressf("[galleries] %d of %d", index, len(galleries))
+			}
+
+			jobCh <- gallery
+		}
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
@@ -936,6 +1029,12 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 
 		newPerformerJSON.Tags = tag.GetNames(tags)
 
+		newPerformerJSON.Notes, err = note.GetPerformerNotesJSON(ctx, r.Note, p.ID)
+		if err != nil {
+			logger.Errorf("[performers] <%s> error getting performer notes JSON: %v", p.Name, err)
+			continue
+		}
+
 		if t.includeDependencies {
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
@@ -1014,6 +1113,30 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
 
+		if err := s.LoadDefaultTagIDs(ctx, r.Studio); err != nil {
+			logger.Errorf("[studios] <%s> error getting studio default tags: %v", s.Name, err)
+			continue
+		}
+
+		defaultTags, err := r.Tag.FindMany(ctx, s.DefaultTagIDs.List())
+		if err != nil {
+			logger.Errorf("[studios] <%s> error getting studio default tags: %v", s.Name, err)
+			continue
+		}
+
+		newStudioJSON.DefaultTags = tag.GetNames(defaultTags)
+
+		if t.includeDependencies {
+			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, s.DefaultTagIDs.List())
+		}
+
+		if err := s.LoadDefaultURLs(ctx, r.Studio); err != nil {
+			logger.Errorf("[studios] <%s> error getting studio default urls: %v", s.Name, err)
+			continue
+		}
+
+		newStudioJSON.DefaultURLs = s.DefaultURLs.List()
+
 		fn := newStudioJSON.Filename()
 
 		if err := t.json.saveStudio(fn, newStudioJSON); err != nil {
@@ -1066,6 +1189,7 @@ func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan
 	defer wg.Done()
 
 	tagReader := t.repository.Tag
+	categoryReader := t.repository.TagCategory
 
 	for thisTag := range jobChan {
 		newTagJSON, err := tag.ToJSON(ctx, tagReader, thisTag)
@@ -1075,6 +1199,17 @@ func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan
 			continue
 		}
 
+		if thisTag.CategoryID != nil {
+			category, err := categoryReader.Find(ctx, *thisTag.CategoryID)
+			if err != nil {
+				logger.Errorf("[tags] <%s> error getting tag category: %v", thisTag.Name, err)
+				continue
+			}
+			if category != nil {
+				newTagJSON.Category = category.Name
+			}
+		}
+
 		fn := newTagJSON.Filename()
 
 		if err := t.json.saveTag(fn, newTagJSON); err != nil {
@@ -1233,7 +1368,7 @@ func (t *ExportTask) exportSavedFilter(ctx context.Context, wg *sync.WaitGroup,
 	defer wg.Done()
 
 	for thisFilter := range jobChan {
-		newJSON, err := savedfilter.ToJSON(ctx, thisFilter)
+		newJSON, err := savedfilter.ToJSON(ctx, t.repository.SavedFilterFolder, thisFilter)
 
 		if err != nil {
 			logger.Errorf("[saved filter] <%s> error getting saved filter JSON: %v", thisFilter.Name, err)
@@ -0,0 +1,392 @@
+package manager
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
+	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// defaultMarkerClipDuration is the length of clips exported from markers
+// without an end time.
+const defaultMarkerClipDuration = 20.0
+
+const markerClipExt = ".mp4"
+
+type ExportMarkerClipsInput struct {
+	// IDs of the markers to export
+	MarkerIDs []string `json:"marker_ids"`
+	// Template used to generate the path of each clip, relative to the destination
+	Template string `json:"template"`
+	// Directory to write the clips to
+	Destination string `json:"destination"`
+	// Always re-encode the clips, rather than copying the streams where possible
+	Reencode *bool `json:"reencode"`
+	// Length of clips for markers without an end time. Defaults to 20 seconds.
+	DefaultDuration *float64 `json:"default_duration"`
+	// Defaults to SUFFIX
+	Collision *organize.CollisionStrategy `json:"collision"`
+}
+
+type DownloadMarkerClipsInput struct {
+	// IDs of the markers to export
+	MarkerIDs []string `json:"marker_ids"`
+	// Template used to generate the path of each clip within the zip file
+	Template string `json:"template"`
+	// Always re-encode the clips, rather than copying the streams where possible
+	Reencode *bool `json:"reencode"`
+	// Length of clips for markers without an end time. Defaults to 20 seconds.
+	DefaultDuration *float64 `json:"default_duration"`
+}
+
+// markerClip is a single planned marker clip export.
+type markerClip struct {
+	markerID int
+	input    string
+	output   string
+	seconds  float64
+	duration float64
+}
+
+type markerClipPlanOptions struct {
+	template        organize.MarkerTemplate
+	destination     string
+	defaultDuration *float64
+	collision       organize.CollisionStrategy
+}
+
+// planMarkerClips returns the clips to export for the given markers.
+// Markers that cannot be exported are logged and skipped.
+// Must be called within a read transaction.
+func planMarkerClips(ctx context.Context, r models.Repository, markerIDs []string, options markerClipPlanOptions) ([]markerClip, error) {
+	if err := options.template.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(markerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting marker ids: %w", err)
+	}
+
+	defaultDuration := defaultMarkerClipDuration
+	if options.defaultDuration != nil {
+		if *options.defaultDuration <= 0 {
+			return nil, fmt.Errorf("default duration must be greater than zero")
+		}
+		defaultDuration = *options.defaultDuration
+	}
+
+	planner := organize.NewPlanner(&file.OsFS{}, options.collision)
+
+	var ret []markerClip
+	for _, id := range ids {
+		m, err := r.SceneMarker.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("finding marker %d: %w", id, err)
+		}
+		if m == nil {
+			return nil, fmt.Errorf("marker with id %d not found", id)
+		}
+
+		s, err := r.Scene.Find(ctx, m.SceneID)
+		if err != nil {
+			return nil, fmt.Errorf("finding scene %d: %w", m.SceneID, err)
+		}
+		if s == nil {
+			return nil, fmt.Errorf("scene with id %d not found", m.SceneID)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+			return nil, fmt.Errorf("loading primary file for scene %d: %w", s.ID, err)
+		}
+
+		f := s.Files.Primary()
+		if f == nil {
+			logger.Warnf("scene %d of marker %d has no files, skipping", s.ID, id)
+			continue
+		}
+
+		if f.ZipFileID != nil {
+			logger.Warnf("file %s is in a zip file, skipping marker %d", f.Path, id)
+			continue
+		}
+
+		end := m.Seconds + defaultDuration
+		if m.EndSeconds != nil {
+			end = *m.EndSeconds
+		}
+		if f.Duration > 0 {
+			end = math.Min(end, f.Duration)
+		}
+
+		if end <= m.Seconds {
+			logger.Warnf("marker %d has no duration within file %s, skipping", id, f.Path)
+			continue
+		}
+
+		tag, err := r.Tag.Find(ctx, m.PrimaryTagID)
+		if err != nil {
+			return nil, fmt.Errorf("finding primary tag for marker %d: %w", id, err)
+		}
+		var tagName string
+		if tag != nil {
+			tagName = tag.Name
+		}
+
+		fields, err := organizeSceneFields(ctx, r, s, f)
+		if err != nil {
+			return nil, err
+		}
+		fields = organize.MarkerFields(fields, m, tagName, end)
+		// clips are always written as mp4
+		fields[organize.FieldExt] = markerClipExt
+
+		relPath, err := options.template.Render(fields)
+		if err != nil {
+			return nil, fmt.Errorf("rendering template for marker %d: %w", id, err)
+		}
+
+		move, err := planner.Add(f.ID, f.Path, filepath.Join(options.destination, relPath))
+		if err != nil {
+			return nil, err
+		}
+
+		if move.Skipped != "" {
+			logger.Infof("Not exporting marker %d to %s: %s", id, move.NewPath, move.Skipped)
+			continue
+		}
+
+		ret = append(ret, markerClip{
+			markerID: id,
+			input:    f.Path,
+			output:   move.NewPath,
+			seconds:  m.Seconds,
+			duration: end - m.Seconds,
+		})
+	}
+
+	return ret, nil
+}
+
+func markerClipGenerator(overwrite bool) *generate.Generator {
+	return &generate.Generator{
+		Encoder:      instance.FFMpeg,
+		FFMpegConfig: instance.Config,
+		LockManager:  instance.ReadLockManager,
+		MarkerPaths:  instance.Paths.SceneMarkers,
+		Overwrite:    overwrite,
+	}
+}
+
+// exportMarkerClips writes the clips, returning the number of clips that
+// were successfully written.
+func exportMarkerClips(ctx context.Context, clips []markerClip, reencode bool, overwrite bool, progress *job.Progress) int {
+	g := markerClipGenerator(overwrite)
+
+	if progress != nil {
+		progress.SetTotal(len(clips))
+	}
+
+	exported := 0
+	for _, c := range clips {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		logger.Debugf("Exporting marker %d to %s", c.markerID, c.output)
+
+		if err := fsutil.EnsureDir(filepath.Dir(c.output)); err != nil {
+			logger.Errorf("error creating directory for marker clip %s: %v", c.output, err)
+		} else if err := g.MarkerClip(ctx, c.input, c.output, generate.MarkerClipOptions{
+			Seconds:  c.seconds,
+			Duration: c.duration,
+			Reencode: reencode,
+		}); err != nil {
+			logger.Errorf("error exporting marker %d: %v", c.markerID, err)
+			logErrorOutput(err)
+		} else {
+			exported++
+		}
+
+		if progress != nil {
+			progress.Increment()
+		}
+	}
+
+	return exported
+}
+
+type ExportMarkerClipsJob struct {
+	repository models.Repository
+	input      ExportMarkerClipsInput
+}
+
+func (s *Manager) ExportMarkerClips(ctx context.Context, input ExportMarkerClipsInput) (int, error) {
+	if input.Destination == "" {
+		return 0, fmt.Errorf("destination must not be empty")
+	}
+
+	if err := organize.MarkerTemplate(input.Template).Validate(); err != nil {
+		return 0, err
+	}
+
+	j := &ExportMarkerClipsJob{
+		repository: s.Repository,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Exporting marker clips...", j), nil
+}
+
+func (j *ExportMarkerClipsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	collision := organize.CollisionStrategySuffix
+	if j.input.Collision != nil {
+		collision = *j.input.Collision
+	}
+
+	var clips []markerClip
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		clips, err = planMarkerClips(ctx, r, j.input.MarkerIDs, markerClipPlanOptions{
+			template:        organize.MarkerTemplate(j.input.Template),
+			destination:     j.input.Destination,
+			defaultDuration: j.input.DefaultDuration,
+			collision:       collision,
+		})
+		return err
+	}); err != nil {
+		return fmt.Errorf("planning marker clips: %w", err)
+	}
+
+	if len(clips) == 0 {
+		logger.Info("No marker clips to export")
+		return nil
+	}
+
+	reencode := j.input.Reencode != nil && *j.input.Reencode
+	// the planner has resolved collisions, so any existing file is replaced
+	exported := exportMarkerClips(ctx, clips, reencode, true, progress)
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	logger.Infof("Exported %d of %d marker clips to %s", exported, len(clips), j.input.Destination)
+	return nil
+}
+
+// DownloadMarkerClips exports the markers to a zip file and registers it
+// with the download store. Returns the download hash.
+func (s *Manager) DownloadMarkerClips(ctx context.Context, input DownloadMarkerClipsInput) (string, error) {
+	if err := fsutil.EnsureDir(s.Paths.Generated.Downloads); err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp(s.Paths.Generated.Downloads, "markers")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	r := s.Repository
+	var clips []markerClip
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		clips, err = planMarkerClips(ctx, r, input.MarkerIDs, markerClipPlanOptions{
+			template:        organize.MarkerTemplate(input.Template),
+			destination:     dir,
+			defaultDuration: input.DefaultDuration,
+			collision:       organize.CollisionStrategySuffix,
+		})
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("planning marker clips: %w", err)
+	}
+
+	if len(clips) == 0 {
+		return "", fmt.Errorf("no marker clips to export")
+	}
+
+	reencode := input.Reencode != nil && *input.Reencode
+	if exported := exportMarkerClips(ctx, clips, reencode, true, nil); exported == 0 {
+		return "", fmt.Errorf("no marker clips were exported")
+	}
+
+	z, err := os.CreateTemp(s.Paths.Generated.Downloads, "markers*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer z.Close()
+
+	if err := zipMarkerClips(z, dir, clips); err != nil {
+		return "", err
+	}
+
+	downloadHash, err := s.DownloadStore.RegisterFile(z.Name(), "", false)
+	if err != nil {
+		return "", fmt.Errorf("error registering file for download: %w", err)
+	}
+	logger.Debugf("Generated marker clips zip file %s with hash %s", z.Name(), downloadHash)
+
+	return downloadHash, nil
+}
+
+func zipMarkerClips(w io.Writer, dir string, clips []markerClip) error {
+	z := zip.NewWriter(w)
+	defer z.Close()
+
+	for _, c := range clips {
+		if err := zipMarkerClip(z, dir, c.output); err != nil {
+			// clips that failed to export are logged by exportMarkerClips
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func zipMarkerClip(z *zip.Writer, dir string, fn string) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	name, err := filepath.Rel(dir, fn)
+	if err != nil {
+		return err
+	}
+
+	// video is already compressed, so store the clips as-is
+	w, err := z.CreateHeader(&zip.FileHeader{
+		Name:   filepath.ToSlash(name),
+		Method: zip.Store,
+	})
+	if err != nil {
+		return fmt.Errorf("adding %s to zip: %w", name, err)
+	}
+
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("writing %s to zip: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,403 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+const (
+	// FunscriptMarkerSource is the source of suggestions made by the
+	// funscript marker task.
+	FunscriptMarkerSource = "funscript"
+
+	// funscriptMarkerWindow is the length in milliseconds of the windows
+	// that the script intensity is averaged over.
+	funscriptMarkerWindow = 5000
+
+	// the defaults match the colours of the interactive heatmap, where
+	// 375 is red and 100 is blue-green
+	funscriptMarkerPeakThresholdDefault   = 375
+	funscriptMarkerValleyThresholdDefault = 100
+	funscriptMarkerMinDurationDefault     = 10
+	funscriptMarkerPeakTagDefault         = "Intense"
+	funscriptMarkerValleyTagDefault       = "Slow"
+)
+
+type FunscriptMarkersInput struct {
+	// SceneIDs are the scenes to analyze. All interactive scenes are
+	// analyzed if empty.
+	SceneIDs []string `json:"scene_ids"`
+	// PeakThreshold is the minimum average speed of a peak, in percent of a
+	// full stroke per second.
+	PeakThreshold *float64 `json:"peak_threshold"`
+	// ValleyThreshold is the maximum average speed of a valley, in percent
+	// of a full stroke per second.
+	ValleyThreshold *float64 `json:"valley_threshold"`
+	// MinDuration is the minimum duration of a peak or valley in seconds.
+	MinDuration *float64 `json:"min_duration"`
+	// PeakTag and ValleyTag are the names of the primary tags of the
+	// markers. Missing tags are created.
+	PeakTag   *string `json:"peak_tag"`
+	ValleyTag *string `json:"valley_tag"`
+	// Apply creates the markers. Otherwise they are stored as suggestions,
+	// replacing the previous suggestions of the scene.
+	Apply bool `json:"apply"`
+}
+
+type funscriptMarkerOptions struct {
+	peakThreshold   float64
+	valleyThreshold float64
+	// minDuration is in milliseconds
+	minDuration int64
+}
+
+// FunscriptMarkers queues a job that proposes markers at the intensity peaks
+// and valleys of the scripts of interactive scenes.
+func (s *Manager) FunscriptMarkers(ctx context.Context, input FunscriptMarkersInput) (int, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: converting scene ids: %v", ErrInput, err)
+	}
+
+	floatOrDefault := func(v *float64, def float64) float64 {
+		if v != nil {
+			return *v
+		}
+		return def
+	}
+
+	stringOrDefault := func(v *string, def string) string {
+		if v != nil && *v != "" {
+			return *v
+		}
+		return def
+	}
+
+	options := funscriptMarkerOptions{
+		peakThreshold:   floatOrDefault(input.PeakThreshold, funscriptMarkerPeakThresholdDefault),
+		valleyThreshold: floatOrDefault(input.ValleyThreshold, funscriptMarkerValleyThresholdDefault),
+		minDuration:     int64(floatOrDefault(input.MinDuration, funscriptMarkerMinDurationDefault) * 1000),
+	}
+
+	if options.peakThreshold <= options.valleyThreshold {
+		return 0, fmt.Errorf("%w: peak threshold must be greater than valley threshold", ErrInput)
+	}
+	if options.valleyThreshold < 0 || options.minDuration < 0 {
+		return 0, fmt.Errorf("%w: thresholds and minimum duration must not be negative", ErrInput)
+	}
+
+	j := &FunscriptMarkersJob{
+		repository: s.Repository,
+		sceneIDs:   sceneIDs,
+		options:    options,
+		peakTag:    stringOrDefault(input.PeakTag, funscriptMarkerPeakTagDefault),
+		valleyTag:  stringOrDefault(input.ValleyTag, funscriptMarkerValleyTagDefault),
+		apply:      input.Apply,
+	}
+
+	return s.JobManager.Add(ctx, "Generating funscript markers...", j), nil
+}
+
+// FunscriptMarkersJob analyzes the scripts of interactive scenes, and creates
+// or suggests markers at the intensity peaks and valleys.
+type FunscriptMarkersJob struct {
+	repository models.Repository
+	// sceneIDs are the scenes to analyze. All interactive scenes are
+	// analyzed if empty.
+	sceneIDs  []int
+	options   funscriptMarkerOptions
+	peakTag   string
+	valleyTag string
+	apply     bool
+
+	tagIDs map[string]int
+}
+
+func (j *FunscriptMarkersJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	sceneIDs := j.sceneIDs
+	if len(sceneIDs) == 0 {
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			interactive := true
+			perPage := -1
+			result, err := r.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: &models.FindFilterType{PerPage: &perPage},
+				},
+				SceneFilter: &models.SceneFilterType{Interactive: &interactive},
+			})
+			if err != nil {
+				return fmt.Errorf("finding interactive scenes: %w", err)
+			}
+
+			sceneIDs = result.IDs
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	progress.SetTotal(len(sceneIDs))
+	logger.Infof("Generating funscript markers for %d scenes", len(sceneIDs))
+
+	total := 0
+	for _, id := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Analyzing scene %d", id), func() {
+			n, err := j.processScene(ctx, id)
+			if err != nil {
+				logger.Errorf("error generating funscript markers for scene %d: %v", id, err)
+				return
+			}
+			total += n
+		})
+
+		progress.Increment()
+	}
+
+	if j.apply {
+		logger.Infof("Finished generating funscript markers: %d markers created", total)
+	} else {
+		logger.Infof("Finished generating funscript markers: %d markers suggested", total)
+	}
+	return nil
+}
+
+func (j *FunscriptMarkersJob) processScene(ctx context.Context, sceneID int) (int, error) {
+	r := j.repository
+
+	var s *models.Scene
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = r.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		return s.LoadPrimaryFile(ctx, r.File)
+	}); err != nil {
+		return 0, err
+	}
+
+	primaryFile := s.Files.Primary()
+	if primaryFile == nil || !primaryFile.Interactive {
+		logger.Debugf("skipping scene %s without a script", s.DisplayName())
+		return 0, nil
+	}
+
+	funscriptPath := video.GetFunscriptPath(s.Path)
+	funscript, err := (&InteractiveHeatmapSpeedGenerator{}).LoadFunscriptData(funscriptPath, primaryFile.Duration)
+	if err != nil {
+		return 0, fmt.Errorf("loading funscript: %w", err)
+	}
+	funscript.UpdateIntensityAndSpeed()
+
+	regions := findFunscriptRegions(funscript.Actions, j.options)
+
+	ret := 0
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if !j.apply {
+			// replace the previous suggestions
+			if err := r.Suggestion.DestroyBySceneID(ctx, s.ID, FunscriptMarkerSource); err != nil {
+				return err
+			}
+		}
+
+		existing, err := r.SceneMarker.FindBySceneID(ctx, s.ID)
+		if err != nil {
+			return fmt.Errorf("finding markers: %w", err)
+		}
+
+		for _, region := range regions {
+			tagName := j.valleyTag
+			if region.peak {
+				tagName = j.peakTag
+			}
+
+			tagID, err := j.getTagID(ctx, tagName)
+			if err != nil {
+				return err
+			}
+
+			suggestion := models.NewSceneMarkerSuggestion()
+			suggestion.SceneID = s.ID
+			suggestion.Title = tagName
+			suggestion.Seconds = float64(region.start) / 1000
+			endSeconds := float64(region.end) / 1000
+			suggestion.EndSeconds = &endSeconds
+			suggestion.PrimaryTagID = tagID
+			suggestion.Source = FunscriptMarkerSource
+
+			// don't duplicate markers from previous runs
+			if hasSceneMarker(existing, suggestion) {
+				continue
+			}
+
+			if j.apply {
+				marker := suggestion.SceneMarker()
+				if err := r.SceneMarker.Create(ctx, &marker); err != nil {
+					return fmt.Errorf("creating marker: %w", err)
+				}
+			} else {
+				if err := r.Suggestion.Create(ctx, &suggestion); err != nil {
+					return fmt.Errorf("creating suggestion: %w", err)
+				}
+			}
+
+			ret++
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+// getTagID returns the id of the tag with the name, creating it if missing.
+func (j *FunscriptMarkersJob) getTagID(ctx context.Context, name string) (int, error) {
+	if id, found := j.tagIDs[name]; found {
+		return id, nil
+	}
+
+	qb := j.repository.Tag
+	existing, err := qb.FindByName(ctx, name, true)
+	if err != nil {
+		return 0, fmt.Errorf("finding tag %s: %w", name, err)
+	}
+
+	var id int
+	if existing != nil {
+		id = existing.ID
+	} else {
+		newTag := models.NewTag()
+		newTag.Name = name
+		if err := qb.Create(ctx, &newTag); err != nil {
+			return 0, fmt.Errorf("creating tag %s: %w", name, err)
+		}
+		id = newTag.ID
+	}
+
+	if j.tagIDs == nil {
+		j.tagIDs = make(map[string]int)
+	}
+	j.tagIDs[name] = id
+
+	return id, nil
+}
+
+// hasSceneMarker returns true if one of the markers has the primary tag and
+// start time of the suggestion.
+func hasSceneMarker(markers []*models.SceneMarker, s models.SceneMarkerSuggestion) bool {
+	for _, m := range markers {
+		if m.PrimaryTagID == s.PrimaryTagID && math.Abs(m.Seconds-s.Seconds) < 0.5 {
+			return true
+		}
+	}
+	return false
+}
+
+// funscriptRegion is a peak or valley of the intensity of a script. start and
+// end are in milliseconds.
+type funscriptRegion struct {
+	peak  bool
+	start int64
+	end   int64
+}
+
+// findFunscriptRegions returns the peaks and valleys of the script actions,
+// which must be sorted and have their speeds calculated. The speeds are
+// averaged over windows of funscriptMarkerWindow. Consecutive windows at or
+// above the peak threshold form a peak, and those at or below the valley
+// threshold form a valley. Windows without movement are neither, so that
+// pauses in the script are not reported as valleys. Regions of the same kind
+// separated by a single window are merged.
+func findFunscriptRegions(actions []Action, options funscriptMarkerOptions) []funscriptRegion {
+	if len(actions) < 2 {
+		return nil
+	}
+
+	const (
+		none   = 0
+		peak   = 1
+		valley = -1
+	)
+
+	first := actions[0].At
+	last := actions[len(actions)-1].At
+	n := int((last-first)/funscriptMarkerWindow) + 1
+
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	// the speed of the first action is not known
+	for _, a := range actions[1:] {
+		w := int((a.At - first) / funscriptMarkerWindow)
+		sums[w] += a.Speed
+		counts[w]++
+	}
+
+	classes := make([]int, n)
+	for i := range classes {
+		if counts[i] == 0 || sums[i] == 0 {
+			continue
+		}
+
+		avg := sums[i] / float64(counts[i])
+		switch {
+		case avg >= options.peakThreshold:
+			classes[i] = peak
+		case avg <= options.valleyThreshold:
+			classes[i] = valley
+		}
+	}
+
+	var regions []funscriptRegion
+	for i := 0; i < n; {
+		c := classes[i]
+		end := i
+		for end+1 < n && classes[end+1] == c {
+			end++
+		}
+
+		if c != none {
+			region := funscriptRegion{
+				peak:  c == peak,
+				start: first + int64(i)*funscriptMarkerWindow,
+				end:   min(first+int64(end+1)*funscriptMarkerWindow, last),
+			}
+
+			if l := len(regions) - 1; l >= 0 && regions[l].peak == region.peak && region.start-regions[l].end <= funscriptMarkerWindow {
+				regions[l].end = region.end
+			} else {
+				regions = append(regions, region)
+			}
+		}
+
+		i = end + 1
+	}
+
+	var ret []funscriptRegion
+	for _, r := range regions {
+		if r.end-r.start >= options.minDuration {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// strokes returns actions from start to end in milliseconds, moving a full
+// stroke every interval milliseconds.
+func strokes(start, end, interval int64) []Action {
+	var ret []Action
+	pos := 0
+	for at := start; at < end; at += interval {
+		ret = append(ret, Action{At: at, Pos: pos})
+		pos = 100 - pos
+	}
+	return ret
+}
+
+func TestFindFunscriptRegions(t *testing.T) {
+	options := funscriptMarkerOptions{
+		peakThreshold:   375,
+		valleyThreshold: 100,
+		minDuration:     10000,
+	}
+
+	var actions []Action
+	// 20 seconds of medium strokes at 200%/s
+	actions = append(actions, strokes(0, 20000, 500)...)
+	// 20 seconds of fast strokes at 500%/s
+	actions = append(actions, strokes(20000, 40000, 200)...)
+	// 5 seconds of medium strokes, which is too short to separate the peaks
+	actions = append(actions, strokes(40000, 45000, 500)...)
+	// 10 seconds of fast strokes
+	actions = append(actions, strokes(45000, 55000, 200)...)
+	// 20 seconds pause
+	// 30 seconds of slow strokes at 50%/s
+	actions = append(actions, strokes(75000, 105000, 2000)...)
+	// 5 seconds of fast strokes, which is too short to be a peak
+	actions = append(actions, strokes(105000, 110000, 200)...)
+	actions = append(actions, Action{At: 110000, Pos: 0})
+
+	script := Script{Actions: actions}
+	script.UpdateIntensityAndSpeed()
+
+	got := findFunscriptRegions(script.Actions, options)
+	assert.Equal(t, []funscriptRegion{
+		{peak: true, start: 20000, end: 55000},
+		{peak: false, start: 75000, end: 105000},
+	}, got)
+
+	assert.Nil(t, findFunscriptRegions(nil, options))
+	assert.Nil(t, findFunscriptRegions(actions[:1], options))
+}
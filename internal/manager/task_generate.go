@@ -7,6 +7,7 @@ import (
 
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/hash/videophash"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -14,11 +15,14 @@ import (
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/transcribe"
+	"github.com/stashapp/stash/pkg/worker"
 )
 
 type GenerateMetadataInput struct {
 	Covers              bool                         `json:"covers"`
 	Sprites             bool                         `json:"sprites"`
+	Barcodes            bool                         `json:"barcodes"`
 	Previews            bool                         `json:"previews"`
 	ImagePreviews       bool                         `json:"imagePreviews"`
 	PreviewOptions      *GeneratePreviewOptionsInput `json:"previewOptions"`
@@ -27,11 +31,19 @@ type GenerateMetadataInput struct {
 	MarkerScreenshots   bool                         `json:"markerScreenshots"`
 	Transcodes          bool                         `json:"transcodes"`
 	// Generate transcodes even if not required
-	ForceTranscodes           bool `json:"forceTranscodes"`
-	Phashes                   bool `json:"phashes"`
+	ForceTranscodes bool `json:"forceTranscodes"`
+	Phashes         bool `json:"phashes"`
+	// generate phashes using the configured phash options
+	CustomPhashes             bool `json:"customPhashes"`
 	InteractiveHeatmapsSpeeds bool `json:"interactiveHeatmapsSpeeds"`
+	SceneHeatmaps             bool `json:"sceneHeatmaps"`
+	Transcripts               bool `json:"transcripts"`
 	ClipPreviews              bool `json:"clipPreviews"`
 	ImageThumbnails           bool `json:"imageThumbnails"`
+	// generate phashes for image clips, animated images and gallery covers
+	ImagePhashes bool `json:"imagePhashes"`
+	// generate phashes for all images in galleries, used to detect image stacks
+	GalleryImagePhashes bool `json:"galleryImagePhashes"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
@@ -61,6 +73,10 @@ type GenerateJob struct {
 
 	overwrite      bool
 	fileNamingAlgo models.HashAlgorithm
+	phashOptions   videophash.Options
+	resumer        *generateResumer
+	// nil if transcripts are not generated
+	transcriber transcribe.Transcriber
 
 	totals totalsGenerate
 }
@@ -68,14 +84,19 @@ type GenerateJob struct {
 type totalsGenerate struct {
 	covers                   int64
 	sprites                  int64
+	barcodes                 int64
 	previews                 int64
 	imagePreviews            int64
 	markers                  int64
 	transcodes               int64
 	phashes                  int64
+	customPhashes            int64
 	interactiveHeatmapSpeeds int64
+	sceneHeatmaps            int64
+	transcripts              int64
 	clipPreviews             int64
 	imageThumbnails          int64
+	imagePhashes             int64
 
 	tasks int
 }
@@ -87,14 +108,41 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 
 	j.overwrite = j.input.Overwrite
 	j.fileNamingAlgo = config.GetInstance().GetVideoFileNamingAlgorithm()
+	j.phashOptions = config.GetInstance().GetPhashOptions()
+
+	if j.input.CustomPhashes {
+		if err := j.phashOptions.Validate(); err != nil {
+			logger.Warnf("Not generating custom phashes: invalid phash options: %v", err)
+			j.input.CustomPhashes = false
+		} else if j.phashOptions.IsDefault() {
+			logger.Warnf("Not generating custom phashes: phash options are the defaults")
+			j.input.CustomPhashes = false
+		}
+	}
+
+	if j.input.Transcripts {
+		j.transcriber = instance.transcriber()
+		if j.transcriber == nil {
+			logger.Warnf("Not generating transcripts: %v", transcribe.ErrNotConfigured)
+		}
+	}
 
 	config := config.GetInstance()
 	parallelTasks := config.GetParallelTasksWithAutoDetection()
 
 	logger.Infof("Generate started with %d parallel tasks", parallelTasks)
 
+	// progress is persisted so that the job can resume if interrupted
+	j.resumer = newGenerateResumer(j.input)
+
+	// stop queuing tasks if the server shuts down. Running tasks are allowed
+	// to finish.
+	queueCtx, cancelQueue := job.WithShutdownCancel(ctx)
+	defer cancelQueue()
+
 	queue := make(chan Task, generateQueueSize)
 	go func() {
+		ctx := queueCtx
 		defer close(queue)
 
 		sceneIDs, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
@@ -128,7 +176,7 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 							return err
 						}
 
-						j.queueSceneJobs(ctx, g, s, queue)
+						j.queueResumableSceneJobs(ctx, g, s, queue)
 					}
 				}
 
@@ -157,6 +205,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.Sprites {
 			logMsg += fmt.Sprintf(" %d sprites", totals.sprites)
 		}
+		if j.input.Barcodes {
+			logMsg += fmt.Sprintf(" %d barcodes", totals.barcodes)
+		}
 		if j.input.Previews {
 			logMsg += fmt.Sprintf(" %d previews", totals.previews)
 		}
@@ -172,15 +223,27 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.Phashes {
 			logMsg += fmt.Sprintf(" %d phashes", totals.phashes)
 		}
+		if j.input.CustomPhashes {
+			logMsg += fmt.Sprintf(" %d custom phashes", totals.customPhashes)
+		}
 		if j.input.InteractiveHeatmapsSpeeds {
 			logMsg += fmt.Sprintf(" %d heatmaps & speeds", totals.interactiveHeatmapSpeeds)
 		}
+		if j.input.SceneHeatmaps {
+			logMsg += fmt.Sprintf(" %d scene heatmaps", totals.sceneHeatmaps)
+		}
+		if j.input.Transcripts {
+			logMsg += fmt.Sprintf(" %d transcripts", totals.transcripts)
+		}
 		if j.input.ClipPreviews {
 			logMsg += fmt.Sprintf(" %d Image Clip Previews", totals.clipPreviews)
 		}
 		if j.input.ImageThumbnails {
 			logMsg += fmt.Sprintf(" %d Image Thumbnails", totals.imageThumbnails)
 		}
+		if j.input.ImagePhashes {
+			logMsg += fmt.Sprintf(" %d Image phashes", totals.imagePhashes)
+		}
 		if logMsg == "Generating" {
 			logMsg = "Nothing selected to generate"
 		}
@@ -190,6 +253,8 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 	}()
 
 	wg := sizedwaitgroup.New(parallelTasks)
+	// tasks run by remote workers do not use local resources
+	remoteWg := sizedwaitgroup.New(maxRemoteTasks)
 
 	// Start measuring how long the generate has taken. (consider moving this up)
 	start := time.Now()
@@ -203,11 +268,30 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		}
 	}()
 
+	generatedPath := config.GetGeneratedPath()
+	var diskErr error
+
 	for f := range queue {
-		if job.IsCancelled(ctx) {
+		if job.IsCancelled(ctx) || job.IsShuttingDown(ctx) {
 			break
 		}
 
+		// don't fill the disk past the reserved space
+		if diskErr = checkReservedDiskSpace(generatedPath); diskErr != nil {
+			logger.Errorf("Stopping generate: %v", diskErr)
+			break
+		}
+
+		if rt, ok := asRemoteTask(f, &wg); ok {
+			remoteWg.Add()
+			go progress.ExecuteTask(rt.GetDescription(), func() {
+				rt.Start(ctx)
+				remoteWg.Done()
+				progress.Increment()
+			})
+			continue
+		}
+
 		wg.Add()
 		// #1879 - need to make a copy of f - otherwise there is a race condition
 		// where f is changed when the goroutine runs
@@ -219,13 +303,29 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		})
 	}
 
+	// remote tasks may fall back to running locally
+	remoteWg.Wait()
 	wg.Wait()
 
+	if job.IsShuttingDown(ctx) {
+		// keep the progress so that the job resumes on the next start
+		j.resumer.save()
+		logger.Info("Generate interrupted by shutdown")
+		return job.ErrInterrupted
+	}
+
+	// jobs that are cancelled or fail are not resumed
+	removeGenerateResumeState()
+
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
 	}
 
+	if diskErr != nil {
+		return diskErr
+	}
+
 	elapsed := time.Since(start)
 	logger.Info(fmt.Sprintf("Generate finished (%s)", elapsed))
 	return nil
@@ -236,6 +336,7 @@ func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, que
 
 	j.queueScenesTasks(ctx, g, queue)
 	j.queueImagesTasks(ctx, g, queue)
+	j.queueGalleryCoverPhashTasks(ctx, queue)
 }
 
 func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generator, queue chan<- Task) {
@@ -266,7 +367,7 @@ func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generato
 				return
 			}
 
-			j.queueSceneJobs(ctx, g, ss, queue)
+			j.queueResumableSceneJobs(ctx, g, ss, queue)
 		}
 
 		if len(scenes) != batchSize {
@@ -284,7 +385,7 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 
 	r := j.repository
 
-	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
+	for more := j.input.ClipPreviews || j.input.ImageThumbnails || j.input.ImagePhashes || j.input.GalleryImagePhashes; more; {
 		if job.IsCancelled(ctx) {
 			return
 		}
@@ -305,6 +406,13 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 				return
 			}
 
+			if j.input.GalleryImagePhashes {
+				if err := ss.LoadGalleryIDs(ctx, r.Image); err != nil {
+					logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
+					return
+				}
+			}
+
 			j.queueImageJob(g, ss, queue)
 		}
 
@@ -352,8 +460,18 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 }
 
 func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task) {
+	// scenes may have no files
+	if f := scene.Files.Primary(); f != nil && isPendingContent(f) {
+		return
+	}
+
 	r := j.repository
 
+	// covers, sprites and previews are extracted from the video file together
+	var coverTask *GenerateCoverTask
+	var spriteTask *GenerateSpriteTask
+	var previewTask *GeneratePreviewTask
+
 	if j.input.Covers {
 		task := &GenerateCoverTask{
 			repository: r,
@@ -363,8 +481,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 
 		if task.required(ctx) {
 			j.totals.covers++
-			j.totals.tasks++
-			queue <- task
+			coverTask = task
 		}
 	}
 
@@ -377,6 +494,20 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 
 		if task.required() {
 			j.totals.sprites++
+			spriteTask = task
+		}
+	}
+
+	if j.input.Barcodes {
+		task := &GenerateBarcodeTask{
+			Scene:               *scene,
+			Overwrite:           j.overwrite,
+			fileNamingAlgorithm: j.fileNamingAlgo,
+			generator:           g,
+		}
+
+		if task.required() {
+			j.totals.barcodes++
 			j.totals.tasks++
 			queue <- task
 		}
@@ -406,11 +537,12 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 				j.totals.imagePreviews++
 			}
 
-			j.totals.tasks++
-			queue <- task
+			previewTask = task
 		}
 	}
 
+	j.queueFrameTasks(coverTask, spriteTask, previewTask, g, scene, queue)
+
 	if j.input.Markers {
 		task := &GenerateMarkersTask{
 			repository:          r,
@@ -466,6 +598,23 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
+	if j.input.CustomPhashes {
+		for _, f := range scene.Files.List() {
+			task := &GenerateCustomPhashTask{
+				repository: r,
+				File:       f,
+				Options:    j.phashOptions,
+				Overwrite:  j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.customPhashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
+	}
+
 	if j.input.InteractiveHeatmapsSpeeds {
 		task := &GenerateInteractiveHeatmapSpeedTask{
 			repository:          r,
@@ -480,6 +629,73 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			queue <- task
 		}
 	}
+
+	if j.input.SceneHeatmaps {
+		task := &GenerateSceneHeatmapTask{
+			repository:          r,
+			Scene:               *scene,
+			Overwrite:           j.overwrite,
+			fileNamingAlgorithm: j.fileNamingAlgo,
+		}
+
+		if task.required() {
+			j.totals.sceneHeatmaps++
+			j.totals.tasks++
+			queue <- task
+		}
+	}
+
+	if j.transcriber != nil {
+		task := &GenerateTranscriptTask{
+			repository:  r,
+			Scene:       *scene,
+			Overwrite:   j.overwrite,
+			transcriber: j.transcriber,
+		}
+
+		if task.required(ctx) {
+			j.totals.transcripts++
+			j.totals.tasks++
+			queue <- task
+		}
+	}
+}
+
+// queueFrameTasks queues the cover, sprite and preview tasks. Tasks requiring
+// frames to be extracted from the video are combined into a single task,
+// unless remote workers are available to run them.
+func (j *GenerateJob) queueFrameTasks(coverTask *GenerateCoverTask, spriteTask *GenerateSpriteTask, previewTask *GeneratePreviewTask, g *generate.Generator, scene *models.Scene, queue chan<- Task) {
+	remote := instance.RemoteWorkers.Active(worker.JobTypeSprite) || instance.RemoteWorkers.Active(worker.JobTypePreview)
+
+	if !remote && (spriteTask != nil || (previewTask != nil && previewTask.videoPreviewRequired())) {
+		j.totals.tasks++
+		queue <- &GenerateSceneFramesTask{
+			Scene:               *scene,
+			Cover:               coverTask,
+			Sprite:              spriteTask,
+			Preview:             previewTask,
+			HardwareDecode:      config.GetInstance().GetGenerateHardwareDecoding(),
+			fileNamingAlgorithm: j.fileNamingAlgo,
+			generator:           g,
+		}
+		return
+	}
+
+	// nothing to batch
+	if coverTask != nil {
+		j.totals.tasks++
+		queue <- coverTask
+	}
+
+	if spriteTask != nil {
+		j.totals.tasks++
+		queue <- spriteTask
+	}
+
+	if previewTask != nil {
+		j.totals.tasks++
+		queue <- previewTask
+	}
 }
 
 func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.SceneMarker, queue chan<- Task) {
@@ -496,6 +712,10 @@ func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.Scene
 }
 
 func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image, queue chan<- Task) {
+	if isPendingContent(image.Files.Primary()) {
+		return
+	}
+
 	if j.input.ImageThumbnails {
 		task := &GenerateImageThumbnailTask{
 			Image:     *image,
@@ -521,4 +741,119 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 			queue <- task
 		}
 	}
+
+	switch {
+	case j.input.ImagePhashes && isAnimatedImage(image):
+		j.queueImagePhashTask(image.Files.Primary(), queue)
+	case j.input.GalleryImagePhashes && len(image.GalleryIDs.List()) > 0:
+		j.queueImagePhashTask(image.Files.Primary(), queue)
+	}
+}
+
+// isAnimatedImage returns true if the primary file of the image is a clip or
+// a GIF, which may be animated.
+func isAnimatedImage(image *models.Image) bool {
+	switch f := image.Files.Primary().(type) {
+	case *models.VideoFile:
+		return true
+	case *models.ImageFile:
+		return f.Format == "gif"
+	}
+
+	return false
+}
+
+// isPendingContent returns true if the contents of f are not downloaded.
+// Generating content for f would download it.
+func isPendingContent(f models.File) bool {
+	if f == nil || !f.Base().PendingContent {
+		return false
+	}
+
+	logger.Debugf("Skipping generation for placeholder file %s", f.Base().Path)
+	return true
+}
+
+func (j *GenerateJob) queueImagePhashTask(f models.File, queue chan<- Task) {
+	if f == nil || isPendingContent(f) {
+		return
+	}
+
+	task := &GenerateImagePhashTask{
+		repository: j.repository,
+		File:       f,
+		Overwrite:  j.overwrite,
+	}
+
+	if task.required() {
+		j.totals.imagePhashes++
+		j.totals.tasks++
+		queue <- task
+	}
+}
+
+// queueGalleryCoverPhashTasks queues phash tasks for the cover images of all
+// galleries. Animated covers are already queued by queueImagesTasks.
+func (j *GenerateJob) queueGalleryCoverPhashTasks(ctx context.Context, queue chan<- Task) {
+	// covers are queued with the other gallery images
+	if !j.input.ImagePhashes || j.input.GalleryImagePhashes {
+		return
+	}
+
+	const batchSize = 1000
+
+	findFilter := models.BatchFindFilter(batchSize)
+	coverRegex := config.GetInstance().GetGalleryCoverRegex()
+
+	r := j.repository
+
+	// covers may be shared between galleries
+	queued := make(map[models.FileID]bool)
+
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		galleries, _, err := r.Gallery.Query(ctx, nil, findFilter)
+		if err != nil {
+			logger.Errorf("Error encountered queuing gallery covers: %s", err.Error())
+			return
+		}
+
+		for _, g := range galleries {
+			if job.IsCancelled(ctx) {
+				return
+			}
+
+			cover, err := image.FindGalleryCover(ctx, r.Image, g.ID, coverRegex)
+			if err != nil {
+				logger.Errorf("Error finding cover for gallery %d: %s", g.ID, err.Error())
+				continue
+			}
+
+			if cover == nil {
+				continue
+			}
+
+			if err := cover.LoadFiles(ctx, r.Image); err != nil {
+				logger.Errorf("Error encountered queuing gallery covers: %s", err.Error())
+				return
+			}
+
+			f := cover.Files.Primary()
+			if f == nil || isAnimatedImage(cover) || queued[f.Base().ID] {
+				continue
+			}
+
+			queued[f.Base().ID] = true
+			j.queueImagePhashTask(f, queue)
+		}
+
+		if len(galleries) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
 }
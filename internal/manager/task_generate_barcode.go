@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+type GenerateBarcodeTask struct {
+	Scene               models.Scene
+	Overwrite           bool
+	fileNamingAlgorithm models.HashAlgorithm
+
+	generator *generate.Generator
+}
+
+func (t *GenerateBarcodeTask) GetDescription() string {
+	return fmt.Sprintf("Generating barcode for %s", t.Scene.Path)
+}
+
+func (t *GenerateBarcodeTask) Start(ctx context.Context) {
+	if !t.required() {
+		return
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	if err := t.generator.Barcode(ctx, t.Scene.Path, sceneHash); err != nil {
+		logger.Errorf("error generating barcode: %v", err)
+		logErrorOutput(err)
+	}
+}
+
+// required returns true if the barcode needs to be generated
+func (t GenerateBarcodeTask) required() bool {
+	if t.Scene.Path == "" {
+		return false
+	}
+
+	if t.Overwrite {
+		return true
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	if sceneHash == "" {
+		return false
+	}
+
+	exists, _ := fsutil.FileExists(instance.Paths.Scene.GetBarcodeFilePath(sceneHash))
+	return !exists
+}
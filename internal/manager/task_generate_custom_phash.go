@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/hash/videophash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GenerateCustomPhashTask generates a phash of a file using the configured
+// phash options. Custom phashes are stored separately from the default
+// phash, which must remain comparable with stash-box fingerprints.
+type GenerateCustomPhashTask struct {
+	repository models.Repository
+	File       *models.VideoFile
+	Options    videophash.Options
+	Overwrite  bool
+}
+
+func (t *GenerateCustomPhashTask) GetDescription() string {
+	return fmt.Sprintf("Generating custom phash for %s", t.File.Path)
+}
+
+func (t *GenerateCustomPhashTask) Start(ctx context.Context) {
+	if !t.required() {
+		return
+	}
+
+	generated, err := videophash.GenerateWithOptions(instance.FFMpeg, t.File, t.Options)
+	if err != nil {
+		logger.Errorf("Error generating custom phash: %v", err)
+		logErrorOutput(err)
+		return
+	}
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		t.File.Fingerprints = t.File.Fingerprints.AppendUnique(models.Fingerprint{
+			Type:        models.FingerprintTypePhashCustom,
+			Fingerprint: int64(*generated),
+		})
+
+		return r.File.Update(ctx, t.File)
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting custom phash: %v", err)
+	}
+}
+
+func (t *GenerateCustomPhashTask) required() bool {
+	if t.Overwrite {
+		return true
+	}
+
+	return t.File.Fingerprints.Get(models.FingerprintTypePhashCustom) == nil
+}
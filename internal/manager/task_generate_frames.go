@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+// number of frames in the generated sprite image
+const spriteChunkCount = 9 * 9
+
+// GenerateSceneFramesTask generates the sprite, cover and video preview of a
+// scene using a single ffmpeg invocation, rather than starting separate ffmpeg
+// processes for each sprite frame and preview segment. If the batched
+// generation cannot be used or fails, each asset is generated separately.
+type GenerateSceneFramesTask struct {
+	Scene models.Scene
+
+	// tasks for the assets to generate. Nil if not required.
+	Cover   *GenerateCoverTask
+	Sprite  *GenerateSpriteTask
+	Preview *GeneratePreviewTask
+
+	HardwareDecode      bool
+	fileNamingAlgorithm models.HashAlgorithm
+
+	generator *generate.Generator
+}
+
+func (t *GenerateSceneFramesTask) GetDescription() string {
+	return fmt.Sprintf("Generating frames for %s", t.Scene.Path)
+}
+
+func (t *GenerateSceneFramesTask) Start(ctx context.Context) {
+	videoFile, err := instance.FFProbe.NewVideoFile(t.Scene.Path)
+	if err != nil {
+		logger.Errorf("error reading video file: %v", err)
+		return
+	}
+
+	if spriteRequiresSlowSeek(*videoFile, spriteChunkCount) || videoFile.FrameRate <= 0.01 {
+		// batched generation only supports seeking by time
+		t.startSeparately(ctx)
+		return
+	}
+
+	options := generate.SceneFramesOptions{
+		Sprite:         t.Sprite != nil,
+		HardwareDecode: t.HardwareDecode,
+	}
+
+	if t.Cover != nil {
+		at := videoFile.VideoStreamDuration * 0.2
+		if t.Cover.ScreenshotAt != nil {
+			at = *t.Cover.ScreenshotAt
+		}
+		options.Screenshot = &at
+	}
+
+	if t.Preview != nil && t.Preview.videoPreviewRequired() {
+		previewOptions := t.Preview.Options
+		if videoFile.AudioCodec == "" {
+			previewOptions.Audio = false
+		}
+		options.Preview = &previewOptions
+	}
+
+	// the tasks have already determined which assets are required
+	g := *t.generator
+	g.Overwrite = true
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	coverImageData, err := g.SceneFrames(ctx, t.Scene.Path, videoFile.VideoStreamDuration, sceneHash, options)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Warnf("[generator] failed generating frames for %s, generating separately: %v", t.Scene.Path, err)
+		logErrorOutput(err)
+		t.startSeparately(ctx)
+		return
+	}
+
+	if t.Cover != nil {
+		t.Cover.saveCover(ctx, coverImageData)
+	}
+
+	if t.Preview != nil && t.Preview.imagePreviewRequired() {
+		if err := t.Preview.generateWebp(sceneHash); err != nil {
+			logger.Errorf("error generating preview webp: %v", err)
+			logErrorOutput(err)
+		}
+	}
+}
+
+func (t *GenerateSceneFramesTask) startSeparately(ctx context.Context) {
+	if t.Cover != nil {
+		t.Cover.Start(ctx)
+	}
+	if t.Sprite != nil {
+		t.Sprite.Start(ctx)
+	}
+	if t.Preview != nil {
+		t.Preview.Start(ctx)
+	}
+}
@@ -0,0 +1,75 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/imagephash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GenerateImagePhashTask generates the phash of an image clip, an animated
+// image or a gallery cover image.
+type GenerateImagePhashTask struct {
+	repository models.Repository
+	File       models.File
+	Overwrite  bool
+}
+
+func (t *GenerateImagePhashTask) GetDescription() string {
+	return fmt.Sprintf("Generating phash for %s", t.File.Base().Path)
+}
+
+func (t *GenerateImagePhashTask) Start(ctx context.Context) {
+	if !t.required() {
+		return
+	}
+
+	generated, err := t.generate()
+	if err != nil {
+		logger.Errorf("Error generating phash for %s: %v", t.File.Base().Path, err)
+		logErrorOutput(err)
+		return
+	}
+
+	hash := int64(*generated)
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		base := t.File.Base()
+		base.Fingerprints = base.Fingerprints.AppendUnique(models.Fingerprint{
+			Type:        models.FingerprintTypePhash,
+			Fingerprint: hash,
+		})
+
+		return r.File.Update(ctx, t.File)
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting phash: %v", err)
+	}
+}
+
+func (t *GenerateImagePhashTask) generate() (*uint64, error) {
+	if vf, ok := t.File.(*models.VideoFile); ok {
+		return imagephash.GenerateClip(instance.FFMpeg, vf)
+	}
+
+	logger.Infof("[generator] generating phash for %s", t.File.Base().Path)
+
+	rc, err := t.File.Base().Open(&file.OsFS{})
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer rc.Close()
+
+	return imagephash.Generate(rc)
+}
+
+func (t *GenerateImagePhashTask) required() bool {
+	if t.Overwrite {
+		return true
+	}
+
+	return t.File.Base().Fingerprints.Get(models.FingerprintTypePhash) == nil
+}
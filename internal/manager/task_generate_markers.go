@@ -3,7 +3,6 @@ package manager
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
@@ -90,8 +89,8 @@ func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 
 	// Make the folder for the scenes markers
-	markersFolder := filepath.Join(instance.Paths.Generated.Markers, sceneHash)
-	if err := fsutil.EnsureDir(markersFolder); err != nil {
+	markersFolder := instance.Paths.SceneMarkers.GetFolderPath(sceneHash)
+	if err := fsutil.EnsureDirAll(markersFolder); err != nil {
 		logger.Warnf("could not create the markers folder (%v): %v", markersFolder, err)
 	}
 
@@ -122,7 +121,7 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *models.VideoFile, scene
 	}
 
 	if t.Screenshot {
-		if err := g.SceneMarkerScreenshot(context.TODO(), videoFile.Path, sceneHash, seconds, videoFile.Width); err != nil {
+		if err := g.SceneMarkerScreenshot(context.TODO(), videoFile.Path, sceneHash, seconds, sceneMarker.ScreenshotSeconds(), videoFile.Width); err != nil {
 			logger.Errorf("[generator] failed to generate marker screenshot: %v", err)
 			logErrorOutput(err)
 		}
@@ -52,17 +52,21 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		hash = int64(*generated)
 	}
 
+	if err := t.setPhash(ctx, hash); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting phash: %v", err)
+	}
+}
+
+func (t *GeneratePhashTask) setPhash(ctx context.Context, hash int64) error {
 	r := t.repository
-	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+	return r.WithTxn(ctx, func(ctx context.Context) error {
 		t.File.Fingerprints = t.File.Fingerprints.AppendUnique(models.Fingerprint{
 			Type:        models.FingerprintTypePhash,
 			Fingerprint: hash,
 		})
 
 		return r.File.Update(ctx, t.File)
-	}); err != nil && ctx.Err() == nil {
-		logger.Errorf("Error setting phash: %v", err)
-	}
+	})
 }
 
 func (t *GeneratePhashTask) findExistingPhash(ctx context.Context) (interface{}, error) {
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GenerateSceneHeatmapTask generates a heatmap combining the interactive
+// intensity, marker density and watch data of a scene.
+type GenerateSceneHeatmapTask struct {
+	repository          models.Repository
+	Scene               models.Scene
+	Overwrite           bool
+	fileNamingAlgorithm models.HashAlgorithm
+}
+
+func (t *GenerateSceneHeatmapTask) GetDescription() string {
+	return fmt.Sprintf("Generating heatmap for %s", t.Scene.Path)
+}
+
+func (t *GenerateSceneHeatmapTask) Start(ctx context.Context) {
+	if !t.required() {
+		return
+	}
+
+	primaryFile := t.Scene.Files.Primary()
+	input := SceneHeatmapInput{
+		Duration: primaryFile.Duration,
+	}
+
+	r := t.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		input.Markers, err = r.SceneMarker.FindBySceneID(ctx, t.Scene.ID)
+		if err != nil {
+			return fmt.Errorf("finding markers: %w", err)
+		}
+
+		input.WatchHeat, err = r.Scene.GetWatchHeat(ctx, t.Scene.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		if ctx.Err() == nil {
+			logger.Errorf("error generating heatmap for %s: %v", t.Scene.Path, err)
+		}
+		return
+	}
+
+	if primaryFile.Interactive {
+		funscriptPath := video.GetFunscriptPath(t.Scene.Path)
+		funscript, err := (&InteractiveHeatmapSpeedGenerator{}).LoadFunscriptData(funscriptPath, primaryFile.Duration)
+		if err != nil {
+			logger.Warnf("error loading funscript for %s: %v", t.Scene.Path, err)
+		} else {
+			funscript.UpdateIntensityAndSpeed()
+			input.Funscript = &funscript
+		}
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	imagePath := instance.Paths.Scene.GetHeatmapPath(sceneHash)
+	dataPath := instance.Paths.Scene.GetHeatmapDataPath(sceneHash)
+
+	if err := NewSceneHeatmapGenerator().Generate(input, imagePath, dataPath); err != nil {
+		logger.Errorf("error generating heatmap for %s: %v", t.Scene.Path, err)
+	}
+}
+
+func (t *GenerateSceneHeatmapTask) required() bool {
+	primaryFile := t.Scene.Files.Primary()
+	if primaryFile == nil || primaryFile.Duration <= 0 {
+		return false
+	}
+
+	if t.Overwrite {
+		return true
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	return !t.doesHeatmapExist(sceneHash)
+}
+
+func (t *GenerateSceneHeatmapTask) doesHeatmapExist(sceneChecksum string) bool {
+	if sceneChecksum == "" {
+		return false
+	}
+
+	imageExists, _ := fsutil.FileExists(instance.Paths.Scene.GetHeatmapPath(sceneChecksum))
+	dataExists, _ := fsutil.FileExists(instance.Paths.Scene.GetHeatmapDataPath(sceneChecksum))
+	return imageExists && dataExists
+}
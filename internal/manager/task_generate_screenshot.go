@@ -72,6 +72,12 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		return
 	}
 
+	t.saveCover(ctx, coverImageData)
+}
+
+// saveCover sets the scene cover to the generated screenshot.
+func (t *GenerateCoverTask) saveCover(ctx context.Context, coverImageData []byte) {
+	r := t.repository
 	if err := r.WithTxn(ctx, func(ctx context.Context) error {
 		qb := r.Scene
 		scenePartial := models.NewScenePartial()
@@ -82,8 +88,7 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		}
 
 		// update the scene with the update date
-		_, err = qb.UpdatePartial(ctx, t.Scene.ID, scenePartial)
-		if err != nil {
+		if _, err := qb.UpdatePartial(ctx, t.Scene.ID, scenePartial); err != nil {
 			return fmt.Errorf("error updating scene: %v", err)
 		}
 
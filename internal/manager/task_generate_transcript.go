@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/transcribe"
+)
+
+// transcriber returns the configured speech-to-text engine, or nil if none
+// is configured. A whisper.cpp binary takes precedence over the API.
+func (s *Manager) transcriber() transcribe.Transcriber {
+	cfg := s.Config
+	language := cfg.GetTranscribeLanguage()
+
+	if path := cfg.GetTranscribeWhisperPath(); path != "" {
+		return transcribe.Whisper{
+			Path:     path,
+			Model:    cfg.GetTranscribeWhisperModel(),
+			Language: language,
+		}
+	}
+
+	if u := cfg.GetTranscribeAPIURL(); u != "" {
+		return transcribe.API{
+			URL:      u,
+			APIKey:   cfg.GetTranscribeAPIKey(),
+			Model:    cfg.GetTranscribeAPIModel(),
+			Language: language,
+		}
+	}
+
+	return nil
+}
+
+// GenerateTranscriptTask transcribes the audio of a scene.
+type GenerateTranscriptTask struct {
+	repository  models.Repository
+	Scene       models.Scene
+	Overwrite   bool
+	transcriber transcribe.Transcriber
+}
+
+func (t *GenerateTranscriptTask) GetDescription() string {
+	return fmt.Sprintf("Transcribing audio of %s", t.Scene.Path)
+}
+
+func (t *GenerateTranscriptTask) Start(ctx context.Context) {
+	f := t.Scene.Files.Primary()
+
+	transcript, err := t.transcribe(ctx, f.Path)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Errorf("error transcribing %s: %v", t.Scene.Path, err)
+			logErrorOutput(err)
+		}
+		return
+	}
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.Scene.SetTranscript(ctx, models.SceneTranscript{
+			SceneID:  t.Scene.ID,
+			Language: transcript.Language,
+			Segments: transcript.Segments,
+		})
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("error saving transcript of %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	logger.Infof("Transcribed %d segments of %s", len(transcript.Segments), t.Scene.Path)
+}
+
+// transcribe extracts the audio of the video to a temporary file and
+// transcribes it.
+func (t *GenerateTranscriptTask) transcribe(ctx context.Context, path string) (*transcribe.Transcript, error) {
+	tmp, err := os.CreateTemp(instance.Paths.Generated.Tmp, "transcript-*.wav")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := instance.FFMpeg.Generate(ctx, transcoder.ExtractAudio(path, tmp.Name())); err != nil {
+		return nil, fmt.Errorf("extracting audio: %w", err)
+	}
+
+	return t.transcriber.Transcribe(ctx, tmp.Name())
+}
+
+func (t *GenerateTranscriptTask) required(ctx context.Context) bool {
+	f := t.Scene.Files.Primary()
+	if f == nil || f.AudioCodec == "" {
+		return false
+	}
+
+	if t.Overwrite {
+		return true
+	}
+
+	existing, err := t.repository.Scene.GetTranscript(ctx, t.Scene.ID)
+	if err != nil {
+		logger.Errorf("error getting transcript of %s: %v", t.Scene.Path, err)
+		return false
+	}
+
+	return existing == nil
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/scraper/federation"
+	"github.com/stashapp/stash/pkg/scraper/local"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 var ErrInput = errors.New("invalid request input")
@@ -24,6 +29,10 @@ type IdentifyJob struct {
 
 	stashBoxes []*models.StashBox
 	progress   *job.Progress
+
+	artifacts *job.ArtifactStore
+	// rows of the identify report
+	results [][]string
 }
 
 func CreateIdentifyJob(input identify.Options) *IdentifyJob {
@@ -31,9 +40,49 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 		postHookExecutor: instance.PluginCache,
 		input:            input,
 		stashBoxes:       instance.Config.GetStashBoxes(),
+		artifacts:        instance.ArtifactStore,
 	}
 }
 
+// getIdentifyOptions returns the identify options of the named preset. If
+// preset is empty, the default identify settings are returned, or nil if
+// they are not set.
+func getIdentifyOptions(cfg *config.Config, preset string) (*identify.Options, error) {
+	var ret identify.Options
+	if preset != "" {
+		ret.Preset = &preset
+	} else {
+		defaults := cfg.GetDefaultIdentifySettings()
+		if defaults == nil {
+			return nil, nil
+		}
+		ret = *defaults
+	}
+
+	if ret.Preset != nil && *ret.Preset != "" {
+		p := cfg.GetIdentifyPreset(*ret.Preset)
+		if p == nil {
+			return nil, fmt.Errorf("identify preset %q not found", *ret.Preset)
+		}
+
+		ret = ret.ApplyPreset(*p)
+	}
+
+	return &ret, nil
+}
+
+// identifySceneIDs identifies the scenes with the given ids, ignoring the
+// paths and scene ids of input.
+func identifySceneIDs(ctx context.Context, progress *job.Progress, input identify.Options, sceneIDs []int) error {
+	input.Paths = nil
+	input.SceneIDs = make([]string, len(sceneIDs))
+	for i, id := range sceneIDs {
+		input.SceneIDs[i] = strconv.Itoa(id)
+	}
+
+	return CreateIdentifyJob(input).Execute(ctx, progress)
+}
+
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
 
@@ -86,9 +135,24 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 		return fmt.Errorf("error encountered while identifying scenes: %w", err)
 	}
 
+	if err := j.writeArtifact(); err != nil {
+		logger.Warnf("error writing identify report: %v", err)
+	}
+
 	return nil
 }
 
+// writeArtifact writes the outcome of each identified scene as a CSV
+// artifact of the job.
+func (j *IdentifyJob) writeArtifact() error {
+	if j.artifacts == nil || len(j.results) == 0 {
+		return nil
+	}
+
+	header := []string{"scene_id", "path", "outcome", "source", "error"}
+	return j.artifacts.WriteCSV(j.progress, "identify", header, j.results)
+}
+
 func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.ScraperSource) error {
 	r := instance.Repository
 
@@ -133,6 +197,7 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 		return
 	}
 
+	var result *identify.Result
 	var taskError error
 	j.progress.ExecuteTask("Identifying "+s.Path, func() {
 		r := instance.Repository
@@ -143,17 +208,26 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 			PerformerCreator:   r.Performer,
 			TagFinderCreator:   r.Tag,
 
+			SceneMarkerReaderCreator:     r.SceneMarker,
+			SceneFieldSourceReaderWriter: r.Scene,
+
 			DefaultOptions:              j.input.Options,
 			Sources:                     sources,
 			SceneUpdatePostHookExecutor: j.postHookExecutor,
 		}
 
-		taskError = task.Identify(ctx, s)
+		result, taskError = task.IdentifyWithResult(ctx, s)
 	})
 
+	row := []string{strconv.Itoa(s.ID), s.Path, "error", "", ""}
 	if taskError != nil {
 		logger.Errorf("Error encountered identifying %s: %v", s.Path, taskError)
+		row[4] = taskError.Error()
+	} else {
+		row[2] = string(result.Outcome)
+		row[3] = result.Source
 	}
+	j.results = append(j.results, row)
 
 	j.progress.Increment()
 }
@@ -161,6 +235,28 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	var ret []identify.ScraperSource
 	for _, source := range j.input.Sources {
+		if utils.IsTrue(source.Source.LocalFiles) {
+			ret = append(ret, identify.ScraperSource{
+				Name:       "local files",
+				Options:    source.Options,
+				Scraper:    localSource{local.NewScraper(local.NewRepository(instance.Repository))},
+				SourceType: models.FieldSourceTypeLocalFiles,
+				PreScrape:  true,
+			})
+			continue
+		}
+
+		if source.Source.FederatedInstanceURL != nil {
+			src, err := j.getFederatedSource(*source.Source.FederatedInstanceURL)
+			if err != nil {
+				return nil, err
+			}
+
+			src.Options = source.Options
+			ret = append(ret, *src)
+			continue
+		}
+
 		// get scraper source
 		stashBox, err := j.getStashBox(source.Source)
 		if err != nil {
@@ -177,6 +273,8 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 					stashBox.Endpoint,
 				},
 				RemoteSite: stashBox.Endpoint,
+				SourceType: models.FieldSourceTypeStashBox,
+				SourceID:   stashBox.Endpoint,
 			}
 		} else {
 			scraperID := *source.Source.ScraperID
@@ -190,6 +288,8 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 					cache:     instance.ScraperCache,
 					scraperID: scraperID,
 				},
+				SourceType: models.FieldSourceTypeScraper,
+				SourceID:   scraperID,
 			}
 		}
 
@@ -200,6 +300,21 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	return ret, nil
 }
 
+func (j *IdentifyJob) getFederatedSource(url string) (*identify.ScraperSource, error) {
+	fi := instance.Config.GetFederatedInstance(url)
+	if fi == nil {
+		return nil, fmt.Errorf("%w: federated instance with url %q", models.ErrNotFound, url)
+	}
+
+	repo := federation.NewRepository(instance.Repository)
+	return &identify.ScraperSource{
+		Name:       "federated stash: " + fi.URL,
+		Scraper:    federationSource{federation.NewClient(*fi, repo)},
+		SourceType: models.FieldSourceTypeFederated,
+		SourceID:   fi.URL,
+	}, nil
+}
+
 func (j *IdentifyJob) getStashBox(src *scraper.Source) (*models.StashBox, error) {
 	if src.ScraperID != nil {
 		return nil, nil
@@ -207,7 +322,7 @@ func (j *IdentifyJob) getStashBox(src *scraper.Source) (*models.StashBox, error)
 
 	// must be stash-box
 	if src.StashBoxIndex == nil && src.StashBoxEndpoint == nil {
-		return nil, fmt.Errorf("%w: stash_box_index or stash_box_endpoint or scraper_id must be set", ErrInput)
+		return nil, fmt.Errorf("%w: stash_box_index or stash_box_endpoint or scraper_id or federated_instance_url or local_files must be set", ErrInput)
 	}
 
 	return resolveStashBox(j.stashBoxes, *src)
@@ -266,6 +381,36 @@ func (s stashboxSource) String() string {
 	return fmt.Sprintf("stash-box %s", s.endpoint)
 }
 
+type federationSource struct {
+	*federation.Client
+}
+
+func (s federationSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scraper.ScrapedScene, error) {
+	results, err := s.FindSceneByFingerprints(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying federated instance using scene ID %d: %w", sceneID, err)
+	}
+
+	return results, nil
+}
+
+type localSource struct {
+	*local.Scraper
+}
+
+func (s localSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scraper.ScrapedScene, error) {
+	result, err := s.ScrapeScene(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading local files of scene ID %d: %w", sceneID, err)
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return []*scraper.ScrapedScene{result}, nil
+}
+
 type scraperSource struct {
 	cache     *scraper.Cache
 	scraperID string
@@ -19,6 +19,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/note"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/scene"
@@ -222,7 +223,28 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 				Input:        *performerJSON,
 			}
 
-			return performImport(ctx, importer, t.DuplicateBehaviour)
+			if err := performImport(ctx, importer, t.DuplicateBehaviour); err != nil {
+				return err
+			}
+
+			// skipped as a duplicate
+			if importer.ID == 0 {
+				return nil
+			}
+
+			for _, n := range performerJSON.Notes {
+				noteImporter := &note.Importer{
+					PerformerID:  &importer.ID,
+					Input:        n,
+					ReaderWriter: r.Note,
+				}
+
+				if err := performImport(ctx, noteImporter, t.DuplicateBehaviour); err != nil {
+					return err
+				}
+			}
+
+			return nil
 		}); err != nil {
 			logger.Errorf("[performers] <%s> import failed: %v", fi.Name(), err)
 		}
@@ -632,9 +654,10 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 
 func (t *ImportTask) importTag(ctx context.Context, tagJSON *jsonschema.Tag, pendingParent map[string][]*jsonschema.Tag, fail bool) error {
 	importer := &tag.Importer{
-		ReaderWriter:        t.repository.Tag,
-		Input:               *tagJSON,
-		MissingRefBehaviour: t.MissingRefBehaviour,
+		ReaderWriter:         t.repository.Tag,
+		CategoryReaderWriter: t.repository.TagCategory,
+		Input:                *tagJSON,
+		MissingRefBehaviour:  t.MissingRefBehaviour,
 	}
 
 	// first phase: return error if parent does not exist
@@ -724,6 +747,21 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 				}
 			}
 
+			// import the scene notes
+			if sceneImporter.ID != 0 {
+				for _, n := range sceneJSON.Notes {
+					noteImporter := &note.Importer{
+						SceneID:      &sceneImporter.ID,
+						Input:        n,
+						ReaderWriter: r.Note,
+					}
+
+					if err := performImport(ctx, noteImporter, t.DuplicateBehaviour); err != nil {
+						return err
+					}
+				}
+			}
+
 			return nil
 		}); err != nil {
 			logger.Errorf("[scenes] <%s> import failed: %v", fi.Name(), err)
@@ -771,6 +809,7 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 				PerformerWriter: r.Performer,
 				StudioWriter:    r.Studio,
 				TagWriter:       r.Tag,
+				RegionWriter:    r.ImageRegion,
 			}
 
 			return performImport(ctx, imageImporter, t.DuplicateBehaviour)
@@ -821,6 +860,7 @@ func (t *ImportTask) ImportSavedFilters(ctx context.Context) {
 func (t *ImportTask) importSavedFilter(ctx context.Context, savedFilterJSON *jsonschema.SavedFilter) error {
 	importer := &savedfilter.Importer{
 		ReaderWriter:        t.repository.SavedFilter,
+		FolderWriter:        t.repository.SavedFilterFolder,
 		Input:               *savedFilterJSON,
 		MissingRefBehaviour: t.MissingRefBehaviour,
 	}
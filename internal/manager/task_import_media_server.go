@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/mediaserver"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const mediaServerImportReportFile = "media_server_import_report.json"
+
+type ImportMediaServerInput struct {
+	Type mediaserver.Type `json:"type"`
+	// Path to the Plex or Kodi database file
+	DatabasePath *string `json:"database_path"`
+	// Plex account to import. Defaults to the server owner.
+	PlexAccountID *int `json:"plex_account_id"`
+	// Jellyfin server URL, API key and user ID
+	URL    *string `json:"url"`
+	APIKey *string `json:"api_key"`
+	UserID *string `json:"user_id"`
+
+	PathMappings []*mediaserver.PathMapping `json:"path_mappings"`
+
+	// Values to import. Each defaults to true.
+	PlayCount   *bool `json:"play_count"`
+	ResumeTime  *bool `json:"resume_time"`
+	Rating      *bool `json:"rating"`
+	Collections *bool `json:"collections"`
+	// Overwrite existing resume times and ratings. Defaults to false.
+	Overwrite *bool `json:"overwrite"`
+}
+
+func (i ImportMediaServerInput) reader() (mediaserver.Reader, error) {
+	str := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	switch i.Type {
+	case mediaserver.TypePlex, mediaserver.TypeKodi:
+		if str(i.DatabasePath) == "" {
+			return nil, fmt.Errorf("database path is required for %s", i.Type)
+		}
+
+		if i.Type == mediaserver.TypeKodi {
+			return mediaserver.KodiReader{DatabasePath: *i.DatabasePath}, nil
+		}
+
+		accountID := mediaserver.DefaultPlexAccountID
+		if i.PlexAccountID != nil {
+			accountID = *i.PlexAccountID
+		}
+
+		return mediaserver.PlexReader{DatabasePath: *i.DatabasePath, AccountID: accountID}, nil
+	case mediaserver.TypeJellyfin:
+		return mediaserver.JellyfinReader{
+			URL:    str(i.URL),
+			APIKey: str(i.APIKey),
+			UserID: str(i.UserID),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported media server type %q", i.Type)
+}
+
+func (i ImportMediaServerInput) options() mediaserver.Options {
+	orDefault := func(v *bool, def bool) bool {
+		if v == nil {
+			return def
+		}
+		return *v
+	}
+
+	ret := mediaserver.Options{
+		PlayCount:   orDefault(i.PlayCount, true),
+		ResumeTime:  orDefault(i.ResumeTime, true),
+		Rating:      orDefault(i.Rating, true),
+		Collections: orDefault(i.Collections, true),
+		Overwrite:   orDefault(i.Overwrite, false),
+	}
+
+	for _, m := range i.PathMappings {
+		if m != nil {
+			ret.PathMappings = append(ret.PathMappings, *m)
+		}
+	}
+
+	return ret
+}
+
+func mediaServerImportReportPath() string {
+	return filepath.Join(config.GetInstance().GetConfigPath(), mediaServerImportReportFile)
+}
+
+// GetMediaServerImportReport returns the report of the last media server
+// import, or nil if no import has been run.
+func GetMediaServerImportReport() (*mediaserver.Report, error) {
+	data, err := os.ReadFile(mediaServerImportReportPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ret mediaserver.Report
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("reading media server import report: %w", err)
+	}
+
+	return &ret, nil
+}
+
+func saveMediaServerImportReport(r *mediaserver.Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(mediaServerImportReportPath(), data, 0644)
+}
+
+type ImportMediaServerJob struct {
+	repository models.Repository
+	input      ImportMediaServerInput
+}
+
+func (s *Manager) ImportMediaServer(ctx context.Context, input ImportMediaServerInput) (int, error) {
+	// validate the input before queuing the job
+	if _, err := input.reader(); err != nil {
+		return 0, err
+	}
+
+	j := &ImportMediaServerJob{
+		repository: s.Repository,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Importing watch state from %s...", input.Type), j), nil
+}
+
+func (j *ImportMediaServerJob) Execute(ctx context.Context, progress *job.Progress) error {
+	reader, err := j.input.reader()
+	if err != nil {
+		return err
+	}
+
+	var items []mediaserver.Item
+	progress.ExecuteTask(fmt.Sprintf("Reading items from %s", j.input.Type), func() {
+		items, err = reader.Items(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("reading items from %s: %w", j.input.Type, err)
+	}
+
+	r := j.repository
+	report := &mediaserver.Report{
+		Type:      j.input.Type,
+		StartedAt: time.Now(),
+		Unmatched: []string{},
+		Ambiguous: []string{},
+	}
+	importer := &mediaserver.Importer{
+		File:    r.File,
+		Scene:   r.Scene,
+		Group:   r.Group,
+		Options: j.input.options(),
+		Report:  report,
+	}
+
+	progress.SetTotal(len(items))
+
+	for _, item := range items {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			break
+		}
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return importer.ImportItem(ctx, item)
+		}); err != nil {
+			logger.Errorf("Error importing %s: %v", item.Path, err)
+		}
+
+		progress.Increment()
+	}
+
+	report.EndedAt = time.Now()
+
+	if err := saveMediaServerImportReport(report); err != nil {
+		logger.Warnf("error saving media server import report: %v", err)
+	}
+
+	logger.Infof("Imported watch state from %s: %d items, %d matched by path, %d matched by basename and size, %d unmatched, %d ambiguous",
+		j.input.Type, report.Total, report.MatchedPath, report.MatchedSize, len(report.Unmatched), len(report.Ambiguous))
+
+	return nil
+}
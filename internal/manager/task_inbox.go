@@ -0,0 +1,475 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+const (
+	inboxScheduleCheckInterval = time.Minute
+
+	// files modified more recently than this are assumed to still be
+	// downloading, and are left for the next check
+	inboxSettleTime = time.Minute
+)
+
+var ErrInboxNotConfigured = errors.New("inbox is not configured")
+
+type InboxItemStatus string
+
+const (
+	InboxItemStatusMoved  InboxItemStatus = "MOVED"
+	InboxItemStatusFailed InboxItemStatus = "FAILED"
+)
+
+func (e InboxItemStatus) IsValid() bool {
+	switch e {
+	case InboxItemStatusMoved, InboxItemStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e InboxItemStatus) String() string {
+	return string(e)
+}
+
+func (e *InboxItemStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InboxItemStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InboxItemStatus", str)
+	}
+	return nil
+}
+
+func (e InboxItemStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type InboxReportItem struct {
+	Path string `json:"path"`
+	// NewPath is empty if the file was not moved
+	NewPath string          `json:"new_path"`
+	Status  InboxItemStatus `json:"status"`
+	// Error contains the reason the file was not moved, if applicable
+	Error string `json:"error"`
+}
+
+// InboxReport lists the files that were moved out of the inbox, and the
+// files that were left in place, by the most recent processing of the inbox.
+type InboxReport struct {
+	CreatedAt time.Time `json:"created_at"`
+	// JournalID is the ID of the organize journal of the moved files
+	JournalID *string            `json:"journal_id"`
+	Items     []*InboxReportItem `json:"items"`
+}
+
+func (r *InboxReport) moved(m organize.Move) {
+	r.Items = append(r.Items, &InboxReportItem{
+		Path:    m.OldPath,
+		NewPath: m.NewPath,
+		Status:  InboxItemStatusMoved,
+	})
+}
+
+func (r *InboxReport) failed(path string, reason string) {
+	logger.Warnf("Leaving %s in inbox: %s", path, reason)
+	r.Items = append(r.Items, &InboxReportItem{
+		Path:   path,
+		Status: InboxItemStatusFailed,
+		Error:  reason,
+	})
+}
+
+// inboxReportStore holds the report of the most recent processing of the inbox.
+type inboxReportStore struct {
+	report *InboxReport
+	mutex  sync.Mutex
+}
+
+func (s *inboxReportStore) get() *InboxReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.report
+}
+
+func (s *inboxReportStore) set(r *InboxReport) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.report = r
+}
+
+// LastInboxReport returns the report of the most recent processing of the
+// inbox, or nil if the inbox has not been processed.
+func (s *Manager) LastInboxReport() *InboxReport {
+	return s.inboxReports.get()
+}
+
+// ValidateInbox returns an error if the inbox configuration is invalid, or
+// if the inbox or destination are not within a library path.
+func ValidateInbox(inbox organize.Inbox) error {
+	if err := inbox.Validate(); err != nil {
+		return err
+	}
+
+	stashPaths := config.GetInstance().GetStashPaths()
+	if stashPaths.GetStashFromDirPath(inbox.Path) == nil {
+		return fmt.Errorf("inbox path %s must be within a stash library path", inbox.Path)
+	}
+	if stashPaths.GetStashFromDirPath(inbox.Destination) == nil {
+		return fmt.Errorf("inbox destination %s must be within a stash library path", inbox.Destination)
+	}
+
+	return nil
+}
+
+// ProcessInbox queues a job that scans and identifies the files in the
+// inbox, and moves the identified files into the library.
+func (s *Manager) ProcessInbox(ctx context.Context) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	j, err := s.newInboxJob()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.JobManager.Add(ctx, "Processing inbox...", j), nil
+}
+
+func (s *Manager) newInboxJob() (*InboxJob, error) {
+	inbox := s.Config.GetInbox()
+	if inbox == nil {
+		return nil, ErrInboxNotConfigured
+	}
+
+	if err := ValidateInbox(*inbox); err != nil {
+		return nil, err
+	}
+
+	return &InboxJob{
+		manager:  s,
+		inbox:    *inbox,
+		journals: GetOrganizeJournalStore(),
+	}, nil
+}
+
+type InboxJob struct {
+	manager  *Manager
+	inbox    organize.Inbox
+	journals organize.JournalStore
+}
+
+func (j *InboxJob) Execute(ctx context.Context, progress *job.Progress) error {
+	mgr := j.manager
+
+	scanJob := ScanJob{
+		scanner: mgr.newScanner(),
+		input: ScanMetadataInput{
+			Paths: []string{j.inbox.Path},
+		},
+		subscriptions: mgr.scanSubs,
+	}
+	if err := scanJob.Execute(ctx, progress); err != nil {
+		return fmt.Errorf("scanning inbox: %w", err)
+	}
+
+	if job.IsCancelled(ctx) {
+		return nil
+	}
+
+	sceneIDs, err := j.findScenes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(sceneIDs) == 0 {
+		logger.Info("No scenes in inbox")
+		mgr.inboxReports.set(&InboxReport{CreatedAt: time.Now()})
+		return nil
+	}
+
+	if err := j.identify(ctx, progress, sceneIDs); err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		return nil
+	}
+
+	report := &InboxReport{
+		CreatedAt: time.Now(),
+	}
+
+	pending, err := j.plan(ctx, sceneIDs, report)
+	if err != nil {
+		return err
+	}
+
+	if err := j.move(ctx, progress, pending, report); err != nil {
+		return err
+	}
+
+	mgr.inboxReports.set(report)
+	return nil
+}
+
+func (j *InboxJob) findScenes(ctx context.Context) ([]int, error) {
+	r := j.manager.Repository
+
+	perPage := -1
+	var ret []int
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		result, err := r.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: &models.FindFilterType{
+					PerPage: &perPage,
+				},
+			},
+			SceneFilter: scene.FilterFromPaths([]string{j.inbox.Path}),
+		})
+		if err != nil {
+			return err
+		}
+
+		ret = result.IDs
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding scenes in inbox: %w", err)
+	}
+
+	return ret, nil
+}
+
+// identify identifies the scenes using the default identify settings.
+func (j *InboxJob) identify(ctx context.Context, progress *job.Progress, sceneIDs []int) error {
+	input, err := getIdentifyOptions(j.manager.Config, "")
+	if err != nil {
+		return err
+	}
+
+	if input == nil {
+		logger.Warn("Default identify settings are not set. Inbox scenes will not be identified")
+		return nil
+	}
+
+	if err := identifySceneIDs(ctx, progress, *input, sceneIDs); err != nil {
+		return fmt.Errorf("identifying inbox scenes: %w", err)
+	}
+
+	return nil
+}
+
+// plan returns the moves of the primary files of the identified scenes.
+// Scenes that cannot be moved are added to the report as failed.
+func (j *InboxJob) plan(ctx context.Context, sceneIDs []int, report *InboxReport) ([]organize.Move, error) {
+	r := j.manager.Repository
+	tmpl := organize.Template(j.inbox.Template)
+	planner := organize.NewPlanner(&file.OsFS{}, j.inbox.Collision)
+
+	var ret []organize.Move
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		for _, id := range sceneIDs {
+			s, err := r.Scene.Find(ctx, id)
+			if err != nil {
+				return fmt.Errorf("finding scene %d: %w", id, err)
+			}
+			if s == nil {
+				continue
+			}
+
+			if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+				return fmt.Errorf("loading primary file for scene %d: %w", id, err)
+			}
+
+			f := s.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			if time.Since(f.ModTime) < inboxSettleTime {
+				logger.Debugf("Leaving %s in inbox: recently modified", f.Path)
+				continue
+			}
+
+			if f.ZipFileID != nil {
+				report.failed(f.Path, "file is in a zip file")
+				continue
+			}
+
+			if s.Title == "" {
+				report.failed(f.Path, "scene was not identified")
+				continue
+			}
+
+			fields, err := organizeSceneFields(ctx, r, s, f)
+			if err != nil {
+				return err
+			}
+
+			relPath, err := tmpl.Render(fields)
+			if err != nil {
+				report.failed(f.Path, err.Error())
+				continue
+			}
+
+			m, err := planner.Add(f.ID, f.Path, filepath.Join(j.inbox.Destination, relPath))
+			switch {
+			case err != nil:
+				report.failed(f.Path, err.Error())
+			case m.Skipped != "":
+				report.failed(f.Path, m.Skipped)
+			default:
+				ret = append(ret, m)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("planning inbox moves: %w", err)
+	}
+
+	return ret, nil
+}
+
+// move performs each move in its own transaction, so that a failed move
+// leaves the file in the inbox without affecting the other files. The
+// moved files are recorded in an organize journal so that they can be
+// rolled back.
+func (j *InboxJob) move(ctx context.Context, progress *job.Progress, moves []organize.Move, report *InboxReport) error {
+	if len(moves) == 0 {
+		return nil
+	}
+
+	r := j.manager.Repository
+
+	// write the journal before moving anything, so that an interrupted
+	// operation can be identified
+	journal, err := j.journals.Create(j.inbox.Template, moves)
+	if err != nil {
+		return fmt.Errorf("creating journal: %w", err)
+	}
+
+	progress.SetTotal(len(moves))
+
+	var moved []organize.Move
+	for _, m := range moves {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			mover := file.NewMover(r.File, r.Folder)
+			mover.RegisterHooks(ctx)
+
+			return executeOrganizeMove(ctx, r, mover, m)
+		}); err != nil {
+			report.failed(m.OldPath, err.Error())
+		} else {
+			report.moved(m)
+			moved = append(moved, m)
+		}
+
+		progress.Increment()
+	}
+
+	if len(moved) == 0 {
+		if err := j.journals.Delete(journal.ID); err != nil {
+			logger.Warnf("error removing organize journal %s: %v", journal.ID, err)
+		}
+	} else {
+		journal.Moves = moved
+		if err := j.journals.SetStatus(journal, organize.JournalStatusCompleted); err != nil {
+			logger.Warnf("error updating organize journal %s: %v", journal.ID, err)
+		}
+		report.JournalID = &journal.ID
+	}
+
+	logger.Infof("Moved %d files from inbox. %d files left in place", len(moved), len(report.Items)-len(moved))
+	return nil
+}
+
+// inboxScheduler queues an InboxJob when the configured inbox interval has
+// elapsed since the inbox was last processed.
+type inboxScheduler struct {
+	manager *Manager
+	queued  atomic.Bool
+
+	// only accessed from the scheduler goroutine
+	lastRun time.Time
+}
+
+func (s *inboxScheduler) start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(inboxScheduleCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.check(ctx)
+			}
+		}
+	}()
+}
+
+func (s *inboxScheduler) check(ctx context.Context) {
+	mgr := s.manager
+	cfg := mgr.Config
+
+	inbox := cfg.GetInbox()
+	if inbox == nil || inbox.Interval <= 0 || cfg.IsNewSystem() || mgr.Database.Ready() != nil {
+		return
+	}
+
+	if time.Since(s.lastRun) < time.Duration(inbox.Interval)*time.Minute {
+		return
+	}
+
+	if mgr.validateFFmpeg() != nil {
+		return
+	}
+
+	j, err := mgr.newInboxJob()
+	if err != nil {
+		logger.Warnf("Not processing inbox: %v", err)
+		s.lastRun = time.Now()
+		return
+	}
+
+	// don't queue another check while one is pending
+	if !s.queued.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.lastRun = time.Now()
+	mgr.JobManager.Add(ctx, "Processing inbox...", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		defer s.queued.Store(false)
+		return j.Execute(ctx, progress)
+	}))
+}
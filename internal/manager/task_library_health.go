@@ -0,0 +1,246 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+const libraryHealthReportFile = "library_health_report.json"
+
+type AnalyzeLibraryHealthInput struct {
+	// Maximum phash distance of duplicate scenes. Defaults to 0 (exact match).
+	Distance *int `json:"distance"`
+	// Maximum difference in seconds between the durations of duplicate
+	// scenes. Defaults to -1 (any duration).
+	DurationDiff *float64 `json:"duration_diff"`
+	// Scenes with a shorter side below this are reported as low quality.
+	MinHeight *int `json:"min_height"`
+	// Scenes with a bitrate below this are reported as low quality.
+	MinBitrate *int `json:"min_bitrate"`
+	// Scenes with a quality score below this are reported as low quality.
+	MinQualityScore *int `json:"min_quality_score"`
+}
+
+func (i AnalyzeLibraryHealthInput) thresholds() scene.HealthThresholds {
+	var ret scene.HealthThresholds
+	if i.MinHeight != nil {
+		ret.MinHeight = *i.MinHeight
+	}
+	if i.MinBitrate != nil {
+		ret.MinBitrate = int64(*i.MinBitrate)
+	}
+	if i.MinQualityScore != nil {
+		ret.MinQualityScore = *i.MinQualityScore
+	}
+	return ret
+}
+
+func libraryHealthReportPath() string {
+	return filepath.Join(config.GetInstance().GetConfigPath(), libraryHealthReportFile)
+}
+
+// GetLibraryHealthReport returns the report of the last library health
+// analysis, or nil if no analysis has been run.
+func GetLibraryHealthReport() (*scene.HealthReport, error) {
+	data, err := os.ReadFile(libraryHealthReportPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ret scene.HealthReport
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("reading library health report: %w", err)
+	}
+
+	return &ret, nil
+}
+
+func saveLibraryHealthReport(r *scene.HealthReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(libraryHealthReportPath(), data, 0644)
+}
+
+type AnalyzeLibraryHealthJob struct {
+	repository models.Repository
+	artifacts  *job.ArtifactStore
+	input      AnalyzeLibraryHealthInput
+}
+
+func (s *Manager) AnalyzeLibraryHealth(ctx context.Context, input AnalyzeLibraryHealthInput) (int, error) {
+	if input.Distance != nil && *input.Distance < 0 {
+		return 0, errors.New("distance must not be negative")
+	}
+
+	j := &AnalyzeLibraryHealthJob{
+		repository: s.Repository,
+		artifacts:  s.ArtifactStore,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Analyzing library health...", j), nil
+}
+
+func (j *AnalyzeLibraryHealthJob) Execute(ctx context.Context, progress *job.Progress) error {
+	distance := 0
+	if j.input.Distance != nil {
+		distance = *j.input.Distance
+	}
+	durationDiff := -1.0
+	if j.input.DurationDiff != nil {
+		durationDiff = *j.input.DurationDiff
+	}
+
+	report := &scene.HealthReport{
+		StartedAt:    time.Now(),
+		Distance:     distance,
+		DurationDiff: durationDiff,
+		Thresholds:   j.input.thresholds(),
+		Groups:       []*scene.HealthGroup{},
+		LowQuality:   []*scene.HealthScene{},
+	}
+
+	r := j.repository
+
+	var err error
+	progress.ExecuteTask("Finding duplicate scenes", func() {
+		err = r.WithReadTxn(ctx, func(ctx context.Context) error {
+			return j.findDuplicates(ctx, report)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("finding duplicate scenes: %w", err)
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if report.Thresholds != (scene.HealthThresholds{}) {
+		if err := j.findLowQuality(ctx, progress, report); err != nil {
+			return fmt.Errorf("finding low quality scenes: %w", err)
+		}
+	}
+
+	report.EndedAt = time.Now()
+
+	if err := saveLibraryHealthReport(report); err != nil {
+		logger.Warnf("error saving library health report: %v", err)
+	}
+
+	if j.artifacts != nil {
+		if err := j.artifacts.WriteJSON(progress, "library-health", report); err != nil {
+			logger.Warnf("error writing library health report: %v", err)
+		}
+	}
+
+	logger.Infof("Library health analysis complete: %d duplicate groups, %d bytes reclaimable, %d low quality scenes",
+		len(report.Groups), report.ReclaimableSize, len(report.LowQuality))
+
+	return nil
+}
+
+func (j *AnalyzeLibraryHealthJob) findDuplicates(ctx context.Context, report *scene.HealthReport) error {
+	r := j.repository
+
+	groups, err := r.Scene.FindDuplicates(ctx, report.Distance, report.DurationDiff, models.PhashDuplicateOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		if len(g) < 2 {
+			continue
+		}
+
+		for _, s := range g {
+			if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+				return fmt.Errorf("loading primary file for scene %d: %w", s.ID, err)
+			}
+		}
+
+		group := scene.NewHealthGroup(g)
+		report.Groups = append(report.Groups, group)
+		report.ReclaimableSize += group.ReclaimableSize
+	}
+
+	return nil
+}
+
+func (j *AnalyzeLibraryHealthJob) findLowQuality(ctx context.Context, progress *job.Progress, report *scene.HealthReport) error {
+	r := j.repository
+
+	const batchSize = 1000
+
+	var total int
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		total, err = r.Scene.Count(ctx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	progress.SetTotal(total)
+
+	findFilter := models.BatchFindFilter(batchSize)
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		var scenes []*models.Scene
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			scenes, err = scene.Query(ctx, r.Scene, nil, findFilter)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+					return fmt.Errorf("loading primary file for scene %d: %w", s.ID, err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			hs := scene.NewHealthScene(s)
+			if report.Thresholds.IsLowQuality(hs) {
+				report.LowQuality = append(report.LowQuality, hs)
+			}
+		}
+
+		progress.AddProcessed(len(scenes))
+
+		if len(scenes) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+const organizeJournalDir = "organize_journals"
+
+type OrganizeFilesInput struct {
+	// IDs of scenes to organize the primary files of
+	SceneIDs []string `json:"scene_ids"`
+	// Template used to generate the new path of each file, relative to the destination
+	Template string `json:"template"`
+	// Directory to move files into. Defaults to the library path containing each file.
+	Destination *string `json:"destination"`
+	// Defaults to SKIP
+	Collision *organize.CollisionStrategy `json:"collision"`
+}
+
+// GetOrganizeJournalStore returns the store for the journals of organize operations.
+func GetOrganizeJournalStore() organize.JournalStore {
+	return organize.JournalStore{
+		Dir: filepath.Join(config.GetInstance().GetConfigPath(), organizeJournalDir),
+	}
+}
+
+// PlanOrganizeFiles returns the moves that would be performed for the given input.
+// Must be called within a read transaction.
+func PlanOrganizeFiles(ctx context.Context, r models.Repository, input OrganizeFilesInput) ([]organize.Move, error) {
+	tmpl := organize.Template(input.Template)
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+
+	stashPaths := config.GetInstance().GetStashPaths()
+	if input.Destination != nil {
+		if stashPaths.GetStashFromDirPath(*input.Destination) == nil {
+			return nil, fmt.Errorf("destination %s must be within a stash library path", *input.Destination)
+		}
+	}
+
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	collision := organize.CollisionStrategySkip
+	if input.Collision != nil {
+		collision = *input.Collision
+	}
+
+	planner := organize.NewPlanner(&file.OsFS{}, collision)
+
+	for _, id := range sceneIDs {
+		s, err := r.Scene.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("finding scene %d: %w", id, err)
+		}
+		if s == nil {
+			return nil, fmt.Errorf("scene with id %d not found", id)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+			return nil, fmt.Errorf("loading primary file for scene %d: %w", id, err)
+		}
+
+		f := s.Files.Primary()
+		if f == nil {
+			logger.Warnf("scene %d has no files, skipping", id)
+			continue
+		}
+
+		if f.ZipFileID != nil {
+			logger.Warnf("file %s is in a zip file, skipping", f.Path)
+			continue
+		}
+
+		fields, err := organizeSceneFields(ctx, r, s, f)
+		if err != nil {
+			return nil, err
+		}
+
+		relPath, err := tmpl.Render(fields)
+		if err != nil {
+			return nil, fmt.Errorf("rendering template for scene %d: %w", id, err)
+		}
+
+		root := ""
+		if input.Destination != nil {
+			root = *input.Destination
+		} else if stash := stashPaths.GetStashFromPath(f.Path); stash != nil {
+			root = stash.Path
+		} else {
+			logger.Warnf("file %s is not within a stash library path, skipping", f.Path)
+			continue
+		}
+
+		if _, err := planner.Add(f.ID, f.Path, filepath.Join(root, relPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	return planner.Moves, nil
+}
+
+func organizeSceneFields(ctx context.Context, r models.Repository, s *models.Scene, f *models.VideoFile) (organize.Fields, error) {
+	var studio string
+	if s.StudioID != nil {
+		st, err := r.Studio.Find(ctx, *s.StudioID)
+		if err != nil {
+			return nil, fmt.Errorf("finding studio for scene %d: %w", s.ID, err)
+		}
+		if st != nil {
+			studio = st.Name
+		}
+	}
+
+	if err := s.LoadPerformerIDs(ctx, r.Scene); err != nil {
+		return nil, fmt.Errorf("loading performers for scene %d: %w", s.ID, err)
+	}
+
+	performers, err := r.Performer.FindMany(ctx, s.PerformerIDs.List())
+	if err != nil {
+		return nil, fmt.Errorf("finding performers for scene %d: %w", s.ID, err)
+	}
+
+	var performerNames []string
+	for _, p := range performers {
+		performerNames = append(performerNames, p.Name)
+	}
+
+	return organize.SceneFields(s, f, studio, performerNames), nil
+}
+
+// executeOrganizeMoves performs the moves in a single transaction. If any
+// move fails, all files are moved back to their original locations.
+func executeOrganizeMoves(ctx context.Context, r models.Repository, moves []organize.Move, progress *job.Progress) error {
+	progress.SetTotal(len(moves))
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		mover := file.NewMover(r.File, r.Folder)
+		mover.RegisterHooks(ctx)
+
+		for _, m := range moves {
+			if job.IsCancelled(ctx) {
+				return ctx.Err()
+			}
+
+			if err := executeOrganizeMove(ctx, r, mover, m); err != nil {
+				return err
+			}
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+}
+
+// executeOrganizeMove performs a single move. Must be called within a
+// transaction.
+func executeOrganizeMove(ctx context.Context, r models.Repository, mover *file.Mover, m organize.Move) error {
+	logger.Debugf("Moving %s to %s", m.OldPath, m.NewPath)
+
+	files, err := r.File.Find(ctx, m.FileID)
+	if err != nil {
+		return fmt.Errorf("finding file %d: %w", m.FileID, err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("file %d not found", m.FileID)
+	}
+
+	f := files[0]
+	if f.Base().Path != m.OldPath {
+		return fmt.Errorf("file %d has moved from %s since the plan was created", m.FileID, m.OldPath)
+	}
+
+	dir := filepath.Dir(m.NewPath)
+	if err := mover.CreateFolderHierarchy(dir); err != nil {
+		return fmt.Errorf("creating folder hierarchy %s in filesystem: %w", dir, err)
+	}
+
+	folder, err := file.GetOrCreateFolderHierarchy(ctx, r.Folder, dir)
+	if err != nil {
+		return fmt.Errorf("getting or creating folder hierarchy: %w", err)
+	}
+
+	return mover.Move(ctx, f, folder, filepath.Base(m.NewPath))
+}
+
+type OrganizeFilesJob struct {
+	repository models.Repository
+	input      OrganizeFilesInput
+	journals   organize.JournalStore
+}
+
+func (s *Manager) OrganizeFiles(ctx context.Context, input OrganizeFilesInput) int {
+	j := &OrganizeFilesJob{
+		repository: s.Repository,
+		input:      input,
+		journals:   GetOrganizeJournalStore(),
+	}
+
+	return s.JobManager.Add(ctx, "Organizing files...", j)
+}
+
+func (j *OrganizeFilesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	var moves []organize.Move
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		moves, err = PlanOrganizeFiles(ctx, r, j.input)
+		return err
+	}); err != nil {
+		return fmt.Errorf("planning file moves: %w", err)
+	}
+
+	var pending []organize.Move
+	for _, m := range moves {
+		if m.Skipped != "" {
+			logger.Infof("Not moving %s: %s", m.OldPath, m.Skipped)
+			continue
+		}
+		pending = append(pending, m)
+	}
+
+	if len(pending) == 0 {
+		logger.Info("No files to organize")
+		return nil
+	}
+
+	// write the journal before moving anything, so that an interrupted
+	// operation can be identified
+	journal, err := j.journals.Create(j.input.Template, pending)
+	if err != nil {
+		return fmt.Errorf("creating journal: %w", err)
+	}
+
+	if err := executeOrganizeMoves(ctx, r, pending, progress); err != nil {
+		// all moves have been rolled back, so the journal is no longer needed
+		if err := j.journals.Delete(journal.ID); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error removing organize journal %s: %v", journal.ID, err)
+		}
+		return fmt.Errorf("organizing files: %w", err)
+	}
+
+	if err := j.journals.SetStatus(journal, organize.JournalStatusCompleted); err != nil {
+		logger.Warnf("error updating organize journal %s: %v", journal.ID, err)
+	}
+
+	logger.Infof("Organized %d files. Journal: %s", len(pending), journal.ID)
+	return nil
+}
+
+type OrganizeRollbackJob struct {
+	repository models.Repository
+	journalID  string
+	journals   organize.JournalStore
+}
+
+func (s *Manager) OrganizeRollback(ctx context.Context, journalID string) int {
+	j := &OrganizeRollbackJob{
+		repository: s.Repository,
+		journalID:  journalID,
+		journals:   GetOrganizeJournalStore(),
+	}
+
+	return s.JobManager.Add(ctx, "Rolling back organized files...", j)
+}
+
+func (j *OrganizeRollbackJob) Execute(ctx context.Context, progress *job.Progress) error {
+	journal, err := j.journals.Find(j.journalID)
+	if err != nil {
+		return err
+	}
+
+	if journal.Status != organize.JournalStatusCompleted {
+		return fmt.Errorf("cannot roll back journal %s with status %s", journal.ID, journal.Status)
+	}
+
+	if err := executeOrganizeMoves(ctx, j.repository, journal.Reversed(), progress); err != nil {
+		return fmt.Errorf("rolling back journal %s: %w", journal.ID, err)
+	}
+
+	if err := j.journals.SetStatus(journal, organize.JournalStatusRolledBack); err != nil {
+		logger.Warnf("error updating organize journal %s: %v", journal.ID, err)
+	}
+
+	logger.Infof("Rolled back %d files from journal %s", len(journal.Moves), journal.ID)
+	return nil
+}
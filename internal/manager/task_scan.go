@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler/lru"
@@ -21,6 +22,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/scraper/local"
 	"github.com/stashapp/stash/pkg/txn"
 )
 
@@ -55,25 +57,44 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 
 	start := time.Now()
 
+	// the scan is stopped if the server shuts down
+	scanCtx, cancelScan := job.WithShutdownCancel(ctx)
+	defer cancelScan()
+
 	const taskQueueSize = 200000
-	taskQueue := job.NewTaskQueue(ctx, progress, taskQueueSize, cfg.GetParallelTasksWithAutoDetection())
+	taskQueue := job.NewTaskQueue(scanCtx, progress, taskQueueSize, cfg.GetParallelTasksWithAutoDetection())
 
 	var minModTime time.Time
 	if j.input.Filter != nil && j.input.Filter.MinModTime != nil {
 		minModTime = *j.input.Filter.MinModTime
 	}
 
-	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
+	// progress is persisted so that the scan can resume if interrupted
+	resumer := newScanResumer(j.input)
+
+	j.scanner.Scan(scanCtx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
 		Paths:                  paths,
 		ScanFilters:            []file.PathFilter{newScanFilter(c, repo, minModTime)},
 		ZipFileExtensions:      cfg.GetGalleryExtensions(),
 		ParallelTasks:          cfg.GetParallelTasksWithAutoDetection(),
 		HandlerRequiredFilters: []file.Filter{newHandlerRequiredFilter(cfg, repo)},
 		Rescan:                 j.input.Rescan,
+		ResumeCursors:          resumer.cursors(),
+		Checkpoint:             resumer.checkpoint,
 	}, progress)
 
 	taskQueue.Close()
 
+	if job.IsShuttingDown(ctx) {
+		// keep the progress so that the scan resumes on the next start
+		resumer.save()
+		logger.Info("Scan interrupted by shutdown")
+		return job.ErrInterrupted
+	}
+
+	// scans cancelled by the user are not resumed
+	removeScanResumeState()
+
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
@@ -109,6 +130,10 @@ type galleryFinder interface {
 	FindByFolderID(ctx context.Context, folderID models.FolderID) ([]*models.Gallery, error)
 }
 
+type folderFinder interface {
+	FindByPath(ctx context.Context, path string) (*models.Folder, error)
+}
+
 type sceneFinder interface {
 	fileCounter
 	FindByPrimaryFileID(ctx context.Context, fileID models.FileID) ([]*models.Scene, error)
@@ -121,6 +146,7 @@ type handlerRequiredFilter struct {
 	SceneFinder    sceneFinder
 	ImageFinder    fileCounter
 	GalleryFinder  galleryFinder
+	FolderFinder   folderFinder
 	CaptionUpdater video.CaptionUpdater
 
 	FolderCache *lru.LRU[bool]
@@ -137,6 +163,7 @@ func newHandlerRequiredFilter(c *config.Config, repo models.Repository) *handler
 		SceneFinder:              repo.Scene,
 		ImageFinder:              repo.Image,
 		GalleryFinder:            repo.Gallery,
+		FolderFinder:             repo.Folder,
 		CaptionUpdater:           repo.File,
 		FolderCache:              lru.New[bool](processes * 2),
 		videoFileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
@@ -191,6 +218,18 @@ func (f *handlerRequiredFilter) Accept(ctx context.Context, ff models.File) bool
 
 		f.FolderCache.Add(ctx, ff.Base().ParentFolderID.String(), true)
 
+		// images in nested folders of a chapter gallery belong to the
+		// gallery of the root folder
+		if chapterRoot, _ := image.FindChapterGalleryRoot(filepath.Dir(path)); chapterRoot != "" {
+			folder, _ := f.FolderFinder.FindByPath(ctx, chapterRoot)
+			if folder == nil {
+				return true
+			}
+
+			g, _ := f.GalleryFinder.FindByFolderID(ctx, folder.ID)
+			return len(g) == 0
+		}
+
 		createGallery := instance.Config.GetCreateGalleriesFromFolders()
 		if !createGallery {
 			// check for presence of .forcegallery
@@ -335,12 +374,22 @@ type scanConfig struct {
 	isGenerateClipPreviews bool
 
 	createGalleriesFromFolders bool
+	importImageSidecars        bool
+	imageSidecarTagMappings    []string
 }
 
 func (c *scanConfig) GetCreateGalleriesFromFolders() bool {
 	return c.createGalleriesFromFolders
 }
 
+func (c *scanConfig) GetImportImageSidecars() bool {
+	return c.importImageSidecars
+}
+
+func (c *scanConfig) GetImageSidecarTagMappings() []string {
+	return c.imageSidecarTagMappings
+}
+
 func videoFileFilter(ctx context.Context, f models.File) bool {
 	return useAsVideo(f.Base().Path)
 }
@@ -359,12 +408,24 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 	r := mgr.Repository
 	pluginCache := mgr.PluginCache
 
+	primaryFileRules, err := scene.ParsePrimaryFileRules(c.GetScenePrimaryFileRules())
+	if err != nil {
+		logger.Warnf("Ignoring scene primary file rules: %v", err)
+		primaryFileRules = nil
+	}
+
 	return []file.Handler{
 		&file.FilteredHandler{
 			Filter: file.FilterFunc(imageFileFilter),
 			Handler: &image.ScanHandler{
 				CreatorUpdater: r.Image,
 				GalleryFinder:  r.Gallery,
+
+				FolderFinder:          r.Folder,
+				ChapterCreatorUpdater: r.GalleryChapter,
+
+				TagFinderCreator: r.Tag,
+
 				ScanGenerator: &imageGenerators{
 					input:              options,
 					taskQueue:          taskQueue,
@@ -376,6 +437,8 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 					isGenerateThumbnails:       options.ScanGenerateThumbnails,
 					isGenerateClipPreviews:     options.ScanGenerateClipPreviews,
 					createGalleriesFromFolders: c.GetCreateGalleriesFromFolders(),
+					importImageSidecars:        c.GetImportImageSidecars(),
+					imageSidecarTagMappings:    c.GetImageSidecarTagMappings(),
 				},
 				PluginCache: pluginCache,
 				Paths:       instance.Paths,
@@ -403,9 +466,12 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 					paths:               mgr.Paths,
 					fileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 					sequentialScanning:  c.GetSequentialScanning(),
+					stashPaths:          c.GetStashPaths(),
 				},
 				FileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 				Paths:               mgr.Paths,
+				PrimaryFileRules:    primaryFileRules,
+				PendingURLs:         local.URLs,
 			},
 		},
 	}
@@ -423,6 +489,11 @@ type imageGenerators struct {
 func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f models.File) error {
 	const overwrite = false
 
+	// generate once the contents have been downloaded
+	if f.Base().PendingContent {
+		return nil
+	}
+
 	progress := g.progress
 	t := g.input
 	path := f.Base().Path
@@ -474,11 +545,27 @@ type sceneGenerators struct {
 	paths               *paths.Paths
 	fileNamingAlgorithm models.HashAlgorithm
 	sequentialScanning  bool
+	stashPaths          config.StashConfigs
+}
+
+// generatePhash returns true if a phash should be generated for the file at
+// path. The fingerprints of the stash path override the scan input.
+func (g *sceneGenerators) generatePhash(path string) bool {
+	if override := pathFingerprints(g.stashPaths, path); len(override) > 0 {
+		return slices.Contains(override, models.FingerprintTypePhash)
+	}
+
+	return g.input.ScanGeneratePhashes
 }
 
 func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *models.VideoFile) error {
 	const overwrite = false
 
+	// generate once the contents have been downloaded
+	if f.PendingContent {
+		return nil
+	}
+
 	progress := g.progress
 	t := g.input
 	path := f.Path
@@ -504,7 +591,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
-	if t.ScanGeneratePhashes {
+	if g.generatePhash(path) {
 		progress.AddTotal(1)
 		phashFn := func(ctx context.Context) {
 			taskPhash := GeneratePhashTask{
@@ -0,0 +1,172 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+)
+
+// stashBoxFavorite is a local performer or studio linked to a stash-box
+// endpoint.
+type stashBoxFavorite struct {
+	id       int
+	stashID  string
+	favorite bool
+}
+
+// mergeStashBoxFavorites merges the local favorites with the favorite stash
+// ids of the stash-box user. It returns the ids of the local objects that
+// should be favorited, and the stash ids that should be favorited on
+// stash-box. Favorites are only ever added, never removed, on either side.
+func mergeStashBoxFavorites(local []stashBoxFavorite, remote []string) (toImport []int, toExport []string) {
+	remoteFavorites := make(map[string]bool)
+	for _, id := range remote {
+		remoteFavorites[id] = true
+	}
+
+	exported := make(map[string]bool)
+	for _, o := range local {
+		switch {
+		case remoteFavorites[o.stashID] && !o.favorite:
+			toImport = append(toImport, o.id)
+		case o.favorite && !remoteFavorites[o.stashID] && !exported[o.stashID]:
+			toExport = append(toExport, o.stashID)
+			exported[o.stashID] = true
+		}
+	}
+
+	return toImport, toExport
+}
+
+// StashBoxFavoritesTask synchronises the favorite performers or studios with
+// the favorites of the stash-box user.
+type StashBoxFavoritesTask struct {
+	repository models.Repository
+	box        *models.StashBox
+	taskType   StashBoxTagTaskType
+}
+
+func (t *StashBoxFavoritesTask) Description() string {
+	if t.taskType == Studio {
+		return "Syncing favorite studios with stash-box"
+	}
+	return "Syncing favorite performers with stash-box"
+}
+
+func (t *StashBoxFavoritesTask) Start(ctx context.Context) error {
+	client := stashbox.NewClient(*t.box, stashbox.NewRepository(t.repository))
+
+	var remote []string
+	var err error
+	if t.taskType == Studio {
+		remote, err = client.FavoriteStudioIDs(ctx)
+	} else {
+		remote, err = client.FavoritePerformerIDs(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("getting stash-box favorites (the endpoint may not support favorites): %w", err)
+	}
+
+	local, err := t.getLocal(ctx)
+	if err != nil {
+		return err
+	}
+
+	toImport, toExport := mergeStashBoxFavorites(local, remote)
+
+	if err := t.importFavorites(ctx, toImport); err != nil {
+		return err
+	}
+
+	exported := 0
+	for _, stashID := range toExport {
+		if t.taskType == Studio {
+			err = client.SetStudioFavorite(ctx, stashID, true)
+		} else {
+			err = client.SetPerformerFavorite(ctx, stashID, true)
+		}
+		if err != nil {
+			logger.Errorf("Error favoriting %s on stash-box: %v", stashID, err)
+			continue
+		}
+		exported++
+	}
+
+	logger.Infof("Imported %d and exported %d stash-box favorites", len(toImport), exported)
+	return nil
+}
+
+func (t *StashBoxFavoritesTask) getLocal(ctx context.Context) ([]stashBoxFavorite, error) {
+	r := t.repository
+	endpoint := t.box.Endpoint
+
+	var ret []stashBoxFavorite
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		if t.taskType == Studio {
+			studios, err := r.Studio.FindByStashIDStatus(ctx, true, endpoint)
+			if err != nil {
+				return fmt.Errorf("finding studios: %w", err)
+			}
+
+			for _, s := range studios {
+				if err := s.LoadStashIDs(ctx, r.Studio); err != nil {
+					return fmt.Errorf("loading studio stash ids: %w", err)
+				}
+				if stashID := s.StashIDs.ForEndpoint(endpoint); stashID != nil {
+					ret = append(ret, stashBoxFavorite{id: s.ID, stashID: stashID.StashID, favorite: s.Favorite})
+				}
+			}
+			return nil
+		}
+
+		performers, err := r.Performer.FindByStashIDStatus(ctx, true, endpoint)
+		if err != nil {
+			return fmt.Errorf("finding performers: %w", err)
+		}
+
+		for _, p := range performers {
+			if err := p.LoadStashIDs(ctx, r.Performer); err != nil {
+				return fmt.Errorf("loading performer stash ids: %w", err)
+			}
+			if stashID := p.StashIDs.ForEndpoint(endpoint); stashID != nil {
+				ret = append(ret, stashBoxFavorite{id: p.ID, stashID: stashID.StashID, favorite: p.Favorite})
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (t *StashBoxFavoritesTask) importFavorites(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	r := t.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			if t.taskType == Studio {
+				partial := models.NewStudioPartial()
+				partial.ID = id
+				partial.Favorite = models.NewOptionalBool(true)
+				if _, err := r.Studio.UpdatePartial(ctx, partial); err != nil {
+					return fmt.Errorf("favoriting studio %d: %w", id, err)
+				}
+				continue
+			}
+
+			partial := models.NewPerformerPartial()
+			partial.Favorite = models.NewOptionalBool(true)
+			if _, err := r.Performer.UpdatePartial(ctx, id, partial); err != nil {
+				return fmt.Errorf("favoriting performer %d: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,24 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeStashBoxFavorites(t *testing.T) {
+	local := []stashBoxFavorite{
+		{id: 1, stashID: "remote-only", favorite: false},
+		{id: 2, stashID: "both", favorite: true},
+		{id: 3, stashID: "local-only", favorite: true},
+		{id: 4, stashID: "local-only", favorite: true},
+		{id: 5, stashID: "neither", favorite: false},
+		{id: 6, stashID: "remote-only", favorite: false},
+	}
+	remote := []string{"remote-only", "both", "unknown"}
+
+	toImport, toExport := mergeStashBoxFavorites(local, remote)
+
+	assert.Equal(t, []int{1, 6}, toImport)
+	assert.Equal(t, []string{"local-only"}, toExport)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type StashBoxTagTaskType int
@@ -138,15 +140,11 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 	if t.performer != nil {
 		storedID, _ := strconv.Atoi(*p.StoredID)
 
-		image, err := p.GetImage(ctx, excluded)
-		if err != nil {
-			logger.Errorf("Error processing scraped performer image for %s: %v", *p.Name, err)
-			return
-		}
+		images := getScrapedPerformerImages(ctx, p, excluded)
 
 		// Start the transaction and update the performer
 		r := instance.Repository
-		err = r.WithTxn(ctx, func(ctx context.Context) error {
+		err := r.WithTxn(ctx, func(ctx context.Context) error {
 			qb := r.Performer
 
 			existingStashIDs, err := qb.GetStashIDs(ctx, storedID)
@@ -164,13 +162,9 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 				return err
 			}
 
-			if len(image) > 0 {
-				if err := qb.UpdateImage(ctx, t.performer.ID, image); err != nil {
-					return err
-				}
-			}
-
-			return nil
+			// images are appended to the image set rather than replacing
+			// the primary image
+			return addPerformerImages(ctx, qb, t.performer.ID, images)
 		})
 		if err != nil {
 			logger.Errorf("Failed to update performer %s: %v", *p.Name, err)
@@ -180,14 +174,10 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 	} else if t.name != nil && p.Name != nil {
 		// Creating a new performer
 		newPerformer := p.ToPerformer(t.box.Endpoint, excluded)
-		image, err := p.GetImage(ctx, excluded)
-		if err != nil {
-			logger.Errorf("Error processing scraped performer image for %s: %v", *p.Name, err)
-			return
-		}
+		images := getScrapedPerformerImages(ctx, p, excluded)
 
 		r := instance.Repository
-		err = r.WithTxn(ctx, func(ctx context.Context) error {
+		err := r.WithTxn(ctx, func(ctx context.Context) error {
 			qb := r.Performer
 
 			if err := performer.ValidateCreate(ctx, *newPerformer, qb); err != nil {
@@ -198,13 +188,7 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 				return err
 			}
 
-			if len(image) > 0 {
-				if err := qb.UpdateImage(ctx, newPerformer.ID, image); err != nil {
-					return err
-				}
-			}
-
-			return nil
+			return addPerformerImages(ctx, qb, newPerformer.ID, images)
 		})
 		if err != nil {
 			logger.Errorf("Failed to create performer %s: %v", *p.Name, err)
@@ -214,6 +198,49 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 	}
 }
 
+type performerImageData struct {
+	data   []byte
+	source string
+}
+
+// getScrapedPerformerImages downloads the images of the scraped performer.
+// Images that cannot be downloaded are skipped.
+func getScrapedPerformerImages(ctx context.Context, p *models.ScrapedPerformer, excluded map[string]bool) []performerImageData {
+	if excluded["image"] {
+		return nil
+	}
+
+	var ret []performerImageData
+	for _, img := range p.Images {
+		data, err := utils.ProcessImageInput(ctx, img)
+		if err != nil {
+			logger.Warnf("Error processing scraped performer image for %s: %v", *p.Name, err)
+			continue
+		}
+
+		source := ""
+		if strings.HasPrefix(img, "http://") || strings.HasPrefix(img, "https://") {
+			source = img
+		}
+
+		ret = append(ret, performerImageData{data: data, source: source})
+	}
+
+	return ret
+}
+
+// addPerformerImages appends the images to the image set of the performer.
+// Images already in the set are not added again.
+func addPerformerImages(ctx context.Context, w models.PerformerImageWriter, performerID int, images []performerImageData) error {
+	for _, img := range images {
+		if _, err := w.AddImage(ctx, performerID, img.data, img.source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (t *StashBoxBatchTagTask) stashBoxStudioTag(ctx context.Context) {
 	studio, err := t.findStashBoxStudio(ctx)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/worker"
 )
 
 type GenerateTranscodeTask struct {
@@ -27,9 +28,35 @@ func (t *GenerateTranscodeTask) GetDescription() string {
 }
 
 func (t *GenerateTranscodeTask) Start(ctx context.Context) {
+	p, err := t.plan()
+	if err != nil {
+		logger.Errorf("[transcode] %v", err)
+		return
+	}
+
+	if p == nil {
+		return
+	}
+
+	if err := runTranscode(ctx, t.g, p.input, p.hash, p.options); err != nil {
+		logger.Errorf("[transcode] error generating transcode: %v", err)
+		return
+	}
+}
+
+// transcodePlan describes how a scene is transcoded.
+type transcodePlan struct {
+	input   string
+	hash    string
+	options worker.TranscodeOptions
+}
+
+// plan returns how the scene should be transcoded, or nil if the scene does
+// not need to be transcoded.
+func (t *GenerateTranscodeTask) plan() (*transcodePlan, error) {
 	hasTranscode := HasTranscode(&t.Scene, t.fileNamingAlgorithm)
 	if !t.Overwrite && hasTranscode {
-		return
+		return nil, nil
 	}
 
 	f := t.Scene.Files.Primary()
@@ -40,8 +67,7 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	var err error
 	container, err = GetVideoFileContainer(f)
 	if err != nil {
-		logger.Errorf("[transcode] error getting scene container: %s", err.Error())
-		return
+		return nil, fmt.Errorf("error getting scene container: %w", err)
 	}
 
 	var videoCodec string
@@ -56,48 +82,64 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	}
 
 	if !t.Force && ffmpeg.IsStreamable(videoCodec, audioCodec, container) == nil {
-		return
+		return nil, nil
 	}
 
 	// TODO - move transcode generation logic elsewhere
 
 	videoFile, err := ffprobe.NewVideoFile(f.Path)
 	if err != nil {
-		logger.Errorf("[transcode] error reading video file: %s", err.Error())
-		return
+		return nil, fmt.Errorf("error reading video file: %w", err)
 	}
 
-	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 	transcodeSize := config.GetInstance().GetMaxTranscodeSize()
 
 	w, h := videoFile.TranscodeScale(transcodeSize.GetMaxResolution())
 
+	ret := &transcodePlan{
+		input: videoFile.Path,
+		hash:  t.Scene.GetHash(t.fileNamingAlgorithm),
+		options: worker.TranscodeOptions{
+			Width:  w,
+			Height: h,
+		},
+	}
+
 	// if scale is being set, then we can't use stream copy
 	scaleSet := w == 0 && h == 0
 
-	if scaleSet && videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
+	switch {
+	case scaleSet && videoCodec == ffmpeg.H264: // for non supported h264 files stream copy the video part
 		if audioCodec == ffmpeg.MissingUnsupported {
-			err = t.g.TranscodeCopyVideo(ctx, videoFile.Path, sceneHash)
+			ret.options.Mode = worker.TranscodeModeCopyVideo
 		} else {
-			err = t.g.TranscodeAudio(ctx, videoFile.Path, sceneHash)
-		}
-	} else {
-		options := generate.TranscodeOptions{
-			Width:  w,
-			Height: h,
+			ret.options.Mode = worker.TranscodeModeAudio
 		}
+	case audioCodec == ffmpeg.MissingUnsupported:
+		// ffmpeg fails if it tries to transcode an unsupported audio codec
+		ret.options.Mode = worker.TranscodeModeVideo
+	default:
+		ret.options.Mode = worker.TranscodeModeFull
+	}
 
-		if audioCodec == ffmpeg.MissingUnsupported {
-			// ffmpeg fails if it tries to transcode an unsupported audio codec
-			err = t.g.TranscodeVideo(ctx, videoFile.Path, sceneHash, options)
-		} else {
-			err = t.g.Transcode(ctx, videoFile.Path, sceneHash, options)
-		}
+	return ret, nil
+}
+
+func runTranscode(ctx context.Context, g *generate.Generator, input string, hash string, o worker.TranscodeOptions) error {
+	options := generate.TranscodeOptions{
+		Width:  o.Width,
+		Height: o.Height,
 	}
 
-	if err != nil {
-		logger.Errorf("[transcode] error generating transcode: %v", err)
-		return
+	switch o.Mode {
+	case worker.TranscodeModeCopyVideo:
+		return g.TranscodeCopyVideo(ctx, input, hash)
+	case worker.TranscodeModeAudio:
+		return g.TranscodeAudio(ctx, input, hash)
+	case worker.TranscodeModeVideo:
+		return g.TranscodeVideo(ctx, input, hash, options)
+	default:
+		return g.Transcode(ctx, input, hash, options)
 	}
 }
 
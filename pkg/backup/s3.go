@@ -0,0 +1,149 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service = "s3"
+
+	// s3UnsignedPayload is used as the payload hash so that the uploaded
+	// file does not need to be read twice.
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+func (t Target) s3Endpoint() string {
+	if t.URL != "" {
+		return t.URL
+	}
+
+	return fmt.Sprintf("https://s3.%s.amazonaws.com", t.Region)
+}
+
+// s3Request returns a signed path-style PutObject request.
+func (t Target) s3Request(ctx context.Context, name string, body io.Reader) (*http.Request, error) {
+	u, err := url.Parse(t.s3Endpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	key := name
+	if t.Prefix != "" {
+		key = strings.TrimSuffix(t.Prefix, "/") + "/" + name
+	}
+	u = u.JoinPath(t.Bucket, key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	signV4(req, s3UnsignedPayload, t.Username, t.Password, t.Region, s3Service, time.Now())
+
+	return req, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
+
+func signingKey(secret, date, region, service string) []byte {
+	k := hmacSHA256([]byte("AWS4"+secret), date)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	return hmacSHA256(k, "aws4_request")
+}
+
+// signV4 signs the request using AWS signature version 4. The host header
+// and all headers set on the request are signed.
+func signV4(req *http.Request, payloadHash, accessKey, secret, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey(secret, date, region, service), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := q[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, url.QueryEscape(k)+"="+strings.ReplaceAll(url.QueryEscape(v), "+", "%20"))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,180 @@
+// Package backup provides off-site targets for database backups.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type TargetType string
+
+const (
+	// Amazon S3 or a S3 compatible service
+	TargetTypeS3 TargetType = "S3"
+	// WebDAV server
+	TargetTypeWebDAV TargetType = "WEBDAV"
+)
+
+var AllTargetType = []TargetType{
+	TargetTypeS3,
+	TargetTypeWebDAV,
+}
+
+func (e TargetType) IsValid() bool {
+	switch e {
+	case TargetTypeS3, TargetTypeWebDAV:
+		return true
+	}
+	return false
+}
+
+func (e TargetType) String() string {
+	return string(e)
+}
+
+func (e *TargetType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TargetType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BackupTargetType", str)
+	}
+	return nil
+}
+
+func (e TargetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Target is an off-site location to which database backups are uploaded.
+type Target struct {
+	Type TargetType `json:"type"`
+	// URL is the WebDAV collection to upload into, or the S3 endpoint.
+	// The S3 endpoint defaults to the AWS endpoint of the region.
+	URL string `json:"url"`
+	// Bucket and Region are only used by S3.
+	Bucket string `json:"bucket"`
+	Region string `json:"region"`
+	// Prefix is prepended to the object key of S3 uploads.
+	Prefix string `json:"prefix"`
+	// Username is the WebDAV user name, or the S3 access key ID.
+	Username string `json:"username"`
+	// Password is the WebDAV password, or the S3 secret access key.
+	Password string `json:"password"`
+}
+
+// Enabled returns true if the target has a type set.
+func (t Target) Enabled() bool {
+	return t.Type != ""
+}
+
+func (t Target) Validate() error {
+	switch t.Type {
+	case "":
+		return nil
+	case TargetTypeWebDAV:
+		if t.URL == "" {
+			return errors.New("url is required for WebDAV targets")
+		}
+	case TargetTypeS3:
+		if t.Bucket == "" || t.Region == "" {
+			return errors.New("bucket and region are required for S3 targets")
+		}
+		if t.Username == "" || t.Password == "" {
+			return errors.New("access key is required for S3 targets")
+		}
+	default:
+		return fmt.Errorf("invalid target type %q", t.Type)
+	}
+
+	if t.URL != "" {
+		u, err := url.Parse(t.URL)
+		if err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid url %q: scheme must be http or https", t.URL)
+		}
+	}
+
+	return nil
+}
+
+// Upload uploads the file at path to the target, using the base name of the
+// file as the name of the uploaded file.
+func (t Target) Upload(ctx context.Context, client *http.Client, path string) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(path)
+
+	var req *http.Request
+	switch t.Type {
+	case TargetTypeWebDAV:
+		req, err = t.webDAVRequest(ctx, name, f)
+	case TargetTypeS3:
+		req, err = t.s3Request(ctx, name, f)
+	default:
+		return errors.New("target is not enabled")
+	}
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = info.Size()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("upload to %s failed: http error %d: %s", req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func (t Target) webDAVRequest(ctx context.Context, name string, body io.Reader) (*http.Request, error) {
+	u, err := url.Parse(t.URL)
+	if err != nil {
+		return nil, err
+	}
+	u = u.JoinPath(name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Username != "" || t.Password != "" {
+		req.SetBasicAuth(t.Username, t.Password)
+	}
+
+	return req, nil
+}
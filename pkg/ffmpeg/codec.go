@@ -23,6 +23,7 @@ var (
 	VideoCodecLibWebP = makeVideoCodec("WebP", "libwebp")
 	VideoCodecBMP     = makeVideoCodec("BMP", "bmp")
 	VideoCodecMJpeg   = makeVideoCodec("Jpeg", "mjpeg")
+	VideoCodecPNG     = makeVideoCodec("PNG", "png")
 	VideoCodecVP9     = makeVideoCodec("VPX-VP9", "libvpx-vp9")
 	VideoCodecVPX     = makeVideoCodec("VPX-VP8", "libvpx")
 	VideoCodecLibX265 = makeVideoCodec("x265", "libx265")
@@ -40,7 +41,8 @@ func (c AudioCodec) Args() []string {
 }
 
 var (
-	AudioCodecAAC     AudioCodec = "aac"
-	AudioCodecLibOpus AudioCodec = "libopus"
-	AudioCodecCopy    AudioCodec = "copy"
+	AudioCodecAAC      AudioCodec = "aac"
+	AudioCodecLibOpus  AudioCodec = "libopus"
+	AudioCodecCopy     AudioCodec = "copy"
+	AudioCodecPCMS16LE AudioCodec = "pcm_s16le"
 )
@@ -73,3 +73,26 @@ func TestFFMpegVersion_GreaterThan(t *testing.T) {
 		})
 	}
 }
+
+func TestPixFmtBitDepth(t *testing.T) {
+	tests := []struct {
+		pixFmt string
+		want   int
+	}{
+		{"", 0},
+		{"yuv420p", 8},
+		{"nv12", 8},
+		{"yuv420p10le", 10},
+		{"yuv444p12be", 12},
+		{"gbrp16", 16},
+		{"p010le", 10},
+		{"p016le", 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pixFmt, func(t *testing.T) {
+			if got := pixFmtBitDepth(tt.pixFmt); got != tt.want {
+				t.Errorf("pixFmtBitDepth(%q) = %d, want %d", tt.pixFmt, got, tt.want)
+			}
+		})
+	}
+}
@@ -111,8 +111,22 @@ type VideoFile struct {
 	FrameRate    float64
 	Rotation     int64
 	FrameCount   int64
+	// BitDepth is the number of bits per colour component, or 0 if unknown.
+	BitDepth      int
+	ColorTransfer string
+	// DolbyVision is true if the video stream has a Dolby Vision
+	// configuration record.
+	DolbyVision bool
+	// SphericalProjection is the projection from the spherical video
+	// metadata, if present. For example, "equirectangular".
+	SphericalProjection string
+	// Stereo3DType is the layout from the stereo 3D metadata, if present.
+	// For example, "side by side".
+	Stereo3DType string
 
 	AudioCodec string
+	// AudioBitrate is the bitrate of the audio stream, or 0 if unknown.
+	AudioBitrate int64
 }
 
 // TranscodeScale calculates the dimension scaling for a transcode, where maxSize is the maximum size of the longest dimension of the input video.
@@ -223,12 +237,12 @@ func (f *FFProbe) NewVideoFile(videoPath string) (*VideoFile, error) {
 		"-show_error",
 	}
 
-	// show_entries stream_side_data=rotation requires 5.x or later ffprobe
+	// show_entries stream_side_data requires 5.x or later ffprobe
 	if f.version.major >= 5 {
-		args = append(args, "-show_entries", "stream_side_data=rotation")
+		args = append(args, "-show_entries", "stream_side_data=side_data_type,rotation,projection,type")
 	}
 
-	args = append(args, videoPath)
+	args = append(args, fsutil.LongPath(videoPath))
 
 	cmd := stashExec.Command(f.path, args...)
 	out, err := cmd.Output()
@@ -248,7 +262,7 @@ func (f *FFProbe) NewVideoFile(videoPath string) (*VideoFile, error) {
 // GetReadFrameCount counts the actual frames of the video file.
 // Used when the frame count is missing or incorrect.
 func (f *FFProbe) GetReadFrameCount(path string) (int64, error) {
-	args := []string{"-v", "quiet", "-print_format", "json", "-count_frames", "-show_format", "-show_streams", "-show_error", path}
+	args := []string{"-v", "quiet", "-print_format", "json", "-count_frames", "-show_format", "-show_streams", "-show_error", fsutil.LongPath(path)}
 	out, err := stashExec.Command(f.path, args...).Output()
 
 	if err != nil {
@@ -298,6 +312,7 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	audioStream := result.getAudioStream()
 	if audioStream != nil {
 		result.AudioCodec = audioStream.CodecName
+		result.AudioBitrate, _ = strconv.ParseInt(audioStream.BitRate, 10, 64)
 		result.AudioStream = audioStream
 	}
 
@@ -336,6 +351,10 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 			result.Height = videoStream.Width
 		}
 
+		result.BitDepth = bitDepth(videoStream)
+		result.ColorTransfer = videoStream.ColorTransfer
+		parseSideData(result, videoStream)
+
 		result.VideoStreamDuration, err = strconv.ParseFloat(videoStream.Duration, 64)
 		if err != nil {
 			// Revert to the historical behaviour, which is still correct in the vast majority of cases.
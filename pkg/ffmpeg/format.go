@@ -20,6 +20,7 @@ var (
 	FormatMP4      Format = "mp4"
 	FormatWebm     Format = "webm"
 	FormatMatroska Format = "matroska"
+	FormatWav      Format = "wav"
 )
 
 // ImageFormat represents the input format for an image for ffmpeg.
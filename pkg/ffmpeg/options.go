@@ -3,6 +3,8 @@ package ffmpeg
 import (
 	"fmt"
 	"runtime"
+
+	"github.com/stashapp/stash/pkg/fsutil"
 )
 
 // Arger is an interface that can be used to append arguments to an Args slice.
@@ -43,13 +45,15 @@ func (a Args) Duration(seconds float64) Args {
 }
 
 // Input adds the input (-i) and returns the result.
+// Long file paths are converted to a form that ffmpeg can open.
 func (a Args) Input(i string) Args {
-	return append(a, "-i", i)
+	return append(a, "-i", fsutil.LongPath(i))
 }
 
 // Output adds the output o and returns the result.
+// Long file paths are converted to a form that ffmpeg can open.
 func (a Args) Output(o string) Args {
-	return append(a, o)
+	return append(a, fsutil.LongPath(o))
 }
 
 // NullOutput adds a null output and returns the result.
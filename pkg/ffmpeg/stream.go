@@ -40,6 +40,9 @@ type StreamManagerConfig interface {
 	GetLiveTranscodeInputArgs() []string
 	GetLiveTranscodeOutputArgs() []string
 	GetTranscodeHardwareAcceleration() bool
+	// GetDiskSpaceReservedBytes returns the number of bytes that must be
+	// left free in the cache directory.
+	GetDiskSpaceReservedBytes() int64
 }
 
 func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config StreamManagerConfig, lockManager *fsutil.ReadLockManager) *StreamManager {
@@ -55,7 +55,7 @@ const (
 type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
-	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string)
+	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, trim StreamTrim)
 	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
 }
 
@@ -412,7 +412,8 @@ func segmentExists(path string) bool {
 
 // serveHLSManifest serves a generated HLS playlist. The URLs for the segments
 // are of the form {r.URL}/%d.ts{?urlQuery} where %d is the segment index.
-func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+// Only the segments within trim are included.
+func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, trim StreamTrim) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
 		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
@@ -447,17 +448,18 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		urlQueryString = "?" + urlQuery.Encode()
 	}
 
+	segment := trim.firstSegment()
+
 	var buf bytes.Buffer
 
 	fmt.Fprint(&buf, "#EXTM3U\n")
 
 	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
-	fmt.Fprint(&buf, "#EXT-X-MEDIA-SEQUENCE:0\n")
+	fmt.Fprintf(&buf, "#EXT-X-MEDIA-SEQUENCE:%d\n", segment)
 	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", segmentLength)
 	fmt.Fprint(&buf, "#EXT-X-PLAYLIST-TYPE:VOD\n")
 
-	leftover := probeResult.FileDuration
-	segment := 0
+	leftover := trim.end(probeResult.FileDuration) - float64(segment*segmentLength)
 
 	for leftover > 0 {
 		thisLength := float64(segmentLength)
@@ -478,8 +480,9 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-// serveDASHManifest serves a generated DASH manifest.
-func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+// serveDASHManifest serves a generated DASH manifest. Only the segments
+// within trim are included.
+func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, trim StreamTrim) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
 		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
@@ -556,7 +559,13 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		urlQueryString = "?" + urlQuery.Encode()
 	}
 
-	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
+	// segment timestamps are those of the file, so offset the presentation
+	// to start at the first segment
+	firstSegment := trim.firstSegment()
+	startTime := float64(firstSegment * segmentLength)
+	presentationTimeOffset := uint64(startTime)
+
+	mediaDuration := mpd.Duration(time.Duration((trim.end(probeResult.FileDuration) - startTime) * float64(time.Second)))
 	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
 
 	baseUrl := r.URL.JoinPath("/")
@@ -565,12 +574,18 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 
 	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
 
-	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQueryString, "$Number$_v.webm"+urlQueryString, 0, 1)
+	videoTemplate, _ := video.SetNewSegmentTemplate(2, "init_v.webm"+urlQueryString, "$Number$_v.webm"+urlQueryString, int64(firstSegment), 1)
+	if presentationTimeOffset > 0 {
+		videoTemplate.PresentationTimeOffset = &presentationTimeOffset
+	}
 	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
 
 	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
 		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
-		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
+		audioTemplate, _ := audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, int64(firstSegment), 1)
+		if presentationTimeOffset > 0 {
+			audioTemplate.PresentationTimeOffset = &presentationTimeOffset
+		}
 		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
 	}
 
@@ -581,8 +596,8 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string) {
-	streamType.ServeManifest(sm, w, r, vf, resolution)
+func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string, trim StreamTrim) {
+	streamType.ServeManifest(sm, w, r, vf, resolution, trim)
 }
 
 func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Request, segment *waitingSegment) {
@@ -617,6 +632,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 
 	segment, err := streamType.SegmentType.ParseSegment(options.Segment)
 	// error if segment is past the end of the video
+	// segments are the same regardless of the trim, so it is not checked here
 	if err != nil || segment > lastSegment(options.VideoFile) {
 		http.Error(w, "invalid segment", http.StatusBadRequest)
 		return
@@ -680,6 +696,12 @@ func (sm *StreamManager) startTranscode(stream *runningStream, segment int, done
 
 	logger.Debugf("[transcode] starting transcode for %s at segment #%d", stream.dir, segment)
 
+	if err := fsutil.CheckDiskSpace(sm.cacheDir, sm.config.GetDiskSpaceReservedBytes()); err != nil {
+		logger.Errorf("[transcode] %v", err)
+		done <- err
+		return
+	}
+
 	if err := os.MkdirAll(stream.outputDir, os.ModePerm); err != nil {
 		logger.Errorf("[transcode] %v", err)
 		done <- err
@@ -147,6 +147,7 @@ type TranscodeOptions struct {
 	VideoFile  *models.VideoFile
 	Resolution string
 	StartTime  float64
+	Trim       StreamTrim
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -201,12 +202,17 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
-	if o.StartTime != 0 {
-		args = args.Seek(o.StartTime)
+	startTime := o.Trim.startPosition(o.StartTime)
+	if startTime != 0 {
+		args = args.Seek(startTime)
 	}
 
 	args = args.Input(o.VideoFile.Path)
 
+	if remaining, trimmed := o.Trim.remaining(startTime); trimmed {
+		args = args.Duration(remaining)
+	}
+
 	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw)
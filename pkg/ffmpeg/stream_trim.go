@@ -0,0 +1,47 @@
+package ffmpeg
+
+import (
+	"math"
+)
+
+// StreamTrim restricts a stream to part of a video file, such as to skip an
+// intro or outro. The zero value streams the whole file.
+type StreamTrim struct {
+	// Start is the offset in seconds to start the stream from.
+	Start float64
+	// End is the offset in seconds to end the stream at. Zero or an offset
+	// past the end of the file streams to the end of the file.
+	End float64
+}
+
+// end returns the end offset of the trimmed stream of a file with the given
+// duration.
+func (t StreamTrim) end(duration float64) float64 {
+	if t.End > 0 && t.End < duration {
+		return t.End
+	}
+	return duration
+}
+
+// startPosition returns the position to start a transcode from, given the
+// requested start time. Start times before the trim start are moved to the
+// trim start.
+func (t StreamTrim) startPosition(startTime float64) float64 {
+	return math.Max(startTime, t.Start)
+}
+
+// remaining returns the number of seconds from position to the trim end. It
+// returns false if the stream is not trimmed at the end.
+func (t StreamTrim) remaining(position float64) (float64, bool) {
+	if t.End <= 0 {
+		return 0, false
+	}
+	return math.Max(t.End-position, 0), true
+}
+
+// firstSegment returns the index of the segment containing the trim start.
+// Segmented streams start at the beginning of this segment, so that segments
+// are the same regardless of the trim.
+func (t StreamTrim) firstSegment() int {
+	return int(math.Max(t.Start, 0) / segmentLength)
+}
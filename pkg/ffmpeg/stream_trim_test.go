@@ -0,0 +1,48 @@
+package ffmpeg
+
+import "testing"
+
+func TestStreamTrim(t *testing.T) {
+	const duration = 100
+
+	tests := []struct {
+		name         string
+		trim         StreamTrim
+		startTime    float64
+		wantEnd      float64
+		wantStart    float64
+		wantDuration float64
+		wantTrimmed  bool
+		wantSegment  int
+	}{
+		{"untrimmed", StreamTrim{}, 0, 100, 0, 0, false, 0},
+		{"untrimmed seek", StreamTrim{}, 30, 100, 30, 0, false, 0},
+		{"start", StreamTrim{Start: 15}, 0, 100, 15, 0, false, 7},
+		{"seek past start", StreamTrim{Start: 15}, 30, 100, 30, 0, false, 7},
+		{"end", StreamTrim{End: 90}, 0, 90, 0, 90, true, 0},
+		{"end past file", StreamTrim{End: 120}, 0, 100, 0, 120, true, 0},
+		{"start and end", StreamTrim{Start: 10, End: 90}, 0, 90, 10, 80, true, 5},
+		{"seek past end", StreamTrim{Start: 10, End: 90}, 95, 90, 95, 0, true, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.trim.end(duration); got != tt.wantEnd {
+				t.Errorf("end() = %v, want %v", got, tt.wantEnd)
+			}
+
+			start := tt.trim.startPosition(tt.startTime)
+			if start != tt.wantStart {
+				t.Errorf("startPosition() = %v, want %v", start, tt.wantStart)
+			}
+
+			got, trimmed := tt.trim.remaining(start)
+			if got != tt.wantDuration || trimmed != tt.wantTrimmed {
+				t.Errorf("remaining() = %v, %v, want %v, %v", got, trimmed, tt.wantDuration, tt.wantTrimmed)
+			}
+
+			if got := tt.trim.firstSegment(); got != tt.wantSegment {
+				t.Errorf("firstSegment() = %v, want %v", got, tt.wantSegment)
+			}
+		})
+	}
+}
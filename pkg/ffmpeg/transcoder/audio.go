@@ -0,0 +1,24 @@
+package transcoder
+
+import (
+	"github.com/stashapp/stash/pkg/ffmpeg"
+)
+
+// ExtractAudio returns the arguments to extract the audio of input to output
+// as 16 kHz mono 16-bit PCM WAV, the format used by speech-to-text models.
+func ExtractAudio(input string, output string) ffmpeg.Args {
+	var args ffmpeg.Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(ffmpeg.LogLevelError)
+
+	args = args.Overwrite().
+		Input(input)
+
+	args = append(args, "-vn", "-ac", "1", "-ar", "16000")
+
+	args = args.AudioCodec(ffmpeg.AudioCodecPCMS16LE).
+		Format(ffmpeg.FormatWav).
+		Output(output)
+
+	return args
+}
@@ -42,3 +42,34 @@ func ImageThumbnail(input string, options ImageThumbnailOptions) ffmpeg.Args {
 
 	return args
 }
+
+type ImageTransformOptions struct {
+	VideoFilter ffmpeg.VideoFilter
+	OutputCodec ffmpeg.VideoCodec
+	OutputPath  string
+	Quality     int
+}
+
+// ImageTransform returns the arguments to apply the video filter of the
+// options to the image input, encoding the result using the output codec.
+func ImageTransform(input string, options ImageTransformOptions) ffmpeg.Args {
+	var args ffmpeg.Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(ffmpeg.LogLevelError)
+
+	args = args.Overwrite().
+		Input(input).
+		VideoFilter(options.VideoFilter).
+		VideoCodec(options.OutputCodec)
+
+	args = append(args, "-frames:v", "1")
+
+	if options.Quality > 0 {
+		args = args.FixedQualityScaleVideo(options.Quality)
+	}
+
+	args = args.ImageFormat(ffmpeg.ImageFormatImage2Pipe).
+		Output(options.OutputPath)
+
+	return args
+}
@@ -48,6 +48,9 @@ type FFProbeStream struct {
 	CodecType          string `json:"codec_type"`
 	CodedHeight        int    `json:"coded_height,omitempty"`
 	CodedWidth         int    `json:"coded_width,omitempty"`
+	ColorPrimaries     string `json:"color_primaries,omitempty"`
+	ColorSpace         string `json:"color_space,omitempty"`
+	ColorTransfer      string `json:"color_transfer,omitempty"`
 	DisplayAspectRatio string `json:"display_aspect_ratio,omitempty"`
 	Disposition        struct {
 		AttachedPic     int `json:"attached_pic"`
@@ -95,6 +98,11 @@ type FFProbeStream struct {
 	SampleFmt     string `json:"sample_fmt,omitempty"`
 	SampleRate    string `json:"sample_rate,omitempty"`
 	SideDataList  []struct {
-		Rotation int `json:"rotation"`
+		SideDataType string `json:"side_data_type"`
+		Rotation     int    `json:"rotation"`
+		// set for spherical mapping side data
+		Projection string `json:"projection"`
+		// set for stereo 3d side data
+		Type string `json:"type"`
 	} `json:"side_data_list"`
 }
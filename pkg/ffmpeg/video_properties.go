@@ -0,0 +1,72 @@
+package ffmpeg
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// color transfer characteristics of HDR videos
+	ColorTransferPQ  = "smpte2084"
+	ColorTransferHLG = "arib-std-b67"
+
+	sideDataTypeDolbyVision = "DOVI configuration record"
+	sideDataTypeSpherical   = "Spherical Mapping"
+	sideDataTypeStereo3D    = "Stereo 3D"
+)
+
+// codec tags of Dolby Vision streams
+var dolbyVisionCodecTags = []string{"dvh1", "dvhe", "dav1", "dva1", "dvav"}
+
+// matches the bit depth of pixel formats such as yuv420p10le, gbrp12 and
+// gray10le, but not the chroma subsampling of formats such as nv12
+var pixFmtBitDepthRE = regexp.MustCompile(`(?:p|gray|rgb)(9|10|12|14|16)(?:le|be)?$`)
+
+// matches the bit depth of packed pixel formats such as p010le and p016le
+var packedPixFmtBitDepthRE = regexp.MustCompile(`^p0(10|12|16)(?:le|be)?$`)
+
+// bitDepth returns the number of bits per colour component of the stream,
+// or 0 if unknown.
+func bitDepth(s *FFProbeStream) int {
+	if v, err := strconv.Atoi(s.BitsPerRawSample); err == nil && v > 0 {
+		return v
+	}
+
+	return pixFmtBitDepth(s.PixFmt)
+}
+
+func pixFmtBitDepth(pixFmt string) int {
+	if pixFmt == "" {
+		return 0
+	}
+
+	for _, re := range []*regexp.Regexp{packedPixFmtBitDepthRE, pixFmtBitDepthRE} {
+		if m := re.FindStringSubmatch(pixFmt); m != nil {
+			v, _ := strconv.Atoi(m[1])
+			return v
+		}
+	}
+
+	// remaining formats such as yuv420p and nv12 are 8 bit
+	return 8
+}
+
+func parseSideData(v *VideoFile, s *FFProbeStream) {
+	for _, t := range dolbyVisionCodecTags {
+		if strings.EqualFold(s.CodecTagString, t) {
+			v.DolbyVision = true
+		}
+	}
+
+	for _, sd := range s.SideDataList {
+		switch sd.SideDataType {
+		case sideDataTypeDolbyVision:
+			v.DolbyVision = true
+		case sideDataTypeSpherical:
+			v.SphericalProjection = sd.Projection
+		case sideDataTypeStereo3D:
+			v.Stereo3DType = sd.Type
+		}
+	}
+}
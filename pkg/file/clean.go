@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -38,20 +39,58 @@ type CleanOptions struct {
 	// PathFilter are used to determine if a file should be included.
 	// Excluded files are marked for cleaning.
 	PathFilter PathFilter
+
+	// Rules are applied to files that exist and are accepted by PathFilter.
+	// Files that do not satisfy a rule are marked for cleaning.
+	Rules []*models.CleanRule
+
+	// Confirmed is the report of a previous dry run. If set, only the files
+	// and folders listed in the report are deleted.
+	Confirmed *CleanReport
+}
+
+// CleanReportItem is a file or folder that was, or would be in a dry run,
+// removed by the clean process.
+type CleanReportItem struct {
+	Path   string
+	Folder bool
+	Reason string
+}
+
+// CleanReport lists the files and folders that were, or would be in a dry
+// run, removed by the clean process.
+type CleanReport struct {
+	Items []CleanReportItem
+}
+
+// Contains returns true if the report contains the file or folder with the
+// given path.
+func (r *CleanReport) Contains(path string, folder bool) bool {
+	for _, i := range r.Items {
+		if i.Path == path && i.Folder == folder {
+			return true
+		}
+	}
+
+	return false
 }
 
-// Clean starts the clean process.
-func (s *Cleaner) Clean(ctx context.Context, options CleanOptions, progress *job.Progress) {
+// Clean starts the clean process. Returns the report of the files and
+// folders that were removed, or nil if an error occurred.
+func (s *Cleaner) Clean(ctx context.Context, options CleanOptions, progress *job.Progress) *CleanReport {
 	j := &cleanJob{
 		Cleaner:  s,
 		progress: progress,
 		options:  options,
 	}
 
-	if err := j.execute(ctx); err != nil {
+	report, err := j.execute(ctx)
+	if err != nil {
 		logger.Errorf("error cleaning files: %v", err)
-		return
+		return nil
 	}
+
+	return report
 }
 
 type fileOrFolder struct {
@@ -64,19 +103,24 @@ type deleteSet struct {
 	fileIDSet   map[models.FileID]string
 
 	folderIDSet map[models.FolderID]string
+
+	reasons map[fileOrFolder]string
 }
 
 func newDeleteSet() deleteSet {
 	return deleteSet{
 		fileIDSet:   make(map[models.FileID]string),
 		folderIDSet: make(map[models.FolderID]string),
+		reasons:     make(map[fileOrFolder]string),
 	}
 }
 
-func (s *deleteSet) add(id models.FileID, path string) {
+func (s *deleteSet) add(id models.FileID, path string, reason string) {
 	if _, ok := s.fileIDSet[id]; !ok {
-		s.orderedList = append(s.orderedList, fileOrFolder{fileID: id})
+		ff := fileOrFolder{fileID: id}
+		s.orderedList = append(s.orderedList, ff)
 		s.fileIDSet[id] = path
+		s.reasons[ff] = reason
 	}
 }
 
@@ -85,10 +129,12 @@ func (s *deleteSet) has(id models.FileID) bool {
 	return ok
 }
 
-func (s *deleteSet) addFolder(id models.FolderID, path string) {
+func (s *deleteSet) addFolder(id models.FolderID, path string, reason string) {
 	if _, ok := s.folderIDSet[id]; !ok {
-		s.orderedList = append(s.orderedList, fileOrFolder{folderID: id})
+		ff := fileOrFolder{folderID: id}
+		s.orderedList = append(s.orderedList, ff)
 		s.folderIDSet[id] = path
+		s.reasons[ff] = reason
 	}
 }
 
@@ -101,7 +147,43 @@ func (s *deleteSet) len() int {
 	return len(s.orderedList)
 }
 
-func (j *cleanJob) execute(ctx context.Context) error {
+func (s *deleteSet) path(ff fileOrFolder) string {
+	if ff.fileID != 0 {
+		return s.fileIDSet[ff.fileID]
+	}
+	return s.folderIDSet[ff.folderID]
+}
+
+// confirmed removes any files and folders that are not in the confirmed report.
+func (s *deleteSet) confirmed(report *CleanReport) {
+	var list []fileOrFolder
+	for _, ff := range s.orderedList {
+		path := s.path(ff)
+		if !report.Contains(path, ff.folderID != 0) {
+			logger.Infof("Not in confirmed clean report, skipping: %q", path)
+			continue
+		}
+
+		list = append(list, ff)
+	}
+
+	s.orderedList = list
+}
+
+func (s *deleteSet) report() *CleanReport {
+	ret := &CleanReport{}
+	for _, ff := range s.orderedList {
+		ret.Items = append(ret.Items, CleanReportItem{
+			Path:   s.path(ff),
+			Folder: ff.folderID != 0,
+			Reason: s.reasons[ff],
+		})
+	}
+
+	return ret
+}
+
+func (j *cleanJob) execute(ctx context.Context) (*CleanReport, error) {
 	progress := j.progress
 
 	toDelete := newDeleteSet()
@@ -126,24 +208,31 @@ func (j *cleanJob) execute(ctx context.Context) error {
 
 		return nil
 	}); err != nil {
-		return err
+		return nil, err
 	}
 
 	progress.AddTotal(fileCount + folderCount)
 	progress.Definite()
 
 	if err := j.assessFiles(ctx, &toDelete); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := j.assessFolders(ctx, &toDelete); err != nil {
-		return err
+		return nil, err
 	}
 
 	if j.options.DryRun && toDelete.len() > 0 {
 		// add progress for files that would've been deleted
 		progress.AddProcessed(toDelete.len())
-		return nil
+		return toDelete.report(), nil
+	}
+
+	if j.options.Confirmed != nil {
+		total := toDelete.len()
+		toDelete.confirmed(j.options.Confirmed)
+		// add progress for files that were skipped
+		progress.AddProcessed(total - toDelete.len())
 	}
 
 	progress.ExecuteTask(fmt.Sprintf("Cleaning %d files and folders", toDelete.len()), func() {
@@ -163,7 +252,7 @@ func (j *cleanJob) execute(ctx context.Context) error {
 		}
 	})
 
-	return nil
+	return toDelete.report(), nil
 }
 
 func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
@@ -195,8 +284,8 @@ func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
 				}
 
 				progress.ExecuteTask(fmt.Sprintf("Assessing file %s for clean", path), func() {
-					if j.shouldClean(ctx, f) {
-						err = j.flagFileForDelete(ctx, toDelete, f)
+					if reason := j.shouldClean(ctx, f); reason != "" {
+						err = j.flagFileForDelete(ctx, toDelete, f, reason)
 					} else {
 						// increment progress, no further processing
 						progress.Increment()
@@ -223,7 +312,7 @@ func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
 }
 
 // flagFolderForDelete adds folders to the toDelete set, with the leaf folders added first
-func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f models.File) error {
+func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f models.File, reason string) error {
 	r := j.Repository
 	// add contained files first
 	containedFiles, err := r.File.FindByZipFileID(ctx, f.Base().ID)
@@ -233,7 +322,7 @@ func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f
 
 	for _, cf := range containedFiles {
 		logger.Infof("Marking contained file %q to clean", cf.Base().Path)
-		toDelete.add(cf.Base().ID, cf.Base().Path)
+		toDelete.add(cf.Base().ID, cf.Base().Path, "contained in cleaned file")
 	}
 
 	// add contained folders as well
@@ -244,10 +333,10 @@ func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f
 
 	for _, cf := range containedFolders {
 		logger.Infof("Marking contained folder %q to clean", cf.Path)
-		toDelete.addFolder(cf.ID, cf.Path)
+		toDelete.addFolder(cf.ID, cf.Path, "contained in cleaned file")
 	}
 
-	toDelete.add(f.Base().ID, f.Base().Path)
+	toDelete.add(f.Base().ID, f.Base().Path, reason)
 
 	return nil
 }
@@ -281,8 +370,8 @@ func (j *cleanJob) assessFolders(ctx context.Context, toDelete *deleteSet) error
 
 				err = nil
 				progress.ExecuteTask(fmt.Sprintf("Assessing folder %s for clean", path), func() {
-					if j.shouldCleanFolder(ctx, f) {
-						if err = j.flagFolderForDelete(ctx, toDelete, f); err != nil {
+					if reason := j.shouldCleanFolder(ctx, f); reason != "" {
+						if err = j.flagFolderForDelete(ctx, toDelete, f, reason); err != nil {
 							return
 						}
 					} else {
@@ -310,10 +399,10 @@ func (j *cleanJob) assessFolders(ctx context.Context, toDelete *deleteSet) error
 	return nil
 }
 
-func (j *cleanJob) flagFolderForDelete(ctx context.Context, toDelete *deleteSet, folder *models.Folder) error {
+func (j *cleanJob) flagFolderForDelete(ctx context.Context, toDelete *deleteSet, folder *models.Folder, reason string) error {
 	// it is possible that child folders may be included while parent folders are not
 	// so we need to check child folders separately
-	toDelete.addFolder(folder.ID, folder.Path)
+	toDelete.addFolder(folder.ID, folder.Path, reason)
 
 	return nil
 }
@@ -329,42 +418,62 @@ func isNotFound(err error) bool {
 			errors.As(err, &pathErr))
 }
 
-func (j *cleanJob) shouldClean(ctx context.Context, f models.File) bool {
+// Reasons that files and folders are cleaned
+const (
+	cleanReasonNotFound       = "not found"
+	cleanReasonInvalidSymlink = "invalid symlink"
+	cleanReasonExcluded       = "excluded by library configuration"
+)
+
+// shouldClean returns the reason that the file should be cleaned, or an
+// empty string if it should not be cleaned.
+func (j *cleanJob) shouldClean(ctx context.Context, f models.File) string {
 	path := f.Base().Path
 
 	info, err := f.Base().Info(j.FS)
 	if err != nil && !isNotFound(err) {
 		logger.Errorf("error getting file info for %q, not cleaning: %v", path, err)
-		return false
+		return ""
 	}
 
 	if info == nil {
 		// info is nil - file not exist
 		logger.Infof("File not found. Marking to clean: \"%s\"", path)
-		return true
+		return cleanReasonNotFound
 	}
 
 	// run through path filter, if returns false then the file should be cleaned
 	filter := j.options.PathFilter
 
 	// don't log anything - assume filter will have logged the reason
-	return !filter.Accept(ctx, path, info)
+	if !filter.Accept(ctx, path, info) {
+		return cleanReasonExcluded
+	}
+
+	if reason := cleanRuleViolation(j.options.Rules, path, info, time.Now()); reason != "" {
+		logger.Infof("File is %s. Marking to clean: \"%s\"", reason, path)
+		return reason
+	}
+
+	return ""
 }
 
-func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool {
+// shouldCleanFolder returns the reason that the folder should be cleaned,
+// or an empty string if it should not be cleaned.
+func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) string {
 	path := f.Path
 
 	info, err := f.Info(j.FS)
 
 	if err != nil && !isNotFound(err) {
 		logger.Errorf("error getting folder info for %q, not cleaning: %v", path, err)
-		return false
+		return ""
 	}
 
 	if info == nil {
 		// info is nil - file not exist
 		logger.Infof("Folder not found. Marking to clean: \"%s\"", path)
-		return true
+		return cleanReasonNotFound
 	}
 
 	// #3261 - handle symlinks
@@ -373,13 +482,13 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool
 		if err != nil {
 			// don't bail out if symlink is invalid
 			logger.Infof("Invalid symlink. Marking to clean: \"%s\"", path)
-			return true
+			return cleanReasonInvalidSymlink
 		}
 
 		info, err = j.FS.Lstat(finalPath)
 		if err != nil && !isNotFound(err) {
 			logger.Errorf("error getting file info for %q (-> %s), not cleaning: %v", path, finalPath, err)
-			return false
+			return ""
 		}
 	}
 
@@ -387,7 +496,11 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool
 	filter := j.options.PathFilter
 
 	// don't log anything - assume filter will have logged the reason
-	return !filter.Accept(ctx, path, info)
+	if !filter.Accept(ctx, path, info) {
+		return cleanReasonExcluded
+	}
+
+	return ""
 }
 
 func (j *cleanJob) deleteFile(ctx context.Context, fileID models.FileID, fn string) {
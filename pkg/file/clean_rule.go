@@ -0,0 +1,63 @@
+package file
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// cleanRuleViolation returns the reason that the file at path does not
+// satisfy the applicable clean rules. Returns an empty string if the file
+// satisfies all rules that apply to it.
+func cleanRuleViolation(rules []*models.CleanRule, path string, info fs.FileInfo, now time.Time) string {
+	for _, r := range rules {
+		if !fsutil.IsPathInDir(r.Path, path) {
+			continue
+		}
+
+		if reason := ruleViolation(r, path, info, now); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
+
+func ruleViolation(r *models.CleanRule, path string, info fs.FileInfo, now time.Time) string {
+	if r.MinSize != nil && info.Size() < *r.MinSize {
+		return fmt.Sprintf("smaller than %d bytes (rule for %s)", *r.MinSize, r.Path)
+	}
+
+	if r.MaxSize != nil && info.Size() > *r.MaxSize {
+		return fmt.Sprintf("larger than %d bytes (rule for %s)", *r.MaxSize, r.Path)
+	}
+
+	if len(r.Extensions) > 0 && !hasExtension(r.Extensions, path) {
+		return fmt.Sprintf("extension not in %s (rule for %s)", strings.Join(r.Extensions, ", "), r.Path)
+	}
+
+	if r.MaxAgeDays != nil {
+		maxAge := time.Duration(*r.MaxAgeDays) * 24 * time.Hour
+		if now.Sub(info.ModTime()) > maxAge {
+			return fmt.Sprintf("modified more than %d days ago (rule for %s)", *r.MaxAgeDays, r.Path)
+		}
+	}
+
+	return ""
+}
+
+func hasExtension(extensions []string, path string) bool {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	for _, e := range extensions {
+		if strings.EqualFold(strings.TrimPrefix(e, "."), ext) {
+			return true
+		}
+	}
+
+	return false
+}
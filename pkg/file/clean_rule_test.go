@@ -0,0 +1,68 @@
+package file
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFileInfo struct {
+	fs.FileInfo
+	size    int64
+	modTime time.Time
+}
+
+func (i testFileInfo) Size() int64        { return i.size }
+func (i testFileInfo) ModTime() time.Time { return i.modTime }
+
+func TestCleanRuleViolation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	minSize := int64(1000)
+	maxSize := int64(5000)
+	maxAgeDays := 30
+
+	dir := filepath.Join("stash", "rules")
+	other := filepath.Join("stash", "other")
+
+	rules := []*models.CleanRule{
+		{
+			Path:       dir,
+			MinSize:    &minSize,
+			MaxSize:    &maxSize,
+			Extensions: []string{"mp4", ".MKV"},
+			MaxAgeDays: &maxAgeDays,
+		},
+	}
+
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-31 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		path    string
+		size    int64
+		modTime time.Time
+		want    bool
+	}{
+		{"valid", filepath.Join(dir, "a.mp4"), 2000, recent, false},
+		{"extension case", filepath.Join(dir, "a.mkv"), 2000, recent, false},
+		{"too small", filepath.Join(dir, "a.mp4"), 999, recent, true},
+		{"too large", filepath.Join(dir, "a.mp4"), 5001, recent, true},
+		{"extension", filepath.Join(dir, "a.avi"), 2000, recent, true},
+		{"too old", filepath.Join(dir, "a.mp4"), 2000, old, true},
+		{"subdirectory", filepath.Join(dir, "sub", "a.avi"), 2000, recent, true},
+		{"other path", filepath.Join(other, "a.avi"), 1, old, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := testFileInfo{size: tt.size, modTime: tt.modTime}
+			got := cleanRuleViolation(rules, tt.path, info, now)
+			assert.Equal(t, tt.want, got != "", got)
+		})
+	}
+}
@@ -14,6 +14,9 @@ type Repository struct {
 
 	File   models.FileReaderWriter
 	Folder models.FolderReaderWriter
+
+	// Quarantine is optional. If nil, unreadable files are not quarantined.
+	Quarantine models.QuarantinedFileReaderWriter
 }
 
 func NewRepository(repo models.Repository) Repository {
@@ -21,6 +24,7 @@ func NewRepository(repo models.Repository) Repository {
 		TxnManager: repo.TxnManager,
 		File:       repo.File,
 		Folder:     repo.Folder,
+		Quarantine: repo.Quarantine,
 	}
 }
 
@@ -59,6 +59,10 @@ func (f *OsFS) Open(name string) (fs.ReadDirFile, error) {
 }
 
 func (f *OsFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	if isPdfPath(name) {
+		return newPdfFS(f, name)
+	}
+
 	return newZipFS(f, name, size)
 }
 
@@ -61,7 +61,7 @@ func (i *Importer) fileJSONToFile(ctx context.Context, fileJSON jsonschema.DirEn
 		if err != nil {
 			return nil, err
 		}
-		return &models.VideoFile{
+		ret := &models.VideoFile{
 			BaseFile:         baseFile,
 			Format:           ff.Format,
 			Width:            ff.Width,
@@ -71,9 +71,17 @@ func (i *Importer) fileJSONToFile(ctx context.Context, fileJSON jsonschema.DirEn
 			AudioCodec:       ff.AudioCodec,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
+			BitDepth:         ff.BitDepth,
+			AudioBitRate:     ff.AudioBitRate,
+			HDRFormat:        ff.HDRFormat,
+			VRProjection:     ff.VRProjection,
+			StereoLayout:     ff.StereoLayout,
 			Interactive:      ff.Interactive,
 			InteractiveSpeed: ff.InteractiveSpeed,
-		}, nil
+		}
+		// the quality score is derived from the other properties
+		ret.QualityScore = ret.CalculateQualityScore()
+		return ret, nil
 	case *jsonschema.ImageFile:
 		baseFile, err := i.baseFileJSONToBaseFile(ctx, ff.BaseFile)
 		if err != nil {
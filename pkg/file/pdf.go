@@ -0,0 +1,366 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	pdfExt = ".pdf"
+
+	// pdfPageSize is the size in pixels of the longest side of rendered
+	// pdf pages.
+	pdfPageSize = 2048
+	// pdfPageQuality is the jpeg quality of rendered pdf pages.
+	pdfPageQuality = 90
+	// pdfPageExt is the extension of the virtual page files of a pdf file.
+	pdfPageExt = ".jpg"
+)
+
+var (
+	errPdfToolsNotFound = errors.New("pdftoppm and pdfinfo are required to read pdf files")
+
+	pdftoppmPath string
+	pdfinfoPath  string
+	pdfToolsOnce sync.Once
+)
+
+// getPdfTools returns the paths to the poppler pdftoppm and pdfinfo
+// executables, or empty strings if they are not found.
+func getPdfTools() (pdftoppm string, pdfinfo string) {
+	pdfToolsOnce.Do(func() {
+		pdftoppmPath, _ = exec.LookPath("pdftoppm")
+		pdfinfoPath, _ = exec.LookPath("pdfinfo")
+	})
+	return pdftoppmPath, pdfinfoPath
+}
+
+func isPdfPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), pdfExt)
+}
+
+// pdfFS is a read-only file system backed by a pdf file. It contains a jpeg
+// image file for each page of the pdf, named in page order. Pages are
+// rendered when they are opened.
+type pdfFS struct {
+	path    string
+	modTime time.Time
+	pages   int
+
+	pdftoppm string
+
+	// the last rendered page is kept, since each page is opened more than
+	// once while scanning, and pages are scanned in order
+	mu       sync.Mutex
+	lastPage int
+	lastData []byte
+}
+
+func newPdfFS(f models.FS, path string) (*pdfFS, error) {
+	pdftoppm, pdfinfo := getPdfTools()
+	if pdftoppm == "" || pdfinfo == "" {
+		return nil, errPdfToolsNotFound
+	}
+
+	info, err := f.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := pdfPageCount(pdfinfo, path)
+	if err != nil {
+		return nil, fmt.Errorf("reading page count of %q: %w", path, err)
+	}
+
+	return &pdfFS{
+		path:     path,
+		modTime:  info.ModTime(),
+		pages:    pages,
+		pdftoppm: pdftoppm,
+	}, nil
+}
+
+func pdfPageCount(pdfinfo string, path string) (int, error) {
+	cmd := stashExec.Command(pdfinfo, fsutil.LongPath(path))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if v, found := strings.CutPrefix(scanner.Text(), "Pages:"); found {
+			return strconv.Atoi(strings.TrimSpace(v))
+		}
+	}
+
+	return 0, errors.New("page count not found in pdfinfo output")
+}
+
+// pageName returns the name of the file of the 1-based page. Page numbers
+// are padded so that names sort in page order.
+func (f *pdfFS) pageName(page int) string {
+	width := max(4, len(strconv.Itoa(f.pages)))
+	return fmt.Sprintf("page-%0*d%s", width, page, pdfPageExt)
+}
+
+// page returns the 1-based page number of the named page file.
+func (f *pdfFS) page(name string) (int, error) {
+	v, found := strings.CutPrefix(name, "page-")
+	if found {
+		v, found = strings.CutSuffix(v, pdfPageExt)
+	}
+
+	if found {
+		page, err := strconv.Atoi(v)
+		if err == nil && page >= 1 && page <= f.pages && name == f.pageName(page) {
+			return page, nil
+		}
+	}
+
+	return 0, fs.ErrNotExist
+}
+
+func (f *pdfFS) rel(name string) (string, error) {
+	if f.path == name {
+		return ".", nil
+	}
+
+	relName, err := filepath.Rel(f.path, name)
+	if err != nil {
+		return "", fmt.Errorf("internal error getting relative path: %w", err)
+	}
+
+	return filepath.ToSlash(relName), nil
+}
+
+func (f *pdfFS) render(page int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.lastPage == page {
+		return f.lastData, nil
+	}
+
+	pageArg := strconv.Itoa(page)
+	cmd := stashExec.Command(f.pdftoppm,
+		"-f", pageArg, "-l", pageArg,
+		"-singlefile",
+		"-scale-to", strconv.Itoa(pdfPageSize),
+		"-jpeg", "-jpegopt", "quality="+strconv.Itoa(pdfPageQuality),
+		fsutil.LongPath(f.path),
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("rendering page %d of %q: %w: %s", page, f.path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	f.lastPage = page
+	f.lastData = data
+
+	return data, nil
+}
+
+func (f *pdfFS) dirInfo() fs.FileInfo {
+	return &pdfFileInfo{
+		name:    filepath.Base(f.path),
+		modTime: f.modTime,
+		isDir:   true,
+	}
+}
+
+func (f *pdfFS) pageInfo(page int, data []byte) fs.FileInfo {
+	return &pdfFileInfo{
+		name:    f.pageName(page),
+		size:    int64(len(data)),
+		modTime: f.modTime,
+	}
+}
+
+func (f *pdfFS) Stat(name string) (fs.FileInfo, error) {
+	relName, err := f.rel(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if relName == "." {
+		return f.dirInfo(), nil
+	}
+
+	page, err := f.page(relName)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	data, err := f.render(page)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.pageInfo(page, data), nil
+}
+
+func (f *pdfFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Stat(name)
+}
+
+func (f *pdfFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return nil, errZipFSOpenZip
+}
+
+func (f *pdfFS) IsPathCaseSensitive(path string) (bool, error) {
+	return true, nil
+}
+
+func (f *pdfFS) Open(name string) (fs.ReadDirFile, error) {
+	relName, err := f.rel(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if relName == "." {
+		return &pdfDir{fs: f}, nil
+	}
+
+	page, err := f.page(relName)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	data, err := f.render(page)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pdfPageFile{
+		Reader: bytes.NewReader(data),
+		info:   f.pageInfo(page, data),
+	}, nil
+}
+
+func (f *pdfFS) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastPage = 0
+	f.lastData = nil
+	return nil
+}
+
+func (f *pdfFS) OpenOnly(name string) (io.ReadCloser, error) {
+	r, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedReadCloser{
+		ReadCloser: r,
+		outer:      f,
+	}, nil
+}
+
+type pdfFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func (i *pdfFileInfo) Name() string       { return i.name }
+func (i *pdfFileInfo) Size() int64        { return i.size }
+func (i *pdfFileInfo) ModTime() time.Time { return i.modTime }
+func (i *pdfFileInfo) IsDir() bool        { return i.isDir }
+func (i *pdfFileInfo) Sys() any           { return nil }
+
+func (i *pdfFileInfo) Mode() fs.FileMode {
+	if i.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+type pdfPageFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *pdfPageFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *pdfPageFile) Close() error               { return nil }
+
+func (f *pdfPageFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: f.info.Name(), Err: errors.New("not a directory")}
+}
+
+// pdfDir is the root directory of a pdf file system.
+type pdfDir struct {
+	fs *pdfFS
+	// next is the 1-based page number of the next entry to read
+	next int
+}
+
+func (d *pdfDir) Stat() (fs.FileInfo, error) { return d.fs.dirInfo(), nil }
+func (d *pdfDir) Close() error               { return nil }
+
+func (d *pdfDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.fs.path, Err: errors.New("is a directory")}
+}
+
+func (d *pdfDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.next == 0 {
+		d.next = 1
+	}
+
+	remaining := d.fs.pages - d.next + 1
+	if n > 0 && remaining == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < remaining {
+		remaining = n
+	}
+
+	ret := make([]fs.DirEntry, remaining)
+	for i := range ret {
+		ret[i] = &pdfPageEntry{fs: d.fs, page: d.next}
+		d.next++
+	}
+	return ret, nil
+}
+
+// pdfPageEntry is a directory entry for a page. The page is only rendered
+// when its info is read.
+type pdfPageEntry struct {
+	fs   *pdfFS
+	page int
+}
+
+func (e *pdfPageEntry) Name() string      { return e.fs.pageName(e.page) }
+func (e *pdfPageEntry) IsDir() bool       { return false }
+func (e *pdfPageEntry) Type() fs.FileMode { return 0 }
+
+func (e *pdfPageEntry) Info() (fs.FileInfo, error) {
+	data, err := e.fs.render(e.page)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.fs.pageInfo(e.page, data), nil
+}
@@ -0,0 +1,82 @@
+package file
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPdfFS_Page(t *testing.T) {
+	f := &pdfFS{path: filepath.Join("gallery", "set.pdf"), pages: 12}
+
+	assert.Equal(t, "page-0001.jpg", f.pageName(1))
+	assert.Equal(t, "page-0012.jpg", f.pageName(12))
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{"page-0001.jpg", 1, false},
+		{"page-0012.jpg", 12, false},
+		{"page-0013.jpg", 0, true},
+		{"page-0000.jpg", 0, true},
+		{"page-1.jpg", 0, true},
+		{"page-0001.png", 0, true},
+		{"cover.jpg", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.page(tt.name)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, fs.ErrNotExist)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// page numbers are padded to the number of digits in the page count
+	f.pages = 12345
+	assert.Equal(t, "page-00001.jpg", f.pageName(1))
+}
+
+func TestPdfFS_ReadDir(t *testing.T) {
+	f := &pdfFS{path: filepath.Join("gallery", "set.pdf"), pages: 3}
+
+	dir, err := f.Open(f.path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	info, err := dir.Stat()
+	if assert.NoError(t, err) {
+		assert.True(t, info.IsDir())
+		assert.Equal(t, "set.pdf", info.Name())
+	}
+
+	var names []string
+	for {
+		entries, err := dir.ReadDir(2)
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+	}
+
+	assert.Equal(t, []string{"page-0001.jpg", "page-0002.jpg", "page-0003.jpg"}, names)
+
+	_, err = f.Open(filepath.Join(f.path, "page-0004.jpg"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
@@ -0,0 +1,34 @@
+package file
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// IsPlaceholder returns true if info describes a cloud placeholder file whose
+// contents have not been downloaded, such as an online-only OneDrive or
+// Dropbox file, or a sparse file on an rclone mount.
+// Reading a placeholder file causes its contents to be downloaded.
+func IsPlaceholder(info fs.FileInfo) bool {
+	if info.IsDir() {
+		return false
+	}
+
+	return isPlaceholder(info)
+}
+
+// isPendingContent returns true if the file at path is a placeholder. If the
+// file is a symlink, the target file is checked.
+func isPendingContent(f models.FS, path string, info fs.FileInfo) bool {
+	if info.Mode()&os.ModeSymlink == os.ModeSymlink {
+		targetInfo, err := f.Stat(path)
+		if err != nil {
+			return false
+		}
+		info = targetInfo
+	}
+
+	return IsPlaceholder(info)
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+// +build !windows
+
+package file
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// placeholderMinSize is the minimum size of a file that may be considered a
+// placeholder. Small files may have no allocated blocks when their contents
+// are stored inline with the filesystem metadata.
+const placeholderMinSize = 1024 * 1024
+
+// placeholderAllocatedRatio is the fraction of a file that must be allocated
+// on disk for it not to be considered a placeholder.
+const placeholderAllocatedRatio = 10
+
+func isPlaceholder(info fs.FileInfo) bool {
+	if info.Size() < placeholderMinSize {
+		return false
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+
+	// Blocks is always in 512-byte units
+	allocated := int64(stat.Blocks) * 512
+	return allocated*placeholderAllocatedRatio < info.Size()
+}
@@ -0,0 +1,68 @@
+//go:build !windows
+// +build !windows
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsPlaceholder(t *testing.T) {
+	dir := t.TempDir()
+
+	const size = 8 * placeholderMinSize
+
+	writeFile := func(name string, contents int64) os.FileInfo {
+		t.Helper()
+
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		if contents > 0 {
+			if _, err := f.Write(make([]byte, contents)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// extends the file without allocating blocks
+		if err := f.Truncate(size); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := f.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+
+	sparse := writeFile("sparse", 0)
+	if !isPlaceholder(sparse) {
+		t.Skip("filesystem does not support sparse files")
+	}
+
+	if !IsPlaceholder(sparse) {
+		t.Errorf("IsPlaceholder(sparse) = false, want true")
+	}
+
+	if full := writeFile("full", size); IsPlaceholder(full) {
+		t.Errorf("IsPlaceholder(full) = true, want false")
+	}
+
+	if partial := writeFile("partial", size/2); IsPlaceholder(partial) {
+		t.Errorf("IsPlaceholder(partial) = true, want false")
+	}
+
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsPlaceholder(dirInfo) {
+		t.Errorf("IsPlaceholder(dir) = true, want false")
+	}
+}
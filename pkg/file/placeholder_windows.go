@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package file
+
+import (
+	"io/fs"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// placeholderAttributes are set on files whose contents are stored remotely.
+const placeholderAttributes = windows.FILE_ATTRIBUTE_OFFLINE |
+	windows.FILE_ATTRIBUTE_RECALL_ON_OPEN |
+	windows.FILE_ATTRIBUTE_RECALL_ON_DATA_ACCESS
+
+func isPlaceholder(info fs.FileInfo) bool {
+	attrs, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return false
+	}
+
+	return attrs.FileAttributes&placeholderAttributes != 0
+}
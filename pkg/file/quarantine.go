@@ -0,0 +1,126 @@
+package file
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// UnreadableFileError is returned when the contents of a new file cannot be
+// read or probed, for example because the file is corrupt or truncated.
+// Unreadable files are quarantined rather than added to the library.
+type UnreadableFileError struct {
+	Err error
+}
+
+func (e *UnreadableFileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnreadableFileError) Unwrap() error {
+	return e.Err
+}
+
+// loadQuarantine loads the quarantined files, so that they do not need to be
+// queried for each scanned file.
+func (s *scanJob) loadQuarantine(ctx context.Context) error {
+	s.quarantined = make(map[string]*models.QuarantinedFile)
+
+	if s.Repository.Quarantine == nil {
+		return nil
+	}
+
+	return s.withDB(ctx, func(ctx context.Context) error {
+		all, err := s.Repository.Quarantine.All(ctx)
+		if err != nil {
+			return fmt.Errorf("loading quarantined files: %w", err)
+		}
+
+		for _, q := range all {
+			s.quarantined[q.Path] = q
+		}
+
+		return nil
+	})
+}
+
+// isQuarantineIgnored returns true if the file is quarantined, has been
+// ignored, and has not changed since it was quarantined.
+func (s *scanJob) isQuarantineIgnored(f scanFile) bool {
+	q := s.quarantined[f.Path]
+	return q != nil && q.Ignored && q.Size == f.Size && q.ModTime.Equal(f.ModTime)
+}
+
+// quarantine records the file as unreadable, or updates the existing record.
+func (s *scanJob) quarantine(ctx context.Context, f scanFile, scanErr error) {
+	if s.Repository.Quarantine == nil {
+		return
+	}
+
+	logger.Warnf("Quarantining unreadable file %q: %v", f.Path, scanErr)
+
+	if err := s.withTxn(ctx, func(ctx context.Context) error {
+		qb := s.Repository.Quarantine
+
+		existing, err := qb.FindByPath(ctx, f.Path)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			newFile := models.NewQuarantinedFile()
+			newFile.Path = f.Path
+			newFile.Error = scanErr.Error()
+			newFile.Size = f.Size
+			newFile.ModTime = f.ModTime
+			return qb.Create(ctx, &newFile)
+		}
+
+		partial := models.NewQuarantinedFilePartial()
+		partial.Error = models.NewOptionalString(scanErr.Error())
+		partial.Size = models.NewOptionalInt64(f.Size)
+		partial.ModTime = models.NewOptionalTime(f.ModTime)
+		partial.Attempts = models.NewOptionalInt(existing.Attempts + 1)
+		// a changed file is no longer ignored
+		if existing.Size != f.Size || !existing.ModTime.Equal(f.ModTime) {
+			partial.Ignored = models.NewOptionalBool(false)
+		}
+
+		_, err = qb.UpdatePartial(ctx, existing.ID, partial)
+		return err
+	}); err != nil {
+		logger.Errorf("error quarantining %q: %v", f.Path, err)
+	}
+}
+
+// releaseQuarantine removes the quarantine record of a file that has been
+// added to the library.
+func (s *scanJob) releaseQuarantine(ctx context.Context, f scanFile) {
+	q := s.quarantined[f.Path]
+	if q == nil || s.Repository.Quarantine == nil || s.isQuarantineIgnored(f) {
+		return
+	}
+
+	released := false
+	if err := s.withTxn(ctx, func(ctx context.Context) error {
+		// the file may have been deferred rather than added
+		existing, err := s.Repository.File.FindByPath(ctx, f.Path)
+		if err != nil || existing == nil {
+			return err
+		}
+
+		released = true
+		return s.Repository.Quarantine.Destroy(ctx, q.ID)
+	}); err != nil {
+		logger.Errorf("error removing %q from quarantine: %v", f.Path, err)
+		return
+	}
+
+	if !released {
+		return
+	}
+
+	logger.Infof("%s was scanned successfully and removed from quarantine", f.Path)
+}
@@ -0,0 +1,61 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanJob_isQuarantineIgnored(t *testing.T) {
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	const size = 100
+
+	s := &scanJob{
+		quarantined: map[string]*models.QuarantinedFile{
+			"ignored": {Path: "ignored", Size: size, ModTime: modTime, Ignored: true},
+			"retried": {Path: "retried", Size: size, ModTime: modTime},
+		},
+	}
+
+	scanFileFor := func(path string, size int64, modTime time.Time) scanFile {
+		return scanFile{
+			BaseFile: &models.BaseFile{
+				DirEntry: models.DirEntry{ModTime: modTime},
+				Path:     path,
+				Size:     size,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		f    scanFile
+		want bool
+	}{
+		{"not quarantined", scanFileFor("other", size, modTime), false},
+		{"ignored", scanFileFor("ignored", size, modTime), true},
+		{"ignored resized", scanFileFor("ignored", size+1, modTime), false},
+		{"ignored modified", scanFileFor("ignored", size, modTime.Add(time.Second)), false},
+		{"not ignored", scanFileFor("retried", size, modTime), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.isQuarantineIgnored(tt.f))
+		})
+	}
+}
+
+func TestUnreadableFileError(t *testing.T) {
+	cause := errors.New("invalid data found when processing input")
+	err := fmt.Errorf("handling file: %w", &UnreadableFileError{Err: cause})
+
+	var unreadableErr *UnreadableFileError
+	assert.True(t, errors.As(err, &unreadableErr))
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, cause.Error(), unreadableErr.Error())
+}
@@ -115,6 +115,10 @@ type scanJob struct {
 	folderPathToID sync.Map
 	zipPathToID    sync.Map
 	count          int
+	checkpoints    *scanCheckpointer
+
+	// quarantined maps paths to quarantined files. Read-only once loaded.
+	quarantined map[string]*models.QuarantinedFile
 
 	txnRetryer txn.Retryer
 }
@@ -137,6 +141,15 @@ type ScanOptions struct {
 
 	// When true files in path will be rescanned even if they haven't changed
 	Rescan bool
+
+	// ResumeCursors maps paths to the last file processed by an interrupted
+	// scan of the path. Entries up to and including the cursor are skipped.
+	ResumeCursors map[string]string
+
+	// Checkpoint is called with a path and the last file in the path before
+	// which all files have been processed. It is called as files are processed,
+	// and should not block.
+	Checkpoint func(path string, cursor string)
 }
 
 // Scan starts the scanning process.
@@ -152,6 +165,10 @@ func (s *Scanner) Scan(ctx context.Context, handlers []Handler, options ScanOpti
 		},
 	}
 
+	if options.Checkpoint != nil {
+		job.checkpoints = newScanCheckpointer(options.Checkpoint)
+	}
+
 	job.execute(ctx)
 }
 
@@ -159,6 +176,9 @@ type scanFile struct {
 	*models.BaseFile
 	fs   models.FS
 	info fs.FileInfo
+
+	// seq is the position of the file in the scan queue
+	seq int
 }
 
 func (s *scanJob) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -174,6 +194,11 @@ func (s *scanJob) execute(ctx context.Context) {
 	logger.Infof("scanning %d paths", len(paths))
 	s.startTime = time.Now()
 
+	if err := s.loadQuarantine(ctx); err != nil {
+		logger.Errorf("error scanning files: %v", err)
+		return
+	}
+
 	s.fileQueue = make(chan scanFile, scanQueueSize)
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -208,7 +233,9 @@ func (s *scanJob) queueFiles(ctx context.Context, paths []string) error {
 	var err error
 	s.ProgressReports.ExecuteTask("Walking directory tree", func() {
 		for _, p := range paths {
-			err = symWalk(s.FS, p, s.queueFileFunc(ctx, s.FS, nil))
+			s.checkpoints.setRoot(p)
+			walkFn := resumeWalkFunc(p, s.options.ResumeCursors[p], s.queueFileFunc(ctx, s.FS, nil))
+			err = symWalk(s.FS, p, walkFn)
 			if err != nil {
 				return
 			}
@@ -260,9 +287,10 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zipFile *scanF
 				DirEntry: models.DirEntry{
 					ModTime: modTime(info),
 				},
-				Path:     path,
-				Basename: filepath.Base(path),
-				Size:     size,
+				Path:           path,
+				Basename:       filepath.Base(path),
+				Size:           size,
+				PendingContent: isPendingContent(f, path, info),
 			},
 			fs:   f,
 			info: info,
@@ -305,6 +333,7 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f models.FS, zipFile *scanF
 			return nil
 		}
 
+		ff.seq = s.checkpoints.queue(path)
 		s.fileQueue <- ff
 
 		s.count++
@@ -429,8 +458,20 @@ func (s *scanJob) processQueueItem(ctx context.Context, f scanFile) {
 			err = s.handleFile(ctx, f)
 		}
 
-		if err != nil && !errors.Is(err, context.Canceled) {
+		var unreadableErr *UnreadableFileError
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.As(err, &unreadableErr):
+			s.quarantine(ctx, f, unreadableErr.Err)
+		case err != nil:
 			logger.Errorf("error processing %q: %v", f.Path, err)
+		case err == nil && !f.info.IsDir():
+			s.releaseQuarantine(ctx, f)
+		}
+
+		// files interrupted by cancellation must be scanned again on resume
+		if ctx.Err() == nil {
+			s.checkpoints.done(f.seq, s.retrying)
 		}
 	})
 }
@@ -718,22 +759,34 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 		}
 
 		s.retryList = append(s.retryList, f)
+		s.checkpoints.retry(f.seq)
 		return nil, nil
 	}
 
 	baseFile.ParentFolderID = *parentFolderID
 
-	const useExisting = false
-	fp, err := s.calculateFingerprints(f.fs, baseFile, path, useExisting)
-	if err != nil {
-		return nil, err
+	if s.isQuarantineIgnored(f) {
+		logger.Debugf("%s is quarantined and ignored. Skipping.", path)
+		return nil, nil
+	}
+
+	var fp models.Fingerprints
+	if baseFile.PendingContent {
+		// reading the file would download its contents
+		logger.Infof("%s is a placeholder. Deferring fingerprints until its contents are downloaded.", path)
+	} else {
+		const useExisting = false
+		fp, err = s.calculateFingerprints(f.fs, baseFile, path, useExisting)
+		if err != nil {
+			return nil, &UnreadableFileError{Err: err}
+		}
 	}
 
 	baseFile.SetFingerprints(fp)
 
 	file, err := s.fireDecorators(ctx, f.fs, baseFile)
 	if err != nil {
-		return nil, err
+		return nil, &UnreadableFileError{Err: err}
 	}
 
 	// determine if the file is renamed from an existing file in the store
@@ -1027,34 +1080,54 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 	fileModTime := f.ModTime
 	updated := !fileModTime.Equal(base.ModTime)
 	forceRescan := s.options.Rescan
+	// placeholder contents have been downloaded since the last scan
+	downloaded := base.PendingContent && !f.PendingContent
 
-	if !updated && !forceRescan {
+	if !updated && !forceRescan && !downloaded {
 		return s.onUnchangedFile(ctx, f, existing)
 	}
 
 	oldBase := *base
 
-	if !updated && forceRescan {
-		logger.Infof("rescanning %s", path)
-	} else {
+	switch {
+	case updated:
 		logger.Infof("%s has been updated: rescanning", path)
+	case downloaded:
+		logger.Infof("%s has been downloaded: rescanning", path)
+	default:
+		logger.Infof("rescanning %s", path)
 	}
 
 	base.ModTime = fileModTime
 	base.Size = f.Size
 	base.UpdatedAt = time.Now()
 
-	// calculate and update fingerprints for the file
-	const useExisting = false
-	fp, err := s.calculateFingerprints(f.fs, base, path, useExisting)
-	if err != nil {
-		return nil, err
+	if updated {
+		// the contents may no longer be shared with the linked file
+		base.LinkedFileID = nil
 	}
 
-	s.removeOutdatedFingerprints(existing, fp)
-	existing.SetFingerprints(fp)
+	switch {
+	case !f.PendingContent:
+		// calculate and update fingerprints for the file
+		const useExisting = false
+		fp, err := s.calculateFingerprints(f.fs, base, path, useExisting)
+		if err != nil {
+			return nil, err
+		}
+
+		s.removeOutdatedFingerprints(existing, fp)
+		existing.SetFingerprints(fp)
+		base.PendingContent = false
+	case updated:
+		// existing fingerprints no longer match the contents, which can't be
+		// read until they are downloaded
+		logger.Infof("%s is a placeholder. Deferring fingerprints until its contents are downloaded.", path)
+		base.Fingerprints = nil
+		base.PendingContent = true
+	}
 
-	existing, err = s.fireDecorators(ctx, f.fs, existing)
+	existing, err := s.fireDecorators(ctx, f.fs, existing)
 	if err != nil {
 		return nil, err
 	}
@@ -1077,9 +1150,17 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 	return existing, nil
 }
 
+// checksumFingerprintTypes are the fingerprint types that are checksums of
+// the whole file contents, and are not always calculated.
+var checksumFingerprintTypes = []string{
+	models.FingerprintTypeMD5,
+	models.FingerprintTypeSHA256,
+	models.FingerprintTypeBLAKE3,
+}
+
 func (s *scanJob) removeOutdatedFingerprints(existing models.File, fp models.Fingerprints) {
-	// HACK - if no MD5 fingerprint was returned, and the oshash is changed
-	// then remove the MD5 fingerprint
+	// HACK - if the oshash is changed, remove any checksums that were not
+	// recalculated, since they no longer match the contents
 	oshash := fp.For(models.FingerprintTypeOshash)
 	if oshash == nil {
 		return
@@ -1091,17 +1172,16 @@ func (s *scanJob) removeOutdatedFingerprints(existing models.File, fp models.Fin
 		return
 	}
 
-	md5 := fp.For(models.FingerprintTypeMD5)
+	b := existing.Base()
+	for _, t := range checksumFingerprintTypes {
+		if fp.For(t) != nil || b.Fingerprints.For(t) == nil {
+			// recalculated or not set - nothing to do
+			continue
+		}
 
-	if md5 != nil {
-		// nothing to do
-		return
+		logger.Infof("Removing outdated %s checksum from %s", t, b.Path)
+		b.Fingerprints = b.Fingerprints.Remove(t)
 	}
-
-	// oshash has changed, MD5 is missing - remove MD5 from the existing fingerprints
-	logger.Infof("Removing outdated checksum from %s", existing.Base().Path)
-	b := existing.Base()
-	b.Fingerprints = b.Fingerprints.Remove(models.FingerprintTypeMD5)
 }
 
 // returns a file only if it was updated
@@ -1117,10 +1197,12 @@ func (s *scanJob) onUnchangedFile(ctx context.Context, f scanFile, existing mode
 		}
 	}
 
-	// calculate missing fingerprints
-	existing, err = s.setMissingFingerprints(ctx, f, existing)
-	if err != nil {
-		return nil, err
+	// calculate missing fingerprints, unless the contents are not downloaded
+	if !f.PendingContent {
+		existing, err = s.setMissingFingerprints(ctx, f, existing)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	handlerRequired := false
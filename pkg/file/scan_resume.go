@@ -0,0 +1,212 @@
+package file
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// compareWalkOrder compares two paths under the same root by the order in
+// which they are visited by symWalk. Directory entries are visited in name
+// order, and directories are visited before their contents.
+// Returns -1 if a is visited before b, 1 if a is visited after b, and 0 if
+// the paths are equal.
+func compareWalkOrder(root, a, b string) int {
+	aParts := walkPathParts(root, a)
+	bParts := walkPathParts(root, b)
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func walkPathParts(root, path string) []string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return nil
+	}
+
+	return strings.Split(rel, string(filepath.Separator))
+}
+
+// isPathAncestor returns true if path is equal to or a parent directory of
+// child.
+func isPathAncestor(path, child string) bool {
+	if path == child {
+		return true
+	}
+
+	rel, err := filepath.Rel(path, child)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resumeSkip determines whether an entry was already processed by a scan
+// which processed all entries of root up to and including cursor.
+// skip is true if the entry itself must not be processed again. descend is
+// true if the entry is a directory which must still be walked, because it
+// contains entries after the cursor.
+func resumeSkip(root, cursor, path string, isDir bool) (skip bool, descend bool) {
+	if cursor == "" {
+		return false, isDir
+	}
+
+	if isDir && isPathAncestor(path, cursor) {
+		return true, true
+	}
+
+	if compareWalkOrder(root, path, cursor) <= 0 {
+		return true, false
+	}
+
+	return false, isDir
+}
+
+type checkpointEntry struct {
+	root  string
+	path  string
+	done  bool
+	retry bool
+}
+
+// scanCheckpointer tracks the files queued for scanning in walk order, and
+// reports the last file of each scan path before which all queued files
+// have been processed. Files are processed in parallel, so a file is only
+// reported once all files queued before it are also processed.
+type scanCheckpointer struct {
+	fn func(root, cursor string)
+
+	mutex   sync.Mutex
+	root    string
+	entries map[int]*checkpointEntry
+	queued  int
+	next    int
+}
+
+func newScanCheckpointer(fn func(root, cursor string)) *scanCheckpointer {
+	return &scanCheckpointer{
+		fn:      fn,
+		entries: make(map[int]*checkpointEntry),
+	}
+}
+
+// setRoot sets the scan path of subsequently queued files.
+func (c *scanCheckpointer) setRoot(root string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.root = root
+}
+
+// queue adds a file to the end of the walk order and returns its sequence
+// number.
+func (c *scanCheckpointer) queue(path string) int {
+	if c == nil {
+		return 0
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	seq := c.queued
+	c.entries[seq] = &checkpointEntry{
+		root: c.root,
+		path: path,
+	}
+	c.queued++
+
+	return seq
+}
+
+// retry marks a file as deferred until after the scan queue is exhausted.
+// Files after it are not reported until it is processed.
+func (c *scanCheckpointer) retry(seq int) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e := c.entries[seq]; e != nil {
+		e.retry = true
+	}
+}
+
+// done marks a file as processed. Deferred files are only marked as
+// processed when retrying is true.
+func (c *scanCheckpointer) done(seq int, retrying bool) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e := c.entries[seq]
+	if e == nil || (e.retry && !retrying) {
+		return
+	}
+	e.done = true
+
+	var last *checkpointEntry
+	for {
+		e := c.entries[c.next]
+		if e == nil || !e.done {
+			break
+		}
+
+		if last != nil && last.root != e.root {
+			c.fn(last.root, last.path)
+		}
+
+		last = e
+		delete(c.entries, c.next)
+		c.next++
+	}
+
+	if last != nil {
+		c.fn(last.root, last.path)
+	}
+}
+
+// resumeWalkFunc wraps fn so that entries of root up to and including cursor
+// are skipped.
+func resumeWalkFunc(root, cursor string, fn fs.WalkDirFunc) fs.WalkDirFunc {
+	if cursor == "" {
+		return fn
+	}
+
+	return func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d == nil {
+			return fn(path, d, err)
+		}
+
+		skip, descend := resumeSkip(root, cursor, path, d.IsDir())
+		switch {
+		case !skip:
+			return fn(path, d, err)
+		case descend:
+			return nil
+		case d.IsDir():
+			return fs.SkipDir
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package file
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareWalkOrder(t *testing.T) {
+	root := filepath.Join("stash", "library")
+	p := func(parts ...string) string {
+		return filepath.Join(append([]string{root}, parts...)...)
+	}
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"equal", p("a", "b.mp4"), p("a", "b.mp4"), 0},
+		{"root first", root, p("a"), -1},
+		{"parent first", p("a"), p("a", "b.mp4"), -1},
+		{"child after", p("a", "b.mp4"), p("a"), 1},
+		{"sibling order", p("a", "b.mp4"), p("a", "c.mp4"), -1},
+		// a/z is walked before "a b" since a sorts before "a b"
+		{"component order", p("a", "z.mp4"), p("a b"), -1},
+		{"nested before later sibling", p("a", "b", "c.mp4"), p("a", "c.mp4"), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareWalkOrder(root, tt.a, tt.b))
+		})
+	}
+}
+
+func TestResumeSkip(t *testing.T) {
+	root := filepath.Join("stash", "library")
+	p := func(parts ...string) string {
+		return filepath.Join(append([]string{root}, parts...)...)
+	}
+	cursor := p("b", "c", "d.mp4")
+
+	tests := []struct {
+		name        string
+		path        string
+		isDir       bool
+		wantSkip    bool
+		wantDescend bool
+	}{
+		{"root", root, true, true, true},
+		{"earlier folder", p("a"), true, true, false},
+		{"earlier file", p("a.mp4"), false, true, false},
+		{"ancestor folder", p("b"), true, true, true},
+		{"processed sibling", p("b", "c", "a.mp4"), false, true, false},
+		{"cursor", cursor, false, true, false},
+		{"later sibling", p("b", "c", "e.mp4"), false, false, false},
+		{"later folder", p("b", "d"), true, false, true},
+		{"similar folder name", p("b", "c d"), true, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, descend := resumeSkip(root, cursor, tt.path, tt.isDir)
+			assert.Equal(t, tt.wantSkip, skip, "skip")
+			assert.Equal(t, tt.wantDescend, descend, "descend")
+		})
+	}
+}
+
+func TestScanCheckpointer(t *testing.T) {
+	type checkpoint struct {
+		root   string
+		cursor string
+	}
+	var got []checkpoint
+	c := newScanCheckpointer(func(root, cursor string) {
+		got = append(got, checkpoint{root, cursor})
+	})
+
+	c.setRoot("a")
+	a1 := c.queue("a/1")
+	a2 := c.queue("a/2")
+	c.setRoot("b")
+	b1 := c.queue("b/1")
+	b2 := c.queue("b/2")
+
+	// out of order completion is not reported
+	c.done(a2, false)
+	assert.Empty(t, got)
+
+	c.done(a1, false)
+	assert.Equal(t, []checkpoint{{"a", "a/2"}}, got)
+
+	// deferred files hold back the checkpoint until retried
+	c.retry(b1)
+	c.done(b1, false)
+	c.done(b2, false)
+	assert.Len(t, got, 1)
+
+	c.done(b1, true)
+	assert.Equal(t, []checkpoint{{"a", "a/2"}, {"b", "b/2"}}, got)
+}
@@ -0,0 +1,173 @@
+package video
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func hdrFormat(v *ffmpeg.VideoFile) *models.HDRFormat {
+	var ret models.HDRFormat
+	switch {
+	case v.DolbyVision:
+		ret = models.HDRFormatDolbyVision
+	case v.ColorTransfer == ffmpeg.ColorTransferPQ:
+		ret = models.HDRFormatHDR10
+	case v.ColorTransfer == ffmpeg.ColorTransferHLG:
+		ret = models.HDRFormatHLG
+	default:
+		return nil
+	}
+
+	return &ret
+}
+
+// projections from the spherical video metadata
+var sphericalProjections = map[string]models.VRProjection{
+	"equirectangular":       models.VRProjectionEquirectangular,
+	"tiled equirectangular": models.VRProjectionEquirectangular,
+	"half equirectangular":  models.VRProjectionEquirectangular180,
+	"cubemap":               models.VRProjectionCubemap,
+	"fisheye":               models.VRProjectionFisheye,
+}
+
+// layouts from the stereo 3D metadata
+var stereo3DLayouts = map[string]models.StereoLayout{
+	"side by side":                        models.StereoLayoutSideBySide,
+	"side by side (quincunx subsampling)": models.StereoLayoutSideBySide,
+	"top and bottom":                      models.StereoLayoutTopBottom,
+}
+
+var filenameTokenRE = regexp.MustCompile(`[^a-z0-9]+`)
+
+// filename tokens that identify the projection of a video as VR on their own
+var vrProjectionTokens = map[string]models.VRProjection{
+	"vr180":      models.VRProjectionEquirectangular180,
+	"180x180":    models.VRProjectionEquirectangular180,
+	"vr360":      models.VRProjectionEquirectangular,
+	"360x180":    models.VRProjectionEquirectangular,
+	"cubemap":    models.VRProjectionCubemap,
+	"eac":        models.VRProjectionCubemap,
+	"fisheye":    models.VRProjectionFisheye,
+	"fisheye190": models.VRProjectionFisheye,
+	"fisheye200": models.VRProjectionFisheye,
+	"mkx200":     models.VRProjectionFisheye,
+	"mkx220":     models.VRProjectionFisheye,
+	"rf52":       models.VRProjectionFisheye,
+	"vrca220":    models.VRProjectionFisheye,
+}
+
+// filename tokens that only identify the projection when the filename
+// otherwise indicates a VR video
+var vrFieldOfViewTokens = map[string]models.VRProjection{
+	"180": models.VRProjectionEquirectangular180,
+	"360": models.VRProjectionEquirectangular,
+}
+
+// filename tokens that identify the stereo layout on their own
+var stereoLayoutTokens = map[string]models.StereoLayout{
+	"sbs":       models.StereoLayoutSideBySide,
+	"3dh":       models.StereoLayoutSideBySide,
+	"3dv":       models.StereoLayoutTopBottom,
+	"overunder": models.StereoLayoutTopBottom,
+}
+
+// filename tokens that only identify the stereo layout when the filename
+// otherwise indicates a VR video
+var vrStereoLayoutTokens = map[string]models.StereoLayout{
+	"lr": models.StereoLayoutSideBySide,
+	"rl": models.StereoLayoutSideBySide,
+	"tb": models.StereoLayoutTopBottom,
+	"bt": models.StereoLayoutTopBottom,
+	"ou": models.StereoLayoutTopBottom,
+}
+
+// vrFromFilename detects the VR projection and stereo layout of a video
+// from the naming conventions used by VR players, such as
+// "name_180_LR.mp4" or "name_FISHEYE190_3dh.mp4". The projection is empty
+// if the filename indicates a VR video without specifying it.
+func vrFromFilename(path string) (projection models.VRProjection, layout models.StereoLayout, isVR bool) {
+	base := strings.ToLower(filepath.Base(path))
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	tokens := filenameTokenRE.Split(base, -1)
+
+	var (
+		fov       models.VRProjection
+		vrLayout  models.StereoLayout
+		hasVRWord bool
+	)
+
+	for _, t := range tokens {
+		if v, ok := vrProjectionTokens[t]; ok && projection == "" {
+			projection = v
+		}
+		if v, ok := vrFieldOfViewTokens[t]; ok && fov == "" {
+			fov = v
+		}
+		if v, ok := stereoLayoutTokens[t]; ok && layout == "" {
+			layout = v
+		}
+		if v, ok := vrStereoLayoutTokens[t]; ok && vrLayout == "" {
+			vrLayout = v
+		}
+		if t == "vr" {
+			hasVRWord = true
+		}
+	}
+
+	// the field of view and VR layout tokens are ambiguous on their own,
+	// but together indicate a VR video, for example "name_180_LR"
+	isVR = projection != "" || hasVRWord || (fov != "" && (layout != "" || vrLayout != ""))
+	if !isVR {
+		return "", layout, false
+	}
+
+	if projection == "" {
+		projection = fov
+	}
+	if layout == "" {
+		layout = vrLayout
+	}
+
+	return projection, layout, true
+}
+
+// vrProperties returns the VR projection and stereo layout of the video,
+// from its metadata if present, otherwise from its filename.
+func vrProperties(path string, v *ffmpeg.VideoFile) (*models.VRProjection, *models.StereoLayout) {
+	projection, layout, isVR := vrFromFilename(path)
+
+	if p, ok := sphericalProjections[v.SphericalProjection]; ok {
+		// the spherical metadata of 180 degree videos is often only
+		// distinguishable by its bounds, so prefer a filename that
+		// specifies 180 degrees
+		if p != models.VRProjectionEquirectangular || projection != models.VRProjectionEquirectangular180 {
+			projection = p
+		}
+		isVR = true
+	}
+
+	if l, ok := stereo3DLayouts[v.Stereo3DType]; ok {
+		layout = l
+	}
+
+	if isVR && projection == "" {
+		// VR players assume 180 degree video by default
+		projection = models.VRProjectionEquirectangular180
+	}
+
+	var retProjection *models.VRProjection
+	if isVR {
+		retProjection = &projection
+	}
+
+	var retLayout *models.StereoLayout
+	if layout != "" {
+		retLayout = &layout
+	}
+
+	return retProjection, retLayout
+}
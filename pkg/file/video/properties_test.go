@@ -0,0 +1,61 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestVRProperties(t *testing.T) {
+	const (
+		eq    = models.VRProjectionEquirectangular
+		eq180 = models.VRProjectionEquirectangular180
+		fish  = models.VRProjectionFisheye
+		sbs   = models.StereoLayoutSideBySide
+		tb    = models.StereoLayoutTopBottom
+	)
+
+	tests := []struct {
+		path           string
+		probe          ffmpeg.VideoFile
+		wantProjection models.VRProjection
+		wantLayout     models.StereoLayout
+	}{
+		{"/stash/scene.mp4", ffmpeg.VideoFile{}, "", ""},
+		{"/stash/episode 180.mp4", ffmpeg.VideoFile{}, "", ""},
+		{"/stash/scene_LR.mp4", ffmpeg.VideoFile{}, "", ""},
+		{"/stash/scene_180_LR.mp4", ffmpeg.VideoFile{}, eq180, sbs},
+		{"/stash/scene_360_TB.mp4", ffmpeg.VideoFile{}, eq, tb},
+		{"/stash/scene_VR180.mp4", ffmpeg.VideoFile{}, eq180, ""},
+		{"/stash/scene_VR_LR.mp4", ffmpeg.VideoFile{}, eq180, sbs},
+		{"/stash/scene_FISHEYE190_3dh.mp4", ffmpeg.VideoFile{}, fish, sbs},
+		{"/stash/scene_MKX200.mp4", ffmpeg.VideoFile{}, fish, ""},
+		{"/stash/scene.360x180.3dv.mkv", ffmpeg.VideoFile{}, eq, tb},
+		{"/stash/scene_SBS.mp4", ffmpeg.VideoFile{}, "", sbs},
+		// metadata takes precedence over the filename
+		{"/stash/scene_VR_LR.mp4", ffmpeg.VideoFile{SphericalProjection: "equirectangular", Stereo3DType: "top and bottom"}, eq, tb},
+		{"/stash/scene.mp4", ffmpeg.VideoFile{SphericalProjection: "equirectangular"}, eq, ""},
+		// except where the filename specifies 180 degrees
+		{"/stash/scene_180_LR.mp4", ffmpeg.VideoFile{SphericalProjection: "equirectangular"}, eq180, sbs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			projection, layout := vrProperties(tt.path, &tt.probe)
+
+			var gotProjection models.VRProjection
+			if projection != nil {
+				gotProjection = *projection
+			}
+			var gotLayout models.StereoLayout
+			if layout != nil {
+				gotLayout = *layout
+			}
+
+			assert.Equal(t, tt.wantProjection, gotProjection)
+			assert.Equal(t, tt.wantLayout, gotLayout)
+		})
+	}
+}
@@ -43,18 +43,28 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 		interactive = true
 	}
 
-	return &models.VideoFile{
-		BaseFile:    base,
-		Format:      string(container),
-		VideoCodec:  videoFile.VideoCodec,
-		AudioCodec:  videoFile.AudioCodec,
-		Width:       videoFile.Width,
-		Height:      videoFile.Height,
-		Duration:    videoFile.FileDuration,
-		FrameRate:   videoFile.FrameRate,
-		BitRate:     videoFile.Bitrate,
-		Interactive: interactive,
-	}, nil
+	vrProjection, stereoLayout := vrProperties(base.Path, videoFile)
+
+	ret := &models.VideoFile{
+		BaseFile:     base,
+		Format:       string(container),
+		VideoCodec:   videoFile.VideoCodec,
+		AudioCodec:   videoFile.AudioCodec,
+		Width:        videoFile.Width,
+		Height:       videoFile.Height,
+		Duration:     videoFile.FileDuration,
+		FrameRate:    videoFile.FrameRate,
+		BitRate:      videoFile.Bitrate,
+		BitDepth:     videoFile.BitDepth,
+		AudioBitRate: videoFile.AudioBitrate,
+		HDRFormat:    hdrFormat(videoFile),
+		VRProjection: vrProjection,
+		StereoLayout: stereoLayout,
+		Interactive:  interactive,
+	}
+	ret.QualityScore = ret.CalculateQualityScore()
+
+	return ret, nil
 }
 
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f models.File) bool {
@@ -77,5 +87,7 @@ func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f model
 		vf.Format == unsetString || vf.Width == unsetNumber ||
 		vf.Height == unsetNumber || vf.FrameRate == unsetNumber ||
 		vf.Duration == unsetNumber ||
-		vf.BitRate == unsetNumber || interactive != vf.Interactive
+		vf.BitRate == unsetNumber || vf.BitDepth == unsetNumber ||
+		vf.AudioBitRate == unsetNumber ||
+		interactive != vf.Interactive
 }
@@ -120,3 +120,39 @@ func GetIntraDir(pattern string, depth, length int) string {
 	}
 	return intraDir
 }
+
+// RemoveEmptyDirs removes the empty subdirectories of root, including
+// subdirectories that only contain empty directories. root itself is not
+// removed.
+func RemoveEmptyDirs(root string) error {
+	var dirs []string
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && path != root {
+			dirs = append(dirs, path)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// remove the deepest directories first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
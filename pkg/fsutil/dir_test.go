@@ -77,3 +77,34 @@ func TestDirExists(t *testing.T) {
 		}
 	}
 }
+
+func TestRemoveEmptyDirs(t *testing.T) {
+	root := t.TempDir()
+
+	empty := filepath.Join(root, "ab", "cd")
+	nonEmpty := filepath.Join(root, "ef", "01")
+	for _, d := range []string{empty, nonEmpty} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(nonEmpty, "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assert := assert.New(t)
+	assert.NoError(RemoveEmptyDirs(root))
+
+	for _, tc := range []struct {
+		dir      string
+		expected bool
+	}{
+		{root, true},
+		{filepath.Join(root, "ab"), false},
+		{empty, false},
+		{nonEmpty, true},
+	} {
+		result, _ := DirExists(tc.dir)
+		assert.Equal(tc.expected, result, "expected: %t for dir: %s", tc.expected, tc.dir)
+	}
+}
@@ -0,0 +1,60 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInsufficientDiskSpace = errors.New("insufficient disk space")
+
+// DiskSpace is the size and available space of a filesystem, in bytes.
+type DiskSpace struct {
+	Total int64
+	Free  int64
+}
+
+// Used returns the number of bytes used on the filesystem.
+func (d DiskSpace) Used() int64 {
+	return d.Total - d.Free
+}
+
+// UsedPercent returns the percentage of the filesystem that is used.
+func (d DiskSpace) UsedPercent() float64 {
+	if d.Total <= 0 {
+		return 0
+	}
+
+	return float64(d.Used()) / float64(d.Total) * 100
+}
+
+// GetDiskSpace returns the size and available space of the filesystem
+// containing path.
+func GetDiskSpace(path string) (*DiskSpace, error) {
+	ret, err := getDiskSpace(path)
+	if err != nil {
+		return nil, fmt.Errorf("getting disk space of %s: %w", path, err)
+	}
+
+	return ret, nil
+}
+
+// CheckDiskSpace returns an error wrapping ErrInsufficientDiskSpace if the
+// filesystem containing path has reserved bytes or fewer available. Returns
+// nil if reserved is not positive or the available space cannot be
+// determined.
+func CheckDiskSpace(path string, reserved int64) error {
+	if reserved <= 0 || path == "" {
+		return nil
+	}
+
+	ds, err := getDiskSpace(path)
+	if err != nil {
+		return nil
+	}
+
+	if ds.Free <= reserved {
+		return fmt.Errorf("%w: %s has %d bytes free, %d bytes reserved", ErrInsufficientDiskSpace, path, ds.Free, reserved)
+	}
+
+	return nil
+}
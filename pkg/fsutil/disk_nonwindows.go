@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package fsutil
+
+import "syscall"
+
+func getDiskSpace(path string) (*DiskSpace, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return nil, err
+	}
+
+	// Bavail is the space available to unprivileged users
+	blockSize := uint64(stat.Bsize)
+	return &DiskSpace{
+		Total: int64(uint64(stat.Blocks) * blockSize),
+		Free:  int64(uint64(stat.Bavail) * blockSize),
+	}, nil
+}
+
+func sameDevice(a, b string) (bool, error) {
+	var sa, sb syscall.Stat_t
+	if err := syscall.Stat(a, &sa); err != nil {
+		return false, err
+	}
+	if err := syscall.Stat(b, &sb); err != nil {
+		return false, err
+	}
+
+	return sa.Dev == sb.Dev, nil
+}
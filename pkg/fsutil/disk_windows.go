@@ -0,0 +1,50 @@
+//go:build windows
+// +build windows
+
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+func getDiskSpace(path string) (*DiskSpace, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var freeAvailable, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &freeAvailable, &total, &totalFree); err != nil {
+		return nil, err
+	}
+
+	return &DiskSpace{
+		Total: int64(total),
+		Free:  int64(freeAvailable),
+	}, nil
+}
+
+// sameDevice compares the volumes of the paths. Paths on the same volume
+// are on the same filesystem.
+func sameDevice(a, b string) (bool, error) {
+	for _, p := range []string{a, b} {
+		if _, err := os.Stat(p); err != nil {
+			return false, err
+		}
+	}
+
+	a, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	b, err = filepath.Abs(b)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.EqualFold(filepath.VolumeName(a), filepath.VolumeName(b)), nil
+}
@@ -0,0 +1,121 @@
+package fsutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var ErrReflinkNotSupported = errors.New("reflinks are not supported by the filesystem")
+
+// LinkMode is the method used to share storage between identical files.
+type LinkMode string
+
+const (
+	// LinkModeHardlink replaces files with hard links. Hard linked files share
+	// the same inode, so changes to one are visible in all of them.
+	LinkModeHardlink LinkMode = "hardlink"
+	// LinkModeReflink replaces files with copy-on-write clones. Cloned files
+	// share storage until one of them is modified.
+	LinkModeReflink LinkMode = "reflink"
+)
+
+func (m LinkMode) IsValid() bool {
+	switch m {
+	case LinkModeHardlink, LinkModeReflink:
+		return true
+	}
+	return false
+}
+
+// SameDevice returns true if the files at paths a and b are on the same
+// filesystem.
+func SameDevice(a, b string) (bool, error) {
+	ret, err := sameDevice(a, b)
+	if err != nil {
+		return false, fmt.Errorf("comparing devices of %s and %s: %w", a, b, err)
+	}
+
+	return ret, nil
+}
+
+// SameContents returns true if the files at paths a and b have identical
+// contents.
+func SameContents(a, b string) (bool, error) {
+	fa, err := os.Open(a)
+	if err != nil {
+		return false, err
+	}
+	defer fa.Close()
+
+	fb, err := os.Open(b)
+	if err != nil {
+		return false, err
+	}
+	defer fb.Close()
+
+	const bufSize = 64 * 1024
+	bufA := make([]byte, bufSize)
+	bufB := make([]byte, bufSize)
+
+	for {
+		na, errA := io.ReadFull(fa, bufA)
+		nb, errB := io.ReadFull(fb, bufB)
+
+		if !bytes.Equal(bufA[:na], bufB[:nb]) {
+			return false, nil
+		}
+
+		doneA := errors.Is(errA, io.EOF) || errors.Is(errA, io.ErrUnexpectedEOF)
+		doneB := errors.Is(errB, io.EOF) || errors.Is(errB, io.ErrUnexpectedEOF)
+
+		switch {
+		case errA != nil && !doneA:
+			return false, errA
+		case errB != nil && !doneB:
+			return false, errB
+		case doneA || doneB:
+			return doneA == doneB, nil
+		}
+	}
+}
+
+// ReplaceWithLink replaces the file at dst with a link to the file at src,
+// using the given mode. The link is created alongside dst and renamed over
+// it, so dst is left untouched if the link cannot be created.
+func ReplaceWithLink(src, dst string, mode LinkMode) error {
+	info, err := os.Stat(dst)
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+".stashlink")
+
+	switch mode {
+	case LinkModeHardlink:
+		err = os.Link(src, tmp)
+	case LinkModeReflink:
+		err = reflink(src, tmp)
+		if err == nil {
+			err = os.Chmod(tmp, info.Mode().Perm())
+		}
+	default:
+		err = fmt.Errorf("invalid link mode %q", mode)
+	}
+
+	if err != nil {
+		// remove the partially created link, if any
+		_ = os.Remove(tmp)
+		return fmt.Errorf("linking %s to %s: %w", dst, src, err)
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("replacing %s: %w", dst, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+//go:build linux
+// +build linux
+
+package fsutil
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflink clones the contents of src into the new file dst, sharing the
+// underlying extents. Supported by filesystems such as btrfs and xfs.
+func reflink(src, dst string) (err error) {
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	w, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := w.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	if err := unix.IoctlFileClone(int(w.Fd()), int(r.Fd())); err != nil {
+		if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EXDEV) || errors.Is(err, unix.EINVAL) {
+			return ErrReflinkNotSupported
+		}
+		return err
+	}
+
+	return nil
+}
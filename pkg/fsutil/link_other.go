@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package fsutil
+
+func reflink(src, dst string) error {
+	return ErrReflinkNotSupported
+}
@@ -0,0 +1,106 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestSameContents(t *testing.T) {
+	dir := t.TempDir()
+
+	large := make([]byte, 200*1024)
+	for i := range large {
+		large[i] = byte(i)
+	}
+	largeChanged := append([]byte(nil), large...)
+	largeChanged[len(largeChanged)-1]++
+
+	tests := []struct {
+		name string
+		a    []byte
+		b    []byte
+		want bool
+	}{
+		{"empty", nil, nil, true},
+		{"equal", []byte("abc"), []byte("abc"), true},
+		{"different", []byte("abc"), []byte("abd"), false},
+		{"prefix", []byte("abc"), []byte("abcd"), false},
+		{"large equal", large, large, true},
+		{"large different", large, largeChanged, false},
+		{"large prefix", large, large[:len(large)-1], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := writeTestFile(t, dir, "a", tt.a)
+			b := writeTestFile(t, dir, "b", tt.b)
+
+			got, err := SameContents(a, b)
+			if err != nil {
+				t.Fatalf("SameContents() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SameContents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceWithLink(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte("contents")
+	src := writeTestFile(t, dir, "src", data)
+	dst := writeTestFile(t, dir, "dst", data)
+
+	if err := ReplaceWithLink(src, dst, LinkModeHardlink); err != nil {
+		t.Fatalf("ReplaceWithLink() error = %v", err)
+	}
+
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstInfo, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !os.SameFile(srcInfo, dstInfo) {
+		t.Errorf("ReplaceWithLink() did not link %s to %s", dst, src)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("ReplaceWithLink() left %d files in directory, want 2", len(entries))
+	}
+}
+
+func TestReplaceWithLinkFailure(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte("contents")
+	dst := writeTestFile(t, dir, "dst", data)
+
+	if err := ReplaceWithLink(filepath.Join(dir, "missing"), dst, LinkModeHardlink); err == nil {
+		t.Fatal("ReplaceWithLink() expected error")
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("ReplaceWithLink() modified %s on failure", dst)
+	}
+}
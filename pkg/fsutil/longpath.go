@@ -0,0 +1,85 @@
+package fsutil
+
+import "strings"
+
+// Windows limits paths to MAX_PATH (260) characters unless they are prefixed
+// with \\?\. Go adds the prefix itself when calling the os package, but
+// external tools such as ffmpeg receive paths as arguments and fail on long
+// paths unless the prefix is added explicitly.
+const (
+	windowsLongPathPrefix    = `\\?\`
+	windowsLongUNCPathPrefix = `\\?\UNC\`
+	windowsDevicePathPrefix  = `\\.\`
+
+	// windowsMaxPath is the length from which paths are prefixed. Directory
+	// paths must leave room for an 8.3 file name, so the limit is
+	// MAX_PATH - 12.
+	windowsMaxPath = 248
+)
+
+// LongPath returns the path in a form that supports long paths and UNC
+// shares when passed to external tools. On Windows, absolute paths that
+// exceed the MAX_PATH limit are converted to extended-length paths. Other
+// paths, and all paths on other platforms, are returned unchanged.
+func LongPath(path string) string {
+	if path == "" {
+		return path
+	}
+
+	return longPath(path)
+}
+
+// NormalizePath returns the path in a canonical form for storing and
+// comparing. On Windows, the extended-length prefix is removed, forward
+// slashes are replaced with backslashes and the path is made absolute, since
+// Go only supports long paths that are absolute. On other platforms the path
+// is cleaned. Empty paths are returned unchanged.
+func NormalizePath(path string) string {
+	if path == "" {
+		return path
+	}
+
+	return normalizePath(path)
+}
+
+func isWindowsUNCPath(path string) bool {
+	return strings.HasPrefix(path, `\\`) && !strings.HasPrefix(path, windowsLongPathPrefix) && !strings.HasPrefix(path, windowsDevicePathPrefix)
+}
+
+func isWindowsDrivePath(path string) bool {
+	return len(path) >= 3 && path[1] == ':' && path[2] == '\\'
+}
+
+// windowsLongPath returns the extended-length form of an absolute, cleaned
+// Windows path if it is too long for MAX_PATH. Relative paths cannot be
+// prefixed and are returned unchanged.
+func windowsLongPath(path string) string {
+	if len(path) < windowsMaxPath || strings.HasPrefix(path, windowsLongPathPrefix) || strings.HasPrefix(path, windowsDevicePathPrefix) {
+		return path
+	}
+
+	switch {
+	case isWindowsUNCPath(path):
+		// \\server\share\... becomes \\?\UNC\server\share\...
+		return windowsLongUNCPathPrefix + path[2:]
+	case isWindowsDrivePath(path):
+		return windowsLongPathPrefix + path
+	}
+
+	return path
+}
+
+// windowsStripLongPathPrefix converts extended-length Windows paths back to
+// their regular form and replaces forward slashes with backslashes.
+func windowsStripLongPathPrefix(path string) string {
+	path = strings.ReplaceAll(path, "/", `\`)
+
+	switch {
+	case strings.HasPrefix(strings.ToUpper(path), windowsLongUNCPathPrefix):
+		return `\\` + path[len(windowsLongUNCPathPrefix):]
+	case strings.HasPrefix(path, windowsLongPathPrefix):
+		return path[len(windowsLongPathPrefix):]
+	}
+
+	return path
+}
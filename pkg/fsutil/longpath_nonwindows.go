@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package fsutil
+
+import "path/filepath"
+
+func longPath(path string) string {
+	return path
+}
+
+func normalizePath(path string) string {
+	return filepath.Clean(path)
+}
@@ -0,0 +1,53 @@
+package fsutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWindowsLongPath(t *testing.T) {
+	long := strings.Repeat(`\folder`, 40) + `\file.mp4`
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"short drive path", `C:\videos\file.mp4`, `C:\videos\file.mp4`},
+		{"short UNC path", `\\server\share\file.mp4`, `\\server\share\file.mp4`},
+		{"long drive path", `C:` + long, `\\?\C:` + long},
+		{"long UNC path", `\\server\share` + long, `\\?\UNC\server\share` + long},
+		{"already prefixed", `\\?\C:` + long, `\\?\C:` + long},
+		{"device path", `\\.\pipe` + long, `\\.\pipe` + long},
+		{"long relative path", `videos` + long, `videos` + long},
+		{"long url", "http://example.com/" + strings.Repeat("a", 300), "http://example.com/" + strings.Repeat("a", 300)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := windowsLongPath(tt.path); got != tt.want {
+				t.Errorf("windowsLongPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowsStripLongPathPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"regular path", `C:\videos`, `C:\videos`},
+		{"forward slashes", `C:/videos/file.mp4`, `C:\videos\file.mp4`},
+		{"prefixed drive path", `\\?\C:\videos`, `C:\videos`},
+		{"prefixed UNC path", `\\?\UNC\server\share\videos`, `\\server\share\videos`},
+		{"forward slash UNC path", `//server/share/videos`, `\\server\share\videos`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := windowsStripLongPathPrefix(tt.path); got != tt.want {
+				t.Errorf("windowsStripLongPathPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
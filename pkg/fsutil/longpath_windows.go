@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package fsutil
+
+import "path/filepath"
+
+func longPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	return windowsLongPath(filepath.Clean(path))
+}
+
+func normalizePath(path string) string {
+	path = windowsStripLongPathPrefix(path)
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return abs
+}
@@ -0,0 +1,117 @@
+package gallery
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organize"
+)
+
+// DefaultDownloadTemplate keeps the original filenames of the images.
+const DefaultDownloadTemplate organize.ImageTemplate = "{basename}"
+
+// DownloadFile is a file to be written to a gallery download.
+type DownloadFile struct {
+	// Name is the slash-separated path of the file within the archive.
+	Name string
+	File models.File
+}
+
+// DownloadFiles returns the primary files of the images, named using the
+// template. The images must have their primary files loaded, and images
+// without files are skipped. Duplicate names are made unique by adding a
+// number before the extension.
+func DownloadFiles(g *models.Gallery, images []*models.Image, t organize.ImageTemplate) ([]DownloadFile, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	var ret []DownloadFile
+	used := make(map[string]bool)
+	for index, i := range images {
+		f := i.Files.Primary()
+		if f == nil {
+			continue
+		}
+
+		fields := organize.ImageFields(i, f, g.GetTitle(), index+1, len(images))
+		name, err := t.Render(fields)
+		if err != nil {
+			return nil, fmt.Errorf("image %d: %w", i.ID, err)
+		}
+
+		name = uniqueName(filepath.ToSlash(name), used)
+		used[strings.ToLower(name)] = true
+
+		ret = append(ret, DownloadFile{
+			Name: name,
+			File: f,
+		})
+	}
+
+	return ret, nil
+}
+
+// uniqueName returns name, or name with a number added if it has already
+// been used. Names are compared case-insensitively, since archives are
+// commonly extracted to case-insensitive filesystems.
+func uniqueName(name string, used map[string]bool) string {
+	if !used[strings.ToLower(name)] {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for n := 2; ; n++ {
+		candidate := base + " (" + strconv.Itoa(n) + ")" + ext
+		if !used[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+// WriteDownload writes the files to w as a zip archive. Files are stored
+// without compression, since image formats are already compressed.
+func WriteDownload(ctx context.Context, w io.Writer, fs models.FS, files []DownloadFile) error {
+	zw := zip.NewWriter(w)
+
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := writeDownloadFile(zw, fs, f); err != nil {
+			return fmt.Errorf("writing %s: %w", f.File.Base().Path, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeDownloadFile(zw *zip.Writer, fs models.FS, f DownloadFile) error {
+	base := f.File.Base()
+
+	r, err := base.Open(fs)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     f.Name,
+		Method:   zip.Store,
+		Modified: base.ModTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fw, r)
+	return err
+}
@@ -0,0 +1,83 @@
+package gallery
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func downloadTestImage(id int, path string) *models.Image {
+	return &models.Image{
+		ID: id,
+		Files: models.NewRelatedFiles([]models.File{
+			&models.ImageFile{
+				BaseFile: &models.BaseFile{
+					Path:     path,
+					Basename: filepath.Base(path),
+				},
+			},
+		}),
+	}
+}
+
+func TestDownloadFiles(t *testing.T) {
+	g := &models.Gallery{Title: "Gallery"}
+	images := []*models.Image{
+		downloadTestImage(1, filepath.Join("a", "img.jpg")),
+		downloadTestImage(2, filepath.Join("b", "img.jpg")),
+		downloadTestImage(3, filepath.Join("b", "IMG.jpg")),
+		{ID: 4, Files: models.NewRelatedFiles(nil)},
+	}
+
+	got, err := DownloadFiles(g, images, DefaultDownloadTemplate)
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range got {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"img.jpg", "img (2).jpg", "IMG (3).jpg"}, names)
+
+	got, err = DownloadFiles(g, images[:2], "{gallery}/{index}")
+	require.NoError(t, err)
+	assert.Equal(t, "Gallery/1.jpg", got[0].Name)
+	assert.Equal(t, "Gallery/2.jpg", got[1].Name)
+
+	_, err = DownloadFiles(g, images, "{unknown}")
+	assert.Error(t, err)
+}
+
+func TestWriteDownload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "img.jpg")
+	content := []byte("image content")
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	files, err := DownloadFiles(&models.Gallery{}, []*models.Image{downloadTestImage(1, path)}, "{index}")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDownload(context.Background(), &buf, &file.OsFS{}, files))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "1.jpg", zr.File[0].Name)
+
+	r, err := zr.File[0].Open()
+	require.NoError(t, err)
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
@@ -18,6 +18,10 @@ func ToBasicJSON(gallery *models.Gallery) (*jsonschema.Gallery, error) {
 		URLs:         gallery.URLs.List(),
 		Details:      gallery.Details,
 		Photographer: gallery.Photographer,
+		Country:      gallery.Country,
+		City:         gallery.City,
+		Latitude:     gallery.Latitude,
+		Longitude:    gallery.Longitude,
 		CreatedAt:    json.JSONTime{Time: gallery.CreatedAt},
 		UpdatedAt:    json.JSONTime{Time: gallery.UpdatedAt},
 	}
@@ -39,6 +43,7 @@ func ToBasicJSON(gallery *models.Gallery) (*jsonschema.Gallery, error) {
 	}
 
 	newGalleryJSON.Organized = gallery.Organized
+	newGalleryJSON.Archived = gallery.Archived
 
 	return &newGalleryJSON, nil
 }
@@ -72,6 +72,14 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	if galleryJSON.Photographer != "" {
 		newGallery.Photographer = galleryJSON.Photographer
 	}
+	if galleryJSON.Country != "" {
+		newGallery.Country = galleryJSON.Country
+	}
+	if galleryJSON.City != "" {
+		newGallery.City = galleryJSON.City
+	}
+	newGallery.Latitude = galleryJSON.Latitude
+	newGallery.Longitude = galleryJSON.Longitude
 	if len(galleryJSON.URLs) > 0 {
 		newGallery.URLs = models.NewRelatedStrings(galleryJSON.URLs)
 	} else if galleryJSON.URL != "" {
@@ -88,6 +96,7 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	}
 
 	newGallery.Organized = galleryJSON.Organized
+	newGallery.Archived = galleryJSON.Archived
 	newGallery.CreatedAt = galleryJSON.CreatedAt.GetTime()
 	newGallery.UpdatedAt = galleryJSON.UpdatedAt.GetTime()
 
@@ -0,0 +1,58 @@
+package gallery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPage = errors.New("page must not be negative")
+
+// ReadingProgress returns the fraction of a gallery with imageCount images
+// that has been read when the image at the zero-based index page was read.
+func ReadingProgress(page int, imageCount int) float64 {
+	if imageCount <= 0 {
+		return 0
+	}
+
+	ret := float64(page+1) / float64(imageCount)
+	if ret > 1 {
+		ret = 1
+	}
+
+	return ret
+}
+
+// SaveReadingProgress records that the image at the zero-based index page of
+// the gallery was read. If it is the last image of the gallery, the reading
+// session ends and a view is added to the gallery. Otherwise, the reading
+// session is started or updated.
+// Returns true if the reading session ended.
+func (s *Service) SaveReadingProgress(ctx context.Context, galleryID int, page int) (bool, error) {
+	if page < 0 {
+		return false, ErrInvalidPage
+	}
+
+	imageCount, err := s.ImageFinder.CountByGalleryID(ctx, galleryID)
+	if err != nil {
+		return false, fmt.Errorf("counting images: %w", err)
+	}
+
+	if imageCount > 0 && page >= imageCount-1 {
+		if err := s.Repository.DeleteReadingSession(ctx, galleryID); err != nil {
+			return false, fmt.Errorf("ending reading session: %w", err)
+		}
+
+		if _, err := s.Repository.AddViews(ctx, galleryID, nil); err != nil {
+			return false, fmt.Errorf("adding view: %w", err)
+		}
+
+		return true, nil
+	}
+
+	if _, err := s.Repository.SaveReadingSession(ctx, galleryID, page); err != nil {
+		return false, fmt.Errorf("saving reading session: %w", err)
+	}
+
+	return false, nil
+}
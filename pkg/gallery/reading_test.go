@@ -0,0 +1,64 @@
+package gallery
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadingProgress(t *testing.T) {
+	assert.Equal(t, 0.0, ReadingProgress(0, 0))
+	assert.Equal(t, 0.25, ReadingProgress(0, 4))
+	assert.Equal(t, 1.0, ReadingProgress(3, 4))
+	assert.Equal(t, 1.0, ReadingProgress(10, 4))
+}
+
+func TestService_SaveReadingProgress(t *testing.T) {
+	const (
+		galleryID  = 1
+		imageCount = 10
+	)
+
+	tests := []struct {
+		name      string
+		page      int
+		wantEnded bool
+		wantErr   bool
+	}{
+		{"first page", 0, false, false},
+		{"middle page", 5, false, false},
+		{"last page", imageCount - 1, true, false},
+		{"past last page", imageCount, true, false},
+		{"negative page", -1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			galleryRW := &mocks.GalleryReaderWriter{}
+			imageRW := &mocks.ImageReaderWriter{}
+
+			imageRW.On("CountByGalleryID", testCtx, galleryID).Return(imageCount, nil)
+			if tt.wantEnded {
+				galleryRW.On("DeleteReadingSession", testCtx, galleryID).Return(nil).Once()
+				galleryRW.On("AddViews", testCtx, galleryID, mock.Anything).Return(nil, nil).Once()
+			} else if !tt.wantErr {
+				galleryRW.On("SaveReadingSession", testCtx, galleryID, tt.page).Return(nil, nil).Once()
+			}
+
+			s := &Service{
+				Repository:  galleryRW,
+				ImageFinder: imageRW,
+			}
+
+			ended, err := s.SaveReadingProgress(testCtx, galleryID, tt.page)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.SaveReadingProgress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.wantEnded, ended)
+			galleryRW.AssertExpectations(t)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 type ImageFinder interface {
 	FindByFolderID(ctx context.Context, folder models.FolderID) ([]*models.Image, error)
 	FindByZipFileID(ctx context.Context, zipFileID models.FileID) ([]*models.Image, error)
+	CountByGalleryID(ctx context.Context, galleryID int) (int, error)
 	models.GalleryIDLoader
 }
 
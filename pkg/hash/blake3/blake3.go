@@ -0,0 +1,300 @@
+// Package blake3 provides utility functions for generating BLAKE3 hashes.
+//
+// The implementation follows the BLAKE3 reference implementation. It is not
+// optimised for SIMD, but is fast enough for fingerprinting files.
+package blake3
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+	"math/bits"
+	"os"
+)
+
+// Size is the size of a BLAKE3 checksum in bytes.
+const Size = 32
+
+const (
+	blockLen = 64
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+	0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(s *[16]uint32, a, b, c, d int, mx, my uint32) {
+	s[a] = s[a] + s[b] + mx
+	s[d] = bits.RotateLeft32(s[d]^s[a], -16)
+	s[c] = s[c] + s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -12)
+	s[a] = s[a] + s[b] + my
+	s[d] = bits.RotateLeft32(s[d]^s[a], -8)
+	s[c] = s[c] + s[d]
+	s[b] = bits.RotateLeft32(s[b]^s[c], -7)
+}
+
+func round(s *[16]uint32, m *[16]uint32) {
+	// columns
+	g(s, 0, 4, 8, 12, m[0], m[1])
+	g(s, 1, 5, 9, 13, m[2], m[3])
+	g(s, 2, 6, 10, 14, m[4], m[5])
+	g(s, 3, 7, 11, 15, m[6], m[7])
+	// diagonals
+	g(s, 0, 5, 10, 15, m[8], m[9])
+	g(s, 1, 6, 11, 12, m[10], m[11])
+	g(s, 2, 7, 8, 13, m[12], m[13])
+	g(s, 3, 4, 9, 14, m[14], m[15])
+}
+
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen uint32, flags uint32) [16]uint32 {
+	s := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+
+	m := *block
+	for r := 0; r < 7; r++ {
+		round(&s, &m)
+		if r < 6 {
+			var permuted [16]uint32
+			for i, p := range msgPermutation {
+				permuted[i] = m[p]
+			}
+			m = permuted
+		}
+	}
+
+	for i := 0; i < 8; i++ {
+		s[i] ^= s[i+8]
+		s[i+8] ^= cv[i]
+	}
+
+	return s
+}
+
+func first8(s [16]uint32) (ret [8]uint32) {
+	copy(ret[:], s[:8])
+	return
+}
+
+func blockWords(b *[blockLen]byte) (ret [16]uint32) {
+	for i := range ret {
+		ret[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	return
+}
+
+// output is the state needed to produce either a chaining value or the root
+// output of a node.
+type output struct {
+	cv       [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o output) chainingValue() [8]uint32 {
+	return first8(compress(&o.cv, &o.block, o.counter, o.blockLen, o.flags))
+}
+
+func (o output) rootBytes(out []byte) {
+	var counter uint64
+	for len(out) > 0 {
+		words := compress(&o.cv, &o.block, counter, o.blockLen, o.flags|flagRoot)
+
+		var buf [blockLen]byte
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(buf[i*4:], w)
+		}
+
+		n := copy(out, buf[:])
+		out = out[n:]
+		counter++
+	}
+}
+
+func parentOutput(left, right [8]uint32) output {
+	var block [16]uint32
+	copy(block[:8], left[:])
+	copy(block[8:], right[:])
+
+	return output{
+		cv:       iv,
+		block:    block,
+		blockLen: blockLen,
+		flags:    flagParent,
+	}
+}
+
+type chunkState struct {
+	cv               [8]uint32
+	counter          uint64
+	block            [blockLen]byte
+	blockLen         int
+	blocksCompressed int
+}
+
+func newChunkState(counter uint64) chunkState {
+	return chunkState{
+		cv:      iv,
+		counter: counter,
+	}
+}
+
+func (c *chunkState) len() int {
+	return blockLen*c.blocksCompressed + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunkState) update(p []byte) {
+	for len(p) > 0 {
+		// compress the buffered block only once more input arrives, since
+		// the last block of a chunk is compressed with different flags
+		if c.blockLen == blockLen {
+			words := blockWords(&c.block)
+			c.cv = first8(compress(&c.cv, &words, c.counter, blockLen, c.startFlag()))
+			c.blocksCompressed++
+			c.block = [blockLen]byte{}
+			c.blockLen = 0
+		}
+
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	return output{
+		cv:       c.cv,
+		block:    blockWords(&c.block),
+		counter:  c.counter,
+		blockLen: uint32(c.blockLen),
+		flags:    c.startFlag() | flagChunkEnd,
+	}
+}
+
+type digest struct {
+	chunk   chunkState
+	cvStack [][8]uint32
+}
+
+// New returns a new hash.Hash computing the 256-bit BLAKE3 checksum.
+func New() hash.Hash {
+	d := &digest{}
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	d.chunk = newChunkState(0)
+	d.cvStack = d.cvStack[:0]
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return blockLen
+}
+
+// addChunkChainingValue merges completed subtrees, as indicated by the
+// trailing zero bits of the total number of chunks, before pushing cv.
+func (d *digest) addChunkChainingValue(cv [8]uint32, totalChunks uint64) {
+	for totalChunks&1 == 0 {
+		left := d.cvStack[len(d.cvStack)-1]
+		d.cvStack = d.cvStack[:len(d.cvStack)-1]
+		cv = parentOutput(left, cv).chainingValue()
+		totalChunks >>= 1
+	}
+	d.cvStack = append(d.cvStack, cv)
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// finalise the current chunk only once more input arrives, since
+		// the last chunk may be the root
+		if d.chunk.len() == chunkLen {
+			cv := d.chunk.output().chainingValue()
+			totalChunks := d.chunk.counter + 1
+			d.addChunkChainingValue(cv, totalChunks)
+			d.chunk = newChunkState(totalChunks)
+		}
+
+		take := min(chunkLen-d.chunk.len(), len(p))
+		d.chunk.update(p[:take])
+		p = p[take:]
+	}
+
+	return n, nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	o := d.chunk.output()
+	for i := len(d.cvStack) - 1; i >= 0; i-- {
+		o = parentOutput(d.cvStack[i], o.chainingValue())
+	}
+
+	var out [Size]byte
+	o.rootBytes(out[:])
+	return append(b, out[:]...)
+}
+
+// Sum256 returns the BLAKE3 checksum of data.
+func Sum256(data []byte) [Size]byte {
+	d := New()
+	_, _ = d.Write(data)
+
+	var ret [Size]byte
+	d.Sum(ret[:0])
+	return ret
+}
+
+// FromBytes returns a BLAKE3 checksum string from data.
+func FromBytes(data []byte) string {
+	result := Sum256(data)
+	return hex.EncodeToString(result[:])
+}
+
+// FromFilePath returns a BLAKE3 checksum string for the file at filePath.
+// It returns an empty string and an error if an error occurs opening the file.
+func FromFilePath(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return FromReader(f)
+}
+
+// FromReader returns a BLAKE3 checksum string from data read from src.
+// It returns an empty string and an error if an error occurs reading from src.
+func FromReader(src io.Reader) (string, error) {
+	h := New()
+	if _, err := io.Copy(h, src); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
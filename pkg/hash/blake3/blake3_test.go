@@ -0,0 +1,64 @@
+package blake3
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// testInput returns the input used by the official BLAKE3 test vectors.
+func testInput(n int) []byte {
+	ret := make([]byte, n)
+	for i := range ret {
+		ret[i] = byte(i % 251)
+	}
+	return ret
+}
+
+func TestFromBytes(t *testing.T) {
+	// from the official BLAKE3 test vectors
+	tests := []struct {
+		len  int
+		want string
+	}{
+		{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+		{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+		{63, "e9bc37a594daad83be9470df7f7b3798297c3d834ce80ba85d6e207627b7db7b"},
+		{64, "4eed7141ea4a5cd4b788606bd23f46e212af9cacebacdc7d1f4c6dc7f2511b98"},
+		{1023, "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11"},
+		{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+		{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.len), func(t *testing.T) {
+			if got := FromBytes(testInput(tt.len)); got != tt.want {
+				t.Errorf("FromBytes() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromReader(t *testing.T) {
+	input := testInput(8192 + 17)
+
+	want := FromBytes(input)
+	got, err := FromReader(bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("FromReader() = %s, want %s", got, want)
+	}
+
+	// writes of uneven sizes must give the same result
+	h := New()
+	for p := input; len(p) > 0; {
+		n := min(len(p), 100)
+		_, _ = h.Write(p[:n])
+		p = p[n:]
+	}
+	if got := fmt.Sprintf("%x", h.Sum(nil)); got != want {
+		t.Errorf("Write() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	gohash "hash"
+	"io"
+
+	"github.com/stashapp/stash/pkg/hash/blake3"
+)
+
+// checksumAlgorithms are the supported checksum algorithms, keyed by the
+// fingerprint type they are stored as.
+var checksumAlgorithms = map[string]func() gohash.Hash{
+	"md5":    md5.New,
+	"sha256": sha256.New,
+	"blake3": blake3.New,
+}
+
+// IsChecksumAlgorithm returns true if name is a supported checksum algorithm.
+func IsChecksumAlgorithm(name string) bool {
+	_, found := checksumAlgorithms[name]
+	return found
+}
+
+// ChecksumsFromReader returns the hex-encoded checksums of the data read from
+// src for each of the named algorithms, keyed by algorithm. src is only read
+// once, regardless of the number of algorithms.
+func ChecksumsFromReader(src io.Reader, algorithms []string) (map[string]string, error) {
+	hashes := make(map[string]gohash.Hash, len(algorithms))
+	writers := make([]io.Writer, 0, len(algorithms))
+	for _, a := range algorithms {
+		if _, done := hashes[a]; done {
+			continue
+		}
+
+		newHash, found := checksumAlgorithms[a]
+		if !found {
+			return nil, fmt.Errorf("unsupported checksum algorithm %q", a)
+		}
+
+		h := newHash()
+		hashes[a] = h
+		writers = append(writers, h)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), src); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]string, len(hashes))
+	for a, h := range hashes {
+		ret[a] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	return ret, nil
+}
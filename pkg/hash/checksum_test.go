@@ -0,0 +1,24 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumsFromReader(t *testing.T) {
+	got, err := ChecksumsFromReader(strings.NewReader("abc"), []string{"md5", "sha256", "blake3", "md5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, map[string]string{
+		"md5":    "900150983cd24fb0d6963f7d28e17f72",
+		"sha256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"blake3": "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85",
+	}, got)
+
+	_, err = ChecksumsFromReader(strings.NewReader("abc"), []string{"crc32"})
+	assert.Error(t, err)
+}
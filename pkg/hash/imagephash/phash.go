@@ -0,0 +1,137 @@
+// Package imagephash generates perceptual hashes for image files.
+//
+// Animated images and clips are hashed using the same sprite method as
+// videophash, so that their hashes can be compared against scene phashes.
+// Static images are hashed directly.
+package imagephash
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	// register image formats for decoding
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/corona10/goimagehash"
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/hash/videophash"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// gifHeader is the prefix shared by GIF87a and GIF89a files.
+var gifHeader = []byte("GIF8")
+
+// GenerateClip returns the phash of an image clip. Clips are hashed in the
+// same way as scene files.
+func GenerateClip(encoder *ffmpeg.FFMpeg, f *models.VideoFile) (*uint64, error) {
+	return videophash.Generate(encoder, f)
+}
+
+// Generate returns the phash of the image read from r. Animated GIFs are
+// hashed from a sprite of their frames, in the same way as scene files.
+// Other images are hashed from the decoded image.
+func Generate(r io.Reader) (*uint64, error) {
+	br := bufio.NewReader(r)
+
+	header, _ := br.Peek(len(gifHeader))
+	if bytes.Equal(header, gifHeader) {
+		g, err := gif.DecodeAll(br)
+		if err != nil {
+			return nil, fmt.Errorf("decoding gif: %w", err)
+		}
+
+		if len(g.Image) > 1 {
+			return hashImage(gifSprite(g))
+		}
+
+		return hashImage(g.Image[0])
+	}
+
+	img, _, err := image.Decode(br)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	return hashImage(img)
+}
+
+func hashImage(img image.Image) (*uint64, error) {
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("computing phash: %w", err)
+	}
+
+	hashValue := hash.GetHash()
+	return &hashValue, nil
+}
+
+// spriteFrameIndexes returns the indexes of the frames used in a sprite for
+// an animation of frameCount frames. Like scene sprites, the first and last
+// 5% are skipped.
+func spriteFrameIndexes(frameCount int) []int {
+	offset := 0.05 * float64(frameCount)
+	stepSize := (0.9 * float64(frameCount)) / float64(videophash.SpriteFrames)
+
+	ret := make([]int, videophash.SpriteFrames)
+	for i := range ret {
+		idx := int(offset + float64(i)*stepSize)
+		if idx >= frameCount {
+			idx = frameCount - 1
+		}
+		ret[i] = idx
+	}
+
+	return ret
+}
+
+// gifFrames returns the fully composed frames of g. GIF frames may only
+// cover part of the canvas, so each is drawn over the previous ones.
+func gifFrames(g *gif.GIF) []image.Image {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+	}
+
+	canvas := image.NewNRGBA(bounds)
+	ret := make([]image.Image, len(g.Image))
+	for i, frame := range g.Image {
+		var previous *image.NRGBA
+		if i < len(g.Disposal) && g.Disposal[i] == gif.DisposalPrevious {
+			previous = imaging.Clone(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		ret[i] = imaging.Clone(canvas)
+
+		if i < len(g.Disposal) {
+			switch g.Disposal[i] {
+			case gif.DisposalBackground:
+				draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+			case gif.DisposalPrevious:
+				canvas = previous
+			}
+		}
+	}
+
+	return ret
+}
+
+func gifSprite(g *gif.GIF) image.Image {
+	frames := gifFrames(g)
+
+	var images []image.Image
+	for _, idx := range spriteFrameIndexes(len(frames)) {
+		images = append(images, imaging.Resize(frames[idx], videophash.ScreenshotSize, 0, imaging.Lanczos))
+	}
+
+	return videophash.CombineImages(images)
+}
@@ -0,0 +1,75 @@
+package imagephash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpriteFrameIndexes(t *testing.T) {
+	got := spriteFrameIndexes(100)
+	assert.Len(t, got, 25)
+	assert.Equal(t, 5, got[0])
+	assert.Equal(t, 91, got[24])
+
+	// fewer frames than the sprite repeats frames
+	got = spriteFrameIndexes(2)
+	assert.Len(t, got, 25)
+	for _, idx := range got {
+		assert.True(t, idx >= 0 && idx < 2)
+	}
+}
+
+func testImage(c color.Color) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, 32, 32), color.Palette{color.Black, color.White, c})
+	for x := 0; x < 32; x++ {
+		for y := 0; y < 32; y++ {
+			if x < y {
+				img.Set(x, y, c)
+			}
+		}
+	}
+	return img
+}
+
+func TestGenerate(t *testing.T) {
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, testImage(color.White)); err != nil {
+		t.Fatal(err)
+	}
+
+	pngHash, err := Generate(&pngBuf)
+	assert.Nil(t, err)
+	assert.NotNil(t, pngHash)
+
+	// a single frame gif of the same image has the same hash
+	var gifBuf bytes.Buffer
+	if err := gif.Encode(&gifBuf, testImage(color.White), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	gifHash, err := Generate(&gifBuf)
+	assert.Nil(t, err)
+	assert.Equal(t, pngHash, gifHash)
+
+	var animBuf bytes.Buffer
+	anim := &gif.GIF{
+		Image: []*image.Paletted{testImage(color.White), testImage(color.Black)},
+		Delay: []int{10, 10},
+	}
+	if err := gif.EncodeAll(&animBuf, anim); err != nil {
+		t.Fatal(err)
+	}
+
+	animHash, err := Generate(&animBuf)
+	assert.Nil(t, err)
+	assert.NotNil(t, animHash)
+
+	_, err = Generate(bytes.NewReader([]byte("not an image")))
+	assert.NotNil(t, err)
+}
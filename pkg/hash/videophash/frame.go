@@ -0,0 +1,89 @@
+package videophash
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// borderLuminance is the maximum luminance of a pixel of a black border.
+	borderLuminance = 24
+	// maxBorderFraction is the maximum fraction of a frame dimension that is
+	// removed from each side as a border.
+	maxBorderFraction = 0.3
+)
+
+// normaliseFrame applies the border and orientation options to a frame.
+func normaliseFrame(img image.Image, o Options) image.Image {
+	if o.CropBorders {
+		img = cropBorders(img)
+	}
+
+	if o.NormaliseOrientation {
+		if b := img.Bounds(); b.Dy() > b.Dx() {
+			img = imaging.Rotate90(img)
+		}
+	}
+
+	return img
+}
+
+func isDark(img image.Image, x, y int) bool {
+	return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y <= borderLuminance
+}
+
+func isDarkRow(img image.Image, y, minX, maxX int) bool {
+	for x := minX; x < maxX; x++ {
+		if !isDark(img, x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDarkColumn(img image.Image, x, minY, maxY int) bool {
+	for y := minY; y < maxY; y++ {
+		if !isDark(img, x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// cropBorders removes black letterbox and pillarbox borders from the image.
+// Frames that are entirely dark, such as fades, are returned unchanged.
+func cropBorders(img image.Image) image.Image {
+	b := img.Bounds()
+	maxRows := int(float64(b.Dy()) * maxBorderFraction)
+	maxCols := int(float64(b.Dx()) * maxBorderFraction)
+
+	top, bottom := b.Min.Y, b.Max.Y
+	for top < b.Min.Y+maxRows && isDarkRow(img, top, b.Min.X, b.Max.X) {
+		top++
+	}
+	for bottom > b.Max.Y-maxRows && isDarkRow(img, bottom-1, b.Min.X, b.Max.X) {
+		bottom--
+	}
+
+	left, right := b.Min.X, b.Max.X
+	for left < b.Min.X+maxCols && isDarkColumn(img, left, top, bottom) {
+		left++
+	}
+	for right > b.Max.X-maxCols && isDarkColumn(img, right-1, top, bottom) {
+		right--
+	}
+
+	// a border on every side of the limit is most likely a dark frame
+	if top-b.Min.Y == maxRows && b.Max.Y-bottom == maxRows {
+		return img
+	}
+
+	crop := image.Rect(left, top, right, bottom)
+	if crop == b {
+		return img
+	}
+
+	return imaging.Crop(img, crop)
+}
@@ -0,0 +1,123 @@
+package videophash
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Sampling is the strategy used to choose the times of the frames of a
+// phash sprite.
+type Sampling string
+
+const (
+	// SamplingUniform samples frames at equal steps from the start of the
+	// sampled range. This is the sampling of the default phash.
+	SamplingUniform Sampling = "UNIFORM"
+	// SamplingMidpoint samples the midpoint of equal segments of the
+	// sampled range, which is less sensitive to trimmed intros.
+	SamplingMidpoint Sampling = "MIDPOINT"
+)
+
+var AllSampling = []Sampling{
+	SamplingUniform,
+	SamplingMidpoint,
+}
+
+func (e Sampling) IsValid() bool {
+	return slices.Contains(AllSampling, e)
+}
+
+func (e Sampling) String() string {
+	return string(e)
+}
+
+func (e *Sampling) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Sampling(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PhashSampling", str)
+	}
+	return nil
+}
+
+func (e Sampling) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+const (
+	minFrameCount = 4
+	maxFrameCount = 100
+	minDCTSize    = 32
+	maxDCTSize    = 256
+)
+
+// Options are the options used to generate a phash.
+type Options struct {
+	// FrameCount is the number of frames in the sprite.
+	FrameCount int `json:"frame_count"`
+	// Sampling is the strategy used to choose the frame times.
+	Sampling Sampling `json:"sampling"`
+	// DCTSize is the size that the sprite is resized to before the discrete
+	// cosine transform. Must be a power of two.
+	DCTSize int `json:"dct_size"`
+	// CropBorders removes black letterbox and pillarbox borders from each
+	// frame.
+	CropBorders bool `json:"crop_borders"`
+	// NormaliseOrientation rotates portrait frames to landscape, so that
+	// videos that differ only by a 90 degree rotation produce the same hash.
+	NormaliseOrientation bool `json:"normalise_orientation"`
+}
+
+// DefaultOptions are the options of the standard phash, as shared with
+// stash-box.
+var DefaultOptions = Options{
+	FrameCount: SpriteFrames,
+	Sampling:   SamplingUniform,
+	DCTSize:    64,
+}
+
+// IsDefault returns true if the options produce the standard phash.
+func (o Options) IsDefault() bool {
+	return o == DefaultOptions
+}
+
+// Validate returns an error if the options are not valid.
+func (o Options) Validate() error {
+	if o.FrameCount < minFrameCount || o.FrameCount > maxFrameCount {
+		return fmt.Errorf("frame count must be between %d and %d", minFrameCount, maxFrameCount)
+	}
+
+	if !o.Sampling.IsValid() {
+		return fmt.Errorf("invalid sampling %q", o.Sampling)
+	}
+
+	if o.DCTSize < minDCTSize || o.DCTSize > maxDCTSize || o.DCTSize&(o.DCTSize-1) != 0 {
+		return errors.New("dct size must be a power of two between 32 and 256")
+	}
+
+	return nil
+}
+
+// frameTimes returns the times of the frames of the sprite, excluding the
+// first and last 5% of the video to avoid intros and outros.
+func (o Options) frameTimes(duration float64) []float64 {
+	offset := 0.05 * duration
+	stepSize := (0.9 * duration) / float64(o.FrameCount)
+
+	ret := make([]float64, o.FrameCount)
+	for i := range ret {
+		ret[i] = offset + float64(i)*stepSize
+		if o.Sampling == SamplingMidpoint {
+			ret[i] += stepSize / 2
+		}
+	}
+
+	return ret
+}
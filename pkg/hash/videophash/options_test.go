@@ -0,0 +1,107 @@
+package videophash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, DefaultOptions.Validate())
+	assert.True(t, DefaultOptions.IsDefault())
+
+	tests := []struct {
+		name    string
+		o       Options
+		wantErr bool
+	}{
+		{"custom", Options{FrameCount: 36, Sampling: SamplingMidpoint, DCTSize: 128, CropBorders: true}, false},
+		{"too few frames", Options{FrameCount: 2, Sampling: SamplingUniform, DCTSize: 64}, true},
+		{"invalid sampling", Options{FrameCount: 25, Sampling: "RANDOM", DCTSize: 64}, true},
+		{"dct size not power of two", Options{FrameCount: 25, Sampling: SamplingUniform, DCTSize: 100}, true},
+		{"dct size too small", Options{FrameCount: 25, Sampling: SamplingUniform, DCTSize: 16}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.o.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
+
+func TestOptions_frameTimes(t *testing.T) {
+	o := DefaultOptions
+	got := o.frameTimes(100)
+	assert.Len(t, got, 25)
+	assert.InDelta(t, 5, got[0], 0.001)
+	assert.InDelta(t, 91.4, got[24], 0.001)
+
+	o.Sampling = SamplingMidpoint
+	o.FrameCount = 9
+	got = o.frameTimes(90)
+	assert.Len(t, got, 9)
+	assert.InDelta(t, 9, got[0], 0.001)
+	assert.InDelta(t, 81, got[8], 0.001)
+}
+
+// letterboxed returns a white w x h image with black bars of the given
+// height at the top and bottom.
+func letterboxed(w, h, bar int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if y < bar || y >= h-bar {
+				c = color.NRGBA{A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCropBorders(t *testing.T) {
+	got := cropBorders(letterboxed(160, 120, 15))
+	assert.Equal(t, image.Rect(0, 0, 160, 90), got.Bounds())
+
+	// no borders
+	img := letterboxed(160, 90, 0)
+	assert.Equal(t, img, cropBorders(img))
+
+	// entirely dark frames are not cropped
+	dark := image.NewNRGBA(image.Rect(0, 0, 160, 90))
+	assert.Equal(t, image.Image(dark), cropBorders(dark))
+}
+
+func TestNormaliseFrame(t *testing.T) {
+	portrait := letterboxed(90, 160, 0)
+
+	got := normaliseFrame(portrait, Options{NormaliseOrientation: true})
+	assert.Equal(t, image.Rect(0, 0, 160, 90), got.Bounds())
+
+	got = normaliseFrame(portrait, Options{})
+	assert.Equal(t, image.Rect(0, 0, 90, 160), got.Bounds())
+}
+
+func TestCombineGrid(t *testing.T) {
+	var images []image.Image
+	for i := 0; i < 10; i++ {
+		images = append(images, letterboxed(16, 9, 0))
+	}
+	// a differently sized frame is resized to fit the grid
+	images = append(images, letterboxed(32, 18, 0))
+
+	got := combineGrid(images)
+	// 11 images fit in 4 columns and 3 rows
+	assert.Equal(t, image.Rect(0, 0, 64, 27), got.Bounds())
+}
+
+func TestHashImage(t *testing.T) {
+	img := letterboxed(160, 120, 15)
+
+	// hashes are deterministic for any transform size
+	assert.Equal(t, hashImage(img, 64), hashImage(img, 64))
+	assert.NotZero(t, hashImage(img, 128))
+}
@@ -9,6 +9,8 @@ import (
 	"math"
 
 	"github.com/corona10/goimagehash"
+	"github.com/corona10/goimagehash/etcs"
+	"github.com/corona10/goimagehash/transforms"
 	"github.com/disintegration/imaging"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
@@ -21,6 +23,11 @@ const (
 	screenshotSize = 160
 	columns        = 5
 	rows           = 5
+
+	// SpriteFrames is the number of frames in a phash sprite.
+	SpriteFrames = columns * rows
+	// ScreenshotSize is the width of each frame in a phash sprite.
+	ScreenshotSize = screenshotSize
 )
 
 func Generate(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (*uint64, error) {
@@ -37,6 +44,34 @@ func Generate(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (*uint64, err
 	return &hashValue, nil
 }
 
+// GenerateWithOptions generates a phash of the video file using the given
+// options. The hash is the same as that of Generate if the options are the
+// default options.
+func GenerateWithOptions(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile, o Options) (*uint64, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	if o.IsDefault() {
+		return Generate(encoder, videoFile)
+	}
+
+	logger.Infof("[generator] generating custom phash sprite for %s", videoFile.Path)
+
+	var images []image.Image
+	for _, t := range o.frameTimes(videoFile.Duration) {
+		img, err := generateSpriteScreenshot(encoder, videoFile.Path, t)
+		if err != nil {
+			return nil, fmt.Errorf("generating sprite screenshot: %w", err)
+		}
+
+		images = append(images, normaliseFrame(img, o))
+	}
+
+	hashValue := hashImage(combineGrid(images), o.DCTSize)
+	return &hashValue, nil
+}
+
 func generateSpriteScreenshot(encoder *ffmpeg.FFMpeg, input string, t float64) (image.Image, error) {
 	options := transcoder.ScreenshotOptions{
 		Width:      screenshotSize,
@@ -60,7 +95,9 @@ func generateSpriteScreenshot(encoder *ffmpeg.FFMpeg, input string, t float64) (
 	return img, nil
 }
 
-func combineImages(images []image.Image) image.Image {
+// CombineImages combines the images into a single sprite of columns x rows,
+// as used to compute the phash of a video.
+func CombineImages(images []image.Image) image.Image {
 	width := images[0].Bounds().Size().X
 	height := images[0].Bounds().Size().Y
 	canvasWidth := width * columns
@@ -76,6 +113,47 @@ func combineImages(images []image.Image) image.Image {
 	return montage
 }
 
+// combineGrid combines the images into the smallest square-ish grid that
+// fits all of them. Images are resized to the size of the first image.
+func combineGrid(images []image.Image) image.Image {
+	cols := int(math.Ceil(math.Sqrt(float64(len(images)))))
+	gridRows := (len(images) + cols - 1) / cols
+
+	width := images[0].Bounds().Dx()
+	height := images[0].Bounds().Dy()
+	montage := imaging.New(width*cols, height*gridRows, color.NRGBA{})
+	for index, img := range images {
+		if img.Bounds().Dx() != width || img.Bounds().Dy() != height {
+			img = imaging.Resize(img, width, height, imaging.Lanczos)
+		}
+
+		x := width * (index % cols)
+		y := height * (index / cols)
+		montage = imaging.Paste(montage, img, image.Pt(x, y))
+	}
+
+	return montage
+}
+
+// hashImage computes the 64 bit perceptual hash of the image, using a
+// discrete cosine transform of size x size pixels.
+func hashImage(img image.Image, size int) uint64 {
+	resized := imaging.Resize(img, size, size, imaging.Linear)
+	pixels := transforms.Rgb2Gray(resized)
+	dct := transforms.DCT2D(pixels, size, size)
+	flattens := transforms.FlattenPixels(dct, 8, 8)
+	median := etcs.MedianOfPixels(flattens)
+
+	var ret uint64
+	for idx, p := range flattens {
+		if p > median {
+			ret |= 1 << uint(64-idx-1)
+		}
+	}
+
+	return ret
+}
+
 func generateSprite(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (image.Image, error) {
 	logger.Infof("[generator] generating phash sprite for %s", videoFile.Path)
 
@@ -100,5 +178,5 @@ func generateSprite(encoder *ffmpeg.FFMpeg, videoFile *models.VideoFile) (image.
 		return nil, fmt.Errorf("images slice is empty, failed to generate phash sprite for %s", videoFile.Path)
 	}
 
-	return combineImages(images), nil
+	return CombineImages(images), nil
 }
@@ -18,6 +18,10 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 		URLs:         image.URLs.List(),
 		Details:      image.Details,
 		Photographer: image.Photographer,
+		Country:      image.Country,
+		City:         image.City,
+		Latitude:     image.Latitude,
+		Longitude:    image.Longitude,
 		CreatedAt:    json.JSONTime{Time: image.CreatedAt},
 		UpdatedAt:    json.JSONTime{Time: image.UpdatedAt},
 	}
@@ -31,6 +35,7 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 	}
 
 	newImageJSON.Organized = image.Organized
+	newImageJSON.Archived = image.Archived
 	newImageJSON.OCounter = image.OCounter
 
 	for _, f := range image.Files.List() {
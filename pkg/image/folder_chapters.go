@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ChapterGalleryFilename marks a folder whose images, including those in
+// nested folders, form a single gallery. Each nested folder becomes a
+// chapter of the gallery.
+const ChapterGalleryFilename = ".chaptergallery"
+
+type ScanFolderFinder interface {
+	FindByPath(ctx context.Context, path string) (*models.Folder, error)
+	FindByParentFolderID(ctx context.Context, parentFolderID models.FolderID) ([]*models.Folder, error)
+}
+
+type ScanChapterCreatorUpdater interface {
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.GalleryChapter, error)
+	Create(ctx context.Context, newGalleryChapter *models.GalleryChapter) error
+	UpdatePartial(ctx context.Context, id int, updatedGalleryChapter models.GalleryChapterPartial) (*models.GalleryChapter, error)
+}
+
+func isChapterGalleryFolder(folderPath string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(folderPath, ChapterGalleryFilename)); err == nil {
+		return true, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("Could not test Path %s: %w", folderPath, err)
+	}
+
+	return false, nil
+}
+
+// FindChapterGalleryRoot returns the nearest folder, starting at folderPath
+// and moving up, that contains a ChapterGalleryFilename file. It returns an
+// empty string if there is none.
+func FindChapterGalleryRoot(folderPath string) (string, error) {
+	for dir := folderPath; ; {
+		found, err := isChapterGalleryFolder(dir)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// chapterGalleryFolderIDs returns the id of the root folder and those of its
+// nested folders. Nested folders that are chapter gallery roots themselves are
+// excluded, along with their descendants.
+func (h *ScanHandler) chapterGalleryFolderIDs(ctx context.Context, root *models.Folder) ([]models.FolderID, error) {
+	ret := []models.FolderID{root.ID}
+
+	children, err := h.FolderFinder.FindByParentFolderID(ctx, root.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding sub-folders of %s: %w", root.Path, err)
+	}
+
+	for _, c := range children {
+		isRoot, err := isChapterGalleryFolder(c.Path)
+		if err != nil {
+			return nil, err
+		}
+		if isRoot {
+			continue
+		}
+
+		ids, err := h.chapterGalleryFolderIDs(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ids...)
+	}
+
+	return ret, nil
+}
+
+func (h *ScanHandler) getOrCreateChapterGallery(ctx context.Context, rootPath string) (*models.Gallery, error) {
+	root, err := h.FolderFinder.FindByPath(ctx, rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("finding chapter gallery folder: %w", err)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("chapter gallery folder %s not found", rootPath)
+	}
+
+	folderIDs, err := h.chapterGalleryFolderIDs(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.getOrCreateFolderBasedGallery(ctx, root.ID, rootPath, folderIDs)
+}
+
+// folderChapter is a chapter of a chapter gallery.
+type folderChapter struct {
+	title      string
+	imageIndex int
+}
+
+// folderChapters returns a chapter for each folder under rootPath that
+// contains images. images must be in gallery order. The chapter starts at the
+// first image of the folder, using the 1-based image index.
+func folderChapters(rootPath string, images []*models.Image) []folderChapter {
+	var ret []folderChapter
+	seen := make(map[string]bool)
+
+	for i, img := range images {
+		rel, err := filepath.Rel(rootPath, filepath.Dir(img.Path))
+		// skip images in the root folder and outside of it
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		title := filepath.ToSlash(rel)
+		if seen[title] {
+			continue
+		}
+		seen[title] = true
+
+		ret = append(ret, folderChapter{
+			title:      title,
+			imageIndex: i + 1,
+		})
+	}
+
+	return ret
+}
+
+// updateFolderChapters creates a chapter for each nested folder of the chapter
+// gallery, and moves existing chapters with the same title to the first image
+// of the folder. Other chapters are left untouched.
+func (h *ScanHandler) updateFolderChapters(ctx context.Context, g *models.Gallery, rootPath string) error {
+	images, err := h.CreatorUpdater.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("finding gallery images: %w", err)
+	}
+
+	existing, err := h.ChapterCreatorUpdater.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("finding gallery chapters: %w", err)
+	}
+
+	byTitle := make(map[string]*models.GalleryChapter)
+	for _, c := range existing {
+		byTitle[c.Title] = c
+	}
+
+	for _, fc := range folderChapters(rootPath, images) {
+		if c := byTitle[fc.title]; c != nil {
+			if c.ImageIndex == fc.imageIndex {
+				continue
+			}
+
+			partial := models.NewGalleryChapterPartial()
+			partial.ImageIndex = models.NewOptionalInt(fc.imageIndex)
+			if _, err := h.ChapterCreatorUpdater.UpdatePartial(ctx, c.ID, partial); err != nil {
+				return fmt.Errorf("updating gallery chapter: %w", err)
+			}
+			continue
+		}
+
+		logger.Infof("Creating chapter %s in gallery %s", fc.title, g.Path)
+
+		newChapter := models.NewGalleryChapter()
+		newChapter.GalleryID = g.ID
+		newChapter.Title = fc.title
+		newChapter.ImageIndex = fc.imageIndex
+		if err := h.ChapterCreatorUpdater.Create(ctx, &newChapter); err != nil {
+			return fmt.Errorf("creating gallery chapter: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package image
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderChapters(t *testing.T) {
+	root := filepath.Join("library", "gallery")
+
+	images := []*models.Image{
+		{Path: filepath.Join(root, "cover.jpg")},
+		{Path: filepath.Join(root, "01 intro", "1.jpg")},
+		{Path: filepath.Join(root, "01 intro", "2.jpg")},
+		{Path: filepath.Join(root, "02 main", "1.jpg")},
+		{Path: filepath.Join(root, "02 main", "extra", "1.jpg")},
+		{Path: filepath.Join("library", "other", "1.jpg")},
+	}
+
+	want := []folderChapter{
+		{"01 intro", 2},
+		{"02 main", 4},
+		{"02 main/extra", 5},
+	}
+
+	assert.Equal(t, want, folderChapters(root, images))
+}
@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -28,11 +29,13 @@ type Importer struct {
 	GalleryFinder       GalleryFinder
 	PerformerWriter     models.PerformerFinderCreator
 	TagWriter           models.TagFinderCreator
+	RegionWriter        models.ImageRegionFinderCreator // regions are not imported if nil
 	Input               jsonschema.Image
 	MissingRefBehaviour models.ImportMissingRefEnum
 
-	ID    int
-	image models.Image
+	ID      int
+	image   models.Image
+	regions []models.ImageRegion
 }
 
 func (i *Importer) PreImport(ctx context.Context) error {
@@ -58,6 +61,10 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		return err
 	}
 
+	if err := i.populateRegions(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -69,6 +76,7 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 
 		Title:     imageJSON.Title,
 		Organized: imageJSON.Organized,
+		Archived:  imageJSON.Archived,
 		OCounter:  imageJSON.OCounter,
 		CreatedAt: imageJSON.CreatedAt.GetTime(),
 		UpdatedAt: imageJSON.UpdatedAt.GetTime(),
@@ -86,6 +94,14 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 	if imageJSON.Photographer != "" {
 		newImage.Photographer = imageJSON.Photographer
 	}
+	if imageJSON.Country != "" {
+		newImage.Country = imageJSON.Country
+	}
+	if imageJSON.City != "" {
+		newImage.City = imageJSON.City
+	}
+	newImage.Latitude = imageJSON.Latitude
+	newImage.Longitude = imageJSON.Longitude
 	if imageJSON.Rating != 0 {
 		newImage.Rating = &imageJSON.Rating
 	}
@@ -225,47 +241,55 @@ func (i *Importer) populateGalleries(ctx context.Context) error {
 
 func (i *Importer) populatePerformers(ctx context.Context) error {
 	if len(i.Input.Performers) > 0 {
-		names := i.Input.Performers
-		performers, err := i.PerformerWriter.FindByNames(ctx, names, false)
+		performers, err := i.importPerformers(ctx, i.Input.Performers)
 		if err != nil {
 			return err
 		}
 
-		var pluckedNames []string
-		for _, performer := range performers {
-			if performer.Name == "" {
-				continue
-			}
-			pluckedNames = append(pluckedNames, performer.Name)
+		for _, p := range performers {
+			i.image.PerformerIDs.Add(p.ID)
 		}
+	}
 
-		missingPerformers := sliceutil.Filter(names, func(name string) bool {
-			return !slices.Contains(pluckedNames, name)
-		})
+	return nil
+}
 
-		if len(missingPerformers) > 0 {
-			if i.MissingRefBehaviour == models.ImportMissingRefEnumFail {
-				return fmt.Errorf("image performers [%s] not found", strings.Join(missingPerformers, ", "))
-			}
+func (i *Importer) importPerformers(ctx context.Context, names []string) ([]*models.Performer, error) {
+	performers, err := i.PerformerWriter.FindByNames(ctx, names, false)
+	if err != nil {
+		return nil, err
+	}
 
-			if i.MissingRefBehaviour == models.ImportMissingRefEnumCreate {
-				createdPerformers, err := i.createPerformers(ctx, missingPerformers)
-				if err != nil {
-					return fmt.Errorf("error creating image performers: %v", err)
-				}
+	var pluckedNames []string
+	for _, performer := range performers {
+		if performer.Name == "" {
+			continue
+		}
+		pluckedNames = append(pluckedNames, performer.Name)
+	}
 
-				performers = append(performers, createdPerformers...)
-			}
+	missingPerformers := sliceutil.Filter(names, func(name string) bool {
+		return !slices.Contains(pluckedNames, name)
+	})
 
-			// ignore if MissingRefBehaviour set to Ignore
+	if len(missingPerformers) > 0 {
+		if i.MissingRefBehaviour == models.ImportMissingRefEnumFail {
+			return nil, fmt.Errorf("image performers [%s] not found", strings.Join(missingPerformers, ", "))
 		}
 
-		for _, p := range performers {
-			i.image.PerformerIDs.Add(p.ID)
+		if i.MissingRefBehaviour == models.ImportMissingRefEnumCreate {
+			createdPerformers, err := i.createPerformers(ctx, missingPerformers)
+			if err != nil {
+				return nil, fmt.Errorf("error creating image performers: %v", err)
+			}
+
+			performers = append(performers, createdPerformers...)
 		}
+
+		// ignore if MissingRefBehaviour set to Ignore
 	}
 
-	return nil
+	return performers, nil
 }
 
 func (i *Importer) createPerformers(ctx context.Context, names []string) ([]*models.Performer, error) {
@@ -303,10 +327,112 @@ func (i *Importer) populateTags(ctx context.Context) error {
 	return nil
 }
 
+// populateRegions resolves the tags and performers of the regions. Regions
+// left without a tag or performer are dropped.
+func (i *Importer) populateRegions(ctx context.Context) error {
+	if i.RegionWriter == nil || len(i.Input.Regions) == 0 {
+		return nil
+	}
+
+	var tagNames, performerNames []string
+	for _, r := range i.Input.Regions {
+		if r.Tag != "" {
+			tagNames = sliceutil.AppendUnique(tagNames, r.Tag)
+		}
+		if r.Performer != "" {
+			performerNames = sliceutil.AppendUnique(performerNames, r.Performer)
+		}
+	}
+
+	tagIDs := make(map[string]int)
+	if len(tagNames) > 0 {
+		tags, err := importTags(ctx, i.TagWriter, tagNames, i.MissingRefBehaviour)
+		if err != nil {
+			return err
+		}
+		for _, t := range tags {
+			tagIDs[t.Name] = t.ID
+		}
+	}
+
+	performerIDs := make(map[string]int)
+	if len(performerNames) > 0 {
+		performers, err := i.importPerformers(ctx, performerNames)
+		if err != nil {
+			return err
+		}
+		for _, p := range performers {
+			performerIDs[p.Name] = p.ID
+		}
+	}
+
+	for _, r := range i.Input.Regions {
+		newRegion := models.NewImageRegion()
+		newRegion.X = r.X
+		newRegion.Y = r.Y
+		newRegion.Width = r.Width
+		newRegion.Height = r.Height
+
+		if id, found := tagIDs[r.Tag]; found {
+			newRegion.TagID = &id
+		}
+		if id, found := performerIDs[r.Performer]; found {
+			newRegion.PerformerID = &id
+		}
+
+		if err := ValidateRegion(newRegion); err != nil {
+			if errors.Is(err, ErrRegionTargetMissing) {
+				continue
+			}
+			return fmt.Errorf("invalid image region: %w", err)
+		}
+
+		i.regions = append(i.regions, newRegion)
+	}
+
+	return nil
+}
+
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if i.RegionWriter == nil || len(i.regions) == 0 {
+		return nil
+	}
+
+	existing, err := i.RegionWriter.FindByImageID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting existing image regions: %v", err)
+	}
+
+	for _, r := range i.regions {
+		r.ImageID = id
+
+		// don't duplicate regions when importing over an existing image
+		if slices.ContainsFunc(existing, func(e *models.ImageRegion) bool {
+			return sameRegion(*e, r)
+		}) {
+			continue
+		}
+
+		if err := i.RegionWriter.Create(ctx, &r); err != nil {
+			return fmt.Errorf("error creating image region: %v", err)
+		}
+	}
+
 	return nil
 }
 
+func sameRegion(a, b models.ImageRegion) bool {
+	return a.X == b.X && a.Y == b.Y && a.Width == b.Width && a.Height == b.Height &&
+		intPtrEqual(a.TagID, b.TagID) && intPtrEqual(a.PerformerID, b.PerformerID)
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (i *Importer) Name() string {
 	if i.Input.Title != "" {
 		return i.Input.Title
@@ -292,3 +292,67 @@ func TestImporterPreImportWithMissingTagCreateErr(t *testing.T) {
 
 	db.AssertExpectations(t)
 }
+
+func TestImporterRegions(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const imageID = 1
+	tagID := existingTagID
+	performerID := existingPerformerID
+
+	i := Importer{
+		TagWriter:       db.Tag,
+		PerformerWriter: db.Performer,
+		RegionWriter:    db.ImageRegion,
+		Input: jsonschema.Image{
+			Regions: []jsonschema.ImageRegion{
+				{X: 0.1, Y: 0.1, Width: 0.2, Height: 0.2, Tag: existingTagName},
+				{X: 0.5, Y: 0.5, Width: 0.2, Height: 0.2, Performer: existingPerformerName},
+				{X: 0, Y: 0, Width: 1, Height: 1, Tag: missingTagName},
+			},
+		},
+		MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+	}
+
+	db.Tag.On("FindByNames", testCtx, []string{existingTagName, missingTagName}, false).Return([]*models.Tag{
+		{
+			ID:   existingTagID,
+			Name: existingTagName,
+		},
+	}, nil).Once()
+	db.Performer.On("FindByNames", testCtx, []string{existingPerformerName}, false).Return([]*models.Performer{
+		{
+			ID:   existingPerformerID,
+			Name: existingPerformerName,
+		},
+	}, nil).Once()
+
+	err := i.populateRegions(testCtx)
+	assert.Nil(t, err)
+
+	// the region with the missing tag is dropped
+	if assert.Len(t, i.regions, 2) {
+		assert.Equal(t, &tagID, i.regions[0].TagID)
+		assert.Equal(t, &performerID, i.regions[1].PerformerID)
+	}
+
+	// the tag region already exists
+	db.ImageRegion.On("FindByImageID", testCtx, imageID).Return([]*models.ImageRegion{
+		{
+			ImageID: imageID,
+			X:       0.1,
+			Y:       0.1,
+			Width:   0.2,
+			Height:  0.2,
+			TagID:   &tagID,
+		},
+	}, nil).Once()
+	db.ImageRegion.On("Create", testCtx, mock.MatchedBy(func(r *models.ImageRegion) bool {
+		return r.ImageID == imageID && r.PerformerID != nil && *r.PerformerID == existingPerformerID
+	})).Return(nil).Once()
+
+	err = i.PostImport(testCtx, imageID)
+	assert.Nil(t, err)
+
+	db.AssertExpectations(t)
+}
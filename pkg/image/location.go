@@ -0,0 +1,61 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rwcarlsen/goexif/exif"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// readEXIFLocation returns the GPS latitude and longitude from the EXIF data
+// of the image file. Returns nil values if the file has no GPS data.
+func readEXIFLocation(f models.File) (latitude *float64, longitude *float64, err error) {
+	reader, err := f.Open(&file.OsFS{})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer reader.Close()
+
+	x, err := exif.Decode(reader)
+	if err != nil {
+		if errors.Is(err, io.EOF) || exif.IsCriticalError(err) {
+			// no exif data
+			return nil, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("decoding exif data: %w", err)
+	}
+
+	lat, long, err := x.LatLong()
+	if err != nil {
+		// assume not present
+		return nil, nil, nil
+	}
+
+	if err := models.ValidateCoordinates(&lat, &long); err != nil {
+		return nil, nil, err
+	}
+
+	return &lat, &long, nil
+}
+
+// setEXIFLocation sets the location of a new image from the EXIF data of the
+// file, if present.
+func setEXIFLocation(i *models.Image, f models.File) {
+	if _, isImage := f.(*models.ImageFile); !isImage {
+		return
+	}
+
+	lat, long, err := readEXIFLocation(f)
+	if err != nil {
+		logger.Warnf("Error reading location from %s: %v", f.Base().Path, err)
+		return
+	}
+
+	i.Latitude = lat
+	i.Longitude = long
+}
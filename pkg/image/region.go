@@ -0,0 +1,107 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+var (
+	ErrRegionTargetMissing = errors.New("region must have a tag or performer")
+	ErrRegionOutOfBounds   = errors.New("region must be within the image, with coordinates between 0 and 1")
+	ErrRegionEmpty         = errors.New("region width and height must be greater than zero")
+)
+
+// ValidateRegion returns an error if the region is not within the image, or
+// does not have a tag or performer.
+func ValidateRegion(r models.ImageRegion) error {
+	if r.TagID == nil && r.PerformerID == nil {
+		return ErrRegionTargetMissing
+	}
+
+	if r.Width <= 0 || r.Height <= 0 {
+		return ErrRegionEmpty
+	}
+
+	if r.X < 0 || r.Y < 0 || r.X+r.Width > 1 || r.Y+r.Height > 1 {
+		return ErrRegionOutOfBounds
+	}
+
+	return nil
+}
+
+// ApplyRegionPartial returns a copy of the region with the set values of the
+// partial applied.
+func ApplyRegionPartial(r models.ImageRegion, partial models.ImageRegionPartial) models.ImageRegion {
+	if partial.X.Set {
+		r.X = partial.X.Value
+	}
+	if partial.Y.Set {
+		r.Y = partial.Y.Value
+	}
+	if partial.Width.Set {
+		r.Width = partial.Width.Value
+	}
+	if partial.Height.Set {
+		r.Height = partial.Height.Value
+	}
+	if partial.TagID.Set {
+		r.TagID = partial.TagID.Ptr()
+	}
+	if partial.PerformerID.Set {
+		r.PerformerID = partial.PerformerID.Ptr()
+	}
+
+	return r
+}
+
+// GetRegionsJSON returns the JSON representation of the regions of the
+// image. Tags and performers are referenced by name.
+func GetRegionsJSON(ctx context.Context, reader models.ImageRegionFinder, tagReader models.TagGetter, performerReader models.PerformerGetter, imageID int) ([]jsonschema.ImageRegion, error) {
+	regions, err := reader.FindByImageID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image regions: %v", err)
+	}
+
+	var results []jsonschema.ImageRegion
+	for _, r := range regions {
+		newRegionJSON := jsonschema.ImageRegion{
+			X:      r.X,
+			Y:      r.Y,
+			Width:  r.Width,
+			Height: r.Height,
+		}
+
+		if r.TagID != nil {
+			t, err := tagReader.Find(ctx, *r.TagID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting image region tag: %v", err)
+			}
+			if t != nil {
+				newRegionJSON.Tag = t.Name
+			}
+		}
+
+		if r.PerformerID != nil {
+			p, err := performerReader.Find(ctx, *r.PerformerID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting image region performer: %v", err)
+			}
+			if p != nil {
+				newRegionJSON.Performer = p.Name
+			}
+		}
+
+		// regions left without a tag or performer are not exported
+		if newRegionJSON.Tag == "" && newRegionJSON.Performer == "" {
+			continue
+		}
+
+		results = append(results, newRegionJSON)
+	}
+
+	return results, nil
+}
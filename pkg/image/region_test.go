@@ -0,0 +1,33 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestValidateRegion(t *testing.T) {
+	tagID := 1
+
+	tests := []struct {
+		name   string
+		region models.ImageRegion
+		want   error
+	}{
+		{"valid", models.ImageRegion{X: 0.1, Y: 0.2, Width: 0.3, Height: 0.4, TagID: &tagID}, nil},
+		{"whole image", models.ImageRegion{Width: 1, Height: 1, PerformerID: &tagID}, nil},
+		{"no target", models.ImageRegion{Width: 1, Height: 1}, ErrRegionTargetMissing},
+		{"zero width", models.ImageRegion{Height: 1, TagID: &tagID}, ErrRegionEmpty},
+		{"negative position", models.ImageRegion{X: -0.1, Width: 0.5, Height: 0.5, TagID: &tagID}, ErrRegionOutOfBounds},
+		{"exceeds width", models.ImageRegion{X: 0.6, Width: 0.5, Height: 0.5, TagID: &tagID}, ErrRegionOutOfBounds},
+		{"exceeds height", models.ImageRegion{Y: 0.6, Width: 0.5, Height: 0.5, TagID: &tagID}, ErrRegionOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateRegion(tt.region))
+		})
+	}
+}
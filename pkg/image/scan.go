@@ -25,6 +25,7 @@ type ScanCreatorUpdater interface {
 	FindByFolderID(ctx context.Context, folderID models.FolderID) ([]*models.Image, error)
 	FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]*models.Image, error)
 	GetFiles(ctx context.Context, relatedID int) ([]models.File, error)
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.Image, error)
 	GetGalleryIDs(ctx context.Context, relatedID int) ([]int, error)
 
 	Create(ctx context.Context, newImage *models.Image, fileIDs []models.FileID) error
@@ -39,8 +40,15 @@ type GalleryFinderCreator interface {
 	UpdatePartial(ctx context.Context, id int, updatedGallery models.GalleryPartial) (*models.Gallery, error)
 }
 
+type TagFinderCreator interface {
+	models.TagQueryer
+	models.TagCreator
+}
+
 type ScanConfig interface {
 	GetCreateGalleriesFromFolders() bool
+	GetImportImageSidecars() bool
+	GetImageSidecarTagMappings() []string
 }
 
 type ScanGenerator interface {
@@ -51,6 +59,11 @@ type ScanHandler struct {
 	CreatorUpdater ScanCreatorUpdater
 	GalleryFinder  GalleryFinderCreator
 
+	FolderFinder          ScanFolderFinder
+	ChapterCreatorUpdater ScanChapterCreatorUpdater
+
+	TagFinderCreator TagFinderCreator
+
 	ScanGenerator ScanGenerator
 
 	ScanConfig ScanConfig
@@ -70,6 +83,15 @@ func (h *ScanHandler) validate() error {
 	if h.GalleryFinder == nil {
 		return errors.New("GalleryFinder is required")
 	}
+	if h.FolderFinder == nil {
+		return errors.New("FolderFinder is required")
+	}
+	if h.ChapterCreatorUpdater == nil {
+		return errors.New("ChapterCreatorUpdater is required")
+	}
+	if h.TagFinderCreator == nil {
+		return errors.New("TagFinderCreator is required")
+	}
 	if h.ScanConfig == nil {
 		return errors.New("ScanConfig is required")
 	}
@@ -101,7 +123,9 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		}
 	}
 
-	if len(existing) > 0 {
+	isNew := len(existing) == 0
+
+	if !isNew {
 		updateExisting := oldFile != nil
 
 		if err := h.associateExisting(ctx, existing, imageFile, updateExisting); err != nil {
@@ -111,10 +135,11 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		// create a new image
 		newImage := models.NewImage()
 		newImage.GalleryIDs = models.NewRelatedIDs([]int{})
+		setEXIFLocation(&newImage, f)
 
 		logger.Infof("%s doesn't exist. Creating new image...", f.Base().Path)
 
-		g, err := h.getGalleryToAssociate(ctx, &newImage, f)
+		g, chapterRoot, err := h.getGalleryToAssociate(ctx, &newImage, f)
 		if err != nil {
 			return err
 		}
@@ -136,6 +161,12 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 			if _, err := h.GalleryFinder.UpdatePartial(ctx, g.ID, galleryPartial); err != nil {
 				return fmt.Errorf("updating gallery updated at timestamp: %w", err)
 			}
+
+			if chapterRoot != "" {
+				if err := h.updateFolderChapters(ctx, g, chapterRoot); err != nil {
+					return err
+				}
+			}
 		}
 
 		h.PluginCache.RegisterPostHooks(ctx, newImage.ID, hook.ImageCreatePost, nil, nil)
@@ -143,6 +174,15 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		existing = []*models.Image{&newImage}
 	}
 
+	// only import sidecar files for new or changed image files
+	if isNew || oldFile != nil {
+		for _, i := range existing {
+			if err := h.importSidecar(ctx, i, f); err != nil {
+				return err
+			}
+		}
+	}
+
 	// remove the old thumbnail if the checksum changed - we'll regenerate it
 	if oldFile != nil {
 		oldHash := oldFile.Base().Fingerprints.GetString(models.FingerprintTypeMD5)
@@ -182,7 +222,7 @@ func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.
 		}
 
 		// associate with gallery if applicable
-		g, err := h.getGalleryToAssociate(ctx, i, f)
+		g, chapterRoot, err := h.getGalleryToAssociate(ctx, i, f)
 		if err != nil {
 			return err
 		}
@@ -225,6 +265,12 @@ func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.
 				if _, err := h.GalleryFinder.UpdatePartial(ctx, g.ID, galleryPartial); err != nil {
 					return fmt.Errorf("updating gallery updated at timestamp: %w", err)
 				}
+
+				if chapterRoot != "" {
+					if err := h.updateFolderChapters(ctx, g, chapterRoot); err != nil {
+						return err
+					}
+				}
 			}
 		}
 
@@ -236,8 +282,10 @@ func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.
 	return nil
 }
 
-func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, f models.File) (*models.Gallery, error) {
-	folderID := f.Base().ParentFolderID
+// getOrCreateFolderBasedGallery returns the gallery of the folder, creating it
+// if it does not exist. A new gallery is given the existing images of the
+// folders with the ids in folderIDs.
+func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, folderID models.FolderID, folderPath string, folderIDs []models.FolderID) (*models.Gallery, error) {
 	g, err := h.GalleryFinder.FindByFolderID(ctx, folderID)
 	if err != nil {
 		return nil, fmt.Errorf("finding folder based gallery: %w", err)
@@ -252,7 +300,7 @@ func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, f model
 	newGallery := models.NewGallery()
 	newGallery.FolderID = &folderID
 
-	logger.Infof("Creating folder-based gallery for %s", filepath.Dir(f.Base().Path))
+	logger.Infof("Creating folder-based gallery for %s", folderPath)
 
 	if err := h.GalleryFinder.Create(ctx, &newGallery, nil); err != nil {
 		return nil, fmt.Errorf("creating folder based gallery: %w", err)
@@ -262,17 +310,21 @@ func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, f model
 
 	// it's possible that there are other images in the folder that
 	// need to be added to the new gallery. Find and add them now.
-	if err := h.associateFolderImages(ctx, &newGallery); err != nil {
+	if err := h.associateFolderImages(ctx, &newGallery, folderIDs); err != nil {
 		return nil, fmt.Errorf("associating existing folder images: %w", err)
 	}
 
 	return &newGallery, nil
 }
 
-func (h *ScanHandler) associateFolderImages(ctx context.Context, g *models.Gallery) error {
-	i, err := h.CreatorUpdater.FindByFolderID(ctx, *g.FolderID)
-	if err != nil {
-		return fmt.Errorf("finding images in folder: %w", err)
+func (h *ScanHandler) associateFolderImages(ctx context.Context, g *models.Gallery, folderIDs []models.FolderID) error {
+	var i []*models.Image
+	for _, folderID := range folderIDs {
+		ii, err := h.CreatorUpdater.FindByFolderID(ctx, folderID)
+		if err != nil {
+			return fmt.Errorf("finding images in folder: %w", err)
+		}
+		i = append(i, ii...)
 	}
 
 	for _, ii := range i {
@@ -317,48 +369,63 @@ func (h *ScanHandler) getOrCreateZipBasedGallery(ctx context.Context, zipFile mo
 	return &newGallery, nil
 }
 
-func (h *ScanHandler) getOrCreateGallery(ctx context.Context, f models.File) (*models.Gallery, error) {
+// getOrCreateGallery returns the gallery that the file belongs in, if any. If
+// the gallery is a chapter gallery, the path of its root folder is returned as
+// well.
+func (h *ScanHandler) getOrCreateGallery(ctx context.Context, f models.File) (*models.Gallery, string, error) {
 	// don't create folder-based galleries for files in zip file
 	if f.Base().ZipFile != nil {
-		return h.getOrCreateZipBasedGallery(ctx, f.Base().ZipFile)
+		g, err := h.getOrCreateZipBasedGallery(ctx, f.Base().ZipFile)
+		return g, "", err
 	}
 
 	// Look for specific filename in Folder to find out if the Folder is marked to be handled differently as the setting
 	folderPath := filepath.Dir(f.Base().Path)
 
+	chapterRoot, err := FindChapterGalleryRoot(folderPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if chapterRoot != "" {
+		g, err := h.getOrCreateChapterGallery(ctx, chapterRoot)
+		return g, chapterRoot, err
+	}
+
 	forceGallery := false
 	if _, err := os.Stat(filepath.Join(folderPath, ".forcegallery")); err == nil {
 		forceGallery = true
 	} else if !errors.Is(err, os.ErrNotExist) {
-		return nil, fmt.Errorf("Could not test Path %s: %w", folderPath, err)
+		return nil, "", fmt.Errorf("Could not test Path %s: %w", folderPath, err)
 	}
 	exemptGallery := false
 	if _, err := os.Stat(filepath.Join(folderPath, ".nogallery")); err == nil {
 		exemptGallery = true
 	} else if !errors.Is(err, os.ErrNotExist) {
-		return nil, fmt.Errorf("Could not test Path %s: %w", folderPath, err)
+		return nil, "", fmt.Errorf("Could not test Path %s: %w", folderPath, err)
 	}
 
 	if forceGallery || (h.ScanConfig.GetCreateGalleriesFromFolders() && !exemptGallery) {
-		return h.getOrCreateFolderBasedGallery(ctx, f)
+		folderID := f.Base().ParentFolderID
+		g, err := h.getOrCreateFolderBasedGallery(ctx, folderID, folderPath, []models.FolderID{folderID})
+		return g, "", err
 	}
 
-	return nil, nil
+	return nil, "", nil
 }
 
-func (h *ScanHandler) getGalleryToAssociate(ctx context.Context, newImage *models.Image, f models.File) (*models.Gallery, error) {
-	g, err := h.getOrCreateGallery(ctx, f)
+func (h *ScanHandler) getGalleryToAssociate(ctx context.Context, newImage *models.Image, f models.File) (*models.Gallery, string, error) {
+	g, chapterRoot, err := h.getOrCreateGallery(ctx, f)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if err := newImage.LoadGalleryIDs(ctx, h.CreatorUpdater); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if g != nil && !slices.Contains(newImage.GalleryIDs.List(), g.ID) {
-		return g, nil
+		return g, chapterRoot, nil
 	}
 
-	return nil, nil
+	return nil, "", nil
 }
@@ -0,0 +1,419 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+// sidecarExtensions are the extensions of supported tag sidecar files, in
+// order of preference.
+var sidecarExtensions = []string{".txt", ".json"}
+
+// sidecarTagPlaceholder is replaced with the tag name in tag mappings.
+const sidecarTagPlaceholder = "{tag}"
+
+// booru content ratings
+var sidecarRatings = map[string]string{
+	"g": "general",
+	"s": "sensitive",
+	"q": "questionable",
+	"e": "explicit",
+}
+
+// danbooru tag string fields and the namespace of their tags
+var sidecarTagStringFields = []struct {
+	field     string
+	namespace string
+}{
+	{"tag_string_general", ""},
+	{"tag_string_artist", "artist"},
+	{"tag_string_character", "character"},
+	{"tag_string_copyright", "copyright"},
+	{"tag_string_meta", "meta"},
+}
+
+// SidecarTag is a tag read from a sidecar file.
+type SidecarTag struct {
+	// Namespace is empty if the tag is not namespaced.
+	Namespace string
+	Name      string
+}
+
+func (t SidecarTag) String() string {
+	if t.Namespace == "" {
+		return t.Name
+	}
+
+	return t.Namespace + ":" + t.Name
+}
+
+// Sidecar is the metadata read from a tag sidecar file.
+type Sidecar struct {
+	Title string
+	Tags  []SidecarTag
+	URLs  []string
+}
+
+// FindSidecar returns the path of the tag sidecar file of the image at path.
+// Sidecar files are named either after the full filename of the image, such as
+// image.jpg.txt, or after the filename without its extension, such as
+// image.txt. Returns an empty string if there is no sidecar file.
+func FindSidecar(path string) (string, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	for _, ext := range sidecarExtensions {
+		for _, p := range []string{path + ext, base + ext} {
+			exists, err := fsutil.FileExists(p)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+			if exists {
+				return p, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// ReadSidecar reads the tag sidecar file at path.
+func ReadSidecar(path string) (*Sidecar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return parseJSONSidecar(data)
+	}
+
+	return parseTextSidecar(string(data)), nil
+}
+
+// parseTextSidecar parses a text sidecar file. Files with one tag per line,
+// as exported by Hydrus, may contain namespaced tags. Files with a single line
+// are treated as a booru tag list, separated by commas or whitespace.
+func parseTextSidecar(data string) *Sidecar {
+	var lines []string
+	for _, l := range strings.Split(data, "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	ret := &Sidecar{}
+
+	if len(lines) == 1 && strings.ContainsAny(lines[0], ", \t") {
+		ret.Tags = parseBooruTagList(lines[0])
+		return ret
+	}
+
+	for _, l := range lines {
+		ret.Tags = append(ret.Tags, parseNamespacedTag(l))
+	}
+
+	return ret
+}
+
+func parseNamespacedTag(s string) SidecarTag {
+	namespace, name, found := strings.Cut(s, ":")
+	namespace = strings.TrimSpace(namespace)
+	name = strings.TrimSpace(name)
+	if !found || namespace == "" || name == "" || strings.Contains(namespace, " ") {
+		return SidecarTag{Name: s}
+	}
+
+	return SidecarTag{
+		Namespace: strings.ToLower(namespace),
+		Name:      name,
+	}
+}
+
+// parseBooruTagList parses a list of tags separated by commas or, if there are
+// no commas, by whitespace. Underscores in tags are replaced with spaces.
+func parseBooruTagList(s string) []SidecarTag {
+	var values []string
+	if strings.Contains(s, ",") {
+		values = strings.Split(s, ",")
+	} else {
+		values = strings.Fields(s)
+	}
+
+	var ret []SidecarTag
+	for _, v := range values {
+		if t := booruTag("", v); t.Name != "" {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+func booruTag(namespace string, name string) SidecarTag {
+	return SidecarTag{
+		Namespace: namespace,
+		Name:      strings.TrimSpace(strings.ReplaceAll(name, "_", " ")),
+	}
+}
+
+// parseJSONSidecar parses a json sidecar file, such as the metadata files
+// written by gallery-dl for booru posts.
+func parseJSONSidecar(data []byte) (*Sidecar, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing json sidecar: %w", err)
+	}
+
+	ret := &Sidecar{}
+
+	var title string
+	if json.Unmarshal(raw["title"], &title) == nil {
+		ret.Title = strings.TrimSpace(title)
+	}
+
+	hasTagStrings := false
+	for _, f := range sidecarTagStringFields {
+		var s string
+		if json.Unmarshal(raw[f.field], &s) != nil {
+			continue
+		}
+
+		hasTagStrings = true
+		for _, v := range strings.Fields(s) {
+			ret.Tags = append(ret.Tags, booruTag(f.namespace, v))
+		}
+	}
+
+	if !hasTagStrings {
+		ret.Tags = append(ret.Tags, parseJSONTags(raw["tag_string"])...)
+		ret.Tags = append(ret.Tags, parseJSONTags(raw["tags"])...)
+	}
+
+	var rating string
+	if json.Unmarshal(raw["rating"], &rating) == nil && rating != "" {
+		rating = strings.ToLower(rating)
+		if expanded, ok := sidecarRatings[rating]; ok {
+			rating = expanded
+		}
+		ret.Tags = append(ret.Tags, SidecarTag{Namespace: "rating", Name: rating})
+	}
+
+	var source string
+	if json.Unmarshal(raw["source"], &source) == nil {
+		ret.URLs = append(ret.URLs, source)
+	}
+
+	var sources []string
+	if json.Unmarshal(raw["sources"], &sources) == nil {
+		ret.URLs = append(ret.URLs, sources...)
+	}
+
+	ret.URLs = sliceutil.Filter(ret.URLs, isSidecarURL)
+
+	return ret, nil
+}
+
+// parseJSONTags parses tags that are either a booru tag list string, an array
+// of tags, or an object of namespaces to arrays of tags.
+func parseJSONTags(data json.RawMessage) []SidecarTag {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return parseBooruTagList(s)
+	}
+
+	var ret []SidecarTag
+
+	var list []string
+	if json.Unmarshal(data, &list) == nil {
+		for _, v := range list {
+			if t := booruTag("", v); t.Name != "" {
+				ret = append(ret, t)
+			}
+		}
+		return ret
+	}
+
+	var namespaced map[string][]string
+	if json.Unmarshal(data, &namespaced) == nil {
+		for namespace, list := range namespaced {
+			namespace = strings.ToLower(namespace)
+			if namespace == "general" {
+				namespace = ""
+			}
+
+			for _, v := range list {
+				if t := booruTag(namespace, v); t.Name != "" {
+					ret = append(ret, t)
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+func isSidecarURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// SidecarTagMapping maps tag namespaces to the names of Stash tags.
+type SidecarTagMapping map[string]string
+
+// ParseSidecarTagMapping parses tag mappings in the form
+// <namespace>=<tag name>, where {tag} in the tag name is replaced with the
+// name of the sidecar tag. Tags in namespaces mapped to an empty tag name are
+// ignored.
+func ParseSidecarTagMapping(mappings []string) SidecarTagMapping {
+	ret := make(SidecarTagMapping)
+	for _, m := range mappings {
+		namespace, name, found := strings.Cut(m, "=")
+		if !found {
+			logger.Warnf("invalid sidecar tag mapping %q: must be <namespace>=<tag name>", m)
+			continue
+		}
+
+		ret[strings.ToLower(strings.TrimSpace(namespace))] = strings.TrimSpace(name)
+	}
+
+	return ret
+}
+
+// TagName returns the name of the Stash tag for t. Tags in unmapped
+// namespaces keep their namespace. Returns an empty string if the tag should
+// be ignored.
+func (m SidecarTagMapping) TagName(t SidecarTag) string {
+	if t.Namespace == "" {
+		return t.Name
+	}
+
+	name, found := m[t.Namespace]
+	if !found {
+		return t.String()
+	}
+
+	return strings.ReplaceAll(name, sidecarTagPlaceholder, t.Name)
+}
+
+// importSidecar adds the tags, urls and title from the tag sidecar file of
+// the image file, if present.
+func (h *ScanHandler) importSidecar(ctx context.Context, i *models.Image, f models.File) error {
+	// sidecar files are not supported for images in zip files
+	if !h.ScanConfig.GetImportImageSidecars() || f.Base().ZipFile != nil {
+		return nil
+	}
+
+	sidecarPath, err := FindSidecar(f.Base().Path)
+	if err != nil {
+		return fmt.Errorf("finding sidecar file for %s: %w", f.Base().Path, err)
+	}
+	if sidecarPath == "" {
+		return nil
+	}
+
+	sidecar, err := ReadSidecar(sidecarPath)
+	if err != nil {
+		// don't fail the scan for an invalid sidecar file
+		logger.Warnf("Error reading sidecar file %s: %v", sidecarPath, err)
+		return nil
+	}
+
+	mapping := ParseSidecarTagMapping(h.ScanConfig.GetImageSidecarTagMappings())
+	tagIDs, err := h.getOrCreateSidecarTags(ctx, sidecar.Tags, mapping)
+	if err != nil {
+		return err
+	}
+
+	imagePartial := models.NewImagePartial()
+	changed := false
+
+	if len(tagIDs) > 0 {
+		imagePartial.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		changed = true
+	}
+
+	if len(sidecar.URLs) > 0 {
+		imagePartial.URLs = &models.UpdateStrings{
+			Values: sidecar.URLs,
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+		changed = true
+	}
+
+	// don't overwrite an existing title
+	if sidecar.Title != "" && i.Title == "" {
+		imagePartial.Title = models.NewOptionalString(sidecar.Title)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	logger.Infof("Importing metadata for %s from sidecar file %s", f.Base().Path, sidecarPath)
+
+	if _, err := h.CreatorUpdater.UpdatePartial(ctx, i.ID, imagePartial); err != nil {
+		return fmt.Errorf("updating image from sidecar: %w", err)
+	}
+
+	return nil
+}
+
+// getOrCreateSidecarTags returns the ids of the Stash tags of the sidecar
+// tags, creating tags that do not exist.
+func (h *ScanHandler) getOrCreateSidecarTags(ctx context.Context, tags []SidecarTag, mapping SidecarTagMapping) ([]int, error) {
+	var ret []int
+	for _, t := range tags {
+		name := mapping.TagName(t)
+		if name == "" {
+			continue
+		}
+
+		existing, err := tag.ByName(ctx, h.TagFinderCreator, name)
+		if err != nil {
+			return nil, fmt.Errorf("finding tag %s: %w", name, err)
+		}
+
+		if existing == nil {
+			existing, err = tag.ByAlias(ctx, h.TagFinderCreator, name)
+			if err != nil {
+				return nil, fmt.Errorf("finding tag %s: %w", name, err)
+			}
+		}
+
+		if existing == nil {
+			newTag := models.NewTag()
+			newTag.Name = name
+
+			logger.Infof("Creating tag %s from sidecar file", name)
+
+			if err := h.TagFinderCreator.Create(ctx, &newTag); err != nil {
+				return nil, fmt.Errorf("creating tag %s: %w", name, err)
+			}
+
+			existing = &newTag
+		}
+
+		ret = sliceutil.AppendUnique(ret, existing.ID)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,137 @@
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTextSidecar(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []SidecarTag
+	}{
+		{
+			"hydrus",
+			"character:samus aran\nseries:metroid\n\nblue sky\n",
+			[]SidecarTag{{"character", "samus aran"}, {"series", "metroid"}, {"", "blue sky"}},
+		},
+		{
+			"booru",
+			"1girl long_hair solo\n",
+			[]SidecarTag{{"", "1girl"}, {"", "long hair"}, {"", "solo"}},
+		},
+		{
+			"comma separated",
+			"1girl, long_hair, re:zero",
+			[]SidecarTag{{"", "1girl"}, {"", "long hair"}, {"", "re:zero"}},
+		},
+		{
+			"single namespaced tag",
+			"creator:someone",
+			[]SidecarTag{{"creator", "someone"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseTextSidecar(tt.data).Tags)
+		})
+	}
+}
+
+func TestParseJSONSidecar(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantTags []SidecarTag
+		wantURLs []string
+		title    string
+	}{
+		{
+			"danbooru",
+			`{"tag_string": "ignored", "tag_string_general": "1girl long_hair", "tag_string_artist": "some_artist", "rating": "e", "source": "https://example.com/1"}`,
+			[]SidecarTag{{"", "1girl"}, {"", "long hair"}, {"artist", "some artist"}, {"rating", "explicit"}},
+			[]string{"https://example.com/1"},
+			"",
+		},
+		{
+			"tag list",
+			`{"title": " Title ", "tags": ["blue_sky", "cloud"], "sources": ["https://example.com/1", "file.jpg"]}`,
+			[]SidecarTag{{"", "blue sky"}, {"", "cloud"}},
+			[]string{"https://example.com/1"},
+			"Title",
+		},
+		{
+			"namespaced tags",
+			`{"tags": {"general": ["cloud"], "Species": ["cat"]}, "rating": "questionable"}`,
+			[]SidecarTag{{"", "cloud"}, {"species", "cat"}, {"rating", "questionable"}},
+			nil,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSONSidecar([]byte(tt.data))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.ElementsMatch(t, tt.wantTags, got.Tags)
+			assert.Equal(t, tt.wantURLs, got.URLs)
+			assert.Equal(t, tt.title, got.Title)
+		})
+	}
+
+	_, err := parseJSONSidecar([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestSidecarTagMapping(t *testing.T) {
+	mapping := ParseSidecarTagMapping([]string{
+		"Character={tag}",
+		"meta=",
+		"creator=Artist: {tag}",
+		"invalid",
+	})
+
+	tests := []struct {
+		tag  SidecarTag
+		want string
+	}{
+		{SidecarTag{"", "cloud"}, "cloud"},
+		{SidecarTag{"character", "samus aran"}, "samus aran"},
+		{SidecarTag{"meta", "highres"}, ""},
+		{SidecarTag{"creator", "someone"}, "Artist: someone"},
+		{SidecarTag{"series", "metroid"}, "series:metroid"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, mapping.TagName(tt.tag), tt.tag.String())
+	}
+}
+
+func TestFindSidecar(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("tag"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	got, err := FindSidecar(filepath.Join(dir, "a.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	want := write("a.json")
+	got, _ = FindSidecar(filepath.Join(dir, "a.jpg"))
+	assert.Equal(t, want, got)
+
+	want = write("a.jpg.txt")
+	got, _ = FindSidecar(filepath.Join(dir, "a.jpg"))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,227 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// StackOptions are the options used to group the images of a gallery into
+// stacks.
+type StackOptions struct {
+	// Interval is the maximum number of seconds between consecutive images
+	// of a burst.
+	Interval float64 `json:"interval"`
+	// Distance is the maximum phash distance between consecutive images of
+	// a burst. Images without a phash are grouped by time alone.
+	Distance int `json:"distance"`
+	// DuplicateDistance is the maximum phash distance between near-duplicate
+	// images, which are stacked regardless of when they were taken. Negative
+	// values disable near-duplicate stacking.
+	DuplicateDistance int `json:"duplicate_distance"`
+	// MinSize is the minimum number of images in a stack.
+	MinSize int `json:"min_size"`
+}
+
+var DefaultStackOptions = StackOptions{
+	Interval:          2,
+	Distance:          10,
+	DuplicateDistance: 4,
+	MinSize:           2,
+}
+
+// Stack is a group of images taken in a burst, or that are nearly identical.
+type Stack struct {
+	// Representative is the image shown in place of the stack.
+	Representative *models.Image `json:"representative"`
+	// Images are the images of the stack, in the order they were taken.
+	Images []*models.Image `json:"images"`
+}
+
+type StackFinder interface {
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*models.Image, error)
+}
+
+// FindStacks returns the stacks of the images of the gallery, in the order
+// they were taken.
+func FindStacks(ctx context.Context, r StackFinder, fileGetter models.FileGetter, galleryID int, options StackOptions) ([]*Stack, error) {
+	images, err := r.FindByGalleryID(ctx, galleryID)
+	if err != nil {
+		return nil, fmt.Errorf("finding gallery images: %w", err)
+	}
+
+	var fileIDs []models.FileID
+	for _, i := range images {
+		if i.PrimaryFileID != nil {
+			fileIDs = append(fileIDs, *i.PrimaryFileID)
+		}
+	}
+
+	files, err := fileGetter.Find(ctx, fileIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("finding image files: %w", err)
+	}
+
+	filesByID := make(map[models.FileID]models.File)
+	for _, f := range files {
+		filesByID[f.Base().ID] = f
+	}
+
+	var stackImages []stackImage
+	for _, i := range images {
+		if i.PrimaryFileID == nil {
+			continue
+		}
+
+		if f := filesByID[*i.PrimaryFileID]; f != nil {
+			stackImages = append(stackImages, newStackImage(i, f))
+		}
+	}
+
+	return groupStacks(stackImages, options), nil
+}
+
+type stackImage struct {
+	image *models.Image
+	time  time.Time
+	// phash is nil if the file has no phash
+	phash  *uint64
+	pixels int
+	size   int64
+}
+
+func newStackImage(i *models.Image, f models.File) stackImage {
+	base := f.Base()
+	ret := stackImage{
+		image: i,
+		// cameras set the modification time to the time the photo was taken
+		time: base.ModTime,
+		size: base.Size,
+	}
+
+	if v, ok := base.Fingerprints.Get(models.FingerprintTypePhash).(int64); ok {
+		phash := uint64(v)
+		ret.phash = &phash
+	}
+
+	if vf, ok := f.(models.VisualFile); ok {
+		ret.pixels = vf.GetWidth() * vf.GetHeight()
+	}
+
+	return ret
+}
+
+// phashWithin returns true if both images have a phash within distance of
+// each other. If missingMatches is true, images without a phash match.
+func (a stackImage) phashWithin(b stackImage, distance int, missingMatches bool) bool {
+	if a.phash == nil || b.phash == nil {
+		return missingMatches
+	}
+
+	return utils.HammingDistance(*a.phash, *b.phash) <= distance
+}
+
+// better returns true if a is a better representative of a stack than b.
+func (a stackImage) better(b stackImage) bool {
+	ra, rb := 0, 0
+	if a.image.Rating != nil {
+		ra = *a.image.Rating
+	}
+	if b.image.Rating != nil {
+		rb = *b.image.Rating
+	}
+
+	switch {
+	case ra != rb:
+		return ra > rb
+	case a.pixels != b.pixels:
+		return a.pixels > b.pixels
+	default:
+		return a.size > b.size
+	}
+}
+
+// groupStacks groups images into stacks. Consecutive images are stacked if
+// they were taken within the interval and their phashes are within the
+// distance. Images with phashes within the duplicate distance are stacked
+// regardless of time.
+func groupStacks(images []stackImage, options StackOptions) []*Stack {
+	images = slices.Clone(images)
+	slices.SortStableFunc(images, func(a, b stackImage) int {
+		return a.time.Compare(b.time)
+	})
+
+	// union-find of the stack of each image
+	parent := make([]int, len(images))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		// the root is always the earliest image of the stack
+		if ra < rb {
+			parent[rb] = ra
+		} else {
+			parent[ra] = rb
+		}
+	}
+
+	maxInterval := time.Duration(options.Interval * float64(time.Second))
+
+	for i := 1; i < len(images); i++ {
+		prev, cur := images[i-1], images[i]
+		if cur.time.Sub(prev.time) <= maxInterval && cur.phashWithin(prev, options.Distance, true) {
+			union(i-1, i)
+		}
+	}
+
+	if options.DuplicateDistance >= 0 {
+		for i := range images {
+			for j := i + 1; j < len(images); j++ {
+				if images[i].phashWithin(images[j], options.DuplicateDistance, false) {
+					union(i, j)
+				}
+			}
+		}
+	}
+
+	// images are sorted by time, so stacks are created in order of their
+	// earliest image
+	var ret []*Stack
+	stacks := make(map[int]*Stack)
+	representatives := make(map[int]stackImage)
+	for i, img := range images {
+		root := find(i)
+		s := stacks[root]
+		if s == nil {
+			s = &Stack{}
+			stacks[root] = s
+			ret = append(ret, s)
+		}
+
+		s.Images = append(s.Images, img.image)
+
+		if r, found := representatives[root]; !found || img.better(r) {
+			representatives[root] = img
+			s.Representative = img.image
+		}
+	}
+
+	minSize := max(options.MinSize, 2)
+	return slices.DeleteFunc(ret, func(s *Stack) bool {
+		return len(s.Images) < minSize
+	})
+}
@@ -0,0 +1,62 @@
+package image
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestGroupStacks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newImage := func(id int, seconds float64, phash *uint64, pixels int) stackImage {
+		return stackImage{
+			image:  &models.Image{ID: id},
+			time:   start.Add(time.Duration(seconds * float64(time.Second))),
+			phash:  phash,
+			pixels: pixels,
+		}
+	}
+
+	hash := func(v uint64) *uint64 { return &v }
+
+	images := []stackImage{
+		// burst of three, the second has the highest resolution
+		newImage(1, 0, hash(0x00), 100),
+		newImage(2, 0.5, hash(0x01), 200),
+		newImage(3, 1, hash(0x03), 100),
+		// taken straight after, but a different shot
+		newImage(4, 1.5, hash(0xffff_ffff), 100),
+		// single image without a phash
+		newImage(5, 60, nil, 100),
+		// near-duplicate of the first burst, taken later
+		newImage(6, 120, hash(0x00), 100),
+		// burst of images without phashes
+		newImage(8, 181, nil, 100),
+		newImage(7, 180, nil, 100),
+	}
+
+	ids := func(stacks []*Stack) [][]int {
+		var ret [][]int
+		for _, s := range stacks {
+			var stackIDs []int
+			for _, i := range s.Images {
+				stackIDs = append(stackIDs, i.ID)
+			}
+			ret = append(ret, stackIDs)
+		}
+		return ret
+	}
+
+	got := groupStacks(images, DefaultStackOptions)
+	assert.Equal(t, [][]int{{1, 2, 3, 6}, {7, 8}}, ids(got))
+	assert.Equal(t, 2, got[0].Representative.ID)
+
+	noDuplicates := DefaultStackOptions
+	noDuplicates.DuplicateDistance = -1
+	noDuplicates.MinSize = 3
+	assert.Equal(t, [][]int{{1, 2, 3}}, ids(groupStacks(images, noDuplicates)))
+}
@@ -0,0 +1,241 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+)
+
+// MaxTransformDimension is the maximum width or height of a transformed
+// image.
+const MaxTransformDimension = 4096
+
+var ErrInvalidTransform = errors.New("invalid image transform")
+
+type TransformFormat string
+
+const (
+	TransformFormatJpeg TransformFormat = "jpeg"
+	TransformFormatPng  TransformFormat = "png"
+	TransformFormatWebp TransformFormat = "webp"
+)
+
+func (f TransformFormat) IsValid() bool {
+	switch f {
+	case TransformFormatJpeg, TransformFormatPng, TransformFormatWebp:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type of the format.
+func (f TransformFormat) ContentType() string {
+	return "image/" + string(f)
+}
+
+// Extension returns the file extension of the format, including the dot.
+func (f TransformFormat) Extension() string {
+	if f == TransformFormatJpeg {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+func (f TransformFormat) codec() ffmpeg.VideoCodec {
+	switch f {
+	case TransformFormatPng:
+		return ffmpeg.VideoCodecPNG
+	case TransformFormatWebp:
+		return ffmpeg.VideoCodecLibWebP
+	}
+	return ffmpeg.VideoCodecMJpeg
+}
+
+// CropRect is a rectangle of the source image, in pixels.
+type CropRect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Transform is a transformation of an image. The image is cropped, then
+// rotated, then resized to fit within the width and height, maintaining the
+// aspect ratio. Images are never enlarged.
+type Transform struct {
+	// Width is the maximum width of the result. 0 for no limit.
+	Width int
+	// Height is the maximum height of the result. 0 for no limit.
+	Height int
+	Crop   *CropRect
+	// Rotate is the clockwise rotation in degrees. Must be a multiple of 90.
+	Rotate int
+	// Format is the format of the result. Defaults to jpeg.
+	Format TransformFormat
+}
+
+// ParseTransform parses a transform from the w, h, crop, rotate and format
+// query parameters. Other parameters are ignored. crop is given as
+// x,y,width,height.
+func ParseTransform(q neturl.Values) (Transform, error) {
+	var ret Transform
+
+	parseInt := func(key string) (int, error) {
+		v := q.Get(key)
+		if v == "" {
+			return 0, nil
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s: %q", ErrInvalidTransform, key, v)
+		}
+		return i, nil
+	}
+
+	var err error
+	if ret.Width, err = parseInt("w"); err != nil {
+		return ret, err
+	}
+	if ret.Height, err = parseInt("h"); err != nil {
+		return ret, err
+	}
+	if ret.Rotate, err = parseInt("rotate"); err != nil {
+		return ret, err
+	}
+
+	if v := q.Get("crop"); v != "" {
+		parts := strings.Split(v, ",")
+		if len(parts) != 4 {
+			return ret, fmt.Errorf("%w: crop: %q", ErrInvalidTransform, v)
+		}
+
+		var values [4]int
+		for i, p := range parts {
+			values[i], err = strconv.Atoi(p)
+			if err != nil {
+				return ret, fmt.Errorf("%w: crop: %q", ErrInvalidTransform, v)
+			}
+		}
+
+		ret.Crop = &CropRect{X: values[0], Y: values[1], Width: values[2], Height: values[3]}
+	}
+
+	ret.Format = TransformFormat(q.Get("format"))
+	if ret.Format == "" {
+		ret.Format = TransformFormatJpeg
+	}
+
+	if err := ret.Validate(); err != nil {
+		return ret, err
+	}
+
+	return ret, nil
+}
+
+// Validate returns an error if the transform is not valid.
+func (t Transform) Validate() error {
+	if t.Width < 0 || t.Width > MaxTransformDimension || t.Height < 0 || t.Height > MaxTransformDimension {
+		return fmt.Errorf("%w: dimensions must be between 0 and %d", ErrInvalidTransform, MaxTransformDimension)
+	}
+
+	if t.Rotate%90 != 0 || t.Rotate < 0 || t.Rotate >= 360 {
+		return fmt.Errorf("%w: rotate must be 0, 90, 180 or 270", ErrInvalidTransform)
+	}
+
+	if c := t.Crop; c != nil && (c.X < 0 || c.Y < 0 || c.Width <= 0 || c.Height <= 0) {
+		return fmt.Errorf("%w: crop must be within the image", ErrInvalidTransform)
+	}
+
+	if !t.Format.IsValid() {
+		return fmt.Errorf("%w: unsupported format %q", ErrInvalidTransform, t.Format)
+	}
+
+	return nil
+}
+
+// Query returns the query parameters of the transform. Parameters with
+// default values are omitted.
+func (t Transform) Query() neturl.Values {
+	ret := neturl.Values{}
+	if t.Width > 0 {
+		ret.Set("w", strconv.Itoa(t.Width))
+	}
+	if t.Height > 0 {
+		ret.Set("h", strconv.Itoa(t.Height))
+	}
+	if c := t.Crop; c != nil {
+		ret.Set("crop", fmt.Sprintf("%d,%d,%d,%d", c.X, c.Y, c.Width, c.Height))
+	}
+	if t.Rotate != 0 {
+		ret.Set("rotate", strconv.Itoa(t.Rotate))
+	}
+	if t.Format != "" && t.Format != TransformFormatJpeg {
+		ret.Set("format", string(t.Format))
+	}
+	return ret
+}
+
+// String returns the canonical encoding of the transform, which is the same
+// for equivalent transforms.
+func (t Transform) String() string {
+	return t.Query().Encode()
+}
+
+func (t Transform) videoFilter() ffmpeg.VideoFilter {
+	var ret ffmpeg.VideoFilter
+
+	if c := t.Crop; c != nil {
+		ret = ret.Append(fmt.Sprintf("crop=%d:%d:%d:%d", c.Width, c.Height, c.X, c.Y))
+	}
+
+	switch t.Rotate {
+	case 90:
+		ret = ret.Append("transpose=clock")
+	case 180:
+		ret = ret.Append("hflip").Append("vflip")
+	case 270:
+		ret = ret.Append("transpose=cclock")
+	}
+
+	// the min expressions prevent enlarging the image
+	switch {
+	case t.Width > 0 && t.Height > 0:
+		ret = ret.Append(fmt.Sprintf("scale='min(%d,iw)':'min(%d,ih)':force_original_aspect_ratio=decrease", t.Width, t.Height))
+	case t.Width > 0:
+		ret = ret.Append(fmt.Sprintf("scale='min(%d,iw)':-1", t.Width))
+	case t.Height > 0:
+		ret = ret.Append(fmt.Sprintf("scale=-1:'min(%d,ih)'", t.Height))
+	}
+
+	return ret
+}
+
+// Transform applies the transform to the image data, returning the encoded
+// result.
+func (e *ThumbnailEncoder) Transform(ctx context.Context, data []byte, t Transform) ([]byte, error) {
+	format := t.Format
+	if format == "" {
+		format = TransformFormatJpeg
+	}
+
+	quality := 0
+	if format == TransformFormatJpeg {
+		quality = ffmpegImageQuality
+	}
+
+	args := transcoder.ImageTransform("-", transcoder.ImageTransformOptions{
+		VideoFilter: t.videoFilter(),
+		OutputCodec: format.codec(),
+		OutputPath:  "-",
+		Quality:     quality,
+	})
+
+	return e.FFMpeg.GenerateOutput(ctx, args, bytes.NewReader(data))
+}
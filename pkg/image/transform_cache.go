@@ -0,0 +1,199 @@
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// TransformCacheConfig provides the settings of a TransformCache.
+type TransformCacheConfig interface {
+	// GetImageTransformCachePath returns the directory of the cache. The
+	// cache is disabled if empty.
+	GetImageTransformCachePath() string
+	// GetImageTransformCacheSizeBytes returns the maximum size of the cache
+	// in bytes. The cache is disabled if 0.
+	GetImageTransformCacheSizeBytes() int64
+}
+
+// TransformCache stores transformed images on disk. When the cache exceeds
+// its maximum size, the least recently used images are removed.
+type TransformCache struct {
+	Config TransformCacheConfig
+
+	mutex sync.Mutex
+	// dir is the directory that size was calculated for
+	dir string
+	// size is the total size of the cached images, or -1 if not known
+	size int64
+}
+
+func NewTransformCache(c TransformCacheConfig) *TransformCache {
+	return &TransformCache{
+		Config: c,
+		size:   -1,
+	}
+}
+
+func (c *TransformCache) enabled() bool {
+	return c.Config.GetImageTransformCachePath() != "" && c.Config.GetImageTransformCacheSizeBytes() > 0
+}
+
+func (c *TransformCache) path(key string, format TransformFormat) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Config.GetImageTransformCachePath(), name[:2], name+format.Extension())
+}
+
+// Get returns the path of the cached image with the key, or an empty string
+// if it is not cached.
+func (c *TransformCache) Get(key string, format TransformFormat) string {
+	if !c.enabled() {
+		return ""
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	path := c.path(key, format)
+
+	// the modification time is used as the last access time
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return ""
+	}
+
+	return path
+}
+
+// Put stores the image data with the key, returning the path of the cached
+// image. It returns an empty string if the cache is disabled.
+func (c *TransformCache) Put(key string, format TransformFormat, data []byte) (string, error) {
+	if !c.enabled() {
+		return "", nil
+	}
+
+	// don't cache images that would not fit
+	if int64(len(data)) > c.Config.GetImageTransformCacheSizeBytes() {
+		return "", nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if dir := c.Config.GetImageTransformCachePath(); dir != c.dir {
+		c.dir = dir
+		c.size = -1
+	}
+
+	if c.size < 0 {
+		files, err := c.list()
+		if err != nil {
+			return "", err
+		}
+		c.size = totalSize(files)
+	}
+
+	path := c.path(key, format)
+	if err := fsutil.EnsureDirAll(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		c.size -= info.Size()
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		// the size of the partially written file is not known
+		c.size = -1
+		return "", err
+	}
+	c.size += int64(len(data))
+
+	if c.size > c.Config.GetImageTransformCacheSizeBytes() {
+		if err := c.prune(); err != nil {
+			c.size = -1
+			return "", err
+		}
+	}
+
+	return path, nil
+}
+
+type cachedTransform struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func totalSize(files []cachedTransform) int64 {
+	var ret int64
+	for _, f := range files {
+		ret += f.size
+	}
+	return ret
+}
+
+// list returns the images in the cache.
+func (c *TransformCache) list() ([]cachedTransform, error) {
+	var ret []cachedTransform
+
+	err := filepath.WalkDir(c.Config.GetImageTransformCachePath(), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, cachedTransform{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+
+	return ret, err
+}
+
+// prune removes the least recently used images until the cache is within its
+// maximum size.
+func (c *TransformCache) prune() error {
+	files, err := c.list()
+	if err != nil {
+		return err
+	}
+
+	c.size = totalSize(files)
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	maxSize := c.Config.GetImageTransformCacheSizeBytes()
+	for _, f := range files {
+		if c.size <= maxSize {
+			break
+		}
+
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		c.size -= f.size
+	}
+
+	return nil
+}
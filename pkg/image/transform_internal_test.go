@@ -0,0 +1,133 @@
+package image
+
+import (
+	"errors"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransform(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    Transform
+		wantErr bool
+	}{
+		{"", Transform{Format: TransformFormatJpeg}, false},
+		{"w=320&h=240&t=123&sig=abc", Transform{Width: 320, Height: 240, Format: TransformFormatJpeg}, false},
+		{"crop=10,20,300,400&rotate=90&format=webp", Transform{
+			Crop:   &CropRect{X: 10, Y: 20, Width: 300, Height: 400},
+			Rotate: 90,
+			Format: TransformFormatWebp,
+		}, false},
+		{"w=abc", Transform{}, true},
+		{"w=5000", Transform{}, true},
+		{"h=-1", Transform{}, true},
+		{"rotate=45", Transform{}, true},
+		{"rotate=360", Transform{}, true},
+		{"crop=1,2,3", Transform{}, true},
+		{"crop=0,0,0,10", Transform{}, true},
+		{"format=gif", Transform{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, _ := neturl.ParseQuery(tt.query)
+			got, err := ParseTransform(q)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidTransform), "expected ErrInvalidTransform, got %v", err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTransformString(t *testing.T) {
+	a := Transform{Width: 320, Rotate: 180, Format: TransformFormatJpeg}
+	b := Transform{Rotate: 180, Width: 320}
+	assert.Equal(t, a.String(), b.String())
+	assert.Equal(t, "rotate=180&w=320", a.String())
+
+	// round trip
+	parsed, err := ParseTransform(a.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, a, parsed)
+}
+
+func TestTransformVideoFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Transform
+		want ffmpeg.VideoFilter
+	}{
+		{"none", Transform{}, ""},
+		{"width", Transform{Width: 320}, "scale='min(320,iw)':-1"},
+		{"height", Transform{Height: 240}, "scale=-1:'min(240,ih)'"},
+		{"both", Transform{Width: 320, Height: 240}, "scale='min(320,iw)':'min(240,ih)':force_original_aspect_ratio=decrease"},
+		{"crop rotate", Transform{Crop: &CropRect{X: 1, Y: 2, Width: 3, Height: 4}, Rotate: 270}, "crop=3:4:1:2,transpose=cclock"},
+		{"rotate 180", Transform{Rotate: 180}, "hflip,vflip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.t.videoFilter())
+		})
+	}
+}
+
+type testTransformCacheConfig struct {
+	path string
+	size int64
+}
+
+func (c testTransformCacheConfig) GetImageTransformCachePath() string     { return c.path }
+func (c testTransformCacheConfig) GetImageTransformCacheSizeBytes() int64 { return c.size }
+
+func TestTransformCache(t *testing.T) {
+	cache := NewTransformCache(testTransformCacheConfig{path: t.TempDir(), size: 10})
+
+	assert.Equal(t, "", cache.Get("a", TransformFormatJpeg))
+
+	put := func(key string, data string) string {
+		path, err := cache.Put(key, TransformFormatJpeg, []byte(data))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return path
+	}
+
+	aPath := put("a", "1234")
+	bPath := put("b", "1234")
+	assert.FileExists(t, aPath)
+	assert.Equal(t, ".jpg", filepath.Ext(aPath))
+
+	// make b the least recently used
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(bPath, old, old))
+	assert.Equal(t, aPath, cache.Get("a", TransformFormatJpeg))
+
+	// exceeds the maximum size, so b is removed
+	cPath := put("c", "1234")
+	assert.FileExists(t, aPath)
+	assert.NoFileExists(t, bPath)
+	assert.FileExists(t, cPath)
+	assert.Equal(t, "", cache.Get("b", TransformFormatJpeg))
+
+	// images larger than the cache are not cached
+	assert.Equal(t, "", put("d", "12345678901"))
+
+	// disabled
+	disabled := NewTransformCache(testTransformCacheConfig{path: t.TempDir()})
+	path, err := disabled.Put("a", TransformFormatJpeg, []byte("1234"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", path)
+}
@@ -0,0 +1,348 @@
+// Package imagesearch finds the performer images, scene covers and images in
+// the library that are similar to a given image, using perceptual hashes.
+package imagesearch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/hash/imagephash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const (
+	// DefaultDistance is the maximum phash distance of a match if no
+	// distance is given.
+	DefaultDistance = 10
+	// MaxDistance is the largest accepted phash distance. Larger distances
+	// match nearly every image.
+	MaxDistance = 24
+
+	// DefaultLimit is the number of matches returned if no limit is given.
+	DefaultLimit = 20
+	// MaxLimit is the maximum number of matches returned.
+	MaxLimit = 100
+
+	// hashBatchSize is the number of missing phashes computed per
+	// transaction.
+	hashBatchSize = 50
+
+	// phashBits is the number of bits in a phash, used to convert a
+	// distance to a similarity.
+	phashBits = 64
+)
+
+type MatchType string
+
+const (
+	MatchTypePerformerImage MatchType = "PERFORMER_IMAGE"
+	MatchTypeSceneCover     MatchType = "SCENE_COVER"
+	MatchTypeImage          MatchType = "IMAGE"
+)
+
+var AllMatchType = []MatchType{
+	MatchTypePerformerImage,
+	MatchTypeSceneCover,
+	MatchTypeImage,
+}
+
+func (e MatchType) IsValid() bool {
+	return slices.Contains(AllMatchType, e)
+}
+
+func (e MatchType) String() string {
+	return string(e)
+}
+
+func (e *MatchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReverseImageSearchMatchType", str)
+	}
+	return nil
+}
+
+func (e MatchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Match is an object with an image similar to the searched image. Exactly one
+// of the object fields is set, according to Type.
+type Match struct {
+	Type MatchType `json:"type"`
+	ID   int       `json:"id"`
+	// Distance is the hamming distance between the phashes of the images.
+	Distance int `json:"distance"`
+	// Similarity is the similarity of the images between 0 and 1.
+	Similarity float64 `json:"similarity"`
+
+	Performer *models.Performer `json:"performer"`
+	Scene     *models.Scene     `json:"scene"`
+	Image     *models.Image     `json:"image"`
+}
+
+type PerformerFinder interface {
+	models.PerformerGetter
+	models.ImagePhashReaderWriter
+	GetImage(ctx context.Context, performerID int) ([]byte, error)
+}
+
+type SceneFinder interface {
+	models.SceneGetter
+	models.ImagePhashReaderWriter
+	GetCover(ctx context.Context, sceneID int) ([]byte, error)
+}
+
+type ImageFinder interface {
+	models.ImageGetter
+	FindByFilePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error)
+}
+
+type Repository struct {
+	TxnManager models.TxnManager
+
+	Performer PerformerFinder
+	Scene     SceneFinder
+	Image     ImageFinder
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		TxnManager: repo.TxnManager,
+		Performer:  repo.Performer,
+		Scene:      repo.Scene,
+		Image:      repo.Image,
+	}
+}
+
+type Options struct {
+	// Distance is the maximum phash distance of a match.
+	Distance int
+	// Types are the types of object to match. All types are matched if
+	// empty.
+	Types []MatchType
+	Limit int
+}
+
+// Search returns the objects with an image similar to the image read from
+// img, ordered by similarity. Performer images and scene covers that have
+// not been hashed are hashed first, which may take some time for the first
+// search of a large library. Images only match if a phash of their file has
+// been generated, which is the case for animated images and gallery covers,
+// so most gallery images do not match. Matching is by phash only - faces are
+// not matched.
+//
+// Search manages its own transactions.
+func Search(ctx context.Context, r Repository, img io.Reader, options Options) ([]*Match, error) {
+	hash, err := imagephash.Generate(img)
+	if err != nil {
+		return nil, fmt.Errorf("hashing image: %w", err)
+	}
+	phash := int64(*hash)
+
+	distance := options.Distance
+	if distance <= 0 {
+		distance = DefaultDistance
+	}
+	distance = min(distance, MaxDistance)
+
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	types := options.Types
+	if len(types) == 0 {
+		types = AllMatchType
+	}
+
+	if slices.Contains(types, MatchTypePerformerImage) {
+		if err := hashMissing(ctx, r.TxnManager, r.Performer, r.Performer.GetImage); err != nil {
+			return nil, fmt.Errorf("hashing performer images: %w", err)
+		}
+	}
+
+	if slices.Contains(types, MatchTypeSceneCover) {
+		if err := hashMissing(ctx, r.TxnManager, r.Scene, r.Scene.GetCover); err != nil {
+			return nil, fmt.Errorf("hashing scene covers: %w", err)
+		}
+	}
+
+	var ret []*Match
+	if err := txn.WithReadTxn(ctx, r.TxnManager, func(ctx context.Context) error {
+		ret, err = findMatches(ctx, r, phash, distance, types)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	sortMatches(ret)
+
+	if err := txn.WithReadTxn(ctx, r.TxnManager, func(ctx context.Context) error {
+		ret, err = loadObjects(ctx, r, ret, limit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// hashMissing computes and stores the missing phashes of the images returned
+// by getImage. Images are read in a read transaction and hashed outside of
+// it, so that writes are not blocked while hashing. Images that cannot be
+// hashed are stored with a null phash so they are not retried until the
+// image changes.
+func hashMissing(ctx context.Context, mgr models.TxnManager, rw models.ImagePhashReaderWriter, getImage func(ctx context.Context, id int) ([]byte, error)) error {
+	for {
+		var ids []int
+		images := make(map[int][]byte)
+		if err := txn.WithReadTxn(ctx, mgr, func(ctx context.Context) error {
+			var err error
+			ids, err = rw.FindMissingImagePhashes(ctx, hashBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				images[id], err = getImage(ctx, id)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		phashes := make(map[int]*int64)
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			hash, err := imagephash.Generate(bytes.NewReader(images[id]))
+			if err != nil {
+				logger.Debugf("could not hash image of %d: %v", id, err)
+				phashes[id] = nil
+				continue
+			}
+
+			v := int64(*hash)
+			phashes[id] = &v
+		}
+
+		if err := txn.WithTxn(ctx, mgr, func(ctx context.Context) error {
+			for _, id := range ids {
+				if err := rw.UpdateImagePhash(ctx, id, phashes[id]); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func findMatches(ctx context.Context, r Repository, phash int64, distance int, types []MatchType) ([]*Match, error) {
+	finders := map[MatchType]func(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error){
+		MatchTypePerformerImage: r.Performer.FindByImagePhash,
+		MatchTypeSceneCover:     r.Scene.FindByImagePhash,
+		MatchTypeImage:          r.Image.FindByFilePhash,
+	}
+
+	var ret []*Match
+	for _, t := range AllMatchType {
+		if !slices.Contains(types, t) {
+			continue
+		}
+
+		matches, err := finders[t](ctx, phash, distance)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range matches {
+			ret = append(ret, &Match{
+				Type:       t,
+				ID:         m.ID,
+				Distance:   m.Distance,
+				Similarity: 1 - float64(m.Distance)/phashBits,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// sortMatches sorts matches by ascending distance, then by type in the order
+// of AllMatchType, then by id.
+func sortMatches(matches []*Match) {
+	slices.SortStableFunc(matches, func(a, b *Match) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+
+		if a.Type != b.Type {
+			return slices.Index(AllMatchType, a.Type) - slices.Index(AllMatchType, b.Type)
+		}
+
+		return a.ID - b.ID
+	})
+}
+
+// loadObjects sets the object of each match, removing matches for objects
+// that have since been destroyed or are not visible to the user. At most
+// limit matches are returned, so that removed matches are replaced by the
+// next matches.
+func loadObjects(ctx context.Context, r Repository, matches []*Match, limit int) ([]*Match, error) {
+	var ret []*Match
+	for _, m := range matches {
+		if len(ret) >= limit {
+			break
+		}
+
+		var err error
+		found := false
+		switch m.Type {
+		case MatchTypePerformerImage:
+			m.Performer, err = r.Performer.Find(ctx, m.ID)
+			found = m.Performer != nil
+		case MatchTypeSceneCover:
+			m.Scene, err = r.Scene.Find(ctx, m.ID)
+			found = m.Scene != nil
+		case MatchTypeImage:
+			m.Image, err = r.Image.Find(ctx, m.ID)
+			found = m.Image != nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("loading %s %d: %w", m.Type, m.ID, err)
+		}
+
+		if found {
+			ret = append(ret, m)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,162 @@
+package imagesearch
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, 32, 32))
+	for x := 0; x < 32; x++ {
+		for y := 0; y < 32; y++ {
+			img.Set(x, y, color.Gray{Y: uint8(x * 8)})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	img := testPNG(t)
+
+	db := mocks.NewDatabase()
+	r := Repository{
+		TxnManager: db,
+		Performer:  db.Performer,
+		Scene:      db.Scene,
+		Image:      db.Image,
+	}
+
+	// performer 1 has an unhashed image, performer 2 an image that cannot
+	// be hashed
+	db.Performer.On("FindMissingImagePhashes", mock.Anything, hashBatchSize).Return([]int{1, 2}, nil).Once()
+	db.Performer.On("FindMissingImagePhashes", mock.Anything, hashBatchSize).Return(nil, nil).Once()
+	db.Performer.On("GetImage", mock.Anything, 1).Return(img, nil)
+	db.Performer.On("GetImage", mock.Anything, 2).Return([]byte("not an image"), nil)
+	db.Performer.On("UpdateImagePhash", mock.Anything, 1, mock.AnythingOfType("*int64")).Return(nil)
+	db.Performer.On("UpdateImagePhash", mock.Anything, 2, (*int64)(nil)).Return(nil)
+
+	db.Scene.On("FindMissingImagePhashes", mock.Anything, hashBatchSize).Return(nil, nil)
+
+	db.Performer.On("FindByImagePhash", mock.Anything, mock.Anything, DefaultDistance).Return([]models.PhashMatch{{ID: 1, Distance: 0}}, nil)
+	db.Scene.On("FindByImagePhash", mock.Anything, mock.Anything, DefaultDistance).Return([]models.PhashMatch{{ID: 3, Distance: 4}, {ID: 4, Distance: 0}}, nil)
+	db.Image.On("FindByFilePhash", mock.Anything, mock.Anything, DefaultDistance).Return([]models.PhashMatch{{ID: 5, Distance: 2}}, nil)
+
+	db.Performer.On("Find", mock.Anything, 1).Return(&models.Performer{ID: 1}, nil)
+	db.Scene.On("Find", mock.Anything, 3).Return(&models.Scene{ID: 3}, nil)
+	db.Scene.On("Find", mock.Anything, 4).Return(&models.Scene{ID: 4}, nil)
+	// image 5 has been destroyed since matching
+	db.Image.On("Find", mock.Anything, 5).Return(nil, nil)
+
+	got, err := Search(ctx, r, bytes.NewReader(img), Options{Limit: 3})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	type result struct {
+		Type MatchType
+		ID   int
+	}
+	var results []result
+	for _, m := range got {
+		results = append(results, result{m.Type, m.ID})
+	}
+
+	// equal distances are ordered by type, and the destroyed image is
+	// replaced by the next match
+	assert.Equal(t, []result{
+		{MatchTypePerformerImage, 1},
+		{MatchTypeSceneCover, 4},
+		{MatchTypeSceneCover, 3},
+	}, results)
+	assert.Equal(t, 1.0, got[0].Similarity)
+	assert.NotNil(t, got[0].Performer)
+	assert.NotNil(t, got[1].Scene)
+
+	db.AssertExpectations(t)
+}
+
+func TestSearch_Types(t *testing.T) {
+	ctx := context.Background()
+	img := testPNG(t)
+
+	db := mocks.NewDatabase()
+	r := Repository{
+		TxnManager: db,
+		Performer:  db.Performer,
+		Scene:      db.Scene,
+		Image:      db.Image,
+	}
+
+	db.Image.On("FindByFilePhash", mock.Anything, mock.Anything, MaxDistance).Return([]models.PhashMatch{{ID: 5, Distance: 12}}, nil)
+	db.Image.On("Find", mock.Anything, 5).Return(&models.Image{ID: 5}, nil)
+
+	got, err := Search(ctx, r, bytes.NewReader(img), Options{
+		Distance: 40,
+		Types:    []MatchType{MatchTypeImage},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, 0.8125, got[0].Similarity)
+	}
+
+	db.AssertExpectations(t)
+}
+
+func TestSearch_Limit(t *testing.T) {
+	ctx := context.Background()
+	img := testPNG(t)
+
+	db := mocks.NewDatabase()
+	r := Repository{
+		TxnManager: db,
+		Performer:  db.Performer,
+		Scene:      db.Scene,
+		Image:      db.Image,
+	}
+
+	db.Scene.On("FindMissingImagePhashes", mock.Anything, hashBatchSize).Return(nil, nil)
+	db.Scene.On("FindByImagePhash", mock.Anything, mock.Anything, DefaultDistance).Return([]models.PhashMatch{
+		{ID: 1, Distance: 0},
+		{ID: 2, Distance: 1},
+		{ID: 3, Distance: 2},
+	}, nil)
+
+	// scene 1 is not visible to the user, and scene 3 is beyond the limit
+	// so is not loaded
+	db.Scene.On("Find", mock.Anything, 1).Return(nil, nil)
+	db.Scene.On("Find", mock.Anything, 2).Return(&models.Scene{ID: 2}, nil)
+
+	got, err := Search(ctx, r, bytes.NewReader(img), Options{
+		Types: []MatchType{MatchTypeSceneCover},
+		Limit: 1,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, 2, got[0].ID)
+	}
+
+	db.AssertExpectations(t)
+}
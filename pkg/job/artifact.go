@@ -0,0 +1,189 @@
+package job
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// artifactTimeFormat is the format of the time prefix of artifact file names.
+const artifactTimeFormat = "20060102-150405"
+
+// Artifact is a file produced by a job, such as a report of its results.
+type Artifact struct {
+	// Name is the file name of the artifact in the reports directory.
+	Name      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// ArtifactConfig provides the settings of an ArtifactStore.
+type ArtifactConfig interface {
+	GetReportsDirectoryPathOrDefault() string
+	GetReportRetention() time.Duration
+}
+
+// ArtifactStore writes job artifacts to the reports directory, and removes
+// artifacts that are older than the retention period.
+type ArtifactStore struct {
+	Config ArtifactConfig
+}
+
+func NewArtifactStore(c ArtifactConfig) *ArtifactStore {
+	return &ArtifactStore{
+		Config: c,
+	}
+}
+
+// WriteJSON writes v as a JSON artifact of the job. The name is used as the
+// base of the file name.
+func (s *ArtifactStore) WriteJSON(progress *Progress, name string, v interface{}) error {
+	return s.write(progress, name+".json", func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	})
+}
+
+// WriteCSV writes the header and rows as a CSV artifact of the job. The name
+// is used as the base of the file name.
+func (s *ArtifactStore) WriteCSV(progress *Progress, name string, header []string, rows [][]string) error {
+	return s.write(progress, name+".csv", func(w io.Writer) error {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		return cw.WriteAll(rows)
+	})
+}
+
+func (s *ArtifactStore) write(progress *Progress, name string, fn func(w io.Writer) error) error {
+	dir := s.Config.GetReportsDirectoryPathOrDefault()
+	if err := fsutil.EnsureDirAll(dir); err != nil {
+		return fmt.Errorf("creating reports directory: %w", err)
+	}
+
+	now := time.Now()
+	prefix := now.Format(artifactTimeFormat)
+	if id := progress.jobID(); id != 0 {
+		prefix = fmt.Sprintf("%s-job%d", prefix, id)
+	}
+	fileName := prefix + "-" + name
+
+	f, err := os.OpenFile(filepath.Join(dir, fileName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("creating report %s: %w", fileName, err)
+	}
+
+	writeErr := fn(f)
+	closeErr := f.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("writing report %s: %w", fileName, err)
+	}
+
+	info, err := os.Stat(f.Name())
+	if err != nil {
+		return err
+	}
+
+	progress.AddArtifact(Artifact{
+		Name:      fileName,
+		Size:      info.Size(),
+		CreatedAt: now,
+	})
+
+	if err := s.Prune(); err != nil {
+		logger.Warnf("error removing old reports: %v", err)
+	}
+
+	return nil
+}
+
+// List returns the artifacts in the reports directory, newest first.
+func (s *ArtifactStore) List() ([]Artifact, error) {
+	entries, err := os.ReadDir(s.Config.GetReportsDirectoryPathOrDefault())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Artifact
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, Artifact{
+			Name:      e.Name(),
+			Size:      info.Size(),
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.After(ret[j].CreatedAt)
+	})
+
+	return ret, nil
+}
+
+// Path returns the path of the named artifact. Returns ErrArtifactNotFound if
+// the name is not the name of a file in the reports directory.
+func (s *ArtifactStore) Path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", ErrArtifactNotFound
+	}
+
+	ret := filepath.Join(s.Config.GetReportsDirectoryPathOrDefault(), name)
+	info, err := os.Stat(ret)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", ErrArtifactNotFound
+	}
+
+	return ret, nil
+}
+
+// Prune removes the artifacts that are older than the retention period.
+func (s *ArtifactStore) Prune() error {
+	retention := s.Config.GetReportRetention()
+	if retention <= 0 {
+		return nil
+	}
+
+	artifacts, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	dir := s.Config.GetReportsDirectoryPathOrDefault()
+	cutoff := time.Now().Add(-retention)
+	for _, a := range artifacts {
+		if a.CreatedAt.Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, a.Name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package job
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testArtifactConfig struct {
+	dir       string
+	retention time.Duration
+}
+
+func (c testArtifactConfig) GetReportsDirectoryPathOrDefault() string {
+	return c.dir
+}
+
+func (c testArtifactConfig) GetReportRetention() time.Duration {
+	return c.retention
+}
+
+func TestArtifactStoreWriteCSV(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	s := NewArtifactStore(testArtifactConfig{dir: dir})
+
+	m := NewManager()
+	j := &Job{ID: 3}
+	p := createProgress(m, j)
+
+	err := s.WriteCSV(&p, "clean", []string{"type", "path"}, [][]string{{"FILE", "/a, b.mp4"}})
+	if !assert.NoError(t, err) || !assert.Len(t, j.Artifacts, 1) {
+		return
+	}
+
+	a := j.Artifacts[0]
+	assert.Regexp(t, `^\d{8}-\d{6}-job3-clean\.csv$`, a.Name)
+
+	path, err := s.Path(a.Name)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "type,path\nFILE,\"/a, b.mp4\"\n", string(data))
+	assert.Equal(t, int64(len(data)), a.Size)
+
+	list, err := s.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestArtifactStorePath(t *testing.T) {
+	dir := t.TempDir()
+	s := NewArtifactStore(testArtifactConfig{dir: dir})
+
+	if err := os.WriteFile(filepath.Join(dir, "report.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"report.json", false},
+		{"missing.json", true},
+		{"sub", true},
+		{"../report.json", true},
+		{"sub/../report.json", true},
+		{"", true},
+		{"..", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Path(tt.name)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrArtifactNotFound)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestArtifactStorePrune(t *testing.T) {
+	dir := t.TempDir()
+	s := NewArtifactStore(testArtifactConfig{dir: dir, retention: 24 * time.Hour})
+
+	oldPath := filepath.Join(dir, "old.csv")
+	newPath := filepath.Join(dir, "new.csv")
+	for _, p := range []string{oldPath, newPath} {
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(oldPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, s.Prune())
+	assert.NoFileExists(t, oldPath)
+	assert.FileExists(t, newPath)
+}
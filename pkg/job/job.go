@@ -60,10 +60,14 @@ type Job struct {
 	EndTime   *time.Time
 	AddTime   time.Time
 	Error     *string
+	// files produced by the job
+	Artifacts []Artifact
 
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
+	// closed when the job has finished executing
+	done chan struct{}
 }
 
 // TimeElapsed returns the total time elapsed for the job.
@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -22,6 +23,10 @@ type Manager struct {
 	notEmpty *sync.Cond
 	stop     chan struct{}
 
+	// closed when the manager is shutting down
+	shutdown     chan struct{}
+	shuttingDown bool
+
 	lastID int
 
 	subscriptions       []*ManagerSubscription
@@ -32,6 +37,7 @@ type Manager struct {
 func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
+		shutdown:            make(chan struct{}),
 		updateThrottleLimit: defaultThrottleLimit,
 	}
 
@@ -119,6 +125,11 @@ func (m *Manager) nextID() int {
 
 func (m *Manager) getReadyJob() *Job {
 	// assumes lock held
+	// don't start new jobs while shutting down
+	if m.shuttingDown {
+		return nil
+	}
+
 	for _, j := range m.queue {
 		if j.Status == StatusReady {
 			return j
@@ -180,9 +191,11 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	j.Status = StatusRunning
 
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
+	ctx = context.WithValue(ctx, shutdownKey, m.shutdown)
 	j.cancelFunc = cancelFunc
 
 	done = make(chan struct{})
+	j.done = done
 	go m.executeJob(ctx, j, done)
 
 	m.notifyJobUpdate(j)
@@ -207,6 +220,15 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 
 	progress := m.newProgress(j)
 	if err := j.exec.Execute(ctx, progress); err != nil {
+		if errors.Is(err, ErrInterrupted) {
+			logger.Infof("job %d - %s interrupted by shutdown", j.ID, j.Description)
+
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			j.Status = StatusStopping
+			return
+		}
+
 		logger.Errorf("task failed due to error: %v", err)
 		j.error(err)
 	}
@@ -392,6 +414,14 @@ func (u *updater) notifyUpdate() {
 	u.updateTimer = nil
 }
 
+func (u *updater) addArtifact(a Artifact) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.Artifacts = append(u.job.Artifacts, a)
+	u.notifyUpdate()
+}
+
 func (u *updater) updateProgress(progress float64, details []string) {
 	u.m.mutex.Lock()
 	defer u.m.mutex.Unlock()
@@ -343,3 +343,47 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func TestShutdown(t *testing.T) {
+	m := NewManager()
+
+	// first job checkpoints and returns when the manager is shutting down
+	exec1 := MakeJobExec(func(ctx context.Context, p *Progress) error {
+		for !IsShuttingDown(ctx) {
+			time.Sleep(sleepTime)
+		}
+		return ErrInterrupted
+	})
+	exec2 := newTestExec(make(chan struct{}))
+
+	assert := assert.New(t)
+
+	assert.False(IsShuttingDown(context.Background()))
+
+	jobID1 := m.Add(context.Background(), "job 1", exec1)
+	jobID2 := m.Add(context.Background(), "job 2", exec2)
+
+	time.Sleep(sleepTime)
+
+	got := m.Shutdown(time.Second)
+
+	// both jobs should be returned in queue order
+	assert.Equal([]JobExec{exec1, exec2}, got)
+
+	// first job should be cancelled rather than failed
+	j := m.GetJob(jobID1)
+	assert.Equal(StatusCancelled, j.Status)
+	assert.Nil(j.Error)
+
+	// second job should never have started
+	select {
+	case <-exec2.started:
+		t.Error("job 2 was started during shutdown")
+	default:
+	}
+
+	assert.NotEqual(StatusRunning, m.GetJob(jobID2).Status)
+
+	// shutting down again should have no effect
+	assert.Nil(m.Shutdown(time.Second))
+}
@@ -174,3 +174,22 @@ func (p *Progress) ExecuteTask(description string, fn func()) {
 	defer p.removeTask(t)
 	fn()
 }
+
+// AddArtifact attaches an artifact to the job.
+func (p *Progress) AddArtifact(a Artifact) {
+	if p.updater == nil {
+		return
+	}
+
+	p.updater.addArtifact(a)
+}
+
+// jobID returns the id of the job, or 0 if the progress is not attached to a
+// job.
+func (p *Progress) jobID() int {
+	if p.updater == nil {
+		return 0
+	}
+
+	return p.updater.job.ID
+}
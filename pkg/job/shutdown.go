@@ -0,0 +1,135 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// shutdownCancelWait is the time to wait for running jobs to stop after they
+// have been cancelled during shutdown.
+const shutdownCancelWait = 5 * time.Second
+
+// ErrInterrupted should be returned by a JobExec that has stopped early
+// because the manager is shutting down. The job is marked as cancelled
+// rather than failed.
+var ErrInterrupted = errors.New("job interrupted by shutdown")
+
+type contextKey int
+
+const shutdownKey contextKey = iota
+
+// IsShuttingDown returns true if the job manager executing the job is
+// shutting down. Jobs should checkpoint their progress and return
+// ErrInterrupted as soon as possible.
+func IsShuttingDown(ctx context.Context) bool {
+	shutdown, _ := ctx.Value(shutdownKey).(chan struct{})
+	if shutdown == nil {
+		return false
+	}
+
+	select {
+	case <-shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithShutdownCancel returns a copy of ctx that is cancelled when the job
+// manager executing the job starts shutting down. This allows long-running
+// operations that only observe context cancellation to stop promptly.
+func WithShutdownCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	ret, cancel := context.WithCancel(ctx)
+
+	shutdown, _ := ctx.Value(shutdownKey).(chan struct{})
+	if shutdown != nil {
+		go func() {
+			select {
+			case <-shutdown:
+				cancel()
+			case <-ret.Done():
+			}
+		}()
+	}
+
+	return ret, cancel
+}
+
+// Shutdown stops the manager from starting new jobs and notifies running
+// jobs that the manager is shutting down. It waits up to gracePeriod for
+// running jobs to finish, after which they are cancelled. The dispatcher
+// thread is stopped once running jobs have stopped.
+//
+// Returns the executors of the jobs that were queued or interrupted, in queue
+// order. Jobs that were cancelled by the user before shutdown are not
+// included.
+func (m *Manager) Shutdown(gracePeriod time.Duration) []JobExec {
+	m.mutex.Lock()
+	if m.shuttingDown {
+		m.mutex.Unlock()
+		return nil
+	}
+
+	m.shuttingDown = true
+	close(m.shutdown)
+
+	var pending []*Job
+	var running []chan struct{}
+	for _, j := range m.queue {
+		switch j.Status {
+		case StatusReady:
+			pending = append(pending, j)
+		case StatusRunning:
+			pending = append(pending, j)
+			if j.done != nil {
+				running = append(running, j.done)
+			}
+		case StatusStopping:
+			// wait for jobs being cancelled by the user, but don't resume them
+			if j.done != nil {
+				running = append(running, j.done)
+			}
+		}
+	}
+	m.mutex.Unlock()
+
+	if !waitAll(running, gracePeriod) {
+		m.CancelAll()
+		waitAll(running, shutdownCancelWait)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var ret []JobExec
+	for _, j := range pending {
+		// jobs that ran to completion or failed are not resumed
+		if j.Status == StatusFinished || j.Status == StatusFailed {
+			continue
+		}
+		ret = append(ret, j.exec)
+	}
+
+	close(m.stop)
+	m.notEmpty.Broadcast()
+
+	return ret
+}
+
+// waitAll waits for all of the channels to be closed. Returns false if the
+// timeout elapsed first.
+func waitAll(chans []chan struct{}, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for _, c := range chans {
+		select {
+		case <-c:
+		case <-timer.C:
+			return false
+		}
+	}
+
+	return true
+}
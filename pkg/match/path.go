@@ -94,6 +94,12 @@ func nameMatchesPath(name, path string) int {
 	return regexpMatchesPath(re, path)
 }
 
+// NameMatchesText returns true if the name matches the text using the same
+// rules as the path matching functions.
+func NameMatchesText(name, text string) bool {
+	return nameMatchesPath(name, text) != -1
+}
+
 // nameToRegexp compiles a regexp pattern to match paths from the given name.
 // Set useUnicode to true if this regexp is to be used on any strings with unicode characters.
 func nameToRegexp(name string, useUnicode bool) *regexp.Regexp {
@@ -171,3 +171,49 @@ func ScrapedTag(ctx context.Context, qb models.TagQueryer, s *models.ScrapedTag)
 	s.StoredID = &id
 	return nil
 }
+
+// ScrapedTagCategory matches the category of the provided tag with the tag
+// categories in the database and sets the CategoryID field if one is found.
+// If the category is not set and the tag name is namespaced, as in
+// "Location:Beach", and the namespace matches an existing category, then the
+// namespace is removed from the name and used as the category.
+func ScrapedTagCategory(ctx context.Context, qb models.TagCategoryFinder, s *models.ScrapedTag) error {
+	if s.CategoryID != nil {
+		return nil
+	}
+
+	if s.Category != nil && *s.Category != "" {
+		c, err := qb.FindByName(ctx, *s.Category)
+		if err != nil {
+			return err
+		}
+
+		if c != nil {
+			id := strconv.Itoa(c.ID)
+			s.CategoryID = &id
+		}
+
+		return nil
+	}
+
+	category, name := tag.SplitCategory(s.Name)
+	if category == "" {
+		return nil
+	}
+
+	c, err := qb.FindByName(ctx, category)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		// not a known namespace - leave the name as-is
+		return nil
+	}
+
+	id := strconv.Itoa(c.ID)
+	s.Name = name
+	s.Category = &c.Name
+	s.CategoryID = &id
+	return nil
+}
@@ -0,0 +1,308 @@
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// PathMapping replaces the From prefix of paths from another media manager
+// with To, for when the other application sees files at different locations.
+type PathMapping struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Options control which values are imported.
+type Options struct {
+	PathMappings []PathMapping
+	PlayCount    bool
+	ResumeTime   bool
+	Rating       bool
+	// Collections adds scenes to groups with the same name as their
+	// collections, creating the groups if necessary.
+	Collections bool
+	// Overwrite replaces existing resume times and ratings. By default,
+	// only empty values are set.
+	Overwrite bool
+}
+
+// MatchType describes how an item was matched to a file.
+type MatchType string
+
+const (
+	MatchTypeNone MatchType = ""
+	MatchTypePath MatchType = "PATH"
+	// the file was matched by a unique file with the same basename and size
+	MatchTypeBasenameSize MatchType = "BASENAME_SIZE"
+)
+
+// Report summarises the results of an import.
+type Report struct {
+	Type      Type      `json:"type"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+
+	Total          int `json:"total"`
+	MatchedPath    int `json:"matched_path"`
+	MatchedSize    int `json:"matched_size"`
+	PlayCounts     int `json:"play_counts"`
+	ResumeTimes    int `json:"resume_times"`
+	Ratings        int `json:"ratings"`
+	GroupsAssigned int `json:"groups_assigned"`
+
+	// Paths of items that could not be matched to a scene
+	Unmatched []string `json:"unmatched"`
+	// Paths of items that matched more than one file
+	Ambiguous []string `json:"ambiguous"`
+}
+
+type FileFinder interface {
+	FindByPath(ctx context.Context, path string) (models.File, error)
+	FindByBasenameAndSize(ctx context.Context, basename string, size int64) ([]models.File, error)
+}
+
+type SceneFinderUpdater interface {
+	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Scene, error)
+	CountViews(ctx context.Context, id int) (int, error)
+	models.SceneGroupLoader
+	AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error)
+	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
+	UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error)
+}
+
+type GroupFinderCreator interface {
+	FindByName(ctx context.Context, name string, nocase bool) (*models.Group, error)
+	models.GroupCreator
+}
+
+// Importer applies items from another media manager to matching scenes.
+type Importer struct {
+	File    FileFinder
+	Scene   SceneFinderUpdater
+	Group   GroupFinderCreator
+	Options Options
+
+	Report *Report
+}
+
+// MapPath applies the first matching path mapping to the path, and
+// converts it to use the native path separator.
+func MapPath(mappings []PathMapping, path string) string {
+	for _, m := range mappings {
+		if m.From != "" && strings.HasPrefix(path, m.From) {
+			path = m.To + strings.TrimPrefix(path, m.From)
+			break
+		}
+	}
+
+	return filepath.FromSlash(path)
+}
+
+// ImportItem applies the item to its matching scenes. Items without any
+// watch state are ignored. Must be called within a write transaction.
+func (i *Importer) ImportItem(ctx context.Context, item Item) error {
+	if !item.hasState() {
+		return nil
+	}
+
+	i.Report.Total++
+
+	f, err := i.match(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	if f == nil {
+		return nil
+	}
+
+	scenes, err := i.Scene.FindByFileID(ctx, f.Base().ID)
+	if err != nil {
+		return fmt.Errorf("finding scenes for %s: %w", f.Base().Path, err)
+	}
+
+	if len(scenes) == 0 {
+		i.Report.Unmatched = append(i.Report.Unmatched, item.Path)
+		return nil
+	}
+
+	for _, s := range scenes {
+		if err := i.applyToScene(ctx, s, item); err != nil {
+			return fmt.Errorf("updating scene %d: %w", s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// match returns the file matching the item, or nil if none or more than
+// one file matches.
+func (i *Importer) match(ctx context.Context, item Item) (models.File, error) {
+	path := MapPath(i.Options.PathMappings, item.Path)
+
+	f, err := i.File.FindByPath(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("finding file %s: %w", path, err)
+	}
+
+	if f != nil {
+		i.Report.MatchedPath++
+		return f, nil
+	}
+
+	if item.Size > 0 {
+		// the other application may use a different separator
+		basename := item.Path[strings.LastIndexAny(item.Path, `/\`)+1:]
+
+		files, err := i.File.FindByBasenameAndSize(ctx, basename, item.Size)
+		if err != nil {
+			return nil, fmt.Errorf("finding file %s: %w", basename, err)
+		}
+
+		switch len(files) {
+		case 0:
+		case 1:
+			i.Report.MatchedSize++
+			return files[0], nil
+		default:
+			i.Report.Ambiguous = append(i.Report.Ambiguous, item.Path)
+			return nil, nil
+		}
+	}
+
+	i.Report.Unmatched = append(i.Report.Unmatched, item.Path)
+	return nil, nil
+}
+
+func (i *Importer) applyToScene(ctx context.Context, s *models.Scene, item Item) error {
+	o := i.Options
+
+	if o.PlayCount && item.PlayCount > 0 {
+		if err := i.applyPlayCount(ctx, s, item); err != nil {
+			return err
+		}
+	}
+
+	if o.ResumeTime && item.ResumeTime > 0 && (o.Overwrite || s.ResumeTime == 0) && item.ResumeTime != s.ResumeTime {
+		if _, err := i.Scene.SaveActivity(ctx, s.ID, &item.ResumeTime, nil); err != nil {
+			return err
+		}
+		i.Report.ResumeTimes++
+	}
+
+	partial := models.NewScenePartial()
+	changed := false
+
+	if o.Rating && item.Rating != nil && (o.Overwrite || s.Rating == nil) {
+		if s.Rating == nil || *s.Rating != *item.Rating {
+			partial.Rating = models.NewOptionalInt(*item.Rating)
+			changed = true
+			i.Report.Ratings++
+		}
+	}
+
+	if o.Collections && len(item.Collections) > 0 {
+		groupIDs, err := i.newGroupIDs(ctx, s, item.Collections)
+		if err != nil {
+			return err
+		}
+
+		if len(groupIDs) > 0 {
+			partial.GroupIDs = &models.UpdateGroupIDs{
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+			for _, id := range groupIDs {
+				partial.GroupIDs.Groups = append(partial.GroupIDs.Groups, models.GroupsScenes{GroupID: id})
+			}
+			changed = true
+			i.Report.GroupsAssigned += len(groupIDs)
+		}
+	}
+
+	if changed {
+		if _, err := i.Scene.UpdatePartial(ctx, s.ID, partial); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyPlayCount adds views so that the play count of the scene is at
+// least the play count of the item. Added views are dated at the last
+// played time of the item.
+func (i *Importer) applyPlayCount(ctx context.Context, s *models.Scene, item Item) error {
+	count, err := i.Scene.CountViews(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+
+	missing := item.PlayCount - count
+	if missing <= 0 {
+		return nil
+	}
+
+	date := time.Now()
+	if item.LastPlayed != nil {
+		date = *item.LastPlayed
+	}
+
+	dates := make([]time.Time, missing)
+	for j := range dates {
+		dates[j] = date
+	}
+
+	if _, err := i.Scene.AddViews(ctx, s.ID, dates); err != nil {
+		return err
+	}
+
+	i.Report.PlayCounts++
+	return nil
+}
+
+// newGroupIDs returns the IDs of the groups named after the collections
+// that the scene is not already in, creating groups as needed.
+func (i *Importer) newGroupIDs(ctx context.Context, s *models.Scene, collections []string) ([]int, error) {
+	if err := s.LoadGroups(ctx, i.Scene); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[int]bool)
+	for _, g := range s.Groups.List() {
+		existing[g.GroupID] = true
+	}
+
+	var ret []int
+	for _, name := range collections {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		g, err := i.Group.FindByName(ctx, name, true)
+		if err != nil {
+			return nil, fmt.Errorf("finding group %s: %w", name, err)
+		}
+
+		if g == nil {
+			newGroup := models.NewGroup()
+			newGroup.Name = name
+			if err := i.Group.Create(ctx, &newGroup); err != nil {
+				return nil, fmt.Errorf("creating group %s: %w", name, err)
+			}
+			g = &newGroup
+		}
+
+		if !existing[g.ID] {
+			existing[g.ID] = true
+			ret = append(ret, g.ID)
+		}
+	}
+
+	return ret, nil
+}
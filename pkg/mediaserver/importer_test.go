@@ -0,0 +1,136 @@
+package mediaserver
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestMapPath(t *testing.T) {
+	mappings := []PathMapping{
+		{From: "/data/media", To: "/stash"},
+		{From: "/data", To: "/other"},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"first mapping", "/data/media/a.mp4", "/stash/a.mp4"},
+		{"second mapping", "/data/b.mp4", "/other/b.mp4"},
+		{"no mapping", "/elsewhere/c.mp4", "/elsewhere/c.mp4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, filepath.FromSlash(tt.want), MapPath(mappings, tt.path))
+		})
+	}
+}
+
+func TestRatingFromTen(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	assert.Nil(t, ratingFromTen(0))
+	assert.Equal(t, intPtr(80), ratingFromTen(8))
+	assert.Equal(t, intPtr(75), ratingFromTen(7.5))
+	assert.Equal(t, intPtr(100), ratingFromTen(11))
+}
+
+func TestParseDBTime(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+
+	assert.Equal(t, want.Unix(), parseDBTime(want.Unix()).Unix())
+	assert.Equal(t, want, *parseDBTime("2024-01-02 03:04:05"))
+	assert.Equal(t, want, *parseDBTime([]byte("2024-01-02 03:04:05")))
+	assert.Nil(t, parseDBTime(nil))
+	assert.Nil(t, parseDBTime("invalid"))
+}
+
+func TestImporter_ImportItem(t *testing.T) {
+	const (
+		sceneID  = 1
+		fileID   = 2
+		groupID  = 3
+		newGroup = 4
+	)
+
+	rating := 80
+	lastPlayed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := &models.VideoFile{BaseFile: &models.BaseFile{ID: fileID, Path: "/stash/a.mp4"}}
+
+	db := mocks.NewDatabase()
+	ctx := context.Background()
+
+	db.File.On("FindByPath", ctx, filepath.FromSlash("/stash/a.mp4")).Return(f, nil).Once()
+	db.Scene.On("FindByFileID", ctx, models.FileID(fileID)).Return([]*models.Scene{{ID: sceneID, ResumeTime: 10}}, nil).Once()
+	db.Scene.On("CountViews", ctx, sceneID).Return(1, nil).Once()
+	db.Scene.On("AddViews", ctx, sceneID, []time.Time{lastPlayed, lastPlayed}).Return(nil, nil).Once()
+	db.Scene.On("GetGroups", ctx, sceneID).Return([]models.GroupsScenes{{GroupID: groupID}}, nil).Once()
+	db.Group.On("FindByName", ctx, "Existing", true).Return(&models.Group{ID: groupID, Name: "Existing"}, nil).Once()
+	db.Group.On("FindByName", ctx, "New", true).Return(nil, nil).Once()
+	db.Group.On("Create", ctx, mock.MatchedBy(func(g *models.Group) bool {
+		return g.Name == "New"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Group).ID = newGroup
+	}).Return(nil).Once()
+	db.Scene.On("UpdatePartial", ctx, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.Rating.Value == rating &&
+			len(p.GroupIDs.Groups) == 1 && p.GroupIDs.Groups[0].GroupID == newGroup
+	})).Return(nil, nil).Once()
+
+	// unmatched item
+	db.File.On("FindByPath", ctx, filepath.FromSlash("/stash/b.mp4")).Return(nil, nil).Once()
+	db.File.On("FindByBasenameAndSize", ctx, "b.mp4", int64(100)).Return(nil, nil).Once()
+
+	report := &Report{}
+	i := &Importer{
+		File:  db.File,
+		Scene: db.Scene,
+		Group: db.Group,
+		Options: Options{
+			PathMappings: []PathMapping{{From: `D:\media`, To: "/stash"}},
+			PlayCount:    true,
+			ResumeTime:   true,
+			Rating:       true,
+			Collections:  true,
+		},
+		Report: report,
+	}
+
+	items := []Item{
+		{
+			Path:        `D:\media/a.mp4`,
+			PlayCount:   3,
+			LastPlayed:  &lastPlayed,
+			ResumeTime:  20,
+			Rating:      &rating,
+			Collections: []string{"Existing", "New"},
+		},
+		{Path: `D:\media/b.mp4`, Size: 100, PlayCount: 1},
+		// no watch state
+		{Path: `D:\media/c.mp4`},
+	}
+
+	for _, item := range items {
+		assert.NoError(t, i.ImportItem(ctx, item))
+	}
+
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.MatchedPath)
+	assert.Equal(t, 1, report.PlayCounts)
+	// resume time is not overwritten by default
+	assert.Equal(t, 0, report.ResumeTimes)
+	assert.Equal(t, 1, report.Ratings)
+	assert.Equal(t, 1, report.GroupsAssigned)
+	assert.Equal(t, []string{`D:\media/b.mp4`}, report.Unmatched)
+
+	db.AssertExpectations(t)
+}
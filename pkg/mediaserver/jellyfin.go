@@ -0,0 +1,187 @@
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	jellyfinPageSize = 500
+	jellyfinTimeout  = 60 * time.Second
+	// playback positions are expressed in ticks of 100 nanoseconds
+	jellyfinTicksPerSecond = 10000000
+)
+
+// JellyfinReader reads watch state from a Jellyfin server using its API.
+type JellyfinReader struct {
+	// URL is the base URL of the server.
+	URL    string
+	APIKey string
+	// UserID is the ID of the user to read the watch state of.
+	UserID string
+
+	Client *http.Client
+}
+
+type jellyfinUserData struct {
+	PlaybackPositionTicks int64    `json:"PlaybackPositionTicks"`
+	PlayCount             int      `json:"PlayCount"`
+	Played                bool     `json:"Played"`
+	LastPlayedDate        string   `json:"LastPlayedDate"`
+	Rating                *float64 `json:"Rating"`
+}
+
+type jellyfinMediaSource struct {
+	Size int64 `json:"Size"`
+}
+
+type jellyfinItem struct {
+	ID           string                `json:"Id"`
+	Name         string                `json:"Name"`
+	Path         string                `json:"Path"`
+	UserData     *jellyfinUserData     `json:"UserData"`
+	MediaSources []jellyfinMediaSource `json:"MediaSources"`
+}
+
+type jellyfinItemsResult struct {
+	Items            []jellyfinItem `json:"Items"`
+	TotalRecordCount int            `json:"TotalRecordCount"`
+}
+
+func (r JellyfinReader) client() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+
+	return &http.Client{Timeout: jellyfinTimeout}
+}
+
+func (r JellyfinReader) Items(ctx context.Context) ([]Item, error) {
+	if r.URL == "" || r.APIKey == "" || r.UserID == "" {
+		return nil, fmt.Errorf("jellyfin url, api key and user id are required")
+	}
+
+	collections, err := r.collections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading collections: %w", err)
+	}
+
+	items, err := r.allItems(ctx, url.Values{
+		"IncludeItemTypes": {"Movie,Episode,Video,MusicVideo"},
+		"Fields":           {"Path,MediaSources"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading items: %w", err)
+	}
+
+	var ret []Item
+	for _, i := range items {
+		if i.Path == "" {
+			continue
+		}
+
+		item := Item{
+			Path:        i.Path,
+			Collections: collections[i.ID],
+		}
+
+		if len(i.MediaSources) > 0 {
+			item.Size = i.MediaSources[0].Size
+		}
+
+		if d := i.UserData; d != nil {
+			item.PlayCount = d.PlayCount
+			if d.Played && item.PlayCount == 0 {
+				item.PlayCount = 1
+			}
+			item.ResumeTime = float64(d.PlaybackPositionTicks) / jellyfinTicksPerSecond
+			if d.Rating != nil {
+				item.Rating = ratingFromTen(*d.Rating)
+			}
+			if t, err := time.Parse(time.RFC3339Nano, d.LastPlayedDate); err == nil {
+				item.LastPlayed = &t
+			}
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, nil
+}
+
+// collections returns the collection names of each item ID.
+func (r JellyfinReader) collections(ctx context.Context) (map[string][]string, error) {
+	boxSets, err := r.allItems(ctx, url.Values{
+		"IncludeItemTypes": {"BoxSet"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string][]string)
+	for _, b := range boxSets {
+		children, err := r.allItems(ctx, url.Values{
+			"ParentId": {b.ID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading collection %s: %w", b.Name, err)
+		}
+
+		for _, c := range children {
+			ret[c.ID] = append(ret[c.ID], b.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func (r JellyfinReader) allItems(ctx context.Context, params url.Values) ([]jellyfinItem, error) {
+	var ret []jellyfinItem
+	for {
+		params.Set("Recursive", "true")
+		params.Set("EnableImages", "false")
+		params.Set("StartIndex", strconv.Itoa(len(ret)))
+		params.Set("Limit", strconv.Itoa(jellyfinPageSize))
+
+		var page jellyfinItemsResult
+		if err := r.get(ctx, "/Users/"+url.PathEscape(r.UserID)+"/Items", params, &page); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, page.Items...)
+
+		if len(page.Items) == 0 || len(ret) >= page.TotalRecordCount {
+			return ret, nil
+		}
+	}
+}
+
+func (r JellyfinReader) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	u := strings.TrimRight(r.URL, "/") + path + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Emby-Token", r.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http error %d from %s", resp.StatusCode, path)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,75 @@
+package mediaserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// kodiResumeBookmarkType is the bookmark type of resume points in the Kodi database.
+const kodiResumeBookmarkType = 1
+
+// KodiReader reads watch state from a Kodi video database file (MyVideosNNN.db).
+type KodiReader struct {
+	DatabasePath string
+}
+
+func (r KodiReader) Items(ctx context.Context) ([]Item, error) {
+	db, err := openDB(r.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// file size is not stored by Kodi
+	const query = `
+SELECT p.strPath, f.strFilename, f.playCount, f.lastPlayed, b.timeInSeconds,
+  COALESCE(m.userrating, e.userrating), s.strSet
+FROM files f
+INNER JOIN path p ON p.idPath = f.idPath
+LEFT JOIN bookmark b ON b.idFile = f.idFile AND b.type = ?
+LEFT JOIN movie m ON m.idFile = f.idFile
+LEFT JOIN episode e ON e.idFile = f.idFile
+LEFT JOIN sets s ON s.idSet = m.idSet
+`
+
+	rows, err := db.QueryContext(ctx, query, kodiResumeBookmarkType)
+	if err != nil {
+		return nil, fmt.Errorf("reading files: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []Item
+	for rows.Next() {
+		var (
+			dir        string
+			filename   string
+			playCount  sql.NullInt64
+			lastPlayed interface{}
+			resume     sql.NullFloat64
+			rating     sql.NullFloat64
+			set        sql.NullString
+		)
+
+		if err := rows.Scan(&dir, &filename, &playCount, &lastPlayed, &resume, &rating, &set); err != nil {
+			return nil, err
+		}
+
+		item := Item{
+			// Kodi paths include the trailing separator
+			Path:       dir + filename,
+			PlayCount:  int(playCount.Int64),
+			LastPlayed: parseDBTime(lastPlayed),
+			ResumeTime: resume.Float64,
+			Rating:     ratingFromTen(rating.Float64),
+		}
+
+		if set.String != "" {
+			item.Collections = []string{set.String}
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, rows.Err()
+}
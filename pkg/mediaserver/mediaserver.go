@@ -0,0 +1,92 @@
+// Package mediaserver imports watch state from other media managers,
+// such as Plex, Jellyfin and Kodi.
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Type string
+
+const (
+	TypePlex     Type = "PLEX"
+	TypeJellyfin Type = "JELLYFIN"
+	TypeKodi     Type = "KODI"
+)
+
+var AllType = []Type{
+	TypePlex,
+	TypeJellyfin,
+	TypeKodi,
+}
+
+func (e Type) IsValid() bool {
+	switch e {
+	case TypePlex, TypeJellyfin, TypeKodi:
+		return true
+	}
+	return false
+}
+
+func (e Type) String() string {
+	return string(e)
+}
+
+func (e *Type) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Type(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MediaServerType", str)
+	}
+	return nil
+}
+
+func (e Type) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Item is the watch state of a single file in another media manager.
+type Item struct {
+	// Path of the file, as seen by the other media manager.
+	Path string
+	// Size of the file in bytes, or zero if unknown.
+	Size       int64
+	PlayCount  int
+	LastPlayed *time.Time
+	// ResumeTime is the resume point in seconds, or zero if not set.
+	ResumeTime float64
+	// Rating expressed in 1-100 scale, or nil if not rated.
+	Rating      *int
+	Collections []string
+}
+
+func (i Item) hasState() bool {
+	return i.PlayCount > 0 || i.ResumeTime > 0 || i.Rating != nil || len(i.Collections) > 0
+}
+
+// Reader reads the watch state of all files from another media manager.
+type Reader interface {
+	Items(ctx context.Context) ([]Item, error)
+}
+
+// ratingFromTen converts a rating in a 0-10 scale to a 1-100 scale.
+// Zero is treated as not rated.
+func ratingFromTen(v float64) *int {
+	if v <= 0 {
+		return nil
+	}
+
+	ret := int(v*10 + 0.5)
+	if ret > 100 {
+		ret = 100
+	}
+	return &ret
+}
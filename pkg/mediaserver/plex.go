@@ -0,0 +1,109 @@
+package mediaserver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// plexCollectionTagType is the tag type of collections in the Plex database.
+const plexCollectionTagType = 2
+
+// DefaultPlexAccountID is the account ID of the Plex server owner.
+const DefaultPlexAccountID = 1
+
+// PlexReader reads watch state from a Plex Media Server database file
+// (com.plexapp.plugins.library.db).
+type PlexReader struct {
+	DatabasePath string
+	// AccountID is the Plex account to read the watch state of.
+	AccountID int
+}
+
+func (r PlexReader) Items(ctx context.Context) ([]Item, error) {
+	db, err := openDB(r.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	collections, err := r.collections(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("reading collections: %w", err)
+	}
+
+	const query = `
+SELECT mi.id, mp.file, mp.size, mis.view_count, mis.view_offset, mis.rating, mis.last_viewed_at
+FROM media_parts mp
+INNER JOIN media_items m ON m.id = mp.media_item_id
+INNER JOIN metadata_items mi ON mi.id = m.metadata_item_id
+LEFT JOIN metadata_item_settings mis ON mis.guid = mi.guid AND mis.account_id = ?
+WHERE mp.file IS NOT NULL AND mp.file != ''
+`
+
+	rows, err := db.QueryContext(ctx, query, r.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("reading media parts: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []Item
+	for rows.Next() {
+		var (
+			metadataID int
+			path       string
+			size       sql.NullInt64
+			viewCount  sql.NullInt64
+			viewOffset sql.NullInt64
+			rating     sql.NullFloat64
+			lastViewed interface{}
+		)
+
+		if err := rows.Scan(&metadataID, &path, &size, &viewCount, &viewOffset, &rating, &lastViewed); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, Item{
+			Path:       path,
+			Size:       size.Int64,
+			PlayCount:  int(viewCount.Int64),
+			LastPlayed: parseDBTime(lastViewed),
+			// view offset is stored in milliseconds
+			ResumeTime:  float64(viewOffset.Int64) / 1000,
+			Rating:      ratingFromTen(rating.Float64),
+			Collections: collections[metadataID],
+		})
+	}
+
+	return ret, rows.Err()
+}
+
+// collections returns the collection names of each metadata item.
+func (r PlexReader) collections(ctx context.Context, db *sql.DB) (map[int][]string, error) {
+	const query = `
+SELECT tg.metadata_item_id, t.tag
+FROM taggings tg
+INNER JOIN tags t ON t.id = tg.tag_id
+WHERE t.tag_type = ?
+`
+
+	rows, err := db.QueryContext(ctx, query, plexCollectionTagType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make(map[int][]string)
+	for rows.Next() {
+		var (
+			id   int
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		ret[id] = append(ret[id], name)
+	}
+
+	return ret, rows.Err()
+}
@@ -0,0 +1,69 @@
+package mediaserver
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	// registers the sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var dbTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// openDB opens the sqlite database of another media manager in read-only mode.
+func openDB(path string) (*sql.DB, error) {
+	// build the dsn as a url so that characters such as ? and # in the
+	// path are escaped
+	dsn := url.URL{Scheme: "file", Path: path, RawQuery: "mode=ro"}
+	db, err := sql.Open("sqlite3", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("opening database %s: %w", path, err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening database %s: %w", path, err)
+	}
+
+	return db, nil
+}
+
+// parseDBTime parses a time column, which may be stored as a unix
+// timestamp or as text depending on the application and version.
+func parseDBTime(v interface{}) *time.Time {
+	var s string
+	switch t := v.(type) {
+	case int64:
+		if t <= 0 {
+			return nil
+		}
+		ret := time.Unix(t, 0)
+		return &ret
+	case time.Time:
+		return &t
+	case []byte:
+		s = string(t)
+	case string:
+		s = t
+	default:
+		return nil
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return parseDBTime(i)
+	}
+
+	for _, f := range dbTimeFormats {
+		if ret, err := time.ParseInLocation(f, s, time.Local); err == nil {
+			return &ret
+		}
+	}
+
+	return nil
+}
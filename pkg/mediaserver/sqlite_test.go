@@ -0,0 +1,49 @@
+package mediaserver
+
+import (
+	"database/sql"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenDB(t *testing.T) {
+	// characters that are special in urls must not be treated as part of
+	// the query string
+	dir := filepath.Join(t.TempDir(), "media #1?")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "library.db")
+
+	dsn := url.URL{Scheme: "file", Path: path, RawQuery: "mode=rwc"}
+	rw, err := sql.Open("sqlite3", dsn.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rw.Exec("CREATE TABLE items (name TEXT); INSERT INTO items VALUES ('item')"); err != nil {
+		t.Fatal(err)
+	}
+	rw.Close()
+
+	db, err := openDB(path)
+	if err != nil {
+		t.Fatalf("openDB() error = %v", err)
+	}
+	defer db.Close()
+
+	var name string
+	if assert.NoError(t, db.QueryRow("SELECT name FROM items").Scan(&name)) {
+		assert.Equal(t, "item", name)
+	}
+
+	// the database is opened read-only
+	_, err = db.Exec("INSERT INTO items VALUES ('other')")
+	assert.Error(t, err)
+
+	_, err = openDB(filepath.Join(dir, "missing.db"))
+	assert.Error(t, err)
+}
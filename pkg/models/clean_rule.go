@@ -0,0 +1,15 @@
+package models
+
+// CleanRule is a rule applied by the clean task to the files within Path.
+// Files that do not satisfy the rule are removed from the library.
+type CleanRule struct {
+	Path string `json:"path"`
+	// Files smaller than this many bytes are cleaned
+	MinSize *int64 `json:"min_size"`
+	// Files larger than this many bytes are cleaned
+	MaxSize *int64 `json:"max_size"`
+	// If set, files without one of these extensions are cleaned
+	Extensions []string `json:"extensions"`
+	// Files last modified more than this many days ago are cleaned
+	MaxAgeDays *int `json:"max_age_days"`
+}
@@ -6,15 +6,42 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-// Date wraps a time.Time with a format of "YYYY-MM-DD"
+// DatePrecision is the precision of a Date. Partial dates are stored as the
+// first day of the year or month.
+type DatePrecision int
+
+const (
+	DatePrecisionDay DatePrecision = iota
+	DatePrecisionMonth
+	DatePrecisionYear
+)
+
+// Date wraps a time.Time with a format of "YYYY-MM-DD", "YYYY-MM" or "YYYY",
+// depending on its precision.
 type Date struct {
 	time.Time
+	Precision DatePrecision
 }
 
-const dateFormat = "2006-01-02"
+const (
+	dateFormat      = "2006-01-02"
+	dateMonthFormat = "2006-01"
+	dateYearFormat  = "2006"
+)
+
+func (d DatePrecision) layout() string {
+	switch d {
+	case DatePrecisionMonth:
+		return dateMonthFormat
+	case DatePrecisionYear:
+		return dateYearFormat
+	default:
+		return dateFormat
+	}
+}
 
 func (d Date) String() string {
-	return d.Format(dateFormat)
+	return d.Format(d.Precision.layout())
 }
 
 func (d Date) After(o Date) bool {
@@ -22,10 +49,32 @@ func (d Date) After(o Date) bool {
 }
 
 // ParseDate uses utils.ParseDateStringAsTime to parse a string into a date.
+// Strings in the format "YYYY-MM" or "YYYY" are parsed as partial dates.
 func ParseDate(s string) (Date, error) {
 	ret, err := utils.ParseDateStringAsTime(s)
-	if err != nil {
-		return Date{}, err
+	if err == nil {
+		return Date{Time: ret}, nil
+	}
+
+	for _, p := range []DatePrecision{DatePrecisionMonth, DatePrecisionYear} {
+		if t, perr := time.Parse(p.layout(), s); perr == nil {
+			return Date{Time: t, Precision: p}, nil
+		}
+	}
+
+	return Date{}, err
+}
+
+// DatePrecisionOfLayout returns the precision of dates parsed with the
+// time layout. Layouts without a day are month precision, and layouts
+// without a month are year precision.
+func DatePrecisionOfLayout(layout string) DatePrecision {
+	t := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)
+	if t.Format(layout) != t.AddDate(0, 0, 1).Format(layout) {
+		return DatePrecisionDay
+	}
+	if t.Format(layout) != t.AddDate(0, 1, 0).Format(layout) {
+		return DatePrecisionMonth
 	}
-	return Date{Time: ret}, nil
+	return DatePrecisionYear
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		s             string
+		want          time.Time
+		wantPrecision DatePrecision
+		wantErr       bool
+	}{
+		{"2021-05-06", time.Date(2021, 5, 6, 0, 0, 0, 0, time.UTC), DatePrecisionDay, false},
+		{"2021-05", time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), DatePrecisionMonth, false},
+		{"2021", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), DatePrecisionYear, false},
+		{"05-2021", time.Time{}, DatePrecisionDay, true},
+		{"", time.Time{}, DatePrecisionDay, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.True(t, tt.want.Equal(got.Time))
+			assert.Equal(t, tt.wantPrecision, got.Precision)
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.s, got.String())
+			}
+		})
+	}
+}
+
+func TestDatePrecisionOfLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   DatePrecision
+	}{
+		{"2006-01-02", DatePrecisionDay},
+		{"Jan 2, 2006", DatePrecisionDay},
+		{"Monday 2006", DatePrecisionDay},
+		{"January 2006", DatePrecisionMonth},
+		{"01/2006", DatePrecisionMonth},
+		{"2006", DatePrecisionYear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			assert.Equal(t, tt.want, DatePrecisionOfLayout(tt.layout))
+		})
+	}
+}
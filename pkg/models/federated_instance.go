@@ -0,0 +1,10 @@
+package models
+
+// FederatedInstance is another stash instance that metadata can be pulled
+// from.
+type FederatedInstance struct {
+	Name string `json:"name"`
+	// Base URL of the instance, such as http://localhost:9999
+	URL    string `json:"url"`
+	APIKey string `json:"api_key"`
+}
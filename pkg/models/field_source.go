@@ -0,0 +1,164 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// FieldSourceType is the kind of source that set the value of a metadata field.
+type FieldSourceType string
+
+const (
+	// Set by the user
+	FieldSourceTypeManual FieldSourceType = "MANUAL"
+	// Set from a scraper
+	FieldSourceTypeScraper FieldSourceType = "SCRAPER"
+	// Set from a stash-box instance
+	FieldSourceTypeStashBox FieldSourceType = "STASH_BOX"
+	// Set from a federated stash instance
+	FieldSourceTypeFederated FieldSourceType = "FEDERATED"
+	// Read from files alongside the scene's file
+	FieldSourceTypeLocalFiles FieldSourceType = "LOCAL_FILES"
+	// Set by the auto tag task
+	FieldSourceTypeAutoTag FieldSourceType = "AUTO_TAG"
+	// Applied from the defaults of the scene's studio
+	FieldSourceTypeStudioDefaults FieldSourceType = "STUDIO_DEFAULTS"
+)
+
+func (e FieldSourceType) IsValid() bool {
+	switch e {
+	case FieldSourceTypeManual, FieldSourceTypeScraper, FieldSourceTypeStashBox, FieldSourceTypeFederated, FieldSourceTypeLocalFiles, FieldSourceTypeAutoTag, FieldSourceTypeStudioDefaults:
+		return true
+	}
+	return false
+}
+
+func (e FieldSourceType) String() string {
+	return string(e)
+}
+
+func (e *FieldSourceType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FieldSourceType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FieldSourceType", str)
+	}
+	return nil
+}
+
+func (e FieldSourceType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// FieldSource records where the current value of a metadata field of an
+// object came from.
+type FieldSource struct {
+	Field string          `json:"field"`
+	Type  FieldSourceType `json:"type"`
+	// Identifies the source, such as the scraper ID, stash-box endpoint or
+	// studio ID. Empty for manual edits and auto tagging.
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewFieldSources returns field sources for each of the fields, using the
+// current time.
+func NewFieldSources(fields []string, t FieldSourceType, source string) []*FieldSource {
+	now := time.Now()
+	ret := make([]*FieldSource, len(fields))
+	for i, f := range fields {
+		ret[i] = &FieldSource{
+			Field:     f,
+			Type:      t,
+			Source:    source,
+			UpdatedAt: now,
+		}
+	}
+
+	return ret
+}
+
+// fieldSourceNames maps input field names to the field names used for field
+// sources, where they differ.
+var fieldSourceNames = map[string]string{
+	"urls":          "url",
+	"typed_urls":    "url",
+	"twitter":       "url",
+	"instagram":     "url",
+	"studio_id":     "studio",
+	"performer_ids": "performers",
+	"tag_ids":       "tags",
+	"gallery_ids":   "galleries",
+	"group_ids":     "groups",
+	"movie_ids":     "groups",
+	"movies":        "groups",
+	"parent_id":     "parent",
+	"rating100":     "rating",
+	"alias_list":    "aliases",
+}
+
+// nonMetadataInputFields are input fields that are not recorded as field
+// sources.
+var nonMetadataInputFields = []string{
+	"id",
+	"ids",
+	"clientMutationId",
+	"expected_updated_at",
+	"file_ids",
+	"primary_file_id",
+	"organized",
+	"o_counter",
+	"play_count",
+	"play_duration",
+	"resume_time",
+	"custom_fields",
+	"default_tag_ids",
+	"default_urls",
+	"propagate_defaults",
+}
+
+// FieldSourceName returns the field source field name for the given
+// GraphQL input field name.
+func FieldSourceName(inputField string) string {
+	if ret, found := fieldSourceNames[inputField]; found {
+		return ret
+	}
+
+	return inputField
+}
+
+// FieldSourceNames returns the unique field source field names for the
+// given GraphQL input field names, excluding fields that are not metadata.
+func FieldSourceNames(inputFields []string) []string {
+	var ret []string
+	for _, f := range inputFields {
+		if slices.Contains(nonMetadataInputFields, f) {
+			continue
+		}
+
+		name := FieldSourceName(f)
+		if !slices.Contains(ret, name) {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
+
+type FieldSourceReader interface {
+	GetFieldSources(ctx context.Context, id int) ([]*FieldSource, error)
+}
+
+type FieldSourceWriter interface {
+	// SetFieldSources records the sources of the fields of the object,
+	// replacing the existing sources of the same fields.
+	SetFieldSources(ctx context.Context, id int, sources []*FieldSource) error
+}
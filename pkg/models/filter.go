@@ -144,6 +144,47 @@ func (i FloatCriterionInput) ValidModifier() bool {
 	return false
 }
 
+// MeasurementCriterionInput filters by a length or mass in the given units.
+type MeasurementCriterionInput struct {
+	Value    float64           `json:"value"`
+	Value2   *float64          `json:"value2"`
+	Modifier CriterionModifier `json:"modifier"`
+	// Defaults to METRIC
+	Units *MeasurementUnits `json:"units"`
+}
+
+func (i MeasurementCriterionInput) units() MeasurementUnits {
+	if i.Units == nil {
+		return MeasurementUnitsMetric
+	}
+	return *i.Units
+}
+
+func (i MeasurementCriterionInput) toIntCriterion(convert func(float64, MeasurementUnits) int) IntCriterionInput {
+	units := i.units()
+	ret := IntCriterionInput{
+		Value:    convert(i.Value, units),
+		Modifier: i.Modifier,
+	}
+	if i.Value2 != nil {
+		v := convert(*i.Value2, units)
+		ret.Value2 = &v
+	}
+	return ret
+}
+
+// LengthCriterion returns the criterion with values converted to the
+// nearest centimetre.
+func (i MeasurementCriterionInput) LengthCriterion() IntCriterionInput {
+	return i.toIntCriterion(LengthToCm)
+}
+
+// MassCriterion returns the criterion with values converted to the nearest
+// kilogram.
+func (i MeasurementCriterionInput) MassCriterion() IntCriterionInput {
+	return i.toIntCriterion(MassToKg)
+}
+
 type ResolutionCriterionInput struct {
 	Value    ResolutionEnum    `json:"value"`
 	Modifier CriterionModifier `json:"modifier"`
@@ -9,6 +9,11 @@ var (
 	FingerprintTypeOshash = "oshash"
 	FingerprintTypeMD5    = "md5"
 	FingerprintTypePhash  = "phash"
+	// FingerprintTypePhashCustom is a phash generated with non-default
+	// phash options. It is not comparable with phash fingerprints.
+	FingerprintTypePhashCustom = "phash_custom"
+	FingerprintTypeSHA256      = "sha256"
+	FingerprintTypeBLAKE3      = "blake3"
 )
 
 // Fingerprint represents a fingerprint of a file.
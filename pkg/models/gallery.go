@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type GalleryFilterType struct {
 	OperatorFilter[GalleryFilterType]
 	ID           *IntCriterionInput    `json:"id"`
@@ -7,6 +9,12 @@ type GalleryFilterType struct {
 	Code         *StringCriterionInput `json:"code"`
 	Details      *StringCriterionInput `json:"details"`
 	Photographer *StringCriterionInput `json:"photographer"`
+	// Filter by country
+	Country *StringCriterionInput `json:"country"`
+	// Filter by city
+	City *StringCriterionInput `json:"city"`
+	// Filter by location within a bounding box
+	Location *GeoBoundsInput `json:"location"`
 	// Filter by file checksum
 	Checksum *StringCriterionInput `json:"checksum"`
 	// Filter by path
@@ -21,6 +29,8 @@ type GalleryFilterType struct {
 	Rating100 *IntCriterionInput `json:"rating100"`
 	// Filter by organized
 	Organized *bool `json:"organized"`
+	// Filter by archived. Archived galleries are excluded if not set.
+	Archived *bool `json:"archived"`
 	// Filter by average image resolution
 	AverageResolution *ResolutionCriterionInput `json:"average_resolution"`
 	// Filter to only include scenes which have chapters. `true` or `false`
@@ -45,8 +55,16 @@ type GalleryFilterType struct {
 	PerformerAge *IntCriterionInput `json:"performer_age"`
 	// Filter by number of images in this gallery
 	ImageCount *IntCriterionInput `json:"image_count"`
+	// Filter by o-counter
+	OCounter *IntCriterionInput `json:"o_counter"`
+	// Filter by view count
+	ViewCount *IntCriterionInput `json:"view_count"`
+	// Filter to only include galleries with a reading session in progress
+	InProgress *bool `json:"in_progress"`
 	// Filter by url
 	URL *StringCriterionInput `json:"url"`
+	// Filter by urls of a type
+	TypedURL *TypedURLCriterionInput `json:"typed_url"`
 	// Filter by date
 	Date *DateCriterionInput `json:"date"`
 	// Filter by related scenes that meet this criteria
@@ -65,22 +83,41 @@ type GalleryFilterType struct {
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
 }
 
+// FiltersArchived returns true if the filter or one of its sub-filters filters
+// by the archived status. Archived galleries are excluded from queries otherwise.
+func (f *GalleryFilterType) FiltersArchived() bool {
+	if f == nil {
+		return false
+	}
+	return f.Archived != nil || f.SubFilter().FiltersArchived()
+}
+
 type GalleryUpdateInput struct {
-	ClientMutationID *string  `json:"clientMutationId"`
-	ID               string   `json:"id"`
-	Title            *string  `json:"title"`
-	Code             *string  `json:"code"`
-	Urls             []string `json:"urls"`
-	Date             *string  `json:"date"`
-	Details          *string  `json:"details"`
-	Photographer     *string  `json:"photographer"`
-	Rating100        *int     `json:"rating100"`
-	Organized        *bool    `json:"organized"`
-	SceneIds         []string `json:"scene_ids"`
-	StudioID         *string  `json:"studio_id"`
-	TagIds           []string `json:"tag_ids"`
-	PerformerIds     []string `json:"performer_ids"`
-	PrimaryFileID    *string  `json:"primary_file_id"`
+	ClientMutationID *string `json:"clientMutationId"`
+	ID               string  `json:"id"`
+	// ExpectedUpdatedAt is the updated at time of the object as last read by
+	// the client. If set and the object has since been modified, the update
+	// fails with a conflict error.
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at"`
+	Title             *string    `json:"title"`
+	Code              *string    `json:"code"`
+	Urls              []string   `json:"urls"`
+	TypedUrls         []TypedURL `json:"typed_urls"`
+	Date              *string    `json:"date"`
+	Details           *string    `json:"details"`
+	Photographer      *string    `json:"photographer"`
+	Country           *string    `json:"country"`
+	City              *string    `json:"city"`
+	Latitude          *float64   `json:"latitude"`
+	Longitude         *float64   `json:"longitude"`
+	Rating100         *int       `json:"rating100"`
+	Organized         *bool      `json:"organized"`
+	Archived          *bool      `json:"archived"`
+	SceneIds          []string   `json:"scene_ids"`
+	StudioID          *string    `json:"studio_id"`
+	TagIds            []string   `json:"tag_ids"`
+	PerformerIds      []string   `json:"performer_ids"`
+	PrimaryFileID     *string    `json:"primary_file_id"`
 
 	// deprecated
 	URL *string `json:"url"`
@@ -9,6 +9,7 @@ import (
 type GenerateMetadataOptions struct {
 	Covers                    bool                    `json:"covers"`
 	Sprites                   bool                    `json:"sprites"`
+	Barcodes                  bool                    `json:"barcodes"`
 	Previews                  bool                    `json:"previews"`
 	ImagePreviews             bool                    `json:"imagePreviews"`
 	PreviewOptions            *GeneratePreviewOptions `json:"previewOptions"`
@@ -17,9 +18,14 @@ type GenerateMetadataOptions struct {
 	MarkerScreenshots         bool                    `json:"markerScreenshots"`
 	Transcodes                bool                    `json:"transcodes"`
 	Phashes                   bool                    `json:"phashes"`
+	CustomPhashes             bool                    `json:"customPhashes"`
 	InteractiveHeatmapsSpeeds bool                    `json:"interactiveHeatmapsSpeeds"`
+	SceneHeatmaps             bool                    `json:"sceneHeatmaps"`
+	Transcripts               bool                    `json:"transcripts"`
 	ImageThumbnails           bool                    `json:"imageThumbnails"`
 	ClipPreviews              bool                    `json:"clipPreviews"`
+	ImagePhashes              bool                    `json:"imagePhashes"`
+	GalleryImagePhashes       bool                    `json:"galleryImagePhashes"`
 }
 
 type GeneratePreviewOptions struct {
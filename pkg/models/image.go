@@ -9,6 +9,12 @@ type ImageFilterType struct {
 	Code         *StringCriterionInput `json:"code"`
 	Details      *StringCriterionInput `json:"details"`
 	Photographer *StringCriterionInput `json:"photographer"`
+	// Filter by country
+	Country *StringCriterionInput `json:"country"`
+	// Filter by city
+	City *StringCriterionInput `json:"city"`
+	// Filter by location within a bounding box
+	Location *GeoBoundsInput `json:"location"`
 	// Filter by file checksum
 	Checksum *StringCriterionInput `json:"checksum"`
 	// Filter by path
@@ -23,12 +29,18 @@ type ImageFilterType struct {
 	URL *StringCriterionInput `json:"url"`
 	// Filter by organized
 	Organized *bool `json:"organized"`
+	// Filter by archived. Archived images are excluded if not set.
+	Archived *bool `json:"archived"`
 	// Filter by o-counter
 	OCounter *IntCriterionInput `json:"o_counter"`
+	// Filter by view count
+	ViewCount *IntCriterionInput `json:"view_count"`
 	// Filter by resolution
 	Resolution *ResolutionCriterionInput `json:"resolution"`
 	// Filter by landscape/portrait
 	Orientation *OrientationCriterionInput `json:"orientation"`
+	// Filter by files with contents not yet downloaded
+	PendingContent *bool `json:"pending_content"`
 	// Filter to only include images missing this property
 	IsMissing *string `json:"is_missing"`
 	// Filter to only include images with this studio
@@ -63,6 +75,15 @@ type ImageFilterType struct {
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
 }
 
+// FiltersArchived returns true if the filter or one of its sub-filters filters
+// by the archived status. Archived images are excluded from queries otherwise.
+func (f *ImageFilterType) FiltersArchived() bool {
+	if f == nil {
+		return false
+	}
+	return f.Archived != nil || f.SubFilter().FiltersArchived()
+}
+
 type ImageDestroyInput struct {
 	ID              string `json:"id"`
 	DeleteFile      *bool  `json:"delete_file"`
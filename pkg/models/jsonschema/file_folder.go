@@ -11,6 +11,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
@@ -86,6 +87,12 @@ type VideoFile struct {
 	FrameRate  float64 `json:"frame_rate,omitempty"`
 	BitRate    int64   `json:"bitrate,omitempty"`
 
+	BitDepth     int                  `json:"bit_depth,omitempty"`
+	AudioBitRate int64                `json:"audio_bit_rate,omitempty"`
+	HDRFormat    *models.HDRFormat    `json:"hdr_format,omitempty"`
+	VRProjection *models.VRProjection `json:"vr_projection,omitempty"`
+	StereoLayout *models.StereoLayout `json:"stereo_layout,omitempty"`
+
 	Interactive      bool `json:"interactive,omitempty"`
 	InteractiveSpeed *int `json:"interactive_speed,omitempty"`
 }
@@ -26,8 +26,13 @@ type Gallery struct {
 	Date         string           `json:"date,omitempty"`
 	Details      string           `json:"details,omitempty"`
 	Photographer string           `json:"photographer,omitempty"`
+	Country      string           `json:"country,omitempty"`
+	City         string           `json:"city,omitempty"`
+	Latitude     *float64         `json:"latitude,omitempty"`
+	Longitude    *float64         `json:"longitude,omitempty"`
 	Rating       int              `json:"rating,omitempty"`
 	Organized    bool             `json:"organized,omitempty"`
+	Archived     bool             `json:"archived,omitempty"`
 	Chapters     []GalleryChapter `json:"chapters,omitempty"`
 	Studio       string           `json:"studio,omitempty"`
 	Performers   []string         `json:"performers,omitempty"`
@@ -22,16 +22,33 @@ type Image struct {
 	Date         string        `json:"date,omitempty"`
 	Details      string        `json:"details,omitempty"`
 	Photographer string        `json:"photographer,omitempty"`
+	Country      string        `json:"country,omitempty"`
+	City         string        `json:"city,omitempty"`
+	Latitude     *float64      `json:"latitude,omitempty"`
+	Longitude    *float64      `json:"longitude,omitempty"`
 	Organized    bool          `json:"organized,omitempty"`
+	Archived     bool          `json:"archived,omitempty"`
 	OCounter     int           `json:"o_counter,omitempty"`
 	Galleries    []GalleryRef  `json:"galleries,omitempty"`
 	Performers   []string      `json:"performers,omitempty"`
 	Tags         []string      `json:"tags,omitempty"`
+	Regions      []ImageRegion `json:"regions,omitempty"`
 	Files        []string      `json:"files,omitempty"`
 	CreatedAt    json.JSONTime `json:"created_at,omitempty"`
 	UpdatedAt    json.JSONTime `json:"updated_at,omitempty"`
 }
 
+// ImageRegion is a region of an image. Coordinates are normalized to the
+// range 0-1. The tag and performer are referenced by name.
+type ImageRegion struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	Tag       string  `json:"tag,omitempty"`
+	Performer string  `json:"performer,omitempty"`
+}
+
 func (s Image) Filename(basename string, hash string) string {
 	ret := fsutil.SanitiseBasename(s.Title)
 	if ret == "" {
@@ -0,0 +1,13 @@
+package jsonschema
+
+import (
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+type Note struct {
+	Author    string        `json:"author,omitempty"`
+	Body      string        `json:"body"`
+	Pinned    bool          `json:"pinned,omitempty"`
+	CreatedAt json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt json.JSONTime `json:"updated_at,omitempty"`
+}
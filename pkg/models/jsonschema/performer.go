@@ -53,6 +53,7 @@ type Performer struct {
 	Piercings     string             `json:"piercings,omitempty"`
 	Aliases       StringOrStringList `json:"aliases,omitempty"`
 	Favorite      bool               `json:"favorite,omitempty"`
+	FavoritedAt   *json.JSONTime     `json:"favorited_at,omitempty"`
 	Tags          []string           `json:"tags,omitempty"`
 	Image         string             `json:"image,omitempty"`
 	CreatedAt     json.JSONTime      `json:"created_at,omitempty"`
@@ -64,6 +65,7 @@ type Performer struct {
 	Weight        int                `json:"weight,omitempty"`
 	StashIDs      []models.StashID   `json:"stash_ids,omitempty"`
 	IgnoreAutoTag bool               `json:"ignore_auto_tag,omitempty"`
+	Notes         []Note             `json:"notes,omitempty"`
 
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 
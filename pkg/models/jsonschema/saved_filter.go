@@ -11,6 +11,11 @@ type SavedFilter struct {
 	FindFilter   *models.FindFilterType `json:"find_filter"`
 	ObjectFilter map[string]interface{} `json:"object_filter"`
 	UIOptions    map[string]interface{} `json:"ui_options"`
+	// Folder is the names of the folder of the filter and its ancestors,
+	// starting with the top-level folder.
+	Folder    []string `json:"folder,omitempty"`
+	SortOrder int      `json:"sort_order,omitempty"`
+	IsDefault bool     `json:"is_default,omitempty"`
 }
 
 func (s SavedFilter) Filename() string {
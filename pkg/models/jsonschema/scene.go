@@ -12,12 +12,15 @@ import (
 )
 
 type SceneMarker struct {
-	Title      string        `json:"title,omitempty"`
-	Seconds    string        `json:"seconds,omitempty"`
-	PrimaryTag string        `json:"primary_tag,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Seconds      string        `json:"seconds,omitempty"`
+	Details      string        `json:"details,omitempty"`
+	Rating       int           `json:"rating,omitempty"`
+	CoverSeconds string        `json:"cover_seconds,omitempty"`
+	PrimaryTag   string        `json:"primary_tag,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	CreatedAt    json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime `json:"updated_at,omitempty"`
 }
 
 type SceneFile struct {
@@ -50,21 +53,30 @@ type Scene struct {
 	Date      string   `json:"date,omitempty"`
 	Rating    int      `json:"rating,omitempty"`
 	Organized bool     `json:"organized,omitempty"`
+	Pending   bool     `json:"pending,omitempty"`
+	Archived  bool     `json:"archived,omitempty"`
 
 	// deprecated - for import only
 	OCounter int `json:"o_counter,omitempty"`
 
-	Details    string        `json:"details,omitempty"`
-	Director   string        `json:"director,omitempty"`
-	Galleries  []GalleryRef  `json:"galleries,omitempty"`
-	Performers []string      `json:"performers,omitempty"`
-	Groups     []SceneGroup  `json:"movies,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	Markers    []SceneMarker `json:"markers,omitempty"`
-	Files      []string      `json:"files,omitempty"`
-	Cover      string        `json:"cover,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Details     string        `json:"details,omitempty"`
+	Director    string        `json:"director,omitempty"`
+	Country     string        `json:"country,omitempty"`
+	City        string        `json:"city,omitempty"`
+	Latitude    *float64      `json:"latitude,omitempty"`
+	Longitude   *float64      `json:"longitude,omitempty"`
+	StreamStart *float64      `json:"stream_start,omitempty"`
+	StreamEnd   *float64      `json:"stream_end,omitempty"`
+	Galleries   []GalleryRef  `json:"galleries,omitempty"`
+	Performers  []string      `json:"performers,omitempty"`
+	Groups      []SceneGroup  `json:"movies,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
+	Markers     []SceneMarker `json:"markers,omitempty"`
+	Notes       []Note        `json:"notes,omitempty"`
+	Files       []string      `json:"files,omitempty"`
+	Cover       string        `json:"cover,omitempty"`
+	CreatedAt   json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt   json.JSONTime `json:"updated_at,omitempty"`
 
 	// deprecated - for import only
 	LastPlayedAt json.JSONTime `json:"last_played_at,omitempty"`
@@ -12,18 +12,26 @@ import (
 
 type Studio struct {
 	Name          string           `json:"name,omitempty"`
-	URL           string           `json:"url,omitempty"`
+	URLs          []string         `json:"urls,omitempty"`
 	ParentStudio  string           `json:"parent_studio,omitempty"`
 	Image         string           `json:"image,omitempty"`
 	CreatedAt     json.JSONTime    `json:"created_at,omitempty"`
 	UpdatedAt     json.JSONTime    `json:"updated_at,omitempty"`
 	Rating        int              `json:"rating,omitempty"`
 	Favorite      bool             `json:"favorite,omitempty"`
+	FavoritedAt   *json.JSONTime   `json:"favorited_at,omitempty"`
 	Details       string           `json:"details,omitempty"`
 	Aliases       []string         `json:"aliases,omitempty"`
 	StashIDs      []models.StashID `json:"stash_ids,omitempty"`
 	Tags          []string         `json:"tags,omitempty"`
 	IgnoreAutoTag bool             `json:"ignore_auto_tag,omitempty"`
+
+	DefaultTags       []string `json:"default_tags,omitempty"`
+	DefaultURLs       []string `json:"default_urls,omitempty"`
+	PropagateDefaults bool     `json:"propagate_defaults,omitempty"`
+
+	// deprecated - for import only
+	URL string `json:"url,omitempty"`
 }
 
 func (s Studio) Filename() string {
@@ -10,15 +10,18 @@ import (
 )
 
 type Tag struct {
-	Name          string        `json:"name,omitempty"`
-	Description   string        `json:"description,omitempty"`
-	Favorite      bool          `json:"favorite,omitempty"`
-	Aliases       []string      `json:"aliases,omitempty"`
-	Image         string        `json:"image,omitempty"`
-	Parents       []string      `json:"parents,omitempty"`
-	IgnoreAutoTag bool          `json:"ignore_auto_tag,omitempty"`
-	CreatedAt     json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt     json.JSONTime `json:"updated_at,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Favorite      bool           `json:"favorite,omitempty"`
+	FavoritedAt   *json.JSONTime `json:"favorited_at,omitempty"`
+	Aliases       []string       `json:"aliases,omitempty"`
+	Image         string         `json:"image,omitempty"`
+	Parents       []string       `json:"parents,omitempty"`
+	IgnoreAutoTag bool           `json:"ignore_auto_tag,omitempty"`
+	Restricted    bool           `json:"restricted,omitempty"`
+	Category      string         `json:"category,omitempty"`
+	CreatedAt     json.JSONTime  `json:"created_at,omitempty"`
+	UpdatedAt     json.JSONTime  `json:"updated_at,omitempty"`
 }
 
 func (s Tag) Filename() string {
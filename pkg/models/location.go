@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrInvalidLatitude  = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude = errors.New("longitude must be between -180 and 180")
+)
+
+// ValidateCoordinates returns an error if the latitude or longitude are set
+// and out of range.
+func ValidateCoordinates(latitude *float64, longitude *float64) error {
+	if latitude != nil && (*latitude < -90 || *latitude > 90) {
+		return ErrInvalidLatitude
+	}
+	if longitude != nil && (*longitude < -180 || *longitude > 180) {
+		return ErrInvalidLongitude
+	}
+	return nil
+}
+
+// GeoBoundsInput is a latitude and longitude bounding box, inclusive.
+// A box crossing the antimeridian has MinLongitude greater than MaxLongitude.
+type GeoBoundsInput struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type GeoBounds struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type LocationClusterInput struct {
+	// Only objects within these bounds are clustered
+	Bounds *GeoBoundsInput `json:"bounds"`
+	// Size of the grid cells in degrees
+	CellSize float64 `json:"cell_size"`
+}
+
+// LocationCluster is a group of located objects that fall in the same grid
+// cell.
+type LocationCluster struct {
+	// Latitude and Longitude are the mean position of the objects
+	Latitude     float64   `json:"latitude"`
+	Longitude    float64   `json:"longitude"`
+	Bounds       GeoBounds `json:"bounds"`
+	SceneCount   int       `json:"scene_count"`
+	GalleryCount int       `json:"gallery_count"`
+	ImageCount   int       `json:"image_count"`
+}
+
+func (c LocationCluster) Count() int {
+	return c.SceneCount + c.GalleryCount + c.ImageCount
+}
+
+// LocationClusterer clusters located objects into grid cells of cellSize
+// degrees.
+type LocationClusterer interface {
+	LocationClusters(ctx context.Context, cellSize float64, bounds *GeoBoundsInput) ([]*LocationCluster, error)
+}
+
+type locationCell struct {
+	x, y int
+}
+
+func locationCellOf(c *LocationCluster, cellSize float64) locationCell {
+	return locationCell{
+		x: int(math.Floor((c.Longitude + 180) / cellSize)),
+		y: int(math.Floor((c.Latitude + 90) / cellSize)),
+	}
+}
+
+// MergeLocationClusters combines clusters of the same grid cell, such as the
+// clusters of scenes, galleries and images. The clusters are returned in
+// descending order of count.
+func MergeLocationClusters(cellSize float64, clusters ...[]*LocationCluster) []*LocationCluster {
+	var ret []*LocationCluster
+	byCell := make(map[locationCell]*LocationCluster)
+
+	for _, cc := range clusters {
+		for _, c := range cc {
+			cell := locationCellOf(c, cellSize)
+			existing := byCell[cell]
+			if existing == nil {
+				merged := *c
+				byCell[cell] = &merged
+				ret = append(ret, &merged)
+				continue
+			}
+
+			// weighted mean of the positions
+			n1 := float64(existing.Count())
+			n2 := float64(c.Count())
+			existing.Latitude = (existing.Latitude*n1 + c.Latitude*n2) / (n1 + n2)
+			existing.Longitude = (existing.Longitude*n1 + c.Longitude*n2) / (n1 + n2)
+
+			existing.Bounds.MinLatitude = math.Min(existing.Bounds.MinLatitude, c.Bounds.MinLatitude)
+			existing.Bounds.MaxLatitude = math.Max(existing.Bounds.MaxLatitude, c.Bounds.MaxLatitude)
+			existing.Bounds.MinLongitude = math.Min(existing.Bounds.MinLongitude, c.Bounds.MinLongitude)
+			existing.Bounds.MaxLongitude = math.Max(existing.Bounds.MaxLongitude, c.Bounds.MaxLongitude)
+
+			existing.SceneCount += c.SceneCount
+			existing.GalleryCount += c.GalleryCount
+			existing.ImageCount += c.ImageCount
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Count() > ret[j].Count()
+	})
+
+	return ret
+}
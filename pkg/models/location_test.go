@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLocationClusters(t *testing.T) {
+	scenes := []*LocationCluster{
+		{
+			Latitude:   10.5,
+			Longitude:  20.5,
+			Bounds:     GeoBounds{10.2, 10.8, 20.2, 20.8},
+			SceneCount: 1,
+		},
+		{
+			Latitude:   -10.5,
+			Longitude:  -20.5,
+			Bounds:     GeoBounds{-10.5, -10.5, -20.5, -20.5},
+			SceneCount: 1,
+		},
+	}
+	images := []*LocationCluster{
+		{
+			Latitude:   10.1,
+			Longitude:  20.1,
+			Bounds:     GeoBounds{10.1, 10.1, 20.1, 20.1},
+			ImageCount: 3,
+		},
+	}
+
+	got := MergeLocationClusters(1, scenes, images)
+
+	if !assert.Len(t, got, 2) {
+		return
+	}
+
+	assert.Equal(t, 4, got[0].Count())
+	assert.Equal(t, 1, got[0].SceneCount)
+	assert.Equal(t, 3, got[0].ImageCount)
+	assert.InDelta(t, 10.2, got[0].Latitude, 0.0001)
+	assert.InDelta(t, 20.2, got[0].Longitude, 0.0001)
+	assert.Equal(t, GeoBounds{10.1, 10.8, 20.1, 20.8}, got[0].Bounds)
+
+	assert.Equal(t, 1, got[1].Count())
+	assert.Equal(t, -10.5, got[1].Latitude)
+
+	// inputs are not modified
+	assert.Equal(t, 10.5, scenes[0].Latitude)
+}
@@ -0,0 +1,291 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	cmPerInch = 2.54
+	kgPerLb   = 0.45359237
+)
+
+var (
+	ErrInvalidHeight = errors.New("invalid height")
+	ErrInvalidWeight = errors.New("invalid weight")
+)
+
+// MeasurementUnits is the system of units used to input or output
+// performer measurements.
+type MeasurementUnits string
+
+const (
+	// Centimetres and kilograms
+	MeasurementUnitsMetric MeasurementUnits = "METRIC"
+	// Inches and pounds
+	MeasurementUnitsImperial MeasurementUnits = "IMPERIAL"
+)
+
+func (e MeasurementUnits) IsValid() bool {
+	switch e {
+	case MeasurementUnitsMetric, MeasurementUnitsImperial:
+		return true
+	}
+	return false
+}
+
+func (e MeasurementUnits) String() string {
+	return string(e)
+}
+
+func (e *MeasurementUnits) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MeasurementUnits(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MeasurementUnits", str)
+	}
+	return nil
+}
+
+func (e MeasurementUnits) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// LengthToCm converts a length in the given units to the nearest centimetre.
+func LengthToCm(v float64, units MeasurementUnits) int {
+	if units == MeasurementUnitsImperial {
+		v *= cmPerInch
+	}
+	return int(math.Round(v))
+}
+
+// MassToKg converts a mass in the given units to the nearest kilogram.
+func MassToKg(v float64, units MeasurementUnits) int {
+	if units == MeasurementUnitsImperial {
+		v *= kgPerLb
+	}
+	return int(math.Round(v))
+}
+
+func cmToInches(cm int) int {
+	return int(math.Round(float64(cm) / cmPerInch))
+}
+
+var (
+	heightMetricRE   = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(cm|m)?$`)
+	heightImperialRE = regexp.MustCompile(`^(\d+)\s*(?:'|’|ft|feet|foot)\s*(?:(\d+(?:\.\d+)?)\s*(?:"|”|''|in|inch|inches)?)?$`)
+	heightInchesRE   = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(?:"|”|''|in|inch|inches)$`)
+	weightRE         = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(kg|kgs|kilos?|kilograms?|lb|lbs|pounds?)?$`)
+)
+
+// normaliseMeasurement lowercases s, uses a decimal point and trims any
+// alternative value in brackets or following a slash, such as the imperial
+// value in "170 cm (5'7")".
+func normaliseMeasurement(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "(/"); i > 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return strings.ReplaceAll(s, ",", ".")
+}
+
+// ParseHeight parses a height string such as "170", "170 cm", "1.70m" or
+// 5'7", returning the height in centimetres. Values without units are in
+// centimetres, unless they are less than 3, in which case they are in metres.
+func ParseHeight(s string) (int, error) {
+	s = normaliseMeasurement(s)
+
+	if m := heightMetricRE.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		if m[2] == "m" || (m[2] == "" && v < 3) {
+			v *= 100
+		}
+		if v > 0 {
+			return int(math.Round(v)), nil
+		}
+	}
+
+	if m := heightImperialRE.FindStringSubmatch(s); m != nil {
+		feet, _ := strconv.ParseFloat(m[1], 64)
+		var inches float64
+		if m[2] != "" {
+			inches, _ = strconv.ParseFloat(m[2], 64)
+		}
+		if v := feet*12 + inches; v > 0 {
+			return LengthToCm(v, MeasurementUnitsImperial), nil
+		}
+	}
+
+	if m := heightInchesRE.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		if v > 0 {
+			return LengthToCm(v, MeasurementUnitsImperial), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidHeight, s)
+}
+
+// ParseWeight parses a weight string such as "60", "60kg" or "132 lbs",
+// returning the weight in kilograms. Values without units are in kilograms.
+func ParseWeight(s string) (int, error) {
+	s = normaliseMeasurement(s)
+
+	if m := weightRE.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		units := MeasurementUnitsMetric
+		if strings.HasPrefix(m[2], "lb") || strings.HasPrefix(m[2], "pound") {
+			units = MeasurementUnitsImperial
+		}
+		if v > 0 {
+			return MassToKg(v, units), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidWeight, s)
+}
+
+// FormatHeight formats a height in centimetres using the given units.
+func FormatHeight(cm int, units MeasurementUnits) string {
+	if units == MeasurementUnitsImperial {
+		inches := cmToInches(cm)
+		return fmt.Sprintf(`%d'%d"`, inches/12, inches%12)
+	}
+	return fmt.Sprintf("%d cm", cm)
+}
+
+// FormatWeight formats a weight in kilograms using the given units.
+func FormatWeight(kg int, units MeasurementUnits) string {
+	if units == MeasurementUnitsImperial {
+		return fmt.Sprintf("%d lb", int(math.Round(float64(kg)/kgPerLb)))
+	}
+	return fmt.Sprintf("%d kg", kg)
+}
+
+// Measurements are the body measurements parsed from the free-text
+// measurements of a performer. Lengths are in centimetres.
+type Measurements struct {
+	Bust    *int
+	CupSize string
+	Waist   *int
+	Hips    *int
+}
+
+func (m Measurements) IsEmpty() bool {
+	return m.Bust == nil && m.CupSize == "" && m.Waist == nil && m.Hips == nil
+}
+
+var (
+	measurementsSeparatorRE = regexp.MustCompile(`\s*[-–x]\s*`)
+	measurementRE           = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+|"|”|'')?$`)
+	cupSizeRE               = regexp.MustCompile(`^[a-k]+$`)
+)
+
+// the largest bust, waist or hips value that is assumed to be in inches
+// when measurements have no units
+const maxImperialMeasurement = 60
+
+// ParseMeasurements makes a best-effort attempt to parse measurements such
+// as "34B-24-36", "86C-61-91 cm" or "34DD". Lengths without units are assumed
+// to be in inches, unless any value is too large to be in inches. Parts that
+// cannot be parsed are left empty.
+func ParseMeasurements(s string) Measurements {
+	s = normaliseMeasurement(s)
+	if s == "" {
+		return Measurements{}
+	}
+
+	var (
+		ret    Measurements
+		units  MeasurementUnits
+		values []*float64
+	)
+
+	for i, part := range measurementsSeparatorRE.Split(s, 3) {
+		var v *float64
+
+		if m := measurementRE.FindStringSubmatch(part); m != nil {
+			f, _ := strconv.ParseFloat(m[1], 64)
+			if f > 0 {
+				v = &f
+			}
+
+			switch suffix := m[2]; {
+			case suffix == "cm":
+				units = MeasurementUnitsMetric
+			case suffix == "in" || suffix == "inch" || suffix == "inches" || suffix == `"` || suffix == "”" || suffix == "''":
+				units = MeasurementUnitsImperial
+			case i == 0 && cupSizeRE.MatchString(suffix):
+				ret.CupSize = strings.ToUpper(suffix)
+			}
+		} else if i == 0 && cupSizeRE.MatchString(part) {
+			// cup size only
+			ret.CupSize = strings.ToUpper(part)
+		}
+
+		values = append(values, v)
+	}
+
+	if units == "" {
+		units = MeasurementUnitsImperial
+		for _, v := range values {
+			if v != nil && *v > maxImperialMeasurement {
+				units = MeasurementUnitsMetric
+			}
+		}
+	}
+
+	toCm := func(v *float64) *int {
+		if v == nil {
+			return nil
+		}
+		cm := LengthToCm(*v, units)
+		return &cm
+	}
+
+	for i, v := range values {
+		switch i {
+		case 0:
+			ret.Bust = toCm(v)
+		case 1:
+			ret.Waist = toCm(v)
+		case 2:
+			ret.Hips = toCm(v)
+		}
+	}
+
+	return ret
+}
+
+// Format formats the measurements in the given units, for example
+// "34B-24-36". Missing values are omitted.
+func (m Measurements) Format(units MeasurementUnits) string {
+	format := func(cm *int) string {
+		if cm == nil {
+			return ""
+		}
+		if units == MeasurementUnitsImperial {
+			return strconv.Itoa(cmToInches(*cm))
+		}
+		return strconv.Itoa(*cm)
+	}
+
+	bust := format(m.Bust) + m.CupSize
+	waist := format(m.Waist)
+	hips := format(m.Hips)
+
+	if waist == "" && hips == "" {
+		return bust
+	}
+
+	return bust + "-" + waist + "-" + hips
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeight(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"170", 170, false},
+		{"170 cm", 170, false},
+		{"170cm (5'7\")", 170, false},
+		{"1.70m", 170, false},
+		{"1,70", 170, false},
+		{"5'7\"", 170, false},
+		{"5 ft 7 in", 170, false},
+		{"5'", 152, false},
+		{"67 inches", 170, false},
+		{"", 0, true},
+		{"tall", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseHeight(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHeight() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"60", 60, false},
+		{"60kg", 60, false},
+		{"60.4 kgs", 60, false},
+		{"132 lbs", 60, false},
+		{"132 pounds", 60, false},
+		{"", 0, true},
+		{"light", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWeight(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWeight() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseMeasurements(t *testing.T) {
+	i := func(v int) *int {
+		return &v
+	}
+
+	tests := []struct {
+		input string
+		want  Measurements
+	}{
+		{"34B-24-36", Measurements{Bust: i(86), CupSize: "B", Waist: i(61), Hips: i(91)}},
+		{"86C-61-91", Measurements{Bust: i(86), CupSize: "C", Waist: i(61), Hips: i(91)}},
+		{"32dd - 25 - 35 in", Measurements{Bust: i(81), CupSize: "DD", Waist: i(64), Hips: i(89)}},
+		{"34DD", Measurements{Bust: i(86), CupSize: "DD"}},
+		{"D", Measurements{CupSize: "D"}},
+		{"34-?-36", Measurements{Bust: i(86), Hips: i(91)}},
+		{"", Measurements{}},
+		{"natural", Measurements{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMeasurements(tt.input))
+		})
+	}
+}
+
+func TestMeasurements_Format(t *testing.T) {
+	m := ParseMeasurements("34B-24-36")
+
+	assert.Equal(t, "86B-61-91", m.Format(MeasurementUnitsMetric))
+	assert.Equal(t, "34B-24-36", m.Format(MeasurementUnitsImperial))
+	assert.Equal(t, "34DD", ParseMeasurements("34DD").Format(MeasurementUnitsImperial))
+}
+
+func TestFormatHeight(t *testing.T) {
+	assert.Equal(t, "170 cm", FormatHeight(170, MeasurementUnitsMetric))
+	assert.Equal(t, `5'7"`, FormatHeight(170, MeasurementUnitsImperial))
+	assert.Equal(t, "60 kg", FormatWeight(60, MeasurementUnitsMetric))
+	assert.Equal(t, "132 lb", FormatWeight(60, MeasurementUnitsImperial))
+}
+
+func TestMeasurementCriterionInput_LengthCriterion(t *testing.T) {
+	imperial := MeasurementUnitsImperial
+	value2 := 69.0
+
+	c := MeasurementCriterionInput{
+		Value:    63,
+		Value2:   &value2,
+		Modifier: CriterionModifierBetween,
+		Units:    &imperial,
+	}
+
+	got := c.LengthCriterion()
+	assert.Equal(t, 160, got.Value)
+	assert.Equal(t, 175, *got.Value2)
+	assert.Equal(t, CriterionModifierBetween, got.Modifier)
+
+	c.Units = nil
+	assert.Equal(t, 63, c.LengthCriterion().Value)
+}
@@ -4,11 +4,10 @@ package mocks
 
 import (
 	context "context"
-	fs "io/fs"
-
-	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+	fs "io/fs"
 )
 
 // FileReaderWriter is an autogenerated mock type for the FileReaderWriter type
@@ -176,6 +175,29 @@ func (_m *FileReaderWriter) FindAllInPaths(ctx context.Context, p []string, limi
 	return r0, r1
 }
 
+// FindByBasenameAndSize provides a mock function with given fields: ctx, basename, size
+func (_m *FileReaderWriter) FindByBasenameAndSize(ctx context.Context, basename string, size int64) ([]models.File, error) {
+	ret := _m.Called(ctx, basename, size)
+
+	var r0 []models.File
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []models.File); ok {
+		r0 = rf(ctx, basename, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.File)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, basename, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByFileInfo provides a mock function with given fields: ctx, info, size
 func (_m *FileReaderWriter) FindByFileInfo(ctx context.Context, info fs.FileInfo, size int64) ([]models.File, error) {
 	ret := _m.Called(ctx, info, size)
@@ -268,6 +290,29 @@ func (_m *FileReaderWriter) FindByZipFileID(ctx context.Context, zipFileID model
 	return r0, r1
 }
 
+// FindIdenticalFiles provides a mock function with given fields: ctx
+func (_m *FileReaderWriter) FindIdenticalFiles(ctx context.Context) ([][]models.FileID, error) {
+	ret := _m.Called(ctx)
+
+	var r0 [][]models.FileID
+	if rf, ok := ret.Get(0).(func(context.Context) [][]models.FileID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]models.FileID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCaptions provides a mock function with given fields: ctx, fileID
 func (_m *FileReaderWriter) GetCaptions(ctx context.Context, fileID models.FileID) ([]*models.VideoCaption, error) {
 	ret := _m.Called(ctx, fileID)
@@ -349,6 +394,27 @@ func (_m *FileReaderWriter) Query(ctx context.Context, options models.FileQueryO
 	return r0, r1
 }
 
+// SizeAllInPaths provides a mock function with given fields: ctx, p
+func (_m *FileReaderWriter) SizeAllInPaths(ctx context.Context, p []string) (int64, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, f
 func (_m *FileReaderWriter) Update(ctx context.Context, f models.File) error {
 	ret := _m.Called(ctx, f)
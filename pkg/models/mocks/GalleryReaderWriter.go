@@ -7,6 +7,8 @@ import (
 
 	models "github.com/stashapp/stash/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // GalleryReaderWriter is an autogenerated mock type for the GalleryReaderWriter type
@@ -49,6 +51,52 @@ func (_m *GalleryReaderWriter) AddImages(ctx context.Context, galleryID int, ima
 	return r0
 }
 
+// AddO provides a mock function with given fields: ctx, id, dates
+func (_m *GalleryReaderWriter) AddO(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddViews provides a mock function with given fields: ctx, sceneID, dates
+func (_m *GalleryReaderWriter) AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, sceneID, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, sceneID, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, sceneID, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // All provides a mock function with given fields: ctx
 func (_m *GalleryReaderWriter) All(ctx context.Context) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx)
@@ -93,6 +141,27 @@ func (_m *GalleryReaderWriter) Count(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
+// CountAllViews provides a mock function with given fields: ctx
+func (_m *GalleryReaderWriter) CountAllViews(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountByFileID provides a mock function with given fields: ctx, fileID
 func (_m *GalleryReaderWriter) CountByFileID(ctx context.Context, fileID models.FileID) (int, error) {
 	ret := _m.Called(ctx, fileID)
@@ -114,6 +183,48 @@ func (_m *GalleryReaderWriter) CountByFileID(ctx context.Context, fileID models.
 	return r0, r1
 }
 
+// CountUniqueViews provides a mock function with given fields: ctx
+func (_m *GalleryReaderWriter) CountUniqueViews(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountViews provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) CountViews(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, newGallery, fileIDs
 func (_m *GalleryReaderWriter) Create(ctx context.Context, newGallery *models.Gallery, fileIDs []models.FileID) error {
 	ret := _m.Called(ctx, newGallery, fileIDs)
@@ -128,6 +239,87 @@ func (_m *GalleryReaderWriter) Create(ctx context.Context, newGallery *models.Ga
 	return r0
 }
 
+// DeleteAllViews provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) DeleteAllViews(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteO provides a mock function with given fields: ctx, id, dates
+func (_m *GalleryReaderWriter) DeleteO(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteReadingSession provides a mock function with given fields: ctx, galleryID
+func (_m *GalleryReaderWriter) DeleteReadingSession(ctx context.Context, galleryID int) error {
+	ret := _m.Called(ctx, galleryID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, galleryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteViews provides a mock function with given fields: ctx, id, dates
+func (_m *GalleryReaderWriter) DeleteViews(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Destroy provides a mock function with given fields: ctx, id
 func (_m *GalleryReaderWriter) Destroy(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -372,6 +564,29 @@ func (_m *GalleryReaderWriter) FindMany(ctx context.Context, ids []int) ([]*mode
 	return r0, r1
 }
 
+// FindReadingSessions provides a mock function with given fields: ctx, limit
+func (_m *GalleryReaderWriter) FindReadingSessions(ctx context.Context, limit int) ([]*models.GalleryReadingSession, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []*models.GalleryReadingSession
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.GalleryReadingSession); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GalleryReadingSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUserGalleryByTitle provides a mock function with given fields: ctx, title
 func (_m *GalleryReaderWriter) FindUserGalleryByTitle(ctx context.Context, title string) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx, title)
@@ -395,6 +610,27 @@ func (_m *GalleryReaderWriter) FindUserGalleryByTitle(ctx context.Context, title
 	return r0, r1
 }
 
+// GetAllOCount provides a mock function with given fields: ctx
+func (_m *GalleryReaderWriter) GetAllOCount(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetFiles(ctx context.Context, relatedID int) ([]models.File, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -464,6 +700,234 @@ func (_m *GalleryReaderWriter) GetManyFileIDs(ctx context.Context, ids []int) ([
 	return r0, r1
 }
 
+// GetManyLastViewed provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyLastViewed(ctx context.Context, ids []int) ([]*time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyOCount provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyOCount(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyODates provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyODates(ctx context.Context, ids []int) ([][]time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyPerformerIDs provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManySceneIDs provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManySceneIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyViewCount provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyViewCount(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyViewDates provides a mock function with given fields: ctx, ids
+func (_m *GalleryReaderWriter) GetManyViewDates(ctx context.Context, ids []int) ([][]time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOCount provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) GetOCount(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetODates provides a mock function with given fields: ctx, relatedID
+func (_m *GalleryReaderWriter) GetODates(ctx context.Context, relatedID int) ([]time.Time, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPerformerIDs provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -487,6 +951,29 @@ func (_m *GalleryReaderWriter) GetPerformerIDs(ctx context.Context, relatedID in
 	return r0, r1
 }
 
+// GetReadingSession provides a mock function with given fields: ctx, galleryID
+func (_m *GalleryReaderWriter) GetReadingSession(ctx context.Context, galleryID int) (*models.GalleryReadingSession, error) {
+	ret := _m.Called(ctx, galleryID)
+
+	var r0 *models.GalleryReadingSession
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.GalleryReadingSession); ok {
+		r0 = rf(ctx, galleryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GalleryReadingSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, galleryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSceneIDs provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetSceneIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -533,6 +1020,29 @@ func (_m *GalleryReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]
 	return r0, r1
 }
 
+// GetTypedURLs provides a mock function with given fields: ctx, relatedID
+func (_m *GalleryReaderWriter) GetTypedURLs(ctx context.Context, relatedID int) ([]models.TypedURL, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.TypedURL
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TypedURL); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TypedURL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetURLs provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -556,6 +1066,52 @@ func (_m *GalleryReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]st
 	return r0, r1
 }
 
+// GetViewDates provides a mock function with given fields: ctx, relatedID
+func (_m *GalleryReaderWriter) GetViewDates(ctx context.Context, relatedID int) ([]time.Time, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LocationClusters provides a mock function with given fields: ctx, cellSize, bounds
+func (_m *GalleryReaderWriter) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	ret := _m.Called(ctx, cellSize, bounds)
+
+	var r0 []*models.LocationCluster
+	if rf, ok := ret.Get(0).(func(context.Context, float64, *models.GeoBoundsInput) []*models.LocationCluster); ok {
+		r0 = rf(ctx, cellSize, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LocationCluster)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, float64, *models.GeoBoundsInput) error); ok {
+		r1 = rf(ctx, cellSize, bounds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, galleryFilter, findFilter
 func (_m *GalleryReaderWriter) Query(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType) ([]*models.Gallery, int, error) {
 	ret := _m.Called(ctx, galleryFilter, findFilter)
@@ -642,6 +1198,50 @@ func (_m *GalleryReaderWriter) ResetCover(ctx context.Context, galleryID int) er
 	return r0
 }
 
+// ResetO provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) ResetO(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveReadingSession provides a mock function with given fields: ctx, galleryID, page
+func (_m *GalleryReaderWriter) SaveReadingSession(ctx context.Context, galleryID int, page int) (*models.GalleryReadingSession, error) {
+	ret := _m.Called(ctx, galleryID, page)
+
+	var r0 *models.GalleryReadingSession
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.GalleryReadingSession); ok {
+		r0 = rf(ctx, galleryID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GalleryReadingSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, galleryID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetCover provides a mock function with given fields: ctx, galleryID, coverImageID
 func (_m *GalleryReaderWriter) SetCover(ctx context.Context, galleryID int, coverImageID int) error {
 	ret := _m.Called(ctx, galleryID, coverImageID)
@@ -656,6 +1256,20 @@ func (_m *GalleryReaderWriter) SetCover(ctx context.Context, galleryID int, cove
 	return r0
 }
 
+// SetURLTypes provides a mock function with given fields: ctx, relatedID, types
+func (_m *GalleryReaderWriter) SetURLTypes(ctx context.Context, relatedID int, types map[string]*models.URLType) error {
+	ret := _m.Called(ctx, relatedID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]*models.URLType) error); ok {
+		r0 = rf(ctx, relatedID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedGallery
 func (_m *GalleryReaderWriter) Update(ctx context.Context, updatedGallery *models.Gallery) error {
 	ret := _m.Called(ctx, updatedGallery)
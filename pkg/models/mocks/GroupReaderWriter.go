@@ -335,6 +335,29 @@ func (_m *GroupReaderWriter) GetFrontImage(ctx context.Context, groupID int) ([]
 	return r0, r1
 }
 
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *GroupReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubGroupDescriptions provides a mock function with given fields: ctx, id
 func (_m *GroupReaderWriter) GetSubGroupDescriptions(ctx context.Context, id int) ([]models.GroupIDDescription, error) {
 	ret := _m.Called(ctx, id)
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/stashapp/stash/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ImageReaderWriter is an autogenerated mock type for the ImageReaderWriter type
@@ -28,6 +30,29 @@ func (_m *ImageReaderWriter) AddFileID(ctx context.Context, id int, fileID model
 	return r0
 }
 
+// AddViews provides a mock function with given fields: ctx, sceneID, dates
+func (_m *ImageReaderWriter) AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, sceneID, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, sceneID, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, sceneID, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // All provides a mock function with given fields: ctx
 func (_m *ImageReaderWriter) All(ctx context.Context) ([]*models.Image, error) {
 	ret := _m.Called(ctx)
@@ -72,6 +97,27 @@ func (_m *ImageReaderWriter) Count(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
+// CountAllViews provides a mock function with given fields: ctx
+func (_m *ImageReaderWriter) CountAllViews(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountByFileID provides a mock function with given fields: ctx, fileID
 func (_m *ImageReaderWriter) CountByFileID(ctx context.Context, fileID models.FileID) (int, error) {
 	ret := _m.Called(ctx, fileID)
@@ -114,6 +160,71 @@ func (_m *ImageReaderWriter) CountByGalleryID(ctx context.Context, galleryID int
 	return r0, r1
 }
 
+// CountByGalleryIDs provides a mock function with given fields: ctx, galleryIDs
+func (_m *ImageReaderWriter) CountByGalleryIDs(ctx context.Context, galleryIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, galleryIDs)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, galleryIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, galleryIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountUniqueViews provides a mock function with given fields: ctx
+func (_m *ImageReaderWriter) CountUniqueViews(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountViews provides a mock function with given fields: ctx, id
+func (_m *ImageReaderWriter) CountViews(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CoverByGalleryID provides a mock function with given fields: ctx, galleryId
 func (_m *ImageReaderWriter) CoverByGalleryID(ctx context.Context, galleryId int) (*models.Image, error) {
 	ret := _m.Called(ctx, galleryId)
@@ -172,6 +283,50 @@ func (_m *ImageReaderWriter) DecrementOCounter(ctx context.Context, id int) (int
 	return r0, r1
 }
 
+// DeleteAllViews provides a mock function with given fields: ctx, id
+func (_m *ImageReaderWriter) DeleteAllViews(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteViews provides a mock function with given fields: ctx, id, dates
+func (_m *ImageReaderWriter) DeleteViews(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
+	ret := _m.Called(ctx, id, dates)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) []time.Time); ok {
+		r0 = rf(ctx, id, dates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, dates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Destroy provides a mock function with given fields: ctx, id
 func (_m *ImageReaderWriter) Destroy(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -462,6 +617,144 @@ func (_m *ImageReaderWriter) GetManyFileIDs(ctx context.Context, ids []int) ([][
 	return r0, r1
 }
 
+// GetManyGalleryIDs provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyGalleryIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyLastViewed provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyLastViewed(ctx context.Context, ids []int) ([]*time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyPerformerIDs provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyViewCount provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyViewCount(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyViewDates provides a mock function with given fields: ctx, ids
+func (_m *ImageReaderWriter) GetManyViewDates(ctx context.Context, ids []int) ([][]time.Time, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]time.Time); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPerformerIDs provides a mock function with given fields: ctx, relatedID
 func (_m *ImageReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -531,6 +824,29 @@ func (_m *ImageReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]stri
 	return r0, r1
 }
 
+// GetViewDates provides a mock function with given fields: ctx, relatedID
+func (_m *ImageReaderWriter) GetViewDates(ctx context.Context, relatedID int) ([]time.Time, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IncrementOCounter provides a mock function with given fields: ctx, id
 func (_m *ImageReaderWriter) IncrementOCounter(ctx context.Context, id int) (int, error) {
 	ret := _m.Called(ctx, id)
@@ -552,6 +868,29 @@ func (_m *ImageReaderWriter) IncrementOCounter(ctx context.Context, id int) (int
 	return r0, r1
 }
 
+// LocationClusters provides a mock function with given fields: ctx, cellSize, bounds
+func (_m *ImageReaderWriter) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	ret := _m.Called(ctx, cellSize, bounds)
+
+	var r0 []*models.LocationCluster
+	if rf, ok := ret.Get(0).(func(context.Context, float64, *models.GeoBoundsInput) []*models.LocationCluster); ok {
+		r0 = rf(ctx, cellSize, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LocationCluster)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, float64, *models.GeoBoundsInput) error); ok {
+		r1 = rf(ctx, cellSize, bounds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OCount provides a mock function with given fields: ctx
 func (_m *ImageReaderWriter) OCount(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -758,3 +1097,26 @@ func (_m *ImageReaderWriter) UpdateTags(ctx context.Context, imageID int, tagIDs
 
 	return r0
 }
+
+// FindByFilePhash provides a mock function with given fields: ctx, phash, distance
+func (_m *ImageReaderWriter) FindByFilePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	ret := _m.Called(ctx, phash, distance)
+
+	var r0 []models.PhashMatch
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []models.PhashMatch); ok {
+		r0 = rf(ctx, phash, distance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PhashMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, phash, distance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -14,6 +14,29 @@ type PerformerReaderWriter struct {
 	mock.Mock
 }
 
+// AddImage provides a mock function with given fields: ctx, performerID, image, source
+func (_m *PerformerReaderWriter) AddImage(ctx context.Context, performerID int, image []byte, source string) (*models.PerformerImage, error) {
+	ret := _m.Called(ctx, performerID, image, source)
+
+	var r0 *models.PerformerImage
+	if rf, ok := ret.Get(0).(func(context.Context, int, []byte, string) *models.PerformerImage); ok {
+		r0 = rf(ctx, performerID, image, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PerformerImage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []byte, string) error); ok {
+		r1 = rf(ctx, performerID, image, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // All provides a mock function with given fields: ctx
 func (_m *PerformerReaderWriter) All(ctx context.Context) ([]*models.Performer, error) {
 	ret := _m.Called(ctx)
@@ -268,6 +291,29 @@ func (_m *PerformerReaderWriter) FindByStashIDStatus(ctx context.Context, hasSta
 	return r0, r1
 }
 
+// FindImage provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) FindImage(ctx context.Context, id int) (*models.PerformerImage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.PerformerImage
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.PerformerImage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PerformerImage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids
 func (_m *PerformerReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.Performer, error) {
 	ret := _m.Called(ctx, ids)
@@ -360,6 +406,29 @@ func (_m *PerformerReaderWriter) GetCustomFieldsBulk(ctx context.Context, ids []
 	return r0, r1
 }
 
+// GetFieldSources provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) GetFieldSources(ctx context.Context, id int) ([]*models.FieldSource, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.FieldSource); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, performerID
 func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int) ([]byte, error) {
 	ret := _m.Called(ctx, performerID)
@@ -383,6 +452,75 @@ func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// GetImageData provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) GetImageData(ctx context.Context, id int) ([]byte, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, int) []byte); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImages provides a mock function with given fields: ctx, performerID
+func (_m *PerformerReaderWriter) GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error) {
+	ret := _m.Called(ctx, performerID)
+
+	var r0 []*models.PerformerImage
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.PerformerImage); ok {
+		r0 = rf(ctx, performerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PerformerImage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, performerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *PerformerReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *PerformerReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -429,6 +567,29 @@ func (_m *PerformerReaderWriter) GetTagIDs(ctx context.Context, relatedID int) (
 	return r0, r1
 }
 
+// GetTypedURLs provides a mock function with given fields: ctx, relatedID
+func (_m *PerformerReaderWriter) GetTypedURLs(ctx context.Context, relatedID int) ([]models.TypedURL, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.TypedURL
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TypedURL); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TypedURL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetURLs provides a mock function with given fields: ctx, relatedID
 func (_m *PerformerReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -473,6 +634,43 @@ func (_m *PerformerReaderWriter) HasImage(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// Merge provides a mock function with given fields: ctx, source, destination
+func (_m *PerformerReaderWriter) Merge(ctx context.Context, source []int, destination int) error {
+	ret := _m.Called(ctx, source, destination)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, source, destination)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MergePreview provides a mock function with given fields: ctx, source, destination
+func (_m *PerformerReaderWriter) MergePreview(ctx context.Context, source []int, destination int) (*models.PerformerMergePreview, error) {
+	ret := _m.Called(ctx, source, destination)
+
+	var r0 *models.PerformerMergePreview
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) *models.PerformerMergePreview); ok {
+		r0 = rf(ctx, source, destination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PerformerMergePreview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, int) error); ok {
+		r1 = rf(ctx, source, destination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, performerFilter, findFilter
 func (_m *PerformerReaderWriter) Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error) {
 	ret := _m.Called(ctx, performerFilter, findFilter)
@@ -547,6 +745,76 @@ func (_m *PerformerReaderWriter) QueryForAutoTag(ctx context.Context, words []st
 	return r0, r1
 }
 
+// RemoveImage provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) RemoveImage(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderImages provides a mock function with given fields: ctx, performerID, ids
+func (_m *PerformerReaderWriter) ReorderImages(ctx context.Context, performerID int, ids []int) error {
+	ret := _m.Called(ctx, performerID, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, performerID, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFieldSources provides a mock function with given fields: ctx, id, sources
+func (_m *PerformerReaderWriter) SetFieldSources(ctx context.Context, id int, sources []*models.FieldSource) error {
+	ret := _m.Called(ctx, id, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []*models.FieldSource) error); ok {
+		r0 = rf(ctx, id, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPrimaryImage provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) SetPrimaryImage(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetURLTypes provides a mock function with given fields: ctx, relatedID, types
+func (_m *PerformerReaderWriter) SetURLTypes(ctx context.Context, relatedID int, types map[string]*models.URLType) error {
+	ret := _m.Called(ctx, relatedID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]*models.URLType) error); ok {
+		r0 = rf(ctx, relatedID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedPerformer
 func (_m *PerformerReaderWriter) Update(ctx context.Context, updatedPerformer *models.UpdatePerformerInput) error {
 	ret := _m.Called(ctx, updatedPerformer)
@@ -597,3 +865,63 @@ func (_m *PerformerReaderWriter) UpdatePartial(ctx context.Context, id int, upda
 
 	return r0, r1
 }
+
+// FindMissingImagePhashes provides a mock function with given fields: ctx, limit
+func (_m *PerformerReaderWriter) FindMissingImagePhashes(ctx context.Context, limit int) ([]int, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateImagePhash provides a mock function with given fields: ctx, performerID, phash
+func (_m *PerformerReaderWriter) UpdateImagePhash(ctx context.Context, performerID int, phash *int64) error {
+	ret := _m.Called(ctx, performerID, phash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int64) error); ok {
+		r0 = rf(ctx, performerID, phash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByImagePhash provides a mock function with given fields: ctx, phash, distance
+func (_m *PerformerReaderWriter) FindByImagePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	ret := _m.Called(ctx, phash, distance)
+
+	var r0 []models.PhashMatch
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []models.PhashMatch); ok {
+		r0 = rf(ctx, phash, distance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PhashMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, phash, distance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -111,6 +111,29 @@ func (_m *SavedFilterReaderWriter) FindByMode(ctx context.Context, mode models.F
 	return r0, r1
 }
 
+// FindDefault provides a mock function with given fields: ctx, mode
+func (_m *SavedFilterReaderWriter) FindDefault(ctx context.Context, mode models.FilterMode) (*models.SavedFilter, error) {
+	ret := _m.Called(ctx, mode)
+
+	var r0 *models.SavedFilter
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilterMode) *models.SavedFilter); ok {
+		r0 = rf(ctx, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SavedFilter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FilterMode) error); ok {
+		r1 = rf(ctx, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids, ignoreNotFound
 func (_m *SavedFilterReaderWriter) FindMany(ctx context.Context, ids []int, ignoreNotFound bool) ([]*models.SavedFilter, error) {
 	ret := _m.Called(ctx, ids, ignoreNotFound)
@@ -134,6 +157,48 @@ func (_m *SavedFilterReaderWriter) FindMany(ctx context.Context, ids []int, igno
 	return r0, r1
 }
 
+// Reorder provides a mock function with given fields: ctx, ids
+func (_m *SavedFilterReaderWriter) Reorder(ctx context.Context, ids []int) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDefault provides a mock function with given fields: ctx, mode, id
+func (_m *SavedFilterReaderWriter) SetDefault(ctx context.Context, mode models.FilterMode, id *int) error {
+	ret := _m.Called(ctx, mode, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FilterMode, *int) error); ok {
+		r0 = rf(ctx, mode, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetFolder provides a mock function with given fields: ctx, ids, folderID
+func (_m *SavedFilterReaderWriter) SetFolder(ctx context.Context, ids []int, folderID *int) error {
+	ret := _m.Called(ctx, ids, folderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, *int) error); ok {
+		r0 = rf(ctx, ids, folderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, obj
 func (_m *SavedFilterReaderWriter) Update(ctx context.Context, obj *models.SavedFilter) error {
 	ret := _m.Called(ctx, obj)
@@ -199,6 +199,29 @@ func (_m *SceneMarkerReaderWriter) GetMarkerStrings(ctx context.Context, q *stri
 	return r0, r1
 }
 
+// GetPerformerIDs provides a mock function with given fields: ctx, relatedID
+func (_m *SceneMarkerReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTagIDs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneMarkerReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -310,6 +333,20 @@ func (_m *SceneMarkerReaderWriter) UpdatePartial(ctx context.Context, id int, up
 	return r0, r1
 }
 
+// UpdatePerformers provides a mock function with given fields: ctx, markerID, performerIDs
+func (_m *SceneMarkerReaderWriter) UpdatePerformers(ctx context.Context, markerID int, performerIDs []int) error {
+	ret := _m.Called(ctx, markerID, performerIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, markerID, performerIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTags provides a mock function with given fields: ctx, markerID, tagIDs
 func (_m *SceneMarkerReaderWriter) UpdateTags(ctx context.Context, markerID int, tagIDs []int) error {
 	ret := _m.Called(ctx, markerID, tagIDs)
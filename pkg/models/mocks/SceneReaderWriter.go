@@ -390,6 +390,20 @@ func (_m *SceneReaderWriter) Destroy(ctx context.Context, id int) error {
 	return r0
 }
 
+// DestroyTranscript provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) DestroyTranscript(ctx context.Context, sceneID int) error {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Duration provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Duration(ctx context.Context) (float64, error) {
 	ret := _m.Called(ctx)
@@ -641,19 +655,42 @@ func (_m *SceneReaderWriter) FindByPrimaryFileID(ctx context.Context, fileID mod
 	return r0, r1
 }
 
-// FindDuplicates provides a mock function with given fields: ctx, distance, durationDiff
-func (_m *SceneReaderWriter) FindDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]*models.Scene, error) {
-	ret := _m.Called(ctx, distance, durationDiff)
+// FindDuplicates provides a mock function with given fields: ctx, distance, durationDiff, options
+func (_m *SceneReaderWriter) FindDuplicates(ctx context.Context, distance int, durationDiff float64, options models.PhashDuplicateOptions) ([][]*models.Scene, error) {
+	ret := _m.Called(ctx, distance, durationDiff, options)
 
 	var r0 [][]*models.Scene
-	if rf, ok := ret.Get(0).(func(context.Context, int, float64) [][]*models.Scene); ok {
-		r0 = rf(ctx, distance, durationDiff)
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64, models.PhashDuplicateOptions) [][]*models.Scene); ok {
+		r0 = rf(ctx, distance, durationDiff, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([][]*models.Scene)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, float64, models.PhashDuplicateOptions) error); ok {
+		r1 = rf(ctx, distance, durationDiff, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindImageDuplicates provides a mock function with given fields: ctx, distance, durationDiff
+func (_m *SceneReaderWriter) FindImageDuplicates(ctx context.Context, distance int, durationDiff float64) ([]*models.SceneImageDuplicate, error) {
+	ret := _m.Called(ctx, distance, durationDiff)
+
+	var r0 []*models.SceneImageDuplicate
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64) []*models.SceneImageDuplicate); ok {
+		r0 = rf(ctx, distance, durationDiff)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneImageDuplicate)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, int, float64) error); ok {
 		r1 = rf(ctx, distance, durationDiff)
@@ -731,6 +768,29 @@ func (_m *SceneReaderWriter) GetCover(ctx context.Context, sceneID int) ([]byte,
 	return r0, r1
 }
 
+// GetFieldSources provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetFieldSources(ctx context.Context, id int) ([]*models.FieldSource, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.FieldSource); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetFiles(ctx context.Context, relatedID int) ([]*models.VideoFile, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -823,6 +883,29 @@ func (_m *SceneReaderWriter) GetManyFileIDs(ctx context.Context, ids []int) ([][
 	return r0, r1
 }
 
+// GetManyGalleryIDs provides a mock function with given fields: ctx, ids
+func (_m *SceneReaderWriter) GetManyGalleryIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManyLastViewed provides a mock function with given fields: ctx, ids
 func (_m *SceneReaderWriter) GetManyLastViewed(ctx context.Context, ids []int) ([]*time.Time, error) {
 	ret := _m.Called(ctx, ids)
@@ -892,6 +975,52 @@ func (_m *SceneReaderWriter) GetManyODates(ctx context.Context, ids []int) ([][]
 	return r0, r1
 }
 
+// GetManyPerformerIDs provides a mock function with given fields: ctx, ids
+func (_m *SceneReaderWriter) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *SceneReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetManyViewCount provides a mock function with given fields: ctx, ids
 func (_m *SceneReaderWriter) GetManyViewCount(ctx context.Context, ids []int) ([]int, error) {
 	ret := _m.Called(ctx, ids)
@@ -1005,6 +1134,29 @@ func (_m *SceneReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int)
 	return r0, r1
 }
 
+// GetRatings provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) GetRatings(ctx context.Context, sceneID int) ([]*models.SceneRating, error) {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 []*models.SceneRating
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.SceneRating); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneRating)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -1051,6 +1203,52 @@ func (_m *SceneReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]in
 	return r0, r1
 }
 
+// GetTranscript provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) GetTranscript(ctx context.Context, sceneID int) (*models.SceneTranscript, error) {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 *models.SceneTranscript
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.SceneTranscript); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneTranscript)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTypedURLs provides a mock function with given fields: ctx, relatedID
+func (_m *SceneReaderWriter) GetTypedURLs(ctx context.Context, relatedID int) ([]models.TypedURL, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.TypedURL
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TypedURL); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TypedURL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetURLs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -1097,6 +1295,29 @@ func (_m *SceneReaderWriter) GetViewDates(ctx context.Context, relatedID int) ([
 	return r0, r1
 }
 
+// GetWatchHeat provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) GetWatchHeat(ctx context.Context, sceneID int) (models.WatchHeat, error) {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 models.WatchHeat
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.WatchHeat); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.WatchHeat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, error) {
 	ret := _m.Called(ctx, sceneID)
@@ -1118,6 +1339,29 @@ func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, e
 	return r0, r1
 }
 
+// LocationClusters provides a mock function with given fields: ctx, cellSize, bounds
+func (_m *SceneReaderWriter) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	ret := _m.Called(ctx, cellSize, bounds)
+
+	var r0 []*models.LocationCluster
+	if rf, ok := ret.Get(0).(func(context.Context, float64, *models.GeoBoundsInput) []*models.LocationCluster); ok {
+		r0 = rf(ctx, cellSize, bounds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LocationCluster)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, float64, *models.GeoBoundsInput) error); ok {
+		r1 = rf(ctx, cellSize, bounds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OCountByPerformerID provides a mock function with given fields: ctx, performerID
 func (_m *SceneReaderWriter) OCountByPerformerID(ctx context.Context, performerID int) (int, error) {
 	ret := _m.Called(ctx, performerID)
@@ -1204,6 +1448,20 @@ func (_m *SceneReaderWriter) QueryCount(ctx context.Context, sceneFilter *models
 	return r0, r1
 }
 
+// RefreshGeneratedTitles provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) RefreshGeneratedTitles(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetActivity provides a mock function with given fields: ctx, sceneID, resetResume, resetDuration
 func (_m *SceneReaderWriter) ResetActivity(ctx context.Context, sceneID int, resetResume bool, resetDuration bool) (bool, error) {
 	ret := _m.Called(ctx, sceneID, resetResume, resetDuration)
@@ -1267,6 +1525,62 @@ func (_m *SceneReaderWriter) SaveActivity(ctx context.Context, sceneID int, resu
 	return r0, r1
 }
 
+// SetFieldSources provides a mock function with given fields: ctx, id, sources
+func (_m *SceneReaderWriter) SetFieldSources(ctx context.Context, id int, sources []*models.FieldSource) error {
+	ret := _m.Called(ctx, id, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []*models.FieldSource) error); ok {
+		r0 = rf(ctx, id, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRating provides a mock function with given fields: ctx, sceneID, username, rating
+func (_m *SceneReaderWriter) SetRating(ctx context.Context, sceneID int, username string, rating *int) error {
+	ret := _m.Called(ctx, sceneID, username, rating)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *int) error); ok {
+		r0 = rf(ctx, sceneID, username, rating)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTranscript provides a mock function with given fields: ctx, transcript
+func (_m *SceneReaderWriter) SetTranscript(ctx context.Context, transcript models.SceneTranscript) error {
+	ret := _m.Called(ctx, transcript)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SceneTranscript) error); ok {
+		r0 = rf(ctx, transcript)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetURLTypes provides a mock function with given fields: ctx, relatedID, types
+func (_m *SceneReaderWriter) SetURLTypes(ctx context.Context, relatedID int, types map[string]*models.URLType) error {
+	ret := _m.Called(ctx, relatedID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]*models.URLType) error); ok {
+		r0 = rf(ctx, relatedID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Size(ctx context.Context) (float64, error) {
 	ret := _m.Called(ctx)
@@ -1361,3 +1675,123 @@ func (_m *SceneReaderWriter) Wall(ctx context.Context, q *string) ([]*models.Sce
 
 	return r0, r1
 }
+
+// FindMissingImagePhashes provides a mock function with given fields: ctx, limit
+func (_m *SceneReaderWriter) FindMissingImagePhashes(ctx context.Context, limit int) ([]int, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateImagePhash provides a mock function with given fields: ctx, sceneID, phash
+func (_m *SceneReaderWriter) UpdateImagePhash(ctx context.Context, sceneID int, phash *int64) error {
+	ret := _m.Called(ctx, sceneID, phash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *int64) error); ok {
+		r0 = rf(ctx, sceneID, phash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByImagePhash provides a mock function with given fields: ctx, phash, distance
+func (_m *SceneReaderWriter) FindByImagePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	ret := _m.Called(ctx, phash, distance)
+
+	var r0 []models.PhashMatch
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []models.PhashMatch); ok {
+		r0 = rf(ctx, phash, distance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PhashMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, phash, distance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPending provides a mock function with given fields: ctx, fp, urls
+func (_m *SceneReaderWriter) FindPending(ctx context.Context, fp []models.Fingerprint, urls []string) ([]*models.Scene, error) {
+	ret := _m.Called(ctx, fp, urls)
+
+	var r0 []*models.Scene
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Fingerprint, []string) []*models.Scene); ok {
+		r0 = rf(ctx, fp, urls)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Scene)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []models.Fingerprint, []string) error); ok {
+		r1 = rf(ctx, fp, urls)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingFingerprints provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) GetPendingFingerprints(ctx context.Context, sceneID int) ([]models.Fingerprint, error) {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 []models.Fingerprint
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.Fingerprint); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Fingerprint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePendingFingerprints provides a mock function with given fields: ctx, sceneID, fp
+func (_m *SceneReaderWriter) UpdatePendingFingerprints(ctx context.Context, sceneID int, fp []models.Fingerprint) error {
+	ret := _m.Called(ctx, sceneID, fp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []models.Fingerprint) error); ok {
+		r0 = rf(ctx, sceneID, fp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
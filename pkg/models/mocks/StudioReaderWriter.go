@@ -291,6 +291,75 @@ func (_m *StudioReaderWriter) GetAliases(ctx context.Context, relatedID int) ([]
 	return r0, r1
 }
 
+// GetDefaultTagIDs provides a mock function with given fields: ctx, relatedID
+func (_m *StudioReaderWriter) GetDefaultTagIDs(ctx context.Context, relatedID int) ([]int, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDefaultURLs provides a mock function with given fields: ctx, relatedID
+func (_m *StudioReaderWriter) GetDefaultURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFieldSources provides a mock function with given fields: ctx, id
+func (_m *StudioReaderWriter) GetFieldSources(ctx context.Context, id int) ([]*models.FieldSource, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.FieldSource); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, studioID
 func (_m *StudioReaderWriter) GetImage(ctx context.Context, studioID int) ([]byte, error) {
 	ret := _m.Called(ctx, studioID)
@@ -314,6 +383,29 @@ func (_m *StudioReaderWriter) GetImage(ctx context.Context, studioID int) ([]byt
 	return r0, r1
 }
 
+// GetManyTagIDs provides a mock function with given fields: ctx, ids
+func (_m *StudioReaderWriter) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 [][]int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) [][]int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *StudioReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -360,6 +452,52 @@ func (_m *StudioReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]i
 	return r0, r1
 }
 
+// GetTypedURLs provides a mock function with given fields: ctx, relatedID
+func (_m *StudioReaderWriter) GetTypedURLs(ctx context.Context, relatedID int) ([]models.TypedURL, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.TypedURL
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TypedURL); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TypedURL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *StudioReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasImage provides a mock function with given fields: ctx, studioID
 func (_m *StudioReaderWriter) HasImage(ctx context.Context, studioID int) (bool, error) {
 	ret := _m.Called(ctx, studioID)
@@ -455,6 +593,34 @@ func (_m *StudioReaderWriter) QueryForAutoTag(ctx context.Context, words []strin
 	return r0, r1
 }
 
+// SetFieldSources provides a mock function with given fields: ctx, id, sources
+func (_m *StudioReaderWriter) SetFieldSources(ctx context.Context, id int, sources []*models.FieldSource) error {
+	ret := _m.Called(ctx, id, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []*models.FieldSource) error); ok {
+		r0 = rf(ctx, id, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetURLTypes provides a mock function with given fields: ctx, relatedID, types
+func (_m *StudioReaderWriter) SetURLTypes(ctx context.Context, relatedID int, types map[string]*models.URLType) error {
+	ret := _m.Called(ctx, relatedID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, map[string]*models.URLType) error); ok {
+		r0 = rf(ctx, relatedID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedStudio
 func (_m *StudioReaderWriter) Update(ctx context.Context, updatedStudio *models.Studio) error {
 	ret := _m.Called(ctx, updatedStudio)
@@ -745,3 +745,26 @@ func (_m *TagReaderWriter) UpdatePartial(ctx context.Context, id int, updateTag
 
 	return r0, r1
 }
+
+// UsageCounts provides a mock function with given fields: ctx
+func (_m *TagReaderWriter) UsageCounts(ctx context.Context) (map[int]int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[int]int
+	if rf, ok := ret.Get(0).(func(context.Context) map[int]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -10,18 +10,29 @@ import (
 )
 
 type Database struct {
-	File           *FileReaderWriter
-	Folder         *FolderReaderWriter
-	Gallery        *GalleryReaderWriter
-	GalleryChapter *GalleryChapterReaderWriter
-	Image          *ImageReaderWriter
-	Group          *GroupReaderWriter
-	Performer      *PerformerReaderWriter
-	Scene          *SceneReaderWriter
-	SceneMarker    *SceneMarkerReaderWriter
-	Studio         *StudioReaderWriter
-	Tag            *TagReaderWriter
-	SavedFilter    *SavedFilterReaderWriter
+	File              *FileReaderWriter
+	Folder            *FolderReaderWriter
+	Quarantine        *QuarantinedFileReaderWriter
+	Gallery           *GalleryReaderWriter
+	GalleryChapter    *GalleryChapterReaderWriter
+	Image             *ImageReaderWriter
+	ImageRegion       *ImageRegionReaderWriter
+	Group             *GroupReaderWriter
+	Performer         *PerformerReaderWriter
+	Scene             *SceneReaderWriter
+	SceneMarker       *SceneMarkerReaderWriter
+	Suggestion        *SceneMarkerSuggestionReaderWriter
+	Studio            *StudioReaderWriter
+	Tag               *TagReaderWriter
+	TagCategory       *TagCategoryReaderWriter
+	SavedFilter       *SavedFilterReaderWriter
+	SavedFilterFolder *SavedFilterFolderReaderWriter
+	DefaultFilter     *DefaultFilterReaderWriter
+	ShareLink         *ShareLinkReaderWriter
+	Note              *NoteReaderWriter
+	TOTP              *TOTPReaderWriter
+	Sync              *SyncReader
+	URLStatus         *URLStatusReaderWriter
 }
 
 func (*Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -59,50 +70,83 @@ func (*Database) Reset() error {
 
 func NewDatabase() *Database {
 	return &Database{
-		File:           &FileReaderWriter{},
-		Folder:         &FolderReaderWriter{},
-		Gallery:        &GalleryReaderWriter{},
-		GalleryChapter: &GalleryChapterReaderWriter{},
-		Image:          &ImageReaderWriter{},
-		Group:          &GroupReaderWriter{},
-		Performer:      &PerformerReaderWriter{},
-		Scene:          &SceneReaderWriter{},
-		SceneMarker:    &SceneMarkerReaderWriter{},
-		Studio:         &StudioReaderWriter{},
-		Tag:            &TagReaderWriter{},
-		SavedFilter:    &SavedFilterReaderWriter{},
+		File:              &FileReaderWriter{},
+		Folder:            &FolderReaderWriter{},
+		Quarantine:        &QuarantinedFileReaderWriter{},
+		Gallery:           &GalleryReaderWriter{},
+		GalleryChapter:    &GalleryChapterReaderWriter{},
+		Image:             &ImageReaderWriter{},
+		ImageRegion:       &ImageRegionReaderWriter{},
+		Group:             &GroupReaderWriter{},
+		Performer:         &PerformerReaderWriter{},
+		Scene:             &SceneReaderWriter{},
+		SceneMarker:       &SceneMarkerReaderWriter{},
+		Suggestion:        &SceneMarkerSuggestionReaderWriter{},
+		Studio:            &StudioReaderWriter{},
+		Tag:               &TagReaderWriter{},
+		TagCategory:       &TagCategoryReaderWriter{},
+		SavedFilter:       &SavedFilterReaderWriter{},
+		SavedFilterFolder: &SavedFilterFolderReaderWriter{},
+		DefaultFilter:     &DefaultFilterReaderWriter{},
+		ShareLink:         &ShareLinkReaderWriter{},
+		Note:              &NoteReaderWriter{},
+		TOTP:              &TOTPReaderWriter{},
+		Sync:              &SyncReader{},
+		URLStatus:         &URLStatusReaderWriter{},
 	}
 }
 
 func (db *Database) AssertExpectations(t mock.TestingT) {
 	db.File.AssertExpectations(t)
 	db.Folder.AssertExpectations(t)
+	db.Quarantine.AssertExpectations(t)
 	db.Gallery.AssertExpectations(t)
 	db.GalleryChapter.AssertExpectations(t)
 	db.Image.AssertExpectations(t)
+	db.ImageRegion.AssertExpectations(t)
 	db.Group.AssertExpectations(t)
 	db.Performer.AssertExpectations(t)
 	db.Scene.AssertExpectations(t)
 	db.SceneMarker.AssertExpectations(t)
+	db.Suggestion.AssertExpectations(t)
 	db.Studio.AssertExpectations(t)
 	db.Tag.AssertExpectations(t)
+	db.TagCategory.AssertExpectations(t)
 	db.SavedFilter.AssertExpectations(t)
+	db.SavedFilterFolder.AssertExpectations(t)
+	db.DefaultFilter.AssertExpectations(t)
+	db.ShareLink.AssertExpectations(t)
+	db.Note.AssertExpectations(t)
+	db.TOTP.AssertExpectations(t)
+	db.Sync.AssertExpectations(t)
+	db.URLStatus.AssertExpectations(t)
 }
 
 func (db *Database) Repository() models.Repository {
 	return models.Repository{
-		TxnManager:     db,
-		File:           db.File,
-		Folder:         db.Folder,
-		Gallery:        db.Gallery,
-		GalleryChapter: db.GalleryChapter,
-		Image:          db.Image,
-		Group:          db.Group,
-		Performer:      db.Performer,
-		Scene:          db.Scene,
-		SceneMarker:    db.SceneMarker,
-		Studio:         db.Studio,
-		Tag:            db.Tag,
-		SavedFilter:    db.SavedFilter,
+		TxnManager:        db,
+		File:              db.File,
+		Folder:            db.Folder,
+		Quarantine:        db.Quarantine,
+		Gallery:           db.Gallery,
+		GalleryChapter:    db.GalleryChapter,
+		Image:             db.Image,
+		ImageRegion:       db.ImageRegion,
+		Group:             db.Group,
+		Performer:         db.Performer,
+		Scene:             db.Scene,
+		SceneMarker:       db.SceneMarker,
+		Suggestion:        db.Suggestion,
+		Studio:            db.Studio,
+		Tag:               db.Tag,
+		TagCategory:       db.TagCategory,
+		SavedFilter:       db.SavedFilter,
+		SavedFilterFolder: db.SavedFilterFolder,
+		DefaultFilter:     db.DefaultFilter,
+		ShareLink:         db.ShareLink,
+		Note:              db.Note,
+		TOTP:              db.TOTP,
+		Sync:              db.Sync,
+		URLStatus:         db.URLStatus,
 	}
 }
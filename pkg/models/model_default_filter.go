@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultFilter is the filter and sort applied by default when a user
+// browses a type of object.
+type DefaultFilter struct {
+	ID       int        `json:"id"`
+	Username string     `json:"username"`
+	Mode     FilterMode `json:"mode"`
+
+	FindFilter *FindFilterType `json:"find_filter"`
+	// ObjectFilter is in the same format as the object filter of a saved filter.
+	ObjectFilter map[string]interface{} `json:"object_filter"`
+	DisplayMode  *int                   `json:"display_mode"`
+	ZoomIndex    *int                   `json:"zoom_index"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewDefaultFilter() DefaultFilter {
+	currentTime := time.Now()
+	return DefaultFilter{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
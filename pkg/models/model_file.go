@@ -130,6 +130,15 @@ type BaseFile struct {
 
 	Size int64 `json:"size"`
 
+	// PendingContent is true if the file is a cloud placeholder whose
+	// contents have not been downloaded. Fingerprints are not calculated
+	// until the contents are available.
+	PendingContent bool `json:"pending_content"`
+
+	// LinkedFileID is the ID of the file that this file shares storage
+	// with, if it has been replaced with a hard link or reflink to it.
+	LinkedFileID *FileID `json:"linked_file_id"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -274,6 +283,19 @@ type VideoFile struct {
 	AudioCodec string  `json:"audio_codec"`
 	FrameRate  float64 `json:"frame_rate"`
 	BitRate    int64   `json:"bitrate"`
+	BitDepth   int     `json:"bit_depth"`
+	// AudioBitRate is 0 if unknown or if there is no audio stream.
+	AudioBitRate int64 `json:"audio_bit_rate"`
+	// QualityScore is the technical quality of the file from 0 to 100, or 0
+	// if unknown. See CalculateQualityScore.
+	QualityScore int `json:"quality_score"`
+
+	// HDRFormat is nil for standard dynamic range videos.
+	HDRFormat *HDRFormat `json:"hdr_format"`
+	// VRProjection is nil for videos that are not VR.
+	VRProjection *VRProjection `json:"vr_projection"`
+	// StereoLayout is nil for monoscopic videos.
+	StereoLayout *StereoLayout `json:"stereo_layout"`
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
@@ -18,7 +18,14 @@ type Gallery struct {
 	// Rating expressed in 1-100 scale
 	Rating    *int `json:"rating"`
 	Organized bool `json:"organized"`
-	StudioID  *int `json:"studio_id"`
+	// Archived galleries are excluded from default queries and stats.
+	Archived bool `json:"archived"`
+	StudioID *int `json:"studio_id"`
+
+	Country   string   `json:"country"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 
 	// transient - not persisted
 	Files RelatedFiles
@@ -61,7 +68,12 @@ type GalleryPartial struct {
 	// Rating expressed in 1-100 scale
 	Rating    OptionalInt
 	Organized OptionalBool
+	Archived  OptionalBool
 	StudioID  OptionalInt
+	Country   OptionalString
+	City      OptionalString
+	Latitude  OptionalFloat64
+	Longitude OptionalFloat64
 	// FileModTime OptionalTime
 	CreatedAt OptionalTime
 	UpdatedAt OptionalTime
@@ -171,3 +183,14 @@ func (g Gallery) DisplayName() string {
 }
 
 const DefaultGthumbWidth int = 640
+
+// GalleryReadingSession records how far through a gallery the user has read,
+// so that reading can be continued later. A session ends when the gallery is
+// read to the end.
+type GalleryReadingSession struct {
+	GalleryID int `json:"gallery_id"`
+	// Page is the zero-based index of the last image read
+	Page      int       `json:"page"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
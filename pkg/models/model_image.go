@@ -16,12 +16,19 @@ type Image struct {
 	Details      string `json:"details"`
 	Photographer string `json:"photographer"`
 	// Rating expressed in 1-100 scale
-	Rating    *int           `json:"rating"`
-	Organized bool           `json:"organized"`
-	OCounter  int            `json:"o_counter"`
-	StudioID  *int           `json:"studio_id"`
-	URLs      RelatedStrings `json:"urls"`
-	Date      *Date          `json:"date"`
+	Rating    *int `json:"rating"`
+	Organized bool `json:"organized"`
+	// Archived images are excluded from default queries and stats.
+	Archived bool           `json:"archived"`
+	OCounter int            `json:"o_counter"`
+	StudioID *int           `json:"studio_id"`
+	URLs     RelatedStrings `json:"urls"`
+	Date     *Date          `json:"date"`
+
+	Country   string   `json:"country"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 
 	// transient - not persisted
 	Files         RelatedFiles
@@ -57,8 +64,13 @@ type ImagePartial struct {
 	Details      OptionalString
 	Photographer OptionalString
 	Organized    OptionalBool
+	Archived     OptionalBool
 	OCounter     OptionalInt
 	StudioID     OptionalInt
+	Country      OptionalString
+	City         OptionalString
+	Latitude     OptionalFloat64
+	Longitude    OptionalFloat64
 	CreatedAt    OptionalTime
 	UpdatedAt    OptionalTime
 
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// ImageRegion is a rectangular region of an image with a tag and/or
+// performer attached. Coordinates are normalized to the range 0-1, relative
+// to the top left corner of the image.
+type ImageRegion struct {
+	ID          int       `json:"id"`
+	ImageID     int       `json:"image_id"`
+	X           float64   `json:"x"`
+	Y           float64   `json:"y"`
+	Width       float64   `json:"width"`
+	Height      float64   `json:"height"`
+	TagID       *int      `json:"tag_id"`
+	PerformerID *int      `json:"performer_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewImageRegion() ImageRegion {
+	currentTime := time.Now()
+	return ImageRegion{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// ImageRegionPartial represents part of a ImageRegion object.
+// It is used to update the database entry.
+type ImageRegionPartial struct {
+	X           OptionalFloat64
+	Y           OptionalFloat64
+	Width       OptionalFloat64
+	Height      OptionalFloat64
+	TagID       OptionalInt
+	PerformerID OptionalInt
+	CreatedAt   OptionalTime
+	UpdatedAt   OptionalTime
+}
+
+func NewImageRegionPartial() ImageRegionPartial {
+	currentTime := time.Now()
+	return ImageRegionPartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Note is a markdown note attached to a single scene or performer.
+type Note struct {
+	ID          int       `json:"id"`
+	SceneID     *int      `json:"scene_id"`
+	PerformerID *int      `json:"performer_id"`
+	Author      string    `json:"author"`
+	Body        string    `json:"body"`
+	Pinned      bool      `json:"pinned"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewNote() Note {
+	currentTime := time.Now()
+	return Note{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// NotePartial represents part of a Note object.
+// It is used to update the database entry.
+type NotePartial struct {
+	Author    OptionalString
+	Body      OptionalString
+	Pinned    OptionalBool
+	CreatedAt OptionalTime
+	UpdatedAt OptionalTime
+}
+
+func NewNotePartial() NotePartial {
+	currentTime := time.Now()
+	return NotePartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
@@ -32,6 +32,9 @@ type Performer struct {
 	HairColor     string `json:"hair_color"`
 	Weight        *int   `json:"weight"`
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
+	// FavoritedAt is when the performer was favorited. Nil if the performer
+	// is not a favorite.
+	FavoritedAt *time.Time `json:"favorited_at"`
 
 	Aliases  RelatedStrings  `json:"aliases"`
 	URLs     RelatedStrings  `json:"urls"`
@@ -88,6 +91,8 @@ type PerformerPartial struct {
 	HairColor     OptionalString
 	Weight        OptionalInt
 	IgnoreAutoTag OptionalBool
+	// FavoritedAt is set when Favorite is changed if not set explicitly
+	FavoritedAt OptionalTime
 
 	Aliases  *UpdateStrings
 	TagIDs   *UpdateIDs
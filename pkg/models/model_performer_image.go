@@ -0,0 +1,14 @@
+package models
+
+// PerformerImage is an image in the ordered image set of a performer.
+type PerformerImage struct {
+	ID          int `json:"id"`
+	PerformerID int `json:"performer_id"`
+	Position    int `json:"position"`
+	// Checksum is the checksum of the image blob.
+	Checksum string `json:"checksum"`
+	// Source is where the image was obtained from, such as a URL.
+	Source string `json:"source"`
+	// Primary is true for the image displayed for the performer.
+	Primary bool `json:"primary"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// PerformerMergePreview describes what merging source performers into a
+// destination performer adds to the destination.
+type PerformerMergePreview struct {
+	DestinationID int   `json:"destination_id"`
+	SourceIDs     []int `json:"source_ids"`
+
+	// counts of the related objects of the sources that are not related to
+	// the destination
+	SceneCount   int `json:"scene_count"`
+	ImageCount   int `json:"image_count"`
+	GalleryCount int `json:"gallery_count"`
+	MarkerCount  int `json:"marker_count"`
+
+	// Aliases are the names and aliases of the sources that are not names
+	// or aliases of the destination.
+	Aliases  []string  `json:"aliases"`
+	URLs     []string  `json:"urls"`
+	TagIDs   []int     `json:"tag_ids"`
+	StashIDs []StashID `json:"stash_ids"`
+	// PerformerImageCount is the number of images of the sources that are
+	// not in the image set of the destination.
+	PerformerImageCount int `json:"performer_image_count"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// QuarantinedFile is a file that could not be read or probed when scanned,
+// for example because it is corrupt or truncated. Quarantined files are not
+// added to the library.
+type QuarantinedFile struct {
+	ID   int    `json:"id"`
+	Path string `json:"path"`
+	// Error is the most recent error encountered when scanning the file.
+	Error   string    `json:"error"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	// Attempts is the number of times the file has failed to scan.
+	Attempts int `json:"attempts"`
+	// Ignored files are skipped by subsequent scans until the file changes.
+	Ignored   bool      `json:"ignored"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewQuarantinedFile() QuarantinedFile {
+	currentTime := time.Now()
+	return QuarantinedFile{
+		Attempts:  1,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// QuarantinedFilePartial represents part of a QuarantinedFile object.
+// It is used to update the database entry.
+type QuarantinedFilePartial struct {
+	Error     OptionalString
+	Size      OptionalInt64
+	ModTime   OptionalTime
+	Attempts  OptionalInt
+	Ignored   OptionalBool
+	UpdatedAt OptionalTime
+}
+
+func NewQuarantinedFilePartial() QuarantinedFilePartial {
+	currentTime := time.Now()
+	return QuarantinedFilePartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
@@ -68,4 +68,11 @@ type SavedFilter struct {
 	FindFilter   *FindFilterType        `json:"find_filter"`
 	ObjectFilter map[string]interface{} `json:"object_filter"`
 	UIOptions    map[string]interface{} `json:"ui_options"`
+	// FolderID is nil if the filter is not in a folder.
+	FolderID *int `json:"folder_id"`
+	// Filters are displayed in ascending sort order, then by name.
+	SortOrder int `json:"sort_order"`
+	// IsDefault is true if the filter is applied when browsing its mode by
+	// users that have not set a default filter of their own.
+	IsDefault bool `json:"is_default"`
 }
@@ -0,0 +1,21 @@
+package models
+
+// SavedFilterFolder groups the saved filters of a filter mode. Folders may be
+// nested.
+type SavedFilterFolder struct {
+	ID   int        `json:"id"`
+	Mode FilterMode `json:"mode"`
+	Name string     `json:"name"`
+	// ParentID is nil for top-level folders.
+	ParentID *int `json:"parent_id"`
+	// Folders are displayed in ascending sort order, then by name.
+	SortOrder int `json:"sort_order"`
+}
+
+// SavedFilterFolderPartial represents part of a SavedFilterFolder object.
+// It is used to update the database entry.
+type SavedFilterFolderPartial struct {
+	Name      OptionalString
+	ParentID  OptionalInt
+	SortOrder OptionalInt
+}
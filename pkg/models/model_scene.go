@@ -8,6 +8,11 @@ import (
 	"time"
 )
 
+var (
+	ErrInvalidStreamStart = errors.New("stream start must not be negative")
+	ErrInvalidStreamEnd   = errors.New("stream end must be after stream start")
+)
+
 // Scene stores the metadata for a single video scene.
 type Scene struct {
 	ID       int    `json:"id"`
@@ -19,7 +24,24 @@ type Scene struct {
 	// Rating expressed in 1-100 scale
 	Rating    *int `json:"rating"`
 	Organized bool `json:"organized"`
-	StudioID  *int `json:"studio_id"`
+	// Pending scenes are awaiting a file, which is attached when a matching
+	// file is scanned.
+	Pending bool `json:"pending"`
+	// Archived scenes are excluded from default queries and stats.
+	Archived bool `json:"archived"`
+	StudioID *int `json:"studio_id"`
+
+	Country   string   `json:"country"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+
+	// offsets in seconds to start and end transcoded streams at
+	StreamStart *float64 `json:"stream_start"`
+	StreamEnd   *float64 `json:"stream_end"`
+
+	// generated from the scene title template - not set by updates
+	GeneratedTitle string `json:"generated_title"`
 
 	// transient - not persisted
 	Files         RelatedVideoFiles
@@ -64,7 +86,15 @@ type ScenePartial struct {
 	// Rating expressed in 1-100 scale
 	Rating       OptionalInt
 	Organized    OptionalBool
+	Pending      OptionalBool
+	Archived     OptionalBool
 	StudioID     OptionalInt
+	Country      OptionalString
+	City         OptionalString
+	Latitude     OptionalFloat64
+	Longitude    OptionalFloat64
+	StreamStart  OptionalFloat64
+	StreamEnd    OptionalFloat64
 	CreatedAt    OptionalTime
 	UpdatedAt    OptionalTime
 	ResumeTime   OptionalFloat64
@@ -207,6 +237,7 @@ func (s ScenePartial) UpdateInput(id int) SceneUpdateInput {
 		Date:         dateStr,
 		Rating100:    s.Rating.Ptr(),
 		Organized:    s.Organized.Ptr(),
+		Archived:     s.Archived.Ptr(),
 		StudioID:     s.StudioID.StringPtr(),
 		GalleryIds:   s.GalleryIDs.IDStrings(),
 		PerformerIds: s.PerformerIDs.IDStrings(),
@@ -251,6 +282,23 @@ func (s Scene) GetHash(hashAlgorithm HashAlgorithm) string {
 	return ""
 }
 
+// ValidateStreamTrim returns an error if the stream start is negative, or if
+// the stream end is not after the stream start.
+func ValidateStreamTrim(start *float64, end *float64) error {
+	var startValue float64
+	if start != nil {
+		startValue = *start
+	}
+
+	if startValue < 0 {
+		return ErrInvalidStreamStart
+	}
+	if end != nil && *end <= startValue {
+		return ErrInvalidStreamEnd
+	}
+	return nil
+}
+
 // SceneFileType represents the file metadata for a scene.
 type SceneFileType struct {
 	Size       *string  `graphql:"size" json:"size"`
@@ -272,3 +320,10 @@ type VideoCaption struct {
 func (c VideoCaption) Path(filePath string) string {
 	return filepath.Join(filepath.Dir(filePath), c.Filename)
 }
+
+// SceneImageDuplicate is a group of scenes and images with perceptually
+// similar files.
+type SceneImageDuplicate struct {
+	SceneIDs []int `json:"scene_ids"`
+	ImageIDs []int `json:"image_ids"`
+}
@@ -5,16 +5,29 @@ import (
 )
 
 type SceneMarker struct {
-	ID           int       `json:"id"`
-	Title        string    `json:"title"`
-	Seconds      float64   `json:"seconds"`
-	EndSeconds   *float64  `json:"end_seconds"`
+	ID         int      `json:"id"`
+	Title      string   `json:"title"`
+	Seconds    float64  `json:"seconds"`
+	EndSeconds *float64 `json:"end_seconds"`
+	Details    string   `json:"details"`
+	// Rating expressed in 1-100 scale
+	Rating       *int      `json:"rating"`
+	CoverSeconds *float64  `json:"cover_seconds"`
 	PrimaryTagID int       `json:"primary_tag_id"`
 	SceneID      int       `json:"scene_id"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// ScreenshotSeconds returns the time of the frame used for the marker
+// screenshot. This is CoverSeconds if set, otherwise Seconds.
+func (m SceneMarker) ScreenshotSeconds() float64 {
+	if m.CoverSeconds != nil {
+		return *m.CoverSeconds
+	}
+	return m.Seconds
+}
+
 func NewSceneMarker() SceneMarker {
 	currentTime := time.Now()
 	return SceneMarker{
@@ -29,6 +42,9 @@ type SceneMarkerPartial struct {
 	Title        OptionalString
 	Seconds      OptionalFloat64
 	EndSeconds   OptionalFloat64
+	Details      OptionalString
+	Rating       OptionalInt
+	CoverSeconds OptionalFloat64
 	PrimaryTagID OptionalInt
 	SceneID      OptionalInt
 	CreatedAt    OptionalTime
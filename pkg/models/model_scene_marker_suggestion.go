@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// SceneMarkerSuggestion is a scene marker proposed by a task, such as the
+// funscript marker task, that has not yet been accepted by the user.
+type SceneMarkerSuggestion struct {
+	ID           int      `json:"id"`
+	SceneID      int      `json:"scene_id"`
+	Title        string   `json:"title"`
+	Seconds      float64  `json:"seconds"`
+	EndSeconds   *float64 `json:"end_seconds"`
+	PrimaryTagID int      `json:"primary_tag_id"`
+	// Source identifies the task that proposed the marker.
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSceneMarkerSuggestion() SceneMarkerSuggestion {
+	return SceneMarkerSuggestion{
+		CreatedAt: time.Now(),
+	}
+}
+
+// SceneMarker returns a new scene marker with the values of the suggestion.
+func (s SceneMarkerSuggestion) SceneMarker() SceneMarker {
+	ret := NewSceneMarker()
+	ret.SceneID = s.SceneID
+	ret.Title = s.Title
+	ret.Seconds = s.Seconds
+	ret.EndSeconds = s.EndSeconds
+	ret.PrimaryTagID = s.PrimaryTagID
+	return ret
+}
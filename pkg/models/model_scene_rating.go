@@ -0,0 +1,31 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// SceneRating is the rating of a scene by a single user.
+type SceneRating struct {
+	SceneID int `json:"scene_id"`
+	// Username is empty if authentication is not enabled.
+	Username  string    `json:"username"`
+	Rating    int       `json:"rating100"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type currentUsernameKey struct{}
+
+// WithCurrentUsername returns a context with the name of the current user,
+// which is used to resolve per-user values such as personal ratings.
+func WithCurrentUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, currentUsernameKey{}, username)
+}
+
+// CurrentUsername returns the name of the current user set in the context,
+// or an empty string if not set.
+func CurrentUsername(ctx context.Context) string {
+	ret, _ := ctx.Value(currentUsernameKey{}).(string)
+	return ret
+}
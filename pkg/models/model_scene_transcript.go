@@ -0,0 +1,41 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TranscriptSegment is a span of speech in a transcript.
+type TranscriptSegment struct {
+	// Start and End are in seconds from the start of the scene.
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+// SceneTranscript is the transcript of the audio of a scene.
+type SceneTranscript struct {
+	SceneID int `json:"scene_id"`
+	// Language is the detected or configured language, if known.
+	Language  string              `json:"language"`
+	Segments  []TranscriptSegment `json:"segments"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// TranscriptText returns the text of the segments joined with spaces.
+func TranscriptText(segments []TranscriptSegment) string {
+	var parts []string
+	for _, s := range segments {
+		if t := strings.TrimSpace(s.Text); t != "" {
+			parts = append(parts, t)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Text returns the full text of the transcript.
+func (t SceneTranscript) Text() string {
+	return TranscriptText(t.Segments)
+}
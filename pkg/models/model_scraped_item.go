@@ -13,7 +13,8 @@ type ScrapedStudio struct {
 	// Set if studio matched
 	StoredID     *string        `json:"stored_id"`
 	Name         string         `json:"name"`
-	URL          *string        `json:"url"`
+	URL          *string        `json:"url"` // deprecated
+	URLs         []string       `json:"urls"`
 	Parent       *ScrapedStudio `json:"parent"`
 	Image        *string        `json:"image"`
 	Images       []string       `json:"images"`
@@ -37,8 +38,8 @@ func (s *ScrapedStudio) ToStudio(endpoint string, excluded map[string]bool) *Stu
 		})
 	}
 
-	if s.URL != nil && !excluded["url"] {
-		ret.URL = *s.URL
+	if urls := s.urls(excluded); len(urls) > 0 {
+		ret.URLs = NewRelatedStrings(urls)
 	}
 
 	if s.Parent != nil && s.Parent.StoredID != nil && !excluded["parent"] && !excluded["parent_studio"] {
@@ -49,6 +50,23 @@ func (s *ScrapedStudio) ToStudio(endpoint string, excluded map[string]bool) *Stu
 	return &ret
 }
 
+// urls returns the scraped URLs of the studio. If URLs are provided, only
+// those are used.
+func (s *ScrapedStudio) urls(excluded map[string]bool) []string {
+	if len(s.URLs) > 0 {
+		if excluded["urls"] {
+			return nil
+		}
+		return s.URLs
+	}
+
+	if s.URL != nil && !excluded["url"] {
+		return []string{*s.URL}
+	}
+
+	return nil
+}
+
 func (s *ScrapedStudio) GetImage(ctx context.Context, excluded map[string]bool) ([]byte, error) {
 	// Process the base 64 encoded image string
 	if len(s.Images) > 0 && !excluded["image"] {
@@ -73,8 +91,12 @@ func (s *ScrapedStudio) ToPartial(id string, endpoint string, excluded map[strin
 		ret.Name = NewOptionalString(s.Name)
 	}
 
-	if s.URL != nil && !excluded["url"] {
-		ret.URL = NewOptionalString(*s.URL)
+	// scraped URLs are added to the existing URLs of the studio
+	if urls := s.urls(excluded); len(urls) > 0 {
+		ret.URLs = &UpdateStrings{
+			Values: urls,
+			Mode:   RelationshipUpdateModeAdd,
+		}
 	}
 
 	if s.Parent != nil && !excluded["parent"] {
@@ -184,13 +206,13 @@ func (p *ScrapedPerformer) ToPerformer(endpoint string, excluded map[string]bool
 		}
 	}
 	if p.Height != nil && !excluded["height"] {
-		h, err := strconv.Atoi(*p.Height)
+		h, err := ParseHeight(*p.Height)
 		if err == nil {
 			ret.Height = &h
 		}
 	}
 	if p.Weight != nil && !excluded["weight"] {
-		w, err := strconv.Atoi(*p.Weight)
+		w, err := ParseWeight(*p.Weight)
 		if err == nil {
 			ret.Weight = &w
 		}
@@ -317,13 +339,13 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 		ret.Gender = NewOptionalString(*p.Gender)
 	}
 	if p.Height != nil && !excluded["height"] {
-		h, err := strconv.Atoi(*p.Height)
+		h, err := ParseHeight(*p.Height)
 		if err == nil {
 			ret.Height = NewOptionalInt(h)
 		}
 	}
 	if p.Weight != nil && !excluded["weight"] {
-		w, err := strconv.Atoi(*p.Weight)
+		w, err := ParseWeight(*p.Weight)
 		if err == nil {
 			ret.Weight = NewOptionalInt(w)
 		}
@@ -347,12 +369,13 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 		ret.Tattoos = NewOptionalString(*p.Tattoos)
 	}
 
-	// if URLs are provided, only use those
+	// scraped URLs are added to the existing URLs of the performer.
+	// If URLs are provided, only use those
 	if len(p.URLs) > 0 {
 		if !excluded["urls"] {
 			ret.URLs = &UpdateStrings{
 				Values: p.URLs,
-				Mode:   RelationshipUpdateModeSet,
+				Mode:   RelationshipUpdateModeAdd,
 			}
 		}
 	} else {
@@ -370,7 +393,7 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 		if len(urls) > 0 {
 			ret.URLs = &UpdateStrings{
 				Values: urls,
-				Mode:   RelationshipUpdateModeSet,
+				Mode:   RelationshipUpdateModeAdd,
 			}
 		}
 	}
@@ -394,6 +417,10 @@ type ScrapedTag struct {
 	// Set if tag matched
 	StoredID *string `json:"stored_id"`
 	Name     string  `json:"name"`
+	// Name of the tag category
+	Category *string `json:"category"`
+	// Set if the tag category matched
+	CategoryID *string `json:"category_id"`
 }
 
 func (ScrapedTag) IsScrapedContent() {}
@@ -31,7 +31,7 @@ func Test_scrapedToStudioInput(t *testing.T) {
 			endpoint,
 			&Studio{
 				Name: name,
-				URL:  url,
+				URLs: NewRelatedStrings([]string{url}),
 				StashIDs: NewRelatedStashIDs([]StashID{
 					{
 						Endpoint: endpoint,
@@ -323,8 +323,11 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 			StudioPartial{
 				ID:       id,
 				Name:     NewOptionalString(name),
-				URL:      NewOptionalString(url),
 				ParentID: NewOptionalInt(parentStoredID),
+				URLs: &UpdateStrings{
+					Values: []string{url},
+					Mode:   RelationshipUpdateModeAdd,
+				},
 				StashIDs: &UpdateStashIDs{
 					StashIDs: append(existingStashIDs, StashID{
 						Endpoint: endpoint,
@@ -334,6 +337,23 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 				},
 			},
 		},
+		{
+			"urls",
+			ScrapedStudio{
+				URL:  &url,
+				URLs: []string{"url1", "url2"},
+			},
+			args{
+				id: idStr,
+			},
+			StudioPartial{
+				ID: id,
+				URLs: &UpdateStrings{
+					Values: []string{"url1", "url2"},
+					Mode:   RelationshipUpdateModeAdd,
+				},
+			},
+		},
 		{
 			"exclude all",
 			fullStudio,
@@ -383,3 +403,54 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapedPerformer_ToPartial_URLs(t *testing.T) {
+	url := "url"
+	twitter := "twitter"
+
+	tests := []struct {
+		name     string
+		o        ScrapedPerformer
+		excluded map[string]bool
+		want     *UpdateStrings
+	}{
+		{
+			"urls",
+			ScrapedPerformer{
+				URL:  &url,
+				URLs: []string{"url1", "url2"},
+			},
+			nil,
+			&UpdateStrings{
+				Values: []string{"url1", "url2"},
+				Mode:   RelationshipUpdateModeAdd,
+			},
+		},
+		{
+			"deprecated fields",
+			ScrapedPerformer{
+				URL:     &url,
+				Twitter: &twitter,
+			},
+			nil,
+			&UpdateStrings{
+				Values: []string{url, twitter},
+				Mode:   RelationshipUpdateModeAdd,
+			},
+		},
+		{
+			"excluded",
+			ScrapedPerformer{
+				URLs: []string{"url1"},
+			},
+			map[string]bool{"urls": true},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.o.ToPartial("", tt.excluded, nil)
+			assert.Equal(t, tt.want, got.URLs)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ShareLinkPreview is the metadata of the shared content that is shown when
+// a share link is unfurled by a chat app or other link preview.
+type ShareLinkPreview string
+
+const (
+	// ShareLinkPreviewNone shows no metadata.
+	ShareLinkPreviewNone ShareLinkPreview = "NONE"
+	// ShareLinkPreviewTitle shows the title of the shared content.
+	ShareLinkPreviewTitle ShareLinkPreview = "TITLE"
+	// ShareLinkPreviewFull shows the title, thumbnail and duration of the
+	// shared content.
+	ShareLinkPreviewFull ShareLinkPreview = "FULL"
+)
+
+var AllShareLinkPreview = []ShareLinkPreview{
+	ShareLinkPreviewNone,
+	ShareLinkPreviewTitle,
+	ShareLinkPreviewFull,
+}
+
+func (e ShareLinkPreview) IsValid() bool {
+	return slices.Contains(AllShareLinkPreview, e)
+}
+
+func (e ShareLinkPreview) String() string {
+	return string(e)
+}
+
+func (e *ShareLinkPreview) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShareLinkPreview(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShareLinkPreview", str)
+	}
+	return nil
+}
+
+func (e ShareLinkPreview) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ShareLink grants unauthenticated, read-only access to a single scene or
+// gallery via an unguessable token.
+type ShareLink struct {
+	ID        int    `json:"id"`
+	Token     string `json:"token"`
+	SceneID   *int   `json:"scene_id"`
+	GalleryID *int   `json:"gallery_id"`
+	// Password is the bcrypt hash of the share password, if set.
+	Password  string     `json:"-"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	MaxViews  *int       `json:"max_views"`
+	ViewCount int        `json:"view_count"`
+	// Preview is the metadata shown when the link is unfurled.
+	Preview   ShareLinkPreview `json:"preview"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func NewShareLink() ShareLink {
+	currentTime := time.Now()
+	return ShareLink{
+		Preview:   ShareLinkPreviewNone,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// HasPassword returns true if the share link requires a password.
+func (s ShareLink) HasPassword() bool {
+	return s.Password != ""
+}
+
+// IsExpired returns true if the share link has an expiry time that is
+// before the provided time.
+func (s ShareLink) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// ViewsExhausted returns true if the share link has a view limit and
+// the limit has been reached.
+func (s ShareLink) ViewsExhausted() bool {
+	return s.MaxViews != nil && s.ViewCount >= *s.MaxViews
+}
+
+// IsActive returns true if the share link can still be used to start a new view.
+func (s ShareLink) IsActive(now time.Time) bool {
+	return !s.IsExpired(now) && !s.ViewsExhausted()
+}
@@ -8,7 +8,6 @@ import (
 type Studio struct {
 	ID        int       `json:"id"`
 	Name      string    `json:"name"`
-	URL       string    `json:"url"`
 	ParentID  *int      `json:"parent_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -17,10 +16,23 @@ type Studio struct {
 	Favorite      bool   `json:"favorite"`
 	Details       string `json:"details"`
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
+	// FavoritedAt is when the studio was favorited. Nil if the studio is
+	// not a favorite.
+	FavoritedAt *time.Time `json:"favorited_at"`
+	// PropagateDefaults is true if the default metadata of the studio is
+	// also applied to scenes of its child studios.
+	PropagateDefaults bool `json:"propagate_defaults"`
 
 	Aliases  RelatedStrings  `json:"aliases"`
+	URLs     RelatedStrings  `json:"urls"`
 	TagIDs   RelatedIDs      `json:"tag_ids"`
 	StashIDs RelatedStashIDs `json:"stash_ids"`
+
+	// DefaultTagIDs are added to scenes when they are attributed to the studio.
+	DefaultTagIDs RelatedIDs `json:"default_tag_ids"`
+	// DefaultURLs are URL patterns added to scenes when they are attributed
+	// to the studio. See scene.ExpandStudioDefaultURL.
+	DefaultURLs RelatedStrings `json:"default_urls"`
 }
 
 func NewStudio() Studio {
@@ -35,7 +47,6 @@ func NewStudio() Studio {
 type StudioPartial struct {
 	ID       int
 	Name     OptionalString
-	URL      OptionalString
 	ParentID OptionalInt
 	// Rating expressed in 1-100 scale
 	Rating        OptionalInt
@@ -44,10 +55,16 @@ type StudioPartial struct {
 	CreatedAt     OptionalTime
 	UpdatedAt     OptionalTime
 	IgnoreAutoTag OptionalBool
+	// FavoritedAt is set when Favorite is changed if not set explicitly
+	FavoritedAt       OptionalTime
+	PropagateDefaults OptionalBool
 
-	Aliases  *UpdateStrings
-	TagIDs   *UpdateIDs
-	StashIDs *UpdateStashIDs
+	Aliases       *UpdateStrings
+	URLs          *UpdateStrings
+	TagIDs        *UpdateIDs
+	StashIDs      *UpdateStashIDs
+	DefaultTagIDs *UpdateIDs
+	DefaultURLs   *UpdateStrings
 }
 
 func NewStudioPartial() StudioPartial {
@@ -63,6 +80,12 @@ func (s *Studio) LoadAliases(ctx context.Context, l AliasLoader) error {
 	})
 }
 
+func (s *Studio) LoadURLs(ctx context.Context, l URLLoader) error {
+	return s.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, s.ID)
+	})
+}
+
 func (s *Studio) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	return s.TagIDs.load(func() ([]int, error) {
 		return l.GetTagIDs(ctx, s.ID)
@@ -75,6 +98,18 @@ func (s *Studio) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 	})
 }
 
+func (s *Studio) LoadDefaultTagIDs(ctx context.Context, l StudioDefaultsLoader) error {
+	return s.DefaultTagIDs.load(func() ([]int, error) {
+		return l.GetDefaultTagIDs(ctx, s.ID)
+	})
+}
+
+func (s *Studio) LoadDefaultURLs(ctx context.Context, l StudioDefaultsLoader) error {
+	return s.DefaultURLs.load(func() ([]string, error) {
+		return l.GetDefaultURLs(ctx, s.ID)
+	})
+}
+
 func (s *Studio) LoadRelationships(ctx context.Context, l PerformerReader) error {
 	if err := s.LoadAliases(ctx, l); err != nil {
 		return err
@@ -0,0 +1,35 @@
+package models
+
+// SyncObjectType is the type of object in the sync change feed. The values
+// are recorded against deleted objects in the database.
+type SyncObjectType string
+
+const (
+	SyncObjectTypeScene       SyncObjectType = "scene"
+	SyncObjectTypeImage       SyncObjectType = "image"
+	SyncObjectTypeGallery     SyncObjectType = "gallery"
+	SyncObjectTypePerformer   SyncObjectType = "performer"
+	SyncObjectTypeStudio      SyncObjectType = "studio"
+	SyncObjectTypeTag         SyncObjectType = "tag"
+	SyncObjectTypeGroup       SyncObjectType = "group"
+	SyncObjectTypeSceneMarker SyncObjectType = "scene_marker"
+)
+
+var AllSyncObjectType = []SyncObjectType{
+	SyncObjectTypeScene,
+	SyncObjectTypeImage,
+	SyncObjectTypeGallery,
+	SyncObjectTypePerformer,
+	SyncObjectTypeStudio,
+	SyncObjectTypeTag,
+	SyncObjectTypeGroup,
+	SyncObjectTypeSceneMarker,
+}
+
+// SyncChanges contains the IDs of the objects of a type that were created,
+// updated or deleted since a point in time.
+type SyncChanges struct {
+	Created []int
+	Updated []int
+	Deleted []int
+}
@@ -6,13 +6,20 @@ import (
 )
 
 type Tag struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	Favorite      bool      `json:"favorite"`
-	Description   string    `json:"description"`
-	IgnoreAutoTag bool      `json:"ignore_auto_tag"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Favorite      bool   `json:"favorite"`
+	Description   string `json:"description"`
+	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
+	// Restricted tags hide the objects they are applied to from sessions
+	// that have not unlocked restricted content.
+	Restricted bool      `json:"restricted"`
+	CategoryID *int      `json:"category_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// FavoritedAt is when the tag was favorited. Nil if the tag is not a
+	// favorite.
+	FavoritedAt *time.Time `json:"favorited_at"`
 
 	Aliases   RelatedStrings `json:"aliases"`
 	ParentIDs RelatedIDs     `json:"parent_ids"`
@@ -50,8 +57,12 @@ type TagPartial struct {
 	Description   OptionalString
 	Favorite      OptionalBool
 	IgnoreAutoTag OptionalBool
+	Restricted    OptionalBool
+	CategoryID    OptionalInt
 	CreatedAt     OptionalTime
 	UpdatedAt     OptionalTime
+	// FavoritedAt is set when Favorite is changed if not set explicitly
+	FavoritedAt OptionalTime
 
 	Aliases   *UpdateStrings
 	ParentIDs *UpdateIDs
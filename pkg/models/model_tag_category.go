@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// TagCategory is a namespace that tags belong to, such as "Location" or
+// "Hair Colour".
+type TagCategory struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Categories are displayed in ascending sort order, then by name.
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewTagCategory() TagCategory {
+	currentTime := time.Now()
+	return TagCategory{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// TagCategoryPartial represents part of a TagCategory object.
+// It is used to update the database entry.
+type TagCategoryPartial struct {
+	Name        OptionalString
+	Description OptionalString
+	SortOrder   OptionalInt
+	CreatedAt   OptionalTime
+	UpdatedAt   OptionalTime
+}
+
+func NewTagCategoryPartial() TagCategoryPartial {
+	currentTime := time.Now()
+	return TagCategoryPartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
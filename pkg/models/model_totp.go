@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// TOTPCredential is the two-factor authentication credential of a user.
+type TOTPCredential struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	// Secret is the base32 encoded shared secret.
+	Secret  string `json:"-"`
+	Enabled bool   `json:"enabled"`
+	// LastUsedStep is the time step of the last accepted code, used to
+	// prevent codes from being reused.
+	LastUsedStep int64 `json:"last_used_step"`
+	// SessionVersion is incremented when the two-factor configuration
+	// changes, invalidating existing sessions.
+	SessionVersion int       `json:"session_version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NewTOTPCredential() TOTPCredential {
+	currentTime := time.Now()
+	return TOTPCredential{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// TOTPRecoveryCode is a single use code that may be used in place of a
+// one-time password.
+type TOTPRecoveryCode struct {
+	ID           int `json:"id"`
+	CredentialID int `json:"credential_id"`
+	// CodeHash is the hash of the recovery code.
+	CodeHash string     `json:"-"`
+	UsedAt   *time.Time `json:"used_at"`
+}
+
+// IsUsed returns true if the recovery code has been used.
+func (c TOTPRecoveryCode) IsUsed() bool {
+	return c.UsedAt != nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// URLCheckStatus is the result of the last check of a url.
+type URLCheckStatus string
+
+const (
+	// URLCheckStatusOK is a url that responded successfully.
+	URLCheckStatusOK URLCheckStatus = "OK"
+	// URLCheckStatusChanged is a url that responded successfully, and
+	// scraped metadata that differs from the stored metadata.
+	URLCheckStatusChanged URLCheckStatus = "CHANGED"
+	// URLCheckStatusRedirected is a url that redirects to another url.
+	URLCheckStatusRedirected URLCheckStatus = "REDIRECTED"
+	// URLCheckStatusDead is a url that no longer exists.
+	URLCheckStatusDead URLCheckStatus = "DEAD"
+	// URLCheckStatusError is a url that could not be requested, or that
+	// responded with an error other than not found.
+	URLCheckStatusError URLCheckStatus = "ERROR"
+)
+
+var AllURLCheckStatus = []URLCheckStatus{
+	URLCheckStatusOK,
+	URLCheckStatusChanged,
+	URLCheckStatusRedirected,
+	URLCheckStatusDead,
+	URLCheckStatusError,
+}
+
+func (e URLCheckStatus) IsValid() bool {
+	return slices.Contains(AllURLCheckStatus, e)
+}
+
+func (e URLCheckStatus) String() string {
+	return string(e)
+}
+
+func (e *URLCheckStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = URLCheckStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid URLCheckStatus", str)
+	}
+	return nil
+}
+
+func (e URLCheckStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// URLCheckStatusFromCode returns the status of a url that responded with
+// the given HTTP status code.
+func URLCheckStatusFromCode(code int) URLCheckStatus {
+	switch {
+	case code >= 200 && code < 300:
+		return URLCheckStatusOK
+	case code >= 300 && code < 400:
+		return URLCheckStatusRedirected
+	case code == http.StatusNotFound, code == http.StatusGone, code == http.StatusUnavailableForLegalReasons:
+		return URLCheckStatusDead
+	default:
+		return URLCheckStatusError
+	}
+}
+
+type URLCheckStatusCriterionInput struct {
+	Value    []URLCheckStatus  `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
+
+// URLStatus is the result of the last check of a scene or performer url.
+type URLStatus struct {
+	URL    string         `json:"url"`
+	Status URLCheckStatus `json:"status"`
+	// StatusCode is the HTTP status code of the response. Nil if the url
+	// could not be requested.
+	StatusCode *int `json:"status_code"`
+	// RedirectURL is the url redirected to, if the url redirects.
+	RedirectURL string `json:"redirect_url"`
+	// ContentHash is the hash of the content of the last successful
+	// response.
+	ContentHash string `json:"-"`
+	// Error is the reason the url could not be requested.
+	Error     string    `json:"error"`
+	CheckedAt time.Time `json:"checked_at"`
+	// ChangedAt is the time the content of the url was last seen to change.
+	ChangedAt *time.Time `json:"changed_at"`
+}
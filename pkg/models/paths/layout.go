@@ -0,0 +1,116 @@
+package paths
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// Layout is the directory layout of the generated files of scenes.
+type Layout string
+
+const (
+	// LayoutFlat stores each type of generated file in a single directory.
+	LayoutFlat Layout = "FLAT"
+	// LayoutSharded stores each type of generated file in subdirectories of
+	// its directory, named after the first characters of the scene hash.
+	LayoutSharded Layout = "SHARDED"
+	// LayoutScene stores the generated files of each scene in a folder of
+	// its own.
+	LayoutScene Layout = "SCENE"
+)
+
+var AllLayout = []Layout{
+	LayoutFlat,
+	LayoutSharded,
+	LayoutScene,
+}
+
+func (e Layout) IsValid() bool {
+	return slices.Contains(AllLayout, e)
+}
+
+func (e Layout) String() string {
+	return string(e)
+}
+
+func (e *Layout) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Layout(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GeneratedLayout", str)
+	}
+	return nil
+}
+
+func (e Layout) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+const (
+	shardDirDepth  int = 2
+	shardDirLength int = 2
+	sceneDirDepth  int = 1
+)
+
+// sceneFilePath returns the path of a generated scene file. dir and name are
+// the directory and file name of the file in the flat layout, and sceneName
+// is the file name in the scene folder of the scene layout.
+func (gp *generatedPaths) sceneFilePath(dir string, name string, sceneName string, checksum string) string {
+	switch gp.Layout {
+	case LayoutSharded:
+		return filepath.Join(dir, fsutil.GetIntraDir(checksum, shardDirDepth, shardDirLength), name)
+	case LayoutScene:
+		return filepath.Join(gp.GetSceneFolderPath(checksum), sceneName)
+	default:
+		return filepath.Join(dir, name)
+	}
+}
+
+// GetSceneFolderPath returns the folder of the generated files of the scene
+// with the given hash in the scene layout.
+func (gp *generatedPaths) GetSceneFolderPath(checksum string) string {
+	return filepath.Join(gp.Scenes, fsutil.GetIntraDir(checksum, sceneDirDepth, shardDirLength), checksum)
+}
+
+// IsShardDir returns true if path is a directory that the layout creates
+// under root to spread the files of root, rather than a directory of a
+// single scene.
+func (gp *generatedPaths) IsShardDir(root string, path string) bool {
+	var depth int
+	switch gp.Layout {
+	case LayoutSharded:
+		depth = shardDirDepth
+	case LayoutScene:
+		depth = sceneDirDepth
+	default:
+		return false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) > depth {
+		return false
+	}
+
+	for _, p := range parts {
+		if len(p) != shardDirLength {
+			return false
+		}
+	}
+
+	return true
+}
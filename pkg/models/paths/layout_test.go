@@ -0,0 +1,78 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenePaths_Layout(t *testing.T) {
+	const hash = "0123456789abcdef"
+	const generated = "generated"
+
+	tests := []struct {
+		layout     Layout
+		preview    string
+		sprite     string
+		transcode  string
+		markerPath string
+	}{
+		{
+			LayoutFlat,
+			filepath.Join(generated, "screenshots", hash+".mp4"),
+			filepath.Join(generated, "vtt", hash+"_sprite.jpg"),
+			filepath.Join(generated, "transcodes", hash+".mp4"),
+			filepath.Join(generated, "markers", hash),
+		},
+		{
+			LayoutSharded,
+			filepath.Join(generated, "screenshots", "01", "23", hash+".mp4"),
+			filepath.Join(generated, "vtt", "01", "23", hash+"_sprite.jpg"),
+			filepath.Join(generated, "transcodes", "01", "23", hash+".mp4"),
+			filepath.Join(generated, "markers", "01", "23", hash),
+		},
+		{
+			LayoutScene,
+			filepath.Join(generated, "scenes", "01", hash, "preview.mp4"),
+			filepath.Join(generated, "scenes", "01", hash, hash+"_sprite.jpg"),
+			filepath.Join(generated, "scenes", "01", hash, "transcode.mp4"),
+			filepath.Join(generated, "scenes", "01", hash, "markers"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout.String(), func(t *testing.T) {
+			p := NewPaths(generated, "", tt.layout)
+
+			assert.Equal(t, tt.preview, p.Scene.GetVideoPreviewPath(hash))
+			assert.Equal(t, tt.sprite, p.Scene.GetSpriteImageFilePath(hash))
+			assert.Equal(t, tt.transcode, p.Scene.GetTranscodePath(hash))
+			assert.Equal(t, tt.markerPath, p.SceneMarkers.GetFolderPath(hash))
+		})
+	}
+}
+
+func TestGeneratedPaths_IsShardDir(t *testing.T) {
+	root := filepath.Join("generated", "markers")
+
+	tests := []struct {
+		layout Layout
+		path   string
+		want   bool
+	}{
+		{LayoutFlat, filepath.Join(root, "01"), false},
+		{LayoutSharded, root, false},
+		{LayoutSharded, filepath.Join(root, "01"), true},
+		{LayoutSharded, filepath.Join(root, "01", "23"), true},
+		{LayoutSharded, filepath.Join(root, "01", "23", "0123456789abcdef"), false},
+		{LayoutSharded, filepath.Join("generated", "vtt", "01"), false},
+		{LayoutScene, filepath.Join(root, "01"), true},
+		{LayoutScene, filepath.Join(root, "01", "23"), false},
+	}
+
+	for _, tt := range tests {
+		p := NewPaths("generated", "", tt.layout)
+		assert.Equal(t, tt.want, p.Generated.IsShardDir(root, tt.path), "%s %s", tt.layout, tt.path)
+	}
+}
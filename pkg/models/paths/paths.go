@@ -15,9 +15,11 @@ type Paths struct {
 	Blobs        string
 }
 
-func NewPaths(generatedPath string, blobsPath string) Paths {
+// NewPaths returns the paths of the given directories. layout is the layout
+// of the generated scene files.
+func NewPaths(generatedPath string, blobsPath string, layout Layout) Paths {
 	p := Paths{}
-	p.Generated = newGeneratedPaths(generatedPath)
+	p.Generated = newGeneratedPaths(generatedPath, layout)
 
 	p.Scene = newScenePaths(p)
 	p.SceneMarkers = newSceneMarkerPaths(p)
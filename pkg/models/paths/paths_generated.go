@@ -21,10 +21,17 @@ type generatedPaths struct {
 	Downloads          string
 	Tmp                string
 	InteractiveHeatmap string
+	Heatmaps           string
+	// Scenes contains the scene folders of the scene layout.
+	Scenes string
+
+	Layout Layout
 }
 
-func newGeneratedPaths(path string) *generatedPaths {
-	gp := generatedPaths{}
+func newGeneratedPaths(path string, layout Layout) *generatedPaths {
+	gp := generatedPaths{
+		Layout: layout,
+	}
 	gp.Screenshots = filepath.Join(path, "screenshots")
 	gp.Thumbnails = filepath.Join(path, "thumbnails")
 	gp.Vtt = filepath.Join(path, "vtt")
@@ -33,6 +40,8 @@ func newGeneratedPaths(path string) *generatedPaths {
 	gp.Downloads = filepath.Join(path, "download_stage")
 	gp.Tmp = filepath.Join(path, "tmp")
 	gp.InteractiveHeatmap = filepath.Join(path, "interactive_heatmaps")
+	gp.Heatmaps = filepath.Join(path, "heatmaps")
+	gp.Scenes = filepath.Join(path, "scenes")
 	return &gp
 }
 
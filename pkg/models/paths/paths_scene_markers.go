@@ -16,8 +16,10 @@ func newSceneMarkerPaths(p Paths) *sceneMarkerPaths {
 	return &sp
 }
 
+// GetFolderPath returns the folder of the generated marker files of the
+// scene with the given hash.
 func (sp *sceneMarkerPaths) GetFolderPath(checksum string) string {
-	return filepath.Join(sp.Markers, checksum)
+	return sp.sceneFilePath(sp.Markers, checksum, "markers", checksum)
 }
 
 func (sp *sceneMarkerPaths) GetVideoPreviewPath(checksum string, seconds int) string {
@@ -22,7 +22,7 @@ func (sp *scenePaths) GetLegacyScreenshotPath(checksum string) string {
 }
 
 func (sp *scenePaths) GetTranscodePath(checksum string) string {
-	return filepath.Join(sp.Transcodes, checksum+".mp4")
+	return sp.sceneFilePath(sp.Transcodes, checksum+".mp4", "transcode.mp4", checksum)
 }
 
 func (sp *scenePaths) GetStreamPath(scenePath string, checksum string) string {
@@ -35,21 +35,37 @@ func (sp *scenePaths) GetStreamPath(scenePath string, checksum string) string {
 }
 
 func (sp *scenePaths) GetVideoPreviewPath(checksum string) string {
-	return filepath.Join(sp.Screenshots, checksum+".mp4")
+	return sp.sceneFilePath(sp.Screenshots, checksum+".mp4", "preview.mp4", checksum)
 }
 
 func (sp *scenePaths) GetWebpPreviewPath(checksum string) string {
-	return filepath.Join(sp.Screenshots, checksum+".webp")
+	return sp.sceneFilePath(sp.Screenshots, checksum+".webp", "preview.webp", checksum)
 }
 
+// The sprite image keeps its name in the scene layout, since it is
+// referenced by name from the sprite VTT file.
 func (sp *scenePaths) GetSpriteImageFilePath(checksum string) string {
-	return filepath.Join(sp.Vtt, checksum+"_sprite.jpg")
+	name := checksum + "_sprite.jpg"
+	return sp.sceneFilePath(sp.Vtt, name, name, checksum)
 }
 
 func (sp *scenePaths) GetSpriteVttFilePath(checksum string) string {
-	return filepath.Join(sp.Vtt, checksum+"_thumbs.vtt")
+	name := checksum + "_thumbs.vtt"
+	return sp.sceneFilePath(sp.Vtt, name, name, checksum)
+}
+
+func (sp *scenePaths) GetBarcodeFilePath(checksum string) string {
+	return sp.sceneFilePath(sp.Vtt, checksum+"_barcode.png", "barcode.png", checksum)
 }
 
 func (sp *scenePaths) GetInteractiveHeatmapPath(checksum string) string {
-	return filepath.Join(sp.InteractiveHeatmap, checksum+".png")
+	return sp.sceneFilePath(sp.InteractiveHeatmap, checksum+".png", "interactive_heatmap.png", checksum)
+}
+
+func (sp *scenePaths) GetHeatmapPath(checksum string) string {
+	return sp.sceneFilePath(sp.Heatmaps, checksum+".png", "heatmap.png", checksum)
+}
+
+func (sp *scenePaths) GetHeatmapDataPath(checksum string) string {
+	return sp.sceneFilePath(sp.Heatmaps, checksum+".json", "heatmap.json", checksum)
 }
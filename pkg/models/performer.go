@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 )
 
 type GenderEnum string
@@ -130,6 +131,18 @@ type PerformerFilterType struct {
 	HeightCm *IntCriterionInput `json:"height_cm"`
 	// Filter by measurements
 	Measurements *StringCriterionInput `json:"measurements"`
+	// Filter by bust in the given units
+	Bust *MeasurementCriterionInput `json:"bust"`
+	// Filter by waist in the given units
+	Waist *MeasurementCriterionInput `json:"waist"`
+	// Filter by hips in the given units
+	Hips *MeasurementCriterionInput `json:"hips"`
+	// Filter by cup size
+	CupSize *StringCriterionInput `json:"cup_size"`
+	// Filter by height in the given units
+	HeightInUnits *MeasurementCriterionInput `json:"height_in_units"`
+	// Filter by weight in the given units
+	WeightInUnits *MeasurementCriterionInput `json:"weight_in_units"`
 	// Filter by fake tits value
 	FakeTits *StringCriterionInput `json:"fake_tits"`
 	// Filter by penis length value
@@ -170,6 +183,10 @@ type PerformerFilterType struct {
 	Rating100 *IntCriterionInput `json:"rating100"`
 	// Filter by url
 	URL *StringCriterionInput `json:"url"`
+	// Filter by the statuses of the last checks of the urls
+	URLStatus *URLCheckStatusCriterionInput `json:"url_status"`
+	// Filter by urls of a type
+	TypedURL *TypedURLCriterionInput `json:"typed_url"`
 	// Filter by hair color
 	HairColor *StringCriterionInput `json:"hair_color"`
 	// Filter by weight
@@ -198,6 +215,8 @@ type PerformerFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by the time that the performer was favorited
+	FavoritedAt *TimestampCriterionInput `json:"favorited_at"`
 
 	// Filter by custom fields
 	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
@@ -208,6 +227,7 @@ type PerformerCreateInput struct {
 	Disambiguation *string         `json:"disambiguation"`
 	URL            *string         `json:"url"` // deprecated
 	Urls           []string        `json:"urls"`
+	TypedUrls      []TypedURL      `json:"typed_urls"`
 	Gender         *GenderEnum     `json:"gender"`
 	Birthdate      *string         `json:"birthdate"`
 	Ethnicity      *string         `json:"ethnicity"`
@@ -242,31 +262,36 @@ type PerformerCreateInput struct {
 }
 
 type PerformerUpdateInput struct {
-	ID             string          `json:"id"`
-	Name           *string         `json:"name"`
-	Disambiguation *string         `json:"disambiguation"`
-	URL            *string         `json:"url"` // deprecated
-	Urls           []string        `json:"urls"`
-	Gender         *GenderEnum     `json:"gender"`
-	Birthdate      *string         `json:"birthdate"`
-	Ethnicity      *string         `json:"ethnicity"`
-	Country        *string         `json:"country"`
-	EyeColor       *string         `json:"eye_color"`
-	Height         *string         `json:"height"`
-	HeightCm       *int            `json:"height_cm"`
-	Measurements   *string         `json:"measurements"`
-	FakeTits       *string         `json:"fake_tits"`
-	PenisLength    *float64        `json:"penis_length"`
-	Circumcised    *CircumisedEnum `json:"circumcised"`
-	CareerLength   *string         `json:"career_length"`
-	Tattoos        *string         `json:"tattoos"`
-	Piercings      *string         `json:"piercings"`
-	Aliases        *string         `json:"aliases"`
-	AliasList      []string        `json:"alias_list"`
-	Twitter        *string         `json:"twitter"`   // deprecated
-	Instagram      *string         `json:"instagram"` // deprecated
-	Favorite       *bool           `json:"favorite"`
-	TagIds         []string        `json:"tag_ids"`
+	ID string `json:"id"`
+	// ExpectedUpdatedAt is the updated at time of the object as last read by
+	// the client. If set and the object has since been modified, the update
+	// fails with a conflict error.
+	ExpectedUpdatedAt *time.Time      `json:"expected_updated_at"`
+	Name              *string         `json:"name"`
+	Disambiguation    *string         `json:"disambiguation"`
+	URL               *string         `json:"url"` // deprecated
+	Urls              []string        `json:"urls"`
+	TypedUrls         []TypedURL      `json:"typed_urls"`
+	Gender            *GenderEnum     `json:"gender"`
+	Birthdate         *string         `json:"birthdate"`
+	Ethnicity         *string         `json:"ethnicity"`
+	Country           *string         `json:"country"`
+	EyeColor          *string         `json:"eye_color"`
+	Height            *string         `json:"height"`
+	HeightCm          *int            `json:"height_cm"`
+	Measurements      *string         `json:"measurements"`
+	FakeTits          *string         `json:"fake_tits"`
+	PenisLength       *float64        `json:"penis_length"`
+	Circumcised       *CircumisedEnum `json:"circumcised"`
+	CareerLength      *string         `json:"career_length"`
+	Tattoos           *string         `json:"tattoos"`
+	Piercings         *string         `json:"piercings"`
+	Aliases           *string         `json:"aliases"`
+	AliasList         []string        `json:"alias_list"`
+	Twitter           *string         `json:"twitter"`   // deprecated
+	Instagram         *string         `json:"instagram"` // deprecated
+	Favorite          *bool           `json:"favorite"`
+	TagIds            []string        `json:"tag_ids"`
 	// This should be a URL or a base64 encoded data URL
 	Image         *string        `json:"image"`
 	StashIds      []StashIDInput `json:"stash_ids"`
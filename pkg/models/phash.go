@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// PhashDistanceMetric is the metric used to compare phashes.
+type PhashDistanceMetric string
+
+const (
+	// PhashDistanceMetricHamming is the number of differing bits.
+	PhashDistanceMetricHamming PhashDistanceMetric = "HAMMING"
+	// PhashDistanceMetricWeighted weights differing low frequency bits more
+	// than high frequency bits.
+	PhashDistanceMetricWeighted PhashDistanceMetric = "WEIGHTED"
+)
+
+var AllPhashDistanceMetric = []PhashDistanceMetric{
+	PhashDistanceMetricHamming,
+	PhashDistanceMetricWeighted,
+}
+
+func (e PhashDistanceMetric) IsValid() bool {
+	return slices.Contains(AllPhashDistanceMetric, e)
+}
+
+func (e PhashDistanceMetric) String() string {
+	return string(e)
+}
+
+func (e *PhashDistanceMetric) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PhashDistanceMetric(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PhashDistanceMetric", str)
+	}
+	return nil
+}
+
+func (e PhashDistanceMetric) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// DistanceFunc returns the function that computes the metric. Defaults to
+// the hamming distance.
+func (e PhashDistanceMetric) DistanceFunc() utils.PhashDistanceFunc {
+	if e == PhashDistanceMetricWeighted {
+		return utils.WeightedDistance
+	}
+	return utils.HammingDistance
+}
+
+// PhashDuplicateOptions are the options used to find phash duplicates.
+type PhashDuplicateOptions struct {
+	// FingerprintType is the type of phash fingerprint compared. Defaults to
+	// FingerprintTypePhash.
+	FingerprintType string
+	// Metric is the distance metric. Defaults to hamming distance.
+	Metric PhashDistanceMetric
+}
+
+// GetFingerprintType returns the fingerprint type, or the default phash type
+// if not set.
+func (o PhashDuplicateOptions) GetFingerprintType() string {
+	if o.FingerprintType == "" {
+		return FingerprintTypePhash
+	}
+	return o.FingerprintType
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"slices"
 	"strconv"
 )
 
@@ -67,3 +68,53 @@ func Rating100To5(rating100 int) int {
 func Rating5To100(rating5 int) int {
 	return int(math.Max(minRating100, math.Min(maxRating100, float64(rating5*20))))
 }
+
+// RatingStats aggregates the ratings of an object by all users.
+type RatingStats struct {
+	// Mean is nil if there are no ratings
+	Mean *float64 `json:"mean"`
+	// Median is nil if there are no ratings
+	Median *float64 `json:"median"`
+	Count  int      `json:"count"`
+}
+
+// NewRatingStats returns the aggregate of the provided 1-100 ratings.
+func NewRatingStats(ratings []int) RatingStats {
+	ret := RatingStats{
+		Count: len(ratings),
+	}
+
+	if len(ratings) == 0 {
+		return ret
+	}
+
+	sorted := slices.Clone(ratings)
+	slices.Sort(sorted)
+
+	sum := 0
+	for _, r := range sorted {
+		sum += r
+	}
+	mean := float64(sum) / float64(len(sorted))
+	ret.Mean = &mean
+
+	mid := len(sorted) / 2
+	median := float64(sorted[mid])
+	if len(sorted)%2 == 0 {
+		median = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	ret.Median = &median
+
+	return ret
+}
+
+// AggregateRating returns the mean of the ratings rounded to the nearest
+// integer, or nil if there are no ratings.
+func (s RatingStats) AggregateRating() *int {
+	if s.Mean == nil {
+		return nil
+	}
+
+	ret := int(math.Round(*s.Mean))
+	return &ret
+}
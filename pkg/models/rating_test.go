@@ -2,6 +2,8 @@ package models
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRating100To5(t *testing.T) {
@@ -53,3 +55,32 @@ func TestRating5To100(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRatingStats(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	i := func(v int) *int { return &v }
+
+	tests := []struct {
+		name          string
+		ratings       []int
+		wantMean      *float64
+		wantMedian    *float64
+		wantAggregate *int
+	}{
+		{"none", nil, nil, nil, nil},
+		{"single", []int{60}, f(60), f(60), i(60)},
+		{"odd", []int{100, 20, 60}, f(60), f(60), i(60)},
+		{"even", []int{80, 20, 40, 100}, f(60), f(60), i(60)},
+		{"skewed", []int{10, 20, 90}, f(40), f(20), i(40)},
+		{"rounded", []int{50, 51}, f(50.5), f(50.5), i(51)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRatingStats(tt.ratings)
+			assert.Equal(t, len(tt.ratings), got.Count)
+			assert.Equal(t, tt.wantMean, got.Mean)
+			assert.Equal(t, tt.wantMedian, got.Median)
+			assert.Equal(t, tt.wantAggregate, got.AggregateRating())
+		})
+	}
+}
@@ -31,10 +31,41 @@ type TagRelationLoader interface {
 	GetChildIDs(ctx context.Context, relatedID int) ([]int, error)
 }
 
+// StudioDefaultsLoader loads the metadata applied to scenes attributed to a
+// studio.
+type StudioDefaultsLoader interface {
+	GetDefaultTagIDs(ctx context.Context, relatedID int) ([]int, error)
+	GetDefaultURLs(ctx context.Context, relatedID int) ([]string, error)
+}
+
 type FileIDLoader interface {
 	GetManyFileIDs(ctx context.Context, ids []int) ([][]FileID, error)
 }
 
+// ManySceneIDLoader loads the related scene IDs of multiple objects, in the
+// same order as ids.
+type ManySceneIDLoader interface {
+	GetManySceneIDs(ctx context.Context, ids []int) ([][]int, error)
+}
+
+// ManyGalleryIDLoader loads the related gallery IDs of multiple objects, in
+// the same order as ids.
+type ManyGalleryIDLoader interface {
+	GetManyGalleryIDs(ctx context.Context, ids []int) ([][]int, error)
+}
+
+// ManyPerformerIDLoader loads the related performer IDs of multiple objects,
+// in the same order as ids.
+type ManyPerformerIDLoader interface {
+	GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error)
+}
+
+// ManyTagIDLoader loads the related tag IDs of multiple objects, in the same
+// order as ids.
+type ManyTagIDLoader interface {
+	GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error)
+}
+
 type SceneGroupLoader interface {
 	GetGroups(ctx context.Context, id int) ([]GroupsScenes, error)
 }
@@ -67,6 +98,17 @@ type URLLoader interface {
 	GetURLs(ctx context.Context, relatedID int) ([]string, error)
 }
 
+type TypedURLLoader interface {
+	// GetTypedURLs returns the URLs of the object in order, with their types.
+	GetTypedURLs(ctx context.Context, relatedID int) ([]TypedURL, error)
+}
+
+type URLTypeSetter interface {
+	// SetURLTypes sets the types of the URLs of the object, keyed by URL.
+	// URLs that the object does not have are ignored.
+	SetURLTypes(ctx context.Context, relatedID int, types map[string]*URLType) error
+}
+
 // RelatedIDs represents a list of related IDs.
 // TODO - this can be made generic
 type RelatedIDs struct {
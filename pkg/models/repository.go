@@ -14,19 +14,30 @@ type TxnManager interface {
 type Repository struct {
 	TxnManager TxnManager
 
-	Blob           BlobReader
-	File           FileReaderWriter
-	Folder         FolderReaderWriter
-	Gallery        GalleryReaderWriter
-	GalleryChapter GalleryChapterReaderWriter
-	Image          ImageReaderWriter
-	Group          GroupReaderWriter
-	Performer      PerformerReaderWriter
-	Scene          SceneReaderWriter
-	SceneMarker    SceneMarkerReaderWriter
-	Studio         StudioReaderWriter
-	Tag            TagReaderWriter
-	SavedFilter    SavedFilterReaderWriter
+	Blob              BlobReader
+	File              FileReaderWriter
+	Folder            FolderReaderWriter
+	Quarantine        QuarantinedFileReaderWriter
+	Gallery           GalleryReaderWriter
+	GalleryChapter    GalleryChapterReaderWriter
+	Image             ImageReaderWriter
+	ImageRegion       ImageRegionReaderWriter
+	Group             GroupReaderWriter
+	Performer         PerformerReaderWriter
+	Scene             SceneReaderWriter
+	SceneMarker       SceneMarkerReaderWriter
+	Suggestion        SceneMarkerSuggestionReaderWriter
+	Studio            StudioReaderWriter
+	Tag               TagReaderWriter
+	TagCategory       TagCategoryReaderWriter
+	SavedFilter       SavedFilterReaderWriter
+	SavedFilterFolder SavedFilterFolderReaderWriter
+	DefaultFilter     DefaultFilterReaderWriter
+	ShareLink         ShareLinkReaderWriter
+	Note              NoteReaderWriter
+	TOTP              TOTPReaderWriter
+	Sync              SyncReader
+	URLStatus         URLStatusReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
@@ -6,3 +6,23 @@ import "context"
 type BlobReader interface {
 	EntryExists(ctx context.Context, checksum string) (bool, error)
 }
+
+// PhashMatch is an object whose image matched a perceptual hash.
+type PhashMatch struct {
+	ID       int `db:"id"`
+	Distance int `db:"distance"`
+}
+
+// ImagePhashReaderWriter provides methods to maintain and search the
+// perceptual hashes of the image of an object, such as a scene cover.
+type ImagePhashReaderWriter interface {
+	// FindMissingImagePhashes returns the ids of up to limit objects with an
+	// image that has not been hashed.
+	FindMissingImagePhashes(ctx context.Context, limit int) ([]int, error)
+	// UpdateImagePhash sets the phash of the current image of the object. A
+	// nil phash records that the image could not be hashed.
+	UpdateImagePhash(ctx context.Context, id int, phash *int64) error
+	// FindByImagePhash returns the objects with an image within distance of
+	// phash, ordered by distance.
+	FindByImagePhash(ctx context.Context, phash int64, distance int) ([]PhashMatch, error)
+}
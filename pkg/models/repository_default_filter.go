@@ -0,0 +1,25 @@
+package models
+
+import "context"
+
+// DefaultFilterFinder provides methods to find default filters.
+type DefaultFilterFinder interface {
+	// FindByUsername returns the default filters of the user, ordered by mode.
+	FindByUsername(ctx context.Context, username string) ([]*DefaultFilter, error)
+	// FindByMode returns the default filter of the user for the mode, or nil if not set.
+	FindByMode(ctx context.Context, username string, mode FilterMode) (*DefaultFilter, error)
+}
+
+// DefaultFilterWriter provides methods to modify default filters.
+type DefaultFilterWriter interface {
+	// Set creates or replaces the default filter for the user and mode of the filter.
+	Set(ctx context.Context, filter *DefaultFilter) error
+	// Destroy removes the default filter of the user for the mode.
+	Destroy(ctx context.Context, username string, mode FilterMode) error
+}
+
+// DefaultFilterReaderWriter provides all default filter methods.
+type DefaultFilterReaderWriter interface {
+	DefaultFilterFinder
+	DefaultFilterWriter
+}
@@ -19,6 +19,8 @@ type FileFinder interface {
 	FindByFingerprint(ctx context.Context, fp Fingerprint) ([]File, error)
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]File, error)
 	FindByFileInfo(ctx context.Context, info fs.FileInfo, size int64) ([]File, error)
+	FindByBasenameAndSize(ctx context.Context, basename string, size int64) ([]File, error)
+	FindIdenticalFiles(ctx context.Context) ([][]FileID, error)
 }
 
 // FileQueryer provides methods to query files.
@@ -29,6 +31,7 @@ type FileQueryer interface {
 // FileCounter provides methods to count files.
 type FileCounter interface {
 	CountAllInPaths(ctx context.Context, p []string) (int, error)
+	SizeAllInPaths(ctx context.Context, p []string) (int64, error)
 	CountByFolderID(ctx context.Context, folderID FolderID) (int, error)
 }
 
@@ -52,6 +52,21 @@ type GalleryDestroyer interface {
 	Destroy(ctx context.Context, id int) error
 }
 
+// GalleryReadingSessionReader provides methods to read gallery reading sessions.
+type GalleryReadingSessionReader interface {
+	// GetReadingSession returns nil if the gallery has no reading session.
+	GetReadingSession(ctx context.Context, galleryID int) (*GalleryReadingSession, error)
+	// FindReadingSessions returns the most recently updated reading sessions first.
+	// If limit is less than zero, all sessions are returned.
+	FindReadingSessions(ctx context.Context, limit int) ([]*GalleryReadingSession, error)
+}
+
+// GalleryReadingSessionWriter provides methods to modify gallery reading sessions.
+type GalleryReadingSessionWriter interface {
+	SaveReadingSession(ctx context.Context, galleryID int, page int) (*GalleryReadingSession, error)
+	DeleteReadingSession(ctx context.Context, galleryID int) error
+}
+
 type GalleryCreatorUpdater interface {
 	GalleryCreator
 	GalleryUpdater
@@ -62,14 +77,22 @@ type GalleryReader interface {
 	GalleryFinder
 	GalleryQueryer
 	GalleryCounter
+	LocationClusterer
 
 	URLLoader
+	TypedURLLoader
 	FileIDLoader
 	ImageIDLoader
 	SceneIDLoader
 	PerformerIDLoader
 	TagIDLoader
+	ManySceneIDLoader
+	ManyPerformerIDLoader
+	ManyTagIDLoader
 	FileLoader
+	ViewDateReader
+	ODateReader
+	GalleryReadingSessionReader
 
 	All(ctx context.Context) ([]*Gallery, error)
 }
@@ -85,6 +108,11 @@ type GalleryWriter interface {
 	RemoveImages(ctx context.Context, galleryID int, imageIDs ...int) error
 	SetCover(ctx context.Context, galleryID int, coverImageID int) error
 	ResetCover(ctx context.Context, galleryID int) error
+
+	OHistoryWriter
+	ViewHistoryWriter
+	GalleryReadingSessionWriter
+	URLTypeSetter
 }
 
 // GalleryReaderWriter provides all gallery methods.
@@ -19,6 +19,9 @@ type ImageFinder interface {
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]*Image, error)
 	FindByGalleryID(ctx context.Context, galleryID int) ([]*Image, error)
 	FindByGalleryIDIndex(ctx context.Context, galleryID int, index uint) (*Image, error)
+	// FindByFilePhash returns the images with a file phash within distance of
+	// phash, ordered by distance.
+	FindByFilePhash(ctx context.Context, phash int64, distance int) ([]PhashMatch, error)
 }
 
 // ImageQueryer provides methods to query images.
@@ -36,6 +39,7 @@ type ImageCounter interface {
 	Count(ctx context.Context) (int, error)
 	CountByFileID(ctx context.Context, fileID FileID) (int, error)
 	CountByGalleryID(ctx context.Context, galleryID int) (int, error)
+	CountByGalleryIDs(ctx context.Context, galleryIDs []int) ([]int, error)
 	OCount(ctx context.Context) (int, error)
 	OCountByPerformerID(ctx context.Context, performerID int) (int, error)
 }
@@ -68,13 +72,18 @@ type ImageReader interface {
 	ImageFinder
 	ImageQueryer
 	ImageCounter
+	LocationClusterer
 
 	URLLoader
 	FileIDLoader
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
+	ManyGalleryIDLoader
+	ManyPerformerIDLoader
+	ManyTagIDLoader
 	FileLoader
+	ViewDateReader
 
 	GalleryCoverFinder
 
@@ -93,6 +102,8 @@ type ImageWriter interface {
 	IncrementOCounter(ctx context.Context, id int) (int, error)
 	DecrementOCounter(ctx context.Context, id int) (int, error)
 	ResetOCounter(ctx context.Context, id int) (int, error)
+
+	ViewHistoryWriter
 }
 
 // ImageReaderWriter provides all image methods.
@@ -0,0 +1,53 @@
+package models
+
+import "context"
+
+// ImageRegionGetter provides methods to get image regions by ID.
+type ImageRegionGetter interface {
+	Find(ctx context.Context, id int) (*ImageRegion, error)
+}
+
+// ImageRegionFinder provides methods to find image regions.
+type ImageRegionFinder interface {
+	ImageRegionGetter
+	// FindByImageID returns the regions of the image, in order of creation.
+	FindByImageID(ctx context.Context, imageID int) ([]*ImageRegion, error)
+}
+
+// ImageRegionCreator provides methods to create image regions.
+type ImageRegionCreator interface {
+	Create(ctx context.Context, newRegion *ImageRegion) error
+}
+
+// ImageRegionUpdater provides methods to update image regions.
+type ImageRegionUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedRegion ImageRegionPartial) (*ImageRegion, error)
+}
+
+// ImageRegionDestroyer provides methods to destroy image regions.
+type ImageRegionDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+type ImageRegionFinderCreator interface {
+	ImageRegionFinder
+	ImageRegionCreator
+}
+
+// ImageRegionReader provides all methods to read image regions.
+type ImageRegionReader interface {
+	ImageRegionFinder
+}
+
+// ImageRegionWriter provides all methods to modify image regions.
+type ImageRegionWriter interface {
+	ImageRegionCreator
+	ImageRegionUpdater
+	ImageRegionDestroyer
+}
+
+// ImageRegionReaderWriter provides all image region methods.
+type ImageRegionReaderWriter interface {
+	ImageRegionReader
+	ImageRegionWriter
+}
@@ -0,0 +1,57 @@
+package models
+
+import "context"
+
+// NoteGetter provides methods to get notes by ID.
+type NoteGetter interface {
+	Find(ctx context.Context, id int) (*Note, error)
+}
+
+// NoteFinder provides methods to find notes.
+type NoteFinder interface {
+	NoteGetter
+	// FindBySceneID returns the notes of the scene, pinned notes first,
+	// then most recent first.
+	FindBySceneID(ctx context.Context, sceneID int) ([]*Note, error)
+	// FindByPerformerID returns the notes of the performer, pinned notes
+	// first, then most recent first.
+	FindByPerformerID(ctx context.Context, performerID int) ([]*Note, error)
+}
+
+// NoteCreator provides methods to create notes.
+type NoteCreator interface {
+	Create(ctx context.Context, newNote *Note) error
+}
+
+// NoteUpdater provides methods to update notes.
+type NoteUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedNote NotePartial) (*Note, error)
+}
+
+// NoteDestroyer provides methods to destroy notes.
+type NoteDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+type NoteFinderCreator interface {
+	NoteFinder
+	NoteCreator
+}
+
+// NoteReader provides all methods to read notes.
+type NoteReader interface {
+	NoteFinder
+}
+
+// NoteWriter provides all methods to modify notes.
+type NoteWriter interface {
+	NoteCreator
+	NoteUpdater
+	NoteDestroyer
+}
+
+// NoteReaderWriter provides all note methods.
+type NoteReaderWriter interface {
+	NoteReader
+	NoteWriter
+}
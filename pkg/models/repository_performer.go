@@ -53,6 +53,33 @@ type PerformerUpdater interface {
 	UpdateImage(ctx context.Context, performerID int, image []byte) error
 }
 
+// PerformerImageReader provides methods to read the image sets of performers.
+type PerformerImageReader interface {
+	// GetImages returns the image set of the performer, in order.
+	GetImages(ctx context.Context, performerID int) ([]*PerformerImage, error)
+	// FindImage returns the performer image with the given id. Returns nil if
+	// not found.
+	FindImage(ctx context.Context, id int) (*PerformerImage, error)
+	GetImageData(ctx context.Context, id int) ([]byte, error)
+}
+
+// PerformerImageWriter provides methods to modify the image sets of
+// performers.
+type PerformerImageWriter interface {
+	// AddImage appends an image to the image set of the performer. If the
+	// image is already in the set, the existing image is returned. The image
+	// becomes the primary image if the performer has no image.
+	AddImage(ctx context.Context, performerID int, image []byte, source string) (*PerformerImage, error)
+	// RemoveImage removes an image from the image set of its performer. If
+	// the image was the primary image, the next image in the set becomes
+	// the primary image.
+	RemoveImage(ctx context.Context, id int) error
+	SetPrimaryImage(ctx context.Context, id int) error
+	// ReorderImages sets the order of the image set of the performer. ids
+	// must contain all of the images in the set.
+	ReorderImages(ctx context.Context, performerID int, ids []int) error
+}
+
 // PerformerDestroyer provides methods to destroy performers.
 type PerformerDestroyer interface {
 	Destroy(ctx context.Context, id int) error
@@ -78,13 +105,21 @@ type PerformerReader interface {
 	AliasLoader
 	StashIDLoader
 	TagIDLoader
+	ManyTagIDLoader
 	URLLoader
+	TypedURLLoader
 
 	CustomFieldsReader
+	PerformerImageReader
+	FieldSourceReader
+	ImagePhashReaderWriter
 
 	All(ctx context.Context) ([]*Performer, error)
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
 	HasImage(ctx context.Context, performerID int) (bool, error)
+	// MergePreview returns what merging the source performers into the
+	// destination performer would add to the destination.
+	MergePreview(ctx context.Context, source []int, destination int) (*PerformerMergePreview, error)
 }
 
 // PerformerWriter provides all methods to modify performers.
@@ -92,6 +127,14 @@ type PerformerWriter interface {
 	PerformerCreator
 	PerformerUpdater
 	PerformerDestroyer
+	PerformerImageWriter
+	FieldSourceWriter
+	URLTypeSetter
+
+	// Merge adds the relationships, aliases, URLs, stash IDs and images of
+	// the source performers to the destination performer, then destroys the
+	// source performers.
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // PerformerReaderWriter provides all performer methods.
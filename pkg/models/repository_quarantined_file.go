@@ -0,0 +1,51 @@
+package models
+
+import "context"
+
+// QuarantinedFileGetter provides methods to get quarantined files by ID.
+type QuarantinedFileGetter interface {
+	Find(ctx context.Context, id int) (*QuarantinedFile, error)
+	FindMany(ctx context.Context, ids []int) ([]*QuarantinedFile, error)
+}
+
+// QuarantinedFileFinder provides methods to find quarantined files.
+type QuarantinedFileFinder interface {
+	QuarantinedFileGetter
+	// FindByPath returns nil if the path is not quarantined.
+	FindByPath(ctx context.Context, path string) (*QuarantinedFile, error)
+	// All returns all quarantined files ordered by path.
+	All(ctx context.Context) ([]*QuarantinedFile, error)
+}
+
+// QuarantinedFileCreator provides methods to create quarantined files.
+type QuarantinedFileCreator interface {
+	Create(ctx context.Context, newFile *QuarantinedFile) error
+}
+
+// QuarantinedFileUpdater provides methods to update quarantined files.
+type QuarantinedFileUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedFile QuarantinedFilePartial) (*QuarantinedFile, error)
+}
+
+// QuarantinedFileDestroyer provides methods to destroy quarantined files.
+type QuarantinedFileDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// QuarantinedFileReader provides all methods to read quarantined files.
+type QuarantinedFileReader interface {
+	QuarantinedFileFinder
+}
+
+// QuarantinedFileWriter provides all methods to modify quarantined files.
+type QuarantinedFileWriter interface {
+	QuarantinedFileCreator
+	QuarantinedFileUpdater
+	QuarantinedFileDestroyer
+}
+
+// QuarantinedFileReaderWriter provides all quarantined file methods.
+type QuarantinedFileReaderWriter interface {
+	QuarantinedFileReader
+	QuarantinedFileWriter
+}
@@ -0,0 +1,64 @@
+package models
+
+import "context"
+
+// SavedFilterFolderGetter provides methods to get saved filter folders by ID.
+type SavedFilterFolderGetter interface {
+	// Find returns nil if not found.
+	Find(ctx context.Context, id int) (*SavedFilterFolder, error)
+	FindMany(ctx context.Context, ids []int) ([]*SavedFilterFolder, error)
+}
+
+// SavedFilterFolderFinder provides methods to find saved filter folders.
+type SavedFilterFolderFinder interface {
+	SavedFilterFolderGetter
+	// FindByName returns the folder of the mode with the given parent and
+	// name, ignoring case. Returns nil if not found.
+	FindByName(ctx context.Context, mode FilterMode, parentID *int, name string) (*SavedFilterFolder, error)
+	// FindByMode returns the folders of the mode in display order.
+	FindByMode(ctx context.Context, mode FilterMode) ([]*SavedFilterFolder, error)
+	// All returns all folders in display order.
+	All(ctx context.Context) ([]*SavedFilterFolder, error)
+}
+
+// SavedFilterFolderCreator provides methods to create saved filter folders.
+type SavedFilterFolderCreator interface {
+	Create(ctx context.Context, newFolder *SavedFilterFolder) error
+}
+
+// SavedFilterFolderUpdater provides methods to update saved filter folders.
+type SavedFilterFolderUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedFolder SavedFilterFolderPartial) (*SavedFilterFolder, error)
+	// Reorder sets the sort order of the folders to their index in ids.
+	Reorder(ctx context.Context, ids []int) error
+}
+
+// SavedFilterFolderDestroyer provides methods to destroy saved filter folders.
+type SavedFilterFolderDestroyer interface {
+	// Destroy removes the folder. Its filters and subfolders are moved into
+	// its parent.
+	Destroy(ctx context.Context, id int) error
+}
+
+type SavedFilterFolderFinderCreator interface {
+	SavedFilterFolderFinder
+	SavedFilterFolderCreator
+}
+
+// SavedFilterFolderReader provides all methods to read saved filter folders.
+type SavedFilterFolderReader interface {
+	SavedFilterFolderFinder
+}
+
+// SavedFilterFolderWriter provides all methods to modify saved filter folders.
+type SavedFilterFolderWriter interface {
+	SavedFilterFolderCreator
+	SavedFilterFolderUpdater
+	SavedFilterFolderDestroyer
+}
+
+// SavedFilterFolderReaderWriter provides all saved filter folder methods.
+type SavedFilterFolderReaderWriter interface {
+	SavedFilterFolderReader
+	SavedFilterFolderWriter
+}
@@ -24,7 +24,8 @@ type SceneFinder interface {
 	FindByPerformerID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGroupID(ctx context.Context, groupID int) ([]*Scene, error)
-	FindDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]*Scene, error)
+	FindDuplicates(ctx context.Context, distance int, durationDiff float64, options PhashDuplicateOptions) ([][]*Scene, error)
+	FindImageDuplicates(ctx context.Context, distance int, durationDiff float64) ([]*SceneImageDuplicate, error)
 }
 
 // SceneQueryer provides methods to query scenes.
@@ -83,23 +84,73 @@ type ODateReader interface {
 	GetManyODates(ctx context.Context, ids []int) ([][]time.Time, error)
 }
 
+type SceneRatingReader interface {
+	// GetRatings returns the ratings of the scene by each user.
+	GetRatings(ctx context.Context, sceneID int) ([]*SceneRating, error)
+}
+
+type SceneRatingWriter interface {
+	// SetRating sets the rating of the scene by the user, removing it if
+	// rating is nil. The rating of the scene is set to the mean of the
+	// ratings of all users.
+	SetRating(ctx context.Context, sceneID int, username string, rating *int) error
+}
+
+type SceneTranscriptReader interface {
+	// GetTranscript returns the transcript of the scene, or nil if the scene
+	// has not been transcribed.
+	GetTranscript(ctx context.Context, sceneID int) (*SceneTranscript, error)
+}
+
+type SceneTranscriptWriter interface {
+	// SetTranscript replaces the transcript of the scene.
+	SetTranscript(ctx context.Context, transcript SceneTranscript) error
+	DestroyTranscript(ctx context.Context, sceneID int) error
+}
+
+type ScenePendingReader interface {
+	// GetPendingFingerprints returns the fingerprints of the file expected
+	// for the scene.
+	GetPendingFingerprints(ctx context.Context, sceneID int) ([]Fingerprint, error)
+	// FindPending returns the pending scenes with one of the pending
+	// fingerprints in fp, or with one of urls.
+	FindPending(ctx context.Context, fp []Fingerprint, urls []string) ([]*Scene, error)
+}
+
+type ScenePendingWriter interface {
+	// UpdatePendingFingerprints replaces the pending fingerprints of the
+	// scene.
+	UpdatePendingFingerprints(ctx context.Context, sceneID int, fp []Fingerprint) error
+}
+
 // SceneReader provides all methods to read scenes.
 type SceneReader interface {
 	SceneFinder
 	SceneQueryer
 	SceneCounter
+	LocationClusterer
 
 	URLLoader
+	TypedURLLoader
 	ViewDateReader
 	ODateReader
 	FileIDLoader
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
+	ManyGalleryIDLoader
+	ManyPerformerIDLoader
+	ManyTagIDLoader
 	SceneGroupLoader
 	StashIDLoader
 	VideoFileLoader
 
+	FieldSourceReader
+	SceneRatingReader
+	SceneTranscriptReader
+	ScenePendingReader
+	ImagePhashReaderWriter
+
 	All(ctx context.Context) ([]*Scene, error)
 	Wall(ctx context.Context, q *string) ([]*Scene, error)
 	Size(ctx context.Context) (float64, error)
@@ -107,6 +158,7 @@ type SceneReader interface {
 	PlayDuration(ctx context.Context) (float64, error)
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 	HasCover(ctx context.Context, sceneID int) (bool, error)
+	GetWatchHeat(ctx context.Context, sceneID int) (WatchHeat, error)
 }
 
 type OHistoryWriter interface {
@@ -133,8 +185,17 @@ type SceneWriter interface {
 
 	OHistoryWriter
 	ViewHistoryWriter
+	FieldSourceWriter
+	SceneRatingWriter
+	SceneTranscriptWriter
+	ScenePendingWriter
+	URLTypeSetter
 	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
 	ResetActivity(ctx context.Context, sceneID int, resetResume bool, resetDuration bool) (bool, error)
+
+	// RefreshGeneratedTitles regenerates the generated titles of all scenes
+	// using the current title template.
+	RefreshGeneratedTitles(ctx context.Context) error
 }
 
 // SceneReaderWriter provides all scene methods.
@@ -0,0 +1,47 @@
+package models
+
+import "context"
+
+// SceneMarkerSuggestionGetter provides methods to get scene marker suggestions by ID.
+type SceneMarkerSuggestionGetter interface {
+	Find(ctx context.Context, id int) (*SceneMarkerSuggestion, error)
+	FindMany(ctx context.Context, ids []int) ([]*SceneMarkerSuggestion, error)
+}
+
+// SceneMarkerSuggestionFinder provides methods to find scene marker suggestions.
+type SceneMarkerSuggestionFinder interface {
+	SceneMarkerSuggestionGetter
+	// FindBySceneID returns the suggestions of the scene ordered by seconds.
+	FindBySceneID(ctx context.Context, sceneID int) ([]*SceneMarkerSuggestion, error)
+	// All returns all suggestions ordered by scene and seconds.
+	All(ctx context.Context) ([]*SceneMarkerSuggestion, error)
+}
+
+// SceneMarkerSuggestionCreator provides methods to create scene marker suggestions.
+type SceneMarkerSuggestionCreator interface {
+	Create(ctx context.Context, newSuggestion *SceneMarkerSuggestion) error
+}
+
+// SceneMarkerSuggestionDestroyer provides methods to destroy scene marker suggestions.
+type SceneMarkerSuggestionDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+	// DestroyBySceneID destroys the suggestions of the scene from the source.
+	DestroyBySceneID(ctx context.Context, sceneID int, source string) error
+}
+
+// SceneMarkerSuggestionReader provides all methods to read scene marker suggestions.
+type SceneMarkerSuggestionReader interface {
+	SceneMarkerSuggestionFinder
+}
+
+// SceneMarkerSuggestionWriter provides all methods to modify scene marker suggestions.
+type SceneMarkerSuggestionWriter interface {
+	SceneMarkerSuggestionCreator
+	SceneMarkerSuggestionDestroyer
+}
+
+// SceneMarkerSuggestionReaderWriter provides all scene marker suggestion methods.
+type SceneMarkerSuggestionReaderWriter interface {
+	SceneMarkerSuggestionReader
+	SceneMarkerSuggestionWriter
+}
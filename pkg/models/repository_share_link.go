@@ -0,0 +1,53 @@
+package models
+
+import "context"
+
+// ShareLinkGetter provides methods to get share links by ID.
+type ShareLinkGetter interface {
+	Find(ctx context.Context, id int) (*ShareLink, error)
+}
+
+// ShareLinkFinder provides methods to find share links.
+type ShareLinkFinder interface {
+	ShareLinkGetter
+	FindByToken(ctx context.Context, token string) (*ShareLink, error)
+	FindBySceneID(ctx context.Context, sceneID int) ([]*ShareLink, error)
+	FindByGalleryID(ctx context.Context, galleryID int) ([]*ShareLink, error)
+	All(ctx context.Context) ([]*ShareLink, error)
+}
+
+// ShareLinkCreator provides methods to create share links.
+type ShareLinkCreator interface {
+	Create(ctx context.Context, newShareLink *ShareLink) error
+}
+
+// ShareLinkUpdater provides methods to update share links.
+type ShareLinkUpdater interface {
+	// IncrementViewCount increments the view count of the share link if its
+	// view limit has not been reached. Returns false if the limit has been reached.
+	IncrementViewCount(ctx context.Context, id int) (bool, error)
+	UpdatePreview(ctx context.Context, id int, preview ShareLinkPreview) (*ShareLink, error)
+}
+
+// ShareLinkDestroyer provides methods to destroy share links.
+type ShareLinkDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// ShareLinkReader provides all methods to read share links.
+type ShareLinkReader interface {
+	ShareLinkFinder
+}
+
+// ShareLinkWriter provides all methods to modify share links.
+type ShareLinkWriter interface {
+	ShareLinkCreator
+	ShareLinkUpdater
+	ShareLinkDestroyer
+}
+
+// ShareLinkReaderWriter provides all share link methods.
+type ShareLinkReaderWriter interface {
+	ShareLinkReader
+	ShareLinkWriter
+}
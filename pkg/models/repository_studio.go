@@ -75,8 +75,14 @@ type StudioReader interface {
 	StudioCounter
 
 	AliasLoader
+	URLLoader
+	TypedURLLoader
 	StashIDLoader
 	TagIDLoader
+	ManyTagIDLoader
+	StudioDefaultsLoader
+
+	FieldSourceReader
 
 	All(ctx context.Context) ([]*Studio, error)
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
@@ -88,6 +94,8 @@ type StudioWriter interface {
 	StudioCreator
 	StudioUpdater
 	StudioDestroyer
+	FieldSourceWriter
+	URLTypeSetter
 }
 
 // StudioReaderWriter provides all studio methods.
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// SyncReader provides the change feed used by clients to incrementally
+// synchronise their copy of the library.
+type SyncReader interface {
+	// Changes returns the objects of the given type that were created,
+	// updated or deleted at or after since. If since is nil, all existing
+	// objects are returned as created.
+	Changes(ctx context.Context, objectType SyncObjectType, since *time.Time) (*SyncChanges, error)
+}
@@ -46,6 +46,9 @@ type TagCounter interface {
 	Count(ctx context.Context) (int, error)
 	CountByParentTagID(ctx context.Context, parentID int) (int, error)
 	CountByChildTagID(ctx context.Context, childID int) (int, error)
+	// UsageCounts returns the number of objects each tag is directly applied
+	// to, keyed by tag ID. Tags that are not applied to any object are omitted.
+	UsageCounts(ctx context.Context) (map[int]int, error)
 }
 
 // TagCreator provides methods to create tags.
@@ -0,0 +1,64 @@
+package models
+
+import "context"
+
+// TagCategoryGetter provides methods to get tag categories by ID.
+type TagCategoryGetter interface {
+	Find(ctx context.Context, id int) (*TagCategory, error)
+	FindMany(ctx context.Context, ids []int) ([]*TagCategory, error)
+}
+
+// TagCategoryFinder provides methods to find tag categories.
+type TagCategoryFinder interface {
+	TagCategoryGetter
+	// FindByName returns the category with the given name, ignoring case.
+	// Returns nil if not found.
+	FindByName(ctx context.Context, name string) (*TagCategory, error)
+	// All returns all categories in display order.
+	All(ctx context.Context) ([]*TagCategory, error)
+}
+
+// TagCategoryCounter provides methods to count tag categories.
+type TagCategoryCounter interface {
+	// CountTags returns the number of tags in the category.
+	CountTags(ctx context.Context, id int) (int, error)
+}
+
+// TagCategoryCreator provides methods to create tag categories.
+type TagCategoryCreator interface {
+	Create(ctx context.Context, newCategory *TagCategory) error
+}
+
+// TagCategoryUpdater provides methods to update tag categories.
+type TagCategoryUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedCategory TagCategoryPartial) (*TagCategory, error)
+}
+
+// TagCategoryDestroyer provides methods to destroy tag categories.
+type TagCategoryDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+type TagCategoryFinderCreator interface {
+	TagCategoryFinder
+	TagCategoryCreator
+}
+
+// TagCategoryReader provides all methods to read tag categories.
+type TagCategoryReader interface {
+	TagCategoryFinder
+	TagCategoryCounter
+}
+
+// TagCategoryWriter provides all methods to modify tag categories.
+type TagCategoryWriter interface {
+	TagCategoryCreator
+	TagCategoryUpdater
+	TagCategoryDestroyer
+}
+
+// TagCategoryReaderWriter provides all tag category methods.
+type TagCategoryReaderWriter interface {
+	TagCategoryReader
+	TagCategoryWriter
+}
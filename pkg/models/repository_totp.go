@@ -0,0 +1,47 @@
+package models
+
+import "context"
+
+// TOTPFinder provides methods to find two-factor credentials.
+type TOTPFinder interface {
+	FindByUsername(ctx context.Context, username string) (*TOTPCredential, error)
+	GetRecoveryCodes(ctx context.Context, credentialID int) ([]*TOTPRecoveryCode, error)
+}
+
+// TOTPCreator provides methods to create two-factor credentials.
+type TOTPCreator interface {
+	Create(ctx context.Context, newCredential *TOTPCredential) error
+}
+
+// TOTPUpdater provides methods to update two-factor credentials.
+type TOTPUpdater interface {
+	Update(ctx context.Context, updatedCredential *TOTPCredential) error
+	// ReplaceRecoveryCodes replaces all recovery codes of the credential with the provided hashes.
+	ReplaceRecoveryCodes(ctx context.Context, credentialID int, codeHashes []string) error
+	// UseRecoveryCode marks the unused recovery code with the provided hash as used.
+	// Returns false if no matching unused code exists.
+	UseRecoveryCode(ctx context.Context, credentialID int, codeHash string) (bool, error)
+}
+
+// TOTPDestroyer provides methods to destroy two-factor credentials.
+type TOTPDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// TOTPReader provides all methods to read two-factor credentials.
+type TOTPReader interface {
+	TOTPFinder
+}
+
+// TOTPWriter provides all methods to modify two-factor credentials.
+type TOTPWriter interface {
+	TOTPCreator
+	TOTPUpdater
+	TOTPDestroyer
+}
+
+// TOTPReaderWriter provides all two-factor credential methods.
+type TOTPReaderWriter interface {
+	TOTPReader
+	TOTPWriter
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// URLStatusFinder provides methods to find url statuses.
+type URLStatusFinder interface {
+	// FindByURLs returns the statuses of the urls that have been checked.
+	FindByURLs(ctx context.Context, urls []string) ([]*URLStatus, error)
+	// FindDue returns the scene and performer urls that have not been
+	// checked since checkedBefore. Urls that have never been checked are
+	// returned first, followed by the least recently checked.
+	FindDue(ctx context.Context, checkedBefore time.Time) ([]string, error)
+}
+
+// URLStatusWriter provides methods to modify url statuses.
+type URLStatusWriter interface {
+	// Upsert creates or replaces the status of the url.
+	Upsert(ctx context.Context, status *URLStatus) error
+	// DestroyUnused removes the statuses of urls that no longer belong to a
+	// scene or performer.
+	DestroyUnused(ctx context.Context) error
+}
+
+// URLStatusReaderWriter provides all url status methods.
+type URLStatusReaderWriter interface {
+	URLStatusFinder
+	URLStatusWriter
+}
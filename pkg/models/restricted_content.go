@@ -0,0 +1,19 @@
+package models
+
+import "context"
+
+type restrictedContentKey struct{}
+
+// WithRestrictedContentHidden returns a context in which scenes, images,
+// galleries and scene markers with a restricted tag are not returned by
+// the repository. Writes are not affected.
+func WithRestrictedContentHidden(ctx context.Context) context.Context {
+	return context.WithValue(ctx, restrictedContentKey{}, true)
+}
+
+// RestrictedContentHidden returns true if restricted content is hidden in
+// ctx.
+func RestrictedContentHidden(ctx context.Context) bool {
+	hidden, _ := ctx.Value(restrictedContentKey{}).(bool)
+	return hidden
+}
@@ -7,12 +7,23 @@ type SavedFilterReader interface {
 	Find(ctx context.Context, id int) (*SavedFilter, error)
 	FindMany(ctx context.Context, ids []int, ignoreNotFound bool) ([]*SavedFilter, error)
 	FindByMode(ctx context.Context, mode FilterMode) ([]*SavedFilter, error)
+	// FindDefault returns the default filter of the mode. Returns nil if the
+	// mode has no default filter.
+	FindDefault(ctx context.Context, mode FilterMode) (*SavedFilter, error)
 }
 
 type SavedFilterWriter interface {
 	Create(ctx context.Context, obj *SavedFilter) error
 	Update(ctx context.Context, obj *SavedFilter) error
 	Destroy(ctx context.Context, id int) error
+	// SetDefault makes the filter with the given id the default filter of the
+	// mode. A nil id clears the default filter of the mode.
+	SetDefault(ctx context.Context, mode FilterMode, id *int) error
+	// SetFolder moves the filters into the folder. A nil folderID moves the
+	// filters out of any folder.
+	SetFolder(ctx context.Context, ids []int, folderID *int) error
+	// Reorder sets the sort order of the filters to their index in ids.
+	Reorder(ctx context.Context, ids []int) error
 }
 
 type SavedFilterReaderWriter interface {
@@ -1,6 +1,12 @@
 package models
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 type PHashDuplicationCriterionInput struct {
 	Duplicated *bool `json:"duplicated"`
@@ -15,6 +21,12 @@ type SceneFilterType struct {
 	Code     *StringCriterionInput `json:"code"`
 	Details  *StringCriterionInput `json:"details"`
 	Director *StringCriterionInput `json:"director"`
+	// Filter by country
+	Country *StringCriterionInput `json:"country"`
+	// Filter by city
+	City *StringCriterionInput `json:"city"`
+	// Filter by location within a bounding box
+	Location *GeoBoundsInput `json:"location"`
 	// Filter by file oshash
 	Oshash *StringCriterionInput `json:"oshash"`
 	// Filter by file checksum
@@ -25,12 +37,22 @@ type SceneFilterType struct {
 	PhashDistance *PhashDistanceCriterionInput `json:"phash_distance"`
 	// Filter by path
 	Path *StringCriterionInput `json:"path"`
+	// Filter by full-text search of the audio transcript
+	Transcript *StringCriterionInput `json:"transcript"`
 	// Filter by file count
 	FileCount *IntCriterionInput `json:"file_count"`
-	// Filter by rating expressed as 1-100
+	// Filter by rating expressed as 1-100 - the mean rating of all users
 	Rating100 *IntCriterionInput `json:"rating100"`
+	// Filter by the current user's rating expressed as 1-100
+	PersonalRating100 *IntCriterionInput `json:"personal_rating100"`
+	// Filter by the number of users that have rated the scene
+	RatingCount *IntCriterionInput `json:"rating_count"`
 	// Filter by organized
 	Organized *bool `json:"organized"`
+	// Filter by pending
+	Pending *bool `json:"pending"`
+	// Filter by archived. Archived scenes are excluded if not set.
+	Archived *bool `json:"archived"`
 	// Filter by o-counter
 	OCounter *IntCriterionInput `json:"o_counter"`
 	// Filter Scenes that have an exact phash match available
@@ -43,6 +65,18 @@ type SceneFilterType struct {
 	Framerate *IntCriterionInput `json:"framerate"`
 	// Filter by bitrate
 	Bitrate *IntCriterionInput `json:"bitrate"`
+	// Filter by aspect ratio (width / height)
+	AspectRatio *FloatCriterionInput `json:"aspect_ratio"`
+	// Filter by video bit depth
+	BitDepth *IntCriterionInput `json:"bit_depth"`
+	// Filter by technical quality score
+	QualityScore *IntCriterionInput `json:"quality_score"`
+	// Filter by HDR format
+	HDRFormat *HDRFormatCriterionInput `json:"hdr_format"`
+	// Filter by VR projection
+	VRProjection *VRProjectionCriterionInput `json:"vr_projection"`
+	// Filter by stereoscopic layout
+	StereoLayout *StereoLayoutCriterionInput `json:"stereo_layout"`
 	// Filter by video codec
 	VideoCodec *StringCriterionInput `json:"video_codec"`
 	// Filter by audio codec
@@ -81,8 +115,14 @@ type SceneFilterType struct {
 	StashIDEndpoint *StashIDCriterionInput `json:"stash_id_endpoint"`
 	// Filter by url
 	URL *StringCriterionInput `json:"url"`
+	// Filter by the statuses of the last checks of the urls
+	URLStatus *URLCheckStatusCriterionInput `json:"url_status"`
+	// Filter by urls of a type
+	TypedURL *TypedURLCriterionInput `json:"typed_url"`
 	// Filter by interactive
 	Interactive *bool `json:"interactive"`
+	// Filter by files with contents not yet downloaded
+	PendingContent *bool `json:"pending_content"`
 	// Filter by InteractiveSpeed
 	InteractiveSpeed *IntCriterionInput `json:"interactive_speed"`
 	// Filter by captions
@@ -117,6 +157,15 @@ type SceneFilterType struct {
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
 }
 
+// FiltersArchived returns true if the filter or one of its sub-filters filters
+// by the archived status. Archived scenes are excluded from queries otherwise.
+func (f *SceneFilterType) FiltersArchived() bool {
+	if f == nil {
+		return false
+	}
+	return f.Archived != nil || f.SubFilter().FiltersArchived()
+}
+
 type SceneQueryOptions struct {
 	QueryOptions
 	SceneFilter *SceneFilterType
@@ -151,11 +200,19 @@ type SceneCreateInput struct {
 	Code         *string           `json:"code"`
 	Details      *string           `json:"details"`
 	Director     *string           `json:"director"`
+	Country      *string           `json:"country"`
+	City         *string           `json:"city"`
+	Latitude     *float64          `json:"latitude"`
+	Longitude    *float64          `json:"longitude"`
+	StreamStart  *float64          `json:"stream_start"`
+	StreamEnd    *float64          `json:"stream_end"`
 	URL          *string           `json:"url"`
 	Urls         []string          `json:"urls"`
+	TypedUrls    []TypedURL        `json:"typed_urls"`
 	Date         *string           `json:"date"`
 	Rating100    *int              `json:"rating100"`
 	Organized    *bool             `json:"organized"`
+	Pending      *bool             `json:"pending"`
 	StudioID     *string           `json:"studio_id"`
 	GalleryIds   []string          `json:"gallery_ids"`
 	PerformerIds []string          `json:"performer_ids"`
@@ -169,27 +226,42 @@ type SceneCreateInput struct {
 	// Files will be reassigned from existing scenes if applicable.
 	// Files must not already be primary for another scene.
 	FileIds []string `json:"file_ids"`
+	// Fingerprints of the file expected for a pending scene.
+	PendingFingerprints []PendingFingerprintInput `json:"pending_fingerprints"`
 }
 
 type SceneUpdateInput struct {
-	ClientMutationID *string           `json:"clientMutationId"`
-	ID               string            `json:"id"`
-	Title            *string           `json:"title"`
-	Code             *string           `json:"code"`
-	Details          *string           `json:"details"`
-	Director         *string           `json:"director"`
-	URL              *string           `json:"url"`
-	Urls             []string          `json:"urls"`
-	Date             *string           `json:"date"`
-	Rating100        *int              `json:"rating100"`
-	OCounter         *int              `json:"o_counter"`
-	Organized        *bool             `json:"organized"`
-	StudioID         *string           `json:"studio_id"`
-	GalleryIds       []string          `json:"gallery_ids"`
-	PerformerIds     []string          `json:"performer_ids"`
-	Movies           []SceneMovieInput `json:"movies"`
-	Groups           []SceneGroupInput `json:"groups"`
-	TagIds           []string          `json:"tag_ids"`
+	ClientMutationID *string `json:"clientMutationId"`
+	ID               string  `json:"id"`
+	// ExpectedUpdatedAt is the updated at time of the object as last read by
+	// the client. If set and the object has since been modified, the update
+	// fails with a conflict error.
+	ExpectedUpdatedAt *time.Time        `json:"expected_updated_at"`
+	Title             *string           `json:"title"`
+	Code              *string           `json:"code"`
+	Details           *string           `json:"details"`
+	Director          *string           `json:"director"`
+	Country           *string           `json:"country"`
+	City              *string           `json:"city"`
+	Latitude          *float64          `json:"latitude"`
+	Longitude         *float64          `json:"longitude"`
+	StreamStart       *float64          `json:"stream_start"`
+	StreamEnd         *float64          `json:"stream_end"`
+	URL               *string           `json:"url"`
+	Urls              []string          `json:"urls"`
+	TypedUrls         []TypedURL        `json:"typed_urls"`
+	Date              *string           `json:"date"`
+	Rating100         *int              `json:"rating100"`
+	OCounter          *int              `json:"o_counter"`
+	Organized         *bool             `json:"organized"`
+	Pending           *bool             `json:"pending"`
+	Archived          *bool             `json:"archived"`
+	StudioID          *string           `json:"studio_id"`
+	GalleryIds        []string          `json:"gallery_ids"`
+	PerformerIds      []string          `json:"performer_ids"`
+	Movies            []SceneMovieInput `json:"movies"`
+	Groups            []SceneGroupInput `json:"groups"`
+	TagIds            []string          `json:"tag_ids"`
 	// This should be a URL or a base64 encoded data URL
 	CoverImage    *string        `json:"cover_image"`
 	StashIds      []StashIDInput `json:"stash_ids"`
@@ -197,6 +269,42 @@ type SceneUpdateInput struct {
 	PlayDuration  *float64       `json:"play_duration"`
 	PlayCount     *int           `json:"play_count"`
 	PrimaryFileID *string        `json:"primary_file_id"`
+	// Replaces the fingerprints of the file expected for a pending scene.
+	PendingFingerprints []PendingFingerprintInput `json:"pending_fingerprints"`
+}
+
+// PendingFingerprintInput is a fingerprint of the file expected for a
+// pending scene.
+type PendingFingerprintInput struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// PendingFingerprintTypes are the fingerprint types that pending scenes are
+// matched by. These are the fingerprints calculated when a file is scanned.
+var PendingFingerprintTypes = []string{FingerprintTypeOshash, FingerprintTypeMD5}
+
+// ToPendingFingerprints converts the inputs to fingerprints, returning an
+// error if a fingerprint type cannot be matched.
+func ToPendingFingerprints(inputs []PendingFingerprintInput) ([]Fingerprint, error) {
+	var ret []Fingerprint
+	for _, i := range inputs {
+		if !slices.Contains(PendingFingerprintTypes, i.Type) {
+			return nil, fmt.Errorf("pending fingerprint type must be one of %v", PendingFingerprintTypes)
+		}
+
+		value := strings.TrimSpace(i.Value)
+		if value == "" {
+			return nil, fmt.Errorf("pending %s fingerprint must not be empty", i.Type)
+		}
+
+		ret = append(ret, Fingerprint{
+			Type:        i.Type,
+			Fingerprint: value,
+		})
+	}
+
+	return ret, nil
 }
 
 type SceneDestroyInput struct {
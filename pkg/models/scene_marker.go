@@ -9,6 +9,14 @@ type SceneMarkerFilterType struct {
 	SceneTags *HierarchicalMultiCriterionInput `json:"scene_tags"`
 	// Filter to only include scene markers with these performers
 	Performers *MultiCriterionInput `json:"performers"`
+	// Filter to only include scene markers tagged with these performers
+	MarkerPerformers *MultiCriterionInput `json:"marker_performers"`
+	// Filter by the number of performers tagged on the scene marker
+	PerformerCount *IntCriterionInput `json:"performer_count"`
+	// Filter by rating expressed as 1-100
+	Rating100 *IntCriterionInput `json:"rating100"`
+	// Filter by details
+	Details *StringCriterionInput `json:"details"`
 	// Filter to only include scene markers from these scenes
 	Scenes *MultiCriterionInput `json:"scenes"`
 	// Filter by duration (in seconds)
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type StudioFilterType struct {
 	OperatorFilter[StudioFilterType]
 	Name    *StringCriterionInput `json:"name"`
@@ -28,6 +30,8 @@ type StudioFilterType struct {
 	GalleryCount *IntCriterionInput `json:"gallery_count"`
 	// Filter by url
 	URL *StringCriterionInput `json:"url"`
+	// Filter by urls of a type
+	TypedURL *TypedURLCriterionInput `json:"typed_url"`
 	// Filter by studio aliases
 	Aliases *StringCriterionInput `json:"aliases"`
 	// Filter by subsidiary studio count
@@ -44,12 +48,16 @@ type StudioFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by the time that the studio was favorited
+	FavoritedAt *TimestampCriterionInput `json:"favorited_at"`
 }
 
 type StudioCreateInput struct {
-	Name     string  `json:"name"`
-	URL      *string `json:"url"`
-	ParentID *string `json:"parent_id"`
+	Name      string     `json:"name"`
+	URL       *string    `json:"url"` // deprecated
+	Urls      []string   `json:"urls"`
+	TypedUrls []TypedURL `json:"typed_urls"`
+	ParentID  *string    `json:"parent_id"`
 	// This should be a URL or a base64 encoded data URL
 	Image         *string        `json:"image"`
 	StashIds      []StashIDInput `json:"stash_ids"`
@@ -59,13 +67,23 @@ type StudioCreateInput struct {
 	Aliases       []string       `json:"aliases"`
 	TagIds        []string       `json:"tag_ids"`
 	IgnoreAutoTag *bool          `json:"ignore_auto_tag"`
+
+	DefaultTagIds     []string `json:"default_tag_ids"`
+	DefaultUrls       []string `json:"default_urls"`
+	PropagateDefaults *bool    `json:"propagate_defaults"`
 }
 
 type StudioUpdateInput struct {
-	ID       string  `json:"id"`
-	Name     *string `json:"name"`
-	URL      *string `json:"url"`
-	ParentID *string `json:"parent_id"`
+	ID string `json:"id"`
+	// ExpectedUpdatedAt is the updated at time of the object as last read by
+	// the client. If set and the object has since been modified, the update
+	// fails with a conflict error.
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at"`
+	Name              *string    `json:"name"`
+	URL               *string    `json:"url"` // deprecated
+	Urls              []string   `json:"urls"`
+	TypedUrls         []TypedURL `json:"typed_urls"`
+	ParentID          *string    `json:"parent_id"`
 	// This should be a URL or a base64 encoded data URL
 	Image         *string        `json:"image"`
 	StashIds      []StashIDInput `json:"stash_ids"`
@@ -75,4 +93,8 @@ type StudioUpdateInput struct {
 	Aliases       []string       `json:"aliases"`
 	TagIds        []string       `json:"tag_ids"`
 	IgnoreAutoTag *bool          `json:"ignore_auto_tag"`
+
+	DefaultTagIds     []string `json:"default_tag_ids"`
+	DefaultUrls       []string `json:"default_urls"`
+	PropagateDefaults *bool    `json:"propagate_defaults"`
 }
@@ -38,6 +38,10 @@ type TagFilterType struct {
 	ChildCount *IntCriterionInput `json:"child_count"`
 	// Filter by autotag ignore value
 	IgnoreAutoTag *bool `json:"ignore_auto_tag"`
+	// Filter by restricted value
+	Restricted *bool `json:"restricted"`
+	// Filter by tag category
+	Category *MultiCriterionInput `json:"category"`
 	// Filter by related scenes that meet this criteria
 	ScenesFilter *SceneFilterType `json:"scenes_filter"`
 	// Filter by related images that meet this criteria
@@ -48,4 +52,6 @@ type TagFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by the time that the tag was favorited
+	FavoritedAt *TimestampCriterionInput `json:"favorited_at"`
 }
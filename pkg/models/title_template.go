@@ -0,0 +1,194 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	TitleTemplatePerformers = "performers"
+	TitleTemplateStudio     = "studio"
+	TitleTemplateDate       = "date"
+	TitleTemplateYear       = "year"
+	TitleTemplateCode       = "code"
+	TitleTemplateDirector   = "director"
+)
+
+var validTitleTemplateTokens = []string{
+	TitleTemplatePerformers,
+	TitleTemplateStudio,
+	TitleTemplateDate,
+	TitleTemplateYear,
+	TitleTemplateCode,
+	TitleTemplateDirector,
+}
+
+// TitleTemplateData is the metadata used to render a TitleTemplate.
+type TitleTemplateData struct {
+	Performers []string
+	Studio     string
+	Date       *Date
+	Code       string
+	Director   string
+}
+
+func (d TitleTemplateData) value(token string) string {
+	switch token {
+	case TitleTemplatePerformers:
+		return strings.Join(d.Performers, ", ")
+	case TitleTemplateStudio:
+		return d.Studio
+	case TitleTemplateDate:
+		if d.Date != nil {
+			return d.Date.String()
+		}
+	case TitleTemplateYear:
+		if d.Date != nil {
+			return strconv.Itoa(d.Date.Year())
+		}
+	case TitleTemplateCode:
+		return d.Code
+	case TitleTemplateDirector:
+		return d.Director
+	}
+
+	return ""
+}
+
+type titleTemplateToken struct {
+	// literal text between the previous token and this token
+	separator string
+	token     string
+}
+
+// TitleTemplate generates a title from metadata, for objects without an
+// explicit title. Tokens such as {studio} are replaced with the
+// corresponding metadata. Empty tokens are omitted along with the text
+// separating them from the previous token, so that "{performers} - {studio}"
+// renders as the performer names alone if there is no studio.
+type TitleTemplate struct {
+	// literal text before the first token and after the last token
+	prefix string
+	suffix string
+	tokens []titleTemplateToken
+}
+
+// ParseTitleTemplate parses a title template. Returns nil if s is empty.
+func ParseTitleTemplate(s string) (*TitleTemplate, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	ret := &TitleTemplate{}
+	remaining := s
+	var literal string
+
+	for {
+		start := strings.IndexByte(remaining, '{')
+		if start == -1 {
+			if strings.IndexByte(remaining, '}') != -1 {
+				return nil, fmt.Errorf("unexpected '}' in title template %q", s)
+			}
+			literal += remaining
+			break
+		}
+
+		end := strings.IndexByte(remaining[start:], '}')
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated token in title template %q", s)
+		}
+		end += start
+
+		if strings.IndexByte(remaining[:start], '}') != -1 {
+			return nil, fmt.Errorf("unexpected '}' in title template %q", s)
+		}
+
+		token := remaining[start+1 : end]
+		if !isValidTitleTemplateToken(token) {
+			return nil, fmt.Errorf("unknown token {%s} in title template %q, valid tokens are: %s", token, s, strings.Join(validTitleTemplateTokens, ", "))
+		}
+
+		literal += remaining[:start]
+		if len(ret.tokens) == 0 {
+			ret.prefix = literal
+			literal = ""
+		}
+
+		ret.tokens = append(ret.tokens, titleTemplateToken{
+			separator: literal,
+			token:     token,
+		})
+		literal = ""
+		remaining = remaining[end+1:]
+	}
+
+	if len(ret.tokens) == 0 {
+		return nil, fmt.Errorf("title template %q does not contain any tokens", s)
+	}
+
+	ret.suffix = literal
+
+	return ret, nil
+}
+
+func isValidTitleTemplateToken(token string) bool {
+	for _, t := range validTitleTemplateTokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// Render returns the title generated from d, or an empty string if all of
+// the tokens are empty. The text before the first token and after the last
+// token is only included if those tokens are not empty.
+func (t *TitleTemplate) Render(d TitleTemplateData) string {
+	var b strings.Builder
+
+	last := len(t.tokens) - 1
+	for i, tt := range t.tokens {
+		v := strings.TrimSpace(d.value(tt.token))
+		if v == "" {
+			continue
+		}
+
+		switch {
+		case i == 0:
+			b.WriteString(t.prefix)
+		case b.Len() > 0:
+			b.WriteString(tt.separator)
+		}
+
+		b.WriteString(v)
+
+		if i == last {
+			b.WriteString(t.suffix)
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// Uses returns true if the template contains the given token.
+func (t *TitleTemplate) Uses(token string) bool {
+	for _, tt := range t.tokens {
+		if tt.token == token {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the template in its original form.
+func (t *TitleTemplate) String() string {
+	var b strings.Builder
+	b.WriteString(t.prefix)
+	for _, tt := range t.tokens {
+		b.WriteString(tt.separator)
+		b.WriteString("{" + tt.token + "}")
+	}
+	b.WriteString(t.suffix)
+	return b.String()
+}
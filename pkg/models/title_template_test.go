@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTitleTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantNil  bool
+		wantErr  bool
+	}{
+		{"", true, false},
+		{"  ", true, false},
+		{"{performers} - {studio} - {date}", false, false},
+		{"[{studio}] {performers} ({year})", false, false},
+		{"no tokens", false, true},
+		{"{unknown}", false, true},
+		{"{studio", false, true},
+		{"studio}", false, true},
+		{"{studio}} - {date}", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			got, err := ParseTitleTemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTitleTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantNil, got == nil)
+			if got != nil {
+				assert.Equal(t, tt.template, got.String())
+			}
+		})
+	}
+}
+
+func TestTitleTemplate_Render(t *testing.T) {
+	date := &Date{Time: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)}
+	full := TitleTemplateData{
+		Performers: []string{"Alice", "Bob"},
+		Studio:     "Studio",
+		Date:       date,
+		Code:       "ABC-123",
+		Director:   "Director",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     TitleTemplateData
+		want     string
+	}{
+		{"all", "{performers} - {studio} - {date}", full, "Alice, Bob - Studio - 2024-03-05"},
+		{"year", "{studio} {year}", full, "Studio 2024"},
+		{"code and director", "{code} by {director}", full, "ABC-123 by Director"},
+		{"empty middle", "{performers} - {studio} - {date}", TitleTemplateData{Performers: []string{"Alice"}, Date: date}, "Alice - 2024-03-05"},
+		{"empty first", "{performers} - {studio}", TitleTemplateData{Studio: "Studio"}, "Studio"},
+		{"empty last", "{performers} - {studio}", TitleTemplateData{Performers: []string{"Alice"}}, "Alice"},
+		{"all empty", "{performers} - {studio}", TitleTemplateData{}, ""},
+		{"prefix and suffix", "[{studio}] {performers} ({year})", full, "[Studio] Alice, Bob (2024)"},
+		{"empty prefix token", "[{studio}] {performers} ({year})", TitleTemplateData{Performers: []string{"Alice"}, Date: date}, "Alice (2024)"},
+		{"empty suffix token", "[{studio}] {performers} ({year})", TitleTemplateData{Studio: "Studio", Performers: []string{"Alice"}}, "[Studio] Alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTitleTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTitleTemplate() error = %v", err)
+			}
+
+			assert.Equal(t, tt.want, tmpl.Render(tt.data))
+		})
+	}
+}
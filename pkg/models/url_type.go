@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// URLType is the kind of site that a URL refers to.
+type URLType string
+
+const (
+	// The official site of the object, such as the page of a scene on the
+	// site of its studio.
+	URLTypeOfficial URLType = "OFFICIAL"
+	// A social media profile or post.
+	URLTypeSocial URLType = "SOCIAL"
+	// A store page where the object can be bought.
+	URLTypeStore URLType = "STORE"
+	// The site that the metadata or the file was obtained from.
+	URLTypeSource URLType = "SOURCE"
+)
+
+var AllURLType = []URLType{
+	URLTypeOfficial,
+	URLTypeSocial,
+	URLTypeStore,
+	URLTypeSource,
+}
+
+func (e URLType) IsValid() bool {
+	switch e {
+	case URLTypeOfficial, URLTypeSocial, URLTypeStore, URLTypeSource:
+		return true
+	}
+	return false
+}
+
+func (e URLType) String() string {
+	return string(e)
+}
+
+func (e *URLType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = URLType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid URLType", str)
+	}
+	return nil
+}
+
+func (e URLType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// TypedURL is a URL of an object with its type. Type is nil if the type of
+// the URL is not known.
+type TypedURL struct {
+	URL  string   `json:"url"`
+	Type *URLType `json:"type,omitempty"`
+}
+
+// TypedURLs returns the URLs of urls in order, and the types of the URLs
+// keyed by URL. URLs without a type are mapped to nil.
+func TypedURLs(urls []TypedURL) ([]string, map[string]*URLType) {
+	ret := make([]string, len(urls))
+	types := make(map[string]*URLType, len(urls))
+	for i, u := range urls {
+		ret[i] = u.URL
+		types[u.URL] = u.Type
+	}
+
+	return ret, types
+}
+
+type TypedURLCriterionInput struct {
+	// Type of the URLs to match
+	Type     URLType           `json:"type"`
+	Value    string            `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
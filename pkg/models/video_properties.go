@@ -0,0 +1,156 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// HDRFormat is the high dynamic range format of a video.
+type HDRFormat string
+
+const (
+	HDRFormatHDR10       HDRFormat = "HDR10"
+	HDRFormatHLG         HDRFormat = "HLG"
+	HDRFormatDolbyVision HDRFormat = "DOLBY_VISION"
+)
+
+var AllHDRFormat = []HDRFormat{
+	HDRFormatHDR10,
+	HDRFormatHLG,
+	HDRFormatDolbyVision,
+}
+
+func (e HDRFormat) IsValid() bool {
+	switch e {
+	case HDRFormatHDR10, HDRFormatHLG, HDRFormatDolbyVision:
+		return true
+	}
+	return false
+}
+
+func (e HDRFormat) String() string {
+	return string(e)
+}
+
+func (e *HDRFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HDRFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HDRFormat", str)
+	}
+	return nil
+}
+
+func (e HDRFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// VRProjection is the projection of a VR video.
+type VRProjection string
+
+const (
+	// 360 degree equirectangular
+	VRProjectionEquirectangular VRProjection = "EQUIRECTANGULAR"
+	// 180 degree equirectangular
+	VRProjectionEquirectangular180 VRProjection = "EQUIRECTANGULAR_180"
+	VRProjectionFisheye            VRProjection = "FISHEYE"
+	VRProjectionCubemap            VRProjection = "CUBEMAP"
+)
+
+var AllVRProjection = []VRProjection{
+	VRProjectionEquirectangular,
+	VRProjectionEquirectangular180,
+	VRProjectionFisheye,
+	VRProjectionCubemap,
+}
+
+func (e VRProjection) IsValid() bool {
+	switch e {
+	case VRProjectionEquirectangular, VRProjectionEquirectangular180, VRProjectionFisheye, VRProjectionCubemap:
+		return true
+	}
+	return false
+}
+
+func (e VRProjection) String() string {
+	return string(e)
+}
+
+func (e *VRProjection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VRProjection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VRProjection", str)
+	}
+	return nil
+}
+
+func (e VRProjection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StereoLayout is the layout of the views of a stereoscopic video.
+type StereoLayout string
+
+const (
+	StereoLayoutSideBySide StereoLayout = "SIDE_BY_SIDE"
+	StereoLayoutTopBottom  StereoLayout = "TOP_BOTTOM"
+)
+
+var AllStereoLayout = []StereoLayout{
+	StereoLayoutSideBySide,
+	StereoLayoutTopBottom,
+}
+
+func (e StereoLayout) IsValid() bool {
+	switch e {
+	case StereoLayoutSideBySide, StereoLayoutTopBottom:
+		return true
+	}
+	return false
+}
+
+func (e StereoLayout) String() string {
+	return string(e)
+}
+
+func (e *StereoLayout) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StereoLayout(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StereoLayout", str)
+	}
+	return nil
+}
+
+func (e StereoLayout) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HDRFormatCriterionInput struct {
+	Value    []HDRFormat       `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
+
+type VRProjectionCriterionInput struct {
+	Value    []VRProjection    `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
+
+type StereoLayoutCriterionInput struct {
+	Value    []StereoLayout    `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
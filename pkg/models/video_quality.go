@@ -0,0 +1,110 @@
+package models
+
+import (
+	"math"
+	"strings"
+)
+
+// videoCodecEfficiency is the compression efficiency of video codecs
+// relative to H.264. A codec with an efficiency of 2 needs roughly half of
+// the bitrate of H.264 for the same visual quality.
+var videoCodecEfficiency = map[string]float64{
+	"av1":        2,
+	"hevc":       1.6,
+	"h265":       1.6,
+	"vp9":        1.5,
+	"h264":       1,
+	"vc1":        0.8,
+	"wmv3":       0.7,
+	"vp8":        0.9,
+	"mpeg4":      0.7,
+	"msmpeg4v3":  0.6,
+	"msmpeg4v2":  0.5,
+	"mpeg2video": 0.5,
+	"mpeg1video": 0.4,
+	"mjpeg":      0.2,
+}
+
+// defaultVideoCodecEfficiency is used for codecs not in videoCodecEfficiency.
+const defaultVideoCodecEfficiency = 0.8
+
+const (
+	// weights of the quality score components. These add up to 1.
+	qualityResolutionWeight = 0.45
+	qualityBitrateWeight    = 0.4
+	qualityAudioWeight      = 0.15
+
+	// resolutions at and above maxQualityPixels score full marks, and
+	// resolutions at and below minQualityPixels score nothing
+	minQualityPixels = 426 * 240
+	maxQualityPixels = 3840 * 2160
+
+	// H.264 equivalent bits per pixel per frame
+	minQualityBitsPerPixel = 0.02
+	maxQualityBitsPerPixel = 0.2
+
+	minQualityAudioBitrate = 64000
+	maxQualityAudioBitrate = 256000
+
+	// frame rate assumed if it is unknown
+	defaultQualityFrameRate = 30
+)
+
+// logScale returns the position of v between lo and hi on a logarithmic
+// scale, clamped to [0, 1].
+func logScale(v, lo, hi float64) float64 {
+	if v <= lo {
+		return 0
+	}
+	if v >= hi {
+		return 1
+	}
+	return math.Log(v/lo) / math.Log(hi/lo)
+}
+
+// CalculateQualityScore returns the technical quality of the file from 0 to
+// 100, based on its resolution, its bitrate per pixel adjusted for the
+// efficiency of its video codec, and its audio bitrate. Returns 0 if the
+// resolution of the file is not known.
+func (f VideoFile) CalculateQualityScore() int {
+	if f.Width <= 0 || f.Height <= 0 {
+		return 0
+	}
+
+	pixels := float64(f.Width) * float64(f.Height)
+	resolution := logScale(pixels, minQualityPixels, maxQualityPixels)
+
+	// the bitrate includes the audio stream
+	videoBitrate := float64(f.BitRate)
+	if f.AudioBitRate > 0 && f.AudioBitRate < f.BitRate {
+		videoBitrate -= float64(f.AudioBitRate)
+	}
+
+	frameRate := f.FrameRate
+	if frameRate <= 0 {
+		frameRate = defaultQualityFrameRate
+	}
+
+	efficiency, found := videoCodecEfficiency[strings.ToLower(f.VideoCodec)]
+	if !found {
+		efficiency = defaultVideoCodecEfficiency
+	}
+
+	bitsPerPixel := videoBitrate / (pixels * frameRate) * efficiency
+	bitrate := logScale(bitsPerPixel, minQualityBitsPerPixel, maxQualityBitsPerPixel)
+
+	var audio float64
+	switch {
+	case f.AudioCodec == "":
+		// no audio stream
+	case f.AudioBitRate <= 0:
+		// audio bitrate is not known for some containers, so score it as
+		// average rather than penalising it
+		audio = 0.5
+	default:
+		audio = logScale(float64(f.AudioBitRate), minQualityAudioBitrate, maxQualityAudioBitrate)
+	}
+
+	score := resolution*qualityResolutionWeight + bitrate*qualityBitrateWeight + audio*qualityAudioWeight
+	return int(math.Round(score * 100))
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoFile_CalculateQualityScore(t *testing.T) {
+	const (
+		mbps = 1000000
+		kbps = 1000
+	)
+
+	hd := VideoFile{
+		Width:        1920,
+		Height:       1080,
+		FrameRate:    30,
+		VideoCodec:   "h264",
+		AudioCodec:   "aac",
+		BitRate:      8 * mbps,
+		AudioBitRate: 192 * kbps,
+	}
+
+	with := func(f func(v *VideoFile)) VideoFile {
+		ret := hd
+		f(&ret)
+		return ret
+	}
+
+	score := hd.CalculateQualityScore()
+	assert.Greater(t, score, 0)
+	assert.Less(t, score, 100)
+
+	tests := []struct {
+		name   string
+		better VideoFile
+		worse  VideoFile
+	}{
+		{
+			"higher resolution",
+			with(func(v *VideoFile) { v.Width, v.Height, v.BitRate = 3840, 2160, 32*mbps }),
+			hd,
+		},
+		{
+			"higher bitrate",
+			hd,
+			with(func(v *VideoFile) { v.BitRate = 2 * mbps }),
+		},
+		{
+			"more efficient codec",
+			with(func(v *VideoFile) { v.VideoCodec = "hevc" }),
+			hd,
+		},
+		{
+			"less efficient codec",
+			hd,
+			with(func(v *VideoFile) { v.VideoCodec = "mpeg2video" }),
+		},
+		{
+			"higher frame rate at same bitrate",
+			hd,
+			with(func(v *VideoFile) { v.FrameRate = 60 }),
+		},
+		{
+			"higher audio bitrate",
+			hd,
+			with(func(v *VideoFile) { v.AudioBitRate = 96 * kbps }),
+		},
+		{
+			"unknown audio bitrate",
+			with(func(v *VideoFile) { v.AudioBitRate = 0 }),
+			with(func(v *VideoFile) { v.AudioCodec = ""; v.AudioBitRate = 0 }),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Greater(t, tt.better.CalculateQualityScore(), tt.worse.CalculateQualityScore())
+		})
+	}
+
+	best := VideoFile{
+		Width:        7680,
+		Height:       4320,
+		FrameRate:    30,
+		VideoCodec:   "av1",
+		AudioCodec:   "flac",
+		BitRate:      400 * mbps,
+		AudioBitRate: 1000 * kbps,
+	}
+	assert.Equal(t, 100, best.CalculateQualityScore())
+
+	assert.Equal(t, 0, VideoFile{}.CalculateQualityScore(), "unknown resolution")
+}
@@ -0,0 +1,42 @@
+package models
+
+import "math"
+
+// WatchHeatBucketSeconds is the length in seconds of each bucket of scene
+// watch heat.
+const WatchHeatBucketSeconds = 5
+
+// WatchHeat is the number of seconds each bucket of a scene has been watched.
+// Index i represents the range [i*WatchHeatBucketSeconds, (i+1)*WatchHeatBucketSeconds).
+type WatchHeat []float64
+
+// Add adds the watched range of start to end seconds to the buckets it overlaps.
+func (h WatchHeat) Add(start float64, end float64) WatchHeat {
+	for bucket, seconds := range WatchedBuckets(start, end) {
+		for len(h) <= bucket {
+			h = append(h, 0)
+		}
+		h[bucket] += seconds
+	}
+
+	return h
+}
+
+// WatchedBuckets splits the watched range of start to end seconds into
+// buckets, returning the number of seconds watched in each bucket.
+func WatchedBuckets(start float64, end float64) map[int]float64 {
+	start = math.Max(start, 0)
+	if end <= start {
+		return nil
+	}
+
+	ret := make(map[int]float64)
+	for t := start; t < end; {
+		bucket := int(t / WatchHeatBucketSeconds)
+		bucketEnd := math.Min(float64(bucket+1)*WatchHeatBucketSeconds, end)
+		ret[bucket] += bucketEnd - t
+		t = bucketEnd
+	}
+
+	return ret
+}
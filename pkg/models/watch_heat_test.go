@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchedBuckets(t *testing.T) {
+	tests := []struct {
+		name  string
+		start float64
+		end   float64
+		want  map[int]float64
+	}{
+		{"empty", 10, 10, nil},
+		{"reversed", 10, 5, nil},
+		{"single bucket", 1, 4, map[int]float64{0: 3}},
+		{"bucket boundary", 5, 10, map[int]float64{1: 5}},
+		{"spanning", 3, 12, map[int]float64{0: 2, 1: 5, 2: 2}},
+		{"negative start", -5, 2, map[int]float64{0: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WatchedBuckets(tt.start, tt.end))
+		})
+	}
+}
+
+func TestWatchHeat_Add(t *testing.T) {
+	var h WatchHeat
+	h = h.Add(3, 12)
+	h = h.Add(0, 5)
+
+	assert.Equal(t, WatchHeat{7, 5, 2}, h)
+}
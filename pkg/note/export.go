@@ -0,0 +1,48 @@
+package note
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/json"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+func toJSON(notes []*models.Note) []jsonschema.Note {
+	var results []jsonschema.Note
+
+	for _, n := range notes {
+		results = append(results, jsonschema.Note{
+			Author:    n.Author,
+			Body:      n.Body,
+			Pinned:    n.Pinned,
+			CreatedAt: json.JSONTime{Time: n.CreatedAt},
+			UpdatedAt: json.JSONTime{Time: n.UpdatedAt},
+		})
+	}
+
+	return results
+}
+
+// GetSceneNotesJSON returns the JSON representation of the notes of the
+// scene.
+func GetSceneNotesJSON(ctx context.Context, reader models.NoteFinder, sceneID int) ([]jsonschema.Note, error) {
+	notes, err := reader.FindBySceneID(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene notes: %v", err)
+	}
+
+	return toJSON(notes), nil
+}
+
+// GetPerformerNotesJSON returns the JSON representation of the notes of the
+// performer.
+func GetPerformerNotesJSON(ctx context.Context, reader models.NoteFinder, performerID int) ([]jsonschema.Note, error) {
+	notes, err := reader.FindByPerformerID(ctx, performerID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting performer notes: %v", err)
+	}
+
+	return toJSON(notes), nil
+}
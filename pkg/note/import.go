@@ -0,0 +1,101 @@
+package note
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+type ImporterReaderWriter interface {
+	models.NoteFinderCreator
+	models.NoteUpdater
+}
+
+// Importer imports a note of a scene or performer. Exactly one of SceneID or
+// PerformerID must be set.
+type Importer struct {
+	SceneID      *int
+	PerformerID  *int
+	ReaderWriter ImporterReaderWriter
+	Input        jsonschema.Note
+
+	note models.Note
+}
+
+func (i *Importer) PreImport(ctx context.Context) error {
+	if (i.SceneID == nil) == (i.PerformerID == nil) {
+		return ErrTargetMissing
+	}
+
+	i.note = models.Note{
+		SceneID:     i.SceneID,
+		PerformerID: i.PerformerID,
+		Author:      i.Input.Author,
+		Body:        i.Input.Body,
+		Pinned:      i.Input.Pinned,
+		CreatedAt:   i.Input.CreatedAt.GetTime(),
+		UpdatedAt:   i.Input.UpdatedAt.GetTime(),
+	}
+
+	return nil
+}
+
+func (i *Importer) Name() string {
+	return fmt.Sprintf("note created at %s", i.note.CreatedAt.Format(time.RFC3339))
+}
+
+func (i *Importer) PostImport(ctx context.Context, id int) error {
+	return nil
+}
+
+// FindExistingID returns the note of the same object created at the same
+// time, if any.
+func (i *Importer) FindExistingID(ctx context.Context) (*int, error) {
+	var existing []*models.Note
+	var err error
+	if i.SceneID != nil {
+		existing, err = i.ReaderWriter.FindBySceneID(ctx, *i.SceneID)
+	} else {
+		existing, err = i.ReaderWriter.FindByPerformerID(ctx, *i.PerformerID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range existing {
+		if n.CreatedAt.Unix() == i.note.CreatedAt.Unix() {
+			id := n.ID
+			return &id, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (i *Importer) Create(ctx context.Context) (*int, error) {
+	if err := i.ReaderWriter.Create(ctx, &i.note); err != nil {
+		return nil, fmt.Errorf("error creating note: %v", err)
+	}
+
+	id := i.note.ID
+	return &id, nil
+}
+
+func (i *Importer) Update(ctx context.Context, id int) error {
+	partial := models.NotePartial{
+		Author:    models.NewOptionalString(i.note.Author),
+		Body:      models.NewOptionalString(i.note.Body),
+		Pinned:    models.NewOptionalBool(i.note.Pinned),
+		UpdatedAt: models.NewOptionalTime(i.note.UpdatedAt),
+	}
+
+	if _, err := i.ReaderWriter.UpdatePartial(ctx, id, partial); err != nil {
+		return fmt.Errorf("error updating existing note: %v", err)
+	}
+
+	return nil
+}
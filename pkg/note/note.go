@@ -0,0 +1,76 @@
+// Package note provides the creation and rendering of scene and performer
+// notes.
+package note
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrBodyMissing   = errors.New("note body must not be empty")
+	ErrTargetMissing = errors.New("exactly one of scene or performer must be provided")
+)
+
+// CreateInput contains the options used to create a new note.
+// Exactly one of SceneID or PerformerID must be set.
+type CreateInput struct {
+	SceneID     *int
+	PerformerID *int
+	Author      string
+	Body        string
+	Pinned      bool
+}
+
+func (i CreateInput) validate() error {
+	if (i.SceneID == nil) == (i.PerformerID == nil) {
+		return ErrTargetMissing
+	}
+
+	return ValidateBody(i.Body)
+}
+
+// ValidateBody returns an error if the body is empty or only whitespace.
+func ValidateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return ErrBodyMissing
+	}
+
+	return nil
+}
+
+// Create creates a new note.
+func Create(ctx context.Context, qb models.NoteCreator, input CreateInput) (*models.Note, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	newNote := models.NewNote()
+	newNote.SceneID = input.SceneID
+	newNote.PerformerID = input.PerformerID
+	newNote.Author = strings.TrimSpace(input.Author)
+	newNote.Body = input.Body
+	newNote.Pinned = input.Pinned
+
+	if err := qb.Create(ctx, &newNote); err != nil {
+		return nil, err
+	}
+
+	return &newNote, nil
+}
+
+// RenderMarkdown renders a markdown note body as HTML. Raw HTML in the body
+// is dropped and only safe link protocols are rendered as links, so the
+// output can be inserted into a page as is.
+func RenderMarkdown(body string) string {
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.Safelink | blackfriday.NofollowLinks | blackfriday.NoreferrerLinks | blackfriday.HrefTargetBlank,
+	})
+
+	return string(blackfriday.Run([]byte(body), blackfriday.WithRenderer(renderer)))
+}
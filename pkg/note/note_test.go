@@ -0,0 +1,39 @@
+package note
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInputValidate(t *testing.T) {
+	id := 1
+
+	tests := []struct {
+		name  string
+		input CreateInput
+		want  error
+	}{
+		{"scene", CreateInput{SceneID: &id, Body: "body"}, nil},
+		{"performer", CreateInput{PerformerID: &id, Body: "body"}, nil},
+		{"no target", CreateInput{Body: "body"}, ErrTargetMissing},
+		{"both targets", CreateInput{SceneID: &id, PerformerID: &id, Body: "body"}, ErrTargetMissing},
+		{"empty body", CreateInput{SceneID: &id, Body: " \n"}, ErrBodyMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.input.validate())
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	got := RenderMarkdown("**bold** [link](https://example.com) <script>alert(1)</script> [bad](javascript:alert(1))")
+
+	assert.Contains(t, got, "<strong>bold</strong>")
+	assert.Contains(t, got, `href="https://example.com"`)
+	assert.False(t, strings.Contains(got, "<script>"))
+	assert.False(t, strings.Contains(got, `href="javascript:`))
+}
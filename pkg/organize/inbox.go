@@ -0,0 +1,65 @@
+package organize
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Inbox configures a watch folder. Files added to the folder are scanned,
+// identified and then moved into the library using the template.
+type Inbox struct {
+	// Path is the watch folder. It must be within a library path.
+	Path string `json:"path"`
+	// Template used to generate the new path of each file, relative to Destination.
+	Template string `json:"template"`
+	// Destination is the directory that files are moved into. It must be
+	// within a library path, and must not be within Path.
+	Destination string `json:"destination"`
+	// Collision is the strategy used when the destination already exists.
+	// Defaults to SKIP.
+	Collision CollisionStrategy `json:"collision"`
+	// Interval is the number of minutes between checks of the watch folder.
+	// Zero disables scheduled checks.
+	Interval int `json:"interval"`
+}
+
+// Validate returns an error if the inbox configuration is invalid.
+func (i Inbox) Validate() error {
+	if i.Path == "" {
+		return errors.New("inbox path must be set")
+	}
+
+	if i.Destination == "" {
+		return errors.New("inbox destination must be set")
+	}
+
+	if isWithin(i.Destination, i.Path) {
+		return fmt.Errorf("inbox destination %s must not be within the inbox path", i.Destination)
+	}
+
+	if err := Template(i.Template).Validate(); err != nil {
+		return fmt.Errorf("invalid inbox template: %w", err)
+	}
+
+	if i.Collision != "" && !i.Collision.IsValid() {
+		return fmt.Errorf("invalid collision strategy %q", i.Collision)
+	}
+
+	if i.Interval < 0 {
+		return errors.New("inbox interval must not be negative")
+	}
+
+	return nil
+}
+
+// isWithin returns true if path is dir or is within dir.
+func isWithin(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,46 @@
+package organize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInbox_Validate(t *testing.T) {
+	valid := Inbox{
+		Path:        "/lib/inbox",
+		Template:    "{studio}/{title}",
+		Destination: "/lib/sorted",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(i *Inbox)
+		wantErr bool
+	}{
+		{"valid", func(i *Inbox) {}, false},
+		{"missing path", func(i *Inbox) { i.Path = "" }, true},
+		{"missing destination", func(i *Inbox) { i.Destination = "" }, true},
+		{"destination is path", func(i *Inbox) { i.Destination = "/lib/inbox" }, true},
+		{"destination within path", func(i *Inbox) { i.Destination = "/lib/inbox/sorted" }, true},
+		{"destination with path prefix", func(i *Inbox) { i.Destination = "/lib/inbox2" }, false},
+		{"path within destination", func(i *Inbox) { i.Destination = "/lib" }, false},
+		{"invalid template", func(i *Inbox) { i.Template = "{invalid}" }, true},
+		{"invalid collision", func(i *Inbox) { i.Collision = "invalid" }, true},
+		{"negative interval", func(i *Inbox) { i.Interval = -1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := valid
+			tt.modify(&i)
+
+			err := i.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
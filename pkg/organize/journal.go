@@ -0,0 +1,216 @@
+package organize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+const journalExt = ".json"
+
+var ErrJournalNotFound = errors.New("journal not found")
+
+type JournalStatus string
+
+const (
+	// The moves are being performed
+	JournalStatusPending JournalStatus = "PENDING"
+	// All moves were performed and committed
+	JournalStatusCompleted JournalStatus = "COMPLETED"
+	// The moves were reverted by a later rollback
+	JournalStatusRolledBack JournalStatus = "ROLLED_BACK"
+)
+
+var AllJournalStatus = []JournalStatus{
+	JournalStatusPending,
+	JournalStatusCompleted,
+	JournalStatusRolledBack,
+}
+
+func (e JournalStatus) IsValid() bool {
+	switch e {
+	case JournalStatusPending, JournalStatusCompleted, JournalStatusRolledBack:
+		return true
+	}
+	return false
+}
+
+func (e JournalStatus) String() string {
+	return string(e)
+}
+
+func (e *JournalStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JournalStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrganizeJournalStatus", str)
+	}
+	return nil
+}
+
+func (e JournalStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Journal records a set of moves so that they can be reverted later.
+// A journal left in the pending state indicates that the process was
+// interrupted, and that the filesystem may need to be checked.
+type Journal struct {
+	ID        string        `json:"id"`
+	Template  string        `json:"template"`
+	Status    JournalStatus `json:"status"`
+	Moves     []Move        `json:"moves"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// JournalStore reads and writes journals as json files in a directory.
+type JournalStore struct {
+	Dir string
+}
+
+func newJournalID(t time.Time) string {
+	return t.UTC().Format("20060102T150405.000000000Z")
+}
+
+// Create writes a new pending journal for the provided moves.
+func (s JournalStore) Create(template string, moves []Move) (*Journal, error) {
+	now := time.Now()
+	j := &Journal{
+		ID:        newJournalID(now),
+		Template:  template,
+		Status:    JournalStatusPending,
+		Moves:     moves,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.Save(j); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Save writes the journal, replacing any existing journal with the same ID.
+func (s JournalStore) Save(j *Journal) error {
+	if err := fsutil.EnsureDirAll(s.Dir); err != nil {
+		return fmt.Errorf("creating journal directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a journal is never left partially written
+	fn := s.path(j.ID)
+	tmpFn := fn + ".tmp"
+	if err := os.WriteFile(tmpFn, data, 0644); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+
+	return os.Rename(tmpFn, fn)
+}
+
+// SetStatus updates and saves the status of the journal.
+func (s JournalStore) SetStatus(j *Journal, status JournalStatus) error {
+	j.Status = status
+	j.UpdatedAt = time.Now()
+	return s.Save(j)
+}
+
+// Delete removes the journal.
+func (s JournalStore) Delete(id string) error {
+	return os.Remove(s.path(id))
+}
+
+// Find returns the journal with the given ID. Returns ErrJournalNotFound if
+// it does not exist.
+func (s JournalStore) Find(id string) (*Journal, error) {
+	// prevent path traversal
+	if id == "" || filepath.Base(id) != id {
+		return nil, ErrJournalNotFound
+	}
+
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrJournalNotFound
+		}
+		return nil, err
+	}
+
+	var ret Journal
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("reading journal %s: %w", id, err)
+	}
+
+	return &ret, nil
+}
+
+// All returns all journals, most recent first.
+func (s JournalStore) All() ([]*Journal, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret []*Journal
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), journalExt) {
+			continue
+		}
+
+		j, err := s.Find(strings.TrimSuffix(e.Name(), journalExt))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, j)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.After(ret[j].CreatedAt)
+	})
+
+	return ret, nil
+}
+
+func (s JournalStore) path(id string) string {
+	return filepath.Join(s.Dir, id+journalExt)
+}
+
+// Reversed returns the moves required to revert the journal, in reverse order.
+func (j Journal) Reversed() []Move {
+	var ret []Move
+	for i := len(j.Moves) - 1; i >= 0; i-- {
+		m := j.Moves[i]
+		if m.Skipped != "" {
+			continue
+		}
+
+		ret = append(ret, Move{
+			FileID:  m.FileID,
+			OldPath: m.NewPath,
+			NewPath: m.OldPath,
+		})
+	}
+
+	return ret
+}
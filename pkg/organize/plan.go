@@ -0,0 +1,165 @@
+package organize
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// maxSuffix is the maximum number appended to a filename when resolving collisions.
+const maxSuffix = 1000
+
+var ErrCollision = errors.New("destination already exists")
+
+type CollisionStrategy string
+
+const (
+	// Files that would collide with an existing file are not moved
+	CollisionStrategySkip CollisionStrategy = "SKIP"
+	// A numeric suffix is appended to the filename to make it unique
+	CollisionStrategySuffix CollisionStrategy = "SUFFIX"
+	// The plan fails if any file would collide with an existing file
+	CollisionStrategyFail CollisionStrategy = "FAIL"
+)
+
+var AllCollisionStrategy = []CollisionStrategy{
+	CollisionStrategySkip,
+	CollisionStrategySuffix,
+	CollisionStrategyFail,
+}
+
+func (e CollisionStrategy) IsValid() bool {
+	switch e {
+	case CollisionStrategySkip, CollisionStrategySuffix, CollisionStrategyFail:
+		return true
+	}
+	return false
+}
+
+func (e CollisionStrategy) String() string {
+	return string(e)
+}
+
+func (e *CollisionStrategy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CollisionStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrganizeCollisionStrategy", str)
+	}
+	return nil
+}
+
+func (e CollisionStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Move is a single planned file move.
+type Move struct {
+	FileID  models.FileID `json:"file_id"`
+	OldPath string        `json:"old_path"`
+	NewPath string        `json:"new_path"`
+	// Skipped contains the reason the file will not be moved, if applicable.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Planner builds a list of moves, detecting collisions with existing files
+// and with other moves in the same plan.
+type Planner struct {
+	Statter   file.Statter
+	Collision CollisionStrategy
+
+	Moves []Move
+
+	claimed map[string]bool
+}
+
+func NewPlanner(statter file.Statter, collision CollisionStrategy) *Planner {
+	if !collision.IsValid() {
+		collision = CollisionStrategySkip
+	}
+
+	return &Planner{
+		Statter:   statter,
+		Collision: collision,
+		claimed:   make(map[string]bool),
+	}
+}
+
+// Add adds a move of the file to newPath to the plan. Returns ErrCollision if
+// the destination is taken and the collision strategy is FAIL.
+func (p *Planner) Add(fileID models.FileID, oldPath string, newPath string) (Move, error) {
+	m := Move{
+		FileID:  fileID,
+		OldPath: oldPath,
+		NewPath: newPath,
+	}
+
+	if oldPath == newPath {
+		m.Skipped = "already at destination"
+		p.Moves = append(p.Moves, m)
+		return m, nil
+	}
+
+	if p.taken(newPath) {
+		switch p.Collision {
+		case CollisionStrategyFail:
+			return Move{}, fmt.Errorf("moving %s to %s: %w", oldPath, newPath, ErrCollision)
+		case CollisionStrategySuffix:
+			resolved, err := p.suffixed(newPath)
+			if err != nil {
+				return Move{}, err
+			}
+			m.NewPath = resolved
+		default:
+			m.Skipped = ErrCollision.Error()
+		}
+	}
+
+	if m.Skipped == "" {
+		p.claimed[pathKey(m.NewPath)] = true
+	}
+
+	p.Moves = append(p.Moves, m)
+	return m, nil
+}
+
+func (p *Planner) taken(path string) bool {
+	if p.claimed[pathKey(path)] {
+		return true
+	}
+
+	_, err := p.Statter.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+func (p *Planner) suffixed(path string) (string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+
+	for i := 1; i <= maxSuffix; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if !p.taken(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find unique filename for %s", path)
+}
+
+// pathKey returns the key used to detect collisions within a plan.
+// Paths are compared case-insensitively, since the destination filesystem
+// may be case-insensitive.
+func pathKey(path string) string {
+	return strings.ToLower(filepath.Clean(path))
+}
@@ -0,0 +1,110 @@
+package organize
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapStatter map[string]bool
+
+func (s mapStatter) Stat(name string) (fs.FileInfo, error) {
+	if s[name] {
+		return nil, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+func TestPlanner_Add(t *testing.T) {
+	existing := mapStatter{
+		"/lib/taken.mp4":     true,
+		"/lib/taken (1).mp4": true,
+	}
+
+	tests := []struct {
+		name      string
+		collision CollisionStrategy
+		newPaths  []string
+		want      []Move
+		wantErr   error
+	}{
+		{
+			"unchanged",
+			CollisionStrategySkip,
+			[]string{"/lib/old.mp4"},
+			[]Move{{OldPath: "/lib/old.mp4", NewPath: "/lib/old.mp4", Skipped: "already at destination"}},
+			nil,
+		},
+		{
+			"skip existing",
+			CollisionStrategySkip,
+			[]string{"/lib/taken.mp4"},
+			[]Move{{OldPath: "/lib/old.mp4", NewPath: "/lib/taken.mp4", Skipped: ErrCollision.Error()}},
+			nil,
+		},
+		{
+			"skip within plan",
+			CollisionStrategySkip,
+			[]string{"/lib/new.mp4", "/lib/NEW.mp4"},
+			[]Move{
+				{OldPath: "/lib/old.mp4", NewPath: "/lib/new.mp4"},
+				{OldPath: "/lib/old.mp4", NewPath: "/lib/NEW.mp4", Skipped: ErrCollision.Error()},
+			},
+			nil,
+		},
+		{
+			"suffix existing",
+			CollisionStrategySuffix,
+			[]string{"/lib/taken.mp4", "/lib/taken.mp4"},
+			[]Move{
+				{OldPath: "/lib/old.mp4", NewPath: "/lib/taken (2).mp4"},
+				{OldPath: "/lib/old.mp4", NewPath: "/lib/taken (3).mp4"},
+			},
+			nil,
+		},
+		{
+			"fail existing",
+			CollisionStrategyFail,
+			[]string{"/lib/taken.mp4"},
+			nil,
+			ErrCollision,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlanner(existing, tt.collision)
+
+			var err error
+			for _, np := range tt.newPaths {
+				if _, err = p.Add(0, "/lib/old.mp4", np); err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, p.Moves)
+		})
+	}
+}
+
+func TestJournal_Reversed(t *testing.T) {
+	j := Journal{
+		Moves: []Move{
+			{FileID: 1, OldPath: "a", NewPath: "b"},
+			{FileID: 2, OldPath: "c", NewPath: "d", Skipped: "skipped"},
+			{FileID: 3, OldPath: "e", NewPath: "f"},
+		},
+	}
+
+	assert.Equal(t, []Move{
+		{FileID: 3, OldPath: "f", NewPath: "e"},
+		{FileID: 1, OldPath: "b", NewPath: "a"},
+	}, j.Reversed())
+}
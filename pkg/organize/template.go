@@ -0,0 +1,283 @@
+// Package organize provides template driven renaming and moving of library files.
+package organize
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	FieldID         = "id"
+	FieldTitle      = "title"
+	FieldDate       = "date"
+	FieldYear       = "year"
+	FieldStudio     = "studio"
+	FieldPerformers = "performers"
+	FieldCode       = "code"
+	FieldDirector   = "director"
+	FieldResolution = "resolution"
+	FieldBasename   = "basename"
+	FieldExt        = "ext"
+)
+
+var validFields = map[string]bool{
+	FieldID:         true,
+	FieldTitle:      true,
+	FieldDate:       true,
+	FieldYear:       true,
+	FieldStudio:     true,
+	FieldPerformers: true,
+	FieldCode:       true,
+	FieldDirector:   true,
+	FieldResolution: true,
+	FieldBasename:   true,
+	FieldExt:        true,
+}
+
+// fields available to marker clip templates, in addition to the scene fields
+const (
+	FieldMarkerID    = "marker_id"
+	FieldMarkerTitle = "marker_title"
+	FieldMarkerTag   = "marker_tag"
+	FieldStart       = "start"
+	FieldEnd         = "end"
+)
+
+var validMarkerFields = map[string]bool{
+	FieldMarkerID:    true,
+	FieldMarkerTitle: true,
+	FieldMarkerTag:   true,
+	FieldStart:       true,
+	FieldEnd:         true,
+}
+
+// fields available to image templates, in addition to the common fields
+const (
+	FieldIndex   = "index"
+	FieldGallery = "gallery"
+)
+
+var validImageFields = map[string]bool{
+	FieldID:       true,
+	FieldTitle:    true,
+	FieldDate:     true,
+	FieldYear:     true,
+	FieldCode:     true,
+	FieldBasename: true,
+	FieldExt:      true,
+	FieldIndex:    true,
+	FieldGallery:  true,
+}
+
+var (
+	fieldRE = regexp.MustCompile(`\{(\w+)\}`)
+	// characters that are not permitted in file names on common filesystems
+	invalidCharsRE = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
+)
+
+// Fields maps template field names to their values.
+type Fields map[string]string
+
+// SceneFields returns the template fields for the given scene and video file.
+func SceneFields(s *models.Scene, f *models.VideoFile, studio string, performers []string) Fields {
+	ext := filepath.Ext(f.Basename)
+
+	ret := Fields{
+		FieldID:         strconv.Itoa(s.ID),
+		FieldTitle:      s.Title,
+		FieldStudio:     studio,
+		FieldPerformers: strings.Join(performers, ", "),
+		FieldCode:       s.Code,
+		FieldDirector:   s.Director,
+		FieldBasename:   strings.TrimSuffix(f.Basename, ext),
+		FieldExt:        ext,
+	}
+
+	if s.Date != nil {
+		ret[FieldDate] = s.Date.String()
+		ret[FieldYear] = strconv.Itoa(s.Date.Year())
+	}
+
+	if f.Height > 0 {
+		ret[FieldResolution] = strconv.Itoa(f.Height) + "p"
+	}
+
+	return ret
+}
+
+// MarkerFields adds the template fields for the given marker to fields. The
+// start and end times are formatted as hh.mm.ss.
+func MarkerFields(fields Fields, m *models.SceneMarker, primaryTag string, endSeconds float64) Fields {
+	fields[FieldMarkerID] = strconv.Itoa(m.ID)
+	fields[FieldMarkerTitle] = m.Title
+	fields[FieldMarkerTag] = primaryTag
+	fields[FieldStart] = formatTemplateSeconds(m.Seconds)
+	fields[FieldEnd] = formatTemplateSeconds(endSeconds)
+	return fields
+}
+
+// ImageFields returns the template fields for the given image and its file
+// within a gallery. The index is one-based, and is zero padded to the width
+// of total.
+func ImageFields(i *models.Image, f models.File, gallery string, index int, total int) Fields {
+	basename := f.Base().Basename
+	ext := filepath.Ext(basename)
+
+	ret := Fields{
+		FieldID:       strconv.Itoa(i.ID),
+		FieldTitle:    i.Title,
+		FieldCode:     i.Code,
+		FieldBasename: strings.TrimSuffix(basename, ext),
+		FieldExt:      ext,
+		FieldIndex:    fmt.Sprintf("%0*d", len(strconv.Itoa(total)), index),
+		FieldGallery:  gallery,
+	}
+
+	if i.Date != nil {
+		ret[FieldDate] = i.Date.String()
+		ret[FieldYear] = strconv.Itoa(i.Date.Year())
+	}
+
+	return ret
+}
+
+func formatTemplateSeconds(seconds float64) string {
+	s := int(seconds)
+	return fmt.Sprintf("%02d.%02d.%02d", s/3600, (s/60)%60, s%60)
+}
+
+// Template is a path template, such as "{studio}/{date} {title}{ext}".
+// Forward slashes separate directories. If the template does not contain
+// {ext}, the original file extension is appended.
+type Template string
+
+// Validate returns an error if the template is empty or contains unknown fields.
+func (t Template) Validate() error {
+	return validateTemplate(string(t), validFields)
+}
+
+// Render returns the relative path produced by the template for the
+// provided fields. Field values are sanitised so that they cannot
+// introduce additional path segments. Returns an error if the template
+// produces an empty filename.
+func (t Template) Render(fields Fields) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	return render(string(t), fields)
+}
+
+// MarkerTemplate is a path template for marker clips, such as
+// "{title} - {marker_tag} {start}". It accepts the scene fields and the
+// marker fields.
+type MarkerTemplate string
+
+// Validate returns an error if the template is empty or contains unknown fields.
+func (t MarkerTemplate) Validate() error {
+	return validateTemplate(string(t), validFields, validMarkerFields)
+}
+
+// Render returns the relative path produced by the template for the
+// provided fields. See Template.Render.
+func (t MarkerTemplate) Render(fields Fields) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	return render(string(t), fields)
+}
+
+// ImageTemplate is a filename template for images, such as
+// "{gallery}/{index} {title}". It accepts the image fields.
+type ImageTemplate string
+
+// Validate returns an error if the template is empty or contains unknown fields.
+func (t ImageTemplate) Validate() error {
+	return validateTemplate(string(t), validImageFields)
+}
+
+// Render returns the relative path produced by the template for the
+// provided fields. See Template.Render.
+func (t ImageTemplate) Render(fields Fields) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	return render(string(t), fields)
+}
+
+func validateTemplate(t string, valid ...map[string]bool) error {
+	if strings.TrimSpace(t) == "" {
+		return fmt.Errorf("template must not be empty")
+	}
+
+	for _, m := range fieldRE.FindAllStringSubmatch(t, -1) {
+		found := false
+		for _, v := range valid {
+			if v[m[1]] {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unknown template field {%s}", m[1])
+		}
+	}
+
+	return nil
+}
+
+func render(t string, fields Fields) (string, error) {
+	rendered := fieldRE.ReplaceAllStringFunc(t, func(m string) string {
+		return sanitise(fields[m[1:len(m)-1]])
+	})
+
+	var segments []string
+	for _, s := range strings.Split(rendered, "/") {
+		s = cleanSegment(s)
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	if len(segments) == 0 {
+		return "", fmt.Errorf("template %q produced an empty path", t)
+	}
+
+	// ensure the filename ends with the extension, without trailing spaces
+	// before it from missing values
+	ext := fields[FieldExt]
+	last := segments[len(segments)-1]
+	last = strings.TrimRight(strings.TrimSuffix(last, ext), ". ")
+	if last == "" {
+		return "", fmt.Errorf("template %q produced an empty filename", t)
+	}
+	segments[len(segments)-1] = last + ext
+
+	return filepath.Join(segments...), nil
+}
+
+func sanitise(v string) string {
+	return invalidCharsRE.ReplaceAllString(v, "_")
+}
+
+// cleanSegment trims a path segment, removing empty brackets left by missing
+// values and disallowing relative path segments.
+func cleanSegment(s string) string {
+	for _, empty := range []string{"()", "[]"} {
+		s = strings.ReplaceAll(s, empty, "")
+	}
+
+	s = strings.Join(strings.Fields(s), " ")
+	// trailing dots and spaces are not permitted on Windows
+	s = strings.TrimRight(s, ". ")
+
+	return s
+}
@@ -0,0 +1,113 @@
+package organize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_Render(t *testing.T) {
+	fields := Fields{
+		FieldTitle:  "A Title",
+		FieldDate:   "2023-01-02",
+		FieldStudio: "Studio",
+		FieldExt:    ".mp4",
+	}
+
+	tests := []struct {
+		name     string
+		template Template
+		fields   Fields
+		want     string
+		wantErr  bool
+	}{
+		{"simple", "{studio}/{date} {title}{ext}", fields, filepath.Join("Studio", "2023-01-02 A Title.mp4"), false},
+		{"extension appended", "{title}", fields, "A Title.mp4", false},
+		{"missing value collapses", "{performers}/{title} ({code}){ext}", fields, "A Title.mp4", false},
+		{"separator in value", "{title}{ext}", Fields{FieldTitle: "a/b\\c", FieldExt: ".mp4"}, "a_b_c.mp4", false},
+		{"relative segment removed", "{title}/../{studio}{ext}", fields, filepath.Join("A Title", "Studio.mp4"), false},
+		{"dot value removed", "{title}/{studio}{ext}", Fields{FieldTitle: "..", FieldStudio: "s", FieldExt: ".mp4"}, "s.mp4", false},
+		{"empty filename", "{studio}/{title}{ext}", Fields{FieldStudio: "s", FieldExt: ".mp4"}, "", true},
+		{"unknown field", "{unknown}{ext}", fields, "", true},
+		{"empty template", " ", fields, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.template.Render(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Template.Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMarkerTemplate_Render(t *testing.T) {
+	end := 3725.5
+	fields := MarkerFields(Fields{
+		FieldTitle: "A Title",
+		FieldExt:   ".mp4",
+	}, &models.SceneMarker{
+		ID:      12,
+		Title:   "Marker",
+		Seconds: 65,
+	}, "Tag", end)
+
+	tests := []struct {
+		name     string
+		template MarkerTemplate
+		want     string
+		wantErr  bool
+	}{
+		{"marker fields", "{title} - {marker_tag} {start}-{end}", "A Title - Tag 00.01.05-01.02.05.mp4", false},
+		{"marker id", "{title}/{marker_id} {marker_title}{ext}", filepath.Join("A Title", "12 Marker.mp4"), false},
+		{"unknown field", "{unknown}", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.template.Render(fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MarkerTemplate.Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// marker fields are not valid in scene templates
+	assert.Error(t, Template("{marker_tag}").Validate())
+}
+
+func TestImageTemplate_Render(t *testing.T) {
+	fields := ImageFields(&models.Image{
+		ID:    3,
+		Title: "Image",
+	}, &models.ImageFile{
+		BaseFile: &models.BaseFile{Basename: "img001.jpg"},
+	}, "Gallery", 7, 120)
+
+	tests := []struct {
+		name     string
+		template ImageTemplate
+		want     string
+		wantErr  bool
+	}{
+		{"padded index", "{index} {title}", "007 Image.jpg", false},
+		{"basename", "{gallery}/{basename}", filepath.Join("Gallery", "img001.jpg"), false},
+		{"missing value collapses", "{index} {date} {title}{ext}", "007 Image.jpg", false},
+		{"scene field", "{studio}", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.template.Render(fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImageTemplate.Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package performer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stashapp/stash/pkg/hash/imagephash"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// DefaultNameSimilarity is the default minimum similarity of the names of
+// duplicate performers.
+const DefaultNameSimilarity = 0.9
+
+// DuplicateReason is the reason performers are considered duplicates.
+type DuplicateReason string
+
+const (
+	// names are similar
+	DuplicateReasonName DuplicateReason = "NAME"
+	// the name or an alias of one performer is an alias of another
+	DuplicateReasonAlias DuplicateReason = "ALIAS"
+	// performers share a stash ID
+	DuplicateReasonStashID DuplicateReason = "STASH_ID"
+	// the perceptual hashes of the performer images are similar
+	DuplicateReasonImage DuplicateReason = "IMAGE"
+)
+
+var AllDuplicateReason = []DuplicateReason{
+	DuplicateReasonName,
+	DuplicateReasonAlias,
+	DuplicateReasonStashID,
+	DuplicateReasonImage,
+}
+
+func (e DuplicateReason) IsValid() bool {
+	switch e {
+	case DuplicateReasonName, DuplicateReasonAlias, DuplicateReasonStashID, DuplicateReasonImage:
+		return true
+	}
+	return false
+}
+
+func (e DuplicateReason) String() string {
+	return string(e)
+}
+
+func (e *DuplicateReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DuplicateReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerDuplicateReason", str)
+	}
+	return nil
+}
+
+func (e DuplicateReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// DuplicateGroup is a group of performers that are likely to be duplicates.
+type DuplicateGroup struct {
+	Performers []*models.Performer `json:"performers"`
+	// Reasons are the reasons that performers in the group were matched, in
+	// the order of AllDuplicateReason.
+	Reasons []DuplicateReason `json:"reasons"`
+}
+
+type DuplicateOptions struct {
+	// NameSimilarity is the minimum similarity of names, between 0 and 1.
+	// Only equal names match if zero.
+	NameSimilarity float64
+	// PhashDistance is the maximum distance between the perceptual hashes of
+	// performer images. Images are not compared if negative.
+	PhashDistance int
+}
+
+type DuplicateFinder interface {
+	All(ctx context.Context) ([]*models.Performer, error)
+	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	models.AliasLoader
+	models.StashIDLoader
+}
+
+// FindDuplicates returns groups of performers that are likely to be
+// duplicates. Performers are grouped if their names are similar, if a name or
+// alias of one is an alias of another, if they share a stash ID, or if their
+// images are similar. Performers with different disambiguations are not
+// matched by name or alias.
+func FindDuplicates(ctx context.Context, r DuplicateFinder, options DuplicateOptions) ([]*DuplicateGroup, error) {
+	performers, err := r.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]duplicateCandidate, len(performers))
+	for i, p := range performers {
+		c := duplicateCandidate{
+			name:           normalizeName(p.Name),
+			disambiguation: strings.ToLower(strings.TrimSpace(p.Disambiguation)),
+		}
+
+		aliases, err := r.GetAliases(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range aliases {
+			c.aliases = append(c.aliases, normalizeName(a))
+		}
+
+		c.stashIDs, err = r.GetStashIDs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if options.PhashDistance >= 0 {
+			c.phash, err = performerImagePhash(ctx, r, p)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		candidates[i] = c
+	}
+
+	var ret []*DuplicateGroup
+	for _, cluster := range findDuplicates(candidates, options) {
+		g := &DuplicateGroup{
+			Reasons: cluster.reasons,
+		}
+		for _, i := range cluster.members {
+			g.Performers = append(g.Performers, performers[i])
+		}
+		ret = append(ret, g)
+	}
+
+	return ret, nil
+}
+
+func performerImagePhash(ctx context.Context, r DuplicateFinder, p *models.Performer) (*uint64, error) {
+	img, err := r.GetImage(ctx, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting image of performer %d: %w", p.ID, err)
+	}
+	if len(img) == 0 {
+		return nil, nil
+	}
+
+	ret, err := imagephash.Generate(bytes.NewReader(img))
+	if err != nil {
+		// not fatal - the performer is compared without the image
+		logger.Warnf("error generating phash of image of performer %q: %v", p.Name, err)
+		return nil, nil
+	}
+
+	return ret, nil
+}
+
+type duplicateCandidate struct {
+	// name, disambiguation and aliases are normalised
+	name           string
+	disambiguation string
+	aliases        []string
+	stashIDs       []models.StashID
+	phash          *uint64
+}
+
+// namesComparable returns false if the performers are disambiguated from
+// each other.
+func (c duplicateCandidate) namesComparable(o duplicateCandidate) bool {
+	return c.disambiguation == "" || o.disambiguation == "" || c.disambiguation == o.disambiguation
+}
+
+type duplicateCluster struct {
+	// indexes of the candidates, in ascending order
+	members []int
+	reasons []DuplicateReason
+}
+
+// findDuplicates clusters the candidates, returning clusters of more than
+// one candidate ordered by their first member.
+func findDuplicates(candidates []duplicateCandidate, options DuplicateOptions) []duplicateCluster {
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type edge struct {
+		a, b   int
+		reason DuplicateReason
+	}
+	var edges []edge
+	link := func(a, b int, reason DuplicateReason) {
+		if a == b {
+			return
+		}
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[max(ra, rb)] = min(ra, rb)
+		}
+		edges = append(edges, edge{a, b, reason})
+	}
+
+	// candidates sharing a key are linked to the first candidate with the key
+	linkByKey := func(keys map[string][]int, reason DuplicateReason) {
+		for _, ids := range keys {
+			for _, id := range ids[1:] {
+				link(ids[0], id, reason)
+			}
+		}
+	}
+
+	stashIDs := make(map[string][]int)
+	for i, c := range candidates {
+		for _, s := range c.stashIDs {
+			key := s.Endpoint + "\x00" + s.StashID
+			stashIDs[key] = append(stashIDs[key], i)
+		}
+	}
+	linkByKey(stashIDs, DuplicateReasonStashID)
+
+	// equal names are linked, as are names and aliases if either is an alias
+	names := make(map[string][]int)
+	for i, c := range candidates {
+		if c.name != "" {
+			names[c.name] = append(names[c.name], i)
+		}
+	}
+	for _, ids := range names {
+		for x, i := range ids {
+			for _, j := range ids[x+1:] {
+				if candidates[i].namesComparable(candidates[j]) {
+					link(i, j, DuplicateReasonName)
+				}
+			}
+		}
+	}
+	for i, c := range candidates {
+		for _, a := range c.aliases {
+			for _, j := range names[a] {
+				if c.namesComparable(candidates[j]) {
+					link(i, j, DuplicateReasonAlias)
+				}
+			}
+		}
+	}
+	aliases := make(map[string][]int)
+	for i, c := range candidates {
+		for _, a := range c.aliases {
+			aliases[a] = append(aliases[a], i)
+		}
+	}
+	for _, ids := range aliases {
+		for x, i := range ids {
+			for _, j := range ids[x+1:] {
+				if candidates[i].namesComparable(candidates[j]) {
+					link(i, j, DuplicateReasonAlias)
+				}
+			}
+		}
+	}
+
+	if options.NameSimilarity > 0 {
+		for _, pair := range similarNames(candidates, options.NameSimilarity) {
+			link(pair[0], pair[1], DuplicateReasonName)
+		}
+	}
+
+	if options.PhashDistance >= 0 {
+		for i, c := range candidates {
+			if c.phash == nil {
+				continue
+			}
+			for j := i + 1; j < len(candidates); j++ {
+				o := candidates[j]
+				if o.phash != nil && bits.OnesCount64(*c.phash^*o.phash) <= options.PhashDistance {
+					link(i, j, DuplicateReasonImage)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range candidates {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	reasons := make(map[int][]DuplicateReason)
+	for _, e := range edges {
+		root := find(e.a)
+		if !slices.Contains(reasons[root], e.reason) {
+			reasons[root] = append(reasons[root], e.reason)
+		}
+	}
+
+	var ret []duplicateCluster
+	for i := range candidates {
+		// roots are the lowest index of their cluster
+		if find(i) != i || len(members[i]) < 2 {
+			continue
+		}
+
+		var r []DuplicateReason
+		for _, reason := range AllDuplicateReason {
+			if slices.Contains(reasons[i], reason) {
+				r = append(r, reason)
+			}
+		}
+
+		ret = append(ret, duplicateCluster{
+			members: members[i],
+			reasons: r,
+		})
+	}
+
+	return ret
+}
+
+// similarNames returns the pairs of candidates with similar names. Only
+// candidates with a word starting with the same two letters are compared.
+func similarNames(candidates []duplicateCandidate, threshold float64) [][2]int {
+	blocks := make(map[string][]int)
+	for i, c := range candidates {
+		var prefixes []string
+		for _, w := range strings.Fields(c.name) {
+			prefix := w
+			if utf8.RuneCountInString(w) > 2 {
+				prefix = string([]rune(w)[:2])
+			}
+			if !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+				blocks[prefix] = append(blocks[prefix], i)
+			}
+		}
+	}
+
+	compared := make(map[[2]int]bool)
+	var ret [][2]int
+	for _, ids := range blocks {
+		for x, i := range ids {
+			for _, j := range ids[x+1:] {
+				pair := [2]int{i, j}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				a, b := candidates[i], candidates[j]
+				if a.namesComparable(b) && nameSimilarity(a.name, b.name) >= threshold {
+					ret = append(ret, pair)
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+// normalizeName lower-cases the name and replaces punctuation with spaces.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity returns the similarity of two normalised names between 0
+// and 1. Names are also compared with their words sorted, so that reordered
+// names are similar.
+func nameSimilarity(a, b string) float64 {
+	ret := stringSimilarity(a, b)
+
+	sortedA := strings.Fields(a)
+	sortedB := strings.Fields(b)
+	slices.Sort(sortedA)
+	slices.Sort(sortedB)
+
+	return max(ret, stringSimilarity(strings.Join(sortedA, " "), strings.Join(sortedB, " ")))
+}
+
+// stringSimilarity returns one minus the edit distance of the strings
+// relative to the length of the longer string.
+func stringSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+
+	return 1 - float64(utils.EditDistance(a, b))/float64(longest)
+}
@@ -0,0 +1,77 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"jane doe", "jane doe", 1},
+		{"jane doe", "doe jane", 1},
+		{"jane doe", "jane dow", 0.875},
+		{"jane", "john", 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.InDelta(t, tt.want, nameSimilarity(tt.a, tt.b), 0.001)
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "jane doe", normalizeName("  Jane-Doe. "))
+	assert.Equal(t, "zoë 2", normalizeName("Zoë (2)"))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	phash := func(v uint64) *uint64 { return &v }
+
+	candidates := []duplicateCandidate{
+		// 0, 1: similar names
+		{name: "jane doe"},
+		{name: "jane dow"},
+		// 2, 3: alias of one is the name of the other
+		{name: "alice", aliases: []string{"ally smith"}},
+		{name: "ally smith"},
+		// 4, 5: shared stash id
+		{name: "bob", stashIDs: []models.StashID{{Endpoint: "e", StashID: "1"}}},
+		{name: "robert", stashIDs: []models.StashID{{Endpoint: "e", StashID: "1"}}},
+		// 6, 7: similar images
+		{name: "carol", phash: phash(0xff)},
+		{name: "caroline", phash: phash(0xfe)},
+		// 8, 9: equal names, but disambiguated
+		{name: "dana", disambiguation: "a"},
+		{name: "dana", disambiguation: "b"},
+		// 10: no duplicates
+		{name: "eve", stashIDs: []models.StashID{{Endpoint: "e", StashID: "2"}}},
+	}
+
+	got := findDuplicates(candidates, DuplicateOptions{
+		NameSimilarity: DefaultNameSimilarity - 0.1,
+		PhashDistance:  1,
+	})
+
+	assert.Equal(t, []duplicateCluster{
+		{members: []int{0, 1}, reasons: []DuplicateReason{DuplicateReasonName}},
+		{members: []int{2, 3}, reasons: []DuplicateReason{DuplicateReasonAlias}},
+		{members: []int{4, 5}, reasons: []DuplicateReason{DuplicateReasonStashID}},
+		{members: []int{6, 7}, reasons: []DuplicateReason{DuplicateReasonImage}},
+	}, got)
+
+	// images are not compared with a negative distance and only equal names
+	// match with zero similarity
+	got = findDuplicates(candidates, DuplicateOptions{
+		PhashDistance: -1,
+	})
+
+	assert.Equal(t, []duplicateCluster{
+		{members: []int{2, 3}, reasons: []DuplicateReason{DuplicateReasonAlias}},
+		{members: []int{4, 5}, reasons: []DuplicateReason{DuplicateReasonStashID}},
+	}, got)
+}
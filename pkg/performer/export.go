@@ -41,6 +41,10 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 		UpdatedAt:      json.JSONTime{Time: performer.UpdatedAt},
 	}
 
+	if performer.FavoritedAt != nil {
+		newPerformerJSON.FavoritedAt = &json.JSONTime{Time: *performer.FavoritedAt}
+	}
+
 	if performer.Gender != nil {
 		newPerformerJSON.Gender = performer.Gender.String()
 	}
@@ -176,11 +176,13 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 	}
 
 	id := i.performer.ID
+	i.ID = id
 	return &id, nil
 }
 
 func (i *Importer) Update(ctx context.Context, id int) error {
 	i.performer.ID = id
+	i.ID = id
 	err := i.ReaderWriter.Update(ctx, &models.UpdatePerformerInput{
 		Performer: &i.performer,
 		CustomFields: models.CustomFieldsInput{
@@ -218,6 +220,11 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		StashIDs: models.NewRelatedStashIDs(performerJSON.StashIDs),
 	}
 
+	if performerJSON.FavoritedAt != nil {
+		favoritedAt := performerJSON.FavoritedAt.GetTime()
+		newPerformer.FavoritedAt = &favoritedAt
+	}
+
 	if len(performerJSON.URLs) > 0 {
 		newPerformer.URLs = models.NewRelatedStrings(performerJSON.URLs)
 	} else {
@@ -0,0 +1,80 @@
+package performer
+
+import (
+	"sort"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// AgeAt returns the age in whole years on date of a performer born on
+// birthdate.
+func AgeAt(birthdate models.Date, date models.Date) int {
+	age := date.Year() - birthdate.Year()
+
+	// not yet had their birthday in the year of date
+	if date.Month() < birthdate.Month() || (date.Month() == birthdate.Month() && date.Day() < birthdate.Day()) {
+		age--
+	}
+
+	return age
+}
+
+// SceneAge returns the age of the performer on the date of the scene.
+// Returns nil if the performer has no birthdate or the scene has no date.
+func SceneAge(p *models.Performer, s *models.Scene) *int {
+	if p.Birthdate == nil || s.Date == nil {
+		return nil
+	}
+
+	age := AgeAt(*p.Birthdate, *s.Date)
+	return &age
+}
+
+// TimelineYear is the scenes of a performer dated in a year.
+type TimelineYear struct {
+	Year int `json:"year"`
+	// MinAge and MaxAge are the ages of the performer on the dates of the
+	// earliest and latest scenes of the year. Nil if the performer has no
+	// birthdate.
+	MinAge *int `json:"min_age"`
+	MaxAge *int `json:"max_age"`
+	// Scenes are ordered by date.
+	Scenes []*models.Scene `json:"scenes"`
+}
+
+func (y TimelineYear) SceneCount() int {
+	return len(y.Scenes)
+}
+
+// Timeline groups the scenes of the performer by the year of the scene date,
+// in ascending order. Scenes without a date are excluded.
+func Timeline(p *models.Performer, scenes []*models.Scene) []*TimelineYear {
+	var dated []*models.Scene
+	for _, s := range scenes {
+		if s.Date != nil {
+			dated = append(dated, s)
+		}
+	}
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].Date.Before(dated[j].Date.Time)
+	})
+
+	var ret []*TimelineYear
+	for _, s := range dated {
+		year := s.Date.Year()
+
+		if len(ret) == 0 || ret[len(ret)-1].Year != year {
+			ret = append(ret, &TimelineYear{
+				Year:   year,
+				MinAge: SceneAge(p, s),
+			})
+		}
+
+		y := ret[len(ret)-1]
+		y.Scenes = append(y.Scenes, s)
+		y.MaxAge = SceneAge(p, s)
+	}
+
+	return ret
+}
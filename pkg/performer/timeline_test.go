@@ -0,0 +1,58 @@
+package performer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *models.Date {
+	return &models.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestAgeAt(t *testing.T) {
+	birthdate := *date(1990, time.June, 15)
+
+	tests := []struct {
+		name string
+		date *models.Date
+		want int
+	}{
+		{"before birthday", date(2010, time.June, 14), 19},
+		{"on birthday", date(2010, time.June, 15), 20},
+		{"after birthday", date(2010, time.December, 1), 20},
+		{"earlier month", date(2010, time.January, 30), 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AgeAt(birthdate, *tt.date))
+		})
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	p := &models.Performer{
+		Birthdate: date(1990, time.June, 15),
+	}
+
+	s1 := &models.Scene{ID: 1, Date: date(2011, time.July, 1)}
+	s2 := &models.Scene{ID: 2, Date: date(2010, time.March, 1)}
+	s3 := &models.Scene{ID: 3}
+	s4 := &models.Scene{ID: 4, Date: date(2010, time.August, 1)}
+
+	got := Timeline(p, []*models.Scene{s1, s2, s3, s4})
+
+	age19, age20, age21 := 19, 20, 21
+	assert.Equal(t, []*TimelineYear{
+		{Year: 2010, MinAge: &age19, MaxAge: &age20, Scenes: []*models.Scene{s2, s4}},
+		{Year: 2011, MinAge: &age21, MaxAge: &age21, Scenes: []*models.Scene{s1}},
+	}, got)
+
+	// no birthdate
+	got = Timeline(&models.Performer{}, []*models.Scene{s1})
+	assert.Equal(t, []*TimelineYear{
+		{Year: 2011, Scenes: []*models.Scene{s1}},
+	}, got)
+}
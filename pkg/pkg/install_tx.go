@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// installTx installs packages into a store. The files of replaced packages
+// are kept until commit, so that the store can be restored if any of the
+// installs fail.
+type installTx struct {
+	manager *Manager
+	store   *Store
+	changes []installChange
+}
+
+type installChange struct {
+	id string
+	// files written by the install
+	files []string
+	// dirExisted is true if the package directory existed before the install
+	dirExisted bool
+	// backupDir contains the files of the replaced package.
+	// Empty if the package was not installed.
+	backupDir string
+	// backedUp are the files moved to backupDir, relative to the package directory
+	backedUp []string
+}
+
+func (tx *installTx) install(ctx context.Context, pkg RemotePackage, zr *zip.Reader) error {
+	if err := validatePackageID(pkg.ID); err != nil {
+		return err
+	}
+
+	c := installChange{
+		id: pkg.ID,
+	}
+
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() {
+			c.files = append(c.files, filepath.Clean(f.Name))
+		}
+	}
+
+	if _, err := os.Stat(tx.store.packageDir(pkg.ID)); err == nil {
+		c.dirExisted = true
+	}
+
+	if existing, err := tx.store.getManifest(ctx, pkg.ID); err == nil {
+		if err := tx.backup(&c, *existing); err != nil {
+			return fmt.Errorf("backing up existing package: %w", err)
+		}
+	}
+
+	// record the change before installing, so that a partial install is rolled back
+	tx.changes = append(tx.changes, c)
+
+	return tx.manager.installPackage(pkg, tx.store, zr)
+}
+
+// backup moves the files of the existing package to a backup directory.
+func (tx *installTx) backup(c *installChange, existing Manifest) error {
+	backupDir, err := os.MkdirTemp(tx.store.BaseDir, "."+c.id+"-backup-")
+	if err != nil {
+		return err
+	}
+	c.backupDir = backupDir
+
+	pkgDir := tx.store.packageDir(c.id)
+	files := append([]string{tx.store.ManifestFile}, existing.Files...)
+	for _, f := range files {
+		if err := validatePackageFile(f); err != nil {
+			// not removed by uninstall either, so leave in place
+			continue
+		}
+
+		dest := filepath.Join(backupDir, f)
+		if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+			tx.restore(*c)
+			return err
+		}
+
+		if err := os.Rename(filepath.Join(pkgDir, f), dest); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			tx.restore(*c)
+			return err
+		}
+
+		c.backedUp = append(c.backedUp, f)
+	}
+
+	return nil
+}
+
+// restore removes the installed files of the change and moves back the
+// backed up files of the replaced package.
+func (tx *installTx) restore(c installChange) {
+	pkgDir := tx.store.packageDir(c.id)
+
+	for _, f := range append(c.files, tx.store.ManifestFile) {
+		if err := os.Remove(filepath.Join(pkgDir, f)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error removing %s from package %s: %v", f, c.id, err)
+		}
+	}
+
+	for _, f := range c.backedUp {
+		if err := os.Rename(filepath.Join(c.backupDir, f), filepath.Join(pkgDir, f)); err != nil {
+			logger.Errorf("error restoring %s to package %s: %v", f, c.id, err)
+		}
+	}
+
+	if c.backupDir != "" {
+		if err := os.RemoveAll(c.backupDir); err != nil {
+			logger.Warnf("error removing backup directory %s: %v", c.backupDir, err)
+		}
+	}
+
+	if !c.dirExisted {
+		// the directory was created by the install, so contains nothing else
+		if err := os.RemoveAll(pkgDir); err != nil {
+			logger.Warnf("error removing package directory %s: %v", pkgDir, err)
+		}
+	}
+}
+
+// rollback restores the store to its state before the transaction.
+func (tx *installTx) rollback() {
+	for i := len(tx.changes) - 1; i >= 0; i-- {
+		tx.restore(tx.changes[i])
+	}
+	tx.changes = nil
+}
+
+// commit removes the files of the replaced packages.
+func (tx *installTx) commit() {
+	for _, c := range tx.changes {
+		if c.backupDir == "" {
+			continue
+		}
+
+		if err := os.RemoveAll(c.backupDir); err != nil {
+			logger.Warnf("error removing backup directory %s: %v", c.backupDir, err)
+		}
+	}
+	tx.changes = nil
+}
@@ -127,20 +127,6 @@ func (m *Manager) InstalledStatus(ctx context.Context) (PackageStatusIndex, erro
 	return ret, nil
 }
 
-func (m *Manager) packageByID(ctx context.Context, spec models.PackageSpecInput) (*RemotePackage, error) {
-	l, err := m.ListRemote(ctx, spec.SourceURL)
-	if err != nil {
-		return nil, err
-	}
-
-	pkg, found := l[spec]
-	if !found {
-		return nil, nil
-	}
-
-	return &pkg, nil
-}
-
 func (m *Manager) getStore(remoteURL string) *Store {
 	srcPath := m.PackagePathGetter.GetSourcePath(remoteURL)
 	store := m.Local.sub(srcPath)
@@ -148,53 +134,80 @@ func (m *Manager) getStore(remoteURL string) *Store {
 	return store
 }
 
+// Install installs the package with the given spec, along with any required
+// packages that are not installed or do not satisfy the version requirements.
+// If any package fails to install, all changes are rolled back.
 func (m *Manager) Install(ctx context.Context, spec models.PackageSpecInput) error {
-	remote, err := m.remoteFromURL(spec.SourceURL)
+	remote, err := m.ListRemote(ctx, spec.SourceURL)
 	if err != nil {
-		return fmt.Errorf("creating remote repository: %w", err)
+		return fmt.Errorf("getting remote packages: %w", err)
 	}
 
-	pkg, err := m.packageByID(ctx, spec)
+	installed, err := m.ListInstalled(ctx)
 	if err != nil {
-		return fmt.Errorf("getting remote package: %w", err)
+		return fmt.Errorf("getting installed packages: %w", err)
+	}
+
+	toInstall, err := resolveInstall(spec, remote, installed)
+	if err != nil {
+		return fmt.Errorf("resolving dependencies: %w", err)
+	}
+
+	// download all packages before changing anything
+	zips := make([]*zip.Reader, len(toInstall))
+	for i, pkg := range toInstall {
+		zr, err := m.getPackageZip(ctx, pkg)
+		if err != nil {
+			return fmt.Errorf("getting package %s: %w", pkg.ID, err)
+		}
+		zips[i] = zr
+	}
+
+	tx := &installTx{
+		manager: m,
+		store:   m.getStore(spec.SourceURL),
+	}
+
+	for i, pkg := range toInstall {
+		if pkg.ID != spec.ID {
+			logger.Infof("Installing required package %s %s", pkg.ID, pkg.Version)
+		}
+
+		if err := tx.install(ctx, pkg, zips[i]); err != nil {
+			tx.rollback()
+			return fmt.Errorf("installing package %s: %w", pkg.ID, err)
+		}
 	}
 
-	fromRemote, err := remote.GetPackageZip(ctx, *pkg)
+	tx.commit()
+
+	return nil
+}
+
+func (m *Manager) getPackageZip(ctx context.Context, pkg RemotePackage) (*zip.Reader, error) {
+	fromRemote, err := pkg.Repository.GetPackageZip(ctx, pkg)
 	if err != nil {
-		return fmt.Errorf("getting remote package: %w", err)
+		return nil, err
 	}
 
 	defer fromRemote.Close()
 
 	d, err := io.ReadAll(fromRemote)
 	if err != nil {
-		return fmt.Errorf("reading package data: %w", err)
+		return nil, fmt.Errorf("reading package data: %w", err)
 	}
 
 	sha := fmt.Sprintf("%x", sha256.Sum256(d))
 	if sha != pkg.Sha256 {
-		return fmt.Errorf("package data (%s) does not match expected SHA256 (%s)", sha, pkg.Sha256)
+		return nil, fmt.Errorf("package data (%s) does not match expected SHA256 (%s)", sha, pkg.Sha256)
 	}
 
 	zr, err := zip.NewReader(bytes.NewReader(d), int64(len(d)))
 	if err != nil {
-		return fmt.Errorf("reading zip data: %w", err)
+		return nil, fmt.Errorf("reading zip data: %w", err)
 	}
 
-	store := m.getStore(spec.SourceURL)
-
-	// uninstall existing package if present
-	if _, err := store.getManifest(ctx, pkg.ID); err == nil {
-		if err := m.deletePackageFiles(ctx, store, pkg.ID); err != nil {
-			return fmt.Errorf("uninstalling existing package: %w", err)
-		}
-	}
-
-	if err := m.installPackage(*pkg, store, zr); err != nil {
-		return fmt.Errorf("installing package: %w", err)
-	}
-
-	return nil
+	return zr, nil
 }
 
 func (m *Manager) installPackage(pkg RemotePackage, store *Store, zr *zip.Reader) error {
@@ -203,9 +216,21 @@ func (m *Manager) installPackage(pkg RemotePackage, store *Store, zr *zip.Reader
 		Name:           pkg.Name,
 		Metadata:       pkg.Metadata,
 		PackageVersion: pkg.PackageVersion,
+		Requires:       pkg.Requires,
 		RepositoryURL:  pkg.Repository.Path(),
 	}
 
+	// check all paths before writing anything, so that a malicious package
+	// is not partially installed
+	if err := validatePackageID(pkg.ID); err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		if err := validatePackageFile(filepath.Clean(f.Name)); err != nil {
+			return err
+		}
+	}
+
 	for _, f := range zr.File {
 		if f.FileInfo().IsDir() {
 			continue
@@ -0,0 +1,243 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrMissingDependency     = errors.New("required package not found")
+	ErrUnsatisfiedDependency = errors.New("no version of required package satisfies requirement")
+	ErrDependencyCycle       = errors.New("package dependency cycle")
+)
+
+// requirement operators, longest first so that parsing matches greedily
+var requirementOperators = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// Requirement is a parsed entry of a package's requires list.
+// Entries are a package ID, optionally followed by a version constraint.
+// For example: "my_package" or "my_package >= 1.2".
+type Requirement struct {
+	ID string
+	// Operator is empty if any version of the package satisfies the requirement.
+	Operator string
+	Version  string
+}
+
+// ParseRequirement parses a requires entry.
+func ParseRequirement(s string) (Requirement, error) {
+	i := strings.IndexAny(s, "<>=!")
+	if i == -1 {
+		id := strings.TrimSpace(s)
+		if id == "" {
+			return Requirement{}, errors.New("empty requirement")
+		}
+		return Requirement{ID: id}, nil
+	}
+
+	ret := Requirement{
+		ID: strings.TrimSpace(s[:i]),
+	}
+
+	rest := s[i:]
+	for _, op := range requirementOperators {
+		if strings.HasPrefix(rest, op) {
+			ret.Operator = op
+			ret.Version = strings.TrimSpace(rest[len(op):])
+			break
+		}
+	}
+
+	if ret.ID == "" || ret.Operator == "" || ret.Version == "" {
+		return Requirement{}, fmt.Errorf("invalid requirement %q", s)
+	}
+
+	return ret, nil
+}
+
+func (r Requirement) String() string {
+	return r.ID + r.Operator + r.Version
+}
+
+// SatisfiedBy returns true if the package version satisfies the requirement.
+// A package without a version only satisfies requirements without a
+// version constraint.
+func (r Requirement) SatisfiedBy(version string) bool {
+	if r.Operator == "" {
+		return true
+	}
+
+	if version == "" {
+		return false
+	}
+
+	c := compareVersions(version, r.Version)
+	switch r.Operator {
+	case ">=":
+		return c >= 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	case "!=":
+		return c != 0
+	default:
+		return c == 0
+	}
+}
+
+// compareVersions compares dot-separated versions, with an optional leading
+// "v". Numeric parts are compared numerically, other parts are compared
+// lexically. Missing parts are treated as zero, so "1.2" equals "1.2.0".
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(strings.ToLower(a), "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(strings.ToLower(b), "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		ap := "0"
+		if i < len(aParts) {
+			ap = aParts[i]
+		}
+		bp := "0"
+		if i < len(bParts) {
+			bp = bParts[i]
+		}
+
+		an, aErr := strconv.Atoi(ap)
+		bn, bErr := strconv.Atoi(bp)
+
+		var c int
+		if aErr == nil && bErr == nil {
+			c = an - bn
+		} else {
+			c = strings.Compare(ap, bp)
+		}
+
+		if c < 0 {
+			return -1
+		}
+		if c > 0 {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// resolveInstall returns the packages that must be installed to install the
+// package with the given spec. Required packages are ordered before the
+// packages that require them. Required packages must come from the same
+// source, and are only included if they are not installed, or if the
+// installed version does not satisfy the requirement.
+func resolveInstall(spec models.PackageSpecInput, remote RemotePackageIndex, installed LocalPackageIndex) ([]RemotePackage, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	var ret []RemotePackage
+	state := make(map[string]int)
+
+	var visit func(id string, req *Requirement, path []string) error
+	visit = func(id string, req *Requirement, path []string) error {
+		s := models.PackageSpecInput{
+			ID:        id,
+			SourceURL: spec.SourceURL,
+		}
+
+		if req != nil {
+			if local, found := installed[s]; found && req.SatisfiedBy(local.Version) {
+				return nil
+			}
+		}
+
+		p, found := remote[s]
+		if !found {
+			if req == nil {
+				return fmt.Errorf("package %s not found in %s", id, spec.SourceURL)
+			}
+			return fmt.Errorf("%w: %s (required by %s)", ErrMissingDependency, req, path[len(path)-1])
+		}
+
+		if req != nil && !req.SatisfiedBy(p.Version) {
+			return fmt.Errorf("%w: %s (required by %s, available version %q)", ErrUnsatisfiedDependency, req, path[len(path)-1], p.Version)
+		}
+
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append(path, id), " -> "))
+		case visited:
+			return nil
+		}
+
+		state[id] = visiting
+		for _, r := range p.Requires {
+			parsed, err := ParseRequirement(r)
+			if err != nil {
+				return fmt.Errorf("package %s: %w", id, err)
+			}
+
+			if err := visit(parsed.ID, &parsed, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+
+		ret = append(ret, p)
+		return nil
+	}
+
+	if err := visit(spec.ID, nil, nil); err != nil {
+		return nil, err
+	}
+
+	if err := checkDependents(spec.SourceURL, ret, installed); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// checkDependents returns an error if installing the packages from the
+// source would break the requirements of installed packages.
+func checkDependents(sourceURL string, toInstall []RemotePackage, installed LocalPackageIndex) error {
+	versions := make(map[models.PackageSpecInput]string)
+	for _, p := range toInstall {
+		s := models.PackageSpecInput{
+			ID:        p.ID,
+			SourceURL: sourceURL,
+		}
+		versions[s] = p.Version
+	}
+
+	for _, m := range installed {
+		if _, replaced := versions[m.PackageSpecInput()]; replaced {
+			continue
+		}
+
+		for _, r := range m.Requires {
+			parsed, err := ParseRequirement(r)
+			if err != nil {
+				// ignore invalid requirements of installed packages
+				continue
+			}
+
+			s := models.PackageSpecInput{
+				ID:        parsed.ID,
+				SourceURL: m.RepositoryURL,
+			}
+			v, found := versions[s]
+			if found && !parsed.SatisfiedBy(v) {
+				return fmt.Errorf("%w: installing %s %q would break %s, which requires %s", ErrUnsatisfiedDependency, parsed.ID, v, m.ID, parsed)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Requirement
+		wantErr bool
+	}{
+		{"plugin", Requirement{ID: "plugin"}, false},
+		{" plugin ", Requirement{ID: "plugin"}, false},
+		{"plugin>=1.2", Requirement{ID: "plugin", Operator: ">=", Version: "1.2"}, false},
+		{"plugin >= 1.2", Requirement{ID: "plugin", Operator: ">=", Version: "1.2"}, false},
+		{"plugin<2", Requirement{ID: "plugin", Operator: "<", Version: "2"}, false},
+		{"plugin=1.0", Requirement{ID: "plugin", Operator: "=", Version: "1.0"}, false},
+		{"", Requirement{}, true},
+		{">=1.2", Requirement{}, true},
+		{"plugin>=", Requirement{}, true},
+		{"plugin!1.0", Requirement{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseRequirement(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRequirement(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseRequirement(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirement_SatisfiedBy(t *testing.T) {
+	tests := []struct {
+		requirement string
+		version     string
+		want        bool
+	}{
+		{"plugin", "", true},
+		{"plugin>=1.2", "", false},
+		{"plugin>=1.2", "1.2", true},
+		{"plugin>=1.2", "1.2.0", true},
+		{"plugin>=1.2", "v1.10", true},
+		{"plugin>=1.2", "1.1.9", false},
+		{"plugin>1.2", "1.2", false},
+		{"plugin<2", "1.99", true},
+		{"plugin<2", "2.0", false},
+		{"plugin<=2", "2.0", true},
+		{"plugin==1.0", "1", true},
+		{"plugin!=1.0", "1.0.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.requirement+" "+tt.version, func(t *testing.T) {
+			r, err := ParseRequirement(tt.requirement)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.SatisfiedBy(tt.version); got != tt.want {
+				t.Errorf("SatisfiedBy(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+const testSource = "https://example.com/index.yml"
+
+func makeRemoteIndex(packages ...RemotePackage) RemotePackageIndex {
+	ret := make(RemotePackageIndex)
+	for _, p := range packages {
+		ret[models.PackageSpecInput{ID: p.ID, SourceURL: testSource}] = p
+	}
+	return ret
+}
+
+func makeLocalIndex(packages ...Manifest) LocalPackageIndex {
+	ret := make(LocalPackageIndex)
+	for _, p := range packages {
+		p.RepositoryURL = testSource
+		ret[p.PackageSpecInput()] = p
+	}
+	return ret
+}
+
+func remotePackage(id string, version string, requires ...string) RemotePackage {
+	return RemotePackage{
+		ID:             id,
+		Requires:       requires,
+		PackageVersion: PackageVersion{Version: version},
+	}
+}
+
+func localPackage(id string, version string, requires ...string) Manifest {
+	return Manifest{
+		ID:             id,
+		Requires:       requires,
+		PackageVersion: PackageVersion{Version: version},
+	}
+}
+
+func TestResolveInstall(t *testing.T) {
+	remote := makeRemoteIndex(
+		remotePackage("app", "1.0", "lib>=1.1", "common"),
+		remotePackage("lib", "1.2", "common"),
+		remotePackage("common", "2.0"),
+		remotePackage("old", "1.0", "lib>=2"),
+		remotePackage("missing", "1.0", "nothere"),
+		remotePackage("cycle_a", "1.0", "cycle_b"),
+		remotePackage("cycle_b", "1.0", "cycle_a"),
+	)
+
+	tests := []struct {
+		name      string
+		id        string
+		installed LocalPackageIndex
+		want      []string
+		wantErr   error
+	}{
+		{
+			name: "dependencies first",
+			id:   "app",
+			want: []string{"common", "lib", "app"},
+		},
+		{
+			name:      "satisfied dependencies skipped",
+			id:        "app",
+			installed: makeLocalIndex(localPackage("lib", "1.1"), localPackage("common", "1.0")),
+			want:      []string{"app"},
+		},
+		{
+			name:      "unsatisfied dependency upgraded",
+			id:        "app",
+			installed: makeLocalIndex(localPackage("lib", "1.0"), localPackage("common", "1.0")),
+			want:      []string{"lib", "app"},
+		},
+		{
+			name:    "unsatisfiable",
+			id:      "old",
+			wantErr: ErrUnsatisfiedDependency,
+		},
+		{
+			name:    "missing",
+			id:      "missing",
+			wantErr: ErrMissingDependency,
+		},
+		{
+			name:    "cycle",
+			id:      "cycle_a",
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name:      "breaks installed dependent",
+			id:        "common",
+			installed: makeLocalIndex(localPackage("common", "1.0"), localPackage("other", "1.0", "common<2")),
+			wantErr:   ErrUnsatisfiedDependency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := models.PackageSpecInput{ID: tt.id, SourceURL: testSource}
+			got, err := resolveInstall(spec, remote, tt.installed)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("resolveInstall() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveInstall() error = %v", err)
+			}
+
+			var ids []string
+			for _, p := range got {
+				ids = append(ids, p.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("resolveInstall() = %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("resolveInstall() = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
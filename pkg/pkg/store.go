@@ -15,6 +15,31 @@ import (
 // ManifestFile is the default filename for the package manifest.
 const ManifestFile = "manifest"
 
+// ErrInvalidPath is returned when a package ID or package file name would
+// resolve to a path outside of the package directory.
+var ErrInvalidPath = errors.New("invalid package path")
+
+// validatePackageID returns an error if the package ID is not a single path
+// element. Package IDs are provided by remote repositories, so must not be
+// trusted to stay within the store.
+func validatePackageID(id string) error {
+	if id == "." || !filepath.IsLocal(id) || filepath.Base(id) != id {
+		return fmt.Errorf("%w: package id %q", ErrInvalidPath, id)
+	}
+
+	return nil
+}
+
+// validatePackageFile returns an error if the file name would resolve to a
+// path outside of the package directory.
+func validatePackageFile(name string) error {
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("%w: file %q", ErrInvalidPath, name)
+	}
+
+	return nil
+}
+
 // Store is a folder-based local repository.
 // Packages are installed in their own directory under BaseDir.
 // The package details are stored in a file named based on PackageFile.
@@ -76,6 +101,10 @@ func (r *Store) manifestPath(id string) string {
 }
 
 func (r *Store) getManifest(ctx context.Context, packageID string) (*Manifest, error) {
+	if err := validatePackageID(packageID); err != nil {
+		return nil, err
+	}
+
 	pfp := r.manifestPath(packageID)
 
 	data, err := os.ReadFile(pfp)
@@ -103,6 +132,13 @@ func (r *Store) ensurePackageExists(packageID string) error {
 }
 
 func (r *Store) writeFile(packageID string, name string, mode fs.FileMode, i io.Reader) error {
+	if err := validatePackageID(packageID); err != nil {
+		return err
+	}
+	if err := validatePackageFile(name); err != nil {
+		return err
+	}
+
 	fn := filepath.Join(r.packageDir(packageID), name)
 
 	if err := os.MkdirAll(filepath.Dir(fn), os.ModePerm); err != nil {
@@ -124,6 +160,10 @@ func (r *Store) writeFile(packageID string, name string, mode fs.FileMode, i io.
 }
 
 func (r *Store) writeManifest(packageID string, m Manifest) error {
+	if err := validatePackageID(packageID); err != nil {
+		return err
+	}
+
 	pfp := r.manifestPath(packageID)
 	data, err := yaml.Marshal(m)
 	if err != nil {
@@ -138,6 +178,13 @@ func (r *Store) writeManifest(packageID string, m Manifest) error {
 }
 
 func (r *Store) deleteFile(packageID string, name string) error {
+	if err := validatePackageID(packageID); err != nil {
+		return err
+	}
+	if err := validatePackageFile(name); err != nil {
+		return err
+	}
+
 	// ensure the package exists
 	if err := r.ensurePackageExists(packageID); err != nil {
 		return err
@@ -154,5 +201,9 @@ func (r *Store) deleteManifest(packageID string) error {
 }
 
 func (r *Store) deletePackageDir(packageID string) error {
+	if err := validatePackageID(packageID); err != nil {
+		return err
+	}
+
 	return os.Remove(r.packageDir(packageID))
 }
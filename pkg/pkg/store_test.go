@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidatePackageID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr bool
+	}{
+		{"scraper", false},
+		{"scraper.v2", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../scraper", true},
+		{"sub/scraper", true},
+		{"/scraper", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			err := validatePackageID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePackageID(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePackageFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"scraper.yml", false},
+		{"sub/scraper.py", false},
+		{"sub/../scraper.py", false},
+		{"", true},
+		{"../scraper.yml", true},
+		{"sub/../../scraper.yml", true},
+		{"/etc/passwd", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePackageFile(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePackageFile(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func makeZip(t *testing.T, files ...string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte("data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+func TestManager_installPackage_pathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{
+		BaseDir:      filepath.Join(dir, "scrapers"),
+		ManifestFile: ManifestFile,
+	}
+
+	m := &Manager{}
+	pkg := RemotePackage{
+		ID:         "scraper",
+		Repository: newHttpRepository(url.URL{Scheme: "https", Host: "example.com", Path: "/index.yml"}, nil, &repositoryCache{}),
+	}
+
+	zr := makeZip(t, "scraper.yml", "../escaped.yml")
+	err := m.installPackage(pkg, store, zr)
+	if !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("installPackage() error = %v, want %v", err, ErrInvalidPath)
+	}
+
+	// nothing should be written
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "scraper", "scraper.yml")); !os.IsNotExist(err) {
+		t.Errorf("package file was written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "escaped.yml")); !os.IsNotExist(err) {
+		t.Errorf("escaped file was written: %v", err)
+	}
+
+	zr = makeZip(t, "scraper.yml", "sub/scraper.py")
+	if err := m.installPackage(pkg, store, zr); err != nil {
+		t.Errorf("installPackage() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store.BaseDir, "scraper", "sub", "scraper.py")); err != nil {
+		t.Errorf("package file was not written: %v", err)
+	}
+}
+
+func TestInstallTx_rollback(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := &Store{
+		BaseDir:      dir,
+		ManifestFile: ManifestFile,
+	}
+
+	repo := newHttpRepository(url.URL{Scheme: "https", Host: "example.com", Path: "/index.yml"}, nil, &repositoryCache{})
+	m := &Manager{}
+
+	// install the original version
+	tx := &installTx{manager: m, store: store}
+	if err := tx.install(ctx, RemotePackage{ID: "plugin", Repository: repo}, makeZip(t, "old.yml")); err != nil {
+		t.Fatalf("install() error = %v", err)
+	}
+	tx.commit()
+
+	// update the package and install a new package, failing on the second
+	tx = &installTx{manager: m, store: store}
+	if err := tx.install(ctx, RemotePackage{ID: "plugin", Repository: repo}, makeZip(t, "new.yml")); err != nil {
+		t.Fatalf("install() error = %v", err)
+	}
+	if err := tx.install(ctx, RemotePackage{ID: "other", Repository: repo}, makeZip(t, "other.yml", "../escaped.yml")); err == nil {
+		t.Fatal("install() expected error")
+	}
+	tx.rollback()
+
+	if _, err := os.Stat(filepath.Join(dir, "plugin", "old.yml")); err != nil {
+		t.Errorf("original file was not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "plugin", "new.yml")); !os.IsNotExist(err) {
+		t.Errorf("updated file was not removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "other")); !os.IsNotExist(err) {
+		t.Errorf("new package was not removed: %v", err)
+	}
+
+	list, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || len(list[0].Files) != 1 || list[0].Files[0] != "old.yml" {
+		t.Errorf("List() = %v, want original package", list)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("unexpected entries after rollback: %v", entries)
+	}
+}
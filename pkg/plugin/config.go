@@ -11,6 +11,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/plugin/hook"
 	"github.com/stashapp/stash/pkg/python"
+	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/yaml.v2"
 )
@@ -66,6 +67,11 @@ type Config struct {
 
 	// Settings that will be used to configure the plugin.
 	Settings map[string]SettingConfig `yaml:"settings"`
+
+	// The GraphQL permissions granted to the plugin's tasks and hooks, in the
+	// form <resource>:<read|write>. If not set, the plugin has the same access
+	// as the user that triggered it.
+	Permissions Permissions `yaml:"permissions"`
 }
 
 type PluginCSP struct {
@@ -262,8 +268,22 @@ func (c Config) toPlugin() *Plugin {
 			CSP:            c.UI.CSP,
 			Assets:         c.UI.Assets,
 		},
-		Settings:   c.getPluginSettings(),
-		ConfigPath: c.path,
+		Settings:    c.getPluginSettings(),
+		Permissions: c.Permissions,
+		ConfigPath:  c.path,
+	}
+}
+
+// serviceAccount returns the service account used by the plugin to access
+// the GraphQL API. Returns nil if the plugin does not declare permissions.
+func (c Config) serviceAccount() *session.PluginServiceAccount {
+	if c.Permissions == nil {
+		return nil
+	}
+
+	return &session.PluginServiceAccount{
+		PluginID:    c.id,
+		Permissions: c.Permissions,
 	}
 }
 
@@ -336,6 +356,10 @@ func (c Config) valid() error {
 		}
 	}
 
+	if err := c.Permissions.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
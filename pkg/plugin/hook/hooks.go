@@ -38,6 +38,7 @@ const (
 
 	PerformerCreatePost  TriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  TriggerEnum = "Performer.Update.Post"
+	PerformerMergePost   TriggerEnum = "Performer.Merge.Post"
 	PerformerDestroyPost TriggerEnum = "Performer.Destroy.Post"
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
@@ -77,6 +78,7 @@ var AllHookTriggerEnum = []TriggerEnum{
 
 	PerformerCreatePost,
 	PerformerUpdatePost,
+	PerformerMergePost,
 	PerformerDestroyPost,
 
 	StudioCreatePost,
@@ -118,6 +120,7 @@ func (e TriggerEnum) IsValid() bool {
 
 		PerformerCreatePost,
 		PerformerUpdatePost,
+		PerformerMergePost,
 		PerformerDestroyPost,
 
 		StudioCreatePost,
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Resources that plugin permissions may be granted for.
+const (
+	PermissionResourceAll        = "*"
+	PermissionResourceScenes     = "scenes"
+	PermissionResourceMarkers    = "markers"
+	PermissionResourceImages     = "images"
+	PermissionResourceGalleries  = "galleries"
+	PermissionResourcePerformers = "performers"
+	PermissionResourceStudios    = "studios"
+	PermissionResourceGroups     = "groups"
+	PermissionResourceTags       = "tags"
+	PermissionResourceFiles      = "files"
+	PermissionResourceFilters    = "filters"
+	PermissionResourceScrapers   = "scrapers"
+	PermissionResourcePlugins    = "plugins"
+	PermissionResourceConfig     = "config"
+	PermissionResourceJobs       = "jobs"
+	PermissionResourceSystem     = "system"
+)
+
+var permissionResources = []string{
+	PermissionResourceAll,
+	PermissionResourceScenes,
+	PermissionResourceMarkers,
+	PermissionResourceImages,
+	PermissionResourceGalleries,
+	PermissionResourcePerformers,
+	PermissionResourceStudios,
+	PermissionResourceGroups,
+	PermissionResourceTags,
+	PermissionResourceFiles,
+	PermissionResourceFilters,
+	PermissionResourceScrapers,
+	PermissionResourcePlugins,
+	PermissionResourceConfig,
+	PermissionResourceJobs,
+	PermissionResourceSystem,
+}
+
+const (
+	permissionRead  = "read"
+	permissionWrite = "write"
+)
+
+// Permissions is a list of GraphQL permissions granted to a plugin, in the
+// form <resource>:<read|write>. Write permission implies read permission.
+type Permissions []string
+
+func (p Permissions) validate() error {
+	for _, v := range p {
+		resource, access, found := strings.Cut(v, ":")
+		if !found || (access != permissionRead && access != permissionWrite) {
+			return fmt.Errorf("invalid permission %q: must be <resource>:read or <resource>:write", v)
+		}
+
+		if !slices.Contains(permissionResources, resource) {
+			return fmt.Errorf("invalid permission %q: unknown resource %q", v, resource)
+		}
+	}
+
+	return nil
+}
+
+// Allows returns true if the permissions grant access to resource.
+// If write is true, then write access is required.
+func (p Permissions) Allows(resource string, write bool) bool {
+	for _, v := range p {
+		r, access, _ := strings.Cut(v, ":")
+		if r != resource && r != PermissionResourceAll {
+			continue
+		}
+
+		if !write || access == permissionWrite {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Permission returns the permission string for resource.
+func Permission(resource string, write bool) string {
+	if write {
+		return resource + ":" + permissionWrite
+	}
+
+	return resource + ":" + permissionRead
+}
@@ -39,6 +39,9 @@ type Plugin struct {
 	UI          PluginUI        `json:"ui"`
 	Settings    []PluginSetting `json:"settings"`
 
+	// Permissions is nil if the plugin has unrestricted access.
+	Permissions []string `json:"permissions"`
+
 	Enabled bool `json:"enabled"`
 
 	// ConfigPath is the path to the plugin's configuration file.
@@ -241,8 +244,8 @@ func buildPluginInput(plugin *Config, operation *OperationConfig, serverConnecti
 	}
 }
 
-func (c Cache) makeServerConnection(ctx context.Context) common.StashServerConnection {
-	cookie := c.sessionStore.MakePluginCookie(ctx)
+func (c Cache) makeServerConnection(ctx context.Context, plugin *Config) common.StashServerConnection {
+	cookie := c.sessionStore.MakePluginCookie(ctx, plugin.serviceAccount())
 
 	serverConnection := common.StashServerConnection{
 		Scheme:        "http",
@@ -263,8 +266,6 @@ func (c Cache) makeServerConnection(ctx context.Context) common.StashServerConne
 // name provided. Returns an error if the plugin or the operation could not be
 // resolved.
 func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName *string, args OperationInput, progress chan float64) (Task, error) {
-	serverConnection := c.makeServerConnection(ctx)
-
 	if c.pluginDisabled(pluginID) {
 		return nil, fmt.Errorf("plugin %s is disabled", pluginID)
 	}
@@ -276,6 +277,8 @@ func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName *s
 		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
 	}
 
+	serverConnection := c.makeServerConnection(ctx, plugin)
+
 	var operation *OperationConfig
 	if operationName != nil {
 		operation = plugin.getTask(*operationName)
@@ -296,8 +299,6 @@ func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName *s
 }
 
 func (c Cache) RunPlugin(ctx context.Context, pluginID string, args OperationInput) (interface{}, error) {
-	serverConnection := c.makeServerConnection(ctx)
-
 	if c.pluginDisabled(pluginID) {
 		return nil, fmt.Errorf("plugin %s is disabled", pluginID)
 	}
@@ -305,6 +306,12 @@ func (c Cache) RunPlugin(ctx context.Context, pluginID string, args OperationInp
 	// find the plugin
 	plugin := c.getPlugin(pluginID)
 
+	if plugin == nil {
+		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
+	}
+
+	serverConnection := c.makeServerConnection(ctx, plugin)
+
 	pluginInput := buildPluginInput(plugin, nil, serverConnection, args)
 
 	pt := pluginTask{
@@ -402,7 +409,7 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 
 		for _, h := range hooks {
 			newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
-			serverConnection := c.makeServerConnection(newCtx)
+			serverConnection := c.makeServerConnection(newCtx, &p)
 
 			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
 			addHookContext(pluginInput.Args, hookContext)
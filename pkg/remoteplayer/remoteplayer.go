@@ -0,0 +1,258 @@
+// Package remoteplayer provides a registry of connected players that can be
+// controlled remotely, such as a web player controlled from a phone.
+package remoteplayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandBufferSize is the number of commands that may be queued for a player
+// before further commands are rejected.
+const commandBufferSize = 10
+
+var (
+	ErrNotFound = errors.New("player not found")
+	ErrBusy     = errors.New("player is not accepting commands")
+)
+
+type CommandType string
+
+const (
+	// Play a scene from a time
+	CommandTypePlay CommandType = "PLAY"
+	// Pause the current scene
+	CommandTypePause CommandType = "PAUSE"
+	// Resume the current scene
+	CommandTypeResume CommandType = "RESUME"
+	// Seek to a time in the current scene
+	CommandTypeSeek CommandType = "SEEK"
+	// Stop playback
+	CommandTypeStop CommandType = "STOP"
+)
+
+var AllCommandType = []CommandType{
+	CommandTypePlay,
+	CommandTypePause,
+	CommandTypeResume,
+	CommandTypeSeek,
+	CommandTypeStop,
+}
+
+func (e CommandType) IsValid() bool {
+	return slices.Contains(AllCommandType, e)
+}
+
+func (e CommandType) String() string {
+	return string(e)
+}
+
+func (e *CommandType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommandType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RemotePlayerCommandType", str)
+	}
+	return nil
+}
+
+func (e CommandType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Command is an instruction sent to a player.
+type Command struct {
+	Type CommandType `json:"type"`
+	// SceneID is required for PLAY
+	SceneID *int `json:"scene_id"`
+	// Time in seconds. Required for SEEK, optional for PLAY.
+	Time *float64 `json:"time"`
+}
+
+// Validate returns an error if the command is missing required values.
+func (c Command) Validate() error {
+	if !c.Type.IsValid() {
+		return fmt.Errorf("invalid command type %q", c.Type)
+	}
+
+	if c.Type == CommandTypePlay && c.SceneID == nil {
+		return errors.New("scene id is required to play a scene")
+	}
+
+	if c.Type == CommandTypeSeek && c.Time == nil {
+		return errors.New("time is required to seek")
+	}
+
+	if c.Time != nil && *c.Time < 0 {
+		return errors.New("time must not be negative")
+	}
+
+	return nil
+}
+
+// State is the playback state reported by a player.
+type State struct {
+	// SceneID is nil if nothing is playing
+	SceneID *int    `json:"scene_id"`
+	Time    float64 `json:"time"`
+	Paused  bool    `json:"paused"`
+}
+
+// Player is a registered player session.
+type Player struct {
+	// ID is chosen by the player, so that it is kept when reconnecting.
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Username of the user that registered the player. Empty if
+	// authentication is not enabled.
+	Username     string    `json:"username"`
+	State        State     `json:"state"`
+	RegisteredAt time.Time `json:"registered_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type session struct {
+	player   Player
+	commands chan Command
+}
+
+// Registry holds the currently connected players.
+type Registry struct {
+	mutex    sync.Mutex
+	sessions map[string]*session
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]*session),
+	}
+}
+
+// Register adds a player to the registry and returns the channel that its
+// commands are sent on. The player is removed and the channel is closed when
+// ctx is done. Registering an existing player ID replaces the existing
+// session, closing its channel.
+func (r *Registry) Register(ctx context.Context, id string, name string, username string) (<-chan Command, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errors.New("player id must be set")
+	}
+
+	now := time.Now()
+	s := &session{
+		player: Player{
+			ID:           id,
+			Name:         name,
+			Username:     username,
+			RegisteredAt: now,
+			UpdatedAt:    now,
+		},
+		commands: make(chan Command, commandBufferSize),
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing := r.sessions[id]; existing != nil {
+		if existing.player.Username != username {
+			return nil, fmt.Errorf("player id %q is in use", id)
+		}
+		close(existing.commands)
+	}
+	r.sessions[id] = s
+
+	go func() {
+		<-ctx.Done()
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		// only remove the session if it has not been replaced
+		if r.sessions[id] == s {
+			delete(r.sessions, id)
+			close(s.commands)
+		}
+	}()
+
+	return s.commands, nil
+}
+
+// Players returns the players registered by the user, ordered by name.
+func (r *Registry) Players(username string) []Player {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var ret []Player
+	for _, s := range r.sessions {
+		if s.player.Username == username {
+			ret = append(ret, s.player)
+		}
+	}
+
+	slices.SortFunc(ret, func(a, b Player) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return ret
+}
+
+// find returns the session of the player registered by the user. Must be
+// called with the mutex held.
+func (r *Registry) find(id string, username string) (*session, error) {
+	s := r.sessions[id]
+	if s == nil || s.player.Username != username {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	return s, nil
+}
+
+// Send sends a command to a player registered by the user.
+func (r *Registry) Send(id string, username string, cmd Command) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	s, err := r.find(id, username)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.commands <- cmd:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrBusy, id)
+	}
+}
+
+// ReportState sets the playback state of a player registered by the user.
+func (r *Registry) ReportState(id string, username string, state State) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	s, err := r.find(id, username)
+	if err != nil {
+		return err
+	}
+
+	s.player.State = state
+	s.player.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,136 @@
+package remoteplayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestCommand_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     Command
+		wantErr bool
+	}{
+		{"play", Command{Type: CommandTypePlay, SceneID: intPtr(1), Time: floatPtr(10)}, false},
+		{"play without scene", Command{Type: CommandTypePlay}, true},
+		{"seek", Command{Type: CommandTypeSeek, Time: floatPtr(10)}, false},
+		{"seek without time", Command{Type: CommandTypeSeek}, true},
+		{"negative time", Command{Type: CommandTypeSeek, Time: floatPtr(-1)}, true},
+		{"pause", Command{Type: CommandTypePause}, false},
+		{"invalid type", Command{Type: "invalid"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func receive(t *testing.T, c <-chan Command) (Command, bool) {
+	t.Helper()
+
+	select {
+	case cmd, ok := <-c:
+		return cmd, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for command")
+		return Command{}, false
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	commands, err := r.Register(ctx, "tv", "Living room", "alice")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	_, err = r.Register(context.Background(), "tv", "Other", "bob")
+	assert.Error(t, err, "registering another user's player id")
+
+	assert.Len(t, r.Players("alice"), 1)
+	assert.Empty(t, r.Players("bob"))
+
+	play := Command{Type: CommandTypePlay, SceneID: intPtr(1)}
+	assert.NoError(t, r.Send("tv", "alice", play))
+
+	cmd, ok := receive(t, commands)
+	assert.True(t, ok)
+	assert.Equal(t, play, cmd)
+
+	err = r.Send("tv", "bob", play)
+	assert.True(t, errors.Is(err, ErrNotFound), "sending to another user's player")
+
+	state := State{SceneID: intPtr(1), Time: 5}
+	assert.NoError(t, r.ReportState("tv", "alice", state))
+	assert.Equal(t, state, r.Players("alice")[0].State)
+
+	cancel()
+
+	_, ok = receive(t, commands)
+	assert.False(t, ok, "channel closed when context is done")
+	assert.Empty(t, r.Players("alice"))
+}
+
+func TestRegistry_Reregister(t *testing.T) {
+	r := NewRegistry()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first, err := r.Register(ctx, "tv", "Living room", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	second, err := r.Register(context.Background(), "tv", "Living room", "")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	_, ok := receive(t, first)
+	assert.False(t, ok, "replaced session closed")
+
+	// cancelling the replaced session must not remove the new session
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, r.Players(""), 1)
+
+	assert.NoError(t, r.Send("tv", "", Command{Type: CommandTypeStop}))
+	cmd, ok := receive(t, second)
+	assert.True(t, ok)
+	assert.Equal(t, CommandTypeStop, cmd.Type)
+}
+
+func TestRegistry_Busy(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.Register(context.Background(), "tv", "Living room", ""); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	for i := 0; i < commandBufferSize; i++ {
+		assert.NoError(t, r.Send("tv", "", Command{Type: CommandTypePause}))
+	}
+
+	err := r.Send("tv", "", Command{Type: CommandTypePause})
+	assert.True(t, errors.Is(err, ErrBusy))
+}
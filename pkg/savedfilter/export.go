@@ -2,18 +2,31 @@ package savedfilter
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 )
 
 // ToJSON converts a SavedFilter object into its JSON equivalent.
-func ToJSON(ctx context.Context, filter *models.SavedFilter) (*jsonschema.SavedFilter, error) {
-	return &jsonschema.SavedFilter{
+func ToJSON(ctx context.Context, folderReader models.SavedFilterFolderGetter, filter *models.SavedFilter) (*jsonschema.SavedFilter, error) {
+	ret := &jsonschema.SavedFilter{
 		Name:         filter.Name,
 		Mode:         filter.Mode,
 		FindFilter:   filter.FindFilter,
 		ObjectFilter: filter.ObjectFilter,
 		UIOptions:    filter.UIOptions,
-	}, nil
+		SortOrder:    filter.SortOrder,
+		IsDefault:    filter.IsDefault,
+	}
+
+	if filter.FolderID != nil {
+		folder, err := FolderPath(ctx, folderReader, *filter.FolderID)
+		if err != nil {
+			return nil, fmt.Errorf("getting saved filter folder: %w", err)
+		}
+		ret.Folder = folder
+	}
+
+	return ret, nil
 }
@@ -16,6 +16,17 @@ const (
 	errAliasID    = 4
 	withParentsID = 5
 	errParentsID  = 6
+	withFolderID  = 7
+	errFolderID   = 8
+)
+
+const (
+	folderID       = 10
+	parentFolderID = 11
+	missingFolder  = 12
+
+	folderName       = "folder"
+	parentFolderName = "parent"
 )
 
 const (
@@ -40,6 +51,14 @@ func createSavedFilter(id int) models.SavedFilter {
 	}
 }
 
+func createFolderSavedFilter(id int, folderID int) models.SavedFilter {
+	ret := createSavedFilter(id)
+	ret.FolderID = &folderID
+	ret.SortOrder = 2
+	ret.IsDefault = true
+	return ret
+}
+
 func createJSONSavedFilter() *jsonschema.SavedFilter {
 	return &jsonschema.SavedFilter{
 		Name:         filterName,
@@ -65,6 +84,22 @@ func initTestTable() {
 			createJSONSavedFilter(),
 			false,
 		},
+		{
+			createFolderSavedFilter(withFolderID, folderID),
+			func() *jsonschema.SavedFilter {
+				ret := createJSONSavedFilter()
+				ret.Folder = []string{parentFolderName, folderName}
+				ret.SortOrder = 2
+				ret.IsDefault = true
+				return ret
+			}(),
+			false,
+		},
+		{
+			createFolderSavedFilter(errFolderID, missingFolder),
+			nil,
+			true,
+		},
 	}
 }
 
@@ -73,9 +108,14 @@ func TestToJSON(t *testing.T) {
 
 	db := mocks.NewDatabase()
 
+	parentID := parentFolderID
+	db.SavedFilterFolder.On("Find", testCtx, folderID).Return(&models.SavedFilterFolder{ID: folderID, Name: folderName, ParentID: &parentID}, nil)
+	db.SavedFilterFolder.On("Find", testCtx, parentFolderID).Return(&models.SavedFilterFolder{ID: parentFolderID, Name: parentFolderName}, nil)
+	db.SavedFilterFolder.On("Find", testCtx, missingFolder).Return(nil, nil)
+
 	for i, s := range scenarios {
 		savedFilter := s.savedFilter
-		json, err := ToJSON(testCtx, &savedFilter)
+		json, err := ToJSON(testCtx, db.SavedFilterFolder, &savedFilter)
 
 		switch {
 		case !s.err && err != nil:
@@ -0,0 +1,121 @@
+package savedfilter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// sameMode returns true if filters of mode a may be in folders of mode b.
+// Groups and movies share their filters.
+func sameMode(a, b models.FilterMode) bool {
+	isGroups := func(m models.FilterMode) bool {
+		return m == models.FilterModeGroups || m == models.FilterModeMovies
+	}
+
+	return a == b || (isGroups(a) && isGroups(b))
+}
+
+func findFolder(ctx context.Context, r models.SavedFilterFolderGetter, id int) (*models.SavedFilterFolder, error) {
+	ret, err := r.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		return nil, fmt.Errorf("saved filter folder with id %d not found", id)
+	}
+
+	return ret, nil
+}
+
+// ValidateFolder returns an error if the folder does not exist, or is not a
+// folder of the mode.
+func ValidateFolder(ctx context.Context, r models.SavedFilterFolderGetter, mode models.FilterMode, folderID int) error {
+	folder, err := findFolder(ctx, r, folderID)
+	if err != nil {
+		return err
+	}
+
+	if !sameMode(mode, folder.Mode) {
+		return fmt.Errorf("saved filter folder %q is not a %s folder", folder.Name, mode)
+	}
+
+	return nil
+}
+
+// ValidateFolderParent returns an error if parentID is not a folder of the
+// mode, or if it is the folder with the given id or one of its subfolders.
+// id is 0 for new folders.
+func ValidateFolderParent(ctx context.Context, r models.SavedFilterFolderGetter, id int, mode models.FilterMode, parentID int) error {
+	if err := ValidateFolder(ctx, r, mode, parentID); err != nil {
+		return err
+	}
+
+	visited := make(map[int]bool)
+	for current := &parentID; current != nil; {
+		if *current == id || visited[*current] {
+			return fmt.Errorf("saved filter folder cannot be moved into itself or one of its subfolders")
+		}
+		visited[*current] = true
+
+		folder, err := findFolder(ctx, r, *current)
+		if err != nil {
+			return err
+		}
+		current = folder.ParentID
+	}
+
+	return nil
+}
+
+// FolderPath returns the names of the folder and its ancestors, starting with
+// the top-level folder.
+func FolderPath(ctx context.Context, r models.SavedFilterFolderGetter, folderID int) ([]string, error) {
+	var ret []string
+	visited := make(map[int]bool)
+
+	for current := &folderID; current != nil && !visited[*current]; {
+		visited[*current] = true
+
+		folder, err := findFolder(ctx, r, *current)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append([]string{folder.Name}, ret...)
+		current = folder.ParentID
+	}
+
+	return ret, nil
+}
+
+// EnsureFolderPath returns the ID of the folder of the mode with the given
+// path, as returned by FolderPath, creating any folders that do not exist.
+// Returns nil if path is empty.
+func EnsureFolderPath(ctx context.Context, r models.SavedFilterFolderFinderCreator, mode models.FilterMode, path []string) (*int, error) {
+	var parentID *int
+
+	for _, name := range path {
+		folder, err := r.FindByName(ctx, mode, parentID, name)
+		if err != nil {
+			return nil, fmt.Errorf("finding saved filter folder %q: %w", name, err)
+		}
+
+		if folder == nil {
+			folder = &models.SavedFilterFolder{
+				Mode:     mode,
+				Name:     name,
+				ParentID: parentID,
+			}
+			if err := r.Create(ctx, folder); err != nil {
+				return nil, fmt.Errorf("creating saved filter folder %q: %w", name, err)
+			}
+		}
+
+		id := folder.ID
+		parentID = &id
+	}
+
+	return parentID, nil
+}
@@ -0,0 +1,67 @@
+package savedfilter
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateFolderParent(t *testing.T) {
+	const (
+		rootID  = 1
+		childID = 2
+		otherID = 3
+	)
+
+	db := mocks.NewDatabase()
+
+	root := rootID
+	db.SavedFilterFolder.On("Find", testCtx, rootID).Return(&models.SavedFilterFolder{ID: rootID, Mode: models.FilterModeScenes}, nil)
+	db.SavedFilterFolder.On("Find", testCtx, childID).Return(&models.SavedFilterFolder{ID: childID, Mode: models.FilterModeScenes, ParentID: &root}, nil)
+	db.SavedFilterFolder.On("Find", testCtx, otherID).Return(&models.SavedFilterFolder{ID: otherID, Mode: models.FilterModeImages}, nil)
+
+	r := db.SavedFilterFolder
+
+	assert.NoError(t, ValidateFolderParent(testCtx, r, 0, models.FilterModeScenes, childID))
+	assert.NoError(t, ValidateFolderParent(testCtx, r, otherID, models.FilterModeScenes, rootID))
+
+	// moving into itself or its subfolder
+	assert.Error(t, ValidateFolderParent(testCtx, r, rootID, models.FilterModeScenes, rootID))
+	assert.Error(t, ValidateFolderParent(testCtx, r, rootID, models.FilterModeScenes, childID))
+
+	// different mode
+	assert.Error(t, ValidateFolderParent(testCtx, r, 0, models.FilterModeScenes, otherID))
+}
+
+func TestEnsureFolderPath(t *testing.T) {
+	const (
+		existingID = 1
+		createdID  = 2
+	)
+
+	db := mocks.NewDatabase()
+
+	existing := existingID
+	db.SavedFilterFolder.On("FindByName", testCtx, models.FilterModeScenes, (*int)(nil), "a").Return(&models.SavedFilterFolder{ID: existingID, Name: "a"}, nil).Once()
+	db.SavedFilterFolder.On("FindByName", testCtx, models.FilterModeScenes, &existing, "b").Return(nil, nil).Once()
+	db.SavedFilterFolder.On("Create", testCtx, mock.MatchedBy(func(f *models.SavedFilterFolder) bool {
+		return f.Name == "b" && f.Mode == models.FilterModeScenes && f.ParentID != nil && *f.ParentID == existingID
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.SavedFilterFolder).ID = createdID
+	}).Return(nil).Once()
+
+	got, err := EnsureFolderPath(testCtx, db.SavedFilterFolder, models.FilterModeScenes, []string{"a", "b"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, createdID, *got)
+	}
+
+	got, err = EnsureFolderPath(testCtx, db.SavedFilterFolder, models.FilterModeScenes, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	db.AssertExpectations(t)
+}
@@ -14,6 +14,7 @@ type ImporterReaderWriter interface {
 
 type Importer struct {
 	ReaderWriter        ImporterReaderWriter
+	FolderWriter        models.SavedFilterFolderFinderCreator
 	Input               jsonschema.SavedFilter
 	MissingRefBehaviour models.ImportMissingRefEnum
 
@@ -27,6 +28,16 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		FindFilter:   i.Input.FindFilter,
 		ObjectFilter: i.Input.ObjectFilter,
 		UIOptions:    i.Input.UIOptions,
+		SortOrder:    i.Input.SortOrder,
+		IsDefault:    i.Input.IsDefault,
+	}
+
+	if len(i.Input.Folder) > 0 {
+		folderID, err := EnsureFolderPath(ctx, i.FolderWriter, i.Input.Mode, i.Input.Folder)
+		if err != nil {
+			return err
+		}
+		i.savedFilter.FolderID = folderID
 	}
 
 	return nil
@@ -0,0 +1,344 @@
+package scene
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+var ErrCompareTooFewScenes = errors.New("at least two scenes are required to compare")
+
+// ComparisonField is a field of a set of compared scenes.
+type ComparisonField struct {
+	Name string `json:"name"`
+	// Values are the values of the field for each scene, in the order of the
+	// compared scenes. Single valued fields have at most one value.
+	Values [][]string `json:"values"`
+	// Equal is true if the field has the same value for all scenes. The
+	// order of multi-valued fields is ignored.
+	Equal bool `json:"equal"`
+}
+
+// FingerprintDistance compares the primary files of two scenes.
+type FingerprintDistance struct {
+	SceneA int `json:"scene_a"`
+	SceneB int `json:"scene_b"`
+	// PhashDistance is the hamming distance between the phashes of the
+	// files. Nil if either file has no phash.
+	PhashDistance *int `json:"phash_distance"`
+	OshashEqual   bool `json:"oshash_equal"`
+	MD5Equal      bool `json:"md5_equal"`
+	// DurationDiff is the absolute difference in seconds between the
+	// durations of the files.
+	DurationDiff float64 `json:"duration_diff"`
+}
+
+// Comparison is a field by field comparison of a set of scenes.
+type Comparison struct {
+	Scenes []*models.Scene `json:"scenes"`
+	// Fields are the metadata fields of the scenes.
+	Fields []*ComparisonField `json:"fields"`
+	// Files are the technical details of the primary file of each scene.
+	Files []*ComparisonField `json:"files"`
+	// FingerprintDistances compares each pair of scenes.
+	FingerprintDistances []*FingerprintDistance `json:"fingerprint_distances"`
+}
+
+type CompareRepository struct {
+	Scene     models.SceneReader
+	Studio    models.StudioGetter
+	Performer models.PerformerGetter
+	Tag       models.TagGetter
+	Group     models.GroupGetter
+	Gallery   models.GalleryGetter
+}
+
+// Compare returns a comparison of the scenes with the given ids, in the
+// order of the ids.
+func Compare(ctx context.Context, r CompareRepository, ids []int) (*Comparison, error) {
+	ids = sliceutil.Unique(ids)
+	if len(ids) < 2 {
+		return nil, ErrCompareTooFewScenes
+	}
+
+	scenes, err := r.Scene.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Comparison{
+		Scenes: scenes,
+	}
+
+	// values of each field, indexed by scene
+	fields := make(map[string][][]string)
+	files := make(map[string][][]string)
+
+	for _, s := range scenes {
+		if err := s.LoadRelationships(ctx, r.Scene); err != nil {
+			return nil, fmt.Errorf("loading scene %d relationships: %w", s.ID, err)
+		}
+
+		sceneFields, err := compareFields(ctx, r, s)
+		if err != nil {
+			return nil, fmt.Errorf("comparing scene %d: %w", s.ID, err)
+		}
+
+		for _, name := range comparisonFieldNames {
+			fields[name] = append(fields[name], sceneFields[name])
+		}
+
+		fileFields := compareFileFields(s)
+		for _, name := range comparisonFileFieldNames {
+			files[name] = append(files[name], fileFields[name])
+		}
+	}
+
+	for _, name := range comparisonFieldNames {
+		ret.Fields = append(ret.Fields, newComparisonField(name, fields[name]))
+	}
+	for _, name := range comparisonFileFieldNames {
+		ret.Files = append(ret.Files, newComparisonField(name, files[name]))
+	}
+
+	for i, a := range scenes {
+		for _, b := range scenes[i+1:] {
+			ret.FingerprintDistances = append(ret.FingerprintDistances, compareFingerprints(a, b))
+		}
+	}
+
+	return ret, nil
+}
+
+var comparisonFieldNames = []string{
+	"title",
+	"code",
+	"details",
+	"director",
+	"date",
+	"rating",
+	"organized",
+	"studio",
+	"urls",
+	"performers",
+	"tags",
+	"groups",
+	"galleries",
+	"stash_ids",
+}
+
+var comparisonFileFieldNames = []string{
+	"path",
+	"file_count",
+	"size",
+	"duration",
+	"format",
+	"video_codec",
+	"audio_codec",
+	"resolution",
+	"frame_rate",
+	"bit_rate",
+	"hdr_format",
+	"quality_score",
+	"oshash",
+	"md5",
+	"phash",
+}
+
+func newComparisonField(name string, values [][]string) *ComparisonField {
+	ret := &ComparisonField{
+		Name:   name,
+		Values: values,
+		Equal:  true,
+	}
+
+	first := sortedCopy(values[0])
+	for _, v := range values[1:] {
+		if !slices.Equal(first, sortedCopy(v)) {
+			ret.Equal = false
+			break
+		}
+	}
+
+	return ret
+}
+
+func sortedCopy(v []string) []string {
+	ret := slices.Clone(v)
+	slices.Sort(ret)
+	return ret
+}
+
+// single returns a single valued field value, which is empty if v is the
+// zero value.
+func single[T comparable](v T, format func(T) string) []string {
+	var zero T
+	if v == zero {
+		return []string{}
+	}
+	return []string{format(v)}
+}
+
+func formatString(v string) string { return v }
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func compareFields(ctx context.Context, r CompareRepository, s *models.Scene) (map[string][]string, error) {
+	ret := map[string][]string{
+		"title":     single(s.Title, formatString),
+		"code":      single(s.Code, formatString),
+		"details":   single(s.Details, formatString),
+		"director":  single(s.Director, formatString),
+		"organized": {strconv.FormatBool(s.Organized)},
+		"urls":      s.URLs.List(),
+		"date":      {},
+		"rating":    {},
+		"studio":    {},
+	}
+
+	if s.Date != nil {
+		ret["date"] = []string{s.Date.String()}
+	}
+	if s.Rating != nil {
+		ret["rating"] = []string{strconv.Itoa(*s.Rating)}
+	}
+
+	if s.StudioID != nil {
+		studio, err := r.Studio.Find(ctx, *s.StudioID)
+		if err != nil {
+			return nil, fmt.Errorf("finding studio: %w", err)
+		}
+		if studio != nil {
+			ret["studio"] = []string{studio.Name}
+		}
+	}
+
+	performers, err := r.Performer.FindMany(ctx, s.PerformerIDs.List())
+	if err != nil {
+		return nil, fmt.Errorf("finding performers: %w", err)
+	}
+	ret["performers"] = []string{}
+	for _, p := range performers {
+		name := p.Name
+		if p.Disambiguation != "" {
+			name += " (" + p.Disambiguation + ")"
+		}
+		ret["performers"] = append(ret["performers"], name)
+	}
+
+	tags, err := r.Tag.FindMany(ctx, s.TagIDs.List())
+	if err != nil {
+		return nil, fmt.Errorf("finding tags: %w", err)
+	}
+	ret["tags"] = []string{}
+	for _, t := range tags {
+		ret["tags"] = append(ret["tags"], t.Name)
+	}
+
+	var groupIDs []int
+	for _, g := range s.Groups.List() {
+		groupIDs = append(groupIDs, g.GroupID)
+	}
+	groups, err := r.Group.FindMany(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("finding groups: %w", err)
+	}
+	ret["groups"] = []string{}
+	for _, g := range groups {
+		ret["groups"] = append(ret["groups"], g.Name)
+	}
+
+	galleries, err := r.Gallery.FindMany(ctx, s.GalleryIDs.List())
+	if err != nil {
+		return nil, fmt.Errorf("finding galleries: %w", err)
+	}
+	ret["galleries"] = []string{}
+	for _, g := range galleries {
+		ret["galleries"] = append(ret["galleries"], g.DisplayName())
+	}
+
+	ret["stash_ids"] = []string{}
+	for _, sid := range s.StashIDs.List() {
+		ret["stash_ids"] = append(ret["stash_ids"], sid.Endpoint+"#"+sid.StashID)
+	}
+
+	return ret, nil
+}
+
+func compareFileFields(s *models.Scene) map[string][]string {
+	ret := make(map[string][]string)
+	for _, name := range comparisonFileFieldNames {
+		ret[name] = []string{}
+	}
+	ret["file_count"] = []string{strconv.Itoa(len(s.Files.List()))}
+
+	f := s.Files.Primary()
+	if f == nil {
+		return ret
+	}
+
+	ret["path"] = []string{f.Path}
+	ret["size"] = []string{strconv.FormatInt(f.Size, 10)}
+	ret["duration"] = single(f.Duration, formatFloat)
+	ret["format"] = single(f.Format, formatString)
+	ret["video_codec"] = single(f.VideoCodec, formatString)
+	ret["audio_codec"] = single(f.AudioCodec, formatString)
+	ret["frame_rate"] = single(f.FrameRate, formatFloat)
+	ret["bit_rate"] = single(f.BitRate, func(v int64) string { return strconv.FormatInt(v, 10) })
+	ret["quality_score"] = single(f.QualityScore, strconv.Itoa)
+
+	if f.Width > 0 && f.Height > 0 {
+		ret["resolution"] = []string{fmt.Sprintf("%dx%d", f.Width, f.Height)}
+	}
+	if f.HDRFormat != nil {
+		ret["hdr_format"] = []string{f.HDRFormat.String()}
+	}
+
+	for _, name := range []string{models.FingerprintTypeOshash, models.FingerprintTypeMD5, models.FingerprintTypePhash} {
+		if fp := f.Fingerprints.For(name); fp != nil {
+			ret[name] = []string{fp.Value()}
+		}
+	}
+
+	return ret
+}
+
+func compareFingerprints(a, b *models.Scene) *FingerprintDistance {
+	ret := &FingerprintDistance{
+		SceneA: a.ID,
+		SceneB: b.ID,
+	}
+
+	fa := a.Files.Primary()
+	fb := b.Files.Primary()
+	if fa == nil || fb == nil {
+		return ret
+	}
+
+	ret.DurationDiff = math.Abs(fa.Duration - fb.Duration)
+
+	equal := func(t string) bool {
+		va := fa.Fingerprints.Get(t)
+		return va != nil && va == fb.Fingerprints.Get(t)
+	}
+	ret.OshashEqual = equal(models.FingerprintTypeOshash)
+	ret.MD5Equal = equal(models.FingerprintTypeMD5)
+
+	pa, okA := fa.Fingerprints.Get(models.FingerprintTypePhash).(int64)
+	pb, okB := fb.Fingerprints.Get(models.FingerprintTypePhash).(int64)
+	if okA && okB {
+		d := utils.HammingDistance(uint64(pa), uint64(pb))
+		ret.PhashDistance = &d
+	}
+
+	return ret
+}
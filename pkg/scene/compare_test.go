@@ -0,0 +1,125 @@
+package scene
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompare(t *testing.T) {
+	const (
+		sceneA = 1
+		sceneB = 2
+		tagA   = 3
+		tagB   = 4
+	)
+
+	newFile := func(oshash string, phash int64, duration float64) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				Path: "/videos/" + oshash + ".mp4",
+				Fingerprints: models.Fingerprints{
+					{Type: models.FingerprintTypeOshash, Fingerprint: oshash},
+					{Type: models.FingerprintTypePhash, Fingerprint: phash},
+				},
+			},
+			Format:   "mp4",
+			Width:    1920,
+			Height:   1080,
+			Duration: duration,
+		}
+	}
+
+	newScene := func(id int, title string, tagIDs []int, f *models.VideoFile) *models.Scene {
+		return &models.Scene{
+			ID:           id,
+			Title:        title,
+			URLs:         models.NewRelatedStrings([]string{}),
+			GalleryIDs:   models.NewRelatedIDs([]int{}),
+			PerformerIDs: models.NewRelatedIDs([]int{}),
+			TagIDs:       models.NewRelatedIDs(tagIDs),
+			Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
+			StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+			Files:        models.NewRelatedVideoFiles([]*models.VideoFile{f}),
+		}
+	}
+
+	tags := map[int]*models.Tag{
+		tagA: {ID: tagA, Name: "a"},
+		tagB: {ID: tagB, Name: "b"},
+	}
+
+	db := mocks.NewDatabase()
+	db.Scene.On("FindMany", mock.Anything, []int{sceneA, sceneB}).Return([]*models.Scene{
+		newScene(sceneA, "title", []int{tagA, tagB}, newFile("aaaa", 0x0f, 100)),
+		newScene(sceneB, "title", []int{tagB, tagA}, newFile("bbbb", 0xff, 102.5)),
+	}, nil)
+	db.Performer.On("FindMany", mock.Anything, mock.Anything).Return([]*models.Performer{}, nil)
+	db.Tag.On("FindMany", mock.Anything, mock.Anything).Return(func(ctx context.Context, ids []int) []*models.Tag {
+		var ret []*models.Tag
+		for _, id := range ids {
+			ret = append(ret, tags[id])
+		}
+		return ret
+	}, nil)
+	db.Group.On("FindMany", mock.Anything, mock.Anything).Return([]*models.Group{}, nil)
+	db.Gallery.On("FindMany", mock.Anything, mock.Anything).Return([]*models.Gallery{}, nil)
+
+	r := CompareRepository{
+		Scene:     db.Scene,
+		Studio:    db.Studio,
+		Performer: db.Performer,
+		Tag:       db.Tag,
+		Group:     db.Group,
+		Gallery:   db.Gallery,
+	}
+
+	_, err := Compare(context.Background(), r, []int{sceneA, sceneA})
+	assert.ErrorIs(t, err, ErrCompareTooFewScenes)
+
+	got, err := Compare(context.Background(), r, []int{sceneA, sceneB, sceneA})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	field := func(fields []*ComparisonField, name string) *ComparisonField {
+		for _, f := range fields {
+			if f.Name == name {
+				return f
+			}
+		}
+		t.Fatalf("field %s not found", name)
+		return nil
+	}
+
+	assert.Len(t, got.Fields, len(comparisonFieldNames))
+	assert.Len(t, got.Files, len(comparisonFileFieldNames))
+
+	assert.True(t, field(got.Fields, "title").Equal)
+	assert.Equal(t, [][]string{{}, {}}, field(got.Fields, "studio").Values)
+
+	// tag order is ignored
+	tagsField := field(got.Fields, "tags")
+	assert.True(t, tagsField.Equal)
+	assert.Equal(t, [][]string{{"a", "b"}, {"b", "a"}}, tagsField.Values)
+
+	assert.True(t, field(got.Files, "resolution").Equal)
+	assert.False(t, field(got.Files, "duration").Equal)
+	assert.Equal(t, [][]string{{"aaaa"}, {"bbbb"}}, field(got.Files, "oshash").Values)
+
+	phashDistance := 4
+	assert.Equal(t, []*FingerprintDistance{
+		{
+			SceneA:        sceneA,
+			SceneB:        sceneB,
+			PhashDistance: &phashDistance,
+			DurationDiff:  2.5,
+		},
+	}, got.FingerprintDistances)
+
+	db.AssertExpectations(t)
+}
@@ -2,7 +2,6 @@ package scene
 
 import (
 	"context"
-	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/file/video"
@@ -28,7 +27,17 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		return nil
 	}
 
-	markersFolder := filepath.Join(d.Paths.Generated.Markers, sceneHash)
+	// the scene folder contains all of the generated files of the scene
+	if d.Paths.Generated.Layout == paths.LayoutScene {
+		sceneFolder := d.Paths.Generated.GetSceneFolderPath(sceneHash)
+		exists, _ := fsutil.DirExists(sceneFolder)
+		if exists {
+			return d.Dirs([]string{sceneFolder})
+		}
+		return nil
+	}
+
+	markersFolder := d.Paths.SceneMarkers.GetFolderPath(sceneHash)
 
 	exists, _ := fsutil.FileExists(markersFolder)
 	if exists {
@@ -69,12 +78,30 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 		files = append(files, vttPath)
 	}
 
+	barcodePath := d.Paths.Scene.GetBarcodeFilePath(sceneHash)
+	exists, _ = fsutil.FileExists(barcodePath)
+	if exists {
+		files = append(files, barcodePath)
+	}
+
 	heatmapPath := d.Paths.Scene.GetInteractiveHeatmapPath(sceneHash)
 	exists, _ = fsutil.FileExists(heatmapPath)
 	if exists {
 		files = append(files, heatmapPath)
 	}
 
+	sceneHeatmapPath := d.Paths.Scene.GetHeatmapPath(sceneHash)
+	exists, _ = fsutil.FileExists(sceneHeatmapPath)
+	if exists {
+		files = append(files, sceneHeatmapPath)
+	}
+
+	sceneHeatmapDataPath := d.Paths.Scene.GetHeatmapDataPath(sceneHash)
+	exists, _ = fsutil.FileExists(sceneHeatmapDataPath)
+	if exists {
+		files = append(files, sceneHeatmapDataPath)
+	}
+
 	return d.Files(files)
 }
 
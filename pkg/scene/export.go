@@ -31,13 +31,19 @@ type TagFinder interface {
 // of cover image.
 func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene) (*jsonschema.Scene, error) {
 	newSceneJSON := jsonschema.Scene{
-		Title:     scene.Title,
-		Code:      scene.Code,
-		URLs:      scene.URLs.List(),
-		Details:   scene.Details,
-		Director:  scene.Director,
-		CreatedAt: json.JSONTime{Time: scene.CreatedAt},
-		UpdatedAt: json.JSONTime{Time: scene.UpdatedAt},
+		Title:       scene.Title,
+		Code:        scene.Code,
+		URLs:        scene.URLs.List(),
+		Details:     scene.Details,
+		Director:    scene.Director,
+		Country:     scene.Country,
+		City:        scene.City,
+		Latitude:    scene.Latitude,
+		Longitude:   scene.Longitude,
+		StreamStart: scene.StreamStart,
+		StreamEnd:   scene.StreamEnd,
+		CreatedAt:   json.JSONTime{Time: scene.CreatedAt},
+		UpdatedAt:   json.JSONTime{Time: scene.UpdatedAt},
 	}
 
 	if scene.Date != nil {
@@ -49,6 +55,8 @@ func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene)
 	}
 
 	newSceneJSON.Organized = scene.Organized
+	newSceneJSON.Pending = scene.Pending
+	newSceneJSON.Archived = scene.Archived
 
 	for _, f := range scene.Files.List() {
 		newSceneJSON.Files = append(newSceneJSON.Files, f.Base().Path)
@@ -229,12 +237,21 @@ func GetSceneMarkersJSON(ctx context.Context, markerReader models.SceneMarkerFin
 		sceneMarkerJSON := jsonschema.SceneMarker{
 			Title:      sceneMarker.Title,
 			Seconds:    getDecimalString(sceneMarker.Seconds),
+			Details:    sceneMarker.Details,
 			PrimaryTag: primaryTag.Name,
 			Tags:       getTagNames(sceneMarkerTags),
 			CreatedAt:  json.JSONTime{Time: sceneMarker.CreatedAt},
 			UpdatedAt:  json.JSONTime{Time: sceneMarker.UpdatedAt},
 		}
 
+		if sceneMarker.Rating != nil {
+			sceneMarkerJSON.Rating = *sceneMarker.Rating
+		}
+
+		if sceneMarker.CoverSeconds != nil {
+			sceneMarkerJSON.CoverSeconds = strconv.FormatFloat(*sceneMarker.CoverSeconds, 'f', -1, 64)
+		}
+
 		results = append(results, sceneMarkerJSON)
 	}
 
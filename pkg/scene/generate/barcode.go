@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// barcodeHeight is the height in pixels of the generated barcode image.
+	barcodeHeight = 32
+
+	// bytes per pixel of the rgb24 raw video output
+	rgb24PixelSize = 3
+)
+
+// Barcode generates a "movie barcode" image for the scene, where each column
+// of the image is the average colour of one second of the video.
+func (g Generator) Barcode(ctx context.Context, input string, hash string) error {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	output := g.ScenePaths.GetBarcodeFilePath(hash)
+	if !g.Overwrite {
+		if exists, _ := fsutil.FileExists(output); exists {
+			return nil
+		}
+	}
+
+	logger.Infof("[generator] generating barcode for %s", input)
+
+	if err := g.generateFile(lockCtx, g.ScenePaths, pngPattern, output, g.barcode(input)); err != nil {
+		return err
+	}
+
+	logger.Debug("created barcode: ", output)
+
+	return nil
+}
+
+func (g Generator) barcode(input string) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		var vf ffmpeg.VideoFilter
+		vf = vf.Fps(1)
+		// area scaling averages all pixels in the frame
+		vf = vf.Append("scale=1:1:flags=area")
+
+		var args ffmpeg.Args
+		args = args.LogLevel(ffmpeg.LogLevelError)
+		args = args.Input(input)
+		args = args.SkipAudio()
+		args = args.VideoFilter(vf)
+		args = args.Format(ffmpeg.FormatRawVideo)
+		args = append(args, "-pix_fmt", "rgb24")
+		args = args.Output("-")
+
+		out, err := g.generateOutput(lockCtx, args)
+		if err != nil {
+			return err
+		}
+
+		img, err := barcodeImage(out, barcodeHeight)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(tmpFn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return png.Encode(f, img)
+	}
+}
+
+// barcodeImage creates an image from rgb24 pixel data, one pixel per column.
+func barcodeImage(data []byte, height int) (image.Image, error) {
+	width := len(data) / rgb24PixelSize
+	if width == 0 {
+		return nil, fmt.Errorf("no frames in barcode data")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		i := x * rgb24PixelSize
+		c := color.NRGBA{R: data[i], G: data[i+1], B: data[i+2], A: 255}
+		for y := 0; y < height; y++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img, nil
+}
@@ -0,0 +1,44 @@
+package generate
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_barcodeImage(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		height  int
+		want    []color.NRGBA
+		wantErr bool
+	}{
+		{"empty", []byte{}, 2, nil, true},
+		{"partial pixel", []byte{255, 0}, 2, nil, true},
+		{"two columns", []byte{255, 0, 0, 0, 0, 255}, 2, []color.NRGBA{red, blue}, false},
+		{"trailing bytes ignored", []byte{255, 0, 0, 0}, 1, []color.NRGBA{red}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := barcodeImage(tt.data, tt.height)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, len(tt.want), tt.height), got.Bounds())
+
+			for x, c := range tt.want {
+				for y := 0; y < tt.height; y++ {
+					assert.Equal(t, c, got.At(x, y))
+				}
+			}
+		})
+	}
+}
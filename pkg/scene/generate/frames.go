@@ -0,0 +1,257 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// spriteFrameDuration is the duration of each sprite input. Only the first
+// frame is used, but limiting the duration prevents ffmpeg from decoding
+// the rest of the file.
+const spriteFrameDuration = 1
+
+// SceneFramesOptions configures the outputs of a batched frame extraction.
+type SceneFramesOptions struct {
+	// Sprite generates the sprite image and vtt file.
+	Sprite bool
+	// Screenshot is the time to take the cover screenshot at. No screenshot
+	// is generated if nil.
+	Screenshot *float64
+	// Preview generates the video preview using these options if not nil.
+	// Preview.Audio must only be set if the input has an audio stream.
+	Preview *PreviewOptions
+
+	// HardwareDecode decodes the input using hardware acceleration, if available.
+	HardwareDecode bool
+}
+
+type sceneFramesOutputs struct {
+	Sprite     string
+	Screenshot string
+	Preview    string
+}
+
+// SpriteStepSize returns the duration between each frame of the sprite image.
+func SpriteStepSize(videoDuration float64) float64 {
+	return videoDuration / float64(spriteChunks)
+}
+
+// SceneFrames generates the sprite image, cover screenshot and video preview
+// of a scene using a single ffmpeg invocation. Each frame and preview segment
+// is read from a separately seeked input of the same file, so that only the
+// required parts of the file are decoded.
+//
+// Returns the screenshot image data if a screenshot was requested.
+func (g Generator) SceneFrames(ctx context.Context, input string, videoDuration float64, hash string, options SceneFramesOptions) ([]byte, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	// outputs are moved on success, so ignore errors removing them
+	var tmpFiles []string
+	defer func() {
+		for _, f := range tmpFiles {
+			_ = os.Remove(f)
+		}
+	}()
+
+	newTempFile := func(pattern string) (string, error) {
+		f, err := g.tempFile(g.ScenePaths, pattern)
+		if err != nil {
+			return "", err
+		}
+		tmpFiles = append(tmpFiles, f.Name())
+		return f.Name(), nil
+	}
+
+	spriteOutput := g.ScenePaths.GetSpriteImageFilePath(hash)
+	previewOutput := g.ScenePaths.GetVideoPreviewPath(hash)
+
+	var outputs sceneFramesOutputs
+	var err error
+	if options.Sprite && g.outputRequired(spriteOutput) {
+		if outputs.Sprite, err = newTempFile(jpgPattern); err != nil {
+			return nil, err
+		}
+	}
+	if options.Screenshot != nil {
+		if outputs.Screenshot, err = newTempFile(jpgPattern); err != nil {
+			return nil, err
+		}
+	}
+	if options.Preview != nil && g.outputRequired(previewOutput) {
+		if outputs.Preview, err = newTempFile(mp4Pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	if outputs == (sceneFramesOutputs{}) {
+		return nil, nil
+	}
+
+	logger.Infof("[generator] generating frames for %s", input)
+
+	args := g.sceneFramesArgs(input, videoDuration, options, outputs)
+	if err := g.generate(lockCtx, args); err != nil {
+		return nil, err
+	}
+
+	if outputs.Sprite != "" {
+		if err := moveGenerated(outputs.Sprite, spriteOutput); err != nil {
+			return nil, err
+		}
+		logger.Debug("created sprite image: ", spriteOutput)
+
+		vttOutput := g.ScenePaths.GetSpriteVttFilePath(hash)
+		if err := g.SpriteVTT(ctx, vttOutput, spriteOutput, SpriteStepSize(videoDuration)); err != nil {
+			return nil, err
+		}
+	}
+
+	if outputs.Preview != "" {
+		if err := moveGenerated(outputs.Preview, previewOutput); err != nil {
+			return nil, err
+		}
+		logger.Debug("created video preview: ", previewOutput)
+	}
+
+	var screenshot []byte
+	if outputs.Screenshot != "" {
+		screenshot, err = os.ReadFile(outputs.Screenshot)
+		if err != nil {
+			return nil, err
+		}
+		if len(screenshot) == 0 {
+			return nil, fmt.Errorf("ffmpeg command produced no screenshot output")
+		}
+	}
+
+	return screenshot, nil
+}
+
+func (g Generator) outputRequired(output string) bool {
+	if g.Overwrite {
+		return true
+	}
+
+	exists, _ := fsutil.FileExists(output)
+	return !exists
+}
+
+func (g Generator) sceneFramesArgs(input string, videoDuration float64, options SceneFramesOptions, outputs sceneFramesOutputs) ffmpeg.Args {
+	var args ffmpeg.Args
+	args = args.LogLevel(ffmpeg.LogLevelError).Overwrite()
+
+	nextInput := 0
+	addInput := func(seek float64, duration float64, extraArgs []string) int {
+		if options.HardwareDecode {
+			args = append(args, "-hwaccel", "auto")
+		}
+		args = append(args, extraArgs...)
+		if seek > 0 {
+			args = args.Seek(seek)
+		}
+		if duration > 0 {
+			args = args.Duration(duration)
+		}
+		args = args.Input(input)
+
+		nextInput++
+		return nextInput - 1
+	}
+
+	var filters []string
+	var outputArgs ffmpeg.Args
+
+	if outputs.Sprite != "" {
+		stepSize := SpriteStepSize(videoDuration)
+
+		var labels strings.Builder
+		for i := 0; i < spriteChunks; i++ {
+			idx := addInput(float64(i)*stepSize, spriteFrameDuration, nil)
+			filters = append(filters, fmt.Sprintf("[%d:v:0]trim=end_frame=1,setpts=PTS-STARTPTS,scale=%d:-2[s%d]", idx, spriteScreenshotWidth, i))
+			fmt.Fprintf(&labels, "[s%d]", i)
+		}
+
+		// combine the frames into a single image, filling rows first
+		filters = append(filters, fmt.Sprintf("%sconcat=n=%d:v=1:a=0,tile=%dx%d[sprite]", labels.String(), spriteChunks, spriteCols, spriteRows))
+
+		outputArgs = append(outputArgs, "-map", "[sprite]")
+		outputArgs = outputArgs.VideoFrames(1)
+		outputArgs = outputArgs.FixedQualityScaleVideo(screenshotQuality)
+		outputArgs = outputArgs.Format(ffmpeg.FormatImage2)
+		outputArgs = outputArgs.Output(outputs.Sprite)
+	}
+
+	if outputs.Screenshot != "" {
+		idx := addInput(*options.Screenshot, 0, nil)
+
+		outputArgs = append(outputArgs, "-map", fmt.Sprintf("%d:v:0", idx))
+		outputArgs = outputArgs.VideoFrames(1)
+		outputArgs = outputArgs.FixedQualityScaleVideo(screenshotQuality)
+		outputArgs = outputArgs.Format(ffmpeg.FormatImage2)
+		outputArgs = outputArgs.Output(outputs.Screenshot)
+	}
+
+	if outputs.Preview != "" {
+		previewOptions := *options.Preview
+		segments := previewOptions.segments(videoDuration)
+
+		var labels strings.Builder
+		for i, segment := range segments {
+			idx := addInput(segment.StartTime, segment.Duration, g.FFMpegConfig.GetTranscodeInputArgs())
+			filters = append(filters, fmt.Sprintf("[%d:v:0]setpts=PTS-STARTPTS,scale=%d:-2[p%d]", idx, scenePreviewWidth, i))
+			fmt.Fprintf(&labels, "[p%d]", i)
+
+			if previewOptions.Audio {
+				filters = append(filters, fmt.Sprintf("[%d:a:0]asetpts=PTS-STARTPTS[pa%d]", idx, i))
+				fmt.Fprintf(&labels, "[pa%d]", i)
+			}
+		}
+
+		audioStreams := 0
+		concatOutputs := "[preview]"
+		if previewOptions.Audio {
+			audioStreams = 1
+			concatOutputs += "[previewa]"
+		}
+
+		filters = append(filters, fmt.Sprintf("%sconcat=n=%d:v=1:a=%d%s", labels.String(), len(segments), audioStreams, concatOutputs))
+
+		outputArgs = append(outputArgs, "-map", "[preview]")
+		outputArgs = outputArgs.MaxMuxingQueueSize(1024)
+		outputArgs = outputArgs.VideoCodec(ffmpeg.VideoCodecLibX264)
+		outputArgs = append(outputArgs,
+			"-pix_fmt", "yuv420p",
+			"-profile:v", "high",
+			"-level", "4.2",
+			"-preset", previewOptions.Preset,
+			"-crf", "21",
+			"-threads", "4",
+			"-strict", "-2",
+		)
+
+		if previewOptions.Audio {
+			outputArgs = append(outputArgs, "-map", "[previewa]")
+			outputArgs = outputArgs.AudioCodec(ffmpeg.AudioCodecAAC)
+			outputArgs = outputArgs.AudioBitrate(scenePreviewAudioBitrate)
+		} else {
+			outputArgs = outputArgs.SkipAudio()
+		}
+
+		outputArgs = append(outputArgs, g.FFMpegConfig.GetTranscodeOutputArgs()...)
+		outputArgs = outputArgs.Format(ffmpeg.FormatMP4)
+		outputArgs = outputArgs.Output(outputs.Preview)
+	}
+
+	if len(filters) > 0 {
+		args = append(args, "-filter_complex", strings.Join(filters, ";"))
+	}
+
+	return append(args, outputArgs...)
+}
@@ -0,0 +1,124 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFFMpegConfig struct{}
+
+func (testFFMpegConfig) GetTranscodeInputArgs() []string  { return nil }
+func (testFFMpegConfig) GetTranscodeOutputArgs() []string { return nil }
+
+func countArg(args []string, arg string) int {
+	n := 0
+	for _, a := range args {
+		if a == arg {
+			n++
+		}
+	}
+	return n
+}
+
+func filterComplex(args []string) string {
+	i := slices.Index(args, "-filter_complex")
+	if i == -1 || i+1 >= len(args) {
+		return ""
+	}
+	return args[i+1]
+}
+
+func TestGenerator_sceneFramesArgs(t *testing.T) {
+	g := Generator{FFMpegConfig: testFFMpegConfig{}}
+	screenshotAt := 20.0
+	previewOptions := PreviewOptions{
+		Segments:        4,
+		SegmentDuration: 1,
+		Preset:          "slow",
+		Audio:           true,
+	}
+
+	t.Run("all outputs", func(t *testing.T) {
+		args := g.sceneFramesArgs("in.mp4", 100, SceneFramesOptions{
+			Sprite:         true,
+			Screenshot:     &screenshotAt,
+			Preview:        &previewOptions,
+			HardwareDecode: true,
+		}, sceneFramesOutputs{
+			Sprite:     "sprite.jpg",
+			Screenshot: "screenshot.jpg",
+			Preview:    "preview.mp4",
+		})
+
+		wantInputs := spriteChunks + 1 + previewOptions.Segments
+		assert.Equal(t, wantInputs, countArg(args, "-i"))
+		assert.Equal(t, wantInputs, countArg(args, "-hwaccel"))
+
+		fc := filterComplex(args)
+		assert.Contains(t, fc, "tile=9x9[sprite]")
+		assert.Contains(t, fc, "concat=n=4:v=1:a=1[preview][previewa]")
+
+		// screenshot input follows the sprite inputs
+		assert.Contains(t, args, "81:v:0")
+		assert.Contains(t, args, "[previewa]")
+
+		for _, o := range []string{"sprite.jpg", "screenshot.jpg", "preview.mp4"} {
+			assert.Contains(t, args, o)
+		}
+	})
+
+	t.Run("preview without audio", func(t *testing.T) {
+		noAudio := previewOptions
+		noAudio.Audio = false
+
+		args := g.sceneFramesArgs("in.mp4", 100, SceneFramesOptions{
+			Preview: &noAudio,
+		}, sceneFramesOutputs{
+			Preview: "preview.mp4",
+		})
+
+		assert.Equal(t, noAudio.Segments, countArg(args, "-i"))
+		assert.Zero(t, countArg(args, "-hwaccel"))
+
+		fc := filterComplex(args)
+		assert.Contains(t, fc, "concat=n=4:v=1:a=0[preview]")
+		assert.NotContains(t, fc, ":a:0")
+		assert.Contains(t, args, "-an")
+	})
+
+	t.Run("screenshot only", func(t *testing.T) {
+		args := g.sceneFramesArgs("in.mp4", 100, SceneFramesOptions{
+			Screenshot: &screenshotAt,
+		}, sceneFramesOutputs{
+			Screenshot: "screenshot.jpg",
+		})
+
+		assert.Equal(t, 1, countArg(args, "-i"))
+		assert.NotContains(t, args, "-filter_complex")
+		assert.True(t, strings.HasSuffix(strings.Join(args, " "), "screenshot.jpg"))
+	})
+}
+
+func TestPreviewOptions_segments(t *testing.T) {
+	options := PreviewOptions{
+		Segments:        4,
+		SegmentDuration: 2,
+		ExcludeStart:    "10",
+		ExcludeEnd:      "10",
+	}
+
+	got := options.segments(120)
+	assert.Equal(t, []previewSegment{
+		{StartTime: 10, Duration: 2},
+		{StartTime: 35, Duration: 2},
+		{StartTime: 60, Duration: 2},
+		{StartTime: 85, Duration: 2},
+	}, got)
+
+	// shorter than the total segment duration
+	got = options.segments(5)
+	assert.Equal(t, []previewSegment{{StartTime: 0, Duration: 5}}, got)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
@@ -17,6 +18,7 @@ const (
 	mp4Pattern  = "*.mp4"
 	webpPattern = "*.webp"
 	jpgPattern  = "*.jpg"
+	pngPattern  = "*.png"
 	txtPattern  = "*.txt"
 	vttPattern  = "*.vtt"
 )
@@ -41,6 +43,7 @@ type ScenePaths interface {
 
 	GetSpriteImageFilePath(checksum string) string
 	GetSpriteVttFilePath(checksum string) string
+	GetBarcodeFilePath(checksum string) string
 
 	GetTranscodePath(checksum string) string
 }
@@ -87,6 +90,12 @@ func (g Generator) generateFile(lockCtx *fsutil.LockContext, p Paths, pattern st
 		return err
 	}
 
+	return moveGenerated(tmpFn, output)
+}
+
+// moveGenerated moves the generated temporary file to output. Returns an error
+// if the generated file is empty.
+func moveGenerated(tmpFn string, output string) error {
 	// check if generated empty file
 	stat, err := os.Stat(tmpFn)
 	if err != nil {
@@ -97,6 +106,11 @@ func (g Generator) generateFile(lockCtx *fsutil.LockContext, p Paths, pattern st
 		return fmt.Errorf("ffmpeg command produced no output")
 	}
 
+	// the output directory may not exist in the sharded and scene layouts
+	if err := fsutil.EnsureDirAll(filepath.Dir(output)); err != nil {
+		return err
+	}
+
 	if err := fsutil.SafeMove(tmpFn, output); err != nil {
 		return fmt.Errorf("moving %s to %s failed: %w", tmpFn, output, err)
 	}
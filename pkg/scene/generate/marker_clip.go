@@ -0,0 +1,99 @@
+package generate
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type MarkerClipOptions struct {
+	Seconds  float64
+	Duration float64
+
+	// if true, the clip is always re-encoded rather than stream copied
+	Reencode bool
+}
+
+// MarkerClip writes the section of the input video described by options to
+// output as an mp4 file. The streams are copied where the source codecs
+// support it. If stream copying fails, the clip is re-encoded.
+func (g Generator) MarkerClip(ctx context.Context, input string, output string, options MarkerClipOptions) error {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	if !g.Overwrite {
+		if exists, _ := fsutil.FileExists(output); exists {
+			return nil
+		}
+	}
+
+	if !options.Reencode {
+		err := g.generateFile(lockCtx, g.MarkerPaths, mp4Pattern, output, g.markerClipCopy(input, options))
+		if err == nil {
+			logger.Debug("created marker clip: ", output)
+			return nil
+		}
+
+		if lockCtx.Err() != nil {
+			return err
+		}
+
+		logger.Debugf("stream copying marker clip failed, re-encoding: %v", err)
+	}
+
+	if err := g.generateFile(lockCtx, g.MarkerPaths, mp4Pattern, output, g.markerClipTranscode(input, options)); err != nil {
+		return err
+	}
+
+	logger.Debug("created marker clip: ", output)
+
+	return nil
+}
+
+func (g Generator) markerClipCopy(input string, options MarkerClipOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+			Format:     ffmpeg.FormatMP4,
+			VideoCodec: ffmpeg.VideoCodecCopy,
+			AudioCodec: ffmpeg.AudioCodecCopy,
+			StartTime:  options.Seconds,
+			Duration:   options.Duration,
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
+
+func (g Generator) markerClipTranscode(input string, options MarkerClipOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		var videoArgs ffmpeg.Args
+		videoArgs = append(videoArgs,
+			"-pix_fmt", "yuv420p",
+			"-profile:v", "high",
+			"-level", "4.2",
+			"-preset", "superfast",
+			"-crf", "23",
+			"-movflags", "+faststart",
+		)
+
+		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+			Format:     ffmpeg.FormatMP4,
+			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoArgs:  videoArgs,
+			AudioCodec: ffmpeg.AudioCodecAAC,
+			StartTime:  options.Seconds,
+			SlowSeek:   true,
+			Duration:   options.Duration,
+
+			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
+			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
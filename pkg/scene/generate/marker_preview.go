@@ -152,7 +152,9 @@ func (g Generator) sceneMarkerWebp(input string, options sceneMarkerOptions) gen
 	}
 }
 
-func (g Generator) SceneMarkerScreenshot(ctx context.Context, input string, hash string, seconds float64, width int) error {
+// SceneMarkerScreenshot generates the screenshot for the marker starting at
+// seconds, using the frame at frameSeconds.
+func (g Generator) SceneMarkerScreenshot(ctx context.Context, input string, hash string, seconds float64, frameSeconds float64, width int) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -164,7 +166,7 @@ func (g Generator) SceneMarkerScreenshot(ctx context.Context, input string, hash
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, jpgPattern, output, g.sceneMarkerScreenshot(input, SceneMarkerScreenshotOptions{
-		Seconds: seconds,
+		Seconds: frameSeconds,
 		Width:   width,
 	})); err != nil {
 		return err
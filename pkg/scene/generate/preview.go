@@ -68,6 +68,40 @@ func (g PreviewOptions) getStepSizeAndOffset(videoDuration float64) (stepSize fl
 	return
 }
 
+type previewSegment struct {
+	StartTime float64
+	Duration  float64
+}
+
+// segments returns the sections of the video used to generate the preview.
+// A single segment covering the whole video is returned for videos shorter
+// than the total duration of the segments.
+func (g PreviewOptions) segments(videoDuration float64) []previewSegment {
+	// #2496 - generate a single preview video for videos shorter than segments * segment duration
+	if videoDuration < g.SegmentDuration*float64(g.Segments) {
+		return []previewSegment{{StartTime: 0, Duration: videoDuration}}
+	}
+
+	stepSize, offset := g.getStepSizeAndOffset(videoDuration)
+
+	segmentDuration := g.SegmentDuration
+	// a very short duration can create files without a video stream
+	if segmentDuration < minSegmentDuration {
+		segmentDuration = minSegmentDuration
+		logger.Warnf("[generator] Segment duration (%f) too short. Using %f instead.", g.SegmentDuration, minSegmentDuration)
+	}
+
+	ret := make([]previewSegment, g.Segments)
+	for i := range ret {
+		ret[i] = previewSegment{
+			StartTime: offset + (float64(i) * stepSize),
+			Duration:  segmentDuration,
+		}
+	}
+
+	return ret
+}
+
 func (g Generator) PreviewVideo(ctx context.Context, input string, videoDuration float64, hash string, options PreviewOptions, fallback bool, useVsync2 bool) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
@@ -103,17 +137,7 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 		// remove tmpFiles when done
 		defer func() { removeFiles(tmpFiles) }()
 
-		stepSize, offset := options.getStepSizeAndOffset(videoDuration)
-
-		segmentDuration := options.SegmentDuration
-		// TODO - move this out into calling function
-		// a very short duration can create files without a video stream
-		if segmentDuration < minSegmentDuration {
-			segmentDuration = minSegmentDuration
-			logger.Warnf("[generator] Segment duration (%f) too short. Using %f instead.", options.SegmentDuration, minSegmentDuration)
-		}
-
-		for i := 0; i < options.Segments; i++ {
+		for _, segment := range options.segments(videoDuration) {
 			chunkFile, err := g.tempFile(g.ScenePaths, mp4Pattern)
 			if err != nil {
 				return fmt.Errorf("generating video preview chunk file: %w", err)
@@ -121,11 +145,9 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 
 			tmpFiles = append(tmpFiles, chunkFile.Name())
 
-			time := offset + (float64(i) * stepSize)
-
 			chunkOptions := previewChunkOptions{
-				StartTime:  time,
-				Duration:   segmentDuration,
+				StartTime:  segment.StartTime,
+				Duration:   segment.Duration,
 				OutputPath: chunkFile.Name(),
 				Audio:      options.Audio,
 				Preset:     options.Preset,
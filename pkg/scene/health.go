@@ -0,0 +1,162 @@
+package scene
+
+import (
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// HealthThresholds are the limits below which a scene is considered low
+// quality. A zero value disables the check.
+type HealthThresholds struct {
+	MinHeight       int   `json:"min_height"`
+	MinBitrate      int64 `json:"min_bitrate"`
+	MinQualityScore int   `json:"min_quality_score"`
+}
+
+// HealthScene is the quality summary of a scene's primary file.
+type HealthScene struct {
+	SceneID    int     `json:"scene_id"`
+	Title      string  `json:"title"`
+	Path       string  `json:"path"`
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	Bitrate    int64   `json:"bitrate"`
+	Size       int64   `json:"size"`
+	Duration   float64 `json:"duration"`
+	VideoCodec string  `json:"video_codec"`
+	// QualityScore is 0 if unknown.
+	QualityScore int `json:"quality_score"`
+	// Best is true for the scene that would be kept from its duplicate group.
+	Best bool `json:"best"`
+}
+
+// HealthGroup is a group of near-duplicate scenes, ordered best first.
+type HealthGroup struct {
+	Scenes []*HealthScene `json:"scenes"`
+	// Total size of the files of all but the best scene
+	ReclaimableSize int64 `json:"reclaimable_size"`
+}
+
+// HealthReport is the result of a library health analysis.
+type HealthReport struct {
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+
+	// Phash distance and duration difference used to find duplicates
+	Distance     int     `json:"distance"`
+	DurationDiff float64 `json:"duration_diff"`
+
+	Thresholds HealthThresholds `json:"thresholds"`
+
+	Groups          []*HealthGroup `json:"groups"`
+	ReclaimableSize int64          `json:"reclaimable_size"`
+
+	// Scenes below the quality thresholds
+	LowQuality []*HealthScene `json:"low_quality"`
+}
+
+// NewHealthScene returns the quality summary of a scene. The primary file
+// of the scene must be loaded.
+func NewHealthScene(s *models.Scene) *HealthScene {
+	ret := &HealthScene{
+		SceneID: s.ID,
+		Title:   s.GetTitle(),
+		Path:    s.Path,
+	}
+
+	if f := s.Files.Primary(); f != nil {
+		ret.Width = f.Width
+		ret.Height = f.Height
+		ret.Bitrate = f.BitRate
+		ret.Size = f.Size
+		ret.Duration = f.DurationFinite()
+		ret.VideoCodec = f.VideoCodec
+		ret.QualityScore = f.QualityScore
+	}
+
+	return ret
+}
+
+// compareQuality returns a positive number if a is of better quality than b,
+// a negative number if b is better, or zero if they are equal. The quality
+// score is compared first if it is known for both scenes, then resolution,
+// then bitrate, then file size.
+func compareQuality(a, b *HealthScene) int {
+	cmp := func(x, y int64) int {
+		switch {
+		case x > y:
+			return 1
+		case x < y:
+			return -1
+		}
+		return 0
+	}
+
+	if a.QualityScore > 0 && b.QualityScore > 0 {
+		if c := cmp(int64(a.QualityScore), int64(b.QualityScore)); c != 0 {
+			return c
+		}
+	}
+	if c := cmp(int64(a.Width)*int64(a.Height), int64(b.Width)*int64(b.Height)); c != 0 {
+		return c
+	}
+	if c := cmp(a.Bitrate, b.Bitrate); c != 0 {
+		return c
+	}
+	return cmp(a.Size, b.Size)
+}
+
+// RankHealthScenes sorts the scenes by quality, best first, and marks the
+// best scene. Scenes of equal quality are ordered by ID, so that the oldest
+// scene is kept.
+func RankHealthScenes(scenes []*HealthScene) {
+	sort.Slice(scenes, func(i, j int) bool {
+		if c := compareQuality(scenes[i], scenes[j]); c != 0 {
+			return c > 0
+		}
+		return scenes[i].SceneID < scenes[j].SceneID
+	})
+
+	for i, s := range scenes {
+		s.Best = i == 0
+	}
+}
+
+// NewHealthGroup returns a ranked duplicate group from the given scenes.
+// The primary files of the scenes must be loaded.
+func NewHealthGroup(scenes []*models.Scene) *HealthGroup {
+	ret := &HealthGroup{}
+	for _, s := range scenes {
+		ret.Scenes = append(ret.Scenes, NewHealthScene(s))
+	}
+
+	RankHealthScenes(ret.Scenes)
+
+	for _, s := range ret.Scenes {
+		if !s.Best {
+			ret.ReclaimableSize += s.Size
+		}
+	}
+
+	return ret
+}
+
+// IsLowQuality returns true if the scene is below any of the thresholds.
+// The shorter side of the video is compared against MinHeight, so that
+// portrait videos are treated the same as landscape videos. Scenes without a
+// known resolution, bitrate or quality score are not considered low quality
+// by the respective check.
+func (t HealthThresholds) IsLowQuality(s *HealthScene) bool {
+	if t.MinHeight > 0 && s.Height > 0 && min(s.Width, s.Height) < t.MinHeight {
+		return true
+	}
+	if t.MinBitrate > 0 && s.Bitrate > 0 && s.Bitrate < t.MinBitrate {
+		return true
+	}
+	if t.MinQualityScore > 0 && s.QualityScore > 0 && s.QualityScore < t.MinQualityScore {
+		return true
+	}
+	return false
+}
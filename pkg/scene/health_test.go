@@ -0,0 +1,72 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankHealthScenes(t *testing.T) {
+	scenes := []*HealthScene{
+		{SceneID: 1, Width: 1280, Height: 720, Bitrate: 8000000, Size: 300},
+		{SceneID: 2, Width: 1920, Height: 1080, Bitrate: 4000000, Size: 200},
+		{SceneID: 3, Width: 1920, Height: 1080, Bitrate: 6000000, Size: 100},
+		{SceneID: 4, Width: 1920, Height: 1080, Bitrate: 6000000, Size: 150},
+		{SceneID: 5, Width: 1920, Height: 1080, Bitrate: 6000000, Size: 150},
+		{SceneID: 6},
+	}
+
+	RankHealthScenes(scenes)
+
+	var got []int
+	for _, s := range scenes {
+		got = append(got, s.SceneID)
+	}
+
+	// resolution, then bitrate, then size, then lowest id
+	assert.Equal(t, []int{4, 5, 3, 2, 1, 6}, got)
+	assert.True(t, scenes[0].Best)
+	for _, s := range scenes[1:] {
+		assert.False(t, s.Best)
+	}
+
+	scenes = []*HealthScene{
+		{SceneID: 1, Width: 1920, Height: 1080, Bitrate: 8000000, QualityScore: 60},
+		{SceneID: 2, Width: 1280, Height: 720, Bitrate: 4000000, QualityScore: 70},
+		{SceneID: 3, Width: 3840, Height: 2160, Bitrate: 4000000},
+	}
+
+	RankHealthScenes(scenes)
+
+	got = nil
+	for _, s := range scenes {
+		got = append(got, s.SceneID)
+	}
+
+	// quality score is only compared when known for both scenes
+	assert.Equal(t, []int{3, 2, 1}, got)
+}
+
+func TestHealthThresholds_IsLowQuality(t *testing.T) {
+	thresholds := HealthThresholds{MinHeight: 720, MinBitrate: 2000000, MinQualityScore: 40}
+
+	tests := []struct {
+		name  string
+		scene HealthScene
+		want  bool
+	}{
+		{"ok", HealthScene{Width: 1920, Height: 1080, Bitrate: 5000000}, false},
+		{"low resolution", HealthScene{Width: 640, Height: 480, Bitrate: 5000000}, true},
+		{"portrait", HealthScene{Width: 1080, Height: 1920, Bitrate: 5000000}, false},
+		{"low bitrate", HealthScene{Width: 1920, Height: 1080, Bitrate: 1000000}, true},
+		{"low quality score", HealthScene{Width: 1920, Height: 1080, Bitrate: 5000000, QualityScore: 30}, true},
+		{"unknown", HealthScene{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, thresholds.IsLowQuality(&tt.scene))
+		})
+	}
+
+	assert.False(t, HealthThresholds{}.IsLowQuality(&HealthScene{Width: 320, Height: 240, Bitrate: 1}))
+}
@@ -87,6 +87,12 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 		Code:         sceneJSON.Code,
 		Details:      sceneJSON.Details,
 		Director:     sceneJSON.Director,
+		Country:      sceneJSON.Country,
+		City:         sceneJSON.City,
+		Latitude:     sceneJSON.Latitude,
+		Longitude:    sceneJSON.Longitude,
+		StreamStart:  sceneJSON.StreamStart,
+		StreamEnd:    sceneJSON.StreamEnd,
 		PerformerIDs: models.NewRelatedIDs([]int{}),
 		TagIDs:       models.NewRelatedIDs([]int{}),
 		GalleryIDs:   models.NewRelatedIDs([]int{}),
@@ -111,6 +117,8 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 	}
 
 	newScene.Organized = sceneJSON.Organized
+	newScene.Pending = sceneJSON.Pending
+	newScene.Archived = sceneJSON.Archived
 	newScene.CreatedAt = sceneJSON.CreatedAt.GetTime()
 	newScene.UpdatedAt = sceneJSON.UpdatedAt.GetTime()
 	newScene.ResumeTime = sceneJSON.ResumeTime
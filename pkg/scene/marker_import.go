@@ -30,11 +30,25 @@ func (i *MarkerImporter) PreImport(ctx context.Context) error {
 	i.marker = models.SceneMarker{
 		Title:     i.Input.Title,
 		Seconds:   seconds,
+		Details:   i.Input.Details,
 		SceneID:   i.SceneID,
 		CreatedAt: i.Input.CreatedAt.GetTime(),
 		UpdatedAt: i.Input.UpdatedAt.GetTime(),
 	}
 
+	if i.Input.Rating != 0 {
+		rating := i.Input.Rating
+		i.marker.Rating = &rating
+	}
+
+	if i.Input.CoverSeconds != "" {
+		coverSeconds, err := strconv.ParseFloat(i.Input.CoverSeconds, 64)
+		if err != nil {
+			return fmt.Errorf("invalid cover_seconds %q: %w", i.Input.CoverSeconds, err)
+		}
+		i.marker.CoverSeconds = &coverSeconds
+	}
+
 	if err := i.populateTags(ctx); err != nil {
 		return err
 	}
@@ -11,13 +11,9 @@ import (
 )
 
 func MigrateHash(p *paths.Paths, oldHash string, newHash string) {
-	oldPath := filepath.Join(p.Generated.Markers, oldHash)
-	newPath := filepath.Join(p.Generated.Markers, newHash)
-	migrateSceneFiles(oldPath, newPath)
-
 	scenePaths := p.Scene
-	oldPath = scenePaths.GetVideoPreviewPath(oldHash)
-	newPath = scenePaths.GetVideoPreviewPath(newHash)
+	oldPath := scenePaths.GetVideoPreviewPath(oldHash)
+	newPath := scenePaths.GetVideoPreviewPath(newHash)
 	migrateSceneFiles(oldPath, newPath)
 
 	oldPath = scenePaths.GetWebpPreviewPath(oldHash)
@@ -37,15 +33,32 @@ func MigrateHash(p *paths.Paths, oldHash string, newHash string) {
 	migrateSceneFiles(oldPath, newPath)
 	migrateVttFile(newVttPath, oldPath, newPath)
 
+	oldPath = scenePaths.GetBarcodeFilePath(oldHash)
+	newPath = scenePaths.GetBarcodeFilePath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+
 	oldPath = scenePaths.GetInteractiveHeatmapPath(oldHash)
 	newPath = scenePaths.GetInteractiveHeatmapPath(newHash)
 	migrateSceneFiles(oldPath, newPath)
 
+	oldPath = scenePaths.GetHeatmapPath(oldHash)
+	newPath = scenePaths.GetHeatmapPath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+
+	oldPath = scenePaths.GetHeatmapDataPath(oldHash)
+	newPath = scenePaths.GetHeatmapDataPath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+
 	// #3986 - migrate scene marker files
 	markerPaths := p.SceneMarkers
 	oldPath = markerPaths.GetFolderPath(oldHash)
 	newPath = markerPaths.GetFolderPath(newHash)
 	migrateSceneFolder(oldPath, newPath)
+
+	// remove the old scene folder if it is now empty
+	if p.Generated.Layout == paths.LayoutScene {
+		_ = os.Remove(p.Generated.GetSceneFolderPath(oldHash))
+	}
 }
 
 func migrateSceneFiles(oldName, newName string) {
@@ -57,12 +70,23 @@ func migrateSceneFiles(oldName, newName string) {
 
 	if oldExists {
 		logger.Infof("renaming %s to %s", oldName, newName)
-		if err := os.Rename(oldName, newName); err != nil {
+		if err := renameGenerated(oldName, newName); err != nil {
 			logger.Errorf("error renaming %s to %s: %s", oldName, newName, err.Error())
 		}
 	}
 }
 
+// renameGenerated renames a generated file or folder, creating the parent
+// directory of newName, which may not exist in the sharded and scene
+// layouts.
+func renameGenerated(oldName, newName string) error {
+	if err := fsutil.EnsureDirAll(filepath.Dir(newName)); err != nil {
+		return err
+	}
+
+	return os.Rename(oldName, newName)
+}
+
 // #2481: migrate vtt file contents in addition to renaming
 func migrateVttFile(vttPath, oldSpritePath, newSpritePath string) {
 	// #3356 - don't try to migrate if the file doesn't exist
@@ -102,7 +126,7 @@ func migrateSceneFolder(oldName, newName string) {
 
 	if oldExists {
 		logger.Infof("renaming %s to %s", oldName, newName)
-		if err := os.Rename(oldName, newName); err != nil {
+		if err := renameGenerated(oldName, newName); err != nil {
 			logger.Errorf("error renaming %s to %s: %s", oldName, newName, err.Error())
 		}
 	}
@@ -0,0 +1,64 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+// MigrateLayout moves the generated files of the scene with the given hash
+// from their paths in from to their paths in to. Files that already exist
+// in to are not overwritten.
+func MigrateLayout(from *paths.Paths, to *paths.Paths, hash string) error {
+	type move struct {
+		src  string
+		dest string
+	}
+
+	moves := []move{
+		{from.Scene.GetVideoPreviewPath(hash), to.Scene.GetVideoPreviewPath(hash)},
+		{from.Scene.GetWebpPreviewPath(hash), to.Scene.GetWebpPreviewPath(hash)},
+		{from.Scene.GetTranscodePath(hash), to.Scene.GetTranscodePath(hash)},
+		{from.Scene.GetSpriteImageFilePath(hash), to.Scene.GetSpriteImageFilePath(hash)},
+		{from.Scene.GetSpriteVttFilePath(hash), to.Scene.GetSpriteVttFilePath(hash)},
+		{from.Scene.GetBarcodeFilePath(hash), to.Scene.GetBarcodeFilePath(hash)},
+		{from.Scene.GetInteractiveHeatmapPath(hash), to.Scene.GetInteractiveHeatmapPath(hash)},
+		{from.Scene.GetHeatmapPath(hash), to.Scene.GetHeatmapPath(hash)},
+		{from.Scene.GetHeatmapDataPath(hash), to.Scene.GetHeatmapDataPath(hash)},
+		{from.SceneMarkers.GetFolderPath(hash), to.SceneMarkers.GetFolderPath(hash)},
+	}
+
+	var errs []error
+	for _, m := range moves {
+		if m.src == m.dest {
+			continue
+		}
+
+		if _, err := os.Lstat(m.src); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if _, err := os.Lstat(m.dest); err == nil {
+			errs = append(errs, fmt.Errorf("%s already exists", m.dest))
+			continue
+		}
+
+		logger.Debugf("moving %s to %s", m.src, m.dest)
+		if err := renameGenerated(m.src, m.dest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// remove the old scene folder if it is now empty
+	if from.Generated.Layout == paths.LayoutScene {
+		_ = os.Remove(from.Generated.GetSceneFolderPath(hash))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateLayout(t *testing.T) {
+	const hash = "0123456789abcdef"
+
+	generated := t.TempDir()
+	from := paths.NewPaths(generated, "", paths.LayoutFlat)
+	to := paths.NewPaths(generated, "", paths.LayoutScene)
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(path), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	preview := from.Scene.GetVideoPreviewPath(hash)
+	marker := from.SceneMarkers.GetScreenshotPath(hash, 10)
+	write(preview)
+	write(marker)
+
+	// existing files are not overwritten
+	transcode := from.Scene.GetTranscodePath(hash)
+	write(transcode)
+	write(to.Scene.GetTranscodePath(hash))
+
+	err := MigrateLayout(&from, &to, hash)
+	assert.Error(t, err)
+
+	for _, tc := range []struct {
+		src  string
+		dest string
+	}{
+		{preview, to.Scene.GetVideoPreviewPath(hash)},
+		{marker, to.SceneMarkers.GetScreenshotPath(hash, 10)},
+	} {
+		contents, err := os.ReadFile(tc.dest)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.src, string(contents))
+		assert.NoFileExists(t, tc.src)
+	}
+
+	assert.FileExists(t, transcode)
+}
@@ -0,0 +1,113 @@
+package scene
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// PrimaryFileRuleLarger prefers the larger file.
+	PrimaryFileRuleLarger = "larger"
+	// PrimaryFileRuleShorterPath prefers the file with the shorter path.
+	PrimaryFileRuleShorterPath = "shorter_path"
+	// PrimaryFileRuleQuality prefers the file with the higher quality score.
+	PrimaryFileRuleQuality = "quality"
+	// primaryFileRulePathPrefix prefers files within a path.
+	// The rule is in the form path=<path>.
+	primaryFileRulePathPrefix = "path="
+)
+
+// PrimaryFileRule compares two files of a scene. It returns a negative value
+// if a is preferred, a positive value if b is preferred, and zero if the
+// rule has no preference.
+type PrimaryFileRule func(a, b *models.VideoFile) int
+
+// ParsePrimaryFileRules parses a list of primary file rules. Valid rules are
+// "larger", "shorter_path", "quality" and "path=<path>".
+func ParsePrimaryFileRules(rules []string) ([]PrimaryFileRule, error) {
+	var ret []PrimaryFileRule
+	for _, r := range rules {
+		rule, err := parsePrimaryFileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, rule)
+	}
+
+	return ret, nil
+}
+
+func parsePrimaryFileRule(r string) (PrimaryFileRule, error) {
+	switch {
+	case r == PrimaryFileRuleLarger:
+		return func(a, b *models.VideoFile) int {
+			return compareInt64(b.Size, a.Size)
+		}, nil
+	case r == PrimaryFileRuleShorterPath:
+		return func(a, b *models.VideoFile) int {
+			return compareInt64(int64(len(a.Path)), int64(len(b.Path)))
+		}, nil
+	case r == PrimaryFileRuleQuality:
+		return func(a, b *models.VideoFile) int {
+			return compareInt64(int64(b.QualityScore), int64(a.QualityScore))
+		}, nil
+	case strings.HasPrefix(r, primaryFileRulePathPrefix):
+		dir := strings.TrimPrefix(r, primaryFileRulePathPrefix)
+		if dir == "" {
+			return nil, fmt.Errorf("primary file rule %q: path is required", r)
+		}
+
+		return func(a, b *models.VideoFile) int {
+			aIn := fsutil.IsPathInDir(dir, a.Path)
+			bIn := fsutil.IsPathInDir(dir, b.Path)
+			switch {
+			case aIn && !bIn:
+				return -1
+			case bIn && !aIn:
+				return 1
+			default:
+				return 0
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid primary file rule %q: must be %s, %s, %s or %s<path>", r, PrimaryFileRuleLarger, PrimaryFileRuleShorterPath, PrimaryFileRuleQuality, primaryFileRulePathPrefix)
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ChoosePrimaryFile returns the preferred file according to the rules, which
+// are applied in order until one has a preference. The current primary file
+// is the first file, and is kept if no rule prefers another file.
+func ChoosePrimaryFile(files []*models.VideoFile, rules []PrimaryFileRule) *models.VideoFile {
+	if len(files) == 0 {
+		return nil
+	}
+
+	best := files[0]
+	for _, f := range files[1:] {
+		for _, rule := range rules {
+			c := rule(f, best)
+			if c < 0 {
+				best = f
+			}
+			if c != 0 {
+				break
+			}
+		}
+	}
+
+	return best
+}
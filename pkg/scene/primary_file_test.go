@@ -0,0 +1,65 @@
+package scene
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrimaryFileRules(t *testing.T) {
+	_, err := ParsePrimaryFileRules([]string{"larger", "shorter_path", "quality", "path=/media"})
+	assert.NoError(t, err)
+
+	_, err = ParsePrimaryFileRules([]string{"smaller"})
+	assert.Error(t, err)
+
+	_, err = ParsePrimaryFileRules([]string{"path="})
+	assert.Error(t, err)
+}
+
+func TestChoosePrimaryFile(t *testing.T) {
+	makeFile := func(id int, path string, size int64) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				ID:   models.FileID(id),
+				Path: filepath.FromSlash(path),
+				Size: size,
+			},
+		}
+	}
+
+	current := makeFile(1, "/inbox/long/path/scene.mp4", 100)
+	larger := makeFile(2, "/media/long/path/scene.mp4", 200)
+	shorter := makeFile(3, "/media/scene.mp4", 100)
+	shorter.QualityScore = 60
+	larger.QualityScore = 50
+	files := []*models.VideoFile{current, larger, shorter}
+
+	tests := []struct {
+		name  string
+		rules []string
+		want  *models.VideoFile
+	}{
+		{"no rules", nil, current},
+		{"larger", []string{"larger"}, larger},
+		{"shorter path", []string{"shorter_path"}, shorter},
+		{"quality", []string{"quality"}, shorter},
+		{"larger then shorter path", []string{"larger", "shorter_path"}, larger},
+		{"path then shorter path", []string{"path=/media", "shorter_path"}, shorter},
+		{"path", []string{"path=/inbox", "larger"}, current},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParsePrimaryFileRules(tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, ChoosePrimaryFile(files, rules))
+		})
+	}
+
+	assert.Nil(t, ChoosePrimaryFile(nil, nil))
+}
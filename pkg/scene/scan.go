@@ -25,6 +25,7 @@ var (
 type ScanCreatorUpdater interface {
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Scene, error)
 	FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]*models.Scene, error)
+	FindPending(ctx context.Context, fp []models.Fingerprint, urls []string) ([]*models.Scene, error)
 	GetFiles(ctx context.Context, relatedID int) ([]*models.VideoFile, error)
 
 	Create(ctx context.Context, newScene *models.Scene, fileIDs []models.FileID) error
@@ -45,6 +46,14 @@ type ScanHandler struct {
 
 	FileNamingAlgorithm models.HashAlgorithm
 	Paths               *paths.Paths
+
+	// PrimaryFileRules choose the primary file when a file is added to an
+	// existing scene. The primary file is not changed if empty.
+	PrimaryFileRules []PrimaryFileRule
+
+	// PendingURLs returns the URLs associated with the file at path, used
+	// to match the file to a pending scene. Optional.
+	PendingURLs func(path string) []string
 }
 
 func (h *ScanHandler) validate() error {
@@ -97,6 +106,14 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		}
 	}
 
+	if len(existing) == 0 {
+		// try to match a pending scene awaiting this file
+		existing, err = h.findPending(ctx, videoFile)
+		if err != nil {
+			return fmt.Errorf("finding pending scene: %w", err)
+		}
+	}
+
 	if len(existing) > 0 {
 		updateExisting := oldFile != nil
 		if err := h.associateExisting(ctx, existing, videoFile, updateExisting); err != nil {
@@ -140,6 +157,15 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 	return nil
 }
 
+func (h *ScanHandler) findPending(ctx context.Context, f *models.VideoFile) ([]*models.Scene, error) {
+	var urls []string
+	if h.PendingURLs != nil {
+		urls = h.PendingURLs(f.Path)
+	}
+
+	return h.CreatorUpdater.FindPending(ctx, f.Fingerprints.Filter(models.PendingFingerprintTypes...), urls)
+}
+
 func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.Scene, f *models.VideoFile, updateExisting bool) error {
 	for _, s := range existing {
 		if err := s.LoadFiles(ctx, h.CreatorUpdater); err != nil {
@@ -163,6 +189,23 @@ func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.
 
 			// update updated_at time
 			scenePartial := models.NewScenePartial()
+
+			// the first file is the current primary file
+			files := append([]*models.VideoFile{}, s.Files.List()...)
+			files = append(files, f)
+			if len(files) == 1 {
+				// scene had no files, so the new file is not yet primary
+				scenePartial.PrimaryFileID = &f.ID
+			} else if primary := ChoosePrimaryFile(files, h.PrimaryFileRules); primary != files[0] {
+				logger.Infof("Setting %s as primary file of scene %s", primary.Path, s.DisplayName())
+				scenePartial.PrimaryFileID = &primary.ID
+			}
+
+			if s.Pending {
+				logger.Infof("Pending scene %s is no longer pending", s.DisplayName())
+				scenePartial.Pending = models.NewOptionalBool(false)
+			}
+
 			if _, err := h.CreatorUpdater.UpdatePartial(ctx, s.ID, scenePartial); err != nil {
 				return fmt.Errorf("updating scene: %w", err)
 			}
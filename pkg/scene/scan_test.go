@@ -0,0 +1,84 @@
+package scene
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type nopScanGenerator struct{}
+
+func (nopScanGenerator) Generate(ctx context.Context, s *models.Scene, f *models.VideoFile) error {
+	return nil
+}
+
+// nopPluginConfig is a plugin config with no plugins.
+type nopPluginConfig struct {
+	plugin.ServerConfig
+}
+
+func (nopPluginConfig) GetDisabledPlugins() []string {
+	return nil
+}
+
+func TestScanHandler_Pending(t *testing.T) {
+	const (
+		sceneID = 1
+		fileID  = models.FileID(2)
+		oshash  = "0123456789abcdef"
+		path    = "/videos/scene.mp4"
+		url     = "https://example.com/scene"
+	)
+
+	f := &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			ID:   fileID,
+			Path: path,
+			Fingerprints: models.Fingerprints{
+				{Type: models.FingerprintTypeOshash, Fingerprint: oshash},
+				{Type: models.FingerprintTypePhash, Fingerprint: int64(1)},
+			},
+		},
+	}
+
+	db := mocks.NewDatabase()
+	db.Scene.On("FindByFileID", mock.Anything, fileID).Return(nil, nil)
+	db.Scene.On("FindByFingerprints", mock.Anything, mock.Anything).Return(nil, nil)
+	db.Scene.On("FindPending", mock.Anything, []models.Fingerprint{
+		{Type: models.FingerprintTypeOshash, Fingerprint: oshash},
+	}, []string{url}).Return([]*models.Scene{{ID: sceneID, Pending: true}}, nil)
+	db.Scene.On("GetFiles", mock.Anything, sceneID).Return([]*models.VideoFile{}, nil)
+	db.Scene.On("AddFileID", mock.Anything, sceneID, fileID).Return(nil)
+	db.Scene.On("UpdatePartial", mock.Anything, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		// the file becomes primary and the scene is no longer pending
+		return p.PrimaryFileID != nil && *p.PrimaryFileID == fileID &&
+			p.Pending.Set && !p.Pending.Value
+	})).Return(&models.Scene{ID: sceneID}, nil)
+
+	h := &ScanHandler{
+		CreatorUpdater:      db.Scene,
+		ScanGenerator:       nopScanGenerator{},
+		CaptionUpdater:      db.File,
+		PluginCache:         plugin.NewCache(nopPluginConfig{}),
+		FileNamingAlgorithm: models.HashAlgorithmOshash,
+		Paths:               &paths.Paths{},
+		PendingURLs: func(p string) []string {
+			assert.Equal(t, path, p)
+			return []string{url}
+		},
+	}
+
+	err := txn.WithTxn(context.Background(), db, func(ctx context.Context) error {
+		return h.Handle(ctx, f, nil)
+	})
+	assert.NoError(t, err)
+
+	db.AssertExpectations(t)
+}
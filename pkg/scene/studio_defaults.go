@@ -0,0 +1,145 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+// StudioDefaultsReader provides the methods needed to read the default
+// metadata of studios.
+type StudioDefaultsReader interface {
+	models.StudioGetter
+	models.StudioDefaultsLoader
+}
+
+// StudioDefaultsWriter provides the methods needed to apply the default
+// metadata of studios to a scene. Field sources are recorded if it also
+// implements models.FieldSourceWriter.
+type StudioDefaultsWriter interface {
+	models.SceneUpdater
+	models.TagIDLoader
+	models.URLLoader
+}
+
+// studioDefaultsChain returns the studio and the ancestors of the studio
+// whose defaults are propagated to it, starting with the studio.
+func studioDefaultsChain(ctx context.Context, r StudioDefaultsReader, studioID int) ([]*models.Studio, error) {
+	var ret []*models.Studio
+	visited := make(map[int]bool)
+
+	id := &studioID
+	for id != nil && !visited[*id] {
+		visited[*id] = true
+
+		s, err := r.Find(ctx, *id)
+		if err != nil {
+			return nil, fmt.Errorf("finding studio %d: %w", *id, err)
+		}
+		if s == nil {
+			break
+		}
+
+		if len(ret) == 0 || s.PropagateDefaults {
+			ret = append(ret, s)
+		}
+
+		id = s.ParentID
+	}
+
+	return ret, nil
+}
+
+// ApplyStudioDefaults adds the default tags and URLs of the scene's studio,
+// and of its ancestors that propagate their defaults, to the scene. Existing
+// values are kept. The studio is recorded as the source of the fields that
+// were changed. Returns true if the scene was changed.
+func ApplyStudioDefaults(ctx context.Context, w StudioDefaultsWriter, r StudioDefaultsReader, s *models.Scene) (bool, error) {
+	if s.StudioID == nil {
+		return false, nil
+	}
+
+	chain, err := studioDefaultsChain(ctx, r, *s.StudioID)
+	if err != nil {
+		return false, err
+	}
+
+	// load the defaults first, so that the scene is not read if there are none
+	var defaultTagIDs []int
+	var defaultURLs []string
+	for _, st := range chain {
+		if err := st.LoadDefaultTagIDs(ctx, r); err != nil {
+			return false, err
+		}
+		if err := st.LoadDefaultURLs(ctx, r); err != nil {
+			return false, err
+		}
+
+		defaultTagIDs = append(defaultTagIDs, st.DefaultTagIDs.List()...)
+		defaultURLs = append(defaultURLs, st.DefaultURLs.List()...)
+	}
+
+	if len(defaultTagIDs) == 0 && len(defaultURLs) == 0 {
+		return false, nil
+	}
+
+	if err := s.LoadTagIDs(ctx, w); err != nil {
+		return false, err
+	}
+	if err := s.LoadURLs(ctx, w); err != nil {
+		return false, err
+	}
+
+	var tagIDs []int
+	for _, id := range defaultTagIDs {
+		if !slices.Contains(s.TagIDs.List(), id) && !slices.Contains(tagIDs, id) {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	var urls []string
+	for _, p := range defaultURLs {
+		u := studio.ExpandDefaultURL(p, s)
+		if u != "" && !slices.Contains(s.URLs.List(), u) && !slices.Contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+
+	partial := models.NewScenePartial()
+	var fields []string
+	if len(tagIDs) > 0 {
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		fields = append(fields, "tags")
+	}
+	if len(urls) > 0 {
+		partial.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+		fields = append(fields, "url")
+	}
+
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	if _, err := w.UpdatePartial(ctx, s.ID, partial); err != nil {
+		return false, fmt.Errorf("applying studio defaults: %w", err)
+	}
+
+	if fw, ok := w.(models.FieldSourceWriter); ok {
+		source := strconv.Itoa(*s.StudioID)
+		if err := fw.SetFieldSources(ctx, s.ID, models.NewFieldSources(fields, models.FieldSourceTypeStudioDefaults, source)); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplyStudioDefaults(t *testing.T) {
+	const (
+		sceneID       = 1
+		studioID      = 2
+		parentID      = 3
+		grandparentID = 4
+
+		existingTagID = 10
+		studioTagID   = 11
+		parentTagID   = 12
+	)
+
+	db := mocks.NewDatabase()
+
+	// the grandparent does not propagate its defaults, and its parent is
+	// the parent, which must not loop
+	parent := parentID
+	grandparent := grandparentID
+	db.Studio.On("Find", testCtx, studioID).Return(&models.Studio{ID: studioID, ParentID: &parent}, nil)
+	db.Studio.On("Find", testCtx, parentID).Return(&models.Studio{ID: parentID, PropagateDefaults: true, ParentID: &grandparent}, nil)
+	db.Studio.On("Find", testCtx, grandparentID).Return(&models.Studio{ID: grandparentID, ParentID: &parent}, nil)
+
+	db.Studio.On("GetDefaultTagIDs", testCtx, studioID).Return([]int{existingTagID, studioTagID}, nil)
+	db.Studio.On("GetDefaultURLs", testCtx, studioID).Return([]string{"https://example.com/{code}", "https://example.com/{date}"}, nil)
+	db.Studio.On("GetDefaultTagIDs", testCtx, parentID).Return([]int{parentTagID, studioTagID}, nil)
+	db.Studio.On("GetDefaultURLs", testCtx, parentID).Return([]string{"https://example.com/{code}"}, nil)
+
+	db.Scene.On("GetTagIDs", testCtx, sceneID).Return([]int{existingTagID}, nil)
+	db.Scene.On("GetURLs", testCtx, sceneID).Return(nil, nil)
+
+	db.Scene.On("UpdatePartial", testCtx, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.TagIDs != nil && assert.Equal(t, []int{studioTagID, parentTagID}, p.TagIDs.IDs) &&
+			p.TagIDs.Mode == models.RelationshipUpdateModeAdd &&
+			p.URLs != nil && assert.Equal(t, []string{"https://example.com/ABC"}, p.URLs.Values)
+	})).Return(nil, nil).Once()
+
+	db.Scene.On("SetFieldSources", testCtx, sceneID, mock.MatchedBy(func(sources []*models.FieldSource) bool {
+		return len(sources) == 2 &&
+			sources[0].Field == "tags" && sources[1].Field == "url" &&
+			sources[0].Type == models.FieldSourceTypeStudioDefaults && sources[0].Source == "2"
+	})).Return(nil).Once()
+
+	studio := studioID
+	s := &models.Scene{ID: sceneID, Code: "ABC", StudioID: &studio}
+	changed, err := ApplyStudioDefaults(testCtx, db.Scene, db.Studio, s)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	db.Studio.AssertNotCalled(t, "GetDefaultTagIDs", testCtx, grandparentID)
+	db.AssertExpectations(t)
+}
+
+func TestApplyStudioDefaultsNoStudio(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	changed, err := ApplyStudioDefaults(testCtx, db.Scene, db.Studio, &models.Scene{ID: 1})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	db.AssertExpectations(t)
+}
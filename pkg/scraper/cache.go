@@ -39,7 +39,10 @@ type GlobalConfig interface {
 	GetScraperCDPPath() string
 	GetScraperCertCheck() bool
 	GetPythonPath() string
+	GetScraperRuntimesPath() string
 	GetProxy() string
+	GetScraperProxies() []*Proxy
+	GetScraperProxyHealthCheckURL() string
 }
 
 func isCDPPathHTTP(c GlobalConfig) bool {
@@ -54,6 +57,7 @@ type SceneFinder interface {
 	models.SceneGetter
 	models.URLLoader
 	models.VideoFileLoader
+	models.SceneTranscriptReader
 }
 
 type PerformerFinder interface {
@@ -86,6 +90,8 @@ type Repository struct {
 	PerformerFinder PerformerFinder
 	GroupFinder     match.GroupNamesFinder
 	StudioFinder    StudioFinder
+
+	TagCategoryFinder models.TagCategoryFinder
 }
 
 func NewRepository(repo models.Repository) Repository {
@@ -97,6 +103,8 @@ func NewRepository(repo models.Repository) Repository {
 		PerformerFinder: repo.Performer,
 		GroupFinder:     repo.Group,
 		StudioFinder:    repo.Studio,
+
+		TagCategoryFinder: repo.TagCategory,
 	}
 }
 
@@ -110,18 +118,29 @@ type Cache struct {
 	scrapers     map[string]scraper // Scraper ID -> Scraper
 	globalConfig GlobalConfig
 
+	// providers registered with RegisterProvider. These are kept when the
+	// scrapers are reloaded.
+	providers []Provider
+
 	repository Repository
 }
 
 // newClient creates a scraper-local http client we use throughout the scraper subsystem.
+// Requests are routed through the configured scraper proxies, if any.
 func newClient(gc GlobalConfig) *http.Client {
+	var transport http.RoundTripper = &http.Transport{ // ignore insecure certificates
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: !gc.GetScraperCertCheck()},
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		Proxy:               http.ProxyFromEnvironment,
+	}
+
+	if proxies := gc.GetScraperProxies(); len(proxies) > 0 {
+		transport = newProxyTransport(proxies, gc.GetScraperProxyHealthCheckURL(), gc.GetScraperCertCheck())
+	}
+
 	client := &http.Client{
-		Transport: &http.Transport{ // ignore insecure certificates
-			TLSClientConfig:     &tls.Config{InsecureSkipVerify: !gc.GetScraperCertCheck()},
-			MaxIdleConnsPerHost: maxIdleConnsPerHost,
-			Proxy:               http.ProxyFromEnvironment,
-		},
-		Timeout: scrapeGetTimeout,
+		Transport: transport,
+		Timeout:   scrapeGetTimeout,
 		// defaultCheckRedirect code with max changed from 10 to maxRedirects
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= maxRedirects {
@@ -137,7 +156,8 @@ func newClient(gc GlobalConfig) *http.Client {
 // NewCache returns a new Cache.
 //
 // Scraper configurations are loaded from yml files in the scrapers
-// directory in the config and any subdirectories.
+// directory in the config and any subdirectories. CSV files in the same
+// directories are loaded as metadata providers.
 //
 // Does not load scrapers. Scrapers will need to be
 // loaded explicitly using ReloadScrapers.
@@ -153,21 +173,28 @@ func NewCache(globalConfig GlobalConfig, repo Repository) *Cache {
 }
 
 // ReloadScrapers clears the scraper cache and reloads from the scraper path.
+// The http client is recreated from the current scraper settings.
 // If a scraper cannot be loaded, an error is logged and the scraper is skipped.
 func (c *Cache) ReloadScrapers() {
 	path := c.globalConfig.GetScrapersPath()
 	scrapers := make(map[string]scraper)
 
+	// recreate the client to apply changes to the scraper proxies
+	c.client = newClient(c.globalConfig)
+
 	// Add built-in scrapers
 	freeOnes := getFreeonesScraper(c.globalConfig)
 	autoTag := getAutoTagScraper(c.repository, c.globalConfig)
+	transcript := getTranscriptScraper(c.repository)
 	scrapers[freeOnes.spec().ID] = freeOnes
 	scrapers[autoTag.spec().ID] = autoTag
+	scrapers[transcript.spec().ID] = transcript
 
 	logger.Debugf("Reading scraper configs from %s", path)
 
 	err := fsutil.SymWalk(path, func(fp string, f os.FileInfo, err error) error {
-		if filepath.Ext(fp) == ".yml" {
+		switch filepath.Ext(fp) {
+		case ".yml":
 			conf, err := loadConfigFromYAMLFile(fp)
 			if err != nil {
 				logger.Errorf("Error loading scraper %s: %v", fp, err)
@@ -175,6 +202,13 @@ func (c *Cache) ReloadScrapers() {
 				scraper := newGroupScraper(*conf, c.globalConfig)
 				scrapers[scraper.spec().ID] = scraper
 			}
+		case ".csv":
+			p, err := loadCSVProvider(fp)
+			if err != nil {
+				logger.Errorf("Error loading metadata provider %s: %v", fp, err)
+			} else {
+				scrapers[p.ID()] = providerScraper{provider: p.provider()}
+			}
 		}
 		return nil
 	})
@@ -183,6 +217,26 @@ func (c *Cache) ReloadScrapers() {
 		logger.Errorf("Error reading scraper configs: %v", err)
 	}
 
+	for _, p := range c.providers {
+		scrapers[p.ID()] = providerScraper{provider: p}
+	}
+
+	c.scrapers = scrapers
+}
+
+// RegisterProvider adds a metadata provider, which is then available in the
+// same way as a scraper with the provider ID as the scraper ID. The provider
+// replaces any scraper with the same ID, and is kept when the scrapers are
+// reloaded.
+func (c *Cache) RegisterProvider(p Provider) {
+	c.providers = append(c.providers, p)
+
+	scrapers := make(map[string]scraper, len(c.scrapers)+1)
+	for id, s := range c.scrapers {
+		scrapers[id] = s
+	}
+	scrapers[p.ID()] = providerScraper{provider: p}
+
 	c.scrapers = scrapers
 }
 
@@ -242,6 +296,7 @@ func (c Cache) ScrapeName(ctx context.Context, id, query string, ty ScrapeConten
 		return nil, fmt.Errorf("%w: cannot use scraper %s to scrape by name", ErrNotSupported, id)
 	}
 
+	ctx = withScraperID(ctx, id)
 	content, err := ns.viaName(ctx, c.client, query, ty)
 	if err != nil {
 		return nil, fmt.Errorf("error while name scraping with scraper %s: %w", id, err)
@@ -272,6 +327,7 @@ func (c Cache) ScrapeFragment(ctx context.Context, id string, input Input) (Scra
 		return nil, fmt.Errorf("%w: cannot use scraper %s as a fragment scraper", ErrNotSupported, id)
 	}
 
+	ctx = withScraperID(ctx, id)
 	content, err := fs.viaFragment(ctx, c.client, input)
 	if err != nil {
 		return nil, fmt.Errorf("error while fragment scraping with scraper %s: %w", id, err)
@@ -290,6 +346,7 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 			if !ok {
 				return nil, fmt.Errorf("%w: cannot use scraper %s as an url scraper", ErrNotSupported, s.spec().ID)
 			}
+			ctx = withScraperID(ctx, s.spec().ID)
 			ret, err := ul.viaURL(ctx, c.client, url, ty)
 			if err != nil {
 				return nil, err
@@ -306,6 +363,36 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 	return nil, nil
 }
 
+// ScrapeURLWithScraper scrapes the URL using the scraper with the given ID.
+// Returns an error if the scraper does not support the URL.
+func (c Cache) ScrapeURLWithScraper(ctx context.Context, scraperID string, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	s := c.findScraper(scraperID)
+	if s == nil {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, scraperID)
+	}
+
+	if !s.supportsURL(url, ty) {
+		return nil, fmt.Errorf("%w: scraper %s does not support url %s", ErrNotSupported, scraperID, url)
+	}
+
+	ul, ok := s.(urlScraper)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot use scraper %s as an url scraper", ErrNotSupported, scraperID)
+	}
+
+	ctx = withScraperID(ctx, scraperID)
+	ret, err := ul.viaURL(ctx, c.client, url, ty)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return ret, nil
+	}
+
+	return c.postScrape(ctx, ret)
+}
+
 func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty ScrapeContentType) (ScrapedContent, error) {
 	s := c.findScraper(scraperID)
 	if s == nil {
@@ -316,6 +403,8 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 		return nil, fmt.Errorf("%w: cannot use scraper %s to scrape %v content", ErrNotSupported, scraperID, ty)
 	}
 
+	ctx = withScraperID(ctx, scraperID)
+
 	var ret ScrapedContent
 	switch ty {
 	case ScrapeContentTypeScene:
@@ -63,6 +63,9 @@ type config struct {
 
 	// Scraping driver options
 	DriverOptions *scraperDriverOptions `yaml:"driver"`
+
+	// Managed runtime environments for script scrapers
+	Runtime *runtimeConfig `yaml:"runtime"`
 }
 
 func (c config) validate() error {
@@ -110,6 +113,12 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.Runtime != nil {
+		if err := c.Runtime.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
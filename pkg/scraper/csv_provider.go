@@ -0,0 +1,328 @@
+package scraper
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// csvListSeparator separates the values of multi-valued CSV columns.
+const csvListSeparator = "|"
+
+// csvProvider provides scenes or performers from a CSV file in the scrapers
+// directory. The first row of the file is the header, which names the
+// columns. Files with a title column provide scenes, and files with a name
+// column provide performers. Unknown columns are ignored.
+//
+// Scene columns are id, title, code, date, details, director, studio,
+// performers, tags, urls, duration, oshash, md5 and phash. Performer columns
+// are id, name, disambiguation, aliases, gender, birthdate, country,
+// ethnicity, details, tags and urls.
+type csvProvider struct {
+	id   string
+	name string
+
+	contentType ScrapeContentType
+	rows        []csvRow
+}
+
+// csvRow is a row of a CSV file, keyed by lower case column name.
+type csvRow map[string]string
+
+func (r csvRow) list(column string) []string {
+	var ret []string
+	for _, v := range strings.Split(r[column], csvListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func (r csvRow) ptr(column string) *string {
+	v := r[column]
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func (r csvRow) tags() []*models.ScrapedTag {
+	var ret []*models.ScrapedTag
+	for _, t := range r.list("tags") {
+		ret = append(ret, &models.ScrapedTag{Name: t})
+	}
+	return ret
+}
+
+// loadCSVProvider loads the provider from a CSV file. The ID of the provider
+// is the file name without its extension.
+func loadCSVProvider(path string) (*csvProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return readCSVProvider(id, f)
+}
+
+func readCSVProvider(id string, r io.Reader) (*csvProvider, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	// allow rows to omit trailing empty columns
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var rows []csvRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(csvRow)
+		for i, v := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(v)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	ret := &csvProvider{
+		id:   id,
+		name: id,
+		rows: rows,
+	}
+
+	switch {
+	case slices.Contains(header, "title"):
+		ret.contentType = ScrapeContentTypeScene
+	case slices.Contains(header, "name"):
+		ret.contentType = ScrapeContentTypePerformer
+	default:
+		return nil, errors.New("header must have a title column for scenes or a name column for performers")
+	}
+
+	return ret, nil
+}
+
+func (p *csvProvider) ID() string {
+	return p.id
+}
+
+func (p *csvProvider) Name() string {
+	return p.name
+}
+
+// csvMatch is a row matching a query.
+type csvMatch struct {
+	row   csvRow
+	score int
+}
+
+// scores of csv matches. Rows matching an ID, fingerprint or URL score
+// higher than rows matching a title or name.
+const (
+	csvMatchPartialName = iota + 1
+	csvMatchName
+	csvMatchExact
+)
+
+func rankCSVMatches(matches []csvMatch) []csvRow {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	ret := make([]csvRow, len(matches))
+	for i, m := range matches {
+		ret[i] = m.row
+	}
+	return ret
+}
+
+// matchName returns the score of a title or name match. Exact matches
+// score higher than partial matches.
+func matchName(query, value string) int {
+	if query == "" || value == "" {
+		return 0
+	}
+
+	query = strings.ToLower(query)
+	value = strings.ToLower(value)
+	switch {
+	case query == value:
+		return csvMatchName
+	case strings.Contains(value, query):
+		return csvMatchPartialName
+	}
+
+	return 0
+}
+
+func matchURLs(urls []string, row csvRow) bool {
+	rowURLs := row.list("urls")
+	for _, u := range urls {
+		if slices.Contains(rowURLs, u) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *csvProvider) matchScene(q SceneQuery, r csvRow) int {
+	if q.ExternalID != "" && q.ExternalID == r["id"] {
+		return csvMatchExact
+	}
+
+	for _, fp := range q.Fingerprints {
+		if v := r[fp.Type]; v != "" && strings.EqualFold(v, fp.Value()) {
+			return csvMatchExact
+		}
+	}
+
+	if matchURLs(q.URLs, r) {
+		return csvMatchExact
+	}
+
+	return matchName(q.Title, r["title"])
+}
+
+func (p *csvProvider) queryScenes(q SceneQuery) []*ScrapedScene {
+	var matches []csvMatch
+	for _, row := range p.rows {
+		if score := p.matchScene(q, row); score > 0 {
+			matches = append(matches, csvMatch{row: row, score: score})
+		}
+	}
+
+	var ret []*ScrapedScene
+	for _, row := range rankCSVMatches(matches) {
+		ret = append(ret, row.scene())
+	}
+
+	return ret
+}
+
+func (r csvRow) scene() *ScrapedScene {
+	ret := &ScrapedScene{
+		Title:        r.ptr("title"),
+		Code:         r.ptr("code"),
+		Date:         r.ptr("date"),
+		Details:      r.ptr("details"),
+		Director:     r.ptr("director"),
+		URLs:         r.list("urls"),
+		RemoteSiteID: r.ptr("id"),
+		Tags:         r.tags(),
+	}
+
+	if studio := r["studio"]; studio != "" {
+		ret.Studio = &models.ScrapedStudio{Name: studio}
+	}
+
+	for _, name := range r.list("performers") {
+		ret.Performers = append(ret.Performers, &models.ScrapedPerformer{Name: &name})
+	}
+
+	if d, err := strconv.Atoi(r["duration"]); err == nil && d > 0 {
+		ret.Duration = &d
+	}
+
+	return ret
+}
+
+func (p *csvProvider) queryPerformers(q PerformerQuery) []*models.ScrapedPerformer {
+	var matches []csvMatch
+	for _, row := range p.rows {
+		score := 0
+		switch {
+		case q.ExternalID != "" && q.ExternalID == row["id"], matchURLs(q.URLs, row):
+			score = csvMatchExact
+		default:
+			score = matchName(q.Name, row["name"])
+			for _, alias := range row.list("aliases") {
+				score = max(score, matchName(q.Name, alias))
+			}
+		}
+
+		if score > 0 {
+			matches = append(matches, csvMatch{row: row, score: score})
+		}
+	}
+
+	var ret []*models.ScrapedPerformer
+	for _, row := range rankCSVMatches(matches) {
+		ret = append(ret, row.performer())
+	}
+
+	return ret
+}
+
+func (r csvRow) performer() *models.ScrapedPerformer {
+	ret := &models.ScrapedPerformer{
+		Name:           r.ptr("name"),
+		Disambiguation: r.ptr("disambiguation"),
+		Gender:         r.ptr("gender"),
+		Birthdate:      r.ptr("birthdate"),
+		Country:        r.ptr("country"),
+		Ethnicity:      r.ptr("ethnicity"),
+		Details:        r.ptr("details"),
+		URLs:           r.list("urls"),
+		RemoteSiteID:   r.ptr("id"),
+		Tags:           r.tags(),
+	}
+
+	if aliases := r.list("aliases"); len(aliases) > 0 {
+		v := strings.Join(aliases, ", ")
+		ret.Aliases = &v
+	}
+
+	return ret
+}
+
+// csvSceneProvider and csvPerformerProvider only implement the provider
+// interface of the content type of the file, so that performer files are not
+// listed as scene scrapers and vice versa.
+type csvSceneProvider struct{ *csvProvider }
+
+type csvPerformerProvider struct{ *csvProvider }
+
+// provider returns the csv provider as a Provider of the content type of the
+// file.
+func (p *csvProvider) provider() Provider {
+	if p.contentType == ScrapeContentTypeScene {
+		return csvSceneProvider{p}
+	}
+	return csvPerformerProvider{p}
+}
+
+func (p csvSceneProvider) QueryScenes(_ context.Context, _ *http.Client, q SceneQuery) ([]*ScrapedScene, error) {
+	return p.queryScenes(q), nil
+}
+
+func (p csvPerformerProvider) QueryPerformers(_ context.Context, _ *http.Client, q PerformerQuery) ([]*models.ScrapedPerformer, error) {
+	return p.queryPerformers(q), nil
+}
@@ -0,0 +1,136 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const sceneCSV = `ID,Title,Date,Studio,Performers,Tags,URLs,Duration,oshash
+1,First Scene,2021-03-14,Studio A,Alice|Bob,tag1 | tag2,https://example.com/1,600,abc123
+2,Second Scene,2022-01-01,Studio B,Carol,,https://example.com/2
+3,The First Scene Again
+`
+
+const performerCSV = `id,name,aliases,gender,urls
+p1,Alice,Ally|Al,FEMALE,https://example.com/alice
+p2,Alicia,,FEMALE
+`
+
+func TestReadCSVProvider(t *testing.T) {
+	p, err := readCSVProvider("scenes", strings.NewReader(sceneCSV))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, ScrapeContentTypeScene, p.contentType)
+	assert.Len(t, p.rows, 3)
+
+	p, err = readCSVProvider("performers", strings.NewReader(performerCSV))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, ScrapeContentTypePerformer, p.contentType)
+
+	_, err = readCSVProvider("invalid", strings.NewReader("id,other\n1,2\n"))
+	assert.Error(t, err)
+
+	_, err = readCSVProvider("empty", strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestCSVProvider_QueryScenes(t *testing.T) {
+	p, err := readCSVProvider("scenes", strings.NewReader(sceneCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	titles := func(scenes []*ScrapedScene) []string {
+		var ret []string
+		for _, s := range scenes {
+			ret = append(ret, *s.Title)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name  string
+		query SceneQuery
+		want  []string
+	}{
+		{"exact title first", SceneQuery{Title: "first scene"}, []string{"First Scene", "The First Scene Again"}},
+		{"partial title", SceneQuery{Title: "again"}, []string{"The First Scene Again"}},
+		{"id", SceneQuery{ExternalID: "2"}, []string{"Second Scene"}},
+		{"url", SceneQuery{URLs: []string{"https://example.com/2"}}, []string{"Second Scene"}},
+		{
+			"fingerprint before title",
+			SceneQuery{
+				Title:        "scene",
+				Fingerprints: models.Fingerprints{{Type: models.FingerprintTypeOshash, Fingerprint: "ABC123"}},
+			},
+			[]string{"First Scene", "Second Scene", "The First Scene Again"},
+		},
+		{"no match", SceneQuery{Title: "missing"}, nil},
+		{"empty query", SceneQuery{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, titles(p.queryScenes(tt.query)))
+		})
+	}
+
+	got := p.queryScenes(SceneQuery{ExternalID: "1"})
+	if !assert.Len(t, got, 1) {
+		return
+	}
+
+	s := got[0]
+	assert.Equal(t, "2021-03-14", *s.Date)
+	assert.Equal(t, "Studio A", s.Studio.Name)
+	assert.Len(t, s.Performers, 2)
+	assert.Equal(t, "Bob", *s.Performers[1].Name)
+	assert.Equal(t, []string{"tag1", "tag2"}, []string{s.Tags[0].Name, s.Tags[1].Name})
+	assert.Equal(t, []string{"https://example.com/1"}, s.URLs)
+	assert.Equal(t, 600, *s.Duration)
+	assert.Equal(t, "1", *s.RemoteSiteID)
+}
+
+func TestCSVProvider_QueryPerformers(t *testing.T) {
+	p, err := readCSVProvider("performers", strings.NewReader(performerCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(q PerformerQuery) []string {
+		var ret []string
+		for _, p := range p.queryPerformers(q) {
+			ret = append(ret, *p.Name)
+		}
+		return ret
+	}
+
+	assert.Equal(t, []string{"Alice", "Alicia"}, names(PerformerQuery{Name: "ali"}))
+	assert.Equal(t, []string{"Alice"}, names(PerformerQuery{Name: "ally"}))
+	assert.Equal(t, []string{"Alicia"}, names(PerformerQuery{ExternalID: "p2"}))
+	assert.Equal(t, []string{"Alice"}, names(PerformerQuery{URLs: []string{"https://example.com/alice"}}))
+
+	got := p.queryPerformers(PerformerQuery{Name: "alice"})
+	assert.Equal(t, "Ally, Al", *got[0].Aliases)
+	assert.Equal(t, "FEMALE", *got[0].Gender)
+}
+
+func TestCSVProvider_provider(t *testing.T) {
+	scenes, _ := readCSVProvider("scenes", strings.NewReader(sceneCSV))
+	performers, _ := readCSVProvider("performers", strings.NewReader(performerCSV))
+
+	s := providerScraper{provider: scenes.provider()}
+	assert.True(t, s.supports(ScrapeContentTypeScene))
+	assert.False(t, s.supports(ScrapeContentTypePerformer))
+
+	s = providerScraper{provider: performers.provider()}
+	assert.False(t, s.supports(ScrapeContentTypeScene))
+	assert.True(t, s.supports(ScrapeContentTypePerformer))
+}
@@ -0,0 +1,254 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDebugBodySize is the maximum number of bytes of a response body that is
+// captured in a DebugTrace.
+const maxDebugBodySize = 1024 * 1024
+
+const redactedHeaderValue = "[redacted]"
+
+// redactedHeaders are headers whose values are not captured, since they may
+// contain credentials.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+var ErrInvalidDebugInput = errors.New("exactly one of url, query or fragment must be set")
+
+// DebugHeader is a HTTP header captured in a DebugTrace.
+type DebugHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DebugRequest is a HTTP request made by a scraper.
+type DebugRequest struct {
+	URL            string         `json:"url"`
+	Method         string         `json:"method"`
+	RequestHeaders []*DebugHeader `json:"request_headers"`
+	// StatusCode is nil if no response was received.
+	StatusCode      *int           `json:"status_code"`
+	ResponseHeaders []*DebugHeader `json:"response_headers"`
+	// Body is the response body, up to maxDebugBodySize bytes.
+	Body          string `json:"body"`
+	BodyTruncated bool   `json:"body_truncated"`
+	// Duration of the request in milliseconds.
+	Duration int     `json:"duration"`
+	Error    *string `json:"error"`
+}
+
+// DebugQuery is the evaluation of a selector of a mapped scraper.
+type DebugQuery struct {
+	// Key is the attribute of the scraped object being populated.
+	Key      string `json:"key"`
+	Selector string `json:"selector"`
+	// Results are the values returned by the selector.
+	Results []string `json:"results"`
+	// Processed are the values after post-processing.
+	Processed []string `json:"processed"`
+	Error     *string  `json:"error"`
+}
+
+// DebugTrace captures the requests made and selectors evaluated while
+// scraping.
+type DebugTrace struct {
+	mutex    sync.Mutex
+	Requests []*DebugRequest `json:"requests"`
+	Queries  []*DebugQuery   `json:"queries"`
+}
+
+type debugTraceKey struct{}
+
+// WithDebugTrace returns a context which captures scraper activity in the
+// returned DebugTrace.
+func WithDebugTrace(ctx context.Context) (context.Context, *DebugTrace) {
+	t := &DebugTrace{}
+	return context.WithValue(ctx, debugTraceKey{}, t), t
+}
+
+// debugTraceFromContext returns the DebugTrace of the context, or nil if
+// scraper activity is not being captured.
+func debugTraceFromContext(ctx context.Context) *DebugTrace {
+	t, _ := ctx.Value(debugTraceKey{}).(*DebugTrace)
+	return t
+}
+
+func (t *DebugTrace) addRequest(r *DebugRequest) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Requests = append(t.Requests, r)
+}
+
+func (t *DebugTrace) addQuery(q *DebugQuery) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.Queries = append(t.Queries, q)
+}
+
+func debugHeaders(h http.Header) []*DebugHeader {
+	var ret []*DebugHeader
+	for name, values := range h {
+		redact := false
+		for _, r := range redactedHeaders {
+			if strings.EqualFold(name, r) {
+				redact = true
+				break
+			}
+		}
+
+		for _, v := range values {
+			if redact {
+				v = redactedHeaderValue
+			}
+			ret = append(ret, &DebugHeader{Name: name, Value: v})
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
+
+func newDebugRequest(req *http.Request, resp *http.Response, body []byte, start time.Time, err error) *DebugRequest {
+	ret := &DebugRequest{
+		URL:            req.URL.String(),
+		Method:         req.Method,
+		RequestHeaders: debugHeaders(req.Header),
+		Duration:       int(time.Since(start).Milliseconds()),
+	}
+
+	if resp != nil {
+		ret.StatusCode = &resp.StatusCode
+		ret.ResponseHeaders = debugHeaders(resp.Header)
+	}
+
+	ret.setBody(body)
+
+	if err != nil {
+		errStr := err.Error()
+		ret.Error = &errStr
+	}
+
+	return ret
+}
+
+func newDebugQuery(key string, selector string, found []string, processed []string, err error) *DebugQuery {
+	ret := &DebugQuery{
+		Key:       key,
+		Selector:  selector,
+		Results:   found,
+		Processed: processed,
+	}
+
+	if err != nil {
+		errStr := err.Error()
+		ret.Error = &errStr
+	}
+
+	return ret
+}
+
+func (r *DebugRequest) setBody(body []byte) {
+	if len(body) > maxDebugBodySize {
+		body = body[:maxDebugBodySize]
+		r.BodyTruncated = true
+	}
+
+	r.Body = strings.ToValidUTF8(string(body), "�")
+}
+
+// DebugInput is the input of a debug scrape. Exactly one of URL, Query or
+// Fragment must be set.
+type DebugInput struct {
+	ScraperID string
+	Type      ScrapeContentType
+	URL       *string
+	Query     *string
+	Fragment  *Input
+}
+
+// DebugResult is the result of a debug scrape.
+type DebugResult struct {
+	Content  []ScrapedContent `json:"content"`
+	Error    *string          `json:"error"`
+	Requests []*DebugRequest  `json:"requests"`
+	Queries  []*DebugQuery    `json:"queries"`
+}
+
+// Debug scrapes using the given scraper, capturing the requests it makes and
+// the selectors it evaluates. Scraping errors are returned in the result,
+// along with the activity captured before the error occurred.
+func (c Cache) Debug(ctx context.Context, input DebugInput) (*DebugResult, error) {
+	set := 0
+	if input.URL != nil {
+		set++
+	}
+	if input.Query != nil {
+		set++
+	}
+	if input.Fragment != nil {
+		set++
+	}
+	if set != 1 {
+		return nil, ErrInvalidDebugInput
+	}
+
+	ctx, trace := WithDebugTrace(ctx)
+
+	var (
+		content []ScrapedContent
+		err     error
+	)
+
+	switch {
+	case input.URL != nil:
+		var c1 ScrapedContent
+		c1, err = c.ScrapeURLWithScraper(ctx, input.ScraperID, *input.URL, input.Type)
+		if c1 != nil {
+			content = []ScrapedContent{c1}
+		}
+	case input.Query != nil:
+		content, err = c.ScrapeName(ctx, input.ScraperID, *input.Query, input.Type)
+	default:
+		var c1 ScrapedContent
+		c1, err = c.ScrapeFragment(ctx, input.ScraperID, *input.Fragment)
+		if c1 != nil {
+			content = []ScrapedContent{c1}
+		}
+	}
+
+	trace.mutex.Lock()
+	defer trace.mutex.Unlock()
+
+	ret := &DebugResult{
+		Content:  content,
+		Requests: trace.Requests,
+		Queries:  trace.Queries,
+	}
+
+	if err != nil {
+		errStr := err.Error()
+		ret.Error = &errStr
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("User-Agent", "stash")
+	h.Set("Cookie", "session=secret")
+	h.Set("Authorization", "Bearer secret")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+
+	got := debugHeaders(h)
+
+	assert.Equal(t, []*DebugHeader{
+		{Name: "Accept", Value: "text/html"},
+		{Name: "Accept", Value: "application/json"},
+		{Name: "Authorization", Value: redactedHeaderValue},
+		{Name: "Cookie", Value: redactedHeaderValue},
+		{Name: "User-Agent", Value: "stash"},
+	}, got)
+}
+
+func TestDebugRequestSetBody(t *testing.T) {
+	r := &DebugRequest{}
+	r.setBody([]byte("<html></html>"))
+	assert.Equal(t, "<html></html>", r.Body)
+	assert.False(t, r.BodyTruncated)
+
+	r = &DebugRequest{}
+	r.setBody([]byte(strings.Repeat("a", maxDebugBodySize+1)))
+	assert.Len(t, r.Body, maxDebugBodySize)
+	assert.True(t, r.BodyTruncated)
+}
+
+func TestDebugTraceFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, debugTraceFromContext(ctx))
+
+	// adding to a nil trace is a no-op
+	debugTraceFromContext(ctx).addQuery(&DebugQuery{})
+
+	ctx, trace := WithDebugTrace(ctx)
+	debugTraceFromContext(ctx).addQuery(&DebugQuery{Key: "Title"})
+	assert.Len(t, trace.Queries, 1)
+}
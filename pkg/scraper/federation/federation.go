@@ -0,0 +1,466 @@
+// Package federation provides a client to pull metadata from another stash
+// instance.
+package federation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	graphql "github.com/hasura/go-graphql-client"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// maxResults is the maximum number of scenes returned for a fingerprint.
+const maxResults = 10
+
+type SceneReader interface {
+	models.SceneGetter
+	models.VideoFileLoader
+}
+
+type Repository struct {
+	TxnManager models.TxnManager
+
+	Scene     SceneReader
+	Performer match.PerformerFinder
+	Tag       models.TagQueryer
+	Studio    match.StudioFinder
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		TxnManager: repo.TxnManager,
+		Scene:      repo.Scene,
+		Performer:  repo.Performer,
+		Tag:        repo.Tag,
+		Studio:     repo.Studio,
+	}
+}
+
+func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.WithReadTxn(ctx, r.TxnManager, fn)
+}
+
+// Client represents the client interface to a federated stash instance.
+type Client struct {
+	client     *graphql.Client
+	httpClient *http.Client
+	repository Repository
+	instance   models.FederatedInstance
+}
+
+// NewClient returns a new client for the federated instance.
+func NewClient(instance models.FederatedInstance, repo Repository) *Client {
+	instance.URL = strings.TrimSuffix(instance.URL, "/")
+	httpClient := http.DefaultClient
+
+	client := graphql.NewClient(instance.URL+"/graphql", httpClient)
+	if instance.APIKey != "" {
+		client = client.WithRequestModifier(func(req *http.Request) {
+			req.Header.Set("ApiKey", instance.APIKey)
+		})
+	}
+
+	return &Client{
+		client:     client,
+		httpClient: httpClient,
+		repository: repo,
+		instance:   instance,
+	}
+}
+
+type sceneFingerprints struct {
+	oshashes  []string
+	checksums []string
+	phashes   []string
+}
+
+// FindSceneByFingerprints queries the federated instance for scenes using
+// the OSHASH, MD5 or PHash of the scene's files. Fingerprints are queried in
+// that order, and the results of the first fingerprint that matches any
+// scenes are returned.
+func (c Client) FindSceneByFingerprints(ctx context.Context, sceneID int) ([]*scraper.ScrapedScene, error) {
+	var fps sceneFingerprints
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scene, err := r.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if err := scene.LoadFiles(ctx, r.Scene); err != nil {
+			return err
+		}
+
+		for _, f := range scene.Files.List() {
+			if oshash := f.Fingerprints.GetString(models.FingerprintTypeOshash); oshash != "" {
+				fps.oshashes = append(fps.oshashes, oshash)
+			}
+			if checksum := f.Fingerprints.GetString(models.FingerprintTypeMD5); checksum != "" {
+				fps.checksums = append(fps.checksums, checksum)
+			}
+			if phash := f.Fingerprints.GetInt64(models.FingerprintTypePhash); phash != 0 {
+				fps.phashes = append(fps.phashes, utils.PhashToString(phash))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	queries := []struct {
+		field  string
+		hashes []string
+	}{
+		{"oshash", fps.oshashes},
+		{"checksum", fps.checksums},
+		{"phash_distance", fps.phashes},
+	}
+
+	for _, q := range queries {
+		for _, hash := range q.hashes {
+			scenes, err := c.findScenes(ctx, q.field, hash)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(scenes) == 0 {
+				continue
+			}
+
+			logger.Debugf("Found %d scenes in %s using %s %s", len(scenes), c.instance.URL, q.field, hash)
+
+			var ret []*scraper.ScrapedScene
+			for _, s := range scenes {
+				ss, err := c.federatedSceneToScrapedScene(ctx, s)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, ss)
+			}
+
+			return ret, nil
+		}
+	}
+
+	return nil, nil
+}
+
+type federatedTag struct {
+	Name string `graphql:"name" json:"name"`
+}
+
+type federatedStudio struct {
+	Name string  `graphql:"name" json:"name"`
+	URL  *string `graphql:"url" json:"url"`
+}
+
+type federatedPerformer struct {
+	ID             string   `graphql:"id" json:"id"`
+	Name           string   `graphql:"name" json:"name"`
+	Disambiguation *string  `graphql:"disambiguation" json:"disambiguation"`
+	Gender         *string  `graphql:"gender" json:"gender"`
+	URLs           []string `graphql:"urls" json:"urls"`
+	Birthdate      *string  `graphql:"birthdate" json:"birthdate"`
+	DeathDate      *string  `graphql:"death_date" json:"death_date"`
+	Ethnicity      *string  `graphql:"ethnicity" json:"ethnicity"`
+	Country        *string  `graphql:"country" json:"country"`
+	EyeColor       *string  `graphql:"eye_color" json:"eye_color"`
+	HairColor      *string  `graphql:"hair_color" json:"hair_color"`
+	Height         *int     `graphql:"height_cm" json:"height_cm"`
+	Weight         *int     `graphql:"weight" json:"weight"`
+	Measurements   *string  `graphql:"measurements" json:"measurements"`
+	FakeTits       *string  `graphql:"fake_tits" json:"fake_tits"`
+	CareerLength   *string  `graphql:"career_length" json:"career_length"`
+	Tattoos        *string  `graphql:"tattoos" json:"tattoos"`
+	Piercings      *string  `graphql:"piercings" json:"piercings"`
+	Aliases        []string `graphql:"alias_list" json:"alias_list"`
+	Details        *string  `graphql:"details" json:"details"`
+}
+
+type federatedMarker struct {
+	Title      string         `graphql:"title" json:"title"`
+	Seconds    float64        `graphql:"seconds" json:"seconds"`
+	EndSeconds *float64       `graphql:"end_seconds" json:"end_seconds"`
+	PrimaryTag federatedTag   `graphql:"primary_tag" json:"primary_tag"`
+	Tags       []federatedTag `graphql:"tags" json:"tags"`
+}
+
+type federatedScene struct {
+	ID           string                `graphql:"id" json:"id"`
+	Title        *string               `graphql:"title" json:"title"`
+	Code         *string               `graphql:"code" json:"code"`
+	Details      *string               `graphql:"details" json:"details"`
+	Director     *string               `graphql:"director" json:"director"`
+	URLs         []string              `graphql:"urls" json:"urls"`
+	Date         *string               `graphql:"date" json:"date"`
+	Studio       *federatedStudio      `graphql:"studio" json:"studio"`
+	Tags         []federatedTag        `graphql:"tags" json:"tags"`
+	Performers   []*federatedPerformer `graphql:"performers" json:"performers"`
+	SceneMarkers []federatedMarker     `graphql:"scene_markers" json:"scene_markers"`
+}
+
+func (c Client) findScenes(ctx context.Context, field string, hash string) ([]*federatedScene, error) {
+	// type names must match the remote schema
+	type StringCriterionInput struct {
+		Value    string                   `json:"value"`
+		Modifier models.CriterionModifier `json:"modifier"`
+	}
+
+	type PhashDistanceCriterionInput struct {
+		Value    string                   `json:"value"`
+		Modifier models.CriterionModifier `json:"modifier"`
+		Distance int                      `json:"distance"`
+	}
+
+	type SceneFilterType struct {
+		Oshash        *StringCriterionInput        `json:"oshash,omitempty"`
+		Checksum      *StringCriterionInput        `json:"checksum,omitempty"`
+		PhashDistance *PhashDistanceCriterionInput `json:"phash_distance,omitempty"`
+	}
+
+	var filter SceneFilterType
+	switch field {
+	case "oshash":
+		filter.Oshash = &StringCriterionInput{Value: hash, Modifier: models.CriterionModifierEquals}
+	case "checksum":
+		filter.Checksum = &StringCriterionInput{Value: hash, Modifier: models.CriterionModifierEquals}
+	case "phash_distance":
+		filter.PhashDistance = &PhashDistanceCriterionInput{Value: hash, Modifier: models.CriterionModifierEquals}
+	default:
+		return nil, fmt.Errorf("unsupported fingerprint field %q", field)
+	}
+
+	var q struct {
+		FindScenes struct {
+			Scenes []*federatedScene `graphql:"scenes"`
+		} `graphql:"findScenes(scene_filter: $f, filter: $p)"`
+	}
+
+	perPage := maxResults
+	vars := map[string]interface{}{
+		"f": &filter,
+		"p": models.FindFilterType{
+			PerPage: &perPage,
+		},
+	}
+
+	if err := c.client.Query(ctx, &q, vars); err != nil {
+		return nil, fmt.Errorf("querying %s: %w", c.instance.URL, err)
+	}
+
+	return q.FindScenes.Scenes, nil
+}
+
+// getImage gets the image at the given path of the federated instance,
+// returning it as a base64 data URL.
+func (c Client) getImage(ctx context.Context, path string) (*string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.instance.URL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.instance.APIKey != "" {
+		req.Header.Set("ApiKey", c.instance.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("http error %d getting %s", resp.StatusCode, path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	img := "data:" + contentType + ";base64," + utils.GetBase64StringFromData(body)
+	return &img, nil
+}
+
+func (p federatedPerformer) toScrapedPerformer() *models.ScrapedPerformer {
+	ret := &models.ScrapedPerformer{
+		Name:           &p.Name,
+		Disambiguation: p.Disambiguation,
+		Gender:         p.Gender,
+		URLs:           p.URLs,
+		Birthdate:      p.Birthdate,
+		DeathDate:      p.DeathDate,
+		Ethnicity:      p.Ethnicity,
+		Country:        p.Country,
+		EyeColor:       p.EyeColor,
+		HairColor:      p.HairColor,
+		Measurements:   p.Measurements,
+		FakeTits:       p.FakeTits,
+		CareerLength:   p.CareerLength,
+		Tattoos:        p.Tattoos,
+		Piercings:      p.Piercings,
+		Details:        p.Details,
+	}
+
+	if len(p.Aliases) > 0 {
+		aliases := strings.Join(p.Aliases, ", ")
+		ret.Aliases = &aliases
+	}
+
+	if p.Height != nil {
+		height := strconv.Itoa(*p.Height)
+		ret.Height = &height
+	}
+
+	if p.Weight != nil {
+		weight := strconv.Itoa(*p.Weight)
+		ret.Weight = &weight
+	}
+
+	return ret
+}
+
+func (c Client) federatedSceneToScrapedScene(ctx context.Context, s *federatedScene) (*scraper.ScrapedScene, error) {
+	ret := &scraper.ScrapedScene{
+		Title:    s.Title,
+		Code:     s.Code,
+		Details:  s.Details,
+		Director: s.Director,
+		URLs:     s.URLs,
+		Date:     s.Date,
+	}
+
+	if len(ret.URLs) > 0 {
+		ret.URL = &ret.URLs[0]
+	}
+
+	img, err := c.getImage(ctx, "/scene/"+s.ID+"/screenshot")
+	if err != nil {
+		// not fatal - the rest of the metadata is still useful
+		logger.Warnf("Error getting cover of scene %s from %s: %v", s.ID, c.instance.URL, err)
+	} else {
+		ret.Image = img
+	}
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		if s.Studio != nil {
+			ret.Studio = &models.ScrapedStudio{
+				Name: s.Studio.Name,
+				URL:  s.Studio.URL,
+			}
+
+			if err := match.ScrapedStudio(ctx, r.Studio, ret.Studio, nil); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range s.Performers {
+			sp := p.toScrapedPerformer()
+			if err := match.ScrapedPerformer(ctx, r.Performer, sp, nil); err != nil {
+				return err
+			}
+
+			ret.Performers = append(ret.Performers, sp)
+		}
+
+		tags := make(map[string]*models.ScrapedTag)
+		scrapedTag := func(t federatedTag) (*models.ScrapedTag, error) {
+			if st, found := tags[t.Name]; found {
+				return st, nil
+			}
+
+			st := &models.ScrapedTag{
+				Name: t.Name,
+			}
+			if err := match.ScrapedTag(ctx, r.Tag, st); err != nil {
+				return nil, err
+			}
+
+			tags[t.Name] = st
+			return st, nil
+		}
+
+		for _, t := range s.Tags {
+			st, err := scrapedTag(t)
+			if err != nil {
+				return err
+			}
+			ret.Tags = append(ret.Tags, st)
+		}
+
+		for _, m := range s.SceneMarkers {
+			primaryTag, err := scrapedTag(m.PrimaryTag)
+			if err != nil {
+				return err
+			}
+
+			sm := &scraper.ScrapedSceneMarker{
+				Title:      m.Title,
+				Seconds:    m.Seconds,
+				EndSeconds: m.EndSeconds,
+				PrimaryTag: primaryTag,
+			}
+
+			for _, t := range m.Tags {
+				st, err := scrapedTag(t)
+				if err != nil {
+					return err
+				}
+				sm.Tags = append(sm.Tags, st)
+			}
+
+			ret.Markers = append(ret.Markers, sm)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// only get the images of performers that may be created
+	for i, p := range s.Performers {
+		sp := ret.Performers[i]
+		if sp.StoredID != nil {
+			continue
+		}
+
+		img, err := c.getImage(ctx, "/performer/"+p.ID+"/image")
+		if err != nil {
+			logger.Warnf("Error getting image of performer %s from %s: %v", p.ID, c.instance.URL, err)
+			continue
+		}
+
+		sp.Image = img
+		sp.Images = []string{*img}
+	}
+
+	return ret, nil
+}
+
+// String returns a description of the instance for logging.
+func (c Client) String() string {
+	return fmt.Sprintf("federated stash %s", c.instance.URL)
+}
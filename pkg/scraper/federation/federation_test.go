@@ -0,0 +1,302 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testSceneID  = 1
+	testAPIKey   = "apikey"
+	testOshash   = "oshash"
+	testChecksum = "checksum"
+
+	studioID         = 5
+	knownPerformerID = 3
+)
+
+var testImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// remoteScene is the scene returned by the test server, as returned by the
+// findScenes query of the remote instance.
+const remoteScene = `{
+	"id": "10",
+	"title": "Remote Title",
+	"code": "CODE-1",
+	"details": "Remote details",
+	"director": "Remote Director",
+	"urls": ["https://example.com/scene/1", "https://example.com/scene/2"],
+	"date": "2021-02-03",
+	"studio": {"name": "Remote Studio", "url": "https://example.com/studio"},
+	"tags": [{"name": "Tag"}],
+	"performers": [
+		{"id": "20", "name": "Known", "alias_list": []},
+		{"id": "21", "name": "Unknown", "gender": "FEMALE", "height_cm": 170, "weight": 55, "alias_list": ["Alias 1", "Alias 2"]},
+		{"id": "22", "name": "No Image", "alias_list": []}
+	],
+	"scene_markers": [
+		{"title": "Marker", "seconds": 12.5, "end_seconds": 20, "primary_tag": {"name": "Marker Tag"}, "tags": [{"name": "Tag"}]}
+	]
+}`
+
+type graphqlRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		F struct {
+			Oshash   *struct{ Value string } `json:"oshash"`
+			Checksum *struct{ Value string } `json:"checksum"`
+		} `json:"f"`
+	} `json:"variables"`
+}
+
+// newTestServer returns a server that returns remoteScene for the scenes
+// with the given checksum, and no scenes for anything else.
+func newTestServer(t *testing.T, checksum string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, testAPIKey, r.Header.Get("ApiKey"))
+
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		scenes := "[]"
+		if f := req.Variables.F.Checksum; f != nil && f.Value == checksum {
+			scenes = "[" + remoteScene + "]"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"findScenes": {"scenes": ` + scenes + `}}}`))
+	})
+
+	image := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, testAPIKey, r.Header.Get("ApiKey"))
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(testImage)
+	}
+	mux.HandleFunc("/scene/10/screenshot", image)
+	mux.HandleFunc("/performer/21/image", image)
+	mux.HandleFunc("/performer/20/image", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("image of matched performer should not be requested")
+	})
+	mux.HandleFunc("/performer/22/image", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func newTestDatabase() *mocks.Database {
+	db := mocks.NewDatabase()
+
+	db.Scene.On("Find", mock.Anything, testSceneID).Return(&models.Scene{ID: testSceneID}, nil)
+	db.Scene.On("GetFiles", mock.Anything, testSceneID).Return([]*models.VideoFile{
+		{
+			BaseFile: &models.BaseFile{
+				Fingerprints: models.Fingerprints{
+					{Type: models.FingerprintTypeOshash, Fingerprint: testOshash},
+					{Type: models.FingerprintTypeMD5, Fingerprint: testChecksum},
+				},
+			},
+		},
+	}, nil)
+
+	db.Studio.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]*models.Studio{{ID: studioID}}, 1, nil)
+	db.Tag.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+	db.Performer.On("FindByNames", mock.Anything, []string{"Known"}, true).Return([]*models.Performer{{ID: knownPerformerID}}, nil)
+	db.Performer.On("FindByNames", mock.Anything, mock.Anything, true).Return(nil, nil)
+	db.Performer.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	return db
+}
+
+func TestFindSceneByFingerprints(t *testing.T) {
+	ts := newTestServer(t, testChecksum)
+	db := newTestDatabase()
+
+	c := NewClient(models.FederatedInstance{
+		URL:    ts.URL + "/",
+		APIKey: testAPIKey,
+	}, NewRepository(db.Repository()))
+
+	got, err := c.FindSceneByFingerprints(context.Background(), testSceneID)
+	if !assert.NoError(t, err) || !assert.Len(t, got, 1) {
+		return
+	}
+
+	s := got[0]
+	assert.Equal(t, "Remote Title", *s.Title)
+	assert.Equal(t, "CODE-1", *s.Code)
+	assert.Equal(t, "Remote details", *s.Details)
+	assert.Equal(t, "Remote Director", *s.Director)
+	assert.Equal(t, "2021-02-03", *s.Date)
+	assert.Equal(t, []string{"https://example.com/scene/1", "https://example.com/scene/2"}, s.URLs)
+	assert.Equal(t, "https://example.com/scene/1", *s.URL)
+
+	wantImage := "data:image/png;base64,iVBORw0KGgoAAAANSUhEUg=="
+	if assert.NotNil(t, s.Image) {
+		assert.Equal(t, wantImage, *s.Image)
+	}
+
+	if assert.NotNil(t, s.Studio) {
+		assert.Equal(t, "Remote Studio", s.Studio.Name)
+		assert.Equal(t, "https://example.com/studio", *s.Studio.URL)
+		assert.Equal(t, "5", *s.Studio.StoredID)
+	}
+
+	if assert.Len(t, s.Performers, 3) {
+		known := s.Performers[0]
+		assert.Equal(t, "3", *known.StoredID)
+		assert.Nil(t, known.Image)
+		assert.Nil(t, known.Aliases)
+
+		unknown := s.Performers[1]
+		assert.Nil(t, unknown.StoredID)
+		assert.Equal(t, "Unknown", *unknown.Name)
+		assert.Equal(t, "FEMALE", *unknown.Gender)
+		assert.Equal(t, "170", *unknown.Height)
+		assert.Equal(t, "55", *unknown.Weight)
+		assert.Equal(t, "Alias 1, Alias 2", *unknown.Aliases)
+		if assert.NotNil(t, unknown.Image) {
+			assert.Equal(t, wantImage, *unknown.Image)
+		}
+		assert.Equal(t, []string{wantImage}, unknown.Images)
+
+		// failing to get the image is not fatal
+		noImage := s.Performers[2]
+		assert.Nil(t, noImage.Image)
+		assert.Empty(t, noImage.Images)
+	}
+
+	if assert.Len(t, s.Tags, 1) {
+		assert.Equal(t, "Tag", s.Tags[0].Name)
+		assert.Nil(t, s.Tags[0].StoredID)
+	}
+
+	if assert.Len(t, s.Markers, 1) {
+		m := s.Markers[0]
+		assert.Equal(t, "Marker", m.Title)
+		assert.Equal(t, 12.5, m.Seconds)
+		assert.Equal(t, 20.0, *m.EndSeconds)
+		assert.Equal(t, "Marker Tag", m.PrimaryTag.Name)
+
+		// tags are shared between the scene and its markers
+		if assert.Len(t, m.Tags, 1) {
+			assert.Same(t, s.Tags[0], m.Tags[0])
+		}
+	}
+}
+
+func TestFindSceneByFingerprints_NoMatch(t *testing.T) {
+	ts := newTestServer(t, "other")
+	db := newTestDatabase()
+
+	c := NewClient(models.FederatedInstance{
+		URL:    ts.URL,
+		APIKey: testAPIKey,
+	}, NewRepository(db.Repository()))
+
+	got, err := c.FindSceneByFingerprints(context.Background(), testSceneID)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestFindSceneByFingerprints_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			"http error",
+			func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+			},
+		},
+		{
+			"graphql error",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"errors": [{"message": "not authorized"}], "data": null}`))
+			},
+		},
+		{
+			"invalid response",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data": {"findScenes": {"scenes": "invalid"}}}`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(tt.handler)
+			defer ts.Close()
+
+			db := newTestDatabase()
+			c := NewClient(models.FederatedInstance{URL: ts.URL}, NewRepository(db.Repository()))
+
+			got, err := c.FindSceneByFingerprints(context.Background(), testSceneID)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), ts.URL)
+			}
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestFindSceneByFingerprints_SceneNotFound(t *testing.T) {
+	const missingID = 2
+
+	db := mocks.NewDatabase()
+	db.Scene.On("Find", mock.Anything, missingID).Return(nil, nil)
+
+	c := NewClient(models.FederatedInstance{URL: "http://localhost"}, NewRepository(db.Repository()))
+
+	_, err := c.FindSceneByFingerprints(context.Background(), missingID)
+	assert.Error(t, err)
+}
+
+func TestGetImage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/detect":
+			// no content type - detected from the data
+			w.Header()["Content-Type"] = nil
+			_, _ = w.Write(testImage)
+		case "/forbidden":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(models.FederatedInstance{URL: ts.URL}, Repository{})
+
+	got, err := c.getImage(context.Background(), "/detect")
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(*got, "data:image/png;base64,"))
+	}
+
+	_, err = c.getImage(context.Background(), "/forbidden")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "403")
+	}
+}
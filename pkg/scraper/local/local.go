@@ -0,0 +1,295 @@
+// Package local provides a scene scraper that reads metadata from the files
+// alongside a scene's file, such as Kodi NFO files, text descriptions,
+// poster images and the name of the containing folder.
+package local
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// maxImageSize is the maximum size of a poster image that will be read.
+const maxImageSize = 20 * 1024 * 1024
+
+var (
+	// folderDateRE matches a date in a folder name, such as
+	// "Studio - 2021-03-14 - Title" or "2021.03.14 Title".
+	folderDateRE = regexp.MustCompile(`(\d{4})[-._](\d{2})[-._](\d{2})`)
+
+	// folderStudioRE matches a studio name in square brackets at the
+	// start of a folder name, such as "[Studio] Title".
+	folderStudioRE = regexp.MustCompile(`^\[([^\]]+)\]`)
+)
+
+type Repository struct {
+	TxnManager models.TxnManager
+
+	Scene     models.SceneGetter
+	Performer match.PerformerFinder
+	Tag       models.TagQueryer
+	Studio    match.StudioFinder
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		TxnManager: repo.TxnManager,
+		Scene:      repo.Scene,
+		Performer:  repo.Performer,
+		Tag:        repo.Tag,
+		Studio:     repo.Studio,
+	}
+}
+
+func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.WithReadTxn(ctx, r.TxnManager, fn)
+}
+
+// Scraper reads scene metadata from the files alongside a scene's primary
+// file.
+type Scraper struct {
+	repository Repository
+}
+
+func NewScraper(repo Repository) *Scraper {
+	return &Scraper{
+		repository: repo,
+	}
+}
+
+// ScrapeScene returns the metadata found alongside the primary file of the
+// scene. It returns nil if no metadata was found.
+func (s Scraper) ScrapeScene(ctx context.Context, sceneID int) (*scraper.ScrapedScene, error) {
+	var path string
+
+	r := s.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scene, err := r.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		path = scene.Path
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, nil
+	}
+
+	ret := scrapePath(path)
+	if ret == nil {
+		return nil, nil
+	}
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		return s.matchRelationships(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// matchRelationships sets the stored IDs of the studio, performers and tags
+// of the scraped scene that exist in the database.
+func (s Scraper) matchRelationships(ctx context.Context, ret *scraper.ScrapedScene) error {
+	r := s.repository
+	if ret.Studio != nil {
+		if err := match.ScrapedStudio(ctx, r.Studio, ret.Studio, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range ret.Performers {
+		if err := match.ScrapedPerformer(ctx, r.Performer, p, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range ret.Tags {
+		if err := match.ScrapedTag(ctx, r.Tag, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String returns a description of the scraper for logging.
+func (s Scraper) String() string {
+	return "local files"
+}
+
+// scrapePath returns the metadata found alongside the file at path, or nil
+// if none was found. Metadata is read from, in order of precedence:
+//   - a Kodi NFO file with the same name as the file, or movie.nfo
+//   - a text file with the same name as the file, used as the details
+//   - a poster image with the same name as the file, or poster, cover or
+//     folder images in the same folder
+//   - the date and bracketed studio name in the name of the folder
+func scrapePath(path string) *scraper.ScrapedScene {
+	dir := filepath.Dir(path)
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	sibling := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	ret := &scraper.ScrapedScene{}
+	found := false
+
+	if nfo := firstExisting(sibling(base+".nfo"), sibling("movie.nfo")); nfo != "" {
+		if err := readNFO(nfo, ret); err != nil {
+			logger.Warnf("Error reading %s: %v", nfo, err)
+		} else {
+			found = true
+		}
+	}
+
+	if ret.Details == nil {
+		if txt := firstExisting(sibling(base + ".txt")); txt != "" {
+			details, err := readText(txt)
+			if err != nil {
+				logger.Warnf("Error reading %s: %v", txt, err)
+			} else if details != "" {
+				ret.Details = &details
+				found = true
+			}
+		}
+	}
+
+	if ret.Image == nil {
+		var candidates []string
+		for _, name := range []string{base + "-poster", base, "poster", "cover", "folder"} {
+			for _, ext := range []string{".jpg", ".jpeg", ".png", ".webp"} {
+				candidates = append(candidates, sibling(name+ext))
+			}
+		}
+
+		if img := firstExisting(candidates...); img != "" {
+			image, err := readImage(img)
+			if err != nil {
+				logger.Warnf("Error reading %s: %v", img, err)
+			} else {
+				ret.Image = &image
+				found = true
+			}
+		}
+	}
+
+	folder := filepath.Base(dir)
+	if ret.Date == nil {
+		if date := folderDate(folder); date != "" {
+			ret.Date = &date
+			found = true
+		}
+	}
+
+	if ret.Studio == nil {
+		if m := folderStudioRE.FindStringSubmatch(folder); m != nil {
+			if name := strings.TrimSpace(m[1]); name != "" {
+				ret.Studio = &models.ScrapedStudio{Name: name}
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	return ret
+}
+
+// URLs returns the URLs of the scene in the NFO file alongside the file at
+// path, or nil if there is no NFO file.
+func URLs(path string) []string {
+	dir := filepath.Dir(path)
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	nfo := firstExisting(filepath.Join(dir, base+".nfo"), filepath.Join(dir, "movie.nfo"))
+	if nfo == "" {
+		return nil
+	}
+
+	var ret scraper.ScrapedScene
+	if err := readNFO(nfo, &ret); err != nil {
+		logger.Debugf("Error reading %s: %v", nfo, err)
+		return nil
+	}
+
+	return ret.URLs
+}
+
+func firstExisting(paths ...string) string {
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// folderDate returns the first valid date in the folder name in
+// YYYY-MM-DD format, or an empty string if there is none.
+func folderDate(folder string) string {
+	for _, m := range folderDateRE.FindAllStringSubmatch(folder, -1) {
+		date := m[1] + "-" + m[2] + "-" + m[3]
+		if _, err := time.Parse("2006-01-02", date); err == nil {
+			return date
+		}
+	}
+
+	return ""
+}
+
+func readText(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readImage(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Size() > maxImageSize {
+		return "", fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", fmt.Errorf("unsupported image type %s", contentType)
+	}
+
+	return "data:" + contentType + ";base64," + utils.GetBase64StringFromData(data), nil
+}
@@ -0,0 +1,104 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testNFO = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<movie>
+  <title>NFO Title</title>
+  <plot>NFO plot</plot>
+  <premiered>2020-05-06</premiered>
+  <studio>NFO Studio</studio>
+  <director>NFO Director</director>
+  <actor><name>Performer One</name><role>Self</role></actor>
+  <actor><name>Performer Two</name></actor>
+  <genre>Genre</genre>
+  <tag>Tag</tag>
+  <tag>genre</tag>
+</movie>
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScrapePath_NFO(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "[Folder Studio] 2019-01-02 Folder Title")
+	writeFiles(t, dir, map[string]string{
+		"scene.mp4": "",
+		"scene.nfo": testNFO,
+		"scene.txt": "text details",
+	})
+
+	got := scrapePath(filepath.Join(dir, "scene.mp4"))
+	if !assert.NotNil(t, got) {
+		return
+	}
+
+	assert.Equal(t, "NFO Title", *got.Title)
+	assert.Equal(t, "NFO plot", *got.Details)
+	assert.Equal(t, "2020-05-06", *got.Date)
+	assert.Equal(t, "NFO Director", *got.Director)
+	assert.Equal(t, "NFO Studio", got.Studio.Name)
+
+	var performers []string
+	for _, p := range got.Performers {
+		performers = append(performers, *p.Name)
+	}
+	assert.Equal(t, []string{"Performer One", "Performer Two"}, performers)
+
+	var tags []string
+	for _, tag := range got.Tags {
+		tags = append(tags, tag.Name)
+	}
+	assert.Equal(t, []string{"Genre", "Tag"}, tags)
+	assert.Nil(t, got.Image)
+}
+
+func TestScrapePath_Fallbacks(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	dir := filepath.Join(t.TempDir(), "[Folder Studio] 2019.01.02 Folder Title")
+	writeFiles(t, dir, map[string]string{
+		"scene.mp4":  "",
+		"movie.nfo":  "https://example.com/scene/1\n",
+		"scene.txt":  "  text details\n",
+		"poster.png": png,
+	})
+
+	got := scrapePath(filepath.Join(dir, "scene.mp4"))
+	if !assert.NotNil(t, got) {
+		return
+	}
+
+	assert.Nil(t, got.Title)
+	assert.Equal(t, "https://example.com/scene/1", *got.URL)
+	assert.Equal(t, "text details", *got.Details)
+	assert.Equal(t, "2019-01-02", *got.Date)
+	assert.Equal(t, "Folder Studio", got.Studio.Name)
+	assert.True(t, strings.HasPrefix(*got.Image, "data:image/png;base64,"))
+}
+
+func TestScrapePath_NoMetadata(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Folder 2019-13-40")
+	writeFiles(t, dir, map[string]string{
+		"scene.mp4":  "",
+		"other.nfo":  testNFO,
+		"poster.png": "not an image",
+	})
+
+	assert.Nil(t, scrapePath(filepath.Join(dir, "scene.mp4")))
+}
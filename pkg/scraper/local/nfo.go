@@ -0,0 +1,118 @@
+package local
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+// nfo is the subset of the Kodi NFO format that is used. The root element
+// is not checked, so movie, episodedetails and musicvideo files are all
+// supported.
+type nfo struct {
+	Title       string     `xml:"title"`
+	Plot        string     `xml:"plot"`
+	Outline     string     `xml:"outline"`
+	Premiered   string     `xml:"premiered"`
+	Aired       string     `xml:"aired"`
+	ReleaseDate string     `xml:"releasedate"`
+	Studios     []string   `xml:"studio"`
+	Directors   []string   `xml:"director"`
+	Actors      []nfoActor `xml:"actor"`
+	Genres      []string   `xml:"genre"`
+	Tags        []string   `xml:"tag"`
+}
+
+type nfoActor struct {
+	Name string `xml:"name"`
+}
+
+// readNFO reads the Kodi NFO file at path into ret. NFO files that only
+// contain a URL are also supported, setting the URL of the scene.
+func readNFO(path string, ret *scraper.ScrapedScene) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("<")) {
+		return readNFOURL(string(data), ret)
+	}
+
+	var n nfo
+	if err := xml.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("parsing nfo: %w", err)
+	}
+
+	n.apply(ret)
+	return nil
+}
+
+func readNFOURL(data string, ret *scraper.ScrapedScene) error {
+	line, _, _ := strings.Cut(data, "\n")
+	line = strings.TrimSpace(line)
+
+	u, err := url.Parse(line)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("nfo is neither xml nor a url")
+	}
+
+	ret.URL = &line
+	ret.URLs = []string{line}
+	return nil
+}
+
+func (n nfo) apply(ret *scraper.ScrapedScene) {
+	setString := func(dest **string, values ...string) {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				*dest = &v
+				return
+			}
+		}
+	}
+
+	setString(&ret.Title, n.Title)
+	setString(&ret.Details, n.Plot, n.Outline)
+	setString(&ret.Director, n.Directors...)
+
+	for _, d := range []string{n.Premiered, n.Aired, n.ReleaseDate} {
+		d = strings.TrimSpace(d)
+		if _, err := time.Parse("2006-01-02", d); err == nil {
+			ret.Date = &d
+			break
+		}
+	}
+
+	for _, s := range n.Studios {
+		if s = strings.TrimSpace(s); s != "" {
+			ret.Studio = &models.ScrapedStudio{Name: s}
+			break
+		}
+	}
+
+	for _, a := range n.Actors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			ret.Performers = append(ret.Performers, &models.ScrapedPerformer{Name: &name})
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range append(n.Genres, n.Tags...) {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+
+		seen[strings.ToLower(t)] = true
+		ret.Tags = append(ret.Tags, &models.ScrapedTag{Name: t})
+	}
+}
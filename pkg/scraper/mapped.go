@@ -61,12 +61,17 @@ func (s mappedConfig) process(ctx context.Context, q mappedQuery, common commonM
 				logger.Warnf("key '%v': %v", k, err)
 			}
 
+			var result []string
 			if len(found) > 0 {
-				result := s.postProcess(ctx, q, attrConfig, found)
+				result = s.postProcess(ctx, q, attrConfig, found)
 				for i, text := range result {
 					ret = ret.setKey(i, k, text)
 				}
 			}
+
+			if trace := debugTraceFromContext(ctx); trace != nil {
+				trace.addQuery(newDebugQuery(k, selector, found, result, err))
+			}
 		}
 	}
 
@@ -421,8 +426,10 @@ func (p *postProcessParseDate) Apply(ctx context.Context, value string, q mapped
 		return value
 	}
 
-	// convert it into our date format
-	return parsedValue.Format(internalDateFormat)
+	// convert it into our date format, keeping the precision of the pattern
+	// so that year or month-only dates are not given a fake day
+	d := models.Date{Time: parsedValue, Precision: models.DatePrecisionOfLayout(parseDate)}
+	return d.String()
 }
 
 type postProcessSubtractDays bool
@@ -79,6 +79,18 @@ func Test_postProcessParseDate_Apply(t *testing.T) {
 			"2001=03=23",
 			"2001-03-23",
 		},
+		{
+			"month",
+			"January 2006",
+			"March 2001",
+			"2001-03",
+		},
+		{
+			"year",
+			"2006",
+			"2001",
+			"2001",
+		},
 		{
 			"today",
 			"",
@@ -56,7 +56,7 @@ func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerforme
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
 		tqb := r.TagFinder
 
-		tags, err := postProcessTags(ctx, tqb, p.Tags)
+		tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, p.Tags)
 		if err != nil {
 			return err
 		}
@@ -106,7 +106,7 @@ func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (Scra
 	r := c.repository
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
 		tqb := r.TagFinder
-		tags, err := postProcessTags(ctx, tqb, m.Tags)
+		tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, m.Tags)
 		if err != nil {
 			return err
 		}
@@ -138,7 +138,7 @@ func (c Cache) postScrapeGroup(ctx context.Context, m models.ScrapedGroup) (Scra
 	r := c.repository
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
 		tqb := r.TagFinder
-		tags, err := postProcessTags(ctx, tqb, m.Tags)
+		tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, m.Tags)
 		if err != nil {
 			return err
 		}
@@ -169,7 +169,7 @@ func (c Cache) postScrapeGroup(ctx context.Context, m models.ScrapedGroup) (Scra
 func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPerformer) error {
 	tqb := c.repository.TagFinder
 
-	tags, err := postProcessTags(ctx, tqb, p.Tags)
+	tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, p.Tags)
 	if err != nil {
 		return err
 	}
@@ -246,7 +246,7 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 			}
 		}
 
-		tags, err := postProcessTags(ctx, tqb, scene.Tags)
+		tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, scene.Tags)
 		if err != nil {
 			return err
 		}
@@ -294,7 +294,7 @@ func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (Scraped
 			}
 		}
 
-		tags, err := postProcessTags(ctx, tqb, g.Tags)
+		tags, err := postProcessTags(ctx, tqb, c.repository.TagCategoryFinder, g.Tags)
 		if err != nil {
 			return err
 		}
@@ -315,10 +315,15 @@ func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (Scraped
 	return g, nil
 }
 
-func postProcessTags(ctx context.Context, tqb models.TagQueryer, scrapedTags []*models.ScrapedTag) ([]*models.ScrapedTag, error) {
+func postProcessTags(ctx context.Context, tqb models.TagQueryer, cqb models.TagCategoryFinder, scrapedTags []*models.ScrapedTag) ([]*models.ScrapedTag, error) {
 	var ret []*models.ScrapedTag
 
 	for _, t := range scrapedTags {
+		// match the category first, as it may remove a namespace from the name
+		if err := match.ScrapedTagCategory(ctx, cqb, t); err != nil {
+			return nil, err
+		}
+
 		err := match.ScrapedTag(ctx, tqb, t)
 		if err != nil {
 			return nil, err
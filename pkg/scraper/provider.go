@@ -0,0 +1,290 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Provider is a source of metadata backed by a structured API or database,
+// such as a metadata web service, a stash-box instance or a local CSV file.
+// Unlike scrapers, which are configured in terms of pages and selectors,
+// providers receive typed queries and return typed results.
+//
+// A provider must also implement SceneProvider, PerformerProvider or both.
+// Providers registered with Cache.RegisterProvider are listed and used in
+// the same way as scrapers, with the provider ID as the scraper ID.
+type Provider interface {
+	// ID returns the unique ID of the provider.
+	ID() string
+	// Name returns the display name of the provider.
+	Name() string
+}
+
+// SceneQuery is a query for scenes. Unset fields are empty. Providers
+// should use the most specific fields that they support.
+type SceneQuery struct {
+	// ExternalID is the ID of the scene in the provider.
+	ExternalID string
+	Title      string
+	Date       string
+	URLs       []string
+	// Fingerprints of the files of the scene.
+	Fingerprints models.Fingerprints
+	// Duration of the primary file in seconds.
+	Duration float64
+}
+
+// SceneProvider is a Provider that returns scenes.
+type SceneProvider interface {
+	Provider
+	// QueryScenes returns the scenes matching the query, best match first.
+	QueryScenes(ctx context.Context, client *http.Client, q SceneQuery) ([]*ScrapedScene, error)
+}
+
+// PerformerQuery is a query for performers. Unset fields are empty.
+type PerformerQuery struct {
+	// ExternalID is the ID of the performer in the provider.
+	ExternalID string
+	Name       string
+	URLs       []string
+}
+
+// PerformerProvider is a Provider that returns performers.
+type PerformerProvider interface {
+	Provider
+	// QueryPerformers returns the performers matching the query, best match
+	// first.
+	QueryPerformers(ctx context.Context, client *http.Client, q PerformerQuery) ([]*models.ScrapedPerformer, error)
+}
+
+// URLProvider is a Provider that can look up URLs. The URL is passed in the
+// URLs field of the query.
+type URLProvider interface {
+	Provider
+	// URLPatterns returns the URLs that the provider can look up for the
+	// content type. A URL is supported if it contains one of the patterns.
+	URLPatterns(ty ScrapeContentType) []string
+}
+
+// providerScraper adapts a Provider to the scraper interfaces.
+type providerScraper struct {
+	provider Provider
+}
+
+func (s providerScraper) sceneProvider() (SceneProvider, bool) {
+	p, ok := s.provider.(SceneProvider)
+	return p, ok
+}
+
+func (s providerScraper) performerProvider() (PerformerProvider, bool) {
+	p, ok := s.provider.(PerformerProvider)
+	return p, ok
+}
+
+func (s providerScraper) urlPatterns(ty ScrapeContentType) []string {
+	if !s.supports(ty) {
+		return nil
+	}
+
+	if up, ok := s.provider.(URLProvider); ok {
+		return up.URLPatterns(ty)
+	}
+
+	return nil
+}
+
+func (s providerScraper) spec() Scraper {
+	ret := Scraper{
+		ID:   s.provider.ID(),
+		Name: s.provider.Name(),
+	}
+
+	newSpec := func(ty ScrapeContentType) *ScraperSpec {
+		spec := &ScraperSpec{
+			SupportedScrapes: []ScrapeType{ScrapeTypeName, ScrapeTypeFragment},
+		}
+		if urls := s.urlPatterns(ty); len(urls) > 0 {
+			spec.Urls = urls
+			spec.SupportedScrapes = append(spec.SupportedScrapes, ScrapeTypeURL)
+		}
+		return spec
+	}
+
+	if s.supports(ScrapeContentTypeScene) {
+		ret.Scene = newSpec(ScrapeContentTypeScene)
+	}
+	if s.supports(ScrapeContentTypePerformer) {
+		ret.Performer = newSpec(ScrapeContentTypePerformer)
+	}
+
+	return ret
+}
+
+func (s providerScraper) supports(ty ScrapeContentType) bool {
+	switch ty {
+	case ScrapeContentTypeScene:
+		_, ok := s.sceneProvider()
+		return ok
+	case ScrapeContentTypePerformer:
+		_, ok := s.performerProvider()
+		return ok
+	}
+
+	return false
+}
+
+func (s providerScraper) supportsURL(url string, ty ScrapeContentType) bool {
+	for _, pattern := range s.urlPatterns(ty) {
+		if strings.Contains(url, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s providerScraper) queryScenes(ctx context.Context, client *http.Client, q SceneQuery) ([]*ScrapedScene, error) {
+	p, ok := s.sceneProvider()
+	if !ok {
+		return nil, fmt.Errorf("%w: provider %s does not provide scenes", ErrNotSupported, s.provider.ID())
+	}
+
+	return p.QueryScenes(ctx, client, q)
+}
+
+func (s providerScraper) queryPerformers(ctx context.Context, client *http.Client, q PerformerQuery) ([]*models.ScrapedPerformer, error) {
+	p, ok := s.performerProvider()
+	if !ok {
+		return nil, fmt.Errorf("%w: provider %s does not provide performers", ErrNotSupported, s.provider.ID())
+	}
+
+	return p.QueryPerformers(ctx, client, q)
+}
+
+// first returns the first of the results, or nil if there are none.
+func first[T any](results []*T, err error) (*T, error) {
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+// toContent converts the results to scraped content. Nil results are
+// skipped.
+func toContent[T any](results []*T) []ScrapedContent {
+	var ret []ScrapedContent
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+
+		if c, ok := any(r).(ScrapedContent); ok {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+func (s providerScraper) viaName(ctx context.Context, client *http.Client, name string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	switch ty {
+	case ScrapeContentTypeScene:
+		scenes, err := s.queryScenes(ctx, client, SceneQuery{Title: name})
+		if err != nil {
+			return nil, err
+		}
+		return toContent(scenes), nil
+	case ScrapeContentTypePerformer:
+		performers, err := s.queryPerformers(ctx, client, PerformerQuery{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		return toContent(performers), nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s providerScraper) viaFragment(ctx context.Context, client *http.Client, input Input) (ScrapedContent, error) {
+	switch {
+	case input.Scene != nil:
+		in := input.Scene
+		q := SceneQuery{
+			ExternalID: valueOrZero(in.RemoteSiteID),
+			Title:      valueOrZero(in.Title),
+			Date:       valueOrZero(in.Date),
+			URLs:       in.URLs,
+		}
+
+		ret, err := first(s.queryScenes(ctx, client, q))
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case input.Performer != nil:
+		in := input.Performer
+		q := PerformerQuery{
+			ExternalID: valueOrZero(in.RemoteSiteID),
+			Name:       valueOrZero(in.Name),
+			URLs:       in.URLs,
+		}
+
+		ret, err := first(s.queryPerformers(ctx, client, q))
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s providerScraper) viaScene(ctx context.Context, client *http.Client, scene *models.Scene) (*ScrapedScene, error) {
+	q := SceneQuery{
+		Title: scene.Title,
+		URLs:  scene.URLs.List(),
+	}
+	if scene.Date != nil {
+		q.Date = scene.Date.String()
+	}
+
+	for _, f := range scene.Files.List() {
+		q.Fingerprints = append(q.Fingerprints, f.Fingerprints...)
+	}
+	if f := scene.Files.Primary(); f != nil {
+		q.Duration = f.Duration
+	}
+
+	return first(s.queryScenes(ctx, client, q))
+}
+
+func (s providerScraper) viaURL(ctx context.Context, client *http.Client, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	switch ty {
+	case ScrapeContentTypeScene:
+		ret, err := first(s.queryScenes(ctx, client, SceneQuery{URLs: []string{url}}))
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypePerformer:
+		ret, err := first(s.queryPerformers(ctx, client, PerformerQuery{URLs: []string{url}}))
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+func valueOrZero(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
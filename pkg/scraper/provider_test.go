@@ -0,0 +1,102 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSceneProvider returns a scene titled with the query that it received.
+type testSceneProvider struct {
+	queries []SceneQuery
+}
+
+func (p *testSceneProvider) ID() string   { return "test" }
+func (p *testSceneProvider) Name() string { return "Test" }
+
+func (p *testSceneProvider) QueryScenes(_ context.Context, _ *http.Client, q SceneQuery) ([]*ScrapedScene, error) {
+	p.queries = append(p.queries, q)
+	if q.Title == "none" {
+		return nil, nil
+	}
+
+	title := q.Title
+	return []*ScrapedScene{{Title: &title}, {}}, nil
+}
+
+func (p *testSceneProvider) URLPatterns(ty ScrapeContentType) []string {
+	return []string{"example.com/scene/"}
+}
+
+func TestProviderScraper_spec(t *testing.T) {
+	s := providerScraper{provider: &testSceneProvider{}}
+
+	spec := s.spec()
+	assert.Equal(t, "test", spec.ID)
+	assert.Equal(t, "Test", spec.Name)
+	assert.Nil(t, spec.Performer)
+	if assert.NotNil(t, spec.Scene) {
+		assert.Equal(t, []string{"example.com/scene/"}, spec.Scene.Urls)
+		assert.Equal(t, []ScrapeType{ScrapeTypeName, ScrapeTypeFragment, ScrapeTypeURL}, spec.Scene.SupportedScrapes)
+	}
+
+	assert.True(t, s.supportsURL("https://example.com/scene/1", ScrapeContentTypeScene))
+	assert.False(t, s.supportsURL("https://example.com/scene/1", ScrapeContentTypePerformer))
+	assert.False(t, s.supportsURL("https://example.com/other/1", ScrapeContentTypeScene))
+}
+
+func TestProviderScraper_queries(t *testing.T) {
+	ctx := context.Background()
+	p := &testSceneProvider{}
+	s := providerScraper{provider: p}
+
+	content, err := s.viaName(ctx, nil, "name", ScrapeContentTypeScene)
+	assert.NoError(t, err)
+	assert.Len(t, content, 2)
+
+	_, err = s.viaName(ctx, nil, "name", ScrapeContentTypePerformer)
+	assert.ErrorIs(t, err, ErrNotSupported)
+
+	title := "fragment"
+	remoteID := "remote"
+	got, err := s.viaFragment(ctx, nil, Input{Scene: &ScrapedSceneInput{Title: &title, RemoteSiteID: &remoteID}})
+	assert.NoError(t, err)
+	assert.Equal(t, "fragment", *got.(*ScrapedScene).Title)
+	assert.Equal(t, SceneQuery{Title: "fragment", ExternalID: "remote"}, p.queries[len(p.queries)-1])
+
+	title = "none"
+	got, err = s.viaFragment(ctx, nil, Input{Scene: &ScrapedSceneInput{Title: &title}})
+	assert.NoError(t, err)
+	assert.Nil(t, got, "no results should return an untyped nil")
+
+	_, err = s.viaURL(ctx, nil, "https://example.com/scene/1", ScrapeContentTypeScene)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/scene/1"}, p.queries[len(p.queries)-1].URLs)
+
+	date, _ := models.ParseDate("2021-03-14")
+	scene := &models.Scene{
+		Title: "scene",
+		Date:  &date,
+		URLs:  models.NewRelatedStrings([]string{"https://example.com/scene/1"}),
+		Files: models.NewRelatedVideoFiles([]*models.VideoFile{
+			{
+				BaseFile: &models.BaseFile{
+					Fingerprints: models.Fingerprints{{Type: models.FingerprintTypeOshash, Fingerprint: "abc"}},
+				},
+				Duration: 60,
+			},
+		}),
+	}
+	_, err = s.viaScene(ctx, nil, scene)
+	assert.NoError(t, err)
+	assert.Equal(t, SceneQuery{
+		Title:        "scene",
+		Date:         "2021-03-14",
+		URLs:         []string{"https://example.com/scene/1"},
+		Fingerprints: models.Fingerprints{{Type: models.FingerprintTypeOshash, Fingerprint: "abc"}},
+		Duration:     60,
+	}, p.queries[len(p.queries)-1])
+}
@@ -0,0 +1,462 @@
+package scraper
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// proxyHealthCheckInterval is how often each proxy is checked
+	proxyHealthCheckInterval = 5 * time.Minute
+	proxyHealthCheckTimeout  = 15 * time.Second
+
+	proxyTokenTimeout = 30 * time.Second
+	// proxyTokenExpiryDelta is how long before its expiry a token is refreshed
+	proxyTokenExpiryDelta = 30 * time.Second
+)
+
+// ErrNoProxyAvailable is returned when a scraper is assigned proxies, but
+// none of them are healthy.
+var ErrNoProxyAvailable = errors.New("no healthy scraper proxy available")
+
+// Proxy is a proxy server that scraper requests are routed through.
+type Proxy struct {
+	// Name identifies the proxy in logs.
+	Name string `json:"name"`
+	// URL is the address of the proxy. http, https and socks5 proxies are
+	// supported.
+	URL string `json:"url"`
+	// TokenURL is the OAuth2 token endpoint used to authenticate with the
+	// proxy using the client credentials grant. If empty, requests are not
+	// authenticated, other than with any credentials in URL.
+	TokenURL     string   `json:"token_url"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	// Scrapers are the IDs of the scrapers that use the proxy. If empty, the
+	// proxy is shared by all scrapers that are not assigned a proxy.
+	Scrapers []string `json:"scrapers"`
+}
+
+// Validate returns an error if the proxy cannot be used.
+func (p Proxy) Validate() error {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return fmt.Errorf("proxy url %q invalid: %w", p.URL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+	case "socks5":
+		if p.TokenURL != "" {
+			return errors.New("oauth2 authentication is not supported for socks5 proxies")
+		}
+	default:
+		return fmt.Errorf("proxy url %q must be http, https or socks5", p.URL)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("proxy url %q has no host", p.URL)
+	}
+
+	if p.TokenURL != "" {
+		if _, err := url.ParseRequestURI(p.TokenURL); err != nil {
+			return fmt.Errorf("token url %q invalid: %w", p.TokenURL, err)
+		}
+		if p.ClientID == "" {
+			return errors.New("client id is required when token url is set")
+		}
+	}
+
+	return nil
+}
+
+func (p Proxy) String() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.URL
+}
+
+type scraperIDContextKey struct{}
+
+// withScraperID returns a context for requests made by the scraper with the
+// given ID, so that they are routed through the proxies assigned to it.
+func withScraperID(ctx context.Context, scraperID string) context.Context {
+	return context.WithValue(ctx, scraperIDContextKey{}, scraperID)
+}
+
+func scraperIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(scraperIDContextKey{}).(string)
+	return id
+}
+
+type proxyContextKey struct{}
+
+// proxyState is a configured proxy, along with its token and health.
+type proxyState struct {
+	Proxy
+	url *url.URL
+
+	mutex       sync.Mutex
+	token       string
+	tokenExpiry time.Time
+
+	healthMutex sync.Mutex
+	healthy     bool
+	lastChecked time.Time
+	checking    bool
+}
+
+func (p *proxyState) isHealthy() bool {
+	p.healthMutex.Lock()
+	defer p.healthMutex.Unlock()
+	return p.healthy
+}
+
+// setHealthy records the result of a health check or request.
+func (p *proxyState) setHealthy(healthy bool) {
+	p.healthMutex.Lock()
+	defer p.healthMutex.Unlock()
+
+	if p.healthy != healthy {
+		if healthy {
+			logger.Infof("Scraper proxy %s is available", p)
+		} else {
+			logger.Warnf("Scraper proxy %s is unavailable", p)
+		}
+	}
+
+	p.healthy = healthy
+	p.lastChecked = time.Now()
+}
+
+// markUnhealthy marks the proxy as unhealthy after a failed request. The
+// proxy is checked again the next time it is picked.
+func (p *proxyState) markUnhealthy() {
+	p.setHealthy(false)
+
+	p.healthMutex.Lock()
+	defer p.healthMutex.Unlock()
+	p.lastChecked = time.Time{}
+}
+
+// startCheck returns true if a health check is due, marking the check as
+// in progress.
+func (p *proxyState) startCheck() bool {
+	p.healthMutex.Lock()
+	defer p.healthMutex.Unlock()
+
+	if p.checking || time.Since(p.lastChecked) < proxyHealthCheckInterval {
+		return false
+	}
+
+	p.checking = true
+	return true
+}
+
+func (p *proxyState) endCheck() {
+	p.healthMutex.Lock()
+	defer p.healthMutex.Unlock()
+	p.checking = false
+}
+
+func (p *proxyState) invalidateToken() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.token = ""
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// getToken returns the access token used to authenticate with the proxy,
+// requesting a new one if needed. Returns an empty string if the proxy does
+// not use OAuth2.
+func (p *proxyState) getToken(ctx context.Context, client *http.Client) (string, error) {
+	if p.TokenURL == "" {
+		return "", nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.token != "" && (p.tokenExpiry.IsZero() || time.Now().Before(p.tokenExpiry)) {
+		return p.token, nil
+	}
+
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+	}
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, proxyTokenTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting token for proxy %s: %w", p, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading token for proxy %s: %w", p, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting token for proxy %s: http error %d: %s", p, resp.StatusCode, body)
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return "", fmt.Errorf("decoding token for proxy %s: %w", p, err)
+	}
+
+	if tr.AccessToken == "" {
+		return "", fmt.Errorf("token response for proxy %s has no access token", p)
+	}
+
+	if tr.TokenType != "" && !strings.EqualFold(tr.TokenType, "bearer") {
+		return "", fmt.Errorf("proxy %s: unsupported token type %q", p, tr.TokenType)
+	}
+
+	p.token = tr.AccessToken
+	p.tokenExpiry = time.Time{}
+	if tr.ExpiresIn > 0 {
+		p.tokenExpiry = time.Now().Add(time.Duration(tr.ExpiresIn)*time.Second - proxyTokenExpiryDelta)
+	}
+
+	return p.token, nil
+}
+
+// proxyTransport routes requests through the proxies assigned to the
+// scraper making them. Proxies are rotated between requests, and proxies
+// that fail health checks are skipped until they recover.
+type proxyTransport struct {
+	base *http.Transport
+	// client is used for token requests, which are not proxied
+	client *http.Client
+
+	proxies        []*proxyState
+	healthCheckURL string
+
+	next atomic.Uint64
+}
+
+func newProxyTransport(proxies []*Proxy, healthCheckURL string, certCheck bool) *proxyTransport {
+	ret := &proxyTransport{
+		healthCheckURL: healthCheckURL,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: !certCheck},
+				Proxy:           http.ProxyFromEnvironment,
+			},
+			Timeout: proxyTokenTimeout,
+		},
+	}
+
+	for _, p := range proxies {
+		if err := p.Validate(); err != nil {
+			logger.Errorf("Ignoring scraper proxy %s: %v", p, err)
+			continue
+		}
+
+		// already validated
+		u, _ := url.Parse(p.URL)
+		ret.proxies = append(ret.proxies, &proxyState{
+			Proxy:   *p,
+			url:     u,
+			healthy: true,
+		})
+	}
+
+	ret.base = &http.Transport{ // ignore insecure certificates
+		TLSClientConfig:       &tls.Config{InsecureSkipVerify: !certCheck},
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		Proxy:                 ret.proxyURL,
+		GetProxyConnectHeader: ret.proxyConnectHeader,
+	}
+
+	return ret
+}
+
+// proxyURL returns the proxy chosen for the request, or the proxy from the
+// environment if the scraper is not assigned a proxy.
+func (t *proxyTransport) proxyURL(req *http.Request) (*url.URL, error) {
+	if p, ok := req.Context().Value(proxyContextKey{}).(*proxyState); ok {
+		return p.url, nil
+	}
+
+	return http.ProxyFromEnvironment(req)
+}
+
+func (t *proxyTransport) findProxy(proxyURL *url.URL) *proxyState {
+	for _, p := range t.proxies {
+		if p.url.String() == proxyURL.String() {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// proxyConnectHeader returns the headers sent when tunnelling https requests
+// through a proxy.
+func (t *proxyTransport) proxyConnectHeader(ctx context.Context, proxyURL *url.URL, target string) (http.Header, error) {
+	p := t.findProxy(proxyURL)
+	if p == nil {
+		return nil, nil
+	}
+
+	token, err := p.getToken(ctx, t.client)
+	if err != nil || token == "" {
+		return nil, err
+	}
+
+	return http.Header{
+		"Proxy-Authorization": {"Bearer " + token},
+	}, nil
+}
+
+// candidates returns the proxies used by the scraper with the given ID.
+// Scrapers that are not assigned a proxy use the shared proxies.
+func (t *proxyTransport) candidates(scraperID string) []*proxyState {
+	var assigned, shared []*proxyState
+	for _, p := range t.proxies {
+		switch {
+		case len(p.Scrapers) == 0:
+			shared = append(shared, p)
+		case scraperID != "" && slices.Contains(p.Scrapers, scraperID):
+			assigned = append(assigned, p)
+		}
+	}
+
+	if len(assigned) > 0 {
+		return assigned
+	}
+	return shared
+}
+
+// pick returns the next healthy proxy for the scraper with the given ID.
+// Returns nil if the scraper does not use a proxy.
+func (t *proxyTransport) pick(scraperID string) (*proxyState, error) {
+	candidates := t.candidates(scraperID)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var healthy []*proxyState
+	for _, p := range candidates {
+		if p.startCheck() {
+			go t.check(p)
+		}
+
+		if p.isHealthy() {
+			healthy = append(healthy, p)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil, ErrNoProxyAvailable
+	}
+
+	i := t.next.Add(1) % uint64(len(healthy))
+	return healthy[i], nil
+}
+
+// check requests the health check url through the proxy.
+func (t *proxyTransport) check(p *proxyState) {
+	defer p.endCheck()
+
+	ctx, cancel := context.WithTimeout(context.Background(), proxyHealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.healthCheckURL, nil)
+	if err != nil {
+		logger.Errorf("Invalid scraper proxy health check url %q: %v", t.healthCheckURL, err)
+		return
+	}
+
+	resp, err := t.roundTripVia(req, p)
+	if err != nil {
+		logger.Debugf("Scraper proxy %s health check failed: %v", p, err)
+		p.setHealthy(false)
+		return
+	}
+	defer resp.Body.Close()
+
+	healthy := resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusProxyAuthRequired
+	if !healthy {
+		logger.Debugf("Scraper proxy %s health check failed: http error %d", p, resp.StatusCode)
+	}
+	p.setHealthy(healthy)
+}
+
+// roundTripVia sends the request through the given proxy.
+func (t *proxyTransport) roundTripVia(req *http.Request, p *proxyState) (*http.Response, error) {
+	req = req.Clone(context.WithValue(req.Context(), proxyContextKey{}, p))
+
+	// https requests are authenticated when the tunnel is established
+	if req.URL.Scheme == "http" {
+		token, err := p.getToken(req.Context(), t.client)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			req.Header.Set("Proxy-Authorization", "Bearer "+token)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusProxyAuthRequired {
+		p.invalidateToken()
+	}
+
+	return resp, err
+}
+
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p, err := t.pick(scraperIDFromContext(req.Context()))
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	resp, err := t.roundTripVia(req, p)
+	if err != nil && req.Context().Err() == nil {
+		logger.Debugf("Request via scraper proxy %s failed: %v", p, err)
+		p.markUnhealthy()
+	}
+
+	return resp, err
+}
@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxy   Proxy
+		wantErr bool
+	}{
+		{"http", Proxy{URL: "http://proxy:8080"}, false},
+		{"socks5", Proxy{URL: "socks5://proxy:1080"}, false},
+		{"oauth2", Proxy{URL: "https://proxy", TokenURL: "https://auth/token", ClientID: "id"}, false},
+		{"invalid scheme", Proxy{URL: "ftp://proxy"}, true},
+		{"no host", Proxy{URL: "http://"}, true},
+		{"socks5 oauth2", Proxy{URL: "socks5://proxy", TokenURL: "https://auth/token", ClientID: "id"}, true},
+		{"no client id", Proxy{URL: "http://proxy", TokenURL: "https://auth/token"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.proxy.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
+
+func TestProxyTransport_pick(t *testing.T) {
+	tr := newProxyTransport([]*Proxy{
+		{Name: "shared1", URL: "http://shared1"},
+		{Name: "shared2", URL: "http://shared2"},
+		{Name: "assigned", URL: "http://assigned", Scrapers: []string{"a"}},
+	}, "http://check", true)
+
+	// don't run health checks
+	for _, p := range tr.proxies {
+		p.checking = true
+	}
+
+	picked := func(scraperID string) string {
+		p, err := tr.pick(scraperID)
+		if err != nil {
+			t.Fatalf("pick(%q) error = %v", scraperID, err)
+		}
+		return p.Name
+	}
+
+	assert.Equal(t, "assigned", picked("a"))
+	assert.Equal(t, "assigned", picked("a"))
+
+	// shared proxies are rotated
+	first := picked("b")
+	assert.NotEqual(t, first, picked("b"))
+	assert.Equal(t, first, picked("b"))
+
+	// unhealthy proxies are skipped
+	tr.proxies[0].healthy = false
+	assert.Equal(t, "shared2", picked("b"))
+	assert.Equal(t, "shared2", picked(""))
+
+	tr.proxies[2].healthy = false
+	_, err := tr.pick("a")
+	assert.ErrorIs(t, err, ErrNoProxyAvailable)
+}
+
+func TestProxyTransport_RoundTrip(t *testing.T) {
+	tokens := 0
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if r.FormValue("grant_type") != "client_credentials" || id != "id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		tokens++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token%d","token_type":"bearer","expires_in":3600}`, tokens)
+	}))
+	defer auth.Close()
+
+	// acts as the proxy, since requests for http urls are sent to the proxy
+	// in full
+	var gotAuth, gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Proxy-Authorization")
+		gotURL = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	tr := newProxyTransport([]*Proxy{
+		{URL: proxy.URL, TokenURL: auth.URL, ClientID: "id", ClientSecret: "secret", Scrapers: []string{"a"}},
+	}, "http://check", true)
+	tr.proxies[0].checking = true
+
+	client := &http.Client{Transport: tr}
+
+	do := func(ctx context.Context) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/page", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	do(withScraperID(context.Background(), "a"))
+	assert.Equal(t, "Bearer token1", gotAuth)
+	assert.Equal(t, "http://example.com/page", gotURL)
+
+	// token is reused
+	do(withScraperID(context.Background(), "a"))
+	assert.Equal(t, "Bearer token1", gotAuth)
+	assert.Equal(t, 1, tokens)
+}
@@ -0,0 +1,343 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/python"
+)
+
+// name of the file recording the declaration an environment was created from
+const runtimeStateFile = ".stash-runtime.json"
+
+// runtimeConfig declares the runtime environments of a script scraper.
+// Environments are created in the scraper runtimes directory the first time
+// the scraper is run, and recreated when the declaration changes.
+type runtimeConfig struct {
+	Python *pythonRuntimeConfig `yaml:"python"`
+	Node   *nodeRuntimeConfig   `yaml:"node"`
+}
+
+func (c runtimeConfig) validate() error {
+	if c.Python != nil {
+		if err := validateRuntimePackages("python requirement", c.Python.Requirements); err != nil {
+			return err
+		}
+	}
+
+	if c.Node != nil {
+		if err := validateRuntimePackages("node dependency", c.Node.Dependencies); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRuntimePackages ensures that the packages cannot be interpreted as
+// options by the package manager.
+func validateRuntimePackages(kind string, packages []string) error {
+	for _, p := range packages {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return fmt.Errorf("%s must not be empty", kind)
+		}
+		if strings.HasPrefix(p, "-") {
+			return fmt.Errorf("%s %q must not start with '-'", kind, p)
+		}
+	}
+
+	return nil
+}
+
+// pythonRuntimeConfig declares a python virtual environment.
+type pythonRuntimeConfig struct {
+	// Version pins the python version of the environment, for example "3.11".
+	// The environment is created using the python<version> executable if
+	// present, otherwise the configured python executable, which must match
+	// the version.
+	Version string `yaml:"version"`
+	// Requirements are the pip requirement specifiers to install, for
+	// example "requests==2.31.0".
+	Requirements []string `yaml:"requirements"`
+}
+
+// nodeRuntimeConfig declares a node package environment.
+type nodeRuntimeConfig struct {
+	// Version pins the version of the node executable, for example "20".
+	Version string `yaml:"version"`
+	// Dependencies are the npm package specifiers to install, for example
+	// "cheerio@1.0.0".
+	Dependencies []string `yaml:"dependencies"`
+}
+
+// versionMatches returns true if version matches the pinned version. A pin
+// matches all versions that it is a prefix of, component-wise, so that "3.11"
+// matches "3.11.4" but not "3.1".
+func versionMatches(pin, version string) bool {
+	pin = strings.TrimPrefix(strings.TrimSpace(pin), "v")
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	if pin == "" {
+		return true
+	}
+
+	pinParts := strings.Split(pin, ".")
+	versionParts := strings.Split(version, ".")
+	if len(pinParts) > len(versionParts) {
+		return false
+	}
+
+	for i, p := range pinParts {
+		if p != versionParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// runtimeLocks prevents an environment from being created concurrently by
+// simultaneous scrapes. Keyed by environment directory.
+var runtimeLocks sync.Map
+
+func lockRuntime(dir string) func() {
+	v, _ := runtimeLocks.LoadOrStore(dir, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// runtimeEnvironment is a runtime environment of a scraper.
+type runtimeEnvironment struct {
+	// dir is the directory containing the environment
+	dir string
+	// state identifies the declaration the environment is created from
+	state string
+}
+
+func newRuntimeEnvironment(runtimesPath string, scraperID string, name string, declaration interface{}) (*runtimeEnvironment, error) {
+	if runtimesPath == "" {
+		return nil, errors.New("scraper runtimes path is not set")
+	}
+
+	data, err := json.Marshal(declaration)
+	if err != nil {
+		return nil, err
+	}
+
+	// virtual environments must be created with an absolute path
+	runtimesPath, err = filepath.Abs(runtimesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runtimeEnvironment{
+		dir:   filepath.Join(runtimesPath, scraperID, name),
+		state: md5.FromBytes(data),
+	}, nil
+}
+
+// ensure creates the environment using create, if it does not exist or was
+// created from a different declaration.
+func (e *runtimeEnvironment) ensure(create func() error) error {
+	unlock := lockRuntime(e.dir)
+	defer unlock()
+
+	stateFile := filepath.Join(e.dir, runtimeStateFile)
+	if existing, err := os.ReadFile(stateFile); err == nil && string(existing) == e.state {
+		return nil
+	}
+
+	if err := os.RemoveAll(e.dir); err != nil {
+		return fmt.Errorf("removing existing environment: %w", err)
+	}
+
+	if err := fsutil.EnsureDirAll(e.dir); err != nil {
+		return fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	if err := create(); err != nil {
+		// remove the incomplete environment so that it is recreated next time
+		if rmErr := os.RemoveAll(e.dir); rmErr != nil {
+			logger.Warnf("error removing incomplete environment %s: %v", e.dir, rmErr)
+		}
+		return err
+	}
+
+	return os.WriteFile(stateFile, []byte(e.state), 0644)
+}
+
+// runRuntimeCommand runs a command used to create an environment, returning
+// its output in the error if it fails.
+func runRuntimeCommand(ctx context.Context, dir string, name string, args ...string) error {
+	cmd := stashExec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+
+	logger.Debugf("Running %s", strings.Join(cmd.Args, " "))
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", strings.Join(cmd.Args, " "), err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// outputOf returns the trimmed standard output of a command.
+func outputOf(ctx context.Context, name string, args ...string) (string, error) {
+	output, err := stashExec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// resolvePythonRuntime returns the python executable used to create the
+// virtual environment.
+func resolvePythonRuntime(ctx context.Context, c *pythonRuntimeConfig, configuredPythonPath string) (string, error) {
+	var pythonPath string
+
+	// prefer the executable for the pinned version, for example python3.11
+	if c.Version != "" {
+		if p, err := exec.LookPath("python" + c.Version); err == nil {
+			pythonPath = p
+		}
+	}
+
+	if pythonPath == "" {
+		p, err := python.Resolve(configuredPythonPath)
+		if err != nil {
+			return "", err
+		}
+		pythonPath = string(*p)
+	}
+
+	if c.Version != "" {
+		version, err := outputOf(ctx, pythonPath, "-c", "import platform; print(platform.python_version())")
+		if err != nil {
+			return "", fmt.Errorf("getting version of %s: %w", pythonPath, err)
+		}
+		if !versionMatches(c.Version, version) {
+			return "", fmt.Errorf("python version %s required, but %s is version %s", c.Version, pythonPath, version)
+		}
+	}
+
+	return pythonPath, nil
+}
+
+// venvPython returns the path of the python executable in a virtual
+// environment.
+func venvPython(dir string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "Scripts", "python.exe")
+	}
+	return filepath.Join(dir, "bin", "python")
+}
+
+// ensurePythonRuntime creates the python virtual environment of the scraper
+// if necessary, and returns the path of its python executable.
+func ensurePythonRuntime(ctx context.Context, globalConfig GlobalConfig, scraperID string, c *pythonRuntimeConfig) (string, error) {
+	env, err := newRuntimeEnvironment(globalConfig.GetScraperRuntimesPath(), scraperID, "python", c)
+	if err != nil {
+		return "", err
+	}
+
+	if err := env.ensure(func() error {
+		pythonPath, err := resolvePythonRuntime(ctx, c, globalConfig.GetPythonPath())
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("[%s] creating python environment in %s", scraperID, env.dir)
+		if err := runRuntimeCommand(ctx, env.dir, pythonPath, "-m", "venv", env.dir); err != nil {
+			return fmt.Errorf("creating python environment: %w", err)
+		}
+
+		if len(c.Requirements) > 0 {
+			logger.Infof("[%s] installing python requirements: %s", scraperID, strings.Join(c.Requirements, ", "))
+			args := append([]string{"-m", "pip", "install", "--disable-pip-version-check", "--no-input", "--"}, c.Requirements...)
+			if err := runRuntimeCommand(ctx, env.dir, venvPython(env.dir), args...); err != nil {
+				return fmt.Errorf("installing python requirements: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	return venvPython(env.dir), nil
+}
+
+// ensureNodeRuntime installs the node dependencies of the scraper if
+// necessary, and returns the path of the node_modules directory.
+func ensureNodeRuntime(ctx context.Context, globalConfig GlobalConfig, scraperID string, c *nodeRuntimeConfig) (string, error) {
+	env, err := newRuntimeEnvironment(globalConfig.GetScraperRuntimesPath(), scraperID, "node", c)
+	if err != nil {
+		return "", err
+	}
+
+	if err := env.ensure(func() error {
+		if c.Version != "" {
+			version, err := outputOf(ctx, "node", "--version")
+			if err != nil {
+				return fmt.Errorf("getting node version: %w", err)
+			}
+			if !versionMatches(c.Version, version) {
+				return fmt.Errorf("node version %s required, but node is version %s", c.Version, version)
+			}
+		}
+
+		if len(c.Dependencies) == 0 {
+			return nil
+		}
+
+		// prevent npm from searching parent directories for a package
+		if err := os.WriteFile(filepath.Join(env.dir, "package.json"), []byte(`{"private": true}`), 0644); err != nil {
+			return err
+		}
+
+		logger.Infof("[%s] installing node dependencies: %s", scraperID, strings.Join(c.Dependencies, ", "))
+		args := append([]string{"install", "--no-audit", "--no-fund", "--prefix", env.dir}, c.Dependencies...)
+		if err := runRuntimeCommand(ctx, env.dir, "npm", args...); err != nil {
+			return fmt.Errorf("installing node dependencies: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(env.dir, "node_modules"), nil
+}
+
+// isNodeCommand returns true if arg is "node"
+func isNodeCommand(arg string) bool {
+	return arg == "node"
+}
+
+// appendEnv appends the environment variable to the environment of cmd,
+// prepending it to the existing value if set.
+func appendEnv(cmd *exec.Cmd, name string, value string) {
+	if currentValue, set := os.LookupEnv(name); set {
+		value = fmt.Sprintf("%s%c%s", value, os.PathListSeparator, currentValue)
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", name, value))
+}
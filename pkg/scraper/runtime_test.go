@@ -0,0 +1,110 @@
+package scraper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		pin     string
+		version string
+		want    bool
+	}{
+		{"", "3.11.4", true},
+		{"3", "3.11.4", true},
+		{"3.11", "3.11.4", true},
+		{"3.11.4", "3.11.4", true},
+		{"3.1", "3.11.4", false},
+		{"3.11.4.1", "3.11.4", false},
+		{"3.12", "3.11.4", false},
+		{"20", "v20.11.0", true},
+		{"v20", "v20.11.0", true},
+		{"2", "v20.11.0", false},
+	}
+
+	for _, tt := range tests {
+		got := versionMatches(tt.pin, tt.version)
+		assert.Equal(t, tt.want, got, "versionMatches(%q, %q)", tt.pin, tt.version)
+	}
+}
+
+func TestRuntimeConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       runtimeConfig
+		wantErr bool
+	}{
+		{
+			"valid",
+			runtimeConfig{
+				Python: &pythonRuntimeConfig{Version: "3.11", Requirements: []string{"requests==2.31.0", "lxml"}},
+				Node:   &nodeRuntimeConfig{Version: "20", Dependencies: []string{"cheerio@1.0.0"}},
+			},
+			false,
+		},
+		{
+			"empty requirement",
+			runtimeConfig{Python: &pythonRuntimeConfig{Requirements: []string{" "}}},
+			true,
+		},
+		{
+			"python option",
+			runtimeConfig{Python: &pythonRuntimeConfig{Requirements: []string{"--index-url=http://example.com"}}},
+			true,
+		},
+		{
+			"node option",
+			runtimeConfig{Node: &nodeRuntimeConfig{Dependencies: []string{"-g"}}},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.c.validate()
+			assert.Equal(t, tt.wantErr, err != nil, "validate() error = %v", err)
+		})
+	}
+}
+
+func TestRuntimeEnvironmentEnsure(t *testing.T) {
+	runtimesPath := t.TempDir()
+
+	newEnv := func(requirements ...string) *runtimeEnvironment {
+		env, err := newRuntimeEnvironment(runtimesPath, "scraper", "python", &pythonRuntimeConfig{Requirements: requirements})
+		if err != nil {
+			t.Fatalf("newRuntimeEnvironment: %v", err)
+		}
+		return env
+	}
+
+	created := 0
+	create := func() error {
+		created++
+		return nil
+	}
+
+	env := newEnv("requests")
+	assert.Equal(t, filepath.Join(runtimesPath, "scraper", "python"), env.dir)
+
+	assert.Nil(t, env.ensure(create))
+	assert.Equal(t, 1, created, "environment should be created")
+
+	assert.Nil(t, newEnv("requests").ensure(create))
+	assert.Equal(t, 1, created, "unchanged environment should not be recreated")
+
+	assert.Nil(t, newEnv("requests", "lxml").ensure(create))
+	assert.Equal(t, 2, created, "changed environment should be recreated")
+
+	// failed creation should remove the environment
+	failErr := errors.New("failed")
+	env = newEnv("missing")
+	assert.ErrorIs(t, env.ensure(func() error { return failErr }), failErr)
+	_, err := os.Stat(env.dir)
+	assert.True(t, os.IsNotExist(err), "incomplete environment should be removed")
+}
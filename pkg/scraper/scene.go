@@ -23,10 +23,19 @@ type ScrapedScene struct {
 	RemoteSiteID *string                       `json:"remote_site_id"`
 	Duration     *int                          `json:"duration"`
 	Fingerprints []*models.StashBoxFingerprint `json:"fingerprints"`
+	Markers      []*ScrapedSceneMarker         `json:"markers"`
 }
 
 func (ScrapedScene) IsScrapedContent() {}
 
+type ScrapedSceneMarker struct {
+	Title      string               `json:"title"`
+	Seconds    float64              `json:"seconds"`
+	EndSeconds *float64             `json:"end_seconds"`
+	PrimaryTag *models.ScrapedTag   `json:"primary_tag"`
+	Tags       []*models.ScrapedTag `json:"tags"`
+}
+
 type ScrapedSceneInput struct {
 	Title        *string  `json:"title"`
 	Code         *string  `json:"code"`
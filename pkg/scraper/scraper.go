@@ -20,6 +20,10 @@ type Source struct {
 	StashBoxEndpoint *string `json:"stash_box_endpoint"`
 	// Scraper ID to scrape with. Should be unset if stash_box_index is set
 	ScraperID *string `json:"scraper_id"`
+	// URL of the federated stash instance
+	FederatedInstanceURL *string `json:"federated_instance_url"`
+	// Read metadata from the files alongside the scene's file
+	LocalFiles *bool `json:"local_files"`
 }
 
 // Scraped Content is the forming union over the different scrapers
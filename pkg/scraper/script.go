@@ -226,8 +226,21 @@ func (s *scriptScraper) runScraperScript(ctx context.Context, inString string, o
 
 	var cmd *exec.Cmd
 	if python.IsPythonCommand(command[0]) {
-		pythonPath := s.globalConfig.GetPythonPath()
-		p, err := python.Resolve(pythonPath)
+		var p *python.Python
+		var err error
+
+		if s.config.Runtime != nil && s.config.Runtime.Python != nil {
+			// use the managed environment of the scraper
+			var venvPath string
+			venvPath, err = ensurePythonRuntime(ctx, s.globalConfig, s.config.ID, s.config.Runtime.Python)
+			if err != nil {
+				return fmt.Errorf("preparing python environment: %w", err)
+			}
+			p = python.New(venvPath)
+		} else {
+			pythonPath := s.globalConfig.GetPythonPath()
+			p, err = python.Resolve(pythonPath)
+		}
 
 		if err != nil {
 			logger.Warnf("%s", err)
@@ -243,6 +256,14 @@ func (s *scriptScraper) runScraperScript(ctx context.Context, inString string, o
 		cmd = stashExec.CommandContext(ctx, command[0], command[1:]...)
 	}
 
+	if isNodeCommand(command[0]) && s.config.Runtime != nil && s.config.Runtime.Node != nil {
+		modulesPath, err := ensureNodeRuntime(ctx, s.globalConfig, s.config.ID, s.config.Runtime.Node)
+		if err != nil {
+			return fmt.Errorf("preparing node environment: %w", err)
+		}
+		appendEnv(cmd, "NODE_PATH", modulesPath)
+	}
+
 	cmd.Dir = filepath.Dir(s.config.path)
 
 	stdin, err := cmd.StdinPipe()
@@ -22,6 +22,10 @@ type StashBoxGraphQLClient interface {
 	Me(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*Me, error)
 	SubmitSceneDraft(ctx context.Context, input SceneDraftInput, interceptors ...clientv2.RequestInterceptor) (*SubmitSceneDraft, error)
 	SubmitPerformerDraft(ctx context.Context, input PerformerDraftInput, interceptors ...clientv2.RequestInterceptor) (*SubmitPerformerDraft, error)
+	FavoritePerformers(ctx context.Context, page int, perPage int, interceptors ...clientv2.RequestInterceptor) (*FavoritePerformers, error)
+	FavoriteStudios(ctx context.Context, page int, perPage int, interceptors ...clientv2.RequestInterceptor) (*FavoriteStudios, error)
+	FavoritePerformer(ctx context.Context, id string, favorite bool, interceptors ...clientv2.RequestInterceptor) (*FavoritePerformer, error)
+	FavoriteStudio(ctx context.Context, id string, favorite bool, interceptors ...clientv2.RequestInterceptor) (*FavoriteStudio, error)
 }
 
 type Client struct {
@@ -648,6 +652,64 @@ func (t *SubmitPerformerDraft_SubmitPerformerDraft) GetID() *string {
 	return t.ID
 }
 
+type FavoritePerformers_QueryPerformers_Performers struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FavoritePerformers_QueryPerformers_Performers) GetID() string {
+	if t == nil {
+		t = &FavoritePerformers_QueryPerformers_Performers{}
+	}
+	return t.ID
+}
+
+type FavoritePerformers_QueryPerformers struct {
+	Count      int                                              "json:\"count\" graphql:\"count\""
+	Performers []*FavoritePerformers_QueryPerformers_Performers "json:\"performers\" graphql:\"performers\""
+}
+
+func (t *FavoritePerformers_QueryPerformers) GetCount() int {
+	if t == nil {
+		t = &FavoritePerformers_QueryPerformers{}
+	}
+	return t.Count
+}
+func (t *FavoritePerformers_QueryPerformers) GetPerformers() []*FavoritePerformers_QueryPerformers_Performers {
+	if t == nil {
+		t = &FavoritePerformers_QueryPerformers{}
+	}
+	return t.Performers
+}
+
+type FavoriteStudios_QueryStudios_Studios struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FavoriteStudios_QueryStudios_Studios) GetID() string {
+	if t == nil {
+		t = &FavoriteStudios_QueryStudios_Studios{}
+	}
+	return t.ID
+}
+
+type FavoriteStudios_QueryStudios struct {
+	Count   int                                     "json:\"count\" graphql:\"count\""
+	Studios []*FavoriteStudios_QueryStudios_Studios "json:\"studios\" graphql:\"studios\""
+}
+
+func (t *FavoriteStudios_QueryStudios) GetCount() int {
+	if t == nil {
+		t = &FavoriteStudios_QueryStudios{}
+	}
+	return t.Count
+}
+func (t *FavoriteStudios_QueryStudios) GetStudios() []*FavoriteStudios_QueryStudios_Studios {
+	if t == nil {
+		t = &FavoriteStudios_QueryStudios{}
+	}
+	return t.Studios
+}
+
 type FindSceneByFingerprint struct {
 	FindSceneByFingerprint []*SceneFragment "json:\"findSceneByFingerprint\" graphql:\"findSceneByFingerprint\""
 }
@@ -780,6 +842,50 @@ func (t *SubmitPerformerDraft) GetSubmitPerformerDraft() *SubmitPerformerDraft_S
 	return &t.SubmitPerformerDraft
 }
 
+type FavoritePerformers struct {
+	QueryPerformers FavoritePerformers_QueryPerformers "json:\"queryPerformers\" graphql:\"queryPerformers\""
+}
+
+func (t *FavoritePerformers) GetQueryPerformers() *FavoritePerformers_QueryPerformers {
+	if t == nil {
+		t = &FavoritePerformers{}
+	}
+	return &t.QueryPerformers
+}
+
+type FavoriteStudios struct {
+	QueryStudios FavoriteStudios_QueryStudios "json:\"queryStudios\" graphql:\"queryStudios\""
+}
+
+func (t *FavoriteStudios) GetQueryStudios() *FavoriteStudios_QueryStudios {
+	if t == nil {
+		t = &FavoriteStudios{}
+	}
+	return &t.QueryStudios
+}
+
+type FavoritePerformer struct {
+	FavoritePerformer bool "json:\"favoritePerformer\" graphql:\"favoritePerformer\""
+}
+
+func (t *FavoritePerformer) GetFavoritePerformer() bool {
+	if t == nil {
+		t = &FavoritePerformer{}
+	}
+	return t.FavoritePerformer
+}
+
+type FavoriteStudio struct {
+	FavoriteStudio bool "json:\"favoriteStudio\" graphql:\"favoriteStudio\""
+}
+
+func (t *FavoriteStudio) GetFavoriteStudio() bool {
+	if t == nil {
+		t = &FavoriteStudio{}
+	}
+	return t.FavoriteStudio
+}
+
 const FindSceneByFingerprintDocument = `query FindSceneByFingerprint ($fingerprint: FingerprintQueryInput!) {
 	findSceneByFingerprint(fingerprint: $fingerprint) {
 		... SceneFragment
@@ -1733,6 +1839,108 @@ func (c *Client) SubmitPerformerDraft(ctx context.Context, input PerformerDraftI
 	return &res, nil
 }
 
+const FavoritePerformersDocument = `query FavoritePerformers ($page: Int!, $per_page: Int!) {
+	queryPerformers(input: {is_favorite:true,page:$page,per_page:$per_page}) {
+		count
+		performers {
+			id
+		}
+	}
+}
+`
+
+func (c *Client) FavoritePerformers(ctx context.Context, page int, perPage int, interceptors ...clientv2.RequestInterceptor) (*FavoritePerformers, error) {
+	vars := map[string]any{
+		"page":     page,
+		"per_page": perPage,
+	}
+
+	var res FavoritePerformers
+	if err := c.Client.Post(ctx, "FavoritePerformers", FavoritePerformersDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FavoriteStudiosDocument = `query FavoriteStudios ($page: Int!, $per_page: Int!) {
+	queryStudios(input: {is_favorite:true,page:$page,per_page:$per_page}) {
+		count
+		studios {
+			id
+		}
+	}
+}
+`
+
+func (c *Client) FavoriteStudios(ctx context.Context, page int, perPage int, interceptors ...clientv2.RequestInterceptor) (*FavoriteStudios, error) {
+	vars := map[string]any{
+		"page":     page,
+		"per_page": perPage,
+	}
+
+	var res FavoriteStudios
+	if err := c.Client.Post(ctx, "FavoriteStudios", FavoriteStudiosDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FavoritePerformerDocument = `mutation FavoritePerformer ($id: ID!, $favorite: Boolean!) {
+	favoritePerformer(id: $id, favorite: $favorite)
+}
+`
+
+func (c *Client) FavoritePerformer(ctx context.Context, id string, favorite bool, interceptors ...clientv2.RequestInterceptor) (*FavoritePerformer, error) {
+	vars := map[string]any{
+		"id":       id,
+		"favorite": favorite,
+	}
+
+	var res FavoritePerformer
+	if err := c.Client.Post(ctx, "FavoritePerformer", FavoritePerformerDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FavoriteStudioDocument = `mutation FavoriteStudio ($id: ID!, $favorite: Boolean!) {
+	favoriteStudio(id: $id, favorite: $favorite)
+}
+`
+
+func (c *Client) FavoriteStudio(ctx context.Context, id string, favorite bool, interceptors ...clientv2.RequestInterceptor) (*FavoriteStudio, error) {
+	vars := map[string]any{
+		"id":       id,
+		"favorite": favorite,
+	}
+
+	var res FavoriteStudio
+	if err := c.Client.Post(ctx, "FavoriteStudio", FavoriteStudioDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 var DocumentOperationNames = map[string]string{
 	FindSceneByFingerprintDocument:        "FindSceneByFingerprint",
 	FindScenesByFullFingerprintsDocument:  "FindScenesByFullFingerprints",
@@ -1746,4 +1954,8 @@ var DocumentOperationNames = map[string]string{
 	MeDocument:                            "Me",
 	SubmitSceneDraftDocument:              "SubmitSceneDraft",
 	SubmitPerformerDraftDocument:          "SubmitPerformerDraft",
+	FavoritePerformersDocument:            "FavoritePerformers",
+	FavoriteStudiosDocument:               "FavoriteStudios",
+	FavoritePerformerDocument:             "FavoritePerformer",
+	FavoriteStudioDocument:                "FavoriteStudio",
 }
@@ -706,6 +706,10 @@ func studioFragmentToScrapedStudio(s graphql.StudioFragment) *models.ScrapedStud
 		RemoteSiteID: &s.ID,
 	}
 
+	for _, u := range s.Urls {
+		st.URLs = append(st.URLs, u.URL)
+	}
+
 	if len(st.Images) > 0 {
 		st.Image = &st.Images[0]
 	}
@@ -942,6 +946,66 @@ func (c Client) GetUser(ctx context.Context) (*graphql.Me, error) {
 	return c.client.Me(ctx)
 }
 
+// favoritesPerPage is the page size used when querying the favorites of the
+// current user.
+const favoritesPerPage = 100
+
+// FavoritePerformerIDs returns the stash-box ids of the performers that the
+// current user has favorited.
+func (c Client) FavoritePerformerIDs(ctx context.Context) ([]string, error) {
+	var ret []string
+	for page := 1; ; page++ {
+		result, err := c.client.FavoritePerformers(ctx, page, favoritesPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		performers := result.GetQueryPerformers().GetPerformers()
+		for _, p := range performers {
+			ret = append(ret, p.GetID())
+		}
+
+		if len(performers) == 0 || len(ret) >= result.GetQueryPerformers().GetCount() {
+			return ret, nil
+		}
+	}
+}
+
+// FavoriteStudioIDs returns the stash-box ids of the studios that the current
+// user has favorited.
+func (c Client) FavoriteStudioIDs(ctx context.Context) ([]string, error) {
+	var ret []string
+	for page := 1; ; page++ {
+		result, err := c.client.FavoriteStudios(ctx, page, favoritesPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		studios := result.GetQueryStudios().GetStudios()
+		for _, s := range studios {
+			ret = append(ret, s.GetID())
+		}
+
+		if len(studios) == 0 || len(ret) >= result.GetQueryStudios().GetCount() {
+			return ret, nil
+		}
+	}
+}
+
+// SetPerformerFavorite sets the favorite status of the stash-box performer
+// for the current user.
+func (c Client) SetPerformerFavorite(ctx context.Context, id string, favorite bool) error {
+	_, err := c.client.FavoritePerformer(ctx, id, favorite)
+	return err
+}
+
+// SetStudioFavorite sets the favorite status of the stash-box studio for the
+// current user.
+func (c Client) SetStudioFavorite(ctx context.Context, id string, favorite bool) error {
+	_, err := c.client.FavoriteStudio(ctx, id, favorite)
+	return err
+}
+
 func appendFingerprintUnique(v []*graphql.FingerprintInput, toAdd *graphql.FingerprintInput) []*graphql.FingerprintInput {
 	for _, vv := range v {
 		if vv.Algorithm == toAdd.Algorithm && vv.Hash == toAdd.Hash {
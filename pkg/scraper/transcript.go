@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// transcriptScraperID is the scraper ID for the built-in transcript scraper
+const (
+	transcriptScraperID   = "builtin_transcript"
+	transcriptScraperName = "Audio Transcript"
+
+	// transcriptMarkerInterval is the minimum number of seconds between
+	// suggested markers for the same tag.
+	transcriptMarkerInterval = 60
+)
+
+// transcriptScraper matches performers and tags mentioned in the audio
+// transcript of a scene. Markers are suggested where tags are mentioned.
+type transcriptScraper struct {
+	txnManager      txn.Manager
+	sceneReader     models.SceneTranscriptReader
+	performerReader models.PerformerAutoTagQueryer
+	tagReader       models.TagAutoTagQueryer
+}
+
+func (s transcriptScraper) tagMarkers(ctx context.Context, segments []models.TranscriptSegment, tags []*models.ScrapedTag) ([]*ScrapedSceneMarker, error) {
+	var ret []*ScrapedSceneMarker
+
+	for _, t := range tags {
+		id, _ := strconv.Atoi(*t.StoredID)
+		aliases, err := s.tagReader.GetAliases(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		names := append([]string{t.Name}, aliases...)
+
+		last := -1.0
+		for _, seg := range segments {
+			if last >= 0 && seg.Start-last < transcriptMarkerInterval {
+				continue
+			}
+
+			for _, name := range names {
+				if match.NameMatchesText(name, seg.Text) {
+					ret = append(ret, &ScrapedSceneMarker{
+						Title:      t.Name,
+						Seconds:    seg.Start,
+						PrimaryTag: t,
+					})
+					last = seg.Start
+					break
+				}
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (s transcriptScraper) viaScene(ctx context.Context, _client *http.Client, scene *models.Scene) (*ScrapedScene, error) {
+	var ret *ScrapedScene
+	const trimExt = false
+
+	if err := txn.WithReadTxn(ctx, s.txnManager, func(ctx context.Context) error {
+		transcript, err := s.sceneReader.GetTranscript(ctx, scene.ID)
+		if err != nil {
+			return fmt.Errorf("transcript scraper viaScene: %w", err)
+		}
+		if transcript == nil {
+			return nil
+		}
+
+		text := transcript.Text()
+
+		performers, err := autotagMatchPerformers(ctx, text, s.performerReader, trimExt)
+		if err != nil {
+			return fmt.Errorf("transcript scraper viaScene: %w", err)
+		}
+
+		tags, err := autotagMatchTags(ctx, text, s.tagReader, trimExt)
+		if err != nil {
+			return fmt.Errorf("transcript scraper viaScene: %w", err)
+		}
+
+		markers, err := s.tagMarkers(ctx, transcript.Segments, tags)
+		if err != nil {
+			return fmt.Errorf("transcript scraper viaScene: %w", err)
+		}
+
+		if len(performers) > 0 || len(tags) > 0 {
+			ret = &ScrapedScene{
+				Performers: performers,
+				Tags:       tags,
+				Markers:    markers,
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s transcriptScraper) supports(ty ScrapeContentType) bool {
+	return ty == ScrapeContentTypeScene
+}
+
+func (s transcriptScraper) supportsURL(url string, ty ScrapeContentType) bool {
+	return false
+}
+
+func (s transcriptScraper) spec() Scraper {
+	return Scraper{
+		ID:   transcriptScraperID,
+		Name: transcriptScraperName,
+		Scene: &ScraperSpec{
+			SupportedScrapes: []ScrapeType{
+				ScrapeTypeFragment,
+			},
+		},
+	}
+}
+
+func getTranscriptScraper(repo Repository) scraper {
+	return transcriptScraper{
+		txnManager:      repo.TxnManager,
+		sceneReader:     repo.SceneFinder,
+		performerReader: repo.PerformerFinder,
+		tagReader:       repo.TagFinder,
+	}
+}
@@ -29,6 +29,9 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil && driverOptions.UseCDP {
 		// get the page using chrome dp
+		if t := debugTraceFromContext(ctx); t != nil {
+			return debugURLFromCDP(ctx, t, loadURL, *driverOptions, globalConfig)
+		}
 		return urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
 	}
 
@@ -67,17 +70,33 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		}
 	}
 
+	trace := debugTraceFromContext(ctx)
+	start := time.Now()
+
 	resp, err := client.Do(req)
 	if err != nil {
+		if trace != nil {
+			trace.addRequest(newDebugRequest(req, nil, nil, start, err))
+		}
 		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 400 {
+		err := fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		if trace != nil {
+			// capture the error page, which may explain the error
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxDebugBodySize+1))
+			trace.addRequest(newDebugRequest(req, resp, body, start, err))
+		}
+		return nil, err
+	}
+
 	body, err := io.ReadAll(resp.Body)
+	if trace != nil {
+		trace.addRequest(newDebugRequest(req, resp, body, start, err))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +106,36 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
 }
 
+// debugURLFromCDP loads the url using urlFromCDP, capturing the request in the
+// trace. Headers are not available when using chrome cdp.
+func debugURLFromCDP(ctx context.Context, trace *DebugTrace, urlCDP string, driverOptions scraperDriverOptions, globalConfig GlobalConfig) (io.Reader, error) {
+	start := time.Now()
+	r := &DebugRequest{
+		URL:    urlCDP,
+		Method: http.MethodGet,
+	}
+
+	var body []byte
+	ret, err := urlFromCDP(ctx, urlCDP, driverOptions, globalConfig)
+	if err == nil {
+		body, err = io.ReadAll(ret)
+	}
+
+	r.Duration = int(time.Since(start).Milliseconds())
+	r.setBody(body)
+	if err != nil {
+		errStr := err.Error()
+		r.Error = &errStr
+	}
+	trace.addRequest(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(body), nil
+}
+
 // func urlFromCDP uses chrome cdp and DOM to load and process the url
 // if remote is set as true in the scraperConfig  it will try to use localhost:9222
 // else it will look for google-chrome in path
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stashapp/stash/pkg/hash/md5"
+)
+
+// maxCheckContentSize is the maximum number of bytes of a response that are
+// hashed when checking a url.
+const maxCheckContentSize = 10 * 1024 * 1024
+
+// URLCheckResult is the response to a request of a url.
+type URLCheckResult struct {
+	StatusCode int
+	// RedirectURL is the absolute url that the url redirects to, if the
+	// response is a redirect.
+	RedirectURL string
+	// ContentHash is the hash of the content of a successful response.
+	ContentHash string
+}
+
+// CheckURL requests the url using the scraper http client, without following
+// redirects. Returns an error if the url could not be requested.
+func (c Cache) CheckURL(ctx context.Context, url string) (*URLCheckResult, error) {
+	client := *c.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if userAgent := c.globalConfig.GetScraperUserAgent(); userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	ret := &URLCheckResult{
+		StatusCode: resp.StatusCode,
+	}
+
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		location, err := resp.Location()
+		if err == nil {
+			ret.RedirectURL = location.String()
+		}
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		ret.ContentHash, err = md5.FromReader(io.LimitReader(resp.Body, maxCheckContentSize))
+		if err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+	}
+
+	return ret, nil
+}
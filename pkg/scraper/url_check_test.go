@@ -0,0 +1,60 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_CheckURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content"))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewCache(mockGlobalConfig{}, Repository{})
+
+	tests := []struct {
+		name            string
+		path            string
+		wantStatusCode  int
+		wantRedirectURL string
+		wantHash        bool
+	}{
+		{"ok", "/ok", http.StatusOK, "", true},
+		{"redirect", "/moved", http.StatusMovedPermanently, ts.URL + "/ok", false},
+		{"gone", "/gone", http.StatusGone, "", false},
+		{"not found", "/missing", http.StatusNotFound, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.CheckURL(context.Background(), ts.URL+tt.path)
+			if err != nil {
+				t.Errorf("Cache.CheckURL() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.wantStatusCode, got.StatusCode)
+			assert.Equal(t, tt.wantRedirectURL, got.RedirectURL)
+			assert.Equal(t, tt.wantHash, got.ContentHash != "")
+		})
+	}
+
+	// the same content has the same hash
+	a, _ := c.CheckURL(context.Background(), ts.URL+"/ok")
+	b, _ := c.CheckURL(context.Background(), ts.URL+"/ok")
+	assert.Equal(t, a.ContentHash, b.ContentHash)
+}
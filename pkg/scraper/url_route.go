@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// URLRoute maps scene URLs to a preferred scraper and identify preset.
+// A route matches URLs by either a pattern or a studio.
+type URLRoute struct {
+	// Pattern is a regular expression matched against the URL.
+	Pattern string `json:"pattern"`
+	// StudioID matches URLs on the same host as one of the URLs of the
+	// studio.
+	StudioID *int `json:"studio_id"`
+	// ScraperID is the scraper used for matching URLs. If empty, the first
+	// scraper that supports the URL is used.
+	ScraperID string `json:"scraper_id"`
+	// IdentifyPreset is the name of the identify preset whose field options
+	// are applied to the scraped scene.
+	IdentifyPreset string `json:"identify_preset"`
+}
+
+// Validate returns an error if the route cannot be used.
+func (r URLRoute) Validate() error {
+	if (r.Pattern == "") == (r.StudioID == nil) {
+		return errors.New("exactly one of pattern or studio must be set")
+	}
+
+	if r.ScraperID == "" && r.IdentifyPreset == "" {
+		return errors.New("at least one of scraper or identify preset must be set")
+	}
+
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("url pattern %q invalid: %w", r.Pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// urlHost returns the lowercase host of the URL, without any leading www.
+func urlHost(u string) string {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// MatchURLRoute returns the first route that matches the URL, or nil if none
+// match. studioURLs returns the URLs of the studio with the given ID, and is
+// only called for routes that match by studio.
+func MatchURLRoute(routes []*URLRoute, u string, studioURLs func(studioID int) []string) *URLRoute {
+	host := urlHost(u)
+
+	for _, r := range routes {
+		switch {
+		case r.Pattern != "":
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				continue
+			}
+			if re.MatchString(u) {
+				return r
+			}
+		case r.StudioID != nil:
+			if host == "" {
+				continue
+			}
+			for _, studioURL := range studioURLs(*r.StudioID) {
+				if urlHost(studioURL) == host {
+					return r
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLRoute_Validate(t *testing.T) {
+	studioID := 1
+
+	tests := []struct {
+		name    string
+		route   URLRoute
+		wantErr bool
+	}{
+		{"pattern", URLRoute{Pattern: `example\.com`, ScraperID: "s"}, false},
+		{"studio", URLRoute{StudioID: &studioID, IdentifyPreset: "p"}, false},
+		{"neither", URLRoute{ScraperID: "s"}, true},
+		{"both", URLRoute{Pattern: "a", StudioID: &studioID, ScraperID: "s"}, true},
+		{"no target", URLRoute{Pattern: "a"}, true},
+		{"invalid pattern", URLRoute{Pattern: "(", ScraperID: "s"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
+
+func TestMatchURLRoute(t *testing.T) {
+	studioID := 1
+	urls := map[int][]string{
+		studioID: {"https://www.Studio.example/", "https://store.example/studio"},
+	}
+	studioURLs := func(id int) []string {
+		return urls[id]
+	}
+
+	patternRoute := &URLRoute{Pattern: `^https://scenes\.example/`, ScraperID: "pattern"}
+	studioRoute := &URLRoute{StudioID: &studioID, ScraperID: "studio"}
+	routes := []*URLRoute{patternRoute, studioRoute}
+
+	tests := []struct {
+		name string
+		url  string
+		want *URLRoute
+	}{
+		{"pattern", "https://scenes.example/1", patternRoute},
+		{"studio host", "https://studio.example/scene/1", studioRoute},
+		{"studio host with www", "http://www.studio.example/scene/1", studioRoute},
+		{"second studio url", "https://store.example/scene/1", studioRoute},
+		{"other host", "https://other.example/scene/1", nil},
+		{"invalid url", "::", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchURLRoute(routes, tt.url, studioURLs))
+		})
+	}
+}
@@ -834,10 +834,22 @@ func (mockGlobalConfig) GetPythonPath() string {
 	return ""
 }
 
+func (mockGlobalConfig) GetScraperRuntimesPath() string {
+	return ""
+}
+
 func (mockGlobalConfig) GetProxy() string {
 	return ""
 }
 
+func (mockGlobalConfig) GetScraperProxies() []*Proxy {
+	return nil
+}
+
+func (mockGlobalConfig) GetScraperProxyHealthCheckURL() string {
+	return ""
+}
+
 func TestSubScrape(t *testing.T) {
 	retHTML := `
 	<div>
@@ -0,0 +1,509 @@
+// Package search provides a single search across scenes, performers, tags,
+// studios, galleries and scene markers, returning ranked results.
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+const (
+	// DefaultLimit is the number of results returned if no limit is given.
+	DefaultLimit = 20
+	// MaxLimit is the maximum number of results returned.
+	MaxLimit = 100
+
+	// candidateFactor is the number of candidates queried for each type,
+	// as a multiple of the limit. Candidates are ranked in Go, so more
+	// candidates than results are needed for the best matches to be
+	// returned.
+	candidateFactor = 3
+)
+
+type ResultType string
+
+const (
+	ResultTypeScene       ResultType = "SCENE"
+	ResultTypePerformer   ResultType = "PERFORMER"
+	ResultTypeTag         ResultType = "TAG"
+	ResultTypeStudio      ResultType = "STUDIO"
+	ResultTypeGallery     ResultType = "GALLERY"
+	ResultTypeSceneMarker ResultType = "SCENE_MARKER"
+)
+
+var AllResultType = []ResultType{
+	ResultTypeScene,
+	ResultTypePerformer,
+	ResultTypeTag,
+	ResultTypeStudio,
+	ResultTypeGallery,
+	ResultTypeSceneMarker,
+}
+
+func (e ResultType) IsValid() bool {
+	return slices.Contains(AllResultType, e)
+}
+
+func (e ResultType) String() string {
+	return string(e)
+}
+
+func (e *ResultType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResultType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchResultType", str)
+	}
+	return nil
+}
+
+func (e ResultType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// MatchType describes how a field matched the query.
+type MatchType string
+
+const (
+	// The field is equal to the query, ignoring case.
+	MatchTypeExact MatchType = "EXACT"
+	// The field starts with the query.
+	MatchTypePrefix MatchType = "PREFIX"
+	// Each word of the query starts a word in the field.
+	MatchTypeWords MatchType = "WORDS"
+	// The field contains each word of the query.
+	MatchTypeContains MatchType = "CONTAINS"
+	// The object matched on a field that is not returned, such as a
+	// fingerprint.
+	MatchTypeOther MatchType = "OTHER"
+)
+
+var AllMatchType = []MatchType{
+	MatchTypeExact,
+	MatchTypePrefix,
+	MatchTypeWords,
+	MatchTypeContains,
+	MatchTypeOther,
+}
+
+func (e MatchType) IsValid() bool {
+	return slices.Contains(AllMatchType, e)
+}
+
+func (e MatchType) String() string {
+	return string(e)
+}
+
+func (e *MatchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchMatchType", str)
+	}
+	return nil
+}
+
+func (e MatchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// matchTypeScores are the base scores of each match type.
+var matchTypeScores = map[MatchType]float64{
+	MatchTypeExact:    1,
+	MatchTypePrefix:   0.8,
+	MatchTypeWords:    0.6,
+	MatchTypeContains: 0.4,
+	MatchTypeOther:    0.1,
+}
+
+// Result is a single search result. Exactly one of the object fields is set,
+// according to Type.
+type Result struct {
+	Type ResultType `json:"type"`
+	ID   int        `json:"id"`
+	// Name is the display name of the object.
+	Name string `json:"name"`
+	// Score is the relevance of the result between 0 and 1.
+	Score float64 `json:"score"`
+	// MatchedField is the name of the field that best matched the query.
+	// It is empty if the match type is OTHER.
+	MatchedField string    `json:"matched_field"`
+	MatchedValue string    `json:"matched_value"`
+	MatchType    MatchType `json:"match_type"`
+
+	Scene       *models.Scene       `json:"scene"`
+	Performer   *models.Performer   `json:"performer"`
+	Tag         *models.Tag         `json:"tag"`
+	Studio      *models.Studio      `json:"studio"`
+	Gallery     *models.Gallery     `json:"gallery"`
+	SceneMarker *models.SceneMarker `json:"scene_marker"`
+}
+
+type Repository struct {
+	Scene       models.SceneReader
+	Performer   models.PerformerReader
+	Tag         models.TagReader
+	Studio      models.StudioReader
+	Gallery     models.GalleryReader
+	SceneMarker models.SceneMarkerReader
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		Scene:       repo.Scene,
+		Performer:   repo.Performer,
+		Tag:         repo.Tag,
+		Studio:      repo.Studio,
+		Gallery:     repo.Gallery,
+		SceneMarker: repo.SceneMarker,
+	}
+}
+
+// field is a named value of an object that is compared to the query.
+type field struct {
+	name   string
+	value  string
+	weight float64
+}
+
+// Search returns the objects of the given types that match q, ordered by
+// relevance. All types are searched if types is empty. It must be called
+// within a read transaction.
+func Search(ctx context.Context, r Repository, q string, types []ResultType, limit int) ([]*Result, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	if len(types) == 0 {
+		types = AllResultType
+	}
+
+	s := searcher{
+		r:     r,
+		query: q,
+		findFilter: &models.FindFilterType{
+			Q:       &q,
+			PerPage: ptr(limit * candidateFactor),
+		},
+	}
+
+	searchFns := map[ResultType]func(ctx context.Context) error{
+		ResultTypeScene:       s.scenes,
+		ResultTypePerformer:   s.performers,
+		ResultTypeTag:         s.tags,
+		ResultTypeStudio:      s.studios,
+		ResultTypeGallery:     s.galleries,
+		ResultTypeSceneMarker: s.sceneMarkers,
+	}
+
+	for _, t := range AllResultType {
+		if !slices.Contains(types, t) {
+			continue
+		}
+
+		if err := searchFns[t](ctx); err != nil {
+			return nil, fmt.Errorf("searching %s: %w", strings.ToLower(t.String()), err)
+		}
+	}
+
+	ret := s.results
+	sortResults(ret)
+
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+
+	return ret, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// sortResults sorts results by descending score, then by type in the order
+// of AllResultType, then by name.
+func sortResults(results []*Result) {
+	slices.SortStableFunc(results, func(a, b *Result) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+
+		if a.Type != b.Type {
+			return slices.Index(AllResultType, a.Type) - slices.Index(AllResultType, b.Type)
+		}
+
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+type searcher struct {
+	r          Repository
+	query      string
+	findFilter *models.FindFilterType
+	results    []*Result
+}
+
+// add adds a result for the object, using the field that best matches the
+// query as the reason.
+func (s *searcher) add(r *Result, fields []field) {
+	r.MatchType = MatchTypeOther
+	r.Score = matchTypeScores[MatchTypeOther]
+
+	for _, f := range fields {
+		mt, ok := matchValue(s.query, f.value)
+		if !ok {
+			continue
+		}
+
+		score := matchTypeScores[mt] * f.weight
+		if score > r.Score {
+			r.Score = score
+			r.MatchType = mt
+			r.MatchedField = f.name
+			r.MatchedValue = f.value
+		}
+	}
+
+	s.results = append(s.results, r)
+}
+
+func aliasFields(aliases []string) []field {
+	var ret []field
+	for _, a := range aliases {
+		ret = append(ret, field{"aliases", a, 0.9})
+	}
+	return ret
+}
+
+func (s *searcher) scenes(ctx context.Context) error {
+	scenes, err := scene.Query(ctx, s.r.Scene, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range scenes {
+		s.add(&Result{
+			Type:  ResultTypeScene,
+			ID:    o.ID,
+			Name:  o.GetTitle(),
+			Scene: o,
+		}, []field{
+			{"title", o.Title, 1},
+			{"code", o.Code, 0.9},
+			{"path", filepath.Base(o.Path), 0.7},
+			{"details", o.Details, 0.5},
+		})
+	}
+
+	return nil
+}
+
+func (s *searcher) performers(ctx context.Context) error {
+	performers, _, err := s.r.Performer.Query(ctx, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range performers {
+		if err := o.LoadAliases(ctx, s.r.Performer); err != nil {
+			return err
+		}
+
+		s.add(&Result{
+			Type:      ResultTypePerformer,
+			ID:        o.ID,
+			Name:      o.Name,
+			Performer: o,
+		}, append([]field{
+			{"name", o.Name, 1},
+		}, aliasFields(o.Aliases.List())...))
+	}
+
+	return nil
+}
+
+func (s *searcher) tags(ctx context.Context) error {
+	tags, _, err := s.r.Tag.Query(ctx, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range tags {
+		if err := o.LoadAliases(ctx, s.r.Tag); err != nil {
+			return err
+		}
+
+		s.add(&Result{
+			Type: ResultTypeTag,
+			ID:   o.ID,
+			Name: o.Name,
+			Tag:  o,
+		}, append([]field{
+			{"name", o.Name, 1},
+		}, aliasFields(o.Aliases.List())...))
+	}
+
+	return nil
+}
+
+func (s *searcher) studios(ctx context.Context) error {
+	studios, _, err := s.r.Studio.Query(ctx, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range studios {
+		if err := o.LoadAliases(ctx, s.r.Studio); err != nil {
+			return err
+		}
+
+		s.add(&Result{
+			Type:   ResultTypeStudio,
+			ID:     o.ID,
+			Name:   o.Name,
+			Studio: o,
+		}, append([]field{
+			{"name", o.Name, 1},
+		}, aliasFields(o.Aliases.List())...))
+	}
+
+	return nil
+}
+
+func (s *searcher) galleries(ctx context.Context) error {
+	galleries, _, err := s.r.Gallery.Query(ctx, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range galleries {
+		s.add(&Result{
+			Type:    ResultTypeGallery,
+			ID:      o.ID,
+			Name:    o.GetTitle(),
+			Gallery: o,
+		}, []field{
+			{"title", o.Title, 1},
+			{"code", o.Code, 0.9},
+			{"path", filepath.Base(o.Path), 0.7},
+			{"details", o.Details, 0.5},
+		})
+	}
+
+	return nil
+}
+
+func (s *searcher) sceneMarkers(ctx context.Context) error {
+	markers, _, err := s.r.SceneMarker.Query(ctx, nil, s.findFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range markers {
+		primaryTag, err := s.r.Tag.Find(ctx, o.PrimaryTagID)
+		if err != nil {
+			return err
+		}
+
+		var tagName string
+		if primaryTag != nil {
+			tagName = primaryTag.Name
+		}
+
+		name := o.Title
+		if name == "" {
+			name = tagName
+		}
+
+		// the scene title is also searched, but is weighted low so that
+		// markers don't crowd out the scene itself
+		var sceneTitle string
+		sc, err := s.r.Scene.Find(ctx, o.SceneID)
+		if err != nil {
+			return err
+		}
+		if sc != nil {
+			sceneTitle = sc.Title
+		}
+
+		s.add(&Result{
+			Type:        ResultTypeSceneMarker,
+			ID:          o.ID,
+			Name:        name,
+			SceneMarker: o,
+		}, []field{
+			{"title", o.Title, 1},
+			{"primary_tag", tagName, 0.7},
+			{"scene_title", sceneTitle, 0.3},
+		})
+	}
+
+	return nil
+}
+
+// matchValue returns how value matches the query, ignoring case. It
+// returns false if value does not contain every word of the query.
+func matchValue(query, value string) (MatchType, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	value = strings.ToLower(strings.TrimSpace(value))
+	if query == "" || value == "" {
+		return "", false
+	}
+
+	switch {
+	case value == query:
+		return MatchTypeExact, true
+	case strings.HasPrefix(value, query):
+		return MatchTypePrefix, true
+	}
+
+	queryWords := strings.Fields(query)
+	for _, w := range queryWords {
+		if !strings.Contains(value, w) {
+			return "", false
+		}
+	}
+
+	valueWords := strings.FieldsFunc(value, func(r rune) bool {
+		return !isWordRune(r)
+	})
+	for _, w := range queryWords {
+		if !slices.ContainsFunc(valueWords, func(vw string) bool {
+			return strings.HasPrefix(vw, w)
+		}) {
+			return MatchTypeContains, true
+		}
+	}
+
+	return MatchTypeWords, true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+}
@@ -0,0 +1,75 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchValue(t *testing.T) {
+	tests := []struct {
+		query string
+		value string
+		want  MatchType
+		found bool
+	}{
+		{"jane doe", "Jane Doe", MatchTypeExact, true},
+		{"jane", "Jane Doe", MatchTypePrefix, true},
+		{"doe jane", "Jane Doe", MatchTypeWords, true},
+		{"do ja", "Jane Doe", MatchTypeWords, true},
+		{"oe", "Jane Doe", MatchTypeContains, true},
+		{"jane oe", "Jane Doe", MatchTypeContains, true},
+		{"jane smith", "Jane Doe", "", false},
+		{"jane", "", "", false},
+		{"", "Jane Doe", "", false},
+		{"scene", "studio.scene.2020.mp4", MatchTypeWords, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.value, func(t *testing.T) {
+			got, found := matchValue(tt.query, tt.value)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearcherRanking(t *testing.T) {
+	s := searcher{query: "jane doe"}
+
+	s.add(&Result{Type: ResultTypeScene, ID: 1, Name: "Scene"}, []field{
+		{"title", "Scene", 1},
+		{"details", "Jane Doe stars", 0.5},
+	})
+	s.add(&Result{Type: ResultTypePerformer, ID: 2, Name: "Jane Doe"}, []field{
+		{"name", "Jane Doe", 1},
+	})
+	s.add(&Result{Type: ResultTypePerformer, ID: 3, Name: "Janet"}, []field{
+		{"name", "Janet", 1},
+		{"aliases", "Jane Doe", 0.9},
+	})
+	s.add(&Result{Type: ResultTypeScene, ID: 4, Name: "Fingerprint match"}, []field{
+		{"title", "Fingerprint match", 1},
+	})
+	s.add(&Result{Type: ResultTypeTag, ID: 5, Name: "Jane Doe"}, []field{
+		{"name", "Jane Doe", 1},
+	})
+
+	sortResults(s.results)
+
+	var got []int
+	for _, r := range s.results {
+		got = append(got, r.ID)
+	}
+
+	// equal scores are ordered by type
+	assert.Equal(t, []int{2, 5, 3, 1, 4}, got)
+
+	alias := s.results[2]
+	assert.Equal(t, "aliases", alias.MatchedField)
+	assert.Equal(t, "Jane Doe", alias.MatchedValue)
+	assert.Equal(t, MatchTypeExact, alias.MatchType)
+
+	other := s.results[4]
+	assert.Equal(t, MatchTypeOther, other.MatchType)
+	assert.Equal(t, "", other.MatchedField)
+}
@@ -16,6 +16,13 @@ type VisitedPluginHook struct {
 	HookType hook.TriggerEnum
 }
 
+// PluginServiceAccount is the identity used by a plugin with restricted
+// permissions to access the GraphQL API.
+type PluginServiceAccount struct {
+	PluginID    string
+	Permissions []string
+}
+
 func init() {
 	gob.Register([]VisitedPluginHook{})
 }
@@ -33,6 +40,16 @@ func (s *Store) VisitedPluginHandler() func(http.Handler) http.Handler {
 				visitedPlugins, _ := val.([]VisitedPluginHook)
 
 				ctx := setVisitedPluginHooks(r.Context(), visitedPlugins)
+
+				// the cookie is signed, so the permissions can be trusted
+				if pluginID, _ := session.Values[pluginIDKey].(string); pluginID != "" {
+					permissions, _ := session.Values[pluginPermissionsKey].([]string)
+					ctx = SetPluginServiceAccount(ctx, &PluginServiceAccount{
+						PluginID:    pluginID,
+						Permissions: permissions,
+					})
+				}
+
 				r = r.WithContext(ctx)
 			}
 
@@ -60,7 +77,23 @@ func setVisitedPluginHooks(ctx context.Context, visitedPlugins []VisitedPluginHo
 	return context.WithValue(ctx, contextVisitedPlugins, visitedPlugins)
 }
 
-func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
+// SetPluginServiceAccount sets the plugin service account of the request.
+func SetPluginServiceAccount(ctx context.Context, account *PluginServiceAccount) context.Context {
+	return context.WithValue(ctx, contextPluginServiceAccount, account)
+}
+
+// GetPluginServiceAccount returns the service account of the plugin making
+// the request. Returns nil if the request is not from a plugin with
+// restricted permissions.
+func GetPluginServiceAccount(ctx context.Context) *PluginServiceAccount {
+	ret, _ := ctx.Value(contextPluginServiceAccount).(*PluginServiceAccount)
+	return ret
+}
+
+// MakePluginCookie returns a session cookie for a plugin to access the
+// server. If account is not nil, the plugin's access is restricted to the
+// permissions of the account.
+func (s *Store) MakePluginCookie(ctx context.Context, account *PluginServiceAccount) *http.Cookie {
 	currentUser := GetCurrentUserID(ctx)
 	visitedPlugins := GetVisitedPluginHooks(ctx)
 
@@ -71,6 +104,11 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 
 	session.Values[visitedPluginHooksKey] = visitedPlugins
 
+	if account != nil {
+		session.Values[pluginIDKey] = account.PluginID
+		session.Values[pluginPermissionsKey] = account.Permissions
+	}
+
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		s.sessionStore.Codecs...)
 	if err != nil {
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const restrictedUnlockedKey = "restrictedUnlocked"
+
+// UnlockRestrictedHeader is the header that api key requests set to true to
+// unlock restricted content. Api key requests have no session to store the
+// unlock flag in.
+const UnlockRestrictedHeader = "UnlockRestricted"
+
+const unlockPasswordFormKey = "password"
+
+// UnlockRestrictedContent unlocks restricted content for the current
+// session. If credentials are configured, the password form value must be
+// the user's password.
+func (s *Store) UnlockRestrictedContent(w http.ResponseWriter, r *http.Request, hasCredentials bool) error {
+	if hasCredentials && !s.config.ValidateCredentials(s.config.GetUsername(), r.FormValue(unlockPasswordFormKey)) {
+		return &InvalidCredentialsError{Username: s.config.GetUsername()}
+	}
+
+	return s.setRestrictedUnlocked(w, r, true)
+}
+
+// LockRestrictedContent hides restricted content from the current session.
+func (s *Store) LockRestrictedContent(w http.ResponseWriter, r *http.Request) error {
+	return s.setRestrictedUnlocked(w, r, false)
+}
+
+func (s *Store) setRestrictedUnlocked(w http.ResponseWriter, r *http.Request, unlocked bool) error {
+	// ignore error - an invalid cookie is replaced
+	session, _ := s.sessionStore.Get(r, cookieName)
+
+	if unlocked {
+		session.Values[restrictedUnlockedKey] = true
+	} else {
+		delete(session.Values, restrictedUnlockedKey)
+	}
+
+	return session.Save(r, w)
+}
+
+// RestrictedContentUnlocked returns true if restricted content is unlocked
+// for the request.
+func (s *Store) RestrictedContentUnlocked(r *http.Request) bool {
+	if r.Header.Get(ApiKeyHeader) != "" || r.URL.Query().Get(ApiKeyParameter) != "" {
+		unlocked, _ := strconv.ParseBool(r.Header.Get(UnlockRestrictedHeader))
+		return unlocked
+	}
+
+	session, err := s.sessionStore.Get(r, cookieName)
+	if err != nil {
+		return false
+	}
+
+	unlocked, _ := session.Values[restrictedUnlockedKey].(bool)
+	return unlocked
+}
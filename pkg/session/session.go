@@ -5,8 +5,11 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gorilla/sessions"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/logger"
 )
 
@@ -15,11 +18,29 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextPluginServiceAccount
 )
 
 const (
 	userIDKey             = "userID"
 	visitedPluginHooksKey = "visitedPluginsHooks"
+	pluginIDKey           = "pluginID"
+	pluginPermissionsKey  = "pluginPermissions"
+	sessionVersionKey     = "sessionVersion"
+
+	// key for a login that has passed the password check but not the
+	// two-factor check
+	pendingLoginKey = "pendingLogin"
+)
+
+const (
+	// pendingLoginTimeout is the time allowed to enter the two-factor code
+	// after entering the username and password.
+	pendingLoginTimeout = 5 * time.Minute
+	// maxTwoFactorAttempts is the number of invalid two-factor codes allowed
+	// before the username and password must be entered again.
+	maxTwoFactorAttempts = 5
+	pendingLoginIDLength = 32
 )
 
 const (
@@ -31,6 +52,7 @@ const (
 	cookieName      = "session"
 	usernameFormKey = "username"
 	passwordFormKey = "password"
+	codeFormKey     = "code"
 )
 
 type InvalidCredentialsError struct {
@@ -42,11 +64,51 @@ func (e InvalidCredentialsError) Error() string {
 	return "invalid credentials"
 }
 
-var ErrUnauthorized = errors.New("unauthorized")
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrTwoFactorRequired is returned by Login when the username and
+	// password are valid, but a two-factor code must also be provided.
+	ErrTwoFactorRequired = errors.New("two-factor code required")
+	// ErrInvalidTwoFactorCode is returned by Login when the provided
+	// two-factor code is invalid.
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	// ErrTooManyTwoFactorAttempts is returned by Login when too many invalid
+	// two-factor codes have been provided. The username and password must
+	// be entered again.
+	ErrTooManyTwoFactorAttempts = errors.New("too many invalid two-factor codes")
+)
+
+// TwoFactorProvider provides two-factor authentication of users.
+type TwoFactorProvider interface {
+	// TwoFactorEnabled returns true if the user must provide a code to log in.
+	TwoFactorEnabled(ctx context.Context, username string) (bool, error)
+	// VerifyTwoFactor returns true if the code is valid for the user.
+	VerifyTwoFactor(ctx context.Context, username string, code string) (bool, error)
+	// SessionVersion returns the current session version of the user.
+	// Sessions created with a different version are treated as logged out.
+	SessionVersion(ctx context.Context, username string) (int, error)
+}
+
+// pendingLogin is a login that has passed the password check but not the
+// two-factor check. Pending logins are kept on the server, since the session
+// cookie could be replayed to reset the number of failed attempts.
+type pendingLogin struct {
+	username string
+	started  time.Time
+	failures int
+}
+
+func (p *pendingLogin) expired(now time.Time) bool {
+	return now.Sub(p.started) > pendingLoginTimeout
+}
 
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	twoFactor    TwoFactorProvider
+
+	pendingMutex  sync.Mutex
+	pendingLogins map[string]*pendingLogin
 }
 
 func NewStore(c SessionConfig) *Store {
@@ -61,10 +123,24 @@ func NewStore(c SessionConfig) *Store {
 	return ret
 }
 
+// RegisterTwoFactorProvider sets the provider used to require a second
+// factor on login and to check the session version of logged in users.
+func (s *Store) RegisterTwoFactorProvider(p TwoFactorProvider) {
+	s.twoFactor = p
+}
+
+// Login logs in the user using the username and password form values.
+// If the user has enabled two-factor authentication, ErrTwoFactorRequired
+// is returned and the login is completed by calling Login again with the
+// code form value.
 func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	// ignore error - we want a new session regardless
 	newSession, _ := s.sessionStore.Get(r, cookieName)
 
+	if code := r.FormValue(codeFormKey); code != "" {
+		return s.loginTwoFactor(w, r, newSession, code)
+	}
+
 	username := r.FormValue(usernameFormKey)
 	password := r.FormValue(passwordFormKey)
 
@@ -73,12 +149,154 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 		return &InvalidCredentialsError{Username: username}
 	}
 
+	if s.twoFactor != nil {
+		enabled, err := s.twoFactor.TwoFactorEnabled(r.Context(), username)
+		if err != nil {
+			return err
+		}
+
+		if enabled {
+			id, err := s.addPendingLogin(username)
+			if err != nil {
+				return err
+			}
+
+			delete(newSession.Values, userIDKey)
+			newSession.Values[pendingLoginKey] = id
+
+			if err := newSession.Save(r, w); err != nil {
+				return err
+			}
+
+			return ErrTwoFactorRequired
+		}
+	}
+
+	return s.completeLogin(w, r, newSession, username)
+}
+
+func (s *Store) loginTwoFactor(w http.ResponseWriter, r *http.Request, session *sessions.Session, code string) error {
+	id, _ := session.Values[pendingLoginKey].(string)
+
+	// the password must have been entered recently
+	username := s.getPendingLogin(id)
+	if username == "" || s.twoFactor == nil {
+		return &InvalidCredentialsError{Username: username}
+	}
+
+	ok, err := s.twoFactor.VerifyTwoFactor(r.Context(), username, code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		if !s.failPendingLogin(id) {
+			return ErrTooManyTwoFactorAttempts
+		}
+		return ErrInvalidTwoFactorCode
+	}
+
+	s.removePendingLogin(id)
+
+	return s.completeLogin(w, r, session, username)
+}
+
+// addPendingLogin adds a pending login for the user and returns its ID.
+// Expired pending logins are removed.
+func (s *Store) addPendingLogin(username string) (string, error) {
+	id, err := hash.GenerateRandomKey(pendingLoginIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+
+	if s.pendingLogins == nil {
+		s.pendingLogins = make(map[string]*pendingLogin)
+	}
+
+	for k, p := range s.pendingLogins {
+		if p.expired(now) {
+			delete(s.pendingLogins, k)
+		}
+	}
+
+	s.pendingLogins[id] = &pendingLogin{
+		username: username,
+		started:  now,
+	}
+
+	return id, nil
+}
+
+// getPendingLogin returns the username of the pending login, or an empty
+// string if it does not exist or has expired.
+func (s *Store) getPendingLogin(id string) string {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+
+	p := s.pendingLogins[id]
+	if p == nil {
+		return ""
+	}
+
+	if p.expired(time.Now()) {
+		delete(s.pendingLogins, id)
+		return ""
+	}
+
+	return p.username
+}
+
+// failPendingLogin records an invalid two-factor code for the pending login.
+// Returns false if the pending login has been discarded because too many
+// invalid codes have been provided.
+func (s *Store) failPendingLogin(id string) bool {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+
+	p := s.pendingLogins[id]
+	if p == nil {
+		return false
+	}
+
+	p.failures++
+	if p.failures >= maxTwoFactorAttempts {
+		delete(s.pendingLogins, id)
+		return false
+	}
+
+	return true
+}
+
+func (s *Store) removePendingLogin(id string) {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+
+	delete(s.pendingLogins, id)
+}
+
+func (s *Store) completeLogin(w http.ResponseWriter, r *http.Request, session *sessions.Session, username string) error {
+	delete(session.Values, pendingLoginKey)
+	delete(session.Values, restrictedUnlockedKey)
+
+	if s.twoFactor != nil {
+		version, err := s.twoFactor.SessionVersion(r.Context(), username)
+		if err != nil {
+			return err
+		}
+		session.Values[sessionVersionKey] = version
+	}
+
 	// since we only have one user, don't leak the name
 	logger.Info("User logged in")
 
-	newSession.Values[userIDKey] = username
+	session.Values[userIDKey] = username
 
-	err := newSession.Save(r, w)
+	err := session.Save(r, w)
 	if err != nil {
 		return err
 	}
@@ -93,6 +311,8 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	delete(session.Values, userIDKey)
+	delete(session.Values, sessionVersionKey)
+	delete(session.Values, restrictedUnlockedKey)
 	session.Options.MaxAge = -1
 
 	err = session.Save(r, w)
@@ -125,12 +345,33 @@ func (s *Store) GetSessionUserID(w http.ResponseWriter, r *http.Request) (string
 
 		ret, _ := val.(string)
 
+		if ret != "" && !s.isCurrentSessionVersion(r.Context(), session, ret) {
+			return "", nil
+		}
+
 		return ret, nil
 	}
 
 	return "", nil
 }
 
+// isCurrentSessionVersion returns false if the session was created before
+// the user's session version last changed.
+func (s *Store) isCurrentSessionVersion(ctx context.Context, session *sessions.Session, username string) bool {
+	if s.twoFactor == nil {
+		return true
+	}
+
+	current, err := s.twoFactor.SessionVersion(ctx, username)
+	if err != nil {
+		logger.Errorf("error getting session version: %v", err)
+		return false
+	}
+
+	version, _ := session.Values[sessionVersionKey].(int)
+	return version == current
+}
+
 func SetCurrentUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, contextUser, userID)
 }
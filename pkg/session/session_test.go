@@ -0,0 +1,40 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingLoginAttempts(t *testing.T) {
+	s := &Store{}
+
+	id, err := s.addPendingLogin("user")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "user", s.getPendingLogin(id))
+	assert.Equal(t, "", s.getPendingLogin("other"))
+
+	for i := 1; i < maxTwoFactorAttempts; i++ {
+		assert.True(t, s.failPendingLogin(id), "attempt %d", i)
+	}
+
+	// the pending login is discarded after too many attempts
+	assert.False(t, s.failPendingLogin(id))
+	assert.Equal(t, "", s.getPendingLogin(id))
+}
+
+func TestPendingLoginExpired(t *testing.T) {
+	s := &Store{}
+
+	id, err := s.addPendingLogin("user")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s.pendingLogins[id].started = time.Now().Add(-pendingLoginTimeout - time.Second)
+	assert.Equal(t, "", s.getPendingLogin(id))
+}
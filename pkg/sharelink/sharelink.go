@@ -0,0 +1,129 @@
+// Package sharelink provides the creation and validation of public share links.
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/hash"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// tokenLength is the number of random bytes used to generate a share token.
+const tokenLength = 32
+
+var (
+	ErrNotFound         = errors.New("share link not found")
+	ErrExpired          = errors.New("share link has expired")
+	ErrViewsExhausted   = errors.New("share link view limit reached")
+	ErrPasswordRequired = errors.New("share link requires a password")
+	ErrInvalidPassword  = errors.New("invalid share link password")
+)
+
+// CreateInput contains the options used to create a new share link.
+// Exactly one of SceneID or GalleryID must be set.
+type CreateInput struct {
+	SceneID   *int
+	GalleryID *int
+	Password  string
+	ExpiresAt *time.Time
+	MaxViews  *int
+	// Preview defaults to ShareLinkPreviewNone if empty.
+	Preview models.ShareLinkPreview
+}
+
+func (i CreateInput) validate() error {
+	if (i.SceneID == nil) == (i.GalleryID == nil) {
+		return errors.New("exactly one of scene or gallery must be provided")
+	}
+
+	if i.MaxViews != nil && *i.MaxViews <= 0 {
+		return errors.New("max views must be greater than zero")
+	}
+
+	if i.Preview != "" && !i.Preview.IsValid() {
+		return fmt.Errorf("invalid preview %q", i.Preview)
+	}
+
+	if i.ExpiresAt != nil && !i.ExpiresAt.After(time.Now()) {
+		return errors.New("expiry time must be in the future")
+	}
+
+	return nil
+}
+
+// Create creates a new share link with a random token.
+func Create(ctx context.Context, qb models.ShareLinkCreator, input CreateInput) (*models.ShareLink, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	token, err := hash.GenerateRandomKey(tokenLength)
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+
+	newLink := models.NewShareLink()
+	newLink.Token = token
+	newLink.SceneID = input.SceneID
+	newLink.GalleryID = input.GalleryID
+	newLink.ExpiresAt = input.ExpiresAt
+	newLink.MaxViews = input.MaxViews
+	if input.Preview != "" {
+		newLink.Preview = input.Preview
+	}
+
+	if input.Password != "" {
+		h, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("hashing password: %w", err)
+		}
+		newLink.Password = string(h)
+	}
+
+	if err := qb.Create(ctx, &newLink); err != nil {
+		return nil, err
+	}
+
+	return &newLink, nil
+}
+
+// CheckAccess returns an error if the share link may not be used with the
+// provided password at the provided time. If newView is true, then the view
+// limit is also enforced.
+func CheckAccess(link *models.ShareLink, password string, now time.Time, newView bool) error {
+	if link.IsExpired(now) {
+		return ErrExpired
+	}
+
+	if newView && link.ViewsExhausted() {
+		return ErrViewsExhausted
+	}
+
+	if link.HasPassword() {
+		if password == "" {
+			return ErrPasswordRequired
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(link.Password), []byte(password)); err != nil {
+			return ErrInvalidPassword
+		}
+	}
+
+	return nil
+}
+
+// GetPreview returns the metadata that may be shown when the share link is
+// unfurled at the provided time. Links that require a password, have expired
+// or have no views remaining show no metadata.
+func GetPreview(link *models.ShareLink, now time.Time) models.ShareLinkPreview {
+	if link.HasPassword() || !link.IsActive(now) || !link.Preview.IsValid() {
+		return models.ShareLinkPreviewNone
+	}
+
+	return link.Preview
+}
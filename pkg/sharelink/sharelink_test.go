@@ -0,0 +1,75 @@
+package sharelink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestCheckAccess(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	two := 2
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		link     models.ShareLink
+		password string
+		newView  bool
+		want     error
+	}{
+		{"no restrictions", models.ShareLink{}, "", true, nil},
+		{"not expired", models.ShareLink{ExpiresAt: &future}, "", true, nil},
+		{"expired", models.ShareLink{ExpiresAt: &past}, "", true, ErrExpired},
+		{"expired existing view", models.ShareLink{ExpiresAt: &past}, "", false, ErrExpired},
+		{"views remaining", models.ShareLink{MaxViews: &two, ViewCount: 1}, "", true, nil},
+		{"views exhausted", models.ShareLink{MaxViews: &two, ViewCount: 2}, "", true, ErrViewsExhausted},
+		{"views exhausted existing view", models.ShareLink{MaxViews: &two, ViewCount: 2}, "", false, nil},
+		{"password missing", models.ShareLink{Password: string(hashed)}, "", true, ErrPasswordRequired},
+		{"password invalid", models.ShareLink{Password: string(hashed)}, "wrong", true, ErrInvalidPassword},
+		{"password valid", models.ShareLink{Password: string(hashed)}, "secret", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckAccess(&tt.link, tt.password, now, tt.newView)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetPreview(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	two := 2
+
+	tests := []struct {
+		name string
+		link models.ShareLink
+		want models.ShareLinkPreview
+	}{
+		{"full", models.ShareLink{Preview: models.ShareLinkPreviewFull}, models.ShareLinkPreviewFull},
+		{"title", models.ShareLink{Preview: models.ShareLinkPreviewTitle}, models.ShareLinkPreviewTitle},
+		{"none", models.ShareLink{Preview: models.ShareLinkPreviewNone}, models.ShareLinkPreviewNone},
+		{"unset", models.ShareLink{}, models.ShareLinkPreviewNone},
+		{"password", models.ShareLink{Preview: models.ShareLinkPreviewFull, Password: "hash"}, models.ShareLinkPreviewNone},
+		{"expired", models.ShareLink{Preview: models.ShareLinkPreviewFull, ExpiresAt: &past}, models.ShareLinkPreviewNone},
+		{"views exhausted", models.ShareLink{Preview: models.ShareLinkPreviewFull, MaxViews: &two, ViewCount: 2}, models.ShareLinkPreviewNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetPreview(&tt.link, now))
+		})
+	}
+}
@@ -49,6 +49,13 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.deleteStashIDs() },
 			func() error { return db.clearOHistory() },
 			func() error { return db.clearWatchHistory() },
+			func() error { return db.clearShareLinks() },
+			func() error { return db.clearTOTPCredentials() },
+			func() error { return db.clearLocations() },
+			func() error { return db.truncateTable(defaultFilterTable) },
+			func() error { return db.truncateTable(noteTable) },
+			func() error { return db.truncateTable(quarantinedFileTable) },
+			func() error { return db.truncateTable(sceneMarkerSuggestionTable) },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseCaptions(ctx) },
@@ -60,8 +67,10 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.anonymisePerformers(ctx) },
 			func() error { return db.anonymiseStudios(ctx) },
 			func() error { return db.anonymiseTags(ctx) },
+			func() error { return db.anonymiseTagCategories(ctx) },
 			func() error { return db.anonymiseGroups(ctx) },
 			func() error { return db.anonymiseSavedFilters(ctx) },
+			func() error { return db.anonymiseSavedFilterFolders(ctx) },
 			func() error { return db.Optimise(ctx) },
 		})
 	}(); err != nil {
@@ -89,6 +98,7 @@ func (db *Anonymiser) deleteBlobs() error {
 		func() error { return db.truncateColumn(tagTable, tagImageBlobColumn) },
 		func() error { return db.truncateColumn(studioTable, studioImageBlobColumn) },
 		func() error { return db.truncateColumn(performerTable, performerImageBlobColumn) },
+		func() error { return db.truncateTable(performerImagesTable) },
 		func() error { return db.truncateColumn(sceneTable, sceneCoverBlobColumn) },
 		func() error { return db.truncateColumn(groupTable, groupFrontImageBlobColumn) },
 		func() error { return db.truncateColumn(groupTable, groupBackImageBlobColumn) },
@@ -108,15 +118,44 @@ func (db *Anonymiser) deleteStashIDs() error {
 func (db *Anonymiser) clearOHistory() error {
 	return utils.Do([]func() error{
 		func() error { return db.truncateTable(scenesODatesTable) },
+		func() error { return db.truncateTable(galleriesODatesTable) },
 	})
 }
 
 func (db *Anonymiser) clearWatchHistory() error {
 	return utils.Do([]func() error{
 		func() error { return db.truncateTable(scenesViewDatesTable) },
+		func() error { return db.truncateTable(scenesWatchHeatTable) },
+		func() error { return db.truncateTable(galleriesViewDatesTable) },
+		func() error { return db.truncateTable(imagesViewDatesTable) },
+		func() error { return db.truncateTable(galleryReadingSessionsTable) },
 	})
 }
 
+func (db *Anonymiser) clearShareLinks() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(shareLinkTable) },
+	})
+}
+
+func (db *Anonymiser) clearTOTPCredentials() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(totpRecoveryCodeTable) },
+		func() error { return db.truncateTable(totpCredentialTable) },
+	})
+}
+
+func (db *Anonymiser) clearLocations() error {
+	var fns []func() error
+	for _, table := range []string{sceneTable, galleryTable, imageTable} {
+		for _, column := range []string{"country", "city", "latitude", "longitude"} {
+			fns = append(fns, func() error { return db.truncateColumn(table, column) })
+		}
+	}
+
+	return utils.Do(fns)
+}
+
 func (db *Anonymiser) anonymiseFolders(ctx context.Context) error {
 	logger.Infof("Anonymising folders")
 	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
@@ -346,6 +385,7 @@ func (db *Anonymiser) anonymiseMarkers(ctx context.Context) error {
 			query := dialect.From(table).Select(
 				table.Col(idColumn),
 				table.Col("title"),
+				table.Col("details"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
 
 			gotSome = false
@@ -353,13 +393,15 @@ func (db *Anonymiser) anonymiseMarkers(ctx context.Context) error {
 			const single = false
 			return queryFunc(ctx, query, single, func(rows *sqlx.Rows) error {
 				var (
-					id    int
-					title string
+					id      int
+					title   string
+					details sql.NullString
 				)
 
 				if err := rows.Scan(
 					&id,
 					&title,
+					&details,
 				); err != nil {
 					return err
 				}
@@ -368,6 +410,12 @@ func (db *Anonymiser) anonymiseMarkers(ctx context.Context) error {
 					return err
 				}
 
+				if details.Valid {
+					if err := db.anonymiseText(ctx, table, "details", details.String); err != nil {
+						return err
+					}
+				}
+
 				lastID = id
 				gotSome = true
 				total++
@@ -619,7 +667,6 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 			query := dialect.From(table).Select(
 				table.Col(idColumn),
 				table.Col("name"),
-				table.Col("url"),
 				table.Col("details"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
 
@@ -630,14 +677,12 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 				var (
 					id      int
 					name    sql.NullString
-					url     sql.NullString
 					details sql.NullString
 				)
 
 				if err := rows.Scan(
 					&id,
 					&name,
-					&url,
 					&details,
 				); err != nil {
 					return err
@@ -645,7 +690,6 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 
 				set := goqu.Record{}
 				db.obfuscateNullString(set, "name", name)
-				db.obfuscateNullString(set, "url", url)
 				db.obfuscateNullString(set, "details", details)
 
 				if len(set) > 0 {
@@ -677,6 +721,14 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseURLs(ctx, studiosURLsJoinTable, "studio_id"); err != nil {
+		return err
+	}
+
+	if err := db.anonymiseURLs(ctx, studiosDefaultURLsTable, "studio_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -871,6 +923,69 @@ func (db *Anonymiser) anonymiseTags(ctx context.Context) error {
 	return nil
 }
 
+func (db *Anonymiser) anonymiseTagCategories(ctx context.Context) error {
+	logger.Infof("Anonymising tag categories")
+	table := tagCategoryTableMgr.table
+	lastID := 0
+	total := 0
+	const logEvery = 10000
+
+	for gotSome := true; gotSome; {
+		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
+			query := dialect.From(table).Select(
+				table.Col(idColumn),
+				table.Col("name"),
+				table.Col("description"),
+			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
+
+			gotSome = false
+
+			const single = false
+			return queryFunc(ctx, query, single, func(rows *sqlx.Rows) error {
+				var (
+					id          int
+					name        sql.NullString
+					description sql.NullString
+				)
+
+				if err := rows.Scan(
+					&id,
+					&name,
+					&description,
+				); err != nil {
+					return err
+				}
+
+				set := goqu.Record{}
+				db.obfuscateNullString(set, "name", name)
+				db.obfuscateNullString(set, "description", description)
+
+				if len(set) > 0 {
+					stmt := dialect.Update(table).Set(set).Where(table.Col(idColumn).Eq(id))
+
+					if _, err := exec(ctx, stmt); err != nil {
+						return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+					}
+				}
+
+				lastID = id
+				gotSome = true
+				total++
+
+				if total%logEvery == 0 {
+					logger.Infof("Anonymised %d tag categories", total)
+				}
+
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *Anonymiser) anonymiseGroups(ctx context.Context) error {
 	logger.Infof("Anonymising groups")
 	table := groupTableMgr.table
@@ -1005,6 +1120,65 @@ func (db *Anonymiser) anonymiseSavedFilters(ctx context.Context) error {
 	return nil
 }
 
+func (db *Anonymiser) anonymiseSavedFilterFolders(ctx context.Context) error {
+	logger.Infof("Anonymising saved filter folders")
+	table := savedFilterFolderTableMgr.table
+	lastID := 0
+	total := 0
+	const logEvery = 10000
+
+	for gotSome := true; gotSome; {
+		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
+			query := dialect.From(table).Select(
+				table.Col(idColumn),
+				table.Col("name"),
+			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
+
+			gotSome = false
+
+			const single = false
+			return queryFunc(ctx, query, single, func(rows *sqlx.Rows) error {
+				var (
+					id   int
+					name sql.NullString
+				)
+
+				if err := rows.Scan(
+					&id,
+					&name,
+				); err != nil {
+					return err
+				}
+
+				set := goqu.Record{}
+				db.obfuscateNullString(set, "name", name)
+
+				if len(set) > 0 {
+					stmt := dialect.Update(table).Set(set).Where(table.Col(idColumn).Eq(id))
+
+					if _, err := exec(ctx, stmt); err != nil {
+						return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+					}
+				}
+
+				lastID = id
+				gotSome = true
+				total++
+
+				if total%logEvery == 0 {
+					logger.Infof("Anonymised %d saved filter folders", total)
+				}
+
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *Anonymiser) anonymiseText(ctx context.Context, table exp.IdentifierExpression, column string, value string) error {
 	set := goqu.Record{}
 	set[column] = db.obfuscateString(value, letters)
@@ -0,0 +1,14 @@
+package sqlite
+
+// archivedColumn is the column of scenes, images and galleries that marks
+// them as archived. Archived objects are kept, but are excluded from queries
+// that do not filter on the archived status, and from stats.
+const archivedColumn = "archived"
+
+// excludeArchived excludes archived objects of table from query, unless the
+// filter of the query filters on the archived status.
+func excludeArchived(query *queryBuilder, table string, filtersArchived bool) {
+	if !filtersArchived {
+		query.addWhere(table + "." + archivedColumn + " = 0")
+	}
+}
@@ -0,0 +1,148 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneQueryArchived(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		archived := &models.Scene{Title: "archived scene", Archived: true}
+		if err := db.Scene.Create(ctx, archived, nil); err != nil {
+			t.Errorf("Error creating scene: %v", err)
+			return nil
+		}
+
+		query := func(filter *models.SceneFilterType) []int {
+			result, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: models.BatchFindFilter(-1),
+				},
+				SceneFilter: filter,
+			})
+			if err != nil {
+				t.Errorf("SceneStore.Query() error = %v", err)
+				return nil
+			}
+			return result.IDs
+		}
+
+		isArchived := true
+		notArchived := false
+
+		// excluded by default
+		assert.NotContains(t, query(nil), archived.ID)
+		assert.Contains(t, query(&models.SceneFilterType{Archived: &isArchived}), archived.ID)
+		assert.NotContains(t, query(&models.SceneFilterType{Archived: &notArchived}), archived.ID)
+
+		// filtering on archived in a sub-filter includes archived scenes
+		subFilter := &models.SceneFilterType{
+			OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+				Or: &models.SceneFilterType{Archived: &isArchived},
+			},
+			Archived: &notArchived,
+		}
+		assert.Contains(t, query(subFilter), archived.ID)
+
+		// excluded from count
+		count, err := db.Scene.Count(ctx)
+		if err != nil {
+			t.Errorf("SceneStore.Count() error = %v", err)
+			return nil
+		}
+		unarchived := query(&models.SceneFilterType{Archived: &notArchived})
+		assert.Equal(t, len(unarchived), count)
+
+		// can be found by id
+		found, err := db.Scene.Find(ctx, archived.ID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, found) {
+			assert.True(t, found.Archived)
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryArchived(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		archived := &models.Image{Title: "archived image", Archived: true}
+		if err := db.Image.Create(ctx, archived, nil); err != nil {
+			t.Errorf("Error creating image: %v", err)
+			return nil
+		}
+
+		galleryID := galleryIDs[galleryIdxWithImage]
+		if err := db.Gallery.AddImages(ctx, galleryID, archived.ID); err != nil {
+			t.Errorf("Error adding image to gallery: %v", err)
+			return nil
+		}
+
+		query := func(filter *models.ImageFilterType) []int {
+			result, err := db.Image.Query(ctx, models.ImageQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: models.BatchFindFilter(-1),
+				},
+				ImageFilter: filter,
+			})
+			if err != nil {
+				t.Errorf("ImageStore.Query() error = %v", err)
+				return nil
+			}
+			return result.IDs
+		}
+
+		isArchived := true
+		assert.NotContains(t, query(nil), archived.ID)
+		assert.Contains(t, query(&models.ImageFilterType{Archived: &isArchived}), archived.ID)
+
+		// archived images are not counted in galleries
+		count, err := db.Image.CountByGalleryID(ctx, galleryID)
+		if err != nil {
+			t.Errorf("ImageStore.CountByGalleryID() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 1, count)
+
+		return nil
+	})
+}
+
+func TestGalleryQueryArchived(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		archived := &models.Gallery{Title: "archived gallery", Archived: true}
+		if err := db.Gallery.Create(ctx, archived, nil); err != nil {
+			t.Errorf("Error creating gallery: %v", err)
+			return nil
+		}
+
+		query := func(filter *models.GalleryFilterType) []int {
+			galleries, _, err := db.Gallery.Query(ctx, filter, models.BatchFindFilter(-1))
+			if err != nil {
+				t.Errorf("GalleryStore.Query() error = %v", err)
+				return nil
+			}
+
+			var ret []int
+			for _, g := range galleries {
+				ret = append(ret, g.ID)
+			}
+			return ret
+		}
+
+		isArchived := true
+		assert.NotContains(t, query(nil), archived.ID)
+		assert.Contains(t, query(&models.GalleryFilterType{Archived: &isArchived}), archived.ID)
+
+		return nil
+	})
+}
@@ -97,6 +97,17 @@ func (s *FilesystemStore) Write(ctx context.Context, checksum string, data []byt
 
 	fn := s.checksumToPath(checksum)
 
+	// blobs are addressed by checksum, so an existing file of the same size
+	// already contains the data
+	if f, err := fs.Open(fn); err == nil {
+		info, err := f.Stat()
+		f.Close()
+		if err == nil && info.Size() == int64(len(data)) {
+			logger.Debugf("Blob file %s already exists", fn)
+			return nil
+		}
+	}
+
 	// create the directory if it doesn't exist
 	if err := fs.MkdirAll(filepath.Dir(fn), 0755); err != nil {
 		return fmt.Errorf("creating directory %q: %w", filepath.Dir(fn), err)
@@ -107,6 +118,7 @@ func (s *FilesystemStore) Write(ctx context.Context, checksum string, data []byt
 	if err != nil {
 		return fmt.Errorf("creating file %q: %w", fn, err)
 	}
+	defer out.Close()
 
 	r := bytes.NewReader(data)
 
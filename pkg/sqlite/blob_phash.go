@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// Phashes of image blobs are keyed by the blob checksum, so that they are
+// invalidated when the image changes, and removed with the blob.
+const blobPhashesTable = "blob_phashes"
+
+// FindMissingImagePhashes returns the ids of up to limit objects with an
+// image in blobCol that has not been hashed.
+func (qb *blobJoinQueryBuilder) FindMissingImagePhashes(ctx context.Context, blobCol string, limit int) ([]int, error) {
+	query := utils.StrFormat(`
+SELECT {joinTable}.id FROM {joinTable}
+LEFT JOIN {phashTable} ON {phashTable}.checksum = {joinTable}.{joinCol}
+WHERE {joinTable}.{joinCol} IS NOT NULL AND {phashTable}.checksum IS NULL
+LIMIT ?
+`, utils.StrFormatMap{
+		"joinTable":  qb.joinTable,
+		"joinCol":    blobCol,
+		"phashTable": blobPhashesTable,
+	})
+
+	var ret []int
+	if err := dbWrapper.Select(ctx, &ret, query, limit); err != nil {
+		return nil, fmt.Errorf("finding missing image phashes: %w", err)
+	}
+
+	return ret, nil
+}
+
+// UpdateImagePhash sets the phash of the current image in blobCol of the
+// object. A nil phash records that the image could not be hashed.
+func (qb *blobJoinQueryBuilder) UpdateImagePhash(ctx context.Context, id int, blobCol string, phash *int64) error {
+	checksum, err := qb.getChecksum(ctx, id, blobCol)
+	if err != nil {
+		return err
+	}
+
+	if checksum == nil {
+		return nil
+	}
+
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %s (checksum, phash) VALUES (?, ?)", blobPhashesTable)
+	if _, err := dbWrapper.Exec(ctx, query, *checksum, phash); err != nil {
+		return fmt.Errorf("updating image phash: %w", err)
+	}
+
+	return nil
+}
+
+// FindByImagePhash returns the objects with an image in blobCol whose phash
+// is within distance of phash, ordered by distance.
+func (qb *blobJoinQueryBuilder) FindByImagePhash(ctx context.Context, blobCol string, phash int64, distance int) ([]models.PhashMatch, error) {
+	query := utils.StrFormat(`
+SELECT {joinTable}.id AS id, phash_distance({phashTable}.phash, ?) AS distance FROM {joinTable}
+INNER JOIN {phashTable} ON {phashTable}.checksum = {joinTable}.{joinCol}
+WHERE {phashTable}.phash IS NOT NULL AND phash_distance({phashTable}.phash, ?) <= ?
+ORDER BY distance, {joinTable}.id
+`, utils.StrFormatMap{
+		"joinTable":  qb.joinTable,
+		"joinCol":    blobCol,
+		"phashTable": blobPhashesTable,
+	})
+
+	var ret []models.PhashMatch
+	if err := dbWrapper.Select(ctx, &ret, query, phash, phash, distance); err != nil {
+		return nil, fmt.Errorf("finding images by phash: %w", err)
+	}
+
+	return ret, nil
+}
@@ -207,6 +207,52 @@ func floatCriterionHandler(c *models.FloatCriterionInput, column string, addJoin
 	}
 }
 
+// lengthCriterionHandler filters a length column in centimetres.
+func lengthCriterionHandler(c *models.MeasurementCriterionInput, column string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c != nil {
+			clause, args := getIntCriterionWhereClause(column, c.LengthCriterion())
+			f.addWhere(clause, args...)
+		}
+	}
+}
+
+// massCriterionHandler filters a mass column in kilograms.
+func massCriterionHandler(c *models.MeasurementCriterionInput, column string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c != nil {
+			clause, args := getIntCriterionWhereClause(column, c.MassCriterion())
+			f.addWhere(clause, args...)
+		}
+	}
+}
+
+// geoBoundsCriterionHandler filters by latitude and longitude within a
+// bounding box.
+func geoBoundsCriterionHandler(bounds *models.GeoBoundsInput, latitudeColumn string, longitudeColumn string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if bounds != nil {
+			clause, args := getGeoBoundsWhereClause(*bounds, latitudeColumn, longitudeColumn)
+			f.addWhere(clause, args...)
+		}
+	}
+}
+
+// getGeoBoundsWhereClause returns the where clause for a bounding box. Bounds
+// crossing the antimeridian have a minimum longitude greater than the maximum.
+func getGeoBoundsWhereClause(bounds models.GeoBoundsInput, latitudeColumn string, longitudeColumn string) (string, []interface{}) {
+	clause := latitudeColumn + " BETWEEN ? AND ? AND "
+	args := []interface{}{bounds.MinLatitude, bounds.MaxLatitude, bounds.MinLongitude, bounds.MaxLongitude}
+
+	if bounds.MinLongitude <= bounds.MaxLongitude {
+		clause += longitudeColumn + " BETWEEN ? AND ?"
+	} else {
+		clause += "(" + longitudeColumn + " >= ? OR " + longitudeColumn + " <= ?)"
+	}
+
+	return clause, args
+}
+
 func floatIntCriterionHandler(durationFilter *models.IntCriterionInput, column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if durationFilter != nil {
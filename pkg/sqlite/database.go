@@ -8,6 +8,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -32,9 +36,14 @@ const (
 
 	// environment variable to set the cache size
 	cacheSizeEnv = "STASH_SQLITE_CACHE_SIZE"
+
+	// connection limit and cache size (in KiB when negative) used in low
+	// memory mode
+	lowMemoryMaxReadConnections = 2
+	lowMemoryCacheSize          = "-512"
 )
 
-var appSchemaVersion uint = 71
+var appSchemaVersion uint = 106
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -66,19 +75,30 @@ func (e *MismatchedSchemaVersionError) Error() string {
 }
 
 type storeRepository struct {
-	Blobs          *BlobStore
-	File           *FileStore
-	Folder         *FolderStore
-	Image          *ImageStore
-	Gallery        *GalleryStore
-	GalleryChapter *GalleryChapterStore
-	Scene          *SceneStore
-	SceneMarker    *SceneMarkerStore
-	Performer      *PerformerStore
-	SavedFilter    *SavedFilterStore
-	Studio         *StudioStore
-	Tag            *TagStore
-	Group          *GroupStore
+	Blobs             *BlobStore
+	File              *FileStore
+	Folder            *FolderStore
+	Quarantine        *QuarantinedFileStore
+	Image             *ImageStore
+	ImageRegion       *ImageRegionStore
+	Gallery           *GalleryStore
+	GalleryChapter    *GalleryChapterStore
+	Scene             *SceneStore
+	SceneMarker       *SceneMarkerStore
+	Suggestion        *SceneMarkerSuggestionStore
+	Performer         *PerformerStore
+	SavedFilter       *SavedFilterStore
+	SavedFilterFolder *SavedFilterFolderStore
+	DefaultFilter     *DefaultFilterStore
+	ShareLink         *ShareLinkStore
+	Note              *NoteStore
+	TOTP              *TOTPStore
+	Sync              *SyncStore
+	URLStatus         *URLStatusStore
+	Studio            *StudioStore
+	Tag               *TagStore
+	TagCategory       *TagCategoryStore
+	Group             *GroupStore
 }
 
 type Database struct {
@@ -90,6 +110,14 @@ type Database struct {
 
 	schemaVersion uint
 
+	// reduces the connection count and cache size of the database
+	lowMemory bool
+
+	walOptions WALOptions
+
+	snapshot      *snapshot
+	snapshotMutex sync.Mutex
+
 	lockChan chan struct{}
 }
 
@@ -104,19 +132,30 @@ func NewDatabase() *Database {
 
 	r := &storeRepository{}
 	*r = storeRepository{
-		Blobs:          blobStore,
-		File:           fileStore,
-		Folder:         folderStore,
-		Scene:          NewSceneStore(r, blobStore),
-		SceneMarker:    NewSceneMarkerStore(),
-		Image:          NewImageStore(r),
-		Gallery:        galleryStore,
-		GalleryChapter: NewGalleryChapterStore(),
-		Performer:      performerStore,
-		Studio:         studioStore,
-		Tag:            tagStore,
-		Group:          NewGroupStore(blobStore),
-		SavedFilter:    NewSavedFilterStore(),
+		Blobs:             blobStore,
+		File:              fileStore,
+		Folder:            folderStore,
+		Quarantine:        NewQuarantinedFileStore(),
+		Scene:             NewSceneStore(r, blobStore),
+		SceneMarker:       NewSceneMarkerStore(),
+		Suggestion:        NewSceneMarkerSuggestionStore(),
+		Image:             NewImageStore(r),
+		ImageRegion:       NewImageRegionStore(),
+		Gallery:           galleryStore,
+		GalleryChapter:    NewGalleryChapterStore(),
+		Performer:         performerStore,
+		Studio:            studioStore,
+		Tag:               tagStore,
+		TagCategory:       NewTagCategoryStore(),
+		Group:             NewGroupStore(blobStore),
+		SavedFilter:       NewSavedFilterStore(),
+		SavedFilterFolder: NewSavedFilterFolderStore(),
+		DefaultFilter:     NewDefaultFilterStore(),
+		ShareLink:         NewShareLinkStore(),
+		Note:              NewNoteStore(),
+		TOTP:              NewTOTPStore(),
+		Sync:              NewSyncStore(),
+		URLStatus:         NewURLStatusStore(),
 	}
 
 	ret := &Database{
@@ -131,6 +170,12 @@ func (db *Database) SetBlobStoreOptions(options BlobStoreOptions) {
 	*db.Blobs = *NewBlobStore(options)
 }
 
+// SetLowMemoryMode reduces the memory used by database connections. Takes
+// effect when the database is next opened.
+func (db *Database) SetLowMemoryMode(v bool) {
+	db.lowMemory = v
+}
+
 // Ready returns an error if the database is not ready to begin transactions.
 func (db *Database) Ready() error {
 	if db.readDB == nil || db.writeDB == nil {
@@ -216,6 +261,10 @@ func (db *Database) Close() error {
 	db.lock()
 	defer db.unlock()
 
+	if _, err := db.EndSnapshot(); err != nil {
+		logger.Warnf("error releasing database snapshot: %v", err)
+	}
+
 	if db.readDB != nil {
 		if err := db.readDB.Close(); err != nil {
 			return err
@@ -236,7 +285,7 @@ func (db *Database) Close() error {
 
 func (db *Database) open(disableForeignKeys bool, writable bool) (*sqlx.DB, error) {
 	// https://github.com/mattn/go-sqlite3
-	url := "file:" + db.dbPath + "?_journal=WAL&_sync=NORMAL&_busy_timeout=50"
+	url := "file:" + db.dbPath + "?_journal=WAL&_busy_timeout=50" + db.walParams()
 	if !disableForeignKeys {
 		url += "&_fk=true"
 	}
@@ -251,6 +300,8 @@ func (db *Database) open(disableForeignKeys bool, writable bool) (*sqlx.DB, erro
 	// default is -2000 which is 2MB
 	if cacheSize := os.Getenv(cacheSizeEnv); cacheSize != "" {
 		url += "&_cache_size=" + cacheSize
+	} else if db.lowMemory {
+		url += "&_cache_size=" + lowMemoryCacheSize
 	}
 
 	conn, err := sqlx.Open(sqlite3Driver, url)
@@ -279,8 +330,13 @@ func (db *Database) openReadDB() error {
 	)
 	var err error
 	db.readDB, err = db.open(disableForeignKeys, writable)
-	db.readDB.SetMaxOpenConns(maxReadConnections)
-	db.readDB.SetMaxIdleConns(maxReadConnections)
+
+	readConnections := maxReadConnections
+	if db.lowMemory {
+		readConnections = lowMemoryMaxReadConnections
+	}
+	db.readDB.SetMaxOpenConns(readConnections)
+	db.readDB.SetMaxIdleConns(readConnections)
 	db.readDB.SetConnMaxIdleTime(dbConnTimeout)
 	return err
 }
@@ -359,6 +415,71 @@ func (db *Database) Backup(backupPath string) (err error) {
 	return nil
 }
 
+// VerifyBackup checks the integrity of a backup of the database.
+func (db *Database) VerifyBackup(backupPath string) error {
+	backupDB, err := sqlx.Connect(sqlite3Driver, "file:"+backupPath+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("open backup %s failed: %w", backupPath, err)
+	}
+	defer backupDB.Close()
+
+	var results []string
+	if err := backupDB.Select(&results, "PRAGMA integrity_check"); err != nil {
+		return fmt.Errorf("integrity check failed: %w", err)
+	}
+
+	if len(results) != 1 || results[0] != "ok" {
+		return fmt.Errorf("backup %s is corrupt: %s", backupPath, strings.Join(results, "; "))
+	}
+
+	return nil
+}
+
+// Backups returns the paths of the backups of the database in the given
+// directory, as named by DatabaseBackupPath, oldest first.
+func (db *Database) Backups(backupDirectoryPath string) ([]string, error) {
+	entries, err := os.ReadDir(backupDirectoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	type backup struct {
+		path      string
+		timestamp string
+	}
+
+	var backups []backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if m := db.backupNameRE().FindStringSubmatch(e.Name()); m != nil {
+			backups = append(backups, backup{
+				path:      filepath.Join(backupDirectoryPath, e.Name()),
+				timestamp: m[1],
+			})
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp < backups[j].timestamp
+	})
+
+	ret := make([]string, len(backups))
+	for i, b := range backups {
+		ret[i] = b.path
+	}
+
+	return ret, nil
+}
+
+// backupNameRE matches the file names returned by DatabaseBackupPath,
+// capturing the timestamp.
+func (db *Database) backupNameRE() *regexp.Regexp {
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(filepath.Base(db.dbPath)) + `\.\d+\.(\d{8}_\d{6})$`)
+}
+
 func (db *Database) Anonymise(outPath string) error {
 	anon, err := NewAnonymiser(db, outPath)
 
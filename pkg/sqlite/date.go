@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"time"
 
+	"gopkg.in/guregu/null.v4"
+
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -62,9 +64,27 @@ func (d *NullDate) DatePtr() *models.Date {
 	return &models.Date{Time: d.Date}
 }
 
+// DatePtrWithPrecision returns the date with the precision stored in a
+// separate column. Dates without a precision have day precision.
+func (d *NullDate) DatePtrWithPrecision(precision null.Int) *models.Date {
+	ret := d.DatePtr()
+	if ret != nil {
+		ret.Precision = models.DatePrecision(precision.Int64)
+	}
+
+	return ret
+}
+
 func NullDateFromDatePtr(d *models.Date) NullDate {
 	if d == nil {
 		return NullDate{Valid: false}
 	}
 	return NullDate{Date: d.Time, Valid: true}
 }
+
+func datePrecisionFromDatePtr(d *models.Date) null.Int {
+	if d == nil {
+		return null.Int{}
+	}
+	return null.IntFrom(int64(d.Precision))
+}
@@ -0,0 +1,204 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	defaultFilterTable = "default_filters"
+)
+
+type defaultFilterRow struct {
+	ID           int               `db:"id" goqu:"skipinsert"`
+	Username     string            `db:"username"`
+	Mode         models.FilterMode `db:"mode"`
+	FindFilter   string            `db:"find_filter"`
+	ObjectFilter string            `db:"object_filter"`
+	DisplayMode  null.Int          `db:"display_mode"`
+	ZoomIndex    null.Int          `db:"zoom_index"`
+	CreatedAt    Timestamp         `db:"created_at"`
+	UpdatedAt    Timestamp         `db:"updated_at"`
+}
+
+func (r *defaultFilterRow) fromDefaultFilter(o models.DefaultFilter) {
+	r.ID = o.ID
+	r.Username = o.Username
+	r.Mode = o.Mode
+
+	// encode the filters as json
+	r.FindFilter = encodeJSONOrEmpty(o.FindFilter)
+	r.ObjectFilter = encodeJSONOrEmpty(o.ObjectFilter)
+
+	r.DisplayMode = intFromPtr(o.DisplayMode)
+	r.ZoomIndex = intFromPtr(o.ZoomIndex)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *defaultFilterRow) resolve() *models.DefaultFilter {
+	ret := &models.DefaultFilter{
+		ID:          r.ID,
+		Username:    r.Username,
+		Mode:        r.Mode,
+		DisplayMode: nullIntPtr(r.DisplayMode),
+		ZoomIndex:   nullIntPtr(r.ZoomIndex),
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
+	}
+
+	// decode the filters from json
+	if r.FindFilter != "" {
+		ret.FindFilter = &models.FindFilterType{}
+		decodeJSON(r.FindFilter, &ret.FindFilter)
+	}
+	if r.ObjectFilter != "" {
+		ret.ObjectFilter = make(map[string]interface{})
+		decodeJSON(r.ObjectFilter, &ret.ObjectFilter)
+	}
+
+	return ret
+}
+
+type DefaultFilterStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewDefaultFilterStore() *DefaultFilterStore {
+	return &DefaultFilterStore{
+		repository: repository{
+			tableName: defaultFilterTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: defaultFilterTableMgr,
+	}
+}
+
+func (qb *DefaultFilterStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *DefaultFilterStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *DefaultFilterStore) Set(ctx context.Context, filter *models.DefaultFilter) error {
+	existing, err := qb.FindByMode(ctx, filter.Username, filter.Mode)
+	if err != nil {
+		return err
+	}
+
+	var r defaultFilterRow
+	r.fromDefaultFilter(*filter)
+
+	var id int
+	if existing != nil {
+		id = existing.ID
+		r.ID = id
+		r.CreatedAt = Timestamp{Timestamp: existing.CreatedAt}
+
+		if err := qb.tableMgr.updateByID(ctx, id, r); err != nil {
+			return err
+		}
+	} else {
+		id, err = qb.tableMgr.insertID(ctx, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after set: %w", err)
+	}
+
+	*filter = *updated
+
+	return nil
+}
+
+func (qb *DefaultFilterStore) Destroy(ctx context.Context, username string, mode models.FilterMode) error {
+	table := qb.table()
+	q := dialect.Delete(table).Where(
+		table.Col("username").Eq(username),
+		table.Col("mode").Eq(mode),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *DefaultFilterStore) find(ctx context.Context, id int) (*models.DefaultFilter, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *DefaultFilterStore) FindByUsername(ctx context.Context, username string) ([]*models.DefaultFilter, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).
+		Where(table.Col("username").Eq(username)).
+		Order(table.Col("mode").Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+// returns nil, nil if not found
+func (qb *DefaultFilterStore) FindByMode(ctx context.Context, username string, mode models.FilterMode) (*models.DefaultFilter, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("username").Eq(username),
+		table.Col("mode").Eq(mode),
+	)
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *DefaultFilterStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.DefaultFilter, error) {
+	const single = false
+	var ret []*models.DefaultFilter
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f defaultFilterRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultFilterSetFindDestroy(t *testing.T) {
+	const (
+		username = "defaultFilterUser"
+		other    = "otherUser"
+	)
+
+	sort := "date"
+	direction := models.SortDirectionEnumDesc
+	displayMode := 1
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.DefaultFilter
+
+		f := models.NewDefaultFilter()
+		f.Username = username
+		f.Mode = models.FilterModeScenes
+		f.FindFilter = &models.FindFilterType{
+			Sort:      &sort,
+			Direction: &direction,
+		}
+		f.ObjectFilter = map[string]interface{}{
+			"test": "foo",
+		}
+		f.DisplayMode = &displayMode
+
+		if err := qb.Set(ctx, &f); err != nil {
+			t.Errorf("Error setting default filter: %v", err)
+			return nil
+		}
+		firstID := f.ID
+
+		// setting again replaces the existing filter
+		replacement := models.NewDefaultFilter()
+		replacement.Username = username
+		replacement.Mode = models.FilterModeScenes
+		replacement.FindFilter = &models.FindFilterType{
+			Sort: &sort,
+		}
+
+		if err := qb.Set(ctx, &replacement); err != nil {
+			t.Errorf("Error replacing default filter: %v", err)
+			return nil
+		}
+		assert.Equal(t, firstID, replacement.ID)
+
+		otherFilter := models.NewDefaultFilter()
+		otherFilter.Username = other
+		otherFilter.Mode = models.FilterModeScenes
+		if err := qb.Set(ctx, &otherFilter); err != nil {
+			t.Errorf("Error setting default filter: %v", err)
+			return nil
+		}
+
+		found, err := qb.FindByMode(ctx, username, models.FilterModeScenes)
+		if err != nil {
+			t.Errorf("Error finding default filter: %v", err)
+			return nil
+		}
+		if assert.NotNil(t, found) {
+			assert.Equal(t, sort, *found.FindFilter.Sort)
+			assert.Nil(t, found.FindFilter.Direction)
+			assert.Nil(t, found.ObjectFilter)
+			assert.Nil(t, found.DisplayMode)
+		}
+
+		all, err := qb.FindByUsername(ctx, username)
+		if err != nil {
+			t.Errorf("Error finding default filters: %v", err)
+			return nil
+		}
+		assert.Len(t, all, 1)
+
+		if err := qb.Destroy(ctx, username, models.FilterModeScenes); err != nil {
+			t.Errorf("Error destroying default filter: %v", err)
+			return nil
+		}
+
+		found, err = qb.FindByMode(ctx, username, models.FilterModeScenes)
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		// other users are unaffected
+		found, err = qb.FindByMode(ctx, other, models.FilterModeScenes)
+		assert.NoError(t, err)
+		assert.NotNil(t, found)
+
+		return nil
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/WithoutPants/sortorder/casefolded"
 	sqlite3 "github.com/mattn/go-sqlite3"
@@ -11,6 +14,11 @@ import (
 
 const sqlite3Driver = "sqlite3ex"
 
+// walAutoCheckpointParam is a DSN parameter handled by the custom driver,
+// which sets the wal_autocheckpoint pragma on each new connection. It is
+// not supported by the sqlite3 driver.
+const walAutoCheckpointParam = "_wal_autocheckpoint"
+
 func init() {
 	// register custom driver
 	sql.Register(sqlite3Driver, &CustomSQLiteDriver{})
@@ -23,8 +31,19 @@ type CustomSQLiteConn struct {
 }
 
 func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
+	dsn, walAutoCheckpoint, err := parseWALAutoCheckpoint(dsn)
+	if err != nil {
+		return nil, err
+	}
+
 	sqlite3Driver := &sqlite3.SQLiteDriver{
 		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if walAutoCheckpoint != nil {
+				if _, err := conn.Exec(fmt.Sprintf("PRAGMA wal_autocheckpoint = %d;", *walAutoCheckpoint), nil); err != nil {
+					return fmt.Errorf("error setting wal_autocheckpoint: %v", err)
+				}
+			}
+
 			funcs := map[string]interface{}{
 				"regexp":            regexFn,
 				"durationToTinyInt": durationToTinyIntFn,
@@ -63,6 +82,32 @@ func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
 	return &CustomSQLiteConn{conn.(*sqlite3.SQLiteConn)}, nil
 }
 
+// parseWALAutoCheckpoint removes the walAutoCheckpointParam parameter from
+// dsn, returning the new dsn and the parameter value if present.
+func parseWALAutoCheckpoint(dsn string) (string, *int, error) {
+	pos := strings.IndexRune(dsn, '?')
+	if pos == -1 {
+		return dsn, nil, nil
+	}
+
+	params, err := url.ParseQuery(dsn[pos+1:])
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !params.Has(walAutoCheckpointParam) {
+		return dsn, nil, nil
+	}
+
+	v, err := strconv.Atoi(params.Get(walAutoCheckpointParam))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid %s value: %w", walAutoCheckpointParam, err)
+	}
+
+	params.Del(walAutoCheckpointParam)
+	return dsn[:pos+1] + params.Encode(), &v, nil
+}
+
 func (c *CustomSQLiteConn) Close() error {
 	conn := c.SQLiteConn
 
@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseWALAutoCheckpoint(t *testing.T) {
+	zero := 0
+	pages := 500
+
+	tests := []struct {
+		name    string
+		dsn     string
+		wantDSN string
+		want    *int
+		wantErr bool
+	}{
+		{"no params", "file:stash.db", "file:stash.db", nil, false},
+		{"not set", "file:stash.db?_journal=WAL", "file:stash.db?_journal=WAL", nil, false},
+		{"set", "file:stash.db?_journal=WAL&_wal_autocheckpoint=500", "file:stash.db?_journal=WAL", &pages, false},
+		{"disabled", "file:stash.db?_wal_autocheckpoint=0&mode=ro", "file:stash.db?mode=ro", &zero, false},
+		{"invalid", "file:stash.db?_wal_autocheckpoint=abc", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDSN, got, err := parseWALAutoCheckpoint(tt.dsn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseWALAutoCheckpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantDSN, gotDSN)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const favoritedAtColumn = "favorited_at"
+
+// favoritedAtFrom returns the favorited time of an object being created or
+// replaced. Favorites without a favorited time are favorited at updatedAt.
+func favoritedAtFrom(favorite bool, favoritedAt *time.Time, updatedAt time.Time) NullTimestamp {
+	if !favorite {
+		return NullTimestamp{}
+	}
+
+	if favoritedAt == nil {
+		favoritedAt = &updatedAt
+	}
+
+	return NullTimestampFromTimePtr(favoritedAt)
+}
+
+// setFavoritedAt sets the favorited time when the favorite flag is set. The
+// existing time is kept if the object is already a favorite, and cleared if
+// the object is no longer a favorite.
+func (r *updateRecord) setFavoritedAt(favorite models.OptionalBool, favoritedAt models.OptionalTime, updatedAt models.OptionalTime) {
+	switch {
+	case favoritedAt.Set:
+		r.setNullTimestamp(favoritedAtColumn, favoritedAt)
+	case !favorite.Set:
+		return
+	case !favorite.Value:
+		r.set(favoritedAtColumn, NullTimestamp{})
+	default:
+		t := time.Now()
+		if updatedAt.Set {
+			t = updatedAt.Value
+		}
+
+		r.set(favoritedAtColumn, goqu.L("COALESCE("+favoritedAtColumn+", ?)", Timestamp{Timestamp: t}))
+	}
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type fieldSourceRow struct {
+	Field      string    `db:"field"`
+	SourceType string    `db:"source_type"`
+	Source     string    `db:"source"`
+	UpdatedAt  Timestamp `db:"updated_at"`
+}
+
+func (r *fieldSourceRow) resolve() *models.FieldSource {
+	return &models.FieldSource{
+		Field:     r.Field,
+		Type:      models.FieldSourceType(r.SourceType),
+		Source:    r.Source,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+type fieldSourcesStore struct {
+	table exp.IdentifierExpression
+	fk    exp.IdentifierExpression
+}
+
+func (s *fieldSourcesStore) GetFieldSources(ctx context.Context, id int) ([]*models.FieldSource, error) {
+	q := dialect.Select("field", "source_type", "source", "updated_at").From(s.table).Where(s.fk.Eq(id)).Order(goqu.C("field").Asc())
+
+	const single = false
+	var ret []*models.FieldSource
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var row fieldSourceRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting field sources: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (s *fieldSourcesStore) SetFieldSources(ctx context.Context, id int, sources []*models.FieldSource) error {
+	if len(sources) == 0 {
+		return nil
+	}
+
+	fk := s.fk.GetCol().(string)
+	conflictKey := fk + ", field"
+	q := dialect.Insert(s.table).Prepared(true).
+		OnConflict(goqu.DoUpdate(conflictKey, goqu.Record{
+			"source_type": goqu.I("excluded.source_type"),
+			"source":      goqu.I("excluded.source"),
+			"updated_at":  goqu.I("excluded.updated_at"),
+		}))
+
+	r := make([]interface{}, len(sources))
+	for i, src := range sources {
+		if !src.Type.IsValid() {
+			return fmt.Errorf("invalid field source type %q", src.Type)
+		}
+
+		r[i] = goqu.Record{
+			fk:            id,
+			"field":       src.Field,
+			"source_type": src.Type.String(),
+			"source":      src.Source,
+			"updated_at":  Timestamp{Timestamp: src.UpdatedAt},
+		}
+	}
+
+	if _, err := exec(ctx, q.Rows(r...)); err != nil {
+		return fmt.Errorf("setting field sources: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
 )
 
 const (
@@ -35,6 +38,8 @@ type basicFileRow struct {
 	ZipFileID      null.Int        `db:"zip_file_id"`
 	ParentFolderID models.FolderID `db:"parent_folder_id"`
 	Size           int64           `db:"size"`
+	PendingContent bool            `db:"pending_content"`
+	LinkedFileID   null.Int        `db:"linked_file_id"`
 	ModTime        Timestamp       `db:"mod_time"`
 	CreatedAt      Timestamp       `db:"created_at"`
 	UpdatedAt      Timestamp       `db:"updated_at"`
@@ -46,6 +51,8 @@ func (r *basicFileRow) fromBasicFile(o models.BaseFile) {
 	r.ZipFileID = nullIntFromFileIDPtr(o.ZipFileID)
 	r.ParentFolderID = o.ParentFolderID
 	r.Size = o.Size
+	r.PendingContent = o.PendingContent
+	r.LinkedFileID = nullIntFromFileIDPtr(o.LinkedFileID)
 	r.ModTime = Timestamp{Timestamp: o.ModTime}
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
@@ -61,6 +68,12 @@ type videoFileRow struct {
 	AudioCodec       string        `db:"audio_codec"`
 	FrameRate        float64       `db:"frame_rate"`
 	BitRate          int64         `db:"bit_rate"`
+	BitDepth         int           `db:"bit_depth"`
+	AudioBitRate     int64         `db:"audio_bit_rate"`
+	QualityScore     int           `db:"quality_score"`
+	HDRFormat        zero.String   `db:"hdr_format"`
+	VRProjection     zero.String   `db:"vr_projection"`
+	StereoLayout     zero.String   `db:"stereo_layout"`
 	Interactive      bool          `db:"interactive"`
 	InteractiveSpeed null.Int      `db:"interactive_speed"`
 }
@@ -75,6 +88,18 @@ func (f *videoFileRow) fromVideoFile(ff models.VideoFile) {
 	f.AudioCodec = ff.AudioCodec
 	f.FrameRate = ff.FrameRate
 	f.BitRate = ff.BitRate
+	f.BitDepth = ff.BitDepth
+	f.AudioBitRate = ff.AudioBitRate
+	f.QualityScore = ff.QualityScore
+	if ff.HDRFormat != nil && ff.HDRFormat.IsValid() {
+		f.HDRFormat = zero.StringFrom(ff.HDRFormat.String())
+	}
+	if ff.VRProjection != nil && ff.VRProjection.IsValid() {
+		f.VRProjection = zero.StringFrom(ff.VRProjection.String())
+	}
+	if ff.StereoLayout != nil && ff.StereoLayout.IsValid() {
+		f.StereoLayout = zero.StringFrom(ff.StereoLayout.String())
+	}
 	f.Interactive = ff.Interactive
 	f.InteractiveSpeed = intFromPtr(ff.InteractiveSpeed)
 }
@@ -105,12 +130,18 @@ type videoFileQueryRow struct {
 	AudioCodec       null.String `db:"audio_codec"`
 	FrameRate        null.Float  `db:"frame_rate"`
 	BitRate          null.Int    `db:"bit_rate"`
+	BitDepth         null.Int    `db:"bit_depth"`
+	AudioBitRate     null.Int    `db:"audio_bit_rate"`
+	QualityScore     null.Int    `db:"quality_score"`
+	HDRFormat        null.String `db:"hdr_format"`
+	VRProjection     null.String `db:"vr_projection"`
+	StereoLayout     null.String `db:"stereo_layout"`
 	Interactive      null.Bool   `db:"interactive"`
 	InteractiveSpeed null.Int    `db:"interactive_speed"`
 }
 
 func (f *videoFileQueryRow) resolve() *models.VideoFile {
-	return &models.VideoFile{
+	ret := &models.VideoFile{
 		Format:           f.Format.String,
 		Width:            int(f.Width.Int64),
 		Height:           int(f.Height.Int64),
@@ -119,9 +150,27 @@ func (f *videoFileQueryRow) resolve() *models.VideoFile {
 		AudioCodec:       f.AudioCodec.String,
 		FrameRate:        f.FrameRate.Float64,
 		BitRate:          f.BitRate.Int64,
+		BitDepth:         int(f.BitDepth.Int64),
+		AudioBitRate:     f.AudioBitRate.Int64,
+		QualityScore:     int(f.QualityScore.Int64),
 		Interactive:      f.Interactive.Bool,
 		InteractiveSpeed: nullIntPtr(f.InteractiveSpeed),
 	}
+
+	if f.HDRFormat.ValueOrZero() != "" {
+		v := models.HDRFormat(f.HDRFormat.String)
+		ret.HDRFormat = &v
+	}
+	if f.VRProjection.ValueOrZero() != "" {
+		v := models.VRProjection(f.VRProjection.String)
+		ret.VRProjection = &v
+	}
+	if f.StereoLayout.ValueOrZero() != "" {
+		v := models.StereoLayout(f.StereoLayout.String)
+		ret.StereoLayout = &v
+	}
+
+	return ret
 }
 
 func videoFileQueryColumns() []interface{} {
@@ -136,6 +185,12 @@ func videoFileQueryColumns() []interface{} {
 		table.Col("audio_codec"),
 		table.Col("frame_rate"),
 		table.Col("bit_rate"),
+		table.Col("bit_depth"),
+		table.Col("audio_bit_rate"),
+		table.Col("quality_score"),
+		table.Col("hdr_format"),
+		table.Col("vr_projection"),
+		table.Col("stereo_layout"),
 		table.Col("interactive"),
 		table.Col("interactive_speed"),
 	}
@@ -172,6 +227,8 @@ type fileQueryRow struct {
 	ZipFileID      null.Int      `db:"zip_file_id"`
 	ParentFolderID null.Int      `db:"parent_folder_id"`
 	Size           null.Int      `db:"size"`
+	PendingContent null.Bool     `db:"pending_content"`
+	LinkedFileID   null.Int      `db:"linked_file_id"`
 	ModTime        NullTimestamp `db:"mod_time"`
 	CreatedAt      NullTimestamp `db:"file_created_at"`
 	UpdatedAt      NullTimestamp `db:"file_updated_at"`
@@ -197,6 +254,8 @@ func (r *fileQueryRow) resolve() models.File {
 		ParentFolderID: models.FolderID(r.ParentFolderID.Int64),
 		Basename:       r.Basename.String,
 		Size:           r.Size.Int64,
+		PendingContent: r.PendingContent.Bool,
+		LinkedFileID:   nullIntFileIDPtr(r.LinkedFileID),
 		CreatedAt:      r.CreatedAt.Timestamp,
 		UpdatedAt:      r.UpdatedAt.Timestamp,
 	}
@@ -455,6 +514,8 @@ func (qb *FileStore) selectDataset() *goqu.SelectDataset {
 		table.Col("zip_file_id"),
 		table.Col("parent_folder_id"),
 		table.Col("size"),
+		table.Col("pending_content"),
+		table.Col("linked_file_id"),
 		table.Col("mod_time"),
 		table.Col("created_at").As("file_created_at"),
 		table.Col("updated_at").As("file_updated_at"),
@@ -692,6 +753,30 @@ func (qb *FileStore) CountAllInPaths(ctx context.Context, p []string) (int, erro
 	return count(ctx, q)
 }
 
+// SizeAllInPaths returns the total size of all files that are within any
+// of the given paths. Returns the size of all files if p is empty. Files
+// sharing storage with another file are not included.
+func (qb *FileStore) SizeAllInPaths(ctx context.Context, p []string) (int64, error) {
+	table := qb.table()
+	folderTable := folderTableMgr.table
+
+	q := dialect.Select(
+		goqu.COALESCE(goqu.SUM(table.Col("size")), 0),
+	).From(table).Prepared(true).InnerJoin(
+		folderTable,
+		goqu.On(table.Col("parent_folder_id").Eq(folderTable.Col(idColumn))),
+	).Where(table.Col("linked_file_id").IsNull())
+
+	q = qb.allInPaths(q, p)
+
+	var ret int64
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return 0, fmt.Errorf("getting size of files in paths %s: %w", p, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *FileStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset) ([]models.File, error) {
 	table := qb.table()
 
@@ -717,6 +802,42 @@ func (qb *FileStore) FindByFingerprint(ctx context.Context, fp models.Fingerprin
 	return qb.findBySubquery(ctx, sq)
 }
 
+var findIdenticalFilesQuery = `
+SELECT GROUP_CONCAT(files.id) as ids
+FROM files
+INNER JOIN files_fingerprints ON (files.id = files_fingerprints.file_id AND files_fingerprints.type = 'oshash')
+WHERE files.zip_file_id IS NULL AND files.pending_content = 0
+GROUP BY files_fingerprints.fingerprint, files.size
+HAVING COUNT(*) > 1
+ORDER BY files.size DESC;
+`
+
+// FindIdenticalFiles returns groups of the IDs of files outside of zip files
+// with the same oshash and size. Each group is ordered by ID.
+func (qb *FileStore) FindIdenticalFiles(ctx context.Context) ([][]models.FileID, error) {
+	var groups []string
+	if err := dbWrapper.Select(ctx, &groups, findIdenticalFilesQuery); err != nil {
+		return nil, fmt.Errorf("finding identical files: %w", err)
+	}
+
+	var ret [][]models.FileID
+	for _, g := range groups {
+		var ids []models.FileID
+		for _, strID := range strings.Split(g, ",") {
+			if id, err := strconv.Atoi(strID); err == nil {
+				ids = append(ids, models.FileID(id))
+			}
+		}
+
+		if len(ids) > 1 {
+			slices.Sort(ids)
+			ret = append(ret, ids)
+		}
+	}
+
+	return ret, nil
+}
+
 func (qb *FileStore) FindByZipFileID(ctx context.Context, zipFileID models.FileID) ([]models.File, error) {
 	table := qb.table()
 
@@ -742,6 +863,17 @@ func (qb *FileStore) FindByFileInfo(ctx context.Context, info fs.FileInfo, size
 	return qb.getMany(ctx, q)
 }
 
+func (qb *FileStore) FindByBasenameAndSize(ctx context.Context, basename string, size int64) ([]models.File, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("basename").Eq(basename),
+		table.Col("size").Eq(size),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
 func (qb *FileStore) CountByFolderID(ctx context.Context, folderID models.FolderID) (int, error) {
 	table := qb.table()
 
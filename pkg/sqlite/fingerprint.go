@@ -6,6 +6,7 @@ import (
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/models"
 	"gopkg.in/guregu/null.v4"
 )
@@ -14,6 +15,22 @@ const (
 	fingerprintTable = "files_fingerprints"
 )
 
+// isFingerprintType returns true if t is a fingerprint type calculated when
+// scanning files.
+func isFingerprintType(t string) bool {
+	return t == models.FingerprintTypePhash || t == models.FingerprintTypeOshash || hash.IsChecksumAlgorithm(t)
+}
+
+// missingFingerprintCriterion filters to objects with a file missing a
+// fingerprint of fpType. filesJoinTable is the join table between the objects
+// and their files, which must already be joined. fpType must be a valid
+// fingerprint type.
+func missingFingerprintCriterion(f *filterBuilder, filesJoinTable string, fpType string) {
+	as := "fingerprints_" + fpType
+	f.addLeftJoin(fingerprintTable, as, fmt.Sprintf("%[1]s.file_id = %[2]s.file_id AND %[2]s.type = '%[3]s'", filesJoinTable, as, fpType))
+	f.addWhere(as + ".fingerprint IS NULL")
+}
+
 type fingerprintQueryRow struct {
 	Type        null.String `db:"fingerprint_type"`
 	Fingerprint interface{} `db:"fingerprint"`
@@ -27,22 +27,32 @@ const (
 	galleryIDColumn          = "gallery_id"
 	galleriesURLsTable       = "gallery_urls"
 	galleriesURLColumn       = "url"
+	galleriesViewDatesTable  = "galleries_view_dates"
+	galleryViewDateColumn    = "view_date"
+	galleriesODatesTable     = "galleries_o_dates"
+	galleryODateColumn       = "o_date"
 )
 
 type galleryRow struct {
-	ID           int         `db:"id" goqu:"skipinsert"`
-	Title        zero.String `db:"title"`
-	Code         zero.String `db:"code"`
-	Date         NullDate    `db:"date"`
-	Details      zero.String `db:"details"`
-	Photographer zero.String `db:"photographer"`
+	ID            int         `db:"id" goqu:"skipinsert"`
+	Title         zero.String `db:"title"`
+	Code          zero.String `db:"code"`
+	Date          NullDate    `db:"date"`
+	DatePrecision null.Int    `db:"date_precision"`
+	Details       zero.String `db:"details"`
+	Photographer  zero.String `db:"photographer"`
 	// expressed as 1-100
-	Rating    null.Int  `db:"rating"`
-	Organized bool      `db:"organized"`
-	StudioID  null.Int  `db:"studio_id,omitempty"`
-	FolderID  null.Int  `db:"folder_id,omitempty"`
-	CreatedAt Timestamp `db:"created_at"`
-	UpdatedAt Timestamp `db:"updated_at"`
+	Rating    null.Int    `db:"rating"`
+	Organized bool        `db:"organized"`
+	Archived  bool        `db:"archived"`
+	StudioID  null.Int    `db:"studio_id,omitempty"`
+	FolderID  null.Int    `db:"folder_id,omitempty"`
+	Country   zero.String `db:"country"`
+	City      zero.String `db:"city"`
+	Latitude  null.Float  `db:"latitude"`
+	Longitude null.Float  `db:"longitude"`
+	CreatedAt Timestamp   `db:"created_at"`
+	UpdatedAt Timestamp   `db:"updated_at"`
 }
 
 func (r *galleryRow) fromGallery(o models.Gallery) {
@@ -50,12 +60,18 @@ func (r *galleryRow) fromGallery(o models.Gallery) {
 	r.Title = zero.StringFrom(o.Title)
 	r.Code = zero.StringFrom(o.Code)
 	r.Date = NullDateFromDatePtr(o.Date)
+	r.DatePrecision = datePrecisionFromDatePtr(o.Date)
 	r.Details = zero.StringFrom(o.Details)
 	r.Photographer = zero.StringFrom(o.Photographer)
 	r.Rating = intFromPtr(o.Rating)
 	r.Organized = o.Organized
+	r.Archived = o.Archived
 	r.StudioID = intFromPtr(o.StudioID)
 	r.FolderID = nullIntFromFolderIDPtr(o.FolderID)
+	r.Country = zero.StringFrom(o.Country)
+	r.City = zero.StringFrom(o.City)
+	r.Latitude = null.FloatFromPtr(o.Latitude)
+	r.Longitude = null.FloatFromPtr(o.Longitude)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 }
@@ -74,13 +90,18 @@ func (r *galleryQueryRow) resolve() *models.Gallery {
 		ID:            r.ID,
 		Title:         r.Title.String,
 		Code:          r.Code.String,
-		Date:          r.Date.DatePtr(),
+		Date:          r.Date.DatePtrWithPrecision(r.DatePrecision),
 		Details:       r.Details.String,
 		Photographer:  r.Photographer.String,
 		Rating:        nullIntPtr(r.Rating),
 		Organized:     r.Organized,
+		Archived:      r.Archived,
 		StudioID:      nullIntPtr(r.StudioID),
 		FolderID:      nullIntFolderIDPtr(r.FolderID),
+		Country:       r.Country.String,
+		City:          r.City.String,
+		Latitude:      nullFloatPtr(r.Latitude),
+		Longitude:     nullFloatPtr(r.Longitude),
 		PrimaryFileID: nullIntFileIDPtr(r.PrimaryFileID),
 		CreatedAt:     r.CreatedAt.Timestamp,
 		UpdatedAt:     r.UpdatedAt.Timestamp,
@@ -102,12 +123,17 @@ type galleryRowRecord struct {
 func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 	r.setNullString("title", o.Title)
 	r.setNullString("code", o.Code)
-	r.setNullDate("date", o.Date)
+	r.setNullDateWithPrecision("date", "date_precision", o.Date)
 	r.setNullString("details", o.Details)
 	r.setNullString("photographer", o.Photographer)
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
+	r.setBool("archived", o.Archived)
 	r.setNullInt("studio_id", o.StudioID)
+	r.setNullString("country", o.Country)
+	r.setNullString("city", o.City)
+	r.setNullFloat64("latitude", o.Latitude)
+	r.setNullFloat64("longitude", o.Longitude)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
 }
@@ -162,14 +188,16 @@ var (
 				tableName: galleriesImagesTable,
 				idColumn:  galleryIDColumn,
 			},
-			fkColumn: "image_id",
+			fkColumn:       "image_id",
+			restrictedTags: imageRestrictedTags,
 		},
 		scenes: joinRepository{
 			repository: repository{
 				tableName: galleriesScenesTable,
 				idColumn:  galleryIDColumn,
 			},
-			fkColumn: sceneIDColumn,
+			fkColumn:       sceneIDColumn,
+			restrictedTags: sceneRestrictedTags,
 		},
 		files: filesRepository{
 			repository: repository{
@@ -182,6 +210,8 @@ var (
 
 type GalleryStore struct {
 	tableMgr *table
+	oDateManager
+	viewDateManager
 
 	fileStore   *FileStore
 	folderStore *FolderStore
@@ -189,9 +219,11 @@ type GalleryStore struct {
 
 func NewGalleryStore(fileStore *FileStore, folderStore *FolderStore) *GalleryStore {
 	return &GalleryStore{
-		tableMgr:    galleryTableMgr,
-		fileStore:   fileStore,
-		folderStore: folderStore,
+		tableMgr:        galleryTableMgr,
+		oDateManager:    oDateManager{galleriesOTableMgr},
+		viewDateManager: viewDateManager{galleriesViewTableMgr},
+		fileStore:       fileStore,
+		folderStore:     folderStore,
 	}
 }
 
@@ -469,6 +501,8 @@ func (qb *GalleryStore) get(ctx context.Context, q *goqu.SelectDataset) (*models
 }
 
 func (qb *GalleryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Gallery, error) {
+	q = restrictDataset(ctx, q, galleryRestrictedTags.where(galleryTable+".id"))
+
 	const single = false
 	var ret []*models.Gallery
 	var lastID int
@@ -515,6 +549,11 @@ func (qb *GalleryStore) CountByFileID(ctx context.Context, fileID models.FileID)
 }
 
 func (qb *GalleryStore) FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]*models.Gallery, error) {
+	// an empty where clause would match everything
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
 	fingerprintTable := fingerprintTableMgr.table
 
 	var ex []exp.Expression
@@ -646,6 +685,7 @@ func (qb *GalleryStore) CountByImageID(ctx context.Context, imageID int) (int, e
 	joinTable := galleriesImagesJoinTable
 
 	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col(imageIDColumn).Eq(imageID))
+	q = restrictDataset(ctx, q, galleryRestrictedTags.where(galleriesImagesTable+"."+galleryIDColumn))
 	return count(ctx, q)
 }
 
@@ -669,8 +709,10 @@ func (qb *GalleryStore) FindUserGalleryByTitle(ctx context.Context, title string
 	return ret, nil
 }
 
+// Count returns the number of galleries, excluding archived galleries.
 func (qb *GalleryStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table()).Where(qb.table().Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, galleryRestrictedTags.where(galleryTable+".id"))
 	return count(ctx, q)
 }
 
@@ -688,6 +730,8 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 
 	query := galleryRepository.newQuery()
 	distinctIDs(&query, galleryTable)
+	restrictQuery(ctx, &query, galleryRestrictedTags.where(galleryTable+".id"))
+	excludeArchived(&query, galleryTable, galleryFilter.FiltersArchived())
 
 	if q := findFilter.Q; q != nil && *q != "" {
 		query.addJoins(
@@ -775,6 +819,10 @@ var gallerySortOptions = sortOptions{
 	"file_mod_time",
 	"id",
 	"images_count",
+	"last_o_at",
+	"last_read_at",
+	"last_viewed_at",
+	"o_counter",
 	"path",
 	"performer_count",
 	"random",
@@ -782,6 +830,7 @@ var gallerySortOptions = sortOptions{
 	"tag_count",
 	"title",
 	"updated_at",
+	"view_count",
 }
 
 func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.FindFilterType) error {
@@ -833,6 +882,16 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		query.sortAndPagination += getCountSort(galleryTable, galleriesTagsTable, galleryIDColumn, direction)
 	case "performer_count":
 		query.sortAndPagination += getCountSort(galleryTable, performersGalleriesTable, galleryIDColumn, direction)
+	case "view_count":
+		query.sortAndPagination += getCountSort(galleryTable, galleriesViewDatesTable, galleryIDColumn, direction)
+	case "last_viewed_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", galleriesViewDatesTable, galleryIDColumn, galleryTable, getSortDirection(direction))
+	case "o_counter":
+		query.sortAndPagination += getCountSort(galleryTable, galleriesODatesTable, galleryIDColumn, direction)
+	case "last_o_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", galleriesODatesTable, galleryIDColumn, galleryTable, getSortDirection(direction))
+	case "last_read_at":
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT updated_at FROM %s AS sort WHERE sort.%s = %s.id) %s", galleryReadingSessionsTable, galleryIDColumn, galleryTable, getSortDirection(direction))
 	case "path":
 		// special handling for path
 		addFileTable()
@@ -846,6 +905,8 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		addFileTable()
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(galleries.title, files.basename, basename(COALESCE(folders.path, ''))) COLLATE NATURAL_CI " + direction + ", file_folder.path COLLATE NATURAL_CI " + direction
+	case "date":
+		query.sortAndPagination += getDateSort(direction, galleryTable)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "galleries")
 	}
@@ -860,6 +921,14 @@ func (qb *GalleryStore) GetURLs(ctx context.Context, galleryID int) ([]string, e
 	return galleriesURLsTableMgr.get(ctx, galleryID)
 }
 
+func (qb *GalleryStore) GetTypedURLs(ctx context.Context, galleryID int) ([]models.TypedURL, error) {
+	return galleriesURLsTableMgr.getTyped(ctx, galleryID)
+}
+
+func (qb *GalleryStore) SetURLTypes(ctx context.Context, galleryID int, types map[string]*models.URLType) error {
+	return galleriesURLsTableMgr.setTypes(ctx, galleryID, types)
+}
+
 func (qb *GalleryStore) AddFileID(ctx context.Context, id int, fileID models.FileID) error {
 	const firstPrimary = false
 	return galleriesFilesTableMgr.insertJoins(ctx, id, firstPrimary, []models.FileID{fileID})
@@ -873,6 +942,14 @@ func (qb *GalleryStore) GetTagIDs(ctx context.Context, id int) ([]int, error) {
 	return galleryRepository.tags.getIDs(ctx, id)
 }
 
+func (qb *GalleryStore) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return galleryRepository.performers.getManyIDs(ctx, ids)
+}
+
+func (qb *GalleryStore) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return galleryRepository.tags.getManyIDs(ctx, ids)
+}
+
 func (qb *GalleryStore) GetImageIDs(ctx context.Context, galleryID int) ([]int, error) {
 	return galleryRepository.images.getIDs(ctx, galleryID)
 }
@@ -901,3 +978,7 @@ func (qb *GalleryStore) ResetCover(ctx context.Context, galleryID int) error {
 func (qb *GalleryStore) GetSceneIDs(ctx context.Context, id int) ([]int, error) {
 	return galleryRepository.scenes.getIDs(ctx, id)
 }
+
+func (qb *GalleryStore) GetManySceneIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return galleryRepository.scenes.getManyIDs(ctx, ids)
+}
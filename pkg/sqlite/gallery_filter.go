@@ -61,6 +61,9 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(filter.Code, "galleries.code"),
 		stringCriterionHandler(filter.Details, "galleries.details"),
 		stringCriterionHandler(filter.Photographer, "galleries.photographer"),
+		stringCriterionHandler(filter.Country, "galleries.country"),
+		stringCriterionHandler(filter.City, "galleries.city"),
+		geoBoundsCriterionHandler(filter.Location, "galleries.latitude", "galleries.longitude"),
 
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if filter.Checksum != nil {
@@ -87,7 +90,9 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		qb.fileCountCriterionHandler(filter.FileCount),
 		intCriterionHandler(filter.Rating100, "galleries.rating", nil),
 		qb.urlsCriterionHandler(filter.URL),
+		typedURLCriterionHandler(filter.TypedURL, galleriesURLsTable, galleryIDColumn, "galleries.id"),
 		boolCriterionHandler(filter.Organized, "galleries.organized", nil),
+		boolCriterionHandler(filter.Archived, "galleries.archived", nil),
 		qb.missingCriterionHandler(filter.IsMissing),
 		qb.tagsCriterionHandler(filter.Tags),
 		qb.tagCountCriterionHandler(filter.TagCount),
@@ -99,6 +104,9 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		qb.performerTagsCriterionHandler(filter.PerformerTags),
 		qb.averageResolutionCriterionHandler(filter.AverageResolution),
 		qb.imageCountCriterionHandler(filter.ImageCount),
+		qb.oCountCriterionHandler(filter.OCounter),
+		qb.viewCountCriterionHandler(filter.ViewCount),
+		qb.inProgressCriterionHandler(filter.InProgress),
 		qb.performerFavoriteCriterionHandler(filter.PerformerFavorite),
 		qb.performerAgeCriterionHandler(filter.PerformerAge),
 		&dateCriterionHandler{filter.Date, "galleries.date", nil},
@@ -251,6 +259,39 @@ func (qb *galleryFilterHandler) fileCountCriterionHandler(fileCount *models.IntC
 	return h.handler(fileCount)
 }
 
+func (qb *galleryFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: galleryTable,
+		joinTable:    galleriesODatesTable,
+		primaryFK:    galleryIDColumn,
+	}
+
+	return h.handler(count)
+}
+
+func (qb *galleryFilterHandler) viewCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: galleryTable,
+		joinTable:    galleriesViewDatesTable,
+		primaryFK:    galleryIDColumn,
+	}
+
+	return h.handler(count)
+}
+
+func (qb *galleryFilterHandler) inProgressCriterionHandler(inProgress *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if inProgress != nil {
+			f.addLeftJoin(galleryReadingSessionsTable, "", "gallery_reading_sessions.gallery_id = galleries.id")
+			if *inProgress {
+				f.addWhere("gallery_reading_sessions.gallery_id IS NOT NULL")
+			} else {
+				f.addWhere("gallery_reading_sessions.gallery_id IS NULL")
+			}
+		}
+	}
+}
+
 func (qb *galleryFilterHandler) missingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -271,6 +312,8 @@ func (qb *galleryFilterHandler) missingCriterionHandler(isMissing *string) crite
 			case "tags":
 				galleryRepository.tags.join(f, "tags_join", "galleries.id")
 				f.addWhere("tags_join.gallery_id IS NULL")
+			case "location":
+				f.addWhere("galleries.latitude IS NULL OR galleries.longitude IS NULL")
 			default:
 				f.addWhere("(galleries." + *isMissing + " IS NULL OR TRIM(galleries." + *isMissing + ") = '')")
 			}
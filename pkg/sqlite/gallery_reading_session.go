@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	galleryReadingSessionsTable = "gallery_reading_sessions"
+)
+
+type galleryReadingSessionRow struct {
+	GalleryID int       `db:"gallery_id"`
+	Page      int       `db:"page"`
+	StartedAt Timestamp `db:"started_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *galleryReadingSessionRow) resolve() *models.GalleryReadingSession {
+	return &models.GalleryReadingSession{
+		GalleryID: r.GalleryID,
+		Page:      r.Page,
+		StartedAt: r.StartedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+func (qb *GalleryStore) readingSessionSelectDataset() *goqu.SelectDataset {
+	table := galleryReadingSessionTableMgr.table
+	return dialect.From(table).Select(table.All())
+}
+
+// SaveReadingSession sets the page of the reading session of the gallery,
+// starting a new session if the gallery does not have one.
+func (qb *GalleryStore) SaveReadingSession(ctx context.Context, galleryID int, page int) (*models.GalleryReadingSession, error) {
+	table := galleryReadingSessionTableMgr.table
+	now := Timestamp{Timestamp: time.Now()}
+
+	q := dialect.Insert(table).Prepared(true).Rows(galleryReadingSessionRow{
+		GalleryID: galleryID,
+		Page:      page,
+		StartedAt: now,
+		UpdatedAt: now,
+	}).OnConflict(goqu.DoUpdate(galleryIDColumn, goqu.Record{
+		"page":       goqu.I("excluded.page"),
+		"updated_at": goqu.I("excluded.updated_at"),
+	}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return nil, fmt.Errorf("saving reading session: %w", err)
+	}
+
+	return qb.GetReadingSession(ctx, galleryID)
+}
+
+func (qb *GalleryStore) DeleteReadingSession(ctx context.Context, galleryID int) error {
+	return galleryReadingSessionTableMgr.destroy(ctx, []int{galleryID})
+}
+
+// returns nil, nil if not found
+func (qb *GalleryStore) GetReadingSession(ctx context.Context, galleryID int) (*models.GalleryReadingSession, error) {
+	q := qb.readingSessionSelectDataset().Where(galleryReadingSessionTableMgr.byID(galleryID))
+
+	ret, err := qb.getReadingSessions(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *GalleryStore) FindReadingSessions(ctx context.Context, limit int) ([]*models.GalleryReadingSession, error) {
+	table := galleryReadingSessionTableMgr.table
+	q := qb.readingSessionSelectDataset().Order(table.Col("updated_at").Desc())
+
+	if limit >= 0 {
+		q = q.Limit(uint(limit))
+	}
+
+	return qb.getReadingSessions(ctx, q)
+}
+
+func (qb *GalleryStore) getReadingSessions(ctx context.Context, q *goqu.SelectDataset) ([]*models.GalleryReadingSession, error) {
+	const single = false
+	var ret []*models.GalleryReadingSession
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f galleryReadingSessionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
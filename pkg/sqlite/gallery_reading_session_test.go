@@ -0,0 +1,64 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGalleryStore_ReadingSession(t *testing.T) {
+	qb := db.Gallery
+	galleryID := galleryIDs[galleryIdxWithImage]
+
+	withRollbackTxn(func(ctx context.Context) error {
+		assert := assert.New(t)
+
+		got, err := qb.GetReadingSession(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetReadingSession() error = %v", err)
+			return nil
+		}
+		assert.Nil(got)
+
+		started, err := qb.SaveReadingSession(ctx, galleryID, 1)
+		if err != nil {
+			t.Errorf("GalleryStore.SaveReadingSession() error = %v", err)
+			return nil
+		}
+		assert.Equal(galleryID, started.GalleryID)
+		assert.Equal(1, started.Page)
+
+		updated, err := qb.SaveReadingSession(ctx, galleryID, 3)
+		if err != nil {
+			t.Errorf("GalleryStore.SaveReadingSession() error = %v", err)
+			return nil
+		}
+		assert.Equal(3, updated.Page)
+		assert.Equal(started.StartedAt, updated.StartedAt)
+
+		sessions, err := qb.FindReadingSessions(ctx, -1)
+		if err != nil {
+			t.Errorf("GalleryStore.FindReadingSessions() error = %v", err)
+			return nil
+		}
+		assert.Len(sessions, 1)
+
+		if err := qb.DeleteReadingSession(ctx, galleryID); err != nil {
+			t.Errorf("GalleryStore.DeleteReadingSession() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.GetReadingSession(ctx, galleryID)
+		if err != nil {
+			t.Errorf("GalleryStore.GetReadingSession() error = %v", err)
+			return nil
+		}
+		assert.Nil(got)
+
+		return nil
+	})
+}
@@ -605,6 +605,9 @@ FROM groups_scenes
 INNER JOIN performers_scenes ON performers_scenes.scene_id = groups_scenes.scene_id
 WHERE performers_scenes.performer_id = ?
 `
+	if hideRestricted(ctx) {
+		query += "AND " + sceneRestrictedTags.where("groups_scenes.scene_id")
+	}
 	args := []interface{}{performerID}
 	return groupRepository.runCountQuery(ctx, query, args)
 }
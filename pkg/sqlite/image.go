@@ -27,6 +27,8 @@ const (
 	imagesFilesTable      = "images_files"
 	imagesURLsTable       = "image_urls"
 	imageURLColumn        = "url"
+	imagesViewDatesTable  = "images_view_dates"
+	imageViewDateColumn   = "view_date"
 )
 
 type imageRow struct {
@@ -34,15 +36,21 @@ type imageRow struct {
 	Title zero.String `db:"title"`
 	Code  zero.String `db:"code"`
 	// expressed as 1-100
-	Rating       null.Int    `db:"rating"`
-	Date         NullDate    `db:"date"`
-	Details      zero.String `db:"details"`
-	Photographer zero.String `db:"photographer"`
-	Organized    bool        `db:"organized"`
-	OCounter     int         `db:"o_counter"`
-	StudioID     null.Int    `db:"studio_id,omitempty"`
-	CreatedAt    Timestamp   `db:"created_at"`
-	UpdatedAt    Timestamp   `db:"updated_at"`
+	Rating        null.Int    `db:"rating"`
+	Date          NullDate    `db:"date"`
+	DatePrecision null.Int    `db:"date_precision"`
+	Details       zero.String `db:"details"`
+	Photographer  zero.String `db:"photographer"`
+	Organized     bool        `db:"organized"`
+	Archived      bool        `db:"archived"`
+	OCounter      int         `db:"o_counter"`
+	StudioID      null.Int    `db:"studio_id,omitempty"`
+	Country       zero.String `db:"country"`
+	City          zero.String `db:"city"`
+	Latitude      null.Float  `db:"latitude"`
+	Longitude     null.Float  `db:"longitude"`
+	CreatedAt     Timestamp   `db:"created_at"`
+	UpdatedAt     Timestamp   `db:"updated_at"`
 }
 
 func (r *imageRow) fromImage(i models.Image) {
@@ -51,11 +59,17 @@ func (r *imageRow) fromImage(i models.Image) {
 	r.Code = zero.StringFrom(i.Code)
 	r.Rating = intFromPtr(i.Rating)
 	r.Date = NullDateFromDatePtr(i.Date)
+	r.DatePrecision = datePrecisionFromDatePtr(i.Date)
 	r.Details = zero.StringFrom(i.Details)
 	r.Photographer = zero.StringFrom(i.Photographer)
 	r.Organized = i.Organized
+	r.Archived = i.Archived
 	r.OCounter = i.OCounter
 	r.StudioID = intFromPtr(i.StudioID)
+	r.Country = zero.StringFrom(i.Country)
+	r.City = zero.StringFrom(i.City)
+	r.Latitude = null.FloatFromPtr(i.Latitude)
+	r.Longitude = null.FloatFromPtr(i.Longitude)
 	r.CreatedAt = Timestamp{Timestamp: i.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: i.UpdatedAt}
 }
@@ -74,12 +88,17 @@ func (r *imageQueryRow) resolve() *models.Image {
 		Title:        r.Title.String,
 		Code:         r.Code.String,
 		Rating:       nullIntPtr(r.Rating),
-		Date:         r.Date.DatePtr(),
+		Date:         r.Date.DatePtrWithPrecision(r.DatePrecision),
 		Details:      r.Details.String,
 		Photographer: r.Photographer.String,
 		Organized:    r.Organized,
+		Archived:     r.Archived,
 		OCounter:     r.OCounter,
 		StudioID:     nullIntPtr(r.StudioID),
+		Country:      r.Country.String,
+		City:         r.City.String,
+		Latitude:     nullFloatPtr(r.Latitude),
+		Longitude:    nullFloatPtr(r.Longitude),
 
 		PrimaryFileID: nullIntFileIDPtr(r.PrimaryFileID),
 		Checksum:      r.PrimaryFileChecksum.String,
@@ -103,12 +122,17 @@ func (r *imageRowRecord) fromPartial(i models.ImagePartial) {
 	r.setNullString("title", i.Title)
 	r.setNullString("code", i.Code)
 	r.setNullInt("rating", i.Rating)
-	r.setNullDate("date", i.Date)
+	r.setNullDateWithPrecision("date", "date_precision", i.Date)
 	r.setNullString("details", i.Details)
 	r.setNullString("photographer", i.Photographer)
 	r.setBool("organized", i.Organized)
+	r.setBool("archived", i.Archived)
 	r.setInt("o_counter", i.OCounter)
 	r.setNullInt("studio_id", i.StudioID)
+	r.setNullString("country", i.Country)
+	r.setNullString("city", i.City)
+	r.setNullFloat64("latitude", i.Latitude)
+	r.setNullFloat64("longitude", i.Longitude)
 	r.setTimestamp("created_at", i.CreatedAt)
 	r.setTimestamp("updated_at", i.UpdatedAt)
 }
@@ -160,7 +184,8 @@ var (
 				tableName: galleriesImagesTable,
 				idColumn:  imageIDColumn,
 			},
-			fkColumn: galleryIDColumn,
+			fkColumn:       galleryIDColumn,
+			restrictedTags: galleryRestrictedTags,
 		},
 
 		files: filesRepository{
@@ -185,6 +210,7 @@ var (
 type ImageStore struct {
 	tableMgr *table
 	oCounterManager
+	viewDateManager
 
 	repo *storeRepository
 }
@@ -193,6 +219,7 @@ func NewImageStore(r *storeRepository) *ImageStore {
 	return &ImageStore{
 		tableMgr:        imageTableMgr,
 		oCounterManager: oCounterManager{imageTableMgr},
+		viewDateManager: viewDateManager{imagesViewTableMgr},
 		repo:            r,
 	}
 }
@@ -456,6 +483,8 @@ func (qb *ImageStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.I
 }
 
 func (qb *ImageStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Image, error) {
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(imageTable+".id"))
+
 	const single = false
 	var ret []*models.Image
 	var lastID int
@@ -566,6 +595,11 @@ func (qb *ImageStore) CountByFileID(ctx context.Context, fileID models.FileID) (
 }
 
 func (qb *ImageStore) FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]*models.Image, error) {
+	// an empty where clause would match everything
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
 	table := qb.table()
 	fingerprintTable := fingerprintTableMgr.table
 
@@ -662,17 +696,83 @@ func (qb *ImageStore) FindByGalleryIDIndex(ctx context.Context, galleryID int, i
 	return ret[0], nil
 }
 
+func (qb *ImageStore) FindByFilePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	// phashes of images are only generated for animated images and gallery
+	// covers, so most images will not have one
+	query := `
+SELECT images_files.image_id AS id, MIN(phash_distance(files_fingerprints.fingerprint, ?)) AS distance
+FROM images_files
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+WHERE typeof(files_fingerprints.fingerprint) = 'integer' AND phash_distance(files_fingerprints.fingerprint, ?) <= ?
+GROUP BY images_files.image_id
+ORDER BY distance, images_files.image_id
+`
+
+	var ret []models.PhashMatch
+	if err := dbWrapper.Select(ctx, &ret, query, phash, phash, distance); err != nil {
+		return nil, fmt.Errorf("finding images by phash: %w", err)
+	}
+
+	return ret, nil
+}
+
+// CountByGalleryID returns the number of images in the gallery, excluding
+// archived images.
 func (qb *ImageStore) CountByGalleryID(ctx context.Context, galleryID int) (int, error) {
 	joinTable := goqu.T(galleriesImagesTable)
 
-	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col("gallery_id").Eq(galleryID))
+	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(
+		joinTable.Col("gallery_id").Eq(galleryID),
+		joinTable.Col(imageIDColumn).NotIn(qb.archivedIDs()),
+	)
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(galleriesImagesTable+".image_id"))
 	return count(ctx, q)
 }
 
+// CountByGalleryIDs returns the number of images in each of the galleries,
+// in the same order as galleryIDs. Archived images are excluded.
+func (qb *ImageStore) CountByGalleryIDs(ctx context.Context, galleryIDs []int) ([]int, error) {
+	ret := make([]int, len(galleryIDs))
+	if len(galleryIDs) == 0 {
+		return ret, nil
+	}
+
+	joinTable := goqu.T(galleriesImagesTable)
+	q := dialect.Select(joinTable.Col("gallery_id"), goqu.COUNT("*")).From(joinTable).Where(
+		joinTable.Col("gallery_id").In(galleryIDs),
+		joinTable.Col(imageIDColumn).NotIn(qb.archivedIDs()),
+	).GroupBy(joinTable.Col("gallery_id"))
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(galleriesImagesTable+".image_id"))
+
+	idToIndex := idToIndexMap(galleryIDs)
+
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var id, count int
+		if err := rows.Scan(&id, &count); err != nil {
+			return err
+		}
+
+		ret[idToIndex[id]] = count
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("counting images by gallery: %w", err)
+	}
+
+	return ret, nil
+}
+
+// archivedIDs returns a dataset selecting the ids of archived images.
+func (qb *ImageStore) archivedIDs() *goqu.SelectDataset {
+	table := qb.table()
+	return dialect.From(table).Select(table.Col(idColumn)).Where(table.Col(archivedColumn).Eq(1))
+}
+
 func (qb *ImageStore) OCountByPerformerID(ctx context.Context, performerID int) (int, error) {
 	table := qb.table()
 	joinTable := performersImagesJoinTable
 	q := dialect.Select(goqu.COALESCE(goqu.SUM("o_counter"), 0)).From(table).InnerJoin(joinTable, goqu.On(table.Col(idColumn).Eq(joinTable.Col(imageIDColumn)))).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(imageTable+".id"))
 
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -686,6 +786,8 @@ func (qb *ImageStore) OCount(ctx context.Context) (int, error) {
 	table := qb.table()
 
 	q := dialect.Select(goqu.COALESCE(goqu.SUM("o_counter"), 0)).From(table)
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(imageTable+".id"))
+
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -744,8 +846,10 @@ func (qb *ImageStore) FindByZipFileID(ctx context.Context, zipFileID models.File
 	return ret, nil
 }
 
+// Count returns the number of images, excluding archived images.
 func (qb *ImageStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table()).Where(qb.table().Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(imageTable+".id"))
 	return count(ctx, q)
 }
 
@@ -760,7 +864,9 @@ func (qb *ImageStore) Size(ctx context.Context) (float64, error) {
 	).InnerJoin(
 		fileTable,
 		goqu.On(imagesFilesJoinTable.Col(fileIDColumn).Eq(fileTable.Col(idColumn))),
-	)
+	).Where(table.Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, imageRestrictedTags.where(imageTable+".id"))
+
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -783,6 +889,8 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 
 	query := imageRepository.newQuery()
 	distinctIDs(&query, imageTable)
+	restrictQuery(ctx, &query, imageRestrictedTags.where(imageTable+".id"))
+	excludeArchived(&query, imageTable, imageFilter.FiltersArchived())
 
 	if q := findFilter.Q; q != nil && *q != "" {
 		query.addJoins(
@@ -921,6 +1029,7 @@ var imageSortOptions = sortOptions{
 	"file_mod_time",
 	"filesize",
 	"id",
+	"last_viewed_at",
 	"o_counter",
 	"path",
 	"performer_count",
@@ -929,6 +1038,7 @@ var imageSortOptions = sortOptions{
 	"tag_count",
 	"title",
 	"updated_at",
+	"view_count",
 }
 
 func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *models.FindFilterType) error {
@@ -979,6 +1089,10 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			sortClause = getCountSort(imageTable, imagesTagsTable, imageIDColumn, direction)
 		case "performer_count":
 			sortClause = getCountSort(imageTable, performersImagesTable, imageIDColumn, direction)
+		case "view_count":
+			sortClause = getCountSort(imageTable, imagesViewDatesTable, imageIDColumn, direction)
+		case "last_viewed_at":
+			sortClause = fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", imagesViewDatesTable, imageIDColumn, imageTable, getSortDirection(direction))
 		case "mod_time", "filesize":
 			addFilesJoin()
 			sortClause = getSort(sort, direction, "files")
@@ -986,6 +1100,8 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			addFilesJoin()
 			addFolderJoin()
 			sortClause = " ORDER BY COALESCE(images.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+		case "date":
+			sortClause = getDateSort(direction, imageTable)
 		default:
 			sortClause = getSort(sort, direction, "images")
 		}
@@ -1023,6 +1139,18 @@ func (qb *ImageStore) GetGalleryIDs(ctx context.Context, imageID int) ([]int, er
 	return imageRepository.galleries.getIDs(ctx, imageID)
 }
 
+func (qb *ImageStore) GetManyGalleryIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return imageRepository.galleries.getManyIDs(ctx, ids)
+}
+
+func (qb *ImageStore) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return imageRepository.performers.getManyIDs(ctx, ids)
+}
+
+func (qb *ImageStore) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return imageRepository.tags.getManyIDs(ctx, ids)
+}
+
 // func (qb *imageQueryBuilder) UpdateGalleries(ctx context.Context, imageID int, galleryIDs []int) error {
 // 	// Delete the existing joins and then create new ones
 // 	return qb.galleriesRepository().replace(ctx, imageID, galleryIDs)
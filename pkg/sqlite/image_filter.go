@@ -66,17 +66,23 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(imageFilter.Code, "images.code"),
 		stringCriterionHandler(imageFilter.Details, "images.details"),
 		stringCriterionHandler(imageFilter.Photographer, "images.photographer"),
+		stringCriterionHandler(imageFilter.Country, "images.country"),
+		stringCriterionHandler(imageFilter.City, "images.city"),
+		geoBoundsCriterionHandler(imageFilter.Location, "images.latitude", "images.longitude"),
 
 		pathCriterionHandler(imageFilter.Path, "folders.path", "files.basename", imageRepository.addFoldersTable),
 		qb.fileCountCriterionHandler(imageFilter.FileCount),
 		intCriterionHandler(imageFilter.Rating100, "images.rating", nil),
 		intCriterionHandler(imageFilter.OCounter, "images.o_counter", nil),
+		qb.viewCountCriterionHandler(imageFilter.ViewCount),
 		boolCriterionHandler(imageFilter.Organized, "images.organized", nil),
+		boolCriterionHandler(imageFilter.Archived, "images.archived", nil),
 		&dateCriterionHandler{imageFilter.Date, "images.date", nil},
 		qb.urlsCriterionHandler(imageFilter.URL),
 
 		resolutionCriterionHandler(imageFilter.Resolution, "image_files.height", "image_files.width", imageRepository.addImageFilesTable),
 		orientationCriterionHandler(imageFilter.Orientation, "image_files.height", "image_files.width", imageRepository.addImageFilesTable),
+		boolCriterionHandler(imageFilter.PendingContent, "files.pending_content", imageRepository.addFilesTable),
 		qb.missingCriterionHandler(imageFilter.IsMissing),
 
 		qb.tagsCriterionHandler(imageFilter.Tags),
@@ -136,6 +142,16 @@ func (qb *imageFilterHandler) fileCountCriterionHandler(fileCount *models.IntCri
 	return h.handler(fileCount)
 }
 
+func (qb *imageFilterHandler) viewCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: imageTable,
+		joinTable:    imagesViewDatesTable,
+		primaryFK:    imageIDColumn,
+	}
+
+	return h.handler(count)
+}
+
 func (qb *imageFilterHandler) missingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -151,7 +167,15 @@ func (qb *imageFilterHandler) missingCriterionHandler(isMissing *string) criteri
 			case "tags":
 				imageRepository.tags.join(f, "tags_join", "images.id")
 				f.addWhere("tags_join.image_id IS NULL")
+			case "location":
+				f.addWhere("images.latitude IS NULL OR images.longitude IS NULL")
 			default:
+				if isFingerprintType(*isMissing) {
+					imageRepository.addImagesFilesTable(f)
+					missingFingerprintCriterion(f, imagesFilesTable, *isMissing)
+					return
+				}
+
 				f.addWhere("(images." + *isMissing + " IS NULL OR TRIM(images." + *isMissing + ") = '')")
 			}
 		}
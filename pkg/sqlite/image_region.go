@@ -0,0 +1,205 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	imageRegionTable = "image_regions"
+)
+
+type imageRegionRow struct {
+	ID          int       `db:"id" goqu:"skipinsert"`
+	ImageID     int       `db:"image_id"`
+	X           float64   `db:"x"`
+	Y           float64   `db:"y"`
+	Width       float64   `db:"width"`
+	Height      float64   `db:"height"`
+	TagID       null.Int  `db:"tag_id,omitempty"`
+	PerformerID null.Int  `db:"performer_id,omitempty"`
+	CreatedAt   Timestamp `db:"created_at"`
+	UpdatedAt   Timestamp `db:"updated_at"`
+}
+
+func (r *imageRegionRow) fromImageRegion(o models.ImageRegion) {
+	r.ID = o.ID
+	r.ImageID = o.ImageID
+	r.X = o.X
+	r.Y = o.Y
+	r.Width = o.Width
+	r.Height = o.Height
+	r.TagID = intFromPtr(o.TagID)
+	r.PerformerID = intFromPtr(o.PerformerID)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *imageRegionRow) resolve() *models.ImageRegion {
+	ret := &models.ImageRegion{
+		ID:          r.ID,
+		ImageID:     r.ImageID,
+		X:           r.X,
+		Y:           r.Y,
+		Width:       r.Width,
+		Height:      r.Height,
+		TagID:       nullIntPtr(r.TagID),
+		PerformerID: nullIntPtr(r.PerformerID),
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type imageRegionRowRecord struct {
+	updateRecord
+}
+
+func (r *imageRegionRowRecord) fromPartial(o models.ImageRegionPartial) {
+	r.setFloat64("x", o.X)
+	r.setFloat64("y", o.Y)
+	r.setFloat64("width", o.Width)
+	r.setFloat64("height", o.Height)
+	r.setNullInt("tag_id", o.TagID)
+	r.setNullInt("performer_id", o.PerformerID)
+	r.setTimestamp("created_at", o.CreatedAt)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type ImageRegionStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewImageRegionStore() *ImageRegionStore {
+	return &ImageRegionStore{
+		repository: repository{
+			tableName: imageRegionTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: imageRegionTableMgr,
+	}
+}
+
+func (qb *ImageRegionStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *ImageRegionStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *ImageRegionStore) Create(ctx context.Context, newObject *models.ImageRegion) error {
+	var r imageRegionRow
+	r.fromImageRegion(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *ImageRegionStore) UpdatePartial(ctx context.Context, id int, partial models.ImageRegionPartial) (*models.ImageRegion, error) {
+	r := imageRegionRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *ImageRegionStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *ImageRegionStore) Find(ctx context.Context, id int) (*models.ImageRegion, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *ImageRegionStore) find(ctx context.Context, id int) (*models.ImageRegion, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *ImageRegionStore) FindByImageID(ctx context.Context, imageID int) ([]*models.ImageRegion, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(imageIDColumn).Eq(imageID)).Order(
+		table.Col(idColumn).Asc(),
+	)
+	return qb.getMany(ctx, q)
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *ImageRegionStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.ImageRegion, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *ImageRegionStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.ImageRegion, error) {
+	const single = false
+	var ret []*models.ImageRegion
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f imageRegionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
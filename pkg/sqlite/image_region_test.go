@@ -0,0 +1,80 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageRegionCreateUpdateFindDestroy(t *testing.T) {
+	imageID := imageIDs[imageIdxWithTag]
+	tagID := tagIDs[tagIdxWithImage]
+	performerID := performerIDs[performerIdxWithImage]
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.ImageRegion
+
+		tagRegion := models.NewImageRegion()
+		tagRegion.ImageID = imageID
+		tagRegion.X = 0.1
+		tagRegion.Y = 0.2
+		tagRegion.Width = 0.3
+		tagRegion.Height = 0.4
+		tagRegion.TagID = &tagID
+
+		performerRegion := models.NewImageRegion()
+		performerRegion.ImageID = imageID
+		performerRegion.Width = 0.5
+		performerRegion.Height = 0.5
+		performerRegion.PerformerID = &performerID
+
+		for _, r := range []*models.ImageRegion{&tagRegion, &performerRegion} {
+			if err := qb.Create(ctx, r); err != nil {
+				t.Errorf("Error creating image region: %v", err)
+				return nil
+			}
+		}
+
+		found, err := qb.FindByImageID(ctx, imageID)
+		if err != nil {
+			t.Errorf("Error finding image regions: %v", err)
+			return nil
+		}
+		if assert.Len(t, found, 2) {
+			assert.Equal(t, tagRegion, *found[0])
+			assert.Equal(t, performerRegion, *found[1])
+		}
+
+		partial := models.NewImageRegionPartial()
+		partial.X = models.NewOptionalFloat64(0.5)
+		partial.TagID = models.NewOptionalIntPtr(nil)
+		partial.PerformerID = models.NewOptionalInt(performerID)
+		updated, err := qb.UpdatePartial(ctx, tagRegion.ID, partial)
+		if err != nil {
+			t.Errorf("Error updating image region: %v", err)
+			return nil
+		}
+		assert.Equal(t, 0.5, updated.X)
+		assert.Nil(t, updated.TagID)
+		assert.Equal(t, &performerID, updated.PerformerID)
+
+		if err := qb.Destroy(ctx, tagRegion.ID); err != nil {
+			t.Errorf("Error destroying image region: %v", err)
+			return nil
+		}
+
+		destroyed, err := qb.Find(ctx, tagRegion.ID)
+		if err != nil {
+			t.Errorf("Error finding image region: %v", err)
+			return nil
+		}
+		assert.Nil(t, destroyed)
+
+		return nil
+	})
+}
@@ -1335,6 +1335,23 @@ func Test_imageQueryBuilder_CountByGalleryID(t *testing.T) {
 	}
 }
 
+func Test_imageQueryBuilder_CountByGalleryIDs(t *testing.T) {
+	runWithRollbackTxn(t, "count", func(t *testing.T, ctx context.Context) {
+		ids := []int{
+			galleryIDs[galleryIdxWithTwoImages],
+			galleryIDs[galleryIdx1WithPerformer],
+		}
+
+		got, err := db.Image.CountByGalleryIDs(ctx, ids)
+		if err != nil {
+			t.Errorf("imageQueryBuilder.CountByGalleryIDs() error = %v", err)
+			return
+		}
+
+		assert.Equal(t, []int{2, 0}, got)
+	})
+}
+
 func imagesToIDs(i []*models.Image) []int {
 	var ret []int
 	for _, ii := range i {
@@ -1990,6 +2007,27 @@ func TestImageQueryIsMissingGalleries(t *testing.T) {
 	})
 }
 
+func TestImageQueryIsMissingFingerprint(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Image
+
+		// test images only have an md5 fingerprint
+		isMissing := "md5"
+		imageFilter := models.ImageFilterType{
+			IsMissing: &isMissing,
+		}
+
+		images := queryImages(ctx, t, sqb, &imageFilter, models.BatchFindFilter(-1))
+		assert.Len(t, images, 0)
+
+		isMissing = "oshash"
+		images = queryImages(ctx, t, sqb, &imageFilter, models.BatchFindFilter(-1))
+		assert.Len(t, images, totalImages)
+
+		return nil
+	})
+}
+
 func TestImageQueryIsMissingStudio(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		sqb := db.Image
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var errInvalidCellSize = errors.New("cell size must be greater than zero")
+
+type locationClusterRow struct {
+	Count        int     `db:"count"`
+	Latitude     float64 `db:"latitude"`
+	Longitude    float64 `db:"longitude"`
+	MinLatitude  float64 `db:"min_latitude"`
+	MaxLatitude  float64 `db:"max_latitude"`
+	MinLongitude float64 `db:"min_longitude"`
+	MaxLongitude float64 `db:"max_longitude"`
+}
+
+// locationClusters groups the located rows of the table into grid cells of
+// cellSize degrees. setCount is used to set the number of rows in each
+// cluster.
+func (r *repository) locationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput, setCount func(c *models.LocationCluster, n int)) ([]*models.LocationCluster, error) {
+	if cellSize <= 0 {
+		return nil, errInvalidCellSize
+	}
+
+	table := r.tableName
+	lat := table + ".latitude"
+	long := table + ".longitude"
+
+	where := fmt.Sprintf("%s IS NOT NULL AND %s IS NOT NULL", lat, long)
+	var args []interface{}
+	if bounds != nil {
+		clause, boundsArgs := getGeoBoundsWhereClause(*bounds, lat, long)
+		where += " AND " + clause
+		args = append(args, boundsArgs...)
+	}
+
+	// latitude and longitude are offset to be positive so that the cast
+	// rounds down
+	query := fmt.Sprintf(`SELECT COUNT(*) AS count,
+AVG(%[1]s) AS latitude, AVG(%[2]s) AS longitude,
+MIN(%[1]s) AS min_latitude, MAX(%[1]s) AS max_latitude,
+MIN(%[2]s) AS min_longitude, MAX(%[2]s) AS max_longitude
+FROM %[3]s
+WHERE %[4]s
+GROUP BY CAST((%[1]s + 90) / ? AS INTEGER), CAST((%[2]s + 180) / ? AS INTEGER)`, lat, long, table, where)
+	args = append(args, cellSize, cellSize)
+
+	var ret []*models.LocationCluster
+	if err := r.queryFunc(ctx, query, args, false, func(rows *sqlx.Rows) error {
+		var row locationClusterRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		c := &models.LocationCluster{
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+			Bounds: models.GeoBounds{
+				MinLatitude:  row.MinLatitude,
+				MaxLatitude:  row.MaxLatitude,
+				MinLongitude: row.MinLongitude,
+				MaxLongitude: row.MaxLongitude,
+			},
+		}
+		setCount(c, row.Count)
+		ret = append(ret, c)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("querying location clusters: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (qb *SceneStore) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	return sceneRepository.locationClusters(ctx, cellSize, bounds, func(c *models.LocationCluster, n int) {
+		c.SceneCount = n
+	})
+}
+
+func (qb *GalleryStore) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	return galleryRepository.locationClusters(ctx, cellSize, bounds, func(c *models.LocationCluster, n int) {
+		c.GalleryCount = n
+	})
+}
+
+func (qb *ImageStore) LocationClusters(ctx context.Context, cellSize float64, bounds *models.GeoBoundsInput) ([]*models.LocationCluster, error) {
+	return imageRepository.locationClusters(ctx, cellSize, bounds, func(c *models.LocationCluster, n int) {
+		c.ImageCount = n
+	})
+}
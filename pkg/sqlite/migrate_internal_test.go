@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// migrateTo runs all migrations up to and including the given schema version.
+func migrateTo(ctx context.Context, t *testing.T, m *Migrator, version uint) {
+	t.Helper()
+
+	for v := m.CurrentSchemaVersion() + 1; v <= version; v++ {
+		if err := m.RunMigration(ctx, v); err != nil {
+			t.Fatalf("running migration %d: %v", v, err)
+		}
+	}
+}
+
+func TestMigration82PerformerImages(t *testing.T) {
+	ctx := context.Background()
+
+	db := NewDatabase()
+	db.dbPath = filepath.Join(t.TempDir(), "stash.sqlite")
+
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator error = %v", err)
+	}
+	defer m.Close()
+
+	migrateTo(ctx, t, m, 81)
+
+	stmts := []string{
+		"INSERT INTO `blobs` (`checksum`, `blob`) VALUES ('abc', X'0102')",
+		"INSERT INTO `performers` (`id`, `name`, `image_blob`, `created_at`, `updated_at`) VALUES (1, 'with image', 'abc', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+		"INSERT INTO `performers` (`id`, `name`, `created_at`, `updated_at`) VALUES (2, 'without image', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+	}
+	for _, stmt := range stmts {
+		if _, err := m.conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+
+	migrateTo(ctx, t, m, 82)
+
+	type performerImage struct {
+		PerformerID int    `db:"performer_id"`
+		Position    int    `db:"position"`
+		ImageBlob   string `db:"image_blob"`
+	}
+
+	var got []performerImage
+	if err := m.conn.SelectContext(ctx, &got, "SELECT `performer_id`, `position`, `image_blob` FROM `performer_images`"); err != nil {
+		t.Fatalf("select performer_images: %v", err)
+	}
+
+	// the existing image becomes the first image of the performer
+	assert.Equal(t, []performerImage{
+		{PerformerID: 1, Position: 0, ImageBlob: "abc"},
+	}, got)
+}
@@ -0,0 +1,5 @@
+-- archived scenes, images and galleries are excluded from queries that do
+-- not filter on the archived status, and from stats
+ALTER TABLE `scenes` ADD COLUMN `archived` boolean not null default '0';
+ALTER TABLE `images` ADD COLUMN `archived` boolean not null default '0';
+ALTER TABLE `galleries` ADD COLUMN `archived` boolean not null default '0';
@@ -0,0 +1,15 @@
+-- markers proposed by tasks that have not been accepted
+CREATE TABLE `scene_marker_suggestions` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `title` varchar(255) not null,
+  `seconds` float not null,
+  `end_seconds` float,
+  `primary_tag_id` integer not null,
+  `source` varchar(255) not null,
+  `created_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`primary_tag_id`) references `tags`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_marker_suggestions_on_scene_id` ON `scene_marker_suggestions` (`scene_id`, `seconds`);
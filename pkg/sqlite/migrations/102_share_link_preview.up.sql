@@ -0,0 +1,2 @@
+-- existing links are not unfurled, so that their content stays private
+ALTER TABLE `share_links` ADD COLUMN `preview` varchar(255) not null default 'NONE';
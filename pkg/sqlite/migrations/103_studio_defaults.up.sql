@@ -0,0 +1,20 @@
+-- metadata applied to scenes when they are attributed to a studio
+ALTER TABLE `studios` ADD COLUMN `propagate_defaults` boolean not null default '0';
+
+CREATE TABLE `studio_default_tags` (
+  `studio_id` integer NOT NULL,
+  `tag_id` integer NOT NULL,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `tag_id`)
+);
+
+CREATE INDEX `index_studio_default_tags_on_tag_id` on `studio_default_tags` (`tag_id`);
+
+CREATE TABLE `studio_default_urls` (
+  `studio_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `position`, `url`)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE `saved_filter_folders` (
+  `id` integer not null primary key autoincrement,
+  `mode` varchar(255) not null,
+  `name` varchar(255) not null,
+  `parent_id` integer,
+  `sort_order` integer not null default 0,
+  foreign key(`parent_id`) references `saved_filter_folders`(`id`) on delete SET NULL
+);
+
+CREATE INDEX `index_saved_filter_folders_on_parent_id` on `saved_filter_folders` (`parent_id`);
+
+ALTER TABLE `saved_filters` ADD COLUMN `folder_id` integer REFERENCES `saved_filter_folders`(`id`) ON DELETE SET NULL;
+ALTER TABLE `saved_filters` ADD COLUMN `sort_order` integer not null default 0;
+ALTER TABLE `saved_filters` ADD COLUMN `is_default` boolean not null default '0';
+
+CREATE INDEX `index_saved_filters_on_folder_id` on `saved_filters` (`folder_id`);
+-- at most one default filter per mode
+CREATE UNIQUE INDEX `index_saved_filters_on_mode_default` on `saved_filters` (`mode`) WHERE `is_default` = 1;
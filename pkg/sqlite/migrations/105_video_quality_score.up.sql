@@ -0,0 +1,5 @@
+-- audio_bit_rate of -1 marks existing files as missing metadata, so that they
+-- are probed again on the next scan and their quality score is calculated
+ALTER TABLE `video_files` ADD COLUMN `audio_bit_rate` integer not null default -1;
+ALTER TABLE `video_files` ADD COLUMN `quality_score` integer not null default 0;
+CREATE INDEX `index_video_files_on_quality_score` ON `video_files` (`quality_score`);
@@ -0,0 +1,30 @@
+-- the type of a URL, such as OFFICIAL or SOCIAL. NULL if not known.
+ALTER TABLE `scene_urls` ADD COLUMN `type` varchar(255);
+ALTER TABLE `gallery_urls` ADD COLUMN `type` varchar(255);
+ALTER TABLE `performer_urls` ADD COLUMN `type` varchar(255);
+
+CREATE TABLE `studio_urls` (
+  `studio_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  `type` varchar(255),
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `position`, `url`)
+);
+
+CREATE INDEX `studio_urls_url` on `studio_urls` (`url`);
+
+INSERT INTO `studio_urls`
+  (
+    `studio_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    '0',
+    `url`
+  FROM `studios`
+  WHERE `studios`.`url` IS NOT NULL AND `studios`.`url` != '';
+
+ALTER TABLE `studios` DROP COLUMN `url`;
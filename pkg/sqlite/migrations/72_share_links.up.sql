@@ -0,0 +1,19 @@
+CREATE TABLE `share_links` (
+  `id` integer not null primary key autoincrement,
+  `token` varchar(255) not null,
+  `scene_id` integer,
+  `gallery_id` integer,
+  `password` varchar(255),
+  `expires_at` datetime,
+  `max_views` integer,
+  `view_count` integer not null default 0,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  check ((`scene_id` is null) != (`gallery_id` is null))
+);
+
+CREATE UNIQUE INDEX `index_share_links_token_unique` ON `share_links` (`token`);
+CREATE INDEX `index_share_links_scene_id` ON `share_links` (`scene_id`);
+CREATE INDEX `index_share_links_gallery_id` ON `share_links` (`gallery_id`);
@@ -0,0 +1,22 @@
+CREATE TABLE `totp_credentials` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `secret` varchar(255) not null,
+  `enabled` boolean not null default '0',
+  `last_used_step` integer not null default 0,
+  `session_version` integer not null default 0,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_totp_credentials_username_unique` ON `totp_credentials` (`username`);
+
+CREATE TABLE `totp_recovery_codes` (
+  `id` integer not null primary key autoincrement,
+  `credential_id` integer not null,
+  `code_hash` varchar(255) not null,
+  `used_at` datetime,
+  foreign key(`credential_id`) references `totp_credentials`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_totp_recovery_codes_credential_id` ON `totp_recovery_codes` (`credential_id`);
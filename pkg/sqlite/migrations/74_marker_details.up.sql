@@ -0,0 +1,13 @@
+ALTER TABLE `scene_markers` ADD COLUMN `details` text;
+ALTER TABLE `scene_markers` ADD COLUMN `rating` tinyint;
+ALTER TABLE `scene_markers` ADD COLUMN `cover_seconds` float;
+
+CREATE TABLE `performers_scene_markers` (
+  `performer_id` integer NOT NULL,
+  `scene_marker_id` integer NOT NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  foreign key(`scene_marker_id`) references `scene_markers`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_marker_id`, `performer_id`)
+);
+
+CREATE INDEX `index_performers_scene_markers_on_performer_id` on `performers_scene_markers` (`performer_id`);
@@ -0,0 +1,13 @@
+CREATE TABLE `default_filters` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `mode` varchar(255) not null,
+  `find_filter` blob,
+  `object_filter` blob,
+  `display_mode` integer,
+  `zoom_index` integer,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_default_filters_username_mode_unique` ON `default_filters` (`username`, `mode`);
@@ -0,0 +1,33 @@
+CREATE TABLE `galleries_view_dates` (
+  `gallery_id` integer not null,
+  `view_date` datetime not null,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_galleries_view_dates` ON `galleries_view_dates` (`gallery_id`);
+
+CREATE TABLE `galleries_o_dates` (
+  `gallery_id` integer not null,
+  `o_date` datetime not null,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_galleries_o_dates` ON `galleries_o_dates` (`gallery_id`);
+
+CREATE TABLE `images_view_dates` (
+  `image_id` integer not null,
+  `view_date` datetime not null,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_images_view_dates` ON `images_view_dates` (`image_id`);
+
+CREATE TABLE `gallery_reading_sessions` (
+  `gallery_id` integer not null primary key,
+  `page` integer not null,
+  `started_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_gallery_reading_sessions_updated_at` ON `gallery_reading_sessions` (`updated_at`);
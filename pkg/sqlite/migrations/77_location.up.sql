@@ -0,0 +1,15 @@
+ALTER TABLE `scenes` ADD COLUMN `country` varchar(255);
+ALTER TABLE `scenes` ADD COLUMN `city` varchar(255);
+ALTER TABLE `scenes` ADD COLUMN `latitude` real;
+ALTER TABLE `scenes` ADD COLUMN `longitude` real;
+ALTER TABLE `galleries` ADD COLUMN `country` varchar(255);
+ALTER TABLE `galleries` ADD COLUMN `city` varchar(255);
+ALTER TABLE `galleries` ADD COLUMN `latitude` real;
+ALTER TABLE `galleries` ADD COLUMN `longitude` real;
+ALTER TABLE `images` ADD COLUMN `country` varchar(255);
+ALTER TABLE `images` ADD COLUMN `city` varchar(255);
+ALTER TABLE `images` ADD COLUMN `latitude` real;
+ALTER TABLE `images` ADD COLUMN `longitude` real;
+CREATE INDEX `index_scenes_on_latitude_longitude` ON `scenes` (`latitude`, `longitude`);
+CREATE INDEX `index_galleries_on_latitude_longitude` ON `galleries` (`latitude`, `longitude`);
+CREATE INDEX `index_images_on_latitude_longitude` ON `images` (`latitude`, `longitude`);
@@ -0,0 +1,16 @@
+CREATE TABLE `notes` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer,
+  `performer_id` integer,
+  `author` varchar(255),
+  `body` text not null,
+  `pinned` boolean not null default '0',
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  check ((`scene_id` is null) != (`performer_id` is null))
+);
+
+CREATE INDEX `index_notes_scene_id` ON `notes` (`scene_id`);
+CREATE INDEX `index_notes_performer_id` ON `notes` (`performer_id`);
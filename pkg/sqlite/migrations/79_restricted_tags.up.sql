@@ -0,0 +1,2 @@
+ALTER TABLE `tags` ADD COLUMN `restricted` boolean not null default '0';
+CREATE INDEX `index_tags_on_restricted` ON `tags` (`restricted`);
@@ -0,0 +1,2 @@
+ALTER TABLE `files` ADD COLUMN `pending_content` boolean not null default '0';
+CREATE INDEX `index_files_on_pending_content` ON `files` (`pending_content`);
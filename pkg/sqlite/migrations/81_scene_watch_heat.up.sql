@@ -0,0 +1,7 @@
+CREATE TABLE `scenes_watch_heat` (
+  `scene_id` integer NOT NULL,
+  `bucket` integer NOT NULL,
+  `seconds` real NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `bucket`)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE `performer_images` (
+  `id` integer not null primary key autoincrement,
+  `performer_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `image_blob` varchar(255) NOT NULL REFERENCES `blobs`(`checksum`),
+  `source` varchar(255),
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_performer_images_on_performer_id_image_blob` ON `performer_images` (`performer_id`, `image_blob`);
+CREATE INDEX `index_performer_images_on_image_blob` ON `performer_images` (`image_blob`);
+
+-- the existing image becomes the primary image of the set
+INSERT INTO `performer_images` (`performer_id`, `position`, `image_blob`)
+SELECT `id`, 0, `image_blob` FROM `performers` WHERE `image_blob` IS NOT NULL;
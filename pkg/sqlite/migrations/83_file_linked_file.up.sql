@@ -0,0 +1,2 @@
+ALTER TABLE `files` ADD COLUMN `linked_file_id` integer REFERENCES `files`(`id`) ON DELETE SET NULL;
+CREATE INDEX `index_files_on_linked_file_id` ON `files` (`linked_file_id`) WHERE `linked_file_id` IS NOT NULL;
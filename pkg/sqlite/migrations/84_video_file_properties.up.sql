@@ -0,0 +1,7 @@
+-- bit_depth of -1 marks existing files as missing metadata, so that they are
+-- probed again on the next scan
+ALTER TABLE `video_files` ADD COLUMN `bit_depth` integer not null default -1;
+ALTER TABLE `video_files` ADD COLUMN `hdr_format` varchar(255);
+ALTER TABLE `video_files` ADD COLUMN `vr_projection` varchar(255);
+ALTER TABLE `video_files` ADD COLUMN `stereo_layout` varchar(255);
+CREATE INDEX `index_video_files_on_vr_projection` ON `video_files` (`vr_projection`) WHERE `vr_projection` IS NOT NULL;
@@ -0,0 +1,56 @@
+CREATE TABLE `deleted_objects` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `object_id` integer not null,
+  `deleted_at` datetime not null
+);
+
+CREATE INDEX `index_deleted_objects_on_deleted_at` ON `deleted_objects` (`deleted_at`);
+
+CREATE TRIGGER `scenes_deleted` AFTER DELETE ON `scenes`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('scene', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `images_deleted` AFTER DELETE ON `images`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('image', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `galleries_deleted` AFTER DELETE ON `galleries`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('gallery', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `performers_deleted` AFTER DELETE ON `performers`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('performer', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `studios_deleted` AFTER DELETE ON `studios`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('studio', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `tags_deleted` AFTER DELETE ON `tags`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('tag', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `groups_deleted` AFTER DELETE ON `groups`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('group', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
+
+CREATE TRIGGER `scene_markers_deleted` AFTER DELETE ON `scene_markers`
+BEGIN
+  INSERT INTO `deleted_objects` (`object_type`, `object_id`, `deleted_at`)
+  VALUES ('scene_marker', OLD.`id`, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+END;
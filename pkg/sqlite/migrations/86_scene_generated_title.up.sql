@@ -0,0 +1 @@
+ALTER TABLE `scenes` ADD COLUMN `generated_title` varchar(255);
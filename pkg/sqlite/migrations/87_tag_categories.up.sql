@@ -0,0 +1,14 @@
+CREATE TABLE `tag_categories` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `description` text,
+  `sort_order` integer not null default 0,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_tag_categories_on_name_unique` ON `tag_categories` (`name` COLLATE NOCASE);
+
+ALTER TABLE `tags` ADD COLUMN `category_id` integer REFERENCES `tag_categories`(`id`) ON DELETE SET NULL;
+
+CREATE INDEX `index_tags_on_category_id` ON `tags` (`category_id`);
@@ -0,0 +1,13 @@
+CREATE TABLE `quarantined_files` (
+  `id` integer not null primary key autoincrement,
+  `path` varchar(255) not null,
+  `error` text not null,
+  `size` integer not null default 0,
+  `mod_time` datetime not null,
+  `attempts` integer not null default 1,
+  `ignored` boolean not null default false,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_quarantined_files_on_path_unique` ON `quarantined_files` (`path`);
@@ -0,0 +1,5 @@
+-- populated from the measurements column in the post-migration
+ALTER TABLE `performers` ADD COLUMN `bust` integer;
+ALTER TABLE `performers` ADD COLUMN `waist` integer;
+ALTER TABLE `performers` ADD COLUMN `hips` integer;
+ALTER TABLE `performers` ADD COLUMN `cup_size` varchar(255);
@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+type schema89Migrator struct {
+	migrator
+}
+
+func post89(ctx context.Context, db *sqlx.DB) error {
+	logger.Info("Running post-migration for schema version 89")
+
+	m := schema89Migrator{
+		migrator: migrator{
+			db: db,
+		},
+	}
+
+	return m.migrate(ctx)
+}
+
+type schema89Performer struct {
+	id           int
+	measurements string
+}
+
+func (m *schema89Migrator) migrate(ctx context.Context) error {
+	// parse the existing free-text measurements into the new columns
+	if err := m.withTxn(ctx, func(tx *sqlx.Tx) error {
+		query := "SELECT `id`, `measurements` FROM `performers` WHERE `measurements` IS NOT NULL AND `measurements` != ''"
+
+		rows, err := tx.Query(query)
+		if err != nil {
+			return err
+		}
+
+		var performers []schema89Performer
+		for rows.Next() {
+			var p schema89Performer
+			if err := rows.Scan(&p.id, &p.measurements); err != nil {
+				rows.Close()
+				return err
+			}
+
+			performers = append(performers, p)
+		}
+
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+
+		parsed := 0
+		for _, p := range performers {
+			v := models.ParseMeasurements(p.measurements)
+			if v.IsEmpty() {
+				logger.Debugf("Could not parse measurements %q of performer %d", p.measurements, p.id)
+				continue
+			}
+
+			var cupSize *string
+			if v.CupSize != "" {
+				cupSize = &v.CupSize
+			}
+
+			if _, err := tx.Exec("UPDATE `performers` SET `bust` = ?, `waist` = ?, `hips` = ?, `cup_size` = ? WHERE `id` = ?", v.Bust, v.Waist, v.Hips, cupSize, p.id); err != nil {
+				return fmt.Errorf("updating measurements of performer %d: %w", p.id, err)
+			}
+
+			parsed++
+		}
+
+		logger.Infof("Parsed measurements of %d of %d performers", parsed, len(performers))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func init() {
+	sqlite.RegisterPostMigration(89, post89)
+}
@@ -0,0 +1,29 @@
+CREATE TABLE `scene_field_sources` (
+  `scene_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `source_type` varchar(32) NOT NULL,
+  `source` varchar(255) NOT NULL DEFAULT '',
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`scene_id`, `field`),
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE TABLE `performer_field_sources` (
+  `performer_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `source_type` varchar(32) NOT NULL,
+  `source` varchar(255) NOT NULL DEFAULT '',
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`performer_id`, `field`),
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE
+);
+
+CREATE TABLE `studio_field_sources` (
+  `studio_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `source_type` varchar(32) NOT NULL,
+  `source` varchar(255) NOT NULL DEFAULT '',
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`studio_id`, `field`),
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE
+);
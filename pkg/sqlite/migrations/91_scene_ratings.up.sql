@@ -0,0 +1,15 @@
+CREATE TABLE `scene_ratings` (
+  `scene_id` integer NOT NULL,
+  `username` varchar(255) NOT NULL DEFAULT '',
+  `rating` tinyint NOT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`scene_id`, `username`),
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_ratings_on_username` ON `scene_ratings` (`username`);
+
+-- existing ratings are attributed to the unauthenticated user
+INSERT INTO `scene_ratings` (`scene_id`, `username`, `rating`, `created_at`, `updated_at`)
+  SELECT `id`, '', `rating`, `updated_at`, `updated_at` FROM `scenes` WHERE `rating` IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE `image_regions` (
+  `id` integer not null primary key autoincrement,
+  `image_id` integer not null,
+  `x` real not null,
+  `y` real not null,
+  `width` real not null,
+  `height` real not null,
+  `tag_id` integer,
+  `performer_id` integer,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE,
+  foreign key(`tag_id`) references `tags`(`id`) on delete SET NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete SET NULL
+);
+
+CREATE INDEX `index_image_regions_image_id` ON `image_regions` (`image_id`);
+CREATE INDEX `index_image_regions_tag_id` ON `image_regions` (`tag_id`);
+CREATE INDEX `index_image_regions_performer_id` ON `image_regions` (`performer_id`);
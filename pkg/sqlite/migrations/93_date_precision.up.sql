@@ -0,0 +1,4 @@
+-- precision of partial dates: 0 = day, 1 = month, 2 = year
+ALTER TABLE `scenes` ADD COLUMN `date_precision` tinyint;
+ALTER TABLE `galleries` ADD COLUMN `date_precision` tinyint;
+ALTER TABLE `images` ADD COLUMN `date_precision` tinyint;
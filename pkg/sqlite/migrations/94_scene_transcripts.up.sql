@@ -0,0 +1,33 @@
+CREATE TABLE `scene_transcripts` (
+  `scene_id` integer NOT NULL PRIMARY KEY,
+  `language` varchar(255),
+  `text` text NOT NULL,
+  -- JSON array of segments with start and end times
+  `segments` text NOT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+-- full-text index of the transcripts, kept up to date by the triggers below
+CREATE VIRTUAL TABLE `scene_transcripts_fts` USING fts4(content=`scene_transcripts`, `text`);
+
+CREATE TRIGGER `scene_transcripts_fts_insert` AFTER INSERT ON `scene_transcripts`
+BEGIN
+  INSERT INTO `scene_transcripts_fts` (`docid`, `text`) VALUES (NEW.`scene_id`, NEW.`text`);
+END;
+
+CREATE TRIGGER `scene_transcripts_fts_before_update` BEFORE UPDATE ON `scene_transcripts`
+BEGIN
+  DELETE FROM `scene_transcripts_fts` WHERE `docid` = OLD.`scene_id`;
+END;
+
+CREATE TRIGGER `scene_transcripts_fts_after_update` AFTER UPDATE ON `scene_transcripts`
+BEGIN
+  INSERT INTO `scene_transcripts_fts` (`docid`, `text`) VALUES (NEW.`scene_id`, NEW.`text`);
+END;
+
+CREATE TRIGGER `scene_transcripts_fts_delete` BEFORE DELETE ON `scene_transcripts`
+BEGIN
+  DELETE FROM `scene_transcripts_fts` WHERE `docid` = OLD.`scene_id`;
+END;
@@ -0,0 +1,3 @@
+-- offsets in seconds to start and end transcoded streams at
+ALTER TABLE `scenes` ADD COLUMN `stream_start` real;
+ALTER TABLE `scenes` ADD COLUMN `stream_end` real;
@@ -0,0 +1,6 @@
+-- perceptual hashes of image blobs, used for reverse image searches.
+-- A null phash means the blob could not be hashed.
+CREATE TABLE `blob_phashes` (
+  `checksum` varchar(255) NOT NULL PRIMARY KEY REFERENCES `blobs`(`checksum`) ON DELETE CASCADE,
+  `phash` integer
+);
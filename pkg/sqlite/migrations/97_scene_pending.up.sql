@@ -0,0 +1,13 @@
+-- pending scenes are awaiting a file, which is attached when a file matching
+-- one of their pending fingerprints or urls is scanned
+ALTER TABLE `scenes` ADD COLUMN `pending` boolean not null default '0';
+
+CREATE TABLE `scene_pending_fingerprints` (
+  `scene_id` integer NOT NULL,
+  `type` varchar(255) NOT NULL,
+  `fingerprint` varchar(255) NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY (`scene_id`, `type`, `fingerprint`)
+);
+
+CREATE INDEX `index_scene_pending_fingerprints_type_fingerprint` ON `scene_pending_fingerprints` (`type`, `fingerprint`);
@@ -0,0 +1,13 @@
+-- the time that performers, studios and tags were favorited. Existing
+-- favorites use their last update time.
+ALTER TABLE `performers` ADD COLUMN `favorited_at` datetime;
+ALTER TABLE `studios` ADD COLUMN `favorited_at` datetime;
+ALTER TABLE `tags` ADD COLUMN `favorited_at` datetime;
+
+UPDATE `performers` SET `favorited_at` = `updated_at` WHERE `favorite` = 1;
+UPDATE `studios` SET `favorited_at` = `updated_at` WHERE `favorite` = 1;
+UPDATE `tags` SET `favorited_at` = `updated_at` WHERE `favorite` = 1;
+
+CREATE INDEX `index_performers_on_favorited_at` ON `performers` (`favorited_at`);
+CREATE INDEX `index_studios_on_favorited_at` ON `studios` (`favorited_at`);
+CREATE INDEX `index_tags_on_favorited_at` ON `tags` (`favorited_at`);
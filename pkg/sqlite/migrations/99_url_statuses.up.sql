@@ -0,0 +1,13 @@
+-- result of the last check of each scene and performer url
+CREATE TABLE `url_statuses` (
+  `url` varchar(255) NOT NULL PRIMARY KEY,
+  `status` varchar(255) NOT NULL,
+  `status_code` integer,
+  `redirect_url` text,
+  `content_hash` varchar(255),
+  `error` text,
+  `checked_at` datetime NOT NULL,
+  `changed_at` datetime
+);
+
+CREATE INDEX `index_url_statuses_on_checked_at` ON `url_statuses` (`checked_at`);
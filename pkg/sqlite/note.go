@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	noteTable = "notes"
+)
+
+type noteRow struct {
+	ID          int         `db:"id" goqu:"skipinsert"`
+	SceneID     null.Int    `db:"scene_id,omitempty"`
+	PerformerID null.Int    `db:"performer_id,omitempty"`
+	Author      zero.String `db:"author"`
+	Body        string      `db:"body"`
+	Pinned      bool        `db:"pinned"`
+	CreatedAt   Timestamp   `db:"created_at"`
+	UpdatedAt   Timestamp   `db:"updated_at"`
+}
+
+func (r *noteRow) fromNote(o models.Note) {
+	r.ID = o.ID
+	r.SceneID = intFromPtr(o.SceneID)
+	r.PerformerID = intFromPtr(o.PerformerID)
+	r.Author = zero.StringFrom(o.Author)
+	r.Body = o.Body
+	r.Pinned = o.Pinned
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *noteRow) resolve() *models.Note {
+	ret := &models.Note{
+		ID:          r.ID,
+		SceneID:     nullIntPtr(r.SceneID),
+		PerformerID: nullIntPtr(r.PerformerID),
+		Author:      r.Author.String,
+		Body:        r.Body,
+		Pinned:      r.Pinned,
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type noteRowRecord struct {
+	updateRecord
+}
+
+func (r *noteRowRecord) fromPartial(o models.NotePartial) {
+	r.setNullString("author", o.Author)
+	r.setString("body", o.Body)
+	r.setBool("pinned", o.Pinned)
+	r.setTimestamp("created_at", o.CreatedAt)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type NoteStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewNoteStore() *NoteStore {
+	return &NoteStore{
+		repository: repository{
+			tableName: noteTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: noteTableMgr,
+	}
+}
+
+func (qb *NoteStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *NoteStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *NoteStore) Create(ctx context.Context, newObject *models.Note) error {
+	var r noteRow
+	r.fromNote(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *NoteStore) UpdatePartial(ctx context.Context, id int, partial models.NotePartial) (*models.Note, error) {
+	r := noteRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *NoteStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *NoteStore) Find(ctx context.Context, id int) (*models.Note, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *NoteStore) find(ctx context.Context, id int) (*models.Note, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *NoteStore) findBy(ctx context.Context, col string, id int) ([]*models.Note, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(col).Eq(id)).Order(
+		table.Col("pinned").Desc(),
+		table.Col("created_at").Desc(),
+		table.Col(idColumn).Desc(),
+	)
+	return qb.getMany(ctx, q)
+}
+
+func (qb *NoteStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.Note, error) {
+	return qb.findBy(ctx, sceneIDColumn, sceneID)
+}
+
+func (qb *NoteStore) FindByPerformerID(ctx context.Context, performerID int) ([]*models.Note, error) {
+	return qb.findBy(ctx, performerIDColumn, performerID)
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *NoteStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.Note, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *NoteStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Note, error) {
+	const single = false
+	var ret []*models.Note
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f noteRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
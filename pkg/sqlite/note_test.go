@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteCreateUpdateFindDestroy(t *testing.T) {
+	sceneID := sceneIDs[sceneIdxWithPerformer]
+	performerID := performerIDs[performerIdxWithScene]
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Note
+
+		older := models.NewNote()
+		older.SceneID = &sceneID
+		older.Author = "author"
+		older.Body = "older"
+		older.CreatedAt = older.CreatedAt.Add(-time.Hour)
+
+		newer := models.NewNote()
+		newer.SceneID = &sceneID
+		newer.Body = "newer"
+
+		pinned := models.NewNote()
+		pinned.SceneID = &sceneID
+		pinned.Body = "pinned"
+		pinned.Pinned = true
+		pinned.CreatedAt = pinned.CreatedAt.Add(-2 * time.Hour)
+
+		performerNote := models.NewNote()
+		performerNote.PerformerID = &performerID
+		performerNote.Body = "performer"
+
+		for _, n := range []*models.Note{&older, &newer, &pinned, &performerNote} {
+			if err := qb.Create(ctx, n); err != nil {
+				t.Errorf("Error creating note: %v", err)
+				return nil
+			}
+		}
+
+		found, err := qb.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			t.Errorf("Error finding scene notes: %v", err)
+			return nil
+		}
+
+		var bodies []string
+		for _, n := range found {
+			bodies = append(bodies, n.Body)
+		}
+		assert.Equal(t, []string{"pinned", "newer", "older"}, bodies)
+
+		found, err = qb.FindByPerformerID(ctx, performerID)
+		if err != nil {
+			t.Errorf("Error finding performer notes: %v", err)
+			return nil
+		}
+		if assert.Len(t, found, 1) {
+			assert.Equal(t, "performer", found[0].Body)
+			assert.Nil(t, found[0].SceneID)
+		}
+
+		partial := models.NewNotePartial()
+		partial.Body = models.NewOptionalString("updated")
+		partial.Author = models.NewOptionalStringPtr(nil)
+		updated, err := qb.UpdatePartial(ctx, older.ID, partial)
+		if err != nil {
+			t.Errorf("Error updating note: %v", err)
+			return nil
+		}
+		assert.Equal(t, "updated", updated.Body)
+		assert.Equal(t, "", updated.Author)
+
+		if err := qb.Destroy(ctx, older.ID); err != nil {
+			t.Errorf("Error destroying note: %v", err)
+			return nil
+		}
+
+		destroyed, err := qb.Find(ctx, older.ID)
+		if err != nil {
+			t.Errorf("Error finding note: %v", err)
+			return nil
+		}
+		assert.Nil(t, destroyed)
+
+		return nil
+	})
+}
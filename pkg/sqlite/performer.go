@@ -50,12 +50,19 @@ type performerRow struct {
 	CreatedAt     Timestamp   `db:"created_at"`
 	UpdatedAt     Timestamp   `db:"updated_at"`
 	// expressed as 1-100
-	Rating        null.Int    `db:"rating"`
-	Details       zero.String `db:"details"`
-	DeathDate     NullDate    `db:"death_date"`
-	HairColor     zero.String `db:"hair_color"`
-	Weight        null.Int    `db:"weight"`
-	IgnoreAutoTag bool        `db:"ignore_auto_tag"`
+	Rating        null.Int      `db:"rating"`
+	Details       zero.String   `db:"details"`
+	DeathDate     NullDate      `db:"death_date"`
+	HairColor     zero.String   `db:"hair_color"`
+	Weight        null.Int      `db:"weight"`
+	IgnoreAutoTag bool          `db:"ignore_auto_tag"`
+	FavoritedAt   NullTimestamp `db:"favorited_at"`
+
+	// parsed from Measurements, not used in resolution
+	Bust    null.Int    `db:"bust"`
+	Waist   null.Int    `db:"waist"`
+	Hips    null.Int    `db:"hips"`
+	CupSize zero.String `db:"cup_size"`
 
 	// not used in resolution or updates
 	ImageBlob zero.String `db:"image_blob"`
@@ -74,6 +81,7 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	r.EyeColor = zero.StringFrom(o.EyeColor)
 	r.Height = intFromPtr(o.Height)
 	r.Measurements = zero.StringFrom(o.Measurements)
+	r.setMeasurements(models.ParseMeasurements(o.Measurements))
 	r.FakeTits = zero.StringFrom(o.FakeTits)
 	r.PenisLength = null.FloatFromPtr(o.PenisLength)
 	if o.Circumcised != nil && o.Circumcised.IsValid() {
@@ -91,6 +99,14 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	r.HairColor = zero.StringFrom(o.HairColor)
 	r.Weight = intFromPtr(o.Weight)
 	r.IgnoreAutoTag = o.IgnoreAutoTag
+	r.FavoritedAt = favoritedAtFrom(o.Favorite, o.FavoritedAt, o.UpdatedAt)
+}
+
+func (r *performerRow) setMeasurements(m models.Measurements) {
+	r.Bust = intFromPtr(m.Bust)
+	r.Waist = intFromPtr(m.Waist)
+	r.Hips = intFromPtr(m.Hips)
+	r.CupSize = zero.StringFrom(m.CupSize)
 }
 
 func (r *performerRow) resolve() *models.Performer {
@@ -119,6 +135,7 @@ func (r *performerRow) resolve() *models.Performer {
 		HairColor:     r.HairColor.String,
 		Weight:        nullIntPtr(r.Weight),
 		IgnoreAutoTag: r.IgnoreAutoTag,
+		FavoritedAt:   r.FavoritedAt.TimePtr(),
 	}
 
 	if r.Gender.ValueOrZero() != "" {
@@ -148,6 +165,9 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullString("eye_color", o.EyeColor)
 	r.setNullInt("height", o.Height)
 	r.setNullString("measurements", o.Measurements)
+	if o.Measurements.Set {
+		r.setMeasurements(models.ParseMeasurements(o.Measurements.Value))
+	}
 	r.setNullString("fake_tits", o.FakeTits)
 	r.setNullFloat64("penis_length", o.PenisLength)
 	r.setNullString("circumcised", o.Circumcised)
@@ -155,6 +175,7 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullString("tattoos", o.Tattoos)
 	r.setNullString("piercings", o.Piercings)
 	r.setBool("favorite", o.Favorite)
+	r.setFavoritedAt(o.Favorite, o.FavoritedAt, o.UpdatedAt)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
 	r.setNullInt("rating", o.Rating)
@@ -165,6 +186,13 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setBool("ignore_auto_tag", o.IgnoreAutoTag)
 }
 
+func (r *performerRowRecord) setMeasurements(m models.Measurements) {
+	r.setNullInt("bust", models.NewOptionalIntPtr(m.Bust))
+	r.setNullInt("waist", models.NewOptionalIntPtr(m.Waist))
+	r.setNullInt("hips", models.NewOptionalIntPtr(m.Hips))
+	r.setNullString("cup_size", models.NewOptionalString(m.CupSize))
+}
+
 type performerRepositoryType struct {
 	repository
 
@@ -227,6 +255,7 @@ var (
 type PerformerStore struct {
 	blobJoinQueryBuilder
 	customFieldsStore
+	fieldSourcesStore
 
 	tableMgr *table
 }
@@ -241,6 +270,10 @@ func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 			table: performersCustomFieldsTable,
 			fk:    performersCustomFieldsTable.Col(performerIDColumn),
 		},
+		fieldSourcesStore: fieldSourcesStore{
+			table: performersFieldSourcesTable,
+			fk:    performersFieldSourcesTable.Col(performerIDColumn),
+		},
 		tableMgr: performerTableMgr,
 	}
 }
@@ -344,6 +377,12 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 		return nil, err
 	}
 
+	if partial.Name.Set {
+		if err := refreshPerformerSceneTitles(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -383,16 +422,25 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Upda
 		return err
 	}
 
-	return nil
+	return refreshPerformerSceneTitles(ctx, updatedObject.ID)
 }
 
 func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
 	// must handle image checksums manually
-	if err := qb.destroyImage(ctx, id); err != nil {
+	if err := qb.destroyImages(ctx, id); err != nil {
+		return err
+	}
+
+	sceneIDs, err := performerSceneIDsForTitle(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := performerRepository.destroyExisting(ctx, []int{id}); err != nil {
 		return err
 	}
 
-	return performerRepository.destroyExisting(ctx, []int{id})
+	return refreshSceneGeneratedTitlesFor(ctx, sceneIDs)
 }
 
 // returns nil, nil if not found
@@ -728,10 +776,13 @@ func (qb *PerformerStore) sortByLastPlayedAt(direction string) string {
 
 var performerSortOptions = sortOptions{
 	"birthdate",
+	"bust",
 	"career_length",
 	"created_at",
+	"favorited_at",
 	"galleries_count",
 	"height",
+	"hips",
 	"id",
 	"images_count",
 	"last_o_at",
@@ -746,6 +797,7 @@ var performerSortOptions = sortOptions{
 	"scenes_count",
 	"tag_count",
 	"updated_at",
+	"waist",
 	"weight",
 }
 
@@ -796,6 +848,10 @@ func (qb *PerformerStore) GetTagIDs(ctx context.Context, id int) ([]int, error)
 	return performerRepository.tags.getIDs(ctx, id)
 }
 
+func (qb *PerformerStore) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return performerRepository.tags.getManyIDs(ctx, ids)
+}
+
 func (qb *PerformerStore) GetImage(ctx context.Context, performerID int) ([]byte, error) {
 	return qb.blobJoinQueryBuilder.GetImage(ctx, performerID, performerImageBlobColumn)
 }
@@ -804,14 +860,22 @@ func (qb *PerformerStore) HasImage(ctx context.Context, performerID int) (bool,
 	return qb.blobJoinQueryBuilder.HasImage(ctx, performerID, performerImageBlobColumn)
 }
 
-func (qb *PerformerStore) UpdateImage(ctx context.Context, performerID int, image []byte) error {
-	return qb.blobJoinQueryBuilder.UpdateImage(ctx, performerID, performerImageBlobColumn, image)
-}
-
 func (qb *PerformerStore) destroyImage(ctx context.Context, performerID int) error {
 	return qb.blobJoinQueryBuilder.DestroyImage(ctx, performerID, performerImageBlobColumn)
 }
 
+func (qb *PerformerStore) FindMissingImagePhashes(ctx context.Context, limit int) ([]int, error) {
+	return qb.blobJoinQueryBuilder.FindMissingImagePhashes(ctx, performerImageBlobColumn, limit)
+}
+
+func (qb *PerformerStore) UpdateImagePhash(ctx context.Context, performerID int, phash *int64) error {
+	return qb.blobJoinQueryBuilder.UpdateImagePhash(ctx, performerID, performerImageBlobColumn, phash)
+}
+
+func (qb *PerformerStore) FindByImagePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	return qb.blobJoinQueryBuilder.FindByImagePhash(ctx, performerImageBlobColumn, phash, distance)
+}
+
 func (qb *PerformerStore) GetAliases(ctx context.Context, performerID int) ([]string, error) {
 	return performersAliasesTableMgr.get(ctx, performerID)
 }
@@ -820,6 +884,14 @@ func (qb *PerformerStore) GetURLs(ctx context.Context, performerID int) ([]strin
 	return performersURLsTableMgr.get(ctx, performerID)
 }
 
+func (qb *PerformerStore) GetTypedURLs(ctx context.Context, performerID int) ([]models.TypedURL, error) {
+	return performersURLsTableMgr.getTyped(ctx, performerID)
+}
+
+func (qb *PerformerStore) SetURLTypes(ctx context.Context, performerID int, types map[string]*models.URLType) error {
+	return performersURLsTableMgr.setTypes(ctx, performerID, types)
+}
+
 func (qb *PerformerStore) GetStashIDs(ctx context.Context, performerID int) ([]models.StashID, error) {
 	return performersStashIDsTableMgr.get(ctx, performerID)
 }
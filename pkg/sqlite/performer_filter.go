@@ -119,6 +119,11 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		intCriterionHandler(heightCmCrit, tableName+".height", nil),
 
 		stringCriterionHandler(filter.Measurements, tableName+".measurements"),
+		lengthCriterionHandler(filter.Bust, tableName+".bust"),
+		lengthCriterionHandler(filter.Waist, tableName+".waist"),
+		lengthCriterionHandler(filter.Hips, tableName+".hips"),
+		stringCriterionHandler(filter.CupSize, tableName+".cup_size"),
+		lengthCriterionHandler(filter.HeightInUnits, tableName+".height"),
 		stringCriterionHandler(filter.FakeTits, tableName+".fake_tits"),
 		floatCriterionHandler(filter.PenisLength, tableName+".penis_length", nil),
 
@@ -135,7 +140,10 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		intCriterionHandler(filter.Rating100, tableName+".rating", nil),
 		stringCriterionHandler(filter.HairColor, tableName+".hair_color"),
 		qb.urlsCriterionHandler(filter.URL),
+		urlStatusCriterionHandler(filter.URLStatus, performerURLsTable, performerIDColumn, "performers.id"),
+		typedURLCriterionHandler(filter.TypedURL, performerURLsTable, performerIDColumn, "performers.id"),
 		intCriterionHandler(filter.Weight, tableName+".weight", nil),
+		massCriterionHandler(filter.WeightInUnits, tableName+".weight"),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if filter.StashID != nil {
 				performerRepository.stashIDs.join(f, "performer_stash_ids", "performers.id")
@@ -167,6 +175,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		&dateCriterionHandler{filter.DeathDate, tableName + ".death_date", nil},
 		&timestampCriterionHandler{filter.CreatedAt, tableName + ".created_at", nil},
 		&timestampCriterionHandler{filter.UpdatedAt, tableName + ".updated_at", nil},
+		&timestampCriterionHandler{filter.FavoritedAt, tableName + ".favorited_at", nil},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "performers_scenes.scene_id",
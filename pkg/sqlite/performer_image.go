@@ -0,0 +1,374 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	performerImagesTable         = "performer_images"
+	performerImagePositionColumn = "position"
+)
+
+type performerImageRow struct {
+	ID          int         `db:"id" goqu:"skipinsert"`
+	PerformerID int         `db:"performer_id"`
+	Position    int         `db:"position"`
+	ImageBlob   string      `db:"image_blob"`
+	Source      zero.String `db:"source"`
+}
+
+type performerImageQueryRow struct {
+	performerImageRow
+	IsPrimary bool `db:"is_primary"`
+}
+
+func (r *performerImageQueryRow) resolve() *models.PerformerImage {
+	return &models.PerformerImage{
+		ID:          r.ID,
+		PerformerID: r.PerformerID,
+		Position:    r.Position,
+		Checksum:    r.ImageBlob,
+		Source:      r.Source.String,
+		Primary:     r.IsPrimary,
+	}
+}
+
+func (qb *PerformerStore) imagesSelectDataset() *goqu.SelectDataset {
+	table := performerImagesTableMgr.table
+	performers := performerTableMgr.table
+
+	return dialect.From(table).InnerJoin(
+		performers,
+		goqu.On(performers.Col(idColumn).Eq(table.Col(performerIDColumn))),
+	).Select(
+		table.All(),
+		goqu.L("COALESCE(? = ?, 0)", performers.Col(performerImageBlobColumn), table.Col(performerImageBlobColumn)).As("is_primary"),
+	)
+}
+
+func (qb *PerformerStore) getImages(ctx context.Context, q *goqu.SelectDataset) ([]*models.PerformerImage, error) {
+	const single = false
+	var ret []*models.PerformerImage
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row performerImageQueryRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting performer images: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (qb *PerformerStore) GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error) {
+	table := performerImagesTableMgr.table
+	q := qb.imagesSelectDataset().Where(table.Col(performerIDColumn).Eq(performerID)).Order(
+		table.Col(performerImagePositionColumn).Asc(),
+		table.Col(idColumn).Asc(),
+	)
+
+	return qb.getImages(ctx, q)
+}
+
+func (qb *PerformerStore) FindImage(ctx context.Context, id int) (*models.PerformerImage, error) {
+	table := performerImagesTableMgr.table
+	q := qb.imagesSelectDataset().Where(table.Col(idColumn).Eq(id))
+
+	ret, err := qb.getImages(ctx, q)
+	if err != nil || len(ret) == 0 {
+		return nil, err
+	}
+
+	return ret[0], nil
+}
+
+func (qb *PerformerStore) findImageByChecksum(ctx context.Context, performerID int, checksum string) (*models.PerformerImage, error) {
+	table := performerImagesTableMgr.table
+	q := qb.imagesSelectDataset().Where(
+		table.Col(performerIDColumn).Eq(performerID),
+		table.Col(performerImageBlobColumn).Eq(checksum),
+	)
+
+	ret, err := qb.getImages(ctx, q)
+	if err != nil || len(ret) == 0 {
+		return nil, err
+	}
+
+	return ret[0], nil
+}
+
+func (qb *PerformerStore) findPrimaryImage(ctx context.Context, performerID int) (*models.PerformerImage, error) {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, img := range images {
+		if img.Primary {
+			return img, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (qb *PerformerStore) GetImageData(ctx context.Context, id int) ([]byte, error) {
+	const sqlQuery = `
+SELECT blobs.checksum, blobs.blob FROM performer_images INNER JOIN blobs ON performer_images.image_blob = blobs.checksum
+WHERE performer_images.id = ?
+`
+
+	ret, _, err := qb.blobStore.readSQL(ctx, sqlQuery, id)
+	return ret, err
+}
+
+// setPrimaryImageBlob sets the primary image of the performer to the image
+// with the given checksum. A nil checksum clears the primary image.
+func (qb *PerformerStore) setPrimaryImageBlob(ctx context.Context, performerID int, checksum *string) error {
+	return performerTableMgr.updateByID(ctx, performerID, goqu.Record{
+		performerImageBlobColumn: checksum,
+	})
+}
+
+func (qb *PerformerStore) nextImagePosition(ctx context.Context, performerID int) (int, error) {
+	table := performerImagesTableMgr.table
+	q := dialect.From(table).Select(
+		goqu.L("COALESCE(MAX(?) + 1, 0)", table.Col(performerImagePositionColumn)),
+	).Where(table.Col(performerIDColumn).Eq(performerID))
+
+	var ret int
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+// addImageBlob appends the image with the given checksum to the image set of
+// the performer.
+func (qb *PerformerStore) addImageBlob(ctx context.Context, performerID int, checksum string, source string) (int, error) {
+	position, err := qb.nextImagePosition(ctx, performerID)
+	if err != nil {
+		return 0, err
+	}
+
+	return performerImagesTableMgr.insertID(ctx, performerImageRow{
+		PerformerID: performerID,
+		Position:    position,
+		ImageBlob:   checksum,
+		Source:      zero.StringFrom(source),
+	})
+}
+
+func (qb *PerformerStore) AddImage(ctx context.Context, performerID int, image []byte, source string) (*models.PerformerImage, error) {
+	if len(image) == 0 {
+		return nil, errors.New("image is empty")
+	}
+
+	checksum, err := qb.blobStore.Write(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := qb.findImageByChecksum(ctx, performerID, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		// keep the existing image, adding the source if it is unknown
+		if existing.Source == "" && source != "" {
+			if err := performerImagesTableMgr.updateByID(ctx, existing.ID, goqu.Record{"source": source}); err != nil {
+				return nil, err
+			}
+			existing.Source = source
+		}
+		return existing, nil
+	}
+
+	id, err := qb.addImageBlob(ctx, performerID, checksum, source)
+	if err != nil {
+		return nil, err
+	}
+
+	hasImage, err := qb.HasImage(ctx, performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasImage {
+		if err := qb.setPrimaryImageBlob(ctx, performerID, &checksum); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.FindImage(ctx, id)
+}
+
+func (qb *PerformerStore) RemoveImage(ctx context.Context, id int) error {
+	img, err := qb.FindImage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if img == nil {
+		return &NotFoundError{ID: id, Table: performerImagesTable}
+	}
+
+	if err := performerImagesTableMgr.destroy(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	if img.Primary {
+		// the next image in the set becomes the primary image
+		remaining, err := qb.GetImages(ctx, img.PerformerID)
+		if err != nil {
+			return err
+		}
+
+		var checksum *string
+		if len(remaining) > 0 {
+			checksum = &remaining[0].Checksum
+		}
+
+		if err := qb.setPrimaryImageBlob(ctx, img.PerformerID, checksum); err != nil {
+			return err
+		}
+	}
+
+	return qb.blobStore.Delete(ctx, img.Checksum)
+}
+
+func (qb *PerformerStore) SetPrimaryImage(ctx context.Context, id int) error {
+	img, err := qb.FindImage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if img == nil {
+		return &NotFoundError{ID: id, Table: performerImagesTable}
+	}
+
+	return qb.setPrimaryImageBlob(ctx, img.PerformerID, &img.Checksum)
+}
+
+func (qb *PerformerStore) ReorderImages(ctx context.Context, performerID int, ids []int) error {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) != len(images) {
+		return fmt.Errorf("expected %d image ids, got %d", len(images), len(ids))
+	}
+
+	for _, img := range images {
+		if !slices.Contains(ids, img.ID) {
+			return fmt.Errorf("image %d of performer %d missing from order", img.ID, performerID)
+		}
+	}
+
+	for i, id := range ids {
+		if err := performerImagesTableMgr.updateByID(ctx, id, goqu.Record{performerImagePositionColumn: i}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateImage replaces the primary image of the performer. If the image is
+// already in the image set, it becomes the primary image instead. An empty
+// image removes the primary image from the set.
+func (qb *PerformerStore) UpdateImage(ctx context.Context, performerID int, image []byte) error {
+	primary, err := qb.findPrimaryImage(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	if len(image) == 0 {
+		if primary == nil {
+			return qb.destroyImage(ctx, performerID)
+		}
+		return qb.RemoveImage(ctx, primary.ID)
+	}
+
+	checksum, err := qb.blobStore.Write(ctx, image)
+	if err != nil {
+		return err
+	}
+
+	existing, err := qb.findImageByChecksum(ctx, performerID, checksum)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case existing != nil:
+		// nothing to replace
+	case primary == nil:
+		if _, err := qb.addImageBlob(ctx, performerID, checksum, ""); err != nil {
+			return err
+		}
+	default:
+		if err := performerImagesTableMgr.updateByID(ctx, primary.ID, goqu.Record{
+			performerImageBlobColumn: checksum,
+			"source":                 nil,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := qb.setPrimaryImageBlob(ctx, performerID, &checksum); err != nil {
+		return err
+	}
+
+	// #3595 - delete the replaced blob
+	if existing == nil && primary != nil {
+		if err := qb.blobStore.Delete(ctx, primary.Checksum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// destroyImages removes the image set of the performer, deleting blobs that
+// are no longer referenced.
+func (qb *PerformerStore) destroyImages(ctx context.Context, performerID int) error {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.destroyImage(ctx, performerID); err != nil {
+		return err
+	}
+
+	table := performerImagesTableMgr.table
+	q := dialect.Delete(table).Where(table.Col(performerIDColumn).Eq(performerID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying performer images: %w", err)
+	}
+
+	for _, img := range images {
+		if err := qb.blobStore.Delete(ctx, img.Checksum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
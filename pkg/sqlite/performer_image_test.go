@@ -0,0 +1,277 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func createImagePerformer(ctx context.Context, t *testing.T, name string) *models.Performer {
+	t.Helper()
+
+	performer := models.Performer{
+		Name: name,
+	}
+	if err := db.Performer.Create(ctx, &models.CreatePerformerInput{Performer: &performer}); err != nil {
+		t.Fatalf("Create error = %v", err)
+	}
+
+	return &performer
+}
+
+func performerImageIDs(images []*models.PerformerImage) []int {
+	var ret []int
+	for _, img := range images {
+		ret = append(ret, img.ID)
+	}
+	return ret
+}
+
+func primaryPerformerImageID(images []*models.PerformerImage) int {
+	for _, img := range images {
+		if img.Primary {
+			return img.ID
+		}
+	}
+	return 0
+}
+
+func TestPerformerImages_Add(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performer := createImagePerformer(ctx, t, "TestPerformerImages_Add")
+
+		first, err := qb.AddImage(ctx, performer.ID, []byte("first"), "")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		// the first image becomes the primary image
+		assert.True(t, first.Primary)
+		assert.Equal(t, 0, first.Position)
+
+		primary, err := qb.GetImage(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("first"), primary)
+
+		second, err := qb.AddImage(ctx, performer.ID, []byte("second"), "https://example.com/second.jpg")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		assert.False(t, second.Primary)
+		assert.Equal(t, 1, second.Position)
+
+		data, err := qb.GetImageData(ctx, second.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("second"), data)
+
+		// adding an existing image returns it, adding the source if unknown
+		again, err := qb.AddImage(ctx, performer.ID, []byte("first"), "https://example.com/first.jpg")
+		if assert.NoError(t, err) {
+			assert.Equal(t, first.ID, again.ID)
+			assert.Equal(t, "https://example.com/first.jpg", again.Source)
+		}
+
+		images, err := qb.GetImages(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{first.ID, second.ID}, performerImageIDs(images))
+
+		_, err = qb.AddImage(ctx, performer.ID, nil, "")
+		assert.Error(t, err)
+
+		return nil
+	})
+}
+
+func TestPerformerImages_Reorder(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performer := createImagePerformer(ctx, t, "TestPerformerImages_Reorder")
+
+		var ids []int
+		for _, data := range []string{"a", "b", "c"} {
+			img, err := qb.AddImage(ctx, performer.ID, []byte(data), "")
+			if !assert.NoError(t, err) {
+				return nil
+			}
+			ids = append(ids, img.ID)
+		}
+
+		order := []int{ids[2], ids[0], ids[1]}
+		if !assert.NoError(t, qb.ReorderImages(ctx, performer.ID, order)) {
+			return nil
+		}
+
+		images, err := qb.GetImages(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, order, performerImageIDs(images))
+
+		// reordering does not change the primary image
+		assert.Equal(t, ids[0], primaryPerformerImageID(images))
+
+		// all images of the performer must be included
+		assert.Error(t, qb.ReorderImages(ctx, performer.ID, []int{ids[0], ids[1]}))
+		assert.Error(t, qb.ReorderImages(ctx, performer.ID, []int{ids[0], ids[1], ids[1]}))
+
+		// new images are added after the last position
+		img, err := qb.AddImage(ctx, performer.ID, []byte("d"), "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, 3, img.Position)
+		}
+
+		return nil
+	})
+}
+
+func TestPerformerImages_SetPrimary(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performer := createImagePerformer(ctx, t, "TestPerformerImages_SetPrimary")
+
+		first, err := qb.AddImage(ctx, performer.ID, []byte("first"), "")
+		assert.NoError(t, err)
+		second, err := qb.AddImage(ctx, performer.ID, []byte("second"), "")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		if !assert.NoError(t, qb.SetPrimaryImage(ctx, second.ID)) {
+			return nil
+		}
+
+		images, err := qb.GetImages(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, second.ID, primaryPerformerImageID(images))
+
+		primary, err := qb.GetImage(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("second"), primary)
+
+		// the order is unchanged
+		assert.Equal(t, []int{first.ID, second.ID}, performerImageIDs(images))
+
+		var notFound *sqlite.NotFoundError
+		assert.ErrorAs(t, qb.SetPrimaryImage(ctx, -1), &notFound)
+
+		return nil
+	})
+}
+
+func TestPerformerImages_RemovePrimary(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performer := createImagePerformer(ctx, t, "TestPerformerImages_RemovePrimary")
+
+		first, err := qb.AddImage(ctx, performer.ID, []byte("first"), "")
+		assert.NoError(t, err)
+		second, err := qb.AddImage(ctx, performer.ID, []byte("second"), "")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		if !assert.NoError(t, qb.RemoveImage(ctx, first.ID)) {
+			return nil
+		}
+
+		// the next image becomes the primary image
+		images, err := qb.GetImages(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{second.ID}, performerImageIDs(images))
+		assert.Equal(t, second.ID, primaryPerformerImageID(images))
+
+		primary, err := qb.GetImage(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("second"), primary)
+
+		// removing the last image clears the primary image
+		if !assert.NoError(t, qb.RemoveImage(ctx, second.ID)) {
+			return nil
+		}
+
+		hasImage, err := qb.HasImage(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.False(t, hasImage)
+
+		var notFound *sqlite.NotFoundError
+		assert.ErrorAs(t, qb.RemoveImage(ctx, second.ID), &notFound)
+
+		return nil
+	})
+}
+
+func TestPerformerImages_SharedBlob(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		p1 := createImagePerformer(ctx, t, "TestPerformerImages_SharedBlob 1")
+		p2 := createImagePerformer(ctx, t, "TestPerformerImages_SharedBlob 2")
+
+		img1, err := qb.AddImage(ctx, p1.ID, []byte("shared"), "")
+		assert.NoError(t, err)
+		img2, err := qb.AddImage(ctx, p2.ID, []byte("shared"), "")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		// the same blob is used by both performers
+		assert.Equal(t, img1.Checksum, img2.Checksum)
+		assert.NotEqual(t, img1.ID, img2.ID)
+
+		// removing the image from one performer keeps the blob for the other
+		if !assert.NoError(t, qb.RemoveImage(ctx, img1.ID)) {
+			return nil
+		}
+
+		data, err := qb.GetImageData(ctx, img2.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("shared"), data)
+
+		return nil
+	})
+}
+
+func TestPerformerImages_Destroy(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performer := createImagePerformer(ctx, t, "TestPerformerImages_Destroy")
+		other := createImagePerformer(ctx, t, "TestPerformerImages_Destroy other")
+
+		img, err := qb.AddImage(ctx, performer.ID, []byte("destroyed"), "")
+		assert.NoError(t, err)
+		shared, err := qb.AddImage(ctx, performer.ID, []byte("kept"), "")
+		assert.NoError(t, err)
+		otherImg, err := qb.AddImage(ctx, other.ID, []byte("kept"), "")
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		if !assert.NoError(t, qb.Destroy(ctx, performer.ID)) {
+			return nil
+		}
+
+		images, err := qb.GetImages(ctx, performer.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, images)
+
+		found, err := qb.FindImage(ctx, img.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		found, err = qb.FindImage(ctx, shared.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		// blobs used by other performers are kept
+		data, err := qb.GetImageData(ctx, otherImg.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("kept"), data)
+
+		return nil
+	})
+}
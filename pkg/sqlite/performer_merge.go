@@ -0,0 +1,296 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// performerMergeJoinTables maps the performer join tables to the column of
+// the object joined to the performer.
+var performerMergeJoinTables = map[string]string{
+	performersScenesTable:       sceneIDColumn,
+	performersImagesTable:       imageIDColumn,
+	performersGalleriesTable:    galleryIDColumn,
+	performersSceneMarkersTable: "scene_marker_id",
+	performersTagsTable:         tagIDColumn,
+}
+
+func performerMergeArgs(source []int, destination int) ([]interface{}, error) {
+	if len(source) == 0 {
+		return nil, errors.New("no source performers")
+	}
+
+	ret := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return nil, errors.New("cannot merge where source == destination")
+		}
+		ret[i] = id
+	}
+
+	return ret, nil
+}
+
+// countMergeJoins returns the number of distinct objects joined to the source
+// performers that are not joined to the destination performer.
+func countMergeJoins(ctx context.Context, table string, idColumn string, source []interface{}, destination int) (int, error) {
+	query := fmt.Sprintf(`SELECT COUNT(DISTINCT j.%[2]s) FROM %[1]s j
+WHERE j.performer_id IN %[3]s
+AND NOT EXISTS(SELECT 1 FROM %[1]s o WHERE o.%[2]s = j.%[2]s AND o.performer_id = ?)`, table, idColumn, getInBinding(len(source)))
+
+	args := append(append([]interface{}{}, source...), destination)
+
+	var ret int
+	if err := dbWrapper.Get(ctx, &ret, query, args...); err != nil {
+		return 0, fmt.Errorf("counting %s: %w", table, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *PerformerStore) MergePreview(ctx context.Context, source []int, destination int) (*models.PerformerMergePreview, error) {
+	srcArgs, err := performerMergeArgs(source, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.PerformerMergePreview{
+		DestinationID: destination,
+		SourceIDs:     source,
+	}
+
+	counts := []struct {
+		table string
+		dest  *int
+	}{
+		{performersScenesTable, &ret.SceneCount},
+		{performersImagesTable, &ret.ImageCount},
+		{performersGalleriesTable, &ret.GalleryCount},
+		{performersSceneMarkersTable, &ret.MarkerCount},
+	}
+	for _, c := range counts {
+		*c.dest, err = countMergeJoins(ctx, c.table, performerMergeJoinTables[c.table], srcArgs, destination)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dest, err := qb.find(ctx, destination)
+	if err != nil {
+		return nil, fmt.Errorf("finding destination performer: %w", err)
+	}
+
+	// names and aliases are compared case-insensitively
+	names := map[string]bool{
+		strings.ToLower(dest.Name): true,
+	}
+	aliases, err := qb.GetAliases(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases {
+		names[strings.ToLower(a)] = true
+	}
+
+	urls, err := qb.GetURLs(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	images, err := qb.GetImages(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	checksums := make(map[string]bool)
+	for _, img := range images {
+		checksums[img.Checksum] = true
+	}
+
+	for _, id := range source {
+		src, err := qb.find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("finding source performer %d: %w", id, err)
+		}
+
+		srcAliases, err := qb.GetAliases(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range append([]string{src.Name}, srcAliases...) {
+			if !names[strings.ToLower(a)] {
+				names[strings.ToLower(a)] = true
+				ret.Aliases = append(ret.Aliases, a)
+			}
+		}
+
+		srcURLs, err := qb.GetURLs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range srcURLs {
+			if !slices.Contains(urls, u) && !slices.Contains(ret.URLs, u) {
+				ret.URLs = append(ret.URLs, u)
+			}
+		}
+
+		srcTagIDs, err := qb.GetTagIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range srcTagIDs {
+			if !slices.Contains(tagIDs, t) && !slices.Contains(ret.TagIDs, t) {
+				ret.TagIDs = append(ret.TagIDs, t)
+			}
+		}
+
+		srcStashIDs, err := qb.GetStashIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range srcStashIDs {
+			if !containsStashID(stashIDs, s) && !containsStashID(ret.StashIDs, s) {
+				ret.StashIDs = append(ret.StashIDs, s)
+			}
+		}
+
+		srcImages, err := qb.GetImages(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range srcImages {
+			if !checksums[img.Checksum] {
+				checksums[img.Checksum] = true
+				ret.PerformerImageCount++
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func containsStashID(ids []models.StashID, v models.StashID) bool {
+	for _, id := range ids {
+		if id.Endpoint == v.Endpoint && id.StashID == v.StashID {
+			return true
+		}
+	}
+	return false
+}
+
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	preview, err := qb.MergePreview(ctx, source, destination)
+	if err != nil {
+		return err
+	}
+
+	srcArgs, err := performerMergeArgs(source, destination)
+	if err != nil {
+		return err
+	}
+	inBinding := getInBinding(len(source))
+
+	// generated titles of the scenes of the sources include the source names
+	var sceneIDs []int
+	for _, id := range source {
+		ids, err := performerSceneIDsForTitle(ctx, id)
+		if err != nil {
+			return err
+		}
+		sceneIDs = sliceutil.AppendUniques(sceneIDs, ids)
+	}
+
+	args := append([]interface{}{destination}, srcArgs...)
+	args = append(args, destination)
+	for table, idColumn := range performerMergeJoinTables {
+		// joins that could not be moved are removed when the sources are destroyed
+		if _, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			args...,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{noteTable, imageRegionTable} {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET performer_id = ? WHERE performer_id IN "+inBinding, args[:len(args)-1]...); err != nil {
+			return err
+		}
+	}
+
+	if err := performersAliasesTableMgr.insertJoins(ctx, destination, preview.Aliases); err != nil {
+		return err
+	}
+	if err := performersURLsTableMgr.addJoins(ctx, destination, preview.URLs); err != nil {
+		return err
+	}
+	if err := performersStashIDsTableMgr.insertJoins(ctx, destination, preview.StashIDs); err != nil {
+		return err
+	}
+
+	if err := qb.mergeImages(ctx, source, destination); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return refreshSceneGeneratedTitlesFor(ctx, sceneIDs)
+}
+
+// mergeImages appends the images of the source performers to the image set
+// of the destination performer.
+func (qb *PerformerStore) mergeImages(ctx context.Context, source []int, destination int) error {
+	hasImage, err := qb.HasImage(ctx, destination)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		images, err := qb.GetImages(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, img := range images {
+			existing, err := qb.findImageByChecksum(ctx, destination, img.Checksum)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				continue
+			}
+
+			if _, err := qb.addImageBlob(ctx, destination, img.Checksum, img.Source); err != nil {
+				return err
+			}
+
+			if !hasImage {
+				checksum := img.Checksum
+				if err := qb.setPrimaryImageBlob(ctx, destination, &checksum); err != nil {
+					return err
+				}
+				hasImage = true
+			}
+		}
+	}
+
+	return nil
+}
@@ -241,6 +241,7 @@ func Test_PerformerStore_Update(t *testing.T) {
 		stashID2       = "stashid2"
 		createdAt      = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt      = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+		favoritedAt    = time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
 
 		birthdate, _ = models.ParseDate("2003-02-01")
 		deathdate, _ = models.ParseDate("2023-02-01")
@@ -291,8 +292,9 @@ func Test_PerformerStore_Update(t *testing.T) {
 							Endpoint: endpoint2,
 						},
 					}),
-					CreatedAt: createdAt,
-					UpdatedAt: updatedAt,
+					CreatedAt:   createdAt,
+					UpdatedAt:   updatedAt,
+					FavoritedAt: &favoritedAt,
 				},
 			},
 			false,
@@ -465,6 +467,7 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 		stashID2       = "stashid2"
 		createdAt      = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt      = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+		favoritedAt    = time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
 
 		birthdate, _ = models.ParseDate("2003-02-01")
 		deathdate, _ = models.ParseDate("2023-02-01")
@@ -505,6 +508,7 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 					Mode:   models.RelationshipUpdateModeSet,
 				},
 				Favorite:      models.NewOptionalBool(favorite),
+				FavoritedAt:   models.NewOptionalTime(favoritedAt),
 				Rating:        models.NewOptionalInt(rating),
 				Details:       models.NewOptionalString(details),
 				DeathDate:     models.NewOptionalDate(deathdate),
@@ -568,8 +572,9 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 						Endpoint: endpoint2,
 					},
 				}),
-				CreatedAt: createdAt,
-				UpdatedAt: updatedAt,
+				CreatedAt:   createdAt,
+				UpdatedAt:   updatedAt,
+				FavoritedAt: &favoritedAt,
 			},
 			false,
 		},
@@ -636,6 +641,59 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 	}
 }
 
+func Test_PerformerStore_UpdatePartialFavoritedAt(t *testing.T) {
+	var (
+		firstUpdate  = time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
+		secondUpdate = time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		id := performerIDs[performerIdxWithScene]
+
+		update := func(favorite bool, updatedAt time.Time) *time.Time {
+			got, err := qb.UpdatePartial(ctx, id, models.PerformerPartial{
+				Favorite:  models.NewOptionalBool(favorite),
+				UpdatedAt: models.NewOptionalTime(updatedAt),
+			})
+			if err != nil {
+				t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+				return nil
+			}
+			return got.FavoritedAt
+		}
+
+		assert.Nil(t, update(false, firstUpdate))
+
+		// favoriting sets the time
+		assert.Equal(t, &firstUpdate, update(true, firstUpdate))
+
+		// favoriting an existing favorite keeps the time
+		assert.Equal(t, &firstUpdate, update(true, secondUpdate))
+
+		// the favorited time is filterable
+		performers, _, err := qb.Query(ctx, &models.PerformerFilterType{
+			FavoritedAt: &models.TimestampCriterionInput{
+				Value:    "2001-06-01T00:00:00Z",
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+		if err != nil {
+			t.Errorf("PerformerStore.Query() error = %v", err)
+		}
+		var ids []int
+		for _, p := range performers {
+			ids = append(ids, p.ID)
+		}
+		assert.Contains(t, ids, id)
+
+		// unfavoriting clears the time
+		assert.Nil(t, update(false, secondUpdate))
+
+		return nil
+	})
+}
+
 func Test_PerformerStore_UpdatePartialCustomFields(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,235 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const quarantinedFileTable = "quarantined_files"
+
+type quarantinedFileRow struct {
+	ID        int       `db:"id" goqu:"skipinsert"`
+	Path      string    `db:"path"`
+	Error     string    `db:"error"`
+	Size      int64     `db:"size"`
+	ModTime   Timestamp `db:"mod_time"`
+	Attempts  int       `db:"attempts"`
+	Ignored   bool      `db:"ignored"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *quarantinedFileRow) fromQuarantinedFile(o models.QuarantinedFile) {
+	r.ID = o.ID
+	r.Path = o.Path
+	r.Error = o.Error
+	r.Size = o.Size
+	r.ModTime = Timestamp{Timestamp: o.ModTime}
+	r.Attempts = o.Attempts
+	r.Ignored = o.Ignored
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *quarantinedFileRow) resolve() *models.QuarantinedFile {
+	ret := &models.QuarantinedFile{
+		ID:        r.ID,
+		Path:      r.Path,
+		Error:     r.Error,
+		Size:      r.Size,
+		ModTime:   r.ModTime.Timestamp,
+		Attempts:  r.Attempts,
+		Ignored:   r.Ignored,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type quarantinedFileRowRecord struct {
+	updateRecord
+}
+
+func (r *quarantinedFileRowRecord) fromPartial(o models.QuarantinedFilePartial) {
+	r.setString("error", o.Error)
+	r.setInt64("size", o.Size)
+	r.setTimestamp("mod_time", o.ModTime)
+	r.setInt("attempts", o.Attempts)
+	r.setBool("ignored", o.Ignored)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type QuarantinedFileStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewQuarantinedFileStore() *QuarantinedFileStore {
+	return &QuarantinedFileStore{
+		repository: repository{
+			tableName: quarantinedFileTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: quarantinedFileTableMgr,
+	}
+}
+
+func (qb *QuarantinedFileStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *QuarantinedFileStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *QuarantinedFileStore) Create(ctx context.Context, newObject *models.QuarantinedFile) error {
+	var r quarantinedFileRow
+	r.fromQuarantinedFile(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *QuarantinedFileStore) UpdatePartial(ctx context.Context, id int, partial models.QuarantinedFilePartial) (*models.QuarantinedFile, error) {
+	r := quarantinedFileRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *QuarantinedFileStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *QuarantinedFileStore) Find(ctx context.Context, id int) (*models.QuarantinedFile, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *QuarantinedFileStore) FindMany(ctx context.Context, ids []int) ([]*models.QuarantinedFile, error) {
+	ret := make([]*models.QuarantinedFile, len(ids))
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		unsorted, err := qb.getMany(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range unsorted {
+			i := slices.Index(ids, s.ID)
+			ret[i] = s
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("quarantined file with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *QuarantinedFileStore) find(ctx context.Context, id int) (*models.QuarantinedFile, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *QuarantinedFileStore) FindByPath(ctx context.Context, path string) (*models.QuarantinedFile, error) {
+	q := qb.selectDataset().Prepared(true).Where(qb.table().Col("path").Eq(path))
+	ret, err := qb.get(ctx, q)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *QuarantinedFileStore) All(ctx context.Context) ([]*models.QuarantinedFile, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("path").Asc()))
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *QuarantinedFileStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.QuarantinedFile, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *QuarantinedFileStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.QuarantinedFile, error) {
+	const single = false
+	var ret []*models.QuarantinedFile
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f quarantinedFileRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -54,14 +54,14 @@ func (r *updateRecord) setNullInt(destField string, v models.OptionalInt) {
 	}
 }
 
-// func (r *updateRecord) setInt64(destField string, v models.OptionalInt64) {
-// 	if v.Set {
-// 		if v.Null {
-// 			panic("null value not allowed in optional int64")
-// 		}
-// 		r.set(destField, v.Value)
-// 	}
-// }
+func (r *updateRecord) setInt64(destField string, v models.OptionalInt64) {
+	if v.Set {
+		if v.Null {
+			panic("null value not allowed in optional int64")
+		}
+		r.set(destField, v.Value)
+	}
+}
 
 // func (r *updateRecord) setNullInt64(destField string, v models.OptionalInt64) {
 // 	if v.Set {
@@ -93,7 +93,6 @@ func (r *updateRecord) setTimestamp(destField string, v models.OptionalTime) {
 	}
 }
 
-//nolint:golint,unused
 func (r *updateRecord) setNullTimestamp(destField string, v models.OptionalTime) {
 	if v.Set {
 		r.set(destField, NullTimestampFromTimePtr(v.Ptr()))
@@ -105,3 +104,11 @@ func (r *updateRecord) setNullDate(destField string, v models.OptionalDate) {
 		r.set(destField, NullDateFromDatePtr(v.Ptr()))
 	}
 }
+
+// setNullDateWithPrecision sets the date and the column storing its precision.
+func (r *updateRecord) setNullDateWithPrecision(destField string, precisionField string, v models.OptionalDate) {
+	if v.Set {
+		r.set(destField, NullDateFromDatePtr(v.Ptr()))
+		r.set(precisionField, datePrecisionFromDatePtr(v.Ptr()))
+	}
+}
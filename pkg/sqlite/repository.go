@@ -233,6 +233,10 @@ type joinRepository struct {
 	// fields for ordering
 	foreignTable string
 	orderBy      string
+
+	// restrictedTags is set if the foreign objects are hidden when they
+	// have a restricted tag
+	restrictedTags *restrictedTags
 }
 
 func (r *joinRepository) getIDs(ctx context.Context, id int) ([]int, error) {
@@ -243,6 +247,10 @@ func (r *joinRepository) getIDs(ctx context.Context, id int) ([]int, error) {
 
 	query := fmt.Sprintf(`SELECT %[2]s.%[1]s as id from %s%s WHERE %s = ?`, r.fkColumn, r.tableName, joinStr, r.idColumn)
 
+	if r.restrictedTags != nil && hideRestricted(ctx) {
+		query += " AND " + r.restrictedTags.where(r.tableName+"."+r.fkColumn)
+	}
+
 	if r.orderBy != "" {
 		query += " ORDER BY " + r.orderBy
 	}
@@ -250,6 +258,55 @@ func (r *joinRepository) getIDs(ctx context.Context, id int) ([]int, error) {
 	return r.runIdsQuery(ctx, query, []interface{}{id})
 }
 
+// getManyIDs returns the foreign ids of each of the ids, in the same order
+// as ids.
+func (r *joinRepository) getManyIDs(ctx context.Context, ids []int) ([][]int, error) {
+	ret := make([][]int, len(ids))
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	var joinStr string
+	if r.foreignTable != "" {
+		joinStr = fmt.Sprintf(" INNER JOIN %s ON %[1]s.id = %s.%s", r.foreignTable, r.tableName, r.fkColumn)
+	}
+
+	query := fmt.Sprintf(`SELECT %[2]s.%[3]s as id, %[2]s.%[1]s as fk from %[2]s%[4]s WHERE %[2]s.%[3]s IN %[5]s`, r.fkColumn, r.tableName, r.idColumn, joinStr, getInBinding(len(ids)))
+
+	if r.restrictedTags != nil && hideRestricted(ctx) {
+		query += " AND " + r.restrictedTags.where(r.tableName+"."+r.fkColumn)
+	}
+
+	if r.orderBy != "" {
+		query += " ORDER BY " + r.orderBy
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	idToIndex := idToIndexMap(ids)
+	if err := r.queryFunc(ctx, query, args, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			ID int `db:"id"`
+			FK int `db:"fk"`
+		}
+
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		i := idToIndex[row.ID]
+		ret[i] = append(ret[i], row.FK)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *joinRepository) insert(ctx context.Context, id int, foreignIDs ...int) error {
 	stmt, err := dbWrapper.Prepare(ctx, fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", r.tableName, r.idColumn, r.fkColumn))
 	if err != nil {
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// restrictedTags is the join table between an object table and its tags,
+// used to hide objects with a restricted tag.
+type restrictedTags struct {
+	joinTable string
+	fkColumn  string
+}
+
+var (
+	sceneRestrictedTags       = &restrictedTags{joinTable: scenesTagsTable, fkColumn: sceneIDColumn}
+	imageRestrictedTags       = &restrictedTags{joinTable: imagesTagsTable, fkColumn: imageIDColumn}
+	galleryRestrictedTags     = &restrictedTags{joinTable: galleriesTagsTable, fkColumn: galleryIDColumn}
+	sceneMarkerRestrictedTags = &restrictedTags{joinTable: "scene_markers_tags", fkColumn: "scene_marker_id"}
+)
+
+// where returns a where clause excluding objects with a restricted tag.
+// idExpr is the column containing the object id.
+func (t restrictedTags) where(idExpr string) string {
+	return fmt.Sprintf(
+		"NOT EXISTS (SELECT 1 FROM %[1]s INNER JOIN %[2]s ON %[2]s.id = %[1]s.%[3]s WHERE %[1]s.%[4]s = %[5]s AND %[2]s.restricted = 1)",
+		t.joinTable, tagTable, tagIDColumn, t.fkColumn, idExpr,
+	)
+}
+
+// sceneMarkerRestrictedWhere returns a where clause excluding scene markers
+// with a restricted primary tag or tag, or on a restricted scene.
+func sceneMarkerRestrictedWhere() string {
+	return strings.Join([]string{
+		fmt.Sprintf("%s.primary_tag_id NOT IN (SELECT id FROM %s WHERE restricted = 1)", sceneMarkerTable, tagTable),
+		sceneMarkerRestrictedTags.where(sceneMarkerTable + ".id"),
+		sceneRestrictedTags.where(sceneMarkerTable + "." + sceneIDColumn),
+	}, " AND ")
+}
+
+// hideRestricted returns true if objects with a restricted tag should be
+// excluded from reads. Writes always see all objects, so that updates do
+// not drop relationships to hidden objects.
+func hideRestricted(ctx context.Context) bool {
+	if !models.RestrictedContentHidden(ctx) {
+		return false
+	}
+
+	writable, _ := ctx.Value(writableKey).(bool)
+	return !writable
+}
+
+// restrictDataset adds where to q if restricted objects are hidden in ctx.
+func restrictDataset(ctx context.Context, q *goqu.SelectDataset, where string) *goqu.SelectDataset {
+	if !hideRestricted(ctx) {
+		return q
+	}
+
+	return q.Where(goqu.L(where))
+}
+
+// restrictQuery adds where to query if restricted objects are hidden in ctx.
+func restrictQuery(ctx context.Context, query *queryBuilder, where string) {
+	if hideRestricted(ctx) {
+		query.addWhere(where)
+	}
+}
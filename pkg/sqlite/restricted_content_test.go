@@ -0,0 +1,128 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+func setTagRestricted(t *testing.T, tagID int, restricted bool) {
+	if err := withTxn(func(ctx context.Context) error {
+		_, err := db.Tag.UpdatePartial(ctx, tagID, models.TagPartial{
+			Restricted: models.NewOptionalBool(restricted),
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("setting tag restricted: %v", err)
+	}
+}
+
+func TestRestrictedContentHidden(t *testing.T) {
+	tagID := tagIDs[tagIdxWithScene]
+	sceneID := sceneIDs[sceneIdxWithTag]
+
+	setTagRestricted(t, tagID, true)
+	defer setTagRestricted(t, tagID, false)
+
+	unlockedCtx := context.Background()
+	lockedCtx := models.WithRestrictedContentHidden(unlockedCtx)
+
+	var unlockedCount int
+	if err := txn.WithReadTxn(unlockedCtx, db, func(ctx context.Context) error {
+		scene, err := db.Scene.Find(ctx, sceneID)
+		assert.Nil(t, err)
+		assert.NotNil(t, scene)
+
+		unlockedCount, err = db.Scene.Count(ctx)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txn.WithReadTxn(lockedCtx, db, func(ctx context.Context) error {
+		scene, err := db.Scene.Find(ctx, sceneID)
+		assert.Nil(t, err)
+		assert.Nil(t, scene)
+
+		count, err := db.Scene.Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, unlockedCount-1, count)
+
+		ids, err := db.Scene.QueryCount(ctx, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, unlockedCount-1, ids)
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// writes see restricted content
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		scene, err := db.Scene.Find(models.WithRestrictedContentHidden(ctx), sceneID)
+		assert.Nil(t, err)
+		assert.NotNil(t, scene)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestrictedContentHidden_GalleryImageCount(t *testing.T) {
+	tagID := tagIDs[tagIdxWithImage]
+	imageID := imageIDs[imageIdxWithGallery]
+	galleryID := galleryIDs[galleryIdxWithImage]
+
+	setImageTag := func(mode models.RelationshipUpdateMode) {
+		if err := withTxn(func(ctx context.Context) error {
+			_, err := db.Image.UpdatePartial(ctx, imageID, models.ImagePartial{
+				TagIDs: &models.UpdateIDs{IDs: []int{tagID}, Mode: mode},
+			})
+			return err
+		}); err != nil {
+			t.Fatalf("setting image tag: %v", err)
+		}
+	}
+
+	setImageTag(models.RelationshipUpdateModeAdd)
+	defer setImageTag(models.RelationshipUpdateModeRemove)
+
+	setTagRestricted(t, tagID, true)
+	defer setTagRestricted(t, tagID, false)
+
+	lockedCtx := models.WithRestrictedContentHidden(context.Background())
+
+	if err := txn.WithReadTxn(lockedCtx, db, func(ctx context.Context) error {
+		count, err := db.Image.CountByGalleryID(ctx, galleryID)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, count)
+
+		counts, err := db.Image.CountByGalleryIDs(ctx, []int{galleryID})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0}, counts)
+
+		count, err = db.Gallery.CountByImageID(ctx, imageID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txn.WithReadTxn(context.Background(), db, func(ctx context.Context) error {
+		count, err := db.Image.CountByGalleryID(ctx, galleryID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -28,6 +29,9 @@ type savedFilterRow struct {
 	FindFilter   string            `db:"find_filter"`
 	ObjectFilter string            `db:"object_filter"`
 	UIOptions    string            `db:"ui_options"`
+	FolderID     null.Int          `db:"folder_id,omitempty"`
+	SortOrder    int               `db:"sort_order"`
+	IsDefault    bool              `db:"is_default"`
 }
 
 func encodeJSONOrEmpty(v interface{}) string {
@@ -62,13 +66,20 @@ func (r *savedFilterRow) fromSavedFilter(o models.SavedFilter) {
 	r.FindFilter = encodeJSONOrEmpty(o.FindFilter)
 	r.ObjectFilter = encodeJSONOrEmpty(o.ObjectFilter)
 	r.UIOptions = encodeJSONOrEmpty(o.UIOptions)
+
+	r.FolderID = intFromPtr(o.FolderID)
+	r.SortOrder = o.SortOrder
+	r.IsDefault = o.IsDefault
 }
 
 func (r *savedFilterRow) resolve() *models.SavedFilter {
 	ret := &models.SavedFilter{
-		ID:   r.ID,
-		Mode: r.Mode,
-		Name: r.Name,
+		ID:        r.ID,
+		Mode:      r.Mode,
+		Name:      r.Name,
+		FolderID:  nullIntPtr(r.FolderID),
+		SortOrder: r.SortOrder,
+		IsDefault: r.IsDefault,
 	}
 
 	// decode the filters from json
@@ -145,6 +156,51 @@ func (qb *SavedFilterStore) Destroy(ctx context.Context, id int) error {
 	return qb.destroyExisting(ctx, []int{id})
 }
 
+// SetDefault makes the filter with the given id the default filter of the
+// mode. A nil id clears the default filter of the mode.
+func (qb *SavedFilterStore) SetDefault(ctx context.Context, mode models.FilterMode, id *int) error {
+	table := qb.table()
+
+	q := dialect.Update(table).Set(goqu.Record{"is_default": false}).Where(
+		table.Col("mode").Eq(mode),
+		table.Col("is_default").IsTrue(),
+	)
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("clearing default filter: %w", err)
+	}
+
+	if id == nil {
+		return nil
+	}
+
+	return qb.tableMgr.updateByID(ctx, *id, goqu.Record{"is_default": true})
+}
+
+// SetFolder moves the filters into the folder. A nil folderID moves the
+// filters out of any folder.
+func (qb *SavedFilterStore) SetFolder(ctx context.Context, ids []int, folderID *int) error {
+	table := qb.table()
+
+	return batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.Update(table).Set(goqu.Record{"folder_id": intFromPtr(folderID)}).Where(table.Col(idColumn).In(batch))
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("setting folder of saved filters: %w", err)
+		}
+		return nil
+	})
+}
+
+// Reorder sets the sort order of the filters to their index in ids.
+func (qb *SavedFilterStore) Reorder(ctx context.Context, ids []int) error {
+	for i, id := range ids {
+		if err := qb.tableMgr.updateByID(ctx, id, goqu.Record{"sort_order": i}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *SavedFilterStore) Find(ctx context.Context, id int) (*models.SavedFilter, error) {
 	ret, err := qb.find(ctx, id)
@@ -242,7 +298,10 @@ func (qb *SavedFilterStore) FindByMode(ctx context.Context, mode models.FilterMo
 		whereClause = table.Col("mode").Eq(mode)
 	}
 
-	sq := qb.selectDataset().Prepared(true).Where(whereClause).Order(table.Col("name").Asc())
+	sq := qb.selectDataset().Prepared(true).Where(whereClause).Order(
+		table.Col("sort_order").Asc(),
+		table.Col("name").Asc(),
+	)
 	ret, err := qb.getMany(ctx, sq)
 
 	if err != nil {
@@ -252,6 +311,23 @@ func (qb *SavedFilterStore) FindByMode(ctx context.Context, mode models.FilterMo
 	return ret, nil
 }
 
+// FindDefault returns the default filter of the mode. Returns nil if the mode
+// has no default filter.
+func (qb *SavedFilterStore) FindDefault(ctx context.Context, mode models.FilterMode) (*models.SavedFilter, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("mode").Eq(mode),
+		table.Col("is_default").IsTrue(),
+	)
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return ret, err
+}
+
 func (qb *SavedFilterStore) All(ctx context.Context) ([]*models.SavedFilter, error) {
 	return qb.getMany(ctx, qb.selectDataset())
 }
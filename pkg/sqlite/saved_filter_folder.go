@@ -0,0 +1,288 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	savedFilterFolderTable = "saved_filter_folders"
+)
+
+type savedFilterFolderRow struct {
+	ID        int               `db:"id" goqu:"skipinsert"`
+	Mode      models.FilterMode `db:"mode"`
+	Name      string            `db:"name"`
+	ParentID  null.Int          `db:"parent_id,omitempty"`
+	SortOrder int               `db:"sort_order"`
+}
+
+func (r *savedFilterFolderRow) fromSavedFilterFolder(o models.SavedFilterFolder) {
+	r.ID = o.ID
+	r.Mode = o.Mode
+	r.Name = o.Name
+	r.ParentID = intFromPtr(o.ParentID)
+	r.SortOrder = o.SortOrder
+}
+
+func (r *savedFilterFolderRow) resolve() *models.SavedFilterFolder {
+	return &models.SavedFilterFolder{
+		ID:        r.ID,
+		Mode:      r.Mode,
+		Name:      r.Name,
+		ParentID:  nullIntPtr(r.ParentID),
+		SortOrder: r.SortOrder,
+	}
+}
+
+type savedFilterFolderRowRecord struct {
+	updateRecord
+}
+
+func (r *savedFilterFolderRowRecord) fromPartial(o models.SavedFilterFolderPartial) {
+	r.setString("name", o.Name)
+	r.setNullInt("parent_id", o.ParentID)
+	r.setInt("sort_order", o.SortOrder)
+}
+
+type SavedFilterFolderStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewSavedFilterFolderStore() *SavedFilterFolderStore {
+	return &SavedFilterFolderStore{
+		repository: repository{
+			tableName: savedFilterFolderTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: savedFilterFolderTableMgr,
+	}
+}
+
+func (qb *SavedFilterFolderStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *SavedFilterFolderStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *SavedFilterFolderStore) displayOrder() []exp.OrderedExpression {
+	table := qb.table()
+	return []exp.OrderedExpression{
+		table.Col("sort_order").Asc(),
+		goqu.L("name COLLATE NATURAL_CI").Asc(),
+		table.Col(idColumn).Asc(),
+	}
+}
+
+func (qb *SavedFilterFolderStore) Create(ctx context.Context, newObject *models.SavedFilterFolder) error {
+	var r savedFilterFolderRow
+	r.fromSavedFilterFolder(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *SavedFilterFolderStore) UpdatePartial(ctx context.Context, id int, partial models.SavedFilterFolderPartial) (*models.SavedFilterFolder, error) {
+	r := savedFilterFolderRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+// Reorder sets the sort order of the folders to their index in ids.
+func (qb *SavedFilterFolderStore) Reorder(ctx context.Context, ids []int) error {
+	for i, id := range ids {
+		if err := qb.tableMgr.updateByID(ctx, id, goqu.Record{"sort_order": i}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Destroy removes the folder. Its filters and subfolders are moved into its
+// parent.
+func (qb *SavedFilterFolderStore) Destroy(ctx context.Context, id int) error {
+	existing, err := qb.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	parentID := intFromPtr(existing.ParentID)
+
+	table := qb.table()
+	q := dialect.Update(table).Set(goqu.Record{"parent_id": parentID}).Where(table.Col("parent_id").Eq(id))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("moving subfolders: %w", err)
+	}
+
+	filterTable := savedFilterTableMgr.table
+	q = dialect.Update(filterTable).Set(goqu.Record{"folder_id": parentID}).Where(filterTable.Col("folder_id").Eq(id))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("moving saved filters: %w", err)
+	}
+
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *SavedFilterFolderStore) Find(ctx context.Context, id int) (*models.SavedFilterFolder, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *SavedFilterFolderStore) FindMany(ctx context.Context, ids []int) ([]*models.SavedFilterFolder, error) {
+	ret := make([]*models.SavedFilterFolder, len(ids))
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		unsorted, err := qb.getMany(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range unsorted {
+			i := slices.Index(ids, s.ID)
+			ret[i] = s
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("saved filter folder with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *SavedFilterFolderStore) find(ctx context.Context, id int) (*models.SavedFilterFolder, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *SavedFilterFolderStore) FindByName(ctx context.Context, mode models.FilterMode, parentID *int, name string) (*models.SavedFilterFolder, error) {
+	table := qb.table()
+
+	parentClause := table.Col("parent_id").IsNull()
+	if parentID != nil {
+		parentClause = table.Col("parent_id").Eq(*parentID)
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("mode").Eq(mode),
+		parentClause,
+		goqu.L("name = ? COLLATE NOCASE", name),
+	).Limit(1)
+	ret, err := qb.get(ctx, q)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *SavedFilterFolderStore) FindByMode(ctx context.Context, mode models.FilterMode) ([]*models.SavedFilterFolder, error) {
+	table := qb.table()
+
+	// groups and movies share their filters
+	var whereClause exp.Expression
+	if mode == models.FilterModeGroups || mode == models.FilterModeMovies {
+		whereClause = goqu.Or(
+			table.Col("mode").Eq(models.FilterModeGroups),
+			table.Col("mode").Eq(models.FilterModeMovies),
+		)
+	} else {
+		whereClause = table.Col("mode").Eq(mode)
+	}
+
+	return qb.getMany(ctx, qb.selectDataset().Prepared(true).Where(whereClause).Order(qb.displayOrder()...))
+}
+
+func (qb *SavedFilterFolderStore) All(ctx context.Context) ([]*models.SavedFilterFolder, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.displayOrder()...))
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *SavedFilterFolderStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.SavedFilterFolder, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *SavedFilterFolderStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SavedFilterFolder, error) {
+	const single = false
+	var ret []*models.SavedFilterFolder
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f savedFilterFolderRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
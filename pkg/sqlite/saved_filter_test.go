@@ -96,6 +96,115 @@ func TestSavedFilterDestroy(t *testing.T) {
 	})
 }
 
+func TestSavedFilterFolders(t *testing.T) {
+	const mode = models.FilterModeGalleries
+
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		fqb := db.SavedFilterFolder
+		qb := db.SavedFilter
+
+		parent := models.SavedFilterFolder{Mode: mode, Name: "parent"}
+		if err := fqb.Create(ctx, &parent); err != nil {
+			return err
+		}
+
+		child := models.SavedFilterFolder{Mode: mode, Name: "child", ParentID: &parent.ID}
+		if err := fqb.Create(ctx, &child); err != nil {
+			return err
+		}
+
+		found, err := fqb.FindByName(ctx, mode, &parent.ID, "CHILD")
+		if err != nil {
+			return err
+		}
+		if assert.NotNil(t, found) {
+			assert.Equal(t, child.ID, found.ID)
+		}
+
+		found, err = fqb.FindByName(ctx, mode, nil, "child")
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, found)
+
+		first := models.SavedFilter{Mode: mode, Name: "first"}
+		second := models.SavedFilter{Mode: mode, Name: "second"}
+		for _, f := range []*models.SavedFilter{&first, &second} {
+			if err := qb.Create(ctx, f); err != nil {
+				return err
+			}
+		}
+
+		if err := qb.SetFolder(ctx, []int{first.ID, second.ID}, &child.ID); err != nil {
+			return err
+		}
+
+		// display order follows the sort order
+		if err := qb.Reorder(ctx, []int{second.ID, first.ID}); err != nil {
+			return err
+		}
+
+		filters, err := qb.FindByMode(ctx, mode)
+		if err != nil {
+			return err
+		}
+		if assert.Len(t, filters, 2) {
+			assert.Equal(t, second.ID, filters[0].ID)
+			assert.Equal(t, &child.ID, filters[0].FolderID)
+		}
+
+		// the default filter is replaced
+		if err := qb.SetDefault(ctx, mode, &first.ID); err != nil {
+			return err
+		}
+		if err := qb.SetDefault(ctx, mode, &second.ID); err != nil {
+			return err
+		}
+
+		def, err := qb.FindDefault(ctx, mode)
+		if err != nil {
+			return err
+		}
+		if assert.NotNil(t, def) {
+			assert.Equal(t, second.ID, def.ID)
+		}
+
+		if err := qb.SetDefault(ctx, mode, nil); err != nil {
+			return err
+		}
+		def, err = qb.FindDefault(ctx, mode)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, def)
+
+		// filters of a destroyed folder are moved into its parent
+		if err := fqb.Destroy(ctx, child.ID); err != nil {
+			return err
+		}
+
+		moved, err := qb.Find(ctx, first.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, &parent.ID, moved.FolderID)
+
+		if err := fqb.Destroy(ctx, parent.ID); err != nil {
+			return err
+		}
+
+		moved, err = qb.Find(ctx, first.ID)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, moved.FolderID)
+
+		return nil
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
 // TODO Update
 // TODO Destroy
 // TODO Find
@@ -70,26 +70,45 @@ SELECT scenes.id as id
 FROM scenes
 INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id)
 INNER JOIN files ON (scenes_files.file_id = files.id)
-INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = ?)
 INNER JOIN video_files ON (files.id == video_files.file_id)
 ORDER BY files.size DESC;
 `
 
+var findAllImagePhashesQuery = `
+SELECT images.id as id
+    , files_fingerprints.fingerprint as phash
+    , COALESCE(video_files.duration, 0) as duration
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+LEFT JOIN video_files ON (images_files.file_id == video_files.file_id);
+`
+
 type sceneRow struct {
-	ID       int         `db:"id" goqu:"skipinsert"`
-	Title    zero.String `db:"title"`
-	Code     zero.String `db:"code"`
-	Details  zero.String `db:"details"`
-	Director zero.String `db:"director"`
-	Date     NullDate    `db:"date"`
+	ID            int         `db:"id" goqu:"skipinsert"`
+	Title         zero.String `db:"title"`
+	Code          zero.String `db:"code"`
+	Details       zero.String `db:"details"`
+	Director      zero.String `db:"director"`
+	Date          NullDate    `db:"date"`
+	DatePrecision null.Int    `db:"date_precision"`
 	// expressed as 1-100
-	Rating       null.Int  `db:"rating"`
-	Organized    bool      `db:"organized"`
-	StudioID     null.Int  `db:"studio_id,omitempty"`
-	CreatedAt    Timestamp `db:"created_at"`
-	UpdatedAt    Timestamp `db:"updated_at"`
-	ResumeTime   float64   `db:"resume_time"`
-	PlayDuration float64   `db:"play_duration"`
+	Rating       null.Int    `db:"rating"`
+	Organized    bool        `db:"organized"`
+	Pending      bool        `db:"pending"`
+	Archived     bool        `db:"archived"`
+	StudioID     null.Int    `db:"studio_id,omitempty"`
+	Country      zero.String `db:"country"`
+	City         zero.String `db:"city"`
+	Latitude     null.Float  `db:"latitude"`
+	Longitude    null.Float  `db:"longitude"`
+	StreamStart  null.Float  `db:"stream_start"`
+	StreamEnd    null.Float  `db:"stream_end"`
+	CreatedAt    Timestamp   `db:"created_at"`
+	UpdatedAt    Timestamp   `db:"updated_at"`
+	ResumeTime   float64     `db:"resume_time"`
+	PlayDuration float64     `db:"play_duration"`
 
 	// not used in resolutions or updates
 	CoverBlob zero.String `db:"cover_blob"`
@@ -102,9 +121,18 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.Details = zero.StringFrom(o.Details)
 	r.Director = zero.StringFrom(o.Director)
 	r.Date = NullDateFromDatePtr(o.Date)
+	r.DatePrecision = datePrecisionFromDatePtr(o.Date)
 	r.Rating = intFromPtr(o.Rating)
 	r.Organized = o.Organized
+	r.Pending = o.Pending
+	r.Archived = o.Archived
 	r.StudioID = intFromPtr(o.StudioID)
+	r.Country = zero.StringFrom(o.Country)
+	r.City = zero.StringFrom(o.City)
+	r.Latitude = null.FloatFromPtr(o.Latitude)
+	r.Longitude = null.FloatFromPtr(o.Longitude)
+	r.StreamStart = null.FloatFromPtr(o.StreamStart)
+	r.StreamEnd = null.FloatFromPtr(o.StreamEnd)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 	r.ResumeTime = o.ResumeTime
@@ -118,6 +146,9 @@ type sceneQueryRow struct {
 	PrimaryFileBasename   zero.String `db:"primary_file_basename"`
 	PrimaryFileOshash     zero.String `db:"primary_file_oshash"`
 	PrimaryFileChecksum   zero.String `db:"primary_file_checksum"`
+
+	// maintained by refreshSceneGeneratedTitles, so not part of sceneRow
+	GeneratedTitle zero.String `db:"generated_title"`
 }
 
 func (r *sceneQueryRow) resolve() *models.Scene {
@@ -127,10 +158,21 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		Code:      r.Code.String,
 		Details:   r.Details.String,
 		Director:  r.Director.String,
-		Date:      r.Date.DatePtr(),
+		Date:      r.Date.DatePtrWithPrecision(r.DatePrecision),
 		Rating:    nullIntPtr(r.Rating),
 		Organized: r.Organized,
+		Pending:   r.Pending,
+		Archived:  r.Archived,
 		StudioID:  nullIntPtr(r.StudioID),
+		Country:   r.Country.String,
+		City:      r.City.String,
+		Latitude:  nullFloatPtr(r.Latitude),
+		Longitude: nullFloatPtr(r.Longitude),
+
+		StreamStart: nullFloatPtr(r.StreamStart),
+		StreamEnd:   nullFloatPtr(r.StreamEnd),
+
+		GeneratedTitle: r.GeneratedTitle.String,
 
 		PrimaryFileID: nullIntFileIDPtr(r.PrimaryFileID),
 		OSHash:        r.PrimaryFileOshash.String,
@@ -159,10 +201,18 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setNullString("code", o.Code)
 	r.setNullString("details", o.Details)
 	r.setNullString("director", o.Director)
-	r.setNullDate("date", o.Date)
+	r.setNullDateWithPrecision("date", "date_precision", o.Date)
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
+	r.setBool("pending", o.Pending)
+	r.setBool("archived", o.Archived)
 	r.setNullInt("studio_id", o.StudioID)
+	r.setNullString("country", o.Country)
+	r.setNullString("city", o.City)
+	r.setNullFloat64("latitude", o.Latitude)
+	r.setNullFloat64("longitude", o.Longitude)
+	r.setNullFloat64("stream_start", o.StreamStart)
+	r.setNullFloat64("stream_end", o.StreamEnd)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
 	r.setFloat64("resume_time", o.ResumeTime)
@@ -192,7 +242,8 @@ var (
 				tableName: scenesGalleriesTable,
 				idColumn:  sceneIDColumn,
 			},
-			fkColumn: galleryIDColumn,
+			fkColumn:       galleryIDColumn,
+			restrictedTags: galleryRestrictedTags,
 		},
 		tags: joinRepository{
 			repository: repository{
@@ -231,6 +282,7 @@ var (
 
 type SceneStore struct {
 	blobJoinQueryBuilder
+	fieldSourcesStore
 
 	tableMgr *table
 	oDateManager
@@ -245,6 +297,10 @@ func NewSceneStore(r *storeRepository, blobStore *BlobStore) *SceneStore {
 			blobStore: blobStore,
 			joinTable: sceneTable,
 		},
+		fieldSourcesStore: fieldSourcesStore{
+			table: scenesFieldSourcesTable,
+			fk:    scenesFieldSourcesTable.Col(sceneIDColumn),
+		},
 
 		tableMgr:        sceneTableMgr,
 		viewDateManager: viewDateManager{scenesViewTableMgr},
@@ -350,6 +406,10 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 		}
 	}
 
+	if err := refreshSceneGeneratedTitlesFor(ctx, []int{id}); err != nil {
+		return err
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -411,6 +471,10 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if err := refreshSceneGeneratedTitlesFor(ctx, []int{id}); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -469,7 +533,7 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 		}
 	}
 
-	return nil
+	return refreshSceneGeneratedTitlesFor(ctx, []int{updatedObject.ID})
 }
 
 func (qb *SceneStore) Destroy(ctx context.Context, id int) error {
@@ -562,6 +626,8 @@ func (qb *SceneStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.S
 }
 
 func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Scene, error) {
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
+
 	const single = false
 	var ret []*models.Scene
 	var lastID int
@@ -650,6 +716,11 @@ func (qb *SceneStore) CountByFileID(ctx context.Context, fileID models.FileID) (
 }
 
 func (qb *SceneStore) FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]*models.Scene, error) {
+	// an empty where clause would match everything
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
 	fingerprintTable := fingerprintTableMgr.table
 
 	var ex []exp.Expression
@@ -753,6 +824,7 @@ func (qb *SceneStore) CountByPerformerID(ctx context.Context, performerID int) (
 	joinTable := scenesPerformersJoinTable
 
 	q := dialect.Select(goqu.COUNT("*")).From(joinTable).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(performersScenesTable+"."+sceneIDColumn))
 	return count(ctx, q)
 }
 
@@ -770,6 +842,7 @@ func (qb *SceneStore) OCountByPerformerID(ctx context.Context, performerID int)
 			table.Col(idColumn).Eq(joinTable.Col(sceneIDColumn)),
 		),
 	).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
 
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -792,8 +865,10 @@ func (qb *SceneStore) FindByGroupID(ctx context.Context, groupID int) ([]*models
 	return ret, nil
 }
 
+// Count returns the number of scenes, excluding archived scenes.
 func (qb *SceneStore) Count(ctx context.Context) (int, error) {
-	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table()).Where(qb.table().Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
 	return count(ctx, q)
 }
 
@@ -808,7 +883,9 @@ func (qb *SceneStore) Size(ctx context.Context) (float64, error) {
 	).InnerJoin(
 		fileTable,
 		goqu.On(scenesFilesJoinTable.Col(fileIDColumn).Eq(fileTable.Col(idColumn))),
-	)
+	).Where(table.Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
+
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
 		return 0, err
@@ -829,7 +906,8 @@ func (qb *SceneStore) Duration(ctx context.Context) (float64, error) {
 	).InnerJoin(
 		videoFileTable,
 		goqu.On(videoFileTable.Col("file_id").Eq(scenesFilesJoinTable.Col("file_id"))),
-	)
+	).Where(table.Col(archivedColumn).Eq(0))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
 
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -843,6 +921,7 @@ func (qb *SceneStore) PlayDuration(ctx context.Context) (float64, error) {
 	table := qb.table()
 
 	q := dialect.Select(goqu.COALESCE(goqu.SUM("play_duration"), 0)).From(table)
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
 
 	var ret float64
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -857,6 +936,7 @@ func (qb *SceneStore) CountByStudioID(ctx context.Context, studioID int) (int, e
 	table := qb.table()
 
 	q := dialect.Select(goqu.COUNT("*")).From(table).Where(table.Col(studioIDColumn).Eq(studioID))
+	q = restrictDataset(ctx, q, sceneRestrictedTags.where(sceneTable+".id"))
 	return count(ctx, q)
 }
 
@@ -917,6 +997,8 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 
 	query := sceneRepository.newQuery()
 	distinctIDs(&query, sceneTable)
+	restrictQuery(ctx, &query, sceneRestrictedTags.where(sceneTable+".id"))
+	excludeArchived(&query, sceneTable, sceneFilter.FiltersArchived())
 
 	if q := findFilter.Q; q != nil && *q != "" {
 		query.addJoins(
@@ -1071,9 +1153,11 @@ var sceneSortOptions = sortOptions{
 	"movie_scene_number",
 	"o_counter",
 	"organized",
+	"performer_age",
 	"performer_count",
 	"play_count",
 	"play_duration",
+	"quality_score",
 	"resume_time",
 	"path",
 	"perceptual_similarity",
@@ -1139,6 +1223,9 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		query.sortAndPagination += getCountSort(sceneTable, scenesTagsTable, sceneIDColumn, direction)
 	case "performer_count":
 		query.sortAndPagination += getCountSort(sceneTable, performersScenesTable, sceneIDColumn, direction)
+	case "performer_age":
+		// sort by the age of the youngest performer at the scene date
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MIN(cast(strftime('%%Y.%%m%%d', scenes.date) - strftime('%%Y.%%m%%d', performers.birthdate) as int)) FROM %s AS sort INNER JOIN performers ON performers.id = sort.performer_id WHERE sort.%s = %s.id AND performers.birthdate != '' AND scenes.date != '') %s", performersScenesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "file_count":
 		query.sortAndPagination += getCountSort(sceneTable, scenesFilesTable, sceneIDColumn, direction)
 	case "path":
@@ -1176,13 +1263,13 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 	case "duration":
 		addVideoFileTable()
 		query.sortAndPagination += getSort(sort, direction, videoFileTable)
-	case "interactive", "interactive_speed":
+	case "interactive", "interactive_speed", "quality_score":
 		addVideoFileTable()
 		query.sortAndPagination += getSort(sort, direction, videoFileTable)
 	case "title":
 		addFileTable()
 		addFolderTable()
-		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, scenes.generated_title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
 		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
 	case "last_played_at":
@@ -1191,6 +1278,8 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "o_counter":
 		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+	case "date":
+		query.sortAndPagination += getDateSort(direction, sceneTable)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1222,6 +1311,15 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 		}
 	}
 
+	// the player reports the time played since the last save, ending at the
+	// resume time. The resume time is reset to zero when the scene is
+	// completed, in which case the watched range is unknown.
+	if resumeTime != nil && playDuration != nil && *resumeTime > 0 {
+		if err := qb.addWatchHeat(ctx, id, *resumeTime-*playDuration, *resumeTime); err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -1238,6 +1336,10 @@ func (qb *SceneStore) ResetActivity(ctx context.Context, id int, resetResume boo
 
 	if resetDuration {
 		record["play_duration"] = 0.0
+
+		if err := qb.destroyWatchHeat(ctx, id); err != nil {
+			return false, err
+		}
 	}
 
 	if len(record) > 0 {
@@ -1253,6 +1355,14 @@ func (qb *SceneStore) GetURLs(ctx context.Context, sceneID int) ([]string, error
 	return scenesURLsTableMgr.get(ctx, sceneID)
 }
 
+func (qb *SceneStore) GetTypedURLs(ctx context.Context, sceneID int) ([]models.TypedURL, error) {
+	return scenesURLsTableMgr.getTyped(ctx, sceneID)
+}
+
+func (qb *SceneStore) SetURLTypes(ctx context.Context, sceneID int, types map[string]*models.URLType) error {
+	return scenesURLsTableMgr.setTypes(ctx, sceneID, types)
+}
+
 func (qb *SceneStore) GetCover(ctx context.Context, sceneID int) ([]byte, error) {
 	return qb.GetImage(ctx, sceneID, sceneCoverBlobColumn)
 }
@@ -1269,6 +1379,18 @@ func (qb *SceneStore) destroyCover(ctx context.Context, sceneID int) error {
 	return qb.DestroyImage(ctx, sceneID, sceneCoverBlobColumn)
 }
 
+func (qb *SceneStore) FindMissingImagePhashes(ctx context.Context, limit int) ([]int, error) {
+	return qb.blobJoinQueryBuilder.FindMissingImagePhashes(ctx, sceneCoverBlobColumn, limit)
+}
+
+func (qb *SceneStore) UpdateImagePhash(ctx context.Context, sceneID int, phash *int64) error {
+	return qb.blobJoinQueryBuilder.UpdateImagePhash(ctx, sceneID, sceneCoverBlobColumn, phash)
+}
+
+func (qb *SceneStore) FindByImagePhash(ctx context.Context, phash int64, distance int) ([]models.PhashMatch, error) {
+	return qb.blobJoinQueryBuilder.FindByImagePhash(ctx, sceneCoverBlobColumn, phash, distance)
+}
+
 func (qb *SceneStore) AssignFiles(ctx context.Context, sceneID int, fileIDs []models.FileID) error {
 	// assuming a file can only be assigned to a single scene
 	if err := scenesFilesTableMgr.destroyJoins(ctx, fileIDs); err != nil {
@@ -1320,6 +1442,18 @@ func (qb *SceneStore) GetGalleryIDs(ctx context.Context, id int) ([]int, error)
 	return sceneRepository.galleries.getIDs(ctx, id)
 }
 
+func (qb *SceneStore) GetManyPerformerIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return sceneRepository.performers.getManyIDs(ctx, ids)
+}
+
+func (qb *SceneStore) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return sceneRepository.tags.getManyIDs(ctx, ids)
+}
+
+func (qb *SceneStore) GetManyGalleryIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return sceneRepository.galleries.getManyIDs(ctx, ids)
+}
+
 func (qb *SceneStore) AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error {
 	return scenesGalleriesTableMgr.addJoins(ctx, sceneID, galleryIDs)
 }
@@ -1328,11 +1462,15 @@ func (qb *SceneStore) GetStashIDs(ctx context.Context, sceneID int) ([]models.St
 	return sceneRepository.stashIDs.get(ctx, sceneID)
 }
 
-func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]*models.Scene, error) {
+// FindDuplicates returns groups of scenes with files that have phashes
+// within distance of each other.
+func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, durationDiff float64, options models.PhashDuplicateOptions) ([][]*models.Scene, error) {
+	fingerprintType := options.GetFingerprintType()
+
 	var dupeIds [][]int
 	if distance == 0 {
 		var ids []string
-		if err := dbWrapper.Select(ctx, &ids, findExactDuplicateQuery, durationDiff); err != nil {
+		if err := dbWrapper.Select(ctx, &ids, findExactDuplicateQuery, durationDiff, fingerprintType); err != nil {
 			return nil, err
 		}
 
@@ -1352,7 +1490,7 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 	} else {
 		var hashes []*utils.Phash
 
-		if err := sceneRepository.queryFunc(ctx, findAllPhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+		if err := sceneRepository.queryFunc(ctx, findAllPhashesQuery, []interface{}{fingerprintType}, false, func(rows *sqlx.Rows) error {
 			phash := utils.Phash{
 				Bucket:   -1,
 				Duration: -1,
@@ -1367,7 +1505,7 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 			return nil, err
 		}
 
-		dupeIds = utils.FindDuplicates(hashes, distance, durationDiff)
+		dupeIds = utils.FindDuplicatesWithDistance(hashes, distance, durationDiff, options.Metric.DistanceFunc())
 	}
 
 	var duplicates [][]*models.Scene
@@ -1382,6 +1520,73 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 	return duplicates, nil
 }
 
+// FindImageDuplicates returns groups of scenes and images with perceptually
+// similar files. Only groups containing at least one scene and one image are
+// returned. Static images have no duration, so durationDiff only applies to
+// image clips.
+func (qb *SceneStore) FindImageDuplicates(ctx context.Context, distance int, durationDiff float64) ([]*models.SceneImageDuplicate, error) {
+	// scenes and images share the id space of the phash matcher, so each is
+	// given a key, with images keyed after scenes
+	var hashes []*utils.Phash
+	var keys []phashKey
+	keyIndex := make(map[phashKey]int)
+
+	addHashes := func(query string, args []interface{}, isImage bool) error {
+		return sceneRepository.queryFunc(ctx, query, args, false, func(rows *sqlx.Rows) error {
+			phash := utils.Phash{
+				Bucket:   -1,
+				Duration: -1,
+			}
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+
+			key := phashKey{id: phash.SceneID, isImage: isImage}
+			idx, found := keyIndex[key]
+			if !found {
+				idx = len(keys)
+				keyIndex[key] = idx
+				keys = append(keys, key)
+			}
+
+			phash.SceneID = idx
+			hashes = append(hashes, &phash)
+			return nil
+		})
+	}
+
+	if err := addHashes(findAllPhashesQuery, []interface{}{models.FingerprintTypePhash}, false); err != nil {
+		return nil, err
+	}
+	if err := addHashes(findAllImagePhashesQuery, nil, true); err != nil {
+		return nil, err
+	}
+
+	var ret []*models.SceneImageDuplicate
+	for _, group := range utils.FindDuplicates(hashes, distance, durationDiff) {
+		dupe := &models.SceneImageDuplicate{}
+		for _, idx := range group {
+			key := keys[idx]
+			if key.isImage {
+				dupe.ImageIDs = append(dupe.ImageIDs, key.id)
+			} else {
+				dupe.SceneIDs = append(dupe.SceneIDs, key.id)
+			}
+		}
+
+		if len(dupe.SceneIDs) > 0 && len(dupe.ImageIDs) > 0 {
+			ret = append(ret, dupe)
+		}
+	}
+
+	return ret, nil
+}
+
+type phashKey struct {
+	id      int
+	isImage bool
+}
+
 func sortByPath(scenes [][]*models.Scene) {
 	lessFunc := func(i int, j int) bool {
 		firstPathI := getFirstPath(scenes[i])
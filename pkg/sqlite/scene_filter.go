@@ -62,6 +62,9 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(sceneFilter.Code, "scenes.code"),
 		stringCriterionHandler(sceneFilter.Details, "scenes.details"),
 		stringCriterionHandler(sceneFilter.Director, "scenes.director"),
+		stringCriterionHandler(sceneFilter.Country, "scenes.country"),
+		stringCriterionHandler(sceneFilter.City, "scenes.city"),
+		geoBoundsCriterionHandler(sceneFilter.Location, "scenes.latitude", "scenes.longitude"),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.Oshash != nil {
 				qb.addSceneFilesTable(f)
@@ -93,20 +96,47 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		qb.phashDistanceCriterionHandler(sceneFilter.PhashDistance),
 
 		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
+		qb.personalRatingCriterionHandler(sceneFilter.PersonalRating100),
+		qb.ratingCountCriterionHandler(sceneFilter.RatingCount),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
+		boolCriterionHandler(sceneFilter.Pending, "scenes.pending", nil),
+		boolCriterionHandler(sceneFilter.Archived, "scenes.archived", nil),
 
 		floatIntCriterionHandler(sceneFilter.Duration, "video_files.duration", qb.addVideoFilesTable),
 		resolutionCriterionHandler(sceneFilter.Resolution, "video_files.height", "video_files.width", qb.addVideoFilesTable),
 		orientationCriterionHandler(sceneFilter.Orientation, "video_files.height", "video_files.width", qb.addVideoFilesTable),
 		floatIntCriterionHandler(sceneFilter.Framerate, "ROUND(video_files.frame_rate)", qb.addVideoFilesTable),
 		intCriterionHandler(sceneFilter.Bitrate, "video_files.bit_rate", qb.addVideoFilesTable),
+		floatCriterionHandler(sceneFilter.AspectRatio, "(CAST(video_files.width AS REAL) / NULLIF(video_files.height, 0))", qb.addVideoFilesTable),
+		intCriterionHandler(sceneFilter.BitDepth, "video_files.bit_depth", qb.addVideoFilesTable),
+		intCriterionHandler(sceneFilter.QualityScore, "video_files.quality_score", qb.addVideoFilesTable),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			if c := sceneFilter.HDRFormat; c != nil {
+				qb.addVideoFilesTable(f)
+				enumCriterionHandler(c.Modifier, utils.StringerSliceToStringSlice(c.Value), "video_files.hdr_format")(ctx, f)
+			}
+		}),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			if c := sceneFilter.VRProjection; c != nil {
+				qb.addVideoFilesTable(f)
+				enumCriterionHandler(c.Modifier, utils.StringerSliceToStringSlice(c.Value), "video_files.vr_projection")(ctx, f)
+			}
+		}),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			if c := sceneFilter.StereoLayout; c != nil {
+				qb.addVideoFilesTable(f)
+				enumCriterionHandler(c.Modifier, utils.StringerSliceToStringSlice(c.Value), "video_files.stereo_layout")(ctx, f)
+			}
+		}),
 		qb.codecCriterionHandler(sceneFilter.VideoCodec, "video_files.video_codec", qb.addVideoFilesTable),
 		qb.codecCriterionHandler(sceneFilter.AudioCodec, "video_files.audio_codec", qb.addVideoFilesTable),
 
 		qb.hasMarkersCriterionHandler(sceneFilter.HasMarkers),
 		qb.isMissingCriterionHandler(sceneFilter.IsMissing),
 		qb.urlsCriterionHandler(sceneFilter.URL),
+		urlStatusCriterionHandler(sceneFilter.URLStatus, scenesURLsTable, sceneIDColumn, "scenes.id"),
+		typedURLCriterionHandler(sceneFilter.TypedURL, scenesURLsTable, sceneIDColumn, "scenes.id"),
 
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.StashID != nil {
@@ -123,6 +153,7 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		},
 
 		boolCriterionHandler(sceneFilter.Interactive, "video_files.interactive", qb.addVideoFilesTable),
+		boolCriterionHandler(sceneFilter.PendingContent, "files.pending_content", qb.addFilesTable),
 		intCriterionHandler(sceneFilter.InteractiveSpeed, "video_files.interactive_speed", qb.addVideoFilesTable),
 
 		qb.captionCriterionHandler(sceneFilter.Captions),
@@ -156,6 +187,7 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		qb.performerFavoriteCriterionHandler(sceneFilter.PerformerFavorite),
 		qb.performerAgeCriterionHandler(sceneFilter.PerformerAge),
 		qb.phashDuplicatedCriterionHandler(sceneFilter.Duplicated, qb.addSceneFilesTable),
+		sceneTranscriptCriterionHandler(sceneFilter.Transcript),
 		&dateCriterionHandler{sceneFilter.Date, "scenes.date", nil},
 		&timestampCriterionHandler{sceneFilter.CreatedAt, "scenes.created_at", nil},
 		&timestampCriterionHandler{sceneFilter.UpdatedAt, "scenes.updated_at", nil},
@@ -252,6 +284,29 @@ func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionI
 	return h.handler(count)
 }
 
+func (qb *sceneFilterHandler) ratingCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    scenesRatingsTable,
+		primaryFK:    sceneIDColumn,
+	}
+
+	return h.handler(count)
+}
+
+// personalRatingCriterionHandler filters by the rating of the user set in the context.
+func (qb *sceneFilterHandler) personalRatingCriterionHandler(rating *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if rating == nil {
+			return
+		}
+
+		f.addLeftJoin(scenesRatingsTable, "personal_ratings", "personal_ratings.scene_id = scenes.id AND personal_ratings.username = ?", models.CurrentUsername(ctx))
+		clause, args := getIntCriterionWhereClause("personal_ratings.rating", *rating)
+		f.addWhere(clause, args...)
+	}
+}
+
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
 	h := countCriterionHandlerBuilder{
 		primaryTable: sceneTable,
@@ -333,13 +388,17 @@ func (qb *sceneFilterHandler) isMissingCriterionHandler(isMissing *string) crite
 			case "stash_id":
 				sceneRepository.stashIDs.join(f, "scene_stash_ids", "scenes.id")
 				f.addWhere("scene_stash_ids.scene_id IS NULL")
-			case "phash":
-				qb.addSceneFilesTable(f)
-				f.addLeftJoin(fingerprintTable, "fingerprints_phash", "scenes_files.file_id = fingerprints_phash.file_id AND fingerprints_phash.type = 'phash'")
-				f.addWhere("fingerprints_phash.fingerprint IS NULL")
 			case "cover":
 				f.addWhere("scenes.cover_blob IS NULL")
+			case "location":
+				f.addWhere("scenes.latitude IS NULL OR scenes.longitude IS NULL")
 			default:
+				if isFingerprintType(*isMissing) {
+					qb.addSceneFilesTable(f)
+					missingFingerprintCriterion(f, scenesFilesTable, *isMissing)
+					return
+				}
+
 				f.addWhere("(scenes." + *isMissing + " IS NULL OR TRIM(scenes." + *isMissing + ") = '')")
 			}
 		}
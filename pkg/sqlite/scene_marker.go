@@ -11,11 +11,16 @@ import (
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
 	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
 
 	"github.com/stashapp/stash/pkg/models"
 )
 
-const sceneMarkerTable = "scene_markers"
+const (
+	sceneMarkerTable            = "scene_markers"
+	sceneMarkerIDColumn         = "scene_marker_id"
+	performersSceneMarkersTable = "performers_scene_markers"
+)
 
 const countSceneMarkersForTagQuery = `
 SELECT scene_markers.id FROM scene_markers
@@ -24,15 +29,29 @@ WHERE tags_join.tag_id = ? OR scene_markers.primary_tag_id = ?
 GROUP BY scene_markers.id
 `
 
+// countSceneMarkersForTagRestrictedQuery returns countSceneMarkersForTagQuery
+// with the additional where clause.
+func countSceneMarkersForTagRestrictedQuery(where string) string {
+	return `
+SELECT scene_markers.id FROM scene_markers
+LEFT JOIN scene_markers_tags as tags_join on tags_join.scene_marker_id = scene_markers.id
+WHERE (tags_join.tag_id = ? OR scene_markers.primary_tag_id = ?) AND ` + where + `
+GROUP BY scene_markers.id
+`
+}
+
 type sceneMarkerRow struct {
-	ID           int        `db:"id" goqu:"skipinsert"`
-	Title        string     `db:"title"` // TODO: make db schema (and gql schema) nullable
-	Seconds      float64    `db:"seconds"`
-	PrimaryTagID int        `db:"primary_tag_id"`
-	SceneID      int        `db:"scene_id"`
-	CreatedAt    Timestamp  `db:"created_at"`
-	UpdatedAt    Timestamp  `db:"updated_at"`
-	EndSeconds   null.Float `db:"end_seconds"`
+	ID           int         `db:"id" goqu:"skipinsert"`
+	Title        string      `db:"title"` // TODO: make db schema (and gql schema) nullable
+	Seconds      float64     `db:"seconds"`
+	PrimaryTagID int         `db:"primary_tag_id"`
+	SceneID      int         `db:"scene_id"`
+	CreatedAt    Timestamp   `db:"created_at"`
+	UpdatedAt    Timestamp   `db:"updated_at"`
+	EndSeconds   null.Float  `db:"end_seconds"`
+	Details      zero.String `db:"details"`
+	Rating       null.Int    `db:"rating"`
+	CoverSeconds null.Float  `db:"cover_seconds"`
 }
 
 func (r *sceneMarkerRow) fromSceneMarker(o models.SceneMarker) {
@@ -42,6 +61,11 @@ func (r *sceneMarkerRow) fromSceneMarker(o models.SceneMarker) {
 	if o.EndSeconds != nil {
 		r.EndSeconds = null.FloatFrom(*o.EndSeconds)
 	}
+	r.Details = zero.StringFrom(o.Details)
+	r.Rating = intFromPtr(o.Rating)
+	if o.CoverSeconds != nil {
+		r.CoverSeconds = null.FloatFrom(*o.CoverSeconds)
+	}
 	r.PrimaryTagID = o.PrimaryTagID
 	r.SceneID = o.SceneID
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
@@ -54,6 +78,9 @@ func (r *sceneMarkerRow) resolve() *models.SceneMarker {
 		Title:        r.Title,
 		Seconds:      r.Seconds,
 		EndSeconds:   r.EndSeconds.Ptr(),
+		Details:      r.Details.String,
+		Rating:       nullIntPtr(r.Rating),
+		CoverSeconds: r.CoverSeconds.Ptr(),
 		PrimaryTagID: r.PrimaryTagID,
 		SceneID:      r.SceneID,
 		CreatedAt:    r.CreatedAt.Timestamp,
@@ -76,6 +103,9 @@ func (r *sceneMarkerRowRecord) fromPartial(o models.SceneMarkerPartial) {
 	}
 	r.setFloat64("seconds", o.Seconds)
 	r.setNullFloat64("end_seconds", o.EndSeconds)
+	r.setNullString("details", o.Details)
+	r.setNullInt("rating", o.Rating)
+	r.setNullFloat64("cover_seconds", o.CoverSeconds)
 	r.setInt("primary_tag_id", o.PrimaryTagID)
 	r.setInt("scene_id", o.SceneID)
 	r.setTimestamp("created_at", o.CreatedAt)
@@ -85,8 +115,9 @@ func (r *sceneMarkerRowRecord) fromPartial(o models.SceneMarkerPartial) {
 type sceneMarkerRepositoryType struct {
 	repository
 
-	scenes repository
-	tags   joinRepository
+	scenes     repository
+	tags       joinRepository
+	performers joinRepository
 }
 
 var (
@@ -106,6 +137,13 @@ var (
 			},
 			fkColumn: tagIDColumn,
 		},
+		performers: joinRepository{
+			repository: repository{
+				tableName: performersSceneMarkersTable,
+				idColumn:  sceneMarkerIDColumn,
+			},
+			fkColumn: performerIDColumn,
+		},
 	}
 )
 
@@ -235,6 +273,8 @@ func (qb *SceneMarkerStore) get(ctx context.Context, q *goqu.SelectDataset) (*mo
 }
 
 func (qb *SceneMarkerStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneMarker, error) {
+	q = restrictDataset(ctx, q, sceneMarkerRestrictedWhere())
+
 	const single = false
 	var ret []*models.SceneMarker
 	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
@@ -255,9 +295,14 @@ func (qb *SceneMarkerStore) getMany(ctx context.Context, q *goqu.SelectDataset)
 }
 
 func (qb *SceneMarkerStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneMarker, error) {
+	where := "scene_markers.scene_id = ?"
+	if hideRestricted(ctx) {
+		where += " AND " + sceneMarkerRestrictedWhere()
+	}
+
 	query := `
 		SELECT scene_markers.* FROM scene_markers
-		WHERE scene_markers.scene_id = ?
+		WHERE ` + where + `
 		GROUP BY scene_markers.id
 		ORDER BY scene_markers.seconds ASC
 	`
@@ -266,8 +311,13 @@ func (qb *SceneMarkerStore) FindBySceneID(ctx context.Context, sceneID int) ([]*
 }
 
 func (qb *SceneMarkerStore) CountByTagID(ctx context.Context, tagID int) (int, error) {
+	query := countSceneMarkersForTagQuery
+	if hideRestricted(ctx) {
+		query = countSceneMarkersForTagRestrictedQuery(sceneMarkerRestrictedWhere())
+	}
+
 	args := []interface{}{tagID, tagID}
-	return sceneMarkerRepository.runCountQuery(ctx, sceneMarkerRepository.buildCountQuery(countSceneMarkersForTagQuery), args)
+	return sceneMarkerRepository.runCountQuery(ctx, sceneMarkerRepository.buildCountQuery(query), args)
 }
 
 func (qb *SceneMarkerStore) GetMarkerStrings(ctx context.Context, q *string, sort *string) ([]*models.MarkerStringsResultType, error) {
@@ -306,6 +356,7 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 
 	query := sceneMarkerRepository.newQuery()
 	distinctIDs(&query, sceneMarkerTable)
+	restrictQuery(ctx, &query, sceneMarkerRestrictedWhere())
 
 	if q := findFilter.Q; q != nil && *q != "" {
 		query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
@@ -368,6 +419,8 @@ var sceneMarkerSortOptions = sortOptions{
 	"seconds",
 	"updated_at",
 	"duration",
+	"rating",
+	"performer_count",
 }
 
 func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *models.FindFilterType) error {
@@ -390,6 +443,8 @@ func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *
 	case "duration":
 		sort = "(scene_markers.end_seconds - scene_markers.seconds)"
 		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable)
+	case "performer_count":
+		query.sortAndPagination += getCountSort(sceneMarkerTable, performersSceneMarkersTable, sceneMarkerIDColumn, direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, sceneMarkerTable)
 	}
@@ -450,8 +505,18 @@ func (qb *SceneMarkerStore) UpdateTags(ctx context.Context, id int, tagIDs []int
 	return sceneMarkerRepository.tags.replace(ctx, id, tagIDs)
 }
 
+func (qb *SceneMarkerStore) GetPerformerIDs(ctx context.Context, id int) ([]int, error) {
+	return sceneMarkerRepository.performers.getIDs(ctx, id)
+}
+
+func (qb *SceneMarkerStore) UpdatePerformers(ctx context.Context, id int, performerIDs []int) error {
+	// Delete the existing joins and then create new ones
+	return sceneMarkerRepository.performers.replace(ctx, id, performerIDs)
+}
+
 func (qb *SceneMarkerStore) Count(ctx context.Context) (int, error) {
 	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	q = restrictDataset(ctx, q, sceneMarkerRestrictedWhere())
 	return count(ctx, q)
 }
 
@@ -40,6 +40,10 @@ func (qb *sceneMarkerFilterHandler) criterionHandler() criterionHandler {
 		qb.tagsCriterionHandler(sceneMarkerFilter.Tags),
 		qb.sceneTagsCriterionHandler(sceneMarkerFilter.SceneTags),
 		qb.performersCriterionHandler(sceneMarkerFilter.Performers),
+		qb.markerPerformersCriterionHandler(sceneMarkerFilter.MarkerPerformers),
+		qb.performerCountCriterionHandler(sceneMarkerFilter.PerformerCount),
+		intCriterionHandler(sceneMarkerFilter.Rating100, "scene_markers.rating", nil),
+		stringCriterionHandler(sceneMarkerFilter.Details, "scene_markers.details"),
 		qb.scenesCriterionHandler(sceneMarkerFilter.Scenes),
 		floatCriterionHandler(sceneMarkerFilter.Duration, "COALESCE(scene_markers.end_seconds - scene_markers.seconds, NULL)", nil),
 		&timestampCriterionHandler{sceneMarkerFilter.CreatedAt, "scene_markers.created_at", nil},
@@ -190,6 +194,32 @@ func (qb *sceneMarkerFilterHandler) performersCriterionHandler(performers *model
 	}
 }
 
+func (qb *sceneMarkerFilterHandler) markerPerformersCriterionHandler(performers *models.MultiCriterionInput) criterionHandlerFunc {
+	h := joinedMultiCriterionHandlerBuilder{
+		primaryTable: sceneMarkerTable,
+		joinTable:    performersSceneMarkersTable,
+		joinAs:       "marker_performers_join",
+		primaryFK:    sceneMarkerIDColumn,
+		foreignFK:    performerIDColumn,
+
+		addJoinTable: func(f *filterBuilder) {
+			sceneMarkerRepository.performers.join(f, "marker_performers_join", "scene_markers.id")
+		},
+	}
+
+	return h.handler(performers)
+}
+
+func (qb *sceneMarkerFilterHandler) performerCountCriterionHandler(performerCount *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: sceneMarkerTable,
+		joinTable:    performersSceneMarkersTable,
+		primaryFK:    sceneMarkerIDColumn,
+	}
+
+	return h.handler(performerCount)
+}
+
 func (qb *sceneMarkerFilterHandler) scenesCriterionHandler(scenes *models.MultiCriterionInput) criterionHandlerFunc {
 	addJoinsFunc := func(f *filterBuilder) {
 		f.addLeftJoin(sceneTable, "markers_scenes", "markers_scenes.id = scene_markers.scene_id")
@@ -0,0 +1,211 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const sceneMarkerSuggestionTable = "scene_marker_suggestions"
+
+type sceneMarkerSuggestionRow struct {
+	ID           int        `db:"id" goqu:"skipinsert"`
+	SceneID      int        `db:"scene_id"`
+	Title        string     `db:"title"`
+	Seconds      float64    `db:"seconds"`
+	EndSeconds   null.Float `db:"end_seconds"`
+	PrimaryTagID int        `db:"primary_tag_id"`
+	Source       string     `db:"source"`
+	CreatedAt    Timestamp  `db:"created_at"`
+}
+
+func (r *sceneMarkerSuggestionRow) fromSceneMarkerSuggestion(o models.SceneMarkerSuggestion) {
+	r.ID = o.ID
+	r.SceneID = o.SceneID
+	r.Title = o.Title
+	r.Seconds = o.Seconds
+	r.EndSeconds = null.FloatFromPtr(o.EndSeconds)
+	r.PrimaryTagID = o.PrimaryTagID
+	r.Source = o.Source
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+}
+
+func (r *sceneMarkerSuggestionRow) resolve() *models.SceneMarkerSuggestion {
+	ret := &models.SceneMarkerSuggestion{
+		ID:           r.ID,
+		SceneID:      r.SceneID,
+		Title:        r.Title,
+		Seconds:      r.Seconds,
+		EndSeconds:   r.EndSeconds.Ptr(),
+		PrimaryTagID: r.PrimaryTagID,
+		Source:       r.Source,
+		CreatedAt:    r.CreatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type SceneMarkerSuggestionStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewSceneMarkerSuggestionStore() *SceneMarkerSuggestionStore {
+	return &SceneMarkerSuggestionStore{
+		repository: repository{
+			tableName: sceneMarkerSuggestionTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: sceneMarkerSuggestionTableMgr,
+	}
+}
+
+func (qb *SceneMarkerSuggestionStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *SceneMarkerSuggestionStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *SceneMarkerSuggestionStore) Create(ctx context.Context, newObject *models.SceneMarkerSuggestion) error {
+	var r sceneMarkerSuggestionRow
+	r.fromSceneMarkerSuggestion(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *SceneMarkerSuggestionStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *SceneMarkerSuggestionStore) DestroyBySceneID(ctx context.Context, sceneID int, source string) error {
+	table := qb.table()
+	q := dialect.Delete(table).Where(
+		table.Col("scene_id").Eq(sceneID),
+		table.Col("source").Eq(source),
+	)
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying scene marker suggestions: %w", err)
+	}
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *SceneMarkerSuggestionStore) Find(ctx context.Context, id int) (*models.SceneMarkerSuggestion, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *SceneMarkerSuggestionStore) FindMany(ctx context.Context, ids []int) ([]*models.SceneMarkerSuggestion, error) {
+	ret := make([]*models.SceneMarkerSuggestion, len(ids))
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		unsorted, err := qb.getMany(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range unsorted {
+			i := slices.Index(ids, s.ID)
+			ret[i] = s
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("scene marker suggestion with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *SceneMarkerSuggestionStore) find(ctx context.Context, id int) (*models.SceneMarkerSuggestion, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *SceneMarkerSuggestionStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneMarkerSuggestion, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col("scene_id").Eq(sceneID)).Order(table.Col("seconds").Asc(), table.Col(idColumn).Asc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *SceneMarkerSuggestionStore) All(ctx context.Context) ([]*models.SceneMarkerSuggestion, error) {
+	table := qb.table()
+	return qb.getMany(ctx, qb.selectDataset().Order(table.Col("scene_id").Asc(), table.Col("seconds").Asc(), table.Col(idColumn).Asc()))
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *SceneMarkerSuggestionStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.SceneMarkerSuggestion, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *SceneMarkerSuggestionStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneMarkerSuggestion, error) {
+	const single = false
+	var ret []*models.SceneMarkerSuggestion
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f sceneMarkerSuggestionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,79 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneMarkerSuggestionStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		const source = "test"
+
+		sceneID := sceneIDs[sceneIdxWithMarkers]
+		end := 20.0
+		suggestions := []models.SceneMarkerSuggestion{
+			{SceneID: sceneID, Title: "b", Seconds: 30, PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers], Source: source},
+			{SceneID: sceneID, Title: "a", Seconds: 10, EndSeconds: &end, PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers], Source: source},
+			{SceneID: sceneID, Title: "other", Seconds: 5, PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers], Source: "other"},
+		}
+
+		for i := range suggestions {
+			if err := db.Suggestion.Create(ctx, &suggestions[i]); err != nil {
+				t.Errorf("SceneMarkerSuggestionStore.Create() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err := db.Suggestion.Find(ctx, suggestions[1].ID)
+		if err != nil {
+			t.Errorf("SceneMarkerSuggestionStore.Find() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, got) {
+			assert.Equal(t, "a", got.Title)
+			assert.Equal(t, &end, got.EndSeconds)
+		}
+
+		bySceneID, err := db.Suggestion.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneMarkerSuggestionStore.FindBySceneID() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, bySceneID, 3) {
+			assert.Equal(t, "other", bySceneID[0].Title)
+			assert.Equal(t, "a", bySceneID[1].Title)
+			assert.Equal(t, "b", bySceneID[2].Title)
+		}
+
+		if err := db.Suggestion.DestroyBySceneID(ctx, sceneID, source); err != nil {
+			t.Errorf("SceneMarkerSuggestionStore.DestroyBySceneID() error = %v", err)
+			return nil
+		}
+
+		bySceneID, err = db.Suggestion.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneMarkerSuggestionStore.FindBySceneID() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, bySceneID, 1) {
+			assert.Equal(t, "other", bySceneID[0].Title)
+		}
+
+		if err := db.Suggestion.Destroy(ctx, bySceneID[0].ID); err != nil {
+			t.Errorf("SceneMarkerSuggestionStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = db.Suggestion.Find(ctx, bySceneID[0].ID)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
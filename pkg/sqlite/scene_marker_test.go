@@ -501,6 +501,87 @@ func TestMarkerQueryDuration(t *testing.T) {
 
 }
 
+func TestMarkerDetailsRatingPerformers(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.SceneMarker
+
+		details := "marker details"
+		rating := 60
+		coverSeconds := 12.5
+		performerID := performerIDs[performerIdx1WithScene]
+
+		marker := models.NewSceneMarker()
+		marker.Title = "TestMarkerDetailsRatingPerformers"
+		marker.Seconds = 10
+		marker.Details = details
+		marker.Rating = &rating
+		marker.CoverSeconds = &coverSeconds
+		marker.SceneID = sceneIDs[sceneIdxWithMarkers]
+		marker.PrimaryTagID = tagIDs[tagIdxWithPrimaryMarkers]
+
+		if err := qb.Create(ctx, &marker); err != nil {
+			t.Errorf("Error creating marker: %v", err)
+			return nil
+		}
+
+		if err := qb.UpdatePerformers(ctx, marker.ID, []int{performerID}); err != nil {
+			t.Errorf("Error updating marker performers: %v", err)
+			return nil
+		}
+
+		found, err := qb.Find(ctx, marker.ID)
+		if err != nil {
+			t.Errorf("Error finding marker: %v", err)
+			return nil
+		}
+
+		assert.Equal(t, details, found.Details)
+		assert.Equal(t, &rating, found.Rating)
+		assert.Equal(t, coverSeconds, found.ScreenshotSeconds())
+
+		gotPerformerIDs, err := qb.GetPerformerIDs(ctx, marker.ID)
+		if err != nil {
+			t.Errorf("Error getting marker performers: %v", err)
+			return nil
+		}
+		assert.Equal(t, []int{performerID}, gotPerformerIDs)
+
+		filters := map[string]*models.SceneMarkerFilterType{
+			"marker performers": {
+				MarkerPerformers: &models.MultiCriterionInput{
+					Value:    []string{strconv.Itoa(performerID)},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+			"performer count": {
+				PerformerCount: &models.IntCriterionInput{
+					Value:    1,
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			"rating": {
+				Rating100: &models.IntCriterionInput{
+					Value:    rating,
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			"details": {
+				Details: &models.StringCriterionInput{
+					Value:    details,
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+		}
+
+		for name, f := range filters {
+			ids := markersToIDs(queryMarkers(ctx, t, qb, f, nil))
+			assert.Equal(t, []int{marker.ID}, ids, name)
+		}
+
+		return nil
+	})
+}
+
 func queryMarkers(ctx context.Context, t *testing.T, sqb models.SceneMarkerReader, markerFilter *models.SceneMarkerFilterType, findFilter *models.FindFilterType) []*models.SceneMarker {
 	t.Helper()
 	result, _, err := sqb.Query(ctx, markerFilter, findFilter)
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const scenesPendingFingerprintsTable = "scene_pending_fingerprints"
+
+type scenePendingFingerprintRow struct {
+	Type        string `db:"type"`
+	Fingerprint string `db:"fingerprint"`
+}
+
+// GetPendingFingerprints returns the fingerprints of the file expected for
+// the scene.
+func (qb *SceneStore) GetPendingFingerprints(ctx context.Context, sceneID int) ([]models.Fingerprint, error) {
+	table := scenesPendingFingerprintsTableMgr.table
+	q := dialect.From(table).Select(table.Col("type"), table.Col("fingerprint")).
+		Where(scenesPendingFingerprintsTableMgr.byID(sceneID)).
+		Order(table.Col("type").Asc(), table.Col("fingerprint").Asc())
+
+	const single = false
+	var ret []models.Fingerprint
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row scenePendingFingerprintRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, models.Fingerprint{
+			Type:        row.Type,
+			Fingerprint: row.Fingerprint,
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting scene pending fingerprints: %w", err)
+	}
+
+	return ret, nil
+}
+
+// UpdatePendingFingerprints replaces the pending fingerprints of the scene.
+func (qb *SceneStore) UpdatePendingFingerprints(ctx context.Context, sceneID int, fp []models.Fingerprint) error {
+	if err := scenesPendingFingerprintsTableMgr.destroy(ctx, []int{sceneID}); err != nil {
+		return fmt.Errorf("removing scene pending fingerprints: %w", err)
+	}
+
+	if len(fp) == 0 {
+		return nil
+	}
+
+	var rows []interface{}
+	for _, f := range fp {
+		rows = append(rows, goqu.Record{
+			sceneIDColumn: sceneID,
+			"type":        f.Type,
+			"fingerprint": f.Value(),
+		})
+	}
+
+	q := dialect.Insert(scenesPendingFingerprintsTableMgr.table).Prepared(true).Rows(rows...).OnConflict(goqu.DoNothing())
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting scene pending fingerprints: %w", err)
+	}
+
+	return nil
+}
+
+// FindPending returns the pending scenes with one of the pending fingerprints
+// in fp, or with one of urls.
+func (qb *SceneStore) FindPending(ctx context.Context, fp []models.Fingerprint, urls []string) ([]*models.Scene, error) {
+	// an empty where clause would match everything
+	if len(fp) == 0 && len(urls) == 0 {
+		return nil, nil
+	}
+
+	fpTable := scenesPendingFingerprintsTableMgr.table
+
+	var ex []exp.Expression
+	for _, v := range fp {
+		ex = append(ex, goqu.And(
+			fpTable.Col("type").Eq(v.Type),
+			fpTable.Col("fingerprint").Eq(v.Value()),
+		))
+	}
+
+	var sq *goqu.SelectDataset
+	if len(ex) > 0 {
+		sq = dialect.From(fpTable).Select(fpTable.Col(sceneIDColumn)).Where(goqu.Or(ex...))
+	}
+
+	if len(urls) > 0 {
+		urlsq := dialect.From(scenesURLsJoinTable).Select(scenesURLsJoinTable.Col(sceneIDColumn)).
+			Where(scenesURLsJoinTable.Col(sceneURLColumn).In(urls))
+		if sq == nil {
+			sq = urlsq
+		} else {
+			sq = sq.Union(urlsq)
+		}
+	}
+
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col(idColumn).In(sq),
+		table.Col("pending").IsTrue(),
+	)
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending scenes: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	scenesRatingsTable = "scene_ratings"
+)
+
+type sceneRatingRow struct {
+	SceneID   int       `db:"scene_id"`
+	Username  string    `db:"username"`
+	Rating    int       `db:"rating"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *sceneRatingRow) resolve() *models.SceneRating {
+	return &models.SceneRating{
+		SceneID:   r.SceneID,
+		Username:  r.Username,
+		Rating:    r.Rating,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+// GetRatings returns the ratings of the scene by all users, ordered by username.
+func (qb *SceneStore) GetRatings(ctx context.Context, sceneID int) ([]*models.SceneRating, error) {
+	table := scenesRatingsTableMgr.table
+	q := dialect.From(table).Select(table.All()).Where(scenesRatingsTableMgr.byID(sceneID)).Order(table.Col("username").Asc())
+
+	const single = false
+	var ret []*models.SceneRating
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row sceneRatingRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting scene ratings: %w", err)
+	}
+
+	return ret, nil
+}
+
+// SetRating sets the rating of the scene by the user. The rating is removed
+// if nil. The aggregate rating of the scene is updated to the mean of the
+// remaining ratings.
+func (qb *SceneStore) SetRating(ctx context.Context, sceneID int, username string, rating *int) error {
+	if err := qb.tableMgr.checkIDExists(ctx, sceneID); err != nil {
+		return err
+	}
+
+	table := scenesRatingsTableMgr.table
+	if rating == nil {
+		q := dialect.Delete(table).Where(
+			table.Col(sceneIDColumn).Eq(sceneID),
+			table.Col("username").Eq(username),
+		)
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("removing scene rating: %w", err)
+		}
+	} else {
+		now := Timestamp{Timestamp: time.Now()}
+		q := dialect.Insert(table).Prepared(true).Rows(goqu.Record{
+			sceneIDColumn: sceneID,
+			"username":    username,
+			"rating":      *rating,
+			"created_at":  now,
+			"updated_at":  now,
+		}).OnConflict(goqu.DoUpdate(sceneIDColumn+", username", goqu.Record{
+			"rating":     goqu.I("excluded.rating"),
+			"updated_at": goqu.I("excluded.updated_at"),
+		}))
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("setting scene rating: %w", err)
+		}
+	}
+
+	return qb.updateAggregateRating(ctx, sceneID)
+}
+
+// updateAggregateRating sets the rating of the scene to the rounded mean of
+// its per-user ratings.
+func (qb *SceneStore) updateAggregateRating(ctx context.Context, sceneID int) error {
+	ratings, err := qb.GetRatings(ctx, sceneID)
+	if err != nil {
+		return err
+	}
+
+	values := make([]int, len(ratings))
+	for i, r := range ratings {
+		values[i] = r.Rating
+	}
+
+	aggregate := models.NewRatingStats(values).AggregateRating()
+	if err := qb.tableMgr.updateByID(ctx, sceneID, goqu.Record{
+		"rating": intFromPtr(aggregate),
+	}); err != nil {
+		return fmt.Errorf("updating aggregate rating: %w", err)
+	}
+
+	return nil
+}
@@ -1567,6 +1567,48 @@ func Test_sceneQueryBuilder_FindMany(t *testing.T) {
 	}
 }
 
+func Test_sceneQueryBuilder_GetManyRelatedIDs(t *testing.T) {
+	ids := []int{
+		sceneIDs[sceneIdxWithTwoTags],
+		sceneIDs[sceneIdxWithTwoPerformers],
+		sceneIDs[sceneIdxWithGallery],
+	}
+
+	qb := db.Scene
+
+	runWithRollbackTxn(t, "get many", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		gotTagIDs, err := qb.GetManyTagIDs(ctx, ids)
+		if err != nil {
+			t.Errorf("sceneQueryBuilder.GetManyTagIDs() error = %v", err)
+			return
+		}
+		assert.Len(gotTagIDs, len(ids))
+		assert.ElementsMatch(indexesToIDs(tagIDs, sceneTags[sceneIdxWithTwoTags]), gotTagIDs[0])
+		assert.Empty(gotTagIDs[1])
+		assert.Empty(gotTagIDs[2])
+
+		gotPerformerIDs, err := qb.GetManyPerformerIDs(ctx, ids)
+		if err != nil {
+			t.Errorf("sceneQueryBuilder.GetManyPerformerIDs() error = %v", err)
+			return
+		}
+		assert.Empty(gotPerformerIDs[0])
+		assert.ElementsMatch(indexesToIDs(performerIDs, scenePerformers[sceneIdxWithTwoPerformers]), gotPerformerIDs[1])
+		assert.Empty(gotPerformerIDs[2])
+
+		gotGalleryIDs, err := qb.GetManyGalleryIDs(ctx, ids)
+		if err != nil {
+			t.Errorf("sceneQueryBuilder.GetManyGalleryIDs() error = %v", err)
+			return
+		}
+		assert.Empty(gotGalleryIDs[0])
+		assert.Empty(gotGalleryIDs[1])
+		assert.ElementsMatch(indexesToIDs(galleryIDs, sceneGalleries[sceneIdxWithGallery]), gotGalleryIDs[2])
+	})
+}
+
 func Test_sceneQueryBuilder_FindByChecksum(t *testing.T) {
 	getChecksum := func(index int) string {
 		return getSceneStringValue(index, checksumField)
@@ -3250,6 +3292,28 @@ func TestSceneQueryIsMissingPhash(t *testing.T) {
 	})
 }
 
+func TestSceneQueryIsMissingFingerprint(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+
+		// all test scenes have an md5 fingerprint
+		isMissing := "md5"
+		sceneFilter := models.SceneFilterType{
+			IsMissing: &isMissing,
+		}
+
+		scenes := queryScene(ctx, t, sqb, &sceneFilter, models.BatchFindFilter(-1))
+		assert.Len(t, scenes, 0)
+
+		// no test scenes have a sha256 fingerprint
+		isMissing = "sha256"
+		scenes = queryScene(ctx, t, sqb, &sceneFilter, models.BatchFindFilter(-1))
+		assert.Len(t, scenes, totalScenes)
+
+		return nil
+	})
+}
+
 func TestSceneQueryPerformers(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -4345,6 +4409,68 @@ func TestSceneUpdateSceneCover(t *testing.T) {
 	}
 }
 
+func TestSceneImagePhash(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		if err := qb.UpdateCover(ctx, sceneID, []byte("TestSceneImagePhash")); err != nil {
+			t.Errorf("SceneStore.UpdateCover() error = %v", err)
+			return nil
+		}
+
+		missing, err := qb.FindMissingImagePhashes(ctx, 1000)
+		if err != nil {
+			t.Errorf("SceneStore.FindMissingImagePhashes() error = %v", err)
+			return nil
+		}
+		assert.Contains(t, missing, sceneID)
+
+		phash := int64(0x0f0f0f0f0f0f0f0f)
+		if err := qb.UpdateImagePhash(ctx, sceneID, &phash); err != nil {
+			t.Errorf("SceneStore.UpdateImagePhash() error = %v", err)
+			return nil
+		}
+
+		missing, err = qb.FindMissingImagePhashes(ctx, 1000)
+		if err != nil {
+			t.Errorf("SceneStore.FindMissingImagePhashes() error = %v", err)
+			return nil
+		}
+		assert.NotContains(t, missing, sceneID)
+
+		// differs from phash by 3 bits
+		matches, err := qb.FindByImagePhash(ctx, phash^0x7, 3)
+		if err != nil {
+			t.Errorf("SceneStore.FindByImagePhash() error = %v", err)
+			return nil
+		}
+		assert.Contains(t, matches, models.PhashMatch{ID: sceneID, Distance: 3})
+
+		matches, err = qb.FindByImagePhash(ctx, phash^0x7, 2)
+		if err != nil {
+			t.Errorf("SceneStore.FindByImagePhash() error = %v", err)
+			return nil
+		}
+		assert.NotContains(t, matches, models.PhashMatch{ID: sceneID, Distance: 3})
+
+		// changing the cover invalidates the phash
+		if err := qb.UpdateCover(ctx, sceneID, []byte("TestSceneImagePhash changed")); err != nil {
+			t.Errorf("SceneStore.UpdateCover() error = %v", err)
+			return nil
+		}
+
+		missing, err = qb.FindMissingImagePhashes(ctx, 1000)
+		if err != nil {
+			t.Errorf("SceneStore.FindMissingImagePhashes() error = %v", err)
+			return nil
+		}
+		assert.Contains(t, missing, sceneID)
+
+		return nil
+	})
+}
+
 func TestSceneStashIDs(t *testing.T) {
 	if err := withTxn(func(ctx context.Context) error {
 		qb := db.Scene
@@ -4487,7 +4613,7 @@ func TestSceneStore_FindDuplicates(t *testing.T) {
 	withRollbackTxn(func(ctx context.Context) error {
 		distance := 0
 		durationDiff := -1.
-		got, err := qb.FindDuplicates(ctx, distance, durationDiff)
+		got, err := qb.FindDuplicates(ctx, distance, durationDiff, models.PhashDuplicateOptions{})
 		if err != nil {
 			t.Errorf("SceneStore.FindDuplicates() error = %v", err)
 			return nil
@@ -4497,7 +4623,7 @@ func TestSceneStore_FindDuplicates(t *testing.T) {
 
 		distance = 1
 		durationDiff = -1.
-		got, err = qb.FindDuplicates(ctx, distance, durationDiff)
+		got, err = qb.FindDuplicates(ctx, distance, durationDiff, models.PhashDuplicateOptions{})
 		if err != nil {
 			t.Errorf("SceneStore.FindDuplicates() error = %v", err)
 			return nil
@@ -4709,6 +4835,48 @@ func TestSceneStore_SaveActivity(t *testing.T) {
 	}
 }
 
+func TestSceneStore_WatchHeat(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		sceneID := sceneIDs[sceneIdx1WithPerformer]
+
+		save := func(resumeTime, playDuration float64) {
+			if _, err := qb.SaveActivity(ctx, sceneID, &resumeTime, &playDuration); err != nil {
+				t.Errorf("SceneStore.SaveActivity() error = %v", err)
+			}
+		}
+
+		save(12, 9)
+		save(10, 10)
+		// resume time reset on completion is ignored
+		save(0, 10)
+
+		got, err := qb.GetWatchHeat(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetWatchHeat() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, models.WatchHeat{7, 10, 2}, got)
+
+		if _, err := qb.ResetActivity(ctx, sceneID, false, true); err != nil {
+			t.Errorf("SceneStore.ResetActivity() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.GetWatchHeat(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetWatchHeat() error = %v", err)
+			return nil
+		}
+
+		assert.Empty(t, got)
+
+		return nil
+	})
+}
+
 // TODO Count
 // TODO SizeCount
 
@@ -4785,3 +4953,81 @@ func TestSceneStore_CountUniqueViews(t *testing.T) {
 		return nil
 	})
 }
+
+func TestScenePending(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		const (
+			oshash = "TestScenePending"
+			url    = "https://example.com/TestScenePending"
+		)
+
+		s := &models.Scene{
+			Title:   "TestScenePending",
+			Pending: true,
+			URLs:    models.NewRelatedStrings([]string{url}),
+		}
+		if err := qb.Create(ctx, s, nil); err != nil {
+			t.Errorf("SceneStore.Create() error = %v", err)
+			return nil
+		}
+
+		fp := []models.Fingerprint{{Type: models.FingerprintTypeOshash, Fingerprint: oshash}}
+		if err := qb.UpdatePendingFingerprints(ctx, s.ID, fp); err != nil {
+			t.Errorf("SceneStore.UpdatePendingFingerprints() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.GetPendingFingerprints(ctx, s.ID)
+		if err != nil {
+			t.Errorf("SceneStore.GetPendingFingerprints() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, fp, got)
+
+		sceneIDs := func(scenes []*models.Scene) []int {
+			var ret []int
+			for _, s := range scenes {
+				ret = append(ret, s.ID)
+			}
+			return ret
+		}
+
+		found, err := qb.FindPending(ctx, fp, nil)
+		if err != nil {
+			t.Errorf("SceneStore.FindPending() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, []int{s.ID}, sceneIDs(found))
+
+		found, err = qb.FindPending(ctx, []models.Fingerprint{{Type: models.FingerprintTypeMD5, Fingerprint: oshash}}, []string{url})
+		if err != nil {
+			t.Errorf("SceneStore.FindPending() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, []int{s.ID}, sceneIDs(found))
+
+		found, err = qb.FindPending(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("SceneStore.FindPending() error = %v", err)
+			return nil
+		}
+		assert.Empty(t, found)
+
+		// scenes that are no longer pending are not found
+		if _, err := qb.UpdatePartial(ctx, s.ID, models.ScenePartial{Pending: models.NewOptionalBool(false)}); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		found, err = qb.FindPending(ctx, fp, []string{url})
+		if err != nil {
+			t.Errorf("SceneStore.FindPending() error = %v", err)
+			return nil
+		}
+		assert.Empty(t, found)
+
+		return nil
+	})
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// separates performer names in the generated title query
+const performerNameSeparator = "\x1f"
+
+// sceneTitleTemplate is the template used to generate the titles of scenes.
+// Nil if titles are not generated.
+var sceneTitleTemplate atomic.Pointer[models.TitleTemplate]
+
+// SetSceneTitleTemplate sets the template used to generate titles for scenes
+// without a title. Existing generated titles are not changed until
+// RefreshGeneratedTitles is called.
+func (db *Database) SetSceneTitleTemplate(t *models.TitleTemplate) {
+	sceneTitleTemplate.Store(t)
+}
+
+type sceneTitleRow struct {
+	ID             int         `db:"id"`
+	GeneratedTitle zero.String `db:"generated_title"`
+	Code           zero.String `db:"code"`
+	Director       zero.String `db:"director"`
+	Date           NullDate    `db:"date"`
+	DatePrecision  null.Int    `db:"date_precision"`
+	StudioName     zero.String `db:"studio_name"`
+	PerformerNames zero.String `db:"performer_names"`
+}
+
+func (r sceneTitleRow) templateData() models.TitleTemplateData {
+	ret := models.TitleTemplateData{
+		Studio:   r.StudioName.String,
+		Date:     r.Date.DatePtrWithPrecision(r.DatePrecision),
+		Code:     r.Code.String,
+		Director: r.Director.String,
+	}
+
+	if r.PerformerNames.String != "" {
+		ret.Performers = strings.Split(r.PerformerNames.String, performerNameSeparator)
+	}
+
+	return ret
+}
+
+// refreshSceneGeneratedTitles regenerates the generated titles of the scenes
+// matching where.
+func refreshSceneGeneratedTitles(ctx context.Context, where string, args ...interface{}) error {
+	tmpl := sceneTitleTemplate.Load()
+
+	if tmpl == nil {
+		query := fmt.Sprintf("UPDATE %s SET generated_title = NULL WHERE generated_title IS NOT NULL AND (%s)", sceneTable, where)
+		if _, err := dbWrapper.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("clearing generated titles: %w", err)
+		}
+		return nil
+	}
+
+	query := fmt.Sprintf(`SELECT %[1]s.id, %[1]s.generated_title, %[1]s.code, %[1]s.director, %[1]s.date, %[1]s.date_precision, %[2]s.name AS studio_name,
+(SELECT GROUP_CONCAT(name, '%[5]s') FROM (
+  SELECT %[3]s.name FROM %[4]s INNER JOIN %[3]s ON %[3]s.id = %[4]s.performer_id
+  WHERE %[4]s.scene_id = %[1]s.id ORDER BY %[3]s.name COLLATE NATURAL_CI
+)) AS performer_names
+FROM %[1]s LEFT JOIN %[2]s ON %[2]s.id = %[1]s.studio_id
+WHERE %[6]s`, sceneTable, studioTable, performerTable, performersScenesTable, performerNameSeparator, where)
+
+	var rows []sceneTitleRow
+	if err := dbWrapper.Select(ctx, &rows, query, args...); err != nil {
+		return fmt.Errorf("querying scenes for generated titles: %w", err)
+	}
+
+	for _, r := range rows {
+		title := tmpl.Render(r.templateData())
+		if title == r.GeneratedTitle.String {
+			continue
+		}
+
+		// generated titles do not change the updated time of the scene
+		if _, err := dbWrapper.Exec(ctx, fmt.Sprintf("UPDATE %s SET generated_title = ? WHERE id = ?", sceneTable), zero.StringFrom(title), r.ID); err != nil {
+			return fmt.Errorf("setting generated title of scene %d: %w", r.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// refreshSceneGeneratedTitlesFor regenerates the generated titles of the
+// scenes with the given IDs.
+func refreshSceneGeneratedTitlesFor(ctx context.Context, sceneIDs []int) error {
+	// generated titles are cleared by RefreshGeneratedTitles when the
+	// template is removed
+	if sceneTitleTemplate.Load() == nil {
+		return nil
+	}
+
+	return batchExec(sceneIDs, defaultBatchSize, func(batch []int) error {
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+		return refreshSceneGeneratedTitles(ctx, fmt.Sprintf("%s.id IN %s", sceneTable, getInBinding(len(batch))), args...)
+	})
+}
+
+// titleTemplateUses returns true if scene titles are generated using the
+// given token.
+func titleTemplateUses(token string) bool {
+	tmpl := sceneTitleTemplate.Load()
+	return tmpl != nil && tmpl.Uses(token)
+}
+
+// RefreshGeneratedTitles regenerates the generated titles of all scenes
+// using the current title template.
+func (qb *SceneStore) RefreshGeneratedTitles(ctx context.Context) error {
+	return refreshSceneGeneratedTitles(ctx, "1 = 1")
+}
+
+// performerSceneIDsForTitle returns the IDs of the scenes whose generated
+// titles include the name of the performer.
+func performerSceneIDsForTitle(ctx context.Context, performerID int) ([]int, error) {
+	if !titleTemplateUses(models.TitleTemplatePerformers) {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT scene_id FROM %s WHERE performer_id = ?", performersScenesTable)
+	return sceneIDsForTitle(ctx, query, performerID)
+}
+
+// studioSceneIDsForTitle returns the IDs of the scenes whose generated titles
+// include the name of the studio.
+func studioSceneIDsForTitle(ctx context.Context, studioID int) ([]int, error) {
+	if !titleTemplateUses(models.TitleTemplateStudio) {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT id FROM %s WHERE studio_id = ?", sceneTable)
+	return sceneIDsForTitle(ctx, query, studioID)
+}
+
+func refreshPerformerSceneTitles(ctx context.Context, performerID int) error {
+	sceneIDs, err := performerSceneIDsForTitle(ctx, performerID)
+	if err != nil {
+		return err
+	}
+	return refreshSceneGeneratedTitlesFor(ctx, sceneIDs)
+}
+
+func refreshStudioSceneTitles(ctx context.Context, studioID int) error {
+	sceneIDs, err := studioSceneIDsForTitle(ctx, studioID)
+	if err != nil {
+		return err
+	}
+	return refreshSceneGeneratedTitlesFor(ctx, sceneIDs)
+}
+
+func sceneIDsForTitle(ctx context.Context, query string, id int) ([]int, error) {
+	var ret []int
+	if err := dbWrapper.Select(ctx, &ret, query, id); err != nil {
+		return nil, fmt.Errorf("finding scenes for generated titles: %w", err)
+	}
+	return ret, nil
+}
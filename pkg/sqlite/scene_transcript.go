@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	scenesTranscriptsTable    = "scene_transcripts"
+	scenesTranscriptsFTSTable = "scene_transcripts_fts"
+)
+
+type sceneTranscriptRow struct {
+	SceneID  int         `db:"scene_id"`
+	Language zero.String `db:"language"`
+	// the full text is indexed for searching
+	Text      string    `db:"text"`
+	Segments  string    `db:"segments"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *sceneTranscriptRow) resolve() (*models.SceneTranscript, error) {
+	ret := &models.SceneTranscript{
+		SceneID:   r.SceneID,
+		Language:  r.Language.String,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+
+	if err := json.Unmarshal([]byte(r.Segments), &ret.Segments); err != nil {
+		return nil, fmt.Errorf("decoding transcript segments: %w", err)
+	}
+
+	return ret, nil
+}
+
+// GetTranscript returns the transcript of the scene, or nil if the scene has
+// not been transcribed.
+func (qb *SceneStore) GetTranscript(ctx context.Context, sceneID int) (*models.SceneTranscript, error) {
+	table := scenesTranscriptsTableMgr.table
+	q := dialect.From(table).Select(table.All()).Where(scenesTranscriptsTableMgr.byID(sceneID))
+
+	const single = true
+	var ret *models.SceneTranscript
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row sceneTranscriptRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		var err error
+		ret, err = row.resolve()
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("getting scene transcript: %w", err)
+	}
+
+	return ret, nil
+}
+
+// SetTranscript replaces the transcript of the scene. The full-text index is
+// updated by triggers on the table.
+func (qb *SceneStore) SetTranscript(ctx context.Context, transcript models.SceneTranscript) error {
+	if err := qb.tableMgr.checkIDExists(ctx, transcript.SceneID); err != nil {
+		return err
+	}
+
+	segments, err := json.Marshal(transcript.Segments)
+	if err != nil {
+		return fmt.Errorf("encoding transcript segments: %w", err)
+	}
+
+	now := Timestamp{Timestamp: time.Now()}
+	q := dialect.Insert(scenesTranscriptsTableMgr.table).Prepared(true).Rows(goqu.Record{
+		sceneIDColumn: transcript.SceneID,
+		"language":    zero.StringFrom(transcript.Language),
+		"text":        transcript.Text(),
+		"segments":    string(segments),
+		"created_at":  now,
+		"updated_at":  now,
+	}).OnConflict(goqu.DoUpdate(sceneIDColumn, goqu.Record{
+		"language":   goqu.I("excluded.language"),
+		"text":       goqu.I("excluded.text"),
+		"segments":   goqu.I("excluded.segments"),
+		"updated_at": goqu.I("excluded.updated_at"),
+	}))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting scene transcript: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *SceneStore) DestroyTranscript(ctx context.Context, sceneID int) error {
+	q := dialect.Delete(scenesTranscriptsTableMgr.table).Where(scenesTranscriptsTableMgr.byID(sceneID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("removing scene transcript: %w", err)
+	}
+
+	return nil
+}
+
+// sceneTranscriptCriterionHandler filters scenes by a full-text search of
+// their transcripts. The value of the equals, includes and excludes modifiers
+// uses the SQLite full-text query syntax.
+func sceneTranscriptCriterionHandler(c *models.StringCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		matching := fmt.Sprintf("SELECT docid FROM %[1]s WHERE %[1]s MATCH ?", scenesTranscriptsFTSTable)
+		exists := fmt.Sprintf("SELECT scene_id FROM %s", scenesTranscriptsTable)
+
+		switch c.Modifier {
+		case models.CriterionModifierIncludes, models.CriterionModifierEquals:
+			f.addWhere(fmt.Sprintf("scenes.id IN (%s)", matching), c.Value)
+		case models.CriterionModifierExcludes, models.CriterionModifierNotEquals:
+			f.addWhere(fmt.Sprintf("scenes.id NOT IN (%s)", matching), c.Value)
+		case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+			if _, err := regexp.Compile(c.Value); err != nil {
+				f.setError(err)
+				return
+			}
+			in := "IN"
+			if c.Modifier == models.CriterionModifierNotMatchesRegex {
+				in = "NOT IN"
+			}
+			f.addWhere(fmt.Sprintf("scenes.id %s (%s WHERE text regexp ?)", in, exists), c.Value)
+		case models.CriterionModifierIsNull:
+			f.addWhere(fmt.Sprintf("scenes.id NOT IN (%s)", exists))
+		case models.CriterionModifierNotNull:
+			f.addWhere(fmt.Sprintf("scenes.id IN (%s)", exists))
+		default:
+			f.setError(fmt.Errorf("modifier %s is not supported for transcript criterion", c.Modifier))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	scenesWatchHeatTable = "scenes_watch_heat"
+)
+
+type sceneWatchHeatRow struct {
+	SceneID int     `db:"scene_id"`
+	Bucket  int     `db:"bucket"`
+	Seconds float64 `db:"seconds"`
+}
+
+// addWatchHeat adds the watched range of start to end seconds to the watch
+// heat of the scene.
+func (qb *SceneStore) addWatchHeat(ctx context.Context, sceneID int, start float64, end float64) error {
+	buckets := models.WatchedBuckets(start, end)
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	rows := make([]sceneWatchHeatRow, 0, len(buckets))
+	for bucket, seconds := range buckets {
+		rows = append(rows, sceneWatchHeatRow{
+			SceneID: sceneID,
+			Bucket:  bucket,
+			Seconds: seconds,
+		})
+	}
+
+	table := scenesWatchHeatTableMgr.table
+	q := dialect.Insert(table).Prepared(true).Rows(rows).OnConflict(goqu.DoUpdate(sceneIDColumn+", bucket", goqu.Record{
+		"seconds": goqu.L("seconds + excluded.seconds"),
+	}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("adding watch heat: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *SceneStore) destroyWatchHeat(ctx context.Context, sceneID int) error {
+	return scenesWatchHeatTableMgr.destroy(ctx, []int{sceneID})
+}
+
+// GetWatchHeat returns the watch heat of the scene. Returns nil if the scene
+// has not been watched.
+func (qb *SceneStore) GetWatchHeat(ctx context.Context, sceneID int) (models.WatchHeat, error) {
+	table := scenesWatchHeatTableMgr.table
+	q := dialect.From(table).Select(table.All()).Where(scenesWatchHeatTableMgr.byID(sceneID)).Order(table.Col("bucket").Asc())
+
+	const single = false
+	var ret models.WatchHeat
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row sceneWatchHeatRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		for len(ret) <= row.Bucket {
+			ret = append(ret, 0)
+		}
+		ret[row.Bucket] = row.Seconds
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting watch heat: %w", err)
+	}
+
+	return ret, nil
+}
@@ -1748,7 +1748,7 @@ func createStudios(ctx context.Context, n int, o int) error {
 		tids := indexesToIDs(tagIDs, studioTags[i])
 		studio := models.Studio{
 			Name:          name,
-			URL:           getStudioStringValue(index, urlField),
+			URLs:          models.NewRelatedStrings([]string{getStudioStringValue(index, urlField)}),
 			Favorite:      getStudioBoolValue(index),
 			IgnoreAutoTag: getIgnoreAutoTag(i),
 			TagIDs:        models.NewRelatedIDs(tids),
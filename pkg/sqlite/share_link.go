@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	shareLinkTable = "share_links"
+)
+
+type shareLinkRow struct {
+	ID        int           `db:"id" goqu:"skipinsert"`
+	Token     string        `db:"token"`
+	SceneID   null.Int      `db:"scene_id,omitempty"`
+	GalleryID null.Int      `db:"gallery_id,omitempty"`
+	Password  zero.String   `db:"password"`
+	ExpiresAt NullTimestamp `db:"expires_at"`
+	MaxViews  null.Int      `db:"max_views"`
+	ViewCount int           `db:"view_count"`
+	Preview   string        `db:"preview"`
+	CreatedAt Timestamp     `db:"created_at"`
+	UpdatedAt Timestamp     `db:"updated_at"`
+}
+
+func (r *shareLinkRow) fromShareLink(o models.ShareLink) {
+	r.ID = o.ID
+	r.Token = o.Token
+	r.SceneID = intFromPtr(o.SceneID)
+	r.GalleryID = intFromPtr(o.GalleryID)
+	r.Password = zero.StringFrom(o.Password)
+	r.ExpiresAt = NullTimestampFromTimePtr(o.ExpiresAt)
+	r.MaxViews = intFromPtr(o.MaxViews)
+	r.ViewCount = o.ViewCount
+	r.Preview = o.Preview.String()
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *shareLinkRow) resolve() *models.ShareLink {
+	ret := &models.ShareLink{
+		ID:        r.ID,
+		Token:     r.Token,
+		SceneID:   nullIntPtr(r.SceneID),
+		GalleryID: nullIntPtr(r.GalleryID),
+		Password:  r.Password.String,
+		ExpiresAt: r.ExpiresAt.TimePtr(),
+		MaxViews:  nullIntPtr(r.MaxViews),
+		ViewCount: r.ViewCount,
+		Preview:   models.ShareLinkPreview(r.Preview),
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type ShareLinkStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewShareLinkStore() *ShareLinkStore {
+	return &ShareLinkStore{
+		repository: repository{
+			tableName: shareLinkTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: shareLinkTableMgr,
+	}
+}
+
+func (qb *ShareLinkStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *ShareLinkStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *ShareLinkStore) Create(ctx context.Context, newObject *models.ShareLink) error {
+	var r shareLinkRow
+	r.fromShareLink(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *ShareLinkStore) IncrementViewCount(ctx context.Context, id int) (bool, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return false, err
+	}
+
+	// the view limit is checked in the same statement as the increment, so
+	// that concurrent views cannot exceed it
+	table := qb.table()
+	q := dialect.Update(table).Prepared(true).
+		Set(goqu.Record{
+			"view_count": goqu.L("view_count + 1"),
+			"updated_at": Timestamp{Timestamp: time.Now()},
+		}).
+		Where(
+			qb.tableMgr.byID(id),
+			goqu.Or(
+				table.Col("max_views").IsNull(),
+				table.Col("view_count").Lt(table.Col("max_views")),
+			),
+		)
+
+	result, err := exec(ctx, q)
+	if err != nil {
+		return false, fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	ra, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return ra > 0, nil
+}
+
+func (qb *ShareLinkStore) UpdatePreview(ctx context.Context, id int, preview models.ShareLinkPreview) (*models.ShareLink, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := qb.tableMgr.updateByID(ctx, id, goqu.Record{
+		"preview":    preview.String(),
+		"updated_at": Timestamp{Timestamp: time.Now()},
+	}); err != nil {
+		return nil, err
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *ShareLinkStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *ShareLinkStore) Find(ctx context.Context, id int) (*models.ShareLink, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *ShareLinkStore) find(ctx context.Context, id int) (*models.ShareLink, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found
+func (qb *ShareLinkStore) FindByToken(ctx context.Context, token string) (*models.ShareLink, error) {
+	q := qb.selectDataset().Prepared(true).Where(qb.table().Col("token").Eq(token))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *ShareLinkStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.ShareLink, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(sceneIDColumn).Eq(sceneID)).Order(table.Col("created_at").Desc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *ShareLinkStore) FindByGalleryID(ctx context.Context, galleryID int) ([]*models.ShareLink, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(galleryIDColumn).Eq(galleryID)).Order(table.Col("created_at").Desc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *ShareLinkStore) All(ctx context.Context) ([]*models.ShareLink, error) {
+	q := qb.selectDataset().Order(qb.table().Col("created_at").Desc())
+	return qb.getMany(ctx, q)
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *ShareLinkStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.ShareLink, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *ShareLinkStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.ShareLink, error) {
+	const single = false
+	var ret []*models.ShareLink
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f shareLinkRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,50 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareLinkIncrementViewCount(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.ShareLink
+
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		maxViews := 2
+		link := models.NewShareLink()
+		link.Token = "increment-view-count"
+		link.SceneID = &sceneID
+		link.MaxViews = &maxViews
+
+		if err := qb.Create(ctx, &link); err != nil {
+			t.Errorf("Create error = %v", err)
+			return nil
+		}
+
+		for i, want := range []bool{true, true, false} {
+			got, err := qb.IncrementViewCount(ctx, link.ID)
+			if err != nil {
+				t.Errorf("IncrementViewCount error = %v", err)
+				return nil
+			}
+			assert.Equal(t, want, got, "view %d", i+1)
+		}
+
+		found, err := qb.Find(ctx, link.ID)
+		if err != nil {
+			t.Errorf("Find error = %v", err)
+			return nil
+		}
+
+		// views beyond the limit are not counted
+		assert.Equal(t, maxViews, found.ViewCount)
+
+		return nil
+	})
+}
@@ -116,6 +116,19 @@ func getSort(sort string, direction string, tableName string) string {
 	}
 }
 
+// getDateSort sorts by the date column of the table. Partial dates are
+// stored as the start of the year or month, so they are sorted before more
+// precise dates on the same day when ascending.
+func getDateSort(direction string, tableName string) string {
+	direction = getSortDirection(direction)
+	precisionDirection := "DESC"
+	if direction == "DESC" {
+		precisionDirection = "ASC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, COALESCE(%s, 0) %s", getColumn(tableName, "date"), direction, getColumn(tableName, "date_precision"), precisionDirection)
+}
+
 func getRandomSort(tableName string, direction string, seed uint64) string {
 	// cap seed at 10^8
 	seed %= 1e8
@@ -22,6 +22,8 @@ const (
 	studioIDColumn        = "studio_id"
 	studioAliasesTable    = "studio_aliases"
 	studioAliasColumn     = "alias"
+	studioURLsTable       = "studio_urls"
+	studioURLColumn       = "url"
 	studioParentIDColumn  = "parent_id"
 	studioNameColumn      = "name"
 	studioImageBlobColumn = "image_blob"
@@ -31,15 +33,17 @@ const (
 type studioRow struct {
 	ID        int         `db:"id" goqu:"skipinsert"`
 	Name      zero.String `db:"name"`
-	URL       zero.String `db:"url"`
 	ParentID  null.Int    `db:"parent_id,omitempty"`
 	CreatedAt Timestamp   `db:"created_at"`
 	UpdatedAt Timestamp   `db:"updated_at"`
 	// expressed as 1-100
-	Rating        null.Int    `db:"rating"`
-	Favorite      bool        `db:"favorite"`
-	Details       zero.String `db:"details"`
-	IgnoreAutoTag bool        `db:"ignore_auto_tag"`
+	Rating        null.Int      `db:"rating"`
+	Favorite      bool          `db:"favorite"`
+	Details       zero.String   `db:"details"`
+	IgnoreAutoTag bool          `db:"ignore_auto_tag"`
+	FavoritedAt   NullTimestamp `db:"favorited_at"`
+
+	PropagateDefaults bool `db:"propagate_defaults"`
 
 	// not used in resolutions or updates
 	ImageBlob zero.String `db:"image_blob"`
@@ -48,7 +52,6 @@ type studioRow struct {
 func (r *studioRow) fromStudio(o models.Studio) {
 	r.ID = o.ID
 	r.Name = zero.StringFrom(o.Name)
-	r.URL = zero.StringFrom(o.URL)
 	r.ParentID = intFromPtr(o.ParentID)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
@@ -56,13 +59,14 @@ func (r *studioRow) fromStudio(o models.Studio) {
 	r.Favorite = o.Favorite
 	r.Details = zero.StringFrom(o.Details)
 	r.IgnoreAutoTag = o.IgnoreAutoTag
+	r.FavoritedAt = favoritedAtFrom(o.Favorite, o.FavoritedAt, o.UpdatedAt)
+	r.PropagateDefaults = o.PropagateDefaults
 }
 
 func (r *studioRow) resolve() *models.Studio {
 	ret := &models.Studio{
 		ID:            r.ID,
 		Name:          r.Name.String,
-		URL:           r.URL.String,
 		ParentID:      nullIntPtr(r.ParentID),
 		CreatedAt:     r.CreatedAt.Timestamp,
 		UpdatedAt:     r.UpdatedAt.Timestamp,
@@ -70,6 +74,9 @@ func (r *studioRow) resolve() *models.Studio {
 		Favorite:      r.Favorite,
 		Details:       r.Details.String,
 		IgnoreAutoTag: r.IgnoreAutoTag,
+		FavoritedAt:   r.FavoritedAt.TimePtr(),
+
+		PropagateDefaults: r.PropagateDefaults,
 	}
 
 	return ret
@@ -81,7 +88,6 @@ type studioRowRecord struct {
 
 func (r *studioRowRecord) fromPartial(o models.StudioPartial) {
 	r.setNullString("name", o.Name)
-	r.setNullString("url", o.URL)
 	r.setNullInt("parent_id", o.ParentID)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
@@ -89,6 +95,8 @@ func (r *studioRowRecord) fromPartial(o models.StudioPartial) {
 	r.setBool("favorite", o.Favorite)
 	r.setNullString("details", o.Details)
 	r.setBool("ignore_auto_tag", o.IgnoreAutoTag)
+	r.setFavoritedAt(o.Favorite, o.FavoritedAt, o.UpdatedAt)
+	r.setBool("propagate_defaults", o.PropagateDefaults)
 }
 
 type studioRepositoryType struct {
@@ -141,6 +149,7 @@ var (
 type StudioStore struct {
 	blobJoinQueryBuilder
 	tagRelationshipStore
+	fieldSourcesStore
 
 	tableMgr *table
 }
@@ -156,6 +165,10 @@ func NewStudioStore(blobStore *BlobStore) *StudioStore {
 				joinTable: studiosTagsTableMgr,
 			},
 		},
+		fieldSourcesStore: fieldSourcesStore{
+			table: studiosFieldSourcesTable,
+			fk:    studiosFieldSourcesTable.Col(studioIDColumn),
+		},
 
 		tableMgr: studioTableMgr,
 	}
@@ -190,6 +203,13 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		const startPos = 0
+		if err := studiosURLsTableMgr.insertJoins(ctx, id, startPos, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tagRelationshipStore.createRelationships(ctx, id, newObject.TagIDs); err != nil {
 		return err
 	}
@@ -200,6 +220,19 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 		}
 	}
 
+	if newObject.DefaultTagIDs.Loaded() {
+		if err := studiosDefaultTagsTableMgr.insertJoins(ctx, id, newObject.DefaultTagIDs.List()); err != nil {
+			return err
+		}
+	}
+
+	if newObject.DefaultURLs.Loaded() {
+		const startPos = 0
+		if err := studiosDefaultURLsTableMgr.insertJoins(ctx, id, startPos, newObject.DefaultURLs.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -234,6 +267,12 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if input.URLs != nil {
+		if err := studiosURLsTableMgr.modifyJoins(ctx, input.ID, input.URLs.Values, input.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := qb.tagRelationshipStore.modifyRelationships(ctx, input.ID, input.TagIDs); err != nil {
 		return nil, err
 	}
@@ -244,6 +283,24 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if input.DefaultTagIDs != nil {
+		if err := studiosDefaultTagsTableMgr.modifyJoins(ctx, input.ID, input.DefaultTagIDs.IDs, input.DefaultTagIDs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DefaultURLs != nil {
+		if err := studiosDefaultURLsTableMgr.modifyJoins(ctx, input.ID, input.DefaultURLs.Values, input.DefaultURLs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Name.Set {
+		if err := refreshStudioSceneTitles(ctx, input.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.Find(ctx, input.ID)
 }
 
@@ -262,6 +319,12 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		}
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := studiosURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tagRelationshipStore.replaceRelationships(ctx, updatedObject.ID, updatedObject.TagIDs); err != nil {
 		return err
 	}
@@ -272,7 +335,19 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		}
 	}
 
-	return nil
+	if updatedObject.DefaultTagIDs.Loaded() {
+		if err := studiosDefaultTagsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.DefaultTagIDs.List()); err != nil {
+			return err
+		}
+	}
+
+	if updatedObject.DefaultURLs.Loaded() {
+		if err := studiosDefaultURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.DefaultURLs.List()); err != nil {
+			return err
+		}
+	}
+
+	return refreshStudioSceneTitles(ctx, updatedObject.ID)
 }
 
 func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
@@ -281,7 +356,16 @@ func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
 		return err
 	}
 
-	return studioRepository.destroyExisting(ctx, []int{id})
+	sceneIDs, err := studioSceneIDsForTitle(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := studioRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return refreshSceneGeneratedTitlesFor(ctx, sceneIDs)
 }
 
 // returns nil, nil if not found
@@ -584,6 +668,7 @@ var studioSortOptions = sortOptions{
 	"images_count",
 	"name",
 	"scenes_count",
+	"favorited_at",
 	"random",
 	"rating",
 	"updated_at",
@@ -649,3 +734,23 @@ func (qb *StudioStore) GetStashIDs(ctx context.Context, studioID int) ([]models.
 func (qb *StudioStore) GetAliases(ctx context.Context, studioID int) ([]string, error) {
 	return studiosAliasesTableMgr.get(ctx, studioID)
 }
+
+func (qb *StudioStore) GetURLs(ctx context.Context, studioID int) ([]string, error) {
+	return studiosURLsTableMgr.get(ctx, studioID)
+}
+
+func (qb *StudioStore) GetTypedURLs(ctx context.Context, studioID int) ([]models.TypedURL, error) {
+	return studiosURLsTableMgr.getTyped(ctx, studioID)
+}
+
+func (qb *StudioStore) SetURLTypes(ctx context.Context, studioID int, types map[string]*models.URLType) error {
+	return studiosURLsTableMgr.setTypes(ctx, studioID, types)
+}
+
+func (qb *StudioStore) GetDefaultTagIDs(ctx context.Context, studioID int) ([]int, error) {
+	return studiosDefaultTagsTableMgr.get(ctx, studioID)
+}
+
+func (qb *StudioStore) GetDefaultURLs(ctx context.Context, studioID int) ([]string, error) {
+	return studiosDefaultURLsTableMgr.get(ctx, studioID)
+}
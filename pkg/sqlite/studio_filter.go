@@ -55,7 +55,8 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 	return compoundHandler{
 		stringCriterionHandler(studioFilter.Name, studioTable+".name"),
 		stringCriterionHandler(studioFilter.Details, studioTable+".details"),
-		stringCriterionHandler(studioFilter.URL, studioTable+".url"),
+		qb.urlsCriterionHandler(studioFilter.URL),
+		typedURLCriterionHandler(studioFilter.TypedURL, studioURLsTable, studioIDColumn, "studios.id"),
 		intCriterionHandler(studioFilter.Rating100, studioTable+".rating", nil),
 		boolCriterionHandler(studioFilter.Favorite, studioTable+".favorite", nil),
 		boolCriterionHandler(studioFilter.IgnoreAutoTag, studioTable+".ignore_auto_tag", nil),
@@ -84,6 +85,7 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 		qb.childCountCriterionHandler(studioFilter.ChildCount),
 		&timestampCriterionHandler{studioFilter.CreatedAt, studioTable + ".created_at", nil},
 		&timestampCriterionHandler{studioFilter.UpdatedAt, studioTable + ".updated_at", nil},
+		&timestampCriterionHandler{studioFilter.FavoritedAt, studioTable + ".favorited_at", nil},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes.id",
@@ -118,6 +120,9 @@ func (qb *studioFilterHandler) isMissingCriterionHandler(isMissing *string) crit
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
 			switch *isMissing {
+			case "url":
+				studiosURLsTableMgr.join(f, "", "studios.id")
+				f.addWhere("studio_urls.url IS NULL")
 			case "image":
 				f.addWhere("studios.image_blob IS NULL")
 			case "stash_id":
@@ -202,6 +207,20 @@ func (qb *studioFilterHandler) aliasCriterionHandler(alias *models.StringCriteri
 	return h.handler(alias)
 }
 
+func (qb *studioFilterHandler) urlsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		primaryTable: studioTable,
+		primaryFK:    studioIDColumn,
+		joinTable:    studioURLsTable,
+		stringColumn: studioURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			studiosURLsTableMgr.join(f, "", "studios.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
 func (qb *studioFilterHandler) childCountCriterionHandler(childCount *models.IntCriterionInput) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if childCount != nil {
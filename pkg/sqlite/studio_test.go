@@ -109,7 +109,7 @@ func TestStudioQueryNameAndUrl(t *testing.T) {
 
 		assert.Len(t, studios, 1)
 		assert.Equal(t, studioName, studios[0].Name)
-		assert.Equal(t, studioUrl, studios[0].URL)
+		assert.Equal(t, studioUrl, getStudioURL(ctx, t, studios[0]))
 
 		return nil
 	})
@@ -147,7 +147,7 @@ func TestStudioQueryNameNotUrl(t *testing.T) {
 		for _, studio := range studios {
 			verifyString(t, studio.Name, nameCriterion)
 			urlCriterion.Modifier = models.CriterionModifierNotEquals
-			verifyString(t, studio.URL, urlCriterion)
+			verifyString(t, getStudioURL(ctx, t, studio), urlCriterion)
 		}
 
 		return nil
@@ -656,7 +656,7 @@ func TestStudioQueryURL(t *testing.T) {
 
 	verifyFn := func(ctx context.Context, g *models.Studio) {
 		t.Helper()
-		verifyString(t, g.URL, urlCriterion)
+		verifyString(t, getStudioURL(ctx, t, g), urlCriterion)
 	}
 
 	verifyStudioQuery(t, filter, verifyFn)
@@ -808,6 +808,22 @@ func verifyStudiosTagCount(t *testing.T, tagCountCriterion models.IntCriterionIn
 	})
 }
 
+// getStudioURL returns the first url of the studio, or an empty string if
+// it has none.
+func getStudioURL(ctx context.Context, t *testing.T, s *models.Studio) string {
+	t.Helper()
+	if err := s.LoadURLs(ctx, db.Studio); err != nil {
+		t.Errorf("Error loading studio urls: %v", err)
+		return ""
+	}
+
+	urls := s.URLs.List()
+	if len(urls) == 0 {
+		return ""
+	}
+	return urls[0]
+}
+
 func verifyStudioQuery(t *testing.T, filter models.StudioFilterType, verifyFn func(ctx context.Context, s *models.Studio)) {
 	withTxn(func(ctx context.Context) error {
 		t.Helper()
@@ -1039,6 +1055,69 @@ func testStudioAlias(ctx context.Context, t *testing.T, s *models.Studio) {
 	assert.Len(t, s.Aliases.List(), 0)
 }
 
+func TestStudioDefaults(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		urls := []string{"https://example.com/{code}", "https://example.com/{date}"}
+		newStudio := models.NewStudio()
+		newStudio.Name = "TestStudioDefaults"
+		newStudio.PropagateDefaults = true
+		newStudio.DefaultTagIDs = models.NewRelatedIDs([]int{tagIDs[tagIdx1WithScene]})
+		newStudio.DefaultURLs = models.NewRelatedStrings(urls)
+
+		if err := qb.Create(ctx, &newStudio); err != nil {
+			return fmt.Errorf("Error creating studio: %s", err.Error())
+		}
+
+		s, err := qb.Find(ctx, newStudio.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting studio: %s", err.Error())
+		}
+
+		assert.True(t, s.PropagateDefaults)
+		if err := s.LoadDefaultTagIDs(ctx, qb); err != nil {
+			return err
+		}
+		if err := s.LoadDefaultURLs(ctx, qb); err != nil {
+			return err
+		}
+		assert.Equal(t, []int{tagIDs[tagIdx1WithScene]}, s.DefaultTagIDs.List())
+		assert.Equal(t, urls, s.DefaultURLs.List())
+
+		// add a tag, remove a url and stop propagating
+		s, err = qb.UpdatePartial(ctx, models.StudioPartial{
+			ID:                s.ID,
+			PropagateDefaults: models.NewOptionalBool(false),
+			DefaultTagIDs: &models.UpdateIDs{
+				IDs:  []int{tagIDs[tagIdx2WithScene]},
+				Mode: models.RelationshipUpdateModeAdd,
+			},
+			DefaultURLs: &models.UpdateStrings{
+				Values: urls[:1],
+				Mode:   models.RelationshipUpdateModeRemove,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		assert.False(t, s.PropagateDefaults)
+		if err := s.LoadDefaultTagIDs(ctx, qb); err != nil {
+			return err
+		}
+		if err := s.LoadDefaultURLs(ctx, qb); err != nil {
+			return err
+		}
+		assert.ElementsMatch(t, []int{tagIDs[tagIdx1WithScene], tagIDs[tagIdx2WithScene]}, s.DefaultTagIDs.List())
+		assert.Equal(t, urls[1:], s.DefaultURLs.List())
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TestStudioQueryFast does a quick test for major errors, no result verification
 func TestStudioQueryFast(t *testing.T) {
 
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const deletedObjectsTable = "deleted_objects"
+
+// syncTable is an object table in the sync change feed.
+type syncTable struct {
+	table string
+	// restrictedWhere returns a where clause excluding objects with a
+	// restricted tag, if the objects can be restricted.
+	restrictedWhere func() string
+}
+
+var syncTables = map[models.SyncObjectType]syncTable{
+	models.SyncObjectTypeScene: {
+		table:           sceneTable,
+		restrictedWhere: func() string { return sceneRestrictedTags.where(sceneTable + ".id") },
+	},
+	models.SyncObjectTypeImage: {
+		table:           imageTable,
+		restrictedWhere: func() string { return imageRestrictedTags.where(imageTable + ".id") },
+	},
+	models.SyncObjectTypeGallery: {
+		table:           galleryTable,
+		restrictedWhere: func() string { return galleryRestrictedTags.where(galleryTable + ".id") },
+	},
+	models.SyncObjectTypePerformer: {table: performerTable},
+	models.SyncObjectTypeStudio:    {table: studioTable},
+	models.SyncObjectTypeTag:       {table: tagTable},
+	models.SyncObjectTypeGroup:     {table: groupTable},
+	models.SyncObjectTypeSceneMarker: {
+		table:           sceneMarkerTable,
+		restrictedWhere: sceneMarkerRestrictedWhere,
+	},
+}
+
+// SyncStore reads the change feed of the object tables. Deleted objects
+// are recorded in the deleted_objects table by triggers on the object
+// tables.
+type SyncStore struct {
+	repository
+}
+
+func NewSyncStore() *SyncStore {
+	return &SyncStore{
+		repository: repository{
+			tableName: deletedObjectsTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *SyncStore) Changes(ctx context.Context, objectType models.SyncObjectType, since *time.Time) (*models.SyncChanges, error) {
+	t, ok := syncTables[objectType]
+	if !ok {
+		return nil, fmt.Errorf("invalid sync object type %q", objectType)
+	}
+
+	// objects with a restricted tag are hidden from the feed. Objects that
+	// were restricted since the cursor are reported as deleted, so that
+	// clients remove their copies.
+	visible := "1 = 1"
+	if t.restrictedWhere != nil && hideRestricted(ctx) {
+		visible = t.restrictedWhere()
+	}
+
+	ret := &models.SyncChanges{}
+	var err error
+
+	if since == nil {
+		query := fmt.Sprintf("SELECT %[1]s.id FROM %[1]s WHERE %[2]s ORDER BY %[1]s.id", t.table, visible)
+		ret.Created, err = qb.runIdsQuery(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return ret, nil
+	}
+
+	// timestamps are stored with their local offset, so they must be
+	// normalised before comparison
+	cursor := UTCTimestamp{Timestamp{*since}}
+
+	query := fmt.Sprintf("SELECT %[1]s.id FROM %[1]s WHERE datetime(%[1]s.created_at) >= datetime(?) AND %[2]s ORDER BY %[1]s.id", t.table, visible)
+	ret.Created, err = qb.runIdsQuery(ctx, query, []interface{}{cursor})
+	if err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf("SELECT %[1]s.id FROM %[1]s WHERE datetime(%[1]s.created_at) < datetime(?) AND datetime(%[1]s.updated_at) >= datetime(?) AND %[2]s ORDER BY %[1]s.id", t.table, visible)
+	ret.Updated, err = qb.runIdsQuery(ctx, query, []interface{}{cursor, cursor})
+	if err != nil {
+		return nil, err
+	}
+
+	// exclude deleted ids that have since been reused
+	query = fmt.Sprintf(`SELECT DISTINCT object_id AS id FROM %[1]s WHERE object_type = ? AND datetime(deleted_at) >= datetime(?) AND object_id NOT IN (SELECT id FROM %[2]s)
+UNION SELECT %[2]s.id FROM %[2]s WHERE datetime(%[2]s.updated_at) >= datetime(?) AND NOT (%[3]s)
+ORDER BY id`, deletedObjectsTable, t.table, visible)
+	ret.Deleted, err = qb.runIdsQuery(ctx, query, []interface{}{objectType, cursor, cursor})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
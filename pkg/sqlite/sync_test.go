@@ -0,0 +1,83 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncChanges(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		now := time.Now()
+		since := now.Add(-time.Minute)
+
+		created := &models.Scene{
+			Title:     "sync created",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		updated := &models.Scene{
+			Title:     "sync updated",
+			CreatedAt: now.Add(-time.Hour),
+			UpdatedAt: now,
+		}
+		unchanged := &models.Scene{
+			Title:     "sync unchanged",
+			CreatedAt: now.Add(-time.Hour),
+			UpdatedAt: now.Add(-time.Hour),
+		}
+		deleted := &models.Scene{
+			Title:     "sync deleted",
+			CreatedAt: now.Add(-time.Hour),
+			UpdatedAt: now.Add(-time.Hour),
+		}
+
+		for _, s := range []*models.Scene{created, updated, unchanged, deleted} {
+			if err := db.Scene.Create(ctx, s, nil); err != nil {
+				t.Errorf("Error creating scene: %v", err)
+				return nil
+			}
+		}
+
+		if err := db.Scene.Destroy(ctx, deleted.ID); err != nil {
+			t.Errorf("Error destroying scene: %v", err)
+			return nil
+		}
+
+		got, err := db.Sync.Changes(ctx, models.SyncObjectTypeScene, &since)
+		if err != nil {
+			t.Errorf("SyncStore.Changes() error = %v", err)
+			return nil
+		}
+
+		assert.Contains(t, got.Created, created.ID)
+		assert.NotContains(t, got.Created, updated.ID)
+		assert.Contains(t, got.Updated, updated.ID)
+		assert.NotContains(t, got.Updated, created.ID)
+		assert.Contains(t, got.Deleted, deleted.ID)
+
+		for _, ids := range [][]int{got.Created, got.Updated, got.Deleted} {
+			assert.NotContains(t, ids, unchanged.ID)
+		}
+
+		// without a cursor, all existing objects are created
+		got, err = db.Sync.Changes(ctx, models.SyncObjectTypeScene, nil)
+		if err != nil {
+			t.Errorf("SyncStore.Changes() error = %v", err)
+			return nil
+		}
+
+		assert.Contains(t, got.Created, unchanged.ID)
+		assert.NotContains(t, got.Created, deleted.ID)
+		assert.Empty(t, got.Updated)
+		assert.Empty(t, got.Deleted)
+
+		return nil
+	})
+}
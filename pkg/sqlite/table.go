@@ -201,6 +201,42 @@ func (t *joinTable) get(ctx context.Context, id int) ([]int, error) {
 	return ret, nil
 }
 
+// getMany returns the foreign keys of each of the ids, in the same order as ids.
+func (t *joinTable) getMany(ctx context.Context, ids []int) ([][]int, error) {
+	ret := make([][]int, len(ids))
+	if len(ids) == 0 {
+		return ret, nil
+	}
+
+	q := dialect.Select(t.idColumn, t.fkColumn).From(t.table.table).Where(t.idColumn.In(ids))
+
+	if t.orderBy != nil {
+		if t.foreignTable != nil {
+			q = q.InnerJoin(t.foreignTable.table, goqu.On(t.foreignTable.idColumn.Eq(t.fkColumn)))
+		}
+		q = q.Order(t.orderBy)
+	}
+
+	idToIndex := idToIndexMap(ids)
+
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var id, fk int
+		if err := rows.Scan(&id, &fk); err != nil {
+			return err
+		}
+
+		i := idToIndex[id]
+		ret[i] = append(ret[i], fk)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting foreign keys from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
 func (t *joinTable) insertJoins(ctx context.Context, id int, foreignIDs []int) error {
 	// manually create SQL so that we can prepare once
 	// ignore duplicates
@@ -853,6 +889,17 @@ func (t *relatedFilesTable) setPrimary(ctx context.Context, id int, fileID model
 type viewHistoryTable struct {
 	table
 	dateColumn exp.IdentifierExpression
+	// restrictedTags hides the history of restricted objects from the
+	// totals
+	restrictedTags *restrictedTags
+}
+
+func (t *viewHistoryTable) restrict(ctx context.Context, q *goqu.SelectDataset) *goqu.SelectDataset {
+	if t.restrictedTags == nil {
+		return q
+	}
+
+	return restrictDataset(ctx, q, t.restrictedTags.where(t.table.table.GetTable()+"."+t.restrictedTags.fkColumn))
 }
 
 func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, error) {
@@ -1016,6 +1063,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 	table := t.table.table
 	q := dialect.Select(goqu.COUNT("*")).From(table)
+	q = t.restrict(ctx, q)
 
 	const single = true
 	var ret int
@@ -1034,6 +1082,7 @@ func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 func (t *viewHistoryTable) getUniqueCount(ctx context.Context) (int, error) {
 	table := t.table.table
 	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table)
+	q = t.restrict(ctx, q)
 
 	const single = true
 	var ret int
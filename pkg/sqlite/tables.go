@@ -27,16 +27,22 @@ var (
 	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
 	scenesGroupsJoinTable     = goqu.T(groupsScenesTable)
 	scenesURLsJoinTable       = goqu.T(scenesURLsTable)
+	scenesFieldSourcesTable   = goqu.T("scene_field_sources")
 
 	performersAliasesJoinTable  = goqu.T(performersAliasesTable)
 	performersURLsJoinTable     = goqu.T(performerURLsTable)
 	performersTagsJoinTable     = goqu.T(performersTagsTable)
 	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
 	performersCustomFieldsTable = goqu.T("performer_custom_fields")
+	performersFieldSourcesTable = goqu.T("performer_field_sources")
 
 	studiosAliasesJoinTable  = goqu.T(studioAliasesTable)
 	studiosTagsJoinTable     = goqu.T(studiosTagsTable)
 	studiosStashIDsJoinTable = goqu.T("studio_stash_ids")
+	studiosFieldSourcesTable = goqu.T("studio_field_sources")
+	studiosDefaultTagsTable  = goqu.T("studio_default_tags")
+	studiosDefaultURLsTable  = goqu.T("studio_default_urls")
+	studiosURLsJoinTable     = goqu.T(studioURLsTable)
 
 	groupsURLsJoinTable     = goqu.T(groupURLsTable)
 	groupsTagsJoinTable     = goqu.T(groupsTagsTable)
@@ -92,6 +98,15 @@ var (
 		},
 		valueColumn: imagesURLsJoinTable.Col(imageURLColumn),
 	}
+
+	imagesViewTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(imagesViewDatesTable),
+			idColumn: goqu.T(imagesViewDatesTable).Col(imageIDColumn),
+		},
+		dateColumn:     goqu.T(imagesViewDatesTable).Col(imageViewDateColumn),
+		restrictedTags: imageRestrictedTags,
+	}
 )
 
 var (
@@ -136,12 +151,37 @@ var (
 		idColumn: goqu.T(galleriesChaptersTable).Col(idColumn),
 	}
 
-	galleriesURLsTableMgr = &orderedValueTable[string]{
+	galleriesURLsTableMgr = &urlsTable{
+		orderedValueTable: orderedValueTable[string]{
+			table: table{
+				table:    galleriesURLsJoinTable,
+				idColumn: galleriesURLsJoinTable.Col(galleryIDColumn),
+			},
+			valueColumn: galleriesURLsJoinTable.Col(galleriesURLColumn),
+		},
+	}
+
+	galleriesViewTableMgr = &viewHistoryTable{
 		table: table{
-			table:    galleriesURLsJoinTable,
-			idColumn: galleriesURLsJoinTable.Col(galleryIDColumn),
+			table:    goqu.T(galleriesViewDatesTable),
+			idColumn: goqu.T(galleriesViewDatesTable).Col(galleryIDColumn),
 		},
-		valueColumn: galleriesURLsJoinTable.Col(galleriesURLColumn),
+		dateColumn:     goqu.T(galleriesViewDatesTable).Col(galleryViewDateColumn),
+		restrictedTags: galleryRestrictedTags,
+	}
+
+	galleriesOTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(galleriesODatesTable),
+			idColumn: goqu.T(galleriesODatesTable).Col(galleryIDColumn),
+		},
+		dateColumn:     goqu.T(galleriesODatesTable).Col(galleryODateColumn),
+		restrictedTags: galleryRestrictedTags,
+	}
+
+	galleryReadingSessionTableMgr = &table{
+		table:    goqu.T(galleryReadingSessionsTable),
+		idColumn: goqu.T(galleryReadingSessionsTable).Col(galleryIDColumn),
 	}
 )
 
@@ -195,12 +235,14 @@ var (
 		},
 	}
 
-	scenesURLsTableMgr = &orderedValueTable[string]{
-		table: table{
-			table:    scenesURLsJoinTable,
-			idColumn: scenesURLsJoinTable.Col(sceneIDColumn),
+	scenesURLsTableMgr = &urlsTable{
+		orderedValueTable: orderedValueTable[string]{
+			table: table{
+				table:    scenesURLsJoinTable,
+				idColumn: scenesURLsJoinTable.Col(sceneIDColumn),
+			},
+			valueColumn: scenesURLsJoinTable.Col(sceneURLColumn),
 		},
-		valueColumn: scenesURLsJoinTable.Col(sceneURLColumn),
 	}
 
 	scenesViewTableMgr = &viewHistoryTable{
@@ -208,7 +250,28 @@ var (
 			table:    goqu.T(scenesViewDatesTable),
 			idColumn: goqu.T(scenesViewDatesTable).Col(sceneIDColumn),
 		},
-		dateColumn: goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		dateColumn:     goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		restrictedTags: sceneRestrictedTags,
+	}
+
+	scenesWatchHeatTableMgr = &table{
+		table:    goqu.T(scenesWatchHeatTable),
+		idColumn: goqu.T(scenesWatchHeatTable).Col(sceneIDColumn),
+	}
+
+	scenesRatingsTableMgr = &table{
+		table:    goqu.T(scenesRatingsTable),
+		idColumn: goqu.T(scenesRatingsTable).Col(sceneIDColumn),
+	}
+
+	scenesTranscriptsTableMgr = &table{
+		table:    goqu.T(scenesTranscriptsTable),
+		idColumn: goqu.T(scenesTranscriptsTable).Col(sceneIDColumn),
+	}
+
+	scenesPendingFingerprintsTableMgr = &table{
+		table:    goqu.T(scenesPendingFingerprintsTable),
+		idColumn: goqu.T(scenesPendingFingerprintsTable).Col(sceneIDColumn),
 	}
 
 	scenesOTableMgr = &viewHistoryTable{
@@ -216,7 +279,8 @@ var (
 			table:    goqu.T(scenesODatesTable),
 			idColumn: goqu.T(scenesODatesTable).Col(sceneIDColumn),
 		},
-		dateColumn: goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		dateColumn:     goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		restrictedTags: sceneRestrictedTags,
 	}
 )
 
@@ -253,6 +317,11 @@ var (
 		idColumn: goqu.T(performerTable).Col(idColumn),
 	}
 
+	performerImagesTableMgr = &table{
+		table:    goqu.T(performerImagesTable),
+		idColumn: goqu.T(performerImagesTable).Col(idColumn),
+	}
+
 	performersAliasesTableMgr = &stringTable{
 		table: table{
 			table:    performersAliasesJoinTable,
@@ -261,12 +330,14 @@ var (
 		stringColumn: performersAliasesJoinTable.Col(performerAliasColumn),
 	}
 
-	performersURLsTableMgr = &orderedValueTable[string]{
-		table: table{
-			table:    performersURLsJoinTable,
-			idColumn: performersURLsJoinTable.Col(performerIDColumn),
+	performersURLsTableMgr = &urlsTable{
+		orderedValueTable: orderedValueTable[string]{
+			table: table{
+				table:    performersURLsJoinTable,
+				idColumn: performersURLsJoinTable.Col(performerIDColumn),
+			},
+			valueColumn: performersURLsJoinTable.Col(performerURLColumn),
 		},
-		valueColumn: performersURLsJoinTable.Col(performerURLColumn),
 	}
 
 	performersTagsTableMgr = &joinTable{
@@ -313,6 +384,32 @@ var (
 			idColumn: studiosStashIDsJoinTable.Col(studioIDColumn),
 		},
 	}
+
+	studiosDefaultTagsTableMgr = &joinTable{
+		table: table{
+			table:    studiosDefaultTagsTable,
+			idColumn: studiosDefaultTagsTable.Col(studioIDColumn),
+		},
+		fkColumn: studiosDefaultTagsTable.Col(tagIDColumn),
+	}
+
+	studiosURLsTableMgr = &urlsTable{
+		orderedValueTable: orderedValueTable[string]{
+			table: table{
+				table:    studiosURLsJoinTable,
+				idColumn: studiosURLsJoinTable.Col(studioIDColumn),
+			},
+			valueColumn: studiosURLsJoinTable.Col(studioURLColumn),
+		},
+	}
+
+	studiosDefaultURLsTableMgr = &orderedValueTable[string]{
+		table: table{
+			table:    studiosDefaultURLsTable,
+			idColumn: studiosDefaultURLsTable.Col(studioIDColumn),
+		},
+		valueColumn: studiosDefaultURLsTable.Col("url"),
+	}
 )
 
 var (
@@ -383,4 +480,59 @@ var (
 		table:    goqu.T(savedFilterTable),
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
+
+	shareLinkTableMgr = &table{
+		table:    goqu.T(shareLinkTable),
+		idColumn: goqu.T(shareLinkTable).Col(idColumn),
+	}
+
+	urlStatusTableMgr = &table{
+		table:    goqu.T(urlStatusTable),
+		idColumn: goqu.T(urlStatusTable).Col(urlStatusURLColumn),
+	}
+
+	totpCredentialTableMgr = &table{
+		table:    goqu.T(totpCredentialTable),
+		idColumn: goqu.T(totpCredentialTable).Col(idColumn),
+	}
+
+	totpRecoveryCodeTableMgr = &table{
+		table:    goqu.T(totpRecoveryCodeTable),
+		idColumn: goqu.T(totpRecoveryCodeTable).Col(idColumn),
+	}
+
+	savedFilterFolderTableMgr = &table{
+		table:    goqu.T(savedFilterFolderTable),
+		idColumn: goqu.T(savedFilterFolderTable).Col(idColumn),
+	}
+
+	defaultFilterTableMgr = &table{
+		table:    goqu.T(defaultFilterTable),
+		idColumn: goqu.T(defaultFilterTable).Col(idColumn),
+	}
+
+	noteTableMgr = &table{
+		table:    goqu.T(noteTable),
+		idColumn: goqu.T(noteTable).Col(idColumn),
+	}
+
+	tagCategoryTableMgr = &table{
+		table:    goqu.T(tagCategoryTable),
+		idColumn: goqu.T(tagCategoryTable).Col(idColumn),
+	}
+
+	quarantinedFileTableMgr = &table{
+		table:    goqu.T(quarantinedFileTable),
+		idColumn: goqu.T(quarantinedFileTable).Col(idColumn),
+	}
+
+	sceneMarkerSuggestionTableMgr = &table{
+		table:    goqu.T(sceneMarkerSuggestionTable),
+		idColumn: goqu.T(sceneMarkerSuggestionTable).Col(idColumn),
+	}
+
+	imageRegionTableMgr = &table{
+		table:    goqu.T(imageRegionTable),
+		idColumn: goqu.T(imageRegionTable).Col(idColumn),
+	}
 )
@@ -31,13 +31,16 @@ const (
 )
 
 type tagRow struct {
-	ID            int         `db:"id" goqu:"skipinsert"`
-	Name          null.String `db:"name"` // TODO: make schema non-nullable
-	Favorite      bool        `db:"favorite"`
-	Description   zero.String `db:"description"`
-	IgnoreAutoTag bool        `db:"ignore_auto_tag"`
-	CreatedAt     Timestamp   `db:"created_at"`
-	UpdatedAt     Timestamp   `db:"updated_at"`
+	ID            int           `db:"id" goqu:"skipinsert"`
+	Name          null.String   `db:"name"` // TODO: make schema non-nullable
+	Favorite      bool          `db:"favorite"`
+	Description   zero.String   `db:"description"`
+	IgnoreAutoTag bool          `db:"ignore_auto_tag"`
+	Restricted    bool          `db:"restricted"`
+	CategoryID    null.Int      `db:"category_id,omitempty"`
+	CreatedAt     Timestamp     `db:"created_at"`
+	UpdatedAt     Timestamp     `db:"updated_at"`
+	FavoritedAt   NullTimestamp `db:"favorited_at"`
 
 	// not used in resolutions or updates
 	ImageBlob zero.String `db:"image_blob"`
@@ -49,8 +52,11 @@ func (r *tagRow) fromTag(o models.Tag) {
 	r.Favorite = o.Favorite
 	r.Description = zero.StringFrom(o.Description)
 	r.IgnoreAutoTag = o.IgnoreAutoTag
+	r.Restricted = o.Restricted
+	r.CategoryID = intFromPtr(o.CategoryID)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+	r.FavoritedAt = favoritedAtFrom(o.Favorite, o.FavoritedAt, o.UpdatedAt)
 }
 
 func (r *tagRow) resolve() *models.Tag {
@@ -60,8 +66,11 @@ func (r *tagRow) resolve() *models.Tag {
 		Favorite:      r.Favorite,
 		Description:   r.Description.String,
 		IgnoreAutoTag: r.IgnoreAutoTag,
+		Restricted:    r.Restricted,
+		CategoryID:    nullIntPtr(r.CategoryID),
 		CreatedAt:     r.CreatedAt.Timestamp,
 		UpdatedAt:     r.UpdatedAt.Timestamp,
+		FavoritedAt:   r.FavoritedAt.TimePtr(),
 	}
 
 	return ret
@@ -90,6 +99,9 @@ func (r *tagRowRecord) fromPartial(o models.TagPartial) {
 	r.setNullString("description", o.Description)
 	r.setBool("favorite", o.Favorite)
 	r.setBool("ignore_auto_tag", o.IgnoreAutoTag)
+	r.setBool("restricted", o.Restricted)
+	r.setNullInt("category_id", o.CategoryID)
+	r.setFavoritedAt(o.Favorite, o.FavoritedAt, o.UpdatedAt)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
 }
@@ -549,6 +561,44 @@ func (qb *TagStore) CountByChildTagID(ctx context.Context, childID int) (int, er
 	return count(ctx, q)
 }
 
+// tagUsageColumns are the tables and columns that reference tags directly.
+var tagUsageColumns = [][2]string{
+	{scenesTagsTable, tagIDColumn},
+	{"scene_markers_tags", tagIDColumn},
+	{sceneMarkerTable, "primary_tag_id"},
+	{imagesTagsTable, tagIDColumn},
+	{galleriesTagsTable, tagIDColumn},
+	{performersTagsTable, tagIDColumn},
+	{studiosTagsTable, tagIDColumn},
+	{groupsTagsTable, tagIDColumn},
+	{imageRegionTable, tagIDColumn},
+}
+
+func (qb *TagStore) UsageCounts(ctx context.Context) (map[int]int, error) {
+	var selects []string
+	for _, c := range tagUsageColumns {
+		selects = append(selects, fmt.Sprintf("SELECT %[2]s AS tag_id FROM %[1]s WHERE %[2]s IS NOT NULL", c[0], c[1]))
+	}
+
+	query := "SELECT tag_id, COUNT(*) FROM (" + strings.Join(selects, " UNION ALL ") + ") GROUP BY tag_id"
+
+	ret := make(map[int]int)
+	const single = false
+	if err := tagRepository.queryFunc(ctx, query, nil, single, func(rows *sqlx.Rows) error {
+		var id, n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return err
+		}
+
+		ret[id] = n
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (qb *TagStore) Count(ctx context.Context) (int, error) {
 	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
 	return count(ctx, q)
@@ -635,7 +685,9 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 }
 
 var tagSortOptions = sortOptions{
+	"category",
 	"created_at",
+	"favorited_at",
 	"galleries_count",
 	"groups_count",
 	"id",
@@ -686,6 +738,10 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 		sortQuery += getCountSort(tagTable, studiosTagsTable, tagIDColumn, direction)
 	case "movies_count", "groups_count":
 		sortQuery += getCountSort(tagTable, groupsTagsTable, tagIDColumn, direction)
+	case "category":
+		// categories are ordered by their sort order, then name. Uncategorised tags are always last.
+		dir := getSortDirection(direction)
+		sortQuery += fmt.Sprintf(" ORDER BY tags.category_id IS NULL ASC, (SELECT sort_order FROM %[1]s WHERE %[1]s.id = tags.category_id) %[2]s, (SELECT name FROM %[1]s WHERE %[1]s.id = tags.category_id) COLLATE NATURAL_CI %[2]s", tagCategoryTable, dir)
 	default:
 		sortQuery += getSort(sort, direction, "tags")
 	}
@@ -778,12 +834,13 @@ func (qb *TagStore) Merge(ctx context.Context, source []int, destination int) er
 	args = append(args, srcArgs...)
 
 	tagTables := map[string]string{
-		scenesTagsTable:      sceneIDColumn,
-		"scene_markers_tags": "scene_marker_id",
-		galleriesTagsTable:   galleryIDColumn,
-		imagesTagsTable:      imageIDColumn,
-		"performers_tags":    "performer_id",
-		"studios_tags":       "studio_id",
+		scenesTagsTable:       sceneIDColumn,
+		"scene_markers_tags":  "scene_marker_id",
+		galleriesTagsTable:    galleryIDColumn,
+		imagesTagsTable:       imageIDColumn,
+		"performers_tags":     "performer_id",
+		"studios_tags":        "studio_id",
+		"studio_default_tags": "studio_id",
 	}
 
 	args = append(args, destination)
@@ -934,3 +991,7 @@ func (s *tagRelationshipStore) CountByTagID(ctx context.Context, tagID int) (int
 func (s *tagRelationshipStore) GetTagIDs(ctx context.Context, id int) ([]int, error) {
 	return s.joinTable.get(ctx, id)
 }
+
+func (s *tagRelationshipStore) GetManyTagIDs(ctx context.Context, ids []int) ([][]int, error) {
+	return s.joinTable.getMany(ctx, ids)
+}
@@ -0,0 +1,241 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	tagCategoryTable    = "tag_categories"
+	tagCategoryIDColumn = "category_id"
+)
+
+type tagCategoryRow struct {
+	ID          int         `db:"id" goqu:"skipinsert"`
+	Name        string      `db:"name"`
+	Description zero.String `db:"description"`
+	SortOrder   int         `db:"sort_order"`
+	CreatedAt   Timestamp   `db:"created_at"`
+	UpdatedAt   Timestamp   `db:"updated_at"`
+}
+
+func (r *tagCategoryRow) fromTagCategory(o models.TagCategory) {
+	r.ID = o.ID
+	r.Name = o.Name
+	r.Description = zero.StringFrom(o.Description)
+	r.SortOrder = o.SortOrder
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *tagCategoryRow) resolve() *models.TagCategory {
+	ret := &models.TagCategory{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description.String,
+		SortOrder:   r.SortOrder,
+		CreatedAt:   r.CreatedAt.Timestamp,
+		UpdatedAt:   r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type tagCategoryRowRecord struct {
+	updateRecord
+}
+
+func (r *tagCategoryRowRecord) fromPartial(o models.TagCategoryPartial) {
+	r.setString("name", o.Name)
+	r.setNullString("description", o.Description)
+	r.setInt("sort_order", o.SortOrder)
+	r.setTimestamp("created_at", o.CreatedAt)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type TagCategoryStore struct {
+	repository
+
+	tableMgr *table
+}
+
+func NewTagCategoryStore() *TagCategoryStore {
+	return &TagCategoryStore{
+		repository: repository{
+			tableName: tagCategoryTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: tagCategoryTableMgr,
+	}
+}
+
+func (qb *TagCategoryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *TagCategoryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *TagCategoryStore) Create(ctx context.Context, newObject *models.TagCategory) error {
+	var r tagCategoryRow
+	r.fromTagCategory(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *TagCategoryStore) UpdatePartial(ctx context.Context, id int, partial models.TagCategoryPartial) (*models.TagCategory, error) {
+	r := tagCategoryRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+// Destroy removes the category. Tags in the category become uncategorised.
+func (qb *TagCategoryStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *TagCategoryStore) Find(ctx context.Context, id int) (*models.TagCategory, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *TagCategoryStore) FindMany(ctx context.Context, ids []int) ([]*models.TagCategory, error) {
+	ret := make([]*models.TagCategory, len(ids))
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		unsorted, err := qb.getMany(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range unsorted {
+			i := slices.Index(ids, s.ID)
+			ret[i] = s
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("tag category with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *TagCategoryStore) find(ctx context.Context, id int) (*models.TagCategory, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *TagCategoryStore) FindByName(ctx context.Context, name string) (*models.TagCategory, error) {
+	q := qb.selectDataset().Prepared(true).Where(goqu.L("name = ? COLLATE NOCASE", name)).Limit(1)
+	ret, err := qb.get(ctx, q)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *TagCategoryStore) All(ctx context.Context) ([]*models.TagCategory, error) {
+	table := qb.table()
+
+	return qb.getMany(ctx, qb.selectDataset().Order(
+		table.Col("sort_order").Asc(),
+		goqu.L("name COLLATE NATURAL_CI").Asc(),
+		table.Col(idColumn).Asc(),
+	))
+}
+
+func (qb *TagCategoryStore) CountTags(ctx context.Context, id int) (int, error) {
+	q := dialect.Select(goqu.COUNT("*")).From(tagTableMgr.table).Where(tagTableMgr.table.Col(tagCategoryIDColumn).Eq(id))
+	return count(ctx, q)
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *TagCategoryStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.TagCategory, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *TagCategoryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.TagCategory, error) {
+	const single = false
+	var ret []*models.TagCategory
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f tagCategoryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		s := f.resolve()
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -67,6 +67,8 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		boolCriterionHandler(tagFilter.Favorite, tagTable+".favorite", nil),
 		stringCriterionHandler(tagFilter.Description, tagTable+".description"),
 		boolCriterionHandler(tagFilter.IgnoreAutoTag, tagTable+".ignore_auto_tag", nil),
+		boolCriterionHandler(tagFilter.Restricted, tagTable+".restricted", nil),
+		qb.categoryCriterionHandler(tagFilter.Category),
 
 		qb.isMissingCriterionHandler(tagFilter.IsMissing),
 		qb.sceneCountCriterionHandler(tagFilter.SceneCount),
@@ -85,6 +87,7 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		tagHierarchyHandler.ChildCountCriterionHandler(tagFilter.ChildCount),
 		&timestampCriterionHandler{tagFilter.CreatedAt, "tags.created_at", nil},
 		&timestampCriterionHandler{tagFilter.UpdatedAt, "tags.updated_at", nil},
+		&timestampCriterionHandler{tagFilter.FavoritedAt, "tags.favorited_at", nil},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_tags.scene_id",
@@ -129,6 +132,21 @@ func (qb *tagFilterHandler) aliasCriterionHandler(alias *models.StringCriterionI
 	return h.handler(alias)
 }
 
+func (qb *tagFilterHandler) categoryCriterionHandler(categories *models.MultiCriterionInput) criterionHandlerFunc {
+	addJoinsFunc := func(f *filterBuilder) {
+		f.addLeftJoin(tagCategoryTable, "tag_category", "tag_category.id = tags.category_id")
+	}
+	h := multiCriterionHandlerBuilder{
+		primaryTable: tagTable,
+		foreignTable: "tag_category",
+		joinTable:    "",
+		primaryFK:    tagIDColumn,
+		foreignFK:    tagCategoryIDColumn,
+		addJoinsFunc: addJoinsFunc,
+	}
+	return h.handler(categories)
+}
+
 func (qb *tagFilterHandler) isMissingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -0,0 +1,247 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	totpCredentialTable    = "totp_credentials"
+	totpRecoveryCodeTable  = "totp_recovery_codes"
+	totpCredentialIDColumn = "credential_id"
+)
+
+type totpCredentialRow struct {
+	ID             int       `db:"id" goqu:"skipinsert"`
+	Username       string    `db:"username"`
+	Secret         string    `db:"secret"`
+	Enabled        bool      `db:"enabled"`
+	LastUsedStep   int64     `db:"last_used_step"`
+	SessionVersion int       `db:"session_version"`
+	CreatedAt      Timestamp `db:"created_at"`
+	UpdatedAt      Timestamp `db:"updated_at"`
+}
+
+func (r *totpCredentialRow) fromTOTPCredential(o models.TOTPCredential) {
+	r.ID = o.ID
+	r.Username = o.Username
+	r.Secret = o.Secret
+	r.Enabled = o.Enabled
+	r.LastUsedStep = o.LastUsedStep
+	r.SessionVersion = o.SessionVersion
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *totpCredentialRow) resolve() *models.TOTPCredential {
+	ret := &models.TOTPCredential{
+		ID:             r.ID,
+		Username:       r.Username,
+		Secret:         r.Secret,
+		Enabled:        r.Enabled,
+		LastUsedStep:   r.LastUsedStep,
+		SessionVersion: r.SessionVersion,
+		CreatedAt:      r.CreatedAt.Timestamp,
+		UpdatedAt:      r.UpdatedAt.Timestamp,
+	}
+
+	return ret
+}
+
+type totpRecoveryCodeRow struct {
+	ID           int           `db:"id" goqu:"skipinsert"`
+	CredentialID int           `db:"credential_id"`
+	CodeHash     string        `db:"code_hash"`
+	UsedAt       NullTimestamp `db:"used_at"`
+}
+
+func (r *totpRecoveryCodeRow) resolve() *models.TOTPRecoveryCode {
+	return &models.TOTPRecoveryCode{
+		ID:           r.ID,
+		CredentialID: r.CredentialID,
+		CodeHash:     r.CodeHash,
+		UsedAt:       r.UsedAt.TimePtr(),
+	}
+}
+
+type TOTPStore struct {
+	repository
+
+	tableMgr             *table
+	recoveryCodeTableMgr *table
+}
+
+func NewTOTPStore() *TOTPStore {
+	return &TOTPStore{
+		repository: repository{
+			tableName: totpCredentialTable,
+			idColumn:  idColumn,
+		},
+		tableMgr:             totpCredentialTableMgr,
+		recoveryCodeTableMgr: totpRecoveryCodeTableMgr,
+	}
+}
+
+func (qb *TOTPStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *TOTPStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *TOTPStore) Create(ctx context.Context, newObject *models.TOTPCredential) error {
+	var r totpCredentialRow
+	r.fromTOTPCredential(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *TOTPStore) Update(ctx context.Context, updatedObject *models.TOTPCredential) error {
+	var r totpCredentialRow
+	r.fromTOTPCredential(*updatedObject)
+
+	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (qb *TOTPStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *TOTPStore) find(ctx context.Context, id int) (*models.TOTPCredential, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found
+func (qb *TOTPStore) FindByUsername(ctx context.Context, username string) (*models.TOTPCredential, error) {
+	q := qb.selectDataset().Prepared(true).Where(qb.table().Col("username").Eq(username))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *TOTPStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.TOTPCredential, error) {
+	const single = true
+	var ret *models.TOTPCredential
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f totpCredentialRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = f.resolve()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret, nil
+}
+
+func (qb *TOTPStore) GetRecoveryCodes(ctx context.Context, credentialID int) ([]*models.TOTPRecoveryCode, error) {
+	table := qb.recoveryCodeTableMgr.table
+	q := dialect.From(table).Select(table.All()).Prepared(true).
+		Where(table.Col(totpCredentialIDColumn).Eq(credentialID)).
+		Order(table.Col(idColumn).Asc())
+
+	const single = false
+	var ret []*models.TOTPRecoveryCode
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f totpRecoveryCodeRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *TOTPStore) ReplaceRecoveryCodes(ctx context.Context, credentialID int, codeHashes []string) error {
+	table := qb.recoveryCodeTableMgr.table
+	q := dialect.Delete(table).Where(table.Col(totpCredentialIDColumn).Eq(credentialID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying %s: %w", table.GetTable(), err)
+	}
+
+	for _, h := range codeHashes {
+		r := totpRecoveryCodeRow{
+			CredentialID: credentialID,
+			CodeHash:     h,
+		}
+
+		if _, err := qb.recoveryCodeTableMgr.insert(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *TOTPStore) UseRecoveryCode(ctx context.Context, credentialID int, codeHash string) (bool, error) {
+	table := qb.recoveryCodeTableMgr.table
+	q := dialect.Update(table).Prepared(true).
+		Set(goqu.Record{"used_at": Timestamp{Timestamp: time.Now()}}).
+		Where(
+			table.Col(totpCredentialIDColumn).Eq(credentialID),
+			table.Col("code_hash").Eq(codeHash),
+			table.Col("used_at").IsNull(),
+		)
+
+	result, err := exec(ctx, q)
+	if err != nil {
+		return false, fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	ra, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return ra > 0, nil
+}
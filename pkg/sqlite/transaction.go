@@ -117,19 +117,30 @@ func (db *Database) IsLocked(err error) bool {
 
 func (db *Database) Repository() models.Repository {
 	return models.Repository{
-		TxnManager:     db,
-		Blob:           db.Blobs,
-		File:           db.File,
-		Folder:         db.Folder,
-		Gallery:        db.Gallery,
-		GalleryChapter: db.GalleryChapter,
-		Image:          db.Image,
-		Group:          db.Group,
-		Performer:      db.Performer,
-		Scene:          db.Scene,
-		SceneMarker:    db.SceneMarker,
-		Studio:         db.Studio,
-		Tag:            db.Tag,
-		SavedFilter:    db.SavedFilter,
+		TxnManager:        db,
+		Blob:              db.Blobs,
+		File:              db.File,
+		Folder:            db.Folder,
+		Quarantine:        db.Quarantine,
+		Gallery:           db.Gallery,
+		GalleryChapter:    db.GalleryChapter,
+		Image:             db.Image,
+		ImageRegion:       db.ImageRegion,
+		Group:             db.Group,
+		Performer:         db.Performer,
+		Scene:             db.Scene,
+		SceneMarker:       db.SceneMarker,
+		Suggestion:        db.Suggestion,
+		Studio:            db.Studio,
+		Tag:               db.Tag,
+		TagCategory:       db.TagCategory,
+		SavedFilter:       db.SavedFilter,
+		SavedFilterFolder: db.SavedFilterFolder,
+		DefaultFilter:     db.DefaultFilter,
+		ShareLink:         db.ShareLink,
+		Note:              db.Note,
+		TOTP:              db.TOTP,
+		Sync:              db.Sync,
+		URLStatus:         db.URLStatus,
 	}
 }
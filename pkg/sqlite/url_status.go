@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	urlStatusTable     = "url_statuses"
+	urlStatusURLColumn = "url"
+)
+
+type urlStatusRow struct {
+	URL         string        `db:"url"`
+	Status      string        `db:"status"`
+	StatusCode  null.Int      `db:"status_code"`
+	RedirectURL zero.String   `db:"redirect_url"`
+	ContentHash zero.String   `db:"content_hash"`
+	Error       zero.String   `db:"error"`
+	CheckedAt   Timestamp     `db:"checked_at"`
+	ChangedAt   NullTimestamp `db:"changed_at"`
+}
+
+func (r *urlStatusRow) resolve() *models.URLStatus {
+	return &models.URLStatus{
+		URL:         r.URL,
+		Status:      models.URLCheckStatus(r.Status),
+		StatusCode:  nullIntPtr(r.StatusCode),
+		RedirectURL: r.RedirectURL.String,
+		ContentHash: r.ContentHash.String,
+		Error:       r.Error.String,
+		CheckedAt:   r.CheckedAt.Timestamp,
+		ChangedAt:   r.ChangedAt.TimePtr(),
+	}
+}
+
+type URLStatusStore struct{}
+
+func NewURLStatusStore() *URLStatusStore {
+	return &URLStatusStore{}
+}
+
+func (qb *URLStatusStore) table() exp.IdentifierExpression {
+	return urlStatusTableMgr.table
+}
+
+// FindByURLs returns the statuses of the urls that have been checked.
+func (qb *URLStatusStore) FindByURLs(ctx context.Context, urls []string) ([]*models.URLStatus, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	table := qb.table()
+	q := dialect.From(table).Select(table.All()).Prepared(true).
+		Where(table.Col(urlStatusURLColumn).In(urls))
+
+	const single = false
+	var ret []*models.URLStatus
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var row urlStatusRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting url statuses: %w", err)
+	}
+
+	return ret, nil
+}
+
+// usedURLsDataset returns the distinct urls of all scenes and performers.
+func usedURLsDataset() *goqu.SelectDataset {
+	return dialect.From(scenesURLsJoinTable).Select(scenesURLsJoinTable.Col(sceneURLColumn).As(urlStatusURLColumn)).
+		Union(dialect.From(performersURLsJoinTable).Select(performersURLsJoinTable.Col(performerURLColumn)))
+}
+
+// FindDue returns the scene and performer urls that have not been checked
+// since checkedBefore. Urls that have never been checked are returned first,
+// followed by the least recently checked.
+func (qb *URLStatusStore) FindDue(ctx context.Context, checkedBefore time.Time) ([]string, error) {
+	table := qb.table()
+	used := goqu.T("used_urls")
+	q := dialect.From(usedURLsDataset().As("used_urls")).Select(used.Col(urlStatusURLColumn)).Prepared(true).
+		LeftJoin(table, goqu.On(table.Col(urlStatusURLColumn).Eq(used.Col(urlStatusURLColumn)))).
+		Where(goqu.Or(
+			table.Col("checked_at").IsNull(),
+			table.Col("checked_at").Lt(Timestamp{Timestamp: checkedBefore}),
+		)).
+		Order(table.Col("checked_at").Asc().NullsFirst(), used.Col(urlStatusURLColumn).Asc())
+
+	const single = false
+	var ret []string
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var url string
+		if err := r.Scan(&url); err != nil {
+			return err
+		}
+
+		// urls are stored trimmed, but may be empty
+		if url != "" {
+			ret = append(ret, url)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding due urls: %w", err)
+	}
+
+	return ret, nil
+}
+
+// Upsert creates or replaces the status of the url.
+func (qb *URLStatusStore) Upsert(ctx context.Context, status *models.URLStatus) error {
+	q := dialect.Insert(qb.table()).Prepared(true).Rows(goqu.Record{
+		urlStatusURLColumn: status.URL,
+		"status":           status.Status.String(),
+		"status_code":      intFromPtr(status.StatusCode),
+		"redirect_url":     zero.StringFrom(status.RedirectURL),
+		"content_hash":     zero.StringFrom(status.ContentHash),
+		"error":            zero.StringFrom(status.Error),
+		"checked_at":       Timestamp{Timestamp: status.CheckedAt},
+		"changed_at":       NullTimestampFromTimePtr(status.ChangedAt),
+	}).OnConflict(goqu.DoUpdate(urlStatusURLColumn, goqu.Record{
+		"status":       goqu.I("excluded.status"),
+		"status_code":  goqu.I("excluded.status_code"),
+		"redirect_url": goqu.I("excluded.redirect_url"),
+		"content_hash": goqu.I("excluded.content_hash"),
+		"error":        goqu.I("excluded.error"),
+		"checked_at":   goqu.I("excluded.checked_at"),
+		"changed_at":   goqu.I("excluded.changed_at"),
+	}))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting url status: %w", err)
+	}
+
+	return nil
+}
+
+// DestroyUnused removes the statuses of urls that no longer belong to a scene
+// or performer.
+func (qb *URLStatusStore) DestroyUnused(ctx context.Context) error {
+	q := dialect.Delete(qb.table()).Where(qb.table().Col(urlStatusURLColumn).NotIn(usedURLsDataset()))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("removing unused url statuses: %w", err)
+	}
+
+	return nil
+}
+
+// urlStatusCriterionHandler filters objects by the statuses of their urls.
+// The includes and equals modifiers match objects with a url with one of the
+// statuses. The excludes and not equals modifiers match objects without such
+// a url.
+func urlStatusCriterionHandler(c *models.URLCheckStatusCriterionInput, urlsTable string, idColumn string, parentIDCol string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil || len(c.Value) == 0 {
+			return
+		}
+
+		statuses := utils.StringerSliceToStringSlice(c.Value)
+		matching := fmt.Sprintf("SELECT %[1]s.%[2]s FROM %[1]s INNER JOIN %[3]s ON %[3]s.url = %[1]s.url WHERE %[3]s.status IN %[4]s",
+			urlsTable, idColumn, urlStatusTable, getInBinding(len(statuses)))
+
+		args := make([]interface{}, len(statuses))
+		for i, s := range statuses {
+			args[i] = s
+		}
+
+		switch c.Modifier {
+		case models.CriterionModifierIncludes, models.CriterionModifierEquals:
+			f.addWhere(fmt.Sprintf("%s IN (%s)", parentIDCol, matching), args...)
+		case models.CriterionModifierExcludes, models.CriterionModifierNotEquals:
+			f.addWhere(fmt.Sprintf("%s NOT IN (%s)", parentIDCol, matching), args...)
+		default:
+			f.setError(fmt.Errorf("unsupported url status modifier: %s", c.Modifier))
+		}
+	}
+}
@@ -0,0 +1,178 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLStatusStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		const (
+			deadURL      = "https://example.com/url-status/dead"
+			okURL        = "https://example.com/url-status/ok"
+			uncheckedURL = "https://example.com/url-status/unchecked"
+			unusedURL    = "https://example.com/url-status/unused"
+		)
+
+		now := time.Now()
+		deadScene := &models.Scene{
+			Title: "url status dead",
+			URLs:  models.NewRelatedStrings([]string{deadURL, uncheckedURL}),
+		}
+		okScene := &models.Scene{
+			Title: "url status ok",
+			URLs:  models.NewRelatedStrings([]string{okURL}),
+		}
+		for _, s := range []*models.Scene{deadScene, okScene} {
+			if err := db.Scene.Create(ctx, s, nil); err != nil {
+				t.Errorf("Error creating scene: %v", err)
+				return nil
+			}
+		}
+
+		notFound := http.StatusNotFound
+		statuses := []*models.URLStatus{
+			{URL: deadURL, Status: models.URLCheckStatusDead, StatusCode: &notFound, CheckedAt: now.Add(-2 * time.Hour)},
+			{URL: okURL, Status: models.URLCheckStatusOK, ContentHash: "hash", CheckedAt: now},
+			{URL: unusedURL, Status: models.URLCheckStatusOK, CheckedAt: now},
+		}
+		for _, s := range statuses {
+			if err := db.URLStatus.Upsert(ctx, s); err != nil {
+				t.Errorf("URLStatusStore.Upsert() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err := db.URLStatus.FindByURLs(ctx, []string{deadURL, okURL, uncheckedURL})
+		if err != nil {
+			t.Errorf("URLStatusStore.FindByURLs() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, got, 2) {
+			byURL := make(map[string]*models.URLStatus)
+			for _, s := range got {
+				byURL[s.URL] = s
+			}
+			assert.Equal(t, models.URLCheckStatusDead, byURL[deadURL].Status)
+			assert.Equal(t, &notFound, byURL[deadURL].StatusCode)
+			assert.Equal(t, "hash", byURL[okURL].ContentHash)
+			assert.Nil(t, byURL[okURL].StatusCode)
+		}
+
+		due, err := db.URLStatus.FindDue(ctx, now.Add(-time.Hour))
+		if err != nil {
+			t.Errorf("URLStatusStore.FindDue() error = %v", err)
+			return nil
+		}
+		assert.Contains(t, due, deadURL)
+		assert.Contains(t, due, uncheckedURL)
+		assert.NotContains(t, due, okURL)
+		assert.NotContains(t, due, unusedURL)
+		// unchecked urls are returned first
+		assert.Less(t, slices.Index(due, uncheckedURL), slices.Index(due, deadURL))
+
+		// the status of the url is replaced
+		if err := db.URLStatus.Upsert(ctx, &models.URLStatus{URL: deadURL, Status: models.URLCheckStatusError, Error: "timeout", CheckedAt: now}); err != nil {
+			t.Errorf("URLStatusStore.Upsert() error = %v", err)
+			return nil
+		}
+		got, err = db.URLStatus.FindByURLs(ctx, []string{deadURL})
+		if err != nil {
+			t.Errorf("URLStatusStore.FindByURLs() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, models.URLCheckStatusError, got[0].Status)
+			assert.Equal(t, "timeout", got[0].Error)
+			assert.Nil(t, got[0].StatusCode)
+		}
+
+		if err := db.URLStatus.DestroyUnused(ctx); err != nil {
+			t.Errorf("URLStatusStore.DestroyUnused() error = %v", err)
+			return nil
+		}
+		got, err = db.URLStatus.FindByURLs(ctx, []string{okURL, unusedURL})
+		if err != nil {
+			t.Errorf("URLStatusStore.FindByURLs() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, okURL, got[0].URL)
+		}
+
+		return nil
+	})
+}
+
+func TestSceneQueryURLStatus(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		const (
+			deadURL = "https://example.com/url-status-filter/dead"
+			okURL   = "https://example.com/url-status-filter/ok"
+		)
+
+		deadScene := &models.Scene{
+			Title: "url status filter dead",
+			URLs:  models.NewRelatedStrings([]string{deadURL, okURL}),
+		}
+		okScene := &models.Scene{
+			Title: "url status filter ok",
+			URLs:  models.NewRelatedStrings([]string{okURL}),
+		}
+		for _, s := range []*models.Scene{deadScene, okScene} {
+			if err := db.Scene.Create(ctx, s, nil); err != nil {
+				t.Errorf("Error creating scene: %v", err)
+				return nil
+			}
+		}
+
+		now := time.Now()
+		for url, status := range map[string]models.URLCheckStatus{
+			deadURL: models.URLCheckStatusDead,
+			okURL:   models.URLCheckStatusOK,
+		} {
+			if err := db.URLStatus.Upsert(ctx, &models.URLStatus{URL: url, Status: status, CheckedAt: now}); err != nil {
+				t.Errorf("URLStatusStore.Upsert() error = %v", err)
+				return nil
+			}
+		}
+
+		query := func(modifier models.CriterionModifier) []int {
+			result, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: models.BatchFindFilter(-1),
+				},
+				SceneFilter: &models.SceneFilterType{
+					URLStatus: &models.URLCheckStatusCriterionInput{
+						Value:    []models.URLCheckStatus{models.URLCheckStatusDead},
+						Modifier: modifier,
+					},
+				},
+			})
+			if err != nil {
+				t.Errorf("SceneStore.Query() error = %v", err)
+				return nil
+			}
+			return result.IDs
+		}
+
+		includes := query(models.CriterionModifierIncludes)
+		assert.Contains(t, includes, deadScene.ID)
+		assert.NotContains(t, includes, okScene.ID)
+
+		excludes := query(models.CriterionModifierExcludes)
+		assert.NotContains(t, excludes, deadScene.ID)
+		assert.Contains(t, excludes, okScene.ID)
+
+		return nil
+	})
+}
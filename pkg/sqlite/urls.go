@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const urlTypeColumn = "type"
+
+// urlsTable is an ordered table of URLs with an optional type per URL. The
+// types of URLs are kept when the URLs are replaced or modified.
+type urlsTable struct {
+	orderedValueTable[string]
+}
+
+func (t *urlsTable) typeColumn() exp.IdentifierExpression {
+	return t.table.table.Col(urlTypeColumn)
+}
+
+type typedURLRow struct {
+	URL  string      `db:"url"`
+	Type zero.String `db:"type"`
+}
+
+func (r typedURLRow) resolve() models.TypedURL {
+	ret := models.TypedURL{
+		URL: r.URL,
+	}
+	if r.Type.Valid && r.Type.String != "" {
+		t := models.URLType(r.Type.String)
+		ret.Type = &t
+	}
+
+	return ret
+}
+
+func (t *urlsTable) getTyped(ctx context.Context, id int) ([]models.TypedURL, error) {
+	q := dialect.Select(t.valueColumn.As("url"), t.typeColumn().As("type")).From(t.table.table).
+		Where(t.idColumn.Eq(id)).Order(t.positionColumn().Asc())
+
+	const single = false
+	var ret []models.TypedURL
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v typedURLRow
+		if err := rows.StructScan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, v.resolve())
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting urls from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+// getTypes returns the types of the URLs keyed by URL. URLs without a type
+// are not included.
+func (t *urlsTable) getTypes(ctx context.Context, id int) (map[string]*models.URLType, error) {
+	urls, err := t.getTyped(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*models.URLType)
+	for _, u := range urls {
+		if u.Type != nil {
+			ret[u.URL] = u.Type
+		}
+	}
+
+	return ret, nil
+}
+
+// setTypes sets the types of the URLs of the object. URLs that the object
+// does not have are ignored.
+func (t *urlsTable) setTypes(ctx context.Context, id int, types map[string]*models.URLType) error {
+	for url, ty := range types {
+		var v zero.String
+		if ty != nil {
+			if !ty.IsValid() {
+				return fmt.Errorf("invalid url type: %s", ty)
+			}
+			v = zero.StringFrom(ty.String())
+		}
+
+		q := dialect.Update(t.table.table).Prepared(true).Set(goqu.Record{urlTypeColumn: v}).
+			Where(t.idColumn.Eq(id), t.valueColumn.Eq(url))
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("setting url type in %s: %w", t.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *urlsTable) replaceJoins(ctx context.Context, id int, v []string) error {
+	types, err := t.getTypes(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := t.orderedValueTable.replaceJoins(ctx, id, v); err != nil {
+		return err
+	}
+
+	return t.setTypes(ctx, id, types)
+}
+
+func (t *urlsTable) destroyJoins(ctx context.Context, id int, v []string) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting existing %s: %w", t.table.table.GetTable(), err)
+	}
+
+	newValue := sliceutil.Exclude(existing, v)
+	if len(newValue) == len(existing) {
+		return nil
+	}
+
+	return t.replaceJoins(ctx, id, newValue)
+}
+
+func (t *urlsTable) modifyJoins(ctx context.Context, id int, v []string, mode models.RelationshipUpdateMode) error {
+	switch mode {
+	case models.RelationshipUpdateModeSet:
+		return t.replaceJoins(ctx, id, v)
+	case models.RelationshipUpdateModeAdd:
+		return t.addJoins(ctx, id, v)
+	case models.RelationshipUpdateModeRemove:
+		return t.destroyJoins(ctx, id, v)
+	}
+
+	return nil
+}
+
+// typedURLCriterionHandler filters objects by their urls of a type. The
+// negated modifiers match objects without a matching url of the type. The
+// is null modifier matches objects without a url of the type.
+func typedURLCriterionHandler(c *models.TypedURLCriterionInput, urlsTable string, idColumn string, parentIDCol string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		if !c.Type.IsValid() {
+			f.setError(fmt.Errorf("invalid url type: %s", c.Type))
+			return
+		}
+
+		var (
+			urlClause string
+			args      = []interface{}{c.Type.String()}
+			not       bool
+		)
+
+		switch c.Modifier {
+		case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+			urlClause = " AND url LIKE ?"
+			args = append(args, "%"+c.Value+"%")
+			not = c.Modifier == models.CriterionModifierExcludes
+		case models.CriterionModifierEquals, models.CriterionModifierNotEquals:
+			urlClause = " AND url LIKE ?"
+			args = append(args, c.Value)
+			not = c.Modifier == models.CriterionModifierNotEquals
+		case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+			if _, err := regexp.Compile(c.Value); err != nil {
+				f.setError(err)
+				return
+			}
+			urlClause = " AND url regexp ?"
+			args = append(args, c.Value)
+			not = c.Modifier == models.CriterionModifierNotMatchesRegex
+		case models.CriterionModifierNotNull:
+		case models.CriterionModifierIsNull:
+			not = true
+		default:
+			f.setError(fmt.Errorf("unsupported typed url modifier: %s", c.Modifier))
+			return
+		}
+
+		matching := fmt.Sprintf("SELECT %s FROM %s WHERE type = ?%s", idColumn, urlsTable, urlClause)
+
+		in := "IN"
+		if not {
+			in = "NOT IN"
+		}
+
+		f.addWhere(fmt.Sprintf("%s %s (%s)", parentIDCol, in, matching), args...)
+	}
+}
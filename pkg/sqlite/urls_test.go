@@ -0,0 +1,255 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func urlTypePtr(t models.URLType) *models.URLType {
+	return &t
+}
+
+func TestTypedURLs(t *testing.T) {
+	const (
+		official = "https://studio.example/scene/1"
+		social   = "https://social.example/post/1"
+		untyped  = "https://other.example/1"
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		scene := models.Scene{
+			URLs: models.NewRelatedStrings([]string{official, social, untyped}),
+		}
+		if err := qb.Create(ctx, &scene, nil); err != nil {
+			t.Errorf("Create error = %v", err)
+			return nil
+		}
+
+		if err := qb.SetURLTypes(ctx, scene.ID, map[string]*models.URLType{
+			official: urlTypePtr(models.URLTypeOfficial),
+			social:   urlTypePtr(models.URLTypeSocial),
+		}); err != nil {
+			t.Errorf("SetURLTypes error = %v", err)
+			return nil
+		}
+
+		got, err := qb.GetTypedURLs(ctx, scene.ID)
+		if err != nil {
+			t.Errorf("GetTypedURLs error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []models.TypedURL{
+			{URL: official, Type: urlTypePtr(models.URLTypeOfficial)},
+			{URL: social, Type: urlTypePtr(models.URLTypeSocial)},
+			{URL: untyped},
+		}, got)
+
+		// types are kept when the urls are reordered or removed
+		if _, err := qb.UpdatePartial(ctx, scene.ID, models.ScenePartial{
+			URLs: &models.UpdateStrings{
+				Values: []string{social, official},
+				Mode:   models.RelationshipUpdateModeSet,
+			},
+		}); err != nil {
+			t.Errorf("UpdatePartial error = %v", err)
+			return nil
+		}
+
+		got, err = qb.GetTypedURLs(ctx, scene.ID)
+		if err != nil {
+			t.Errorf("GetTypedURLs error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []models.TypedURL{
+			{URL: social, Type: urlTypePtr(models.URLTypeSocial)},
+			{URL: official, Type: urlTypePtr(models.URLTypeOfficial)},
+		}, got)
+
+		if _, err := qb.UpdatePartial(ctx, scene.ID, models.ScenePartial{
+			URLs: &models.UpdateStrings{
+				Values: []string{social},
+				Mode:   models.RelationshipUpdateModeRemove,
+			},
+		}); err != nil {
+			t.Errorf("UpdatePartial error = %v", err)
+			return nil
+		}
+
+		got, err = qb.GetTypedURLs(ctx, scene.ID)
+		if err != nil {
+			t.Errorf("GetTypedURLs error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []models.TypedURL{
+			{URL: official, Type: urlTypePtr(models.URLTypeOfficial)},
+		}, got)
+
+		// invalid types are rejected
+		err = qb.SetURLTypes(ctx, scene.ID, map[string]*models.URLType{
+			official: urlTypePtr("INVALID"),
+		})
+		assert.Error(t, err)
+
+		return nil
+	})
+}
+
+func TestTypedURLCriterion(t *testing.T) {
+	const (
+		official = "https://studio.example/scene/typed"
+		social   = "https://social.example/post/typed"
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		scene := models.Scene{
+			URLs: models.NewRelatedStrings([]string{official, social}),
+		}
+		if err := qb.Create(ctx, &scene, nil); err != nil {
+			t.Errorf("Create error = %v", err)
+			return nil
+		}
+
+		if err := qb.SetURLTypes(ctx, scene.ID, map[string]*models.URLType{
+			official: urlTypePtr(models.URLTypeOfficial),
+			social:   urlTypePtr(models.URLTypeSocial),
+		}); err != nil {
+			t.Errorf("SetURLTypes error = %v", err)
+			return nil
+		}
+
+		tests := []struct {
+			name      string
+			criterion models.TypedURLCriterionInput
+			want      bool
+		}{
+			{
+				"includes official",
+				models.TypedURLCriterionInput{Type: models.URLTypeOfficial, Value: "studio.example", Modifier: models.CriterionModifierIncludes},
+				true,
+			},
+			{
+				"includes wrong type",
+				models.TypedURLCriterionInput{Type: models.URLTypeSocial, Value: "studio.example", Modifier: models.CriterionModifierIncludes},
+				false,
+			},
+			{
+				"excludes official",
+				models.TypedURLCriterionInput{Type: models.URLTypeOfficial, Value: "studio.example", Modifier: models.CriterionModifierExcludes},
+				false,
+			},
+			{
+				"equals social",
+				models.TypedURLCriterionInput{Type: models.URLTypeSocial, Value: social, Modifier: models.CriterionModifierEquals},
+				true,
+			},
+			{
+				"matches regex",
+				models.TypedURLCriterionInput{Type: models.URLTypeOfficial, Value: `scene/typ.d$`, Modifier: models.CriterionModifierMatchesRegex},
+				true,
+			},
+			{
+				"store not null",
+				models.TypedURLCriterionInput{Type: models.URLTypeStore, Modifier: models.CriterionModifierNotNull},
+				false,
+			},
+			{
+				"store is null",
+				models.TypedURLCriterionInput{Type: models.URLTypeStore, Modifier: models.CriterionModifierIsNull},
+				true,
+			},
+		}
+
+		// needed so that we don't hit the default limit of 25 scenes
+		pp := 1000
+		findFilter := &models.FindFilterType{
+			PerPage: &pp,
+		}
+
+		for _, tt := range tests {
+			criterion := tt.criterion
+			filter := models.SceneFilterType{
+				TypedURL: &criterion,
+			}
+
+			scenes := queryScene(ctx, t, qb, &filter, findFilter)
+			var ids []int
+			for _, s := range scenes {
+				ids = append(ids, s.ID)
+			}
+
+			if tt.want {
+				assert.Contains(t, ids, scene.ID, tt.name)
+			} else {
+				assert.NotContains(t, ids, scene.ID, tt.name)
+			}
+		}
+
+		return nil
+	})
+}
+
+func TestStudioURLs(t *testing.T) {
+	const (
+		official = "https://studio.example"
+		store    = "https://store.example/studio"
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		studio := models.Studio{
+			Name: "studio with urls",
+			URLs: models.NewRelatedStrings([]string{official, store}),
+		}
+		if err := qb.Create(ctx, &studio); err != nil {
+			t.Errorf("Create error = %v", err)
+			return nil
+		}
+
+		if err := qb.SetURLTypes(ctx, studio.ID, map[string]*models.URLType{
+			store: urlTypePtr(models.URLTypeStore),
+		}); err != nil {
+			t.Errorf("SetURLTypes error = %v", err)
+			return nil
+		}
+
+		got, err := qb.GetTypedURLs(ctx, studio.ID)
+		if err != nil {
+			t.Errorf("GetTypedURLs error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []models.TypedURL{
+			{URL: official},
+			{URL: store, Type: urlTypePtr(models.URLTypeStore)},
+		}, got)
+
+		filter := models.StudioFilterType{
+			TypedURL: &models.TypedURLCriterionInput{
+				Type:     models.URLTypeStore,
+				Value:    "store.example",
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}
+
+		studios := queryStudio(ctx, t, qb, &filter, nil)
+		if assert.Len(t, studios, 1) {
+			assert.Equal(t, studio.ID, studios[0].ID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,279 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// defaultWALAutoCheckpoint is the SQLite default number of write-ahead log
+// pages after which a checkpoint is automatically run.
+const defaultWALAutoCheckpoint = 1000
+
+// ErrSnapshotActive is returned when beginning a snapshot while another
+// snapshot is held.
+var ErrSnapshotActive = errors.New("a database snapshot is already active")
+
+// SynchronousMode is the value of the SQLite synchronous pragma.
+// See https://www.sqlite.org/pragma.html#pragma_synchronous.
+type SynchronousMode string
+
+const (
+	SynchronousModeOff    SynchronousMode = "OFF"
+	SynchronousModeNormal SynchronousMode = "NORMAL"
+	SynchronousModeFull   SynchronousMode = "FULL"
+)
+
+func (e SynchronousMode) IsValid() bool {
+	switch e {
+	case SynchronousModeOff, SynchronousModeNormal, SynchronousModeFull:
+		return true
+	}
+	return false
+}
+
+// WALOptions tunes the write-ahead log of the database.
+type WALOptions struct {
+	// Synchronous defaults to NORMAL if empty.
+	Synchronous SynchronousMode
+	// AutoCheckpoint is the number of log pages after which SQLite checkpoints
+	// the log automatically. Zero disables automatic checkpoints, which is
+	// useful when checkpoints are performed by an external replication tool.
+	// Negative values use the SQLite default.
+	AutoCheckpoint int
+}
+
+func (o WALOptions) synchronous() SynchronousMode {
+	if o.Synchronous == "" {
+		return SynchronousModeNormal
+	}
+	return o.Synchronous
+}
+
+// SetWALOptions sets the write-ahead log options. Takes effect when the
+// database is next opened.
+func (db *Database) SetWALOptions(options WALOptions) {
+	if options.Synchronous != "" && !options.Synchronous.IsValid() {
+		logger.Warnf("invalid database synchronous mode %q, using %s", options.Synchronous, SynchronousModeNormal)
+		options.Synchronous = ""
+	}
+
+	db.walOptions = options
+}
+
+// walParams returns the connection parameters for the write-ahead log options.
+func (db *Database) walParams() string {
+	ret := "&_sync=" + string(db.walOptions.synchronous())
+
+	if ac := db.walOptions.AutoCheckpoint; ac >= 0 && ac != defaultWALAutoCheckpoint {
+		ret += "&" + walAutoCheckpointParam + "=" + strconv.Itoa(ac)
+	}
+
+	return ret
+}
+
+type CheckpointMode string
+
+const (
+	// Checkpoints as many frames as possible without waiting for readers or writers
+	CheckpointModePassive CheckpointMode = "PASSIVE"
+	// Waits for writers, then checkpoints all frames
+	CheckpointModeFull CheckpointMode = "FULL"
+	// As FULL, then waits for readers so that the log is restarted
+	CheckpointModeRestart CheckpointMode = "RESTART"
+	// As RESTART, then truncates the log file to zero bytes
+	CheckpointModeTruncate CheckpointMode = "TRUNCATE"
+)
+
+var AllCheckpointMode = []CheckpointMode{
+	CheckpointModePassive,
+	CheckpointModeFull,
+	CheckpointModeRestart,
+	CheckpointModeTruncate,
+}
+
+func (e CheckpointMode) IsValid() bool {
+	switch e {
+	case CheckpointModePassive, CheckpointModeFull, CheckpointModeRestart, CheckpointModeTruncate:
+		return true
+	}
+	return false
+}
+
+func (e CheckpointMode) String() string {
+	return string(e)
+}
+
+func (e *CheckpointMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CheckpointMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DatabaseCheckpointMode", str)
+	}
+	return nil
+}
+
+func (e CheckpointMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// CheckpointResult is the result of a write-ahead log checkpoint.
+type CheckpointResult struct {
+	// Busy is true if the checkpoint could not complete because of other
+	// readers or writers.
+	Busy bool `json:"busy"`
+	// LogPages is the number of pages in the write-ahead log.
+	LogPages int `json:"log_pages"`
+	// CheckpointedPages is the number of log pages written to the database.
+	CheckpointedPages int `json:"checkpointed_pages"`
+}
+
+// Checkpoint runs a write-ahead log checkpoint using the given mode.
+func (db *Database) Checkpoint(ctx context.Context, mode CheckpointMode) (*CheckpointResult, error) {
+	if err := db.Ready(); err != nil {
+		return nil, err
+	}
+
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("invalid checkpoint mode %q", mode)
+	}
+
+	var busy int
+	ret := &CheckpointResult{}
+	// mode is validated above, so it is safe to include in the statement
+	row := db.writeDB.QueryRowContext(ctx, "PRAGMA wal_checkpoint("+string(mode)+")")
+	if err := row.Scan(&busy, &ret.LogPages, &ret.CheckpointedPages); err != nil {
+		return nil, fmt.Errorf("checkpointing database: %w", err)
+	}
+
+	ret.Busy = busy != 0
+	return ret, nil
+}
+
+// snapshot is a read transaction held open on a dedicated connection.
+type snapshot struct {
+	conn  *sqlx.DB
+	tx    *sqlx.Tx
+	timer *time.Timer
+}
+
+func (s *snapshot) release() error {
+	s.timer.Stop()
+
+	rollbackErr := s.tx.Rollback()
+	if err := s.conn.Close(); err != nil {
+		return err
+	}
+
+	return rollbackErr
+}
+
+// BeginSnapshot holds a read transaction open, so that checkpoints do not
+// write changes made after the snapshot into the database file, and the
+// write-ahead log is not reset. This allows external tools to copy the
+// database file while stash is running. The snapshot is released by
+// EndSnapshot, or automatically after timeout.
+func (db *Database) BeginSnapshot(ctx context.Context, timeout time.Duration) error {
+	if err := db.Ready(); err != nil {
+		return err
+	}
+
+	db.snapshotMutex.Lock()
+	defer db.snapshotMutex.Unlock()
+
+	if db.snapshot != nil {
+		return ErrSnapshotActive
+	}
+
+	// write the existing log to the database file first, so that the
+	// database file is as close to the snapshot as possible
+	if _, err := db.Checkpoint(ctx, CheckpointModePassive); err != nil {
+		logger.Warnf("error checkpointing database before snapshot: %v", err)
+	}
+
+	const (
+		disableForeignKeys = false
+		writable           = false
+	)
+	conn, err := db.open(disableForeignKeys, writable)
+	if err != nil {
+		return err
+	}
+	conn.SetMaxOpenConns(1)
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("beginning snapshot transaction: %w", err)
+	}
+
+	// read transactions start when the database is first read
+	var v int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master").Scan(&v); err != nil {
+		_ = tx.Rollback()
+		conn.Close()
+		return fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	s := &snapshot{
+		conn: conn,
+		tx:   tx,
+	}
+	s.timer = time.AfterFunc(timeout, func() {
+		logger.Warnf("Database snapshot was not ended within %s, releasing", timeout)
+		if err := db.endSnapshot(s); err != nil {
+			logger.Errorf("error releasing database snapshot: %v", err)
+		}
+	})
+
+	db.snapshot = s
+	logger.Info("Database snapshot started")
+	return nil
+}
+
+// EndSnapshot releases the snapshot held by BeginSnapshot. Returns false if
+// there is no active snapshot.
+func (db *Database) EndSnapshot() (bool, error) {
+	db.snapshotMutex.Lock()
+	s := db.snapshot
+	db.snapshotMutex.Unlock()
+
+	if s == nil {
+		return false, nil
+	}
+
+	return true, db.endSnapshot(s)
+}
+
+func (db *Database) endSnapshot(s *snapshot) error {
+	db.snapshotMutex.Lock()
+	defer db.snapshotMutex.Unlock()
+
+	// the snapshot may have been released already
+	if db.snapshot != s {
+		return nil
+	}
+
+	db.snapshot = nil
+	logger.Info("Database snapshot ended")
+	return s.release()
+}
+
+// SnapshotActive returns true if a snapshot is held.
+func (db *Database) SnapshotActive() bool {
+	db.snapshotMutex.Lock()
+	defer db.snapshotMutex.Unlock()
+
+	return db.snapshot != nil
+}
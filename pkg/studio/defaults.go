@@ -0,0 +1,76 @@
+package studio
+
+import (
+	"fmt"
+	neturl "net/url"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Placeholders in default URL patterns, which are replaced with the values
+// of the scene the URL is added to.
+const (
+	DefaultURLCode  = "{code}"
+	DefaultURLTitle = "{title}"
+	DefaultURLDate  = "{date}"
+)
+
+var defaultURLPlaceholderRE = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateDefaultURLs returns an error if any of the default URL patterns is
+// not an absolute http or https URL, or contains an unknown placeholder.
+func ValidateDefaultURLs(patterns []string) error {
+	for _, p := range patterns {
+		for _, ph := range defaultURLPlaceholderRE.FindAllString(p, -1) {
+			switch ph {
+			case DefaultURLCode, DefaultURLTitle, DefaultURLDate:
+			default:
+				return fmt.Errorf("default URL %q: unknown placeholder %s", p, ph)
+			}
+		}
+
+		u, err := neturl.Parse(defaultURLPlaceholderRE.ReplaceAllString(p, "x"))
+		if err != nil {
+			return fmt.Errorf("default URL %q: %w", p, err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("default URL %q must be an absolute http or https URL", p)
+		}
+	}
+
+	return nil
+}
+
+// ExpandDefaultURL returns the default URL pattern with its placeholders
+// replaced by the escaped values of the scene. It returns an empty string if
+// the pattern uses a value that the scene does not have.
+func ExpandDefaultURL(pattern string, s *models.Scene) string {
+	date := ""
+	if s.Date != nil {
+		date = s.Date.String()
+	}
+
+	values := map[string]string{
+		DefaultURLCode:  s.Code,
+		DefaultURLTitle: s.Title,
+		DefaultURLDate:  date,
+	}
+
+	missing := false
+	ret := defaultURLPlaceholderRE.ReplaceAllStringFunc(pattern, func(ph string) string {
+		v := strings.TrimSpace(values[ph])
+		if v == "" {
+			missing = true
+		}
+		return neturl.PathEscape(v)
+	})
+
+	if missing {
+		return ""
+	}
+
+	return ret
+}
@@ -0,0 +1,62 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDefaultURLs(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{"https://example.com/scene/{code}", false},
+		{"http://example.com/{date}/{title}", false},
+		{"https://example.com/", false},
+		{"https://example.com/{id}", true},
+		{"example.com/{code}", true},
+		{"ftp://example.com/{code}", true},
+		{"/scene/{code}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := ValidateDefaultURLs([]string{tt.pattern})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExpandDefaultURL(t *testing.T) {
+	date, _ := models.ParseDate("2024-03-05")
+	s := &models.Scene{
+		Code:  "ABC-123",
+		Title: "A title/with slash",
+		Date:  &date,
+	}
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"https://example.com/scene/{code}", "https://example.com/scene/ABC-123"},
+		{"https://example.com/{date}/{title}", "https://example.com/2024-03-05/A%20title%2Fwith%20slash"},
+		{"https://example.com/", "https://example.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExpandDefaultURL(tt.pattern, s))
+		})
+	}
+
+	// missing values
+	assert.Equal(t, "", ExpandDefaultURL("https://example.com/{code}", &models.Scene{Title: "title"}))
+	assert.Equal(t, "", ExpandDefaultURL("https://example.com/{date}", &models.Scene{Code: "code"}))
+}
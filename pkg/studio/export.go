@@ -14,6 +14,7 @@ import (
 type FinderImageStashIDGetter interface {
 	models.StudioGetter
 	models.AliasLoader
+	models.URLLoader
 	models.StashIDLoader
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 }
@@ -22,12 +23,17 @@ type FinderImageStashIDGetter interface {
 func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models.Studio) (*jsonschema.Studio, error) {
 	newStudioJSON := jsonschema.Studio{
 		Name:          studio.Name,
-		URL:           studio.URL,
 		Details:       studio.Details,
 		Favorite:      studio.Favorite,
 		IgnoreAutoTag: studio.IgnoreAutoTag,
 		CreatedAt:     json.JSONTime{Time: studio.CreatedAt},
 		UpdatedAt:     json.JSONTime{Time: studio.UpdatedAt},
+
+		PropagateDefaults: studio.PropagateDefaults,
+	}
+
+	if studio.FavoritedAt != nil {
+		newStudioJSON.FavoritedAt = &json.JSONTime{Time: *studio.FavoritedAt}
 	}
 
 	if studio.ParentID != nil {
@@ -50,6 +56,11 @@ func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models
 	}
 	newStudioJSON.Aliases = studio.Aliases.List()
 
+	if err := studio.LoadURLs(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading studio urls: %w", err)
+	}
+	newStudioJSON.URLs = studio.URLs.List()
+
 	if err := studio.LoadStashIDs(ctx, reader); err != nil {
 		return nil, fmt.Errorf("loading studio stash ids: %w", err)
 	}
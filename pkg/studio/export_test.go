@@ -60,7 +60,7 @@ func createFullStudio(id int, parentID int) models.Studio {
 	ret := models.Studio{
 		ID:            id,
 		Name:          studioName,
-		URL:           url,
+		URLs:          models.NewRelatedStrings([]string{url}),
 		Details:       details,
 		Favorite:      true,
 		CreatedAt:     createTime,
@@ -70,6 +70,7 @@ func createFullStudio(id int, parentID int) models.Studio {
 		Aliases:       models.NewRelatedStrings(aliases),
 		TagIDs:        models.NewRelatedIDs([]int{}),
 		StashIDs:      models.NewRelatedStashIDs(stashIDs),
+		DefaultTagIDs: models.NewRelatedIDs([]int{}),
 	}
 
 	if parentID != 0 {
@@ -85,6 +86,7 @@ func createEmptyStudio(id int) models.Studio {
 		CreatedAt: createTime,
 		UpdatedAt: updateTime,
 		Aliases:   models.NewRelatedStrings([]string{}),
+		URLs:      models.NewRelatedStrings([]string{}),
 		TagIDs:    models.NewRelatedIDs([]int{}),
 		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
 	}
@@ -93,7 +95,7 @@ func createEmptyStudio(id int) models.Studio {
 func createFullJSONStudio(parentStudio, image string, aliases []string) *jsonschema.Studio {
 	return &jsonschema.Studio{
 		Name:     studioName,
-		URL:      url,
+		URLs:     []string{url},
 		Details:  details,
 		Favorite: true,
 		CreatedAt: json.JSONTime{
@@ -120,6 +122,7 @@ func createEmptyJSONStudio() *jsonschema.Studio {
 			Time: updateTime,
 		},
 		Aliases:  []string{},
+		URLs:     []string{},
 		StashIDs: []models.StashID{},
 	}
 }
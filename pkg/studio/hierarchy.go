@@ -0,0 +1,195 @@
+package studio
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// HierarchyChange is a parent link between two studios inferred from a
+// directory of the form Parent/Studio.
+type HierarchyChange struct {
+	Studio string `json:"studio"`
+	Parent string `json:"parent"`
+	// CreateStudio and CreateParent are true if the studios do not exist
+	// and will be created.
+	CreateStudio bool `json:"create_studio"`
+	CreateParent bool `json:"create_parent"`
+	// Path is the first directory the link was inferred from.
+	Path string `json:"path"`
+}
+
+// InferHierarchyLink returns the studio and parent studio names of a
+// directory path relative to a library path. The last directory is the
+// studio and the one before it is the parent. ok is false if the path has
+// fewer than two directories.
+func InferHierarchyLink(relDir string) (studio string, parent string, ok bool) {
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(relDir)), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	studio = strings.TrimSpace(parts[len(parts)-1])
+	parent = strings.TrimSpace(parts[len(parts)-2])
+
+	if studio == "" || parent == "" || studio == "." || parent == "." || parent == ".." {
+		return "", "", false
+	}
+
+	// a studio cannot be its own parent
+	if strings.EqualFold(studio, parent) {
+		return "", "", false
+	}
+
+	return studio, parent, true
+}
+
+type HierarchyPlanReader interface {
+	models.StudioGetter
+	FindByName(ctx context.Context, name string, nocase bool) (*models.Studio, error)
+}
+
+// PlanHierarchy returns the changes needed to link the studios inferred from
+// the given directories, relative to their library paths. Studios that
+// already have a parent are left alone. If a studio is inferred with more
+// than one parent, the first in directory order is used.
+func PlanHierarchy(ctx context.Context, qb HierarchyPlanReader, relDirs []string) ([]HierarchyChange, error) {
+	dirs := make([]string, len(relDirs))
+	copy(dirs, relDirs)
+	sort.Strings(dirs)
+
+	var ret []HierarchyChange
+	planned := make(map[string]bool)
+	// names of studios created by earlier changes
+	created := make(map[string]bool)
+
+	for _, dir := range dirs {
+		name, parentName, ok := InferHierarchyLink(dir)
+		if !ok {
+			continue
+		}
+
+		key := strings.ToLower(name)
+		if planned[key] {
+			continue
+		}
+		planned[key] = true
+
+		s, err := qb.FindByName(ctx, name, true)
+		if err != nil {
+			return nil, fmt.Errorf("finding studio %s: %w", name, err)
+		}
+
+		parent, err := qb.FindByName(ctx, parentName, true)
+		if err != nil {
+			return nil, fmt.Errorf("finding studio %s: %w", parentName, err)
+		}
+
+		if s != nil {
+			if s.ParentID != nil {
+				continue
+			}
+
+			if parent != nil {
+				// don't create a cycle
+				if err := validateParent(ctx, s.ID, parent.ID, qb); err != nil {
+					logger.Warnf("Not setting parent of studio %s to %s: %v", s.Name, parent.Name, err)
+					continue
+				}
+			}
+		}
+
+		parentKey := strings.ToLower(parentName)
+		change := HierarchyChange{
+			Studio:       name,
+			Parent:       parentName,
+			CreateStudio: s == nil && !created[key],
+			CreateParent: parent == nil && !created[parentKey],
+			Path:         dir,
+		}
+
+		if s != nil {
+			change.Studio = s.Name
+		}
+		if parent != nil {
+			change.Parent = parent.Name
+		}
+
+		created[key] = true
+		created[parentKey] = true
+
+		ret = append(ret, change)
+	}
+
+	return ret, nil
+}
+
+type HierarchyApplier interface {
+	models.StudioGetter
+	FindByName(ctx context.Context, name string, nocase bool) (*models.Studio, error)
+	Create(ctx context.Context, newStudio *models.Studio) error
+	UpdatePartial(ctx context.Context, updatedStudio models.StudioPartial) (*models.Studio, error)
+}
+
+func findOrCreateByName(ctx context.Context, qb HierarchyApplier, name string) (*models.Studio, error) {
+	s, err := qb.FindByName(ctx, name, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding studio %s: %w", name, err)
+	}
+
+	if s != nil {
+		return s, nil
+	}
+
+	logger.Infof("Creating studio %s", name)
+
+	newStudio := models.NewStudio()
+	newStudio.Name = name
+	if err := qb.Create(ctx, &newStudio); err != nil {
+		return nil, fmt.Errorf("creating studio %s: %w", name, err)
+	}
+
+	return &newStudio, nil
+}
+
+// ApplyHierarchy creates the missing studios of the changes and sets the
+// parent of each studio. Studios that have gained a parent since the changes
+// were planned are left alone.
+func ApplyHierarchy(ctx context.Context, qb HierarchyApplier, changes []HierarchyChange) error {
+	for _, c := range changes {
+		parent, err := findOrCreateByName(ctx, qb, c.Parent)
+		if err != nil {
+			return err
+		}
+
+		s, err := findOrCreateByName(ctx, qb, c.Studio)
+		if err != nil {
+			return err
+		}
+
+		if s.ParentID != nil {
+			continue
+		}
+
+		if err := validateParent(ctx, s.ID, parent.ID, qb); err != nil {
+			logger.Warnf("Not setting parent of studio %s to %s: %v", s.Name, parent.Name, err)
+			continue
+		}
+
+		logger.Infof("Setting parent of studio %s to %s", s.Name, parent.Name)
+
+		partial := models.NewStudioPartial()
+		partial.ID = s.ID
+		partial.ParentID = models.NewOptionalInt(parent.ID)
+		if _, err := qb.UpdatePartial(ctx, partial); err != nil {
+			return fmt.Errorf("setting parent of studio %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
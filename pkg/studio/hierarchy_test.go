@@ -0,0 +1,66 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInferHierarchyLink(t *testing.T) {
+	tests := []struct {
+		relDir     string
+		wantStudio string
+		wantParent string
+		wantOk     bool
+	}{
+		{"Network/Studio", "Studio", "Network", true},
+		{"Other/Network/Studio", "Studio", "Network", true},
+		{"Studio", "", "", false},
+		{".", "", "", false},
+		{"Studio/studio", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relDir, func(t *testing.T) {
+			studio, parent, ok := InferHierarchyLink(tt.relDir)
+			assert.Equal(t, tt.wantStudio, studio)
+			assert.Equal(t, tt.wantParent, parent)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestPlanHierarchy(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	parentID := 3
+
+	network := models.Studio{ID: 1, Name: "Network"}
+	orphan := models.Studio{ID: 2, Name: "Orphan"}
+	linked := models.Studio{ID: 4, Name: "Linked", ParentID: &parentID}
+
+	db.Studio.On("FindByName", testCtx, "Network", true).Return(&network, nil)
+	db.Studio.On("FindByName", testCtx, "Orphan", true).Return(&orphan, nil)
+	db.Studio.On("FindByName", testCtx, "Linked", true).Return(&linked, nil)
+	db.Studio.On("FindByName", testCtx, mock.Anything, true).Return(nil, nil)
+	db.Studio.On("Find", testCtx, network.ID).Return(&network, nil)
+
+	got, err := PlanHierarchy(testCtx, db.Studio, []string{
+		"Network/Orphan",
+		"Network/Linked",
+		"Network/New",
+		"New Network/New",
+		"New Network/Another",
+		"Loose",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []HierarchyChange{
+		{Studio: "New", Parent: "Network", CreateStudio: true, Path: "Network/New"},
+		{Studio: "Orphan", Parent: "Network", Path: "Network/Orphan"},
+		{Studio: "Another", Parent: "New Network", CreateStudio: true, CreateParent: true, Path: "New Network/Another"},
+	}, got)
+}
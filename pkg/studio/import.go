@@ -66,6 +66,17 @@ func (i *Importer) populateTags(ctx context.Context) error {
 		}
 	}
 
+	if len(i.Input.DefaultTags) > 0 {
+		tags, err := importTags(ctx, i.TagWriter, i.Input.DefaultTags, i.MissingRefBehaviour)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range tags {
+			i.studio.DefaultTagIDs.Add(p.ID)
+		}
+	}
+
 	return nil
 }
 
@@ -217,7 +228,6 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 func studioJSONtoStudio(studioJSON jsonschema.Studio) models.Studio {
 	newStudio := models.Studio{
 		Name:          studioJSON.Name,
-		URL:           studioJSON.URL,
 		Aliases:       models.NewRelatedStrings(studioJSON.Aliases),
 		Details:       studioJSON.Details,
 		Favorite:      studioJSON.Favorite,
@@ -227,11 +237,26 @@ func studioJSONtoStudio(studioJSON jsonschema.Studio) models.Studio {
 
 		TagIDs:   models.NewRelatedIDs([]int{}),
 		StashIDs: models.NewRelatedStashIDs(studioJSON.StashIDs),
+
+		PropagateDefaults: studioJSON.PropagateDefaults,
+		DefaultTagIDs:     models.NewRelatedIDs([]int{}),
+		DefaultURLs:       models.NewRelatedStrings(studioJSON.DefaultURLs),
+	}
+
+	if len(studioJSON.URLs) > 0 {
+		newStudio.URLs = models.NewRelatedStrings(studioJSON.URLs)
+	} else if studioJSON.URL != "" {
+		newStudio.URLs = models.NewRelatedStrings([]string{studioJSON.URL})
 	}
 
 	if studioJSON.Rating != 0 {
 		newStudio.Rating = &studioJSON.Rating
 	}
 
+	if studioJSON.FavoritedAt != nil {
+		favoritedAt := studioJSON.FavoritedAt.GetTime()
+		newStudio.FavoritedAt = &favoritedAt
+	}
+
 	return newStudio
 }
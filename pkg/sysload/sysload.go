@@ -0,0 +1,76 @@
+// Package sysload provides measurements of the load on the host system.
+package sysload
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned when a measurement is not available on the
+// current platform.
+var ErrUnsupported = errors.New("not supported on this platform")
+
+// cpuTimes is the cumulative time spent by all CPUs, in clock ticks.
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+// parseProcStat parses the aggregate cpu line of the contents of /proc/stat.
+func parseProcStat(data string) (cpuTimes, error) {
+	s := bufio.NewScanner(strings.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+
+		var ret cpuTimes
+		for i, f := range fields[1:] {
+			v, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return cpuTimes{}, fmt.Errorf("parsing cpu times: %w", err)
+			}
+
+			ret.total += v
+			// idle and iowait
+			if i == 3 || i == 4 {
+				ret.idle += v
+			}
+		}
+
+		return ret, nil
+	}
+
+	return cpuTimes{}, errors.New("cpu times not found")
+}
+
+// cpuPercent returns the percentage of time that the CPUs were busy between
+// two measurements.
+func cpuPercent(before, after cpuTimes) float64 {
+	if after.total <= before.total {
+		return 0
+	}
+	total := after.total - before.total
+
+	idle := after.idle - before.idle
+	if after.idle < before.idle {
+		idle = 0
+	}
+
+	return float64(total-idle) / float64(total) * 100
+}
+
+// parseThermalTemp parses the contents of a thermal zone temp file, which is
+// in millidegrees Celsius.
+func parseThermalTemp(data string) (float64, error) {
+	v, err := strconv.Atoi(strings.TrimSpace(data))
+	if err != nil {
+		return 0, fmt.Errorf("parsing temperature: %w", err)
+	}
+
+	return float64(v) / 1000, nil
+}
@@ -0,0 +1,80 @@
+//go:build linux
+// +build linux
+
+package sysload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const thermalZoneGlob = "/sys/class/thermal/thermal_zone*/temp"
+
+func readCPUTimes() (cpuTimes, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return cpuTimes{}, err
+	}
+
+	return parseProcStat(string(data))
+}
+
+// CPUPercent returns the percentage of time that the CPUs of the system are
+// busy, measured over interval.
+func CPUPercent(ctx context.Context, interval time.Duration) (float64, error) {
+	before, err := readCPUTimes()
+	if err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(interval):
+	}
+
+	after, err := readCPUTimes()
+	if err != nil {
+		return 0, err
+	}
+
+	return cpuPercent(before, after), nil
+}
+
+// MaxTemperature returns the highest temperature of the thermal zones of the
+// system, in degrees Celsius. Returns ErrUnsupported if the system has no
+// readable thermal zones.
+func MaxTemperature() (float64, error) {
+	paths, err := filepath.Glob(thermalZoneGlob)
+	if err != nil {
+		return 0, err
+	}
+
+	found := false
+	var ret float64
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			// some zones are not readable while the sensor is disabled
+			continue
+		}
+
+		t, err := parseThermalTemp(string(data))
+		if err != nil {
+			continue
+		}
+
+		if !found || t > ret {
+			ret = t
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, ErrUnsupported
+	}
+
+	return ret, nil
+}
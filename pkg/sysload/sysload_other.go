@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package sysload
+
+import (
+	"context"
+	"time"
+)
+
+// CPUPercent returns the percentage of time that the CPUs of the system are
+// busy, measured over interval.
+func CPUPercent(ctx context.Context, interval time.Duration) (float64, error) {
+	return 0, ErrUnsupported
+}
+
+// MaxTemperature returns the highest temperature of the thermal zones of the
+// system, in degrees Celsius.
+func MaxTemperature() (float64, error) {
+	return 0, ErrUnsupported
+}
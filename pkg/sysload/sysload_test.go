@@ -0,0 +1,42 @@
+package sysload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProcStat(t *testing.T) {
+	const data = `cpu  100 0 50 800 50 0 0 0 0 0
+cpu0 50 0 25 400 25 0 0 0 0 0
+intr 12345
+`
+
+	got, err := parseProcStat(data)
+	assert.NoError(t, err)
+	assert.Equal(t, cpuTimes{idle: 850, total: 1000}, got)
+
+	_, err = parseProcStat("intr 12345\n")
+	assert.Error(t, err)
+
+	_, err = parseProcStat("cpu 1 2 x 4 5\n")
+	assert.Error(t, err)
+}
+
+func TestCPUPercent(t *testing.T) {
+	before := cpuTimes{idle: 800, total: 1000}
+
+	assert.InDelta(t, 25, cpuPercent(before, cpuTimes{idle: 1100, total: 1400}), 0.001)
+	assert.InDelta(t, 100, cpuPercent(before, cpuTimes{idle: 800, total: 1100}), 0.001)
+	// no ticks elapsed
+	assert.Equal(t, float64(0), cpuPercent(before, before))
+}
+
+func TestParseThermalTemp(t *testing.T) {
+	got, err := parseThermalTemp("45500\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 45.5, got)
+
+	_, err = parseThermalTemp("")
+	assert.Error(t, err)
+}
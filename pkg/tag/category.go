@@ -0,0 +1,58 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var ErrCategoryNameMissing = errors.New("tag category name must not be blank")
+
+// CategoryNameExistsError is returned when a tag category with the same name
+// already exists.
+type CategoryNameExistsError struct {
+	Name string
+}
+
+func (e *CategoryNameExistsError) Error() string {
+	return fmt.Sprintf("tag category with name '%s' already exists", e.Name)
+}
+
+// EnsureCategoryNameUnique returns an error if a tag category other than the
+// one with the given id has the given name, ignoring case.
+func EnsureCategoryNameUnique(ctx context.Context, id int, name string, qb models.TagCategoryFinder) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrCategoryNameMissing
+	}
+
+	existing, err := qb.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return &CategoryNameExistsError{Name: existing.Name}
+	}
+
+	return nil
+}
+
+// SplitCategory splits a namespaced tag name of the form "Category:Name" into
+// its category and name. If the name has no namespace, category is empty.
+func SplitCategory(name string) (category string, tagName string) {
+	category, tagName, found := strings.Cut(name, ":")
+	if !found {
+		return "", name
+	}
+
+	category = strings.TrimSpace(category)
+	tagName = strings.TrimSpace(tagName)
+	if category == "" || tagName == "" {
+		return "", name
+	}
+
+	return category, tagName
+}
@@ -0,0 +1,31 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCategory(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantCategory string
+		wantName     string
+	}{
+		{"Beach", "", "Beach"},
+		{"Location:Beach", "Location", "Beach"},
+		{"Location: Beach", "Location", "Beach"},
+		{" Hair Colour : Red ", "Hair Colour", "Red"},
+		{"Ratio: 16:9", "Ratio", "16:9"},
+		{":Beach", "", ":Beach"},
+		{"Location:", "", "Location:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, name := SplitCategory(tt.name)
+			assert.Equal(t, tt.wantCategory, category)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
@@ -24,10 +24,15 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 		Description:   tag.Description,
 		Favorite:      tag.Favorite,
 		IgnoreAutoTag: tag.IgnoreAutoTag,
+		Restricted:    tag.Restricted,
 		CreatedAt:     json.JSONTime{Time: tag.CreatedAt},
 		UpdatedAt:     json.JSONTime{Time: tag.UpdatedAt},
 	}
 
+	if tag.FavoritedAt != nil {
+		newTagJSON.FavoritedAt = &json.JSONTime{Time: *tag.FavoritedAt}
+	}
+
 	aliases, err := reader.GetAliases(ctx, tag.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting tag aliases: %v", err)
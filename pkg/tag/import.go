@@ -27,9 +27,10 @@ func (e ParentTagNotExistError) MissingParent() string {
 }
 
 type Importer struct {
-	ReaderWriter        ImporterReaderWriter
-	Input               jsonschema.Tag
-	MissingRefBehaviour models.ImportMissingRefEnum
+	ReaderWriter         ImporterReaderWriter
+	CategoryReaderWriter models.TagCategoryFinderCreator
+	Input                jsonschema.Tag
+	MissingRefBehaviour  models.ImportMissingRefEnum
 
 	tag       models.Tag
 	imageData []byte
@@ -41,10 +42,20 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		Description:   i.Input.Description,
 		Favorite:      i.Input.Favorite,
 		IgnoreAutoTag: i.Input.IgnoreAutoTag,
+		Restricted:    i.Input.Restricted,
 		CreatedAt:     i.Input.CreatedAt.GetTime(),
 		UpdatedAt:     i.Input.UpdatedAt.GetTime(),
 	}
 
+	if i.Input.FavoritedAt != nil {
+		favoritedAt := i.Input.FavoritedAt.GetTime()
+		i.tag.FavoritedAt = &favoritedAt
+	}
+
+	if err := i.populateCategory(ctx); err != nil {
+		return err
+	}
+
 	var err error
 	if len(i.Input.Image) > 0 {
 		i.imageData, err = utils.ProcessBase64Image(i.Input.Image)
@@ -56,6 +67,31 @@ func (i *Importer) PreImport(ctx context.Context) error {
 	return nil
 }
 
+// populateCategory sets the category of the tag, creating it if it does not
+// exist. Categories are not exported separately, so are always created.
+func (i *Importer) populateCategory(ctx context.Context) error {
+	if i.Input.Category == "" || i.CategoryReaderWriter == nil {
+		return nil
+	}
+
+	category, err := i.CategoryReaderWriter.FindByName(ctx, i.Input.Category)
+	if err != nil {
+		return fmt.Errorf("error finding tag category by name: %v", err)
+	}
+
+	if category == nil {
+		newCategory := models.NewTagCategory()
+		newCategory.Name = i.Input.Category
+		if err := i.CategoryReaderWriter.Create(ctx, &newCategory); err != nil {
+			return fmt.Errorf("error creating tag category: %v", err)
+		}
+		category = &newCategory
+	}
+
+	i.tag.CategoryID = &category.ID
+	return nil
+}
+
 func (i *Importer) PostImport(ctx context.Context, id int) error {
 	if len(i.imageData) > 0 {
 		if err := i.ReaderWriter.UpdateImage(ctx, id, i.imageData); err != nil {
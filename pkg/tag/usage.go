@@ -0,0 +1,261 @@
+package tag
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// DefaultDuplicateDistance is the default maximum edit distance between the
+// names of duplicate tags.
+const DefaultDuplicateDistance = 1
+
+// minDistanceLength is the minimum length in runes of names that are matched
+// by edit distance. Shorter names are only matched if equal, so that short
+// names such as "4k" and "8k" are not considered duplicates.
+const minDistanceLength = 5
+
+type UsageFinder interface {
+	All(ctx context.Context) ([]*models.Tag, error)
+	UsageCounts(ctx context.Context) (map[int]int, error)
+	models.TagRelationLoader
+}
+
+type tagUsage int
+
+const (
+	// the tag is applied to an object
+	usageDirect tagUsage = iota
+	// the tag is not applied to any object, but one of its descendants is
+	usageDescendant
+	// neither the tag nor its descendants are applied to any object
+	usageNone
+)
+
+// FindUnused returns the tags that are not applied to any object and that
+// have no descendants that are applied to an object.
+func FindUnused(ctx context.Context, r UsageFinder) ([]*models.Tag, error) {
+	return findByUsage(ctx, r, usageNone)
+}
+
+// FindHierarchyOnly returns the tags that are not applied to any object, but
+// that have descendants that are. These tags are only used through the tag
+// hierarchy.
+func FindHierarchyOnly(ctx context.Context, r UsageFinder) ([]*models.Tag, error) {
+	return findByUsage(ctx, r, usageDescendant)
+}
+
+func findByUsage(ctx context.Context, r UsageFinder, u tagUsage) ([]*models.Tag, error) {
+	tags, err := r.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := r.UsageCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[int][]int)
+	for _, t := range tags {
+		parents[t.ID], err = r.GetParentIDs(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	usage := classifyUsage(counts, parents)
+
+	var ret []*models.Tag
+	for _, t := range tags {
+		if usage[t.ID] == u {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret, nil
+}
+
+// classifyUsage returns the usage of each tag in parents, given the direct
+// usage counts of the tags and the parent IDs of each tag.
+func classifyUsage(counts map[int]int, parents map[int][]int) map[int]tagUsage {
+	ret := make(map[int]tagUsage, len(parents))
+	for id := range parents {
+		ret[id] = usageNone
+	}
+
+	for id := range parents {
+		if counts[id] > 0 {
+			ret[id] = usageDirect
+		}
+	}
+
+	// mark the ancestors of used tags. The hierarchy is walked from each used
+	// tag, stopping at tags that have already been reached.
+	for id := range parents {
+		if ret[id] != usageDirect {
+			continue
+		}
+
+		queue := slices.Clone(parents[id])
+		for len(queue) > 0 {
+			p := queue[0]
+			queue = queue[1:]
+
+			if ret[p] != usageNone {
+				continue
+			}
+
+			ret[p] = usageDescendant
+			queue = append(queue, parents[p]...)
+		}
+	}
+
+	return ret
+}
+
+type DuplicateFinder interface {
+	All(ctx context.Context) ([]*models.Tag, error)
+	models.AliasLoader
+}
+
+// FindDuplicates returns groups of tags that are likely to be duplicates.
+// Tags are grouped if a name or alias of one is equal to a name or alias of
+// another when case and punctuation are ignored, or if they are within
+// distance edits of each other. Groups and the tags in each group are
+// ordered by name.
+func FindDuplicates(ctx context.Context, r DuplicateFinder, distance int) ([][]*models.Tag, error) {
+	tags, err := r.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([][]string, len(tags))
+	for i, t := range tags {
+		aliases, err := r.GetAliases(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		names[i] = append(names[i], normalizeName(t.Name))
+		for _, a := range aliases {
+			names[i] = append(names[i], normalizeName(a))
+		}
+	}
+
+	var ret [][]*models.Tag
+	for _, group := range findDuplicates(names, distance) {
+		var g []*models.Tag
+		for _, i := range group {
+			g = append(g, tags[i])
+		}
+		ret = append(ret, g)
+	}
+
+	return ret, nil
+}
+
+// findDuplicates groups the indexes of names, where each element holds the
+// normalised names of a tag. Groups of more than one tag are returned,
+// ordered by their first member.
+func findDuplicates(names [][]string, distance int) [][]int {
+	parent := make([]int, len(names))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	link := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[max(ra, rb)] = min(ra, rb)
+		}
+	}
+
+	type entry struct {
+		name   string
+		length int
+		tag    int
+	}
+
+	var entries []entry
+	first := make(map[string]int)
+	for i, nn := range names {
+		for _, n := range nn {
+			if n == "" {
+				continue
+			}
+
+			// equal names are linked to the first tag with the name
+			if f, found := first[n]; found {
+				link(f, i)
+				continue
+			}
+
+			first[n] = i
+			entries = append(entries, entry{name: n, length: utf8.RuneCountInString(n), tag: i})
+		}
+	}
+
+	if distance > 0 {
+		// names can only be within distance of names with a similar length
+		slices.SortStableFunc(entries, func(a, b entry) int {
+			return a.length - b.length
+		})
+
+		for x, a := range entries {
+			if a.length < minDistanceLength {
+				continue
+			}
+
+			for _, b := range entries[x+1:] {
+				if b.length-a.length > distance {
+					break
+				}
+
+				if find(a.tag) != find(b.tag) && utils.EditDistance(a.name, b.name) <= distance {
+					link(a.tag, b.tag)
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range names {
+		r := find(i)
+		if _, found := groups[r]; !found {
+			roots = append(roots, r)
+		}
+		groups[r] = append(groups[r], i)
+	}
+
+	var ret [][]int
+	for _, r := range roots {
+		if len(groups[r]) > 1 {
+			ret = append(ret, groups[r])
+		}
+	}
+
+	return ret
+}
+
+// normalizeName returns name in lower case, with punctuation replaced with
+// single spaces.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,58 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyUsage(t *testing.T) {
+	// 1 -> 2 -> 3, 4 -> 5, 6 -> 7 -> 6
+	parents := map[int][]int{
+		1: nil,
+		2: {1},
+		3: {2},
+		4: nil,
+		5: {4},
+		6: {7},
+		7: {6},
+	}
+	counts := map[int]int{
+		3: 2,
+		4: 1,
+		7: 1,
+	}
+
+	assert.Equal(t, map[int]tagUsage{
+		1: usageDescendant,
+		2: usageDescendant,
+		3: usageDirect,
+		4: usageDirect,
+		5: usageNone,
+		6: usageDescendant,
+		7: usageDirect,
+	}, classifyUsage(counts, parents))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	names := [][]string{
+		// 0, 3: equal when normalised
+		{"blonde hair"},
+		// 1, 4: alias of one is within distance of the name of the other
+		{"outdoors", "outside"},
+		// 2, 5: short names are not matched by distance
+		{"4k"},
+		{"blonde hair"},
+		{"outsides"},
+		{"8k"},
+		// 6: not a duplicate
+		{"indoors"},
+	}
+
+	assert.Equal(t, [][]int{{0, 3}, {1, 4}}, findDuplicates(names, 1))
+	assert.Equal(t, [][]int{{0, 3}}, findDuplicates(names, 0))
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "blonde hair", normalizeName(" Blonde-Hair. "))
+}
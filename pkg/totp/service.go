@@ -0,0 +1,349 @@
+package totp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+var (
+	ErrNoUsername     = errors.New("two-factor authentication requires a username and password to be configured")
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrNotEnrolled    = errors.New("two-factor authentication enrollment has not been started")
+	ErrInvalidCode    = errors.New("invalid two-factor code")
+)
+
+// Status describes the two-factor configuration of a user.
+type Status struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// Enrollment contains the details required to add a new secret to an
+// authenticator app.
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// Service manages the two-factor credentials of users. It implements
+// session.TwoFactorProvider. Methods manage their own transactions and
+// must not be called within an existing transaction.
+type Service struct {
+	TxnManager txn.Manager
+	Repository models.TOTPReaderWriter
+	// Issuer is the name shown in authenticator apps.
+	Issuer string
+
+	mutex sync.Mutex
+	// cache of session versions, since these are checked on every request
+	sessionVersions map[string]int
+}
+
+func (s *Service) find(ctx context.Context, username string) (*models.TOTPCredential, error) {
+	if username == "" {
+		return nil, ErrNoUsername
+	}
+
+	return s.Repository.FindByUsername(ctx, username)
+}
+
+// TwoFactorEnabled returns true if the user has enabled two-factor authentication.
+func (s *Service) TwoFactorEnabled(ctx context.Context, username string) (bool, error) {
+	var ret bool
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		ret = c != nil && c.Enabled
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
+
+// VerifyTwoFactor returns true if the code is a valid one-time password or
+// unused recovery code for the user. Accepted codes cannot be used again.
+func (s *Service) VerifyTwoFactor(ctx context.Context, username string, code string) (bool, error) {
+	var ret bool
+	if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if c == nil || !c.Enabled {
+			return nil
+		}
+
+		ret, err = s.verify(ctx, c, code, true)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return ret, nil
+}
+
+// verify checks the code against the credential, optionally accepting
+// recovery codes. Must be called within a write transaction.
+func (s *Service) verify(ctx context.Context, c *models.TOTPCredential, code string, allowRecovery bool) (bool, error) {
+	if step, ok := Validate(c.Secret, code, time.Now(), c.LastUsedStep); ok {
+		c.LastUsedStep = step
+		c.UpdatedAt = time.Now()
+		if err := s.Repository.Update(ctx, c); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	return s.Repository.UseRecoveryCode(ctx, c.ID, HashRecoveryCode(code))
+}
+
+// SessionVersion returns the current session version of the user.
+// Sessions created with a different version are no longer valid.
+func (s *Service) SessionVersion(ctx context.Context, username string) (int, error) {
+	s.mutex.Lock()
+	v, found := s.sessionVersions[username]
+	s.mutex.Unlock()
+
+	if found {
+		return v, nil
+	}
+
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if c != nil {
+			v = c.SessionVersion
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	s.setSessionVersion(username, v)
+	return v, nil
+}
+
+func (s *Service) setSessionVersion(username string, v int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.sessionVersions == nil {
+		s.sessionVersions = make(map[string]int)
+	}
+	s.sessionVersions[username] = v
+}
+
+// Status returns the two-factor status of the user.
+func (s *Service) Status(ctx context.Context, username string) (*Status, error) {
+	ret := &Status{}
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if c == nil || !c.Enabled {
+			return nil
+		}
+
+		ret.Enabled = true
+
+		codes, err := s.Repository.GetRecoveryCodes(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, rc := range codes {
+			if !rc.IsUsed() {
+				ret.RecoveryCodesRemaining++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Enroll generates a new secret for the user. Two-factor authentication is
+// not enabled until the secret is confirmed with a valid code.
+func (s *Service) Enroll(ctx context.Context, username string) (*Enrollment, error) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generating secret: %w", err)
+	}
+
+	if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if c == nil {
+			newCredential := models.NewTOTPCredential()
+			newCredential.Username = username
+			newCredential.Secret = secret
+			return s.Repository.Create(ctx, &newCredential)
+		}
+
+		if c.Enabled {
+			return ErrAlreadyEnabled
+		}
+
+		c.Secret = secret
+		c.LastUsedStep = 0
+		c.UpdatedAt = time.Now()
+		return s.Repository.Update(ctx, c)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Enrollment{
+		Secret: secret,
+		URI:    URI(s.Issuer, username, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication if the code is valid for the
+// enrolled secret. Returns the new recovery codes. All existing sessions
+// of the user are invalidated.
+func (s *Service) Confirm(ctx context.Context, username string, code string) ([]string, error) {
+	return s.change(ctx, username, func(ctx context.Context, c *models.TOTPCredential) ([]string, error) {
+		if c.Enabled {
+			return nil, ErrAlreadyEnabled
+		}
+
+		ok, err := s.verify(ctx, c, code, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidCode
+		}
+
+		c.Enabled = true
+		return s.replaceRecoveryCodes(ctx, c)
+	})
+}
+
+// Disable disables two-factor authentication if the code is a valid
+// one-time password or recovery code. All existing sessions of the user
+// are invalidated.
+func (s *Service) Disable(ctx context.Context, username string, code string) error {
+	_, err := s.change(ctx, username, func(ctx context.Context, c *models.TOTPCredential) ([]string, error) {
+		if !c.Enabled {
+			return nil, ErrNotEnabled
+		}
+
+		ok, err := s.verify(ctx, c, code, true)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidCode
+		}
+
+		// the credential row is retained so that the session version
+		// continues to increase
+		c.Enabled = false
+		c.Secret = ""
+		c.LastUsedStep = 0
+
+		return nil, s.Repository.ReplaceRecoveryCodes(ctx, c.ID, nil)
+	})
+
+	return err
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user if the
+// code is a valid one-time password. All existing sessions of the user are
+// invalidated.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, username string, code string) ([]string, error) {
+	return s.change(ctx, username, func(ctx context.Context, c *models.TOTPCredential) ([]string, error) {
+		if !c.Enabled {
+			return nil, ErrNotEnabled
+		}
+
+		ok, err := s.verify(ctx, c, code, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidCode
+		}
+
+		return s.replaceRecoveryCodes(ctx, c)
+	})
+}
+
+// change applies fn to the credential of the user and increments the
+// session version in a single transaction.
+func (s *Service) change(ctx context.Context, username string, fn func(ctx context.Context, c *models.TOTPCredential) ([]string, error)) ([]string, error) {
+	var ret []string
+	var version int
+	if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		c, err := s.find(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if c == nil {
+			return ErrNotEnrolled
+		}
+
+		ret, err = fn(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		c.SessionVersion++
+		c.UpdatedAt = time.Now()
+		version = c.SessionVersion
+		return s.Repository.Update(ctx, c)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.setSessionVersion(username, version)
+	return ret, nil
+}
+
+func (s *Service) replaceRecoveryCodes(ctx context.Context, c *models.TOTPCredential) ([]string, error) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("generating recovery codes: %w", err)
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = HashRecoveryCode(code)
+	}
+
+	if err := s.Repository.ReplaceRecoveryCodes(ctx, c.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
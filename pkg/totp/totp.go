@@ -0,0 +1,155 @@
+// Package totp implements time-based one-time passwords (RFC 6238) and
+// recovery codes for two-factor authentication.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SHA1 is required by RFC 6238 and supported by all authenticator apps
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the duration of each time step.
+	Period = 30 * time.Second
+	// Digits is the number of digits in a generated code.
+	Digits = 6
+	// Skew is the number of time steps before and after the current step
+	// for which codes are accepted, to allow for clock drift.
+	Skew = 1
+
+	secretLength = 20
+
+	recoveryCodeLength = 10
+	// RecoveryCodeCount is the number of recovery codes generated at once.
+	RecoveryCodeCount = 10
+	// characters used in recovery codes, excluding ambiguous characters
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// Step returns the time step for the given time.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code returns the code for the secret at the given time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("decoding secret: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate checks the code against the secret at the given time. Codes
+// for time steps at or before lastUsedStep are rejected to prevent reuse.
+// Returns the matched time step if valid.
+func Validate(secret string, code string, t time.Time, lastUsedStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for i := -Skew; i <= Skew; i++ {
+		step := current + int64(i)
+		if step <= lastUsedStep {
+			continue
+		}
+
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns the otpauth URI used to enrol the secret in an authenticator app.
+func URI(issuer string, account string, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+
+	return u.String()
+}
+
+// GenerateRecoveryCodes returns RecoveryCodeCount new random recovery codes.
+func GenerateRecoveryCodes() ([]string, error) {
+	ret := make([]string, RecoveryCodeCount)
+	for i := range ret {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		// format as xxxxx-xxxxx for readability
+		ret[i] = string(b[:recoveryCodeLength/2]) + "-" + string(b[recoveryCodeLength/2:])
+	}
+
+	return ret, nil
+}
+
+// HashRecoveryCode returns the hash of the recovery code to be stored.
+// Recovery codes are random and high entropy, so a fast hash is sufficient.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret from the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// RFC 6238 SHA1 test vectors, truncated to 6 digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := Step(now)
+
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		{"current", "081804", 0, step, true},
+		{"spaces", "081 804", 0, step, true},
+		{"previous step", mustCode(t, step-1), 0, step - 1, true},
+		{"next step", mustCode(t, step+1), 0, step + 1, true},
+		{"outside skew", mustCode(t, step-2), 0, 0, false},
+		{"reused", "081804", step, 0, false},
+		{"wrong", "000000", 0, 0, false},
+		{"wrong length", "12345", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := Validate(rfcSecret, tt.code, now, tt.lastUsedStep)
+			assert.Equal(t, tt.wantOK, gotOK)
+			assert.Equal(t, tt.wantStep, gotStep)
+		})
+	}
+}
+
+func mustCode(t *testing.T, step int64) string {
+	c, err := Code(rfcSecret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		assert.Len(t, c, recoveryCodeLength+1)
+		assert.False(t, seen[c])
+		seen[c] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	assert.Equal(t, HashRecoveryCode("abcde-fghjk"), HashRecoveryCode(" ABCDEFGHJK "))
+	assert.NotEqual(t, HashRecoveryCode("abcde-fghjk"), HashRecoveryCode("abcde-fghjm"))
+}
+
+func TestURI(t *testing.T) {
+	got := URI("Stash", "user", "SECRET")
+	assert.True(t, strings.HasPrefix(got, "otpauth://totp/Stash:user?"))
+	assert.Contains(t, got, "secret=SECRET")
+}
@@ -0,0 +1,156 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const DefaultAPIModel = "whisper-1"
+
+// API transcribes audio using an OpenAI-compatible speech-to-text endpoint.
+type API struct {
+	// URL is the URL of the transcriptions endpoint, for example
+	// https://api.openai.com/v1/audio/transcriptions.
+	URL    string
+	APIKey string
+	// Model defaults to DefaultAPIModel if empty.
+	Model string
+	// Language is the spoken language. The language is detected if empty.
+	Language string
+	HTTP     *http.Client
+}
+
+type apiResponse struct {
+	Language string `json:"language"`
+	Text     string `json:"text"`
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+}
+
+func (r apiResponse) transcript() *Transcript {
+	ret := &Transcript{
+		Language: r.Language,
+	}
+
+	for _, s := range r.Segments {
+		text := strings.TrimSpace(s.Text)
+		if text == "" {
+			continue
+		}
+
+		ret.Segments = append(ret.Segments, models.TranscriptSegment{
+			Start: s.Start,
+			End:   s.End,
+			Text:  text,
+		})
+	}
+
+	// some endpoints do not return segments
+	if len(ret.Segments) == 0 && strings.TrimSpace(r.Text) != "" {
+		ret.Segments = []models.TranscriptSegment{{Text: strings.TrimSpace(r.Text)}}
+	}
+
+	return ret
+}
+
+func (a API) httpClient() *http.Client {
+	if a.HTTP != nil {
+		return a.HTTP
+	}
+	return http.DefaultClient
+}
+
+func (a API) model() string {
+	if a.Model == "" {
+		return DefaultAPIModel
+	}
+	return a.Model
+}
+
+// writeRequest writes the multipart request body.
+func (a API) writeRequest(w *multipart.Writer, audioPath string) error {
+	f, err := os.Open(audioPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fw, err := w.CreateFormFile("file", filepath.Base(audioPath))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fw, f); err != nil {
+		return err
+	}
+
+	fields := map[string]string{
+		"model":           a.model(),
+		"response_format": "verbose_json",
+	}
+	if a.Language != "" {
+		fields["language"] = a.Language
+	}
+
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func (a API) Transcribe(ctx context.Context, audioPath string) (*Transcript, error) {
+	// stream the audio rather than holding it in memory
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(a.writeRequest(mw, audioPath))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if a.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.APIKey)
+	}
+
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending transcription request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("transcription request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var out apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding transcription response: %w", err)
+	}
+
+	ret := out.transcript()
+	if ret.Language == "" {
+		ret.Language = a.Language
+	}
+
+	return ret, nil
+}
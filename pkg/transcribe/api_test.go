@@ -0,0 +1,85 @@
+package transcribe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestAPI_Transcribe(t *testing.T) {
+	const apiKey = "secret"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+apiKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(f)
+		if string(data) != "audio" {
+			http.Error(w, "unexpected file", http.StatusBadRequest)
+			return
+		}
+
+		if r.FormValue("model") != DefaultAPIModel || r.FormValue("response_format") != "verbose_json" || r.FormValue("language") != "en" {
+			http.Error(w, "unexpected fields", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"text":"hello there","segments":[{"start":0.5,"end":1.5,"text":" hello"},{"start":2,"end":3,"text":" "},{"start":3,"end":4.25,"text":"there "}]}`))
+	}))
+	defer server.Close()
+
+	audioPath := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(audioPath, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := API{
+		URL:      server.URL,
+		APIKey:   apiKey,
+		Language: "en",
+	}
+
+	got, err := a.Transcribe(context.Background(), audioPath)
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	assert.Equal(t, &Transcript{
+		Language: "en",
+		Segments: []models.TranscriptSegment{
+			{Start: 0.5, End: 1.5, Text: "hello"},
+			{Start: 3, End: 4.25, Text: "there"},
+		},
+	}, got)
+
+	a.APIKey = "wrong"
+	_, err = a.Transcribe(context.Background(), audioPath)
+	assert.Error(t, err)
+}
+
+func Test_apiResponse_transcript(t *testing.T) {
+	r := apiResponse{
+		Language: "english",
+		Text:     " text without segments ",
+	}
+
+	assert.Equal(t, &Transcript{
+		Language: "english",
+		Segments: []models.TranscriptSegment{{Text: "text without segments"}},
+	}, r.transcript())
+}
@@ -0,0 +1,26 @@
+// Package transcribe converts speech in audio files to text, using either a
+// local whisper.cpp binary or an external speech-to-text API.
+package transcribe
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ErrNotConfigured is returned when no speech-to-text engine is configured.
+var ErrNotConfigured = errors.New("transcription is not configured")
+
+// Transcript is the text of an audio file.
+type Transcript struct {
+	// Language is the detected or requested language, if known.
+	Language string
+	Segments []models.TranscriptSegment
+}
+
+// Transcriber transcribes audio files. The audio should be 16 kHz mono WAV,
+// as produced by transcoder.ExtractAudio.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audioPath string) (*Transcript, error)
+}
@@ -0,0 +1,108 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Whisper runs a whisper.cpp binary to transcribe audio.
+type Whisper struct {
+	// Path is the path of the whisper.cpp command line binary.
+	Path string
+	// Model is the path of the ggml model file.
+	Model string
+	// Language is the spoken language. The language is detected if empty.
+	Language string
+}
+
+// whisperOutput is the JSON output of whisper.cpp.
+type whisperOutput struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets struct {
+			// milliseconds
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text string `json:"text"`
+	} `json:"transcription"`
+}
+
+func (o whisperOutput) transcript() *Transcript {
+	ret := &Transcript{
+		Language: o.Result.Language,
+	}
+
+	for _, s := range o.Transcription {
+		text := strings.TrimSpace(s.Text)
+		if text == "" {
+			continue
+		}
+
+		ret.Segments = append(ret.Segments, models.TranscriptSegment{
+			Start: float64(s.Offsets.From) / 1000,
+			End:   float64(s.Offsets.To) / 1000,
+			Text:  text,
+		})
+	}
+
+	return ret
+}
+
+func (w Whisper) language() string {
+	if w.Language == "" {
+		return "auto"
+	}
+	return w.Language
+}
+
+func (w Whisper) Transcribe(ctx context.Context, audioPath string) (*Transcript, error) {
+	// the JSON output is written next to the audio file
+	outBase := strings.TrimSuffix(audioPath, ".wav")
+	outPath := outBase + ".json"
+	defer os.Remove(outPath)
+
+	args := []string{
+		"-m", w.Model,
+		"-f", audioPath,
+		"-l", w.language(),
+		"-oj",
+		"-of", outBase,
+		"-np",
+	}
+
+	cmd := exec.CommandContext(ctx, w.Path, args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running whisper: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading whisper output: %w", err)
+	}
+
+	var out whisperOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decoding whisper output: %w", err)
+	}
+
+	ret := out.transcript()
+	if ret.Language == "" {
+		ret.Language = w.Language
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,37 @@
+package transcribe
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const whisperJSON = `{
+	"result": {"language": "en"},
+	"transcription": [
+		{"timestamps": {"from": "00:00:00,000", "to": "00:00:02,500"}, "offsets": {"from": 0, "to": 2500}, "text": " Hello there."},
+		{"timestamps": {"from": "00:00:02,500", "to": "00:00:03,000"}, "offsets": {"from": 2500, "to": 3000}, "text": " "},
+		{"timestamps": {"from": "00:00:03,000", "to": "00:00:05,120"}, "offsets": {"from": 3000, "to": 5120}, "text": " General Kenobi."}
+	]
+}`
+
+func Test_whisperOutput_transcript(t *testing.T) {
+	var out whisperOutput
+	if err := json.Unmarshal([]byte(whisperJSON), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.transcript()
+	assert.Equal(t, &Transcript{
+		Language: "en",
+		Segments: []models.TranscriptSegment{
+			{Start: 0, End: 2.5, Text: "Hello there."},
+			{Start: 3, End: 5.12, Text: "General Kenobi."},
+		},
+	}, got)
+
+	assert.Equal(t, "Hello there. General Kenobi.", models.TranscriptText(got.Segments))
+}
@@ -3,14 +3,29 @@ package utils
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
-	"path/filepath"
+	"os"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/stashapp/stash/pkg/hash/md5"
 )
 
+// the number of file ETags to keep in memory
+const fileETagCacheSize = 4096
+
+type fileETagKey struct {
+	path    string
+	size    int64
+	modTime int64
+}
+
+// cache of file ETags, so that files are only hashed once per modification
+var fileETags, _ = lru.New[fileETagKey, string](fileETagCacheSize)
+
 // Returns an MD5 hash of data, formatted for use as an HTTP ETag header.
 // Intended for use with `http.ServeContent`, to respond to conditional requests.
 func GenerateETag(data []byte) string {
@@ -35,12 +50,11 @@ func ServeStaticContent(w http.ResponseWriter, r *http.Request, data []byte) {
 	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
 }
 
-// Serves static content at filepath, adding Cache-Control: no-cache.
-// Responds to conditional requests using the file modtime.
+// Serves static content at filepath, adding Cache-Control: no-cache and a
+// strong ETag header generated from the file contents.
+// Responds to conditional and range requests using the ETag and the file modtime.
 func ServeStaticFile(w http.ResponseWriter, r *http.Request, filepath string) {
-	setStaticContentCacheControl(w, r)
-
-	http.ServeFile(w, r, filepath)
+	serveStaticFile(w, r, filepath, nil)
 }
 
 func toHTTPError(err error) (msg string, httpStatus int) {
@@ -55,12 +69,13 @@ func toHTTPError(err error) (msg string, httpStatus int) {
 
 // ServeStaticFileModTime serves a static file at the given path using the given modTime instead of the file modTime.
 func ServeStaticFileModTime(w http.ResponseWriter, r *http.Request, path string, modTime time.Time) {
-	setStaticContentCacheControl(w, r)
+	serveStaticFile(w, r, path, &modTime)
+}
 
-	dir, file := filepath.Split(path)
-	fs := http.Dir(dir)
+func serveStaticFile(w http.ResponseWriter, r *http.Request, path string, modTime *time.Time) {
+	setStaticContentCacheControl(w, r)
 
-	f, err := fs.Open(file)
+	f, err := os.Open(path)
 	if err != nil {
 		msg, code := toHTTPError(err)
 		http.Error(w, msg, code)
@@ -69,11 +84,54 @@ func ServeStaticFileModTime(w http.ResponseWriter, r *http.Request, path string,
 	defer f.Close()
 
 	d, err := f.Stat()
+	if err == nil && d.IsDir() {
+		err = fs.ErrNotExist
+	}
 	if err != nil {
 		msg, code := toHTTPError(err)
 		http.Error(w, msg, code)
 		return
 	}
 
-	http.ServeContent(w, r, d.Name(), modTime, f)
+	etag, err := fileETag(f, path, d)
+	if err != nil {
+		msg, code := toHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+	w.Header().Set("ETag", etag)
+
+	mt := d.ModTime()
+	if modTime != nil {
+		mt = *modTime
+	}
+
+	http.ServeContent(w, r, d.Name(), mt, f)
+}
+
+// fileETag returns a strong ETag for the file, using the MD5 hash of its
+// contents. f is returned to the start of the file.
+func fileETag(f io.ReadSeeker, path string, info fs.FileInfo) (string, error) {
+	key := fileETagKey{
+		path:    path,
+		size:    info.Size(),
+		modTime: info.ModTime().UnixNano(),
+	}
+
+	if etag, found := fileETags.Get(key); found {
+		return etag, nil
+	}
+
+	hash, err := md5.FromReader(f)
+	if err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hash + `"`
+	fileETags.Add(key, etag)
+	return etag, nil
 }
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeStaticFile(t *testing.T) {
+	data := []byte("0123456789")
+	path := filepath.Join(t.TempDir(), "sprite.jpg")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(header map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/sprite", nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		ServeStaticFile(w, r, path)
+		return w
+	}
+
+	w := serve(nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, GenerateETag(data), w.Header().Get("ETag"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name     string
+		header   map[string]string
+		wantCode int
+		wantBody string
+	}{
+		{"if-none-match", map[string]string{"If-None-Match": etag}, http.StatusNotModified, ""},
+		{"if-none-match changed", map[string]string{"If-None-Match": `"other"`}, http.StatusOK, string(data)},
+		{"range", map[string]string{"Range": "bytes=2-4"}, http.StatusPartialContent, "234"},
+		{"if-range", map[string]string{"Range": "bytes=2-4", "If-Range": etag}, http.StatusPartialContent, "234"},
+		{"if-range changed", map[string]string{"Range": "bytes=2-4", "If-Range": `"other"`}, http.StatusOK, string(data)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.header)
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/sprite", nil)
+		w := httptest.NewRecorder()
+		ServeStaticFile(w, r, filepath.Join(t.TempDir(), "missing.jpg"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -1,20 +1,36 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
 	"time"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
 )
 
 // Timeout to get the image. Includes transfer time. May want to make this
 // configurable at some point.
 const imageGetTimeout = time.Second * 60
 
+// The maximum size of an image read from a URL.
+const maxImageURLSize = 50 * 1024 * 1024
+
+var (
+	ErrInvalidImage  = errors.New("not a supported image")
+	ErrImageTooLarge = fmt.Errorf("image is larger than %d bytes", maxImageURLSize)
+)
+
 const base64RE = `^data:.+\/(.+);base64,(.*)$`
 
 // ProcessImageInput transforms an image string either from a base64 encoded
@@ -31,7 +47,12 @@ func ProcessImageInput(ctx context.Context, imageInput string) ([]byte, error) {
 	}
 
 	// assume input is a URL. Read it.
-	return ReadImageFromURL(ctx, imageInput)
+	data, err := ReadImageFromURL(ctx, imageInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return PrepareImage(data)
 }
 
 // ReadImageFromURL returns image data from a URL
@@ -70,14 +91,69 @@ func ReadImageFromURL(ctx context.Context, url string) ([]byte, error) {
 
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageURLSize+1))
 	if err != nil {
 		return nil, err
 	}
 
+	if len(body) > maxImageURLSize {
+		return nil, ErrImageTooLarge
+	}
+
 	return body, nil
 }
 
+// ImageContentType returns the content type of the image data, or an empty
+// string if data is not a recognised image.
+func ImageContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return contentType
+	case strings.HasPrefix(contentType, "text/xml") || strings.HasPrefix(contentType, "text/plain"):
+		if bytes.Contains(data, []byte("<svg")) {
+			return "image/svg+xml"
+		}
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && (string(data[8:12]) == "avif" || string(data[8:12]) == "avis"):
+		return "image/avif"
+	case bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*")):
+		return "image/tiff"
+	}
+
+	return ""
+}
+
+// PrepareImage validates that data is a supported image, returning
+// ErrInvalidImage if it is not. Images in formats that browsers do not
+// display well are converted to PNG.
+func PrepareImage(data []byte) ([]byte, error) {
+	var decode func(io.Reader) (image.Image, error)
+
+	switch ImageContentType(data) {
+	case "":
+		return nil, ErrInvalidImage
+	case "image/bmp":
+		decode = bmp.Decode
+	case "image/tiff":
+		decode = tiff.Decode
+	default:
+		return data, nil
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("converting image to png: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // ProcessBase64Image transforms a base64 encoded string from a form post and
 // returns the image itself as a byte slice.
 func ProcessBase64Image(imageString string) ([]byte, error) {
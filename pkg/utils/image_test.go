@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/bmp"
+)
+
+func TestPrepareImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatal(err)
+	}
+
+	var bmpData bytes.Buffer
+	if err := bmp.Encode(&bmpData, img); err != nil {
+		t.Fatal(err)
+	}
+
+	svgData := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="2" height="2"></svg>`)
+
+	tests := []struct {
+		name            string
+		data            []byte
+		wantContentType string
+		wantErr         bool
+	}{
+		{"png", pngData.Bytes(), "image/png", false},
+		{"bmp", bmpData.Bytes(), "image/png", false},
+		{"svg", svgData, "image/svg+xml", false},
+		{"html", []byte("<!DOCTYPE html><html><body>Not found</body></html>"), "", true},
+		{"empty", []byte{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrepareImage(tt.data)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidImage)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContentType, ImageContentType(got))
+		})
+	}
+}
+
+func TestImageContentType(t *testing.T) {
+	avif := append([]byte{0, 0, 0, 0x1c}, []byte("ftypavif")...)
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+
+	assert.Equal(t, "image/avif", ImageContentType(avif))
+	assert.Equal(t, "image/tiff", ImageContentType(tiff))
+	assert.Equal(t, "", ImageContentType([]byte("plain text")))
+	assert.Equal(t, "image/gif", ImageContentType([]byte("GIF89a")))
+}
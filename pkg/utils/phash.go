@@ -2,9 +2,9 @@ package utils
 
 import (
 	"math"
+	"math/bits"
 	"strconv"
 
-	"github.com/corona10/goimagehash"
 	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
@@ -16,9 +16,56 @@ type Phash struct {
 	Bucket    int
 }
 
+// PhashDistanceFunc returns the distance between two phashes, between 0 and
+// 64.
+type PhashDistanceFunc func(a, b uint64) int
+
+// HammingDistance returns the number of differing bits of two phashes.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// phashWeights are the weights of each bit of a phash, in bit order. The
+// bits of low frequency DCT coefficients are weighted twice as much as
+// those of the highest frequency, since they change least when a video is
+// re-encoded, cropped or scaled. Weights sum to 64.
+var phashWeights = func() [64]float64 {
+	var ret [64]float64
+	total := 0.
+	for idx := range ret {
+		x, y := idx%8, idx/8
+		ret[idx] = 2 - float64(x+y)/14
+		total += ret[idx]
+	}
+
+	for idx := range ret {
+		ret[idx] *= 64 / total
+	}
+	return ret
+}()
+
+// WeightedDistance returns the distance between two phashes, weighting
+// differences in low frequency bits more than high frequency bits.
+func WeightedDistance(a, b uint64) int {
+	diff := a ^ b
+	ret := 0.
+	for idx, w := range phashWeights {
+		if diff&(1<<uint(64-idx-1)) != 0 {
+			ret += w
+		}
+	}
+
+	return int(math.Round(ret))
+}
+
 func FindDuplicates(hashes []*Phash, distance int, durationDiff float64) [][]int {
+	return FindDuplicatesWithDistance(hashes, distance, durationDiff, HammingDistance)
+}
+
+// FindDuplicatesWithDistance returns the groups of similar hashes, using
+// distanceFn to compare hashes.
+func FindDuplicatesWithDistance(hashes []*Phash, distance int, durationDiff float64, distanceFn PhashDistanceFunc) [][]int {
 	for i, scene := range hashes {
-		sceneHash := goimagehash.NewImageHash(uint64(scene.Hash), goimagehash.PHash)
 		for j, neighbor := range hashes {
 			if i != j && scene.SceneID != neighbor.SceneID {
 				neighbourDurationDistance := 0.
@@ -26,8 +73,7 @@ func FindDuplicates(hashes []*Phash, distance int, durationDiff float64) [][]int
 					neighbourDurationDistance = math.Abs(scene.Duration - neighbor.Duration)
 				}
 				if (neighbourDurationDistance <= durationDiff) || (durationDiff < 0) {
-					neighborHash := goimagehash.NewImageHash(uint64(neighbor.Hash), goimagehash.PHash)
-					neighborDistance, _ := sceneHash.Distance(neighborHash)
+					neighborDistance := distanceFn(uint64(scene.Hash), uint64(neighbor.Hash))
 					if neighborDistance <= distance {
 						scene.Neighbors = append(scene.Neighbors, j)
 					}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhashDistances(t *testing.T) {
+	const (
+		a      = uint64(0xf0f0f0f0f0f0f0f0)
+		lowBit = uint64(1) << 63
+	)
+
+	assert.Equal(t, 0, HammingDistance(a, a))
+	assert.Equal(t, 0, WeightedDistance(a, a))
+	assert.Equal(t, 64, HammingDistance(a, ^a))
+	assert.Equal(t, 64, WeightedDistance(a, ^a))
+
+	// the lowest frequency bit weighs more than the highest frequency bit
+	assert.Equal(t, 1, HammingDistance(a, a^lowBit))
+	assert.Equal(t, 1, HammingDistance(a, a^1))
+	assert.Greater(t, WeightedDistance(a, a^lowBit^(lowBit>>1)), WeightedDistance(a, a^1^2))
+}
+
+func TestFindDuplicatesWithDistance(t *testing.T) {
+	newHashes := func() []*Phash {
+		return []*Phash{
+			{SceneID: 1, Hash: 0x0f, Duration: 100, Bucket: -1},
+			{SceneID: 2, Hash: 0x0e, Duration: 100, Bucket: -1},
+			{SceneID: 3, Hash: 0x0f, Duration: 200, Bucket: -1},
+			{SceneID: 4, Hash: -1, Duration: 100, Bucket: -1},
+		}
+	}
+
+	got := FindDuplicates(newHashes(), 1, -1)
+	assert.Equal(t, [][]int{{1, 2, 3}}, got)
+
+	// durations outside the difference are not compared
+	got = FindDuplicates(newHashes(), 1, 10)
+	assert.Equal(t, [][]int{{1, 2}}, got)
+
+	// a distance function that never matches
+	got = FindDuplicatesWithDistance(newHashes(), 1, -1, func(a, b uint64) int { return 64 })
+	assert.Empty(t, got)
+}
@@ -10,3 +10,11 @@ func ExampleStrFormat() {
 	// Output:
 	// bar bar abc
 }
+
+func ExampleEditDistance() {
+	fmt.Println(EditDistance("kitten", "sitting"))
+	fmt.Println(EditDistance("zoë", "zoe"))
+	// Output:
+	// 3
+	// 1
+}
@@ -0,0 +1,198 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const apiKeyHeader = "ApiKey"
+
+// Client is used by workers to take jobs from the main instance.
+type Client struct {
+	// URL is the base URL of the main instance.
+	URL    string
+	APIKey string
+	HTTP   *http.Client
+
+	// WorkerID is set by Register.
+	WorkerID string
+}
+
+// StatusError is returned when the main instance responds with an
+// unexpected status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Message)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) jobPath(jobID string, elem ...string) string {
+	return "/worker/" + url.PathEscape(c.WorkerID) + "/jobs/" + url.PathEscape(jobID) + "/" + strings.Join(elem, "/")
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, out interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.URL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.APIKey != "" {
+		req.Header.Set(apiKeyHeader, c.APIKey)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		err := &StatusError{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+
+		if resp.StatusCode == http.StatusNotFound && err.Message == ErrUnknownWorker.Error() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownWorker, c.WorkerID)
+		}
+		return nil, err
+	}
+
+	if out != nil {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNoContent {
+			return resp, nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) doJSON(ctx context.Context, path string, in interface{}, out interface{}) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, path, body, out)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		resp.Body.Close()
+	}
+
+	return resp, nil
+}
+
+// RegisterInput is sent by workers to register with the main instance.
+type RegisterInput struct {
+	Name  string    `json:"name"`
+	Types []JobType `json:"types"`
+}
+
+// Register registers the worker with the main instance and sets WorkerID.
+func (c *Client) Register(ctx context.Context, name string, types []JobType) error {
+	var w Worker
+	if _, err := c.doJSON(ctx, "/worker/register", RegisterInput{Name: name, Types: types}, &w); err != nil {
+		return fmt.Errorf("registering worker: %w", err)
+	}
+
+	c.WorkerID = w.ID
+	return nil
+}
+
+// Next takes the next job from the main instance. Returns nil if there are
+// no jobs for the worker.
+func (c *Client) Next(ctx context.Context) (*Job, error) {
+	var job Job
+	resp, err := c.doJSON(ctx, "/worker/"+url.PathEscape(c.WorkerID)+"/next", nil, &job)
+	if err != nil {
+		return nil, fmt.Errorf("getting next job: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	return &job, nil
+}
+
+// Heartbeat tells the main instance that the worker is still running the job.
+func (c *Client) Heartbeat(ctx context.Context, jobID string) error {
+	if _, err := c.doJSON(ctx, c.jobPath(jobID, "heartbeat"), nil, nil); err != nil {
+		return fmt.Errorf("sending heartbeat: %w", err)
+	}
+	return nil
+}
+
+// DownloadSource writes the source file of the job to dest.
+func (c *Client) DownloadSource(ctx context.Context, jobID string, dest string) error {
+	resp, err := c.do(ctx, http.MethodGet, c.jobPath(jobID, "source"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("downloading source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("downloading source: %w", err)
+	}
+
+	return f.Close()
+}
+
+// UploadArtifact uploads the file at path as the named artifact of the job.
+func (c *Client) UploadArtifact(ctx context.Context, jobID string, name string, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := c.do(ctx, http.MethodPut, c.jobPath(jobID, "artifacts", url.PathEscape(name)), f, nil)
+	if err != nil {
+		return fmt.Errorf("uploading %s: %w", name, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// Complete reports the result of the job to the main instance.
+func (c *Client) Complete(ctx context.Context, jobID string, r Result) error {
+	if _, err := c.doJSON(ctx, c.jobPath(jobID, "complete"), r, nil); err != nil {
+		return fmt.Errorf("completing job: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	const apiKey = "key"
+
+	jobs := []Job{{ID: "job", Type: JobTypePhash}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/worker/register", func(w http.ResponseWriter, r *http.Request) {
+		var input RegisterInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, "name", input.Name)
+		_ = json.NewEncoder(w).Encode(Worker{ID: "worker"})
+	})
+	mux.HandleFunc("/worker/worker/next", func(w http.ResponseWriter, r *http.Request) {
+		if len(jobs) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jobs[0])
+		jobs = jobs[1:]
+	})
+	mux.HandleFunc("/worker/unknown/next", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, ErrUnknownWorker.Error(), http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(apiKeyHeader) != apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	c := &Client{URL: server.URL + "/", APIKey: "wrong"}
+	err := c.Register(ctx, "name", AllJobTypes)
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+
+	c.APIKey = apiKey
+	require.NoError(t, c.Register(ctx, "name", AllJobTypes))
+	assert.Equal(t, "worker", c.WorkerID)
+
+	job, err := c.Next(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, "job", job.ID)
+
+	job, err = c.Next(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	c.WorkerID = "unknown"
+	_, err = c.Next(ctx)
+	assert.ErrorIs(t, err, ErrUnknownWorker)
+}
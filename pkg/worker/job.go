@@ -0,0 +1,104 @@
+// Package worker distributes generate jobs to remote stash instances.
+//
+// The main instance holds a Pool of jobs. Remote workers register with the
+// main instance, take jobs from the pool, download the source file of each
+// job, generate the artifacts locally and upload them back to the main
+// instance.
+package worker
+
+import (
+	"errors"
+
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+var (
+	ErrUnknownWorker   = errors.New("unknown worker")
+	ErrUnknownJob      = errors.New("unknown job")
+	ErrUnknownArtifact = errors.New("unknown artifact")
+	ErrNoWorkers       = errors.New("no remote workers available")
+)
+
+type JobType string
+
+const (
+	JobTypePreview   JobType = "preview"
+	JobTypeSprite    JobType = "sprite"
+	JobTypePhash     JobType = "phash"
+	JobTypeTranscode JobType = "transcode"
+)
+
+// AllJobTypes are the job types supported by workers.
+var AllJobTypes = []JobType{
+	JobTypePreview,
+	JobTypeSprite,
+	JobTypePhash,
+	JobTypeTranscode,
+}
+
+// Names of the artifacts uploaded by workers.
+const (
+	ArtifactPreviewVideo = "preview.mp4"
+	ArtifactPreviewImage = "preview.webp"
+	ArtifactSpriteImage  = "sprite.jpg"
+	ArtifactSpriteVTT    = "sprite.vtt"
+	ArtifactTranscode    = "transcode.mp4"
+)
+
+// Job is a generate job sent to a worker.
+type Job struct {
+	ID   string  `json:"id"`
+	Type JobType `json:"type"`
+	// Hash is used to name the generated files.
+	Hash string `json:"hash"`
+	// Basename is the basename of the source file.
+	Basename string `json:"basename"`
+	// Duration is the duration of the source video in seconds.
+	Duration float64 `json:"duration"`
+	// Artifacts are the names of the artifacts to upload.
+	Artifacts []string `json:"artifacts"`
+
+	Preview   *PreviewOptions   `json:"preview,omitempty"`
+	Transcode *TranscodeOptions `json:"transcode,omitempty"`
+}
+
+// HasArtifact returns true if the job expects the named artifact.
+func (j Job) HasArtifact(name string) bool {
+	for _, a := range j.Artifacts {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+type PreviewOptions struct {
+	Options generate.PreviewOptions `json:"options"`
+}
+
+type TranscodeMode string
+
+const (
+	// TranscodeModeFull transcodes video and audio.
+	TranscodeModeFull TranscodeMode = "full"
+	// TranscodeModeVideo transcodes video, discarding unsupported audio.
+	TranscodeModeVideo TranscodeMode = "video"
+	// TranscodeModeAudio copies the video and transcodes audio.
+	TranscodeModeAudio TranscodeMode = "audio"
+	// TranscodeModeCopyVideo copies the video, discarding unsupported audio.
+	TranscodeModeCopyVideo TranscodeMode = "copy_video"
+)
+
+type TranscodeOptions struct {
+	Mode   TranscodeMode `json:"mode"`
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+}
+
+// Result is the outcome of a job reported by a worker.
+type Result struct {
+	// Error is set if the job failed.
+	Error string `json:"error,omitempty"`
+	// Phash is the generated hash of phash jobs.
+	Phash *uint64 `json:"phash,omitempty"`
+}
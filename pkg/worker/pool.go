@@ -0,0 +1,348 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLeaseTimeout is how long a worker may hold a job without
+	// sending a heartbeat before the job is offered to other workers.
+	DefaultLeaseTimeout = 5 * time.Minute
+	// DefaultWorkerTimeout is how long a worker may go without contacting
+	// the pool before it is no longer considered active.
+	DefaultWorkerTimeout = 2 * time.Minute
+
+	defaultCheckInterval = 10 * time.Second
+)
+
+// Worker is a registered remote worker.
+type Worker struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Types    []JobType `json:"types"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+func (w Worker) supports(t JobType) bool {
+	for _, tt := range w.Types {
+		if tt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Task is a job to dispatch to a worker, with the paths used by the main
+// instance to serve and store its files.
+type Task struct {
+	Job
+	// SourcePath is the path of the file served to the worker.
+	SourcePath string
+	// Artifacts maps the names of the artifacts to the paths they are
+	// written to.
+	Artifacts map[string]string
+}
+
+type dispatched struct {
+	task Task
+	// workerID is the worker holding the job, or empty if the job is pending.
+	workerID     string
+	leaseExpires time.Time
+	done         chan Result
+}
+
+// Pool holds the jobs dispatched to remote workers.
+type Pool struct {
+	LeaseTimeout  time.Duration
+	WorkerTimeout time.Duration
+
+	checkInterval time.Duration
+	now           func() time.Time
+
+	mutex   sync.Mutex
+	workers map[string]*Worker
+	jobs    map[string]*dispatched
+	// pending jobs in dispatch order
+	pending []*dispatched
+}
+
+func NewPool() *Pool {
+	return &Pool{
+		LeaseTimeout:  DefaultLeaseTimeout,
+		WorkerTimeout: DefaultWorkerTimeout,
+		checkInterval: defaultCheckInterval,
+		now:           time.Now,
+		workers:       make(map[string]*Worker),
+		jobs:          make(map[string]*dispatched),
+	}
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Register adds a worker that accepts jobs of the given types.
+func (p *Pool) Register(name string, types []JobType) Worker {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	w := &Worker{
+		ID:       newID(),
+		Name:     name,
+		Types:    types,
+		LastSeen: p.now(),
+	}
+	p.workers[w.ID] = w
+
+	return *w
+}
+
+// Workers returns the registered workers, ordered by name.
+func (p *Pool) Workers() []Worker {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var ret []Worker
+	for _, w := range p.workers {
+		ret = append(ret, *w)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+
+	return ret
+}
+
+// Active returns true if an active worker accepts jobs of type t.
+func (p *Pool) Active(t JobType) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.activeLocked(t)
+}
+
+func (p *Pool) activeLocked(t JobType) bool {
+	for _, w := range p.workers {
+		if w.supports(t) && p.now().Sub(w.LastSeen) < p.WorkerTimeout {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatch queues the task and waits until a worker completes it. Returns
+// ErrNoWorkers if the task is pending while no active worker accepts it.
+// The task is removed from the pool if ctx is cancelled.
+func (p *Pool) Dispatch(ctx context.Context, t Task) (*Result, error) {
+	t.ID = newID()
+	t.Job.Artifacts = nil
+	for name := range t.Artifacts {
+		t.Job.Artifacts = append(t.Job.Artifacts, name)
+	}
+	sort.Strings(t.Job.Artifacts)
+
+	d := &dispatched{
+		task: t,
+		done: make(chan Result, 1),
+	}
+
+	p.mutex.Lock()
+	p.jobs[t.ID] = d
+	p.pending = append(p.pending, d)
+	p.mutex.Unlock()
+
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case r := <-d.done:
+			return &r, nil
+		case <-ctx.Done():
+			p.remove(d)
+			return nil, ctx.Err()
+		case <-ticker.C:
+			if p.abandoned(d) {
+				return nil, fmt.Errorf("%w for %s jobs", ErrNoWorkers, t.Type)
+			}
+		}
+	}
+}
+
+// abandoned returns true, removing the job, if it is pending while no
+// active worker accepts it.
+func (p *Pool) abandoned(d *dispatched) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.expireLocked()
+
+	if d.workerID != "" || p.activeLocked(d.task.Type) {
+		return false
+	}
+
+	p.removeLocked(d)
+	return true
+}
+
+func (p *Pool) remove(d *dispatched) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.removeLocked(d)
+}
+
+func (p *Pool) removeLocked(d *dispatched) {
+	delete(p.jobs, d.task.ID)
+	for i, pd := range p.pending {
+		if pd == d {
+			p.pending = append(p.pending[:i], p.pending[i+1:]...)
+			break
+		}
+	}
+}
+
+// expireLocked returns jobs with expired leases to the front of the queue.
+func (p *Pool) expireLocked() {
+	now := p.now()
+
+	var expired []*dispatched
+	for _, d := range p.jobs {
+		if d.workerID != "" && now.After(d.leaseExpires) {
+			d.workerID = ""
+			expired = append(expired, d)
+		}
+	}
+
+	if len(expired) > 0 {
+		p.pending = append(expired, p.pending...)
+	}
+}
+
+func (p *Pool) touchLocked(workerID string) (*Worker, error) {
+	w := p.workers[workerID]
+	if w == nil {
+		return nil, ErrUnknownWorker
+	}
+
+	w.LastSeen = p.now()
+	return w, nil
+}
+
+// Next assigns the next pending job accepted by the worker to it. Returns
+// nil if there are no pending jobs for the worker.
+func (p *Pool) Next(workerID string) (*Job, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	w, err := p.touchLocked(workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.expireLocked()
+
+	for i, d := range p.pending {
+		if !w.supports(d.task.Type) {
+			continue
+		}
+
+		p.pending = append(p.pending[:i], p.pending[i+1:]...)
+		d.workerID = workerID
+		d.leaseExpires = p.now().Add(p.LeaseTimeout)
+
+		job := d.task.Job
+		return &job, nil
+	}
+
+	return nil, nil
+}
+
+// claimedLocked returns the job if it is held by the worker.
+func (p *Pool) claimedLocked(workerID string, jobID string) (*dispatched, error) {
+	if _, err := p.touchLocked(workerID); err != nil {
+		return nil, err
+	}
+
+	d := p.jobs[jobID]
+	if d == nil || d.workerID != workerID {
+		return nil, ErrUnknownJob
+	}
+
+	return d, nil
+}
+
+// Heartbeat extends the lease of the worker on the job.
+func (p *Pool) Heartbeat(workerID string, jobID string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	d, err := p.claimedLocked(workerID, jobID)
+	if err != nil {
+		return err
+	}
+
+	d.leaseExpires = p.now().Add(p.LeaseTimeout)
+	return nil
+}
+
+// SourcePath returns the path of the source file of the job held by the
+// worker.
+func (p *Pool) SourcePath(workerID string, jobID string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	d, err := p.claimedLocked(workerID, jobID)
+	if err != nil {
+		return "", err
+	}
+
+	return d.task.SourcePath, nil
+}
+
+// ArtifactPath returns the path that the named artifact of the job held by
+// the worker is written to.
+func (p *Pool) ArtifactPath(workerID string, jobID string, name string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	d, err := p.claimedLocked(workerID, jobID)
+	if err != nil {
+		return "", err
+	}
+
+	ret, found := d.task.Artifacts[name]
+	if !found {
+		return "", fmt.Errorf("%w: %s", ErrUnknownArtifact, name)
+	}
+
+	return ret, nil
+}
+
+// Complete removes the job held by the worker from the pool, returning the
+// result to the dispatcher.
+func (p *Pool) Complete(workerID string, jobID string, r Result) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	d, err := p.claimedLocked(workerID, jobID)
+	if err != nil {
+		return err
+	}
+
+	p.removeLocked(d)
+	d.done <- r
+
+	return nil
+}
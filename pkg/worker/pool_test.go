@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	mutex sync.Mutex
+	t     time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.t
+}
+
+func (c *testClock) add(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestPool() (*Pool, *testClock) {
+	clock := &testClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	p := NewPool()
+	p.now = clock.now
+	p.checkInterval = time.Millisecond
+	return p, clock
+}
+
+// waitForPending waits until n jobs are pending in the pool.
+func waitForPending(t *testing.T, p *Pool, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		return len(p.pending) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestPool_Dispatch(t *testing.T) {
+	p, _ := newTestPool()
+	w := p.Register("worker", []JobType{JobTypeSprite})
+
+	type dispatchResult struct {
+		r   *Result
+		err error
+	}
+	results := make(chan dispatchResult, 1)
+	go func() {
+		r, err := p.Dispatch(context.Background(), Task{
+			Job:        Job{Type: JobTypeSprite, Hash: "hash"},
+			SourcePath: "/videos/video.mp4",
+			Artifacts: map[string]string{
+				ArtifactSpriteVTT:   "/generated/hash_thumbs.vtt",
+				ArtifactSpriteImage: "/generated/hash_sprite.jpg",
+			},
+		})
+		results <- dispatchResult{r, err}
+	}()
+	waitForPending(t, p, 1)
+
+	// worker does not accept other job types
+	other := p.Register("other", []JobType{JobTypePhash})
+	job, err := p.Next(other.ID)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	job, err = p.Next(w.ID)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, "hash", job.Hash)
+	assert.Equal(t, []string{ArtifactSpriteImage, ArtifactSpriteVTT}, job.Artifacts)
+
+	source, err := p.SourcePath(w.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/videos/video.mp4", source)
+
+	dest, err := p.ArtifactPath(w.ID, job.ID, ArtifactSpriteVTT)
+	require.NoError(t, err)
+	assert.Equal(t, "/generated/hash_thumbs.vtt", dest)
+
+	_, err = p.ArtifactPath(w.ID, job.ID, "../../etc/passwd")
+	assert.ErrorIs(t, err, ErrUnknownArtifact)
+
+	// only the worker holding the job may access it
+	_, err = p.SourcePath(other.ID, job.ID)
+	assert.ErrorIs(t, err, ErrUnknownJob)
+
+	require.NoError(t, p.Complete(w.ID, job.ID, Result{Error: "failed"}))
+
+	got := <-results
+	require.NoError(t, got.err)
+	assert.Equal(t, "failed", got.r.Error)
+
+	assert.ErrorIs(t, p.Complete(w.ID, job.ID, Result{}), ErrUnknownJob)
+}
+
+func TestPool_Next_unknownWorker(t *testing.T) {
+	p, _ := newTestPool()
+	_, err := p.Next("unknown")
+	assert.ErrorIs(t, err, ErrUnknownWorker)
+}
+
+func TestPool_Dispatch_noWorkers(t *testing.T) {
+	p, clock := newTestPool()
+	w := p.Register("worker", []JobType{JobTypePhash})
+
+	assert.True(t, p.Active(JobTypePhash))
+	assert.False(t, p.Active(JobTypeSprite))
+
+	// worker stops polling
+	clock.add(p.WorkerTimeout)
+	assert.False(t, p.Active(JobTypePhash))
+
+	_, err := p.Dispatch(context.Background(), Task{Job: Job{Type: JobTypePhash}})
+	assert.ErrorIs(t, err, ErrNoWorkers)
+
+	_, err = p.Next(w.ID)
+	require.NoError(t, err)
+	assert.True(t, p.Active(JobTypePhash))
+}
+
+func TestPool_Dispatch_cancelled(t *testing.T) {
+	p, _ := newTestPool()
+	w := p.Register("worker", []JobType{JobTypePhash})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := p.Dispatch(ctx, Task{Job: Job{Type: JobTypePhash}})
+		errs <- err
+	}()
+	waitForPending(t, p, 1)
+
+	cancel()
+	assert.True(t, errors.Is(<-errs, context.Canceled))
+
+	job, err := p.Next(w.ID)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestPool_leaseExpiry(t *testing.T) {
+	p, clock := newTestPool()
+	// keep the workers active while the clock is advanced
+	p.WorkerTimeout = time.Hour
+	w1 := p.Register("worker1", []JobType{JobTypePhash})
+	w2 := p.Register("worker2", []JobType{JobTypePhash})
+
+	go func() {
+		_, _ = p.Dispatch(context.Background(), Task{Job: Job{Type: JobTypePhash}})
+	}()
+	waitForPending(t, p, 1)
+
+	job, err := p.Next(w1.ID)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	// heartbeats keep the lease
+	clock.add(p.LeaseTimeout - time.Second)
+	require.NoError(t, p.Heartbeat(w1.ID, job.ID))
+	clock.add(p.LeaseTimeout - time.Second)
+
+	other, err := p.Next(w2.ID)
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	// the job is reassigned once the lease expires
+	clock.add(2 * time.Second)
+	other, err = p.Next(w2.ID)
+	require.NoError(t, err)
+	require.NotNil(t, other)
+	assert.Equal(t, job.ID, other.ID)
+
+	assert.ErrorIs(t, p.Heartbeat(w1.ID, job.ID), ErrUnknownJob)
+	require.NoError(t, p.Complete(w2.ID, job.ID, Result{}))
+}
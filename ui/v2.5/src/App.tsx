@@ -76,6 +76,13 @@ const SceneFilenameParser = lazyComponent(
 const SceneDuplicateChecker = lazyComponent(
   () => import("./components/SceneDuplicateChecker/SceneDuplicateChecker")
 );
+const PerformerDuplicateChecker = lazyComponent(
+  () =>
+    import("./components/PerformerDuplicateChecker/PerformerDuplicateChecker")
+);
+const TagCleanup = lazyComponent(
+  () => import("./components/TagCleanup/TagCleanup")
+);
 
 const appleRendering = isPlatformUniquelyRenderedByApple();
 
@@ -246,6 +253,11 @@ export const App: React.FC = () => {
               path="/sceneDuplicateChecker"
               component={SceneDuplicateChecker}
             />
+            <Route
+              path="/performerDuplicateChecker"
+              component={PerformerDuplicateChecker}
+            />
+            <Route path="/tagCleanup" component={TagCleanup} />
             <Route path="/setup" component={Setup} />
             <Route path="/migrate" component={Migrate} />
             <PluginRoutes />
@@ -61,6 +61,9 @@ export const GenerateDialog: React.FC<ISceneGenerateDialog> = ({
       const { generate } = configuration.defaults;
       setOptions(withoutTypename(generate));
       setConfigRead(true);
+    } else if (configuration?.general.lowMemoryMode) {
+      // phashes are not generated by default in low memory mode
+      setOptions((existing) => ({ ...existing, phashes: false }));
     }
 
     if (configuration?.general) {
@@ -19,7 +19,7 @@ import {
 } from "src/components/Tagger/constants";
 import { DirectorySelectionDialog } from "src/components/Settings/Tasks/DirectorySelectionDialog";
 import { Manual } from "src/components/Help/Manual";
-import { IScraperSource } from "./constants";
+import { IScraperSource, LOCAL_FILES_ID } from "./constants";
 import { OptionsEditor } from "./Options";
 import { SourcesEditor, SourcesList } from "./Sources";
 import {
@@ -125,8 +125,16 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
       })
     );
 
+    ret.push({
+      id: LOCAL_FILES_ID,
+      displayName: intl.formatMessage({
+        id: "config.tasks.identify.local_files",
+      }),
+      local_files: true,
+    });
+
     return ret;
-  }, [configData, scraperData]);
+  }, [configData, scraperData, intl]);
 
   const selectionStatus = useMemo(() => {
     if (selectedIds) {
@@ -212,7 +220,8 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
           const found = allSources.find(
             (ss) =>
               ss.scraper_id === s.source.scraper_id ||
-              ss.stash_box_endpoint === s.source.stash_box_endpoint
+              ss.stash_box_endpoint === s.source.stash_box_endpoint ||
+              (ss.local_files && s.source.local_files)
           );
 
           if (!found) return;
@@ -280,6 +289,7 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
           source: {
             scraper_id: s.scraper_id,
             stash_box_endpoint: s.stash_box_endpoint,
+            local_files: s.local_files,
           },
           options: s.options,
         };
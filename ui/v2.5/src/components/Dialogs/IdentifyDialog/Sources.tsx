@@ -50,6 +50,7 @@ export const SourcesEditor: React.FC<ISourceEditor> = ({
       displayName: selectedSource.displayName,
       scraper_id: selectedSource.scraper_id,
       stash_box_endpoint: selectedSource.stash_box_endpoint,
+      local_files: selectedSource.local_files,
     });
   }
 
@@ -77,7 +78,10 @@ export const SourcesEditor: React.FC<ISourceEditor> = ({
         variant: "secondary",
       }}
       disabled={
-        (!source.scraper_id && !source.stash_box_endpoint) || editingField
+        (!source.scraper_id &&
+          !source.stash_box_endpoint &&
+          !source.local_files) ||
+        editingField
       }
     >
       <Form>
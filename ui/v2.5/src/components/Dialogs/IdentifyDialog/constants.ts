@@ -5,9 +5,12 @@ export interface IScraperSource {
   displayName: string;
   stash_box_endpoint?: string;
   scraper_id?: string;
+  local_files?: boolean;
   options?: GQL.IdentifyMetadataOptionsInput;
 }
 
+export const LOCAL_FILES_ID = "local-files";
+
 export const sceneFields = [
   "title",
   "code",
@@ -202,6 +202,12 @@ export const GalleryPage: React.FC<IProps> = ({ gallery, add }) => {
               <FormattedMessage id="actions.rescan" />
             </Dropdown.Item>
           ) : undefined}
+          <Dropdown.Item
+            className="bg-secondary text-white"
+            href={gallery.paths.download}
+          >
+            <FormattedMessage id="actions.download" />
+          </Dropdown.Item>
           <Dropdown.Item
             className="bg-secondary text-white"
             onClick={() => onResetCover()}
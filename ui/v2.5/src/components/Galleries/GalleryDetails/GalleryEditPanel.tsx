@@ -22,7 +22,7 @@ import {
   PerformerSelect,
 } from "src/components/Performers/PerformerSelect";
 import {
-  yupDateString,
+  yupFuzzyDateString,
   yupFormikValidate,
   yupUniqueStringList,
 } from "src/utils/yup";
@@ -69,7 +69,7 @@ export const GalleryEditPanel: React.FC<IProps> = ({
     title: titleRequired ? yup.string().required() : yup.string().ensure(),
     code: yup.string().ensure(),
     urls: yupUniqueStringList(intl),
-    date: yupDateString(intl),
+    date: yupFuzzyDateString(intl),
     photographer: yup.string().ensure(),
     studio_id: yup.string().required().nullable(),
     performer_ids: yup.array(yup.string().required()).defined(),
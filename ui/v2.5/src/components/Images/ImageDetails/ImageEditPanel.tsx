@@ -10,7 +10,7 @@ import { useFormik } from "formik";
 import { Prompt } from "react-router-dom";
 import isEqual from "lodash-es/isEqual";
 import {
-  yupDateString,
+  yupFuzzyDateString,
   yupFormikValidate,
   yupUniqueStringList,
 } from "src/utils/yup";
@@ -66,7 +66,7 @@ export const ImageEditPanel: React.FC<IProps> = ({
     title: yup.string().ensure(),
     code: yup.string().ensure(),
     urls: yupUniqueStringList(intl),
-    date: yupDateString(intl),
+    date: yupFuzzyDateString(intl),
     details: yup.string().ensure(),
     photographer: yup.string().ensure(),
     gallery_ids: yup.array(yup.string().required()).defined(),
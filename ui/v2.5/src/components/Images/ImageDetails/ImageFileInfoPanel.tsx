@@ -57,6 +57,14 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
             </dd>
           </>
         )}
+        {props.file.pending_content && (
+          <>
+            <dt></dt>
+            <dd className="pending-content">
+              <FormattedMessage id="pending_content" />
+            </dd>
+          </>
+        )}
         <TextField id="media_info.checksum" value={checksum?.value} truncate />
         <URLField
           id="path"
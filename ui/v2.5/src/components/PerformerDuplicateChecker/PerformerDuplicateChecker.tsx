@@ -0,0 +1,405 @@
+import React, { useState } from "react";
+import { Badge, Button, Card, Col, Form, Row, Table } from "react-bootstrap";
+import { Link, useHistory } from "react-router-dom";
+import { FormattedMessage, useIntl } from "react-intl";
+import { faObjectGroup } from "@fortawesome/free-solid-svg-icons";
+
+import * as GQL from "src/core/generated-graphql";
+import { usePerformerMerge } from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+import { LoadingIndicator } from "../Shared/LoadingIndicator";
+import { ErrorMessage } from "../Shared/ErrorMessage";
+import { ModalComponent } from "../Shared/Modal";
+import { TagLink } from "../Shared/TagLink";
+
+const defaultNameSimilarity = "0.9";
+const defaultImageDistance = "4";
+
+type Performer = GQL.SlimPerformerDataFragment;
+
+interface IMergeModalProps {
+  destination: Performer;
+  sources: Performer[];
+  onClose: (merged: boolean) => void;
+}
+
+const PerformerMergePreviewModal: React.FC<IMergeModalProps> = ({
+  destination,
+  sources,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [running, setRunning] = useState(false);
+  const [mergePerformers] = usePerformerMerge();
+
+  const source = sources.map((s) => s.id);
+  const { data, loading, error } = GQL.usePerformerMergePreviewQuery({
+    fetchPolicy: "no-cache",
+    variables: { source, destination: destination.id },
+  });
+
+  async function onMerge() {
+    try {
+      setRunning(true);
+      const result = await mergePerformers({
+        variables: { source, destination: destination.id },
+      });
+      if (result.data?.performerMerge) {
+        Toast.success(intl.formatMessage({ id: "toast.merged_performers" }));
+        onClose(true);
+      }
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setRunning(false);
+    }
+  }
+
+  function renderPreview() {
+    if (loading) return <LoadingIndicator />;
+    if (error) return <ErrorMessage error={error.message} />;
+
+    const preview = data?.performerMergePreview;
+    if (!preview) return;
+
+    const counts: [string, number][] = [
+      ["scenes", preview.scene_count],
+      ["images", preview.image_count],
+      ["galleries", preview.gallery_count],
+      ["markers", preview.marker_count],
+      ["performer_dupe_check.performer_images", preview.performer_image_count],
+    ];
+
+    return (
+      <>
+        <p>
+          <FormattedMessage
+            id="performer_dupe_check.merge_preview"
+            values={{ name: destination.name }}
+          />
+        </p>
+        <Table size="sm" borderless>
+          <tbody>
+            {counts.map(([id, count]) => (
+              <tr key={id}>
+                <td>
+                  <FormattedMessage id={id} />
+                </td>
+                <td>{count}</td>
+              </tr>
+            ))}
+            <tr>
+              <td>
+                <FormattedMessage id="aliases" />
+              </td>
+              <td>{preview.aliases.join(", ")}</td>
+            </tr>
+            <tr>
+              <td>
+                <FormattedMessage id="urls" />
+              </td>
+              <td>
+                {preview.urls.map((u) => (
+                  <div key={u}>{u}</div>
+                ))}
+              </td>
+            </tr>
+            <tr>
+              <td>
+                <FormattedMessage id="tags" />
+              </td>
+              <td>
+                {preview.tags.map((t) => (
+                  <TagLink key={t.id} tag={t} linkType="performer" />
+                ))}
+              </td>
+            </tr>
+            <tr>
+              <td>
+                <FormattedMessage id="stash_ids" />
+              </td>
+              <td>
+                {preview.stash_ids.map((s) => (
+                  <div key={`${s.endpoint}${s.stash_id}`}>{s.stash_id}</div>
+                ))}
+              </td>
+            </tr>
+          </tbody>
+        </Table>
+      </>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      header={intl.formatMessage({ id: "actions.merge" })}
+      icon={faObjectGroup}
+      accept={{
+        text: intl.formatMessage({ id: "actions.merge" }),
+        onClick: () => onMerge(),
+      }}
+      disabled={loading || !!error}
+      cancel={{
+        variant: "secondary",
+        onClick: () => onClose(false),
+      }}
+      isRunning={running}
+    >
+      {renderPreview()}
+    </ModalComponent>
+  );
+};
+
+export const PerformerDuplicateChecker: React.FC = () => {
+  const intl = useIntl();
+  const history = useHistory();
+  const query = new URLSearchParams(history.location.search);
+  const nameSimilarity = Number.parseFloat(
+    query.get("nameSimilarity") ?? defaultNameSimilarity
+  );
+  const imageDistance = query.get("imageDistance");
+
+  // the performer to keep in each group, keyed by the first performer id
+  const [destinations, setDestinations] = useState<Record<string, string>>(
+    {}
+  );
+  const [merging, setMerging] = useState<Performer[]>();
+
+  const { data, loading, error, refetch } =
+    GQL.useFindDuplicatePerformersQuery({
+      fetchPolicy: "no-cache",
+      variables: {
+        input: {
+          name_similarity: nameSimilarity,
+          phash_distance: imageDistance
+            ? Number.parseInt(imageDistance, 10)
+            : undefined,
+        },
+      },
+    });
+
+  const groups = data?.findDuplicatePerformers ?? [];
+
+  const setQuery = (q: Record<string, string | undefined>) => {
+    const newQuery = new URLSearchParams(query);
+    for (const key of Object.keys(q)) {
+      const value = q[key];
+      if (value !== undefined) {
+        newQuery.set(key, value);
+      } else {
+        newQuery.delete(key);
+      }
+    }
+    history.push({ search: newQuery.toString() });
+  };
+
+  function groupDestination(performers: Performer[]) {
+    const id = destinations[performers[0].id];
+    return performers.find((p) => p.id === id) ?? performers[0];
+  }
+
+  function onMergeClosed(merged: boolean) {
+    setMerging(undefined);
+    if (merged) {
+      refetch();
+    }
+  }
+
+  function renderMergeModal() {
+    if (!merging) return;
+
+    const destination = groupDestination(merging);
+    return (
+      <PerformerMergePreviewModal
+        destination={destination}
+        sources={merging.filter((p) => p !== destination)}
+        onClose={onMergeClosed}
+      />
+    );
+  }
+
+  function renderGroup(
+    group: GQL.FindDuplicatePerformersQuery["findDuplicatePerformers"][0]
+  ) {
+    const { performers } = group;
+    const destination = groupDestination(performers);
+
+    return (
+      <tbody key={performers[0].id}>
+        {performers.map((p, i) => (
+          <tr key={p.id}>
+            <td>
+              <Form.Check
+                type="radio"
+                name={`destination-${performers[0].id}`}
+                checked={p === destination}
+                onChange={() =>
+                  setDestinations({
+                    ...destinations,
+                    [performers[0].id]: p.id,
+                  })
+                }
+              />
+            </td>
+            <td>
+              {p.image_path && (
+                <img
+                  className="performer-image"
+                  src={p.image_path}
+                  alt={p.name}
+                />
+              )}
+            </td>
+            <td>
+              <Link to={`/performers/${p.id}`}>{p.name}</Link>
+              {p.disambiguation && (
+                <span className="performer-disambiguation">
+                  {` (${p.disambiguation})`}
+                </span>
+              )}
+            </td>
+            <td>
+              {i === 0 &&
+                group.reasons.map((r) => (
+                  <Badge key={r} variant="secondary" className="mr-1">
+                    <FormattedMessage
+                      id={`performer_dupe_check.reasons.${r.toLowerCase()}`}
+                    />
+                  </Badge>
+                ))}
+            </td>
+            <td>
+              {i === 0 && (
+                <Button
+                  size="sm"
+                  variant="secondary"
+                  onClick={() => setMerging(performers)}
+                >
+                  <FormattedMessage id="actions.merge" />
+                </Button>
+              )}
+            </td>
+          </tr>
+        ))}
+        <tr className="separator" />
+      </tbody>
+    );
+  }
+
+  function renderResults() {
+    if (loading) return <LoadingIndicator />;
+    if (error) return <ErrorMessage error={error.message} />;
+
+    return (
+      <>
+        <h6>
+          <FormattedMessage
+            id="performer_dupe_check.found_sets"
+            values={{ setCount: groups.length }}
+          />
+        </h6>
+        {groups.length > 0 && (
+          <Table responsive striped className="duplicate-checker-table">
+            <thead>
+              <tr>
+                <th>
+                  <FormattedMessage id="performer_dupe_check.destination" />
+                </th>
+                <th />
+                <th>
+                  <FormattedMessage id="name" />
+                </th>
+                <th />
+                <th />
+              </tr>
+            </thead>
+            {groups.map(renderGroup)}
+          </Table>
+        )}
+      </>
+    );
+  }
+
+  return (
+    <Card id="performer-duplicate-checker" className="col col-xl-12 mx-auto">
+      {renderMergeModal()}
+      <h4>
+        <FormattedMessage id="performer_dupe_check.title" />
+      </h4>
+      <Form>
+        <Form.Group>
+          <Row noGutters>
+            <Form.Label>
+              <FormattedMessage id="performer_dupe_check.name_similarity" />
+            </Form.Label>
+            <Col xs="auto">
+              <Form.Control
+                type="number"
+                min={0}
+                max={1}
+                step={0.05}
+                defaultValue={nameSimilarity}
+                onBlur={(e: React.FocusEvent<HTMLInputElement>) =>
+                  setQuery({
+                    nameSimilarity:
+                      e.currentTarget.value === defaultNameSimilarity
+                        ? undefined
+                        : e.currentTarget.value,
+                  })
+                }
+                className="input-control ml-4"
+              />
+            </Col>
+          </Row>
+          <Form.Text>
+            <FormattedMessage id="performer_dupe_check.name_similarity_desc" />
+          </Form.Text>
+        </Form.Group>
+        <Form.Group>
+          <Form.Check
+            id="compare-images"
+            checked={imageDistance !== null}
+            label={intl.formatMessage({
+              id: "performer_dupe_check.compare_images",
+            })}
+            onChange={() =>
+              setQuery({
+                imageDistance:
+                  imageDistance === null ? defaultImageDistance : undefined,
+              })
+            }
+          />
+          <Form.Text>
+            <FormattedMessage id="performer_dupe_check.compare_images_desc" />
+          </Form.Text>
+        </Form.Group>
+        {imageDistance !== null && (
+          <Form.Group>
+            <Row noGutters>
+              <Form.Label>
+                <FormattedMessage id="performer_dupe_check.image_distance" />
+              </Form.Label>
+              <Col xs="auto">
+                <Form.Control
+                  type="number"
+                  min={0}
+                  max={64}
+                  defaultValue={imageDistance}
+                  onBlur={(e: React.FocusEvent<HTMLInputElement>) =>
+                    setQuery({ imageDistance: e.currentTarget.value })
+                  }
+                  className="input-control ml-4"
+                />
+              </Col>
+            </Row>
+          </Form.Group>
+        )}
+      </Form>
+      {renderResults()}
+    </Card>
+  );
+};
+
+export default PerformerDuplicateChecker;
@@ -26,6 +26,7 @@ import { PerformerScenesPanel } from "./PerformerScenesPanel";
 import { PerformerGalleriesPanel } from "./PerformerGalleriesPanel";
 import { PerformerGroupsPanel } from "./PerformerGroupsPanel";
 import { PerformerImagesPanel } from "./PerformerImagesPanel";
+import { PerformerPhotosPanel } from "./PerformerPhotosPanel";
 import { PerformerAppearsWithPanel } from "./performerAppearsWithPanel";
 import { PerformerEditPanel } from "./PerformerEditPanel";
 import { PerformerSubmitButton } from "./PerformerSubmitButton";
@@ -63,6 +64,7 @@ const validTabs = [
   "images",
   "groups",
   "appearswith",
+  "photos",
 ] as const;
 type TabKey = (typeof validTabs)[number];
 
@@ -196,6 +198,19 @@ const PerformerTabs: React.FC<{
           performer={performer}
         />
       </Tab>
+
+      <Tab
+        eventKey="photos"
+        title={
+          <TabTitleCounter
+            messageID="photos"
+            count={performer.images.length}
+            abbreviateCounter={abbreviateCounter}
+          />
+        }
+      >
+        <PerformerPhotosPanel performer={performer} />
+      </Tab>
     </Tabs>
   );
 };
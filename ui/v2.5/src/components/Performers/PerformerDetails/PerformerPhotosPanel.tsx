@@ -0,0 +1,144 @@
+import React, { useState } from "react";
+import { Button, ButtonGroup } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import cx from "classnames";
+import {
+  faArrowLeft,
+  faArrowRight,
+  faStar,
+  faTrashAlt,
+} from "@fortawesome/free-solid-svg-icons";
+import * as GQL from "src/core/generated-graphql";
+import {
+  mutatePerformerImageAdd,
+  mutatePerformerImageDestroy,
+  mutatePerformerImageSetPrimary,
+  mutatePerformerImagesReorder,
+} from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+import { Icon } from "src/components/Shared/Icon";
+import { ImageInput } from "src/components/Shared/ImageInput";
+import { ExternalLink } from "src/components/Shared/ExternalLink";
+import ImageUtils from "src/utils/image";
+
+interface IPerformerPhotosPanel {
+  performer: GQL.PerformerDataFragment;
+}
+
+export const PerformerPhotosPanel: React.FC<IPerformerPhotosPanel> = ({
+  performer,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [isUpdating, setIsUpdating] = useState(false);
+
+  const images = performer.images;
+
+  async function run(fn: () => Promise<unknown>) {
+    setIsUpdating(true);
+    try {
+      await fn();
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setIsUpdating(false);
+    }
+  }
+
+  function onAddImage(image: string) {
+    run(() => mutatePerformerImageAdd({ performer_id: performer.id, image }));
+  }
+
+  function onImageChange(event: React.FormEvent<HTMLInputElement>) {
+    ImageUtils.onImageChange(event, onAddImage);
+  }
+
+  function onMove(index: number, offset: number) {
+    const ids = images.map((i) => i.id);
+    const target = index + offset;
+    [ids[index], ids[target]] = [ids[target], ids[index]];
+
+    run(() =>
+      mutatePerformerImagesReorder({
+        performer_id: performer.id,
+        image_ids: ids,
+      })
+    );
+  }
+
+  function renderImage(image: GQL.PerformerImageDataFragment, index: number) {
+    return (
+      <div
+        key={image.id}
+        className={cx("performer-photo", { primary: image.primary })}
+      >
+        <img src={image.image_path} alt={performer.name} loading="lazy" />
+        {image.source && (
+          <div className="performer-photo-source">
+            <ExternalLink href={image.source}>{image.source}</ExternalLink>
+          </div>
+        )}
+        <ButtonGroup size="sm">
+          <Button
+            variant="secondary"
+            disabled={isUpdating || index === 0}
+            title={intl.formatMessage({ id: "actions.move_left" })}
+            onClick={() => onMove(index, -1)}
+          >
+            <Icon icon={faArrowLeft} />
+          </Button>
+          <Button
+            variant={image.primary ? "primary" : "secondary"}
+            disabled={isUpdating || image.primary}
+            title={intl.formatMessage({ id: "actions.make_primary" })}
+            onClick={() =>
+              run(() => mutatePerformerImageSetPrimary(performer.id, image.id))
+            }
+          >
+            <Icon icon={faStar} />
+          </Button>
+          <Button
+            variant="secondary"
+            disabled={isUpdating || index === images.length - 1}
+            title={intl.formatMessage({ id: "actions.move_right" })}
+            onClick={() => onMove(index, 1)}
+          >
+            <Icon icon={faArrowRight} />
+          </Button>
+          <Button
+            variant="danger"
+            disabled={isUpdating}
+            title={intl.formatMessage({ id: "actions.delete" })}
+            onClick={() =>
+              run(() => mutatePerformerImageDestroy(performer.id, image.id))
+            }
+          >
+            <Icon icon={faTrashAlt} />
+          </Button>
+        </ButtonGroup>
+      </div>
+    );
+  }
+
+  return (
+    <div className="performer-photos">
+      <div className="performer-photos-toolbar">
+        <ImageInput
+          isEditing
+          text={intl.formatMessage({ id: "actions.add_photo" })}
+          onImageChange={onImageChange}
+          onImageURL={onAddImage}
+        />
+      </div>
+      {images.length === 0 ? (
+        <div className="performer-photos-empty">
+          <FormattedMessage id="performer_photos.empty" />
+        </div>
+      ) : (
+        <div className="performer-photos-grid">
+          {images.map((image, index) => renderImage(image, index))}
+        </div>
+      )}
+    </div>
+  );
+};
@@ -589,9 +589,12 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
         })
         .map((stream) => {
           const src = new URL(stream.url);
+          // stream trimming is handled by the player, so that the times
+          // of the transcoded streams are the same as the file
+          src.searchParams.set("trim", "false");
 
           return {
-            src: stream.url,
+            src: src.toString(),
             type: stream.mime_type ?? undefined,
             label: stream.label ?? undefined,
             offset: !isDirect(src),
@@ -661,6 +664,12 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       startPosition = resumeTime;
     }
 
+    // skip the intro unless a start time was requested
+    const streamStart = scene.stream_start ?? 0;
+    if (!_initialTimestamp && startPosition < streamStart) {
+      startPosition = streamStart;
+    }
+
     setTime(startPosition);
 
     player.load();
@@ -780,6 +789,30 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
     auto.current = false;
   }, [getPlayer, scene, ready, interactiveClient, currentScript]);
 
+  // stop at the stream end of the scene, to skip the outro
+  useEffect(() => {
+    const player = getPlayer();
+    const streamEnd = scene.stream_end;
+    if (!player || !streamEnd) return;
+
+    let lastTime = player.currentTime();
+
+    function timeupdate(this: VideoJsPlayer) {
+      const time = this.currentTime();
+      // don't stop when seeking past the stream end
+      const crossed = lastTime < streamEnd! && time >= streamEnd!;
+      if (crossed && !this.paused() && time - lastTime < 1) {
+        this.pause();
+        this.trigger("ended");
+      }
+      lastTime = time;
+    }
+
+    player.on("timeupdate", timeupdate);
+
+    return () => player.off("timeupdate", timeupdate);
+  }, [getPlayer, scene]);
+
   // Attach handler for onComplete event
   useEffect(() => {
     const player = getPlayer();
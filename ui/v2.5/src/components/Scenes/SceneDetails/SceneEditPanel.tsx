@@ -27,7 +27,7 @@ import { galleryTitle } from "src/core/galleries";
 import { lazyComponent } from "src/utils/lazyComponent";
 import isEqual from "lodash-es/isEqual";
 import {
-  yupDateString,
+  yupFuzzyDateString,
   yupFormikValidate,
   yupUniqueStringList,
 } from "src/utils/yup";
@@ -41,6 +41,8 @@ import { Gallery, GallerySelect } from "src/components/Galleries/GallerySelect";
 import { Group } from "src/components/Groups/GroupSelect";
 import { useTagsEdit } from "src/hooks/tagsEdit";
 import { ScraperMenu } from "src/components/Shared/ScraperMenu";
+import { DurationInput } from "src/components/Shared/DurationInput";
+import { getPlayerPosition } from "src/components/ScenePlayer/util";
 
 const SceneScrapeDialog = lazyComponent(() => import("./SceneScrapeDialog"));
 const SceneQueryModal = lazyComponent(() => import("./SceneQueryModal"));
@@ -112,8 +114,21 @@ export const SceneEditPanel: React.FC<IProps> = ({
     title: yup.string().ensure(),
     code: yup.string().ensure(),
     urls: yupUniqueStringList(intl),
-    date: yupDateString(intl),
+    date: yupFuzzyDateString(intl),
     director: yup.string().ensure(),
+    stream_start: yup.number().min(0).nullable().defined(),
+    stream_end: yup
+      .number()
+      .min(0)
+      .nullable()
+      .defined()
+      .test(
+        "is-greater-than-stream-start",
+        intl.formatMessage({ id: "end_time_before_start_time" }),
+        function (value) {
+          return value === null || value > (this.parent.stream_start ?? 0);
+        }
+      ),
     gallery_ids: yup.array(yup.string().required()).defined(),
     studio_id: yup.string().required().nullable(),
     performer_ids: yup.array(yup.string().required()).defined(),
@@ -138,6 +153,8 @@ export const SceneEditPanel: React.FC<IProps> = ({
       urls: scene.urls ?? [],
       date: scene.date ?? "",
       director: scene.director ?? "",
+      stream_start: scene.stream_start ?? null,
+      stream_end: scene.stream_end ?? null,
       gallery_ids: (scene.galleries ?? []).map((g) => g.id),
       studio_id: scene.studio?.id ?? null,
       performer_ids: (scene.performers ?? []).map((p) => p.id),
@@ -658,6 +675,29 @@ export const SceneEditPanel: React.FC<IProps> = ({
     return renderField("tag_ids", title, tagsControl(), fullWidthProps);
   }
 
+  function renderStreamTimeField(field: "stream_start" | "stream_end") {
+    const { error } = formik.getFieldMeta(field);
+
+    const title = intl.formatMessage({ id: field });
+    const control = (
+      <>
+        <DurationInput
+          value={formik.values[field]}
+          setValue={(v) => formik.setFieldValue(field, v ?? null)}
+          onReset={() =>
+            formik.setFieldValue(field, getPlayerPosition() ?? null)
+          }
+          error={error}
+        />
+        {formik.touched[field] && error && (
+          <Form.Control.Feedback type="invalid">{error}</Form.Control.Feedback>
+        )}
+      </>
+    );
+
+    return renderField(field, title, control);
+  }
+
   function renderDetailsField() {
     const props = {
       labelProps: {
@@ -737,6 +777,8 @@ export const SceneEditPanel: React.FC<IProps> = ({
 
             {renderDateField("date")}
             {renderInputField("director")}
+            {renderStreamTimeField("stream_start")}
+            {renderStreamTimeField("stream_end")}
 
             {renderGalleriesField()}
             {renderStudioField()}
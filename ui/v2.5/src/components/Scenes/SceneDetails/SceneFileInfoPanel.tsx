@@ -18,6 +18,12 @@ import TextUtils from "src/utils/text";
 import { TextField, URLField, URLsField } from "src/utils/field";
 import { StashIDPill } from "src/components/Shared/StashID";
 import { PatchComponent } from "../../../patch";
+import {
+  hdrFormatStrings,
+  stereoLayoutStrings,
+  videoPropertyToString,
+  vrProjectionStrings,
+} from "src/models/list-filter/criteria/video-properties";
 
 interface IFileInfoPanelProps {
   sceneID: string;
@@ -77,6 +83,14 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
             </dd>
           </>
         )}
+        {props.file.pending_content && (
+          <>
+            <dt></dt>
+            <dd className="pending-content">
+              <FormattedMessage id="pending_content" />
+            </dd>
+          </>
+        )}
         <TextField id="media_info.hash" value={oshash?.value} truncate />
         <TextField id="media_info.checksum" value={checksum?.value} truncate />
         <URLField
@@ -138,6 +152,34 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           value={props.file.audio_codec ?? ""}
           truncate
         />
+        <TextField
+          id="bit_depth"
+          value={props.file.bit_depth > 0 ? `${props.file.bit_depth}` : ""}
+        />
+        <TextField
+          id="quality_score"
+          value={
+            props.file.quality_score > 0 ? `${props.file.quality_score}` : ""
+          }
+        />
+        <TextField
+          id="hdr_format"
+          value={videoPropertyToString(hdrFormatStrings, props.file.hdr_format)}
+        />
+        <TextField
+          id="vr_projection"
+          value={videoPropertyToString(
+            vrProjectionStrings,
+            props.file.vr_projection
+          )}
+        />
+        <TextField
+          id="stereo_layout"
+          value={videoPropertyToString(
+            stereoLayoutStrings,
+            props.file.stereo_layout
+          )}
+        />
       </dl>
       {props.ofMany && props.onSetPrimaryFile && !props.primary && (
         <div>
@@ -1,7 +1,8 @@
 import React from "react";
 import { Button } from "react-bootstrap";
-import { useIntl } from "react-intl";
-import { useLatestVersion } from "src/core/StashService";
+import { FormattedNumber, useIntl } from "react-intl";
+import { useLatestVersion, useMemoryStats } from "src/core/StashService";
+import TextUtils from "src/utils/text";
 import { ExternalLink } from "../Shared/ExternalLink";
 import { ConstantSetting, SettingGroup } from "./Inputs";
 import { SettingSection } from "./SettingSection";
@@ -21,6 +22,12 @@ export const SettingsAboutPanel: React.FC = () => {
     networkStatus,
   } = useLatestVersion();
 
+  const {
+    data: dataMemory,
+    refetch: refetchMemory,
+    networkStatus: networkStatusMemory,
+  } = useMemoryStats();
+
   function renderLatestVersion() {
     if (errorLatest) {
       return (
@@ -85,6 +92,80 @@ export const SettingsAboutPanel: React.FC = () => {
     }
   }
 
+  function renderBytes(bytes: number | undefined) {
+    const { size, unit } = TextUtils.fileSize(bytes);
+    return (
+      <FormattedNumber
+        value={size}
+        // eslint-disable-next-line react/style-prop-object
+        style="unit"
+        unit={unit}
+        unitDisplay="narrow"
+        maximumFractionDigits={TextUtils.fileSizeFractionalDigits(unit)}
+      />
+    );
+  }
+
+  function renderMemoryStats() {
+    const stats = dataMemory?.memoryStats;
+    if (!stats || networkStatusMemory === 4) {
+      return (
+        <SettingGroup
+          settingProps={{
+            headingID: "loading.generic",
+          }}
+        />
+      );
+    }
+
+    return (
+      <SettingGroup
+        settingProps={{
+          headingID: stats.lowMemoryMode
+            ? "config.about.memory.low_memory_mode_enabled"
+            : "config.about.memory.low_memory_mode_disabled",
+        }}
+      >
+        <ConstantSetting
+          headingID="config.about.memory.heap_alloc"
+          value={stats.heapAlloc}
+          renderValue={renderBytes}
+        />
+        <ConstantSetting
+          headingID="config.about.memory.heap_in_use"
+          value={stats.heapInUse}
+          renderValue={renderBytes}
+        />
+        <ConstantSetting
+          headingID="config.about.memory.heap_sys"
+          value={stats.heapSys}
+          renderValue={renderBytes}
+        />
+        <ConstantSetting
+          headingID="config.about.memory.sys"
+          value={stats.sys}
+          renderValue={renderBytes}
+        />
+        <ConstantSetting
+          headingID="config.about.memory.num_gc"
+          value={stats.numGC}
+        />
+        <ConstantSetting
+          headingID="config.about.memory.goroutines"
+          value={stats.goroutines}
+        />
+        <div className="setting">
+          <div />
+          <div>
+            <Button onClick={() => refetchMemory()}>
+              {intl.formatMessage({ id: "actions.refresh" })}
+            </Button>
+          </div>
+        </div>
+      </SettingGroup>
+    );
+  }
+
   return (
     <>
       <SettingSection headingID="config.about.version">
@@ -108,6 +189,10 @@ export const SettingsAboutPanel: React.FC = () => {
         {renderLatestVersion()}
       </SettingSection>
 
+      <SettingSection headingID="config.about.memory.heading">
+        {renderMemoryStats()}
+      </SettingSection>
+
       <SettingSection headingID="config.categories.about">
         <div className="setting">
           <div>
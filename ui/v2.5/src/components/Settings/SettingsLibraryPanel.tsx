@@ -106,6 +106,16 @@ export const SettingsLibraryPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.library.scene_options">
+        <StringSetting
+          id="scene-title-template"
+          headingID="config.general.scene_title_template_label"
+          subHeadingID="config.general.scene_title_template_desc"
+          value={general.sceneTitleTemplate ?? ""}
+          onChange={(v) => saveGeneral({ sceneTitleTemplate: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.library.gallery_and_image_options">
         <BooleanSetting
           id="create-galleries-from-folders"
@@ -138,6 +148,32 @@ export const SettingsLibraryPanel: React.FC = () => {
           value={general.galleryCoverRegex ?? ""}
           onChange={(v) => saveGeneral({ galleryCoverRegex: v })}
         />
+
+        <BooleanSetting
+          id="import-image-sidecars"
+          headingID="config.general.import_image_sidecars_label"
+          subHeadingID="config.general.import_image_sidecars_desc"
+          checked={general.importImageSidecars ?? false}
+          onChange={(v) => saveGeneral({ importImageSidecars: v })}
+        />
+
+        <StringListSetting
+          id="image-sidecar-tag-mappings"
+          headingID="config.general.image_sidecar_tag_mappings_label"
+          subHeadingID="config.general.image_sidecar_tag_mappings_desc"
+          value={general.imageSidecarTagMappings ?? undefined}
+          onChange={(v) => saveGeneral({ imageSidecarTagMappings: v })}
+          defaultNewValue="character={tag}"
+        />
+
+        <StringListSetting
+          id="scene-primary-file-rules"
+          headingID="config.general.scene_primary_file_rules_label"
+          subHeadingID="config.general.scene_primary_file_rules_desc"
+          value={general.scenePrimaryFileRules ?? undefined}
+          onChange={(v) => saveGeneral({ scenePrimaryFileRules: v })}
+          defaultNewValue="larger"
+        />
       </SettingSection>
 
       <SettingSection headingID="config.ui.delete_options.heading">
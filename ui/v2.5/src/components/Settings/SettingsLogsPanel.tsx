@@ -1,7 +1,7 @@
 import React, { useEffect, useState } from "react";
 import { useIntl } from "react-intl";
 import * as GQL from "src/core/generated-graphql";
-import { useLoggingSubscribe, queryLogs } from "src/core/StashService";
+import { useLoggingSubscribe } from "src/core/StashService";
 import { SelectSetting } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 import { JobTable } from "./Tasks/JobTable";
@@ -70,30 +70,16 @@ class LogEntry {
 const MAX_LOG_ENTRIES = 50000;
 // maximum number of log entries to display
 const MAX_DISPLAY_LOG_ENTRIES = 1000;
+// number of recent log entries to request when subscribing
+const LOG_BACKFILL = 1000;
 const logLevels = ["Trace", "Debug", "Info", "Warning", "Error"];
 
 export const SettingsLogsPanel: React.FC = () => {
   const [entries, setEntries] = useState<LogEntry[]>([]);
-  const { data, error } = useLoggingSubscribe();
+  const { data, error } = useLoggingSubscribe({ backfill: LOG_BACKFILL });
   const [logLevel, setLogLevel] = useState<string>("Info");
   const intl = useIntl();
 
-  useEffect(() => {
-    async function getInitialLogs() {
-      const logQuery = await queryLogs();
-      if (logQuery.error) return;
-
-      const initEntries = logQuery.data.logs.map((e) => new LogEntry(e));
-      if (initEntries.length !== 0) {
-        setEntries((prev) => {
-          return [...prev, ...initEntries].slice(0, MAX_LOG_ENTRIES);
-        });
-      }
-    }
-
-    getInitialLogs();
-  }, []);
-
   useEffect(() => {
     if (!data) return;
 
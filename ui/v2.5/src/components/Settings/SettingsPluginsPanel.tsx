@@ -191,6 +191,7 @@ export const SettingsPluginsPanel: React.FC = () => {
           }
         >
           {renderPluginHooks(plugin.hooks ?? undefined)}
+          {renderPluginPermissions(plugin.permissions)}
           <PluginSettings
             pluginID={plugin.id}
             settings={plugin.settings ?? []}
@@ -239,6 +240,37 @@ export const SettingsPluginsPanel: React.FC = () => {
       );
     }
 
+    function renderPluginPermissions(permissions?: string[] | null) {
+      // plugins without permissions have unrestricted access
+      if (!permissions) {
+        return;
+      }
+
+      return (
+        <div className="setting">
+          <div>
+            <h5>
+              <FormattedMessage id="config.plugins.permissions" />
+            </h5>
+            {permissions.length === 0 ? (
+              <small className="text-muted">
+                <FormattedMessage id="config.plugins.no_permissions" />
+              </small>
+            ) : (
+              <ul>
+                {permissions.map((p) => (
+                  <li key={p}>
+                    <code>{p}</code>
+                  </li>
+                ))}
+              </ul>
+            )}
+          </div>
+          <div />
+        </div>
+      );
+    }
+
     return renderPlugins();
   }, [data?.plugins, intl, Toast, changedPluginID]);
 
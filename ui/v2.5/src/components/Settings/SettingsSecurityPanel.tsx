@@ -1,5 +1,5 @@
 import React from "react";
-import { ModalSetting, NumberSetting } from "./Inputs";
+import { BooleanSetting, ModalSetting, NumberSetting } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 import * as GQL from "src/core/generated-graphql";
 import { Button, Form } from "react-bootstrap";
@@ -163,6 +163,14 @@ export const SettingsSecurityPanel: React.FC = () => {
           value={general.maxSessionAge ?? undefined}
           onChange={(v) => saveGeneral({ maxSessionAge: v })}
         />
+
+        <BooleanSetting
+          id="sql-query-enabled"
+          headingID="config.general.auth.sql_query"
+          subHeadingID="config.general.auth.sql_query_desc"
+          checked={general.sqlQueryEnabled ?? false}
+          onChange={(v) => saveGeneral({ sqlQueryEnabled: v })}
+        />
       </SettingSection>
     </>
   );
@@ -38,6 +38,15 @@ export const SettingsConfigurationPanel: React.FC = () => {
     GQL.StreamingResolutionEnum.Original,
   ].map(resolutionToString);
 
+  const fingerprintAlgorithms = general.fingerprintAlgorithms ?? [];
+
+  function setFingerprintAlgorithm(algorithm: string, enabled: boolean) {
+    const others = fingerprintAlgorithms.filter((a) => a !== algorithm);
+    saveGeneral({
+      fingerprintAlgorithms: enabled ? [...others, algorithm] : others,
+    });
+  }
+
   function resolutionToString(r: GQL.StreamingResolutionEnum | undefined) {
     switch (r) {
       case GQL.StreamingResolutionEnum.Low:
@@ -256,6 +265,51 @@ export const SettingsConfigurationPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.general.transcription.heading">
+        <StringSetting
+          id="transcribe-whisper-path"
+          headingID="config.general.transcription.whisper_path.heading"
+          subHeadingID="config.general.transcription.whisper_path.description"
+          value={general.transcribeWhisperPath ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeWhisperPath: v })}
+        />
+        <StringSetting
+          id="transcribe-whisper-model"
+          headingID="config.general.transcription.whisper_model.heading"
+          subHeadingID="config.general.transcription.whisper_model.description"
+          value={general.transcribeWhisperModel ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeWhisperModel: v })}
+        />
+        <StringSetting
+          id="transcribe-api-url"
+          headingID="config.general.transcription.api_url.heading"
+          subHeadingID="config.general.transcription.api_url.description"
+          value={general.transcribeAPIURL ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeAPIURL: v })}
+        />
+        <StringSetting
+          id="transcribe-api-key"
+          headingID="config.general.transcription.api_key.heading"
+          subHeadingID="config.general.transcription.api_key.description"
+          value={general.transcribeAPIKey ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeAPIKey: v })}
+        />
+        <StringSetting
+          id="transcribe-api-model"
+          headingID="config.general.transcription.api_model.heading"
+          subHeadingID="config.general.transcription.api_model.description"
+          value={general.transcribeAPIModel ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeAPIModel: v })}
+        />
+        <StringSetting
+          id="transcribe-language"
+          headingID="config.general.transcription.language.heading"
+          subHeadingID="config.general.transcription.language.description"
+          value={general.transcribeLanguage ?? undefined}
+          onChange={(v) => saveGeneral({ transcribeLanguage: v })}
+        />
+      </SettingSection>
+
       <SettingSection advanced headingID="config.general.hashing">
         <BooleanSetting
           id="calculate-md5-and-ohash"
@@ -265,6 +319,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ calculateMD5: v })}
         />
 
+        <BooleanSetting
+          id="calculate-sha256"
+          headingID="config.general.calculate_sha256_label"
+          subHeadingID="config.general.calculate_fingerprint_desc"
+          checked={fingerprintAlgorithms.includes("sha256")}
+          onChange={(v) => setFingerprintAlgorithm("sha256", v)}
+        />
+
+        <BooleanSetting
+          id="calculate-blake3"
+          headingID="config.general.calculate_blake3_label"
+          subHeadingID="config.general.calculate_fingerprint_desc"
+          checked={fingerprintAlgorithms.includes("blake3")}
+          onChange={(v) => setFingerprintAlgorithm("blake3", v)}
+        />
+
         <SelectSetting
           id="generated_file_naming_hash"
           headingID="config.general.generated_file_naming_hash_head"
@@ -371,6 +441,37 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.parallelTasks ?? undefined}
           onChange={(v) => saveGeneral({ parallelTasks: v })}
         />
+        <BooleanSetting
+          id="low-memory-mode"
+          headingID="config.general.low_memory_mode_head"
+          subHeadingID="config.general.low_memory_mode_desc"
+          checked={general.lowMemoryMode ?? false}
+          onChange={(v) => saveGeneral({ lowMemoryMode: v })}
+        />
+      </SettingSection>
+
+      <SettingSection headingID="config.general.remote_workers.heading">
+        <BooleanSetting
+          id="remote-workers-enabled"
+          headingID="config.general.remote_workers.enabled.heading"
+          subHeadingID="config.general.remote_workers.enabled.description"
+          checked={general.remoteWorkersEnabled ?? false}
+          onChange={(v) => saveGeneral({ remoteWorkersEnabled: v })}
+        />
+        <StringSetting
+          id="remote-worker-server-url"
+          headingID="config.general.remote_workers.server_url.heading"
+          subHeadingID="config.general.remote_workers.server_url.description"
+          value={general.remoteWorkerServerURL ?? undefined}
+          onChange={(v) => saveGeneral({ remoteWorkerServerURL: v })}
+        />
+        <StringSetting
+          id="remote-worker-api-key"
+          headingID="config.general.remote_workers.api_key.heading"
+          subHeadingID="config.general.remote_workers.api_key.description"
+          value={general.remoteWorkerAPIKey ?? undefined}
+          onChange={(v) => saveGeneral({ remoteWorkerAPIKey: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.preview_generation">
@@ -31,6 +31,26 @@ export const SettingsToolsPanel: React.FC = () => {
             </Link>
           }
         />
+
+        <Setting
+          heading={
+            <Link to="/performerDuplicateChecker">
+              <Button>
+                <FormattedMessage id="config.tools.performer_duplicate_checker" />
+              </Button>
+            </Link>
+          }
+        />
+
+        <Setting
+          heading={
+            <Link to="/tagCleanup">
+              <Button>
+                <FormattedMessage id="config.tools.tag_cleanup" />
+              </Button>
+            </Link>
+          }
+        />
       </SettingsToolsSection>
     </SettingSection>
   );
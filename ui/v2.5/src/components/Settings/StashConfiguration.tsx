@@ -128,6 +128,7 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
                   path: v,
                   excludeVideo: false,
                   excludeImage: false,
+                  quota: 0,
                 },
               ]);
             setIsCreating(false);
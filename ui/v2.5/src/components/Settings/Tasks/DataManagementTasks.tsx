@@ -11,7 +11,9 @@ import {
   mutateMigrateSceneScreenshots,
   mutateMigrateBlobs,
   mutateOptimiseDatabase,
+  mutateCalculateFingerprints,
   mutateCleanGenerated,
+  mutateConsolidateFiles,
 } from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
 import downloadFile from "src/utils/download";
@@ -19,7 +21,7 @@ import { ModalComponent } from "src/components/Shared/Modal";
 import { ImportDialog } from "./ImportDialog";
 import * as GQL from "src/core/generated-graphql";
 import { SettingSection } from "../SettingSection";
-import { BooleanSetting, Setting } from "../Inputs";
+import { BooleanSetting, SelectSetting, Setting } from "../Inputs";
 import { ManualLink } from "src/components/Help/context";
 import { Icon } from "src/components/Shared/Icon";
 import { ConfigurationContext } from "src/hooks/Config";
@@ -176,6 +178,12 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     dryRun: false,
   });
 
+  const [consolidateOptions, setConsolidateOptions] =
+    useState<GQL.ConsolidateFilesInput>({
+      mode: GQL.FileLinkMode.Hardlink,
+      dryRun: false,
+    });
+
   const [migrateBlobsOptions, setMigrateBlobsOptions] =
     useState<GQL.MigrateBlobsInput>({
       deleteOld: true,
@@ -276,6 +284,24 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onConsolidateFiles() {
+    try {
+      await mutateConsolidateFiles(consolidateOptions);
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.consolidate_files",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onMigrateHashNaming() {
     try {
       await mutateMigrateHashNaming();
@@ -363,6 +389,24 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onCalculateFingerprints() {
+    try {
+      await mutateCalculateFingerprints({});
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.calculate_fingerprints",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onOptimiseDatabase() {
     try {
       await mutateOptimiseDatabase();
@@ -489,6 +533,70 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Setting>
         </div>
 
+        <div className="setting-group">
+          <Setting
+            heading={
+              <>
+                <FormattedMessage id="actions.consolidate_files" />
+                <ManualLink tab="Tasks">
+                  <Icon icon={faQuestionCircle} />
+                </ManualLink>
+              </>
+            }
+            subHeadingID="config.tasks.consolidate_files.description"
+          >
+            <Button
+              id="consolidateFiles"
+              variant="danger"
+              onClick={() => onConsolidateFiles()}
+            >
+              <FormattedMessage id="actions.consolidate_files" />
+            </Button>
+          </Setting>
+
+          <SelectSetting
+            id="consolidate-files-mode"
+            headingID="config.tasks.consolidate_files.mode"
+            value={consolidateOptions.mode}
+            onChange={(v) =>
+              setConsolidateOptions({
+                ...consolidateOptions,
+                mode: v as GQL.FileLinkMode,
+              })
+            }
+          >
+            {Object.values(GQL.FileLinkMode).map((m) => (
+              <option key={m} value={m}>
+                {intl.formatMessage({
+                  id: `config.tasks.consolidate_files.modes.${m.toLowerCase()}`,
+                })}
+              </option>
+            ))}
+          </SelectSetting>
+
+          <BooleanSetting
+            id="consolidate-files-dry-run"
+            checked={consolidateOptions.dryRun}
+            headingID="config.tasks.consolidate_files.dry_run"
+            onChange={(v) =>
+              setConsolidateOptions({ ...consolidateOptions, dryRun: v })
+            }
+          />
+        </div>
+
+        <Setting
+          headingID="actions.calculate_fingerprints"
+          subHeadingID="config.tasks.calculate_fingerprints"
+        >
+          <Button
+            id="calculateFingerprints"
+            variant="secondary"
+            onClick={() => onCalculateFingerprints()}
+          >
+            <FormattedMessage id="actions.calculate_fingerprints" />
+          </Button>
+        </Setting>
+
         <Setting
           headingID="actions.optimise_database"
           subHeading={
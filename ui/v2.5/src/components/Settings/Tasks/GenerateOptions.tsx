@@ -153,6 +153,22 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             headingID="dialogs.scene_gen.interactive_heatmap_speed"
             onChange={(v) => setOptions({ interactiveHeatmapsSpeeds: v })}
           />
+
+          <BooleanSetting
+            id="scene-heatmap-task"
+            checked={options.sceneHeatmaps ?? false}
+            headingID="dialogs.scene_gen.scene_heatmaps"
+            tooltipID="dialogs.scene_gen.scene_heatmaps_tooltip"
+            onChange={(v) => setOptions({ sceneHeatmaps: v })}
+          />
+
+          <BooleanSetting
+            id="transcript-task"
+            checked={options.transcripts ?? false}
+            headingID="dialogs.scene_gen.transcripts"
+            tooltipID="dialogs.scene_gen.transcripts_tooltip"
+            onChange={(v) => setOptions({ transcripts: v })}
+          />
         </>
       )}
       {showImageOptions && (
@@ -169,6 +185,13 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             headingID="dialogs.scene_gen.image_thumbnails"
             onChange={(v) => setOptions({ imageThumbnails: v })}
           />
+          <BooleanSetting
+            id="image-phashes"
+            checked={options.imagePhashes ?? false}
+            headingID="dialogs.scene_gen.image_phash"
+            tooltipID="dialogs.scene_gen.image_phash_tooltip"
+            onChange={(v) => setOptions({ imagePhashes: v })}
+          />
         </>
       )}
       <BooleanSetting
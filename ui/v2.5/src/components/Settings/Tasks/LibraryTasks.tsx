@@ -158,6 +158,9 @@ export const LibraryTasks: React.FC = () => {
       if (configuration?.defaults.generate) {
         const { generate } = configuration.defaults;
         setGenerateOptions(withoutTypename(generate));
+      } else if (configuration?.general.lowMemoryMode) {
+        // phashes are not generated by default in low memory mode
+        setGenerateOptions((existing) => ({ ...existing, phashes: false }));
       }
 
       if (configuration?.general) {
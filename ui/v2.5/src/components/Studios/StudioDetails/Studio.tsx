@@ -286,10 +286,7 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
 
   const showAllCounts = uiConfig?.showChildStudioContent;
 
-  // make array of url so that it doesn't re-render on every change
-  const urls = useMemo(() => {
-    return studio?.url ? [studio.url] : [];
-  }, [studio.url]);
+  const urls = useMemo(() => studio.urls ?? [], [studio.urls]);
 
   const studioImage = useMemo(() => {
     const existingPath = studio.image_path;
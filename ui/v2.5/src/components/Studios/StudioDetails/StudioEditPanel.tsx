@@ -14,7 +14,11 @@ import isEqual from "lodash-es/isEqual";
 import { useToast } from "src/hooks/Toast";
 import { handleUnsavedChanges } from "src/utils/navigation";
 import { formikUtils } from "src/utils/form";
-import { yupFormikValidate, yupUniqueAliases } from "src/utils/yup";
+import {
+  yupFormikValidate,
+  yupUniqueAliases,
+  yupUniqueStringList,
+} from "src/utils/yup";
 import { Studio, StudioSelect } from "../StudioSelect";
 import { useTagsEdit } from "src/hooks/tagsEdit";
 
@@ -47,7 +51,7 @@ export const StudioEditPanel: React.FC<IStudioEditPanel> = ({
 
   const schema = yup.object({
     name: yup.string().required(),
-    url: yup.string().ensure(),
+    urls: yupUniqueStringList(intl),
     details: yup.string().ensure(),
     parent_id: yup.string().required().nullable(),
     aliases: yupUniqueAliases(intl, "name"),
@@ -60,7 +64,7 @@ export const StudioEditPanel: React.FC<IStudioEditPanel> = ({
   const initialValues = {
     id: studio.id,
     name: studio.name ?? "",
-    url: studio.url ?? "",
+    urls: studio.urls ?? [],
     details: studio.details ?? "",
     parent_id: studio.parent_studio?.id ?? null,
     aliases: studio.aliases ?? [],
@@ -147,6 +151,7 @@ export const StudioEditPanel: React.FC<IStudioEditPanel> = ({
     renderField,
     renderInputField,
     renderStringListField,
+    renderURLListField,
     renderStashIDsField,
   } = formikUtils(intl, formik);
 
@@ -187,7 +192,7 @@ export const StudioEditPanel: React.FC<IStudioEditPanel> = ({
       <Form noValidate onSubmit={formik.handleSubmit} id="studio-edit">
         {renderInputField("name")}
         {renderStringListField("aliases")}
-        {renderInputField("url")}
+        {renderURLListField("urls")}
         {renderInputField("details", "textarea")}
         {renderParentStudioField()}
         {renderTagsField()}
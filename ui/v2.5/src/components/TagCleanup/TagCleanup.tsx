@@ -0,0 +1,409 @@
+import React, { useState } from "react";
+import {
+  Button,
+  Card,
+  Col,
+  Form,
+  Row,
+  Tab,
+  Table,
+  Tabs,
+} from "react-bootstrap";
+import { Link } from "react-router-dom";
+import { FormattedMessage, useIntl } from "react-intl";
+
+import * as GQL from "src/core/generated-graphql";
+import { useTagsDestroy, useTagsMergeMany } from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+import { LoadingIndicator } from "../Shared/LoadingIndicator";
+import { ErrorMessage } from "../Shared/ErrorMessage";
+import { DeleteEntityDialog } from "../Shared/DeleteEntityDialog";
+
+const defaultDistance = 1;
+
+type Tag = GQL.SlimTagDataFragment;
+
+interface ITagListProps {
+  tags: Tag[];
+  loading: boolean;
+  error?: Error;
+  descriptionID: string;
+  onDeleted: () => void;
+}
+
+// lists tags with checkboxes, and deletes the selected tags
+const TagDeleteList: React.FC<ITagListProps> = ({
+  tags,
+  loading,
+  error,
+  descriptionID,
+  onDeleted,
+}) => {
+  const intl = useIntl();
+  const [selected, setSelected] = useState<Set<string>>(new Set());
+  const [deleting, setDeleting] = useState(false);
+
+  if (loading) return <LoadingIndicator />;
+  if (error) return <ErrorMessage error={error.message} />;
+
+  function toggle(id: string) {
+    const newSelected = new Set(selected);
+    if (newSelected.has(id)) {
+      newSelected.delete(id);
+    } else {
+      newSelected.add(id);
+    }
+    setSelected(newSelected);
+  }
+
+  function toggleAll() {
+    if (selected.size === tags.length) {
+      setSelected(new Set());
+    } else {
+      setSelected(new Set(tags.map((t) => t.id)));
+    }
+  }
+
+  function onDeleteClosed(confirmed: boolean) {
+    setDeleting(false);
+    if (confirmed) {
+      setSelected(new Set());
+      onDeleted();
+    }
+  }
+
+  return (
+    <>
+      {deleting && (
+        <DeleteEntityDialog
+          selected={tags.filter((t) => selected.has(t.id))}
+          onClose={onDeleteClosed}
+          singularEntity={intl.formatMessage({ id: "tag" })}
+          pluralEntity={intl.formatMessage({ id: "tags" })}
+          destroyMutation={useTagsDestroy}
+        />
+      )}
+      <p>
+        <FormattedMessage id={descriptionID} />
+      </p>
+      <h6>
+        <FormattedMessage
+          id="tag_cleanup.found_tags"
+          values={{ count: tags.length }}
+        />
+      </h6>
+      {tags.length > 0 && (
+        <>
+          <Button
+            variant="danger"
+            className="mb-2"
+            disabled={selected.size === 0}
+            onClick={() => setDeleting(true)}
+          >
+            <FormattedMessage id="actions.delete" />
+          </Button>
+          <Table responsive striped size="sm">
+            <thead>
+              <tr>
+                <th>
+                  <Form.Check
+                    checked={selected.size === tags.length}
+                    onChange={toggleAll}
+                  />
+                </th>
+                <th>
+                  <FormattedMessage id="name" />
+                </th>
+                <th>
+                  <FormattedMessage id="aliases" />
+                </th>
+                <th>
+                  <FormattedMessage id="sub_tag_count" />
+                </th>
+              </tr>
+            </thead>
+            <tbody>
+              {tags.map((t) => (
+                <tr key={t.id}>
+                  <td>
+                    <Form.Check
+                      checked={selected.has(t.id)}
+                      onChange={() => toggle(t.id)}
+                    />
+                  </td>
+                  <td>
+                    <Link to={`/tags/${t.id}`}>{t.name}</Link>
+                  </td>
+                  <td>{t.aliases.join(", ")}</td>
+                  <td>{t.child_count}</td>
+                </tr>
+              ))}
+            </tbody>
+          </Table>
+        </>
+      )}
+    </>
+  );
+};
+
+const UnusedTags: React.FC = () => {
+  const { data, loading, error, refetch } = GQL.useFindUnusedTagsQuery({
+    fetchPolicy: "no-cache",
+  });
+
+  return (
+    <TagDeleteList
+      tags={data?.findUnusedTags ?? []}
+      loading={loading}
+      error={error}
+      descriptionID="tag_cleanup.unused_desc"
+      onDeleted={() => refetch()}
+    />
+  );
+};
+
+const HierarchyOnlyTags: React.FC = () => {
+  const { data, loading, error, refetch } = GQL.useFindHierarchyOnlyTagsQuery(
+    {
+      fetchPolicy: "no-cache",
+    }
+  );
+
+  return (
+    <TagDeleteList
+      tags={data?.findHierarchyOnlyTags ?? []}
+      loading={loading}
+      error={error}
+      descriptionID="tag_cleanup.hierarchy_only_desc"
+      onDeleted={() => refetch()}
+    />
+  );
+};
+
+type DuplicateTag = GQL.FindDuplicateTagsQuery["findDuplicateTags"][0][0];
+
+const DuplicateTags: React.FC = () => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [distance, setDistance] = useState(defaultDistance);
+  // the tag to keep in each group, keyed by the first tag id
+  const [destinations, setDestinations] = useState<Record<string, string>>(
+    {}
+  );
+  // the first tag ids of the groups to merge
+  const [selected, setSelected] = useState<Set<string>>(new Set());
+  const [running, setRunning] = useState(false);
+  const [mergeTags] = useTagsMergeMany();
+
+  const { data, loading, error, refetch } = GQL.useFindDuplicateTagsQuery({
+    fetchPolicy: "no-cache",
+    variables: { distance },
+  });
+
+  const groups = data?.findDuplicateTags ?? [];
+
+  function groupDestination(tags: DuplicateTag[]) {
+    const id = destinations[tags[0].id];
+    return tags.find((t) => t.id === id) ?? tags[0];
+  }
+
+  function toggle(id: string) {
+    const newSelected = new Set(selected);
+    if (newSelected.has(id)) {
+      newSelected.delete(id);
+    } else {
+      newSelected.add(id);
+    }
+    setSelected(newSelected);
+  }
+
+  async function onMerge() {
+    const input = groups
+      .filter((g) => selected.has(g[0].id))
+      .map((g) => {
+        const destination = groupDestination(g);
+        return {
+          destination: destination.id,
+          source: g.filter((t) => t !== destination).map((t) => t.id),
+        };
+      });
+
+    try {
+      setRunning(true);
+      await mergeTags({ variables: { input } });
+      Toast.success(intl.formatMessage({ id: "toast.merged_tags" }));
+      setSelected(new Set());
+      setDestinations({});
+      refetch();
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setRunning(false);
+    }
+  }
+
+  function usage(t: DuplicateTag) {
+    const counts: [string, number][] = [
+      ["countables.scenes", t.scene_count],
+      ["countables.images", t.image_count],
+      ["countables.galleries", t.gallery_count],
+      ["countables.performers", t.performer_count],
+    ];
+
+    return counts
+      .map(
+        ([id, count]) => `${count} ${intl.formatMessage({ id }, { count })}`
+      )
+      .join(", ");
+  }
+
+  function renderGroup(tags: DuplicateTag[]) {
+    const destination = groupDestination(tags);
+    const groupID = tags[0].id;
+
+    return (
+      <tbody key={groupID}>
+        {tags.map((t, i) => (
+          <tr key={t.id}>
+            <td>
+              {i === 0 && (
+                <Form.Check
+                  checked={selected.has(groupID)}
+                  onChange={() => toggle(groupID)}
+                />
+              )}
+            </td>
+            <td>
+              <Form.Check
+                type="radio"
+                name={`destination-${groupID}`}
+                checked={t === destination}
+                onChange={() =>
+                  setDestinations({ ...destinations, [groupID]: t.id })
+                }
+              />
+            </td>
+            <td>
+              <Link to={`/tags/${t.id}`}>{t.name}</Link>
+            </td>
+            <td>{t.aliases.join(", ")}</td>
+            <td>{usage(t)}</td>
+          </tr>
+        ))}
+        <tr className="separator" />
+      </tbody>
+    );
+  }
+
+  function renderResults() {
+    if (loading) return <LoadingIndicator />;
+    if (error) return <ErrorMessage error={error.message} />;
+
+    return (
+      <>
+        <h6>
+          <FormattedMessage
+            id="tag_cleanup.found_sets"
+            values={{ setCount: groups.length }}
+          />
+        </h6>
+        {groups.length > 0 && (
+          <>
+            <Button
+              className="mb-2"
+              disabled={selected.size === 0 || running}
+              onClick={() => onMerge()}
+            >
+              <FormattedMessage id="actions.merge" />
+            </Button>
+            <Table responsive striped size="sm">
+              <thead>
+                <tr>
+                  <th />
+                  <th>
+                    <FormattedMessage id="tag_cleanup.destination" />
+                  </th>
+                  <th>
+                    <FormattedMessage id="name" />
+                  </th>
+                  <th>
+                    <FormattedMessage id="aliases" />
+                  </th>
+                  <th />
+                </tr>
+              </thead>
+              {groups.map(renderGroup)}
+            </Table>
+          </>
+        )}
+      </>
+    );
+  }
+
+  return (
+    <>
+      <p>
+        <FormattedMessage id="tag_cleanup.duplicates_desc" />
+      </p>
+      <Form>
+        <Form.Group>
+          <Row noGutters>
+            <Form.Label>
+              <FormattedMessage id="tag_cleanup.distance" />
+            </Form.Label>
+            <Col xs="auto">
+              <Form.Control
+                type="number"
+                min={0}
+                max={5}
+                defaultValue={distance}
+                onBlur={(e: React.FocusEvent<HTMLInputElement>) =>
+                  setDistance(
+                    Number.parseInt(e.currentTarget.value, 10) ||
+                      defaultDistance
+                  )
+                }
+                className="input-control ml-4"
+              />
+            </Col>
+          </Row>
+        </Form.Group>
+      </Form>
+      {renderResults()}
+    </>
+  );
+};
+
+export const TagCleanup: React.FC = () => {
+  const intl = useIntl();
+
+  return (
+    <Card id="tag-cleanup" className="col col-xl-12 mx-auto">
+      <h4>
+        <FormattedMessage id="tag_cleanup.title" />
+      </h4>
+      <Tabs id="tag-cleanup-tabs" mountOnEnter unmountOnExit>
+        <Tab
+          eventKey="duplicates"
+          title={intl.formatMessage({ id: "tag_cleanup.duplicates" })}
+        >
+          <DuplicateTags />
+        </Tab>
+        <Tab
+          eventKey="unused"
+          title={intl.formatMessage({ id: "tag_cleanup.unused" })}
+        >
+          <UnusedTags />
+        </Tab>
+        <Tab
+          eventKey="hierarchy_only"
+          title={intl.formatMessage({ id: "tag_cleanup.hierarchy_only" })}
+        >
+          <HierarchyOnlyTags />
+        </Tab>
+      </Tabs>
+    </Card>
+  );
+};
+
+export default TagCleanup;
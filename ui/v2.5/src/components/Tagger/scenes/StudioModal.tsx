@@ -103,7 +103,7 @@ const StudioDetails: React.FC<IStudioDetailsProps> = ({
       <div className="row">
         <div className="col-12">
           {maybeRenderField("name", studio.name, !isNew)}
-          {maybeRenderField("url", studio.url)}
+          {maybeRenderField("urls", studio.urls?.join(", "))}
           {maybeRenderField("parent_studio", studio.parent?.name, false)}
           {maybeRenderStashBoxLink()}
         </div>
@@ -191,7 +191,7 @@ const StudioModal: React.FC<IStudioModalProps> = ({
 
     const studioData: GQL.StudioCreateInput = {
       name: studio.name,
-      url: studio.url,
+      urls: studio.urls,
       image: studio.image,
       parent_id: studio.parent?.stored_id,
     };
@@ -221,7 +221,7 @@ const StudioModal: React.FC<IStudioModalProps> = ({
 
       parentData = {
         name: studio.parent?.name,
-        url: studio.parent?.url,
+        urls: studio.parent?.urls,
         image: studio.parent?.image,
       };
 
@@ -51,6 +51,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
     parent_ids: yup.array(yup.string().required()).defined(),
     child_ids: yup.array(yup.string().required()).defined(),
     ignore_auto_tag: yup.boolean().defined(),
+    restricted: yup.boolean().defined(),
     image: yup.string().nullable().optional(),
   });
 
@@ -61,6 +62,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
     parent_ids: (tag?.parents ?? []).map((t) => t.id),
     child_ids: (tag?.children ?? []).map((t) => t.id),
     ignore_auto_tag: tag?.ignore_auto_tag ?? false,
+    restricted: tag?.restricted ?? false,
   };
 
   type InputValues = yup.InferType<typeof schema>;
@@ -209,6 +211,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
         {renderSubTagsField()}
         <hr />
         {renderInputField("ignore_auto_tag", "checkbox")}
+        {renderInputField("restricted", "checkbox")}
       </Form>
 
       <DetailsEditNavbar
@@ -1803,6 +1803,99 @@ export const usePerformerDestroy = () =>
     },
   });
 
+export const usePerformerMerge = () =>
+  GQL.usePerformerMergeMutation({
+    update(cache, result, { variables }) {
+      if (!result.data?.performerMerge || !variables) return;
+
+      const { source, destination } = variables;
+
+      for (const id of source) {
+        const obj = { __typename: "Performer", id };
+        deleteObject(cache, obj, GQL.FindPerformerDocument);
+      }
+
+      updateStats(cache, "performer_count", -source.length);
+
+      const obj = { __typename: "Performer", id: destination };
+      evictTypeFields(
+        cache,
+        {
+          ...performerMutationImpactedTypeFields,
+          Performer: ["performer_count"],
+          Studio: ["performer_count"],
+        },
+        cache.identify(obj) // don't evict destination performer
+      );
+
+      evictQueries(cache, [
+        ...performerMutationImpactedQueries,
+        GQL.FindDuplicatePerformersDocument,
+        GQL.FindGroupsDocument, // filter by performers
+        GQL.FindSceneMarkersDocument, // filter by performers
+      ]);
+    },
+  });
+
+// refetches the image set and primary image of the performer
+function evictPerformerImages(
+  cache: ApolloCache<unknown>,
+  performerID: string
+) {
+  const id = cache.identify({ __typename: "Performer", id: performerID });
+  cache.evict({ id, fieldName: "images" });
+  cache.evict({ id, fieldName: "image_path" });
+}
+
+export const mutatePerformerImageAdd = (input: GQL.PerformerImageAddInput) =>
+  client.mutate<GQL.PerformerImageAddMutation>({
+    mutation: GQL.PerformerImageAddDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.performerImageAdd) return;
+
+      evictPerformerImages(cache, input.performer_id);
+    },
+  });
+
+export const mutatePerformerImageDestroy = (performerID: string, id: string) =>
+  client.mutate<GQL.PerformerImageDestroyMutation>({
+    mutation: GQL.PerformerImageDestroyDocument,
+    variables: { id },
+    update(cache, result) {
+      if (!result.data?.performerImageDestroy) return;
+
+      evictPerformerImages(cache, performerID);
+    },
+  });
+
+export const mutatePerformerImageSetPrimary = (
+  performerID: string,
+  id: string
+) =>
+  client.mutate<GQL.PerformerImageSetPrimaryMutation>({
+    mutation: GQL.PerformerImageSetPrimaryDocument,
+    variables: { id },
+    update(cache, result) {
+      if (!result.data?.performerImageSetPrimary) return;
+
+      evictPerformerImages(cache, performerID);
+    },
+  });
+
+export const mutatePerformerImagesReorder = (
+  input: GQL.PerformerImagesReorderInput
+) =>
+  client.mutate<GQL.PerformerImagesReorderMutation>({
+    mutation: GQL.PerformerImagesReorderDocument,
+    variables: { input },
+    update(cache, result) {
+      if (!result.data?.performerImagesReorder) return;
+
+      evictPerformerImages(cache, input.performer_id);
+    },
+  });
+
 export const usePerformersDestroy = (
   input: GQL.PerformersDestroyMutationVariables
 ) =>
@@ -1946,6 +2039,9 @@ const tagMutationImpactedQueries = [
   GQL.FindGalleriesDocument, // filter by tags
   GQL.FindPerformersDocument, // filter by tags
   GQL.FindTagsDocument, // various filters
+  GQL.FindUnusedTagsDocument,
+  GQL.FindHierarchyOnlyTagsDocument,
+  GQL.FindDuplicateTagsDocument,
 ];
 
 export const useTagCreate = () =>
@@ -2061,6 +2157,31 @@ export const useTagsMerge = () =>
     },
   });
 
+export const useTagsMergeMany = () =>
+  GQL.useTagsMergeManyMutation({
+    update(cache, result, { variables }) {
+      if (!result.data?.tagsMergeMany || !variables) return;
+
+      const input = Array.isArray(variables.input)
+        ? variables.input
+        : [variables.input];
+
+      let count = 0;
+      for (const { source } of input) {
+        for (const id of source) {
+          const obj = { __typename: "Tag", id };
+          deleteObject(cache, obj, GQL.FindTagDocument);
+        }
+        count += source.length;
+      }
+
+      updateStats(cache, "tag_count", -count);
+
+      evictTypeFields(cache, tagMutationImpactedTypeFields);
+      evictQueries(cache, tagMutationImpactedQueries);
+    },
+  });
+
 export const useSaveFilter = () =>
   GQL.useSaveFilterMutation({
     update(cache, result) {
@@ -2340,6 +2461,12 @@ export const useStats = () => GQL.useStatsQuery();
 
 export const useVersion = () => GQL.useVersionQuery();
 
+export const useMemoryStats = () =>
+  GQL.useMemoryStatsQuery({
+    fetchPolicy: "no-cache",
+    notifyOnNetworkStatusChange: true,
+  });
+
 export const useLatestVersion = () =>
   GQL.useLatestVersionQuery({
     notifyOnNetworkStatusChange: true,
@@ -2376,7 +2503,9 @@ export const refetchSystemStatus = () => {
 
 export const useJobsSubscribe = () => GQL.useJobsSubscribeSubscription();
 
-export const useLoggingSubscribe = () => GQL.useLoggingSubscribeSubscription();
+export const useLoggingSubscribe = (
+  variables?: GQL.LoggingSubscribeSubscriptionVariables
+) => GQL.useLoggingSubscribeSubscription({ variables });
 
 // all scraper-related queries
 export const scraperMutationImpactedQueries = [
@@ -2710,6 +2839,12 @@ export const mutateCleanGenerated = (input: GQL.CleanGeneratedInput) =>
     variables: { input },
   });
 
+export const mutateConsolidateFiles = (input: GQL.ConsolidateFilesInput) =>
+  client.mutate<GQL.ConsolidateFilesMutation>({
+    mutation: GQL.ConsolidateFilesDocument,
+    variables: { input },
+  });
+
 export const mutateRunPluginTask = (
   pluginId: string,
   taskName: string,
@@ -2759,6 +2894,14 @@ export const mutateOptimiseDatabase = () =>
     mutation: GQL.OptimiseDatabaseDocument,
   });
 
+export const mutateCalculateFingerprints = (
+  input: GQL.CalculateFingerprintsInput
+) =>
+  client.mutate<GQL.MetadataCalculateFingerprintsMutation>({
+    mutation: GQL.MetadataCalculateFingerprintsDocument,
+    variables: { input },
+  });
+
 export const mutateMigrateHashNaming = () =>
   client.mutate<GQL.MigrateHashNamingMutation>({
     mutation: GQL.MigrateHashNamingDocument,
@@ -11,12 +11,16 @@ interface IObjectWithFiles {
 
 export interface IObjectWithTitleFiles extends IObjectWithFiles {
   title?: GQL.Maybe<string>;
+  generated_title?: GQL.Maybe<string>;
 }
 
 export function objectTitle(s: Partial<IObjectWithTitleFiles>) {
   if (s.title) {
     return s.title;
   }
+  if (s.generated_title) {
+    return s.generated_title;
+  }
   if (s.files && s.files.length > 0) {
     return TextUtils.fileNameFromPath(s.files[0].path);
   }
@@ -0,0 +1,13 @@
+import { BooleanCriterion, BooleanCriterionOption } from "./criterion";
+
+export const PendingContentCriterionOption = new BooleanCriterionOption(
+  "pending_content",
+  "pending_content",
+  () => new PendingContentCriterion()
+);
+
+export class PendingContentCriterion extends BooleanCriterion {
+  constructor() {
+    super(PendingContentCriterionOption);
+  }
+}
@@ -0,0 +1,88 @@
+import {
+  CriterionModifier,
+  HdrFormat,
+  StereoLayout,
+  VrProjection,
+} from "src/core/generated-graphql";
+import { CriterionType } from "../types";
+import { CriterionOption, MultiStringCriterion } from "./criterion";
+
+export const hdrFormatStrings = new Map<string, HdrFormat>([
+  ["HDR10", HdrFormat.Hdr10],
+  ["HLG", HdrFormat.Hlg],
+  ["Dolby Vision", HdrFormat.DolbyVision],
+]);
+
+export const vrProjectionStrings = new Map<string, VrProjection>([
+  ["360° Equirectangular", VrProjection.Equirectangular],
+  ["180° Equirectangular", VrProjection.Equirectangular_180],
+  ["Fisheye", VrProjection.Fisheye],
+  ["Cubemap", VrProjection.Cubemap],
+]);
+
+export const stereoLayoutStrings = new Map<string, StereoLayout>([
+  ["Side by Side", StereoLayout.SideBySide],
+  ["Top/Bottom", StereoLayout.TopBottom],
+]);
+
+export function videoPropertyToString<T>(
+  strings: Map<string, T>,
+  value?: T | null
+) {
+  if (!value) {
+    return undefined;
+  }
+
+  return Array.from(strings.entries()).find((e) => e[1] === value)?.[0];
+}
+
+class VideoPropertyCriterion<T> extends MultiStringCriterion {
+  private strings: Map<string, T>;
+
+  constructor(type: CriterionOption, strings: Map<string, T>) {
+    super(type);
+    this.strings = strings;
+  }
+
+  public toCriterionInput() {
+    return {
+      value: this.value
+        .map((v) => this.strings.get(v))
+        .filter((v) => v) as T[],
+      modifier: this.modifier,
+    };
+  }
+}
+
+class VideoPropertyCriterionOption<T> extends CriterionOption {
+  constructor(value: CriterionType, strings: Map<string, T>) {
+    super({
+      messageID: value,
+      type: value,
+      modifierOptions: [
+        CriterionModifier.Includes,
+        CriterionModifier.Excludes,
+        CriterionModifier.IsNull,
+        CriterionModifier.NotNull,
+      ],
+      defaultModifier: CriterionModifier.Includes,
+      options: Array.from(strings.keys()),
+      makeCriterion: () => new VideoPropertyCriterion(this, strings),
+    });
+  }
+}
+
+export const HDRFormatCriterionOption = new VideoPropertyCriterionOption(
+  "hdr_format",
+  hdrFormatStrings
+);
+
+export const VRProjectionCriterionOption = new VideoPropertyCriterionOption(
+  "vr_projection",
+  vrProjectionStrings
+);
+
+export const StereoLayoutCriterionOption = new VideoPropertyCriterionOption(
+  "stereo_layout",
+  stereoLayoutStrings
+);
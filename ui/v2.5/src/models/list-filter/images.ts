@@ -13,6 +13,7 @@ import { PerformersCriterionOption } from "./criteria/performers";
 import { RatingCriterionOption } from "./criteria/rating";
 import { ResolutionCriterionOption } from "./criteria/resolution";
 import { OrientationCriterionOption } from "./criteria/orientation";
+import { PendingContentCriterionOption } from "./criteria/pending-content";
 import { StudiosCriterionOption } from "./criteria/studios";
 import {
   PerformerTagsCriterionOption,
@@ -46,6 +47,7 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
+  PendingContentCriterionOption,
   ImageIsMissingCriterionOption,
   TagsCriterionOption,
   RatingCriterionOption,
@@ -18,6 +18,7 @@ import { PerformersCriterionOption } from "./criteria/performers";
 import { ResolutionCriterionOption } from "./criteria/resolution";
 import { StudiosCriterionOption } from "./criteria/studios";
 import { InteractiveCriterionOption } from "./criteria/interactive";
+import { PendingContentCriterionOption } from "./criteria/pending-content";
 import {
   PerformerTagsCriterionOption,
   // StudioTagsCriterionOption,
@@ -35,6 +36,11 @@ import { StashIDCriterionOption } from "./criteria/stash-ids";
 import { RatingCriterionOption } from "./criteria/rating";
 import { PathCriterionOption } from "./criteria/path";
 import { OrientationCriterionOption } from "./criteria/orientation";
+import {
+  HDRFormatCriterionOption,
+  StereoLayoutCriterionOption,
+  VRProjectionCriterionOption,
+} from "./criteria/video-properties";
 
 const defaultSortBy = "date";
 const sortByOptions = [
@@ -45,6 +51,7 @@ const sortByOptions = [
   "duration",
   "framerate",
   "bitrate",
+  "quality_score",
   "last_played_at",
   "last_o_at",
   "resume_time",
@@ -79,6 +86,7 @@ const criterionOptions = [
   PathCriterionOption,
   createStringCriterionOption("details"),
   createStringCriterionOption("director"),
+  createStringCriterionOption("transcript"),
   createMandatoryStringCriterionOption("oshash", "media_info.hash"),
   createStringCriterionOption("checksum", "media_info.checksum"),
   PhashCriterionOption,
@@ -88,10 +96,16 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
+  createMandatoryNumberCriterionOption("aspect_ratio"),
   createMandatoryNumberCriterionOption("framerate"),
   createMandatoryNumberCriterionOption("bitrate"),
   createStringCriterionOption("video_codec"),
   createStringCriterionOption("audio_codec"),
+  createMandatoryNumberCriterionOption("bit_depth"),
+  createMandatoryNumberCriterionOption("quality_score"),
+  HDRFormatCriterionOption,
+  VRProjectionCriterionOption,
+  StereoLayoutCriterionOption,
   createDurationCriterionOption("duration"),
   createDurationCriterionOption("resume_time"),
   createDurationCriterionOption("play_duration"),
@@ -115,6 +129,7 @@ const criterionOptions = [
   StashIDCriterionOption,
   InteractiveCriterionOption,
   CaptionsCriterionOption,
+  PendingContentCriterionOption,
   createMandatoryNumberCriterionOption("interactive_speed"),
   createMandatoryNumberCriterionOption("file_count"),
   createDateCriterionOption("date"),
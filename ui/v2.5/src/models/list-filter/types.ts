@@ -183,8 +183,10 @@ export type CriterionType =
   | "death_year"
   | "url"
   | "interactive"
+  | "pending_content"
   | "interactive_speed"
   | "captions"
+  | "transcript"
   | "resume_time"
   | "play_count"
   | "play_duration"
@@ -194,6 +196,12 @@ export type CriterionType =
   | "title"
   | "oshash"
   | "orientation"
+  | "aspect_ratio"
+  | "bit_depth"
+  | "quality_score"
+  | "hdr_format"
+  | "vr_projection"
+  | "stereo_layout"
   | "checksum"
   | "phash_distance"
   | "director"
@@ -452,6 +452,18 @@ const formatDate = (intl: IntlShape, date?: string, utc = true) => {
     return "";
   }
 
+  // partial dates are shown without the day or month
+  if (date.match(/^\d{4}$/)) {
+    return date;
+  }
+  if (date.match(/^\d{4}-\d{2}$/)) {
+    return intl.formatDate(date, {
+      year: "numeric",
+      month: "long",
+      timeZone: utc ? "utc" : undefined,
+    });
+  }
+
   return intl.formatDate(date, {
     format: "long",
     timeZone: utc ? "utc" : undefined,
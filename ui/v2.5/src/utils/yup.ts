@@ -147,6 +147,23 @@ export function yupDateString(intl: IntlShape) {
     });
 }
 
+// yupFuzzyDateString also accepts partial dates in YYYY-MM or YYYY form.
+export function yupFuzzyDateString(intl: IntlShape) {
+  return yup
+    .string()
+    .ensure()
+    .test({
+      name: "date",
+      test(value) {
+        if (!value) return true;
+        if (!value.match(/^\d{4}(-\d{2}(-\d{2})?)?$/)) return false;
+        if (Number.isNaN(Date.parse(value))) return false;
+        return true;
+      },
+      message: intl.formatMessage({ id: "validation.fuzzy_date_invalid_form" }),
+    });
+}
+
 type StringEnum<T extends string> = {
   [k: string]: T;
 };